@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/badri/wt/internal/agent"
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/project"
+	"github.com/badri/wt/internal/session"
+)
+
+// projectAgent returns the agent profile configured for a project (Claude if none)
+func projectAgent(proj *project.Project) (*agent.Profile, error) {
+	if proj == nil {
+		return agent.Get("")
+	}
+	return agent.Get(proj.Agent)
+}
+
+// sessionAgent returns the agent profile a session was started with.
+// Sessions from before agent profiles, or with an unknown agent, are Claude.
+func sessionAgent(sess *session.Session) *agent.Profile {
+	if ag, err := agent.Get(sess.Agent); err == nil {
+		return ag
+	}
+	ag, _ := agent.Get(agent.Claude)
+	return ag
+}
+
+// agentCommand returns the command that starts an agent in a project's worktree
+func agentCommand(cfg *config.Config, proj *project.Project, ag *agent.Profile) string {
+	override := ""
+	if proj != nil {
+		override = proj.AgentCmd
+	}
+	return ag.Command(cfg.EditorCmd, override)
+}
+
+// waitForAgent waits for a new session's agent to be ready for its first prompt
+func waitForAgent(sessionName string, ag *agent.Profile) {
+	if !ag.AcceptsPrompts {
+		return
+	}
+
+	logging.Infof("Waiting for %s to start...", ag.Name)
+	if err := ag.WaitReady(sessionName, 60*time.Second); err != nil {
+		logging.Warnf("%v (sending prompt anyway)", err)
+	}
+
+	// Accept startup dialogs such as Claude's bypass permissions warning
+	if err := ag.Prepare(sessionName); err != nil {
+		logging.Warnf("could not accept bypass warning: %v", err)
+	}
+
+	// Additional delay for the agent to fully initialize its UI
+	time.Sleep(2 * time.Second)
+}
+
+// sendInitialPrompt sends the first work prompt to a session's agent
+func sendInitialPrompt(sessionName string, ag *agent.Profile, prompt string) {
+	if !ag.AcceptsPrompts {
+		fmt.Printf("Agent '%s' takes no prompt; the session starts at a shell.\n", ag.Name)
+		return
+	}
+	logging.Infof("Sending initial prompt to worker...")
+	if err := ag.SendPrompt(sessionName, prompt); err != nil {
+		logging.Warnf("could not send initial prompt: %v", err)
+	}
+}
@@ -5,13 +5,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"regexp"
 	"sort"
 	"strings"
 
+	"github.com/badri/wt/internal/bead"
 	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/logging"
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -45,6 +46,7 @@ type BeadFullInfo struct {
 // auditFlags holds parsed flags for the audit command
 type auditFlags struct {
 	interactive bool
+	epic        bool
 	projectDir  string
 }
 
@@ -56,6 +58,8 @@ func parseAuditFlags(args []string) (string, auditFlags) {
 		switch args[i] {
 		case "-i", "--interactive":
 			flags.interactive = true
+		case "--epic":
+			flags.epic = true
 		case "-p", "--project":
 			if i+1 < len(args) {
 				flags.projectDir = args[i+1]
@@ -76,15 +80,24 @@ func cmdAuditHelp() error {
 
 USAGE:
     wt audit <bead-id> [options]
+    wt audit <epic-id> [--epic]
 
 DESCRIPTION:
     Analyzes a bead's description and cross-references it with the codebase
     to determine implementation readiness. Reports what's well-defined,
     what's missing, and suggests clarifying questions.
 
+    For an epic, runs the same audit 'wt auto --epic' does before a run:
+    lists the ready child beads, external blockers and other issues, and
+    files mentioned by more than one bead (possible conflicts). The epic
+    is found in any registered project.
+
 OPTIONS:
     -i, --interactive       Enter interactive mode to resolve issues
     -p, --project <dir>     Project directory (default: current)
+    --epic                  Audit as an epic (detected automatically when
+                            the bead is found in the project directory)
+    --json                  Output as JSON
 
 READINESS LEVELS:
     Ready     - Bead has sufficient context for implementation
@@ -95,6 +108,7 @@ EXAMPLES:
     wt audit wt-abc               Audit bead wt-abc
     wt audit wt-abc -i            Audit and interactively resolve issues
     wt audit wt-abc --project /path/to/project
+    wt audit wt-epic --epic       Check an epic before 'wt auto --epic'
 
 OUTPUT:
     Shows:
@@ -115,8 +129,11 @@ func cmdAudit(cfg *config.Config, args []string) error {
 
 	// Fetch bead information
 	info, err := fetchBeadInfo(beadID, flags.projectDir)
+	if flags.epic || (err == nil && info.IssueType == "epic") {
+		return cmdAuditEpic(cfg, beadID)
+	}
 	if err != nil {
-		return fmt.Errorf("fetching bead: %w", err)
+		return fmt.Errorf("fetching bead: %w (for an epic in another project, use --epic)", err)
 	}
 
 	// Perform the audit
@@ -139,12 +156,7 @@ func cmdAudit(cfg *config.Config, args []string) error {
 }
 
 func fetchBeadInfo(beadID, projectDir string) (*BeadFullInfo, error) {
-	cmd := exec.Command("bd", "show", beadID, "--json")
-	if projectDir != "" {
-		cmd.Dir = projectDir
-	}
-
-	output, err := cmd.Output()
+	output, err := bead.Output(projectDir, "show", beadID, "--json")
 	if err != nil {
 		return nil, fmt.Errorf("bead not found: %s", beadID)
 	}
@@ -163,12 +175,7 @@ func fetchBeadInfo(beadID, projectDir string) (*BeadFullInfo, error) {
 }
 
 func fetchDependencies(beadID, projectDir string) []string {
-	cmd := exec.Command("bd", "dep", "list", beadID, "--json")
-	if projectDir != "" {
-		cmd.Dir = projectDir
-	}
-
-	output, err := cmd.Output()
+	output, err := bead.Output(projectDir, "dep", "list", beadID, "--json")
 	if err != nil {
 		return nil
 	}
@@ -590,12 +597,7 @@ func runInteractiveAudit(info *BeadFullInfo, result *AuditResult, projectDir str
 	}
 
 	// Update the bead description
-	cmd := exec.Command("bd", "update", info.ID, "--description", newDescription)
-	if projectDir != "" {
-		cmd.Dir = projectDir
-	}
-
-	output, err := cmd.CombinedOutput()
+	output, err := bead.CombinedOutput(projectDir, "update", info.ID, "--description", newDescription)
 	if err != nil {
 		return fmt.Errorf("updating bead: %s: %w", string(output), err)
 	}
@@ -603,7 +605,7 @@ func runInteractiveAudit(info *BeadFullInfo, result *AuditResult, projectDir str
 	fmt.Println("✓ Bead description updated.")
 
 	// Re-audit
-	fmt.Println("\nRe-auditing...")
+	logging.Infof("\nRe-auditing...")
 	updatedInfo, err := fetchBeadInfo(info.ID, projectDir)
 	if err != nil {
 		return err
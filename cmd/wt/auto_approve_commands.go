@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/badri/wt/internal/auto"
+	"github.com/badri/wt/internal/config"
+)
+
+// cmdAutoApprove lets an epic run waiting at a --gate checkpoint continue
+func cmdAutoApprove(cfg *config.Config, args []string) error {
+	project, rest, err := parseAutoStateProject(args)
+	if err != nil {
+		return err
+	}
+	if len(rest) > 0 {
+		return fmt.Errorf("unexpected argument: %s\nUsage: wt auto approve [--project <name>]", rest[0])
+	}
+	project, err = resolveAutoStateProject(cfg, project)
+	if err != nil {
+		return err
+	}
+
+	state, err := auto.Approve(cfg, project)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✓ Approved: %s\n", state.PendingGate.Describe(state))
+	fmt.Println("  The run continues within a few seconds.")
+	return nil
+}
+
+// cmdAutoApproveHelp prints help for 'wt auto approve'
+func cmdAutoApproveHelp() error {
+	help := `wt auto approve - Let a gated epic run continue
+
+USAGE:
+    wt auto approve [--project <name>]
+
+DESCRIPTION:
+    An epic run started with --gate stops at its checkpoints, notifies you
+    and the hub, and waits. Review the epic worktree, then approve to let
+    the run go on:
+
+      after-each-bead   The next bead starts
+      before-merge      The epic is closed and its PR opened
+
+    To reject instead, stop the run at the gate with 'wt auto --stop'.
+    Its state keeps the pending gate; 'wt auto --resume' approves it and
+    continues, after any fixes you made in the worktree.
+
+OPTIONS:
+    -p, --project <name>   Project of the run (needed when several epics
+                           have state)
+
+EXAMPLES:
+    wt auto --epic wt-xyz --gate after-each-bead
+    wt auto approve
+    wt auto approve --project myapp
+`
+	fmt.Print(help)
+	return nil
+}
@@ -0,0 +1,377 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/badri/wt/internal/auto"
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/usage"
+)
+
+// cmdAuto runs autonomous batch processing of beads
+func cmdAuto(cfg *config.Config, args []string) error {
+	opts, err := parseAutoFlags(args)
+	if err != nil {
+		return err
+	}
+	runner := auto.NewRunner(cfg, opts)
+	return runner.Run()
+}
+
+func parseAutoFlags(args []string) (*auto.Options, error) {
+	opts := &auto.Options{}
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-p", "--project":
+			if i+1 < len(args) {
+				opts.Project = args[i+1]
+				i++
+			}
+		case "-m", "--merge-mode":
+			if i+1 < len(args) {
+				opts.MergeMode = args[i+1]
+				i++
+			}
+		case "--timeout":
+			if i+1 < len(args) {
+				fmt.Sscanf(args[i+1], "%d", &opts.Timeout)
+				i++
+			}
+		case "-n", "--limit":
+			if i+1 < len(args) {
+				fmt.Sscanf(args[i+1], "%d", &opts.Limit)
+				i++
+			}
+		case "--cooldown":
+			if i+1 < len(args) {
+				d, err := time.ParseDuration(args[i+1])
+				if err != nil {
+					return nil, fmt.Errorf("invalid --cooldown %q: %w", args[i+1], err)
+				}
+				opts.Cooldown = d
+				i++
+			}
+		case "--max-cost":
+			if i+1 < len(args) {
+				c, err := usage.ParseCost(args[i+1])
+				if err != nil {
+					return nil, fmt.Errorf("invalid --max-cost: %w", err)
+				}
+				opts.MaxCost = c
+				i++
+			}
+		case "--max-drift":
+			if i+1 < len(args) {
+				if _, err := fmt.Sscanf(args[i+1], "%d", &opts.MaxDrift); err != nil {
+					return nil, fmt.Errorf("invalid --max-drift %q: want a number of commits", args[i+1])
+				}
+				i++
+			}
+		case "--max-total-points":
+			if i+1 < len(args) {
+				if _, err := fmt.Sscanf(args[i+1], "%d", &opts.MaxTotalPoints); err != nil || opts.MaxTotalPoints <= 0 {
+					return nil, fmt.Errorf("invalid --max-total-points %q: want a positive number", args[i+1])
+				}
+				i++
+			}
+		case "--smallest-first":
+			opts.SmallestFirst = true
+		case "--workers":
+			if i+1 < len(args) {
+				if _, err := fmt.Sscanf(args[i+1], "%d", &opts.Workers); err != nil || opts.Workers <= 0 {
+					return nil, fmt.Errorf("invalid --workers %q: want a positive number", args[i+1])
+				}
+				i++
+			}
+		case "--queue":
+			opts.Queue = true
+		case "--drift-strategy":
+			if i+1 < len(args) {
+				if args[i+1] != "rebase" && args[i+1] != "merge" {
+					return nil, fmt.Errorf("invalid --drift-strategy %q: use rebase or merge", args[i+1])
+				}
+				opts.DriftStrategy = args[i+1]
+				i++
+			}
+		case "--gate":
+			if i+1 < len(args) {
+				if err := auto.ValidateGate(args[i+1]); err != nil {
+					return nil, err
+				}
+				opts.Gate = args[i+1]
+				i++
+			}
+		case "--epic", "-e":
+			if i+1 < len(args) {
+				// Repeated --epic queues the later epics behind the first
+				if opts.Epic == "" {
+					opts.Epic = args[i+1]
+				}
+				opts.Epics = append(opts.Epics, args[i+1])
+				i++
+			}
+		case "--dry-run":
+			opts.DryRun = true
+		case "--check":
+			opts.Check = true
+		case "--stop":
+			opts.Stop = true
+		case "--force":
+			opts.Force = true
+		case "--pause-on-failure":
+			opts.PauseOnFailure = true
+		case "--skip-audit":
+			opts.SkipAudit = true
+		case "--resume":
+			opts.Resume = true
+		case "--abort":
+			opts.Abort = true
+		case "--rollback":
+			opts.Rollback = true
+		case "--isolated":
+			opts.Isolated = true
+		case "--no-pr":
+			opts.NoPR = true
+		case "--resume-context":
+			opts.ResumeContext = true
+		}
+	}
+	if opts.Rollback && !opts.Abort {
+		return nil, fmt.Errorf("--rollback only works with --abort")
+	}
+	if (opts.MaxTotalPoints > 0 || opts.SmallestFirst) && opts.Epic != "" {
+		return nil, fmt.Errorf("--max-total-points and --smallest-first are only supported with --project mode")
+	}
+	if opts.Workers > 1 && (opts.Epic != "" || opts.Queue) {
+		return nil, fmt.Errorf("--workers is only supported with --project mode")
+	}
+	if opts.Queue && opts.Epic != "" {
+		return nil, fmt.Errorf("--queue cannot be combined with --epic: queue items are worked in their own sessions")
+	}
+	if opts.Gate != "" && opts.Epic == "" && !opts.Resume {
+		return nil, fmt.Errorf("--gate only works with --epic runs")
+	}
+	if opts.ResumeContext && opts.Isolated {
+		return nil, fmt.Errorf("--resume-context cannot be combined with --isolated: Claude only resumes sessions from the same worktree")
+	}
+	return opts, nil
+}
+
+// cmdAutoHelp prints help for the auto command
+func cmdAutoHelp() error {
+	help := `wt auto - Autonomous batch processing of beads
+
+USAGE:
+    wt auto --epic <id> [--epic <id>...] [options]
+    wt auto --project <name> [options]
+    wt auto --queue [--project <name>] [options]
+
+DESCRIPTION:
+    Two modes of operation:
+
+    Epic mode (--epic):
+      Processes all ready beads in an epic sequentially in a single worktree.
+      Creates one PR/merge at the end instead of one per bead: the epic
+      branch is pushed and a single PR lists each bead with its commit.
+      In pr-auto mode the PR gets auto-merge enabled.
+
+      Give --epic more than once, or add epics with 'wt auto queue add',
+      to run several epics of one project back to back. Each epic keeps
+      its own state; the queue moves on only when an epic completes.
+
+    Project mode (--project):
+      Processes all ready beads for a project serially, each in its own
+      worktree. Creates separate PRs per bead. Beads run in dependency
+      order, those unblocking the most other beads first; after each bead
+      readiness is checked again, so beads it unblocked run in the same
+      invocation (--limit counts them too).
+
+    Queue mode (--queue):
+      Waits for work pushed to an external queue and works each item in
+      its own session, like project mode. See EXTERNAL QUEUE.
+
+OPTIONS:
+    -e, --epic <id>         Epic ID to process (single worktree mode); repeat
+                            to queue more epics behind it
+    -p, --project <name>    Project to process (separate worktrees mode)
+    -n, --limit <N>         Max beads to process
+    --max-total-points <N>  Project mode: start beads only while their estimates
+                            add up to at most N (see ESTIMATES)
+    --smallest-first        Project mode: among beads free to start, run the
+                            smallest estimate first
+    --workers <N>           Project mode: run up to N beads at once, each in
+                            its own session (see WORKER POOL)
+    --queue                 Consume the external work queue until stopped;
+                            --project is the default project of items
+    -m, --merge-mode <mode> Merge mode: direct, pr-auto, pr-review
+    --timeout <minutes>     Per-bead timeout in minutes (default: 30)
+    --dry-run               Preview what would be processed (includes audit)
+    --pause-on-failure      Stop and preserve worktree if a bead fails
+    --isolated              Epic mode: run each bead in a fresh worktree off the
+                            epic branch; failed beads are discarded cleanly
+    --no-pr                 Epic mode: don't open a PR when the epic completes
+    --resume-context        Epic mode: start each bead by resuming the previous
+                            bead's Claude session (claude --resume) instead of
+                            a fresh Claude, keeping its context
+    --cooldown <duration>   Pause between beads, e.g. 5m (overrides project config)
+    --max-cost <usd>        Pause once the run's estimated Claude cost reaches
+                            this amount, e.g. 20 (overrides project auto.budget)
+    --max-drift <N>         Epic mode: sync the epic branch with main between
+                            beads once it is more than N commits behind
+    --drift-strategy <s>    How to sync: rebase (default) or merge
+    --gate <gate>           Epic mode: wait for 'wt auto approve' after-each-bead
+                            or before-merge (see REVIEW GATES)
+    --skip-audit            Bypass implicit audit (use with caution)
+    --check                 Check status of running/paused auto session
+    --resume                Resume a paused or failed epic run
+    --abort                 Abort and clean up a paused/failed run
+    --rollback              With --abort: also delete the epic branch and
+                            restore bead statuses (reopening closed beads)
+                            to what they were before the run
+    --stop                  Stop the auto runner gracefully
+    --force                 Force start even if another auto is running
+
+STATE COMMANDS:
+    wt auto state [show]              Show progress and the bead queue
+    wt auto state edit                Edit the epic state in $EDITOR
+    wt auto state skip-bead <id>      Leave a hopeless bead out of the run
+    wt auto state requeue-bead <id>   Move a bead to the end (--next: run next)
+    See 'wt auto state --help'.
+
+QUEUE COMMANDS:
+    wt auto queue [list]              Show finished and queued epics
+    wt auto queue add <epic>...       Queue epics behind the current one
+    wt auto queue remove <epic>       Take an epic off the queue
+    wt auto queue clear               Empty the queue
+    See 'wt auto queue --help'.
+
+APPROVE COMMAND:
+    wt auto approve                   Let a run waiting at a gate continue
+    See 'wt auto approve --help'.
+
+INBOX COMMANDS:
+    wt auto inbox [list]              Show items received from the queue
+    wt auto inbox push '<json>'       Push an item to the queue
+    See 'wt auto inbox --help'.
+
+EPIC WORKFLOW:
+    1. Group work into an epic:
+       bd create "Documentation batch" -t epic
+       bd dep add wt-tcf wt-doc-epic
+       bd dep add wt-1a3 wt-doc-epic
+
+    2. Run batch processing:
+       wt auto --epic wt-doc-epic
+
+    3. If a bead fails with --pause-on-failure:
+       - Fix manually in the preserved worktree
+       - wt auto --resume    (continue from where it stopped)
+       - wt auto --abort     (clean up and abandon)
+       - wt auto --abort --rollback
+                             (also undo the run's branch and bead changes)
+
+PROJECT WORKFLOW:
+    Process all ready beads for a project:
+       wt auto --project myapp
+       wt auto --project myapp --limit 3
+
+PACING:
+    Project config can limit how fast auto runs start beads:
+       "auto": {
+         "cooldown": "5m",             Pause between beads
+         "daily_budget": 20,           Max beads started per day
+         "quiet_hours": "22:00-07:00"  No new beads in this window
+       }
+    Auto waits out cooldowns and quiet hours. When the daily budget is
+    spent, epic runs pause (resume with --resume) and project runs stop.
+
+COST BUDGET:
+    Auto estimates what each bead cost from the token usage in Claude's
+    transcripts, priced at API list prices. With a budget, the run stops
+    starting beads once the estimate reaches it and sends a desktop
+    notification; the bead in progress is finished first:
+       "auto": {
+         "budget": 25                  USD per run (--max-cost overrides)
+       }
+    Epic runs pause with their state saved; 'wt auto --check' shows the
+    spend. Continue with 'wt auto --resume --max-cost <higher amount>'.
+
+EXTERNAL QUEUE:
+    CI failures, alert triage and other systems can push work to a Redis
+    list or a NATS subject that a long-running 'wt auto --queue' consumes:
+       "queue": {
+         "url": "redis://localhost:6379",  or nats://localhost:4222
+         "name": "wt.queue",               List key or subject
+         "project": "myapp"                Project of items naming none
+       }
+    Each item (JSON) names an existing bead or gives a title to create one,
+    labeled "queue", whose description links back to the source. Items
+    with an id are worked once. Pacing and budgets apply; an item that
+    can't start is put back and the run stops. Reading an item takes it
+    off the queue: a NATS item published while no worker is connected is
+    lost, and so is a Redis item if wt dies before linking it to a bead.
+
+ESTIMATES:
+    A bead's points are its bd estimate in minutes:
+       bd update wt-abc --estimate 30
+    With --max-total-points, project mode starts the next bead in the
+    queue whose estimate still fits the budget, passing over beads that
+    are too big or have no estimate, and stops once nothing fits.
+    --smallest-first runs quick wins first; dependencies still come
+    first. The run report compares each completed bead's estimate with
+    how long it took, to calibrate future estimates.
+
+WORKER POOL:
+    With --workers N, project mode keeps up to N beads running, each in
+    its own session with its own port offset, instead of one at a time.
+    A bead starts only when it is ready in bd, so beads wait for their
+    blockers to finish; readiness is checked again as each bead ends.
+    --limit, pacing and budgets apply to starting beads; once they stop
+    new starts, the running beads are still waited for. 'wt auto --stop'
+    leaves the running sessions to finish on their own.
+
+MAIN DRIFT:
+    Long epic runs can fall far behind main. With a drift limit, auto
+    checks the epic branch between beads and syncs it once it is more
+    than N commits behind:
+       "auto": {
+         "max_drift": 20,              Commits behind before syncing
+         "drift_strategy": "rebase",   rebase (default) or merge
+         "test_command": "go test ./..."  Run after each sync
+       }
+    A conflicting sync is aborted and the run pauses; so do failing
+    tests. Update the epic worktree by hand, then 'wt auto --resume'.
+
+REVIEW GATES:
+    High-risk epics can run with human checkpoints:
+       wt auto --epic wt-xyz --gate after-each-bead
+       wt auto --epic wt-xyz --gate before-merge
+    At a gate the run saves its state as awaiting-approval, sends a
+    desktop notification and a message to the hub, and waits. Review the
+    epic worktree, then 'wt auto approve' to continue. 'wt auto --stop'
+    at a gate pauses the run with the gate pending; 'wt auto --resume'
+    later approves it and continues.
+
+EXAMPLES:
+    wt auto --epic wt-doc-batch           Process beads in epic
+    wt auto --project myapp               Process ready beads for project
+    wt auto --project myapp --limit 5     Process up to 5 beads
+    wt auto --project myapp --workers 3   Run 3 beads at a time
+    wt auto --project myapp --max-total-points 240 --smallest-first
+                                          Small beads first, ~4h of estimates
+    wt auto --epic wt-a --epic wt-b       Process wt-a, then wt-b
+    wt auto --epic wt-xyz --dry-run       Preview without executing
+    wt auto --epic wt-xyz --isolated      Fresh worktree per bead
+    wt auto --epic wt-xyz --resume-context  Carry Claude's context across beads
+    wt auto --resume --resume-context     Resume a paused run, keeping context
+    wt auto --epic wt-xyz --cooldown 5m   Pause 5 minutes between beads
+    wt auto --epic wt-xyz --max-drift 20  Rebase onto main when 20+ commits behind
+    wt auto --epic wt-xyz --max-cost 20   Pause once ~$20 of Claude usage is spent
+    wt auto --epic wt-xyz --gate before-merge  Wait for approval before the PR
+    wt auto --queue --project myapp       Work items pushed to the queue
+    wt auto --check                       Check status of current run
+`
+	fmt.Print(help)
+	return nil
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/workqueue"
+)
+
+// cmdAutoInbox shows the items 'wt auto --queue' received from the
+// external work queue, and pushes items to it
+func cmdAutoInbox(cfg *config.Config, args []string) error {
+	project, rest, err := parseAutoStateProject(args)
+	if err != nil {
+		return err
+	}
+	if len(rest) == 0 {
+		rest = []string{"list"}
+	}
+
+	switch rest[0] {
+	case "list":
+		return autoInboxList(cfg, project)
+	case "push":
+		if len(rest) != 2 {
+			return fmt.Errorf("usage: wt auto inbox push '<json item>' (or - to read it from stdin)")
+		}
+		return autoInboxPush(cfg, project, rest[1])
+	default:
+		subcommands := []string{"list", "push"}
+		return fmt.Errorf("unknown auto inbox command: %s%s\nUsage: wt auto inbox [list|push]", rest[0], didYouMean(rest[0], subcommands))
+	}
+}
+
+func autoInboxList(cfg *config.Config, project string) error {
+	store, err := workqueue.Load(cfg)
+	if err != nil {
+		return fmt.Errorf("loading queue links: %w", err)
+	}
+	var links []*workqueue.Link
+	for _, l := range store.List() {
+		if project == "" || l.Project == project {
+			links = append(links, l)
+		}
+	}
+
+	if outputJSON {
+		printJSON(links)
+		return nil
+	}
+	if len(links) == 0 {
+		fmt.Println("No queue items received yet.")
+		return nil
+	}
+	fmt.Printf("%-20s %-24s %-12s %-14s %-10s %s\n", "RECEIVED", "ITEM", "PROJECT", "BEAD", "SESSION", "OUTCOME")
+	for _, l := range links {
+		outcome := l.Outcome
+		if outcome == "" {
+			outcome = "running"
+		}
+		received := l.ReceivedAt
+		if len(received) > 19 {
+			received = strings.Replace(received[:19], "T", " ", 1)
+		}
+		fmt.Printf("%-20s %-24s %-12s %-14s %-10s %s\n", received, truncate(l.Ref(), 24), truncate(l.Project, 12), l.Bead, l.Session, outcome)
+		if l.URL != "" {
+			fmt.Printf("%-20s %s\n", "", l.URL)
+		}
+	}
+	return nil
+}
+
+// autoInboxPush validates an item and pushes it to the configured queue,
+// for testing the queue or feeding it from scripts
+func autoInboxPush(cfg *config.Config, project, arg string) error {
+	data := []byte(arg)
+	if arg == "-" {
+		var err error
+		if data, err = io.ReadAll(os.Stdin); err != nil {
+			return fmt.Errorf("reading item: %w", err)
+		}
+	}
+	item, err := workqueue.Parse(data)
+	if err != nil {
+		return err
+	}
+	if project != "" && item.Project == "" {
+		item.Project = project
+		data, _ = json.Marshal(item)
+	}
+
+	q, err := workqueue.Open(cfg.QueueSettings())
+	if err != nil {
+		return err
+	}
+	defer q.Close()
+	if err := q.Push(data); err != nil {
+		return fmt.Errorf("pushing item: %w", err)
+	}
+	fmt.Printf("Pushed %s to %s\n", item.Ref(), cfg.QueueSettings().QueueName())
+	return nil
+}
+
+// cmdAutoInboxHelp shows help for the auto inbox command
+func cmdAutoInboxHelp() error {
+	help := `wt auto inbox - Items received from the external work queue
+
+USAGE:
+    wt auto inbox [list] [-p <project>]
+    wt auto inbox push '<json item>' [-p <project>]
+    wt auto inbox push - [-p <project>]
+
+DESCRIPTION:
+    'wt auto --queue' works items other systems push to a Redis list or
+    NATS subject (queue.url in the wt config). Each item names a bead or
+    describes one to create; wt links the item to the bead, the session
+    that worked it and how that ended. list shows those links, newest
+    first. push adds an item to the queue, e.g. from a CI job or a test.
+
+    An item is a JSON object:
+       {"id": "build-1234", "source": "ci", "project": "myapp",
+        "title": "Fix failing test TestLogin",
+        "description": "...", "url": "https://ci.example.com/1234",
+        "priority": 1, "type": "bug", "labels": ["ci"]}
+    or, for an existing bead:
+       {"id": "alert-77", "source": "alerts", "bead": "myapp-abc"}
+
+    Items with an id are processed once: the same source and id arriving
+    again are skipped.
+
+COMMANDS:
+    list                Show received items (default)
+    push <json>|-       Push an item; - reads it from stdin
+
+OPTIONS:
+    -p, --project <name>  list: only this project; push: project of items
+                          that don't name one
+    --json                list: output as JSON
+    -h, --help            Show this help
+
+EXAMPLES:
+    wt auto inbox
+    wt auto inbox push '{"title": "Investigate 500s on /login", "source": "alerts"}' -p myapp
+    redis-cli RPUSH wt.queue '{"bead": "myapp-abc"}'
+    nats pub wt.queue '{"bead": "myapp-abc"}'
+`
+	fmt.Print(help)
+	return nil
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/badri/wt/internal/auto"
+	"github.com/badri/wt/internal/config"
+)
+
+// cmdAutoQueue manages the epics an epic run processes back to back
+func cmdAutoQueue(cfg *config.Config, args []string) error {
+	project, rest, err := parseAutoStateProject(args)
+	if err != nil {
+		return err
+	}
+	if len(rest) == 0 {
+		rest = []string{"list"}
+	}
+
+	switch rest[0] {
+	case "list":
+		return autoQueueList(cfg, project)
+	case "add":
+		if len(rest) < 2 {
+			return fmt.Errorf("usage: wt auto queue add <epic>... [-p <project>]")
+		}
+		return autoQueueAdd(cfg, project, rest[1:])
+	case "remove":
+		if len(rest) < 2 {
+			return fmt.Errorf("usage: wt auto queue remove <epic> [-p <project>]")
+		}
+		project, err = resolveAutoQueueProject(cfg, project)
+		if err != nil {
+			return err
+		}
+		q, err := auto.LoadEpicQueue(cfg, project)
+		if err != nil {
+			return err
+		}
+		if !q.Remove(rest[1]) {
+			return fmt.Errorf("epic %s is not queued", rest[1])
+		}
+		if err := auto.SaveEpicQueue(cfg, project, q); err != nil {
+			return fmt.Errorf("saving epic queue: %w", err)
+		}
+		fmt.Printf("Removed %s from the queue\n", rest[1])
+		return nil
+	case "clear":
+		project, err = resolveAutoQueueProject(cfg, project)
+		if err != nil {
+			return err
+		}
+		if err := auto.SaveEpicQueue(cfg, project, &auto.EpicQueue{}); err != nil {
+			return fmt.Errorf("clearing epic queue: %w", err)
+		}
+		fmt.Println("Epic queue cleared")
+		return nil
+	default:
+		subcommands := []string{"list", "add", "remove", "clear"}
+		return fmt.Errorf("unknown auto queue command: %s%s\nUsage: wt auto queue [list|add|remove|clear]", rest[0], didYouMean(rest[0], subcommands))
+	}
+}
+
+// resolveAutoQueueProject picks the project whose queue to change. Without
+// --project there must be exactly one queue.
+func resolveAutoQueueProject(cfg *config.Config, project string) (string, error) {
+	if project != "" {
+		return project, nil
+	}
+	projects, err := auto.EpicQueueProjects(cfg)
+	if err != nil {
+		return "", err
+	}
+	switch len(projects) {
+	case 0:
+		return "", fmt.Errorf("no epics are queued")
+	case 1:
+		return projects[0], nil
+	}
+	return "", fmt.Errorf("several projects have queues (%s); choose one with --project", strings.Join(projects, ", "))
+}
+
+func autoQueueList(cfg *config.Config, project string) error {
+	projects := []string{project}
+	if project == "" {
+		var err error
+		if projects, err = auto.EpicQueueProjects(cfg); err != nil {
+			return err
+		}
+	}
+
+	type projectQueue struct {
+		Project string `json:"project,omitempty"`
+		*auto.EpicQueue
+	}
+	var queues []projectQueue
+	for _, p := range projects {
+		q, err := auto.LoadEpicQueue(cfg, p)
+		if err != nil {
+			return fmt.Errorf("loading epic queue: %w", err)
+		}
+		if len(q.Epics) > 0 || len(q.Done) > 0 {
+			queues = append(queues, projectQueue{p, q})
+		}
+	}
+
+	if outputJSON {
+		printJSON(queues)
+		return nil
+	}
+	if len(queues) == 0 {
+		fmt.Println("No epics are queued.")
+		return nil
+	}
+	for i, pq := range queues {
+		if i > 0 {
+			fmt.Println()
+		}
+		if pq.Project != "" {
+			fmt.Printf("Epic queue [%s]\n", pq.Project)
+		} else {
+			fmt.Println("Epic queue")
+		}
+		if state, err := auto.LoadProjectEpicState(cfg, pq.Project); err == nil {
+			fmt.Printf("  → %s [%s, %d/%d beads]\n", state.EpicID, state.Status, len(state.CompletedBeads), len(state.Beads))
+		}
+		for _, epicID := range pq.Done {
+			status := "done"
+			if state, err := auto.LoadEpicRecord(cfg, epicID); err == nil {
+				status = state.Status
+			}
+			fmt.Printf("  ✓ %s [%s]\n", epicID, status)
+		}
+		for j, epicID := range pq.Epics {
+			fmt.Printf("  %d. %s\n", j+1, epicID)
+		}
+	}
+	return nil
+}
+
+// autoQueueAdd queues epics of one project; a running 'wt auto' picks them
+// up after its current epic
+func autoQueueAdd(cfg *config.Config, project string, epics []string) error {
+	for _, epicID := range epics {
+		projName, err := auto.ResolveEpicProject(cfg, epicID)
+		if err != nil {
+			return err
+		}
+		if project == "" {
+			project = projName
+		} else if projName != project {
+			return fmt.Errorf("epic %s belongs to project %s, not %s", epicID, projName, project)
+		}
+	}
+
+	q, err := auto.LoadEpicQueue(cfg, project)
+	if err != nil {
+		return err
+	}
+	current := ""
+	if state, err := auto.LoadProjectEpicState(cfg, project); err == nil {
+		current = state.EpicID
+	}
+	for _, epicID := range epics {
+		if epicID == current {
+			fmt.Printf("%s is the epic in progress; not queued\n", epicID)
+			continue
+		}
+		if q.Add(epicID) {
+			fmt.Printf("Queued %s (position %d)\n", epicID, len(q.Epics))
+		} else {
+			fmt.Printf("%s is already queued\n", epicID)
+		}
+	}
+	if err := auto.SaveEpicQueue(cfg, project, q); err != nil {
+		return fmt.Errorf("saving epic queue: %w", err)
+	}
+
+	if !auto.RunnerActive(cfg, project) && current == "" && len(q.Epics) > 0 {
+		fmt.Printf("No epic run is active for %s. Start one with: wt auto --epic %s\n", project, q.Epics[0])
+	}
+	return nil
+}
+
+// cmdAutoQueueHelp shows help for the auto queue command
+func cmdAutoQueueHelp() error {
+	help := `wt auto queue - Queue epics for an epic run
+
+USAGE:
+    wt auto queue [list] [-p <project>]
+    wt auto queue add <epic>... [-p <project>]
+    wt auto queue remove <epic> [-p <project>]
+    wt auto queue clear [-p <project>]
+
+DESCRIPTION:
+    An epic run processes the queued epics of its project one after
+    another once the current epic completes, under the same lock. Each
+    epic keeps its own state; 'wt auto --check' shows the current epic
+    followed by the finished and queued ones.
+
+    'wt auto --epic A --epic B' queues B behind A. Epics added while
+    wt auto runs are picked up after the current epic. If an epic pauses,
+    fails or ends with failed or skipped beads, the queue waits for it:
+    'wt auto --resume' finishes it and continues with the queue.
+
+    A new run starts with an empty list of finished epics but keeps the
+    epics still queued.
+
+COMMANDS:
+    list                Show the current, finished and queued epics (default)
+    add <epic>...       Queue epics at the end; they must share a project
+    remove <epic>       Take an epic off the queue
+    clear               Empty the queue
+
+OPTIONS:
+    -p, --project <name>  Project of the queue
+    --json                list: output as JSON
+    -h, --help            Show this help
+
+EXAMPLES:
+    wt auto --epic wt-a --epic wt-b     Run wt-a, then wt-b
+    wt auto queue add wt-c              Run wt-c after them
+    wt auto queue remove wt-b           Skip wt-b
+`
+	fmt.Print(help)
+	return nil
+}
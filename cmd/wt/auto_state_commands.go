@@ -0,0 +1,260 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+
+	"github.com/badri/wt/internal/auto"
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/logging"
+)
+
+// cmdAutoState inspects and edits the state of an epic run
+func cmdAutoState(cfg *config.Config, args []string) error {
+	project, rest, err := parseAutoStateProject(args)
+	if err != nil {
+		return err
+	}
+	if len(rest) == 0 {
+		rest = []string{"show"}
+	}
+
+	project, err = resolveAutoStateProject(cfg, project)
+	if err != nil {
+		return err
+	}
+
+	switch rest[0] {
+	case "show":
+		return autoStateShow(cfg, project)
+	case "edit":
+		return autoStateEdit(cfg, project)
+	case "skip-bead":
+		if len(rest) < 2 {
+			return fmt.Errorf("usage: wt auto state skip-bead <id> [reason]")
+		}
+		edit := auto.EpicEdit{Op: auto.EditSkip, Bead: rest[1], Reason: strings.Join(rest[2:], " ")}
+		return autoStateApply(cfg, project, edit)
+	case "requeue-bead":
+		if len(rest) < 2 {
+			return fmt.Errorf("usage: wt auto state requeue-bead <id> [--next]")
+		}
+		edit := auto.EpicEdit{Op: auto.EditRequeue, Bead: rest[1]}
+		for _, arg := range rest[2:] {
+			if arg == "--next" {
+				edit.Front = true
+			}
+		}
+		return autoStateApply(cfg, project, edit)
+	default:
+		subcommands := []string{"show", "edit", "skip-bead", "requeue-bead"}
+		return fmt.Errorf("unknown auto state command: %s%s\nUsage: wt auto state [show|edit|skip-bead|requeue-bead]", rest[0], didYouMean(rest[0], subcommands))
+	}
+}
+
+// parseAutoStateProject pulls -p/--project out of the arguments
+func parseAutoStateProject(args []string) (project string, rest []string, err error) {
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-p", "--project":
+			if i+1 >= len(args) {
+				return "", nil, fmt.Errorf("--project requires a project name")
+			}
+			project = args[i+1]
+			i++
+		default:
+			rest = append(rest, args[i])
+		}
+	}
+	return project, rest, nil
+}
+
+// resolveAutoStateProject picks the project whose epic state to use. Without
+// --project there must be exactly one epic state.
+func resolveAutoStateProject(cfg *config.Config, project string) (string, error) {
+	if project != "" {
+		return project, nil
+	}
+	projects, err := auto.EpicStateProjects(cfg)
+	if err != nil {
+		return "", err
+	}
+	switch len(projects) {
+	case 0:
+		return "", fmt.Errorf("no epic state found. Start an epic with: wt auto --epic <id>")
+	case 1:
+		return projects[0], nil
+	}
+	return "", fmt.Errorf("several epics have state (%s); choose one with --project", strings.Join(projects, ", "))
+}
+
+func autoStateShow(cfg *config.Config, project string) error {
+	state, err := auto.LoadProjectEpicState(cfg, project)
+	if err != nil {
+		return fmt.Errorf("no epic state for project '%s': %w", project, err)
+	}
+	queued, err := auto.QueuedEpicEdits(cfg, project)
+	if err != nil {
+		logging.Warnf("could not read queued edits: %v", err)
+	}
+
+	if outputJSON {
+		printJSON(struct {
+			*auto.EpicState
+			QueuedEdits []auto.EpicEdit `json:"queued_edits,omitempty"`
+		}{state, queued})
+		return nil
+	}
+
+	running := ""
+	if auto.RunnerActive(cfg, project) {
+		running = " (auto running)"
+	}
+	fmt.Printf("Epic:     %s %s\n", state.EpicID, state.EpicTitle)
+	fmt.Printf("Status:   %s%s\n", state.Status, running)
+	fmt.Printf("Session:  %s\n", state.SessionName)
+	if state.PendingGate != nil {
+		next := "wt auto approve --project " + project
+		if running == "" {
+			next = "wt auto --resume --epic " + state.EpicID
+		}
+		fmt.Printf("Gate:     %s pending (continue with: %s)\n", state.PendingGate.Gate, next)
+	}
+	fmt.Printf("Progress: %d/%d beads completed\n\n", len(state.CompletedBeads), len(state.Beads))
+
+	columns := []table.Column{
+		{Title: "#", Width: 3},
+		{Title: "Bead", Width: 16},
+		{Title: "State", Width: 8},
+		{Title: "Title", Width: 34},
+		{Title: "Reason", Width: 28},
+	}
+	var rows []table.Row
+	for i, id := range state.Beads {
+		status, reason := state.BeadStatus(id)
+		rows = append(rows, table.Row{
+			fmt.Sprintf("%d", i+1),
+			id,
+			status,
+			truncate(state.BeadTitles[id], 34),
+			truncate(reason, 28),
+		})
+	}
+	printTable("Bead Queue", columns, rows)
+
+	if len(queued) > 0 {
+		fmt.Println("\nQueued edits (applied before the next bead):")
+		for _, e := range queued {
+			fmt.Printf("  %s\n", formatEpicEdit(e))
+		}
+	}
+	return nil
+}
+
+// autoStateEdit opens the epic state in $EDITOR. Not allowed while auto
+// runs, since the runner would overwrite the file.
+func autoStateEdit(cfg *config.Config, project string) error {
+	if auto.RunnerActive(cfg, project) {
+		return fmt.Errorf("wt auto is running for this epic; use skip-bead/requeue-bead, or stop it first with: wt auto --stop")
+	}
+	state, err := auto.LoadProjectEpicState(cfg, project)
+	if err != nil {
+		return fmt.Errorf("no epic state for project '%s': %w", project, err)
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	edited, err := editText(string(data) + "\n")
+	if err != nil {
+		return fmt.Errorf("running editor: %w", err)
+	}
+	var updated auto.EpicState
+	if err := json.Unmarshal([]byte(edited), &updated); err != nil {
+		return fmt.Errorf("invalid epic state, not saved: %w", err)
+	}
+	if updated.EpicID != state.EpicID {
+		return fmt.Errorf("epic_id can't be changed (was %s), not saved", state.EpicID)
+	}
+	if err := auto.SaveProjectEpicState(cfg, project, &updated); err != nil {
+		return fmt.Errorf("saving epic state: %w", err)
+	}
+	fmt.Printf("Saved epic state for %s.\n", updated.EpicID)
+	return nil
+}
+
+func autoStateApply(cfg *config.Config, project string, edit auto.EpicEdit) error {
+	queued, err := auto.EditEpicState(cfg, project, edit)
+	if err != nil {
+		return err
+	}
+	if queued {
+		fmt.Printf("Queued: %s (the running auto applies it before the next bead)\n", formatEpicEdit(edit))
+	} else {
+		fmt.Printf("Updated: %s\n", formatEpicEdit(edit))
+	}
+	return nil
+}
+
+// formatEpicEdit describes an edit for display
+func formatEpicEdit(e auto.EpicEdit) string {
+	switch e.Op {
+	case auto.EditSkip:
+		if e.Reason != "" {
+			return fmt.Sprintf("skip %s (%s)", e.Bead, e.Reason)
+		}
+		return "skip " + e.Bead
+	case auto.EditRequeue:
+		if e.Front {
+			return fmt.Sprintf("requeue %s to run next", e.Bead)
+		}
+		return fmt.Sprintf("requeue %s at the end", e.Bead)
+	}
+	return e.Op + " " + e.Bead
+}
+
+// cmdAutoStateHelp shows help for the auto state command
+func cmdAutoStateHelp() error {
+	help := `wt auto state - Inspect and edit an epic run
+
+USAGE:
+    wt auto state [show] [-p <project>]
+    wt auto state edit [-p <project>]
+    wt auto state skip-bead <id> [reason] [-p <project>]
+    wt auto state requeue-bead <id> [--next] [-p <project>]
+
+DESCRIPTION:
+    Works on the epic state that 'wt auto --epic' keeps while it runs.
+    Without --project, the only epic with state is used.
+
+    While wt auto is running, skip-bead and requeue-bead are queued and
+    applied by the runner before it picks the next bead; the bead in
+    progress can't be changed. For a paused or failed epic they take
+    effect immediately and are used by 'wt auto --resume'.
+
+COMMANDS:
+    show                Show progress and the bead queue (default)
+    edit                Open the state JSON in $EDITOR (not while running)
+    skip-bead <id>      Leave a bead out of the run; it stays open and the
+                        epic is not closed
+    requeue-bead <id>   Move a bead to the end of the queue, clearing a
+                        failure or skip so it runs again
+
+OPTIONS:
+    -p, --project <name>  Project of the epic
+    --next                requeue-bead: run the bead next instead of last
+    --json                show: output as JSON
+    -h, --help            Show this help
+
+EXAMPLES:
+    wt auto state                               Show the bead queue
+    wt auto state skip-bead wt-abc "needs design decision"
+    wt auto state requeue-bead wt-def --next    Run wt-def next
+`
+	fmt.Print(help)
+	return nil
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/badri/wt/internal/auto"
+)
+
+func TestParseAutoStateProject(t *testing.T) {
+	project, rest, err := parseAutoStateProject([]string{"skip-bead", "-p", "api", "wt-abc", "flaky"})
+	if err != nil {
+		t.Fatalf("parseAutoStateProject() error: %v", err)
+	}
+	if project != "api" || !slices.Equal(rest, []string{"skip-bead", "wt-abc", "flaky"}) {
+		t.Errorf("parseAutoStateProject() = %q, %v", project, rest)
+	}
+	if _, _, err := parseAutoStateProject([]string{"show", "--project"}); err == nil {
+		t.Error("--project without a value should fail")
+	}
+}
+
+func TestFormatEpicEdit(t *testing.T) {
+	tests := []struct {
+		edit auto.EpicEdit
+		want string
+	}{
+		{auto.EpicEdit{Op: auto.EditSkip, Bead: "wt-a"}, "skip wt-a"},
+		{auto.EpicEdit{Op: auto.EditSkip, Bead: "wt-a", Reason: "flaky"}, "skip wt-a (flaky)"},
+		{auto.EpicEdit{Op: auto.EditRequeue, Bead: "wt-b"}, "requeue wt-b at the end"},
+		{auto.EpicEdit{Op: auto.EditRequeue, Bead: "wt-b", Front: true}, "requeue wt-b to run next"},
+	}
+	for _, tt := range tests {
+		if got := formatEpicEdit(tt.edit); got != tt.want {
+			t.Errorf("formatEpicEdit(%+v) = %q, want %q", tt.edit, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/handoff"
+	"github.com/badri/wt/internal/tmux"
+)
+
+// previousSessionFile holds the session 'wt back' returns to
+const previousSessionFile = "previous_session"
+
+// cmdBackHelp shows help for the back command
+func cmdBackHelp() error {
+	help := `wt back - Return to the previously active session
+
+USAGE:
+    wt back
+
+Switches back to the session you were in before the last 'wt <name>',
+'wt pick', 'wt new' or 'wt watch' switch, like 'cd -'. Running it twice
+toggles between two sessions. Inside tmux this uses switch-client, so
+sessions never nest; outside tmux it attaches.
+
+When wt has no record of the previous session (or it has ended), tmux's
+own last session for this client is used.
+
+EXAMPLES:
+    wt back             Return to the previous session
+    bind-key B run-shell "wt back"   tmux binding (see 'wt keys')
+`
+	fmt.Print(help)
+	return nil
+}
+
+// cmdBack switches to the previously active session
+func cmdBack(cfg *config.Config) error {
+	current := ""
+	if tmux.InsideTmux() {
+		current = tmux.CurrentSession()
+	}
+	target := previousSession(cfg, current)
+	if target == "" {
+		return fmt.Errorf("no previous session to return to")
+	}
+	fmt.Printf("Returning to session: %s\n", target)
+	return switchToSession(cfg, target)
+}
+
+// switchToSession moves the terminal to a session: switch-client inside
+// tmux, attach outside it. The session being left is remembered for
+// 'wt back'.
+func switchToSession(cfg *config.Config, name string) error {
+	rememberCurrentSession(cfg, name)
+	return tmux.Attach(name)
+}
+
+// rememberCurrentSession records the session the client is in before it
+// switches to target
+func rememberCurrentSession(cfg *config.Config, target string) {
+	if !tmux.InsideTmux() {
+		return
+	}
+	current := tmux.CurrentSession()
+	if current == "" || current == target {
+		return
+	}
+	dir := filepath.Join(cfg.ConfigDir(), handoff.RuntimeDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return
+	}
+	os.WriteFile(filepath.Join(dir, previousSessionFile), []byte(current+"\n"), 0644)
+}
+
+// previousSession returns the session to go back to from current: the one
+// wt recorded, else tmux's last session for this client. Sessions that
+// have ended are passed over.
+func previousSession(cfg *config.Config, current string) string {
+	var candidates []string
+	if data, err := os.ReadFile(filepath.Join(cfg.ConfigDir(), handoff.RuntimeDir, previousSessionFile)); err == nil {
+		candidates = append(candidates, strings.TrimSpace(string(data)))
+	}
+	if current != "" {
+		candidates = append(candidates, tmux.LastSession())
+	}
+	for _, name := range candidates {
+		if name != "" && name != current && tmux.SessionExists(name) {
+			return name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/handoff"
+)
+
+func TestPreviousSession(t *testing.T) {
+	t.Setenv("TMUX", "")
+	cfg, err := config.LoadFromDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(cfg.ConfigDir(), handoff.RuntimeDir, previousSessionFile)
+
+	// Outside tmux there is no session being left, so nothing is recorded
+	rememberCurrentSession(cfg, "toast")
+	if _, err := os.Stat(file); !os.IsNotExist(err) {
+		t.Errorf("rememberCurrentSession outside tmux wrote %s", file)
+	}
+	if err := cmdBack(cfg); err == nil {
+		t.Error("cmdBack with no previous session should fail")
+	}
+
+	// A recorded session that has ended is passed over
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(file, []byte("wt-no-such-session-1910\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := previousSession(cfg, ""); got != "" {
+		t.Errorf("previousSession() = %q, want empty for an ended session", got)
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/badri/wt/internal/bead"
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/events"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/project"
+	"github.com/badri/wt/internal/session"
+)
+
+// maxActivityCommits caps the commits listed in an activity comment
+const maxActivityCommits = 10
+
+// postBeadActivity posts a session lifecycle event as a comment on its bead
+// when the project has "activity_comments" on, so the bead's history in bd
+// shows what wt did without consulting wt events
+func postBeadActivity(cfg *config.Config, sess *session.Session, e *events.Event) {
+	if sess.Bead == "" || sess.Project == "" {
+		return
+	}
+	proj, err := project.NewManager(cfg).Get(sess.Project)
+	if err != nil || !proj.ActivityComments {
+		return
+	}
+	// Session summaries already list the commits
+	text := activityComment(e, !proj.SummaryComment)
+	if text == "" {
+		return
+	}
+	if err := bead.AddCommentInDir(sess.Bead, text, sess.BeadsDir); err != nil {
+		logging.Warnf("could not post activity to bead: %v", err)
+	}
+}
+
+// activityComment renders a lifecycle event for a bead comment, "" for
+// events that aren't posted
+func activityComment(e *events.Event, withCommits bool) string {
+	var sb strings.Builder
+	switch e.Type {
+	case events.EventSessionStart:
+		fmt.Fprintf(&sb, "wt: session %s started", e.Session)
+		if e.WorktreePath != "" {
+			fmt.Fprintf(&sb, " in %s", e.WorktreePath)
+		}
+	case events.EventSessionEnd:
+		switch e.MergeMode {
+		case "direct":
+			fmt.Fprintf(&sb, "wt: session %s merged directly", e.Session)
+			if e.MergeCommit != "" {
+				fmt.Fprintf(&sb, " (merge commit %s)", shortSHA(e.MergeCommit))
+			}
+		case "pr-auto":
+			fmt.Fprintf(&sb, "wt: session %s opened PR %s with auto-merge", e.Session, e.PRURL)
+		case "pr-review":
+			fmt.Fprintf(&sb, "wt: session %s opened PR %s for review", e.Session, e.PRURL)
+		case "closed":
+			fmt.Fprintf(&sb, "wt: session %s closed", e.Session)
+		case "killed":
+			fmt.Fprintf(&sb, "wt: session %s killed; the bead stays open", e.Session)
+		default:
+			fmt.Fprintf(&sb, "wt: session %s ended (%s)", e.Session, e.MergeMode)
+		}
+	default:
+		return ""
+	}
+
+	if withCommits && e.Summary != nil && len(e.Summary.Commits) > 0 {
+		commits := e.Summary.Commits
+		fmt.Fprintf(&sb, "\n\nCommits (%d):", len(commits))
+		if len(commits) > maxActivityCommits {
+			commits = commits[:maxActivityCommits]
+		}
+		for _, c := range commits {
+			fmt.Fprintf(&sb, "\n- %s", c)
+		}
+		if more := len(e.Summary.Commits) - len(commits); more > 0 {
+			fmt.Fprintf(&sb, "\n- ... and %d more", more)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/badri/wt/internal/events"
+)
+
+func TestActivityComment(t *testing.T) {
+	summary := &events.Summary{Commits: []string{"abc1234 Add parser", "def5678 Fix tests"}}
+
+	tests := []struct {
+		name  string
+		event *events.Event
+		want  string
+	}{
+		{"start", &events.Event{Type: events.EventSessionStart, Session: "toast", WorktreePath: "/wt/toast"},
+			"wt: session toast started in /wt/toast"},
+		{"direct", &events.Event{Type: events.EventSessionEnd, Session: "toast", MergeMode: "direct", MergeCommit: "0123456789abcdef", Summary: summary},
+			"wt: session toast merged directly (merge commit 01234567)\n\nCommits (2):\n- abc1234 Add parser\n- def5678 Fix tests"},
+		{"review", &events.Event{Type: events.EventSessionEnd, Session: "toast", MergeMode: "pr-review", PRURL: "https://github.com/o/r/pull/7"},
+			"wt: session toast opened PR https://github.com/o/r/pull/7 for review"},
+		{"killed", &events.Event{Type: events.EventSessionEnd, Session: "toast", MergeMode: "killed"},
+			"wt: session toast killed; the bead stays open"},
+		{"not posted", &events.Event{Type: events.EventNote, Session: "toast", Note: "hi"}, ""},
+	}
+	for _, tt := range tests {
+		if got := activityComment(tt.event, true); got != tt.want {
+			t.Errorf("%s: activityComment() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	e := &events.Event{Type: events.EventSessionEnd, Session: "toast", MergeMode: "closed", Summary: summary}
+	if got := activityComment(e, false); got != "wt: session toast closed" {
+		t.Errorf("activityComment() without commits = %q", got)
+	}
+
+	var many []string
+	for i := range maxActivityCommits + 3 {
+		many = append(many, fmt.Sprintf("c%d", i))
+	}
+	e.Summary = &events.Summary{Commits: many}
+	if got := activityComment(e, true); !strings.HasSuffix(got, "- ... and 3 more") {
+		t.Errorf("activityComment() should cap the commit list, got %q", got)
+	}
+}
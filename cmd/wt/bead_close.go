@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/badri/wt/internal/bead"
+	"github.com/badri/wt/internal/closing"
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/monitor"
+	"github.com/badri/wt/internal/project"
+	"github.com/badri/wt/internal/session"
+)
+
+// closeBeadAtDone closes a finished session's bead as the project's
+// bead_close policy says. A bead that must stay open is recorded, to be
+// closed by syncPendingCloses once its PR merges or by 'wt close'.
+func closeBeadAtDone(cfg *config.Config, proj *project.Project, sessionName string, sess *session.Session, branch, prURL string, merged bool) {
+	mode := proj.BeadCloseMode()
+	if mode == project.BeadCloseDone || (mode == project.BeadCloseMerged && merged) {
+		logging.Infof("\nClosing bead...")
+		if err := bead.Close(sess.Bead); err != nil {
+			logging.Warnf("could not close bead: %v", err)
+		} else {
+			closeUpstreamIssue(cfg, sess.Bead, "")
+		}
+		return
+	}
+
+	err := closing.Update(cfg, func(store *closing.Store) error {
+		store.Put(&closing.Entry{
+			Bead:     sess.Bead,
+			Session:  sessionName,
+			Branch:   branch,
+			Project:  proj.Name,
+			RepoPath: proj.RepoPath(),
+			PRURL:    prURL,
+			Mode:     mode,
+		})
+		return nil
+	})
+	if err != nil {
+		logging.Warnf("could not save pending closes: %v", err)
+	}
+
+	if mode == project.BeadCloseMerged {
+		fmt.Printf("\nBead %s stays open until its PR merges (bead_close: merged).\n", sess.Bead)
+		return
+	}
+	fmt.Printf("\nBead %s stays open (bead_close: manual). Close it with: wt close %s\n", sess.Bead, sess.Bead)
+}
+
+// syncPendingCloses closes the beads 'wt done' left open until their PR
+// merged, and forgets beads whose PR was closed unmerged (they stay open)
+func syncPendingCloses(cfg *config.Config) {
+	store, err := closing.Load(cfg)
+	if err != nil || len(store.Entries) == 0 {
+		return
+	}
+	var settled []string
+	for beadID, e := range store.Entries {
+		if e.Mode != project.BeadCloseMerged {
+			continue
+		}
+		switch status, _ := monitor.GetPRStatus(e.RepoPath, e.Branch); status {
+		case "merged":
+			logging.Infof("PR for %s merged - closing bead...", beadID)
+			if err := bead.CloseInDir(beadID, e.RepoPath); err != nil {
+				logging.Warnf("could not close bead: %v", err)
+				continue
+			}
+			closeUpstreamIssue(cfg, beadID, "")
+		case "closed":
+			fmt.Printf("PR for %s was closed without merging - bead %s stays open.\n", e.Session, beadID)
+		default:
+			continue
+		}
+		settled = append(settled, beadID)
+	}
+	if len(settled) > 0 {
+		if err := removePendingCloses(cfg, settled...); err != nil {
+			logging.Warnf("could not save pending closes: %v", err)
+		}
+	}
+}
+
+// removePendingCloses drops beads' records, keeping any that another wt
+// process added since they were read
+func removePendingCloses(cfg *config.Config, beadIDs ...string) error {
+	return closing.Update(cfg, func(store *closing.Store) error {
+		for _, id := range beadIDs {
+			store.Remove(id)
+		}
+		return nil
+	})
+}
+
+// closePendingBead closes a bead 'wt done' left open, for 'wt close <bead>'
+// after its session is gone. It reports whether the bead had a record.
+func closePendingBead(cfg *config.Config, beadID string) (bool, error) {
+	store, err := closing.Load(cfg)
+	if err != nil {
+		return false, fmt.Errorf("loading pending closes: %w", err)
+	}
+	e := store.Get(beadID)
+	if e == nil {
+		return false, nil
+	}
+	logging.Infof("Closing bead %s (left open by wt done)...", beadID)
+	if err := bead.CloseInDir(beadID, e.RepoPath); err != nil {
+		return true, err
+	}
+	closeUpstreamIssue(cfg, beadID, "")
+	if err := removePendingCloses(cfg, beadID); err != nil {
+		logging.Warnf("could not save pending closes: %v", err)
+	}
+	fmt.Printf("✓ Bead %s closed\n", beadID)
+	return true, nil
+}
+
+type closeFlags struct {
+	noSummary bool
+}
+
+// parseCloseArgs parses 'wt close <name> [--no-summary]'
+func parseCloseArgs(args []string) (string, closeFlags, error) {
+	var name string
+	var flags closeFlags
+	for _, arg := range args {
+		switch {
+		case arg == "--no-summary":
+			flags.noSummary = true
+		case strings.HasPrefix(arg, "-"):
+			return "", flags, fmt.Errorf("unknown flag: %s", arg)
+		case name != "":
+			return "", flags, fmt.Errorf("unexpected argument: %s", arg)
+		default:
+			name = arg
+		}
+	}
+	return name, flags, nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/badri/wt/internal/closing"
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/project"
+	"github.com/badri/wt/internal/session"
+)
+
+func TestCloseBeadAtDoneDefers(t *testing.T) {
+	cfg, err := config.LoadFromDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	proj := &project.Project{Name: "app", Repo: "/code/app", BeadClose: project.BeadCloseMerged}
+
+	closeBeadAtDone(cfg, proj, "toast", &session.Session{Bead: "app-1"}, "app-1", "https://github.com/o/r/pull/1", false)
+	proj.BeadClose = project.BeadCloseManual
+	closeBeadAtDone(cfg, proj, "shadow", &session.Session{Bead: "app-2"}, "app-2", "", true)
+
+	store, err := closing.Load(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := store.Get("app-1"); e == nil || e.Mode != project.BeadCloseMerged || e.PRURL == "" {
+		t.Errorf("app-1 entry = %+v, want it waiting for its PR", e)
+	}
+	// manual keeps the bead open even when the work merged
+	if e := store.Get("app-2"); e == nil || e.Mode != project.BeadCloseManual || e.Session != "shadow" {
+		t.Errorf("app-2 entry = %+v, want it left for wt close", e)
+	}
+
+	if pending, err := closePendingBead(cfg, "app-9"); pending || err != nil {
+		t.Errorf("closePendingBead(unknown) = %v, %v; want false, nil", pending, err)
+	}
+}
+
+func TestParseCloseArgs(t *testing.T) {
+	name, flags, err := parseCloseArgs([]string{"toast", "--no-summary"})
+	if err != nil || name != "toast" || !flags.noSummary {
+		t.Errorf("parseCloseArgs() = %q, %+v, %v", name, flags, err)
+	}
+	for _, args := range [][]string{{"toast", "--summary"}, {"toast", "shadow"}} {
+		if _, _, err := parseCloseArgs(args); err == nil {
+			t.Errorf("parseCloseArgs(%q) should fail", args)
+		}
+	}
+}
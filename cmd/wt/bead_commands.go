@@ -24,6 +24,7 @@ USAGE:
 
 SUBCOMMANDS:
     create <title>          Create a new bead in the current project
+    show <bead-id>          Show a bead from any project (same as wt show)
 
 CREATE OPTIONS:
     --description, -d <text>    Description for the bead
@@ -91,10 +92,12 @@ func cmdBead(cfg *config.Config, args []string) error {
 			return cmdBeadHelp()
 		}
 		return cmdBeadCreate(cfg, args[1:])
+	case "show":
+		return cmdShow(cfg, args[1:])
 	case "help", "-h", "--help":
 		return cmdBeadHelp()
 	default:
-		return fmt.Errorf("unknown bead subcommand: %s\nRun 'wt bead help' for usage", args[0])
+		return fmt.Errorf("unknown bead subcommand: %s%s\nRun 'wt bead help' for usage", args[0], didYouMean(args[0], []string{"create", "show", "help"}))
 	}
 }
 
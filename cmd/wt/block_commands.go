@@ -0,0 +1,290 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/badri/wt/internal/bead"
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/events"
+	"github.com/badri/wt/internal/hub"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/msg"
+	"github.com/badri/wt/internal/session"
+	"github.com/badri/wt/internal/tmux"
+)
+
+// blockArgs holds the parsed arguments of 'wt block'
+type blockArgs struct {
+	reason string
+	on     string // bead the session's bead waits for
+}
+
+func parseBlockArgs(args []string) (*blockArgs, error) {
+	ba := &blockArgs{}
+	var words []string
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--on":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--on requires a bead ID")
+			}
+			ba.on = args[i+1]
+			i++
+		case strings.HasPrefix(args[i], "--on="):
+			ba.on = strings.TrimPrefix(args[i], "--on=")
+		default:
+			words = append(words, args[i])
+		}
+	}
+	ba.reason = strings.TrimSpace(strings.Join(words, " "))
+	if ba.reason == "" {
+		return nil, fmt.Errorf("usage: wt block \"<reason>\" [--on <bead-id>]")
+	}
+	return ba, nil
+}
+
+// sessionInCwd returns the session whose worktree is the current directory
+func sessionInCwd(state *session.State) (string, *session.Session) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", nil
+	}
+	for name, s := range state.Sessions {
+		if s.Worktree == cwd {
+			return name, s
+		}
+	}
+	return "", nil
+}
+
+// cmdBlock marks the current session blocked, optionally on another bead,
+// and tells the hub why
+func cmdBlock(cfg *config.Config, args []string) error {
+	ba, err := parseBlockArgs(args)
+	if err != nil {
+		return err
+	}
+
+	state, err := session.LoadState(cfg)
+	if err != nil {
+		return err
+	}
+	name, sess := sessionInCwd(state)
+	if sess == nil {
+		return fmt.Errorf("not in a wt session. Run this from inside a session worktree")
+	}
+
+	if ba.on != "" {
+		if ba.on == sess.Bead {
+			return fmt.Errorf("a bead can't block itself")
+		}
+		if err := bead.AddDepInDir(sess.Bead, ba.on, sess.BeadsDir); err != nil {
+			return err
+		}
+	}
+
+	sess.Status = "blocked"
+	sess.StatusMessage = ba.reason
+	sess.BlockedOn = ba.on
+	sess.UpdateActivity()
+	if err := state.Save(); err != nil {
+		return fmt.Errorf("saving state: %w", err)
+	}
+
+	logger := events.NewLogger(cfg)
+	if err := logger.LogBlocked(name, sess.Bead, sess.Project, ba.reason, ba.on); err != nil {
+		logging.Warnf("could not log event: %v", err)
+	}
+
+	fmt.Printf("%s Session '%s' blocked: %s\n", getStatusIcon("blocked"), name, ba.reason)
+	if ba.on != "" {
+		fmt.Printf("   %s now depends on %s\n", sess.Bead, ba.on)
+	}
+
+	notifyHubBlocked(cfg, name, sess, ba)
+	fmt.Println("Stop here and wait; the hub resumes you with 'wt unblock'.")
+	return nil
+}
+
+// notifyHubBlocked leaves a STUCK message for the hub and, when the hub is
+// running, tells its Claude directly
+func notifyHubBlocked(cfg *config.Config, name string, sess *session.Session, ba *blockArgs) {
+	needs := "guidance"
+	if ba.on != "" {
+		needs = "dependency"
+	}
+	body, _ := json.Marshal(msg.StuckBody{BeadID: sess.Bead, Reason: ba.reason, Needs: needs})
+	if store, err := msg.Open(msgDBPath(cfg)); err != nil {
+		logging.Warnf("could not message the hub: %v", err)
+	} else {
+		if _, err := store.Send(&msg.Message{Subject: msg.SubjectStuck, From: name, To: hub.HubSessionName, Body: string(body)}); err != nil {
+			logging.Warnf("could not message the hub: %v", err)
+		}
+		store.Close()
+	}
+
+	if !tmux.SessionExists(hub.HubSessionName) {
+		return
+	}
+	text := fmt.Sprintf("[wt] Worker '%s' (%s) is blocked: %s", name, sess.Bead, ba.reason)
+	if ba.on != "" {
+		text += fmt.Sprintf(" (waiting on %s)", ba.on)
+	}
+	text += fmt.Sprintf(". Run 'wt unblock %s -m \"<answer>\"' once it can continue.", name)
+	if err := tmux.NudgeSession(hub.HubSessionName, text); err != nil {
+		logging.Warnf("could not notify the hub session: %v", err)
+	}
+}
+
+// unblockArgs holds the parsed arguments of 'wt unblock'
+type unblockArgs struct {
+	session string
+	message string
+}
+
+func parseUnblockArgs(args []string) (*unblockArgs, error) {
+	ua := &unblockArgs{}
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--message" || args[i] == "-m":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("%s requires a message", args[i])
+			}
+			ua.message = args[i+1]
+			i++
+		case strings.HasPrefix(args[i], "--message="):
+			ua.message = strings.TrimPrefix(args[i], "--message=")
+		case strings.HasPrefix(args[i], "-"):
+			return nil, fmt.Errorf("unknown flag: %s", args[i])
+		case ua.session == "":
+			ua.session = args[i]
+		default:
+			return nil, fmt.Errorf("usage: wt unblock [session] [-m <message>]")
+		}
+	}
+	return ua, nil
+}
+
+// cmdUnblock resumes a blocked session. Inside a worker it resumes that
+// worker; elsewhere the session is named and the worker is told to go on.
+func cmdUnblock(cfg *config.Config, args []string) error {
+	ua, err := parseUnblockArgs(args)
+	if err != nil {
+		return err
+	}
+
+	state, err := session.LoadState(cfg)
+	if err != nil {
+		return err
+	}
+	self, _ := sessionInCwd(state)
+	var name string
+	var sess *session.Session
+	if ua.session != "" {
+		name, sess = findSessionByNameOrBead(state, ua.session)
+		if sess == nil {
+			return fmt.Errorf("session '%s' not found", ua.session)
+		}
+	} else {
+		name, sess = sessionInCwd(state)
+		if sess == nil {
+			return fmt.Errorf("not in a wt session. Name the session: wt unblock <session>")
+		}
+	}
+	if sess.Status != "blocked" {
+		return fmt.Errorf("session '%s' is not blocked (status: %s)", name, sess.Status)
+	}
+
+	blockedOn := sess.BlockedOn
+	sess.Status = "working"
+	sess.StatusMessage = ua.message
+	sess.BlockedOn = ""
+	sess.UpdateActivity()
+	if err := state.Save(); err != nil {
+		return fmt.Errorf("saving state: %w", err)
+	}
+
+	logger := events.NewLogger(cfg)
+	if err := logger.LogUnblocked(name, sess.Bead, sess.Project, ua.message); err != nil {
+		logging.Warnf("could not log event: %v", err)
+	}
+
+	fmt.Printf("%s Session '%s' unblocked\n", getStatusIcon("working"), name)
+	if blockedOn != "" {
+		fmt.Printf("   Note: %s still depends on %s in bd\n", sess.Bead, blockedOn)
+	}
+
+	// Wake the worker unless it unblocked itself
+	if name != self && tmux.SessionExists(name) {
+		text := "You are unblocked. Continue with your task."
+		if ua.message != "" {
+			text = fmt.Sprintf("You are unblocked: %s\nContinue with your task.", ua.message)
+		}
+		if err := tmux.NudgeSession(name, text); err != nil {
+			logging.Warnf("could not notify '%s': %v", name, err)
+		}
+	}
+	return nil
+}
+
+// cmdBlockHelp shows help for the block command
+func cmdBlockHelp() error {
+	help := `wt block - Mark the current session blocked
+
+USAGE:
+    wt block "<reason>" [--on <bead-id>]
+
+DESCRIPTION:
+    Run from inside a worker session when it can't make progress. Sets the
+    session status to blocked with the reason, logs a 'blocked' event and
+    notifies the hub: a STUCK message for 'wt msg recv --as hub', and a
+    prompt in the hub session if it is running.
+
+    With --on, the session's bead also gets a bd dependency on the blocking
+    bead, so 'bd ready' and 'wt audit' see the relationship.
+
+    The worker should stop and wait. 'wt unblock' resumes it.
+
+OPTIONS:
+    --on <bead-id>      Bead this session's bead is waiting for
+    -h, --help          Show this help
+
+EXAMPLES:
+    wt block "Need API credentials for staging"
+    wt block "Needs the new auth middleware" --on proj-def
+`
+	fmt.Print(help)
+	return nil
+}
+
+// cmdUnblockHelp shows help for the unblock command
+func cmdUnblockHelp() error {
+	help := `wt unblock - Resume a blocked session
+
+USAGE:
+    wt unblock [session] [-m <message>]
+
+DESCRIPTION:
+    Sets a session blocked with 'wt block' back to working and logs an
+    'unblocked' event. Inside a worker session it resumes that session;
+    from the hub or a shell, name the session (or its bead). The worker
+    is then sent "You are unblocked" and the message, if any.
+
+    A bd dependency added with 'wt block --on' is left in place; remove it
+    with 'bd dep remove' if the bead no longer needs the other one.
+
+OPTIONS:
+    -m, --message <text>  Answer or context for the worker
+    -h, --help            Show this help
+
+EXAMPLES:
+    wt unblock                                   Resume the current session
+    wt unblock toast -m "Credentials are in .env.staging"
+    wt unblock proj-abc                          Resume by bead ID
+`
+	fmt.Print(help)
+	return nil
+}
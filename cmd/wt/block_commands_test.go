@@ -0,0 +1,41 @@
+package main
+
+import "testing"
+
+func TestParseBlockArgs(t *testing.T) {
+	ba, err := parseBlockArgs([]string{"Needs", "the auth middleware", "--on", "proj-def"})
+	if err != nil {
+		t.Fatalf("parseBlockArgs() error: %v", err)
+	}
+	if ba.reason != "Needs the auth middleware" || ba.on != "proj-def" {
+		t.Errorf("parseBlockArgs() = %+v", ba)
+	}
+	if ba, _ := parseBlockArgs([]string{"--on=proj-def", "waiting"}); ba == nil || ba.on != "proj-def" {
+		t.Errorf("parseBlockArgs(--on=) = %+v", ba)
+	}
+
+	for _, args := range [][]string{{}, {"--on", "proj-def"}, {"reason", "--on"}} {
+		if _, err := parseBlockArgs(args); err == nil {
+			t.Errorf("parseBlockArgs(%v) should fail", args)
+		}
+	}
+}
+
+func TestParseUnblockArgs(t *testing.T) {
+	ua, err := parseUnblockArgs([]string{"toast", "-m", "Use the v2 API"})
+	if err != nil {
+		t.Fatalf("parseUnblockArgs() error: %v", err)
+	}
+	if ua.session != "toast" || ua.message != "Use the v2 API" {
+		t.Errorf("parseUnblockArgs() = %+v", ua)
+	}
+	if ua, err := parseUnblockArgs(nil); err != nil || ua.session != "" {
+		t.Errorf("parseUnblockArgs(nil) = %+v, %v", ua, err)
+	}
+
+	for _, args := range [][]string{{"toast", "shadow"}, {"-m"}, {"--force"}} {
+		if _, err := parseUnblockArgs(args); err == nil {
+			t.Errorf("parseUnblockArgs(%v) should fail", args)
+		}
+	}
+}
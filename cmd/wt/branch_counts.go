@@ -0,0 +1,87 @@
+package main
+
+import (
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/monitor"
+	"github.com/badri/wt/internal/project"
+	"github.com/badri/wt/internal/session"
+)
+
+// branchCounter measures sessions against the branch they merge into,
+// loading each project's config once
+type branchCounter struct {
+	mgr          *project.Manager
+	projects     map[string]*project.Project
+	defaultMerge string
+}
+
+func newBranchCounter(cfg *config.Config) *branchCounter {
+	return &branchCounter{mgr: project.NewManager(cfg), projects: make(map[string]*project.Project), defaultMerge: cfg.DefaultMergeMode}
+}
+
+// baseBranch returns the branch a session merges into: its stack parent,
+// else the project's default branch
+func (b *branchCounter) baseBranch(sess *session.Session) string {
+	if sess.StackBranch != "" {
+		return sess.StackBranch
+	}
+	if proj := b.project(sess); proj != nil && proj.DefaultBranch != "" {
+		return proj.DefaultBranch
+	}
+	return "main"
+}
+
+// project returns a session's project config, nil if it has none
+func (b *branchCounter) project(sess *session.Session) *project.Project {
+	proj, loaded := b.projects[sess.Project]
+	if !loaded && sess.Project != "" {
+		proj, _ = b.mgr.Get(sess.Project)
+		b.projects[sess.Project] = proj
+	}
+	return proj
+}
+
+// counts returns a session's commits ahead of and behind its base branch,
+// nil when the worktree can't be compared
+func (b *branchCounter) counts(sess *session.Session) *monitor.BranchCounts {
+	if sess.Worktree == "" {
+		return nil
+	}
+	counts, ok := monitor.GetBranchCounts(sess.Worktree, b.baseBranch(sess))
+	if !ok {
+		return nil
+	}
+	return &counts
+}
+
+// formatBranchCounts renders counts for tables, "-" when unknown
+func formatBranchCounts(counts *monitor.BranchCounts) string {
+	if counts == nil {
+		return "-"
+	}
+	return counts.String()
+}
+
+// checks returns the CI check state of a session's PR, "" for sessions
+// whose project merges directly and so has no PRs
+func (b *branchCounter) checks(sess *session.Session) string {
+	if sess.Worktree == "" || sess.Branch == "" {
+		return ""
+	}
+	mergeMode := b.defaultMerge
+	if proj := b.project(sess); proj != nil && proj.MergeMode != "" {
+		mergeMode = proj.MergeMode
+	}
+	if mergeMode == "direct" {
+		return ""
+	}
+	return monitor.GetPRInfo(sess.Worktree, sess.Branch).Checks
+}
+
+// formatChecks renders a CI check state for tables, "-" when there is none
+func formatChecks(checks string) string {
+	if checks == "" || checks == monitor.ChecksNone {
+		return "-"
+	}
+	return monitor.ChecksIcon(checks) + " " + checks
+}
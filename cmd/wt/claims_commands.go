@@ -0,0 +1,321 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/badri/wt/internal/bead"
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/project"
+	"github.com/badri/wt/internal/session"
+	"github.com/charmbracelet/bubbles/table"
+)
+
+// claimForNew claims a bead for 'wt new' or 'wt clone' so other hubs and machines don't
+// spawn a second worker on it. --force takes over a live claim. The
+// returned func releases the claim again if session creation fails.
+func claimForNew(cfg *config.Config, beadID, repoPath string, force bool) (func(), error) {
+	me := cfg.ClaimantID()
+	ttl := cfg.ClaimExpiry()
+	if force {
+		ttl = -1
+	}
+	takenOver, err := bead.ClaimBead(beadID, me, repoPath, ttl)
+	var claimed *bead.ClaimedError
+	if errors.As(err, &claimed) {
+		return nil, fmt.Errorf("%v.\nIf that worker is gone, wait for the claim to expire (claim_ttl %s), run 'wt claims release %s', or rerun with --force",
+			err, ttl, beadID)
+	}
+	if err != nil {
+		// Claims are a guard, not a requirement: older bd versions may lack --assignee
+		logging.Warnf("could not claim bead: %v", err)
+		return func() {}, nil
+	}
+	if takenOver != nil {
+		fmt.Printf("Taking over claim on %s from %s\n", beadID, takenOver.Claimant)
+	}
+	return func() {
+		if err := bead.ReleaseClaim(beadID, repoPath); err != nil {
+			logging.Warnf("%v", err)
+		}
+	}, nil
+}
+
+// releaseSessionClaim puts a session's bead back to open if this hub
+// still holds its claim, so 'wt kill' leaves it ready for someone else
+func releaseSessionClaim(cfg *config.Config, sess *session.Session) {
+	if !sess.IsBead() || sess.BeadsDir == "" {
+		return
+	}
+	c, err := bead.GetClaim(sess.Bead, sess.BeadsDir)
+	if err != nil || c.Claimant != cfg.ClaimantID() {
+		return
+	}
+	if err := bead.ReleaseClaim(sess.Bead, sess.BeadsDir); err != nil {
+		logging.Warnf("%v", err)
+	}
+}
+
+// claimRow is one claimed bead in 'wt claims list'
+type claimRow struct {
+	Project  string `json:"project"`
+	Bead     string `json:"bead"`
+	Title    string `json:"title"`
+	Claimant string `json:"claimant"`
+	Since    string `json:"since,omitempty"`
+	State    string `json:"state"` // mine, active or stale
+	Session  string `json:"session,omitempty"`
+	beadsDir string
+}
+
+// claimState classifies a claim as held by this hub, live elsewhere, or
+// stale
+func claimState(c *bead.Claim, me string, ttl time.Duration, now time.Time) string {
+	switch {
+	case c.Claimant == me:
+		return "mine"
+	case c.Stale(ttl, now):
+		return "stale"
+	default:
+		return "active"
+	}
+}
+
+// collectClaims lists the claimed beads of the given projects, or of all
+// projects when projectName is ""
+func collectClaims(cfg *config.Config, projectName string) ([]claimRow, error) {
+	mgr := project.NewManager(cfg)
+	var projects []*project.Project
+	if projectName != "" {
+		proj, err := mgr.Get(projectName)
+		if err != nil {
+			return nil, fmt.Errorf("project '%s' not found", projectName)
+		}
+		projects = []*project.Project{proj}
+	} else {
+		var err error
+		if projects, err = mgr.List(); err != nil {
+			return nil, err
+		}
+	}
+	state, err := session.LoadState(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	me, ttl, now := cfg.ClaimantID(), cfg.ClaimExpiry(), time.Now()
+	var rows []claimRow
+	for _, proj := range projects {
+		claims, err := bead.ListClaims(proj.BeadsDir())
+		if err != nil {
+			logging.Warnf("%s: %v", proj.Name, err)
+			continue
+		}
+		for _, c := range claims {
+			if c.Claimant == "" {
+				continue
+			}
+			row := claimRow{
+				Project:  proj.Name,
+				Bead:     c.Bead,
+				Title:    c.Title,
+				Claimant: c.Claimant,
+				State:    claimState(c, me, ttl, now),
+				beadsDir: proj.BeadsDir(),
+			}
+			if !c.Since.IsZero() {
+				row.Since = c.Since.UTC().Format(time.RFC3339)
+			}
+			row.Session, _ = state.FindByBead(c.Bead)
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+func cmdClaims(cfg *config.Config, args []string) error {
+	sub := "list"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		sub, args = args[0], args[1:]
+	}
+
+	var projectName, target string
+	dryRun := false
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-p", "--project":
+			if i+1 < len(args) {
+				projectName = args[i+1]
+				i++
+			}
+		case "--dry-run":
+			dryRun = true
+		default:
+			if strings.HasPrefix(args[i], "-") || target != "" {
+				return fmt.Errorf("unknown argument: %s", args[i])
+			}
+			target = args[i]
+		}
+	}
+
+	switch sub {
+	case "list":
+		return claimsList(cfg, projectName)
+	case "release":
+		if target == "" {
+			return fmt.Errorf("usage: wt claims release <bead-id>")
+		}
+		return claimsRelease(cfg, target)
+	case "expire":
+		return claimsExpire(cfg, projectName, dryRun)
+	default:
+		return fmt.Errorf("unknown claims command: %s\nValid commands: list, release, expire", sub)
+	}
+}
+
+func claimsList(cfg *config.Config, projectName string) error {
+	rows, err := collectClaims(cfg, projectName)
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		printEmptyMessage("No claimed beads.", "Beads are claimed by 'wt new' and shown here while in progress.")
+		return nil
+	}
+	if outputJSON {
+		printJSON(rows)
+		return nil
+	}
+
+	fmt.Printf("This hub claims as: %s (claims expire after %s)\n\n", cfg.ClaimantID(), cfg.ClaimExpiry())
+	columns := []table.Column{
+		{Title: "Bead", Width: 16},
+		{Title: "Claimant", Width: 24},
+		{Title: "Age", Width: 9},
+		{Title: "State", Width: 7},
+		{Title: "Session", Width: 14},
+		{Title: "Title", Width: 30},
+	}
+	var tableRows []table.Row
+	for _, r := range rows {
+		age := "-"
+		if t, err := time.Parse(time.RFC3339, r.Since); err == nil {
+			age = formatIdle(int(time.Since(t).Minutes()))
+		}
+		tableRows = append(tableRows, table.Row{
+			r.Bead,
+			truncate(r.Claimant, 24),
+			age,
+			r.State,
+			r.Session,
+			truncate(r.Title, 30),
+		})
+	}
+	printTable("Claimed Beads", columns, tableRows)
+	return nil
+}
+
+// claimsRelease releases one bead's claim, whoever holds it
+func claimsRelease(cfg *config.Config, beadID string) error {
+	state, err := session.LoadState(cfg)
+	if err != nil {
+		return err
+	}
+	if name, _ := state.FindByBead(beadID); name != "" {
+		return fmt.Errorf("bead %s has a running session '%s'. Use 'wt kill %s' instead", beadID, name, name)
+	}
+
+	beadsDir := ""
+	if proj, _ := project.NewManager(cfg).FindByBeadPrefix(beadID); proj != nil {
+		beadsDir = proj.BeadsDir()
+	}
+	c, err := bead.GetClaim(beadID, beadsDir)
+	if err != nil {
+		return err
+	}
+	if !c.Held() {
+		return fmt.Errorf("bead %s is not claimed (status: %s)", beadID, c.Status)
+	}
+	if err := bead.ReleaseClaim(beadID, beadsDir); err != nil {
+		return err
+	}
+	fmt.Printf("Released %s (was claimed by %s)\n", beadID, c.Claimant)
+	return nil
+}
+
+// claimsExpire releases claims older than claim_ttl that no local session
+// is working on
+func claimsExpire(cfg *config.Config, projectName string, dryRun bool) error {
+	rows, err := collectClaims(cfg, projectName)
+	if err != nil {
+		return err
+	}
+	released := 0
+	for _, r := range rows {
+		if r.State != "stale" || r.Session != "" {
+			continue
+		}
+		if dryRun {
+			fmt.Printf("Would release %s (claimed by %s since %s)\n", r.Bead, r.Claimant, r.Since)
+			released++
+			continue
+		}
+		if err := bead.ReleaseClaim(r.Bead, r.beadsDir); err != nil {
+			logging.Warnf("%v", err)
+			continue
+		}
+		fmt.Printf("Released %s (claimed by %s since %s)\n", r.Bead, r.Claimant, r.Since)
+		released++
+	}
+	if released == 0 {
+		fmt.Printf("No claims older than %s.\n", cfg.ClaimExpiry())
+	}
+	return nil
+}
+
+// cmdClaimsHelp shows help for the claims command
+func cmdClaimsHelp() error {
+	help := `wt claims - Show and manage bead claims
+
+USAGE:
+    wt claims [list] [-p <project>]
+    wt claims release <bead-id>
+    wt claims expire [-p <project>] [--dry-run]
+
+DESCRIPTION:
+    'wt new' claims its bead before creating a session: the bead is set
+    to in_progress and assigned to this hub's claimant identity. Claims
+    are stored in beads, so every hub and machine sharing the project sees
+    them, and 'wt new' and 'wt clone --bead' refuse a bead claimed
+    elsewhere. 'wt kill' releases the claim; 'wt close' and 'wt done' close
+    the bead.
+
+    A claim older than claim_ttl (default 24h) is stale and is taken over
+    with a notice. --force on 'wt new' or 'wt clone' takes over any claim.
+
+    The identity is the claimant config key, or user@host with the profile
+    appended for non-default profiles.
+
+COMMANDS:
+    list                List in-progress beads with claimant, age and state
+                        (mine, active, or stale)
+    release <bead>      Put a claimed bead back to open, whoever holds it
+    expire              Release stale claims with no local session
+
+OPTIONS:
+    -p, --project <n>   Only this project
+    --dry-run           Show what expire would release
+    --json              Output as JSON
+    -h, --help          Show this help
+
+EXAMPLES:
+    wt claims                       Claims across all projects
+    wt claims release proj-abc      Free a bead a dead hub left claimed
+    wt claims expire --dry-run      Preview stale claims
+`
+	fmt.Print(help)
+	return nil
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/badri/wt/internal/bead"
+)
+
+func TestClaimState(t *testing.T) {
+	now := time.Now()
+	ttl := 24 * time.Hour
+	tests := []struct {
+		claim *bead.Claim
+		want  string
+	}{
+		{&bead.Claim{Status: "in_progress", Claimant: "me@box", Since: now.Add(-48 * time.Hour)}, "mine"},
+		{&bead.Claim{Status: "in_progress", Claimant: "you@other", Since: now.Add(-time.Hour)}, "active"},
+		{&bead.Claim{Status: "in_progress", Claimant: "you@other", Since: now.Add(-25 * time.Hour)}, "stale"},
+	}
+	for _, tt := range tests {
+		if got := claimState(tt.claim, "me@box", ttl, now); got != tt.want {
+			t.Errorf("claimState(%+v) = %q, want %q", tt.claim, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,381 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/badri/wt/internal/bead"
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/namepool"
+	"github.com/badri/wt/internal/project"
+	"github.com/badri/wt/internal/session"
+	"github.com/badri/wt/internal/worktree"
+)
+
+// cloneMaxCommits caps how many of the original session's commits go into the prompt
+const cloneMaxCommits = 20
+
+type cloneFlags struct {
+	bead        string
+	name        string
+	noSwitch    bool
+	forceSwitch bool
+	noTestEnv   bool
+	force       bool // take over a live claim on the bead
+	resume      bool // finish a clone an interrupted wt clone left half-created
+}
+
+func parseCloneFlags(args []string) (source string, flags cloneFlags) {
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--bead", "-b":
+			if i+1 < len(args) {
+				flags.bead = args[i+1]
+				i++
+			}
+		case "--name":
+			if i+1 < len(args) {
+				flags.name = args[i+1]
+				i++
+			}
+		case "--no-switch":
+			flags.noSwitch = true
+		case "--switch":
+			flags.forceSwitch = true
+		case "--no-test-env":
+			flags.noTestEnv = true
+		case "--force":
+			flags.force = true
+		case "--resume":
+			flags.resume = true
+		default:
+			if source == "" {
+				source = args[i]
+			}
+		}
+	}
+	return
+}
+
+// cmdClone starts a new session branched from an existing session's branch.
+// With --bead the clone works on that bead; otherwise it is a task session
+// for follow-up work on the original.
+func cmdClone(cfg *config.Config, args []string) error {
+	srcRef, flags := parseCloneFlags(args)
+	if srcRef == "" {
+		return fmt.Errorf("usage: wt clone <session> [--bead <new-bead>]")
+	}
+
+	state, err := session.LoadState(cfg)
+	if err != nil {
+		return err
+	}
+	srcName, src := findSessionByNameOrBead(state, srcRef)
+	if src == nil {
+		return fmt.Errorf("session '%s' not found", srcRef)
+	}
+	if src.Branch == "" {
+		return fmt.Errorf("session '%s' has no branch to clone", srcName)
+	}
+
+	mgr := project.NewManager(cfg)
+	proj, _ := mgr.Get(src.Project)
+	repoPath := strings.TrimSuffix(src.BeadsDir, "/.beads")
+	if proj != nil {
+		repoPath = proj.RepoPath()
+	}
+	beadsDir := src.BeadsDir
+
+	// An interrupted wt clone leaves a record of the steps it completed,
+	// kept under the new bead or, for a task clone, the original session
+	pendingKey := flags.bead
+	resumeCmd := fmt.Sprintf("wt clone %s --resume", srcRef)
+	if flags.bead != "" {
+		resumeCmd = fmt.Sprintf("wt clone %s --bead %s --resume", srcRef, flags.bead)
+	} else {
+		pendingKey = srcName + "-followup"
+	}
+	pending, err := session.LoadPending(cfg, pendingKey)
+	if err != nil {
+		return fmt.Errorf("reading progress of interrupted wt clone: %w", err)
+	}
+	if pending != nil && !flags.resume {
+		return fmt.Errorf("an earlier 'wt clone %s' was interrupted (session '%s'). Run '%s' to finish it", srcRef, pending.Name, resumeCmd)
+	}
+	if pending == nil && flags.resume {
+		logging.Infof("Nothing to resume for %s, creating a new session.", pendingKey)
+	}
+	resuming := pending != nil
+	if !resuming {
+		pending = session.NewPending(cfg, pendingKey)
+		pending.Bead = flags.bead
+	}
+
+	// Validate the new bead, if any
+	var beadInfo *bead.BeadInfoFull
+	if flags.bead != "" {
+		for name, sess := range state.Sessions {
+			if sess.Bead == flags.bead && !(resuming && name == pending.Name) {
+				return fmt.Errorf("session '%s' already exists for bead %s", name, flags.bead)
+			}
+		}
+		beadInfo, err = bead.ShowFullInDir(flags.bead, beadsDir)
+		if err != nil {
+			return fmt.Errorf("bead not found: %s", flags.bead)
+		}
+	}
+
+	// Allocate a session name from the project's themed pool; a resumed
+	// run keeps its name, branch and worktree
+	sessionName, themeName := pending.Name, pending.ThemeName
+	branch, worktreePath := pending.Branch, pending.Worktree
+	if !resuming {
+		var pool *namepool.Pool
+		if src.Project != "" {
+			pool, err = namepool.LoadForProject(src.Project)
+		} else {
+			pool, err = namepool.Load(cfg)
+		}
+		if err != nil {
+			return err
+		}
+		sessionName = flags.name
+		if sessionName == "" {
+			themeName, err = pool.Allocate(append(state.UsedNames(), pendingNames(cfg)...))
+			if err != nil {
+				return err
+			}
+			sessionName = themeName
+			if src.Project != "" {
+				sessionName = src.Project + "-" + themeName
+			}
+		}
+		if sessionName, err = claimSessionName(sessionName, flags.name != ""); err != nil {
+			return err
+		}
+
+		// Bead clones use the bead ID as branch; task clones get a follow-up branch
+		branch = flags.bead
+		worktreePath = cfg.ProjectWorktreePath(src.Project, flags.bead)
+		if flags.bead == "" {
+			branch = uniqueBranchName(repoPath, sanitizeBranchName(src.Branch+"-followup"))
+			worktreePath = cfg.ProjectWorktreePath(src.Project, sessionName)
+		}
+	}
+
+	// Summarize the original's work before it changes further
+	baseBranch := "main"
+	if proj != nil && proj.DefaultBranch != "" {
+		baseBranch = proj.DefaultBranch
+	}
+	if src.StackBranch != "" {
+		baseBranch = src.StackBranch
+	}
+	commits := branchCommits(repoPath, baseBranch, src.Branch)
+
+	// The clone is stacked on the original's unmerged branch
+	parentRef := src.Bead
+	if parentRef == "" {
+		parentRef = srcName
+	}
+	pending.Name, pending.ThemeName, pending.Project = sessionName, themeName, src.Project
+	pending.RepoPath, pending.Worktree, pending.Branch = repoPath, worktreePath, branch
+	pending.BaseBranch, pending.StackedOn = src.Branch, parentRef
+
+	sess := &session.Session{
+		Bead:        flags.bead,
+		Project:     src.Project,
+		BeadsDir:    beadsDir,
+		StackedOn:   parentRef,
+		StackBranch: src.Branch,
+	}
+	if flags.bead == "" {
+		sess.Type = session.SessionTypeTask
+		sess.TaskDescription = "Follow-up to " + srcName
+		sess.CompletionCondition = session.ConditionNone
+		sess.Epic = src.Epic // a follow-up belongs with the work it continues
+	} else {
+		sess.Epic = bead.EpicOf(flags.bead, repoPath)
+	}
+
+	return createSession(cfg, state, &newSession{
+		pending:  pending,
+		resuming: resuming,
+		proj:     proj,
+		sess:     sess,
+		createWorktree: func() ([]string, error) {
+			logging.Infof("Creating git worktree at %s from %s...", worktreePath, src.Branch)
+			if len(src.SparsePaths) > 0 {
+				if err := worktree.CreateSparse(repoPath, worktreePath, branch, src.Branch, src.SparsePaths, projectSparse(proj).Cone()); err != nil {
+					return nil, err
+				}
+			} else if err := worktree.CreateFromBranch(repoPath, worktreePath, branch, src.Branch); err != nil {
+				return nil, err
+			}
+			if copied, err := copyEnvFiles(src.Worktree, worktreePath); err != nil {
+				logging.Warnf("could not copy env files: %v", err)
+			} else if len(copied) > 0 {
+				logging.Infof("Copied %s from %s", strings.Join(copied, ", "), srcName)
+			}
+			return src.SparsePaths, nil
+		},
+		prompt: func(vars project.CommandVars) string {
+			srcTitle := src.TaskDescription
+			if src.IsBead() {
+				if info, err := bead.ShowInDir(src.Bead, src.BeadsDir); err == nil && info != nil {
+					srcTitle = info.Title
+				}
+			}
+			context := cloneContext(srcName, src, srcTitle, commits)
+			if beadInfo != nil {
+				return context + "\n" + buildInitialPrompt(flags.bead, beadInfo.Title, beadInfo.Description, sessionName, proj, vars) +
+					stackedPromptNote(parentRef, src.Branch)
+			}
+			return context + "\nThis is a follow-up task session. Review the context above, then wait for instructions " +
+				"from the hub before making changes.\n\n" + buildTaskPrompt(sess.TaskDescription, session.ConditionNone, sessionName, proj)
+		},
+		resumeCmd:   resumeCmd,
+		clonedFrom:  srcName,
+		force:       flags.force,
+		noTestEnv:   flags.noTestEnv,
+		noSwitch:    flags.noSwitch,
+		forceSwitch: flags.forceSwitch,
+	})
+}
+
+// cloneContext summarizes the original session's work for the clone's prompt
+func cloneContext(srcName string, src *session.Session, srcTitle string, commits []string) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("## Context: cloned from session %s\n", srcName))
+	if src.Bead != "" {
+		sb.WriteString(fmt.Sprintf("Original bead: %s", src.Bead))
+		if srcTitle != "" {
+			sb.WriteString(fmt.Sprintf(" - %s", srcTitle))
+		}
+		sb.WriteString("\n")
+	} else if srcTitle != "" {
+		sb.WriteString(fmt.Sprintf("Original task: %s\n", srcTitle))
+	}
+	sb.WriteString(fmt.Sprintf("Your branch starts from %s, which includes all of its commits.\n", src.Branch))
+	if src.StatusMessage != "" {
+		sb.WriteString(fmt.Sprintf("Original status: %s - %s\n", src.Status, src.StatusMessage))
+	}
+	if len(commits) > 0 {
+		sb.WriteString("\nCommits on the original branch:\n")
+		for _, c := range commits {
+			sb.WriteString("- " + c + "\n")
+		}
+	}
+	sb.WriteString("\nThe original session may still change while it awaits review; do not modify its branch.\n")
+	return sb.String()
+}
+
+// branchCommits lists the one-line commits on branch that are not on base, newest first
+func branchCommits(repoPath, base, branch string) []string {
+	cmd := logging.Command("git", "-C", repoPath, "log", "--oneline", fmt.Sprintf("-%d", cloneMaxCommits), base+".."+branch)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil
+	}
+	var commits []string
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if line != "" {
+			commits = append(commits, line)
+		}
+	}
+	return commits
+}
+
+// uniqueBranchName returns name, or name with a numeric suffix if the branch exists
+func uniqueBranchName(repoPath, name string) string {
+	candidate := name
+	for i := 2; worktree.BranchExists(repoPath, candidate); i++ {
+		candidate = fmt.Sprintf("%s-%d", name, i)
+	}
+	return candidate
+}
+
+// copyEnvFiles copies top-level .env files that are not in git (and so were
+// not checked out) from one worktree to another. Returns the copied names.
+func copyEnvFiles(srcDir, dstDir string) ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(srcDir, ".env*"))
+	if err != nil {
+		return nil, err
+	}
+	var copied []string
+	for _, srcPath := range matches {
+		info, err := os.Stat(srcPath)
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		dstPath := filepath.Join(dstDir, filepath.Base(srcPath))
+		if _, err := os.Stat(dstPath); err == nil {
+			continue // tracked file, already checked out
+		}
+		if err := copyFile(srcPath, dstPath, info.Mode().Perm()); err != nil {
+			return copied, err
+		}
+		copied = append(copied, filepath.Base(srcPath))
+	}
+	return copied, nil
+}
+
+func copyFile(src, dst string, perm os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// cmdCloneHelp shows help for the clone command
+func cmdCloneHelp() error {
+	help := `wt clone - Start a new session from an existing session's branch
+
+USAGE:
+    wt clone <session> [options]
+
+DESCRIPTION:
+    Creates a new worktree branched from the session's branch, copies its
+    untracked .env files and port configuration (with a new port offset),
+    and starts a fresh Claude with a summary of the original session's work.
+    Useful for follow-up work while the original awaits review.
+
+    With --bead, the clone works on that bead (branch named after it) and
+    is stacked on the original, so 'wt done' targets the original's branch
+    until it merges. Without --bead, the clone is a task session that waits
+    for instructions.
+
+ARGUMENTS:
+    <session>           Session name or bead ID to clone
+
+OPTIONS:
+    -b, --bead <id>     Bead for the new session
+    --name <name>       Session name (default: next name from the theme)
+    --no-switch         Don't switch to the new session
+    --switch            Switch even when running from the hub
+    --no-test-env       Skip test environment setup
+    --force             Take over another hub's live claim on the bead
+    --resume            Finish a clone an earlier 'wt clone' left half-created
+    -h, --help          Show this help
+
+EXAMPLES:
+    wt clone toast --bead proj-def    Start proj-def on top of toast's work
+    wt clone proj-abc                 Follow-up task session from proj-abc's session
+`
+	fmt.Print(help)
+	return nil
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/badri/wt/internal/session"
+)
+
+func TestParseCloneFlags(t *testing.T) {
+	source, flags := parseCloneFlags([]string{"toast", "--bead", "proj-def", "--name", "proj-rye", "--no-switch", "--no-test-env"})
+	if source != "toast" {
+		t.Errorf("source = %q, want toast", source)
+	}
+	want := cloneFlags{bead: "proj-def", name: "proj-rye", noSwitch: true, noTestEnv: true}
+	if flags != want {
+		t.Errorf("flags = %+v, want %+v", flags, want)
+	}
+
+	source, flags = parseCloneFlags([]string{"-b", "proj-def", "proj-abc", "--switch"})
+	if source != "proj-abc" || flags.bead != "proj-def" || !flags.forceSwitch {
+		t.Errorf("parseCloneFlags() = %q, %+v", source, flags)
+	}
+
+	if _, flags = parseCloneFlags([]string{"toast", "--resume", "--force"}); !flags.resume || !flags.force {
+		t.Errorf("parseCloneFlags(--resume --force) = %+v", flags)
+	}
+}
+
+func TestCloneContext(t *testing.T) {
+	src := &session.Session{
+		Bead:          "proj-abc",
+		Branch:        "proj-abc",
+		Status:        "ready",
+		StatusMessage: "PR: https://github.com/o/r/pull/3",
+	}
+	got := cloneContext("toast", src, "Add login", []string{"abc1234 Add login form", "def5678 Add session store"})
+
+	for _, want := range []string{
+		"cloned from session toast",
+		"Original bead: proj-abc - Add login",
+		"starts from proj-abc",
+		"Original status: ready - PR: https://github.com/o/r/pull/3",
+		"- abc1234 Add login form\n",
+		"- def5678 Add session store\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("cloneContext() missing %q in:\n%s", want, got)
+		}
+	}
+
+	task := &session.Session{Branch: "task/fix-flaky-test", Type: session.SessionTypeTask}
+	got = cloneContext("task-rye", task, "Fix flaky test", nil)
+	if !strings.Contains(got, "Original task: Fix flaky test") {
+		t.Errorf("task cloneContext() missing original task:\n%s", got)
+	}
+	if strings.Contains(got, "Commits on the original branch") {
+		t.Errorf("cloneContext() without commits should omit the commit list:\n%s", got)
+	}
+}
+
+func TestCopyEnvFiles(t *testing.T) {
+	src := t.TempDir()
+	dst := t.TempDir()
+	files := map[string]string{
+		".env":         "SECRET=1",
+		".env.local":   "LOCAL=1",
+		".envrc":       "use flake",
+		"README.md":    "not copied",
+		".env.example": "tracked",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(src, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Tracked files are already checked out in the new worktree and are left alone
+	if err := os.WriteFile(filepath.Join(dst, ".env.example"), []byte("checked out"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	copied, err := copyEnvFiles(src, dst)
+	if err != nil {
+		t.Fatalf("copyEnvFiles() error: %v", err)
+	}
+	slices.Sort(copied)
+	if want := []string{".env", ".env.local", ".envrc"}; !slices.Equal(copied, want) {
+		t.Errorf("copyEnvFiles() = %v, want %v", copied, want)
+	}
+
+	data, _ := os.ReadFile(filepath.Join(dst, ".env"))
+	if string(data) != "SECRET=1" {
+		t.Errorf(".env content = %q", data)
+	}
+	info, _ := os.Stat(filepath.Join(dst, ".env"))
+	if info.Mode().Perm() != 0600 {
+		t.Errorf(".env mode = %v, want 0600", info.Mode().Perm())
+	}
+	data, _ = os.ReadFile(filepath.Join(dst, ".env.example"))
+	if string(data) != "checked out" {
+		t.Errorf(".env.example was overwritten: %q", data)
+	}
+	if _, err := os.Stat(filepath.Join(dst, "README.md")); err == nil {
+		t.Error("README.md should not be copied")
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/badri/wt/internal/bead"
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/events"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/relay"
+	"github.com/badri/wt/internal/session"
+	"github.com/badri/wt/internal/tmux"
+)
+
+// commentPollInterval is how often wt watch reads a session's bead comments;
+// each read runs bd, so it is slower than the refresh
+const commentPollInterval = 30 * time.Second
+
+// commentRelay passes comments added to a session's bead while it runs on to
+// the worker as a "New bead comment:" nudge, so instructions left with
+// 'bd comments add' reach it without a manual wt nudge. Each delivery is
+// logged as a bead_comment event.
+type commentRelay struct {
+	cfg    *config.Config
+	logger *events.Logger
+
+	mu     sync.Mutex
+	polled map[string]time.Time // session -> last comment read
+}
+
+func newCommentRelay(cfg *config.Config) *commentRelay {
+	return &commentRelay{
+		cfg:    cfg,
+		logger: events.NewLogger(cfg),
+		polled: make(map[string]time.Time),
+	}
+}
+
+// check delivers the session's new bead comments, at most once per
+// commentPollInterval
+func (r *commentRelay) check(name string, sess *session.Session) {
+	if sess.IsTask() || sess.Bead == "" || !sessionAgent(sess).AcceptsPrompts || !tmux.SessionExists(name) {
+		return
+	}
+	started, err := time.Parse(time.RFC3339, sess.CreatedAt)
+	if err != nil {
+		return
+	}
+
+	r.mu.Lock()
+	if time.Since(r.polled[name]) < commentPollInterval {
+		r.mu.Unlock()
+		return
+	}
+	r.polled[name] = time.Now()
+	r.mu.Unlock()
+
+	comments, err := bead.CommentsInDir(sess.Bead, sess.BeadsDir)
+	if err != nil || len(comments) == 0 {
+		return
+	}
+	store, err := relay.Load(r.cfg)
+	if err != nil {
+		logging.Warnf("loading bead comment relay: %v", err)
+		return
+	}
+	pending := relay.Pending(comments, started, store.Delivered(name, sess.Bead))
+	if len(pending) == 0 {
+		return
+	}
+
+	for _, c := range pending {
+		if err := sessionAgent(sess).SendPrompt(name, relay.Prompt(c)); err != nil {
+			logging.Warnf("relaying comment on %s to %s: %v", sess.Bead, name, err)
+			break
+		}
+		store.MarkDelivered(name, sess.Bead, c.Key())
+		r.logger.LogBeadComment(name, sess.Bead, sess.Project, commentNote(c))
+	}
+	if err := store.Save(); err != nil {
+		logging.Warnf("saving bead comment relay: %v", err)
+	}
+}
+
+// prune forgets delivered comments of sessions that have ended
+func (r *commentRelay) prune(state *session.State) {
+	store, err := relay.Load(r.cfg)
+	if err != nil || len(store.Entries) == 0 {
+		return
+	}
+	if store.Prune(func(name string) bool { return state.Sessions[name] != nil }) {
+		if err := store.Save(); err != nil {
+			logging.Warnf("saving bead comment relay: %v", err)
+		}
+	}
+}
+
+// commentNote is how a relayed comment shows in wt events
+func commentNote(c bead.Comment) string {
+	if c.Author == "" {
+		return c.Text
+	}
+	return c.Author + ": " + c.Text
+}
@@ -0,0 +1,443 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/badri/wt/internal/bead"
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/project"
+	"github.com/badri/wt/internal/session"
+)
+
+// cmdComplete is the hidden plumbing behind the shell completions:
+// wt __complete sessions|beads [project]|projects prints one value per line.
+// Errors print nothing, so a broken setup never garbles the prompt.
+func cmdComplete(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return nil
+	}
+	var values []string
+	switch args[0] {
+	case "sessions":
+		values = completeSessions(cfg)
+	case "beads":
+		projectName := ""
+		if len(args) > 1 {
+			projectName = args[1]
+		}
+		values = completeBeads(cfg, projectName)
+	case "projects":
+		values = completeProjects(cfg)
+	}
+	for _, v := range values {
+		fmt.Println(v)
+	}
+	return nil
+}
+
+// completeSessions returns the names of active sessions, sorted
+func completeSessions(cfg *config.Config) []string {
+	state, err := session.LoadState(cfg)
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(state.Sessions))
+	for name := range state.Sessions {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// completeBeads returns the IDs of ready beads of one project, or of all
+// registered projects when projectName is empty
+func completeBeads(cfg *config.Config, projectName string) []string {
+	mgr := project.NewManager(cfg)
+	var projects []*project.Project
+	if projectName != "" {
+		proj, err := mgr.Get(projectName)
+		if err != nil {
+			return nil
+		}
+		projects = []*project.Project{proj}
+	} else {
+		projects, _ = mgr.List()
+	}
+
+	var ready []bead.ReadyBead
+	if len(projects) == 0 {
+		ready, _ = bead.Ready()
+	}
+	for _, proj := range projects {
+		beads, err := bead.ReadyInDir(proj.RepoPath() + "/.beads")
+		if err != nil {
+			continue
+		}
+		ready = append(ready, beads...)
+	}
+
+	ids := make([]string, 0, len(ready))
+	for _, b := range ready {
+		ids = append(ids, b.ID)
+	}
+	return ids
+}
+
+// completeProjects returns the names of registered projects
+func completeProjects(cfg *config.Config) []string {
+	projects, err := project.NewManager(cfg).List()
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(projects))
+	for _, proj := range projects {
+		names = append(names, proj.Name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// cmdCompletion generates shell completion scripts
+func cmdCompletion(shell string) error {
+	switch shell {
+	case "bash":
+		fmt.Print(bashCompletion)
+		return nil
+	case "zsh":
+		fmt.Print(zshCompletion)
+		return nil
+	case "fish":
+		fmt.Print(fishCompletion)
+		return nil
+	default:
+		return fmt.Errorf("unsupported shell: %s\nSupported: bash, zsh, fish", shell)
+	}
+}
+
+const bashCompletion = `# wt bash completion
+# Add to ~/.bashrc: eval "$(wt completion bash)"
+
+_wt_completions() {
+    local cur prev commands
+    COMPREPLY=()
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    prev="${COMP_WORDS[COMP_CWORD-1]}"
+
+    commands="list new kill close done status abandon watch seance projects ready create beads project auto events doctor config pick back keys completion version help hub handoff prime signal ack clone shutdown resume-all teardown-all setup-all note rollback import depend nudge block unblock pr open code pause resume retarget claims verify init split show history sparse migrate uninstall"
+
+    case "${prev}" in
+        wt)
+            COMPREPLY=( $(compgen -W "${commands}" -- "${cur}") )
+            return 0
+            ;;
+        new|history)
+            COMPREPLY=( $(compgen -W "$(wt __complete beads 2>/dev/null)" -- "${cur}") )
+            return 0
+            ;;
+        show)
+            if [[ "${COMP_WORDS[1]}" == "show" ]]; then
+                COMPREPLY=( $(compgen -W "$(wt __complete beads 2>/dev/null)" -- "${cur}") )
+            fi
+            return 0
+            ;;
+        kill|close|status|nudge|ack|clone|depend|unblock|open|code|pause|resume|retarget|verify)
+            COMPREPLY=( $(compgen -W "$(wt __complete sessions 2>/dev/null)" -- "${cur}") )
+            return 0
+            ;;
+        ready|beads|--project)
+            COMPREPLY=( $(compgen -W "$(wt __complete projects 2>/dev/null)" -- "${cur}") )
+            return 0
+            ;;
+        project)
+            COMPREPLY=( $(compgen -W "add config set remove warm guidelines" -- "${cur}") )
+            return 0
+            ;;
+        guidelines)
+            COMPREPLY=( $(compgen -W "edit show path" -- "${cur}") )
+            return 0
+            ;;
+        remove|warm|set)
+            if [[ "${COMP_WORDS[1]}" == "project" ]]; then
+                COMPREPLY=( $(compgen -W "$(wt __complete projects 2>/dev/null)" -- "${cur}") )
+            fi
+            return 0
+            ;;
+        config)
+            if [[ "${COMP_WORDS[1]}" == "project" ]]; then
+                COMPREPLY=( $(compgen -W "$(wt __complete projects 2>/dev/null)" -- "${cur}") )
+            else
+                COMPREPLY=( $(compgen -W "show init set edit" -- "${cur}") )
+            fi
+            return 0
+            ;;
+        pr)
+            COMPREPLY=( $(compgen -W "draft ready sync" -- "${cur}") )
+            return 0
+            ;;
+        events)
+            COMPREPLY=( $(compgen -W "emit compact summary" -- "${cur}") )
+            return 0
+            ;;
+        sparse)
+            COMPREPLY=( $(compgen -W "set add off $(wt __complete sessions 2>/dev/null)" -- "${cur}") )
+            return 0
+            ;;
+        auto)
+            COMPREPLY=( $(compgen -W "state queue inbox approve" -- "${cur}") )
+            return 0
+            ;;
+        hub)
+            COMPREPLY=( $(compgen -W "note log" -- "${cur}") )
+            return 0
+            ;;
+        claims)
+            COMPREPLY=( $(compgen -W "list release expire" -- "${cur}") )
+            return 0
+            ;;
+        signal)
+            COMPREPLY=( $(compgen -W "ready blocked error working idle" -- "${cur}") )
+            return 0
+            ;;
+        completion)
+            COMPREPLY=( $(compgen -W "bash zsh fish" -- "${cur}") )
+            return 0
+            ;;
+        *)
+            COMPREPLY=( $(compgen -W "${commands}" -- "${cur}") )
+            return 0
+            ;;
+    esac
+}
+
+complete -F _wt_completions wt
+`
+
+const zshCompletion = `#compdef wt
+# wt zsh completion
+# Add to ~/.zshrc: eval "$(wt completion zsh)"
+
+_wt() {
+    local -a commands
+
+    commands=(
+        'list:List active sessions'
+        'new:Create new session for a bead'
+        'kill:Kill a session (keep bead open)'
+        'close:Close session and bead'
+        'done:Complete work and merge'
+        'status:Show current session status'
+        'abandon:Abandon session without merging'
+        'watch:Live dashboard of sessions'
+        'seance:Talk to past sessions'
+        'projects:List registered projects'
+        'ready:Show ready beads'
+        'create:Create a new bead'
+        'beads:List beads for a project'
+        'project:Manage projects'
+        'auto:Autonomous batch processing'
+        'events:Show wt events'
+        'doctor:Check wt setup'
+        'config:Configuration management'
+        'pick:Interactive session picker'
+        'back:Return to the previous session'
+        'keys:Output tmux keybindings'
+        'completion:Generate shell completions'
+        'version:Show version information'
+        'help:Show help'
+        'hub:Hub session management'
+        'handoff:Hand off to fresh Claude'
+        'prime:Inject context on startup'
+        'signal:Update session status'
+        'ack:Acknowledge a worker signal'
+        'clone:Clone a session onto a new branch'
+        'shutdown:Save and stop all sessions'
+        'resume-all:Restore sessions saved by shutdown'
+        'teardown-all:Stop all test envs, keeping sessions running'
+        'setup-all:Bring back test envs stopped by teardown-all'
+        'note:Annotate a session in the event log'
+        'rollback:Revert a direct merge and reopen its bead'
+        'import:Create beads from GitHub or Jira issues'
+        'depend:Merge a session after another'
+        'nudge:Send a canned prompt to a worker'
+        'block:Mark the current session blocked'
+        'unblock:Resume a blocked session'
+        'split:Break the current bead into child beads'
+        'show:Show a bead from any project'
+        'history:Show what wt did with a bead'
+        'sparse:Show or widen a sparse worktree checkout'
+        'pr:Open draft PRs and mark them ready'
+        'open:Open a session worktree in an editor'
+        'code:Open a session worktree in VS Code'
+        'pause:Stop a session and keep it for later'
+        'resume:Resume a paused session'
+        'retarget:Move a session onto another branch project'
+        'claims:Show and manage bead claims'
+        'verify:Check the default branch after a merge'
+        'init:Set up wt on a new machine'
+        'migrate:Upgrade on-disk state after a version change'
+        'uninstall:Tear down all sessions and delete wt state'
+    )
+
+    _arguments -C \
+        '1: :->command' \
+        '*: :->args'
+
+    case $state in
+        command)
+            _describe 'command' commands
+            ;;
+        args)
+            case $words[2] in
+                new|show|history)
+                    _values 'bead' ${(f)"$(wt __complete beads 2>/dev/null)"}
+                    ;;
+                retarget)
+                    if (( CURRENT == 3 )); then
+                        _values 'session' ${(f)"$(wt __complete sessions 2>/dev/null)"}
+                    else
+                        _values 'project' ${(f)"$(wt __complete projects 2>/dev/null)"}
+                    fi
+                    ;;
+                kill|close|status|nudge|ack|clone|depend|unblock|open|code|pause|resume|verify)
+                    _values 'session' ${(f)"$(wt __complete sessions 2>/dev/null)"}
+                    ;;
+                ready|beads)
+                    _values 'project' ${(f)"$(wt __complete projects 2>/dev/null)"}
+                    ;;
+                project)
+                    if (( CURRENT == 3 )); then
+                        _describe 'subcommand' '(add config set remove warm guidelines)'
+                    elif [[ $words[3] == (config|set|remove|warm) ]] && (( CURRENT == 4 )); then
+                        _values 'project' ${(f)"$(wt __complete projects 2>/dev/null)"}
+                    elif [[ $words[3] == guidelines ]] && (( CURRENT == 4 )); then
+                        _describe 'guidelines command' '(edit show path)'
+                    elif [[ $words[3] == guidelines ]] && (( CURRENT == 5 )); then
+                        _values 'project' ${(f)"$(wt __complete projects 2>/dev/null)"}
+                    fi
+                    ;;
+                config)
+                    _describe 'subcommand' '(show init set edit)'
+                    ;;
+                pr)
+                    _describe 'subcommand' '(draft ready sync)'
+                    ;;
+                events)
+                    _describe 'subcommand' '(emit compact summary)'
+                    ;;
+                sparse)
+                    if (( CURRENT == 3 )); then
+                        _describe 'subcommand' '(set add off)'
+                    fi
+                    _values 'session' ${(f)"$(wt __complete sessions 2>/dev/null)"}
+                    ;;
+                signal)
+                    _describe 'status' '(ready blocked error working idle)'
+                    ;;
+                completion)
+                    _describe 'shell' '(bash zsh fish)'
+                    ;;
+            esac
+            ;;
+    esac
+}
+
+_wt "$@"
+`
+
+const fishCompletion = `# wt fish completion
+# Add to ~/.config/fish/completions/wt.fish
+
+# Disable file completion by default
+complete -c wt -f
+
+# Commands
+complete -c wt -n __fish_use_subcommand -a list -d 'List active sessions'
+complete -c wt -n __fish_use_subcommand -a new -d 'Create new session for a bead'
+complete -c wt -n __fish_use_subcommand -a kill -d 'Kill a session (keep bead open)'
+complete -c wt -n __fish_use_subcommand -a close -d 'Close session and bead'
+complete -c wt -n __fish_use_subcommand -a done -d 'Complete work and merge'
+complete -c wt -n __fish_use_subcommand -a status -d 'Show current session status'
+complete -c wt -n __fish_use_subcommand -a abandon -d 'Abandon session without merging'
+complete -c wt -n __fish_use_subcommand -a watch -d 'Live dashboard of sessions'
+complete -c wt -n __fish_use_subcommand -a seance -d 'Talk to past sessions'
+complete -c wt -n __fish_use_subcommand -a projects -d 'List registered projects'
+complete -c wt -n __fish_use_subcommand -a ready -d 'Show ready beads'
+complete -c wt -n __fish_use_subcommand -a create -d 'Create a new bead'
+complete -c wt -n __fish_use_subcommand -a beads -d 'List beads for a project'
+complete -c wt -n __fish_use_subcommand -a project -d 'Manage projects'
+complete -c wt -n __fish_use_subcommand -a auto -d 'Autonomous batch processing'
+complete -c wt -n __fish_use_subcommand -a events -d 'Show wt events'
+complete -c wt -n __fish_use_subcommand -a doctor -d 'Check wt setup'
+complete -c wt -n __fish_use_subcommand -a config -d 'Configuration management'
+complete -c wt -n __fish_use_subcommand -a pick -d 'Interactive session picker'
+complete -c wt -n __fish_use_subcommand -a back -d 'Return to the previous session'
+complete -c wt -n __fish_use_subcommand -a keys -d 'Output tmux keybindings'
+complete -c wt -n __fish_use_subcommand -a completion -d 'Generate shell completions'
+complete -c wt -n __fish_use_subcommand -a version -d 'Show version information'
+complete -c wt -n __fish_use_subcommand -a help -d 'Show help'
+complete -c wt -n __fish_use_subcommand -a hub -d 'Hub session management'
+complete -c wt -n __fish_use_subcommand -a handoff -d 'Hand off to fresh Claude'
+complete -c wt -n __fish_use_subcommand -a prime -d 'Inject context on startup'
+complete -c wt -n __fish_use_subcommand -a signal -d 'Update session status'
+complete -c wt -n __fish_use_subcommand -a ack -d 'Acknowledge a worker signal'
+complete -c wt -n __fish_use_subcommand -a clone -d 'Clone a session onto a new branch'
+complete -c wt -n __fish_use_subcommand -a shutdown -d 'Save and stop all sessions'
+complete -c wt -n __fish_use_subcommand -a resume-all -d 'Restore sessions saved by shutdown'
+complete -c wt -n __fish_use_subcommand -a teardown-all -d 'Stop all test envs, keeping sessions running'
+complete -c wt -n __fish_use_subcommand -a setup-all -d 'Bring back test envs stopped by teardown-all'
+complete -c wt -n __fish_use_subcommand -a note -d 'Annotate a session in the event log'
+complete -c wt -n __fish_use_subcommand -a rollback -d 'Revert a direct merge and reopen its bead'
+complete -c wt -n __fish_use_subcommand -a import -d 'Create beads from GitHub or Jira issues'
+complete -c wt -n __fish_use_subcommand -a depend -d 'Merge a session after another'
+complete -c wt -n __fish_use_subcommand -a nudge -d 'Send a canned prompt to a worker'
+complete -c wt -n __fish_use_subcommand -a block -d 'Mark the current session blocked'
+complete -c wt -n __fish_use_subcommand -a unblock -d 'Resume a blocked session'
+complete -c wt -n __fish_use_subcommand -a split -d 'Break the current bead into child beads'
+complete -c wt -n __fish_use_subcommand -a show -d 'Show a bead from any project'
+complete -c wt -n __fish_use_subcommand -a history -d 'Show what wt did with a bead'
+complete -c wt -n __fish_use_subcommand -a sparse -d 'Show or widen a sparse worktree checkout'
+complete -c wt -n __fish_use_subcommand -a pr -d 'Open draft PRs and mark them ready'
+complete -c wt -n __fish_use_subcommand -a open -d 'Open a session worktree in an editor'
+complete -c wt -n __fish_use_subcommand -a code -d 'Open a session worktree in VS Code'
+complete -c wt -n __fish_use_subcommand -a pause -d 'Stop a session and keep it for later'
+complete -c wt -n __fish_use_subcommand -a resume -d 'Resume a paused session'
+complete -c wt -n __fish_use_subcommand -a retarget -d 'Move a session onto another branch project'
+complete -c wt -n __fish_use_subcommand -a claims -d 'Show and manage bead claims'
+complete -c wt -n __fish_use_subcommand -a verify -d 'Check the default branch after a merge'
+complete -c wt -n __fish_use_subcommand -a init -d 'Set up wt on a new machine'
+complete -c wt -n __fish_use_subcommand -a migrate -d 'Upgrade on-disk state after a version change'
+complete -c wt -n __fish_use_subcommand -a uninstall -d 'Tear down all sessions and delete wt state'
+
+# Dynamic values
+complete -c wt -n '__fish_seen_subcommand_from new show history' -a '(wt __complete beads 2>/dev/null)' -d 'Bead'
+complete -c wt -n '__fish_seen_subcommand_from kill close status nudge ack clone depend unblock open code pause resume retarget verify' -a '(wt __complete sessions 2>/dev/null)' -d 'Session'
+complete -c wt -n '__fish_seen_subcommand_from ready beads' -a '(wt __complete projects 2>/dev/null)' -d 'Project'
+
+# Completions for 'project' subcommand
+complete -c wt -n '__fish_seen_subcommand_from project; and not __fish_seen_subcommand_from add config set remove warm guidelines' -a 'add config set remove warm guidelines' -d 'Project subcommand'
+complete -c wt -n '__fish_seen_subcommand_from project; and __fish_seen_subcommand_from config set remove warm' -a '(wt __complete projects 2>/dev/null)' -d 'Project'
+complete -c wt -n '__fish_seen_subcommand_from guidelines; and not __fish_seen_subcommand_from edit show path' -a 'edit show path' -d 'Guidelines command'
+complete -c wt -n '__fish_seen_subcommand_from guidelines; and __fish_seen_subcommand_from edit show path' -a '(wt __complete projects 2>/dev/null)' -d 'Project'
+
+# Completions for 'config' subcommand
+complete -c wt -n '__fish_seen_subcommand_from config; and not __fish_seen_subcommand_from project' -a 'show init set edit' -d 'Config subcommand'
+
+# Completions for 'pr' subcommand
+complete -c wt -n '__fish_seen_subcommand_from pr' -a 'draft ready sync' -d 'PR subcommand'
+
+# Completions for 'events' subcommand
+complete -c wt -n '__fish_seen_subcommand_from events' -a 'emit' -d 'Emit a custom event'
+complete -c wt -n '__fish_seen_subcommand_from events' -a 'compact' -d 'Rotate and prune the event log'
+complete -c wt -n '__fish_seen_subcommand_from events' -a 'summary' -d 'Summary of the last day, optionally emailed'
+
+# Completions for 'sparse' subcommand
+complete -c wt -n '__fish_seen_subcommand_from sparse; and not __fish_seen_subcommand_from set add off' -a 'set add off' -d 'Sparse subcommand'
+complete -c wt -n '__fish_seen_subcommand_from sparse' -a '(wt __complete sessions 2>/dev/null)' -d 'Session'
+
+# Completions for 'signal' subcommand
+complete -c wt -n '__fish_seen_subcommand_from signal' -a 'ready blocked error working idle' -d 'Status'
+
+# Completions for 'completion' - shell types
+complete -c wt -n '__fish_seen_subcommand_from completion' -a 'bash zsh fish' -d 'Shell'
+`
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/project"
+	"github.com/badri/wt/internal/session"
+)
+
+func TestCompleteValues(t *testing.T) {
+	cfg, err := config.LoadFromDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := completeSessions(cfg); len(got) != 0 {
+		t.Errorf("completeSessions() = %v, want none", got)
+	}
+
+	state, err := session.LoadState(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state.Sessions["toast"] = &session.Session{Bead: "proj-abc"}
+	state.Sessions["shadow"] = &session.Session{Bead: "proj-def"}
+	if err := state.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := completeSessions(cfg), []string{"shadow", "toast"}; !slices.Equal(got, want) {
+		t.Errorf("completeSessions() = %v, want %v", got, want)
+	}
+
+	mgr := project.NewManager(cfg)
+	for _, name := range []string{"web", "api"} {
+		if err := mgr.Save(&project.Project{Name: name, Repo: t.TempDir()}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, want := completeProjects(cfg), []string{"api", "web"}; !slices.Equal(got, want) {
+		t.Errorf("completeProjects() = %v, want %v", got, want)
+	}
+	if got := completeBeads(cfg, "missing"); len(got) != 0 {
+		t.Errorf("completeBeads() for an unknown project = %v, want none", got)
+	}
+}
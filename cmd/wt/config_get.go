@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/badri/wt/internal/config"
+)
+
+// configKeys are the keys 'wt config get' and 'wt config set' accept,
+// besides notify.<event>
+var configKeys = []string{
+	"worktree_root", "editor_cmd", "default_merge_mode", "idle_detection",
+	"tmux_status", "open_app", "claimant", "claim_ttl", "notify_digest",
+	"context_handoff", "context_window", "events_max_age", "events_max_size",
+}
+
+// getConfig prints the effective value of a key, environment overrides and
+// defaults included, with nothing else on the line so scripts can use it
+func getConfig(cfg *config.Config, key string) error {
+	value, err := configValue(cfg, key)
+	if err != nil {
+		return err
+	}
+	fmt.Println(value)
+	return nil
+}
+
+func configValue(cfg *config.Config, key string) (string, error) {
+	switch key {
+	case "worktree_root":
+		return cfg.WorktreeRoot, nil
+	case "editor_cmd":
+		return cfg.EditorCmd, nil
+	case "default_merge_mode":
+		return cfg.DefaultMergeMode, nil
+	case "idle_detection":
+		if cfg.UseTranscriptActivity() {
+			return "transcript", nil
+		}
+		return "tmux", nil
+	case "tmux_status":
+		return strconv.FormatBool(cfg.TmuxStatus), nil
+	case "open_app":
+		return cfg.OpenAppCmd(), nil
+	case "claimant":
+		return cfg.ClaimantID(), nil
+	case "claim_ttl":
+		return cfg.ClaimExpiry().String(), nil
+	case "notify_digest":
+		if window := cfg.DigestWindow(); window > 0 {
+			return window.String(), nil
+		}
+		return "off", nil
+	case "context_handoff":
+		if cfg.ContextHandoff > 0 {
+			return strconv.Itoa(cfg.ContextHandoff), nil
+		}
+		return "off", nil
+	case "context_window":
+		return strconv.FormatInt(cfg.ContextWindowTokens(), 10), nil
+	case "events_max_age":
+		if cfg.EventsMaxAge() > 0 {
+			return cfg.Events.MaxAge, nil
+		}
+		return "off", nil
+	case "events_max_size":
+		if n := cfg.EventsMaxSize(); n > 0 {
+			return strconv.FormatInt(n, 10), nil
+		}
+		return "off", nil
+	}
+	if eventType, ok := strings.CutPrefix(key, "notify."); ok && slices.Contains(config.NotifyEvents, eventType) {
+		return cfg.NotifyMode(eventType), nil
+	}
+	return "", unknownConfigKey(key)
+}
+
+func unknownConfigKey(key string) error {
+	return fmt.Errorf("unknown config key: %s%s\nValid keys: %s, notify.<event>", key, didYouMean(key, configKeys), strings.Join(configKeys, ", "))
+}
+
+// configSource annotates a value shown by 'wt config show' with where it
+// comes from: the config file, a WT_* variable or the default
+func configSource(cfg *config.Config, key string) string {
+	if src := cfg.Source(key); src != config.SourceEnv {
+		return "(" + src + ")"
+	}
+	return "(env " + config.EnvVar(key) + ")"
+}
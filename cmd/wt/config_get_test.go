@@ -0,0 +1,56 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/badri/wt/internal/config"
+)
+
+func TestConfigValue(t *testing.T) {
+	cfg, err := config.LoadFromDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.ContextHandoff = 80
+	tests := map[string]string{
+		"worktree_root":   "~/worktrees",
+		"idle_detection":  "tmux",
+		"tmux_status":     "false",
+		"open_app":        "code",
+		"claim_ttl":       "24h0m0s",
+		"notify_digest":   "off",
+		"notify.error":    "immediate",
+		"context_handoff": "80",
+		"context_window":  "200000",
+		"events_max_age":  "off",
+		"events_max_size": "10485760",
+	}
+	for key, want := range tests {
+		got, err := configValue(cfg, key)
+		if err != nil || got != want {
+			t.Errorf("configValue(%s) = %q, %v; want %q", key, got, err, want)
+		}
+	}
+	for _, key := range []string{"worktree", "notify.nope", "notify."} {
+		if _, err := configValue(cfg, key); err == nil {
+			t.Errorf("configValue(%s): expected an error", key)
+		}
+	}
+}
+
+func TestConfigValueEnv(t *testing.T) {
+	t.Setenv(config.EditorCmdEnv, "claude")
+	cfg, err := config.LoadFromDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := configValue(cfg, "editor_cmd"); got != "claude" {
+		t.Errorf("editor_cmd = %q, want the WT_EDITOR_CMD value", got)
+	}
+	if got := configSource(cfg, "editor_cmd"); got != "(env WT_EDITOR_CMD)" {
+		t.Errorf("configSource = %q", got)
+	}
+	if got := configSource(cfg, "worktree_root"); got != "(default)" {
+		t.Errorf("configSource = %q", got)
+	}
+}
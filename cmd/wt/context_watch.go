@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/hub"
+	"github.com/badri/wt/internal/monitor"
+	"github.com/badri/wt/internal/tmux"
+)
+
+// contextWatcher asks agents whose conversation nears the end of its context
+// window to hand off to a fresh instance, instead of carrying on until
+// Claude compacts and quality drops. Each conversation is asked once.
+type contextWatcher struct {
+	threshold int // percent of the window
+	window    int64
+	notifier  *monitor.Notifier
+
+	mu    sync.Mutex
+	asked map[string]string // session -> transcript already asked to hand off
+}
+
+// newContextWatcher returns nil when context_handoff is off
+func newContextWatcher(cfg *config.Config, notifier *monitor.Notifier) *contextWatcher {
+	if cfg.ContextHandoff <= 0 {
+		return nil
+	}
+	return &contextWatcher{
+		threshold: cfg.ContextHandoff,
+		window:    cfg.ContextWindowTokens(),
+		notifier:  notifier,
+		asked:     make(map[string]string),
+	}
+}
+
+// check returns how full a session's context window is in percent, -1 when
+// unknown, and asks the agent to hand off when it crossed the threshold
+func (w *contextWatcher) check(name, worktree string) int {
+	usage, ok := monitor.GetContextUsage(worktree)
+	if !ok {
+		return -1
+	}
+	pct := usage.Percent(w.window)
+	if pct < w.threshold {
+		return pct
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.asked[name] == usage.Transcript {
+		return pct
+	}
+	if err := tmux.NudgeSession(name, contextHandoffPrompt(name, pct)); err != nil {
+		return pct
+	}
+	w.asked[name] = usage.Transcript
+	w.notifier.Send("context", name, "wt: Context Nearly Full", fmt.Sprintf("Session '%s' is at %d%% of its context window and was asked to hand off", name, pct))
+	return pct
+}
+
+// checkHub does the same for the hub's own Claude
+func (w *contextWatcher) checkHub() {
+	if !tmux.SessionExists(hub.HubSessionName) {
+		return
+	}
+	if dir := hub.GetStatus().WorkingDir; dir != "" {
+		w.check(hub.HubSessionName, dir)
+	}
+}
+
+// contextHandoffPrompt tells an agent to wrap up and hand off. The hub
+// collects the state of all workers; a worker's handoff checkpoints its
+// worktree, which the fresh instance recovers on startup.
+func contextHandoffPrompt(name string, pct int) string {
+	if name == hub.HubSessionName {
+		return fmt.Sprintf("[wt] Your context window is %d%% full. Before quality degrades, hand off to a fresh hub: "+
+			"run 'wt handoff -c -m \"<what you were doing and what is next>\"'.", pct)
+	}
+	return fmt.Sprintf("[wt] Your context window is %d%% full. Before quality degrades, hand off to a fresh instance: "+
+		"finish or commit the current step, then run 'wt handoff -m \"<what is done and what is next>\"'. "+
+		"The new instance picks up from a checkpoint of this worktree.", pct)
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/hub"
+)
+
+func TestNewContextWatcherOff(t *testing.T) {
+	if w := newContextWatcher(&config.Config{}, nil); w != nil {
+		t.Error("newContextWatcher() without context_handoff should be nil")
+	}
+	w := newContextWatcher(&config.Config{ContextHandoff: 80}, nil)
+	if w == nil || w.threshold != 80 || w.window != config.DefaultContextWindow {
+		t.Errorf("newContextWatcher() = %+v", w)
+	}
+}
+
+func TestContextHandoffPrompt(t *testing.T) {
+	if p := contextHandoffPrompt(hub.HubSessionName, 85); !strings.Contains(p, "85%") || !strings.Contains(p, "wt handoff -c") {
+		t.Errorf("hub prompt = %q", p)
+	}
+	if p := contextHandoffPrompt("toast", 90); strings.Contains(p, "handoff -c") || !strings.Contains(p, "checkpoint") {
+		t.Errorf("worker prompt = %q", p)
+	}
+}
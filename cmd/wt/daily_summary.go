@@ -0,0 +1,182 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/events"
+	"github.com/badri/wt/internal/filelock"
+	"github.com/badri/wt/internal/handoff"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/merge"
+	"github.com/badri/wt/internal/monitor"
+	"github.com/badri/wt/internal/session"
+)
+
+// dailySummarySentFile records the day the daily summary was last sent,
+// so several wt watch processes send it once
+const dailySummarySentFile = "daily_summary_sent"
+
+// cmdEventsSummary prints the activity summary of the last day, or emails
+// it with --email (for cron)
+func cmdEventsSummary(cfg *config.Config, args []string) error {
+	since := 24 * time.Hour
+	email := false
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--since":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--since needs a duration, e.g. 24h")
+			}
+			d, err := time.ParseDuration(args[i+1])
+			if err != nil || d <= 0 {
+				return fmt.Errorf("invalid --since %q: use a duration like 24h", args[i+1])
+			}
+			since = d
+			i++
+		case "--email":
+			email = true
+		default:
+			return fmt.Errorf("unknown argument: %s", args[i])
+		}
+	}
+
+	now := time.Now()
+	summary, err := buildDailySummary(cfg, now.Add(-since), now)
+	if err != nil {
+		return err
+	}
+	if email {
+		e := cfg.EmailSettings()
+		if e == nil {
+			return fmt.Errorf("email is not configured: set notifications.email (smtp_host, from, to) in config.json")
+		}
+		if err := monitor.SendEmail(e, summary.Subject(), summary.Text()); err != nil {
+			return err
+		}
+		fmt.Printf("Sent daily summary to %s\n", strings.Join(e.To, ", "))
+		return nil
+	}
+	if outputJSON {
+		printJSON(summary)
+		return nil
+	}
+	fmt.Println(summary.Subject())
+	fmt.Println()
+	fmt.Print(summary.Text())
+	return nil
+}
+
+// buildDailySummary summarizes the events log between from and to, adding
+// the sessions waiting for review now and leaving out PRs gh reports as
+// merged or closed
+func buildDailySummary(cfg *config.Config, from, to time.Time) (*events.DailySummary, error) {
+	start := from
+	if reviews := to.Add(-events.ReviewWindow); reviews.Before(start) {
+		start = reviews
+	}
+	evts, err := events.NewLogger(cfg).SinceTime(start)
+	if err != nil {
+		return nil, fmt.Errorf("reading events: %w", err)
+	}
+	summary := events.BuildDailySummary(evts, from, to)
+
+	summary.PendingReviews = slices.DeleteFunc(summary.PendingReviews, func(e events.Event) bool {
+		status, err := merge.GetPRStatus("", e.PRURL)
+		return err == nil && status.State != "OPEN"
+	})
+	if state, err := session.LoadState(cfg); err == nil {
+		for name, sess := range state.Sessions {
+			if sess.Status == "ready" {
+				summary.ReadySessions = append(summary.ReadySessions, name)
+			}
+		}
+		slices.Sort(summary.ReadySessions)
+	}
+	return summary, nil
+}
+
+// dailySummaryDue reports whether the daily summary should go out at now:
+// the configured time has passed today and it wasn't sent today yet
+func dailySummaryDue(e *config.Email, lastSent string, now time.Time) bool {
+	at, ok, err := e.SummaryTime(now)
+	if err != nil || !ok {
+		return false
+	}
+	return !now.Before(at) && lastSent != now.Format("2006-01-02")
+}
+
+// dailySummaryRetry is how long wt watch waits before retrying a daily
+// summary that failed to send
+const dailySummaryRetry = 15 * time.Minute
+
+// dailySummaryRetryAt holds off retries after a failed send
+var dailySummaryRetryAt time.Time
+
+// sendDailySummaryIfDue emails the daily summary once a day at the
+// configured time. wt watch calls it on every refresh; the day is marked
+// sent only once the email went out.
+func sendDailySummaryIfDue(cfg *config.Config) {
+	e := cfg.EmailSettings()
+	if e == nil || e.DailySummary == "" {
+		return
+	}
+	path := filepath.Join(cfg.ConfigDir(), handoff.RuntimeDir, dailySummarySentFile)
+	if !dailySummaryDue(e, readDailySummarySent(path), time.Now()) {
+		return
+	}
+
+	// One watcher sends; the others skip this refresh and see the day
+	// marked on the next
+	unlock, err := filelock.Lock(path+".lock", false, 0)
+	if err != nil {
+		return
+	}
+	defer unlock()
+	now := time.Now()
+	if !dailySummaryDue(e, readDailySummarySent(path), now) || now.Before(dailySummaryRetryAt) {
+		return
+	}
+
+	summary, err := buildDailySummary(cfg, now.Add(-24*time.Hour), now)
+	if err == nil {
+		err = monitor.SendEmail(e, summary.Subject(), summary.Text())
+	}
+	if err != nil {
+		dailySummaryRetryAt = now.Add(dailySummaryRetry)
+		logging.Warnf("could not send daily summary, retrying in %v: %v", dailySummaryRetry, err)
+		return
+	}
+	if err := os.WriteFile(path, []byte(now.Format("2006-01-02")+"\n"), 0644); err != nil {
+		logging.Warnf("could not record daily summary as sent: %v", err)
+	}
+}
+
+// readDailySummarySent returns the day the daily summary was last sent
+func readDailySummarySent(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// emailExtras describes what the email sink sends besides manual summaries
+func emailExtras(e *config.Email) string {
+	var extras []string
+	if e.Notify {
+		extras = append(extras, "notifications")
+	}
+	if e.DailySummary != "" {
+		extras = append(extras, "daily summary at "+e.DailySummary)
+	}
+	if len(extras) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(extras, ", ") + ")"
+}
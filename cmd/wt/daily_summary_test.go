@@ -0,0 +1,63 @@
+package main
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/handoff"
+)
+
+func TestDailySummaryDue(t *testing.T) {
+	e := &config.Email{DailySummary: "18:00"}
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local)
+	tests := []struct {
+		at       time.Duration
+		lastSent string
+		want     bool
+	}{
+		{17 * time.Hour, "2026-03-01", false}, // too early
+		{18 * time.Hour, "2026-03-01", true},
+		{23 * time.Hour, "", true},
+		{19 * time.Hour, "2026-03-02", false}, // already sent today
+	}
+	for _, tt := range tests {
+		if got := dailySummaryDue(e, tt.lastSent, day.Add(tt.at)); got != tt.want {
+			t.Errorf("dailySummaryDue(%v, %q) = %v, want %v", tt.at, tt.lastSent, got, tt.want)
+		}
+	}
+	if dailySummaryDue(&config.Email{}, "", day.Add(23*time.Hour)) {
+		t.Error("no daily_summary should never be due")
+	}
+}
+
+func TestSendDailySummaryFailure(t *testing.T) {
+	// A port nothing listens on
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := ln.Addr().(*net.TCPAddr).Port
+	ln.Close()
+
+	cfg, err := config.LoadFromDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Notifications = &config.Notifications{Email: &config.Email{
+		SMTPHost: "127.0.0.1", SMTPPort: port, From: "wt@example.com", To: []string{"team@example.com"}, DailySummary: "00:00",
+	}}
+	defer func() { dailySummaryRetryAt = time.Time{} }()
+
+	sendDailySummaryIfDue(cfg)
+	path := filepath.Join(cfg.ConfigDir(), handoff.RuntimeDir, dailySummarySentFile)
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("failed send marked the day sent (stat: %v)", err)
+	}
+	if !dailySummaryRetryAt.After(time.Now()) {
+		t.Errorf("retry not held off after a failed send: %v", dailySummaryRetryAt)
+	}
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/session"
+)
+
+// cmdDependHelp shows help for the depend command
+func cmdDependHelp() error {
+	help := `wt depend - Declare that a session must merge after another
+
+USAGE:
+    wt depend <session>                 Current session depends on <session>
+    wt depend <session> --remove        Drop that dependency
+    wt depend                           List the current session's dependencies
+
+DESCRIPTION:
+    Parallel workers on coupled beads otherwise merge in whatever order
+    they finish. A dependency makes 'wt done' wait for the prerequisite's
+    branch to merge first:
+
+      direct     refuses to merge
+      pr-auto    opens the PR without auto-merge
+      pr-review  opens the PR and notes the prerequisite
+
+    Sessions and beads are both accepted. Dependencies are shown in
+    'wt list' and 'wt watch'; cycles are refused.
+
+OPTIONS:
+    -s, --session <name>    Session to change instead of the current one
+    --remove                Remove the dependency
+    -h, --help              Show this help
+
+EXAMPLES:
+    wt depend toast                     Merge after toast
+    wt depend wt-abc -s shadow          shadow merges after the wt-abc session
+    wt depend toast --remove            No longer wait for toast
+    wt done --ignore-deps               Merge anyway
+`
+	fmt.Print(help)
+	return nil
+}
+
+// cmdDepend records or removes a merge dependency between sessions
+func cmdDepend(cfg *config.Config, args []string) error {
+	var target, prereq string
+	remove := false
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--session", "-s":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--session requires a session name")
+			}
+			target = args[i+1]
+			i++
+		case "--remove", "--rm":
+			remove = true
+		default:
+			if strings.HasPrefix(args[i], "-") {
+				return fmt.Errorf("unknown flag: %s", args[i])
+			}
+			if prereq != "" {
+				return fmt.Errorf("usage: wt depend <session> [--session <name>] [--remove]")
+			}
+			prereq = args[i]
+		}
+	}
+
+	state, err := session.LoadState(cfg)
+	if err != nil {
+		return err
+	}
+
+	var name string
+	var sess *session.Session
+	if target != "" {
+		name, sess = findSessionByNameOrBead(state, target)
+		if sess == nil {
+			return fmt.Errorf("session '%s' not found", target)
+		}
+	} else {
+		name, sess = currentNoteSession(state)
+		if sess == nil {
+			return fmt.Errorf("not in a wt session; use --session <name>")
+		}
+	}
+
+	if prereq == "" {
+		if len(sess.DependsOn) == 0 {
+			fmt.Printf("%s has no dependencies.\n", name)
+			return nil
+		}
+		fmt.Printf("%s merges after:\n", name)
+		for _, d := range sess.DependsOn {
+			fmt.Printf("  %s\n", dependencyLabel(state, d))
+		}
+		return nil
+	}
+
+	dep, err := resolveDependency(state, prereq)
+	if err != nil {
+		return err
+	}
+
+	if remove {
+		kept := sess.DependsOn[:0]
+		for _, d := range sess.DependsOn {
+			if d.Bead != dep.Bead {
+				kept = append(kept, d)
+			}
+		}
+		if len(kept) == len(sess.DependsOn) {
+			return fmt.Errorf("%s does not depend on %s", name, prereq)
+		}
+		sess.DependsOn = kept
+		if err := state.Save(); err != nil {
+			return err
+		}
+		fmt.Printf("%s no longer waits for %s.\n", name, dependencyLabel(state, dep))
+		return nil
+	}
+
+	if dep.Bead == sess.Bead {
+		return fmt.Errorf("a session cannot depend on itself")
+	}
+	if sess.DependsOnBead(dep.Bead) {
+		fmt.Printf("%s already depends on %s.\n", name, dependencyLabel(state, dep))
+		return nil
+	}
+	if dependsTransitively(state, dep.Bead, sess.Bead) {
+		return fmt.Errorf("%s already depends on %s; that would be a cycle", dependencyLabel(state, dep), name)
+	}
+
+	sess.DependsOn = append(sess.DependsOn, dep)
+	if err := state.Save(); err != nil {
+		return err
+	}
+	fmt.Printf("%s will merge after %s.\n", name, dependencyLabel(state, dep))
+	return nil
+}
+
+// resolveDependency turns a session name or bead ID into a dependency.
+// Beads without an active session use wt's branch convention.
+func resolveDependency(state *session.State, ref string) (session.Dependency, error) {
+	if name, sess := findSessionByNameOrBead(state, ref); sess != nil {
+		if sess.Bead == "" {
+			return session.Dependency{}, fmt.Errorf("session '%s' has no bead to depend on", name)
+		}
+		branch := sess.Branch
+		if branch == "" {
+			branch = sess.Bead
+		}
+		return session.Dependency{Bead: sess.Bead, Branch: branch}, nil
+	}
+	return session.Dependency{Bead: ref, Branch: ref}, nil
+}
+
+// dependsTransitively reports whether the session working on fromBead
+// depends, directly or through other sessions, on toBead
+func dependsTransitively(state *session.State, fromBead, toBead string) bool {
+	seen := make(map[string]bool)
+	stack := []string{fromBead}
+	for len(stack) > 0 {
+		b := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if seen[b] {
+			continue
+		}
+		seen[b] = true
+		_, sess := findSessionByNameOrBead(state, b)
+		if sess == nil || sess.Bead != b {
+			continue
+		}
+		for _, d := range sess.DependsOn {
+			if d.Bead == toBead {
+				return true
+			}
+			stack = append(stack, d.Bead)
+		}
+	}
+	return false
+}
+
+// dependencyLabel names a dependency by its session while one is active
+func dependencyLabel(state *session.State, d session.Dependency) string {
+	for name, sess := range state.Sessions {
+		if sess.Bead == d.Bead {
+			return fmt.Sprintf("%s (%s)", name, d.Bead)
+		}
+	}
+	return d.Bead
+}
+
+// dependencyList names each of a session's dependencies
+func dependencyList(state *session.State, deps []session.Dependency) []string {
+	var labels []string
+	for _, d := range deps {
+		labels = append(labels, dependencyLabel(state, d))
+	}
+	return labels
+}
+
+// dependencyLabels names several dependencies in one line
+func dependencyLabels(state *session.State, deps []session.Dependency) string {
+	return strings.Join(dependencyList(state, deps), ", ")
+}
+
+// unmergedDependencies returns the dependencies whose branch has not landed
+// on the default branch yet
+func unmergedDependencies(dir string, sess *session.Session, defaultBranch string) []session.Dependency {
+	var waiting []session.Dependency
+	for _, d := range sess.DependsOn {
+		if !parentMerged(dir, d.Branch, defaultBranch) {
+			waiting = append(waiting, d)
+		}
+	}
+	return waiting
+}
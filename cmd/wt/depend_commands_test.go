@@ -0,0 +1,39 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/badri/wt/internal/session"
+)
+
+func TestDependencies(t *testing.T) {
+	state := &session.State{Sessions: map[string]*session.Session{
+		"toast":    {Bead: "proj-a", Branch: "proj-a"},
+		"shadow":   {Bead: "proj-b", Branch: "feature/proj-b", DependsOn: []session.Dependency{{Bead: "proj-a", Branch: "proj-a"}}},
+		"obsidian": {Bead: "proj-c", DependsOn: []session.Dependency{{Bead: "proj-b", Branch: "feature/proj-b"}}},
+	}}
+
+	dep, err := resolveDependency(state, "shadow")
+	if err != nil || dep != (session.Dependency{Bead: "proj-b", Branch: "feature/proj-b"}) {
+		t.Errorf("resolveDependency(shadow) = %+v, %v", dep, err)
+	}
+	dep, err = resolveDependency(state, "proj-z")
+	if err != nil || dep != (session.Dependency{Bead: "proj-z", Branch: "proj-z"}) {
+		t.Errorf("resolveDependency(proj-z) = %+v, %v; want the bead's own branch", dep, err)
+	}
+
+	// toast depending on obsidian would close the loop obsidian -> shadow -> toast
+	if !dependsTransitively(state, "proj-c", "proj-a") {
+		t.Error("dependsTransitively(proj-c, proj-a) = false, want true")
+	}
+	if dependsTransitively(state, "proj-a", "proj-c") {
+		t.Error("dependsTransitively(proj-a, proj-c) = true, want false")
+	}
+
+	if got := dependencyLabels(state, state.Sessions["obsidian"].DependsOn); got != "shadow (proj-b)" {
+		t.Errorf("dependencyLabels() = %q", got)
+	}
+	if got := dependencyLabel(state, session.Dependency{Bead: "proj-gone"}); got != "proj-gone" {
+		t.Errorf("dependencyLabel() of an ended session = %q, want the bead", got)
+	}
+}
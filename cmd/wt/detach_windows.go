@@ -0,0 +1,11 @@
+//go:build windows
+
+package main
+
+import "syscall"
+
+// detachedProcAttr starts a process in a process group of its own, so it
+// doesn't get the console's Ctrl-C
+func detachedProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP}
+}
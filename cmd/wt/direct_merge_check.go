@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/merge"
+	"github.com/badri/wt/internal/project"
+)
+
+// directMergeMode checks, before any work is done, that GitHub will
+// accept the push of a direct merge to targetBranch. When branch
+// protection or a ruleset would reject it, wt done opens an auto-merge PR
+// instead: it returns "pr-auto" and says why. Repositories gh can't query
+// are merged directly as before.
+func directMergeMode(proj *project.Project, worktree, targetBranch string) string {
+	if project.RepoHost(proj.RepoURL) == "" {
+		return "direct"
+	}
+	if _, err := exec.LookPath("gh"); err != nil {
+		return "direct"
+	}
+
+	blockers, err := merge.DirectPushBlockers(worktree, targetBranch)
+	if err != nil {
+		logging.Warnf("could not check protection of %s, merging directly: %v", targetBranch, err)
+		return "direct"
+	}
+	if len(blockers) == 0 {
+		return "direct"
+	}
+	fmt.Printf("\nNotice: %s is protected against direct pushes: %s.\n", targetBranch, strings.Join(blockers, "; "))
+	fmt.Println("Opening a PR with auto-merge instead (pr-auto). Set merge_mode to pr-auto to skip this check.")
+	return "pr-auto"
+}
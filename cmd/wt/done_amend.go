@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/events"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/merge"
+	"github.com/badri/wt/internal/session"
+)
+
+// findAmendPR returns the open PR that 'wt done' updates instead of opening
+// a new one: any open PR with --amend-pr, otherwise one that is out of
+// draft in a PR merge mode. A draft from 'wt pr draft' takes the usual
+// path, which marks it ready for review.
+func findAmendPR(dir, branch, mergeMode string, amend bool) (*merge.ExistingPR, error) {
+	if !amend && mergeMode == "direct" {
+		return nil, nil
+	}
+	pr, err := merge.FindOpenPR(dir, branch)
+	if err != nil {
+		if amend {
+			return nil, err
+		}
+		logging.Warnf("%v", err)
+		return nil, nil
+	}
+	if pr == nil {
+		if amend {
+			return nil, fmt.Errorf("--amend-pr: branch %s has no open PR", branch)
+		}
+		return nil, nil
+	}
+	if pr.IsDraft && !amend {
+		return nil, nil
+	}
+	return pr, nil
+}
+
+// amendPR updates a session's open PR with its follow-up commits: it pushes
+// the branch, lists the new commits in the PR description and asks the
+// earlier reviewers to review again. The bead stays open and the session
+// stays up, since the PR hasn't merged yet.
+func amendPR(cfg *config.Config, state *session.State, sessionName string, sess *session.Session, pr *merge.ExistingPR, branch, targetBranch string) error {
+	logging.Infof("\nUpdating PR %s...", pr.URL)
+	if err := merge.FetchMain(sess.Worktree, branch); err != nil {
+		return err
+	}
+	theirs, err := merge.RemoteOnlyCommits(sess.Worktree, branch)
+	if err != nil {
+		return err
+	}
+	if len(theirs) > 0 {
+		return fmt.Errorf("the PR branch has commits this worktree lacks:\n  %s\nPull them with 'git pull --rebase origin %s' and run 'wt done' again", strings.Join(theirs, "\n  "), branch)
+	}
+	commits, err := merge.NewCommits(sess.Worktree, branch, "origin/"+targetBranch)
+	if err != nil {
+		return err
+	}
+	if err := merge.PushUpdate(sess.Worktree, branch); err != nil {
+		return err
+	}
+
+	if len(commits) == 0 {
+		fmt.Println("No new commits; pushed the branch as it is.")
+	} else {
+		fmt.Printf("Pushed %d new commit(s):\n", len(commits))
+		for _, c := range commits {
+			fmt.Printf("  %s\n", c)
+		}
+		body := merge.AppendUpdate(pr.Body, time.Now().Format("2006-01-02"), commits)
+		if err := merge.UpdatePRBody(sess.Worktree, pr.URL, body); err != nil {
+			logging.Warnf("%v", err)
+		} else {
+			fmt.Println("Listed them in the PR description.")
+		}
+		if !pr.IsDraft && len(pr.Reviewers) > 0 {
+			if requested, err := merge.RequestReviewers(sess.Worktree, pr.URL, pr.Reviewers); err != nil {
+				logging.Warnf("%v", err)
+			} else if len(requested) > 0 {
+				fmt.Printf("Re-requested review from %s\n", strings.Join(requested, ", "))
+			}
+		}
+	}
+
+	sess.Status = "ready"
+	sess.StatusMessage = "PR updated: " + pr.URL
+	sess.UpdateActivity()
+	if err := state.Save(); err != nil {
+		return fmt.Errorf("saving state: %w", err)
+	}
+	if err := events.NewLogger(cfg).Log(&events.Event{
+		Type:    events.EventPRUpdated,
+		Session: sessionName,
+		Bead:    sess.Bead,
+		Project: sess.Project,
+		PRURL:   pr.URL,
+		Note:    fmt.Sprintf("%d new commit(s)", len(commits)),
+	}); err != nil {
+		logging.Warnf("could not log event: %v", err)
+	}
+
+	fmt.Printf("\nBead %s stays open until the PR merges. Run 'wt done' again after more changes,\n", sess.Bead)
+	fmt.Printf("or 'wt close %s' once it has merged.\n", sessionName)
+	return nil
+}
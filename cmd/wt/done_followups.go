@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/badri/wt/internal/bead"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/report"
+	"github.com/badri/wt/internal/session"
+)
+
+// scanTODOs lists the TODO and FIXME markers the session's branch adds, so
+// 'wt done' can offer follow-up beads for them once the PR exists
+func scanTODOs(sess *session.Session, targetBranch string) []report.TODO {
+	todos, err := report.ScanTODOs(sess.Worktree, targetBranch)
+	if err != nil {
+		logging.Warnf("could not scan for TODOs: %v", err)
+		return nil
+	}
+	if len(todos) > 0 {
+		fmt.Printf("\nThis branch adds %d TODO/FIXME marker(s):\n", len(todos))
+		for _, t := range todos {
+			fmt.Printf("  %s  %s: %s\n", t.Location(), t.Kind, t.Text)
+		}
+	}
+	return todos
+}
+
+// offerTODOFollowUps creates a follow-up bead for each marker, linked back
+// to the session's bead and PR. It asks first unless create is set (from
+// --follow-ups). Without a terminal to ask on, e.g. when the worker runs
+// 'wt done', the markers are noted on the bead instead so they aren't lost.
+func offerTODOFollowUps(sess *session.Session, todos []report.TODO, prURL string, create bool) {
+	if len(todos) == 0 || sess.BeadsDir == "" {
+		return
+	}
+	if !create {
+		if !stdinIsTerminal() {
+			noteTODOs(sess, todos)
+			return
+		}
+		if !confirm(fmt.Sprintf("\nCreate %d follow-up bead(s) for these markers?", len(todos)), false) {
+			return
+		}
+	}
+
+	origin := sess.Bead
+	if prURL != "" {
+		origin += " (" + prURL + ")"
+	}
+	for _, t := range todos {
+		opts := &bead.CreateOptions{
+			Description: fmt.Sprintf("Left as a %s at %s.\n\nFollow-up from %s.", t.Kind, t.Location(), origin),
+			Priority:    3,
+			Type:        "task",
+		}
+		if t.Kind == "FIXME" {
+			opts.Priority = 2
+			opts.Type = "bug"
+		}
+		id, err := bead.CreateInDir(sess.BeadsDir, t.Title(), opts)
+		if err != nil {
+			logging.Warnf("could not create follow-up for %s: %v", t.Location(), err)
+			continue
+		}
+		linkFollowUp(sess, id)
+		fmt.Printf("  Created follow-up %s: %s\n", id, t.Title())
+	}
+}
+
+// noteTODOs adds the markers to the session's bead as a comment
+func noteTODOs(sess *session.Session, todos []report.TODO) {
+	var sb strings.Builder
+	sb.WriteString("TODO/FIXME markers added by this work:\n")
+	for _, t := range todos {
+		sb.WriteString(fmt.Sprintf("- %s %s: %s\n", t.Location(), t.Kind, t.Text))
+	}
+	if err := bead.AddCommentInDir(sess.Bead, sb.String(), sess.BeadsDir); err != nil {
+		logging.Warnf("could not note TODOs on bead: %v", err)
+		return
+	}
+	fmt.Printf("Noted them on %s; pass --follow-ups to create beads for them.\n", sess.Bead)
+}
+
+// linkFollowUp records that a follow-up bead came out of the session's bead
+func linkFollowUp(sess *session.Session, id string) {
+	if err := bead.AddDiscoveredFromInDir(id, sess.Bead, sess.BeadsDir); err != nil {
+		logging.Warnf("%v", err)
+	}
+}
+
+// stdinIsTerminal reports whether wt can ask the user a question
+func stdinIsTerminal() bool {
+	fi, err := os.Stdin.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
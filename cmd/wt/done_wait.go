@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/merge"
+	"github.com/badri/wt/internal/project"
+)
+
+// defaultMergeWait is how long 'wt done --wait' waits for a pr-auto PR when
+// neither --wait-timeout nor the project's merge_wait says
+const defaultMergeWait = 30 * time.Minute
+
+// mergePollInterval is how often 'wt done --wait' checks on the PR
+const mergePollInterval = 30 * time.Second
+
+// mergeWait decides whether 'wt done' waits for its pr-auto PR to merge, and
+// for how long. --wait-timeout implies --wait; a project with merge_wait
+// waits by default unless --no-wait is given.
+func mergeWait(proj *project.Project, flags doneFlags, mergeMode string) (time.Duration, bool, error) {
+	if flags.noWait {
+		return 0, false, nil
+	}
+	asked := flags.wait || flags.waitTimeout != ""
+	if asked && mergeMode != "pr-auto" {
+		return 0, false, fmt.Errorf("--wait only applies to the pr-auto merge mode")
+	}
+	if mergeMode != "pr-auto" {
+		return 0, false, nil
+	}
+
+	if flags.waitTimeout != "" {
+		timeout, err := time.ParseDuration(flags.waitTimeout)
+		if err != nil || timeout <= 0 {
+			return 0, false, fmt.Errorf("invalid --wait-timeout %q (e.g. 30m)", flags.waitTimeout)
+		}
+		return timeout, true, nil
+	}
+	if proj.MergeWait != "" {
+		timeout, err := time.ParseDuration(proj.MergeWait)
+		if err != nil || timeout <= 0 {
+			return 0, false, fmt.Errorf("invalid merge_wait %q in project %s (e.g. 30m)", proj.MergeWait, proj.Name)
+		}
+		return timeout, true, nil
+	}
+	return defaultMergeWait, asked, nil
+}
+
+// waitForMerge polls an auto-merge PR until it merges and returns the merge
+// commit. It gives up when a check fails, the PR is closed or the timeout
+// passes, saying which checks failed.
+func waitForMerge(timeout, interval time.Duration, status func() (*merge.PRStatus, error)) (string, error) {
+	logging.Infof("Waiting up to %s for the PR to merge...", timeout)
+	deadline := time.Now().Add(timeout)
+	checks := "unknown"
+	for {
+		s, err := status()
+		if err != nil {
+			logging.Warnf("%v", err)
+		} else {
+			switch {
+			case s.State == "MERGED":
+				return s.MergeCommit, nil
+			case s.State == "CLOSED":
+				return "", fmt.Errorf("PR was closed without merging")
+			case s.Checks == merge.ChecksFailed:
+				return "", fmt.Errorf("PR checks failed: %s", strings.Join(s.Failed, ", "))
+			}
+			if s.Checks != checks {
+				checks = s.Checks
+				fmt.Printf("  Checks %s\n", checks)
+			}
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return "", fmt.Errorf("PR did not merge within %s (checks %s)", timeout, checks)
+		}
+		time.Sleep(min(interval, remaining))
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/badri/wt/internal/merge"
+	"github.com/badri/wt/internal/project"
+)
+
+func TestMergeWait(t *testing.T) {
+	proj := &project.Project{Name: "myapp"}
+	tests := []struct {
+		name      string
+		mergeWait string
+		flags     doneFlags
+		mode      string
+		want      time.Duration
+		wantWait  bool
+		wantErr   bool
+	}{
+		{"off by default", "", doneFlags{}, "pr-auto", defaultMergeWait, false, false},
+		{"--wait", "", doneFlags{wait: true}, "pr-auto", defaultMergeWait, true, false},
+		{"--wait-timeout", "", doneFlags{waitTimeout: "45m"}, "pr-auto", 45 * time.Minute, true, false},
+		{"project default", "1h", doneFlags{}, "pr-auto", time.Hour, true, false},
+		{"--no-wait beats project", "1h", doneFlags{noWait: true}, "pr-auto", 0, false, false},
+		{"project ignored outside pr-auto", "1h", doneFlags{}, "direct", 0, false, false},
+		{"--wait outside pr-auto", "", doneFlags{wait: true}, "pr-review", 0, false, true},
+		{"bad timeout", "", doneFlags{waitTimeout: "soon"}, "pr-auto", 0, false, true},
+		{"bad project value", "forever", doneFlags{}, "pr-auto", 0, false, true},
+	}
+	for _, tt := range tests {
+		proj.MergeWait = tt.mergeWait
+		got, wait, err := mergeWait(proj, tt.flags, tt.mode)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if tt.wantErr {
+			continue
+		}
+		if wait != tt.wantWait || (wait && got != tt.want) {
+			t.Errorf("%s: mergeWait() = %v, %v; want %v, %v", tt.name, got, wait, tt.want, tt.wantWait)
+		}
+	}
+}
+
+func TestWaitForMerge(t *testing.T) {
+	poll := func(statuses ...*merge.PRStatus) func() (*merge.PRStatus, error) {
+		i := 0
+		return func() (*merge.PRStatus, error) {
+			s := statuses[min(i, len(statuses)-1)]
+			i++
+			if s == nil {
+				return nil, errors.New("gh unavailable")
+			}
+			return s, nil
+		}
+	}
+	pending := &merge.PRStatus{State: "OPEN", Checks: merge.ChecksPending}
+
+	commit, err := waitForMerge(time.Second, time.Millisecond, poll(nil, pending, &merge.PRStatus{State: "MERGED", MergeCommit: "abc123", Checks: merge.ChecksPassed}))
+	if err != nil || commit != "abc123" {
+		t.Errorf("waitForMerge() = %q, %v; want abc123", commit, err)
+	}
+
+	_, err = waitForMerge(time.Second, time.Millisecond, poll(pending, &merge.PRStatus{State: "OPEN", Checks: merge.ChecksFailed, Failed: []string{"test", "lint"}}))
+	if err == nil || !strings.Contains(err.Error(), "test, lint") {
+		t.Errorf("waitForMerge() error = %v, want the failed checks", err)
+	}
+
+	_, err = waitForMerge(time.Second, time.Millisecond, poll(&merge.PRStatus{State: "CLOSED"}))
+	if err == nil || !strings.Contains(err.Error(), "closed") {
+		t.Errorf("waitForMerge() error = %v, want closed", err)
+	}
+
+	_, err = waitForMerge(20*time.Millisecond, 5*time.Millisecond, poll(pending))
+	if err == nil || !strings.Contains(err.Error(), "checks pending") {
+		t.Errorf("waitForMerge() error = %v, want a timeout", err)
+	}
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/project"
+	"github.com/badri/wt/internal/session"
+	"github.com/badri/wt/internal/testenv"
+)
+
+// parseEnvAllArgs parses 'wt teardown-all|setup-all [--project <name>]'
+func parseEnvAllArgs(command string, args []string) (string, error) {
+	var projectName string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-p", "--project":
+			if i+1 >= len(args) {
+				return "", fmt.Errorf("--project requires a project name")
+			}
+			projectName = args[i+1]
+			i++
+		default:
+			return "", fmt.Errorf("usage: wt %s [--project <name>]", command)
+		}
+	}
+	return projectName, nil
+}
+
+// envAllSessions returns the sessions 'wt teardown-all' and 'wt setup-all'
+// act on, by name: those of the project, if given, that aren't paused
+// (pausing already stopped their environment)
+func envAllSessions(state *session.State, projectName string) []string {
+	var names []string
+	for name, sess := range state.Sessions {
+		if sess.IsPaused() || (projectName != "" && sess.Project != projectName) {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// hasEnvHooks reports whether a project has anything for teardown-all and
+// setup-all to run
+func hasEnvHooks(proj *project.Project) bool {
+	if proj == nil {
+		return false
+	}
+	hasTestEnv := proj.TestEnv != nil && (proj.TestEnv.Setup != "" || proj.TestEnv.Teardown != "")
+	hasHooks := proj.Hooks != nil && (len(proj.Hooks.OnCreate) > 0 || len(proj.Hooks.OnClose) > 0)
+	return hasTestEnv || hasHooks
+}
+
+// cmdTeardownAll stops the test environments of all active sessions, so
+// ports and Docker resources are free overnight. tmux, the agents and the
+// worktrees keep running; 'wt setup-all' brings the environments back.
+func cmdTeardownAll(cfg *config.Config, args []string) error {
+	projectName, err := parseEnvAllArgs("teardown-all", args)
+	if err != nil {
+		return err
+	}
+	state, err := session.LoadState(cfg)
+	if err != nil {
+		return err
+	}
+	mgr := project.NewManager(cfg)
+
+	done, skipped := 0, 0
+	for _, name := range envAllSessions(state, projectName) {
+		sess := state.Sessions[name]
+		if sess.EnvDownAt != "" {
+			skipped++
+			continue
+		}
+		proj, _ := mgr.Get(sess.Project)
+		if !hasEnvHooks(proj) {
+			continue
+		}
+
+		logging.Infof("Tearing down '%s' (port offset %d)...", name, sess.PortOffset)
+		vars := sessionVars(name, sess)
+		if err := testenv.RunTeardown(proj, vars); err != nil {
+			logging.Warnf("teardown failed: %v", err)
+		}
+		if err := testenv.RunOnCloseHooks(proj, vars, envPortVar(proj)); err != nil {
+			logging.Warnf("%v", err)
+		}
+		sess.EnvDownAt = session.Now()
+		if err := state.Save(); err != nil {
+			return fmt.Errorf("saving state: %w", err)
+		}
+		done++
+	}
+
+	switch {
+	case done == 0 && skipped == 0:
+		fmt.Println("No session has a test environment to tear down.")
+	case done == 0:
+		fmt.Printf("All %d environment(s) are already down. Bring them back with: wt setup-all\n", skipped)
+	default:
+		fmt.Printf("\nTore down %d environment(s); sessions and worktrees are still running.\n", done)
+		fmt.Println("Bring them back with: wt setup-all")
+	}
+	return nil
+}
+
+// cmdSetupAll brings back the test environments 'wt teardown-all' stopped,
+// on each session's original port offset
+func cmdSetupAll(cfg *config.Config, args []string) error {
+	projectName, err := parseEnvAllArgs("setup-all", args)
+	if err != nil {
+		return err
+	}
+	state, err := session.LoadState(cfg)
+	if err != nil {
+		return err
+	}
+	mgr := project.NewManager(cfg)
+
+	done := 0
+	var failed []string
+	for _, name := range envAllSessions(state, projectName) {
+		sess := state.Sessions[name]
+		if sess.EnvDownAt == "" {
+			continue
+		}
+		proj, _ := mgr.Get(sess.Project)
+
+		logging.Infof("Setting up '%s' (port offset %d)...", name, sess.PortOffset)
+		if err := setupSessionEnv(proj, sessionVars(name, sess)); err != nil {
+			logging.Warnf("%v", err)
+			failed = append(failed, name)
+		}
+		sess.EnvDownAt = ""
+		if err := state.Save(); err != nil {
+			return fmt.Errorf("saving state: %w", err)
+		}
+		done++
+	}
+
+	if done == 0 {
+		fmt.Println("No torn-down environments to set up.")
+		return nil
+	}
+	fmt.Printf("\nSet up %d environment(s).\n", done)
+	if len(failed) > 0 {
+		return fmt.Errorf("setup failed for %s; check with 'wt status <session>'", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// setupSessionEnv runs a session's test env setup, waits for its health
+// check, and runs the on_create hooks, as 'wt new' does
+func setupSessionEnv(proj *project.Project, vars project.CommandVars) error {
+	if err := testenv.RunSetup(proj, vars); err != nil {
+		return fmt.Errorf("test env setup failed: %w", err)
+	}
+	if proj != nil && proj.TestEnv != nil && proj.TestEnv.HealthCheck != "" {
+		if err := testenv.WaitForHealthy(proj, vars, 30*time.Second); err != nil {
+			return fmt.Errorf("health check failed: %w", err)
+		}
+	}
+	return testenv.RunOnCreateHooks(proj, vars, envPortVar(proj))
+}
+
+// envPortVar is the variable hooks get the session's port in, if any
+func envPortVar(proj *project.Project) string {
+	if proj == nil || proj.TestEnv == nil {
+		return ""
+	}
+	return proj.TestEnv.PortEnv
+}
+
+func cmdTeardownAllHelp() error {
+	help := `wt teardown-all - Stop every session's test environment for the night
+
+USAGE:
+    wt teardown-all [--project <name>]
+
+DESCRIPTION:
+    Runs the test env teardown and on_close hooks of every active session,
+    freeing ports, containers and other Docker resources. Unlike 'wt kill'
+    or 'wt shutdown', tmux, the agents and the worktrees keep running, and
+    each session keeps its port offset. 'wt setup-all' brings the
+    environments back.
+
+    Paused sessions are skipped; their environment is already stopped.
+    Sessions whose environment is down show it in 'wt status'. Killing or
+    finishing one doesn't run the teardown and on_close hooks again.
+
+OPTIONS:
+    -p, --project <name>    Only this project's sessions
+    -h, --help              Show this help
+
+EXAMPLES:
+    wt teardown-all                 End of day: free every environment
+    wt teardown-all --project api   Only the api project's sessions
+`
+	fmt.Print(help)
+	return nil
+}
+
+func cmdSetupAllHelp() error {
+	help := `wt setup-all - Bring back the test environments teardown-all stopped
+
+USAGE:
+    wt setup-all [--project <name>]
+
+DESCRIPTION:
+    Runs the test env setup, the health check and the on_create hooks of
+    every session 'wt teardown-all' tore down, on the same port offsets,
+    so the URLs and ports the workers know still work.
+
+OPTIONS:
+    -p, --project <name>    Only this project's sessions
+    -h, --help              Show this help
+
+EXAMPLES:
+    wt setup-all                    Start of day: environments back up
+    wt setup-all --project api
+`
+	fmt.Print(help)
+	return nil
+}
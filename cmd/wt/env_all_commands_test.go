@@ -0,0 +1,96 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/project"
+	"github.com/badri/wt/internal/session"
+)
+
+func TestParseEnvAllArgs(t *testing.T) {
+	if name, err := parseEnvAllArgs("teardown-all", nil); err != nil || name != "" {
+		t.Errorf("parseEnvAllArgs() = %q, %v", name, err)
+	}
+	if name, err := parseEnvAllArgs("teardown-all", []string{"--project", "api"}); err != nil || name != "api" {
+		t.Errorf("parseEnvAllArgs(--project api) = %q, %v", name, err)
+	}
+	for _, args := range [][]string{{"--project"}, {"toast"}, {"--force"}} {
+		if _, err := parseEnvAllArgs("setup-all", args); err == nil {
+			t.Errorf("parseEnvAllArgs(%v) should fail", args)
+		}
+	}
+}
+
+func TestTeardownAllAndSetupAll(t *testing.T) {
+	cfg, err := config.LoadFromDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	log := filepath.Join(t.TempDir(), "hooks.log")
+	mgr := project.NewManager(cfg)
+	if err := mgr.Save(&project.Project{
+		Name: "api",
+		Repo: t.TempDir(),
+		TestEnv: &project.TestEnv{
+			Setup:    "echo up {{.PortOffset}} >> " + log,
+			Teardown: "echo down {{.PortOffset}} >> " + log,
+		},
+		Hooks: &project.Hooks{OnClose: []string{"echo close $WT_SESSION >> " + log}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := mgr.Save(&project.Project{Name: "web", Repo: t.TempDir()}); err != nil {
+		t.Fatal(err)
+	}
+
+	state, err := session.LoadState(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	state.Sessions["toast"] = &session.Session{Project: "api", PortOffset: 2, Worktree: dir}
+	state.Sessions["rye"] = &session.Session{Project: "api", PortOffset: 3, Worktree: dir, PausedAt: "2026-01-02T10:00:00Z"}
+	state.Sessions["ash"] = &session.Session{Project: "web", PortOffset: 4, Worktree: dir}
+	if err := state.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if got := envAllSessions(state, "api"); !slices.Equal(got, []string{"toast"}) {
+		t.Errorf("envAllSessions(api) = %v, want [toast]", got)
+	}
+
+	if err := cmdTeardownAll(cfg, nil); err != nil {
+		t.Fatal(err)
+	}
+	// A second run finds nothing left to tear down
+	if err := cmdTeardownAll(cfg, nil); err != nil {
+		t.Fatal(err)
+	}
+	state, _ = session.LoadState(cfg)
+	if state.Sessions["toast"].EnvDownAt == "" {
+		t.Error("toast's environment should be marked down")
+	}
+	if state.Sessions["ash"].EnvDownAt != "" || state.Sessions["rye"].EnvDownAt != "" {
+		t.Error("sessions without hooks and paused sessions should be left alone")
+	}
+
+	if err := cmdSetupAll(cfg, nil); err != nil {
+		t.Fatal(err)
+	}
+	state, _ = session.LoadState(cfg)
+	if state.Sessions["toast"].EnvDownAt != "" {
+		t.Error("setup-all should clear EnvDownAt")
+	}
+
+	data, err := os.ReadFile(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := strings.Fields(string(data)), []string{"down", "2", "close", "toast", "up", "2"}; !slices.Equal(got, want) {
+		t.Errorf("hooks ran %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/badri/wt/internal/auto"
+	"github.com/badri/wt/internal/config"
+)
+
+// cmdAuditEpic runs the epic audit 'wt auto --epic' does before a run
+func cmdAuditEpic(cfg *config.Config, epicID string) error {
+	result, err := auto.AuditEpic(cfg, epicID)
+	if err != nil {
+		return fmt.Errorf("auditing epic: %w", err)
+	}
+
+	if outputJSON {
+		printJSON(result)
+		return nil
+	}
+
+	printEpicAuditResult(result)
+	return nil
+}
+
+func printEpicAuditResult(result *auto.EpicAuditResult) {
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39"))
+	readyStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("46"))
+	notReadyStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("196"))
+	crossStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("226"))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("242"))
+
+	fmt.Println()
+	fmt.Printf("%s %s %s\n", headerStyle.Render("Epic audit:"), result.EpicID, dimStyle.Render(result.ProjectDir))
+	fmt.Println()
+
+	if result.Ready {
+		fmt.Printf("Readiness: %s\n\n", readyStyle.Render("Ready"))
+	} else {
+		fmt.Printf("Readiness: %s (%d issues)\n\n", notReadyStyle.Render("Not Ready"), len(result.Issues))
+	}
+
+	if len(result.Beads) > 0 {
+		columns := []table.Column{
+			{Title: "#", Width: 3},
+			{Title: "Bead", Width: 16},
+			{Title: "Title", Width: 50},
+		}
+		var rows []table.Row
+		for i, id := range result.Beads {
+			rows = append(rows, table.Row{fmt.Sprintf("%d", i+1), id, truncate(result.BeadTitles[id], 50)})
+		}
+		printTable("Ready Beads", columns, rows)
+		fmt.Println()
+	}
+
+	if len(result.ExternalBlockers) > 0 {
+		fmt.Printf("%s External blockers:\n", crossStyle.Render("✗"))
+		for _, b := range result.ExternalBlockers {
+			fmt.Printf("  %s %s\n", dimStyle.Render("-"), b)
+		}
+		fmt.Println()
+	}
+
+	if len(result.Issues) > 0 {
+		fmt.Printf("%s Issues:\n", crossStyle.Render("✗"))
+		for _, issue := range result.Issues {
+			fmt.Printf("  %s %s\n", dimStyle.Render("-"), issue)
+		}
+		fmt.Println()
+	}
+
+	if len(result.FileConflicts) > 0 {
+		fmt.Printf("%s Files mentioned by several beads (possible conflicts):\n", warnStyle.Render("⚠"))
+		for _, c := range result.FileConflicts {
+			fmt.Printf("  %s %s\n", dimStyle.Render("-"), c)
+		}
+		fmt.Println()
+	}
+
+	if result.Ready {
+		fmt.Printf("Start the run with: wt auto --epic %s\n", result.EpicID)
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+
+	"github.com/badri/wt/internal/bead"
+)
+
+// epicSummary is an epic's title and progress, shown over the sessions
+// working on it in wt list and wt watch
+type epicSummary struct {
+	ID    string `json:"id"`
+	Title string `json:"title,omitempty"`
+	Done  int    `json:"done"`
+	Total int    `json:"total"`
+}
+
+// progress renders "3/7 done", or "" when the epic's children are unknown
+func (e *epicSummary) progress() string {
+	if e.Total == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d/%d done", e.Done, e.Total)
+}
+
+// label is the epic ID, title and progress on one line
+func (e *epicSummary) label() string {
+	parts := []string{e.ID}
+	if e.Title != "" {
+		parts = append(parts, e.Title)
+	}
+	if p := e.progress(); p != "" {
+		parts = append(parts, "("+p+")")
+	}
+	return strings.Join(parts, " ")
+}
+
+// epicTracker looks each epic up once per listing, however many sessions
+// work on it
+type epicTracker struct {
+	cache map[string]*epicSummary
+}
+
+func newEpicTracker() *epicTracker {
+	return &epicTracker{cache: make(map[string]*epicSummary)}
+}
+
+// summary returns the epic's title and progress, read from the session's
+// beads database. Returns nil for sessions outside any epic.
+func (t *epicTracker) summary(epicID, beadsDir string) *epicSummary {
+	if epicID == "" {
+		return nil
+	}
+	if e, ok := t.cache[epicID]; ok {
+		return e
+	}
+	e := &epicSummary{ID: epicID}
+	if d, err := bead.ShowDetail(epicID, strings.TrimSuffix(beadsDir, "/.beads")); err == nil {
+		e.Title = d.Title
+		e.Done, e.Total = d.Progress()
+	}
+	t.cache[epicID] = e
+	return e
+}
+
+// listItem is a line of wt list: an epic header (with the entry of its
+// first session), or a session indented under its epic by prefix
+type listItem struct {
+	header *epicSummary
+	entry  ListSessionEntry
+	prefix string
+}
+
+// groupByEpic orders list entries for display: sessions outside epics
+// first, as they came, then each epic's sessions under a header, epics
+// and their sessions sorted by name
+func groupByEpic(entries []ListSessionEntry) []listItem {
+	var items []listItem
+	groups := make(map[string][]ListSessionEntry)
+	summaries := make(map[string]*epicSummary)
+	for _, e := range entries {
+		if e.Epic == nil {
+			items = append(items, listItem{entry: e})
+			continue
+		}
+		groups[e.Epic.ID] = append(groups[e.Epic.ID], e)
+		summaries[e.Epic.ID] = e.Epic
+	}
+
+	ids := make([]string, 0, len(groups))
+	for id := range groups {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		members := groups[id]
+		sort.SliceStable(members, func(i, j int) bool { return members[i].Name < members[j].Name })
+		items = append(items, listItem{header: summaries[id], entry: members[0]})
+		for i, e := range members {
+			prefix := "├ "
+			if i == len(members)-1 {
+				prefix = "└ "
+			}
+			items = append(items, listItem{entry: e, prefix: prefix})
+		}
+	}
+	return items
+}
+
+// epicHeaderRow is the wt list row naming an epic, its progress and title
+func epicHeaderRow(e *epicSummary, project string, showActivity bool) table.Row {
+	row := table.Row{e.ID, "epic", e.progress(), "", "", "", truncate(e.Title, 26), truncate(project, 12)}
+	if showActivity {
+		row = slices.Insert(row, 3, "")
+	}
+	return row
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/badri/wt/internal/session"
+)
+
+func TestGroupByEpic(t *testing.T) {
+	epicA := &epicSummary{ID: "app-e1", Title: "Auth revamp", Done: 2, Total: 5}
+	epicB := &epicSummary{ID: "app-e0"}
+	entries := []ListSessionEntry{
+		{Name: "toast", Epic: epicA},
+		{Name: "solo"},
+		{Name: "ash", Epic: epicA},
+		{Name: "crane", Epic: epicB},
+	}
+
+	var got []string
+	for _, item := range groupByEpic(entries) {
+		if item.header != nil {
+			got = append(got, "epic "+item.header.ID)
+			continue
+		}
+		got = append(got, item.prefix+item.entry.Name)
+	}
+	want := []string{"solo", "epic app-e0", "└ crane", "epic app-e1", "├ ash", "└ toast"}
+	if len(got) != len(want) {
+		t.Fatalf("groupByEpic() = %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("groupByEpic()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestEpicSummaryLabel(t *testing.T) {
+	e := &epicSummary{ID: "app-e1", Title: "Auth revamp", Done: 2, Total: 5}
+	if got := e.label(); got != "app-e1 Auth revamp (2/5 done)" {
+		t.Errorf("label() = %q", got)
+	}
+	if got := (&epicSummary{ID: "app-e1"}).label(); got != "app-e1" {
+		t.Errorf("label() without details = %q", got)
+	}
+}
+
+func TestEpicSessions(t *testing.T) {
+	state := &session.State{Sessions: map[string]*session.Session{
+		"toast":      {Bead: "app-c1", Epic: "app-e1"},
+		"auto-appe1": {Bead: "app-e1"}, // recorded before epics were tracked
+		"ash":        {Bead: "app-c2", Epic: "app-e2"},
+		"crane":      {Bead: "app-c3"},
+	}}
+	got := epicSessions(state, "app-e1")
+	if len(got) != 2 || got[0] != "auto-appe1" || got[1] != "toast" {
+		t.Errorf("epicSessions() = %v, want [auto-appe1 toast]", got)
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/events"
+)
+
+// cmdEventsCompact applies the events retention policy now, rather than on
+// the next logged event
+func cmdEventsCompact(cfg *config.Config, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("unexpected argument: %s", args[0])
+	}
+	result, err := events.NewLogger(cfg).Compact()
+	if err != nil {
+		return err
+	}
+	if outputJSON {
+		printJSON(result)
+		return nil
+	}
+
+	fmt.Printf("Policy: %s\n", retentionPolicy(cfg))
+	if result.Rotated != nil {
+		fmt.Printf("Rotated events.jsonl into %s (%s)\n", filepath.Base(result.Rotated.Path), formatSize(result.Rotated.Size))
+	}
+	for _, a := range result.Removed {
+		fmt.Printf("Removed %s\n", filepath.Base(a.Path))
+	}
+	if result.Rotated == nil && len(result.Removed) == 0 {
+		fmt.Println("Nothing to compact.")
+	}
+	var total int64
+	for _, a := range result.Archives {
+		total += a.Size
+	}
+	fmt.Printf("Archives: %d (%s)\n", len(result.Archives), formatSize(total))
+	return nil
+}
+
+// retentionPolicy describes the configured rotation and retention
+func retentionPolicy(cfg *config.Config) string {
+	rotate := "never rotate on size"
+	if n := cfg.EventsMaxSize(); n > 0 {
+		rotate = "rotate past " + formatSize(n)
+	}
+	keep := "keep archives forever"
+	if cfg.Events != nil && cfg.EventsMaxAge() > 0 {
+		keep = "keep " + cfg.Events.MaxAge
+	}
+	return rotate + ", " + keep
+}
+
+// formatSize renders a byte count in KB/MB/GB (powers of 1024)
+func formatSize(n int64) string {
+	switch {
+	case n >= 1<<30:
+		return fmt.Sprintf("%.1f GB", float64(n)/(1<<30))
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", n)
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/events"
+	"github.com/badri/wt/internal/session"
+)
+
+// emitArgs are the arguments of 'wt events emit'
+type emitArgs struct {
+	name    string
+	data    string
+	note    string
+	session string
+	bead    string
+	project string
+}
+
+func parseEmitArgs(args []string) (emitArgs, error) {
+	var ea emitArgs
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch arg {
+		case "--data", "--note", "--session", "-s", "--bead", "-b", "--project", "-p":
+			if i+1 >= len(args) {
+				return ea, fmt.Errorf("%s requires a value", arg)
+			}
+			i++
+			switch arg {
+			case "--data":
+				ea.data = args[i]
+			case "--note":
+				ea.note = args[i]
+			case "--session", "-s":
+				ea.session = args[i]
+			case "--bead", "-b":
+				ea.bead = args[i]
+			case "--project", "-p":
+				ea.project = args[i]
+			}
+		default:
+			if strings.HasPrefix(arg, "-") {
+				return ea, fmt.Errorf("unknown flag: %s", arg)
+			}
+			if ea.name != "" {
+				return ea, fmt.Errorf("unexpected argument: %s", arg)
+			}
+			ea.name = arg
+		}
+	}
+	if ea.name == "" {
+		return ea, fmt.Errorf("usage: wt events emit <name> [--data <json>] [--note <text>]")
+	}
+	return ea, nil
+}
+
+// cmdEventsEmit logs a custom event, namespaced under "custom.", so hooks
+// and scripts can put their own milestones in the events feed. The session,
+// bead and project default to the hook's WT_* variables, or the session
+// whose worktree is the current directory.
+func cmdEventsEmit(cfg *config.Config, args []string) error {
+	ea, err := parseEmitArgs(args)
+	if err != nil {
+		return err
+	}
+	eventType, err := events.CustomType(ea.name)
+	if err != nil {
+		return err
+	}
+
+	if ea.session == "" {
+		ea.session = os.Getenv("WT_SESSION")
+	}
+	if ea.bead == "" {
+		ea.bead = os.Getenv("WT_BEAD")
+	}
+	if ea.project == "" {
+		ea.project = os.Getenv("WT_PROJECT")
+	}
+	if state, err := session.LoadState(cfg); err == nil {
+		sess := state.Sessions[ea.session]
+		if ea.session == "" {
+			ea.session, sess = sessionInCwd(state)
+		}
+		if sess != nil {
+			if ea.bead == "" {
+				ea.bead = sess.Bead
+			}
+			if ea.project == "" {
+				ea.project = sess.Project
+			}
+		}
+	}
+
+	var data json.RawMessage
+	if ea.data != "" {
+		data = json.RawMessage(ea.data)
+	}
+	if err := events.NewLogger(cfg).LogCustom(eventType, ea.session, ea.bead, ea.project, ea.note, data); err != nil {
+		return err
+	}
+	if outputJSON {
+		printJSON(map[string]string{"type": string(eventType), "session": ea.session, "bead": ea.bead, "project": ea.project})
+		return nil
+	}
+	target := ""
+	if ea.session != "" {
+		target = " on " + ea.session
+	} else if ea.bead != "" {
+		target = " on " + ea.bead
+	}
+	fmt.Printf("Emitted %s%s\n", eventType, target)
+	return nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/events"
+)
+
+func TestParseEmitArgs(t *testing.T) {
+	ea, err := parseEmitArgs([]string{"deploy-started", "--data", `{"env":"staging"}`, "-s", "toast", "--note", "staging"})
+	if err != nil {
+		t.Fatalf("parseEmitArgs() error: %v", err)
+	}
+	if ea.name != "deploy-started" || ea.data != `{"env":"staging"}` || ea.session != "toast" || ea.note != "staging" {
+		t.Errorf("parseEmitArgs() = %+v", ea)
+	}
+
+	for _, args := range [][]string{{}, {"--data"}, {"a", "b"}, {"a", "--env", "x"}} {
+		if _, err := parseEmitArgs(args); err == nil {
+			t.Errorf("parseEmitArgs(%q) should fail", args)
+		}
+	}
+}
+
+func TestCmdEventsEmit(t *testing.T) {
+	cfg, err := config.LoadFromDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("WT_SESSION", "toast")
+	t.Setenv("WT_BEAD", "api-7")
+	t.Setenv("WT_PROJECT", "api")
+
+	if err := cmdEventsEmit(cfg, []string{"deploy-started", "--data", `{"env":"staging"}`}); err != nil {
+		t.Fatalf("cmdEventsEmit() error: %v", err)
+	}
+	if err := cmdEventsEmit(cfg, []string{"Deploy Started"}); err == nil {
+		t.Error("cmdEventsEmit() should reject an invalid name")
+	}
+
+	recent, err := events.NewLogger(cfg).Recent(5)
+	if err != nil || len(recent) != 1 {
+		t.Fatalf("Recent() = %v, %v", recent, err)
+	}
+	e := recent[0]
+	if e.Type != "custom.deploy-started" || e.Session != "toast" || e.Bead != "api-7" || e.Project != "api" || string(e.Data) != `{"env":"staging"}` {
+		t.Errorf("unexpected event: %+v", e)
+	}
+}
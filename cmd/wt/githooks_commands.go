@@ -0,0 +1,139 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/githooks"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/project"
+	"github.com/badri/wt/internal/session"
+)
+
+// installGitHooks installs the project's git hooks into a new worktree.
+// Failures are reported as warnings so they never block session creation.
+func installGitHooks(proj *project.Project, worktreePath string, vars githooks.Vars) {
+	if !githooks.Enabled(proj) {
+		return
+	}
+	installed, enabled, err := githooks.Install(proj, worktreePath, vars)
+	if enabled {
+		printWorktreeConfigEnabled(proj)
+	}
+	if err != nil {
+		logging.Warnf("could not install git hooks: %v", err)
+		return
+	}
+	sort.Strings(installed)
+	logging.Infof("Installed git hooks: %s", strings.Join(installed, ", "))
+}
+
+// printWorktreeConfigEnabled tells the user that installing hooks turned on
+// extensions.worktreeConfig in the project's repo
+func printWorktreeConfigEnabled(proj *project.Project) {
+	fmt.Printf("Enabled extensions.worktreeConfig in %s so each worktree can have its own hooks.\n", proj.RepoPath())
+	fmt.Println("  Git now also reads config.worktree files in that repo's worktrees.")
+}
+
+// cmdProjectHooks handles 'wt project hooks install|show'
+func cmdProjectHooks(cfg *config.Config, mgr *project.Manager, args []string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("usage: wt project hooks <install|show> <project> [session]")
+	}
+
+	proj, err := mgr.Get(args[1])
+	if err != nil {
+		return err
+	}
+	if !githooks.Enabled(proj) {
+		fmt.Printf("No git hooks configured for project '%s'.\n", proj.Name)
+		fmt.Printf("Add a \"git_hooks\" section with 'wt project config %s'.\n", proj.Name)
+		return nil
+	}
+
+	state, err := session.LoadState(cfg)
+	if err != nil {
+		return err
+	}
+
+	// Sessions to act on: the named one, or every session of the project
+	var names []string
+	if len(args) > 2 {
+		sess, exists := state.Sessions[args[2]]
+		if !exists {
+			return fmt.Errorf("session '%s' not found", args[2])
+		}
+		if sess.Project != proj.Name {
+			return fmt.Errorf("session '%s' belongs to project '%s', not '%s'", args[2], sess.Project, proj.Name)
+		}
+		names = []string{args[2]}
+	} else {
+		for name, sess := range state.Sessions {
+			if sess.Project == proj.Name {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+	}
+
+	switch args[0] {
+	case "install":
+		if len(names) == 0 {
+			fmt.Printf("No active sessions for project '%s'. Hooks will be installed into new worktrees.\n", proj.Name)
+			return nil
+		}
+		first := state.Sessions[names[0]]
+		if !githooks.WorktreeConfigEnabled(first.Worktree) && stdinIsTerminal() &&
+			!confirm(fmt.Sprintf("Installing hooks turns on extensions.worktreeConfig in %s. Continue?", proj.RepoPath()), true) {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+		for _, name := range names {
+			sess := state.Sessions[name]
+			installed, enabled, err := githooks.Install(proj, sess.Worktree, gitHookVars(name, sess))
+			if enabled {
+				printWorktreeConfigEnabled(proj)
+			}
+			if err != nil {
+				logging.Warnf("%s: %v", name, err)
+				continue
+			}
+			sort.Strings(installed)
+			fmt.Printf("%s: installed %s\n", name, strings.Join(installed, ", "))
+		}
+		return nil
+	case "show":
+		// Show hooks as rendered for a session, or with placeholders left in
+		vars := githooks.Vars{BeadID: "{BEAD_ID}", Session: "{SESSION}", Project: proj.Name, Branch: "{BRANCH}"}
+		if len(args) > 2 {
+			vars = gitHookVars(names[0], state.Sessions[names[0]])
+		}
+		scripts := githooks.Render(proj, vars, "")
+		hookNames := make([]string, 0, len(scripts))
+		for name := range scripts {
+			hookNames = append(hookNames, name)
+		}
+		sort.Strings(hookNames)
+		for i, name := range hookNames {
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Printf("=== %s ===\n%s", name, scripts[name])
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown hooks command: %s (expected install or show)%s", args[0], didYouMean(args[0], []string{"install", "show"}))
+	}
+}
+
+// gitHookVars returns the hook template values for a session
+func gitHookVars(name string, sess *session.Session) githooks.Vars {
+	return githooks.Vars{
+		BeadID:  sess.Bead,
+		Session: name,
+		Project: sess.Project,
+		Branch:  sess.Branch,
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/badri/wt/internal/project"
+)
+
+// cmdProjectGuidelines maintains a project's WORKERS.md, the orchestration
+// rules added to every worker prompt of the project
+func cmdProjectGuidelines(mgr *project.Manager, args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("usage: wt project guidelines <edit|show|path> <name>")
+	}
+	proj, err := mgr.Get(args[1])
+	if err != nil {
+		return err
+	}
+	switch args[0] {
+	case "edit":
+		return editGuidelines(proj)
+	case "show":
+		guidelines := proj.Guidelines()
+		if guidelines == "" {
+			printEmptyMessage(fmt.Sprintf("Project '%s' has no worker guidelines.", proj.Name),
+				fmt.Sprintf("Write them with: wt project guidelines edit %s", proj.Name))
+			return nil
+		}
+		fmt.Println(guidelines)
+		return nil
+	case "path":
+		fmt.Println(proj.GuidelinesPath())
+		return nil
+	default:
+		return fmt.Errorf("unknown guidelines command: %s%s", args[0], didYouMean(args[0], []string{"edit", "show", "path"}))
+	}
+}
+
+// editGuidelines opens WORKERS.md in $EDITOR, starting from a template when
+// the project has none. Emptying the file removes the guidelines.
+func editGuidelines(proj *project.Project) error {
+	path := proj.GuidelinesPath()
+	initial := project.GuidelinesTemplate
+	if data, err := os.ReadFile(path); err == nil {
+		initial = string(data)
+	}
+
+	edited, err := editText(initial)
+	if err != nil {
+		return fmt.Errorf("running editor: %w", err)
+	}
+	if strings.TrimSpace(edited) == "" || edited == project.GuidelinesTemplate {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("removing guidelines: %w", err)
+		}
+		fmt.Printf("Project '%s' has no worker guidelines.\n", proj.Name)
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(edited), 0644); err != nil {
+		return fmt.Errorf("saving guidelines: %w", err)
+	}
+	fmt.Printf("✓ Saved worker guidelines for '%s' (%s)\n", proj.Name, path)
+	fmt.Println("  New sessions and wt auto beads of the project get them in their prompt.")
+	return nil
+}
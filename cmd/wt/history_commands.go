@@ -0,0 +1,292 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/events"
+	"github.com/badri/wt/internal/project"
+	"github.com/badri/wt/internal/session"
+)
+
+// commandTarget is how a recorded command names the session it acts on
+type commandTarget int
+
+const (
+	targetBead    commandTarget = iota // first argument is a bead ID
+	targetSession                      // first argument is a session (or bead), else the current session
+	targetCurrent                      // run from inside the session
+)
+
+// recordedCommands are the commands logged to their bead's history
+var recordedCommands = map[string]commandTarget{
+	"new":      targetBead,
+	"nudge":    targetSession,
+	"ack":      targetSession,
+	"kill":     targetSession,
+	"close":    targetSession,
+	"pause":    targetSession,
+	"resume":   targetSession,
+	"unblock":  targetSession,
+	"retarget": targetSession,
+	"clone":    targetSession,
+	"verify":   targetSession,
+	"rollback": targetSession,
+	"signal":   targetCurrent,
+	"done":     targetCurrent,
+	"abandon":  targetCurrent,
+	"block":    targetCurrent,
+	"split":    targetCurrent,
+	"depend":   targetCurrent,
+}
+
+// commandRecord is a recorded command being run
+type commandRecord struct {
+	cfg     *config.Config
+	args    []string
+	target  commandTarget
+	started time.Time
+	session string
+	bead    string
+	project string
+}
+
+// startCommandRecord resolves the bead a command acts on before it runs,
+// since commands like 'wt done' remove the session. It returns nil for
+// commands that aren't recorded.
+func startCommandRecord(cfg *config.Config, args []string) *commandRecord {
+	if len(args) == 0 || hasHelpFlag(args[1:]) {
+		return nil
+	}
+	target, ok := recordedCommands[args[0]]
+	if !ok {
+		return nil
+	}
+	r := &commandRecord{cfg: cfg, args: args, target: target, started: time.Now()}
+
+	arg := ""
+	if len(args) > 1 && !strings.HasPrefix(args[1], "-") {
+		arg = args[1]
+	}
+	if target == targetBead {
+		r.bead = arg
+		if proj, err := project.NewManager(cfg).FindByBeadPrefix(arg); err == nil {
+			r.project = proj.Name
+		}
+		return r
+	}
+
+	state, err := session.LoadState(cfg)
+	if err != nil {
+		return nil
+	}
+	var name string
+	var sess *session.Session
+	if target == targetSession && arg != "" {
+		name, sess = findSessionByNameOrBead(state, arg)
+		if sess == nil {
+			// verify and rollback also take the bead of a finished session
+			if proj, err := project.NewManager(cfg).FindByBeadPrefix(arg); err == nil {
+				r.bead, r.project = arg, proj.Name
+			}
+			return r
+		}
+	} else {
+		name, sess = currentNoteSession(state)
+	}
+	if sess != nil {
+		r.session, r.bead, r.project = name, sess.Bead, sess.Project
+	}
+	return r
+}
+
+// finish logs the command to its bead's history, with its error
+func (r *commandRecord) finish(cmdErr error) {
+	if r == nil {
+		return
+	}
+	if r.target == targetBead && cmdErr == nil {
+		if state, err := session.LoadState(r.cfg); err == nil {
+			r.session, _ = findSessionByNameOrBead(state, r.bead)
+		}
+	}
+	if r.bead == "" {
+		return
+	}
+	failure := ""
+	if cmdErr != nil {
+		failure = cmdErr.Error()
+	}
+	events.NewLogger(r.cfg).LogCommand(r.session, r.bead, r.project, commandLine(r.args), r.started, failure)
+}
+
+// commandLine renders wt's arguments as a command to paste into a shell
+func commandLine(args []string) string {
+	words := []string{"wt"}
+	for _, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t\n'\"\\$`!*?;&|<>(){}[]#~") {
+			arg = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+		}
+		words = append(words, arg)
+	}
+	return strings.Join(words, " ")
+}
+
+// cmdHistory shows everything wt recorded about a bead, oldest first
+func cmdHistory(cfg *config.Config, args []string) error {
+	var query string
+	commandsOnly := false
+	for _, arg := range args {
+		switch {
+		case arg == "--commands", arg == "-c":
+			commandsOnly = true
+		case strings.HasPrefix(arg, "-"):
+			return fmt.Errorf("unknown flag: %s", arg)
+		case query != "":
+			return fmt.Errorf("unexpected argument: %s", arg)
+		default:
+			query = arg
+		}
+	}
+	if query == "" {
+		return cmdHistoryHelp()
+	}
+
+	// A live session's name stands for its bead
+	beadID := query
+	if state, err := session.LoadState(cfg); err == nil {
+		if _, sess := findSessionByNameOrBead(state, query); sess != nil && sess.Bead != "" {
+			beadID = sess.Bead
+		}
+	}
+
+	all, err := events.NewLogger(cfg).All()
+	if err != nil {
+		return fmt.Errorf("reading events: %w", err)
+	}
+	history := sortedHistory(beadHistory(all, beadID), commandsOnly)
+
+	if len(history) == 0 {
+		printEmptyMessage(fmt.Sprintf("No history for %s.", beadID), "")
+		return nil
+	}
+	if outputJSON {
+		printJSON(history)
+		return nil
+	}
+	if commandsOnly {
+		printCommandScript(beadID, history)
+		return nil
+	}
+
+	fmt.Printf("History of %s\n\n", beadID)
+	for _, e := range history {
+		printHistoryEvent(&e)
+	}
+	return nil
+}
+
+// sortedHistory orders a bead's events by time, keeping log order for
+// events in the same second. Command events carry their start time, so
+// they can come later in the log than events they led to.
+func sortedHistory(history []events.Event, commandsOnly bool) []events.Event {
+	var sorted []events.Event
+	for _, e := range history {
+		if !commandsOnly || e.Type == events.EventCommand {
+			sorted = append(sorted, e)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		ti, erri := time.Parse(time.RFC3339, sorted[i].Time)
+		tj, errj := time.Parse(time.RFC3339, sorted[j].Time)
+		if erri != nil || errj != nil {
+			return false
+		}
+		return ti.Before(tj)
+	})
+	return sorted
+}
+
+func printHistoryEvent(e *events.Event) {
+	line := fmt.Sprintf("%s %s ", formatShowTime(e.Time), getEventIcon(e.Type))
+	if e.Type == events.EventCommand {
+		line += e.Note
+		if e.Session != "" {
+			line += "  (" + e.Session + ")"
+		}
+		fmt.Println(line)
+		if e.Error != "" {
+			fmt.Printf("    failed: %s\n", e.Error)
+		}
+		return
+	}
+
+	line += string(e.Type)
+	if e.Session != "" {
+		line += "  " + e.Session
+	}
+	if e.MergeMode != "" {
+		line += " (" + e.MergeMode + ")"
+	}
+	fmt.Println(line)
+	for _, detail := range []string{e.Note, e.PRURL, e.Permission} {
+		if detail != "" {
+			fmt.Printf("    %s\n", detail)
+		}
+	}
+	if e.Blocker != "" {
+		fmt.Printf("    waiting on %s\n", e.Blocker)
+	}
+}
+
+// printCommandScript prints the commands run on a bead as a shell script,
+// each after a comment saying when and on which session it ran
+func printCommandScript(beadID string, history []events.Event) {
+	fmt.Printf("# wt commands run on %s\n", beadID)
+	for _, e := range history {
+		comment := "# " + e.Time
+		if e.Session != "" {
+			comment += " " + e.Session
+		}
+		if e.Error != "" {
+			comment += " (failed: " + strings.ReplaceAll(e.Error, "\n", " ") + ")"
+		}
+		fmt.Println(comment)
+		fmt.Println(e.Note)
+	}
+}
+
+func cmdHistoryHelp() error {
+	help := `wt history - Show what wt did with a bead, step by step
+
+USAGE:
+    wt history <bead|session> [options]
+
+DESCRIPTION:
+    Lists the bead's events in the order they happened, for reconstructing
+    what the orchestration did after an autonomous change went wrong: the
+    wt commands run on it, such as new, nudge, signal, block, done and
+    kill, with the session they acted on and any error, together with its
+    session starts and ends, PRs, notes and comments.
+
+    Commands are recorded whether a person, the hub, a worker or wt auto
+    ran them; events from rotated logs are included. A command is timed by
+    when it started.
+
+OPTIONS:
+    -c, --commands      Print only the commands, as a shell script to read
+                        or replay step by step
+    --json              Output as JSON
+    -h, --help          Show this help
+
+EXAMPLES:
+    wt history myapp-abc              Timeline of the bead
+    wt history toast                  Timeline of the bead toast works on
+    wt history myapp-abc --commands   The wt commands it took
+`
+	fmt.Print(help)
+	return nil
+}
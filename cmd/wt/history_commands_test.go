@@ -0,0 +1,91 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/events"
+	"github.com/badri/wt/internal/session"
+)
+
+func TestCommandLine(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"new", "api-7", "--no-switch"}, "wt new api-7 --no-switch"},
+		{[]string{"signal", "ready", "tests pass"}, "wt signal ready 'tests pass'"},
+		{[]string{"nudge", "toast", "--prompt", "custom", "don't stop"}, `wt nudge toast --prompt custom 'don'\''t stop'`},
+		{[]string{"ack", "toast", ""}, "wt ack toast ''"},
+	}
+	for _, tt := range tests {
+		if got := commandLine(tt.args); got != tt.want {
+			t.Errorf("commandLine(%q) = %s, want %s", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestSortedHistory(t *testing.T) {
+	history := []events.Event{
+		{Time: "2026-01-02T10:05:00Z", Type: events.EventSessionStart},
+		{Time: "2026-01-02T10:00:00Z", Type: events.EventCommand, Note: "wt new api-7"},
+		{Time: "2026-01-02T10:30:00Z", Type: events.EventCommand, Note: "wt done"},
+		{Time: "2026-01-02T10:30:00Z", Type: events.EventSessionEnd},
+	}
+
+	got := sortedHistory(history, false)
+	var types []events.EventType
+	for _, e := range got {
+		types = append(types, e.Type)
+	}
+	want := []events.EventType{events.EventCommand, events.EventSessionStart, events.EventCommand, events.EventSessionEnd}
+	if len(types) != len(want) {
+		t.Fatalf("sortedHistory() = %v, want %v", types, want)
+	}
+	for i := range want {
+		if types[i] != want[i] {
+			t.Fatalf("sortedHistory() = %v, want %v", types, want)
+		}
+	}
+
+	if got := sortedHistory(history, true); len(got) != 2 || got[0].Note != "wt new api-7" || got[1].Note != "wt done" {
+		t.Errorf("sortedHistory(commands only) = %+v", got)
+	}
+}
+
+func TestCommandRecord(t *testing.T) {
+	cfg, err := config.LoadFromDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	state, err := session.LoadState(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state.Sessions["toast"] = &session.Session{Bead: "api-7", Project: "api"}
+	if err := state.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, args := range [][]string{{"list"}, {"nudge", "--help"}} {
+		if r := startCommandRecord(cfg, args); r != nil {
+			t.Errorf("startCommandRecord(%q) = %+v, want nil", args, r)
+		}
+	}
+
+	startCommandRecord(cfg, []string{"nudge", "toast"}).finish(nil)
+	startCommandRecord(cfg, []string{"kill", "api-7"}).finish(errors.New("session has unsaved work"))
+	startCommandRecord(cfg, []string{"nudge", "shadow"}).finish(nil) // no such session or bead
+
+	recent, err := events.NewLogger(cfg).Recent(5)
+	if err != nil || len(recent) != 2 {
+		t.Fatalf("Recent() = %+v, %v, want 2 events", recent, err)
+	}
+	if e := recent[0]; e.Type != events.EventCommand || e.Note != "wt nudge toast" || e.Session != "toast" || e.Bead != "api-7" || e.Project != "api" || e.Error != "" {
+		t.Errorf("nudge event = %+v", e)
+	}
+	if e := recent[1]; e.Note != "wt kill api-7" || e.Session != "toast" || e.Error != "session has unsaved work" {
+		t.Errorf("kill event = %+v", e)
+	}
+}
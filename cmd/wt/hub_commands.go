@@ -3,7 +3,6 @@ package main
 import (
 	"fmt"
 	"os"
-	"os/exec"
 	"strings"
 	"time"
 
@@ -14,15 +13,29 @@ import (
 	"github.com/badri/wt/internal/events"
 	"github.com/badri/wt/internal/handoff"
 	"github.com/badri/wt/internal/hub"
+	"github.com/badri/wt/internal/logging"
 	"github.com/badri/wt/internal/monitor"
 	"github.com/badri/wt/internal/project"
 	"github.com/badri/wt/internal/session"
+	"github.com/badri/wt/internal/summary"
 	"github.com/badri/wt/internal/tmux"
 )
 
 // cmdHub creates or attaches to the dedicated hub session
 func cmdHub(cfg *config.Config, args []string) error {
+	if len(args) > 0 {
+		switch args[0] {
+		case "note":
+			return cmdHubNote(cfg, args[1:])
+		case "log":
+			return cmdHubLog(cfg, args[1:])
+		}
+	}
 	opts := parseHubFlags(args)
+	if opts.Status && outputJSON {
+		printJSON(handoff.CollectSnapshot(cfg))
+		return nil
+	}
 	return hub.Run(cfg, opts)
 }
 
@@ -32,6 +45,8 @@ func cmdHubHelp() error {
 
 USAGE:
     wt hub [options]
+    wt hub note "<decision>" [--bead <id>] [--session <name>]
+    wt hub log [-n <count>] [--since <duration>] [--bead <id>] [--session <name>] [--grep <text>]
 
 DESCRIPTION:
     The hub is a dedicated tmux session for orchestrating worker sessions.
@@ -42,18 +57,36 @@ OPTIONS:
     -w, --watch         Add watch pane when attaching to existing hub
     --no-watch          Create hub without watch pane
     -d, --detach        Detach from hub (return to previous session)
-    -s, --status        Show hub status without attaching
+    -s, --status        Show hub status without attaching. With --json, a
+                        snapshot of everything the hub oversees: active
+                        sessions with their status and last signal, ready
+                        beads per project, running wt auto runs and pending
+                        handoffs (also shown to the hub's Claude by wt prime)
     -k, --kill          Kill the hub session
     -f, --force         Skip confirmation when killing
     -h, --help          Show this help
 
+DECISION JOURNAL:
+    note "<decision>"   Record an orchestration decision and its rationale.
+                        -b/--bead and -s/--session say what it is about.
+                        The last 20 decisions are included in handoff
+                        context and in wt prime output for the hub, so a
+                        fresh hub knows why things are the way they are.
+    log                 Show recorded decisions, oldest first (default: the
+                        last 20). Filter with -n/--count, --since (e.g. 2h,
+                        3d), -b/--bead, -s/--session and -g/--grep; --json
+                        for scripts.
+
 EXAMPLES:
     wt hub                  Create or attach to hub
     wt hub --no-watch       Create hub without watch pane
     wt hub --watch          Attach and add watch pane if missing
     wt hub --status         Check if hub is running
+    wt hub --status --json  Structured snapshot for scripts and the hub
     wt hub --kill           Terminate hub session
     wt hub --detach         Switch back to previous session
+    wt hub note "Holding proj-12 until the API PR merges" --bead proj-12
+    wt hub log --since 1d   Decisions of the last day
 `
 	fmt.Print(help)
 	return nil
@@ -104,6 +137,10 @@ DESCRIPTION:
     When run from the hub session, the TUI runs directly.
     When run from a worker session, it opens as a tmux popup overlay.
 
+    Workers stopped at a Claude permission dialog are flagged as stuck
+    on "permission" and notified; tool uses matching the project's
+    auto_approve rules are approved (see 'wt project --help').
+
 KEYBOARD:
     ↑/k, ↓/j           Navigate between sessions
     Enter              Switch to selected session (watch keeps running)
@@ -117,12 +154,27 @@ STATUS INDICATORS:
     ● red              Blocked or Error
 
 OPTIONS:
-    --auto-nudge       Enable auto-nudge for stuck/idle sessions
-    -h, --help         Show this help
+    --auto-nudge            Enable auto-nudge for stuck/idle sessions
+    -p, --project <names>   Only show sessions from these projects (comma-separated)
+    -s, --status <names>    Only show sessions with these statuses, e.g. ready,blocked
+    --compact               One short line per session, no detail card
+    --wide                  Table grouped by project with bead, idle time and message
+    --sort <key>            Order sessions by created (default), name, project,
+                            status, duration or idle (see 'wt list --help');
+                            the wide layout still groups by project
+    --reverse               Reverse the order
+    --append                Print a log line per session change instead of the
+                            dashboard; never clears the screen, so it can be
+                            redirected to a file
+    -h, --help              Show this help
 
 EXAMPLES:
-    wt watch                Start the watch dashboard
-    wt watch --auto-nudge   Start with auto-nudge enabled
+    wt watch                          Start the watch dashboard
+    wt watch --auto-nudge             Start with auto-nudge enabled
+    wt watch --wide                   Sessions grouped by project
+    wt watch -p myapp -s ready        Only myapp sessions ready for review
+    wt watch --sort status            Blocked and ready sessions on top
+    wt watch --append >> watch.log    Log session changes to a file
     wt hub --watch          Attach to hub and ensure watch pane exists
 `
 	fmt.Print(help)
@@ -138,7 +190,9 @@ USAGE:
 
 DESCRIPTION:
     Lists or interacts with completed/archived sessions.
-    Useful for understanding past decisions or resuming work.
+    Useful for understanding past decisions or resuming work. The list
+    shows what each session did: its summary, or the worker's last
+    message when it ended.
 
 ARGUMENTS:
     [name]              Session name to interact with (optional)
@@ -146,6 +200,11 @@ ARGUMENTS:
 OPTIONS:
     --spawn             Spawn new tmux session for seance
     -p, --prompt <msg>  One-shot query to past session
+    --export            Write the session's Claude transcript to a file
+    --format <md|json>  Export format (default: md)
+    -o, --output <file> Export file (default: <name>-transcript.<format>,
+                        "-" for stdout)
+    --attach            Also add the transcript to the bead as a work log
     -h, --help          Show this help
 
 EXAMPLES:
@@ -153,6 +212,9 @@ EXAMPLES:
     wt seance mysession                 Resume in new tmux pane
     wt seance mysession --spawn         Spawn new tmux session
     wt seance mysession -p "Why this?"  Ask about a decision
+    wt seance mysession --export        Save the transcript as markdown
+    wt seance mysession --export --format json -o run.json
+    wt seance mysession --export --attach   Keep it on the bead too
 `
 	fmt.Print(help)
 	return nil
@@ -161,60 +223,59 @@ EXAMPLES:
 // cmdWatch displays a live dashboard of all sessions using the TUI.
 // When run from a worker session (not hub), it uses tmux popup to show the watch.
 func cmdWatch(cfg *config.Config, args []string) error {
-	autoNudge := false
-	for _, arg := range args {
-		if arg == "--auto-nudge" {
-			autoNudge = true
-		}
+	opts, err := parseWatchFlags(args)
+	if err != nil {
+		return err
 	}
 
-	// If we're already in a popup context, run directly
-	if os.Getenv("WT_WATCH_POPUP") == "1" {
-		return cmdWatchTUI(cfg, autoNudge)
+	// If we're already in a popup context, or logging, run directly
+	if os.Getenv("WT_WATCH_POPUP") == "1" || opts.append {
+		return cmdWatchTUI(cfg, opts)
 	}
 
 	// Not in tmux at all - run TUI directly
 	if os.Getenv("TMUX") == "" {
-		return cmdWatchTUI(cfg, autoNudge)
+		return cmdWatchTUI(cfg, opts)
 	}
 
 	// Check if we're in the hub session
 	if hub.IsInHub() {
 		// In hub - run TUI directly
-		return cmdWatchTUI(cfg, autoNudge)
+		return cmdWatchTUI(cfg, opts)
 	}
 
 	// Check if we're in a wt worker session
 	state, err := session.LoadState(cfg)
 	if err != nil {
 		// Can't determine - run TUI directly
-		return cmdWatchTUI(cfg, autoNudge)
+		return cmdWatchTUI(cfg, opts)
 	}
 
 	// Get current tmux session name
 	currentSession := tmux.CurrentSession()
 	if currentSession == "" {
 		// Not in a named session - run TUI directly
-		return cmdWatchTUI(cfg, autoNudge)
+		return cmdWatchTUI(cfg, opts)
 	}
 
 	// Check if current session is a wt worker
 	if _, isWorker := state.Sessions[currentSession]; isWorker {
 		// In worker session - use tmux popup for overlay
-		return cmdWatchPopup()
+		return cmdWatchPopup(args)
 	}
 
 	// In some other tmux session (not wt-related) - run TUI directly
-	return cmdWatchTUI(cfg, autoNudge)
+	return cmdWatchTUI(cfg, opts)
 }
 
 // cmdWatchPopup shows watch in a tmux popup overlay
-func cmdWatchPopup() error {
+func cmdWatchPopup(args []string) error {
 	// Use tmux popup to show watch as a floating overlay
 	// -E closes popup when command exits
 	// -w and -h set the size
 	// Set WT_WATCH_POPUP to prevent recursion
-	cmd := exec.Command("tmux", "popup", "-E", "-w", "50%", "-h", "80%", "-e", "WT_WATCH_POPUP=1", "wt", "watch")
+	popupArgs := append([]string{"popup", "-E", "-w", "50%", "-h", "80%", "-e", "WT_WATCH_POPUP=1", "wt", "watch"}, args...)
+	cmd := logging.Command("tmux", popupArgs...)
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
@@ -365,7 +426,8 @@ func cmdSeance(cfg *config.Config, args []string) error {
 	// Parse flags
 	sessionName := args[0]
 	var prompt string
-	var spawn bool
+	var spawn, export bool
+	exportOpts := seanceExportOptions{format: "md"}
 	for i := 1; i < len(args); i++ {
 		switch args[i] {
 		case "-p":
@@ -375,6 +437,20 @@ func cmdSeance(cfg *config.Config, args []string) error {
 			}
 		case "--spawn":
 			spawn = true
+		case "--export":
+			export = true
+		case "--format":
+			if i+1 < len(args) {
+				exportOpts.format = args[i+1]
+				i++
+			}
+		case "-o", "--output":
+			if i+1 < len(args) {
+				exportOpts.output = args[i+1]
+				i++
+			}
+		case "--attach":
+			exportOpts.attach = true
 		}
 	}
 
@@ -388,6 +464,10 @@ func cmdSeance(cfg *config.Config, args []string) error {
 		return fmt.Errorf("session '%s' has no Claude session ID recorded", sessionName)
 	}
 
+	if export {
+		return cmdSeanceExport(cfg, event, exportOpts)
+	}
+
 	if prompt != "" {
 		// One-shot query
 		return cmdSeanceQuery(event, prompt)
@@ -417,7 +497,8 @@ func cmdSeanceList(cfg *config.Config, logger *events.Logger) error {
 	columns := []table.Column{
 		{Title: "", Width: 2},
 		{Title: "Session", Width: 18},
-		{Title: "Title", Width: 36},
+		{Title: "Title", Width: 28},
+		{Title: "What happened", Width: 44},
 		{Title: "Project", Width: 14},
 		{Title: "Time", Width: 16},
 	}
@@ -425,6 +506,13 @@ func cmdSeanceList(cfg *config.Config, logger *events.Logger) error {
 	// Cache project configs to avoid repeated lookups
 	projectMgr := project.NewManager(cfg)
 
+	allNotes, err := logger.Notes()
+	if err != nil {
+		logging.Warnf("could not read notes: %v", err)
+	}
+	type sessionNote struct{ session, text string }
+	var notes []sessionNote
+
 	// Build rows
 	var rows []table.Row
 	for _, sess := range sessions {
@@ -459,16 +547,34 @@ func cmdSeanceList(cfg *config.Config, logger *events.Logger) error {
 			}
 		}
 
+		headline := summary.Headline(sess.Summary)
+		if sess.Type != events.EventHubHandoff {
+			sessNotes := sessionNotes(allNotes, sess.Session, sess.Bead, "", sess.Time)
+			for _, text := range sessNotes {
+				notes = append(notes, sessionNote{sess.Session, text})
+			}
+			if headline == "" && len(sessNotes) > 0 {
+				headline = "✎ " + sessNotes[len(sessNotes)-1]
+			}
+		}
+
 		rows = append(rows, table.Row{
 			icon,
 			truncate(sess.Session, 18),
-			truncate(title, 36),
+			truncate(title, 28),
+			truncate(headline, 44),
 			truncate(projectDisplay, 14),
 			timeStr,
 		})
 	}
 
 	printTable("Past Sessions (seance)", columns, rows)
+	if len(notes) > 0 {
+		fmt.Println("\nNotes:")
+		for _, n := range notes {
+			fmt.Printf("  %-18s %s\n", n.session, n.text)
+		}
+	}
 	fmt.Println("\n⚙️ = Worker session   🏠 = Hub session")
 	fmt.Println("\nCommands:")
 	fmt.Println("  wt seance <name>          Resume in new pane (safe from hub)")
@@ -480,9 +586,9 @@ func cmdSeanceList(cfg *config.Config, logger *events.Logger) error {
 
 func cmdSeanceResume(cfg *config.Config, event *events.Event) error {
 	if event.Type == events.EventHubHandoff {
-		fmt.Printf("Resuming hub session in new pane...\n")
+		logging.Infof("Resuming hub session in new pane...")
 	} else {
-		fmt.Printf("Resuming '%s' (bead: %s) in new pane...\n", event.Session, event.Bead)
+		logging.Infof("Resuming '%s' (bead: %s) in new pane...", event.Session, event.Bead)
 	}
 
 	// Use EditorCmd from config (defaults to "claude --dangerously-skip-permissions")
@@ -498,7 +604,7 @@ func cmdSeanceResume(cfg *config.Config, event *events.Event) error {
 		// Split editorCmd and add --resume flag
 		args := strings.Fields(editorCmd)
 		args = append(args, "--resume", event.ClaudeSession)
-		cmd := exec.Command(args[0], args[1:]...)
+		cmd := logging.Command(args[0], args[1:]...)
 		cmd.Stdin = os.Stdin
 		cmd.Stdout = os.Stdout
 		cmd.Stderr = os.Stderr
@@ -507,7 +613,7 @@ func cmdSeanceResume(cfg *config.Config, event *events.Event) error {
 
 	// Open in new tmux window and run claude --resume
 	resumeCmd := fmt.Sprintf("%s --resume %s", editorCmd, event.ClaudeSession)
-	cmd := exec.Command("tmux", "new-window", "-n", "seance", resumeCmd)
+	cmd := logging.Command("tmux", "new-window", "-n", "seance", resumeCmd)
 	return cmd.Run()
 }
 
@@ -522,8 +628,11 @@ func cmdSeanceSpawn(cfg *config.Config, event *events.Event) error {
 
 	// Check if session already exists (for hub, this is unlikely due to timestamp)
 	if tmux.SessionExists(sessionName) {
+		if !tmux.IsWTSession(sessionName) {
+			return fmt.Errorf("tmux session '%s' already exists and was not started by wt", sessionName)
+		}
 		fmt.Printf("Seance session '%s' already exists. Switching to it.\n", sessionName)
-		return tmux.Attach(sessionName)
+		return switchToSession(cfg, sessionName)
 	}
 
 	// Get working directory
@@ -532,7 +641,7 @@ func cmdSeanceSpawn(cfg *config.Config, event *events.Event) error {
 		workdir = event.WorktreePath
 	}
 
-	fmt.Printf("Spawning seance session '%s' for past session '%s'...\n", sessionName, event.Session)
+	logging.Infof("Spawning seance session '%s' for past session '%s'...", sessionName, event.Session)
 
 	// Use EditorCmd from config (defaults to "claude --dangerously-skip-permissions")
 	editorCmd := cfg.EditorCmd
@@ -549,10 +658,10 @@ func cmdSeanceSpawn(cfg *config.Config, event *events.Event) error {
 }
 
 func cmdSeanceQuery(event *events.Event, prompt string) error {
-	fmt.Printf("Querying Claude session for '%s'...\n", event.Session)
+	logging.Infof("Querying Claude session for '%s'...", event.Session)
 
 	// Run claude with --resume and --print for one-shot
-	cmd := exec.Command("claude", "--resume", event.ClaudeSession, "--print", prompt)
+	cmd := logging.Command("claude", "--resume", event.ClaudeSession, "--print", prompt)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 
@@ -568,7 +677,7 @@ func cmdHandoff(cfg *config.Config, args []string) error {
 		return fmt.Errorf("handoff requires running inside a tmux session")
 	}
 
-	fmt.Println("Performing handoff to fresh Claude instance...")
+	logging.Infof("Performing handoff to fresh Claude instance...")
 
 	result, err := handoff.Run(cfg, opts)
 	if err != nil {
@@ -585,8 +694,11 @@ func cmdHandoff(cfg *config.Config, args []string) error {
 	if result.MarkerWritten {
 		fmt.Println("  ✓ Handoff marker written")
 	}
+	if result.CheckpointSaved {
+		fmt.Println("  ✓ Worktree checkpoint saved")
+	}
 
-	fmt.Println("\nRespawning Claude...")
+	logging.Infof("\nRespawning Claude...")
 	return nil
 }
 
@@ -627,14 +739,14 @@ func cmdPrime(cfg *config.Config, args []string) error {
 	// Clear checkpoint after displaying (context was recovered)
 	if result.IsPostCompaction {
 		if err := handoff.ClearCheckpoint(); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: could not clear checkpoint: %v\n", err)
+			logging.Warnf("could not clear checkpoint: %v", err)
 		}
 	}
 
 	// Archive handoff file after displaying (renames handoff.md to handoff-<timestamp>.md)
 	if result.HandoffContent != "" {
 		if err := handoff.ClearHandoffContent(cfg); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: could not archive handoff file: %v\n", err)
+			logging.Warnf("could not archive handoff file: %v", err)
 		}
 	}
 
@@ -642,13 +754,15 @@ func cmdPrime(cfg *config.Config, args []string) error {
 }
 
 func parsePrimeFlags(args []string) *handoff.PrimeOptions {
-	opts := &handoff.PrimeOptions{}
+	opts := &handoff.PrimeOptions{Quiet: quietFlag}
 	for _, arg := range args {
 		switch arg {
 		case "-q", "--quiet":
 			opts.Quiet = true
 		case "--no-bd-prime":
 			opts.NoBdPrime = true
+		case "--no-status":
+			opts.NoStatus = true
 		case "--hook":
 			opts.HookMode = true
 		}
@@ -699,6 +813,7 @@ func cmdCheckpoint(cfg *config.Config, args []string) error {
 func parseCheckpointFlags(args []string) *handoff.CheckpointOptions {
 	opts := &handoff.CheckpointOptions{
 		Trigger: "manual",
+		Quiet:   quietFlag,
 	}
 	for i := 0; i < len(args); i++ {
 		switch args[i] {
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/handoff"
+)
+
+// parseHubNoteArgs separates --bead/--session from the words of the
+// decision
+func parseHubNoteArgs(args []string) (*handoff.Decision, error) {
+	d := &handoff.Decision{}
+	var words []string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--bead", "-b":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--bead requires a bead ID")
+			}
+			d.Bead = args[i+1]
+			i++
+		case "--session", "-s":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--session requires a session name")
+			}
+			d.Session = args[i+1]
+			i++
+		default:
+			words = append(words, args[i])
+		}
+	}
+	d.Text = strings.TrimSpace(strings.Join(words, " "))
+	if d.Text == "" {
+		return nil, fmt.Errorf("usage: wt hub note \"<decision>\" [--bead <id>] [--session <name>]")
+	}
+	return d, nil
+}
+
+// cmdHubNote appends a decision to the hub's journal
+func cmdHubNote(cfg *config.Config, args []string) error {
+	d, err := parseHubNoteArgs(args)
+	if err != nil {
+		return err
+	}
+	if err := handoff.AppendDecision(cfg, d); err != nil {
+		return fmt.Errorf("recording decision: %w", err)
+	}
+	if outputJSON {
+		printJSON(d)
+		return nil
+	}
+	fmt.Printf("Recorded: %s\n", handoff.FormatDecision(*d))
+	return nil
+}
+
+// parseHubLogArgs parses the filters of 'wt hub log'
+func parseHubLogArgs(args []string) (handoff.DecisionFilter, error) {
+	f := handoff.DecisionFilter{Limit: 20}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if i+1 >= len(args) {
+			return f, fmt.Errorf("unknown flag or missing value: %s", arg)
+		}
+		value := args[i+1]
+		i++
+		switch arg {
+		case "-n", "--count":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				return f, fmt.Errorf("invalid count: %s", value)
+			}
+			f.Limit = n
+		case "--since":
+			d, err := parseDurationString(value)
+			if err != nil {
+				return f, err
+			}
+			f.Since = time.Now().Add(-d)
+		case "--bead", "-b":
+			f.Bead = value
+		case "--session", "-s":
+			f.Session = value
+		case "--grep", "-g":
+			f.Grep = value
+		default:
+			return f, fmt.Errorf("unknown flag: %s", arg)
+		}
+	}
+	return f, nil
+}
+
+// cmdHubLog shows the hub's decision journal, oldest first
+func cmdHubLog(cfg *config.Config, args []string) error {
+	f, err := parseHubLogArgs(args)
+	if err != nil {
+		return err
+	}
+	decisions, err := handoff.LoadDecisions(cfg)
+	if err != nil {
+		return fmt.Errorf("reading decision journal: %w", err)
+	}
+	decisions = handoff.FilterDecisions(decisions, f)
+
+	if len(decisions) == 0 {
+		printEmptyMessage("No decisions recorded.", "Record one with: wt hub note \"<decision>\"")
+		return nil
+	}
+	if outputJSON {
+		printJSON(decisions)
+		return nil
+	}
+	for _, d := range decisions {
+		fmt.Println(handoff.FormatDecision(d))
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseHubNoteArgs(t *testing.T) {
+	d, err := parseHubNoteArgs([]string{"Hold", "proj-12 until the API PR merges", "-b", "proj-12", "--session", "toast"})
+	if err != nil {
+		t.Fatalf("parseHubNoteArgs() error: %v", err)
+	}
+	if d.Text != "Hold proj-12 until the API PR merges" || d.Bead != "proj-12" || d.Session != "toast" {
+		t.Errorf("parseHubNoteArgs() = %+v", d)
+	}
+
+	for _, args := range [][]string{{}, {"--bead", "proj-12"}, {"text", "--bead"}, {"text", "-s"}} {
+		if _, err := parseHubNoteArgs(args); err == nil {
+			t.Errorf("parseHubNoteArgs(%v) should fail", args)
+		}
+	}
+}
+
+func TestParseHubLogArgs(t *testing.T) {
+	f, err := parseHubLogArgs(nil)
+	if err != nil || f.Limit != 20 {
+		t.Fatalf("parseHubLogArgs(nil) = %+v, %v; want limit 20", f, err)
+	}
+
+	f, err = parseHubLogArgs([]string{"-n", "0", "--since", "2d", "--bead", "proj-12", "-s", "toast", "--grep", "api"})
+	if err != nil {
+		t.Fatalf("parseHubLogArgs() error: %v", err)
+	}
+	if f.Limit != 0 || f.Bead != "proj-12" || f.Session != "toast" || f.Grep != "api" {
+		t.Errorf("parseHubLogArgs() = %+v", f)
+	}
+	if age := time.Since(f.Since); age < 47*time.Hour || age > 49*time.Hour {
+		t.Errorf("--since 2d gave %v ago", age)
+	}
+
+	for _, args := range [][]string{{"-n"}, {"-n", "x"}, {"--since", "soon"}, {"--what", "x"}, {"stray"}} {
+		if _, err := parseHubLogArgs(args); err == nil {
+			t.Errorf("parseHubLogArgs(%v) should fail", args)
+		}
+	}
+}
@@ -0,0 +1,347 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+
+	"github.com/badri/wt/internal/bead"
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/importer"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/project"
+)
+
+type importFlags struct {
+	repo          string
+	label         string
+	jql           string
+	project       string
+	limit         int
+	priority      int
+	issueType     string
+	closeUpstream bool
+	doneState     string
+	dryRun        bool
+}
+
+func parseImportFlags(args []string) (importFlags, error) {
+	flags := importFlags{limit: 100, priority: 2, issueType: "task"}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch arg {
+		case "--close-upstream":
+			flags.closeUpstream = true
+			continue
+		case "--dry-run":
+			flags.dryRun = true
+			continue
+		}
+
+		if i+1 >= len(args) {
+			return flags, fmt.Errorf("unknown option or missing value: %s", arg)
+		}
+		value := args[i+1]
+		i++
+		switch arg {
+		case "--repo", "-r":
+			flags.repo = value
+		case "--label", "-l":
+			flags.label = value
+		case "--jql":
+			flags.jql = value
+		case "--project", "-p":
+			flags.project = value
+		case "--type", "-t":
+			flags.issueType = value
+		case "--done-state":
+			flags.doneState = value
+		case "--limit", "-n":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return flags, fmt.Errorf("--limit must be a positive number")
+			}
+			flags.limit = n
+		case "--priority":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 || n > 4 {
+				return flags, fmt.Errorf("--priority must be 0-4")
+			}
+			flags.priority = n
+		default:
+			return flags, fmt.Errorf("unknown option: %s", arg)
+		}
+	}
+	return flags, nil
+}
+
+// cmdImport creates beads from upstream issues
+func cmdImport(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return cmdImportHelp()
+	}
+	source, rest := args[0], args[1:]
+	if source == "list" {
+		return cmdImportList(cfg)
+	}
+
+	flags, err := parseImportFlags(rest)
+	if err != nil {
+		return err
+	}
+
+	var issues []importer.Issue
+	switch source {
+	case importer.SourceGitHub:
+		if flags.repo == "" {
+			return fmt.Errorf("usage: wt import github --repo <owner/repo> [--label <label>]")
+		}
+		issues, err = importer.GitHubIssues(flags.repo, flags.label, flags.limit)
+	case importer.SourceJira:
+		if flags.label == "" && flags.jql == "" {
+			return fmt.Errorf("usage: wt import jira --label <label> | --jql <query>")
+		}
+		var client *importer.JiraClient
+		client, err = importer.NewJiraClientFromEnv()
+		if err == nil {
+			issues, err = client.Search(importer.JiraJQL(flags.label, flags.jql), flags.limit)
+		}
+	default:
+		return fmt.Errorf("unknown import source: %s%s\nUsage: wt import [github|jira|list]", source, didYouMean(source, []string{"github", "jira", "list"}))
+	}
+	if err != nil {
+		return err
+	}
+
+	proj, err := resolveImportProject(project.NewManager(cfg), flags.project)
+	if err != nil {
+		return err
+	}
+
+	store, err := importer.Load(cfg)
+	if err != nil {
+		return fmt.Errorf("loading import links: %w", err)
+	}
+
+	if len(issues) == 0 {
+		fmt.Println("No matching issues found.")
+		return nil
+	}
+
+	fmt.Printf("Importing %d issue(s) into %s:\n", len(issues), proj.Name)
+	created, skipped := 0, 0
+	for i := range issues {
+		issue := &issues[i]
+		if link := store.Find(issue); link != nil {
+			fmt.Printf("  = %-18s already imported as %s\n", issue.Ref(), link.Bead)
+			skipped++
+			continue
+		}
+		if flags.dryRun {
+			fmt.Printf("  + %-18s %s\n", issue.Ref(), truncate(issue.Title, 50))
+			continue
+		}
+
+		beadID, err := bead.CreateInDir(proj.BeadsDir(), issue.Title, &bead.CreateOptions{
+			Description: importer.BeadDescription(issue),
+			Priority:    flags.priority,
+			Type:        flags.issueType,
+		})
+		if err != nil {
+			fmt.Printf("  ! %-18s %v\n", issue.Ref(), err)
+			continue
+		}
+
+		store.Put(&importer.Link{
+			Bead:          beadID,
+			Project:       proj.Name,
+			Source:        issue.Source,
+			Repo:          issue.Repo,
+			Key:           issue.Key,
+			URL:           issue.URL,
+			CloseUpstream: flags.closeUpstream,
+			DoneState:     flags.doneState,
+			ImportedAt:    time.Now().Format(time.RFC3339),
+		})
+		if err := store.Save(); err != nil {
+			return fmt.Errorf("saving import link for %s: %w", beadID, err)
+		}
+		fmt.Printf("  + %-18s → %s  %s\n", issue.Ref(), beadID, truncate(issue.Title, 40))
+		created++
+	}
+
+	if flags.dryRun {
+		fmt.Printf("\nDry run: %d to import, %d already imported.\n", len(issues)-skipped, skipped)
+		return nil
+	}
+	fmt.Printf("\nCreated %d bead(s), %d already imported.\n", created, skipped)
+	if flags.closeUpstream && created > 0 {
+		fmt.Println("Upstream issues will be closed when their beads are closed by wt done/close.")
+	}
+	return nil
+}
+
+// resolveImportProject picks the project to create beads in: --project, the
+// project whose repo contains the current directory, or the only project.
+func resolveImportProject(mgr *project.Manager, name string) (*project.Project, error) {
+	if name != "" {
+		return mgr.Get(name)
+	}
+	projects, err := mgr.List()
+	if err != nil {
+		return nil, err
+	}
+	if cwd, err := os.Getwd(); err == nil {
+		for _, p := range projects {
+			repo := p.RepoPath()
+			if cwd == repo || strings.HasPrefix(cwd, repo+string(filepath.Separator)) {
+				return p, nil
+			}
+		}
+	}
+	if len(projects) == 1 {
+		return projects[0], nil
+	}
+	return nil, fmt.Errorf("can't tell which project to import into; use --project <name>")
+}
+
+// closeUpstreamIssue closes the issue a bead was imported from, if it was
+// imported with --close-upstream. Called after wt closes the bead.
+func closeUpstreamIssue(cfg *config.Config, beadID, indent string) {
+	store, err := importer.Load(cfg)
+	if err != nil {
+		logging.Warnf("could not load import links: %v", err)
+		return
+	}
+	link := store.Get(beadID)
+	if link == nil || !link.CloseUpstream || link.ClosedAt != "" {
+		return
+	}
+
+	logging.Infof("%sClosing upstream issue %s...", indent, link.Ref())
+	comment := fmt.Sprintf("Closed by wt: bead %s is done.", beadID)
+	if err := importer.CloseUpstream(link, comment); err != nil {
+		logging.Warnf("could not close %s: %v", link.Ref(), err)
+		return
+	}
+	link.ClosedAt = time.Now().Format(time.RFC3339)
+	if err := store.Save(); err != nil {
+		logging.Warnf("could not save import links: %v", err)
+	}
+}
+
+// cmdImportList shows the beads created by wt import and their issues
+func cmdImportList(cfg *config.Config) error {
+	store, err := importer.Load(cfg)
+	if err != nil {
+		return fmt.Errorf("loading import links: %w", err)
+	}
+
+	links := make([]*importer.Link, 0, len(store.Entries))
+	for _, l := range store.Entries {
+		links = append(links, l)
+	}
+	sort.Slice(links, func(i, j int) bool {
+		if links[i].ImportedAt != links[j].ImportedAt {
+			return links[i].ImportedAt < links[j].ImportedAt
+		}
+		return links[i].Bead < links[j].Bead
+	})
+
+	if outputJSON {
+		printJSON(links)
+		return nil
+	}
+	if len(links) == 0 {
+		printEmptyMessage("No imported issues.", "Import with: wt import github --repo <owner/repo> --label <label>")
+		return nil
+	}
+
+	columns := []table.Column{
+		{Title: "Bead", Width: 16},
+		{Title: "Project", Width: 12},
+		{Title: "Issue", Width: 24},
+		{Title: "Close Upstream", Width: 14},
+		{Title: "Imported", Width: 16},
+	}
+	var rows []table.Row
+	for _, l := range links {
+		closeUpstream := "no"
+		switch {
+		case l.ClosedAt != "":
+			closeUpstream = "closed"
+		case l.CloseUpstream:
+			closeUpstream = "yes"
+		}
+		imported := l.ImportedAt
+		if t, err := time.Parse(time.RFC3339, l.ImportedAt); err == nil {
+			imported = t.Format("2006-01-02 15:04")
+		}
+		rows = append(rows, table.Row{l.Bead, l.Project, truncate(l.Ref(), 24), closeUpstream, imported})
+	}
+	printTable("Imported Issues", columns, rows)
+	return nil
+}
+
+// cmdImportHelp shows help for the import command
+func cmdImportHelp() error {
+	help := `wt import - Create beads from GitHub or Jira issues
+
+USAGE:
+    wt import github --repo <owner/repo> [--label <label>] [options]
+    wt import jira (--label <label> | --jql <query>) [options]
+    wt import list
+
+DESCRIPTION:
+    Pulls open issues matching a filter and creates a bead for each one.
+    The bead description starts with a link back to the issue, and wt
+    records which issue each bead came from, so running the import again
+    only picks up new issues.
+
+    With --close-upstream, the issue is closed (with a comment) when
+    'wt done' or 'wt close' closes its bead.
+
+    GitHub uses the gh CLI, which must be authenticated. Jira uses the
+    REST API, configured with environment variables:
+        JIRA_URL         e.g. https://example.atlassian.net
+        JIRA_USER        Account email (Jira Cloud)
+        JIRA_API_TOKEN   API token, or a personal access token without
+                         JIRA_USER (Jira Server/Data Center)
+
+COMMANDS:
+    github              Import open GitHub issues of a repository
+    jira                Import open Jira issues
+    list                Show imported issues and their beads
+
+OPTIONS:
+    -r, --repo <owner/repo>   GitHub repository (github)
+    -l, --label <label>       Only issues with this label
+    --jql <query>             Extra JQL to filter Jira issues
+    -p, --project <name>      Project to create beads in (default: the
+                              project of the current directory, or the
+                              only project)
+    -n, --limit <n>           Maximum issues to fetch (default: 100)
+    --priority <0-4>          Priority of the new beads (default: 2)
+    -t, --type <type>         Type of the new beads (default: task)
+    --close-upstream          Close the issue when its bead is closed
+    --done-state <name>       Jira transition or status used to close
+                              (default: Done)
+    --dry-run                 Show what would be imported
+    --json                    list: output as JSON
+    -h, --help                Show this help
+
+EXAMPLES:
+    wt import github --repo acme/app --label agent-ready
+    wt import github --repo acme/app --label agent-ready --close-upstream
+    wt import jira --label agent-ready --jql "project = APP" --dry-run
+    wt import list
+`
+	fmt.Print(help)
+	return nil
+}
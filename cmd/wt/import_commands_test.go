@@ -0,0 +1,27 @@
+package main
+
+import "testing"
+
+func TestParseImportFlags(t *testing.T) {
+	flags, err := parseImportFlags([]string{"--repo", "acme/app", "-l", "agent-ready", "--close-upstream", "-n", "20", "--priority", "1"})
+	if err != nil {
+		t.Fatalf("parseImportFlags() error: %v", err)
+	}
+	if flags.repo != "acme/app" || flags.label != "agent-ready" || !flags.closeUpstream || flags.limit != 20 || flags.priority != 1 {
+		t.Errorf("parseImportFlags() = %+v", flags)
+	}
+	if flags.issueType != "task" || flags.dryRun {
+		t.Errorf("defaults = %+v, want type task and no dry run", flags)
+	}
+
+	for _, args := range [][]string{
+		{"--label"},
+		{"--limit", "0"},
+		{"--priority", "7"},
+		{"--bogus", "x"},
+	} {
+		if _, err := parseImportFlags(args); err == nil {
+			t.Errorf("parseImportFlags(%v) expected error", args)
+		}
+	}
+}
@@ -0,0 +1,359 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/doctor"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/project"
+)
+
+// prerequisite is a tool wt shells out to
+type prerequisite struct {
+	name     string
+	required bool
+	purpose  string
+	install  string
+}
+
+var prerequisites = []prerequisite{
+	{"git", true, "worktrees and merges", "brew install git (macOS) or apt install git (Linux)"},
+	{"tmux", true, "worker sessions", "brew install tmux (macOS) or apt install tmux (Linux)"},
+	{"bd", true, "beads issue tracking", "see https://github.com/badri/beads"},
+	{"claude", true, "the worker agent", "npm install -g @anthropic-ai/claude-code"},
+	{"gh", false, "pull requests (pr-review and pr-auto merge modes)", "brew install gh (macOS), see https://cli.github.com"},
+}
+
+type initFlags struct {
+	yes     bool   // Accept every default without prompting
+	scanDir string // Directory to look for repositories in
+}
+
+func parseInitFlags(args []string) (*initFlags, error) {
+	flags := &initFlags{}
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-y", "--yes":
+			flags.yes = true
+		case "--scan":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--scan requires a directory")
+			}
+			flags.scanDir = args[i+1]
+			i++
+		default:
+			return nil, fmt.Errorf("unknown flag: %s", args[i])
+		}
+	}
+	return flags, nil
+}
+
+// cmdInit bootstraps wt on a fresh machine: prerequisites, config, projects,
+// shell completions and tmux keybindings, then doctor
+func cmdInit(cfg *config.Config, args []string) error {
+	flags, err := parseInitFlags(args)
+	if err != nil {
+		return err
+	}
+
+	ask := func(prompt string, defaultYes bool) bool {
+		if flags.yes {
+			return defaultYes
+		}
+		return confirm(prompt, defaultYes)
+	}
+	askValue := func(prompt, def string) (string, error) {
+		if flags.yes {
+			return def, nil
+		}
+		input, err := readLine(fmt.Sprintf("%s [%s]: ", prompt, def))
+		if err != nil || input == "" {
+			return def, err
+		}
+		return input, nil
+	}
+
+	fmt.Println("wt init - set up wt on this machine")
+
+	// 1. Prerequisites
+	fmt.Println("\n[1/6] Checking prerequisites")
+	missing := checkPrerequisites()
+	if len(missing) > 0 && !ask("\nSome required tools are missing. Continue anyway?", false) {
+		return fmt.Errorf("install %s and rerun 'wt init'", strings.Join(missing, ", "))
+	}
+
+	// 2. Config
+	fmt.Println("\n[2/6] Configuration")
+	if cfg.ConfigExists() {
+		fmt.Printf("  Config already exists at %s, leaving it as is\n", cfg.ConfigPath())
+	} else {
+		if cfg.WorktreeRoot, err = askValue("  Worktree root", cfg.WorktreeRoot); err != nil {
+			return err
+		}
+		mode, err := askValue("  Default merge mode (direct, pr-auto, pr-review)", cfg.DefaultMergeMode)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains([]string{"direct", "pr-auto", "pr-review"}, mode) {
+			fmt.Printf("  Unknown merge mode '%s', using %s\n", mode, cfg.DefaultMergeMode)
+		} else {
+			cfg.DefaultMergeMode = mode
+		}
+		if err := cfg.Save(); err != nil {
+			return fmt.Errorf("creating config: %w", err)
+		}
+		fmt.Printf("  Created %s\n", cfg.ConfigPath())
+	}
+	if err := os.MkdirAll(cfg.WorktreeRootPath(), 0755); err != nil {
+		logging.Warnf("could not create worktree root: %v", err)
+	}
+
+	// 3. Projects
+	fmt.Println("\n[3/6] Projects")
+	scanDir := flags.scanDir
+	if scanDir == "" && !flags.yes {
+		home, _ := os.UserHomeDir()
+		if ask("  Scan a directory for git repositories to register?", true) {
+			if scanDir, err = askValue("  Directory", filepath.Join(home, "code")); err != nil {
+				return err
+			}
+		}
+	}
+	if scanDir != "" {
+		registerScannedProjects(cfg, project.ExpandPath(scanDir), ask)
+	} else {
+		fmt.Println("  Skipped. Register projects later with 'wt project add <name> <path>'")
+	}
+
+	// 4. Shell completions
+	fmt.Println("\n[4/6] Shell completions")
+	if rc, line := completionSetup(os.Getenv("SHELL")); rc == "" {
+		fmt.Println("  Unknown shell, see 'wt completion --help'")
+	} else if ask(fmt.Sprintf("  Install completions in %s?", rc), true) {
+		installLine(rc, line)
+	}
+
+	// 5. tmux keybindings
+	fmt.Println("\n[5/6] tmux keybindings")
+	home, _ := os.UserHomeDir()
+	tmuxConf := filepath.Join(home, ".tmux.conf")
+	if ask(fmt.Sprintf("  Add wt keybindings to %s?", tmuxConf), true) {
+		installLine(tmuxConf, tmuxKeybindings)
+	}
+
+	// 6. Doctor
+	fmt.Println("\n[6/6] Running wt doctor")
+	if err := doctor.Run(cfg); err != nil {
+		return err
+	}
+
+	fmt.Println("\nAll set. Next: 'wt ready' to see work, 'wt hub' to start orchestrating.")
+	return nil
+}
+
+// checkPrerequisites prints which tools are installed and returns the
+// missing required ones
+func checkPrerequisites() []string {
+	var missing []string
+	for _, p := range prerequisites {
+		if path, err := exec.LookPath(p.name); err == nil {
+			fmt.Printf("  ✓ %-7s %s\n", p.name, path)
+			continue
+		}
+		if p.required {
+			missing = append(missing, p.name)
+			fmt.Printf("  ✗ %-7s missing, needed for %s\n", p.name, p.purpose)
+		} else {
+			fmt.Printf("  ! %-7s missing, optional, needed for %s\n", p.name, p.purpose)
+		}
+		fmt.Printf("            Install: %s\n", p.install)
+	}
+	return missing
+}
+
+// scannedRepo is a git repository found by findRepos
+type scannedRepo struct {
+	Path     string
+	HasBeads bool
+}
+
+// findRepos returns the git repositories in dir and its subdirectories, up
+// to depth levels down. It does not descend into repositories.
+func findRepos(dir string, depth int) []scannedRepo {
+	if isDir(filepath.Join(dir, ".git")) {
+		return []scannedRepo{{Path: dir, HasBeads: isDir(filepath.Join(dir, ".beads"))}}
+	}
+	if depth == 0 {
+		return nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var repos []scannedRepo
+	for _, e := range entries {
+		if !e.IsDir() || strings.HasPrefix(e.Name(), ".") || e.Name() == "node_modules" {
+			continue
+		}
+		repos = append(repos, findRepos(filepath.Join(dir, e.Name()), depth-1)...)
+	}
+	return repos
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// registerScannedProjects offers to register each repository under dir
+// that isn't a project yet. Repositories without beads default to no.
+func registerScannedProjects(cfg *config.Config, dir string, ask func(string, bool) bool) {
+	mgr := project.NewManager(cfg)
+	existing, _ := mgr.List()
+	registered := make(map[string]bool)
+	for _, p := range existing {
+		registered[p.Name] = true
+		registered[filepath.Clean(p.RepoPath())] = true
+	}
+
+	repos := findRepos(dir, 2)
+	if len(repos) == 0 {
+		fmt.Printf("  No git repositories found in %s\n", dir)
+		return
+	}
+
+	added := 0
+	for _, repo := range repos {
+		name := filepath.Base(repo.Path)
+		if registered[filepath.Clean(repo.Path)] {
+			continue
+		}
+		if registered[name] {
+			fmt.Printf("  Skipping %s: a project named '%s' exists\n", repo.Path, name)
+			continue
+		}
+		note := ""
+		if !repo.HasBeads {
+			note = " (no .beads, run 'bd init' there first)"
+		}
+		if !ask(fmt.Sprintf("  Register %s as '%s'%s?", repo.Path, name, note), repo.HasBeads) {
+			continue
+		}
+		branch := getCurrentBranch(repo.Path)
+		proj, err := mgr.Add(name, repo.Path, &project.AddOptions{Branch: branch, MergeMode: cfg.DefaultMergeMode})
+		if err != nil {
+			logging.Warnf("could not register %s: %v", name, err)
+			continue
+		}
+		registered[name] = true
+		added++
+		fmt.Printf("    Registered '%s' (branch %s, prefix %s)\n", proj.Name, proj.DefaultBranch, proj.BeadsPrefix)
+		warnProjectRemote(proj)
+	}
+	if added == 0 {
+		fmt.Println("  No new projects registered")
+	}
+}
+
+// completionSetup returns the file that loads completions for shell (a
+// $SHELL path) and the line or script to put there, or "" for unknown shells
+func completionSetup(shell string) (string, string) {
+	home, _ := os.UserHomeDir()
+	switch filepath.Base(shell) {
+	case "bash":
+		return filepath.Join(home, ".bashrc"), `eval "$(wt completion bash)"` + "\n"
+	case "zsh":
+		return filepath.Join(home, ".zshrc"), `eval "$(wt completion zsh)"` + "\n"
+	case "fish":
+		return filepath.Join(home, ".config", "fish", "completions", "wt.fish"), fishCompletion
+	default:
+		return "", ""
+	}
+}
+
+// installLine appends content to path unless its first line is already
+// there, reporting what it did
+func installLine(path, content string) {
+	added, err := appendOnce(path, content)
+	switch {
+	case err != nil:
+		logging.Warnf("could not update %s: %v", path, err)
+	case added:
+		fmt.Printf("  Updated %s\n", path)
+	default:
+		fmt.Printf("  %s is already set up\n", path)
+	}
+}
+
+// appendOnce appends content to the file at path, creating it if needed.
+// The first line of content marks it as installed, so reruns are no-ops.
+func appendOnce(path, content string) (bool, error) {
+	marker, _, _ := strings.Cut(content, "\n")
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+	if strings.Contains(string(data), marker) {
+		return false, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return false, err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+	prefix := ""
+	if len(data) > 0 && !strings.HasSuffix(string(data), "\n") {
+		prefix = "\n"
+	}
+	if len(data) > 0 {
+		prefix += "\n"
+	}
+	if _, err := f.WriteString(prefix + content); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// cmdInitHelp shows help for the init command
+func cmdInitHelp() error {
+	help := `wt init - Set up wt on a new machine
+
+USAGE:
+    wt init [options]
+
+DESCRIPTION:
+    Walks through setting up wt:
+
+      1. Checks for git, tmux, bd, claude and gh, with install hints
+      2. Creates the config file (worktree root, default merge mode)
+      3. Scans a directory for git repositories and registers them as
+         projects (repositories without .beads default to no)
+      4. Installs shell completions for $SHELL (bash, zsh or fish)
+      5. Adds the 'wt keys' tmux keybindings to ~/.tmux.conf
+      6. Runs 'wt doctor'
+
+    Every step is safe to rerun: an existing config is kept, registered
+    repositories are skipped and files already set up are left alone.
+
+OPTIONS:
+    -y, --yes           Accept the defaults without prompting
+    --scan <dir>        Directory to look for repositories in (two levels deep)
+    -h, --help          Show this help
+
+EXAMPLES:
+    wt init                     Interactive setup
+    wt init --scan ~/src        Offer to register the repositories in ~/src
+    wt init -y --scan ~/code    Unattended setup
+`
+	fmt.Print(help)
+	return nil
+}
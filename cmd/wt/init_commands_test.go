@@ -0,0 +1,85 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseInitFlags(t *testing.T) {
+	flags, err := parseInitFlags([]string{"-y", "--scan", "~/src"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !flags.yes || flags.scanDir != "~/src" {
+		t.Errorf("parseInitFlags() = %+v", flags)
+	}
+	for _, args := range [][]string{{"--scan"}, {"--force"}} {
+		if _, err := parseInitFlags(args); err == nil {
+			t.Errorf("parseInitFlags(%v) expected error", args)
+		}
+	}
+}
+
+func TestFindRepos(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{
+		"api/.git", "api/.beads",
+		"web/.git",
+		"web/vendor/lib/.git", // inside a repo: not scanned
+		"clients/mobile/.git",
+		"deep/a/b/.git", // beyond depth 2
+		".hidden/.git",
+	} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var got []string
+	for _, r := range findRepos(root, 2) {
+		rel, _ := filepath.Rel(root, r.Path)
+		if r.HasBeads {
+			rel += "+beads"
+		}
+		got = append(got, rel)
+	}
+	want := "api+beads clients/mobile web"
+	if strings.Join(got, " ") != want {
+		t.Errorf("findRepos() = %v, want %s", got, want)
+	}
+}
+
+func TestAppendOnce(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", ".bashrc")
+	line := `eval "$(wt completion bash)"` + "\n"
+
+	if added, err := appendOnce(path, line); err != nil || !added {
+		t.Fatalf("first appendOnce() = %v, %v", added, err)
+	}
+	if added, err := appendOnce(path, line); err != nil || added {
+		t.Fatalf("second appendOnce() = %v, %v, want no-op", added, err)
+	}
+
+	os.WriteFile(path, []byte("export EDITOR=vim"), 0644)
+	if _, err := appendOnce(path, line); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != "export EDITOR=vim\n\n"+line {
+		t.Errorf("file = %q", data)
+	}
+}
+
+func TestCompletionSetup(t *testing.T) {
+	if rc, line := completionSetup("/bin/zsh"); !strings.HasSuffix(rc, ".zshrc") || !strings.Contains(line, "wt completion zsh") {
+		t.Errorf("completionSetup(zsh) = %s, %q", rc, line)
+	}
+	if rc, _ := completionSetup("/usr/bin/fish"); !strings.HasSuffix(rc, "completions/wt.fish") {
+		t.Errorf("completionSetup(fish) = %s", rc)
+	}
+	if rc, _ := completionSetup("/bin/tcsh"); rc != "" {
+		t.Errorf("completionSetup(tcsh) = %s, want none", rc)
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/badri/wt/internal/auto"
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/session"
+)
+
+// cmdKillEpic kills every session working on an epic: the epic's own
+// session from wt auto and the sessions of its child beads
+func cmdKillEpic(cfg *config.Config, epicID string, flags killFlags) error {
+	state, err := session.LoadState(cfg)
+	if err != nil {
+		return err
+	}
+	names := epicSessions(state, epicID)
+	if len(names) == 0 {
+		return fmt.Errorf("no sessions belong to epic %s", epicID)
+	}
+
+	if project, ok := autoRunOnEpic(cfg, epicID); ok {
+		abort := "wt auto --abort"
+		if project != "" {
+			abort += " --project " + project
+		}
+		logging.Warnf("wt auto is working on %s; stop the run with '%s' so it doesn't start the next bead", epicID, abort)
+	}
+
+	fmt.Printf("Sessions of epic %s: %s\n", epicID, strings.Join(names, ", "))
+	if stdinIsTerminal() && !confirm(fmt.Sprintf("Kill %d session(s)?", len(names)), false) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	var failed []string
+	for _, name := range names {
+		fmt.Println()
+		if err := cmdKill(cfg, name, flags); err != nil {
+			logging.Warnf("could not kill %s: %v", name, err)
+			failed = append(failed, name)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("%d of %d session(s) not killed: %s", len(failed), len(names), strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// epicSessions returns the sessions recorded as working on an epic, or on
+// the epic bead itself (sessions started before epics were recorded), sorted
+func epicSessions(state *session.State, epicID string) []string {
+	var names []string
+	for name, sess := range state.Sessions {
+		if sess.Epic == epicID || sess.Bead == epicID {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// autoRunOnEpic reports whether a wt auto run's state is for the epic, and
+// the project it runs in ("" for the global run)
+func autoRunOnEpic(cfg *config.Config, epicID string) (string, bool) {
+	projects, err := auto.EpicStateProjects(cfg)
+	if err != nil {
+		return "", false
+	}
+	for _, p := range projects {
+		if st, err := auto.LoadProjectEpicState(cfg, p); err == nil && st.EpicID == epicID {
+			return p, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/session"
+	"github.com/badri/wt/internal/worktree"
+)
+
+// parseAbandonFlags parses the flags 'wt abandon' shares with 'wt kill'
+func parseAbandonFlags(args []string) killFlags {
+	var flags killFlags
+	for _, arg := range args {
+		switch arg {
+		case "--force", "-f":
+			flags.force = true
+		case "--stash":
+			flags.stash = true
+		}
+	}
+	return flags
+}
+
+// checkUnsavedWork runs before kill or abandon removes a session's worktree.
+// When the worktree holds uncommitted changes or unpushed commits, it prints
+// what would be lost and only lets the removal go ahead with --force, or
+// after archiving the work (--stash, or yes at the prompt).
+func checkUnsavedWork(cfg *config.Config, name string, sess *session.Session, flags killFlags) error {
+	if !worktree.Exists(sess.Worktree) {
+		return nil
+	}
+	unsaved, err := worktree.FindUnsaved(sess.Worktree)
+	if err != nil {
+		logging.Warnf("could not check %s for unsaved work: %v", sess.Worktree, err)
+		return nil
+	}
+	if unsaved.Empty() {
+		return nil
+	}
+
+	fmt.Printf("Session '%s' has work that is not saved anywhere else:\n", name)
+	fmt.Print(unsaved.Summary(10))
+
+	dest := filepath.Join(cfg.ConfigDir(), "archive", name+"-"+time.Now().Format("20060102-150405"))
+	stash := flags.stash
+	if !stash && !flags.force {
+		if !confirm(fmt.Sprintf("Archive it to %s and continue?", dest), false) {
+			return fmt.Errorf("worktree has unsaved work; use --stash to archive it or --force to discard it")
+		}
+		stash = true
+	}
+	if !stash {
+		fmt.Println("Discarding it (--force).")
+		return nil
+	}
+
+	if err := worktree.Archive(sess.Worktree, dest, unsaved); err != nil {
+		return fmt.Errorf("archiving unsaved work: %w", err)
+	}
+	fmt.Printf("Archived to %s\n", dest)
+	if len(unsaved.Files) > 0 {
+		fmt.Printf("  Restore changes: git apply %s\n", filepath.Join(dest, "changes.patch"))
+	}
+	if len(unsaved.Commits) > 0 {
+		fmt.Printf("  Restore commits: git am %s\n", filepath.Join(dest, "commits", "*.patch"))
+	}
+	fmt.Println()
+	return nil
+}
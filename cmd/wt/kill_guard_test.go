@@ -0,0 +1,16 @@
+package main
+
+import "testing"
+
+func TestParseKillFlags(t *testing.T) {
+	flags := parseKillFlags([]string{"--keep-worktree", "--stash", "-f"})
+	if !flags.keepWorktree || !flags.stash || !flags.force {
+		t.Errorf("parseKillFlags = %+v, want all set", flags)
+	}
+	if flags := parseAbandonFlags([]string{"--keep-worktree"}); flags.keepWorktree {
+		t.Error("abandon should not accept --keep-worktree")
+	}
+	if flags := parseAbandonFlags([]string{"--force"}); !flags.force || flags.stash {
+		t.Errorf("parseAbandonFlags(--force) = %+v", flags)
+	}
+}
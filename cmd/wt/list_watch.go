@@ -0,0 +1,174 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/badri/wt/internal/config"
+)
+
+// defaultListWatchInterval is how often 'wt list --watch' refreshes
+const defaultListWatchInterval = 5 * time.Second
+
+// parseWatchInterval parses a --watch interval given in seconds ("10") or
+// as a duration ("30s", "1m")
+func parseWatchInterval(s string) (time.Duration, bool) {
+	if n, err := strconv.Atoi(s); err == nil {
+		if n <= 0 {
+			return 0, false
+		}
+		return time.Duration(n) * time.Second, true
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < time.Second {
+		return 0, false
+	}
+	return d, true
+}
+
+// listWatchModel is a bare auto-refreshing 'wt list': just the table,
+// without the monitoring and notifications of 'wt watch'
+type listWatchModel struct {
+	cfg      *config.Config
+	flags    listFlags
+	content  string
+	err      error
+	updated  time.Time
+	loading  bool
+	width    int
+	height   int
+	quitting bool
+}
+
+type listTickMsg time.Time
+
+type listRefreshMsg struct {
+	content string
+	err     error
+	at      time.Time
+}
+
+func runListWatch(cfg *config.Config, flags listFlags) error {
+	m := listWatchModel{cfg: cfg, flags: flags, loading: true}
+	_, err := tea.NewProgram(m, tea.WithAltScreen()).Run()
+	return err
+}
+
+func (m listWatchModel) Init() tea.Cmd {
+	return tea.Batch(m.refresh(), m.tick())
+}
+
+func (m listWatchModel) tick() tea.Cmd {
+	return tea.Tick(m.flags.watch, func(t time.Time) tea.Msg {
+		return listTickMsg(t)
+	})
+}
+
+// refresh renders the list in the background, so a slow bd doesn't block
+// key handling
+func (m listWatchModel) refresh() tea.Cmd {
+	cfg, flags := m.cfg, m.flags
+	return func() tea.Msg {
+		content, err := renderListView(cfg, flags)
+		return listRefreshMsg{content: content, err: err, at: time.Now()}
+	}
+}
+
+func (m listWatchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			m.quitting = true
+			return m, tea.Quit
+		case "r":
+			if !m.loading {
+				m.loading = true
+				return m, m.refresh()
+			}
+		}
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+	case listTickMsg:
+		// Skip the refresh while the previous one is still running
+		if m.loading {
+			return m, m.tick()
+		}
+		m.loading = true
+		return m, tea.Batch(m.refresh(), m.tick())
+	case listRefreshMsg:
+		m.loading = false
+		m.content = msg.content
+		m.err = msg.err
+		m.updated = msg.at
+	}
+	return m, nil
+}
+
+func (m listWatchModel) View() string {
+	if m.quitting {
+		return ""
+	}
+
+	body := m.content
+	if m.err != nil {
+		body = statusErrorStyle.Render(fmt.Sprintf("Error: %v", m.err))
+	} else if m.updated.IsZero() {
+		body = tableDimStyle.Render("Loading...")
+	}
+
+	status := fmt.Sprintf("Every %s", m.flags.watch)
+	if !m.updated.IsZero() {
+		status += " · updated " + m.updated.Format("15:04:05")
+	}
+	status += " · r refresh · q quit"
+
+	return plainText(clipView(body, helpStyle.Render(status), m.width, m.height))
+}
+
+// renderListView renders what 'wt list' would print, for the watch view
+func renderListView(cfg *config.Config, flags listFlags) (string, error) {
+	entries, err := collectListEntries(cfg, flags)
+	if err != nil {
+		return "", err
+	}
+	if len(entries) == 0 {
+		if flags.all {
+			return tableDimStyle.Render("No sessions found."), nil
+		}
+		return tableDimStyle.Render("No active sessions."), nil
+	}
+	return renderSessionList(cfg, flags, entries), nil
+}
+
+// clipView fits body plus a footer line into a width x height terminal,
+// cutting off rows and columns that don't fit rather than letting the
+// terminal wrap or scroll. A zero size means unknown and leaves body as is.
+func clipView(body, footer string, width, height int) string {
+	lines := strings.Split(strings.TrimRight(body, "\n"), "\n")
+	if height > 0 {
+		// Keep a blank line and the footer on screen
+		room := height - 2
+		if room < 1 {
+			room = 1
+		}
+		if len(lines) > room {
+			lines = lines[:room]
+		}
+	}
+	lines = append(lines, "", footer)
+
+	if width > 0 {
+		clip := lipgloss.NewStyle().MaxWidth(width)
+		for i, line := range lines {
+			lines[i] = clip.Render(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
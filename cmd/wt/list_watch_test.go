@@ -0,0 +1,53 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseListFlagsWatch(t *testing.T) {
+	tests := []struct {
+		args []string
+		want time.Duration
+		all  bool
+	}{
+		{[]string{}, 0, false},
+		{[]string{"--watch"}, defaultListWatchInterval, false},
+		{[]string{"-w", "10"}, 10 * time.Second, false},
+		{[]string{"--watch", "1m"}, time.Minute, false},
+		{[]string{"--watch", "--all"}, defaultListWatchInterval, true},
+		{[]string{"-w", "0"}, defaultListWatchInterval, false},
+		{[]string{"-w", "100ms"}, defaultListWatchInterval, false},
+	}
+	for _, tt := range tests {
+		flags := parseListFlags(tt.args)
+		if flags.watch != tt.want || flags.all != tt.all {
+			t.Errorf("parseListFlags(%v) = watch %v, all %v; want %v, %v", tt.args, flags.watch, flags.all, tt.want, tt.all)
+		}
+	}
+}
+
+func TestClipView(t *testing.T) {
+	body := "title\nrow one is long\nrow two\nrow three\n"
+
+	got := clipView(body, "footer", 0, 0)
+	if want := "title\nrow one is long\nrow two\nrow three\n\nfooter"; got != want {
+		t.Errorf("unknown size: got %q, want %q", got, want)
+	}
+
+	got = clipView(body, "footer", 0, 4)
+	if want := "title\nrow one is long\n\nfooter"; got != want {
+		t.Errorf("short terminal: got %q, want %q", got, want)
+	}
+
+	got = clipView(body, "footer", 7, 0)
+	for _, line := range strings.Split(got, "\n") {
+		if len(line) > 7 {
+			t.Errorf("narrow terminal: line %q is wider than 7", line)
+		}
+	}
+	if !strings.Contains(got, "row one\n") {
+		t.Errorf("narrow terminal: got %q, want rows cut at 7 columns", got)
+	}
+}
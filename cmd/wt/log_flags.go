@@ -0,0 +1,40 @@
+package main
+
+import (
+	"log/slog"
+	"os"
+
+	"github.com/muesli/termenv"
+
+	"github.com/badri/wt/internal/logging"
+)
+
+// verboseFlag and quietFlag are set by --verbose and --quiet
+var (
+	verboseFlag bool
+	quietFlag   bool
+)
+
+// setupLogging picks the log level from --verbose and --quiet, falling
+// back to WT_VERBOSE and WT_QUIET, and passes it on to the wt processes
+// this one starts. Log lines are colored unless output is plain or stderr
+// isn't a color terminal.
+func setupLogging() {
+	verbose := verboseFlag || (!quietFlag && plainFromEnv(os.Getenv(logging.VerboseEnv)))
+	quiet := quietFlag || (!verboseFlag && plainFromEnv(os.Getenv(logging.QuietEnv)))
+
+	minLevel := slog.LevelInfo
+	switch {
+	case verbose:
+		minLevel = slog.LevelDebug
+		os.Setenv(logging.VerboseEnv, "1")
+		os.Unsetenv(logging.QuietEnv)
+	case quiet:
+		minLevel = slog.LevelError
+		os.Setenv(logging.QuietEnv, "1")
+		os.Unsetenv(logging.VerboseEnv)
+	}
+
+	color := !outputPlain && termenv.NewOutput(os.Stderr).EnvColorProfile() != termenv.Ascii
+	logging.Setup(os.Stderr, minLevel, color)
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/badri/wt/internal/config"
 	"github.com/badri/wt/internal/doctor"
@@ -19,6 +20,10 @@ var (
 // Global output format flag
 var outputJSON bool
 
+// profileOverride is the profile chosen by --profile or WT_PROFILE for this
+// invocation, if any
+var profileOverride string
+
 func main() {
 	if err := run(); err != nil {
 		fmt.Fprintf(os.Stderr, "error: %v\n", err)
@@ -26,21 +31,34 @@ func main() {
 	}
 }
 
-func run() error {
-	cfg, err := config.Load()
+func run() (err error) {
+	// Parse global --json, --plain, --verbose, --quiet and --profile flags
+	args, err := parseGlobalFlags(os.Args[1:])
 	if err != nil {
-		return fmt.Errorf("loading config: %w", err)
+		return err
 	}
+	if outputPlain || plainFromEnv(os.Getenv(PlainEnv)) {
+		enablePlainOutput()
+	}
+	setupLogging()
 
-	args := os.Args[1:]
-
-	// Parse global --json flag
-	args = parseGlobalFlags(args)
+	if profileOverride == "" {
+		profileOverride = os.Getenv(config.ProfileEnv)
+	}
+	cfg, err := config.LoadProfile(profileOverride)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	// Pin the profile for hooks, wt subprocesses and new worker sessions
+	os.Setenv(config.ProfileEnv, cfg.Profile())
 
 	// No args → show help
 	if len(args) == 0 {
 		return cmdHelp()
 	}
+	// Commands on a session or bead go into the bead's history
+	record := startCommandRecord(cfg, args)
+	defer func() { record.finish(err) }()
 	if args[0] == "list" {
 		if hasHelpFlag(args[1:]) {
 			return cmdListHelp()
@@ -61,15 +79,25 @@ func run() error {
 		if len(args) < 2 {
 			return cmdKillHelp()
 		}
+		if args[1] == "--epic" {
+			if len(args) < 3 {
+				return fmt.Errorf("--epic requires an epic ID")
+			}
+			return cmdKillEpic(cfg, args[2], parseKillFlags(args[3:]))
+		}
 		return cmdKill(cfg, args[1], parseKillFlags(args[2:]))
 	case "close":
 		if hasHelpFlag(args[1:]) {
 			return cmdCloseHelp()
 		}
-		if len(args) < 2 {
+		name, flags, err := parseCloseArgs(args[1:])
+		if err != nil {
+			return err
+		}
+		if name == "" {
 			return cmdCloseHelp()
 		}
-		return cmdClose(cfg, args[1])
+		return cmdClose(cfg, name, flags)
 	case "done":
 		if hasHelpFlag(args[1:]) {
 			return cmdDoneHelp()
@@ -79,7 +107,7 @@ func run() error {
 		if hasHelpFlag(args[1:]) {
 			return cmdStatusHelp()
 		}
-		return cmdStatus(cfg)
+		return cmdStatus(cfg, args[1:])
 	case "signal":
 		if hasHelpFlag(args[1:]) {
 			return cmdSignalHelp()
@@ -88,11 +116,123 @@ func run() error {
 			return cmdSignalHelp()
 		}
 		return cmdSignal(cfg, args[1:])
+	case "clone":
+		if hasHelpFlag(args[1:]) || len(args) < 2 {
+			return cmdCloneHelp()
+		}
+		return cmdClone(cfg, args[1:])
+	case "shutdown":
+		if hasHelpFlag(args[1:]) {
+			return cmdShutdownHelp()
+		}
+		return cmdShutdown(cfg, args[1:])
+	case "resume-all":
+		if hasHelpFlag(args[1:]) {
+			return cmdResumeAllHelp()
+		}
+		return cmdResumeAll(cfg, args[1:])
+	case "teardown-all":
+		if hasHelpFlag(args[1:]) {
+			return cmdTeardownAllHelp()
+		}
+		return cmdTeardownAll(cfg, args[1:])
+	case "setup-all":
+		if hasHelpFlag(args[1:]) {
+			return cmdSetupAllHelp()
+		}
+		return cmdSetupAll(cfg, args[1:])
+	case "note":
+		if hasHelpFlag(args[1:]) || len(args) < 2 {
+			return cmdNoteHelp()
+		}
+		return cmdNote(cfg, args[1:])
+	case "nudge":
+		if hasHelpFlag(args[1:]) || len(args) < 2 {
+			return cmdNudgeHelp()
+		}
+		return cmdNudge(cfg, args[1:])
+	case "pr":
+		if hasHelpFlag(args[1:]) {
+			return cmdPRHelp()
+		}
+		return cmdPR(cfg, args[1:])
+	case "block":
+		if hasHelpFlag(args[1:]) || len(args) < 2 {
+			return cmdBlockHelp()
+		}
+		return cmdBlock(cfg, args[1:])
+	case "split":
+		return cmdSplit(cfg, args[1:])
+	case "show":
+		return cmdShow(cfg, args[1:])
+	case "sparse":
+		if hasHelpFlag(args[1:]) {
+			return cmdSparseHelp()
+		}
+		return cmdSparse(cfg, args[1:])
+	case "unblock":
+		if hasHelpFlag(args[1:]) {
+			return cmdUnblockHelp()
+		}
+		return cmdUnblock(cfg, args[1:])
+	case "depend":
+		if hasHelpFlag(args[1:]) {
+			return cmdDependHelp()
+		}
+		return cmdDepend(cfg, args[1:])
+	case "pause":
+		if hasHelpFlag(args[1:]) {
+			return cmdPauseHelp()
+		}
+		return cmdPause(cfg, args[1:])
+	case "resume":
+		if hasHelpFlag(args[1:]) || len(args) < 2 {
+			return cmdResumeHelp()
+		}
+		return cmdResume(cfg, args[1:])
+	case "retarget":
+		if hasHelpFlag(args[1:]) {
+			return cmdRetargetHelp()
+		}
+		return cmdRetarget(cfg, args[1:])
+	case "verify":
+		if hasHelpFlag(args[1:]) {
+			return cmdVerifyHelp()
+		}
+		return cmdVerify(cfg, args[1:])
+	case "claims":
+		if hasHelpFlag(args[1:]) {
+			return cmdClaimsHelp()
+		}
+		return cmdClaims(cfg, args[1:])
+	case "open", "code":
+		if hasHelpFlag(args[1:]) {
+			return cmdOpenHelp()
+		}
+		if args[0] == "code" {
+			return cmdOpen(cfg, append([]string{"--app", "code"}, args[1:]...))
+		}
+		return cmdOpen(cfg, args[1:])
+	case "rollback":
+		if hasHelpFlag(args[1:]) || len(args) < 2 {
+			return cmdRollbackHelp()
+		}
+		return cmdRollback(cfg, args[1:])
+	case "import":
+		if hasHelpFlag(args[1:]) || len(args) < 2 {
+			return cmdImportHelp()
+		}
+		return cmdImport(cfg, args[1:])
+	case "ack":
+		if hasHelpFlag(args[1:]) || len(args) < 2 {
+			return cmdAckHelp()
+		}
+		return cmdAck(cfg, args[1:])
 	case "abandon":
 		if hasHelpFlag(args[1:]) {
 			return cmdAbandonHelp()
 		}
-		return cmdAbandon(cfg)
+		return cmdAbandon(cfg, parseAbandonFlags(args[1:]))
 	case "watch":
 		if hasHelpFlag(args[1:]) {
 			return cmdWatchHelp()
@@ -112,11 +252,14 @@ func run() error {
 		if hasHelpFlag(args[1:]) {
 			return cmdReadyHelp()
 		}
-		var projectFilter string
-		if len(args) > 1 {
-			projectFilter = args[1]
+		flags, err := parseReadyFlags(args[1:])
+		if err != nil {
+			return err
 		}
-		return cmdReady(cfg, projectFilter)
+		if flags.pick {
+			return cmdReadyPick(cfg, flags)
+		}
+		return cmdReady(cfg, flags.project)
 	case "create":
 		if hasHelpFlag(args[1:]) {
 			return cmdCreateHelp()
@@ -143,6 +286,30 @@ func run() error {
 		}
 		return cmdProject(cfg, args[1:])
 	case "auto":
+		if len(args) > 1 && args[1] == "state" {
+			if hasHelpFlag(args[2:]) {
+				return cmdAutoStateHelp()
+			}
+			return cmdAutoState(cfg, args[2:])
+		}
+		if len(args) > 1 && args[1] == "inbox" {
+			if hasHelpFlag(args[2:]) {
+				return cmdAutoInboxHelp()
+			}
+			return cmdAutoInbox(cfg, args[2:])
+		}
+		if len(args) > 1 && args[1] == "queue" {
+			if hasHelpFlag(args[2:]) {
+				return cmdAutoQueueHelp()
+			}
+			return cmdAutoQueue(cfg, args[2:])
+		}
+		if len(args) > 1 && args[1] == "approve" {
+			if hasHelpFlag(args[2:]) {
+				return cmdAutoApproveHelp()
+			}
+			return cmdAutoApprove(cfg, args[2:])
+		}
 		if hasHelpFlag(args[1:]) {
 			return cmdAutoHelp()
 		}
@@ -157,10 +324,36 @@ func run() error {
 			return cmdEventsHelp()
 		}
 		return cmdEvents(cfg, args[1:])
+	case "history":
+		if hasHelpFlag(args[1:]) {
+			return cmdHistoryHelp()
+		}
+		return cmdHistory(cfg, args[1:])
+	case "init":
+		if hasHelpFlag(args[1:]) {
+			return cmdInitHelp()
+		}
+		return cmdInit(cfg, args[1:])
+	case "migrate":
+		if hasHelpFlag(args[1:]) {
+			return cmdMigrateHelp()
+		}
+		return cmdMigrate(cfg, args[1:])
+	case "uninstall":
+		if hasHelpFlag(args[1:]) {
+			return cmdUninstallHelp()
+		}
+		return cmdUninstall(cfg, args[1:])
 	case "doctor":
 		if hasHelpFlag(args[1:]) {
 			return cmdDoctorHelp()
 		}
+		if len(args) > 1 && args[1] == "--migrate-worktrees" {
+			return doctor.MigrateWorktrees(cfg)
+		}
+		if len(args) > 1 && args[1] == "--ports" {
+			return doctor.PrintPorts(cfg)
+		}
 		return doctor.Run(cfg)
 	case "config":
 		if hasHelpFlag(args[1:]) {
@@ -172,6 +365,11 @@ func run() error {
 			return cmdPickHelp()
 		}
 		return cmdPick(cfg)
+	case "back":
+		if hasHelpFlag(args[1:]) {
+			return cmdBackHelp()
+		}
+		return cmdBack(cfg)
 	case "keys":
 		if hasHelpFlag(args[1:]) {
 			return cmdKeysHelp()
@@ -185,6 +383,9 @@ func run() error {
 			return cmdCompletionHelp()
 		}
 		return cmdCompletion(args[1])
+	case "__complete":
+		// Hidden: values for the completion scripts
+		return cmdComplete(cfg, args[1:])
 	case "version", "--version", "-v":
 		return cmdVersion()
 	case "help", "--help", "-h":
@@ -221,15 +422,33 @@ func run() error {
 	}
 }
 
-// parseGlobalFlags extracts global flags like --json from args
-func parseGlobalFlags(args []string) []string {
+// parseGlobalFlags extracts global flags like --json, --verbose and
+// --profile from args. wt prime and wt checkpoint read --quiet from
+// quietFlag.
+func parseGlobalFlags(args []string) ([]string, error) {
 	var filtered []string
-	for _, arg := range args {
-		if arg == "--json" {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--json":
 			outputJSON = true
-		} else {
+		case arg == "--plain":
+			outputPlain = true
+		case arg == "--verbose":
+			verboseFlag = true
+		case arg == "--quiet":
+			quietFlag = true
+		case arg == "--profile":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--profile requires a profile name")
+			}
+			profileOverride = args[i+1]
+			i++
+		case strings.HasPrefix(arg, "--profile="):
+			profileOverride = strings.TrimPrefix(arg, "--profile=")
+		default:
 			filtered = append(filtered, arg)
 		}
 	}
-	return filtered
+	return filtered, nil
 }
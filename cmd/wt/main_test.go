@@ -3,7 +3,9 @@ package main
 import (
 	"testing"
 
+	"github.com/badri/wt/internal/bead"
 	"github.com/badri/wt/internal/project"
+	"github.com/badri/wt/internal/session"
 )
 
 func TestParseNewFlags(t *testing.T) {
@@ -16,6 +18,7 @@ func TestParseNewFlags(t *testing.T) {
 		wantNoSwitch    bool
 		wantForceSwitch bool
 		wantNoTestEnv   bool
+		wantStackOn     string
 	}{
 		{
 			name:       "bead only",
@@ -52,6 +55,12 @@ func TestParseNewFlags(t *testing.T) {
 			wantBeadID:    "test-bead",
 			wantNoTestEnv: true,
 		},
+		{
+			name:        "with stack-on",
+			args:        []string{"test-bead", "--stack-on", "parent-bead"},
+			wantBeadID:  "test-bead",
+			wantStackOn: "parent-bead",
+		},
 		{
 			name:            "all flags",
 			args:            []string{"test-bead", "--repo", "/repo", "--name", "myname", "--switch", "--no-test-env"},
@@ -85,6 +94,9 @@ func TestParseNewFlags(t *testing.T) {
 			if flags.noTestEnv != tt.wantNoTestEnv {
 				t.Errorf("noTestEnv = %v, want %v", flags.noTestEnv, tt.wantNoTestEnv)
 			}
+			if flags.stackOn != tt.wantStackOn {
+				t.Errorf("stackOn = %q, want %q", flags.stackOn, tt.wantStackOn)
+			}
 		})
 	}
 }
@@ -190,6 +202,26 @@ func TestBuildInitialPrompt(t *testing.T) {
 			},
 			wantContains: []string{
 				"Run tests and fix any failures",
+				"## Test Environment",
+				"`PORT_OFFSET` is set in your shell",
+			},
+		},
+		{
+			name:        "project with test env test command",
+			beadID:      "test-jkl",
+			title:       "Tested feature",
+			description: "",
+			sessionName: "wt-heron",
+			proj: &project.Project{
+				MergeMode: "pr-review",
+				TestEnv: &project.TestEnv{
+					HealthCheck: "curl -sf localhost:$((8080 + PORT_OFFSET))/health",
+					Test:        "make test SESSION={{.Session}}",
+				},
+			},
+			wantContains: []string{
+				"Run tests and fix any failures: `make test SESSION=wt-heron`",
+				"Check the services are up with `curl -sf localhost:$((8080 + PORT_OFFSET))/health`",
 			},
 		},
 		{
@@ -209,7 +241,7 @@ func TestBuildInitialPrompt(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := buildInitialPrompt(tt.beadID, tt.title, tt.description, tt.sessionName, tt.proj)
+			result := buildInitialPrompt(tt.beadID, tt.title, tt.description, tt.sessionName, tt.proj, project.CommandVars{Bead: tt.beadID, Session: tt.sessionName})
 
 			for _, want := range tt.wantContains {
 				if !contains(result, want) {
@@ -257,3 +289,105 @@ func TestGetStatusIcon(t *testing.T) {
 		})
 	}
 }
+
+func TestFindSessionByNameOrBead(t *testing.T) {
+	state := &session.State{Sessions: map[string]*session.Session{
+		"toast":  {Bead: "wt-abc"},
+		"shadow": {Bead: "wt-def"},
+	}}
+
+	tests := []struct {
+		query    string
+		wantName string
+	}{
+		{"toast", "toast"},
+		{"wt-def", "shadow"},
+		{"missing", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			name, sess := findSessionByNameOrBead(state, tt.query)
+			if name != tt.wantName {
+				t.Errorf("findSessionByNameOrBead(%q) name = %q, want %q", tt.query, name, tt.wantName)
+			}
+			if (sess == nil) != (tt.wantName == "") {
+				t.Errorf("findSessionByNameOrBead(%q) session = %v", tt.query, sess)
+			}
+		})
+	}
+}
+
+func TestFormatPRStatus(t *testing.T) {
+	tests := []struct {
+		status string
+		url    string
+		want   string
+	}{
+		{"none", "", "none"},
+		{"open", "https://github.com/o/r/pull/1", "open https://github.com/o/r/pull/1"},
+		{"merged", "", "merged"},
+	}
+
+	for _, tt := range tests {
+		if got := formatPRStatus(tt.status, tt.url); got != tt.want {
+			t.Errorf("formatPRStatus(%q, %q) = %q, want %q", tt.status, tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestFormatSignal(t *testing.T) {
+	if got := formatSignal("ready", ""); got != "ready" {
+		t.Errorf("formatSignal() = %q, want %q", got, "ready")
+	}
+	if got := formatSignal("blocked", "need API key"); got != "blocked - need API key" {
+		t.Errorf("formatSignal() = %q, want %q", got, "blocked - need API key")
+	}
+}
+
+func TestParseCreateFlags(t *testing.T) {
+	opts, flags := parseCreateFlags([]string{"--template", "bug", "-e", "--label", "ui,urgent", "-l", "auth", "-p", "1"})
+
+	if flags.template != "bug" || !flags.edit {
+		t.Errorf("flags = %+v, want template bug and edit", flags)
+	}
+	if opts.Priority != 1 {
+		t.Errorf("Priority = %d, want 1", opts.Priority)
+	}
+	if len(opts.Labels) != 3 || opts.Labels[2] != "auth" {
+		t.Errorf("Labels = %v, want [ui urgent auth]", opts.Labels)
+	}
+}
+
+func TestApplyBeadTemplate(t *testing.T) {
+	two := 2
+	tmpl := &project.BeadTemplate{Priority: &two, Labels: []string{"bug", "triage"}, Description: "## Context\n{DESCRIPTION}\n"}
+
+	opts := &bead.CreateOptions{Priority: -1, Description: "Crashes on save", Labels: []string{"bug"}}
+	applyBeadTemplate(opts, "bug", tmpl, "Fix crash")
+
+	if opts.Type != "bug" {
+		t.Errorf("Type = %q, want bug (from template name)", opts.Type)
+	}
+	if opts.Priority != 2 {
+		t.Errorf("Priority = %d, want 2", opts.Priority)
+	}
+	if len(opts.Labels) != 2 {
+		t.Errorf("Labels = %v, want [bug triage]", opts.Labels)
+	}
+	if opts.Description != "## Context\nCrashes on save\n" {
+		t.Errorf("Description = %q", opts.Description)
+	}
+
+	// Explicit flags win, and unknown template names don't become types
+	opts = &bead.CreateOptions{Priority: 0, Type: "task"}
+	applyBeadTemplate(opts, "spike", tmpl, "Investigate")
+	if opts.Type != "task" || opts.Priority != 0 {
+		t.Errorf("explicit flags overridden: type %q priority %d", opts.Type, opts.Priority)
+	}
+	opts = &bead.CreateOptions{Priority: -1}
+	applyBeadTemplate(opts, "spike", tmpl, "Investigate")
+	if opts.Type != "" {
+		t.Errorf("Type = %q, want empty for non-type template name", opts.Type)
+	}
+}
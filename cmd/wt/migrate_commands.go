@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/migrate"
+)
+
+// cmdMigrate upgrades the state in the config dir to the format of this wt
+func cmdMigrate(cfg *config.Config, args []string) error {
+	dryRun := false
+	for _, arg := range args {
+		switch arg {
+		case "--dry-run", "-n":
+			dryRun = true
+		default:
+			return fmt.Errorf("unknown flag: %s", arg)
+		}
+	}
+
+	changes, err := migrate.Plan(cfg)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		if !dryRun {
+			if _, err := migrate.Apply(cfg, nil, time.Now()); err != nil {
+				return err
+			}
+		}
+		fmt.Printf("State in %s is up to date (format %d).\n", cfg.ConfigDir(), migrate.Version)
+		return nil
+	}
+
+	if dryRun {
+		fmt.Printf("wt migrate would make %d change(s) in %s:\n", len(changes), cfg.ConfigDir())
+		for _, c := range changes {
+			fmt.Printf("  [%d] %s\n", c.Version, c.Desc)
+		}
+		fmt.Println("\nRun 'wt migrate' to apply them.")
+		return nil
+	}
+
+	backupDir, err := migrate.Apply(cfg, changes, time.Now())
+	for _, c := range changes {
+		fmt.Printf("  [%d] %s\n", c.Version, c.Desc)
+	}
+	if backupDir != "" {
+		fmt.Printf("Backups: %s\n", backupDir)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✓ Migrated state to format %d\n", migrate.Version)
+	return nil
+}
+
+func cmdMigrateHelp() error {
+	help := `wt migrate - Upgrade wt's on-disk state after a version change
+
+USAGE:
+    wt migrate [--dry-run]
+
+DESCRIPTION:
+    Brings the files in the config dir (~/.config/wt, or the active
+    profile's) to the format this wt writes, instead of editing JSON by hand
+    after an upgrade:
+
+    [1] sessions.json nested under a "sessions" key is flattened
+    [2] the global wt auto files (auto-epic-state.json, auto-queue.json,
+        stop-auto, ...) are renamed to the per-project names of the project
+        they belong to, and a stale global auto.lock is removed
+    [3] events logged with a "ts" time or a dotted type (session.created)
+        are rewritten, in events.jsonl and its rotated archives
+
+    Every file is copied to ~/.config/wt/backups/migrate-<time>/ before it
+    is changed. The format reached is recorded in state-version; a newer
+    format than this wt knows is refused. Running it again is harmless.
+
+OPTIONS:
+    -n, --dry-run       List the changes without making them
+    -h, --help          Show this help
+
+EXAMPLES:
+    wt migrate --dry-run      See what an upgrade needs
+    wt migrate                Upgrade, with backups
+`
+	fmt.Print(help)
+	return nil
+}
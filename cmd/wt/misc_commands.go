@@ -2,6 +2,7 @@ package main
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
@@ -11,143 +12,52 @@ import (
 
 	"github.com/charmbracelet/bubbles/table"
 
-	"github.com/badri/wt/internal/auto"
 	"github.com/badri/wt/internal/bead"
 	"github.com/badri/wt/internal/config"
 	"github.com/badri/wt/internal/events"
+	"github.com/badri/wt/internal/logging"
 	"github.com/badri/wt/internal/session"
 )
 
-// cmdAuto runs autonomous batch processing of beads
-func cmdAuto(cfg *config.Config, args []string) error {
-	opts := parseAutoFlags(args)
-	runner := auto.NewRunner(cfg, opts)
-	return runner.Run()
-}
-
-func parseAutoFlags(args []string) *auto.Options {
-	opts := &auto.Options{}
-	for i := 0; i < len(args); i++ {
-		switch args[i] {
-		case "-p", "--project":
-			if i+1 < len(args) {
-				opts.Project = args[i+1]
-				i++
-			}
-		case "-m", "--merge-mode":
-			if i+1 < len(args) {
-				opts.MergeMode = args[i+1]
-				i++
-			}
-		case "--timeout":
-			if i+1 < len(args) {
-				fmt.Sscanf(args[i+1], "%d", &opts.Timeout)
-				i++
-			}
-		case "-n", "--limit":
-			if i+1 < len(args) {
-				fmt.Sscanf(args[i+1], "%d", &opts.Limit)
-				i++
-			}
-		case "--epic", "-e":
-			if i+1 < len(args) {
-				opts.Epic = args[i+1]
-				i++
-			}
-		case "--dry-run":
-			opts.DryRun = true
-		case "--check":
-			opts.Check = true
-		case "--stop":
-			opts.Stop = true
-		case "--force":
-			opts.Force = true
-		case "--pause-on-failure":
-			opts.PauseOnFailure = true
-		case "--skip-audit":
-			opts.SkipAudit = true
-		case "--resume":
-			opts.Resume = true
-		case "--abort":
-			opts.Abort = true
-		}
-	}
-	return opts
-}
-
-// cmdAutoHelp prints help for the auto command
-func cmdAutoHelp() error {
-	help := `wt auto - Autonomous batch processing of beads
-
-USAGE:
-    wt auto --epic <id> [options]
-    wt auto --project <name> [options]
-
-DESCRIPTION:
-    Two modes of operation:
-
-    Epic mode (--epic):
-      Processes all ready beads in an epic sequentially in a single worktree.
-      Creates one PR/merge at the end instead of one per bead.
-
-    Project mode (--project):
-      Processes all ready beads for a project serially, each in its own
-      worktree. Creates separate PRs per bead.
-
-OPTIONS:
-    -e, --epic <id>         Epic ID to process (single worktree mode)
-    -p, --project <name>    Project to process (separate worktrees mode)
-    -n, --limit <N>         Max beads to process
-    -m, --merge-mode <mode> Merge mode: direct, pr-auto, pr-review
-    --timeout <minutes>     Per-bead timeout in minutes (default: 30)
-    --dry-run               Preview what would be processed (includes audit)
-    --pause-on-failure      Stop and preserve worktree if a bead fails
-    --skip-audit            Bypass implicit audit (use with caution)
-    --check                 Check status of running/paused auto session
-    --resume                Resume a paused or failed epic run
-    --abort                 Abort and clean up a paused/failed run
-    --stop                  Stop the auto runner gracefully
-    --force                 Force start even if another auto is running
-
-EPIC WORKFLOW:
-    1. Group work into an epic:
-       bd create "Documentation batch" -t epic
-       bd dep add wt-tcf wt-doc-epic
-       bd dep add wt-1a3 wt-doc-epic
-
-    2. Run batch processing:
-       wt auto --epic wt-doc-epic
-
-    3. If a bead fails with --pause-on-failure:
-       - Fix manually in the preserved worktree
-       - wt auto --resume    (continue from where it stopped)
-       - wt auto --abort     (clean up and abandon)
-
-PROJECT WORKFLOW:
-    Process all ready beads for a project:
-       wt auto --project myapp
-       wt auto --project myapp --limit 3
-
-EXAMPLES:
-    wt auto --epic wt-doc-batch           Process beads in epic
-    wt auto --project myapp               Process ready beads for project
-    wt auto --project myapp --limit 5     Process up to 5 beads
-    wt auto --epic wt-xyz --dry-run       Preview without executing
-    wt auto --check                       Check status of current run
-`
-	fmt.Print(help)
-	return nil
-}
-
 // cmdEventsHelp shows help for the events command
 func cmdEventsHelp() error {
 	help := `wt events - Show event history
 
 USAGE:
     wt events [options]
+    wt events emit <name> [--data <json>] [--note <text>]
+    wt events compact
+    wt events summary [--since <duration>] [--email]
 
 DESCRIPTION:
     Shows the history of wt events (session starts, completions, etc).
+    Notes added with 'wt note' appear inline in the Note column.
+
+    'wt events emit' adds a custom event, so hooks and scripts can put
+    their own milestones in the feed. Its type is the name prefixed with
+    "custom." (e.g. custom.deploy-started), which keeps it apart from wt's
+    own types. Project hooks run with WT_SESSION, WT_BEAD and WT_PROJECT
+    set, and the event is attributed to that session; elsewhere it is the
+    session of the current worktree, or --session/--bead/--project.
+
+    The log is rotated into gzip-compressed archives (events-<time>.jsonl.gz
+    next to events.jsonl) once it passes events_max_size (10MB by default)
+    or, with events_max_age set, holds events older than that. Archives
+    older than events_max_age are deleted. Reading the log (wt events,
+    --since, wt seance) covers the archives too. 'wt events compact'
+    applies the policy now instead of at the next event.
+
+    'wt events summary' sums up the last day (or --since): sessions
+    completed, merges, failures, and PRs still awaiting review. With
+    --email it is sent with the SMTP settings of notifications.email in
+    config.json; set email.daily_summary ("18:00") and wt watch sends it
+    every day at that time, or run it from cron.
+
+EMIT OPTIONS:
+    --data <json>       JSON payload stored with the event
+    --note <text>       Text shown in the Note column
+    -s, --session <name>, -b, --bead <id>, -p, --project <name>
+                        Attribute the event explicitly
 
 OPTIONS:
     --since <duration>  Show events since duration (e.g., 1h, 24h, 7d)
@@ -160,6 +70,10 @@ EXAMPLES:
     wt events --since 24h   Show events from the last 24 hours
     wt events -f            Watch for new events
     wt events -n 50         Show last 50 events
+    wt events emit deploy-started --data '{"env":"staging"}'
+    wt events compact       Rotate and prune the log now
+    wt events summary       What happened in the last 24 hours
+    wt events summary --email   Email it (e.g. from cron)
 `
 	fmt.Print(help)
 	return nil
@@ -175,13 +89,48 @@ USAGE:
 COMMANDS:
     (none), show        Show current configuration
     init                Create config file with defaults
+    get <key>           Print the effective value of a key (for scripts)
     set <key> <value>   Set a configuration value
     edit                Open config in editor
+    profile list        List config profiles (* marks the active one)
+    profile switch <n>  Make <n> the active profile, creating it if needed
+
+PROFILES:
+    Each profile has its own config, projects, sessions, namepool and
+    event log. The default profile lives in ~/.config/wt, named ones in
+    ~/.config/wt/profiles/<name>/ with worktrees under ~/worktrees/<name>.
+    Any command can pick a profile with --profile <name> or WT_PROFILE;
+    worker sessions stay on the profile that started them.
 
 CONFIG KEYS:
     worktree_root       Directory where worktrees are created
     editor_cmd          Editor command for config editing
     default_merge_mode  Default merge mode: direct, pr-auto, pr-review
+    idle_detection      How activity is detected: tmux (default), transcript
+    tmux_status         Show bead, status and idle time in each session's
+                        tmux status line and window name: true, false
+    open_app            Editor 'wt open' uses, e.g. code, cursor, nvim (code)
+    claimant            Identity 'wt new' claims beads as (user@host)
+    claim_ttl           Age after which another hub's claim may be taken
+                        over, e.g. 12h (24h)
+    notify_digest       Batch 'wt watch' notifications into one summary per
+                        window, e.g. 15m; errors still arrive at once (off)
+    notify.<event>      Delivery of one event type: immediate, digest, off.
+                        Events: idle, ready, blocked, error, ended, permission,
+                        checks, context
+    context_handoff     Context window percent at which 'wt watch' asks a
+                        worker or the hub to run 'wt handoff', e.g. 80 (off)
+    context_window      Context window size in tokens (200000)
+    events_max_age      Delete rotated event archives older than this,
+                        e.g. 90d (off: keep forever)
+    events_max_size     Rotate events.jsonl into a compressed archive past
+                        this size, e.g. 10MB (10MB; off)
+
+ENVIRONMENT:
+    WT_WORKTREE_ROOT, WT_EDITOR_CMD and WT_MERGE_MODE override worktree_root,
+    editor_cmd and default_merge_mode, e.g. in CI jobs. They are never
+    written to the config file; 'wt config show' marks each of these values
+    with its source (file, env or default).
 
 OPTIONS:
     -h, --help          Show this help
@@ -189,8 +138,19 @@ OPTIONS:
 EXAMPLES:
     wt config                           Show current config
     wt config init                      Create config file
+    wt config get worktree_root         Print one value for a script
     wt config set worktree_root ~/wt    Set worktree directory
+    wt config set idle_detection transcript  Classify sessions from Claude transcripts
+    wt config set tmux_status true      Tag new sessions' tmux status lines
+    wt config set open_app cursor       Open worktrees in Cursor
+    wt config set claim_ttl 8h          Let claims from dead hubs expire sooner
+    wt config set notify_digest 15m     One notification summary every 15 minutes
+    wt config set notify.permission immediate  Don't batch permission prompts
+    wt config set context_handoff 80    Hand off agents at 80% context
     wt config edit                      Open config in editor
+    wt config profile switch work       Keep client work in its own profile
+    wt --profile personal list          List sessions of another profile
+    WT_MERGE_MODE=direct wt done        Merge directly for this run only
 `
 	fmt.Print(help)
 	return nil
@@ -225,8 +185,9 @@ USAGE:
     wt keys
 
 DESCRIPTION:
-    Outputs suggested tmux keybindings for wt commands.
-    Add these to your ~/.tmux.conf file.
+    Outputs suggested tmux keybindings for wt commands, and a status-line
+    snippet showing the bead, status and idle time of the wt session you
+    are in. Add these to your ~/.tmux.conf file.
 
 OPTIONS:
     -h, --help          Show this help
@@ -274,17 +235,35 @@ func cmdDoctorHelp() error {
 	help := `wt doctor - Check system requirements
 
 USAGE:
-    wt doctor
+    wt doctor [--migrate-worktrees | --ports]
 
 DESCRIPTION:
     Checks that all required tools are installed and configured correctly.
     Validates git, tmux, bd (beads), and other dependencies.
 
+    Worktrees live in worktree_root/<project>/<bead>. Doctor warns about
+    sessions still in the old flat layout (worktree_root/<bead>), where
+    equal bead IDs or names from two projects land in one directory.
+    --migrate-worktrees moves them with 'git worktree move'; sessions
+    whose tmux session is running are left until they end.
+
+    Each session's test_env ports (base port plus its port offset) are
+    probed: a port must be free or held by that session's env. A process
+    whose working directory is in the session's worktree counts as the
+    env, and so do container port proxies while the env is up. Ports held
+    by other processes are warned about; two sessions mapping the same
+    port is an error. --ports prints the whole map with the process on
+    each port, for "address already in use" errors.
+
 OPTIONS:
+    --migrate-worktrees Move flat-layout worktrees into project directories
+    --ports             Show every session's port map and who holds each port
     -h, --help          Show this help
 
 EXAMPLES:
-    wt doctor           Run system check
+    wt doctor                       Run system check
+    wt doctor --migrate-worktrees   Namespace old worktrees by project
+    wt doctor --ports               Debug "address already in use"
 `
 	fmt.Print(help)
 	return nil
@@ -292,6 +271,15 @@ EXAMPLES:
 
 // cmdEvents shows wt events
 func cmdEvents(cfg *config.Config, args []string) error {
+	if len(args) > 0 && args[0] == "emit" {
+		return cmdEventsEmit(cfg, args[1:])
+	}
+	if len(args) > 0 && args[0] == "compact" {
+		return cmdEventsCompact(cfg, args[1:])
+	}
+	if len(args) > 0 && args[0] == "summary" {
+		return cmdEventsSummary(cfg, args[1:])
+	}
 	logger := events.NewLogger(cfg)
 
 	// Parse flags
@@ -322,20 +310,14 @@ func cmdEvents(cfg *config.Config, args []string) error {
 	// Tail mode
 	if tail {
 		fmt.Println("Watching events... (Ctrl+C to exit)")
-		ctx := make(chan struct{})
 		eventCh := make(chan events.Event, 10)
 
 		go func() {
-			logger.Tail(nil, eventCh)
+			logger.Tail(context.Background(), eventCh)
 		}()
 
-		for {
-			select {
-			case e := <-eventCh:
-				printEvent(&e)
-			case <-ctx:
-				return nil
-			}
+		for e := range eventCh {
+			printEvent(&e)
 		}
 	}
 
@@ -365,6 +347,7 @@ func cmdEvents(cfg *config.Config, args []string) error {
 		{Title: "Project", Width: 12},
 		{Title: "Bead", Width: 18},
 		{Title: "Session", Width: 12},
+		{Title: "Note", Width: 40},
 	}
 
 	// Build rows
@@ -373,6 +356,10 @@ func cmdEvents(cfg *config.Config, args []string) error {
 		t, _ := time.Parse(time.RFC3339, e.Time)
 		timeStr := t.Format("2006-01-02 15:04:05")
 		icon := getEventIcon(e.Type)
+		note := e.Note
+		if note == "" && len(e.Data) > 0 {
+			note = string(e.Data)
+		}
 
 		rows = append(rows, table.Row{
 			timeStr,
@@ -381,6 +368,7 @@ func cmdEvents(cfg *config.Config, args []string) error {
 			truncate(e.Project, 12),
 			truncate(e.Bead, 18),
 			truncate(e.Session, 12),
+			truncate(note, 40),
 		})
 	}
 
@@ -399,10 +387,30 @@ func getEventIcon(eventType events.EventType) string {
 		return "x"
 	case events.EventHubHandoff:
 		return "~"
-	case events.EventPRCreated:
+	case events.EventPRCreated, events.EventPRUpdated:
 		return "^"
 	case events.EventPRMerged:
 		return "+"
+	case events.EventNote:
+		return "@"
+	case events.EventRollback:
+		return "<"
+	case events.EventPermissionRequested:
+		return "?"
+	case events.EventBlocked:
+		return "!"
+	case events.EventUnblocked:
+		return "="
+	case events.EventVerified:
+		return "v"
+	case events.EventVerifyFailed:
+		return "X"
+	case events.EventSessionError:
+		return "E"
+	case events.EventBeadComment:
+		return "\""
+	case events.EventCommand:
+		return "$"
 	default:
 		return "*"
 	}
@@ -415,6 +423,30 @@ func printEvent(e *events.Event) {
 
 	fmt.Printf("%s %s %-14s %-12s %-18s %s\n",
 		timeStr, icon, e.Type, e.Project, e.Bead, e.Session)
+	if e.Note != "" {
+		fmt.Printf("    %s\n", e.Note)
+	}
+	if e.Blocker != "" {
+		fmt.Printf("    waiting on %s\n", e.Blocker)
+	}
+	if e.Error != "" {
+		fmt.Printf("    failed: %s\n", e.Error)
+	}
+	if e.Permission != "" {
+		if e.AutoApproved {
+			fmt.Printf("    %s (auto-approved)\n", e.Permission)
+		} else {
+			fmt.Printf("    %s\n", e.Permission)
+		}
+	}
+	if len(e.Data) > 0 {
+		fmt.Printf("    data: %s\n", e.Data)
+	}
+	if e.Output != "" {
+		for _, line := range strings.Split(e.Output, "\n") {
+			fmt.Printf("    | %s\n", line)
+		}
+	}
 }
 
 // cmdConfig manages wt configuration
@@ -428,6 +460,11 @@ func cmdConfig(cfg *config.Config, args []string) error {
 		return showConfig(cfg)
 	case "init":
 		return initConfig(cfg)
+	case "get":
+		if len(args) != 2 {
+			return fmt.Errorf("usage: wt config get <key>")
+		}
+		return getConfig(cfg, args[1])
 	case "set":
 		if len(args) < 3 {
 			return fmt.Errorf("usage: wt config set <key> <value>")
@@ -435,19 +472,51 @@ func cmdConfig(cfg *config.Config, args []string) error {
 		return setConfig(cfg, args[1], args[2])
 	case "edit", "editor":
 		return configEditor(cfg)
+	case "profile", "profiles":
+		return cmdConfigProfile(cfg, args[1:])
 	default:
-		return fmt.Errorf("unknown config command: %s\nUsage: wt config [show|init|set|edit]", args[0])
+		return fmt.Errorf("unknown config command: %s%s\nUsage: wt config [show|get|init|set|edit|profile]", args[0], didYouMean(args[0], []string{"show", "get", "init", "set", "edit", "profile"}))
 	}
 }
 
 func showConfig(cfg *config.Config) error {
 	fmt.Println("wt Configuration")
 	fmt.Println("---------------------------------------------")
+	fmt.Printf("  Profile:          %s\n", cfg.Profile())
 	fmt.Printf("  Config dir:       %s\n", cfg.ConfigDir())
 	fmt.Printf("  Config file:      %s\n", cfg.ConfigPath())
-	fmt.Printf("  Worktree root:    %s\n", cfg.WorktreeRoot)
-	fmt.Printf("  Editor command:   %s\n", cfg.EditorCmd)
-	fmt.Printf("  Default merge:    %s\n", cfg.DefaultMergeMode)
+	fmt.Printf("  Worktree root:    %s %s\n", cfg.WorktreeRoot, configSource(cfg, "worktree_root"))
+	fmt.Printf("  Editor command:   %s %s\n", cfg.EditorCmd, configSource(cfg, "editor_cmd"))
+	fmt.Printf("  Default merge:    %s %s\n", cfg.DefaultMergeMode, configSource(cfg, "default_merge_mode"))
+	idleDetection := cfg.IdleDetection
+	if idleDetection == "" {
+		idleDetection = "tmux"
+	}
+	fmt.Printf("  Idle detection:   %s\n", idleDetection)
+	fmt.Printf("  Tmux status:      %t\n", cfg.TmuxStatus)
+	fmt.Printf("  Open app:         %s\n", cfg.OpenAppCmd())
+	fmt.Printf("  Claimant:         %s (claims expire after %s)\n", cfg.ClaimantID(), cfg.ClaimExpiry())
+	if window := cfg.DigestWindow(); window > 0 {
+		fmt.Printf("  Notify digest:    every %s\n", window)
+	} else {
+		fmt.Printf("  Notify digest:    off\n")
+	}
+	if e := cfg.EmailSettings(); e != nil {
+		fmt.Printf("  Email:            %s via %s:%d%s\n", strings.Join(e.To, ", "), e.SMTPHost, e.Port(), emailExtras(e))
+	} else {
+		fmt.Printf("  Email:            off\n")
+	}
+	if q := cfg.QueueSettings(); q != nil {
+		fmt.Printf("  Work queue:       %s on %s\n", q.QueueName(), q.DisplayURL())
+	} else {
+		fmt.Printf("  Work queue:       off\n")
+	}
+	if cfg.ContextHandoff > 0 {
+		fmt.Printf("  Context handoff:  at %d%% of %d tokens\n", cfg.ContextHandoff, cfg.ContextWindowTokens())
+	} else {
+		fmt.Printf("  Context handoff:  off\n")
+	}
+	fmt.Printf("  Events log:       %s\n", retentionPolicy(cfg))
 	fmt.Printf("  Sessions file:    %s\n", cfg.SessionsPath())
 	fmt.Printf("  Namepool file:    %s\n", cfg.NamepoolPath())
 
@@ -483,12 +552,57 @@ func setConfig(cfg *config.Config, key, value string) error {
 	case "editor_cmd":
 		cfg.EditorCmd = value
 	case "default_merge_mode":
-		if value != "direct" && value != "pr-auto" && value != "pr-review" {
+		if !config.ValidMergeMode(value) {
 			return fmt.Errorf("invalid merge mode: %s\nValid: direct, pr-auto, pr-review", value)
 		}
 		cfg.DefaultMergeMode = value
+	case "idle_detection":
+		if value != "tmux" && value != "transcript" {
+			return fmt.Errorf("invalid idle detection: %s\nValid: tmux, transcript", value)
+		}
+		cfg.IdleDetection = value
+	case "tmux_status":
+		if value != "true" && value != "false" {
+			return fmt.Errorf("invalid tmux status: %s\nValid: true, false", value)
+		}
+		cfg.TmuxStatus = value == "true"
+	case "open_app":
+		cfg.OpenApp = value
+	case "claimant":
+		cfg.Claimant = value
+	case "claim_ttl":
+		if d, err := time.ParseDuration(value); err != nil || d <= 0 {
+			return fmt.Errorf("invalid claim ttl: %s\nUse a duration like 12h", value)
+		}
+		cfg.ClaimTTL = value
+	case "notify_digest":
+		if err := cfg.SetNotifyDigest(value); err != nil {
+			return err
+		}
+	case "context_handoff":
+		if err := cfg.SetContextHandoff(value); err != nil {
+			return err
+		}
+	case "context_window":
+		if err := cfg.SetContextWindow(value); err != nil {
+			return err
+		}
+	case "events_max_age":
+		if err := cfg.SetEventsMaxAge(value); err != nil {
+			return err
+		}
+	case "events_max_size":
+		if err := cfg.SetEventsMaxSize(value); err != nil {
+			return err
+		}
 	default:
-		return fmt.Errorf("unknown config key: %s\nValid keys: worktree_root, editor_cmd, default_merge_mode", key)
+		if eventType, ok := strings.CutPrefix(key, "notify."); ok {
+			if err := cfg.SetNotifyMode(eventType, value); err != nil {
+				return err
+			}
+			break
+		}
+		return unknownConfigKey(key)
 	}
 
 	if err := cfg.Save(); err != nil {
@@ -496,6 +610,9 @@ func setConfig(cfg *config.Config, key, value string) error {
 	}
 
 	fmt.Printf("Set %s = %s\n", key, value)
+	if env := config.EnvVar(key); cfg.Source(key) == config.SourceEnv {
+		logging.Warnf("%s is set and overrides this value until it is unset", env)
+	}
 	return nil
 }
 
@@ -515,7 +632,7 @@ func configEditor(cfg *config.Config) error {
 		editor = "vi"
 	}
 
-	cmd := exec.Command(editor, cfg.ConfigPath())
+	cmd := logging.Command(editor, cfg.ConfigPath())
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
@@ -569,10 +686,10 @@ func cmdPick(cfg *config.Config) error {
 
 	// Check for fzf
 	if hasFzf() {
-		return pickWithFzf(entries)
+		return pickWithFzf(cfg, entries)
 	}
 
-	return pickWithPrompt(entries)
+	return pickWithPrompt(cfg, entries)
 }
 
 func hasFzf() bool {
@@ -580,7 +697,7 @@ func hasFzf() bool {
 	return err == nil
 }
 
-func pickWithFzf(entries []pickerEntry) error {
+func pickWithFzf(cfg *config.Config, entries []pickerEntry) error {
 	// Build input for fzf
 	var lines []string
 	for _, e := range entries {
@@ -594,7 +711,7 @@ func pickWithFzf(entries []pickerEntry) error {
 	input := strings.Join(lines, "\n")
 
 	// Run fzf
-	cmd := exec.Command("fzf", "--header=Name         Status     Title                          Project",
+	cmd := logging.Command("fzf", "--header=Name         Status     Title                          Project",
 		"--prompt=Select session: ",
 		"--height=40%",
 		"--reverse")
@@ -620,13 +737,11 @@ func pickWithFzf(entries []pickerEntry) error {
 
 	sessionName := fields[0]
 
-	// Switch to session using tmux
 	fmt.Printf("Switching to session: %s\n", sessionName)
-	switchCmd := exec.Command("tmux", "switch-client", "-t", sessionName)
-	return switchCmd.Run()
+	return switchToSession(cfg, sessionName)
 }
 
-func pickWithPrompt(entries []pickerEntry) error {
+func pickWithPrompt(cfg *config.Config, entries []pickerEntry) error {
 	fmt.Println("Active Sessions:")
 	fmt.Println("--------------------------------------------------------------------------------")
 	for i, e := range entries {
@@ -656,8 +771,7 @@ func pickWithPrompt(entries []pickerEntry) error {
 		if idx > 0 && idx <= len(entries) {
 			sessionName := entries[idx-1].name
 			fmt.Printf("Switching to session: %s\n", sessionName)
-			cmd := exec.Command("tmux", "switch-client", "-t", sessionName)
-			return cmd.Run()
+			return switchToSession(cfg, sessionName)
 		}
 	}
 
@@ -665,23 +779,23 @@ func pickWithPrompt(entries []pickerEntry) error {
 	for _, e := range entries {
 		if e.name == input || strings.HasPrefix(e.name, input) {
 			fmt.Printf("Switching to session: %s\n", e.name)
-			cmd := exec.Command("tmux", "switch-client", "-t", e.name)
-			return cmd.Run()
+			return switchToSession(cfg, e.name)
 		}
 	}
 
 	return fmt.Errorf("no session matching '%s'", input)
 }
 
-// cmdKeys outputs tmux keybinding configuration
-func cmdKeys() error {
-	keybindings := `# wt tmux keybindings
+// tmuxKeybindings is the tmux configuration printed by 'wt keys' and
+// installed by 'wt init'
+const tmuxKeybindings = `# wt tmux keybindings
 # Add these to your ~/.tmux.conf
 
 # Session management
 bind-key W display-popup -E -w 80% -h 60% "wt pick"
 bind-key N command-prompt -p "bead:" "run-shell 'wt new %%'"
 bind-key K command-prompt -p "session:" "run-shell 'wt kill %%'"
+bind-key B run-shell "wt back"
 
 # Quick actions
 bind-key S run-shell "wt status"
@@ -700,10 +814,19 @@ bind-key F1 run-shell "wt signal ready"
 bind-key F2 run-shell "wt signal blocked"
 bind-key F3 run-shell "wt signal error"
 
+# Status line: bead, status and idle time of the current wt session
+# (or run 'wt config set tmux_status true' to tag wt sessions only)
+set -g status-interval 15
+set -g status-right-length 80
+set -g status-right "#(wt status --short #{session_name} 2>/dev/null) %H:%M "
+
 # Reload this config
 # bind-key r source-file ~/.tmux.conf \; display "Reloaded!"
 `
-	fmt.Print(keybindings)
+
+// cmdKeys outputs tmux keybinding configuration
+func cmdKeys() error {
+	fmt.Print(tmuxKeybindings)
 	return nil
 }
 
@@ -729,41 +852,94 @@ SESSION COMMANDS:
     wt new <bead>           Create new session for a bead
                             Options: --repo <path>, --name <name>, --no-switch, --no-test-env
     wt <name>               Switch to session by name or bead ID
+    wt clone <name>         New session branched from a session's branch
+                            Options: --bead <id>, --name <name>, --no-switch
+    wt shutdown             Save and stop all sessions (e.g. before a reboot)
+                            Options: --timeout <dur>, --no-wrapup
+    wt resume-all           Restore the sessions saved by 'wt shutdown'
+    wt teardown-all         Stop all test envs overnight, keeping sessions running
+                            Options: --project <name>
+    wt setup-all            Bring back the test envs stopped by 'wt teardown-all'
+                            Options: --project <name>
+    wt pause [name]         Stop a session's tmux and test env, keeping its context
+                            Options: --timeout <dur>, --no-wrapup
+    wt resume <name>        Bring back a paused session (--no-switch)
+    wt retarget <name> <project>
+                            Rebase a session onto another branch's project
+    wt sparse [name]        Show a session's sparse checkout paths
+                            Also: wt sparse set|add <name> <paths...>, wt sparse off <name>
     wt kill <name>          Terminate session (keeps bead open)
                             Options: --keep-worktree
     wt close <name>         Complete session and close bead
     wt done                 Complete current session with merge
-                            Options: --merge-mode <mode>
+                            Options: --merge-mode <mode>, --ignore-deps, --draft, --amend-pr
+    wt pr draft             Open a draft PR early from inside a session
+                            Also: wt pr ready [name], wt pr sync
     wt abandon              Abandon current session without merge
     wt status               Show current session status
+                            Options: --short (one line, for tmux status lines)
     wt signal <status>      Update session status (ready, blocked, error, working, idle)
+                            Options: --wait, --timeout <duration>, --report <file>
+    wt ack <name> [msg]     Acknowledge a signal, releasing 'wt signal --wait'
+    wt block "<reason>"     Mark the current session blocked and notify the hub
+                            Options: --on <bead-id>
+    wt unblock [name]       Resume a blocked session
+                            Options: -m/--message <text>
+    wt split ["<title>"...] Break the current bead into child beads
+                            Options: --chain, --take, --under <epic>
+    wt note "<text>"        Annotate a session in the event log
+                            Options: --session <name>, --bead <id>
+    wt nudge <name>         Send a canned prompt to a worker
+                            Options: --prompt <name>, --list
+    wt depend <name>        Merge the current session only after <name>
+                            Options: --session <name>, --remove
+    wt rollback <name>      Revert a session's direct merge and reopen its bead
+                            Options: --fix, --no-switch, -f/--force
+    wt verify <name>        Check the default branch after a session's merge
+                            Options: -p <project>; also: wt verify revert <name>
+    wt open [name]          Open a session's worktree in an editor
+                            Options: --app <editor>, --recent (also: wt code)
     wt pick                 Interactive session picker (uses fzf if available)
+    wt back                 Return to the previously active session
 
 PROJECT COMMANDS:
     wt projects             List registered projects
     wt project add <n> <p>  Register a project
     wt project config <n>   Edit project configuration
     wt project remove <n>   Unregister a project
+    wt project warm <n>     Fill the dependency cache used for new worktrees
     wt ready [project]      Show beads ready to work on
     wt beads <project>      List beads for a project
                             Options: --status <status>
+    wt show <bead-id>       Show a bead from any project, with its sessions and PRs
+                            Options: --raw, --json
+    wt history <bead>       What wt did with a bead: commands run, sessions, PRs
+                            Options: --commands, --json
+    wt import github        Create beads from GitHub (or Jira) issues
+                            Options: --repo <r>, --label <l>, --close-upstream
     wt create <proj> <title> Create a new bead in project
                             Options: --description, --priority, --type
-    wt audit <bead>         Audit bead readiness for implementation
-                            Options: -i/--interactive, -p/--project
+    wt claims               Show which hub claimed each in-progress bead
+                            Also: wt claims release <bead>, wt claims expire
+    wt audit <bead|epic>    Audit bead or epic readiness for implementation
+                            Options: -i/--interactive, -p/--project, --epic
 
 HUB COMMANDS:
     wt hub                  Start or attach to hub session
                             Options: -d/--detach, -s/--status, -k/--kill
     wt watch                Live dashboard of all sessions
+                            Options: --project, --status, --compact, --wide, --append
     wt auto                 Autonomous batch processing
                             Options: --project, --merge-mode, --timeout, --dry-run, --check, --stop
+    wt auto state           Show or edit an epic run's bead queue
+                            Commands: show, edit, skip-bead <id>, requeue-bead <id>
 
 HISTORY COMMANDS:
     wt seance               List past sessions for resumption
     wt seance <name>        Resume in new tmux pane (safe from hub)
     wt seance <name> --spawn  Spawn new tmux session for seance
     wt seance <name> -p 'q' One-shot query to past session
+    wt seance <name> --export  Export the transcript (--format md|json, --attach)
     wt events               Show event history
                             Options: --since <duration>, -f/--follow, -n <count>
 
@@ -771,22 +947,38 @@ HANDOFF COMMANDS:
     wt handoff              Hand off to fresh Claude instance
                             Options: -m <message>, -c/--collect, --dry-run
     wt prime                Inject context on session startup
-                            Options: -q/--quiet, --no-bd-prime, --hook
+                            Options: -q/--quiet, --no-bd-prime, --no-status, --hook
                             --hook: Read session_id from Claude SessionStart hook JSON on stdin
 
 CONFIGURATION:
+    wt init                 Set up wt on a new machine (prerequisites, config,
+                            projects, completions, tmux keys, doctor)
+                            Options: -y/--yes, --scan <dir>
     wt config               Show current configuration
     wt config init          Create config file with defaults
     wt config set <k> <v>   Set a config value
     wt config edit          Open config in editor
+    wt config profile       List profiles, or 'switch <name>' between them
     wt keys                 Output tmux keybinding suggestions
     wt doctor               Check system requirements
+    wt migrate              Upgrade on-disk state after a version change
+                            Options: -n/--dry-run
+    wt uninstall            Show what --purge would remove
+                            --purge: Kill all sessions, remove worktrees and
+                            delete config/state (--force, -y/--yes)
 
 OTHER:
     wt completion <shell>   Generate shell completion (bash, zsh, fish)
     wt version              Show version information
     wt help                 Show this help
 
+GLOBAL OPTIONS:
+    --json                  JSON output where supported
+    --plain                 ASCII output without colors or emoji (also WT_PLAIN=1)
+    --verbose               Show debug lines and the git/tmux/bd/gh commands run (also WT_VERBOSE=1)
+    --quiet                 Hide logged progress notes and warnings (also WT_QUIET=1)
+    --profile <name>        Use a config profile (also WT_PROFILE)
+
 EXAMPLES:
     wt new wt-123                     Start working on bead wt-123
     wt signal ready "PR created"      Signal that work is ready
@@ -798,187 +990,3 @@ For more information: https://github.com/badri/wt
 	fmt.Print(help)
 	return nil
 }
-
-// cmdCompletion generates shell completion scripts
-func cmdCompletion(shell string) error {
-	switch shell {
-	case "bash":
-		fmt.Print(bashCompletion)
-		return nil
-	case "zsh":
-		fmt.Print(zshCompletion)
-		return nil
-	case "fish":
-		fmt.Print(fishCompletion)
-		return nil
-	default:
-		return fmt.Errorf("unsupported shell: %s\nSupported: bash, zsh, fish", shell)
-	}
-}
-
-const bashCompletion = `# wt bash completion
-# Add to ~/.bashrc: eval "$(wt completion bash)"
-
-_wt_completions() {
-    local cur prev commands
-    COMPREPLY=()
-    cur="${COMP_WORDS[COMP_CWORD]}"
-    prev="${COMP_WORDS[COMP_CWORD-1]}"
-
-    commands="list new kill close done status abandon watch seance projects ready create beads project auto events doctor config pick keys completion version help hub handoff prime signal"
-
-    case "${prev}" in
-        wt)
-            COMPREPLY=( $(compgen -W "${commands}" -- "${cur}") )
-            return 0
-            ;;
-        new)
-            local beads=$(wt ready 2>/dev/null | awk '/^[|]/ {print $2}' | grep -v '^$' | grep -v '^Bead')
-            COMPREPLY=( $(compgen -W "${beads}" -- "${cur}") )
-            return 0
-            ;;
-        kill|close)
-            local sessions=$(wt list 2>/dev/null | awk '/^[|]/ {print $2}' | grep -v '^$' | grep -v '^Name')
-            COMPREPLY=( $(compgen -W "${sessions}" -- "${cur}") )
-            return 0
-            ;;
-        project)
-            COMPREPLY=( $(compgen -W "add config remove" -- "${cur}") )
-            return 0
-            ;;
-        config)
-            COMPREPLY=( $(compgen -W "show init set edit" -- "${cur}") )
-            return 0
-            ;;
-        signal)
-            COMPREPLY=( $(compgen -W "ready blocked error working idle" -- "${cur}") )
-            return 0
-            ;;
-        completion)
-            COMPREPLY=( $(compgen -W "bash zsh fish" -- "${cur}") )
-            return 0
-            ;;
-        *)
-            COMPREPLY=( $(compgen -W "${commands}" -- "${cur}") )
-            return 0
-            ;;
-    esac
-}
-
-complete -F _wt_completions wt
-`
-
-const zshCompletion = `#compdef wt
-# wt zsh completion
-# Add to ~/.zshrc: eval "$(wt completion zsh)"
-
-_wt() {
-    local -a commands
-
-    commands=(
-        'list:List active sessions'
-        'new:Create new session for a bead'
-        'kill:Kill a session (keep bead open)'
-        'close:Close session and bead'
-        'done:Complete work and merge'
-        'status:Show current session status'
-        'abandon:Abandon session without merging'
-        'watch:Live dashboard of sessions'
-        'seance:Talk to past sessions'
-        'projects:List registered projects'
-        'ready:Show ready beads'
-        'create:Create a new bead'
-        'beads:List beads for a project'
-        'project:Manage projects'
-        'auto:Autonomous batch processing'
-        'events:Show wt events'
-        'doctor:Check wt setup'
-        'config:Configuration management'
-        'pick:Interactive session picker'
-        'keys:Output tmux keybindings'
-        'completion:Generate shell completions'
-        'version:Show version information'
-        'help:Show help'
-        'hub:Hub session management'
-        'handoff:Hand off to fresh Claude'
-        'prime:Inject context on startup'
-        'signal:Update session status'
-    )
-
-    _arguments -C \
-        '1: :->command' \
-        '*: :->args'
-
-    case $state in
-        command)
-            _describe 'command' commands
-            ;;
-        args)
-            case $words[2] in
-                project)
-                    _describe 'subcommand' '(add config remove)'
-                    ;;
-                config)
-                    _describe 'subcommand' '(show init set edit)'
-                    ;;
-                signal)
-                    _describe 'status' '(ready blocked error working idle)'
-                    ;;
-                completion)
-                    _describe 'shell' '(bash zsh fish)'
-                    ;;
-            esac
-            ;;
-    esac
-}
-
-_wt "$@"
-`
-
-const fishCompletion = `# wt fish completion
-# Add to ~/.config/fish/completions/wt.fish
-
-# Disable file completion by default
-complete -c wt -f
-
-# Commands
-complete -c wt -n __fish_use_subcommand -a list -d 'List active sessions'
-complete -c wt -n __fish_use_subcommand -a new -d 'Create new session for a bead'
-complete -c wt -n __fish_use_subcommand -a kill -d 'Kill a session (keep bead open)'
-complete -c wt -n __fish_use_subcommand -a close -d 'Close session and bead'
-complete -c wt -n __fish_use_subcommand -a done -d 'Complete work and merge'
-complete -c wt -n __fish_use_subcommand -a status -d 'Show current session status'
-complete -c wt -n __fish_use_subcommand -a abandon -d 'Abandon session without merging'
-complete -c wt -n __fish_use_subcommand -a watch -d 'Live dashboard of sessions'
-complete -c wt -n __fish_use_subcommand -a seance -d 'Talk to past sessions'
-complete -c wt -n __fish_use_subcommand -a projects -d 'List registered projects'
-complete -c wt -n __fish_use_subcommand -a ready -d 'Show ready beads'
-complete -c wt -n __fish_use_subcommand -a create -d 'Create a new bead'
-complete -c wt -n __fish_use_subcommand -a beads -d 'List beads for a project'
-complete -c wt -n __fish_use_subcommand -a project -d 'Manage projects'
-complete -c wt -n __fish_use_subcommand -a auto -d 'Autonomous batch processing'
-complete -c wt -n __fish_use_subcommand -a events -d 'Show wt events'
-complete -c wt -n __fish_use_subcommand -a doctor -d 'Check wt setup'
-complete -c wt -n __fish_use_subcommand -a config -d 'Configuration management'
-complete -c wt -n __fish_use_subcommand -a pick -d 'Interactive session picker'
-complete -c wt -n __fish_use_subcommand -a keys -d 'Output tmux keybindings'
-complete -c wt -n __fish_use_subcommand -a completion -d 'Generate shell completions'
-complete -c wt -n __fish_use_subcommand -a version -d 'Show version information'
-complete -c wt -n __fish_use_subcommand -a help -d 'Show help'
-complete -c wt -n __fish_use_subcommand -a hub -d 'Hub session management'
-complete -c wt -n __fish_use_subcommand -a handoff -d 'Hand off to fresh Claude'
-complete -c wt -n __fish_use_subcommand -a prime -d 'Inject context on startup'
-complete -c wt -n __fish_use_subcommand -a signal -d 'Update session status'
-
-# Completions for 'project' subcommand
-complete -c wt -n '__fish_seen_subcommand_from project' -a 'add config remove' -d 'Project subcommand'
-
-# Completions for 'config' subcommand
-complete -c wt -n '__fish_seen_subcommand_from config' -a 'show init set edit' -d 'Config subcommand'
-
-# Completions for 'signal' subcommand
-complete -c wt -n '__fish_seen_subcommand_from signal' -a 'ready blocked error working idle' -d 'Status'
-
-# Completions for 'completion' - shell types
-complete -c wt -n '__fish_seen_subcommand_from completion' -a 'bash zsh fish' -d 'Shell'
-`
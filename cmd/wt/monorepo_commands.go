@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/badri/wt/internal/bead"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/merge"
+	"github.com/badri/wt/internal/monorepo"
+	"github.com/badri/wt/internal/project"
+)
+
+// checkBeadScope verifies that a bead's changes stay within the monorepo
+// scopes selected by its "scope:<name>" labels. Out-of-scope changes are a
+// warning unless the project sets out_of_scope to "block" and allow is false.
+func checkBeadScope(proj *project.Project, beadID, worktreePath, targetBranch string, allow bool) error {
+	if proj.Monorepo == nil || len(proj.Monorepo.Scopes) == 0 {
+		return nil
+	}
+
+	labels, err := bead.Labels(beadID, "")
+	if err != nil {
+		logging.Warnf("could not read labels of %s, skipping scope check: %v", beadID, err)
+		return nil
+	}
+	scopes := monorepo.ScopesFromLabels(labels)
+	if len(scopes) == 0 {
+		return nil
+	}
+
+	files, err := monorepo.ChangedFiles(worktreePath, diffBase(worktreePath, targetBranch))
+	if err != nil {
+		logging.Warnf("skipping scope check: %v", err)
+		return nil
+	}
+
+	check := monorepo.CheckScope(proj.Monorepo, scopes, files)
+	for _, name := range check.Unknown {
+		logging.Warnf("scope '%s' is not declared in project '%s'", name, proj.Name)
+	}
+	if len(check.OutOfScope) == 0 {
+		fmt.Printf("Changes are within scope: %s\n", strings.Join(scopes, ", "))
+		return nil
+	}
+
+	fmt.Printf("\n%d file(s) changed outside scope %s:\n", len(check.OutOfScope), strings.Join(scopes, ", "))
+	for _, f := range check.OutOfScope {
+		fmt.Printf("  - %s\n", f)
+	}
+	if proj.Monorepo.BlocksOutOfScope() && !allow {
+		return fmt.Errorf("changes outside the bead's scope. Move them to another bead, add a scope label, or rerun with --allow-out-of-scope")
+	}
+	logging.Warnf("continuing with out-of-scope changes")
+	return nil
+}
+
+// requestCodeOwnerReviews requests reviews on a new PR from the CODEOWNERS
+// of the files it changes, when the project enables monorepo.codeowners.
+func requestCodeOwnerReviews(proj *project.Project, worktreePath, targetBranch, prURL string) {
+	if proj.Monorepo == nil || !proj.Monorepo.CodeOwners {
+		return
+	}
+
+	rules, err := monorepo.LoadCodeOwners(worktreePath)
+	if err != nil {
+		logging.Warnf("could not read CODEOWNERS: %v", err)
+		return
+	}
+	if len(rules) == 0 {
+		return
+	}
+	files, err := monorepo.ChangedFiles(worktreePath, diffBase(worktreePath, targetBranch))
+	if err != nil {
+		logging.Warnf("could not request CODEOWNERS reviews: %v", err)
+		return
+	}
+
+	requested, err := merge.RequestReviewers(worktreePath, prURL, monorepo.Reviewers(rules, files))
+	if err != nil {
+		logging.Warnf("could not request CODEOWNERS reviews: %v", err)
+		return
+	}
+	if len(requested) > 0 {
+		fmt.Printf("Requested reviews from code owners: %s\n", strings.Join(requested, ", "))
+	}
+}
+
+// diffBase returns the ref a session's changes are compared against:
+// origin/<target> when it exists, since wt done rebases onto it, else
+// the local target branch.
+func diffBase(worktreePath, targetBranch string) string {
+	remote := "origin/" + targetBranch
+	if logging.Command("git", "-C", worktreePath, "rev-parse", "--verify", "--quiet", remote).Run() == nil {
+		return remote
+	}
+	return targetBranch
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/logging"
 	"github.com/badri/wt/internal/msg"
 )
 
@@ -22,7 +23,7 @@ func cmdMsg(cfg *config.Config, args []string) error {
 	case "list":
 		return cmdMsgList(cfg, args[1:])
 	default:
-		return fmt.Errorf("unknown msg subcommand: %s", args[0])
+		return fmt.Errorf("unknown msg subcommand: %s%s", args[0], didYouMean(args[0], []string{"send", "recv", "list"}))
 	}
 }
 
@@ -119,7 +120,7 @@ func cmdMsgRecv(cfg *config.Config, args []string) error {
 			fmt.Printf("  %s\n", m.Body)
 		}
 		if err := store.Ack(m.ID); err != nil {
-			fmt.Printf("  Warning: failed to ack: %v\n", err)
+			logging.Warnf("failed to ack: %v", err)
 		}
 	}
 	fmt.Printf("\n%d message(s) received and acked.\n", len(msgs))
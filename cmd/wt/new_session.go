@@ -0,0 +1,284 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/events"
+	"github.com/badri/wt/internal/githooks"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/project"
+	"github.com/badri/wt/internal/session"
+	"github.com/badri/wt/internal/testenv"
+	"github.com/badri/wt/internal/tmux"
+	"github.com/badri/wt/internal/worktree"
+)
+
+// newSession is a session for createSession to build. 'wt new' and
+// 'wt clone' fill it in; the pending record carries the name, paths and
+// branches, so that a resumed run builds the same session.
+type newSession struct {
+	pending  *session.Pending
+	resuming bool // pending was left by an interrupted run
+	proj     *project.Project
+
+	// sess holds the fields the caller decides: Bead, Project, BeadsDir,
+	// Epic, stacking and task fields. createSession fills in the rest.
+	sess *session.Session
+
+	// createWorktree creates pending.Worktree on pending.Branch and
+	// returns its sparse paths
+	createWorktree func() ([]string, error)
+
+	// prompt builds the worker's first prompt; nil sends none
+	prompt func(vars project.CommandVars) string
+
+	resumeCmd   string // how to rerun the command with --resume
+	clonedFrom  string // session a clone branched from
+	force       bool   // take over a live claim on the bead
+	shell       bool   // start a shell instead of the agent
+	noTestEnv   bool
+	noSwitch    bool
+	forceSwitch bool
+}
+
+// createSession claims the bead, then creates the worktree, tmux session,
+// test env and session state step by step. A failed step undoes the steps
+// before it; a run that dies midway leaves the pending record for --resume.
+func createSession(cfg *config.Config, state *session.State, ns *newSession) (err error) {
+	pending, proj, sess := ns.pending, ns.proj, ns.sess
+	sessionName, worktreePath, branch := pending.Name, pending.Worktree, pending.Branch
+
+	// Claim the bead so another hub or machine doesn't spawn a second worker
+	releaseClaim := func() {}
+	if sess.Bead != "" {
+		if releaseClaim, err = claimForNew(cfg, sess.Bead, pending.RepoPath, ns.force); err != nil {
+			return err
+		}
+	}
+	defer func() {
+		if err != nil && !ns.resuming {
+			releaseClaim()
+		}
+	}()
+
+	// Record each step so a failure is rolled back and an interruption resumed
+	tx := &newTx{pending: pending}
+	defer func() {
+		if err != nil {
+			tx.rollback()
+		}
+	}()
+	if ns.resuming {
+		logging.Infof("Resuming session '%s' (done: %s)...", sessionName, strings.Join(pending.Steps, ", "))
+	}
+
+	var sparsePaths []string
+	err = tx.run(session.StepWorktree, func() error {
+		if _, err := os.Stat(worktreePath); err == nil {
+			return fmt.Errorf("worktree %s already exists. Run '%s' to reuse it", worktreePath, ns.resumeCmd)
+		}
+		paths, err := ns.createWorktree()
+		if err != nil {
+			return fmt.Errorf("creating worktree: %w", err)
+		}
+		sparsePaths = paths
+
+		// Symlink .claude/ from main repo for project-specific configs (MCP servers, hooks, settings)
+		if err := worktree.SymlinkClaudeDir(pending.RepoPath, worktreePath); err != nil {
+			logging.Warnf("could not symlink .claude/: %v", err)
+		}
+		return nil
+	}, func() { worktree.Remove(worktreePath) })
+	if err != nil {
+		return err
+	}
+
+	// Install project git hooks and commit signing before the worker can commit
+	tx.run(session.StepGitHooks, func() error {
+		if proj != nil {
+			installGitHooks(proj, worktreePath, githooks.Vars{BeadID: sess.Bead, Session: sessionName, Project: proj.Name, Branch: branch})
+		}
+		configureSigning(proj, worktreePath)
+		return nil
+	}, nil)
+
+	// Share dependency and build directories so the worker skips a full install
+	tx.run(session.StepProvision, func() error {
+		provisionWorktree(cfg, proj, worktreePath)
+		return nil
+	}, nil)
+
+	// A tmux session that died since the interrupted run is created again
+	if pending.Done(session.StepTmux) && !tmux.SessionExists(sessionName) {
+		pending.Unmark(session.StepTmux)
+	}
+
+	// Allocate port offset if test env is configured, keeping the offset
+	// of a tmux session that is already running
+	portOffset := pending.PortOffset
+	var portEnv string
+	if proj != nil && proj.TestEnv != nil {
+		if !pending.Done(session.StepTmux) {
+			portOffset = testenv.AllocatePortOffset(proj, collectUsedOffsets(state))
+			pending.PortOffset = portOffset
+		}
+		portEnv = proj.TestEnv.PortEnv
+		if portEnv == "" {
+			portEnv = "PORT_OFFSET"
+		}
+		logging.Infof("Allocated %s=%d", portEnv, portOffset)
+	}
+
+	ag, err := projectAgent(proj)
+	if err != nil {
+		return err
+	}
+
+	// Template variables of the test env, hook and window commands
+	vars := project.CommandVars{Worktree: worktreePath, Bead: sess.Bead, Session: sessionName, Branch: branch, PortOffset: portOffset}
+	if proj != nil {
+		vars.Project = proj.Name
+	}
+
+	err = tx.run(session.StepTmux, func() error {
+		logging.Infof("Creating tmux session '%s'...", sessionName)
+		tmuxOpts := &tmux.SessionOptions{
+			PortOffset: portOffset,
+			PortEnv:    portEnv,
+			Windows:    sessionWindows(proj, vars),
+		}
+		// A shell session doesn't start the agent (empty editorCmd)
+		editorCmd := agentCommand(cfg, proj, ag)
+		if ns.shell {
+			editorCmd = ""
+		}
+		if err := tmux.NewSession(sessionName, worktreePath, sess.BeadsDir, editorCmd, tmuxOpts); err != nil {
+			return fmt.Errorf("creating tmux session: %w", err)
+		}
+		tagTmuxSession(cfg, sessionName, sess.Bead)
+		return nil
+	}, func() { tmux.Kill(sessionName) })
+	if err != nil {
+		return err
+	}
+
+	// Run test env setup if configured and not skipped
+	setupRan := false
+	tx.run(session.StepTestEnv, func() error {
+		if proj != nil && proj.TestEnv != nil && proj.TestEnv.Setup != "" && !ns.noTestEnv {
+			logging.Infof("Running test environment setup...")
+			setupRan = true
+			if err := testenv.RunSetup(proj, vars); err != nil {
+				logging.Warnf("test env setup failed: %v", err)
+			}
+
+			// Wait for health check if configured
+			if proj.TestEnv.HealthCheck != "" {
+				logging.Infof("Waiting for test environment to be ready...")
+				if err := testenv.WaitForHealthy(proj, vars, 30*time.Second); err != nil {
+					logging.Warnf("health check failed: %v", err)
+				}
+			}
+		} else if ns.noTestEnv && proj != nil && proj.TestEnv != nil {
+			logging.Infof("Skipping test environment setup (--no-test-env)")
+		}
+		return nil
+	}, func() {
+		if setupRan && proj.TestEnv.Teardown != "" {
+			testenv.RunTeardown(proj, vars)
+		}
+	})
+
+	// Run on_create hooks if configured
+	tx.run(session.StepOnCreate, func() error {
+		if proj != nil && proj.Hooks != nil && len(proj.Hooks.OnCreate) > 0 {
+			logging.Infof("Running on_create hooks...")
+			if err := testenv.RunOnCreateHooks(proj, vars, portEnv); err != nil {
+				logging.Warnf("on_create hook failed: %v", err)
+			}
+		}
+		return nil
+	}, nil)
+
+	// Save session state
+	sess.Worktree = worktreePath
+	sess.Branch = branch
+	sess.PortOffset = portOffset
+	sess.Status = "working"
+	sess.CreatedAt = session.Now()
+	sess.ThemeName = pending.ThemeName // Track allocated name for namepool deduplication
+	sess.Agent = ag.Name
+	if sparsePaths == nil && ns.resuming {
+		// The worktree step ran in the interrupted attempt
+		sparsePaths, _ = worktree.SparsePaths(worktreePath)
+	}
+	sess.SparsePaths = sparsePaths
+	sess.UpdateActivity()
+
+	err = tx.run(session.StepState, func() error {
+		state.Sessions[sessionName] = sess
+		if err := state.Save(); err != nil {
+			delete(state.Sessions, sessionName)
+			return fmt.Errorf("saving state: %w", err)
+		}
+		if sess.Bead != "" && sess.StackedOn != "" {
+			recordStack(cfg, sess, pending.RepoPath)
+		}
+
+		// Log session start event
+		eventBead := sess.Bead
+		if eventBead == "" {
+			eventBead = "task:" + sess.TaskDescription
+		}
+		events.NewLogger(cfg).LogSessionStart(sessionName, eventBead, sess.Project, worktreePath)
+		postBeadActivity(cfg, sess, &events.Event{Type: events.EventSessionStart, Session: sessionName, WorktreePath: worktreePath})
+		return nil
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	if ns.clonedFrom != "" {
+		fmt.Printf("\nSession '%s' cloned from '%s'.\n", sessionName, ns.clonedFrom)
+	} else {
+		fmt.Printf("\nSession '%s' ready.\n", sessionName)
+	}
+	if sess.Bead != "" {
+		fmt.Printf("  Bead:     %s\n", sess.Bead)
+	}
+	fmt.Printf("  Worktree: %s\n", worktreePath)
+	if sess.StackBranch != "" {
+		fmt.Printf("  Branch:   %s (from %s)\n", branch, sess.StackBranch)
+	} else {
+		fmt.Printf("  Branch:   %s\n", branch)
+	}
+
+	// A shell session has no agent to prompt
+	if !ns.shell {
+		tx.run(session.StepPrompt, func() error {
+			// Wait for the agent to actually be running before sending prompt
+			waitForAgent(sessionName, ag)
+			if ns.prompt != nil {
+				sendInitialPrompt(sessionName, ag, ns.prompt(vars))
+			}
+			return nil
+		}, nil)
+	}
+	tx.commit()
+
+	// Default to no-switch if running from hub (WT_HUB=1), unless --switch is used
+	shouldSwitch := !ns.noSwitch
+	if os.Getenv("WT_HUB") == "1" && !ns.forceSwitch {
+		shouldSwitch = false
+		fmt.Println("\n(Running from hub - staying in hub. Use 'wt <name>' or --switch to attach)")
+	}
+	if shouldSwitch {
+		logging.Infof("\nSwitching...")
+		return switchToSession(cfg, sessionName)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/session"
+)
+
+// newTx tracks the steps of 'wt new' and 'wt clone' in a pending record.
+// When a step fails, the steps done by this run are undone in reverse; when
+// the command dies midway, the record remains and rerunning it with
+// --resume skips the completed steps.
+type newTx struct {
+	pending   *session.Pending
+	undo      []newUndo
+	committed bool
+}
+
+type newUndo struct {
+	step string
+	fn   func()
+}
+
+// run performs a step unless an earlier run already completed it. undo, if
+// not nil, reverts the step should a later step fail.
+func (tx *newTx) run(step string, do func() error, undo func()) error {
+	if tx.pending.Done(step) {
+		return nil
+	}
+	if err := do(); err != nil {
+		return err
+	}
+	if undo != nil {
+		tx.undo = append(tx.undo, newUndo{step: step, fn: undo})
+	}
+	if err := tx.pending.Mark(step); err != nil {
+		logging.Warnf("could not record progress: %v", err)
+	}
+	return nil
+}
+
+// rollback undoes this run's steps after a failure. Once the session is
+// saved it is usable, so nothing is undone; steps from an earlier,
+// interrupted run are kept for the next --resume.
+func (tx *newTx) rollback() {
+	if tx.committed || tx.pending.Done(session.StepState) {
+		return
+	}
+	if len(tx.undo) > 0 {
+		logging.Infof("Cleaning up...")
+	}
+	for i := len(tx.undo) - 1; i >= 0; i-- {
+		tx.undo[i].fn()
+		tx.pending.Unmark(tx.undo[i].step)
+	}
+
+	var err error
+	if len(tx.pending.Steps) == 0 {
+		err = tx.pending.Remove()
+	} else {
+		err = tx.pending.Save()
+	}
+	if err != nil {
+		logging.Warnf("could not update progress record: %v", err)
+	}
+}
+
+// commit drops the pending record once the session is complete
+func (tx *newTx) commit() {
+	tx.committed = true
+	if err := tx.pending.Remove(); err != nil {
+		logging.Warnf("could not remove progress record: %v", err)
+	}
+}
+
+// pendingNew returns the record of an interrupted 'wt new' for a bead to
+// resume. Without one, a worktree left at the bead's path by an older wt
+// is adopted. Returns nil if there is nothing to resume.
+func pendingNew(cfg *config.Config, state *session.State, beadID string) (*session.Pending, error) {
+	pending, err := session.LoadPending(cfg, beadID)
+	if err != nil || pending != nil {
+		return pending, err
+	}
+
+	for _, sess := range state.Sessions {
+		if sess.Bead == beadID {
+			return nil, nil
+		}
+	}
+	worktreePath := existingBeadWorktree(cfg, beadID)
+	if worktreePath == "" {
+		return nil, nil
+	}
+	pending = session.NewPending(cfg, beadID)
+	pending.Worktree = worktreePath
+	pending.Steps = []string{session.StepWorktree}
+	return pending, nil
+}
+
+// existingBeadWorktree finds a bead's worktree in a project directory of
+// any worktree root or, for worktrees created before project namespacing,
+// in worktree_root itself
+func existingBeadWorktree(cfg *config.Config, beadID string) string {
+	var candidates []string
+	for _, root := range cfg.WorktreeRoots() {
+		matches, _ := filepath.Glob(filepath.Join(root, "*", beadID))
+		candidates = append(candidates, matches...)
+	}
+	candidates = append(candidates, cfg.WorktreePath(beadID))
+	for _, path := range candidates {
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			return path
+		}
+	}
+	return ""
+}
+
+// pendingNames returns the theme names held by interrupted 'wt new' runs,
+// so the namepool doesn't hand them out again
+func pendingNames(cfg *config.Config) []string {
+	all, err := session.ListPending(cfg)
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, p := range all {
+		if p.ThemeName != "" {
+			names = append(names, p.ThemeName)
+		} else if p.Name != "" {
+			names = append(names, p.Name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/session"
+)
+
+func TestNewTxRollback(t *testing.T) {
+	cfg, err := config.LoadFromDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// An earlier run created the worktree, then died
+	pending := session.NewPending(cfg, "wt-abc")
+	pending.Mark(session.StepWorktree)
+
+	var undone []string
+	tx := &newTx{pending: pending}
+	undo := func(step string) func() {
+		return func() { undone = append(undone, step) }
+	}
+
+	ran := false
+	tx.run(session.StepWorktree, func() error { ran = true; return nil }, undo(session.StepWorktree))
+	if ran {
+		t.Error("run() should skip a step an earlier run completed")
+	}
+	tx.run(session.StepGitHooks, func() error { return nil }, nil)
+	tx.run(session.StepTmux, func() error { return nil }, undo(session.StepTmux))
+	tx.run(session.StepTestEnv, func() error { return nil }, undo(session.StepTestEnv))
+	if err := tx.run(session.StepState, func() error { return errors.New("disk full") }, nil); err == nil {
+		t.Fatal("run() should return the step's error")
+	}
+	tx.rollback()
+
+	if want := []string{session.StepTestEnv, session.StepTmux}; !reflect.DeepEqual(undone, want) {
+		t.Errorf("undone = %v, want %v", undone, want)
+	}
+	loaded, _ := session.LoadPending(cfg, "wt-abc")
+	if loaded == nil {
+		t.Fatal("rollback() should keep the record while earlier steps remain")
+	}
+	if want := []string{session.StepWorktree, session.StepGitHooks}; !reflect.DeepEqual(loaded.Steps, want) {
+		t.Errorf("Steps after rollback = %v, want %v", loaded.Steps, want)
+	}
+}
+
+func TestNewTxNoRollbackAfterState(t *testing.T) {
+	cfg, err := config.LoadFromDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	undone := false
+	tx := &newTx{pending: session.NewPending(cfg, "wt-abc")}
+	tx.run(session.StepTmux, func() error { return nil }, func() { undone = true })
+	tx.run(session.StepState, func() error { return nil }, nil)
+	tx.rollback()
+	if undone {
+		t.Error("rollback() should keep a session once it is saved")
+	}
+
+	tx.commit()
+	if p, _ := session.LoadPending(cfg, "wt-abc"); p != nil {
+		t.Error("commit() should remove the record")
+	}
+}
+
+func TestPendingNewAdoptsWorktree(t *testing.T) {
+	cfg, err := config.LoadFromDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.WorktreeRoot = t.TempDir()
+	state := &session.State{Sessions: map[string]*session.Session{}}
+
+	if p, err := pendingNew(cfg, state, "wt-abc"); err != nil || p != nil {
+		t.Fatalf("pendingNew(nothing) = %v, %v; want nil, nil", p, err)
+	}
+
+	if err := os.MkdirAll(cfg.WorktreePath("wt-abc"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	p, err := pendingNew(cfg, state, "wt-abc")
+	if err != nil || p == nil {
+		t.Fatalf("pendingNew(worktree) = %v, %v", p, err)
+	}
+	if !p.Done(session.StepWorktree) || p.Done(session.StepTmux) {
+		t.Errorf("adopted steps = %v, want only the worktree", p.Steps)
+	}
+
+	// A worktree belonging to a live session is not adopted
+	state.Sessions["wt-toast"] = &session.Session{Bead: "wt-abc"}
+	if p, _ := pendingNew(cfg, state, "wt-abc"); p != nil {
+		t.Errorf("pendingNew(active session) = %+v, want nil", p)
+	}
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/events"
+	"github.com/badri/wt/internal/session"
+	"github.com/badri/wt/internal/tmux"
+)
+
+// noteArgs holds the parsed arguments of 'wt note'
+type noteArgs struct {
+	text    string
+	session string
+	bead    string
+}
+
+// parseNoteArgs separates --session/--bead from the words of the note
+func parseNoteArgs(args []string) (*noteArgs, error) {
+	na := &noteArgs{}
+	var words []string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--session", "-s":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--session requires a session name")
+			}
+			na.session = args[i+1]
+			i++
+		case "--bead", "-b":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--bead requires a bead ID")
+			}
+			na.bead = args[i+1]
+			i++
+		default:
+			words = append(words, args[i])
+		}
+	}
+	na.text = strings.TrimSpace(strings.Join(words, " "))
+	if na.text == "" {
+		return nil, fmt.Errorf("usage: wt note \"<text>\" [--session <name>] [--bead <id>]")
+	}
+	return na, nil
+}
+
+// cmdNote appends a human annotation to the event log
+func cmdNote(cfg *config.Config, args []string) error {
+	na, err := parseNoteArgs(args)
+	if err != nil {
+		return err
+	}
+
+	state, err := session.LoadState(cfg)
+	if err != nil {
+		return err
+	}
+	logger := events.NewLogger(cfg)
+
+	sessionName, beadID, projectName := na.session, na.bead, ""
+	switch {
+	case na.session != "":
+		if name, sess := findSessionByNameOrBead(state, na.session); sess != nil {
+			sessionName, projectName = name, sess.Project
+			if beadID == "" {
+				beadID = sess.Bead
+			}
+		} else if past, _ := logger.FindSession(na.session); past != nil && past.Session == na.session {
+			projectName = past.Project
+			if beadID == "" {
+				beadID = past.Bead
+			}
+		}
+	case na.bead != "":
+		// Attach the note to the session working on the bead, if any
+		if name, sess := findSessionByNameOrBead(state, na.bead); sess != nil {
+			sessionName, projectName = name, sess.Project
+		}
+	default:
+		name, sess := currentNoteSession(state)
+		if sess == nil {
+			return fmt.Errorf("not in a wt session; use --session <name> or --bead <id>")
+		}
+		sessionName, beadID, projectName = name, sess.Bead, sess.Project
+	}
+
+	if err := logger.LogNote(sessionName, beadID, projectName, na.text); err != nil {
+		return fmt.Errorf("logging note: %w", err)
+	}
+
+	target := sessionName
+	if target == "" {
+		target = beadID
+	} else if beadID != "" {
+		target = fmt.Sprintf("%s (%s)", sessionName, beadID)
+	}
+	fmt.Printf("Noted on %s: %s\n", target, na.text)
+	return nil
+}
+
+// currentNoteSession finds the session for the current worktree, falling
+// back to the tmux session wt note runs in
+func currentNoteSession(state *session.State) (string, *session.Session) {
+	if cwd, err := os.Getwd(); err == nil {
+		for name, sess := range state.Sessions {
+			if sess.Worktree == cwd {
+				return name, sess
+			}
+		}
+	}
+	if name := tmux.CurrentSession(); name != "" {
+		if sess, ok := state.Sessions[name]; ok {
+			return name, sess
+		}
+	}
+	return "", nil
+}
+
+// sessionNotes returns the text of notes about a session or its bead made
+// between from and to
+func sessionNotes(notes []events.Event, name, beadID, from, to string) []string {
+	var texts []string
+	for _, e := range events.NotesFor(notes, name, beadID, from, to) {
+		texts = append(texts, e.Note)
+	}
+	return texts
+}
+
+// cmdNoteHelp shows help for the note command
+func cmdNoteHelp() error {
+	help := `wt note - Annotate a session in the event log
+
+USAGE:
+    wt note "<text>" [--session <name>] [--bead <id>]
+
+DESCRIPTION:
+    Appends a human annotation to the event log, such as why a session was
+    killed or what to check when resuming it. Notes are shown inline in
+    'wt events', 'wt list --all' and 'wt seance'.
+
+    Without --session or --bead, the note is attached to the session you
+    are in.
+
+OPTIONS:
+    -s, --session <name>  Session to annotate (active or past)
+    -b, --bead <id>       Bead to annotate
+    -h, --help            Show this help
+
+EXAMPLES:
+    wt note "Killed: approach was wrong, retry with the v2 API" --session toast
+    wt note "Waiting on design review" --bead proj-abc
+    wt note "Flaky test in auth_test.go, not caused by this change"
+`
+	fmt.Print(help)
+	return nil
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/badri/wt/internal/events"
+)
+
+func TestParseNoteArgs(t *testing.T) {
+	na, err := parseNoteArgs([]string{"Killed:", "wrong approach", "--session", "toast", "-b", "proj-abc"})
+	if err != nil {
+		t.Fatalf("parseNoteArgs() error: %v", err)
+	}
+	if na.text != "Killed: wrong approach" || na.session != "toast" || na.bead != "proj-abc" {
+		t.Errorf("parseNoteArgs() = %+v", na)
+	}
+
+	for _, args := range [][]string{{}, {"--session", "toast"}, {"text", "--bead"}, {"text", "-s"}} {
+		if _, err := parseNoteArgs(args); err == nil {
+			t.Errorf("parseNoteArgs(%v) should fail", args)
+		}
+	}
+}
+
+func TestSessionNotes(t *testing.T) {
+	notes := []events.Event{
+		{Type: events.EventNote, Time: "2026-01-02T10:00:00Z", Session: "toast", Note: "first"},
+		{Type: events.EventNote, Time: "2026-01-02T11:00:00Z", Bead: "proj-abc", Note: "second"},
+		{Type: events.EventNote, Time: "2026-01-03T10:00:00Z", Session: "toast", Note: "after it ended"},
+	}
+	got := sessionNotes(notes, "toast", "proj-abc", "2026-01-02T09:00:00Z", "2026-01-02T12:00:00Z")
+	if want := []string{"first", "second"}; !slices.Equal(got, want) {
+		t.Errorf("sessionNotes() = %v, want %v", got, want)
+	}
+}
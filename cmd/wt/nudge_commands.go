@@ -0,0 +1,243 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/session"
+	"github.com/badri/wt/internal/tmux"
+)
+
+// defaultNudgePrompt is sent when 'wt nudge' is given no prompt
+const defaultNudgePrompt = "continue"
+
+// builtinNudgePrompts are the canned prompts shipped with wt. Files in
+// <config>/prompts/<name>.md add prompts or replace these.
+var builtinNudgePrompts = map[string]string{
+	"continue": "Please continue working on the current task.",
+	"wrap-up": "Please wrap up: finish the change you are making, make sure the build and tests pass, " +
+		"commit your work, and run 'wt signal ready \"<summary>\"' when the bead is done. " +
+		"If you cannot finish, commit what you have and run 'wt signal blocked \"<reason>\"'.",
+	"status-report": "Please give a short status report on {BEAD}: what is done, what is left, " +
+		"and anything blocking you. Then carry on with the task.",
+	"run-tests": "Please run the project's test suite now, fix any failures your changes caused, " +
+		"and report the result.",
+}
+
+// nudgeArgs holds the parsed arguments of 'wt nudge'
+type nudgeArgs struct {
+	session string
+	prompt  string // prompt name, or "custom"
+	text    string // text of a custom prompt
+	list    bool
+}
+
+// parseNudgeArgs parses 'wt nudge <session> [--prompt <name> | --prompt custom "<text>"]'
+func parseNudgeArgs(args []string) (*nudgeArgs, error) {
+	na := &nudgeArgs{prompt: defaultNudgePrompt}
+	var positional []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--list", arg == "-l":
+			na.list = true
+		case arg == "--prompt", arg == "-p":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--prompt requires a prompt name")
+			}
+			na.prompt = args[i+1]
+			i++
+		case strings.HasPrefix(arg, "--prompt="):
+			na.prompt = strings.TrimPrefix(arg, "--prompt=")
+		default:
+			positional = append(positional, arg)
+		}
+	}
+	if na.list {
+		return na, nil
+	}
+
+	if len(positional) == 0 {
+		return nil, fmt.Errorf("usage: wt nudge <session> [--prompt <name>]")
+	}
+	na.session = positional[0]
+	rest := positional[1:]
+	if na.prompt == "custom" {
+		na.text = strings.TrimSpace(strings.Join(rest, " "))
+		if na.text == "" {
+			return nil, fmt.Errorf("--prompt custom requires the text to send")
+		}
+	} else if len(rest) > 0 {
+		return nil, fmt.Errorf("unexpected argument %q (use --prompt custom \"<text>\" to send your own text)", rest[0])
+	}
+	return na, nil
+}
+
+// nudgePromptsDir is where user prompts live: one <name>.md file each
+func nudgePromptsDir(cfg *config.Config) string {
+	return filepath.Join(cfg.ConfigDir(), "prompts")
+}
+
+// loadNudgePrompts returns the prompt library: the built-in prompts plus
+// the files in the prompts directory, which win on a name clash
+func loadNudgePrompts(cfg *config.Config) (map[string]string, error) {
+	prompts := make(map[string]string, len(builtinNudgePrompts))
+	for name, text := range builtinNudgePrompts {
+		prompts[name] = text
+	}
+
+	entries, err := os.ReadDir(nudgePromptsDir(cfg))
+	if os.IsNotExist(err) {
+		return prompts, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading prompts: %w", err)
+	}
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".md")
+		if entry.IsDir() || !ok || name == "" || name == "custom" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(nudgePromptsDir(cfg), entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("reading prompt %s: %w", name, err)
+		}
+		if text := strings.TrimSpace(string(data)); text != "" {
+			prompts[name] = text
+		}
+	}
+	return prompts, nil
+}
+
+// expandNudgePrompt fills in the {SESSION}, {BEAD} and {PROJECT}
+// placeholders of a prompt
+func expandNudgePrompt(text, name string, sess *session.Session) string {
+	beadID := sess.Bead
+	if beadID == "" {
+		beadID = "your task"
+	}
+	return strings.NewReplacer(
+		"{SESSION}", name,
+		"{BEAD}", beadID,
+		"{PROJECT}", sess.Project,
+	).Replace(text)
+}
+
+// cmdNudge sends a canned or custom prompt to a worker's Claude
+func cmdNudge(cfg *config.Config, args []string) error {
+	na, err := parseNudgeArgs(args)
+	if err != nil {
+		return err
+	}
+
+	prompts, err := loadNudgePrompts(cfg)
+	if err != nil {
+		return err
+	}
+	if na.list {
+		return listNudgePrompts(cfg, prompts)
+	}
+
+	state, err := session.LoadState(cfg)
+	if err != nil {
+		return err
+	}
+	name, sess := findSessionByNameOrBead(state, na.session)
+	if sess == nil {
+		return fmt.Errorf("session '%s' not found", na.session)
+	}
+	if !tmux.SessionExists(name) {
+		return fmt.Errorf("session '%s' is not running", name)
+	}
+
+	text := na.text
+	if na.prompt != "custom" {
+		var ok bool
+		if text, ok = prompts[na.prompt]; !ok {
+			return fmt.Errorf("unknown prompt '%s' (see 'wt nudge --list')", na.prompt)
+		}
+	}
+
+	if err := tmux.NudgeSession(name, expandNudgePrompt(text, name, sess)); err != nil {
+		return fmt.Errorf("nudging %s: %w", name, err)
+	}
+	fmt.Printf("✓ Nudged '%s' with %s prompt\n", name, na.prompt)
+	return nil
+}
+
+// listNudgePrompts prints the prompt library
+func listNudgePrompts(cfg *config.Config, prompts map[string]string) error {
+	names := make([]string, 0, len(prompts))
+	for name := range prompts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if outputJSON {
+		printJSON(prompts)
+		return nil
+	}
+
+	columns := []table.Column{
+		{Title: "Prompt", Width: 16},
+		{Title: "Source", Width: 9},
+		{Title: "Text", Width: 60},
+	}
+	rows := make([]table.Row, 0, len(names))
+	for _, name := range names {
+		source := "built-in"
+		if _, err := os.Stat(filepath.Join(nudgePromptsDir(cfg), name+".md")); err == nil {
+			source = "user"
+		}
+		rows = append(rows, table.Row{name, source, truncate(strings.ReplaceAll(prompts[name], "\n", " "), 60)})
+	}
+	printTable("Nudge Prompts", columns, rows)
+	fmt.Printf("\nAdd prompts as %s/<name>.md\n", nudgePromptsDir(cfg))
+	return nil
+}
+
+// cmdNudgeHelp shows help for the nudge command
+func cmdNudgeHelp() error {
+	help := `wt nudge - Send a prompt to a worker
+
+USAGE:
+    wt nudge <session> [--prompt <name>]
+    wt nudge <session> --prompt custom "<text>"
+    wt nudge --list
+
+DESCRIPTION:
+    Types a prompt into a worker's Claude session, the same way 'wt auto'
+    delivers its prompts. The session can be given by name or bead ID.
+    Without --prompt, the 'continue' prompt is sent.
+
+PROMPTS:
+    continue        Ask the worker to carry on with its task
+    wrap-up         Finish, commit and signal ready (or blocked)
+    status-report   Report what is done, what is left and any blockers
+    run-tests       Run the test suite and fix failures
+    custom          Send the text given after the session name
+
+    Add your own prompts, or replace the built-in ones, as markdown files in
+    ~/.config/wt/prompts/<name>.md. {SESSION}, {BEAD} and {PROJECT} are
+    replaced with the worker's session name, bead and project.
+
+OPTIONS:
+    -p, --prompt <name>  Prompt to send (default: continue)
+    -l, --list           List available prompts
+    -h, --help           Show this help
+
+EXAMPLES:
+    wt nudge toast                                  Ask toast to continue
+    wt nudge toast --prompt wrap-up                 Tell toast to wrap up
+    wt nudge wt-abc -p status-report                Nudge by bead ID
+    wt nudge toast --prompt custom "Use the v2 API"
+`
+	fmt.Print(help)
+	return nil
+}
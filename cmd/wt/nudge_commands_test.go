@@ -0,0 +1,83 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/session"
+)
+
+func TestParseNudgeArgs(t *testing.T) {
+	na, err := parseNudgeArgs([]string{"toast"})
+	if err != nil || na.session != "toast" || na.prompt != defaultNudgePrompt {
+		t.Errorf("parseNudgeArgs(toast) = %+v, %v", na, err)
+	}
+
+	na, err = parseNudgeArgs([]string{"toast", "--prompt", "custom", "Use", "the v2 API"})
+	if err != nil || na.prompt != "custom" || na.text != "Use the v2 API" {
+		t.Errorf("parseNudgeArgs(custom) = %+v, %v", na, err)
+	}
+
+	na, err = parseNudgeArgs([]string{"--list"})
+	if err != nil || !na.list {
+		t.Errorf("parseNudgeArgs(--list) = %+v, %v", na, err)
+	}
+
+	for _, args := range [][]string{{}, {"toast", "--prompt"}, {"toast", "-p", "custom"}, {"toast", "extra"}} {
+		if _, err := parseNudgeArgs(args); err == nil {
+			t.Errorf("parseNudgeArgs(%v) should fail", args)
+		}
+	}
+}
+
+func TestLoadNudgePrompts(t *testing.T) {
+	cfg, err := config.LoadFromDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prompts, err := loadNudgePrompts(cfg)
+	if err != nil {
+		t.Fatalf("loadNudgePrompts() error: %v", err)
+	}
+	if prompts["wrap-up"] != builtinNudgePrompts["wrap-up"] {
+		t.Errorf("built-in wrap-up prompt missing")
+	}
+
+	dir := nudgePromptsDir(cfg)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"wrap-up.md": "Stop and commit.\n",
+		"review.md":  "Review {BEAD} in {PROJECT}.",
+		"notes.txt":  "not a prompt",
+		"custom.md":  "reserved",
+		"empty.md":   "  \n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	prompts, err = loadNudgePrompts(cfg)
+	if err != nil {
+		t.Fatalf("loadNudgePrompts() error: %v", err)
+	}
+	if prompts["wrap-up"] != "Stop and commit." {
+		t.Errorf("user wrap-up = %q, want it to replace the built-in", prompts["wrap-up"])
+	}
+	for _, name := range []string{"notes", "notes.txt", "custom", "empty"} {
+		if _, ok := prompts[name]; ok {
+			t.Errorf("prompt %q should not be loaded", name)
+		}
+	}
+
+	sess := &session.Session{Bead: "proj-abc", Project: "api"}
+	if got := expandNudgePrompt(prompts["review"], "toast", sess); got != "Review proj-abc in api." {
+		t.Errorf("expandNudgePrompt() = %q", got)
+	}
+}
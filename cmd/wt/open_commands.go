@@ -0,0 +1,227 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/session"
+)
+
+// maxRecentWorktrees caps the recent worktrees list
+const maxRecentWorktrees = 20
+
+// terminalEditors run in a terminal rather than opening their own window
+var terminalEditors = []string{"nvim", "vim", "vi", "hx", "helix", "nano", "micro", "kak", "emacs"}
+
+// openFlags holds the parsed flags of 'wt open'
+type openFlags struct {
+	target string
+	app    string
+	recent bool
+}
+
+func parseOpenFlags(args []string) (*openFlags, error) {
+	flags := &openFlags{}
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--app" || args[i] == "-a":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--app requires an editor command")
+			}
+			flags.app = args[i+1]
+			i++
+		case strings.HasPrefix(args[i], "--app="):
+			flags.app = strings.TrimPrefix(args[i], "--app=")
+		case args[i] == "--recent":
+			flags.recent = true
+		case strings.HasPrefix(args[i], "-"):
+			return nil, fmt.Errorf("unknown flag: %s", args[i])
+		case flags.target == "":
+			flags.target = args[i]
+		default:
+			return nil, fmt.Errorf("usage: wt open [session] [--app <editor>]")
+		}
+	}
+	return flags, nil
+}
+
+// cmdOpen opens a session's worktree in an editor
+func cmdOpen(cfg *config.Config, args []string) error {
+	flags, err := parseOpenFlags(args)
+	if err != nil {
+		return err
+	}
+	if flags.recent {
+		for _, path := range loadRecentWorktrees(cfg) {
+			fmt.Println(path)
+		}
+		return nil
+	}
+
+	state, err := session.LoadState(cfg)
+	if err != nil {
+		return err
+	}
+	var name string
+	var sess *session.Session
+	if flags.target != "" {
+		name, sess = findSessionByNameOrBead(state, flags.target)
+		if sess == nil {
+			return fmt.Errorf("session '%s' not found%s", flags.target, didYouMean(flags.target, sessionNames(state)))
+		}
+	} else {
+		name, sess = sessionInCwd(state)
+		if sess == nil {
+			return fmt.Errorf("not in a wt session. Name the session: wt open <session>")
+		}
+	}
+	if _, err := os.Stat(sess.Worktree); err != nil {
+		return fmt.Errorf("worktree of '%s' is missing: %s", name, sess.Worktree)
+	}
+
+	app := flags.app
+	if app == "" {
+		app = cfg.OpenAppCmd()
+	}
+	if err := openInEditor(app, name, sess.Worktree); err != nil {
+		return err
+	}
+	if err := recordRecentWorktree(cfg, sess.Worktree); err != nil {
+		logging.Warnf("could not update recent worktrees: %v", err)
+	}
+	return nil
+}
+
+// sessionNames lists the names of all sessions, for suggestions
+func sessionNames(state *session.State) []string {
+	names := make([]string, 0, len(state.Sessions))
+	for name := range state.Sessions {
+		names = append(names, name)
+	}
+	return names
+}
+
+// openInEditor opens dir with app. GUI editors are started in the
+// background; terminal editors get a new tmux window, or this terminal
+// outside tmux.
+func openInEditor(app, name, dir string) error {
+	fields := strings.Fields(app)
+	if len(fields) == 0 {
+		return fmt.Errorf("no editor configured. Use --app or 'wt config set open_app <cmd>'")
+	}
+	if _, err := exec.LookPath(fields[0]); err != nil {
+		return fmt.Errorf("editor '%s' not found in PATH", fields[0])
+	}
+	argv := append(fields, dir)
+
+	if !isTerminalEditor(fields[0]) {
+		cmd := logging.CommandIn(dir, argv[0], argv[1:]...)
+		if err := cmd.Start(); err != nil {
+			return fmt.Errorf("starting %s: %w", fields[0], err)
+		}
+		fmt.Printf("Opened %s in %s\n", dir, fields[0])
+		return cmd.Process.Release()
+	}
+
+	if os.Getenv("TMUX") != "" {
+		cmd := logging.Command("tmux", "new-window", "-n", "open-"+name, "-c", dir, strings.Join(argv, " "))
+		return cmd.Run()
+	}
+	cmd := logging.CommandIn(dir, argv[0], argv[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+func isTerminalEditor(bin string) bool {
+	return slices.Contains(terminalEditors, filepath.Base(bin))
+}
+
+func recentWorktreesPath(cfg *config.Config) string {
+	return filepath.Join(cfg.ConfigDir(), "recent-worktrees")
+}
+
+// loadRecentWorktrees returns recently opened worktrees, newest first,
+// leaving out those that no longer exist
+func loadRecentWorktrees(cfg *config.Config) []string {
+	data, err := os.ReadFile(recentWorktreesPath(cfg))
+	if err != nil {
+		return nil
+	}
+	var paths []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if line == "" {
+			continue
+		}
+		if _, err := os.Stat(line); err == nil {
+			paths = append(paths, line)
+		}
+	}
+	return paths
+}
+
+// recordRecentWorktree moves path to the top of the recent worktrees list,
+// one path per line so pickers like fzf can read it directly
+func recordRecentWorktree(cfg *config.Config, path string) error {
+	paths := addRecent(loadRecentWorktrees(cfg), path, maxRecentWorktrees)
+	return os.WriteFile(recentWorktreesPath(cfg), []byte(strings.Join(paths, "\n")+"\n"), 0644)
+}
+
+// addRecent puts path first in paths without duplicates, keeping at most max
+func addRecent(paths []string, path string, max int) []string {
+	out := []string{path}
+	for _, p := range paths {
+		if p != path {
+			out = append(out, p)
+		}
+	}
+	if len(out) > max {
+		out = out[:max]
+	}
+	return out
+}
+
+// cmdOpenHelp shows help for the open command
+func cmdOpenHelp() error {
+	help := `wt open - Open a session's worktree in an editor
+
+USAGE:
+    wt open [session] [--app <editor>]
+    wt code [session]
+    wt open --recent
+
+DESCRIPTION:
+    Opens the worktree of a session (by name or bead ID) in an editor,
+    to inspect a worker's code outside tmux. Inside a session worktree
+    the session can be left out. 'wt code' is 'wt open --app code'.
+
+    The editor is --app, else the open_app config key (default: code).
+    GUI editors such as code, cursor, zed or idea are started in the
+    background. Terminal editors such as nvim open in a new tmux window,
+    or in this terminal outside tmux.
+
+    Opened worktrees are recorded, newest first, in the recent-worktrees
+    file of the config directory; 'wt open --recent' prints them for
+    pickers like fzf.
+
+OPTIONS:
+    -a, --app <editor>  Editor command, e.g. code, cursor, idea, "nvim -R"
+    --recent            List recently opened worktrees
+    -h, --help          Show this help
+
+EXAMPLES:
+    wt open toast                   Open toast's worktree in the default editor
+    wt code proj-abc                Open by bead ID in VS Code
+    wt open toast --app nvim        Browse in nvim in a new tmux window
+    cd "$(wt open --recent | fzf)"  Jump to a recently opened worktree
+`
+	fmt.Print(help)
+	return nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/badri/wt/internal/config"
+)
+
+func TestParseOpenFlags(t *testing.T) {
+	flags, err := parseOpenFlags([]string{"toast", "--app", "nvim -R"})
+	if err != nil {
+		t.Fatalf("parseOpenFlags() error: %v", err)
+	}
+	if flags.target != "toast" || flags.app != "nvim -R" {
+		t.Errorf("parseOpenFlags() = %+v", flags)
+	}
+	if flags, _ := parseOpenFlags([]string{"--app=idea"}); flags.app != "idea" || flags.target != "" {
+		t.Errorf("--app= form parsed as %+v", flags)
+	}
+	if _, err := parseOpenFlags([]string{"--app"}); err == nil {
+		t.Error("--app without a value should fail")
+	}
+	if _, err := parseOpenFlags([]string{"a", "b"}); err == nil {
+		t.Error("two sessions should fail")
+	}
+}
+
+func TestIsTerminalEditor(t *testing.T) {
+	for bin, want := range map[string]bool{"nvim": true, "/usr/bin/vim": true, "code": false, "idea": false} {
+		if got := isTerminalEditor(bin); got != want {
+			t.Errorf("isTerminalEditor(%q) = %v, want %v", bin, got, want)
+		}
+	}
+}
+
+func TestAddRecent(t *testing.T) {
+	got := addRecent([]string{"/a", "/b", "/c"}, "/b", 3)
+	if !slices.Equal(got, []string{"/b", "/a", "/c"}) {
+		t.Errorf("addRecent() moved entry = %v", got)
+	}
+	got = addRecent([]string{"/a", "/b"}, "/c", 2)
+	if !slices.Equal(got, []string{"/c", "/a"}) {
+		t.Errorf("addRecent() capped = %v", got)
+	}
+}
+
+func TestRecordRecentWorktree(t *testing.T) {
+	cfg, err := config.LoadFromDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, second := t.TempDir(), t.TempDir()
+	for _, dir := range []string{first, second, "/does/not/exist"} {
+		if err := recordRecentWorktree(cfg, dir); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Missing worktrees are dropped when read back
+	if got := loadRecentWorktrees(cfg); !slices.Equal(got, []string{second, first}) {
+		t.Errorf("loadRecentWorktrees() = %v, want [%s %s]", got, second, first)
+	}
+}
@@ -0,0 +1,292 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/project"
+	"github.com/badri/wt/internal/session"
+	"github.com/badri/wt/internal/testenv"
+	"github.com/badri/wt/internal/tmux"
+)
+
+// pausePrompt asks a worker to save its work before its session is paused
+const pausePrompt = "wt is pausing this session. Stop what you are doing and commit your work in progress now; " +
+	"a WIP commit is fine. Do not start anything new. The session will be continued later with 'wt resume'."
+
+// unpausePrompt is sent to a worker whose session 'wt resume' brought back
+const unpausePrompt = "This session was paused with 'wt pause' and has now been resumed. The test environment " +
+	"was restarted, so services may have lost in-memory state. Refresh your context: check git status and " +
+	"git log for your latest work, then continue where you left off."
+
+// parsePauseArgs splits 'wt pause' arguments into the session and the
+// wrap-up flags it shares with 'wt shutdown'
+func parsePauseArgs(args []string) (string, shutdownFlags, error) {
+	var target string
+	var flagArgs []string
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--timeout":
+			flagArgs = append(flagArgs, args[i])
+			if i+1 < len(args) {
+				flagArgs = append(flagArgs, args[i+1])
+				i++
+			}
+		case strings.HasPrefix(args[i], "-"):
+			flagArgs = append(flagArgs, args[i])
+		case target == "":
+			target = args[i]
+		default:
+			return "", shutdownFlags{}, fmt.Errorf("usage: wt pause [session] [--timeout <dur>] [--no-wrapup]")
+		}
+	}
+	flags, err := parseShutdownFlags(flagArgs)
+	return target, flags, err
+}
+
+// pauseTarget resolves the named session, or the one in the current directory
+func pauseTarget(state *session.State, target, command string) (string, *session.Session, error) {
+	if target == "" {
+		name, sess := sessionInCwd(state)
+		if sess == nil {
+			return "", nil, fmt.Errorf("not in a wt session. Name the session: wt %s <session>", command)
+		}
+		return name, sess, nil
+	}
+	name, sess := findSessionByNameOrBead(state, target)
+	if sess == nil {
+		return "", nil, fmt.Errorf("session '%s' not found%s", target, didYouMean(target, sessionNames(state)))
+	}
+	return name, sess, nil
+}
+
+// cmdPause stops a session without ending it: the worker commits, the test
+// environment and tmux session are stopped, and the worktree, port offset
+// and agent conversation are kept for 'wt resume'
+func cmdPause(cfg *config.Config, args []string) error {
+	target, flags, err := parsePauseArgs(args)
+	if err != nil {
+		return err
+	}
+	state, err := session.LoadState(cfg)
+	if err != nil {
+		return err
+	}
+	name, sess, err := pauseTarget(state, target, "pause")
+	if err != nil {
+		return err
+	}
+	if sess.IsPaused() {
+		return fmt.Errorf("session '%s' is already paused. Resume it with: wt resume %s", name, name)
+	}
+
+	self := tmux.CurrentSession() == name
+	if !flags.noWrapup && !self && tmux.SessionExists(name) {
+		logging.Infof("Asking '%s' to commit its work...", name)
+		if err := sessionAgent(sess).SendPrompt(name, pausePrompt); err != nil {
+			logging.Warnf("%v", err)
+		}
+		waitForCleanWorktrees(state, []string{name}, flags.timeout)
+	}
+
+	sess.ResumeID = getClaudeSessionID(sess.Worktree)
+	sess.PausedAt = session.Now()
+	sess.Status = "paused"
+	sess.StatusMessage = ""
+	// Persist before killing anything, in case we are killed with our session
+	if err := state.Save(); err != nil {
+		return fmt.Errorf("saving state: %w", err)
+	}
+
+	proj, _ := project.NewManager(cfg).Get(sess.Project)
+	if proj != nil && proj.TestEnv != nil && sess.EnvDownAt == "" {
+		logging.Infof("Stopping test environment...")
+		if err := testenv.RunPause(proj, sessionVars(name, sess)); err != nil {
+			logging.Warnf("%v", err)
+		}
+	}
+
+	fmt.Printf("%s Session '%s' paused", getStatusIcon("paused"), name)
+	if sess.PortOffset > 0 {
+		fmt.Printf(" (port offset %d kept)", sess.PortOffset)
+	}
+	fmt.Println()
+	if sess.ResumeID == "" && sessionAgent(sess).ResumeNeedsID {
+		fmt.Println("  No Claude session ID found; 'wt resume' will start a fresh conversation.")
+	}
+	fmt.Printf("Resume with: wt resume %s\n", name)
+
+	if tmux.SessionExists(name) {
+		if err := tmux.Kill(name); err != nil {
+			logging.Warnf("%v", err)
+		}
+	}
+	return nil
+}
+
+// cmdResume brings a paused session back: tmux with the agent resuming its
+// conversation, the test environment on the same port offset, and a prompt
+// to refresh its context
+func cmdResume(cfg *config.Config, args []string) error {
+	noSwitch := false
+	var target string
+	for _, arg := range args {
+		switch {
+		case arg == "--no-switch":
+			noSwitch = true
+		case strings.HasPrefix(arg, "-"):
+			return fmt.Errorf("unknown flag: %s", arg)
+		case target == "":
+			target = arg
+		default:
+			return fmt.Errorf("usage: wt resume <session> [--no-switch]")
+		}
+	}
+
+	state, err := session.LoadState(cfg)
+	if err != nil {
+		return err
+	}
+	if target == "" {
+		return fmt.Errorf("usage: wt resume <session>%s", pausedHint(state))
+	}
+	name, sess, err := pauseTarget(state, target, "resume")
+	if err != nil {
+		return err
+	}
+	if !sess.IsPaused() {
+		return fmt.Errorf("session '%s' is not paused (status: %s)", name, sess.Status)
+	}
+	if tmux.SessionExists(name) {
+		return fmt.Errorf("tmux session '%s' already exists", name)
+	}
+
+	mgr := project.NewManager(cfg)
+	proj, _ := mgr.Get(sess.Project)
+	logging.Infof("Resuming '%s'...", name)
+
+	if proj != nil && proj.TestEnv != nil {
+		logging.Infof("  Starting test environment...")
+		if err := testenv.RunResume(proj, sessionVars(name, sess)); err != nil {
+			logging.Warnf("%v", err)
+		}
+	}
+
+	ag := sessionAgent(sess)
+	editorCmd := ag.ResumeCommand(agentCommand(cfg, proj, ag), sess.ResumeID)
+	var portEnv string
+	if proj != nil && proj.TestEnv != nil {
+		portEnv = proj.TestEnv.PortEnv
+	}
+	if err := tmux.NewSession(name, sess.Worktree, sess.BeadsDir, editorCmd, &tmux.SessionOptions{PortOffset: sess.PortOffset, PortEnv: portEnv, Windows: sessionWindows(proj, sessionVars(name, sess))}); err != nil {
+		return err
+	}
+	tagTmuxSession(cfg, name, sess.Bead)
+
+	conversationResumed := ag.CanResume(sess.ResumeID)
+	sess.PausedAt = ""
+	sess.EnvDownAt = ""
+	sess.ResumeID = ""
+	sess.Status = "working"
+	sess.UpdateActivity()
+	if err := state.Save(); err != nil {
+		return fmt.Errorf("saving state: %w", err)
+	}
+
+	if ag.AcceptsPrompts {
+		logging.Infof("  Waiting for %s...", ag.Name)
+		if err := ag.WaitReady(name, 60*time.Second); err != nil {
+			logging.Warnf("%v (sending prompt anyway)", err)
+		}
+		if err := ag.Prepare(name); err != nil {
+			logging.Warnf("could not accept bypass warning: %v", err)
+		}
+		time.Sleep(2 * time.Second)
+		if err := ag.SendPrompt(name, restoredPrompt(mgr, name, sess, conversationResumed, unpausePrompt)); err != nil {
+			logging.Warnf("could not send prompt: %v", err)
+		}
+	}
+
+	fmt.Printf("%s Session '%s' resumed\n", getStatusIcon("working"), name)
+	if noSwitch {
+		return nil
+	}
+	return switchToSession(cfg, name)
+}
+
+// pausedHint lists the paused sessions for a usage error
+func pausedHint(state *session.State) string {
+	var paused []string
+	for name, sess := range state.Sessions {
+		if sess.IsPaused() {
+			paused = append(paused, name)
+		}
+	}
+	if len(paused) == 0 {
+		return "\nNo sessions are paused."
+	}
+	return "\nPaused: " + strings.Join(paused, ", ")
+}
+
+// cmdPauseHelp shows help for the pause command
+func cmdPauseHelp() error {
+	help := `wt pause - Stop a session and keep it for later
+
+USAGE:
+    wt pause [session] [options]
+
+DESCRIPTION:
+    Pauses a session without ending it. The worker is asked to commit its
+    work in progress, then the test environment is stopped (the project's
+    test_env.pause command, or teardown) and the tmux session is killed.
+
+    The session stays in 'wt list' as paused with its worktree, branch
+    and port offset, and its Claude conversation ID is recorded. Bring it
+    back with 'wt resume'; killing and recreating it would lose Claude's
+    context and the environment setup.
+
+    Inside a session worktree the session can be left out.
+
+OPTIONS:
+    --timeout <dur>     How long to wait for the worker to commit (default: 2m)
+    --no-wrapup         Don't ask the worker to commit; pause immediately
+    -h, --help          Show this help
+
+EXAMPLES:
+    wt pause toast                  Pause toast
+    wt pause proj-abc --no-wrapup   Pause by bead ID right away
+`
+	fmt.Print(help)
+	return nil
+}
+
+// cmdResumeHelp shows help for the resume command
+func cmdResumeHelp() error {
+	help := `wt resume - Resume a session stopped with 'wt pause'
+
+USAGE:
+    wt resume <session> [--no-switch]
+
+DESCRIPTION:
+    Restarts the test environment on the session's port offset (the
+    project's test_env.resume command, or setup), recreates the tmux
+    session with Claude resuming its conversation, and asks the worker to
+    refresh its context before continuing. Agents that can't resume get
+    the original bead or task prompt instead.
+
+    To restore sessions saved by 'wt shutdown', use 'wt resume-all'.
+
+OPTIONS:
+    --no-switch         Don't switch to the session afterwards
+    -h, --help          Show this help
+
+EXAMPLES:
+    wt resume toast                 Resume toast and switch to it
+    wt resume proj-abc --no-switch  Resume in the background
+`
+	fmt.Print(help)
+	return nil
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/badri/wt/internal/session"
+)
+
+func TestParsePauseArgs(t *testing.T) {
+	target, flags, err := parsePauseArgs([]string{"toast", "--timeout", "30s"})
+	if err != nil || target != "toast" || flags.timeout != 30*time.Second || flags.noWrapup {
+		t.Errorf("parsePauseArgs() = %q, %+v, %v", target, flags, err)
+	}
+
+	target, flags, err = parsePauseArgs([]string{"--no-wrapup"})
+	if err != nil || target != "" || !flags.noWrapup {
+		t.Errorf("parsePauseArgs(--no-wrapup) = %q, %+v, %v", target, flags, err)
+	}
+
+	for _, args := range [][]string{{"a", "b"}, {"toast", "--force"}, {"--timeout", "soon"}} {
+		if _, _, err := parsePauseArgs(args); err == nil {
+			t.Errorf("parsePauseArgs(%v) should fail", args)
+		}
+	}
+}
+
+func TestPausedSessions(t *testing.T) {
+	state := &session.State{Sessions: map[string]*session.Session{
+		"toast": {PausedAt: "2026-01-02T10:00:00Z", Status: "paused"},
+		"rye":   {Status: "working"},
+	}}
+
+	// Paused sessions are already stopped; shutdown leaves them alone
+	if got := shutdownOrder(state, ""); !slices.Equal(got, []string{"rye"}) {
+		t.Errorf("shutdownOrder() = %v, want [rye]", got)
+	}
+	if hint := pausedHint(state); !strings.Contains(hint, "toast") || strings.Contains(hint, "rye") {
+		t.Errorf("pausedHint() = %q", hint)
+	}
+
+	delete(state.Sessions, "toast")
+	if hint := pausedHint(state); !strings.Contains(hint, "No sessions are paused") {
+		t.Errorf("pausedHint() with none paused = %q", hint)
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/badri/wt/internal/agent"
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/events"
+	"github.com/badri/wt/internal/monitor"
+	"github.com/badri/wt/internal/project"
+	"github.com/badri/wt/internal/session"
+	"github.com/badri/wt/internal/tmux"
+)
+
+// permissionWatcher handles the Claude permission dialogs wt watch finds in
+// worker panes. A dialog covered by the project's auto_approve list is
+// answered "Yes"; any other is logged as a permission_requested event and
+// notified once.
+type permissionWatcher struct {
+	logger   *events.Logger
+	projects *project.Manager
+	notifier *monitor.Notifier
+
+	mu       sync.Mutex
+	notified map[string]string // session -> prompt already notified
+}
+
+func newPermissionWatcher(cfg *config.Config, notifier *monitor.Notifier) *permissionWatcher {
+	return &permissionWatcher{
+		logger:   events.NewLogger(cfg),
+		projects: project.NewManager(cfg),
+		notifier: notifier,
+		notified: make(map[string]string),
+	}
+}
+
+// check returns the permission prompt shown in a session's pane, or nil if
+// there is none. approved reports that wt just answered it.
+func (w *permissionWatcher) check(name string, sess *session.Session) (prompt *monitor.PermissionPrompt, approved bool) {
+	if sessionAgent(sess).Name != agent.Claude {
+		return nil, false
+	}
+	content, err := tmux.CapturePane(name, 40)
+	if err != nil {
+		return nil, false
+	}
+	prompt = monitor.DetectPermissionPrompt(content)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if prompt == nil {
+		delete(w.notified, name)
+		return nil, false
+	}
+	if w.notified[name] == prompt.String() {
+		return prompt, false
+	}
+
+	event := &events.Event{
+		Type:         events.EventPermissionRequested,
+		Session:      name,
+		Bead:         sess.Bead,
+		Project:      sess.Project,
+		WorktreePath: sess.Worktree,
+		Permission:   prompt.String(),
+	}
+
+	if proj, err := w.projects.Get(sess.Project); err == nil && monitor.MatchPermission(proj.AutoApprove, *prompt) {
+		if err := monitor.ApprovePermission(name); err == nil {
+			event.AutoApproved = true
+			w.logger.Log(event)
+			return prompt, true
+		}
+	}
+
+	w.notified[name] = prompt.String()
+	w.logger.Log(event)
+	w.notifier.Send("permission", name, "wt: Permission Needed", fmt.Sprintf("Session '%s' wants to use %s", name, prompt))
+	return prompt, false
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// PlainEnv turns on plain output like --plain, e.g. in CI
+const PlainEnv = "WT_PLAIN"
+
+// outputPlain is set by --plain or WT_PLAIN: no colors, box-drawing
+// characters or emoji, so output survives CI logs, narrow panes and
+// terminal fonts without those glyphs
+var outputPlain bool
+
+// plainFromEnv reports whether WT_PLAIN asks for plain output
+func plainFromEnv(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "0", "false", "no", "off":
+		return false
+	}
+	return true
+}
+
+// enablePlainOutput turns off colors for every style and passes plain
+// output on to the commands wt runs, e.g. hooks that call wt
+func enablePlainOutput() {
+	outputPlain = true
+	lipgloss.SetColorProfile(termenv.Ascii)
+	os.Setenv(PlainEnv, "1")
+}
+
+// plainRunes are the symbols wt prints, as ASCII of the same width so
+// padded columns stay aligned
+var plainRunes = map[rune]rune{
+	'┌': '+', '┐': '+', '└': '+', '┘': '+', '├': '+', '┤': '+', '┬': '+', '┴': '+', '┼': '+',
+	'╭': '+', '╮': '+', '╰': '+', '╯': '+',
+	'─': '-', '━': '-', '│': '|', '┃': '|',
+	'↑': '^', '↓': 'v', '→': '>', '←': '<', '↳': '>',
+	'·': '|', '•': '*', '●': '*', '○': 'o',
+	'✓': '+', '✔': '+', '✗': 'x', '✘': 'x', '⚠': '!',
+	'…': '.', '–': '-', '—': '-', '⏳': '*',
+}
+
+// plainText makes text ASCII-safe when plain output is on: known symbols
+// become their ASCII look-alikes and emoji are dropped. Letters in other
+// scripts, e.g. in bead titles, are kept.
+func plainText(s string) string {
+	if !outputPlain {
+		return s
+	}
+	return toPlain(s)
+}
+
+func toPlain(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		switch {
+		case r < 0x80:
+			sb.WriteRune(r)
+		case plainRunes[r] != 0:
+			sb.WriteRune(plainRunes[r])
+		case r >= 0x2500 && r <= 0x257F: // other box-drawing characters
+			sb.WriteRune('+')
+		case isEmoji(r):
+			// dropped
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// isEmoji reports whether r is a pictograph or one of the invisible runes
+// that join and style them
+func isEmoji(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF, // pictographs, emoticons, symbols
+		r >= 0x2300 && r <= 0x23FF,            // technical symbols, e.g. ⏳ ⏸
+		r >= 0x2600 && r <= 0x27BF,            // miscellaneous symbols and dingbats
+		r >= 0x2B00 && r <= 0x2BFF,            // arrows and stars, e.g. ⭐
+		r == 0x200D, r == 0xFE0F, r == 0x20E3: // joiner, emoji presentation, keycap
+		return true
+	}
+	return unicode.Is(unicode.So, r) && r > 0x2000
+}
+
+// plainStatusIcon is the ASCII stand-in for a status icon
+func plainStatusIcon(status string) string {
+	switch status {
+	case "ready":
+		return "+"
+	case "blocked":
+		return "!"
+	case "error":
+		return "x"
+	case "working":
+		return ">"
+	case "idle":
+		return "z"
+	case "paused":
+		return "="
+	default:
+		return "-"
+	}
+}
+
+// renderPlainTable renders a table as ASCII columns of fixed width, with
+// the header underlined by dashes, so the layout is the same on every run
+func renderPlainTable(title string, columns []table.Column, rows []table.Row) string {
+	var sb strings.Builder
+	if title != "" {
+		sb.WriteString(toPlain(title) + "\n\n")
+	}
+	line := func(cells []string) {
+		var parts []string
+		for i, col := range columns {
+			cell := ""
+			if i < len(cells) {
+				cell = cells[i]
+			}
+			parts = append(parts, padCell(toPlain(cell), col.Width))
+		}
+		sb.WriteString(strings.TrimRight(strings.Join(parts, " "), " ") + "\n")
+	}
+
+	var header, rule []string
+	for _, col := range columns {
+		header = append(header, col.Title)
+		rule = append(rule, strings.Repeat("-", col.Width))
+	}
+	line(header)
+	line(rule)
+	for _, row := range rows {
+		line(row)
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// padCell fits s into exactly width columns, cutting it with "..."
+func padCell(s string, width int) string {
+	if w := lipgloss.Width(s); w <= width {
+		return s + strings.Repeat(" ", width-w)
+	}
+	if width < 3 {
+		return strings.Repeat(".", width)
+	}
+	var sb strings.Builder
+	w := 0
+	for _, r := range s {
+		rw := lipgloss.Width(string(r))
+		if w+rw > width-3 {
+			break
+		}
+		sb.WriteRune(r)
+		w += rw
+	}
+	return sb.String() + strings.Repeat(".", width-w)
+}
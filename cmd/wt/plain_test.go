@@ -0,0 +1,95 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/table"
+)
+
+func TestPlainFromEnv(t *testing.T) {
+	for value, want := range map[string]bool{"": false, "0": false, "false": false, "off": false, "1": true, "true": true, "yes": true} {
+		if got := plainFromEnv(value); got != want {
+			t.Errorf("plainFromEnv(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
+
+func TestToPlain(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"┌─ Status ─┐", "+- Status -+"},
+		{"│  Git:        ✓ Clean  │", "|  Git:        + Clean  |"},
+		{"2↑ 1↓", "2^ 1v"},
+		{"every 2s · q quit", "every 2s | q quit"},
+		{"⏸️ paused", " paused"},
+		{"Ship it 🚀", "Ship it "},
+		{"Café naïve 日本", "Café naïve 日本"},
+	}
+	for _, tt := range tests {
+		if got := toPlain(tt.in); got != tt.want {
+			t.Errorf("toPlain(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestPlainTextOnlyWhenEnabled(t *testing.T) {
+	defer func(old bool) { outputPlain = old }(outputPlain)
+
+	outputPlain = false
+	if got := plainText("✓ done"); got != "✓ done" {
+		t.Errorf("plainText() changed text with plain output off: %q", got)
+	}
+	if got := getStatusIcon("ready"); got != "✅" {
+		t.Errorf("getStatusIcon() = %q with plain output off", got)
+	}
+
+	outputPlain = true
+	if got := plainText("✓ done"); got != "+ done" {
+		t.Errorf("plainText() = %q, want %q", got, "+ done")
+	}
+	for _, status := range []string{"ready", "blocked", "error", "working", "idle", "paused", "other"} {
+		if icon := getStatusIcon(status); len(icon) != 1 || icon[0] >= 0x80 {
+			t.Errorf("getStatusIcon(%q) = %q, want one ASCII character", status, icon)
+		}
+	}
+}
+
+func TestRenderPlainTable(t *testing.T) {
+	columns := []table.Column{{Title: "Name", Width: 6}, {Title: "Status", Width: 8}, {Title: "Title", Width: 10}}
+	rows := []table.Row{
+		{"toast", "working", "Fix login ✓ now"},
+		{"ash", "idle", "↳ note"},
+	}
+	got := renderPlainTable("Active Sessions", columns, rows)
+	want := strings.Join([]string{
+		"Active Sessions",
+		"",
+		"Name   Status   Title",
+		"------ -------- ----------",
+		"toast  working  Fix log...",
+		"ash    idle     > note",
+	}, "\n")
+	if got != want {
+		t.Errorf("renderPlainTable() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestPadCell(t *testing.T) {
+	tests := []struct {
+		in    string
+		width int
+		want  string
+	}{
+		{"abc", 5, "abc  "},
+		{"abcdefgh", 6, "abc..."},
+		{"日本語テキスト", 7, "日本..."},
+		{"abc", 2, ".."},
+	}
+	for _, tt := range tests {
+		if got := padCell(tt.in, tt.width); got != tt.want {
+			t.Errorf("padCell(%q, %d) = %q, want %q", tt.in, tt.width, got, tt.want)
+		}
+	}
+}
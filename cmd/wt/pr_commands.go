@@ -0,0 +1,270 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/badri/wt/internal/bead"
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/draft"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/merge"
+	"github.com/badri/wt/internal/monitor"
+	"github.com/badri/wt/internal/project"
+	"github.com/badri/wt/internal/session"
+)
+
+// cmdPR manages the PRs of sessions
+func cmdPR(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return cmdPRHelp()
+	}
+	switch args[0] {
+	case "draft":
+		return cmdPRDraft(cfg)
+	case "ready":
+		return cmdPRReady(cfg, args[1:])
+	case "sync":
+		syncDraftPRs(cfg)
+		syncVerifyPRs(cfg)
+		syncPendingCloses(cfg)
+		return nil
+	default:
+		return fmt.Errorf("unknown pr subcommand: %s%s", args[0], didYouMean(args[0], []string{"draft", "ready", "sync"}))
+	}
+}
+
+// cmdPRDraft pushes the current session's branch and opens a draft PR for
+// early visibility; it is marked ready when the worker signals ready or
+// runs wt done
+func cmdPRDraft(cfg *config.Config) error {
+	state, err := session.LoadState(cfg)
+	if err != nil {
+		return err
+	}
+	sessionName, sess := sessionInCwd(state)
+	if sess == nil {
+		return fmt.Errorf("not in a wt session. Run this from inside a session worktree")
+	}
+	if sess.IsTask() {
+		return fmt.Errorf("task sessions have no PR")
+	}
+
+	mgr := project.NewManager(cfg)
+	proj, err := mgr.Get(sess.Project)
+	if err != nil {
+		return fmt.Errorf("project not found for session: %w", err)
+	}
+	if proj.MergeMode == "direct" {
+		return fmt.Errorf("project '%s' merges directly; draft PRs need pr-review or pr-auto", proj.Name)
+	}
+
+	defaultBranch := proj.DefaultBranch
+	if defaultBranch == "" {
+		defaultBranch = "main"
+	}
+	branch := sess.Branch
+	if branch == "" {
+		branch = sess.Bead
+	}
+	title := sess.Bead
+	if info, err := bead.Show(sess.Bead); err == nil && info.Title != "" {
+		title = info.Title
+	}
+
+	targetBranch := stackTarget(sess.Worktree, sess, defaultBranch)
+	logging.Infof("Opening draft PR for '%s' against %s...", sessionName, targetBranch)
+	prURL, err := merge.CreateDraftPR(sess.Worktree, branch, targetBranch, title)
+	if err != nil {
+		return fmt.Errorf("creating draft PR: %w", err)
+	}
+	recordDraftPR(cfg, proj, sess, branch, prURL)
+	if sess.StackBranch != "" {
+		recordStackPR(cfg, sess.Bead, prURL)
+	}
+
+	fmt.Printf("Draft PR: %s\n", prURL)
+	fmt.Println("Keep pushing commits; 'wt signal ready' or 'wt done' marks it ready for review.")
+	return nil
+}
+
+// cmdPRReady marks a session's draft PR ready for review
+func cmdPRReady(cfg *config.Config, args []string) error {
+	state, err := session.LoadState(cfg)
+	if err != nil {
+		return err
+	}
+
+	var beadID, dir string
+	if len(args) > 0 {
+		if _, sess := findSessionByNameOrBead(state, args[0]); sess != nil {
+			beadID, dir = sess.Bead, sess.Worktree
+		} else {
+			beadID = args[0] // The session may be gone; drafts are kept by bead
+		}
+	} else {
+		_, sess := sessionInCwd(state)
+		if sess == nil {
+			return fmt.Errorf("not in a wt session. Name the session or bead: wt pr ready <name>")
+		}
+		beadID, dir = sess.Bead, sess.Worktree
+	}
+
+	if markDraftReady(cfg, beadID, dir) == "" {
+		return fmt.Errorf("no draft PR recorded for %s", beadID)
+	}
+	return nil
+}
+
+// recordDraftPR remembers a draft PR so it can be marked ready later
+func recordDraftPR(cfg *config.Config, proj *project.Project, sess *session.Session, branch, prURL string) {
+	store, err := draft.Load(cfg)
+	if err != nil {
+		logging.Warnf("could not load drafts: %v", err)
+		return
+	}
+	store.Put(&draft.Entry{
+		Bead:     sess.Bead,
+		Branch:   branch,
+		Project:  sess.Project,
+		RepoPath: proj.RepoPath(),
+		PRURL:    prURL,
+	})
+	if err := store.Save(); err != nil {
+		logging.Warnf("could not save drafts: %v", err)
+	}
+}
+
+// markDraftReady marks a bead's draft PR, if it has one, ready for review
+// and forgets it. dir is where to run gh; "" uses the recorded repo. Returns
+// the PR URL, or "" if the bead has no draft or it could not be updated.
+func markDraftReady(cfg *config.Config, beadID, dir string) string {
+	store, err := draft.Load(cfg)
+	if err != nil {
+		return ""
+	}
+	e := store.Get(beadID)
+	if e == nil {
+		return ""
+	}
+	if dir == "" {
+		dir = e.RepoPath
+	}
+	if err := merge.MarkPRReady(dir, e.PRURL); err != nil {
+		logging.Warnf("%v", err)
+		return ""
+	}
+	fmt.Printf("Draft PR marked ready for review: %s\n", e.PRURL)
+
+	store.Remove(beadID)
+	if err := store.Save(); err != nil {
+		logging.Warnf("could not save drafts: %v", err)
+	}
+	return e.PRURL
+}
+
+// createReviewPR opens the PR of a pr-review 'wt done'. As a draft, it is
+// marked ready once its checks pass; otherwise a draft opened earlier with
+// 'wt pr draft' is marked ready now. body may be "" for the default.
+func createReviewPR(cfg *config.Config, proj *project.Project, sess *session.Session, branch, targetBranch, title, body string, asDraft bool) (string, error) {
+	if body == "" {
+		body = fmt.Sprintf("Closes bead: %s", branch)
+	}
+	if !asDraft {
+		prURL, err := merge.CreatePRWithBody(sess.Worktree, branch, targetBranch, title, body)
+		if err == nil {
+			markDraftReady(cfg, sess.Bead, sess.Worktree)
+		}
+		return prURL, err
+	}
+
+	prURL, err := merge.CreateDraftPRWithBody(sess.Worktree, branch, targetBranch, title, body)
+	if err != nil {
+		return "", err
+	}
+	recordDraftPR(cfg, proj, sess, branch, prURL)
+	return prURL, nil
+}
+
+// syncDraftPRs marks the draft PRs of finished sessions ready for review once
+// their checks pass, and drops records of PRs that were merged or closed.
+// Drafts of active sessions wait for the worker to signal ready.
+func syncDraftPRs(cfg *config.Config) {
+	store, err := draft.Load(cfg)
+	if err != nil || len(store.Entries) == 0 {
+		return
+	}
+
+	active := make(map[string]bool)
+	if state, err := session.LoadState(cfg); err == nil {
+		for _, sess := range state.Sessions {
+			active[sess.Bead] = true
+		}
+	}
+
+	changed := false
+	for beadID, e := range store.Entries {
+		if status, _ := monitor.GetPRStatus(e.RepoPath, e.Branch); status == "merged" || status == "closed" {
+			store.Remove(beadID)
+			changed = true
+			continue
+		}
+		if active[beadID] {
+			continue
+		}
+
+		checks, err := merge.PRChecks(e.RepoPath, e.PRURL)
+		if err != nil || checks != merge.ChecksPassed {
+			continue
+		}
+		if err := merge.MarkPRReady(e.RepoPath, e.PRURL); err != nil {
+			logging.Warnf("%v", err)
+			continue
+		}
+		fmt.Printf("Checks passed - marked %s ready for review: %s\n", beadID, e.PRURL)
+		store.Remove(beadID)
+		changed = true
+	}
+
+	if changed {
+		if err := store.Save(); err != nil {
+			logging.Warnf("could not save drafts: %v", err)
+		}
+	}
+}
+
+// cmdPRHelp shows help for the pr command
+func cmdPRHelp() error {
+	help := `wt pr - Manage session PRs
+
+USAGE:
+    wt pr <subcommand> [args]
+
+SUBCOMMANDS:
+    draft               Push the current session's branch and open a draft PR
+    ready [name]        Mark a session's draft PR ready for review
+    sync                Mark drafts of finished sessions ready once checks pass,
+                        verify the default branch after auto-merges, and close
+                        beads waiting for their PR (bead_close: merged)
+
+DESCRIPTION:
+    Draft PRs give reviewers early visibility without requesting reviews.
+    A draft opened with 'wt pr draft' inside a session is marked ready when
+    the worker runs 'wt signal ready' or 'wt done'.
+
+    'wt done --draft', or "draft_prs": true in the project config, opens the
+    pr-review PR as a draft instead. It is marked ready once its checks
+    pass: 'wt pr sync' checks, and so do 'wt done' and 'wt close'.
+
+    Outside a session, 'wt pr ready' takes a session name or bead ID.
+
+OPTIONS:
+    -h, --help          Show this help
+
+EXAMPLES:
+    wt pr draft                 Open a draft PR for the current session
+    wt pr ready toast           Request reviews on toast's draft now
+    wt pr sync                  Flip drafts whose checks are green
+`
+	fmt.Print(help)
+	return nil
+}
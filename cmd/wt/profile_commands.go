@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/badri/wt/internal/config"
+)
+
+// cmdConfigProfile lists config profiles or switches between them
+func cmdConfigProfile(cfg *config.Config, args []string) error {
+	if len(args) == 0 || args[0] == "list" {
+		return listProfiles(cfg)
+	}
+
+	switch args[0] {
+	case "switch", "use":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: wt config profile switch <name>")
+		}
+		return switchProfile(args[1])
+	default:
+		return fmt.Errorf("unknown profile command: %s%s\nUsage: wt config profile [list|switch <name>]", args[0], didYouMean(args[0], []string{"list", "switch"}))
+	}
+}
+
+func listProfiles(cfg *config.Config) error {
+	baseDir, err := config.BaseDir()
+	if err != nil {
+		return err
+	}
+	profiles, err := config.ListProfiles(baseDir)
+	if err != nil {
+		return fmt.Errorf("listing profiles: %w", err)
+	}
+
+	if outputJSON {
+		type profileEntry struct {
+			Name      string `json:"name"`
+			ConfigDir string `json:"config_dir"`
+			Active    bool   `json:"active"`
+		}
+		entries := make([]profileEntry, 0, len(profiles))
+		for _, p := range profiles {
+			entries = append(entries, profileEntry{Name: p, ConfigDir: config.ProfileDir(baseDir, p), Active: p == cfg.Profile()})
+		}
+		printJSON(entries)
+		return nil
+	}
+
+	for _, p := range profiles {
+		marker := "  "
+		if p == cfg.Profile() {
+			marker = "* "
+		}
+		fmt.Printf("%s%-16s %s\n", marker, p, config.ProfileDir(baseDir, p))
+	}
+	return nil
+}
+
+func switchProfile(name string) error {
+	baseDir, err := config.BaseDir()
+	if err != nil {
+		return err
+	}
+	if err := config.ValidateProfileName(name); err != nil {
+		return err
+	}
+
+	if !config.ProfileExists(baseDir, name) {
+		created, err := config.CreateProfile(baseDir, name)
+		if err != nil {
+			return fmt.Errorf("creating profile: %w", err)
+		}
+		fmt.Printf("Created profile %s (worktrees in %s)\n", name, created.WorktreeRoot)
+	}
+
+	if err := config.SwitchProfile(baseDir, name); err != nil {
+		return fmt.Errorf("switching profile: %w", err)
+	}
+	fmt.Printf("Switched to profile %s\n", name)
+
+	if profileOverride != "" && profileOverride != name {
+		fmt.Printf("Note: %s=%s (or --profile) still selects %s in this shell\n", config.ProfileEnv, profileOverride, profileOverride)
+	}
+	return nil
+}
@@ -4,13 +4,14 @@ import (
 	"bufio"
 	"fmt"
 	"os"
-	"os/exec"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/table"
 
 	"github.com/badri/wt/internal/bead"
 	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/logging"
 	"github.com/badri/wt/internal/project"
 	"github.com/badri/wt/internal/session"
 )
@@ -26,7 +27,23 @@ COMMANDS:
     (none), list        List all registered projects
     add <name> <path>   Register a new project
     config <name>       Edit project configuration in editor
+    set <name> <key> <value>
+                        Set one config value, e.g. test_env.setup or
+                        hooks.on_create[0] (for scripts and the hub)
     remove <name>       Unregister a project
+    hooks install <name> [session]
+                        Install the project's git hooks into its session worktrees
+    hooks show <name> [session]
+                        Print the git hook scripts for the project
+    warm <name> [--force]
+                        Run the project's warm_command to fill its cache
+    guidelines edit <name>
+                        Edit the project's WORKERS.md in $EDITOR: rules for
+                        its workers, added to every initial and epic prompt
+    guidelines show <name>
+                        Print the project's worker guidelines
+    guidelines path <name>
+                        Print where WORKERS.md is kept (in the wt config dir)
 
 OPTIONS:
     -h, --help          Show this help
@@ -43,7 +60,89 @@ EXAMPLES:
     wt project add myproj-feature ~/code/myproj --branch feature/v2
                                                      Register same repo with different branch
     wt project config myproj                         Edit myproj's configuration
+    wt project set myproj merge_mode direct          Merge myproj's sessions directly
+    wt project set myproj test_env.setup "docker compose up -d"
+                                                     Set a nested key
+    wt project set myproj hooks.on_create[0] "npm ci"
+                                                     Set (or append) a list item
     wt project remove myproj                         Unregister myproj
+    wt project hooks show myproj                     Preview myproj's git hooks
+    wt project hooks install myproj                  Reinstall hooks in active sessions
+    wt project warm myproj --force                   Refresh myproj's dependency cache
+
+GIT HOOKS:
+    Add a "git_hooks" section to the project config to install pre-commit
+    and commit-msg hooks into every new worktree:
+
+    "git_hooks": {
+      "require_bead_id": true,
+      "require_trailer": "Co-Authored-By",
+      "pre_commit": ["go vet ./..."]
+    }
+
+    Commands may use {BEAD_ID}, {SESSION}, {PROJECT} and {BRANCH}.
+    The repository's own hooks still run after wt's. Per-worktree hooks
+    need extensions.worktreeConfig, which wt turns on in the repo's shared
+    config the first time (and says so); 'hooks install' asks first.
+
+WORKTREE PROVISIONING:
+    Add a "provision" section to share dependency and build directories
+    with new worktrees instead of installing them in every session:
+
+    "provision": {
+      "dirs": ["node_modules", ".venv"],
+      "mode": "symlink",
+      "warm_command": "npm ci && python -m venv .venv"
+    }
+
+    Directories come from cache_dir if set, from a wt-managed checkout of
+    the default branch when warm_command is set (warmed once, on the first
+    session or with 'wt project warm'), or else from the main repo. Use
+    "mode": "copy" if workers install packages of their own.
+
+WORKER CONTEXT:
+    List files whose contents start every worker prompt, so workers
+    begin with the project's conventions:
+
+    "context_files": ["docs/ARCHITECTURE.md", "CONTRIBUTING.md"]
+
+    Each file is cut off after context_max_bytes (default 8192).
+
+    Rules that don't belong in the repo go in the project's WORKERS.md,
+    kept in the wt config dir: 'wt project guidelines edit <name>'. wt
+    doctor warns once it is older than guidelines_max_age days (default 90).
+
+MONOREPO SCOPES:
+    Add a "monorepo" section to keep beads within parts of the repo:
+
+    "monorepo": {
+      "scopes": {
+        "api": ["services/api/", "libs/shared/"],
+        "web": ["apps/web/"]
+      },
+      "out_of_scope": "block",
+      "codeowners": true
+    }
+
+    Label a bead "scope:api" and 'wt done' checks that its changes only
+    touch those paths (CODEOWNERS pattern syntax). Out-of-scope changes
+    warn by default; "block" stops the merge unless --allow-out-of-scope
+    is given. "codeowners" requests PR reviews from the CODEOWNERS of the
+    changed files.
+
+PERMISSION PROMPTS:
+    'wt watch' notifies you when a worker stops at a Claude permission
+    dialog. List tool uses it may approve for you, in Claude's rule syntax:
+
+    "auto_approve": ["Read", "Bash(go test:*)", "Edit(docs/*)"]
+
+SETTING VALUES:
+    'wt project set' takes the key as it appears in the config file, with
+    dots for nested sections and [n] for list items; index n equal to the
+    list's length appends. Values are read as JSON when they fit the key
+    (20, true, ["a","b"]) and as text otherwise. Unknown keys, wrong types,
+    invalid merge_mode, auto_rebase, agent and similar values, and command
+    template errors are refused without changing the file.
 
 MULTI-BRANCH WORKFLOWS:
     Register the same repo with different branches to work on feature branches:
@@ -90,20 +189,37 @@ func cmdReadyHelp() error {
 
 USAGE:
     wt ready [project]
+    wt ready [project] --pick [-- <wt new flags>]
 
 DESCRIPTION:
     Lists beads that are ready to work on (no blockers, not in progress).
     Optionally filter by project.
 
+    With --pick, choose a bead interactively and start a session for it
+    with 'wt new'. The picker is fzf when installed, with the highlighted
+    bead's description in the preview pane; otherwise a built-in picker.
+    Flags after -- are passed on to 'wt new'.
+
+    A project's "ready_filter" adds its own gate on top of bd ready, and
+    'wt auto --project' applies it too. It is an expression over the
+    fields id, title, description, status, type, assignee, labels,
+    priority and estimate (minutes), e.g.
+        "ready_filter": "estimate > 0 and not labels has needs-design"
+    Operators: == != < <= > >= contains (labels: has), and, or, not, ( ).
+
 ARGUMENTS:
     [project]           Optional project name to filter by
 
 OPTIONS:
+    --pick              Pick a bead and run 'wt new' for it
     -h, --help          Show this help
 
 EXAMPLES:
     wt ready            Show ready beads from all projects
     wt ready myproject  Show ready beads from myproject only
+    wt ready --pick     Pick any ready bead and start working on it
+    wt ready myproject --pick -- --no-switch
+                        Pick from myproject, start without switching
 `
 	fmt.Print(help)
 	return nil
@@ -127,12 +243,30 @@ OPTIONS:
     --description <desc>  Description for the bead
     --priority <0-4>      Priority (0=critical, 2=medium, 4=backlog)
     --type <type>         Type: task, bug, feature, chore, epic
+    --label <labels>      Labels, comma-separated (repeatable)
+    -T, --template <name> Pre-fill description, type, priority and labels
+                          from a template (built-in: bug, feature, chore)
+    -e, --edit            Open $EDITOR to write the description
     -h, --help            Show this help
 
+TEMPLATES:
+    Templates scaffold the description with context, acceptance criteria
+    and test plan sections; --description fills the Context section.
+    Define or override templates per project under "bead_templates":
+
+    "bead_templates": {
+      "bug": {
+        "priority": 1,
+        "labels": ["bug"],
+        "description": "## Context\n{DESCRIPTION}\n\n## Acceptance Criteria\n- [ ]\n"
+      }
+    }
+
 EXAMPLES:
     wt create myproj "Fix login bug"
     wt create myproj "Add dark mode" --type feature --priority 2
     wt create myproj "Refactor auth" --description "Clean up auth module"
+    wt create myproj "Crash on save" --template bug --edit
 `
 	fmt.Print(help)
 	return nil
@@ -169,7 +303,7 @@ func cmdProjects(cfg *config.Config) error {
 
 func cmdProject(cfg *config.Config, args []string) error {
 	if len(args) == 0 {
-		return fmt.Errorf("usage: wt project <add|config|remove> ...")
+		return fmt.Errorf("usage: wt project <add|config|set|remove|hooks|warm|guidelines> ...")
 	}
 
 	mgr := project.NewManager(cfg)
@@ -189,13 +323,21 @@ func cmdProject(cfg *config.Config, args []string) error {
 			return fmt.Errorf("usage: wt project config <name>")
 		}
 		return cmdProjectConfig(mgr, args[1])
+	case "set":
+		return cmdProjectSet(mgr, args[1:])
 	case "remove", "rm", "delete":
 		if len(args) < 2 {
 			return fmt.Errorf("usage: wt project remove <name>")
 		}
 		return cmdProjectRemove(cfg, mgr, args[1])
+	case "hooks":
+		return cmdProjectHooks(cfg, mgr, args[1:])
+	case "warm":
+		return cmdProjectWarm(cfg, mgr, args[1:])
+	case "guidelines":
+		return cmdProjectGuidelines(mgr, args[1:])
 	default:
-		return fmt.Errorf("unknown project command: %s", args[0])
+		return fmt.Errorf("unknown project command: %s%s", args[0], didYouMean(args[0], []string{"add", "config", "set", "remove", "hooks", "warm", "guidelines"}))
 	}
 }
 
@@ -236,7 +378,7 @@ func parseProjectAddFlags(args []string) (string, string, projectAddFlags) {
 
 // getCurrentBranch returns the current checked-out branch in the given repo path.
 func getCurrentBranch(repoPath string) string {
-	cmd := exec.Command("git", "-C", repoPath, "rev-parse", "--abbrev-ref", "HEAD")
+	cmd := logging.Command("git", "-C", repoPath, "rev-parse", "--abbrev-ref", "HEAD")
 	output, err := cmd.Output()
 	if err != nil {
 		return ""
@@ -361,6 +503,11 @@ func cmdProjectAdd(mgr *project.Manager, name, repoPath string, flags projectAdd
 	fmt.Println("\n--- Project Summary ---")
 	fmt.Printf("  Name:       %s\n", name)
 	fmt.Printf("  Repo:       %s\n", repoPath)
+	if repoURL := project.DetectRepoURL(expandedPath); repoURL != "" {
+		fmt.Printf("  Remote:     %s\n", repoURL)
+	} else {
+		fmt.Printf("  Remote:     (no origin remote)\n")
+	}
 	fmt.Printf("  Branch:     %s\n", branch)
 	fmt.Printf("  Merge mode: %s\n", mergeMode)
 
@@ -391,6 +538,7 @@ func cmdProjectAdd(mgr *project.Manager, name, repoPath string, flags projectAdd
 	fmt.Printf("  Branch:       %s\n", proj.DefaultBranch)
 	fmt.Printf("  Beads prefix: %s\n", proj.BeadsPrefix)
 	fmt.Printf("  Merge mode:   %s\n", proj.MergeMode)
+	warnProjectRemote(proj)
 	fmt.Printf("\nConfigure with: wt project config %s\n", proj.Name)
 
 	return nil
@@ -413,12 +561,24 @@ func cmdProjectConfig(mgr *project.Manager, name string) error {
 		editor = "vi"
 	}
 
-	cmd := exec.Command(editor, configPath)
+	cmd := logging.Command(editor, configPath)
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 
-	return cmd.Run()
+	if err := cmd.Run(); err != nil {
+		return err
+	}
+
+	// Catch template typos now rather than when the next session starts
+	proj, err := mgr.Get(name)
+	if err != nil {
+		return err
+	}
+	if err := proj.ValidateCommands(); err != nil {
+		return fmt.Errorf("%w\nFix it with 'wt project config %s'", err, name)
+	}
+	return nil
 }
 
 func cmdProjectRemove(cfg *config.Config, mgr *project.Manager, name string) error {
@@ -441,7 +601,7 @@ func cmdProjectRemove(cfg *config.Config, mgr *project.Manager, name string) err
 		return fmt.Errorf("cannot remove project '%s': %d active session(s): %v\nClose or kill sessions first", name, len(activeSessions), activeSessions)
 	}
 
-	fmt.Printf("Removing project '%s'...\n", name)
+	logging.Infof("Removing project '%s'...", name)
 	fmt.Printf("  This will:\n")
 	fmt.Printf("    - Remove project registration from wt\n")
 	fmt.Printf("  This will NOT:\n")
@@ -460,34 +620,91 @@ func cmdProjectRemove(cfg *config.Config, mgr *project.Manager, name string) err
 }
 
 func cmdReady(cfg *config.Config, projectFilter string) error {
-	mgr := project.NewManager(cfg)
+	allBeads, hidden, err := collectReady(cfg, projectFilter)
+	if err != nil {
+		return err
+	}
+
+	if len(allBeads) == 0 {
+		printNoReady(projectFilter, hidden)
+		return nil
+	}
 
-	var allBeads []bead.ReadyBead
+	// JSON output
+	if outputJSON {
+		printJSON(allBeads)
+		return nil
+	}
+
+	title := "Ready Work (all projects)"
+	if projectFilter != "" {
+		title = fmt.Sprintf("Ready Work (%s)", projectFilter)
+	}
+
+	// Define columns
+	columns := []table.Column{
+		{Title: "Bead", Width: 16},
+		{Title: "Title", Width: 40},
+		{Title: "Type", Width: 8},
+		{Title: "Priority", Width: 8},
+	}
+
+	// Build rows
+	var rows []table.Row
+	for _, b := range allBeads {
+		priority := fmt.Sprintf("P%d", b.Priority)
+		rows = append(rows, table.Row{
+			b.ID,
+			truncate(b.Title, 40),
+			b.IssueType,
+			priority,
+		})
+	}
+
+	printTable(title, columns, rows)
+	fmt.Printf("\n%d bead(s) ready. Start with: wt new <bead> (or pick one: wt ready --pick)\n", len(allBeads))
+	if hidden > 0 {
+		fmt.Printf("%d more hidden by the project's ready_filter.\n", hidden)
+	}
+
+	return nil
+}
+
+// collectReady gathers the ready beads of one project, or of all projects
+// when projectFilter is empty, after each project's ready_filter. hidden
+// counts the beads a ready_filter excluded.
+func collectReady(cfg *config.Config, projectFilter string) (allBeads []bead.ReadyBead, hidden int, err error) {
+	mgr := project.NewManager(cfg)
 
 	if projectFilter != "" {
 		// Single project - get beads from that project's .beads dir
 		proj, err := mgr.Get(projectFilter)
 		if err != nil {
-			return fmt.Errorf("project '%s' not found. Register with: wt project add %s <path>", projectFilter, projectFilter)
+			return nil, 0, fmt.Errorf("project '%s' not found. Register with: wt project add %s <path>", projectFilter, projectFilter)
 		}
 		beadsDir := proj.RepoPath() + "/.beads"
 		beads, err := bead.ReadyInDir(beadsDir)
 		if err != nil {
-			return err
+			return nil, 0, err
+		}
+		beads, excluded, err := bead.FilterReady(beads, proj.ReadyFilter, proj.RepoPath())
+		if err != nil {
+			return nil, 0, fmt.Errorf("project '%s': %w", proj.Name, err)
 		}
 		allBeads = beads
+		hidden = len(excluded)
 	} else {
 		// No filter - aggregate across all registered projects
 		projects, err := mgr.List()
 		if err != nil {
-			return err
+			return nil, 0, err
 		}
 
 		if len(projects) == 0 {
 			// Fall back to current directory beads
 			beads, err := bead.Ready()
 			if err != nil {
-				return err
+				return nil, 0, err
 			}
 			allBeads = beads
 		} else {
@@ -499,55 +716,30 @@ func cmdReady(cfg *config.Config, projectFilter string) error {
 					// Skip projects without beads
 					continue
 				}
+				beads, excluded, err := bead.FilterReady(beads, proj.ReadyFilter, proj.RepoPath())
+				if err != nil {
+					logging.Warnf("skipping project '%s': %v", proj.Name, err)
+					continue
+				}
 				allBeads = append(allBeads, beads...)
+				hidden += len(excluded)
 			}
 		}
 	}
+	return allBeads, hidden, nil
+}
 
-	if len(allBeads) == 0 {
-		msg := "No ready beads across all projects."
-		if projectFilter != "" {
-			msg = fmt.Sprintf("No ready beads for project '%s'.", projectFilter)
-		}
-		printEmptyMessage(msg, "All caught up!")
-		return nil
-	}
-
-	// JSON output
-	if outputJSON {
-		printJSON(allBeads)
-		return nil
-	}
-
-	title := "Ready Work (all projects)"
+// printNoReady explains an empty ready list
+func printNoReady(projectFilter string, hidden int) {
+	msg := "No ready beads across all projects."
 	if projectFilter != "" {
-		title = fmt.Sprintf("Ready Work (%s)", projectFilter)
-	}
-
-	// Define columns
-	columns := []table.Column{
-		{Title: "Bead", Width: 16},
-		{Title: "Title", Width: 40},
-		{Title: "Type", Width: 8},
-		{Title: "Priority", Width: 8},
+		msg = fmt.Sprintf("No ready beads for project '%s'.", projectFilter)
 	}
-
-	// Build rows
-	var rows []table.Row
-	for _, b := range allBeads {
-		priority := fmt.Sprintf("P%d", b.Priority)
-		rows = append(rows, table.Row{
-			b.ID,
-			truncate(b.Title, 40),
-			b.IssueType,
-			priority,
-		})
+	hint := "All caught up!"
+	if hidden > 0 {
+		hint = fmt.Sprintf("%d bead(s) hidden by ready_filter.", hidden)
 	}
-
-	printTable(title, columns, rows)
-	fmt.Printf("\n%d bead(s) ready. Start with: wt new <bead>\n", len(allBeads))
-
-	return nil
+	printEmptyMessage(msg, hint)
 }
 
 // cmdCreate creates a bead in a specific project
@@ -564,7 +756,27 @@ func cmdCreate(cfg *config.Config, projectName string, args []string) error {
 	}
 
 	title := args[0]
-	opts := parseCreateFlags(args[1:])
+	opts, flags := parseCreateFlags(args[1:])
+
+	// Pre-fill from a template, keeping explicit flags
+	if flags.template != "" {
+		tmpl, ok := proj.BeadTemplate(flags.template)
+		if !ok {
+			return fmt.Errorf("unknown template '%s'. Available: %s", flags.template, strings.Join(proj.BeadTemplateNames(), ", "))
+		}
+		applyBeadTemplate(opts, flags.template, tmpl, title)
+	}
+
+	if flags.edit {
+		desc, err := editText(opts.Description)
+		if err != nil {
+			return fmt.Errorf("editing description: %w", err)
+		}
+		if strings.TrimSpace(desc) == "" {
+			return fmt.Errorf("aborting: empty description")
+		}
+		opts.Description = desc
+	}
 
 	// Get beads directory for project
 	beadsDir := proj.RepoPath() + "/.beads"
@@ -579,7 +791,7 @@ func cmdCreate(cfg *config.Config, projectName string, args []string) error {
 	fmt.Printf("  ID:    %s\n", beadID)
 	fmt.Printf("  Title: %s\n", title)
 	if opts.Description != "" {
-		fmt.Printf("  Desc:  %s\n", truncate(opts.Description, 50))
+		fmt.Printf("  Desc:  %s\n", truncate(strings.Join(strings.Fields(opts.Description), " "), 50))
 	}
 	if opts.Type != "" {
 		fmt.Printf("  Type:  %s\n", opts.Type)
@@ -587,13 +799,22 @@ func cmdCreate(cfg *config.Config, projectName string, args []string) error {
 	if opts.Priority >= 0 {
 		fmt.Printf("  Priority: P%d\n", opts.Priority)
 	}
+	if len(opts.Labels) > 0 {
+		fmt.Printf("  Labels: %s\n", strings.Join(opts.Labels, ", "))
+	}
 	fmt.Printf("\nSpawn worker: wt new %s\n", beadID)
 
 	return nil
 }
 
-func parseCreateFlags(args []string) *bead.CreateOptions {
+type createFlags struct {
+	template string
+	edit     bool
+}
+
+func parseCreateFlags(args []string) (*bead.CreateOptions, createFlags) {
 	opts := &bead.CreateOptions{Priority: -1} // -1 means not set
+	var flags createFlags
 	for i := 0; i < len(args); i++ {
 		switch args[i] {
 		case "--description", "-d":
@@ -611,9 +832,21 @@ func parseCreateFlags(args []string) *bead.CreateOptions {
 				opts.Type = args[i+1]
 				i++
 			}
+		case "--label", "-l":
+			if i+1 < len(args) {
+				opts.Labels = append(opts.Labels, strings.Split(args[i+1], ",")...)
+				i++
+			}
+		case "--template", "-T":
+			if i+1 < len(args) {
+				flags.template = args[i+1]
+				i++
+			}
+		case "--edit", "-e":
+			flags.edit = true
 		}
 	}
-	return opts
+	return opts, flags
 }
 
 type beadsFlags struct {
@@ -693,3 +926,66 @@ func cmdBeads(cfg *config.Config, projectName string, flags beadsFlags) error {
 
 	return nil
 }
+
+// applyBeadTemplate fills in bead options from a template. Options already
+// set on the command line win over template defaults.
+func applyBeadTemplate(opts *bead.CreateOptions, name string, tmpl *project.BeadTemplate, title string) {
+	opts.Description = tmpl.Render(title, opts.Description)
+
+	if opts.Type == "" {
+		switch {
+		case tmpl.Type != "":
+			opts.Type = tmpl.Type
+		case slices.Contains([]string{"task", "bug", "feature", "chore", "epic"}, name):
+			opts.Type = name
+		}
+	}
+
+	if opts.Priority < 0 && tmpl.Priority != nil {
+		opts.Priority = *tmpl.Priority
+	}
+
+	for _, label := range tmpl.Labels {
+		if !slices.Contains(opts.Labels, label) {
+			opts.Labels = append(opts.Labels, label)
+		}
+	}
+}
+
+// editText opens $EDITOR on a temp file holding initial and returns the result
+func editText(initial string) (string, error) {
+	f, err := os.CreateTemp("", "wt-bead-*.md")
+	if err != nil {
+		return "", err
+	}
+	path := f.Name()
+	defer os.Remove(path)
+
+	if _, err := f.WriteString(initial); err != nil {
+		f.Close()
+		return "", err
+	}
+	f.Close()
+
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = os.Getenv("VISUAL")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	cmd := logging.Command(editor, path)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/project"
+)
+
+// warnProjectRemote checks at registration that a project using a PR merge
+// mode has a remote gh can reach, so a missing login shows up now rather
+// than halfway through wt done
+func warnProjectRemote(proj *project.Project) {
+	if !project.MergeNeedsGH(proj.MergeMode) {
+		return
+	}
+	if proj.RepoURL == "" {
+		logging.Warnf("%s merges with %s but the repo has no origin remote; add one or use merge_mode direct", proj.Name, proj.MergeMode)
+		return
+	}
+	if err := project.CheckGHAccess(proj.RepoURL); err != nil {
+		logging.Warnf("%s merges with %s but %v", proj.Name, proj.MergeMode, err)
+	}
+}
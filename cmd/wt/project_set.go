@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/project"
+)
+
+// cmdProjectSet changes one value in a project's config without an editor,
+// for scripts and the hub: wt project set <name> <key> <value>
+func cmdProjectSet(mgr *project.Manager, args []string) error {
+	if len(args) != 3 {
+		return fmt.Errorf("usage: wt project set <name> <key> <value>")
+	}
+	name, key, value := args[0], args[1], args[2]
+
+	proj, err := mgr.Get(name)
+	if err != nil {
+		return err
+	}
+	if err := proj.Set(key, value); err != nil {
+		return err
+	}
+	if err := mgr.Save(proj); err != nil {
+		return err
+	}
+	fmt.Printf("Set %s = %s in project %s\n", key, value, name)
+
+	switch key {
+	case "default_branch":
+		if !branchExists(proj.RepoPath(), value) {
+			logging.Warnf("branch %s does not exist in %s yet", value, proj.RepoPath())
+		}
+	case "merge_mode":
+		warnProjectRemote(proj)
+	}
+	return nil
+}
+
+// branchExists reports whether repoPath has the branch locally or on origin
+func branchExists(repoPath, branch string) bool {
+	for _, ref := range []string{"refs/heads/" + branch, "refs/remotes/origin/" + branch} {
+		if logging.Command("git", "-C", repoPath, "rev-parse", "--verify", "--quiet", ref).Run() == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/project"
+	"github.com/badri/wt/internal/provision"
+)
+
+// provisionWorktree shares the project's dependency and build directories
+// with a new worktree. Failures are reported as warnings so they never
+// block session creation; the worker can still install from scratch.
+func provisionWorktree(cfg *config.Config, proj *project.Project, worktreePath string) {
+	if !provision.Enabled(proj) {
+		return
+	}
+	if proj.Provision.WarmCommand != "" {
+		logging.Infof("Provisioning worktree (the first session of a project runs warm_command)...")
+	}
+	result, err := provision.Apply(cfg, proj, worktreePath)
+	if err != nil {
+		logging.Warnf("could not provision worktree: %v", err)
+	}
+	if len(result.Provided) > 0 {
+		verb := "Linked"
+		if provision.Mode(proj) == provision.ModeCopy {
+			verb = "Copied"
+		}
+		logging.Infof("%s %s from %s", verb, strings.Join(result.Provided, ", "), provision.Source(cfg, proj))
+	}
+	if len(result.Missing) > 0 {
+		logging.Warnf("not found in %s, skipped: %s", provision.Source(cfg, proj), strings.Join(result.Missing, ", "))
+	}
+}
+
+// cmdProjectWarm runs a project's warm_command to populate its cache
+func cmdProjectWarm(cfg *config.Config, mgr *project.Manager, args []string) error {
+	var name string
+	force := false
+	for _, arg := range args {
+		switch arg {
+		case "-f", "--force":
+			force = true
+		default:
+			name = arg
+		}
+	}
+	if name == "" {
+		return fmt.Errorf("usage: wt project warm <name> [--force]")
+	}
+
+	proj, err := mgr.Get(name)
+	if err != nil {
+		return err
+	}
+	if proj.Provision == nil || proj.Provision.WarmCommand == "" {
+		fmt.Printf("No warm_command configured for project '%s'.\n", proj.Name)
+		fmt.Printf("Add a \"provision\" section with 'wt project config %s'.\n", proj.Name)
+		return nil
+	}
+
+	source := provision.Source(cfg, proj)
+	fmt.Printf("Warming %s: %s\n", source, proj.Provision.WarmCommand)
+	ran, err := provision.Warm(cfg, proj, force)
+	if err != nil {
+		return err
+	}
+	if !ran {
+		fmt.Println("Cache is already warm. Use --force to update it and run warm_command again.")
+		return nil
+	}
+	fmt.Println("Cache warmed. New worktrees will reuse it.")
+	return nil
+}
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/badri/wt/internal/bead"
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/logging"
+)
+
+// readyFlags holds the options of 'wt ready'
+type readyFlags struct {
+	project string
+	pick    bool
+	newArgs []string // passed to 'wt new' for the picked bead
+}
+
+// parseReadyFlags parses 'wt ready [project] [--pick] [-- <wt new flags>]'
+func parseReadyFlags(args []string) (readyFlags, error) {
+	var flags readyFlags
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; {
+		case arg == "--pick":
+			flags.pick = true
+		case arg == "--":
+			flags.newArgs = args[i+1:]
+			i = len(args)
+		case strings.HasPrefix(arg, "-"):
+			return flags, fmt.Errorf("unknown flag: %s", arg)
+		case flags.project == "":
+			flags.project = arg
+		default:
+			return flags, fmt.Errorf("unexpected argument: %s", arg)
+		}
+	}
+	if len(flags.newArgs) > 0 && !flags.pick {
+		return flags, fmt.Errorf("flags after -- are passed to wt new and need --pick")
+	}
+	return flags, nil
+}
+
+// cmdReadyPick lets the user choose a ready bead and starts a session for
+// it with 'wt new', so the bead ID never has to be copied by hand
+func cmdReadyPick(cfg *config.Config, flags readyFlags) error {
+	beads, hidden, err := collectReady(cfg, flags.project)
+	if err != nil {
+		return err
+	}
+	if len(beads) == 0 {
+		printNoReady(flags.project, hidden)
+		return nil
+	}
+
+	var picked string
+	if hasFzf() {
+		picked, err = pickReadyWithFzf(beads)
+	} else {
+		picked, err = pickReadyWithTUI(beads)
+	}
+	if err != nil || picked == "" {
+		return err
+	}
+
+	newArgs := append([]string{picked}, flags.newArgs...)
+	if flags.project != "" && !hasArg(flags.newArgs, "--project") {
+		newArgs = append(newArgs, "--project", flags.project)
+	}
+	fmt.Printf("Starting: wt new %s\n", strings.Join(newArgs, " "))
+	return cmdNew(cfg, newArgs)
+}
+
+func hasArg(args []string, flag string) bool {
+	for _, a := range args {
+		if a == flag {
+			return true
+		}
+	}
+	return false
+}
+
+// readyLine is a bead's row in the picker
+func readyLine(b bead.ReadyBead) string {
+	return fmt.Sprintf("%-16s P%d %-8s %s", b.ID, b.Priority, b.IssueType, b.Title)
+}
+
+// readyPreview is what the picker shows for the highlighted bead
+func readyPreview(b bead.ReadyBead) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s  %s\n", b.ID, b.Title)
+	fmt.Fprintf(&sb, "P%d %s", b.Priority, b.IssueType)
+	if b.Assignee != "" {
+		fmt.Fprintf(&sb, ", assigned to %s", b.Assignee)
+	}
+	if b.EstimatedMinutes > 0 {
+		fmt.Fprintf(&sb, ", est. %dm", b.EstimatedMinutes)
+	}
+	sb.WriteString("\n")
+	if len(b.Labels) > 0 {
+		fmt.Fprintf(&sb, "Labels: %s\n", strings.Join(b.Labels, ", "))
+	}
+	sb.WriteString("\n")
+	if desc := strings.TrimSpace(b.Description); desc != "" {
+		sb.WriteString(desc + "\n")
+	} else {
+		sb.WriteString("(no description)\n")
+	}
+	return sb.String()
+}
+
+// pickReadyWithFzf runs fzf over the ready beads with each bead's preview
+// in the preview pane. The previews are written to a temp dir, one file
+// per bead, since the beads may come from several projects' databases.
+func pickReadyWithFzf(beads []bead.ReadyBead) (string, error) {
+	dir, err := os.MkdirTemp("", "wt-ready-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(dir)
+
+	var lines []string
+	for i, b := range beads {
+		if err := os.WriteFile(filepath.Join(dir, fmt.Sprint(i)), []byte(readyPreview(b)), 0o644); err != nil {
+			return "", err
+		}
+		// The index field is hidden and names the preview file
+		lines = append(lines, fmt.Sprintf("%d\t%s", i, readyLine(b)))
+	}
+
+	cmd := logging.Command("fzf",
+		"--delimiter=\t", "--with-nth=2..",
+		"--preview=cat "+singleQuote(dir)+"/{1}",
+		"--preview-window=right,50%,wrap",
+		"--header=Bead             Pri Type     Title",
+		"--prompt=Start work on: ",
+		"--height=60%",
+		"--reverse")
+	cmd.Stdin = strings.NewReader(strings.Join(lines, "\n"))
+	cmd.Stderr = os.Stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		// User cancelled or fzf error
+		return "", nil
+	}
+	idx, _, _ := strings.Cut(strings.TrimSpace(string(output)), "\t")
+	var i int
+	if _, err := fmt.Sscanf(idx, "%d", &i); err != nil || i < 0 || i >= len(beads) {
+		return "", nil
+	}
+	return beads[i].ID, nil
+}
+
+// singleQuote quotes s for sh
+func singleQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// readyPickerModel is the built-in picker used when fzf isn't installed:
+// the ready beads on the left, the highlighted one's preview on the right
+type readyPickerModel struct {
+	beads    []bead.ReadyBead
+	cursor   int
+	picked   string
+	width    int
+	height   int
+	quitting bool
+}
+
+func pickReadyWithTUI(beads []bead.ReadyBead) (string, error) {
+	final, err := tea.NewProgram(readyPickerModel{beads: beads}, tea.WithAltScreen()).Run()
+	if err != nil {
+		return "", err
+	}
+	return final.(readyPickerModel).picked, nil
+}
+
+func (m readyPickerModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m readyPickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			m.quitting = true
+			return m, tea.Quit
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.beads)-1 {
+				m.cursor++
+			}
+		case "home", "g":
+			m.cursor = 0
+		case "end", "G":
+			m.cursor = len(m.beads) - 1
+		case "enter":
+			m.picked = m.beads[m.cursor].ID
+			m.quitting = true
+			return m, tea.Quit
+		}
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+	}
+	return m, nil
+}
+
+func (m readyPickerModel) View() string {
+	if m.quitting {
+		return ""
+	}
+	width, height := m.width, m.height
+	if width == 0 {
+		width, height = 100, 24
+	}
+	listWidth := width / 2
+	rows := height - 3
+
+	// Keep the cursor in view
+	start := 0
+	if m.cursor >= rows {
+		start = m.cursor - rows + 1
+	}
+	selected := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39"))
+	var list []string
+	for i := start; i < len(m.beads) && i < start+rows; i++ {
+		line := truncateStr(readyLine(m.beads[i]), listWidth-3)
+		if i == m.cursor {
+			list = append(list, selected.Render("> "+line))
+		} else {
+			list = append(list, "  "+line)
+		}
+	}
+
+	left := lipgloss.NewStyle().Width(listWidth).Render(strings.Join(list, "\n"))
+	right := lipgloss.NewStyle().Width(width - listWidth - 2).MaxHeight(rows).PaddingLeft(2).
+		BorderStyle(lipgloss.NormalBorder()).BorderLeft(true).
+		Render(readyPreview(m.beads[m.cursor]))
+	help := lipgloss.NewStyle().Faint(true).Render("↑/↓ move • enter start session • q quit")
+	return plainText("Start work on:\n" + lipgloss.JoinHorizontal(lipgloss.Top, left, right) + "\n" + help)
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/badri/wt/internal/bead"
+)
+
+func TestParseReadyFlags(t *testing.T) {
+	flags, err := parseReadyFlags([]string{"myproject", "--pick", "--", "--no-switch", "--name", "toast"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if flags.project != "myproject" || !flags.pick {
+		t.Errorf("unexpected flags: %+v", flags)
+	}
+	if want := []string{"--no-switch", "--name", "toast"}; !reflect.DeepEqual(flags.newArgs, want) {
+		t.Errorf("newArgs = %v, want %v", flags.newArgs, want)
+	}
+
+	if flags, err := parseReadyFlags(nil); err != nil || flags.pick || flags.project != "" {
+		t.Errorf("parseReadyFlags(nil) = %+v, %v", flags, err)
+	}
+	for _, args := range [][]string{
+		{"--bogus"},
+		{"one", "two"},
+		{"--", "--no-switch"}, // passthrough without --pick
+	} {
+		if _, err := parseReadyFlags(args); err == nil {
+			t.Errorf("parseReadyFlags(%v): expected error", args)
+		}
+	}
+}
+
+func TestReadyPreview(t *testing.T) {
+	b := bead.ReadyBead{ID: "app-x1", Title: "Fix login", Priority: 1, IssueType: "bug",
+		Labels: []string{"auth"}, Description: "Users are logged out on refresh."}
+	preview := readyPreview(b)
+	for _, want := range []string{"app-x1  Fix login", "P1 bug", "Labels: auth", "Users are logged out on refresh."} {
+		if !strings.Contains(preview, want) {
+			t.Errorf("preview missing %q:\n%s", want, preview)
+		}
+	}
+	if !strings.Contains(readyPreview(bead.ReadyBead{ID: "app-x2"}), "(no description)") {
+		t.Error("expected a placeholder for beads without a description")
+	}
+}
+
+func TestReadyPickerModel(t *testing.T) {
+	beads := []bead.ReadyBead{{ID: "app-a"}, {ID: "app-b"}, {ID: "app-c"}}
+	var m tea.Model = readyPickerModel{beads: beads}
+	for _, key := range []string{"down", "down", "down", "up"} {
+		m, _ = m.Update(keyMsg(key))
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := m.(readyPickerModel).picked; got != "app-b" {
+		t.Errorf("picked = %q, want app-b", got)
+	}
+
+	m, _ = readyPickerModel{beads: beads}.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if got := m.(readyPickerModel).picked; got != "" {
+		t.Errorf("expected no pick after esc, got %q", got)
+	}
+}
+
+func keyMsg(key string) tea.KeyMsg {
+	switch key {
+	case "up":
+		return tea.KeyMsg{Type: tea.KeyUp}
+	case "down":
+		return tea.KeyMsg{Type: tea.KeyDown}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+}
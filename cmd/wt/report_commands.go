@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/badri/wt/internal/bead"
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/report"
+	"github.com/badri/wt/internal/session"
+)
+
+// applyWorkerReport creates the follow-up beads of the session's report and
+// returns the PR description built from it, or "" if the worker attached
+// no report
+func applyWorkerReport(cfg *config.Config, sess *session.Session) string {
+	r, err := report.Load(cfg, sess.Bead)
+	if err != nil {
+		logging.Warnf("%v", err)
+		return ""
+	}
+	if r == nil {
+		return ""
+	}
+	fmt.Printf("\nWorker report: %s\n", r.Brief())
+	if createFollowUps(sess, r) > 0 {
+		// Keep the created bead IDs in case wt done fails and is rerun
+		if err := report.Save(cfg, sess.Bead, r); err != nil {
+			logging.Warnf("%v", err)
+		}
+	}
+	for _, q := range r.OpenQuestions {
+		fmt.Printf("  Open question: %s\n", q)
+	}
+	return r.PRBody(sess.Bead)
+}
+
+// createFollowUps creates a bead for each follow-up not created yet and
+// records its ID in the report. Returns how many were created.
+func createFollowUps(sess *session.Session, r *report.Report) int {
+	if sess.BeadsDir == "" {
+		return 0
+	}
+	created := 0
+	for i := range r.FollowUps {
+		f := &r.FollowUps[i]
+		if f.Bead != "" {
+			continue
+		}
+		opts := &bead.CreateOptions{
+			Description: f.Description,
+			Priority:    2,
+			Type:        f.Type,
+		}
+		if f.Priority != nil {
+			opts.Priority = *f.Priority
+		}
+		if opts.Type == "" {
+			opts.Type = "task"
+		}
+		if opts.Description == "" {
+			opts.Description = fmt.Sprintf("Follow-up from %s.", sess.Bead)
+		} else {
+			opts.Description += fmt.Sprintf("\n\nFollow-up from %s.", sess.Bead)
+		}
+		id, err := bead.CreateInDir(sess.BeadsDir, f.Title, opts)
+		if err != nil {
+			logging.Warnf("could not create follow-up '%s': %v", f.Title, err)
+			continue
+		}
+		f.Bead = id
+		linkFollowUp(sess, id)
+		created++
+		fmt.Printf("  Created follow-up %s: %s\n", id, f.Title)
+	}
+	return created
+}
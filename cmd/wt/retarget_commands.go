@@ -0,0 +1,201 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/draft"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/merge"
+	"github.com/badri/wt/internal/project"
+	"github.com/badri/wt/internal/session"
+	"github.com/badri/wt/internal/tmux"
+	"github.com/badri/wt/internal/worktree"
+)
+
+// retargetPrompt tells a worker its branch now sits on another base branch
+func retargetPrompt(from, to *project.Project) string {
+	return fmt.Sprintf("wt moved this session from project %s to %s: your branch was rebased from %s onto %s, "+
+		"and its PR will target %s. Run git log to check your commits, and build and test against %s before continuing.",
+		from.Name, to.Name, from.DefaultBranchName(), to.DefaultBranchName(), to.DefaultBranchName(), to.DefaultBranchName())
+}
+
+// retargetProjects checks that a session can move from one project to
+// another: both must be registrations of the same repo, on different branches
+func retargetProjects(mgr *project.Manager, from, to *project.Project) error {
+	if from.Name == to.Name {
+		return fmt.Errorf("session is already in project '%s'", to.Name)
+	}
+	matches, err := mgr.FindByRepoURL(from.RepoURL)
+	if err != nil {
+		return err
+	}
+	for _, m := range matches {
+		if m.Name == to.Name {
+			if from.DefaultBranchName() == to.DefaultBranchName() {
+				return fmt.Errorf("projects '%s' and '%s' both use branch %s", from.Name, to.Name, to.DefaultBranchName())
+			}
+			return nil
+		}
+	}
+	var names []string
+	for _, m := range matches {
+		if m.Name != from.Name {
+			names = append(names, fmt.Sprintf("  %s (branch: %s)", m.Name, m.DefaultBranchName()))
+		}
+	}
+	if len(names) == 0 {
+		return fmt.Errorf("project '%s' is not registered for the same repo as '%s', and '%s' has no other branch registered", to.Name, from.Name, from.Name)
+	}
+	return fmt.Errorf("project '%s' is not registered for the same repo as '%s'. Registrations of that repo:\n%s", to.Name, from.Name, strings.Join(names, "\n"))
+}
+
+// cmdRetarget moves a session to another registration of its repo: the
+// session branch is rebased onto that project's default branch, the
+// session's project is updated, and an open PR is retargeted
+func cmdRetarget(cfg *config.Config, args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("usage: wt retarget <session> <project>")
+	}
+	state, err := session.LoadState(cfg)
+	if err != nil {
+		return err
+	}
+	name, sess := findSessionByNameOrBead(state, args[0])
+	if sess == nil {
+		return fmt.Errorf("session '%s' not found%s", args[0], didYouMean(args[0], sessionNames(state)))
+	}
+	if sess.StackBranch != "" {
+		return fmt.Errorf("session '%s' is stacked on %s; its PR targets that branch, not a project's default branch", name, sess.StackBranch)
+	}
+
+	mgr := project.NewManager(cfg)
+	from, err := mgr.Get(sess.Project)
+	if err != nil {
+		return fmt.Errorf("project '%s' of session '%s' not found", sess.Project, name)
+	}
+	to, err := mgr.Get(args[1])
+	if err != nil {
+		return fmt.Errorf("project '%s' not found", args[1])
+	}
+	if err := retargetProjects(mgr, from, to); err != nil {
+		return err
+	}
+
+	dirty, err := merge.HasUncommittedChanges(sess.Worktree)
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("session '%s' has uncommitted changes. Commit or stash them before retargeting", name)
+	}
+
+	branch := sess.Branch
+	if branch == "" {
+		branch = sess.Bead
+	}
+	oldBase, newBase := from.DefaultBranchName(), to.DefaultBranchName()
+
+	logging.Infof("Fetching %s and %s...", oldBase, newBase)
+	for _, b := range []string{oldBase, newBase} {
+		if err := merge.FetchMain(sess.Worktree, b); err != nil {
+			return err
+		}
+	}
+
+	logging.Infof("Rebasing %s from %s onto %s...", branch, oldBase, newBase)
+	result, err := merge.RebaseOnto(sess.Worktree, newBase, oldBase)
+	if err != nil {
+		return err
+	}
+	if result.HasConflicts {
+		if err := merge.AbortRebase(sess.Worktree); err != nil {
+			logging.Warnf("%v", err)
+		}
+		return fmt.Errorf("rebasing onto %s conflicts in %d file(s):\n  - %s\nThe rebase was aborted and session '%s' is unchanged. Rebase by hand with:\n  git rebase --onto origin/%s origin/%s\nthen run 'wt retarget %s %s' again",
+			newBase, len(result.ConflictedFiles), strings.Join(result.ConflictedFiles, "\n  - "), name, newBase, oldBase, name, to.Name)
+	}
+
+	// A pushed branch was rewritten: update it, and point its PR at the new base
+	pr, err := merge.FindOpenPR(sess.Worktree, branch)
+	if err != nil {
+		logging.Warnf("%v", err)
+	}
+	if pr != nil || worktree.BranchExists(sess.Worktree, "origin/"+branch) {
+		logging.Infof("Pushing rebased %s...", branch)
+		if err := merge.PushUpdate(sess.Worktree, branch); err != nil {
+			logging.Warnf("%v", err)
+		}
+	}
+	if pr != nil {
+		logging.Infof("Retargeting PR %s to %s...", pr.URL, newBase)
+		if err := merge.RetargetPR(sess.Worktree, pr.URL, newBase); err != nil {
+			logging.Warnf("%v", err)
+		}
+	}
+
+	sess.Project = to.Name
+	if err := state.Save(); err != nil {
+		return fmt.Errorf("saving session: %w", err)
+	}
+	retargetDraft(cfg, to, sess.Bead)
+
+	if tmux.SessionExists(name) {
+		if err := sessionAgent(sess).SendPrompt(name, retargetPrompt(from, to)); err != nil {
+			logging.Warnf("could not tell the worker: %v", err)
+		}
+	}
+
+	fmt.Printf("✓ Session '%s' moved from %s to %s: %s now builds on %s\n", name, from.Name, to.Name, branch, newBase)
+	if pr != nil {
+		fmt.Printf("  PR %s targets %s\n", pr.URL, newBase)
+	}
+	return nil
+}
+
+// retargetDraft moves a bead's draft PR record to its new project
+func retargetDraft(cfg *config.Config, proj *project.Project, beadID string) {
+	store, err := draft.Load(cfg)
+	if err != nil {
+		return
+	}
+	e := store.Get(beadID)
+	if e == nil {
+		return
+	}
+	e.Project = proj.Name
+	e.RepoPath = proj.RepoPath()
+	if err := store.Save(); err != nil {
+		logging.Warnf("could not save drafts: %v", err)
+	}
+}
+
+func cmdRetargetHelp() error {
+	help := `wt retarget - Move a session onto another branch's project
+
+USAGE:
+    wt retarget <session> <project>
+
+DESCRIPTION:
+    A repo can be registered as several projects, one per branch (e.g.
+    myapp on main and myapp-1.x on release-1.x). A worker started in the
+    wrong one builds on the wrong release branch; retarget moves it:
+
+      1. The session branch is rebased from the old project's default
+         branch onto the new one's; only the session's own commits move
+      2. A pushed branch is force-pushed (with lease) and an open PR is
+         retargeted to the new base branch
+      3. The session's project is updated, so 'wt done' merges into the
+         new branch, and the worker is told about the move
+
+    The worktree must be clean. On conflicts the rebase is aborted and the
+    session is left as it was. Stacked sessions can't be retargeted.
+
+EXAMPLES:
+    wt retarget toast myapp-1.x     Move toast onto release-1.x
+    wt retarget proj-abc myapp      Back onto main, by bead ID
+`
+	fmt.Print(help)
+	return nil
+}
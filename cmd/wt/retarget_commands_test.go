@@ -0,0 +1,46 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/project"
+)
+
+func TestRetargetProjects(t *testing.T) {
+	cfg, err := config.LoadFromDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	mgr := project.NewManager(cfg)
+	projects := map[string]*project.Project{
+		"app":     {Name: "app", Repo: t.TempDir(), RepoURL: "git@github.com:acme/app.git"},
+		"app-1.x": {Name: "app-1.x", Repo: t.TempDir(), RepoURL: "https://github.com/acme/app", DefaultBranch: "release-1.x"},
+		"app-2.x": {Name: "app-2.x", Repo: t.TempDir(), RepoURL: "https://github.com/acme/app", DefaultBranch: "main"},
+		"other":   {Name: "other", Repo: t.TempDir(), RepoURL: "git@github.com:acme/other.git", DefaultBranch: "develop"},
+	}
+	for _, p := range projects {
+		if err := mgr.Save(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := retargetProjects(mgr, projects["app"], projects["app-1.x"]); err != nil {
+		t.Errorf("retargetProjects(app, app-1.x) error: %v", err)
+	}
+	tests := []struct {
+		from, to, want string
+	}{
+		{"app", "app", "already in project"},
+		{"app", "app-2.x", "both use branch main"},
+		{"app", "other", "app-1.x (branch: release-1.x)"},
+		{"other", "app", "no other branch registered"},
+	}
+	for _, tt := range tests {
+		err := retargetProjects(mgr, projects[tt.from], projects[tt.to])
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("retargetProjects(%s, %s) = %v, want %q", tt.from, tt.to, err, tt.want)
+		}
+	}
+}
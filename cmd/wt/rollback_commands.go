@@ -0,0 +1,166 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/badri/wt/internal/bead"
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/events"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/merge"
+	"github.com/badri/wt/internal/project"
+	"github.com/badri/wt/internal/worktree"
+)
+
+type rollbackFlags struct {
+	fix      bool
+	force    bool
+	noSwitch bool
+}
+
+func parseRollbackFlags(args []string) (target string, flags rollbackFlags) {
+	for _, arg := range args {
+		switch arg {
+		case "--fix":
+			flags.fix = true
+		case "-f", "--force":
+			flags.force = true
+		case "--no-switch":
+			flags.noSwitch = true
+		default:
+			if target == "" {
+				target = arg
+			}
+		}
+	}
+	return
+}
+
+// cmdRollback reverts the merge commit of a session's direct merge on the
+// default branch and reopens its bead. With --fix, a new session is started
+// on the bead with the reverted work reapplied.
+func cmdRollback(cfg *config.Config, args []string) error {
+	target, flags := parseRollbackFlags(args)
+	if target == "" {
+		return fmt.Errorf("usage: wt rollback <session|bead> [--fix]")
+	}
+
+	logger := events.NewLogger(cfg)
+	mergeEvent, rollbackEvent, err := logger.FindMerge(target)
+	if err != nil {
+		return fmt.Errorf("reading events: %w", err)
+	}
+	if mergeEvent == nil {
+		return fmt.Errorf("no direct merge recorded for '%s'. Only merges made by 'wt done' in direct mode can be rolled back", target)
+	}
+	if rollbackEvent != nil {
+		return fmt.Errorf("merge %s of '%s' was already rolled back by %s", shortSHA(mergeEvent.MergeCommit), target, shortSHA(rollbackEvent.RevertCommit))
+	}
+
+	proj, err := project.NewManager(cfg).Get(mergeEvent.Project)
+	if err != nil || proj == nil {
+		return fmt.Errorf("project '%s' not found; it is needed to locate the repository", mergeEvent.Project)
+	}
+	repoPath := proj.RepoPath()
+	defaultBranch := proj.DefaultBranch
+	if defaultBranch == "" {
+		defaultBranch = "main"
+	}
+
+	fmt.Printf("Rolling back session '%s':\n", mergeEvent.Session)
+	fmt.Printf("  Bead:         %s\n", mergeEvent.Bead)
+	fmt.Printf("  Project:      %s\n", proj.Name)
+	fmt.Printf("  Merge commit: %s (merged %s)\n", shortSHA(mergeEvent.MergeCommit), mergeEvent.Time)
+	fmt.Printf("  Branch:       %s\n", defaultBranch)
+
+	if flags.fix && worktree.BranchExists(repoPath, mergeEvent.Bead) {
+		return fmt.Errorf("branch %s already exists; delete it or roll back without --fix", mergeEvent.Bead)
+	}
+	if dirty, err := merge.HasUncommittedChanges(repoPath); err != nil {
+		return fmt.Errorf("checking %s: %w", repoPath, err)
+	} else if dirty {
+		return fmt.Errorf("%s has uncommitted changes; commit or stash them first", repoPath)
+	}
+
+	if !flags.force && !confirm(fmt.Sprintf("\nRevert %s on %s and push?", shortSHA(mergeEvent.MergeCommit), defaultBranch), false) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	logging.Infof("\nReverting merge on %s...", defaultBranch)
+	revertCommit, err := merge.RevertMerge(repoPath, mergeEvent.MergeCommit, defaultBranch)
+	if err != nil {
+		return fmt.Errorf("rollback failed: %w", err)
+	}
+	fmt.Printf("Reverted and pushed (%s).\n", shortSHA(revertCommit))
+
+	if err := logger.LogRollback(mergeEvent.Session, mergeEvent.Bead, mergeEvent.Project, mergeEvent.MergeCommit, revertCommit); err != nil {
+		logging.Warnf("could not log rollback: %v", err)
+	}
+
+	logging.Infof("Reopening bead...")
+	if err := bead.UpdateStatusInDir(mergeEvent.Bead, "open", repoPath); err != nil {
+		logging.Warnf("could not reopen bead: %v", err)
+	}
+
+	if !flags.fix {
+		fmt.Printf("\nRolled back. To work on it again: wt new %s\n", mergeEvent.Bead)
+		return nil
+	}
+
+	logging.Infof("\nReapplying the reverted work on branch %s...", mergeEvent.Bead)
+	if err := merge.ReapplyOnBranch(repoPath, mergeEvent.Bead, revertCommit, revertCommit); err != nil {
+		return fmt.Errorf("recreating branch: %w", err)
+	}
+
+	newArgs := []string{mergeEvent.Bead, "--project", proj.Name}
+	if flags.noSwitch {
+		newArgs = append(newArgs, "--no-switch")
+	}
+	return cmdNew(cfg, newArgs)
+}
+
+// shortSHA abbreviates a commit SHA for display
+func shortSHA(sha string) string {
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	return sha
+}
+
+// cmdRollbackHelp shows help for the rollback command
+func cmdRollbackHelp() error {
+	help := `wt rollback - Revert a session's direct merge
+
+USAGE:
+    wt rollback <session|bead> [options]
+
+DESCRIPTION:
+    Finds the merge commit recorded when 'wt done' merged the session
+    directly, reverts it on the project's default branch, pushes, and
+    reopens the bead.
+
+    With --fix, a new session is started on the bead. Its branch is cut
+    from the default branch with the reverted work reapplied, so the fix
+    can be merged again (the original branch can't be: git considers its
+    commits already merged).
+
+    Only direct merges can be rolled back this way; for PR merges, revert
+    the PR on your git host.
+
+ARGUMENTS:
+    <session|bead>      Session name or bead ID of the merged session
+
+OPTIONS:
+    --fix               Start a new session to fix the rolled-back work
+    --no-switch         With --fix, don't switch to the new session
+    -f, --force         Skip confirmation
+    -h, --help          Show this help
+
+EXAMPLES:
+    wt rollback toast                  Revert toast's merge and reopen its bead
+    wt rollback proj-abc --fix         Revert, then start fixing in a new session
+`
+	fmt.Print(help)
+	return nil
+}
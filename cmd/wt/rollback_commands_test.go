@@ -0,0 +1,24 @@
+package main
+
+import "testing"
+
+func TestParseRollbackFlags(t *testing.T) {
+	target, flags := parseRollbackFlags([]string{"--fix", "toast", "-f", "--no-switch"})
+	if target != "toast" || !flags.fix || !flags.force || !flags.noSwitch {
+		t.Errorf("parseRollbackFlags() = %q, %+v", target, flags)
+	}
+
+	target, flags = parseRollbackFlags([]string{"proj-abc"})
+	if target != "proj-abc" || flags.fix || flags.force {
+		t.Errorf("parseRollbackFlags() = %q, %+v", target, flags)
+	}
+}
+
+func TestShortSHA(t *testing.T) {
+	if got := shortSHA("0123456789abcdef"); got != "01234567" {
+		t.Errorf("shortSHA() = %q", got)
+	}
+	if got := shortSHA("abc"); got != "abc" {
+		t.Errorf("shortSHA(short) = %q", got)
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/badri/wt/internal/bead"
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/events"
+	"github.com/badri/wt/internal/project"
+	"github.com/badri/wt/internal/transcript"
+)
+
+// maxWorkLogBytes caps a transcript attached to a bead as a comment
+const maxWorkLogBytes = 64 * 1024
+
+// seanceExportOptions holds the flags of 'wt seance <name> --export'
+type seanceExportOptions struct {
+	format string // "md" or "json"
+	output string // file to write, "-" for stdout
+	attach bool   // also add the markdown transcript to the bead
+}
+
+// cmdSeanceExport writes the Claude transcript of a past session to a file
+func cmdSeanceExport(cfg *config.Config, event *events.Event, opts seanceExportOptions) error {
+	if opts.format != "md" && opts.format != "json" {
+		return fmt.Errorf("invalid --format %q: use md or json", opts.format)
+	}
+
+	path, err := transcript.Find(event.WorktreePath, event.ClaudeSession)
+	if err != nil {
+		return err
+	}
+	messages, err := transcript.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading transcript: %w", err)
+	}
+
+	header := transcript.Header{
+		Session:       event.Session,
+		Bead:          event.Bead,
+		Project:       event.Project,
+		ClaudeSession: event.ClaudeSession,
+		ExportedAt:    time.Now().Format(time.RFC3339),
+	}
+	markdown := transcript.Markdown(header, messages)
+
+	var data []byte
+	if opts.format == "json" {
+		data, err = json.MarshalIndent(transcript.Export{Header: header, Messages: messages}, "", "  ")
+		if err != nil {
+			return err
+		}
+		data = append(data, '\n')
+	} else {
+		data = []byte(markdown)
+	}
+
+	output := opts.output
+	if output == "" {
+		output = fmt.Sprintf("%s-transcript.%s", event.Session, opts.format)
+	}
+	if output == "-" {
+		os.Stdout.Write(data)
+	} else {
+		if err := os.WriteFile(output, data, 0644); err != nil {
+			return fmt.Errorf("writing transcript: %w", err)
+		}
+		fmt.Printf("Exported %d messages from '%s' to %s\n", len(messages), event.Session, output)
+	}
+
+	if opts.attach {
+		return attachWorkLog(cfg, event, markdown, output)
+	}
+	return nil
+}
+
+// attachWorkLog adds an exported transcript to the session's bead as a
+// comment, cut off at maxWorkLogBytes
+func attachWorkLog(cfg *config.Config, event *events.Event, markdown, output string) error {
+	if event.Bead == "" {
+		return fmt.Errorf("session '%s' has no bead to attach the transcript to", event.Session)
+	}
+
+	beadsDir := ""
+	if proj, err := project.NewManager(cfg).Get(event.Project); err == nil {
+		beadsDir = proj.BeadsDir()
+	}
+
+	if len(markdown) > maxWorkLogBytes {
+		note := "the full transcript is not saved"
+		if output != "-" {
+			note = "full transcript in " + output
+		}
+		markdown = fmt.Sprintf("%s\n\n… (truncated, %s)\n", markdown[:maxWorkLogBytes], note)
+	}
+	comment := fmt.Sprintf("Work log of session %s\n\n%s", event.Session, markdown)
+	if err := bead.AddCommentInDir(event.Bead, comment, beadsDir); err != nil {
+		return err
+	}
+	if output != "-" {
+		fmt.Printf("Attached transcript to %s\n", event.Bead)
+	}
+	return nil
+}
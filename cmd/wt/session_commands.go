@@ -4,19 +4,22 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/table"
 
-	"github.com/badri/wt/internal/auto"
 	"github.com/badri/wt/internal/bead"
 	"github.com/badri/wt/internal/config"
 	"github.com/badri/wt/internal/events"
 	"github.com/badri/wt/internal/handoff"
+	"github.com/badri/wt/internal/logging"
 	"github.com/badri/wt/internal/merge"
+	"github.com/badri/wt/internal/monitor"
 	"github.com/badri/wt/internal/namepool"
 	"github.com/badri/wt/internal/project"
+	"github.com/badri/wt/internal/report"
 	"github.com/badri/wt/internal/session"
 	"github.com/badri/wt/internal/testenv"
 	"github.com/badri/wt/internal/tmux"
@@ -30,9 +33,11 @@ type newFlags struct {
 	noSwitch    bool
 	forceSwitch bool
 	noTestEnv   bool
-	shell       bool // Start with shell only, don't launch Claude
-	noPrompt    bool // Start Claude but don't send initial prompt (for wt auto)
-	force       bool // Override safety checks (e.g., epic guard)
+	shell       bool   // Start with shell only, don't launch Claude
+	noPrompt    bool   // Start Claude but don't send initial prompt (for wt auto)
+	force       bool   // Override safety checks (e.g., epic guard)
+	stackOn     string // Parent bead or session to branch off instead of the default branch
+	resume      bool   // Finish a session an interrupted wt new left half-created
 }
 
 // cmdNewHelp shows detailed help for the new command
@@ -46,6 +51,10 @@ DESCRIPTION:
     Creates a git worktree, tmux session, and starts Claude Code
     to work on the specified bead.
 
+    The bead is claimed first: marked in_progress and assigned to this
+    hub (see 'wt claims'). A bead claimed by another hub or machine is
+    refused until the claim expires.
+
 ARGUMENTS:
     <bead-id>           The bead ID to work on (e.g., wt-123, myproject-abc)
 
@@ -59,7 +68,12 @@ OPTIONS:
     --no-test-env       Skip test environment setup
     --shell             Create session with shell only (don't start Claude)
     --no-prompt         Start Claude but don't send initial prompt (for wt auto)
-    --force             Override safety checks (e.g., allow spawning on epics)
+    --force             Override safety checks (e.g., allow spawning on epics,
+                        take over another hub's claim)
+    --resume            Finish a session an earlier 'wt new' left half-created
+    --stack-on <bead>   Branch off another bead's unmerged branch; wt done opens
+                        the PR against that branch and it is retargeted to the
+                        default branch once the parent merges
     -h, --help          Show this help
 
 EXAMPLES:
@@ -68,6 +82,8 @@ EXAMPLES:
     wt new wt-123 --no-switch         Create but stay in current session
     wt new proj-456 --repo ~/code/proj  Specify repo path
     wt new proj-456 -p proj-feature   Use project with specific branch config
+    wt new wt-124 --stack-on wt-123   Build on wt-123 before its PR merges
+    wt new wt-123 --resume            Complete an interrupted wt new
 `
 	fmt.Print(help)
 	return nil
@@ -82,14 +98,34 @@ USAGE:
 
 DESCRIPTION:
     Shows all active worktree sessions with their status, bead, and duration.
+    The Commits column counts commits ahead of (↑) and behind (↓) the branch
+    each session merges into.
+
+    Sessions working on an epic (its own 'wt auto' session, or its child
+    beads) are grouped under a row for the epic that shows how many of its
+    beads are done. Kill a whole group with 'wt kill --epic <id>'.
+
+    Active sessions are listed before past ones, in the --sort order;
+    sessions of an epic stay under their epic, by name.
 
 OPTIONS:
     --all               Show all sessions including completed ones
+    --sort <key>        Order sessions by created (default, oldest first),
+                        name, project, status (blocked and ready first),
+                        duration (longest first) or idle (longest first)
+    --reverse           Reverse the order
+    -w, --watch [secs]  Refresh the table in place (default every 5s).
+                        Press r to refresh now, q to quit.
     -h, --help          Show this help
 
 EXAMPLES:
     wt list             List active sessions
     wt list --all       List all sessions including completed
+    wt list --watch     Keep an auto-refreshing list open in a pane
+    wt list -w 10       Refresh every 10 seconds
+    wt list --sort idle Longest idle sessions first
+    wt list --sort created --reverse
+                        Newest sessions first
 `
 	fmt.Print(help)
 	return nil
@@ -101,21 +137,35 @@ func cmdKillHelp() error {
 
 USAGE:
     wt kill <name> [options]
+    wt kill --epic <epic-id> [options]
 
 DESCRIPTION:
     Terminates the tmux session and optionally removes the worktree.
     The bead remains open for future work.
 
+    With --epic, kills every session of an epic at once: the epic's own
+    session from 'wt auto' and the sessions of its child beads (see the
+    groups in 'wt list'). Stop a running 'wt auto' first with --abort.
+
 ARGUMENTS:
     <name>              Session name to kill
 
+    If the worktree has uncommitted changes or unpushed commits, wt lists
+    them and asks whether to archive them first. Use --stash or --force to
+    decide up front.
+
 OPTIONS:
+    --epic <id>         Kill all sessions of an epic
     --keep-worktree     Keep the git worktree (only kill tmux session)
+    --stash             Archive unsaved work to ~/.config/wt/archive/ first
+    -f, --force         Remove the worktree even if it has unsaved work
     -h, --help          Show this help
 
 EXAMPLES:
     wt kill mysession               Kill session and remove worktree
     wt kill mysession --keep-worktree  Kill session, keep worktree
+    wt kill mysession --stash       Archive unsaved work, then kill
+    wt kill --epic app-e1 --stash   Tear down everything working on app-e1
 `
 	fmt.Print(help)
 	return nil
@@ -126,16 +176,26 @@ func cmdCloseHelp() error {
 	help := `wt close - Complete a session and close the bead
 
 USAGE:
-    wt close <name>
+    wt close <name> [--no-summary]
+    wt close <bead>
 
 DESCRIPTION:
     Terminates the session, removes the worktree, and closes the bead.
     Use this when work on a bead is complete.
 
+    Given the ID of a bead 'wt done' left open (see bead_close in 'wt
+    done --help'), closes that bead; its session is already gone.
+
 ARGUMENTS:
     <name>              Session name to close
+    <bead>              Bead left open by 'wt done'
+
+    The session summary (commits, diff stat and a short paragraph Claude
+    writes, which can take up to 90s) is recorded as for 'wt done'. Run
+    without a terminal, the paragraph is skipped.
 
 OPTIONS:
+    --no-summary        Don't capture a session summary
     -h, --help          Show this help
 
 EXAMPLES:
@@ -160,8 +220,65 @@ DESCRIPTION:
     4. Closing the bead
     5. Cleaning up the session
 
+    A session summary (commits, diff stat and a short Claude-written
+    paragraph) is recorded in the events log and shown by 'wt seance'.
+    Set "summary_comment": true in the project config to also post it
+    as a comment on the bead.
+
+    In monorepo projects, beads labelled "scope:<name>" must only change
+    files in the paths the project declares for that scope. Changes
+    elsewhere are a warning, or an error with "out_of_scope": "block".
+    With "codeowners": true, PRs request reviews from the CODEOWNERS of
+    the changed files.
+
+    Sessions that depend on others ('wt depend') wait for those to merge
+    first: direct merges are refused and pr-auto opens the PR without
+    auto-merge.
+
+    A draft PR opened earlier with 'wt pr draft' is marked ready for
+    review. With --draft or "draft_prs": true in the project config,
+    pr-review PRs open as drafts and are marked ready once checks pass.
+
+    If the branch already has an open PR (out of draft, or any with
+    --amend-pr), wt done updates it instead: it pushes the follow-up
+    commits, lists them in the PR description and re-requests review from
+    earlier reviewers. The bead stays open and the session stays up until
+    the PR merges; 'wt close' then closes the bead.
+
+    TODO and FIXME markers the branch adds are listed, and wt done offers
+    a follow-up bead for each, linked to the bead and PR as
+    "discovered-from". Run without a terminal, e.g. by the worker, it
+    notes them on the bead instead. Follow-ups in a worker report ('wt
+    signal ready --report') are always created and linked the same way.
+
+    With --wait, or "merge_wait": "30m" in the project config, pr-auto
+    polls the PR's checks until it merges. Only then is the bead closed
+    and the session cleaned up; a failed check, a closed PR or the timeout
+    leaves the session in place and names the checks that failed.
+
+    "bead_close" in the project config sets when the bead is closed:
+      done      When wt done merges or opens the PR (default)
+      merged    Once the work is merged. A PR's bead stays open until it
+                merges: 'wt pr sync', 'wt done' and 'wt close' check
+      manual    Never by wt done; close it with 'wt close <bead>'
+
 OPTIONS:
     -m, --merge-mode <mode>  Merge mode: direct, pr-auto, pr-review
+    --no-summary             Skip capturing the end-of-session summary
+    --allow-out-of-scope     Merge even if changes leave the bead's scope
+    --ignore-deps            Merge even if dependencies have not merged
+    --draft                  Open the pr-review PR as a draft, marked ready
+                             once checks pass (see 'wt pr')
+    --wait                   pr-auto: wait for the PR to merge before closing
+                             the bead and cleaning up
+    --wait-timeout <dur>     How long --wait waits (default: the project's
+                             merge_wait, else 30m)
+    --no-wait                Don't wait, even if the project sets merge_wait
+    --amend-pr               Update the branch's open PR (including a draft)
+                             and keep the bead open
+    --follow-ups             Create follow-up beads for added TODO/FIXME
+                             markers without asking
+    --no-follow-ups          Don't look for added TODO/FIXME markers
     -h, --help               Show this help
 
 MERGE MODES:
@@ -178,78 +295,29 @@ EXAMPLES:
 	return nil
 }
 
-// cmdStatusHelp shows help for the status command
-func cmdStatusHelp() error {
-	help := `wt status - Show current session status
-
-USAGE:
-    wt status
-
-DESCRIPTION:
-    Displays detailed information about the current worktree session,
-    including bead info, git status, and session metadata.
-
-OPTIONS:
-    -h, --help          Show this help
-
-EXAMPLES:
-    wt status           Show current session status
-`
-	fmt.Print(help)
-	return nil
-}
-
-// cmdSignalHelp shows help for the signal command
-func cmdSignalHelp() error {
-	help := `wt signal - Update session status
-
-USAGE:
-    wt signal <status> [message]
-
-DESCRIPTION:
-    Updates the status of the current session. This is used to communicate
-    progress to the hub or other monitoring tools.
-
-ARGUMENTS:
-    <status>            Status: ready, blocked, error, working, idle, bead-done
-
-STATUS VALUES:
-    ready       Work is complete, ready for review/merge
-    blocked     Waiting on external dependency or decision
-    error       An error occurred that needs attention
-    working     Actively working on the task
-    idle        Paused but not blocked
-    bead-done   Bead completed in batch mode (include summary for next bead)
-
-OPTIONS:
-    -h, --help          Show this help
-
-EXAMPLES:
-    wt signal ready               Mark session as ready
-    wt signal blocked "Waiting on API access"  Mark blocked with reason
-    wt signal error "Tests failing"            Mark as error with message
-    wt signal bead-done "Added new feature X with tests"  Batch bead complete
-`
-	fmt.Print(help)
-	return nil
-}
-
 // cmdAbandonHelp shows help for the abandon command
 func cmdAbandonHelp() error {
 	help := `wt abandon - Abandon current session without merge
 
 USAGE:
-    wt abandon
+    wt abandon [options]
 
 DESCRIPTION:
     Abandons the current session without merging changes.
     The worktree is removed but the bead remains open.
 
+    If the worktree has uncommitted changes or unpushed commits, wt lists
+    them and asks whether to archive them first. Use --stash or --force to
+    decide up front.
+
 OPTIONS:
+    --stash             Archive unsaved work to ~/.config/wt/archive/ first
+    -f, --force         Remove the worktree even if it has unsaved work
     -h, --help          Show this help
 
 EXAMPLES:
     wt abandon          Abandon current session
+    wt abandon --force  Abandon and discard unsaved work
 `
 	fmt.Print(help)
 	return nil
@@ -286,6 +354,13 @@ func parseNewFlags(args []string) (beadID string, flags newFlags) {
 			flags.noPrompt = true
 		case "--force":
 			flags.force = true
+		case "--resume":
+			flags.resume = true
+		case "--stack-on":
+			if i+1 < len(args) {
+				flags.stackOn = args[i+1]
+				i++
+			}
 		}
 	}
 	return
@@ -293,10 +368,12 @@ func parseNewFlags(args []string) (beadID string, flags newFlags) {
 
 type killFlags struct {
 	keepWorktree bool
+	force        bool // remove the worktree even if it has unsaved work
+	stash        bool // archive unsaved work before removing the worktree
 }
 
 func parseKillFlags(args []string) killFlags {
-	var flags killFlags
+	flags := parseAbandonFlags(args)
 	for _, arg := range args {
 		if arg == "--keep-worktree" {
 			flags.keepWorktree = true
@@ -306,15 +383,27 @@ func parseKillFlags(args []string) killFlags {
 }
 
 type doneFlags struct {
-	mergeMode string
-	noRebase  bool
+	mergeMode       string
+	noRebase        bool
+	noSummary       bool
+	allowOutOfScope bool
+	ignoreDeps      bool
+	draft           bool
+	wait            bool   // pr-auto: wait for the PR to merge before closing the bead
+	noWait          bool   // don't wait, even if the project sets merge_wait
+	waitTimeout     string // how long --wait waits, e.g. "45m"
+	amendPR         bool   // update the branch's open PR instead of opening one
+	followUps       bool   // create beads for added TODOs without asking
+	noFollowUps     bool   // don't scan for added TODOs
 }
 
 type listFlags struct {
-	all     bool   // Include past sessions
-	project string // Filter by project
-	since   string // Filter by time (e.g., "1d", "1w")
-	status  string // Filter by status (completed, killed, abandoned)
+	all     bool          // Include past sessions
+	project string        // Filter by project
+	since   string        // Filter by time (e.g., "1d", "1w")
+	status  string        // Filter by status (completed, killed, abandoned)
+	watch   time.Duration // Refresh interval with --watch (0 = print once)
+	order   sessionOrder  // --sort and --reverse
 }
 
 func parseListFlags(args []string) listFlags {
@@ -338,6 +427,21 @@ func parseListFlags(args []string) listFlags {
 				flags.status = args[i+1]
 				i++
 			}
+		case "--sort":
+			if i+1 < len(args) {
+				flags.order.key = args[i+1]
+				i++
+			}
+		case "--reverse":
+			flags.order.reverse = true
+		case "--watch", "-w":
+			flags.watch = defaultListWatchInterval
+			if i+1 < len(args) {
+				if d, ok := parseWatchInterval(args[i+1]); ok {
+					flags.watch = d
+					i++
+				}
+			}
 		}
 	}
 	return flags
@@ -354,6 +458,29 @@ func parseDoneFlags(args []string) doneFlags {
 			}
 		case "--no-rebase":
 			flags.noRebase = true
+		case "--no-summary":
+			flags.noSummary = true
+		case "--allow-out-of-scope":
+			flags.allowOutOfScope = true
+		case "--ignore-deps":
+			flags.ignoreDeps = true
+		case "--draft":
+			flags.draft = true
+		case "--wait":
+			flags.wait = true
+		case "--no-wait":
+			flags.noWait = true
+		case "--amend-pr":
+			flags.amendPR = true
+		case "--follow-ups":
+			flags.followUps = true
+		case "--no-follow-ups":
+			flags.noFollowUps = true
+		case "--wait-timeout":
+			if i+1 < len(args) {
+				flags.waitTimeout = args[i+1]
+				i++
+			}
 		}
 	}
 	return flags
@@ -387,20 +514,119 @@ type ListSessionEntry struct {
 	Duration  string // Formatted duration
 	IsPast    bool
 	MergeMode string // For past sessions (how it ended)
+	Activity  string // Transcript activity, when idle_detection is "transcript"
+	Bead      string
+	Notes     []string              // Annotations from wt note, with --all
+	DependsOn []string              // Sessions or beads that must merge first (wt depend)
+	Commits   *monitor.BranchCounts // Ahead/behind the base branch, active sessions only
+	Checks    string                // CI checks of the session's PR, active sessions only
+	Epic      *epicSummary          // Epic the session works on, active sessions only
+	Idle      int                   // Minutes without activity, with --sort idle only
 }
 
 func cmdList(cfg *config.Config, args []string) error {
 	flags := parseListFlags(args)
+	if flags.order.key != "" {
+		if _, err := parseSortKey(flags.order.key); err != nil {
+			return err
+		}
+	}
+	if flags.watch > 0 {
+		if outputJSON {
+			return fmt.Errorf("--watch cannot be combined with --json")
+		}
+		return runListWatch(cfg, flags)
+	}
 
-	state, err := session.LoadState(cfg)
+	entries, err := collectListEntries(cfg, flags)
 	if err != nil {
 		return err
 	}
 
+	if len(entries) == 0 {
+		if flags.all {
+			printEmptyMessage("No sessions found.", "Try: wt list --all --since 1w")
+		} else {
+			printEmptyMessage("No active sessions.", "Commands: wt new <bead> | wt list --all")
+		}
+		return nil
+	}
+
+	// JSON output
+	if outputJSON {
+		type ListSessionJSON struct {
+			Name      string       `json:"name"`
+			Type      string       `json:"type"`
+			Status    string       `json:"status"`
+			Title     string       `json:"title"`
+			Project   string       `json:"project"`
+			CreatedAt string       `json:"created_at,omitempty"`
+			EndedAt   string       `json:"ended_at,omitempty"`
+			Duration  string       `json:"duration,omitempty"`
+			IsPast    bool         `json:"is_past"`
+			MergeMode string       `json:"merge_mode,omitempty"`
+			Activity  string       `json:"activity,omitempty"`
+			Notes     []string     `json:"notes,omitempty"`
+			DependsOn []string     `json:"depends_on,omitempty"`
+			Ahead     *int         `json:"ahead,omitempty"`
+			Behind    *int         `json:"behind,omitempty"`
+			Checks    string       `json:"checks,omitempty"`
+			Epic      *epicSummary `json:"epic,omitempty"`
+		}
+		var jsonEntries []ListSessionJSON
+		for _, e := range entries {
+			var ahead, behind *int
+			if e.Commits != nil {
+				ahead, behind = &e.Commits.Ahead, &e.Commits.Behind
+			}
+			jsonEntries = append(jsonEntries, ListSessionJSON{
+				Name:      e.Name,
+				Type:      e.Type,
+				Status:    e.Status,
+				Title:     e.Title,
+				Project:   e.Project,
+				CreatedAt: e.CreatedAt,
+				EndedAt:   e.EndedAt,
+				Duration:  e.Duration,
+				IsPast:    e.IsPast,
+				MergeMode: e.MergeMode,
+				Activity:  e.Activity,
+				Notes:     e.Notes,
+				DependsOn: e.DependsOn,
+				Ahead:     ahead,
+				Behind:    behind,
+				Checks:    e.Checks,
+				Epic:      e.Epic,
+			})
+		}
+		printJSON(jsonEntries)
+		return nil
+	}
+
+	fmt.Println(renderSessionList(cfg, flags, entries))
+
+	if flags.all {
+		fmt.Println("\nCommands: wt <name> (switch) | wt seance <name> (resume past)")
+	} else {
+		fmt.Println("\nCommands: wt <name> (switch) | wt new <bead> | wt list --all")
+	}
+
+	return nil
+}
+
+// collectListEntries gathers the active sessions, plus past ones with --all
+func collectListEntries(cfg *config.Config, flags listFlags) ([]ListSessionEntry, error) {
+	state, err := session.LoadState(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	// Build unified list of sessions
 	var entries []ListSessionEntry
 
 	// Add active sessions
+	counter := newBranchCounter(cfg)
+	epics := newEpicTracker()
 	for name, sess := range state.Sessions {
 		// Apply project filter
 		if flags.project != "" && sess.Project != flags.project {
@@ -449,6 +675,15 @@ func cmdList(cfg *config.Config, args []string) error {
 			durationStr = formatSessionDuration(sess.CreatedAt, "")
 		}
 
+		activity := ""
+		if cfg.UseTranscriptActivity() {
+			activity, _ = monitor.DetectActivity(name, sess.Worktree, 5*time.Minute)
+		}
+		idle := 0
+		if flags.order.key == sortIdle {
+			idle = monitor.GetIdleMinutes(name)
+		}
+
 		entries = append(entries, ListSessionEntry{
 			Name:      name,
 			Type:      sessionType,
@@ -458,6 +693,13 @@ func cmdList(cfg *config.Config, args []string) error {
 			CreatedAt: sess.CreatedAt,
 			Duration:  durationStr,
 			IsPast:    false,
+			Activity:  activity,
+			Bead:      sess.Bead,
+			DependsOn: dependencyList(state, sess.DependsOn),
+			Commits:   counter.counts(sess),
+			Checks:    counter.checks(sess),
+			Epic:      epics.summary(sess.Epic, sess.BeadsDir),
+			Idle:      idle,
 		})
 	}
 
@@ -469,17 +711,17 @@ func cmdList(cfg *config.Config, args []string) error {
 		if flags.since != "" {
 			duration, err := parseDurationString(flags.since)
 			if err != nil {
-				return fmt.Errorf("invalid duration format: %s (use 1d, 1w, 2h, etc.)", flags.since)
+				return nil, fmt.Errorf("invalid duration format: %s (use 1d, 1w, 2h, etc.)", flags.since)
 			}
 			historyEvents, err = eventLogger.Since(duration)
 			if err != nil {
-				return fmt.Errorf("reading events: %w", err)
+				return nil, fmt.Errorf("reading events: %w", err)
 			}
 		} else {
 			// Default to last 100 events when no since filter
 			historyEvents, err = eventLogger.Recent(100)
 			if err != nil {
-				return fmt.Errorf("reading events: %w", err)
+				return nil, fmt.Errorf("reading events: %w", err)
 			}
 		}
 
@@ -542,88 +784,99 @@ func cmdList(cfg *config.Config, args []string) error {
 				Duration:  durationStr,
 				IsPast:    true,
 				MergeMode: e.MergeMode,
+				Bead:      e.Bead,
 			})
 		}
-	}
 
-	if len(entries) == 0 {
-		if flags.all {
-			printEmptyMessage("No sessions found.", "Try: wt list --all --since 1w")
-		} else {
-			printEmptyMessage("No active sessions.", "Commands: wt new <bead> | wt list --all")
-		}
-		return nil
-	}
-
-	// JSON output
-	if outputJSON {
-		type ListSessionJSON struct {
-			Name      string `json:"name"`
-			Type      string `json:"type"`
-			Status    string `json:"status"`
-			Title     string `json:"title"`
-			Project   string `json:"project"`
-			CreatedAt string `json:"created_at,omitempty"`
-			EndedAt   string `json:"ended_at,omitempty"`
-			Duration  string `json:"duration,omitempty"`
-			IsPast    bool   `json:"is_past"`
-			MergeMode string `json:"merge_mode,omitempty"`
+		// Attach notes made while each session ran (or since, for active ones)
+		notes, err := eventLogger.Notes()
+		if err != nil {
+			return nil, fmt.Errorf("reading notes: %w", err)
 		}
-		var jsonEntries []ListSessionJSON
-		for _, e := range entries {
-			jsonEntries = append(jsonEntries, ListSessionJSON{
-				Name:      e.Name,
-				Type:      e.Type,
-				Status:    e.Status,
-				Title:     e.Title,
-				Project:   e.Project,
-				CreatedAt: e.CreatedAt,
-				EndedAt:   e.EndedAt,
-				Duration:  e.Duration,
-				IsPast:    e.IsPast,
-				MergeMode: e.MergeMode,
-			})
+		for i := range entries {
+			entries[i].Notes = sessionNotes(notes, entries[i].Name, entries[i].Bead, entries[i].CreatedAt, entries[i].EndedAt)
 		}
-		printJSON(jsonEntries)
-		return nil
 	}
 
+	// State is a map: order the sessions so they keep their places
+	sortListEntries(entries, flags.order)
+
+	return entries, nil
+}
+
+// renderSessionList renders the session table of wt list
+func renderSessionList(cfg *config.Config, flags listFlags, entries []ListSessionEntry) string {
 	// Define columns with Duration
 	columns := []table.Column{
 		{Title: "Name", Width: 18},
 		{Title: "Type", Width: 6},
 		{Title: "Status", Width: 10},
 		{Title: "Duration", Width: 10},
+		{Title: "Commits", Width: 9},
+		{Title: "Checks", Width: 10},
 		{Title: "Title", Width: 26},
 		{Title: "Project", Width: 12},
 	}
 
-	// Build rows
+	showActivity := cfg.UseTranscriptActivity()
+	if showActivity {
+		columns = slices.Insert(columns, 3, table.Column{Title: "Claude", Width: 18})
+	}
+
+	// Build rows, with the sessions of an epic grouped under it
 	var rows []table.Row
-	for _, entry := range entries {
-		rows = append(rows, table.Row{
-			entry.Name,
+	for _, item := range groupByEpic(entries) {
+		if item.header != nil {
+			rows = append(rows, epicHeaderRow(item.header, item.entry.Project, showActivity))
+			continue
+		}
+		entry := item.entry
+		commits, checks := "", ""
+		if !entry.IsPast {
+			commits = formatBranchCounts(entry.Commits)
+			checks = formatChecks(entry.Checks)
+		}
+		row := table.Row{
+			item.prefix + entry.Name,
 			entry.Type,
 			entry.Status,
 			entry.Duration,
+			commits,
+			checks,
 			truncate(entry.Title, 26),
 			truncate(entry.Project, 12),
-		})
+		}
+		if showActivity {
+			activity := "-"
+			if entry.Activity != "" {
+				activity = entry.Activity
+			}
+			row = slices.Insert(row, 3, activity)
+		}
+		rows = append(rows, row)
+
+		if len(entry.DependsOn) > 0 {
+			depRow := table.Row{"", "deps", "", "", "", "", truncate("↳ after "+strings.Join(entry.DependsOn, ", "), 26), ""}
+			if showActivity {
+				depRow = slices.Insert(depRow, 3, "")
+			}
+			rows = append(rows, depRow)
+		}
+
+		for _, note := range entry.Notes {
+			noteRow := table.Row{"", "note", "", "", "", "", truncate("↳ "+note, 26), ""}
+			if showActivity {
+				noteRow = slices.Insert(noteRow, 3, "")
+			}
+			rows = append(rows, noteRow)
+		}
 	}
 
 	title := "Active Sessions"
 	if flags.all {
 		title = "Sessions (Active + Past)"
 	}
-	printTable(title, columns, rows)
-
-	if flags.all {
-		fmt.Println("\nCommands: wt <name> (switch) | wt seance <name> (resume past)")
-	} else {
-		fmt.Println("\nCommands: wt <name> (switch) | wt new <bead> | wt list --all")
-	}
-
-	return nil
+	return renderTable(title, columns, rows)
 }
 
 // parseDurationString parses duration strings like "1d", "2w", "3h", "30m"
@@ -723,9 +976,27 @@ func cmdNew(cfg *config.Config, args []string) error {
 		return err
 	}
 
+	// An interrupted wt new leaves a record of the steps it completed
+	var pending *session.Pending
+	if flags.resume {
+		pending, err = pendingNew(cfg, state, beadID)
+		if err != nil {
+			return fmt.Errorf("reading progress of interrupted wt new: %w", err)
+		}
+		if pending == nil {
+			logging.Infof("Nothing to resume for %s, creating a new session.", beadID)
+		}
+	} else if pending, _ = session.LoadPending(cfg, beadID); pending != nil {
+		return fmt.Errorf("an earlier 'wt new %s' was interrupted (session '%s'). Run 'wt new %s --resume' to finish it", beadID, pending.Name, beadID)
+	}
+	resuming := pending != nil
+	if !resuming {
+		pending = session.NewPending(cfg, beadID)
+	}
+
 	// Check if session already exists for this bead
 	for name, sess := range state.Sessions {
-		if sess.Bead == beadID {
+		if sess.Bead == beadID && !(resuming && name == pending.Name) {
 			return fmt.Errorf("session '%s' already exists for bead %s", name, beadID)
 		}
 	}
@@ -735,6 +1006,13 @@ func cmdNew(cfg *config.Config, args []string) error {
 	var proj *project.Project
 	mgr := project.NewManager(cfg)
 
+	if flags.project == "" && resuming {
+		flags.project = pending.Project
+	}
+	if repoPath == "" && resuming {
+		repoPath = pending.RepoPath
+	}
+
 	// If explicit project specified, use it
 	if flags.project != "" {
 		var err error
@@ -799,7 +1077,7 @@ func cmdNew(cfg *config.Config, args []string) error {
 		if err != nil {
 			return err
 		}
-		fmt.Printf("Using theme: %s\n", pool.Theme())
+		logging.Infof("Using theme: %s", pool.Theme())
 	} else {
 		// Fall back to file-based namepool
 		pool, err = namepool.Load(cfg)
@@ -810,9 +1088,12 @@ func cmdNew(cfg *config.Config, args []string) error {
 
 	sessionName := flags.name
 	var themeName string // Track allocated name for namepool deduplication
+	if resuming && pending.Name != "" {
+		sessionName, themeName = pending.Name, pending.ThemeName
+	}
 	if sessionName == "" {
 		var err error
-		themeName, err = pool.Allocate(state.UsedNames())
+		themeName, err = pool.Allocate(append(state.UsedNames(), pendingNames(cfg)...))
 		if err != nil {
 			return err
 		}
@@ -823,10 +1104,18 @@ func cmdNew(cfg *config.Config, args []string) error {
 			sessionName = themeName
 		}
 	}
+	if !resuming {
+		if sessionName, err = claimSessionName(sessionName, flags.name != ""); err != nil {
+			return err
+		}
+	}
 
-	// Create worktree using bead ID to guarantee unique paths
-	worktreePath := cfg.WorktreePath(beadID)
-	fmt.Printf("Creating git worktree at %s...\n", worktreePath)
+	// Create worktree using project and bead ID to guarantee unique paths;
+	// a resumed run keeps the path it started with
+	worktreePath := cfg.ProjectWorktreePath(projectName, beadID)
+	if resuming && pending.Worktree != "" {
+		worktreePath = pending.Worktree
+	}
 
 	// Determine base branch for worktree creation
 	baseBranch := "main"
@@ -834,155 +1123,73 @@ func cmdNew(cfg *config.Config, args []string) error {
 		baseBranch = proj.DefaultBranch
 	}
 
-	// Create worktree from the project's base branch
-	if err := worktree.CreateFromBranch(repoPath, worktreePath, beadID, baseBranch); err != nil {
-		return fmt.Errorf("creating worktree: %w", err)
-	}
-	if baseBranch != "main" {
-		fmt.Printf("  Created from branch: %s\n", baseBranch)
-	}
-
-	// Symlink .claude/ from main repo for project-specific configs (MCP servers, hooks, settings)
-	if err := worktree.SymlinkClaudeDir(repoPath, worktreePath); err != nil {
-		fmt.Printf("Warning: could not symlink .claude/: %v\n", err)
-	}
-
-	// beadsDir already set above when validating the bead
-
-	// Allocate port offset if test env is configured
-	var portOffset int
-	var portEnv string
-	if proj != nil && proj.TestEnv != nil {
-		usedOffsets := collectUsedOffsets(state)
-		portOffset = testenv.AllocatePortOffset(proj, usedOffsets)
-		portEnv = proj.TestEnv.PortEnv
-		if portEnv == "" {
-			portEnv = "PORT_OFFSET"
-		}
-		fmt.Printf("Allocated %s=%d\n", portEnv, portOffset)
-	}
-
-	// Create tmux session
-	fmt.Printf("Creating tmux session '%s'...\n", sessionName)
-	tmuxOpts := &tmux.SessionOptions{
-		PortOffset: portOffset,
-		PortEnv:    portEnv,
-	}
-	// When --shell flag is set, don't start Claude (pass empty editorCmd)
-	editorCmd := cfg.EditorCmd
-	if flags.shell {
-		editorCmd = ""
-	}
-	if err := tmux.NewSession(sessionName, worktreePath, beadsDir, editorCmd, tmuxOpts); err != nil {
-		// Cleanup worktree on failure
-		worktree.Remove(worktreePath)
-		return fmt.Errorf("creating tmux session: %w", err)
-	}
-
-	// Run test env setup if configured and not skipped
-	if proj != nil && proj.TestEnv != nil && proj.TestEnv.Setup != "" && !flags.noTestEnv {
-		fmt.Println("Running test environment setup...")
-		if err := testenv.RunSetup(proj, worktreePath, portOffset); err != nil {
-			fmt.Printf("Warning: test env setup failed: %v\n", err)
-		}
-
-		// Wait for health check if configured
-		if proj.TestEnv.HealthCheck != "" {
-			fmt.Println("Waiting for test environment to be ready...")
-			if err := testenv.WaitForHealthy(proj, worktreePath, portOffset, 30*time.Second); err != nil {
-				fmt.Printf("Warning: health check failed: %v\n", err)
-			}
-		}
-	} else if flags.noTestEnv && proj != nil && proj.TestEnv != nil {
-		fmt.Println("Skipping test environment setup (--no-test-env)")
-	}
-
-	// Run on_create hooks if configured
-	if proj != nil && proj.Hooks != nil && len(proj.Hooks.OnCreate) > 0 {
-		fmt.Println("Running on_create hooks...")
-		if err := testenv.RunOnCreateHooks(proj, worktreePath, portOffset, portEnv); err != nil {
-			fmt.Printf("Warning: on_create hook failed: %v\n", err)
+	// Stacked sessions branch off the parent's branch instead
+	var stackedOn string
+	if resuming && pending.BaseBranch != "" {
+		stackedOn, baseBranch = pending.StackedOn, pending.BaseBranch
+	} else if flags.stackOn != "" {
+		stackedOn, baseBranch, err = resolveStackParent(state, repoPath, flags.stackOn)
+		if err != nil {
+			return err
 		}
 	}
 
-	// Determine project name (may have been set earlier for namepool)
-	if projectName == "" {
-		projectName = beadInfo.Project
-		if proj != nil {
-			projectName = proj.Name
-		}
+	// Record each step so a failure is rolled back and an interruption resumed
+	pending.Name, pending.ThemeName = sessionName, themeName
+	if proj != nil {
+		pending.Project = proj.Name
 	}
+	pending.RepoPath, pending.Worktree, pending.Branch = repoPath, worktreePath, beadID
+	pending.BaseBranch, pending.StackedOn = baseBranch, stackedOn
 
-	// Save session state
 	sess := &session.Session{
-		Bead:       beadID,
-		Project:    projectName,
-		Worktree:   worktreePath,
-		Branch:     beadID,
-		PortOffset: portOffset,
-		BeadsDir:   beadsDir,
-		Status:     "working",
-		CreatedAt:  session.Now(),
-		ThemeName:  themeName, // Track allocated name for namepool deduplication
-	}
-	sess.UpdateActivity()
-
-	state.Sessions[sessionName] = sess
-	if err := state.Save(); err != nil {
-		return fmt.Errorf("saving state: %w", err)
-	}
-
-	// Log session start event
-	eventLogger := events.NewLogger(cfg)
-	eventLogger.LogSessionStart(sessionName, beadID, projectName, worktreePath)
-
-	fmt.Printf("\nSession '%s' ready.\n", sessionName)
-	fmt.Printf("  Bead:     %s\n", beadID)
-	fmt.Printf("  Worktree: %s\n", worktreePath)
-	fmt.Printf("  Branch:   %s\n", beadID)
-
-	// Skip Claude initialization when --shell flag is used
-	if !flags.shell {
-		// Wait for Claude to actually be running before sending prompt
-		fmt.Println("Waiting for Claude to start...")
-		if err := tmux.WaitForClaude(sessionName, 60*time.Second); err != nil {
-			fmt.Printf("Warning: %v (sending prompt anyway)\n", err)
-		}
-
-		// Accept the bypass permissions warning dialog if present
-		if err := tmux.AcceptBypassPermissionsWarning(sessionName); err != nil {
-			fmt.Printf("Warning: could not accept bypass warning: %v\n", err)
-		}
-
-		// Additional delay for Claude to fully initialize its UI
-		time.Sleep(2 * time.Second)
-
-		// Send initial work prompt using reliable nudge pattern
-		// Skip if --no-prompt is used (wt auto sends its own batch-aware prompt)
-		if !flags.noPrompt {
-			fmt.Println("Sending initial prompt to worker...")
-			prompt := buildInitialPrompt(beadID, beadInfo.Title, beadInfo.Description, sessionName, proj)
-			if err := tmux.NudgeSession(sessionName, prompt); err != nil {
-				fmt.Printf("Warning: could not send initial prompt: %v\n", err)
+		Bead:     beadID,
+		Project:  projectName,
+		BeadsDir: beadsDir,
+		Epic:     bead.EpicOf(beadID, repoPath),
+	}
+	if stackedOn != "" {
+		sess.StackedOn = stackedOn
+		sess.StackBranch = baseBranch
+	}
+
+	ns := &newSession{
+		pending:  pending,
+		resuming: resuming,
+		proj:     proj,
+		sess:     sess,
+		// Create worktree from the project's base branch
+		createWorktree: func() ([]string, error) {
+			logging.Infof("Creating git worktree at %s...", worktreePath)
+			paths, err := createSessionWorktree(proj, repoPath, worktreePath, beadID, baseBranch)
+			if err != nil {
+				return nil, err
+			}
+			if stackedOn != "" {
+				logging.Infof("  Stacked on %s (branch: %s)", stackedOn, baseBranch)
+			} else if baseBranch != "main" {
+				logging.Infof("  Created from branch: %s", baseBranch)
+			}
+			return paths, nil
+		},
+		resumeCmd:   fmt.Sprintf("wt new %s --resume", beadID),
+		force:       flags.force,
+		shell:       flags.shell,
+		noTestEnv:   flags.noTestEnv,
+		noSwitch:    flags.noSwitch,
+		forceSwitch: flags.forceSwitch,
+	}
+	// Skip the prompt if --no-prompt is used (wt auto sends its own batch-aware prompt)
+	if !flags.noPrompt {
+		ns.prompt = func(vars project.CommandVars) string {
+			prompt := buildInitialPrompt(beadID, beadInfo.Title, beadInfo.Description, sessionName, proj, vars)
+			if stackedOn != "" {
+				prompt += stackedPromptNote(stackedOn, baseBranch)
 			}
+			return prompt
 		}
 	}
-
-	// Determine if we should switch
-	// Default to no-switch if running from hub (WT_HUB=1), unless --switch is used
-	shouldSwitch := !flags.noSwitch
-	if os.Getenv("WT_HUB") == "1" && !flags.forceSwitch {
-		shouldSwitch = false
-		fmt.Println("\n(Running from hub - staying in hub. Use 'wt <name>' or --switch to attach)")
-	}
-
-	// Switch to session unless --no-switch or in hub
-	if shouldSwitch {
-		fmt.Println("\nSwitching...")
-		return tmux.Attach(sessionName)
-	}
-
-	return nil
+	return createSession(cfg, state, ns)
 }
 
 func cmdKill(cfg *config.Config, name string, flags killFlags) error {
@@ -996,50 +1203,61 @@ func cmdKill(cfg *config.Config, name string, flags killFlags) error {
 		return fmt.Errorf("session '%s' not found", name)
 	}
 
-	fmt.Printf("Killing session '%s'...\n", name)
+	if !flags.keepWorktree {
+		if err := checkUnsavedWork(cfg, name, sess, flags); err != nil {
+			return err
+		}
+	}
 
-	// Run teardown hooks if configured
+	logging.Infof("Killing session '%s'...", name)
+
+	// Run teardown hooks if configured (wt teardown-all may have run them)
 	mgr := project.NewManager(cfg)
-	if proj, _ := mgr.Get(sess.Project); proj != nil {
+	if proj, _ := mgr.Get(sess.Project); proj != nil && sess.EnvDownAt == "" {
 		// Run test env teardown
 		if proj.TestEnv != nil && proj.TestEnv.Teardown != "" {
-			fmt.Println("  Running test environment teardown...")
-			if err := testenv.RunTeardown(proj, sess.Worktree, sess.PortOffset); err != nil {
-				fmt.Printf("  Warning: teardown failed: %v\n", err)
+			logging.Infof("  Running test environment teardown...")
+			if err := testenv.RunTeardown(proj, sessionVars(name, sess)); err != nil {
+				logging.Warnf("teardown failed: %v", err)
 			}
 		}
 
 		// Run on_close hooks
 		if proj.Hooks != nil && len(proj.Hooks.OnClose) > 0 {
-			fmt.Println("  Running on_close hooks...")
+			logging.Infof("  Running on_close hooks...")
 			portEnv := ""
 			if proj.TestEnv != nil {
 				portEnv = proj.TestEnv.PortEnv
 			}
-			if err := testenv.RunOnCloseHooks(proj, sess.Worktree, sess.PortOffset, portEnv); err != nil {
-				fmt.Printf("  Warning: on_close hook failed: %v\n", err)
+			if err := testenv.RunOnCloseHooks(proj, sessionVars(name, sess), portEnv); err != nil {
+				logging.Warnf("on_close hook failed: %v", err)
 			}
 		}
 	}
 
 	// Kill tmux session
-	fmt.Println("  Terminating tmux session...")
+	logging.Infof("  Terminating tmux session...")
 	if err := tmux.Kill(name); err != nil {
-		fmt.Printf("  Warning: %v\n", err)
+		logging.Warnf("%v", err)
 	}
 
 	// Remove worktree (unless --keep-worktree)
 	if !flags.keepWorktree {
 		fmt.Printf("  Removing worktree: %s\n", sess.Worktree)
 		if err := worktree.Remove(sess.Worktree); err != nil {
-			fmt.Printf("  Warning: %v\n", err)
+			logging.Warnf("%v", err)
 		}
 	}
 
 	// Log session end event (for seance resumption)
 	eventLogger := events.NewLogger(cfg)
 	claudeSession := getClaudeSessionID(sess.Worktree)
-	eventLogger.LogSessionEnd(name, sess.Bead, sess.Project, claudeSession, "killed", "")
+	eventLogger.LogSessionEndWithSummary(name, sess.Bead, sess.Project, claudeSession, "killed", "", withLastMessage(nil, sess.Worktree, claudeSession))
+	postBeadActivity(cfg, sess, &events.Event{Type: events.EventSessionEnd, Session: name, MergeMode: "killed"})
+
+	// Put the bead back to open so it's ready again, without the old report
+	releaseSessionClaim(cfg, sess)
+	_ = report.Remove(cfg, sess.Bead)
 
 	// Remove from state
 	delete(state.Sessions, name)
@@ -1051,7 +1269,7 @@ func cmdKill(cfg *config.Config, name string, flags killFlags) error {
 	return nil
 }
 
-func cmdClose(cfg *config.Config, name string) error {
+func cmdClose(cfg *config.Config, name string, flags closeFlags) error {
 	state, err := session.LoadState(cfg)
 	if err != nil {
 		return err
@@ -1059,35 +1277,39 @@ func cmdClose(cfg *config.Config, name string) error {
 
 	sess, exists := state.Sessions[name]
 	if !exists {
+		// The session may be gone with its bead left open by wt done
+		if pending, err := closePendingBead(cfg, name); pending || err != nil {
+			return err
+		}
 		return fmt.Errorf("session '%s' not found", name)
 	}
 
-	fmt.Printf("Closing session '%s'...\n", name)
+	logging.Infof("Closing session '%s'...", name)
 	fmt.Printf("  Bead: %s\n", sess.Bead)
 
 	// Get project config for teardown hooks and default branch
 	mgr := project.NewManager(cfg)
 	proj, _ := mgr.Get(sess.Project)
 
-	// Run teardown hooks if configured
-	if proj != nil {
+	// Run teardown hooks if configured (wt teardown-all may have run them)
+	if proj != nil && sess.EnvDownAt == "" {
 		// Run test env teardown
 		if proj.TestEnv != nil && proj.TestEnv.Teardown != "" {
-			fmt.Println("  Running test environment teardown...")
-			if err := testenv.RunTeardown(proj, sess.Worktree, sess.PortOffset); err != nil {
-				fmt.Printf("  Warning: teardown failed: %v\n", err)
+			logging.Infof("  Running test environment teardown...")
+			if err := testenv.RunTeardown(proj, sessionVars(name, sess)); err != nil {
+				logging.Warnf("teardown failed: %v", err)
 			}
 		}
 
 		// Run on_close hooks
 		if proj.Hooks != nil && len(proj.Hooks.OnClose) > 0 {
-			fmt.Println("  Running on_close hooks...")
+			logging.Infof("  Running on_close hooks...")
 			portEnv := ""
 			if proj.TestEnv != nil {
 				portEnv = proj.TestEnv.PortEnv
 			}
-			if err := testenv.RunOnCloseHooks(proj, sess.Worktree, sess.PortOffset, portEnv); err != nil {
-				fmt.Printf("  Warning: on_close hook failed: %v\n", err)
+			if err := testenv.RunOnCloseHooks(proj, sessionVars(name, sess), portEnv); err != nil {
+				logging.Warnf("on_close hook failed: %v", err)
 			}
 		}
 	}
@@ -1104,10 +1326,23 @@ func cmdClose(cfg *config.Config, name string) error {
 		branch = sess.Bead
 	}
 
+	closeTitle := sess.Bead
+	if info, err := bead.ShowInDir(sess.Bead, sess.BeadsDir); err == nil && info.Title != "" {
+		closeTitle = info.Title
+	}
+	// Without a terminal nobody waits on Claude for the narrative
+	var sessionSummary *events.Summary
+	if !flags.noSummary {
+		sessionSummary = captureSessionSummary(sess, defaultBranch, closeTitle, stdinIsTerminal())
+	}
+	postSessionSummary(proj, name, sess, sessionSummary)
+
 	if worktree.IsBranchMerged(sess.Worktree, branch, defaultBranch) {
 		fmt.Println("\n  Branch merged to", defaultBranch, "- closing bead...")
 		if err := bead.Close(sess.Bead); err != nil {
-			fmt.Printf("  Warning: could not close bead: %v\n", err)
+			logging.Warnf("could not close bead: %v", err)
+		} else {
+			closeUpstreamIssue(cfg, sess.Bead, "  ")
 		}
 	} else {
 		fmt.Printf("\n  Branch not merged to %s - keeping bead %s open.\n", defaultBranch, sess.Bead)
@@ -1115,21 +1350,23 @@ func cmdClose(cfg *config.Config, name string) error {
 	}
 
 	// Kill tmux session
-	fmt.Println("  Terminating tmux session...")
+	logging.Infof("  Terminating tmux session...")
 	if err := tmux.Kill(name); err != nil {
-		fmt.Printf("  Warning: %v\n", err)
+		logging.Warnf("%v", err)
 	}
 
 	// Remove worktree
 	fmt.Printf("  Removing worktree: %s\n", sess.Worktree)
 	if err := worktree.Remove(sess.Worktree); err != nil {
-		fmt.Printf("  Warning: %v\n", err)
+		logging.Warnf("%v", err)
 	}
 
 	// Log session end event (for seance resumption)
 	eventLogger := events.NewLogger(cfg)
 	claudeSession := getClaudeSessionID(sess.Worktree)
-	eventLogger.LogSessionEnd(name, sess.Bead, sess.Project, claudeSession, "closed", "")
+	sessionSummary = withLastMessage(sessionSummary, sess.Worktree, claudeSession)
+	eventLogger.LogSessionEndWithSummary(name, sess.Bead, sess.Project, claudeSession, "closed", "", sessionSummary)
+	postBeadActivity(cfg, sess, &events.Event{Type: events.EventSessionEnd, Session: name, MergeMode: "closed", Summary: sessionSummary})
 
 	// Remove from state
 	delete(state.Sessions, name)
@@ -1137,6 +1374,12 @@ func cmdClose(cfg *config.Config, name string) error {
 		return fmt.Errorf("saving state: %w", err)
 	}
 
+	// Retarget PRs stacked on merged parents and drop stale stack records
+	syncStackedPRs(cfg)
+	syncDraftPRs(cfg)
+	syncVerifyPRs(cfg)
+	syncPendingCloses(cfg)
+
 	fmt.Println("\nDone.")
 	return nil
 }
@@ -1204,6 +1447,13 @@ func cmdDone(cfg *config.Config, flags doneFlags) error {
 	if flags.mergeMode != "" {
 		mergeMode = flags.mergeMode
 	}
+	if flags.draft && mergeMode != "pr-review" {
+		return fmt.Errorf("--draft only applies to the pr-review merge mode")
+	}
+	waitTimeout, wait, err := mergeWait(proj, flags, mergeMode)
+	if err != nil {
+		return err
+	}
 
 	defaultBranch := proj.DefaultBranch
 	if defaultBranch == "" {
@@ -1225,28 +1475,65 @@ func cmdDone(cfg *config.Config, flags doneFlags) error {
 		prTitle = sess.Bead
 	}
 
-	fmt.Printf("Completing session '%s'...\n", sessionName)
+	logging.Infof("Completing session '%s'...", sessionName)
 	fmt.Printf("  Bead:       %s\n", sess.Bead)
 	fmt.Printf("  Branch:     %s\n", branch)
 	fmt.Printf("  Merge mode: %s\n", mergeMode)
 
+	// Stacked sessions target the parent's branch until the parent merges
+	targetBranch := stackTarget(cwd, sess, defaultBranch)
+	if targetBranch != defaultBranch {
+		fmt.Printf("  Target:     %s (stacked on %s)\n", targetBranch, sess.StackedOn)
+		if mergeMode == "direct" {
+			return fmt.Errorf("session is stacked on %s, which is not merged yet. Finish %s first or use --merge-mode pr-review", sess.StackedOn, sess.StackedOn)
+		}
+	}
+	if mergeMode == "direct" {
+		mergeMode = directMergeMode(proj, cwd, targetBranch)
+	}
+
+	// A branch whose PR is already open gets that PR updated
+	existingPR, err := findAmendPR(cwd, branch, mergeMode, flags.amendPR)
+	if err != nil {
+		return err
+	}
+	if existingPR != nil {
+		fmt.Printf("  Open PR:    %s (will be updated)\n", existingPR.URL)
+	}
+
+	// Sessions declared with 'wt depend' merge after their prerequisites
+	waitingOn := ""
+	if !flags.ignoreDeps {
+		if waiting := unmergedDependencies(cwd, sess, defaultBranch); len(waiting) > 0 {
+			waitingOn = dependencyLabels(state, waiting)
+			fmt.Printf("  Waits for:  %s (not merged yet)\n", waitingOn)
+			switch mergeMode {
+			case "direct":
+				return fmt.Errorf("session depends on %s, which is not merged yet. Finish it first, use --merge-mode pr-review, or rerun with --ignore-deps", waitingOn)
+			case "pr-auto":
+				fmt.Println("  Opening the PR without auto-merge until the dependencies merge.")
+				mergeMode = "pr-review"
+			}
+		}
+	}
+
 	// Auto-rebase on main unless disabled
 	shouldRebase := !flags.noRebase && proj.AutoRebaseMode() != "false"
 
 	if shouldRebase {
-		fmt.Printf("\nFetching latest %s...\n", defaultBranch)
-		if err := merge.FetchMain(cwd, defaultBranch); err != nil {
-			return fmt.Errorf("fetching %s: %w", defaultBranch, err)
+		logging.Infof("\nFetching latest %s...", targetBranch)
+		if err := merge.FetchMain(cwd, targetBranch); err != nil {
+			return fmt.Errorf("fetching %s: %w", targetBranch, err)
 		}
 
 		// Check if we're behind main
-		behind, err := merge.CommitsBehind(cwd, defaultBranch)
+		behind, err := merge.CommitsBehind(cwd, targetBranch)
 		if err != nil {
-			fmt.Printf("Warning: could not check commits behind: %v\n", err)
+			logging.Warnf("could not check commits behind: %v", err)
 		} else if behind > 0 {
-			fmt.Printf("Branch is %d commits behind %s. Rebasing...\n", behind, defaultBranch)
+			logging.Infof("Branch is %d commits behind %s. Rebasing...", behind, targetBranch)
 
-			result, err := merge.RebaseOnMain(cwd, defaultBranch)
+			result, err := merge.RebaseOnMain(cwd, targetBranch)
 			if err != nil {
 				return fmt.Errorf("rebase failed: %w", err)
 			}
@@ -1274,57 +1561,123 @@ func cmdDone(cfg *config.Config, flags doneFlags) error {
 
 			fmt.Println("Rebase successful.")
 		} else {
-			fmt.Printf("Branch is up-to-date with %s.\n", defaultBranch)
+			fmt.Printf("Branch is up-to-date with %s.\n", targetBranch)
 		}
 	} else if flags.noRebase {
 		fmt.Println("\nSkipping rebase (--no-rebase flag)")
 	}
 
-	var prURL string
+	// Monorepo projects check the changes against the bead's scopes
+	if err := checkBeadScope(proj, sess.Bead, cwd, targetBranch, flags.allowOutOfScope); err != nil {
+		return err
+	}
+	// Projects requiring signed commits refuse unsigned ones before the push
+	if err := checkCommitSignatures(proj, cwd, targetBranch); err != nil {
+		return err
+	}
+
+	if existingPR != nil {
+		return amendPR(cfg, state, sessionName, sess, existingPR, branch, targetBranch)
+	}
+
+	// Capture the session summary while the branch is still ahead of the target
+	var sessionSummary *events.Summary
+	if !flags.noSummary {
+		sessionSummary = captureSessionSummary(sess, targetBranch, prTitle, true)
+	}
+
+	// A report from 'wt signal ready --report' supplies the PR description
+	// and follow-up beads
+	prBody := applyWorkerReport(cfg, sess)
+	var todos []report.TODO
+	if !flags.noFollowUps {
+		todos = scanTODOs(sess, targetBranch)
+	}
+
+	var prURL, mergeCommit string
 
 	switch mergeMode {
 	case "direct":
-		fmt.Println("\nMerging directly to", defaultBranch, "...")
-		if err := merge.DirectMerge(cwd, branch, defaultBranch); err != nil {
+		fmt.Println("\nMerging directly to", targetBranch, "...")
+		var err error
+		mergeCommit, err = merge.DirectMerge(cwd, branch, targetBranch)
+		if err != nil {
 			return fmt.Errorf("direct merge failed: %w", err)
 		}
 		fmt.Println("Merged and pushed successfully.")
+		verifyAfterMerge(cfg, proj, sessionName, sess, mergeCommit)
 
 	case "pr-auto":
-		fmt.Println("\nCreating PR with auto-merge...")
+		logging.Infof("\nCreating PR with auto-merge...")
 		var err error
-		prURL, err = merge.CreatePR(cwd, branch, defaultBranch, prTitle)
+		if prBody == "" {
+			prURL, err = merge.CreatePR(cwd, branch, targetBranch, prTitle)
+		} else {
+			prURL, err = merge.CreatePRWithBody(cwd, branch, targetBranch, prTitle, prBody)
+		}
 		if err != nil {
 			return fmt.Errorf("creating PR: %w", err)
 		}
 		fmt.Printf("PR created: %s\n", prURL)
+		markDraftReady(cfg, sess.Bead, cwd)
+		if sess.StackBranch != "" {
+			recordStackPR(cfg, sess.Bead, prURL)
+		}
+		requestCodeOwnerReviews(proj, cwd, targetBranch, prURL)
 
 		if err := merge.EnableAutoMerge(cwd, prURL); err != nil {
-			fmt.Printf("Warning: could not enable auto-merge: %v\n", err)
+			logging.Warnf("could not enable auto-merge: %v", err)
 			fmt.Println("PR created but you'll need to merge manually.")
+			if wait {
+				return fmt.Errorf("auto-merge is not enabled; session '%s' is left in place", sessionName)
+			}
+		} else if wait {
+			mergeCommit, err = waitForMerge(waitTimeout, mergePollInterval, func() (*merge.PRStatus, error) {
+				return merge.GetPRStatus(cwd, prURL)
+			})
+			if err != nil {
+				return fmt.Errorf("%w\nSession '%s' is left in place; fix the PR and run 'wt done --wait' again", err, sessionName)
+			}
+			fmt.Println("PR merged.")
+			verifyAfterMerge(cfg, proj, sessionName, sess, mergeCommit)
 		} else {
 			fmt.Println("Auto-merge enabled. PR will merge when checks pass.")
+			recordVerifyPR(cfg, proj, sessionName, sess, branch, prURL)
 		}
 
 	case "pr-review":
-		fmt.Println("\nCreating PR for review...")
+		logging.Infof("\nCreating PR for review...")
+		asDraft := flags.draft || proj.DraftPRs
 		var err error
-		prURL, err = merge.CreatePR(cwd, branch, defaultBranch, prTitle)
+		prURL, err = createReviewPR(cfg, proj, sess, branch, targetBranch, prTitle, prBody, asDraft)
 		if err != nil {
 			return fmt.Errorf("creating PR: %w", err)
 		}
 		fmt.Printf("PR created: %s\n", prURL)
+		if asDraft {
+			fmt.Println("Opened as a draft; it is marked ready for review once checks pass (wt pr sync).")
+		}
+		if sess.StackBranch != "" {
+			recordStackPR(cfg, sess.Bead, prURL)
+		}
+		requestCodeOwnerReviews(proj, cwd, targetBranch, prURL)
 		fmt.Println("Waiting for review.")
+		if waitingOn != "" {
+			fmt.Printf("Merge it after %s.\n", waitingOn)
+		}
 
 	default:
 		return fmt.Errorf("unknown merge mode: %s", mergeMode)
 	}
 
+	offerTODOFollowUps(sess, todos, prURL, flags.followUps)
+
 	// Close the bead
-	fmt.Println("\nClosing bead...")
-	if err := bead.Close(sess.Bead); err != nil {
-		fmt.Printf("Warning: could not close bead: %v\n", err)
+	if err := report.Remove(cfg, sess.Bead); err != nil {
+		logging.Warnf("%v", err)
 	}
+	closeBeadAtDone(cfg, proj, sessionName, sess, branch, prURL, mergeMode == "direct" || mergeCommit != "")
+	postSessionSummary(proj, sessionName, sess, sessionSummary)
 
 	// Check for batch mode marker (wt auto creates this to signal we shouldn't clean up)
 	batchMarkerPath := filepath.Join(sess.Worktree, ".wt-batch-mode")
@@ -1334,36 +1687,36 @@ func cmdDone(cfg *config.Config, flags doneFlags) error {
 		fmt.Println("\nBatch mode detected - keeping session alive for next bead.")
 	}
 
-	if !isBatchMode {
+	if !isBatchMode && sess.EnvDownAt == "" {
 		// Run teardown hooks if configured
 		if proj.TestEnv != nil && proj.TestEnv.Teardown != "" {
-			fmt.Println("Running test environment teardown...")
-			if err := testenv.RunTeardown(proj, sess.Worktree, sess.PortOffset); err != nil {
-				fmt.Printf("Warning: teardown failed: %v\n", err)
+			logging.Infof("Running test environment teardown...")
+			if err := testenv.RunTeardown(proj, sessionVars(sessionName, sess)); err != nil {
+				logging.Warnf("teardown failed: %v", err)
 			}
 		}
 
 		if proj.Hooks != nil && len(proj.Hooks.OnClose) > 0 {
-			fmt.Println("Running on_close hooks...")
+			logging.Infof("Running on_close hooks...")
 			portEnv := ""
 			if proj.TestEnv != nil {
 				portEnv = proj.TestEnv.PortEnv
 			}
-			if err := testenv.RunOnCloseHooks(proj, sess.Worktree, sess.PortOffset, portEnv); err != nil {
-				fmt.Printf("Warning: on_close hook failed: %v\n", err)
+			if err := testenv.RunOnCloseHooks(proj, sessionVars(sessionName, sess), portEnv); err != nil {
+				logging.Warnf("on_close hook failed: %v", err)
 			}
 		}
 
 		// Kill tmux session
-		fmt.Println("Terminating tmux session...")
+		logging.Infof("Terminating tmux session...")
 		if err := tmux.Kill(sessionName); err != nil {
-			fmt.Printf("Warning: %v\n", err)
+			logging.Warnf("%v", err)
 		}
 
 		// Remove worktree
 		fmt.Printf("Removing worktree: %s\n", sess.Worktree)
 		if err := worktree.Remove(sess.Worktree); err != nil {
-			fmt.Printf("Warning: %v\n", err)
+			logging.Warnf("%v", err)
 		}
 
 		// Remove from state
@@ -1376,14 +1729,33 @@ func cmdDone(cfg *config.Config, flags doneFlags) error {
 	// Log session end event
 	eventLogger := events.NewLogger(cfg)
 	claudeSession := getClaudeSessionID(sess.Worktree)
-	eventLogger.LogSessionEnd(sessionName, sess.Bead, sess.Project, claudeSession, mergeMode, prURL)
+	if !flags.noSummary {
+		sessionSummary = withLastMessage(sessionSummary, sess.Worktree, claudeSession)
+	}
+	eventLogger.LogSessionEndMerged(sessionName, sess.Bead, sess.Project, claudeSession, mergeMode, prURL, mergeCommit, sessionSummary)
+	postBeadActivity(cfg, sess, &events.Event{
+		Type:        events.EventSessionEnd,
+		Session:     sessionName,
+		MergeMode:   mergeMode,
+		PRURL:       prURL,
+		MergeCommit: mergeCommit,
+		Summary:     sessionSummary,
+	})
+
+	// A direct merge may unblock PRs stacked on this bead
+	if mergeMode == "direct" {
+		syncStackedPRs(cfg)
+	}
+	syncDraftPRs(cfg)
+	syncVerifyPRs(cfg)
+	syncPendingCloses(cfg)
 
 	fmt.Println("\nDone!")
 	return nil
 }
 
 // cmdAbandon abandons the current session without merging
-func cmdAbandon(cfg *config.Config) error {
+func cmdAbandon(cfg *config.Config, flags killFlags) error {
 	cwd, err := os.Getwd()
 	if err != nil {
 		return fmt.Errorf("getting current directory: %w", err)
@@ -1409,41 +1781,45 @@ func cmdAbandon(cfg *config.Config) error {
 		return fmt.Errorf("not in a wt session. Run this from inside a session worktree")
 	}
 
-	fmt.Printf("Abandoning session '%s'...\n", sessionName)
+	if err := checkUnsavedWork(cfg, sessionName, sess, flags); err != nil {
+		return err
+	}
+
+	logging.Infof("Abandoning session '%s'...", sessionName)
 	fmt.Printf("  Bead: %s (will remain open)\n", sess.Bead)
 
-	// Run teardown hooks if configured
+	// Run teardown hooks if configured (wt teardown-all may have run them)
 	mgr := project.NewManager(cfg)
-	if proj, _ := mgr.Get(sess.Project); proj != nil {
+	if proj, _ := mgr.Get(sess.Project); proj != nil && sess.EnvDownAt == "" {
 		if proj.TestEnv != nil && proj.TestEnv.Teardown != "" {
-			fmt.Println("  Running test environment teardown...")
-			if err := testenv.RunTeardown(proj, sess.Worktree, sess.PortOffset); err != nil {
-				fmt.Printf("  Warning: teardown failed: %v\n", err)
+			logging.Infof("  Running test environment teardown...")
+			if err := testenv.RunTeardown(proj, sessionVars(sessionName, sess)); err != nil {
+				logging.Warnf("teardown failed: %v", err)
 			}
 		}
 
 		if proj.Hooks != nil && len(proj.Hooks.OnClose) > 0 {
-			fmt.Println("  Running on_close hooks...")
+			logging.Infof("  Running on_close hooks...")
 			portEnv := ""
 			if proj.TestEnv != nil {
 				portEnv = proj.TestEnv.PortEnv
 			}
-			if err := testenv.RunOnCloseHooks(proj, sess.Worktree, sess.PortOffset, portEnv); err != nil {
-				fmt.Printf("  Warning: on_close hook failed: %v\n", err)
+			if err := testenv.RunOnCloseHooks(proj, sessionVars(sessionName, sess), portEnv); err != nil {
+				logging.Warnf("on_close hook failed: %v", err)
 			}
 		}
 	}
 
 	// Kill tmux session
-	fmt.Println("  Terminating tmux session...")
+	logging.Infof("  Terminating tmux session...")
 	if err := tmux.Kill(sessionName); err != nil {
-		fmt.Printf("  Warning: %v\n", err)
+		logging.Warnf("%v", err)
 	}
 
 	// Remove worktree
 	fmt.Printf("  Removing worktree: %s\n", sess.Worktree)
 	if err := worktree.Remove(sess.Worktree); err != nil {
-		fmt.Printf("  Warning: %v\n", err)
+		logging.Warnf("%v", err)
 	}
 
 	// Remove from state
@@ -1456,226 +1832,6 @@ func cmdAbandon(cfg *config.Config) error {
 	return nil
 }
 
-// StatusJSON is the JSON output format for session status
-type StatusJSON struct {
-	Session       string `json:"session"`
-	Bead          string `json:"bead"`
-	Title         string `json:"title"`
-	Project       string `json:"project"`
-	Branch        string `json:"branch"`
-	MergeMode     string `json:"merge_mode"`
-	Worktree      string `json:"worktree"`
-	Status        string `json:"status"`
-	StatusMessage string `json:"status_message,omitempty"`
-	HasChanges    bool   `json:"has_uncommitted_changes"`
-	PortOffset    int    `json:"port_offset,omitempty"`
-	CreatedAt     string `json:"created_at"`
-	LastActivity  string `json:"last_activity"`
-}
-
-// cmdStatus shows the status of the current session
-func cmdStatus(cfg *config.Config) error {
-	cwd, err := os.Getwd()
-	if err != nil {
-		return fmt.Errorf("getting current directory: %w", err)
-	}
-
-	state, err := session.LoadState(cfg)
-	if err != nil {
-		return err
-	}
-
-	// Find session that matches current directory
-	var sessionName string
-	var sess *session.Session
-	for name, s := range state.Sessions {
-		if s.Worktree == cwd {
-			sessionName = name
-			sess = s
-			break
-		}
-	}
-
-	if sess == nil {
-		return fmt.Errorf("not in a wt session. Run this from inside a session worktree")
-	}
-
-	// Get bead info
-	beadInfo, err := bead.Show(sess.Bead)
-	if err != nil {
-		return fmt.Errorf("getting bead info: %w", err)
-	}
-
-	// Get git status
-	hasChanges, _ := merge.HasUncommittedChanges(cwd)
-	branch, _ := merge.GetCurrentBranch(cwd)
-
-	// Get project info
-	mgr := project.NewManager(cfg)
-	proj, _ := mgr.Get(sess.Project)
-
-	mergeMode := "pr-review"
-	if proj != nil && proj.MergeMode != "" {
-		mergeMode = proj.MergeMode
-	}
-
-	status := sess.Status
-	if status == "" {
-		status = "working"
-	}
-
-	// JSON output
-	if outputJSON {
-		result := StatusJSON{
-			Session:       sessionName,
-			Bead:          sess.Bead,
-			Title:         beadInfo.Title,
-			Project:       sess.Project,
-			Branch:        branch,
-			MergeMode:     mergeMode,
-			Worktree:      sess.Worktree,
-			Status:        status,
-			StatusMessage: sess.StatusMessage,
-			HasChanges:    hasChanges,
-			PortOffset:    sess.PortOffset,
-			CreatedAt:     sess.CreatedAt,
-			LastActivity:  sess.LastActivity,
-		}
-		printJSON(result)
-		return nil
-	}
-
-	fmt.Println("┌─ Session Status ─────────────────────────────────────────────────────┐")
-	fmt.Println("│                                                                       │")
-	fmt.Printf("│  Session:    %-55s │\n", sessionName)
-	fmt.Printf("│  Bead:       %-55s │\n", sess.Bead)
-	fmt.Printf("│  Title:      %-55s │\n", truncate(beadInfo.Title, 55))
-	fmt.Printf("│  Project:    %-55s │\n", sess.Project)
-	fmt.Printf("│  Branch:     %-55s │\n", branch)
-	fmt.Printf("│  Merge mode: %-55s │\n", mergeMode)
-	fmt.Println("│                                                                       │")
-
-	if hasChanges {
-		fmt.Println("│  Git:        ⚠ Uncommitted changes                                    │")
-	} else {
-		fmt.Println("│  Git:        ✓ Clean                                                  │")
-	}
-
-	if sess.PortOffset > 0 {
-		portInfo := fmt.Sprintf("Port offset: %d", sess.PortOffset)
-		fmt.Printf("│  %-67s │\n", portInfo)
-	}
-
-	fmt.Println("│                                                                       │")
-	fmt.Println("└───────────────────────────────────────────────────────────────────────┘")
-	fmt.Println("\nCommands: wt done | wt abandon | wt signal <status>")
-
-	return nil
-}
-
-// cmdSignal updates the session status with an optional message
-func cmdSignal(cfg *config.Config, args []string) error {
-	status := args[0]
-
-	// Validate status
-	validStatuses := map[string]bool{
-		"working":   true,
-		"ready":     true,
-		"blocked":   true,
-		"error":     true,
-		"idle":      true,
-		"bead-done": true,
-	}
-	if !validStatuses[status] {
-		return fmt.Errorf("invalid status: %s\nvalid statuses: working, ready, blocked, error, idle, bead-done", status)
-	}
-
-	// Get optional message
-	message := ""
-	if len(args) > 1 {
-		message = strings.Join(args[1:], " ")
-	}
-
-	// Find current session
-	cwd, err := os.Getwd()
-	if err != nil {
-		return fmt.Errorf("getting current directory: %w", err)
-	}
-
-	state, err := session.LoadState(cfg)
-	if err != nil {
-		return err
-	}
-
-	var sessionName string
-	var sess *session.Session
-	for name, s := range state.Sessions {
-		if s.Worktree == cwd {
-			sessionName = name
-			sess = s
-			break
-		}
-	}
-
-	if sess == nil {
-		return fmt.Errorf("not in a wt session. Run this from inside a session worktree")
-	}
-
-	// Special handling for bead-done in auto mode
-	if status == "bead-done" {
-		epicState, inAutoMode := auto.IsInAutoMode(cfg, cwd)
-		if inAutoMode {
-			fmt.Printf("✓ Bead complete in auto mode. Transitioning to next bead...\n")
-			if err := auto.HandleBeadDone(cfg, epicState, message); err != nil {
-				return fmt.Errorf("handling bead-done in auto mode: %w", err)
-			}
-			// Don't update session status to "bead-done" - let auto mode handle it
-			return nil
-		}
-		// Not in auto mode - just update status normally
-		fmt.Println("Note: Not in auto mode. Use 'wt done' to complete the session.")
-	}
-
-	// Update status
-	sess.Status = status
-	sess.StatusMessage = message
-	sess.UpdateActivity()
-
-	if err := state.Save(); err != nil {
-		return fmt.Errorf("saving state: %w", err)
-	}
-
-	// Display confirmation
-	statusIcon := getStatusIcon(status)
-	fmt.Printf("%s Session '%s' status: %s\n", statusIcon, sessionName, status)
-	if message != "" {
-		fmt.Printf("   Message: %s\n", message)
-	}
-
-	return nil
-}
-
-func cmdSwitch(cfg *config.Config, nameOrBead string) error {
-	state, err := session.LoadState(cfg)
-	if err != nil {
-		return err
-	}
-
-	// First try exact session name match
-	if _, exists := state.Sessions[nameOrBead]; exists {
-		return tmux.Attach(nameOrBead)
-	}
-
-	// Try bead ID match
-	for name, sess := range state.Sessions {
-		if sess.Bead == nameOrBead {
-			return tmux.Attach(name)
-		}
-	}
-
-	return fmt.Errorf("no session found for '%s'", nameOrBead)
-}
-
 // getClaudeSessionID gets the Claude session ID for seance resumption
 func getClaudeSessionID(worktreePath string) string {
 	// First, try reading from .wt/session_id in the worktree
@@ -1736,8 +1892,9 @@ func getClaudeSessionID(worktreePath string) string {
 	return ""
 }
 
-// buildInitialPrompt creates the prompt to send to Claude when starting work on a bead
-func buildInitialPrompt(beadID, title, description, sessionName string, proj *project.Project) string {
+// buildInitialPrompt creates the prompt to send to Claude when starting work on a bead.
+// vars are the session's test env values, for the Test Environment section.
+func buildInitialPrompt(beadID, title, description, sessionName string, proj *project.Project, vars project.CommandVars) string {
 	var sb strings.Builder
 
 	// Main task with bead ID and title
@@ -1749,6 +1906,15 @@ func buildInitialPrompt(beadID, title, description, sessionName string, proj *pr
 		sb.WriteString("\n\n")
 	}
 
+	// Project conventions from context_files
+	sb.WriteString(proj.ContextPrompt(""))
+
+	// Orchestration rules from the project's WORKERS.md
+	sb.WriteString(proj.GuidelinesPrompt())
+
+	// Ports, health check and test command of the session's test env
+	sb.WriteString(testenv.Prompt(proj, vars))
+
 	// Workflow instructions
 	sb.WriteString("Workflow:\n")
 	sb.WriteString("1. Implement the task\n")
@@ -1757,7 +1923,7 @@ func buildInitialPrompt(beadID, title, description, sessionName string, proj *pr
 	// Add test instructions if configured
 	stepNum := 3
 	if proj != nil && proj.TestEnv != nil {
-		sb.WriteString(fmt.Sprintf("%d. Run tests and fix any failures\n", stepNum))
+		sb.WriteString(fmt.Sprintf("%d. %s\n", stepNum, testenv.TestStep(proj, vars)))
 		stepNum++
 	}
 
@@ -1814,6 +1980,9 @@ func buildInitialPrompt(beadID, title, description, sessionName string, proj *pr
 
 // getStatusIcon returns an icon for the given status
 func getStatusIcon(status string) string {
+	if outputPlain {
+		return plainStatusIcon(status)
+	}
 	switch status {
 	case "ready":
 		return "✅"
@@ -1825,6 +1994,8 @@ func getStatusIcon(status string) string {
 		return "🔄"
 	case "idle":
 		return "💤"
+	case "paused":
+		return "⏸️"
 	default:
 		return "•"
 	}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/badri/wt/internal/tmux"
+)
+
+// claimSessionName checks that a new session's name isn't taken by a tmux
+// session already, e.g. one started outside wt that would otherwise be
+// mistaken for the worker. A name the user gave is refused; a generated
+// one gets a numeric suffix.
+func claimSessionName(name string, explicit bool) (string, error) {
+	if !tmux.SessionExists(name) {
+		return name, nil
+	}
+	if explicit {
+		if tmux.IsWTSession(name) {
+			return "", fmt.Errorf("a wt session named '%s' is already running", name)
+		}
+		return "", fmt.Errorf("tmux session '%s' already exists and was not started by wt; pick another --name", name)
+	}
+	free := suffixedName(name, tmux.SessionExists)
+	fmt.Printf("tmux session '%s' already exists; using '%s'\n", name, free)
+	return free, nil
+}
+
+// suffixedName returns the first of name-2, name-3, ... that isn't taken
+func suffixedName(name string, taken func(string) bool) string {
+	for i := 2; ; i++ {
+		candidate := fmt.Sprintf("%s-%d", name, i)
+		if !taken(candidate) {
+			return candidate
+		}
+	}
+}
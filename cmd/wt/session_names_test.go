@@ -0,0 +1,10 @@
+package main
+
+import "testing"
+
+func TestSuffixedName(t *testing.T) {
+	taken := map[string]bool{"app-toast": true, "app-toast-2": true}
+	if got := suffixedName("app-toast", func(n string) bool { return taken[n] }); got != "app-toast-3" {
+		t.Errorf("suffixedName() = %q, want app-toast-3", got)
+	}
+}
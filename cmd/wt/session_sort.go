@@ -0,0 +1,156 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Session orders of wt list and wt watch --sort
+const (
+	sortCreated  = "created"  // oldest first (default)
+	sortName     = "name"     // alphabetical
+	sortProject  = "project"  // by project, then oldest first
+	sortStatus   = "status"   // sessions needing attention first
+	sortDuration = "duration" // longest running first
+	sortIdle     = "idle"     // longest idle first
+)
+
+var sortKeys = []string{sortCreated, sortName, sortProject, sortStatus, sortDuration, sortIdle}
+
+// statusRank puts the statuses that need the hub's attention first; other
+// statuses follow alphabetically
+var statusRank = map[string]int{"error": 0, "blocked": 1, "ready": 2, "idle": 3, "paused": 4, "working": 5}
+
+// sessionOrder is the --sort and --reverse of wt list and wt watch
+type sessionOrder struct {
+	key     string
+	reverse bool
+}
+
+// parseSortKey checks a --sort value
+func parseSortKey(value string) (string, error) {
+	if slices.Contains(sortKeys, value) {
+		return value, nil
+	}
+	return "", fmt.Errorf("invalid --sort %q%s (use %s)", value, didYouMean(value, sortKeys), strings.Join(sortKeys, ", "))
+}
+
+// sortFields are what sessions are ordered by
+type sortFields struct {
+	name     string
+	project  string
+	status   string
+	created  time.Time
+	duration time.Duration
+	idle     int // minutes
+}
+
+// compare orders two sessions by the order's key. Ties fall back to
+// creation time and then name, so positions don't change between refreshes
+// unless the sorted value does.
+func (o sessionOrder) compare(a, b sortFields) int {
+	c := 0
+	switch o.key {
+	case sortName:
+		c = strings.Compare(a.name, b.name)
+	case sortProject:
+		c = strings.Compare(a.project, b.project)
+	case sortStatus:
+		c = cmp.Compare(rankStatus(a.status), rankStatus(b.status))
+		if c == 0 {
+			c = strings.Compare(a.status, b.status)
+		}
+	case sortDuration:
+		c = cmp.Compare(b.duration, a.duration)
+	case sortIdle:
+		c = cmp.Compare(b.idle, a.idle)
+	}
+	if c == 0 {
+		c = a.created.Compare(b.created)
+	}
+	if c == 0 {
+		c = strings.Compare(a.name, b.name)
+	}
+	if o.reverse {
+		return -c
+	}
+	return c
+}
+
+func rankStatus(status string) int {
+	if rank, ok := statusRank[status]; ok {
+		return rank
+	}
+	return len(statusRank)
+}
+
+// sortListEntries orders wt list entries, active sessions before past ones
+func sortListEntries(entries []ListSessionEntry, order sessionOrder) {
+	now := time.Now()
+	slices.SortStableFunc(entries, func(a, b ListSessionEntry) int {
+		if a.IsPast != b.IsPast {
+			if a.IsPast {
+				return 1
+			}
+			return -1
+		}
+		return order.compare(a.sortFields(now), b.sortFields(now))
+	})
+}
+
+func (e ListSessionEntry) sortFields(now time.Time) sortFields {
+	f := sortFields{name: e.Name, project: e.Project, status: e.Status, idle: e.Idle}
+	if t, err := time.Parse(time.RFC3339, e.CreatedAt); err == nil {
+		f.created = t
+		end := now
+		if ended, err := time.Parse(time.RFC3339, e.EndedAt); err == nil {
+			end = ended
+		}
+		f.duration = end.Sub(t)
+	}
+	return f
+}
+
+// sortWatchItems orders wt watch sessions: by project first in the wide
+// layout, sessions of an epic together (by name) after those outside
+// epics, and the rest by the --sort order
+func sortWatchItems(items []sessionItem, opts watchOptions) {
+	now := time.Now()
+	slices.SortStableFunc(items, func(a, b sessionItem) int {
+		if opts.layout == watchLayoutWide && a.project != b.project {
+			return strings.Compare(a.project, b.project)
+		}
+		if a.epicID() != b.epicID() {
+			return strings.Compare(a.epicID(), b.epicID())
+		}
+		if a.epicID() != "" {
+			return strings.Compare(a.name, b.name)
+		}
+		return opts.order.compare(a.sortFields(now), b.sortFields(now))
+	})
+}
+
+func (sess sessionItem) sortFields(now time.Time) sortFields {
+	f := sortFields{name: sess.name, project: sess.project, status: sess.status, created: sess.created, idle: sess.idle}
+	if !sess.created.IsZero() {
+		f.duration = now.Sub(sess.created)
+	}
+	return f
+}
+
+// followCursor keeps the watch selection on the same session when a
+// refresh moves it, e.g. with --sort idle
+func followCursor(old, updated []sessionItem, cursor int) int {
+	if cursor < 0 || cursor >= len(old) {
+		return cursor
+	}
+	for i, sess := range updated {
+		if sess.name == old[cursor].name {
+			return i
+		}
+	}
+	return cursor
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestSortListEntries(t *testing.T) {
+	now := time.Now()
+	at := func(minutesAgo int) string {
+		return now.Add(-time.Duration(minutesAgo) * time.Minute).Format(time.RFC3339)
+	}
+	entries := func() []ListSessionEntry {
+		return []ListSessionEntry{
+			{Name: "toast", Project: "web", Status: "working", CreatedAt: at(30), Idle: 1},
+			{Name: "old", Project: "api", Status: "completed", CreatedAt: at(500), EndedAt: at(400), IsPast: true},
+			{Name: "ash", Project: "web", Status: "ready", CreatedAt: at(90), Idle: 20},
+			{Name: "crane", Project: "api", Status: "blocked", CreatedAt: at(10), Idle: 5},
+		}
+	}
+	tests := []struct {
+		order sessionOrder
+		want  []string
+	}{
+		{sessionOrder{}, []string{"ash", "toast", "crane", "old"}},
+		{sessionOrder{key: sortCreated, reverse: true}, []string{"crane", "toast", "ash", "old"}},
+		{sessionOrder{key: sortName}, []string{"ash", "crane", "toast", "old"}},
+		{sessionOrder{key: sortProject}, []string{"crane", "ash", "toast", "old"}},
+		{sessionOrder{key: sortStatus}, []string{"crane", "ash", "toast", "old"}},
+		{sessionOrder{key: sortDuration}, []string{"ash", "toast", "crane", "old"}},
+		{sessionOrder{key: sortIdle}, []string{"ash", "crane", "toast", "old"}},
+	}
+	for _, tt := range tests {
+		got := entries()
+		sortListEntries(got, tt.order)
+		var names []string
+		for _, e := range got {
+			names = append(names, e.Name)
+		}
+		if !slices.Equal(names, tt.want) {
+			t.Errorf("sortListEntries(%+v) = %v, want %v", tt.order, names, tt.want)
+		}
+	}
+}
+
+func TestSortWatchItems(t *testing.T) {
+	epic := &epicSummary{ID: "app-e1"}
+	now := time.Now()
+	items := []sessionItem{
+		{name: "zeta", project: "web", created: now.Add(-time.Hour)},
+		{name: "toast", project: "api", epic: epic, created: now.Add(-3 * time.Hour)},
+		{name: "ash", project: "api", epic: epic, created: now},
+		{name: "beta", project: "api", created: now.Add(-2 * time.Hour)},
+	}
+	sortWatchItems(items, watchOptions{})
+	var names []string
+	for _, it := range items {
+		names = append(names, it.name)
+	}
+	if want := []string{"beta", "zeta", "ash", "toast"}; !slices.Equal(names, want) {
+		t.Errorf("sortWatchItems() = %v, want %v", names, want)
+	}
+
+	sortWatchItems(items, watchOptions{layout: watchLayoutWide, order: sessionOrder{key: sortName, reverse: true}})
+	names = nil
+	for _, it := range items {
+		names = append(names, it.name)
+	}
+	if want := []string{"beta", "ash", "toast", "zeta"}; !slices.Equal(names, want) {
+		t.Errorf("sortWatchItems(wide) = %v, want %v", names, want)
+	}
+}
+
+func TestParseSortFlags(t *testing.T) {
+	if _, err := parseSortKey("idle"); err != nil {
+		t.Errorf("parseSortKey(idle) error: %v", err)
+	}
+	if _, err := parseSortKey("age"); err == nil {
+		t.Error("parseSortKey(age) should fail")
+	}
+
+	flags := parseListFlags([]string{"--sort", "status", "--reverse"})
+	if flags.order != (sessionOrder{key: sortStatus, reverse: true}) {
+		t.Errorf("parseListFlags() order = %+v", flags.order)
+	}
+	opts, err := parseWatchFlags([]string{"--sort", "idle", "--reverse"})
+	if err != nil || opts.order != (sessionOrder{key: sortIdle, reverse: true}) {
+		t.Errorf("parseWatchFlags() order = %+v, %v", opts.order, err)
+	}
+	for _, args := range [][]string{{"--sort"}, {"--sort", "age"}} {
+		if _, err := parseWatchFlags(args); err == nil {
+			t.Errorf("parseWatchFlags(%v) should fail", args)
+		}
+	}
+}
+
+func TestFollowCursor(t *testing.T) {
+	old := []sessionItem{{name: "ash"}, {name: "toast"}, {name: "crane"}}
+	updated := []sessionItem{{name: "toast"}, {name: "crane"}, {name: "ash"}}
+	if got := followCursor(old, updated, 1); got != 0 {
+		t.Errorf("followCursor() = %d, want 0 (toast moved up)", got)
+	}
+	// ash is gone: the cursor keeps its position
+	if got := followCursor(old, updated[:2], 0); got != 0 {
+		t.Errorf("followCursor() for a gone session = %d, want 0", got)
+	}
+}
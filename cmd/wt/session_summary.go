@@ -0,0 +1,42 @@
+package main
+
+import (
+	"github.com/badri/wt/internal/bead"
+	"github.com/badri/wt/internal/events"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/project"
+	"github.com/badri/wt/internal/session"
+	"github.com/badri/wt/internal/summary"
+)
+
+// captureSessionSummary collects the end-of-session summary for a worker,
+// with a Claude-written paragraph if generate is set.
+// Must run before the worktree is removed and before a direct merge moves the base branch.
+func captureSessionSummary(sess *session.Session, baseBranch, title string, generate bool) *events.Summary {
+	logging.Infof("Capturing session summary...")
+	return summary.Capture(sess.Worktree, baseBranch, title, generate)
+}
+
+// withLastMessage adds the worker's last message from its Claude transcript
+// to a session's end summary, so wt seance can show where it stopped
+func withLastMessage(s *events.Summary, worktreePath, claudeSession string) *events.Summary {
+	text := summary.LastMessage(worktreePath, claudeSession)
+	if text == "" {
+		return s
+	}
+	if s == nil {
+		s = &events.Summary{}
+	}
+	s.LastMessage = text
+	return s
+}
+
+// postSessionSummary posts the summary as a bead comment when the project opts in.
+func postSessionSummary(proj *project.Project, sessionName string, sess *session.Session, s *events.Summary) {
+	if s == nil || proj == nil || !proj.SummaryComment || sess.Bead == "" {
+		return
+	}
+	if err := bead.AddCommentInDir(sess.Bead, summary.FormatComment(sessionName, s), sess.BeadsDir); err != nil {
+		logging.Warnf("could not post summary to bead: %v", err)
+	}
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/project"
+	"github.com/badri/wt/internal/tmux"
+)
+
+// sessionWindows are the project's extra tmux windows for a session, with
+// the template variables of their commands expanded. A window whose command
+// doesn't expand is left out with a warning.
+func sessionWindows(proj *project.Project, vars project.CommandVars) []tmux.Window {
+	if proj == nil {
+		return nil
+	}
+	var windows []tmux.Window
+	for _, w := range proj.Windows {
+		command, err := project.ExpandCommand(w.Command, vars)
+		if err != nil {
+			logging.Warnf("skipping window '%s': %v", w.Name, err)
+			continue
+		}
+		windows = append(windows, tmux.Window{Name: w.Name, Command: command})
+	}
+	return windows
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/badri/wt/internal/project"
+	"github.com/badri/wt/internal/tmux"
+)
+
+func TestSessionWindows(t *testing.T) {
+	if got := sessionWindows(nil, project.CommandVars{}); got != nil {
+		t.Errorf("sessionWindows(nil) = %v, want nil", got)
+	}
+
+	proj := &project.Project{Windows: []project.Window{
+		{Name: "tests", Command: "npm test -- --watch"},
+		{Name: "logs", Command: "docker compose -p {{.Session}} logs -f"},
+		{Name: "broken", Command: "echo {{.Nope}}"},
+		{Name: "shell"},
+	}}
+	got := sessionWindows(proj, project.CommandVars{Session: "toast"})
+	want := []tmux.Window{
+		{Name: "tests", Command: "npm test -- --watch"},
+		{Name: "logs", Command: "docker compose -p toast logs -f"},
+		{Name: "shell"},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("sessionWindows() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,273 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/badri/wt/internal/bead"
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/events"
+	"github.com/badri/wt/internal/project"
+	"github.com/badri/wt/internal/session"
+)
+
+// showHistoryLimit is how many of a bead's events wt show lists
+const showHistoryLimit = 10
+
+// ShowJSON is the output of 'wt show --json'
+type ShowJSON struct {
+	Bead     *bead.Detail   `json:"bead"`
+	Project  string         `json:"project,omitempty"`
+	Sessions []ShowSession  `json:"sessions,omitempty"`
+	PRs      []string       `json:"prs,omitempty"`
+	History  []events.Event `json:"history,omitempty"`
+}
+
+// ShowSession is a live wt session working on the bead
+type ShowSession struct {
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Message  string `json:"message,omitempty"`
+	Worktree string `json:"worktree"`
+}
+
+// cmdShow shows a bead from whichever project owns it, together with what
+// wt knows about it: live sessions, past sessions and their PRs
+func cmdShow(cfg *config.Config, args []string) error {
+	if len(args) == 0 || hasHelpFlag(args) {
+		return cmdShowHelp()
+	}
+	var beadID string
+	raw := false
+	for _, arg := range args {
+		switch {
+		case arg == "--raw":
+			raw = true
+		case strings.HasPrefix(arg, "-"):
+			return fmt.Errorf("unknown flag: %s", arg)
+		default:
+			beadID = arg
+		}
+	}
+	if beadID == "" {
+		return cmdShowHelp()
+	}
+
+	// The owning project is found by prefix; without one, bd looks in the
+	// current directory
+	projName, projectDir := "", ""
+	if proj, err := project.NewManager(cfg).FindByBeadPrefix(beadID); err == nil {
+		projName, projectDir = proj.Name, proj.RepoPath()
+	}
+
+	if raw {
+		output, err := bead.CombinedOutput(projectDir, "show", beadID)
+		fmt.Print(string(output))
+		return err
+	}
+
+	detail, err := bead.ShowDetail(beadID, projectDir)
+	if err != nil {
+		if projName == "" {
+			return fmt.Errorf("%w (no registered project has the prefix of %s)", err, beadID)
+		}
+		return err
+	}
+
+	result := ShowJSON{Bead: detail, Project: projName}
+	if state, err := session.LoadState(cfg); err == nil {
+		result.Sessions = beadSessions(state, beadID)
+	}
+	if all, err := events.NewLogger(cfg).All(); err == nil {
+		history := beadHistory(all, beadID)
+		result.PRs = historyPRs(history)
+		if len(history) > showHistoryLimit {
+			history = history[len(history)-showHistoryLimit:]
+		}
+		result.History = history
+	}
+
+	if outputJSON {
+		printJSON(result)
+		return nil
+	}
+	printShow(&result, projectDir)
+	return nil
+}
+
+// beadSessions returns the live sessions working on a bead, by name
+func beadSessions(state *session.State, beadID string) []ShowSession {
+	var sessions []ShowSession
+	for name, sess := range state.Sessions {
+		if sess.Bead != beadID {
+			continue
+		}
+		status := sess.Status
+		if status == "" {
+			status = "working"
+		}
+		sessions = append(sessions, ShowSession{Name: name, Status: status, Message: sess.StatusMessage, Worktree: sess.Worktree})
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].Name < sessions[j].Name })
+	return sessions
+}
+
+// beadHistory returns a bead's events, oldest first
+func beadHistory(all []events.Event, beadID string) []events.Event {
+	var history []events.Event
+	for _, e := range all {
+		if e.Bead == beadID {
+			history = append(history, e)
+		}
+	}
+	return history
+}
+
+// historyPRs returns the distinct PR URLs in a bead's events
+func historyPRs(history []events.Event) []string {
+	var prs []string
+	for _, e := range history {
+		if e.PRURL != "" && !slices.Contains(prs, e.PRURL) {
+			prs = append(prs, e.PRURL)
+		}
+	}
+	return prs
+}
+
+func printShow(r *ShowJSON, projectDir string) {
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39"))
+	sectionStyle := lipgloss.NewStyle().Bold(true)
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("242"))
+	doneStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("46"))
+
+	d := r.Bead
+	fmt.Println(headerStyle.Render(fmt.Sprintf("%s  %s", d.ID, d.Title)))
+	fmt.Printf("  Status: %s   Priority: P%d   Type: %s\n", d.Status, d.Priority, d.IssueType)
+	if r.Project != "" {
+		fmt.Printf("  Project: %s %s\n", r.Project, dimStyle.Render("("+projectDir+")"))
+	}
+	if d.Assignee != "" {
+		fmt.Printf("  Assignee: %s\n", d.Assignee)
+	}
+	if len(d.Labels) > 0 {
+		fmt.Printf("  Labels: %s\n", strings.Join(d.Labels, ", "))
+	}
+	if d.UpdatedAt != "" {
+		fmt.Printf("  Updated: %s\n", formatShowTime(d.UpdatedAt))
+	}
+
+	for _, section := range []struct{ name, text string }{
+		{"Description", d.Description},
+		{"Design", d.Design},
+		{"Acceptance Criteria", d.AcceptanceCriteria},
+		{"Notes", d.Notes},
+	} {
+		if strings.TrimSpace(section.text) == "" {
+			continue
+		}
+		fmt.Println()
+		fmt.Println(sectionStyle.Render(section.name))
+		for _, line := range strings.Split(strings.TrimRight(section.text, "\n"), "\n") {
+			fmt.Printf("  %s\n", line)
+		}
+	}
+
+	related := func(title string, beads []bead.Related) {
+		if len(beads) == 0 {
+			return
+		}
+		fmt.Println()
+		fmt.Println(sectionStyle.Render(title))
+		for _, b := range beads {
+			icon := "○"
+			if b.Status == "closed" {
+				icon = doneStyle.Render("✓")
+			}
+			line := fmt.Sprintf("  %s %s  %s", icon, b.ID, b.Title)
+			extra := b.Status
+			if b.DependencyType != "" && b.DependencyType != "blocks" {
+				extra += ", " + b.DependencyType
+			}
+			fmt.Printf("%s %s\n", line, dimStyle.Render("["+extra+"]"))
+		}
+	}
+	related("Depends on", d.Dependencies)
+	related("Blocks", d.Dependents)
+
+	if len(r.Sessions) > 0 {
+		fmt.Println()
+		fmt.Println(sectionStyle.Render("Sessions"))
+		for _, s := range r.Sessions {
+			line := fmt.Sprintf("  %s %s  %s", getStatusIcon(s.Status), s.Name, s.Status)
+			if s.Message != "" {
+				line += ": " + s.Message
+			}
+			fmt.Printf("%s %s\n", line, dimStyle.Render(s.Worktree))
+		}
+	}
+
+	if len(r.PRs) > 0 {
+		fmt.Println()
+		fmt.Println(sectionStyle.Render("Pull requests"))
+		for _, pr := range r.PRs {
+			fmt.Printf("  %s\n", pr)
+		}
+	}
+
+	if len(r.History) > 0 {
+		fmt.Println()
+		fmt.Println(sectionStyle.Render("History"))
+		for _, e := range r.History {
+			line := fmt.Sprintf("  %s %s %s", formatShowTime(e.Time), getEventIcon(e.Type), e.Type)
+			if e.Session != "" {
+				line += "  " + e.Session
+			}
+			if e.MergeMode != "" {
+				line += " (" + e.MergeMode + ")"
+			}
+			fmt.Println(line)
+			if e.Note != "" {
+				fmt.Printf("      %s\n", dimStyle.Render(e.Note))
+			}
+		}
+	}
+}
+
+// formatShowTime renders an RFC 3339 time in local time, or as given
+func formatShowTime(s string) string {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return s
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+func cmdShowHelp() error {
+	help := `wt show - Show a bead from any project
+
+USAGE:
+    wt show <bead-id> [options]
+
+Finds the project that owns the bead by its prefix and runs bd show there,
+so there is no need to cd into the right repo. The view combines the bead's
+description, status, dependencies and dependents with what wt knows about
+it: live sessions, recent session history and pull requests.
+
+OPTIONS:
+    --raw               Print bd show's own output instead
+    --json              Output as JSON
+    -h, --help          Show this help
+
+EXAMPLES:
+    wt show myapp-abc
+    wt show myapp-abc --raw
+    wt show myapp-abc --json
+`
+	fmt.Print(help)
+	return nil
+}
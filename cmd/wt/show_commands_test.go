@@ -0,0 +1,44 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/badri/wt/internal/events"
+	"github.com/badri/wt/internal/session"
+)
+
+func TestBeadHistory(t *testing.T) {
+	all := []events.Event{
+		{Type: events.EventSessionStart, Session: "toast", Bead: "app-a"},
+		{Type: events.EventSessionStart, Session: "shadow", Bead: "app-b"},
+		{Type: events.EventSessionEnd, Session: "toast", Bead: "app-a", PRURL: "https://github.com/o/r/pull/1"},
+		{Type: events.EventSessionEnd, Session: "toast2", Bead: "app-a", PRURL: "https://github.com/o/r/pull/1"},
+		{Type: events.EventSessionEnd, Session: "toast3", Bead: "app-a", PRURL: "https://github.com/o/r/pull/2"},
+	}
+	history := beadHistory(all, "app-a")
+	if len(history) != 4 {
+		t.Fatalf("expected 4 events for app-a, got %d", len(history))
+	}
+	prs := historyPRs(history)
+	if len(prs) != 2 || prs[0] != "https://github.com/o/r/pull/1" || prs[1] != "https://github.com/o/r/pull/2" {
+		t.Errorf("historyPRs = %v", prs)
+	}
+}
+
+func TestBeadSessions(t *testing.T) {
+	state := &session.State{Sessions: map[string]*session.Session{
+		"toast":  {Bead: "app-a", Worktree: "/w/toast"},
+		"shadow": {Bead: "app-b", Worktree: "/w/shadow"},
+		"ember":  {Bead: "app-a", Worktree: "/w/ember", Status: "blocked", StatusMessage: "needs API key"},
+	}}
+	got := beadSessions(state, "app-a")
+	if len(got) != 2 {
+		t.Fatalf("expected 2 sessions, got %+v", got)
+	}
+	if got[0].Name != "ember" || got[0].Status != "blocked" || got[0].Message != "needs API key" {
+		t.Errorf("unexpected first session: %+v", got[0])
+	}
+	if got[1].Name != "toast" || got[1].Status != "working" {
+		t.Errorf("unexpected second session: %+v", got[1])
+	}
+}
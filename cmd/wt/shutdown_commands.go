@@ -0,0 +1,429 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/badri/wt/internal/bead"
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/githooks"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/merge"
+	"github.com/badri/wt/internal/project"
+	"github.com/badri/wt/internal/session"
+	"github.com/badri/wt/internal/snapshot"
+	"github.com/badri/wt/internal/testenv"
+	"github.com/badri/wt/internal/tmux"
+	"github.com/badri/wt/internal/worktree"
+)
+
+// shutdownPrompt asks a worker to save its work before the fleet goes down
+const shutdownPrompt = "wt is shutting down all sessions (e.g. for a reboot). Stop what you are doing and commit " +
+	"your work in progress now; a WIP commit is fine. Do not start anything new. The session will be " +
+	"restored later with 'wt resume-all'."
+
+// resumePrompt is sent to a restored worker whose agent conversation was resumed
+const resumePrompt = "This session was restored with 'wt resume-all' after a shutdown. " +
+	"Continue where you left off; check git status and git log for your latest work."
+
+// shutdownPollInterval is how often worktrees are checked while workers wrap up
+const shutdownPollInterval = 5 * time.Second
+
+type shutdownFlags struct {
+	timeout  time.Duration
+	noWrapup bool
+}
+
+func parseShutdownFlags(args []string) (shutdownFlags, error) {
+	flags := shutdownFlags{timeout: 2 * time.Minute}
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--timeout":
+			if i+1 >= len(args) {
+				return flags, fmt.Errorf("--timeout requires a duration (e.g. 5m)")
+			}
+			d, err := time.ParseDuration(args[i+1])
+			if err != nil {
+				return flags, fmt.Errorf("invalid --timeout %q: %w", args[i+1], err)
+			}
+			flags.timeout = d
+			i++
+		case "--no-wrapup":
+			flags.noWrapup = true
+		default:
+			return flags, fmt.Errorf("unknown flag: %s", args[i])
+		}
+	}
+	return flags, nil
+}
+
+// cmdShutdown asks every worker to wrap up, saves uncommitted work and the
+// session list, and stops all sessions so 'wt resume-all' can bring them back.
+func cmdShutdown(cfg *config.Config, args []string) error {
+	flags, err := parseShutdownFlags(args)
+	if err != nil {
+		return err
+	}
+
+	state, err := session.LoadState(cfg)
+	if err != nil {
+		return err
+	}
+	if len(state.Sessions) == 0 {
+		fmt.Println("No active sessions.")
+		return nil
+	}
+
+	// Kill the session we are running in last, so the rest of shutdown completes
+	current := tmux.CurrentSession()
+	names := shutdownOrder(state, current)
+
+	if !flags.noWrapup {
+		logging.Infof("Asking %d worker(s) to commit their work...", len(names))
+		for _, name := range names {
+			if name == current || !tmux.SessionExists(name) {
+				continue
+			}
+			if err := sessionAgent(state.Sessions[name]).SendPrompt(name, shutdownPrompt); err != nil {
+				logging.Warnf("%s: %v", name, err)
+			}
+		}
+		waitForCleanWorktrees(state, names, flags.timeout)
+	}
+
+	snap, err := snapshot.Load(cfg)
+	if err != nil {
+		return fmt.Errorf("loading snapshot: %w", err)
+	}
+	snap.CreatedAt = session.Now()
+
+	mgr := project.NewManager(cfg)
+	logging.Infof("\nSaving sessions...")
+	for _, name := range names {
+		sess := state.Sessions[name]
+		saved := &snapshot.Saved{
+			Session:       sess,
+			Head:          snapshot.Head(sess.Worktree),
+			ClaudeSession: getClaudeSessionID(sess.Worktree),
+		}
+
+		patchPath := snapshot.PatchPath(cfg, name)
+		if hasPatch, err := snapshot.WritePatch(sess.Worktree, patchPath); err != nil {
+			logging.Warnf("%s: could not save uncommitted changes: %v", name, err)
+		} else if hasPatch {
+			saved.Patch = patchPath
+		}
+
+		detail := "clean"
+		if saved.Patch != "" {
+			detail = "uncommitted changes saved to " + saved.Patch
+		}
+		if saved.ClaudeSession == "" && sessionAgent(sess).ResumeNeedsID {
+			detail += ", no Claude session to resume"
+		}
+		fmt.Printf("  %s: %s\n", name, detail)
+		snap.Sessions[name] = saved
+	}
+
+	// Persist before killing anything, in case we are killed with our session
+	if err := snap.Save(); err != nil {
+		return fmt.Errorf("saving snapshot: %w", err)
+	}
+	for _, name := range names {
+		delete(state.Sessions, name)
+	}
+	if err := state.Save(); err != nil {
+		return fmt.Errorf("saving state: %w", err)
+	}
+
+	logging.Infof("\nStopping sessions...")
+	for _, name := range names {
+		sess := snap.Sessions[name].Session
+		if proj, _ := mgr.Get(sess.Project); proj != nil && proj.TestEnv != nil && proj.TestEnv.Teardown != "" && sess.EnvDownAt == "" {
+			if err := testenv.RunTeardown(proj, sessionVars(name, sess)); err != nil {
+				logging.Warnf("%s: teardown failed: %v", name, err)
+			}
+		}
+		if err := tmux.Kill(name); err != nil {
+			logging.Warnf("%s: %v", name, err)
+		}
+	}
+
+	fmt.Printf("\n%d session(s) saved. Restore with: wt resume-all\n", len(names))
+	return nil
+}
+
+// shutdownOrder returns session names sorted, with the current session
+// last. Paused sessions are already stopped and stay as they are.
+func shutdownOrder(state *session.State, current string) []string {
+	names := make([]string, 0, len(state.Sessions))
+	for name, sess := range state.Sessions {
+		if !sess.IsPaused() {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if (names[i] == current) != (names[j] == current) {
+			return names[j] == current
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+// waitForCleanWorktrees waits until every worktree is committed or the timeout expires
+func waitForCleanWorktrees(state *session.State, names []string, timeout time.Duration) {
+	deadline := time.Now().Add(timeout)
+	for {
+		var dirty []string
+		for _, name := range names {
+			if changed, err := merge.HasUncommittedChanges(state.Sessions[name].Worktree); err == nil && changed {
+				dirty = append(dirty, name)
+			}
+		}
+		if len(dirty) == 0 {
+			fmt.Println("  All worktrees committed.")
+			return
+		}
+		if time.Now().After(deadline) {
+			fmt.Printf("  Timed out; uncommitted changes remain in: %s\n", strings.Join(dirty, ", "))
+			return
+		}
+		fmt.Printf("  Waiting on: %s\n", strings.Join(dirty, ", "))
+		time.Sleep(shutdownPollInterval)
+	}
+}
+
+// cmdResumeAll recreates the sessions saved by 'wt shutdown'
+func cmdResumeAll(cfg *config.Config, args []string) error {
+	snap, err := snapshot.Load(cfg)
+	if err != nil {
+		return fmt.Errorf("loading snapshot: %w", err)
+	}
+	if len(snap.Sessions) == 0 {
+		fmt.Println("Nothing to resume. Sessions are saved by 'wt shutdown'.")
+		return nil
+	}
+
+	state, err := session.LoadState(cfg)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(snap.Sessions))
+	for name := range snap.Sessions {
+		if len(args) == 0 || slices.Contains(args, name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if len(names) == 0 {
+		return fmt.Errorf("no saved session named %s", strings.Join(args, ", "))
+	}
+
+	mgr := project.NewManager(cfg)
+	var resumed []string
+	conversationResumed := make(map[string]bool)
+	for _, name := range names {
+		saved := snap.Sessions[name]
+		if err := resumeSession(cfg, mgr, state, name, saved); err != nil {
+			logging.Warnf("%s: %v", name, err)
+			continue
+		}
+		delete(snap.Sessions, name)
+		if err := snap.Save(); err != nil {
+			logging.Warnf("could not save snapshot: %v", err)
+		}
+		if saved.Patch != "" {
+			os.Remove(saved.Patch)
+		}
+		conversationResumed[name] = sessionAgent(saved.Session).CanResume(saved.ClaudeSession)
+		resumed = append(resumed, name)
+	}
+
+	// Prompt the restored workers once all sessions are up
+	for _, name := range resumed {
+		sess := state.Sessions[name]
+		ag := sessionAgent(sess)
+		if !ag.AcceptsPrompts {
+			continue
+		}
+		logging.Infof("Waiting for %s in '%s'...", ag.Name, name)
+		if err := ag.WaitReady(name, 60*time.Second); err != nil {
+			logging.Warnf("%v (sending prompt anyway)", err)
+		}
+		if err := ag.Prepare(name); err != nil {
+			logging.Warnf("could not accept bypass warning: %v", err)
+		}
+		time.Sleep(2 * time.Second)
+		if err := ag.SendPrompt(name, resumedPrompt(mgr, name, sess, conversationResumed[name])); err != nil {
+			logging.Warnf("could not send prompt: %v", err)
+		}
+	}
+
+	fmt.Printf("\nResumed %d of %d session(s).\n", len(resumed), len(names))
+	if len(snap.Sessions) > 0 {
+		fmt.Println("Sessions that could not be resumed are kept; fix the problem and run 'wt resume-all' again.")
+	}
+	return nil
+}
+
+// resumeSession restores one saved session: worktree, uncommitted changes,
+// tmux session (resuming the agent's conversation when possible) and state entry.
+func resumeSession(cfg *config.Config, mgr *project.Manager, state *session.State, name string, saved *snapshot.Saved) error {
+	sess := saved.Session
+	if _, exists := state.Sessions[name]; exists {
+		return fmt.Errorf("a session with this name is already active")
+	}
+	if tmux.SessionExists(name) {
+		return fmt.Errorf("tmux session already exists")
+	}
+
+	proj, _ := mgr.Get(sess.Project)
+	repoPath := strings.TrimSuffix(sess.BeadsDir, "/.beads")
+	if proj != nil {
+		repoPath = proj.RepoPath()
+	}
+
+	logging.Infof("Resuming '%s'...", name)
+
+	// The worktree usually survives a reboot; recreate it from the branch if not
+	recreated := false
+	if _, err := os.Stat(sess.Worktree); os.IsNotExist(err) {
+		fmt.Printf("  Recreating worktree at %s\n", sess.Worktree)
+		if err := recreateWorktree(proj, repoPath, sess); err != nil {
+			return fmt.Errorf("recreating worktree: %w", err)
+		}
+		if err := worktree.SymlinkClaudeDir(repoPath, sess.Worktree); err != nil {
+			logging.Warnf("could not symlink .claude/: %v", err)
+		}
+		if proj != nil {
+			installGitHooks(proj, sess.Worktree, githooks.Vars{BeadID: sess.Bead, Session: name, Project: proj.Name, Branch: sess.Branch})
+		}
+		configureSigning(proj, sess.Worktree)
+		provisionWorktree(cfg, proj, sess.Worktree)
+		recreated = true
+	}
+
+	if saved.Patch != "" {
+		dirty, _ := merge.HasUncommittedChanges(sess.Worktree)
+		if recreated || !dirty {
+			if err := snapshot.ApplyPatch(sess.Worktree, saved.Patch); err != nil {
+				return fmt.Errorf("restoring uncommitted changes from %s: %w", saved.Patch, err)
+			}
+			fmt.Println("  Restored uncommitted changes")
+		} else {
+			fmt.Println("  Uncommitted changes are still in the worktree")
+		}
+	}
+
+	// Resume the agent's conversation where it supports that
+	ag := sessionAgent(sess)
+	editorCmd := ag.ResumeCommand(agentCommand(cfg, proj, ag), saved.ClaudeSession)
+	var portEnv string
+	if proj != nil && proj.TestEnv != nil {
+		portEnv = proj.TestEnv.PortEnv
+	}
+	if err := tmux.NewSession(name, sess.Worktree, sess.BeadsDir, editorCmd, &tmux.SessionOptions{PortOffset: sess.PortOffset, PortEnv: portEnv, Windows: sessionWindows(proj, sessionVars(name, sess))}); err != nil {
+		return err
+	}
+	tagTmuxSession(cfg, name, sess.Bead)
+
+	if proj != nil && proj.TestEnv != nil && proj.TestEnv.Setup != "" {
+		logging.Infof("  Running test environment setup...")
+		if err := testenv.RunSetup(proj, sessionVars(name, sess)); err != nil {
+			logging.Warnf("test env setup failed: %v", err)
+		}
+	}
+	sess.EnvDownAt = ""
+
+	sess.UpdateActivity()
+	state.Sessions[name] = sess
+	if err := state.Save(); err != nil {
+		return fmt.Errorf("saving state: %w", err)
+	}
+	return nil
+}
+
+// resumedPrompt returns the prompt for a restored worker. A resumed
+// conversation only needs a nudge; a fresh one gets the original work prompt.
+func resumedPrompt(mgr *project.Manager, name string, sess *session.Session, conversationResumed bool) string {
+	return restoredPrompt(mgr, name, sess, conversationResumed, resumePrompt)
+}
+
+// restoredPrompt prefixes notice to the work prompt unless the agent's
+// conversation was resumed, in which case the notice is enough
+func restoredPrompt(mgr *project.Manager, name string, sess *session.Session, conversationResumed bool, notice string) string {
+	if conversationResumed {
+		return notice
+	}
+	proj, _ := mgr.Get(sess.Project)
+	if sess.IsTask() {
+		return notice + "\n\n" + buildTaskPrompt(sess.TaskDescription, sess.CompletionCondition, name, proj)
+	}
+	title, description := sess.Bead, ""
+	if info, err := bead.ShowFullInDir(sess.Bead, sess.BeadsDir); err == nil {
+		title, description = info.Title, info.Description
+	}
+	vars := project.CommandVars{Worktree: sess.Worktree, Bead: sess.Bead, Session: name, Project: sess.Project, Branch: sess.Branch, PortOffset: sess.PortOffset}
+	return notice + "\n\n" + buildInitialPrompt(sess.Bead, title, description, name, proj, vars)
+}
+
+func cmdShutdownHelp() error {
+	help := `wt shutdown - Stop all sessions, saving them for 'wt resume-all'
+
+USAGE:
+    wt shutdown [options]
+
+DESCRIPTION:
+    Prepares the whole fleet for a reboot. Each worker is asked to commit
+    its work in progress, and wt waits until every worktree is clean or the
+    timeout expires. Remaining uncommitted changes (including untracked
+    files) are saved as patches in ~/.config/wt/shutdown/, the session list
+    and Claude conversation IDs are recorded in ~/.config/wt/shutdown.json,
+    and all tmux sessions are killed. Worktrees and branches are kept.
+
+OPTIONS:
+    --timeout <dur>     How long to wait for workers to commit (default: 2m)
+    --no-wrapup         Don't ask workers to commit; save and stop immediately
+    -h, --help          Show this help
+
+EXAMPLES:
+    wt shutdown                   Wrap up, save and stop everything
+    wt shutdown --timeout 5m      Give workers longer to commit
+    wt shutdown --no-wrapup       Stop now; uncommitted work is saved as patches
+`
+	fmt.Print(help)
+	return nil
+}
+
+func cmdResumeAllHelp() error {
+	help := `wt resume-all - Restore the sessions saved by 'wt shutdown'
+
+USAGE:
+    wt resume-all [session...]
+
+DESCRIPTION:
+    Recreates each saved session: the worktree (from its branch, if it no
+    longer exists), uncommitted changes saved at shutdown, the tmux session
+    with the same port offset, and the test environment. The agent resumes
+    its previous conversation when it can (Claude by recorded session ID,
+    aider from its chat history); otherwise it starts fresh with the
+    original bead or task prompt.
+
+    Sessions that fail to resume stay saved, so the command can be re-run.
+
+ARGUMENTS:
+    [session...]        Only resume these sessions (default: all)
+
+EXAMPLES:
+    wt resume-all                 Restore everything after a reboot
+    wt resume-all toast           Restore a single session
+`
+	fmt.Print(help)
+	return nil
+}
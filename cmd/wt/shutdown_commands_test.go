@@ -0,0 +1,40 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/badri/wt/internal/session"
+)
+
+func TestParseShutdownFlags(t *testing.T) {
+	flags, err := parseShutdownFlags(nil)
+	if err != nil || flags.timeout != 2*time.Minute || flags.noWrapup {
+		t.Errorf("parseShutdownFlags(nil) = %+v, %v", flags, err)
+	}
+
+	flags, err = parseShutdownFlags([]string{"--timeout", "30s", "--no-wrapup"})
+	if err != nil || flags.timeout != 30*time.Second || !flags.noWrapup {
+		t.Errorf("parseShutdownFlags() = %+v, %v", flags, err)
+	}
+
+	for _, args := range [][]string{{"--timeout"}, {"--timeout", "soon"}, {"--force"}} {
+		if _, err := parseShutdownFlags(args); err == nil {
+			t.Errorf("parseShutdownFlags(%v) should fail", args)
+		}
+	}
+}
+
+func TestShutdownOrder(t *testing.T) {
+	state := &session.State{Sessions: map[string]*session.Session{
+		"toast": {}, "alpha": {}, "rye": {},
+	}}
+
+	if got, want := shutdownOrder(state, ""), []string{"alpha", "rye", "toast"}; !slices.Equal(got, want) {
+		t.Errorf("shutdownOrder() = %v, want %v", got, want)
+	}
+	if got, want := shutdownOrder(state, "alpha"), []string{"rye", "toast", "alpha"}; !slices.Equal(got, want) {
+		t.Errorf("shutdownOrder(current=alpha) = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,395 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/badri/wt/internal/auto"
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/msg"
+	"github.com/badri/wt/internal/report"
+	"github.com/badri/wt/internal/session"
+)
+
+// cmdSignal updates the session status with an optional message
+func cmdSignal(cfg *config.Config, args []string) error {
+	sa, err := parseSignalArgs(args)
+	if err != nil {
+		return err
+	}
+	status := sa.status
+
+	// Validate status
+	validStatuses := map[string]bool{
+		"working":   true,
+		"ready":     true,
+		"blocked":   true,
+		"error":     true,
+		"idle":      true,
+		"bead-done": true,
+	}
+	if !validStatuses[status] {
+		return fmt.Errorf("invalid status: %s\nvalid statuses: working, ready, blocked, error, idle, bead-done", status)
+	}
+
+	message := sa.message
+
+	// Find current session
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+
+	state, err := session.LoadState(cfg)
+	if err != nil {
+		return err
+	}
+
+	var sessionName string
+	var sess *session.Session
+	for name, s := range state.Sessions {
+		if s.Worktree == cwd {
+			sessionName = name
+			sess = s
+			break
+		}
+	}
+
+	// Read the report first, so a malformed one fails before anything changes
+	var workerReport *report.Report
+	if sa.report != "" {
+		if workerReport, err = report.Read(sa.report); err != nil {
+			return err
+		}
+		if message == "" {
+			message = workerReport.Summary
+		}
+	}
+
+	// In auto mode bead-done hands the bead back to the runner, which closes
+	// it and starts the next one. Isolated beads run in worktrees that
+	// aren't sessions, so this comes before the session check.
+	if status == "bead-done" {
+		if autoProject, epicState, ok := auto.FindAutoEpic(cfg, cwd); ok {
+			if err := auto.SignalBeadDone(cfg, autoProject, epicState, message); err != nil {
+				return fmt.Errorf("signaling bead-done: %w", err)
+			}
+			fmt.Printf("✓ Bead %s done. wt auto will close it and start the next bead; exit when you are finished.\n", epicState.CurrentBead)
+			if !auto.RunnerActive(cfg, autoProject) {
+				logging.Warnf("no wt auto is running for this epic. Run 'wt auto --resume' to continue it.")
+			}
+			return nil
+		}
+		if sess != nil {
+			fmt.Println("Note: Not in auto mode. Use 'wt done' to complete the session.")
+		}
+	}
+
+	if sess == nil {
+		return fmt.Errorf("not in a wt session. Run this from inside a session worktree")
+	}
+
+	// With --wait, forget stale acks before the hub can see the new signal
+	var store *msg.Store
+	if sa.wait {
+		store, err = msg.Open(msgDBPath(cfg))
+		if err != nil {
+			return err
+		}
+		defer store.Close()
+		if err := drainAcks(store, sessionName); err != nil {
+			return fmt.Errorf("clearing old acks: %w", err)
+		}
+	}
+
+	// Update status
+	sess.Status = status
+	sess.StatusMessage = message
+	sess.AwaitingAck = sa.wait
+	sess.UpdateActivity()
+
+	if err := state.Save(); err != nil {
+		return fmt.Errorf("saving state: %w", err)
+	}
+
+	// Display confirmation
+	statusIcon := getStatusIcon(status)
+	fmt.Printf("%s Session '%s' status: %s\n", statusIcon, sessionName, status)
+	if message != "" {
+		fmt.Printf("   Message: %s\n", message)
+	}
+	if workerReport != nil {
+		if err := report.Save(cfg, sess.Bead, workerReport); err != nil {
+			return fmt.Errorf("saving report: %w", err)
+		}
+		fmt.Printf("   Report: %s\n", workerReport.Brief())
+	}
+
+	// Reviewers hear about an early draft PR once the worker is done
+	if status == "ready" {
+		markDraftReady(cfg, sess.Bead, cwd)
+	}
+
+	if !sa.wait {
+		return nil
+	}
+
+	logging.Infof("Waiting for acknowledgement (wt ack %s [message])...", sessionName)
+	reply, err := waitForAck(cfg, store, sessionName, sa.timeout)
+	if err != nil {
+		return err
+	}
+	if reply == "" {
+		reply = "Acknowledged."
+	}
+	fmt.Printf("ACK: %s\n", reply)
+	return nil
+}
+
+// cmdSignalHelp shows help for the signal command
+func cmdSignalHelp() error {
+	help := `wt signal - Update session status
+
+USAGE:
+    wt signal <status> [message] [--wait [--timeout <duration>]]
+
+DESCRIPTION:
+    Updates the status of the current session. This is used to communicate
+    progress to the hub or other monitoring tools.
+
+    With --wait, the command blocks until the hub or a human runs
+    'wt ack <session> [message]', then prints the ack message so the
+    worker can continue based on the answer.
+
+ARGUMENTS:
+    <status>            Status: ready, blocked, error, working, idle, bead-done
+
+STATUS VALUES:
+    ready       Work is complete, ready for review/merge
+    blocked     Waiting on external dependency or decision
+    error       An error occurred that needs attention
+    working     Actively working on the task
+    idle        Paused but not blocked
+    bead-done   Bead completed in batch mode (include summary for next bead)
+
+OPTIONS:
+    -w, --wait          Block until the signal is acknowledged with 'wt ack'
+    --timeout <dur>     Give up waiting after this long (e.g. 30m)
+    --report <file>     Attach a structured result (JSON, - for stdin). wt done
+                        uses it for the PR description and creates the
+                        follow-up beads it lists. Keys: summary,
+                        files_changed, tests {command, run, passed, failed},
+                        follow_ups [{title, description, type, priority}],
+                        open_questions
+    -h, --help          Show this help
+
+EXAMPLES:
+    wt signal ready               Mark session as ready
+    wt signal blocked "Waiting on API access"  Mark blocked with reason
+    wt signal error "Tests failing"            Mark as error with message
+    wt signal bead-done "Added new feature X with tests"  Batch bead complete
+    wt signal blocked "Use v1 or v2 API?" --wait          Ask and wait for the answer
+    wt signal ready --report report.json   Finish with a structured result
+`
+	fmt.Print(help)
+	return nil
+}
+
+// ackPollInterval is how often a waiting signal checks for an acknowledgement
+const ackPollInterval = 2 * time.Second
+
+// signalArgs holds the parsed arguments of 'wt signal'
+type signalArgs struct {
+	status  string
+	message string
+	wait    bool
+	timeout time.Duration // 0 waits forever
+	report  string        // Structured result file, "-" for stdin
+}
+
+// parseSignalArgs separates --wait/--timeout from the status and message words
+func parseSignalArgs(args []string) (*signalArgs, error) {
+	sa := &signalArgs{}
+	var words []string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--wait", "-w":
+			sa.wait = true
+		case "--timeout":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--timeout requires a duration (e.g. 30m)")
+			}
+			d, err := time.ParseDuration(args[i+1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid --timeout %q: %w", args[i+1], err)
+			}
+			sa.timeout = d
+			i++
+		case "--report":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--report requires a file (or - for stdin)")
+			}
+			sa.report = args[i+1]
+			i++
+		default:
+			words = append(words, args[i])
+		}
+	}
+	if len(words) == 0 {
+		return nil, fmt.Errorf("usage: wt signal <status> [message] [--wait]")
+	}
+	if sa.timeout > 0 && !sa.wait {
+		return nil, fmt.Errorf("--timeout only applies with --wait")
+	}
+	sa.status = words[0]
+	sa.message = strings.Join(words[1:], " ")
+	return sa, nil
+}
+
+// drainAcks discards acknowledgements left over from earlier signals so a
+// new --wait only returns on an ack sent after it started.
+func drainAcks(store *msg.Store, sessionName string) error {
+	msgs, err := store.Recv(sessionName)
+	if err != nil {
+		return err
+	}
+	for _, m := range msgs {
+		if m.Subject == msg.SubjectAck {
+			if err := store.Ack(m.ID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// waitForAck blocks until an ACK message arrives for the session, the timeout
+// expires or the process is interrupted. It returns the ack message body.
+func waitForAck(cfg *config.Config, store *msg.Store, sessionName string, timeout time.Duration) (string, error) {
+	defer setAwaitingAck(cfg, sessionName, false)
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+
+	var deadline <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+
+	ticker := time.NewTicker(ackPollInterval)
+	defer ticker.Stop()
+
+	for {
+		msgs, err := store.Recv(sessionName)
+		if err != nil {
+			return "", err
+		}
+		for _, m := range msgs {
+			if m.Subject != msg.SubjectAck {
+				continue
+			}
+			if err := store.Ack(m.ID); err != nil {
+				return "", err
+			}
+			return m.Body, nil
+		}
+
+		select {
+		case <-ticker.C:
+		case <-deadline:
+			return "", fmt.Errorf("no acknowledgement within %s", timeout)
+		case <-sigCh:
+			return "", fmt.Errorf("interrupted while waiting for acknowledgement")
+		}
+	}
+}
+
+// setAwaitingAck records on the session whether it is blocked on an ack
+func setAwaitingAck(cfg *config.Config, sessionName string, awaiting bool) {
+	state, err := session.LoadState(cfg)
+	if err != nil {
+		return
+	}
+	sess, ok := state.Sessions[sessionName]
+	if !ok || sess.AwaitingAck == awaiting {
+		return
+	}
+	sess.AwaitingAck = awaiting
+	if err := state.Save(); err != nil {
+		logging.Warnf("could not save state: %v", err)
+	}
+}
+
+// cmdAck acknowledges a session's signal, releasing a 'wt signal --wait'
+func cmdAck(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: wt ack <session> [message]")
+	}
+
+	state, err := session.LoadState(cfg)
+	if err != nil {
+		return err
+	}
+	name, sess := findSessionByNameOrBead(state, args[0])
+	if sess == nil {
+		return fmt.Errorf("session '%s' not found", args[0])
+	}
+	message := strings.Join(args[1:], " ")
+
+	store, err := msg.Open(msgDBPath(cfg))
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	from := "cli"
+	if os.Getenv("WT_HUB") == "1" {
+		from = "hub"
+	}
+	if _, err := store.Send(&msg.Message{
+		Subject: msg.SubjectAck,
+		From:    from,
+		To:      name,
+		Body:    message,
+	}); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Acknowledged %s signal from '%s'\n", sess.Status, name)
+	if !sess.AwaitingAck {
+		fmt.Printf("Note: '%s' is not waiting for an ack; it will not see this message.\n", name)
+	}
+	return nil
+}
+
+// cmdAckHelp shows help for the ack command
+func cmdAckHelp() error {
+	help := `wt ack - Acknowledge a worker's signal
+
+USAGE:
+    wt ack <session> [message]
+
+DESCRIPTION:
+    Releases a worker blocked in 'wt signal <status> --wait'. The message is
+    printed to the worker's stdout so its Claude can act on the answer.
+    The session can be given by name or bead ID.
+
+OPTIONS:
+    -h, --help          Show this help
+
+EXAMPLES:
+    wt ack toast                           Acknowledge without a message
+    wt ack toast "Use the v2 API"          Answer a blocked worker's question
+    wt ack wt-abc "Approved, run wt done"  Acknowledge by bead ID
+`
+	fmt.Print(help)
+	return nil
+}
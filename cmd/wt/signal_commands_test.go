@@ -0,0 +1,91 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/msg"
+)
+
+func TestParseSignalArgs(t *testing.T) {
+	tests := []struct {
+		args    []string
+		want    signalArgs
+		wantErr bool
+	}{
+		{args: []string{"ready"}, want: signalArgs{status: "ready"}},
+		{args: []string{"blocked", "need", "API", "key"}, want: signalArgs{status: "blocked", message: "need API key"}},
+		{args: []string{"blocked", "v1 or v2?", "--wait"}, want: signalArgs{status: "blocked", message: "v1 or v2?", wait: true}},
+		{args: []string{"-w", "ready", "--timeout", "30m"}, want: signalArgs{status: "ready", wait: true, timeout: 30 * time.Minute}},
+		{args: []string{"--wait"}, wantErr: true},
+		{args: []string{"ready", "--timeout", "30m"}, wantErr: true},
+		{args: []string{"ready", "--wait", "--timeout", "soon"}, wantErr: true},
+		{args: []string{"ready", "--wait", "--timeout"}, wantErr: true},
+		{args: []string{"ready", "--report", "r.json"}, want: signalArgs{status: "ready", report: "r.json"}},
+		{args: []string{"ready", "--report"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseSignalArgs(tt.args)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseSignalArgs(%v) expected error", tt.args)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseSignalArgs(%v) error: %v", tt.args, err)
+			continue
+		}
+		if *got != tt.want {
+			t.Errorf("parseSignalArgs(%v) = %+v, want %+v", tt.args, *got, tt.want)
+		}
+	}
+}
+
+func TestWaitForAck(t *testing.T) {
+	dir := t.TempDir()
+	cfg, err := config.LoadFromDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store, err := msg.Open(filepath.Join(dir, "messages.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	// A stale ack from an earlier signal is drained; other messages are kept
+	store.Send(&msg.Message{Subject: msg.SubjectAck, From: "hub", To: "toast", Body: "old answer"})
+	store.Send(&msg.Message{Subject: msg.SubjectTask, From: "hub", To: "toast", Body: "task"})
+	if err := drainAcks(store, "toast"); err != nil {
+		t.Fatalf("drainAcks() error: %v", err)
+	}
+	pending, _ := store.Recv("toast")
+	if len(pending) != 1 || pending[0].Subject != msg.SubjectTask {
+		t.Fatalf("after drainAcks, pending = %+v, want only the TASK message", pending)
+	}
+
+	if _, err := waitForAck(cfg, store, "toast", 10*time.Millisecond); err == nil {
+		t.Error("waitForAck() without an ack should time out")
+	}
+
+	store.Send(&msg.Message{Subject: msg.SubjectAck, From: "hub", To: "toast", Body: "use v2"})
+	reply, err := waitForAck(cfg, store, "toast", time.Second)
+	if err != nil {
+		t.Fatalf("waitForAck() error: %v", err)
+	}
+	if reply != "use v2" {
+		t.Errorf("waitForAck() = %q, want %q", reply, "use v2")
+	}
+
+	// The ack is consumed
+	pending, _ = store.Recv("toast")
+	for _, m := range pending {
+		if m.Subject == msg.SubjectAck {
+			t.Errorf("ack %d was not consumed", m.ID)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/merge"
+	"github.com/badri/wt/internal/project"
+	"github.com/badri/wt/internal/worktree"
+)
+
+// configureSigning makes a worktree sign its commits when the project
+// requires signed commits. Failures are warnings, like git hooks; wt done
+// still refuses to merge unsigned commits.
+func configureSigning(proj *project.Project, worktreePath string) {
+	if proj == nil || !proj.Signing.Required() {
+		return
+	}
+	s := proj.Signing
+	sc := worktree.SigningConfig{
+		Format:         s.Format,
+		Key:            project.ExpandPath(s.Key),
+		AllowedSigners: project.ExpandPath(s.AllowedSigners),
+	}
+	if err := worktree.ConfigureSigning(worktreePath, sc); err != nil {
+		logging.Warnf("could not configure commit signing: %v", err)
+		return
+	}
+	fmt.Println("Commit signing enabled")
+}
+
+// checkCommitSignatures refuses to merge a branch with unsigned commits
+// when the project requires signed commits, so they're caught before the
+// push rather than by branch protection
+func checkCommitSignatures(proj *project.Project, worktreePath, targetBranch string) error {
+	if proj == nil || !proj.Signing.Required() {
+		return nil
+	}
+
+	base := diffBase(worktreePath, targetBranch)
+	sigs, err := merge.CommitSignatures(worktreePath, base)
+	if err != nil {
+		return fmt.Errorf("checking commit signatures: %w", err)
+	}
+
+	var bad []merge.CommitSignature
+	unverified := 0
+	for _, sig := range sigs {
+		switch sig.State {
+		case merge.SignatureMissing, merge.SignatureBad:
+			bad = append(bad, sig)
+		case merge.SignatureUnverified:
+			unverified++
+		}
+	}
+	if len(bad) == 0 {
+		if unverified > 0 {
+			logging.Warnf("%d commit(s) are signed with keys this machine can't verify (set signing.allowed_signers for SSH keys)", unverified)
+		}
+		fmt.Printf("All %d commit(s) are signed.\n", len(sigs))
+		return nil
+	}
+
+	fmt.Printf("\n%d of %d commit(s) are not properly signed:\n", len(bad), len(sigs))
+	for _, sig := range bad {
+		what := "unsigned"
+		if sig.State == merge.SignatureBad {
+			what = "bad signature"
+		}
+		fmt.Printf("  - %s %s (%s)\n", shortSHA(sig.SHA), sig.Subject, what)
+	}
+
+	// Commits made before signing was set up need it to re-sign
+	configureSigning(proj, worktreePath)
+	return fmt.Errorf("project '%s' requires signed commits. Re-sign them with:\n"+
+		"  git rebase --exec 'git commit --amend --no-edit --no-verify -S' %s\n"+
+		"then run 'wt done' again", proj.Name, base)
+}
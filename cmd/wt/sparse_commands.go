@@ -0,0 +1,194 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/badri/wt/internal/bead"
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/monorepo"
+	"github.com/badri/wt/internal/project"
+	"github.com/badri/wt/internal/session"
+	"github.com/badri/wt/internal/worktree"
+)
+
+// beadSparsePaths returns the paths a bead's worktree checks out: the
+// project's sparse paths plus those of the scopes its labels select
+func beadSparsePaths(proj *project.Project, beadID string, labels []string) []string {
+	paths := slices.Clone(proj.Sparse.Paths)
+	for _, scope := range monorepo.ScopesFromLabels(labels) {
+		extra, ok := proj.Sparse.Scopes[scope]
+		if !ok {
+			logging.Warnf("%s selects sparse scope '%s', which project %s doesn't declare", beadID, scope, proj.Name)
+			continue
+		}
+		for _, p := range extra {
+			if !slices.Contains(paths, p) {
+				paths = append(paths, p)
+			}
+		}
+	}
+	return paths
+}
+
+// createSessionWorktree creates a bead's worktree, sparse when the project
+// configures it, and returns the sparse paths (nil for a full checkout)
+func createSessionWorktree(proj *project.Project, repoPath, worktreePath, beadID, baseBranch string) ([]string, error) {
+	if proj == nil || !proj.Sparse.Enabled() {
+		return nil, worktree.CreateFromBranch(repoPath, worktreePath, beadID, baseBranch)
+	}
+	var labels []string
+	if len(proj.Sparse.Scopes) > 0 {
+		var err error
+		if labels, err = bead.Labels(beadID, repoPath); err != nil {
+			logging.Warnf("could not read labels of %s, sparse scopes skipped: %v", beadID, err)
+		}
+	}
+	paths := beadSparsePaths(proj, beadID, labels)
+	if len(paths) == 0 {
+		logging.Infof("  No sparse paths apply to this bead; checking out everything")
+		return nil, worktree.CreateFromBranch(repoPath, worktreePath, beadID, baseBranch)
+	}
+	if err := worktree.CreateSparse(repoPath, worktreePath, beadID, baseBranch, paths, proj.Sparse.Cone()); err != nil {
+		return nil, err
+	}
+	logging.Infof("  Sparse checkout: %s", strings.Join(paths, ", "))
+	return paths, nil
+}
+
+// recreateWorktree recreates a session's worktree from its branch, with
+// the sparse paths it had
+func recreateWorktree(proj *project.Project, repoPath string, sess *session.Session) error {
+	if len(sess.SparsePaths) == 0 {
+		return worktree.Create(repoPath, sess.Worktree, sess.Branch)
+	}
+	return worktree.CreateSparse(repoPath, sess.Worktree, sess.Branch, "", sess.SparsePaths, projectSparse(proj).Cone())
+}
+
+// projectSparse returns a project's sparse config, nil for no project
+func projectSparse(proj *project.Project) *project.Sparse {
+	if proj == nil {
+		return nil
+	}
+	return proj.Sparse
+}
+
+// cmdSparse shows or changes which paths a session's worktree checks out
+func cmdSparse(cfg *config.Config, args []string) error {
+	sub := ""
+	if len(args) > 0 {
+		switch args[0] {
+		case "set", "add", "off":
+			sub, args = args[0], args[1:]
+		}
+	}
+	var target string
+	var paths []string
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			return fmt.Errorf("unknown flag: %s", arg)
+		}
+		if target == "" {
+			target = arg
+		} else {
+			paths = append(paths, arg)
+		}
+	}
+	if (sub == "set" || sub == "add") && len(paths) == 0 {
+		return fmt.Errorf("usage: wt sparse %s <session> <paths...>", sub)
+	}
+
+	state, err := session.LoadState(cfg)
+	if err != nil {
+		return err
+	}
+	name, sess, err := pauseTarget(state, target, "sparse")
+	if err != nil {
+		return err
+	}
+	proj, _ := project.NewManager(cfg).Get(sess.Project)
+
+	switch sub {
+	case "set":
+		err = worktree.SetSparse(sess.Worktree, paths, projectSparse(proj).Cone())
+	case "add":
+		if worktree.IsSparse(sess.Worktree) {
+			err = worktree.AddSparse(sess.Worktree, paths)
+		} else {
+			err = fmt.Errorf("'%s' already checks out everything", name)
+		}
+	case "off":
+		err = worktree.DisableSparse(sess.Worktree)
+	}
+	if err != nil {
+		return err
+	}
+
+	current, err := worktree.SparsePaths(sess.Worktree)
+	if err != nil {
+		return err
+	}
+	if sub != "" {
+		sess.SparsePaths = current
+		if err := state.Save(); err != nil {
+			return fmt.Errorf("saving state: %w", err)
+		}
+	}
+
+	if outputJSON {
+		if current == nil {
+			current = []string{}
+		}
+		printJSON(map[string]any{"session": name, "sparse": len(current) > 0, "paths": current})
+		return nil
+	}
+	if len(current) == 0 {
+		fmt.Printf("'%s' checks out the whole repo.\n", name)
+		return nil
+	}
+	fmt.Printf("'%s' checks out:\n", name)
+	for _, p := range current {
+		fmt.Printf("  %s\n", p)
+	}
+	return nil
+}
+
+func cmdSparseHelp() error {
+	help := `wt sparse - Show or change a session's sparse checkout
+
+USAGE:
+    wt sparse [session]
+    wt sparse set <session> <paths...>
+    wt sparse add <session> <paths...>
+    wt sparse off <session>
+
+Projects with a "sparse" config create worktrees that check out only part
+of the repo: the configured paths plus those of the scopes the bead's
+"scope:<name>" labels select. Use these commands when a worker needs more.
+Without a session, the session in the current directory is used.
+
+SUBCOMMANDS:
+    (none)              List the paths the session checks out
+    set                 Check out exactly these paths
+    add                 Check out these paths as well
+    off                 Check out the whole repo
+
+In cone mode (the default) paths are directories; with "no_cone" in the
+project config they are gitignore-style patterns. Files at the repo root
+are always checked out in cone mode.
+
+OPTIONS:
+    --json              Output as JSON
+    -h, --help          Show this help
+
+EXAMPLES:
+    wt sparse toast
+    wt sparse add toast services/billing libs/money
+    wt sparse set toast services/api
+    wt sparse off toast
+`
+	fmt.Print(help)
+	return nil
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/badri/wt/internal/project"
+)
+
+func TestBeadSparsePaths(t *testing.T) {
+	proj := &project.Project{Name: "mono", Sparse: &project.Sparse{
+		Paths:  []string{"libs/core", "tools"},
+		Scopes: map[string][]string{"api": {"services/api", "libs/core"}, "web": {"apps/web"}},
+	}}
+
+	tests := []struct {
+		name   string
+		labels []string
+		want   []string
+	}{
+		{"no scope labels", []string{"bug"}, []string{"libs/core", "tools"}},
+		{"one scope, shared path once", []string{"scope:api"}, []string{"libs/core", "tools", "services/api"}},
+		{"two scopes", []string{"scope:web", "scope:api"}, []string{"libs/core", "tools", "apps/web", "services/api"}},
+		{"undeclared scope skipped", []string{"scope:ios"}, []string{"libs/core", "tools"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := beadSparsePaths(proj, "mono-1", tt.labels); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("beadSparsePaths() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if len(proj.Sparse.Paths) != 2 {
+		t.Errorf("project paths were modified: %v", proj.Sparse.Paths)
+	}
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/badri/wt/internal/bead"
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/session"
+)
+
+// splitArgs holds the parsed arguments of 'wt split'
+type splitArgs struct {
+	titles    []string
+	priority  int
+	issueType string
+	under     string // epic to file the children under instead of the session's bead
+	chain     bool   // each child depends on the previous one
+	take      bool   // re-scope the session to the first child
+}
+
+func parseSplitArgs(args []string) (*splitArgs, error) {
+	sa := &splitArgs{priority: 2, issueType: "task"}
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--priority", "-p", "--type", "-t", "--under":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("%s requires a value", args[i])
+			}
+			val := args[i+1]
+			i++
+			switch args[i-1] {
+			case "--priority", "-p":
+				if _, err := fmt.Sscanf(val, "%d", &sa.priority); err != nil || sa.priority < 0 || sa.priority > 4 {
+					return nil, fmt.Errorf("invalid priority %q (expected 0-4)", val)
+				}
+			case "--type", "-t":
+				sa.issueType = val
+			default:
+				sa.under = val
+			}
+		case "--chain":
+			sa.chain = true
+		case "--take":
+			sa.take = true
+		default:
+			if strings.HasPrefix(args[i], "-") {
+				return nil, fmt.Errorf("unknown flag: %s", args[i])
+			}
+			if t := strings.TrimSpace(args[i]); t != "" {
+				sa.titles = append(sa.titles, t)
+			}
+		}
+	}
+	return sa, nil
+}
+
+// readSplitTitles prompts for sub-task titles, one per line, until an empty
+// line or end of input
+func readSplitTitles() ([]string, error) {
+	fmt.Println("Sub-task titles, one per line (empty line to finish):")
+	var titles []string
+	scanner := bufio.NewScanner(os.Stdin)
+	for {
+		fmt.Printf("  %d> ", len(titles)+1)
+		if !scanner.Scan() {
+			fmt.Println()
+			break
+		}
+		t := strings.TrimSpace(scanner.Text())
+		if t == "" {
+			break
+		}
+		titles = append(titles, t)
+	}
+	return titles, scanner.Err()
+}
+
+// cmdSplit breaks the session's bead into child beads. Children of an epic
+// are filed under it the way the repo files epic work (child depends on the
+// epic); for any other bead the parent is made to wait on its children.
+func cmdSplit(cfg *config.Config, args []string) error {
+	if hasHelpFlag(args) {
+		return cmdSplitHelp()
+	}
+	sa, err := parseSplitArgs(args)
+	if err != nil {
+		return err
+	}
+
+	state, err := session.LoadState(cfg)
+	if err != nil {
+		return err
+	}
+	name, sess := sessionInCwd(state)
+	if sess == nil {
+		return fmt.Errorf("not in a wt session. Run this from inside a session worktree")
+	}
+	if !sess.IsBead() || sess.BeadsDir == "" {
+		return fmt.Errorf("session '%s' has no bead to split", name)
+	}
+
+	parent := sess.Bead
+	if sa.under != "" {
+		parent = sa.under
+	}
+	info, err := bead.ShowFullInDir(parent, sess.BeadsDir)
+	if err != nil {
+		return fmt.Errorf("looking up %s: %w", parent, err)
+	}
+	isEpic := info.IssueType == "epic"
+	if sa.under != "" && !isEpic {
+		return fmt.Errorf("%s is a %s, not an epic", parent, info.IssueType)
+	}
+
+	if len(sa.titles) == 0 {
+		if sa.titles, err = readSplitTitles(); err != nil {
+			return fmt.Errorf("reading titles: %w", err)
+		}
+		if len(sa.titles) == 0 {
+			fmt.Println("No sub-tasks given; nothing to do.")
+			return nil
+		}
+	}
+
+	fmt.Printf("Splitting %s: %s\n", parent, info.Title)
+	var children []string
+	for _, title := range sa.titles {
+		opts := &bead.CreateOptions{
+			Description: fmt.Sprintf("Split from %s: %s", parent, info.Title),
+			Priority:    sa.priority,
+			Type:        sa.issueType,
+		}
+		id, err := bead.CreateInDir(sess.BeadsDir, title, opts)
+		if err != nil {
+			return err
+		}
+		if isEpic {
+			err = bead.AddDepInDir(id, parent, sess.BeadsDir)
+		} else {
+			err = bead.AddDepInDir(parent, id, sess.BeadsDir)
+		}
+		if err != nil {
+			logging.Warnf("could not link %s to %s: %v", id, parent, err)
+		}
+		if sa.chain && len(children) > 0 {
+			if err := bead.AddDepInDir(id, children[len(children)-1], sess.BeadsDir); err != nil {
+				logging.Warnf("could not chain %s after %s: %v", id, children[len(children)-1], err)
+			}
+		}
+		children = append(children, id)
+		fmt.Printf("  + %s  %s\n", id, title)
+	}
+	if isEpic {
+		fmt.Printf("Filed %d bead(s) under epic %s\n", len(children), parent)
+	} else {
+		fmt.Printf("%s now waits on %d sub-task(s)\n", parent, len(children))
+	}
+
+	if !sa.take {
+		return nil
+	}
+	first := children[0]
+	releaseSessionClaim(cfg, sess)
+	if _, err := bead.ClaimBead(first, cfg.ClaimantID(), sess.BeadsDir, cfg.ClaimExpiry()); err != nil {
+		logging.Warnf("could not claim bead: %v", err)
+	}
+	if err := bead.UpdateStatusInDir(first, "in_progress", sess.BeadsDir); err != nil {
+		logging.Warnf("could not mark %s in progress: %v", first, err)
+	}
+	if err := bead.UpdateStatusInDir(sess.Bead, "open", sess.BeadsDir); err != nil {
+		logging.Warnf("could not reopen %s: %v", sess.Bead, err)
+	}
+	sess.Bead = first
+	sess.UpdateActivity()
+	if err := state.Save(); err != nil {
+		return fmt.Errorf("saving state: %w", err)
+	}
+	fmt.Printf("Session '%s' now works on %s: %s\n", name, first, sa.titles[0])
+	return nil
+}
+
+func cmdSplitHelp() error {
+	help := `wt split - Break the session's bead into smaller beads
+
+USAGE:
+    wt split ["<title>" ...] [options]
+
+Run inside a worker session when the bead turns out to be too big. Each
+title becomes a new bead. Without titles, wt prompts for them one per line.
+
+If the session's bead is an epic (or --under names one), the new beads are
+filed under it. Otherwise the session's bead is made to depend on them, so
+it becomes ready again once every sub-task is closed.
+
+OPTIONS:
+    --priority, -p <0-4>    Priority of the new beads (default: 2)
+    --type, -t <type>       Issue type of the new beads (default: task)
+    --under <epic>          File the new beads under this epic instead
+    --chain                 Make each new bead depend on the previous one
+    --take                  Re-scope this session to the first new bead
+    -h, --help              Show this help
+
+EXAMPLES:
+    wt split "Extract token parser" "Add refresh endpoint"
+    wt split --chain --take "Schema migration" "Backfill" "Switch reads"
+    wt split                  Prompt for sub-task titles
+`
+	fmt.Print(help)
+	return nil
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSplitArgs(t *testing.T) {
+	sa, err := parseSplitArgs([]string{"Extract parser", "--chain", "-p", "1", "Add endpoint", "--take", "--type", "bug"})
+	if err != nil {
+		t.Fatalf("parseSplitArgs() error: %v", err)
+	}
+	if !reflect.DeepEqual(sa.titles, []string{"Extract parser", "Add endpoint"}) {
+		t.Errorf("titles = %v", sa.titles)
+	}
+	if !sa.chain || !sa.take || sa.priority != 1 || sa.issueType != "bug" {
+		t.Errorf("parseSplitArgs() = %+v", sa)
+	}
+
+	sa, err = parseSplitArgs([]string{"--under", "proj-epic"})
+	if err != nil || sa.under != "proj-epic" || len(sa.titles) != 0 || sa.priority != 2 || sa.issueType != "task" {
+		t.Errorf("parseSplitArgs(--under) = %+v, %v", sa, err)
+	}
+
+	for _, args := range [][]string{{"--under"}, {"-p", "7"}, {"-p", "high"}, {"--force"}} {
+		if _, err := parseSplitArgs(args); err == nil {
+			t.Errorf("parseSplitArgs(%v) should fail", args)
+		}
+	}
+}
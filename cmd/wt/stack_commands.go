@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/merge"
+	"github.com/badri/wt/internal/monitor"
+	"github.com/badri/wt/internal/project"
+	"github.com/badri/wt/internal/session"
+	"github.com/badri/wt/internal/stack"
+	"github.com/badri/wt/internal/worktree"
+)
+
+// resolveStackParent finds the branch to stack a new session on. ref may be
+// a session name or bead ID; beads without an active session are expected to
+// have a branch named after the bead (wt's convention).
+func resolveStackParent(state *session.State, repoPath, ref string) (parentBead, parentBranch string, err error) {
+	if name, sess := findSessionByNameOrBead(state, ref); sess != nil {
+		parentBead = sess.Bead
+		parentBranch = sess.Branch
+		if parentBranch == "" {
+			parentBranch = sess.Bead
+		}
+		if parentBead == "" {
+			return "", "", fmt.Errorf("session '%s' has no bead to stack on", name)
+		}
+	} else {
+		parentBead = ref
+		parentBranch = ref
+	}
+
+	if !worktree.BranchExists(repoPath, parentBranch) {
+		return "", "", fmt.Errorf("branch '%s' for --stack-on %s not found", parentBranch, ref)
+	}
+	return parentBead, parentBranch, nil
+}
+
+// stackedPromptNote tells a stacked worker which branch its PR must target
+func stackedPromptNote(parentBead, parentBranch string) string {
+	return fmt.Sprintf("\n\nNOTE: This branch is stacked on %s (branch %s), which is not merged yet. "+
+		"Use `wt done` or open your PR against %s, not the default branch. "+
+		"wt retargets it once %s merges.", parentBead, parentBranch, parentBranch, parentBead)
+}
+
+// recordStack saves the stacking relationship for a new session
+func recordStack(cfg *config.Config, sess *session.Session, repoPath string) {
+	store, err := stack.Load(cfg)
+	if err != nil {
+		logging.Warnf("could not load stacks: %v", err)
+		return
+	}
+	store.Put(&stack.Entry{
+		Bead:         sess.Bead,
+		Branch:       sess.Branch,
+		ParentBead:   sess.StackedOn,
+		ParentBranch: sess.StackBranch,
+		Project:      sess.Project,
+		RepoPath:     repoPath,
+	})
+	if err := store.Save(); err != nil {
+		logging.Warnf("could not save stacks: %v", err)
+	}
+}
+
+// recordStackPR remembers the PR of a stacked session so it can be retargeted later
+func recordStackPR(cfg *config.Config, beadID, prURL string) {
+	store, err := stack.Load(cfg)
+	if err != nil {
+		logging.Warnf("could not load stacks: %v", err)
+		return
+	}
+	e := store.Get(beadID)
+	if e == nil {
+		return
+	}
+	e.PRURL = prURL
+	if err := store.Save(); err != nil {
+		logging.Warnf("could not save stacks: %v", err)
+	}
+}
+
+// stackTarget returns the branch a session's work should merge into: its
+// parent's branch while the parent is unmerged, otherwise the default branch.
+func stackTarget(dir string, sess *session.Session, defaultBranch string) string {
+	if sess.StackBranch == "" {
+		return defaultBranch
+	}
+	if parentMerged(dir, sess.StackBranch, defaultBranch) {
+		fmt.Printf("Parent %s is merged - targeting %s.\n", sess.StackedOn, defaultBranch)
+		return defaultBranch
+	}
+	return sess.StackBranch
+}
+
+// parentMerged reports whether a parent branch has landed on the default branch,
+// either through a merged PR or as an ancestor of the default branch.
+func parentMerged(dir, parentBranch, defaultBranch string) bool {
+	if status, _ := monitor.GetPRStatus(dir, parentBranch); status == "merged" {
+		return true
+	}
+	return worktree.IsBranchMerged(dir, parentBranch, "origin/"+defaultBranch)
+}
+
+// syncStackedPRs retargets stacked PRs whose parent has merged to the default
+// branch and drops stack records that are no longer needed.
+func syncStackedPRs(cfg *config.Config) {
+	store, err := stack.Load(cfg)
+	if err != nil || len(store.Entries) == 0 {
+		return
+	}
+
+	state, err := session.LoadState(cfg)
+	if err != nil {
+		return
+	}
+	active := make(map[string]bool)
+	for _, sess := range state.Sessions {
+		active[sess.Bead] = true
+	}
+
+	mgr := project.NewManager(cfg)
+	changed := false
+	for bead, e := range store.Entries {
+		defaultBranch := "main"
+		if proj, err := mgr.Get(e.Project); err == nil && proj.DefaultBranch != "" {
+			defaultBranch = proj.DefaultBranch
+		}
+
+		if e.PRURL == "" {
+			// No PR yet: keep the record while the child is still being worked on
+			if !active[bead] {
+				store.Remove(bead)
+				changed = true
+			}
+			continue
+		}
+
+		if status, _ := monitor.GetPRStatus(e.RepoPath, e.Branch); status == "merged" || status == "closed" {
+			store.Remove(bead)
+			changed = true
+			continue
+		}
+
+		if !parentMerged(e.RepoPath, e.ParentBranch, defaultBranch) {
+			continue
+		}
+
+		logging.Infof("Parent %s merged - retargeting %s PR to %s...", e.ParentBead, bead, defaultBranch)
+		if err := merge.RetargetPR(e.RepoPath, e.PRURL, defaultBranch); err != nil {
+			logging.Warnf("%v", err)
+			continue
+		}
+		store.Remove(bead)
+		changed = true
+	}
+
+	if changed {
+		if err := store.Save(); err != nil {
+			logging.Warnf("could not save stacks: %v", err)
+		}
+	}
+}
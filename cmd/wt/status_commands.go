@@ -0,0 +1,275 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/badri/wt/internal/bead"
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/merge"
+	"github.com/badri/wt/internal/monitor"
+	"github.com/badri/wt/internal/project"
+	"github.com/badri/wt/internal/report"
+	"github.com/badri/wt/internal/session"
+)
+
+// cmdStatusHelp shows help for the status command
+func cmdStatusHelp() error {
+	help := `wt status - Show session status
+
+USAGE:
+    wt status [session] [--short] [--no-probe]
+
+DESCRIPTION:
+    Displays detailed information about a worktree session, including
+    bead info, git status, PR state, last signal and session metadata.
+
+    With no argument, shows the session whose worktree is the current
+    directory. Pass a session name or bead ID to check any session from
+    the hub or anywhere else.
+
+    --short prints one line (bead, status, idle time) for tmux status
+    lines; see 'wt keys' and the tmux_status config key.
+
+    For projects with a test_env, the project's health_check and status
+    commands are run now (10s limit each) with the session's port offset,
+    and the test_env ports are shown shifted by that offset, so you can
+    tell whether failing tests are the code or the environment.
+
+OPTIONS:
+    --short             One-line status: "wt-abc · working · idle 3m"
+    --no-probe          Don't run the health check or status command
+    -h, --help          Show this help
+
+EXAMPLES:
+    wt status           Show current session status
+    wt status toast     Show status of session 'toast'
+    wt status wt-abc    Show status of the session working on bead wt-abc
+`
+	fmt.Print(help)
+	return nil
+}
+
+// StatusJSON is the JSON output format for session status
+type StatusJSON struct {
+	Session       string `json:"session"`
+	Bead          string `json:"bead"`
+	Title         string `json:"title"`
+	Project       string `json:"project"`
+	Branch        string `json:"branch"`
+	MergeMode     string `json:"merge_mode"`
+	Worktree      string `json:"worktree"`
+	Status        string `json:"status"`
+	StatusMessage string `json:"status_message,omitempty"`
+	AwaitingAck   bool   `json:"awaiting_ack,omitempty"`
+	HasChanges    bool   `json:"has_uncommitted_changes"`
+	PortOffset    int    `json:"port_offset,omitempty"`
+	PRStatus      string `json:"pr_status,omitempty"`
+	PRURL         string `json:"pr_url,omitempty"`
+	CreatedAt     string `json:"created_at"`
+	LastActivity  string `json:"last_activity"`
+
+	TestEnv *TestEnvStatusJSON `json:"test_env,omitempty"`
+	Report  *report.Report     `json:"report,omitempty"` // Attached with 'wt signal --report'
+}
+
+// cmdStatus shows the status of a session, given by name or bead ID,
+// or of the session for the current directory if none is given
+func cmdStatus(cfg *config.Config, args []string) error {
+	var rest []string
+	short, probe := false, true
+	for _, arg := range args {
+		switch arg {
+		case "--short":
+			short = true
+		case "--no-probe":
+			probe = false
+		default:
+			rest = append(rest, arg)
+		}
+	}
+	args = rest
+	if short {
+		target := ""
+		if len(args) > 0 {
+			target = args[0]
+		}
+		return cmdStatusShort(cfg, target)
+	}
+
+	state, err := session.LoadState(cfg)
+	if err != nil {
+		return err
+	}
+
+	var sessionName string
+	var sess *session.Session
+	if len(args) > 0 {
+		sessionName, sess = findSessionByNameOrBead(state, args[0])
+		if sess == nil {
+			return fmt.Errorf("no session found for '%s'", args[0])
+		}
+	} else {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("getting current directory: %w", err)
+		}
+		for name, s := range state.Sessions {
+			if s.Worktree == cwd {
+				sessionName = name
+				sess = s
+				break
+			}
+		}
+		if sess == nil {
+			return fmt.Errorf("not in a wt session. Run this from inside a session worktree or pass a session name")
+		}
+	}
+
+	// Get bead info (task sessions have no bead)
+	title := sess.TaskDescription
+	if sess.IsBead() {
+		beadInfo, err := bead.ShowInDir(sess.Bead, sess.BeadsDir)
+		if err != nil {
+			return fmt.Errorf("getting bead info: %w", err)
+		}
+		title = beadInfo.Title
+	}
+
+	// Get git status from the session's worktree
+	hasChanges, _ := merge.HasUncommittedChanges(sess.Worktree)
+	branch, _ := merge.GetCurrentBranch(sess.Worktree)
+	if branch == "" {
+		branch = sess.Branch
+	}
+
+	// Get project info
+	mgr := project.NewManager(cfg)
+	proj, _ := mgr.Get(sess.Project)
+
+	mergeMode := "pr-review"
+	if proj != nil && proj.MergeMode != "" {
+		mergeMode = proj.MergeMode
+	}
+
+	// Direct mode never opens PRs, so skip the gh lookup
+	prStatus, prURL := "", ""
+	if mergeMode != "direct" && branch != "" {
+		prStatus, prURL = monitor.GetPRStatus(sess.Worktree, branch)
+	}
+
+	status := sess.Status
+	if status == "" {
+		status = "working"
+	}
+
+	testEnv := probeTestEnv(proj, sessionName, sess, probe)
+	workerReport, _ := report.Load(cfg, sess.Bead)
+
+	// JSON output
+	if outputJSON {
+		result := StatusJSON{
+			Session:       sessionName,
+			Bead:          sess.Bead,
+			Title:         title,
+			Project:       sess.Project,
+			Branch:        branch,
+			MergeMode:     mergeMode,
+			Worktree:      sess.Worktree,
+			Status:        status,
+			StatusMessage: sess.StatusMessage,
+			AwaitingAck:   sess.AwaitingAck,
+			HasChanges:    hasChanges,
+			PortOffset:    sess.PortOffset,
+			PRStatus:      prStatus,
+			PRURL:         prURL,
+			CreatedAt:     sess.CreatedAt,
+			LastActivity:  sess.LastActivity,
+			TestEnv:       testEnv,
+			Report:        workerReport,
+		}
+		printJSON(result)
+		return nil
+	}
+
+	// The box is drawn with box-drawing characters; --plain turns them into ASCII
+	var box strings.Builder
+	fmt.Fprintln(&box, "┌─ Session Status ─────────────────────────────────────────────────────┐")
+	fmt.Fprintln(&box, "│                                                                       │")
+	fmt.Fprintf(&box, "│  Session:    %-55s │\n", sessionName)
+	fmt.Fprintf(&box, "│  Bead:       %-55s │\n", sess.Bead)
+	fmt.Fprintf(&box, "│  Title:      %-55s │\n", truncate(title, 55))
+	fmt.Fprintf(&box, "│  Project:    %-55s │\n", sess.Project)
+	fmt.Fprintf(&box, "│  Branch:     %-55s │\n", branch)
+	fmt.Fprintf(&box, "│  Merge mode: %-55s │\n", mergeMode)
+	fmt.Fprintln(&box, "│                                                                       │")
+
+	if hasChanges {
+		fmt.Fprintln(&box, "│  Git:        ⚠ Uncommitted changes                                    │")
+	} else {
+		fmt.Fprintln(&box, "│  Git:        ✓ Clean                                                  │")
+	}
+
+	if prStatus != "" {
+		fmt.Fprintf(&box, "│  PR:         %-55s │\n", truncate(formatPRStatus(prStatus, prURL), 55))
+	}
+
+	fmt.Fprintf(&box, "│  Signal:     %-55s │\n", truncate(formatSignal(status, sess.StatusMessage), 55))
+	if sess.AwaitingAck {
+		fmt.Fprintf(&box, "│  %-67s │\n", fmt.Sprintf("⏳ Waiting for ack: wt ack %s [message]", sessionName))
+	}
+	if workerReport != nil {
+		fmt.Fprintf(&box, "│  Report:     %-55s │\n", truncate(workerReport.Brief(), 55))
+		for _, q := range workerReport.OpenQuestions {
+			fmt.Fprintf(&box, "│  %-67s │\n", truncate("? "+q, 67))
+		}
+	}
+
+	if testEnv != nil {
+		fmt.Fprintln(&box, "│                                                                       │")
+		for _, line := range testEnvLines(testEnv) {
+			fmt.Fprintf(&box, "│  %-67s │\n", truncate(line, 67))
+		}
+	}
+
+	fmt.Fprintln(&box, "│                                                                       │")
+	fmt.Fprintln(&box, "└───────────────────────────────────────────────────────────────────────┘")
+	fmt.Print(plainText(box.String()))
+	if len(args) > 0 {
+		fmt.Printf("\nCommands: wt %s | wt close %s | wt kill %s\n", sessionName, sessionName, sessionName)
+	} else {
+		fmt.Println("\nCommands: wt done | wt abandon | wt signal <status>")
+	}
+
+	return nil
+}
+
+// findSessionByNameOrBead looks up a session by exact name, then by bead ID
+func findSessionByNameOrBead(state *session.State, nameOrBead string) (string, *session.Session) {
+	if sess, exists := state.Sessions[nameOrBead]; exists {
+		return nameOrBead, sess
+	}
+	for name, sess := range state.Sessions {
+		if sess.Bead == nameOrBead {
+			return name, sess
+		}
+	}
+	return "", nil
+}
+
+// formatPRStatus renders a PR state and URL for the status panel
+func formatPRStatus(prStatus, prURL string) string {
+	if prStatus == "none" || prURL == "" {
+		return prStatus
+	}
+	return prStatus + " " + prURL
+}
+
+// formatSignal renders the last signalled status and its message
+func formatSignal(status, message string) string {
+	if message == "" {
+		return status
+	}
+	return status + " - " + message
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/badri/wt/internal/project"
+	"github.com/badri/wt/internal/session"
+	"github.com/badri/wt/internal/testenv"
+)
+
+// maxServiceLines caps the status command output shown in 'wt status'
+const maxServiceLines = 8
+
+// TestEnvStatusJSON is the test environment part of 'wt status --json'
+type TestEnvStatusJSON struct {
+	PortOffset    int                  `json:"port_offset"`
+	PortEnv       string               `json:"port_env"`
+	Ports         []testenv.MappedPort `json:"ports,omitempty"`
+	Health        *testenv.Health      `json:"health,omitempty"`
+	Services      string               `json:"services,omitempty"`
+	ServicesError string               `json:"services_error,omitempty"`
+	DownSince     string               `json:"down_since,omitempty"` // torn down by 'wt teardown-all'
+}
+
+// probeTestEnv collects a session's test environment state. With probe
+// set, the project's health check and status command are run now.
+func probeTestEnv(proj *project.Project, name string, sess *session.Session, probe bool) *TestEnvStatusJSON {
+	if proj == nil || proj.TestEnv == nil {
+		if sess.PortOffset == 0 {
+			return nil
+		}
+		return &TestEnvStatusJSON{PortOffset: sess.PortOffset, PortEnv: "PORT_OFFSET"}
+	}
+
+	env := &TestEnvStatusJSON{
+		PortOffset: sess.PortOffset,
+		PortEnv:    proj.TestEnv.PortEnv,
+		Ports:      testenv.MapPorts(proj, sess.PortOffset),
+	}
+	if env.PortEnv == "" {
+		env.PortEnv = "PORT_OFFSET"
+	}
+	env.DownSince = sess.EnvDownAt
+	if !probe || env.DownSince != "" {
+		return env
+	}
+
+	vars := sessionVars(name, sess)
+	env.Health = testenv.CheckHealth(proj, vars, testenv.DefaultProbeTimeout)
+	services, err := testenv.ServiceStatus(proj, vars, testenv.DefaultProbeTimeout)
+	env.Services = services
+	if err != nil {
+		env.ServicesError = err.Error()
+	}
+	return env
+}
+
+// sessionVars returns the template variables of a session's hook and test
+// env commands
+func sessionVars(name string, sess *session.Session) project.CommandVars {
+	return project.CommandVars{
+		Worktree:   sess.Worktree,
+		Bead:       sess.Bead,
+		Session:    name,
+		Project:    sess.Project,
+		Branch:     sess.Branch,
+		PortOffset: sess.PortOffset,
+	}
+}
+
+// testEnvLines renders the test environment rows of the status box
+func testEnvLines(env *TestEnvStatusJSON) []string {
+	var lines []string
+	switch h := env.Health; {
+	case env.DownSince != "":
+		lines = append(lines, "Test env:    – torn down by wt teardown-all (bring back: wt setup-all)")
+	case h == nil:
+		lines = append(lines, "Test env:    – no health check run")
+	case h.Healthy:
+		lines = append(lines, fmt.Sprintf("Test env:    ✓ Healthy (%.1fs)", h.Duration.Seconds()))
+	default:
+		lines = append(lines, fmt.Sprintf("Test env:    ✗ Unhealthy: %s", h.Error))
+		if last := lastLine(h.Output); last != "" {
+			lines = append(lines, "             "+last)
+		}
+	}
+
+	lines = append(lines, fmt.Sprintf("Port offset: %d (%s)", env.PortOffset, env.PortEnv))
+	if len(env.Ports) > 0 {
+		var ports []string
+		for _, p := range env.Ports {
+			ports = append(ports, fmt.Sprintf("%s %d→%d", p.Name, p.Base, p.Port))
+		}
+		lines = append(lines, "Ports:       "+strings.Join(ports, ", "))
+	}
+
+	if env.Services != "" || env.ServicesError != "" {
+		header := "Services:"
+		if env.ServicesError != "" {
+			header = "Services:    ✗ " + env.ServicesError
+		}
+		lines = append(lines, header)
+		out := strings.Split(env.Services, "\n")
+		if env.Services == "" {
+			out = nil
+		}
+		for i, l := range out {
+			if i == maxServiceLines {
+				lines = append(lines, fmt.Sprintf("  … %d more lines", len(out)-maxServiceLines))
+				break
+			}
+			lines = append(lines, "  "+l)
+		}
+	}
+	return lines
+}
+
+// lastLine returns the last non-empty line of command output
+func lastLine(output string) string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/session"
+)
+
+// commandNames lists the top-level commands offered as did-you-mean suggestions
+var commandNames = []string{
+	"list", "new", "kill", "close", "done", "status", "signal", "abandon",
+	"watch", "seance", "projects", "ready", "create", "beads", "project",
+	"auto", "msg", "events", "doctor", "config", "pick", "back", "keys", "completion",
+	"version", "help", "handoff", "prime", "checkpoint", "hub", "task", "bead",
+	"audit", "ack", "clone", "shutdown", "resume-all", "teardown-all", "setup-all", "note", "nudge", "rollback", "import",
+	"depend", "block", "unblock", "pr", "open", "code", "pause", "resume", "retarget", "claims", "verify", "init", "split", "show", "sparse",
+	"migrate", "uninstall", "history",
+}
+
+// switchResult describes how a 'wt <arg>' argument resolved
+type switchResult struct {
+	Session     string   // Session to attach to, if resolved
+	Note        string   // Explanation when the match was not exact
+	Sessions    []string // Candidate sessions when ambiguous or misspelled
+	Commands    []string // Candidate commands when misspelled
+	Ambiguous   bool     // True when a bead prefix matched several sessions
+	unknownName string
+}
+
+// cmdSwitch attaches to a session by name or bead ID, falling back to fuzzy matching
+func cmdSwitch(cfg *config.Config, nameOrBead string) error {
+	state, err := session.LoadState(cfg)
+	if err != nil {
+		return err
+	}
+
+	result := resolveSwitchTarget(state, nameOrBead)
+	if result.Session != "" {
+		if result.Note != "" {
+			fmt.Println(result.Note)
+		}
+		if state.Sessions[result.Session].IsPaused() {
+			return fmt.Errorf("session '%s' is paused. Resume it with: wt resume %s", result.Session, result.Session)
+		}
+		return switchToSession(cfg, result.Session)
+	}
+	return result.err()
+}
+
+// resolveSwitchTarget resolves a session name or bead ID, tolerating bead ID
+// prefixes and typos in session names.
+func resolveSwitchTarget(state *session.State, input string) switchResult {
+	// Exact session name or bead ID
+	if name, sess := findSessionByNameOrBead(state, input); sess != nil {
+		return switchResult{Session: name}
+	}
+
+	names := make([]string, 0, len(state.Sessions))
+	for name := range state.Sessions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// Unambiguous bead ID prefix
+	var prefixed []string
+	for _, name := range names {
+		if b := state.Sessions[name].Bead; b != "" && strings.HasPrefix(b, input) {
+			prefixed = append(prefixed, name)
+		}
+	}
+	if len(prefixed) == 1 {
+		name := prefixed[0]
+		return switchResult{Session: name, Note: fmt.Sprintf("Matched bead %s (session '%s').", state.Sessions[name].Bead, name)}
+	}
+	if len(prefixed) > 1 {
+		return switchResult{Sessions: prefixed, Ambiguous: true, unknownName: input}
+	}
+
+	// Typos: compare against session names and their theme names
+	maxDist := maxTypoDistance(input)
+	sessionDist := make(map[string]int)
+	for _, name := range names {
+		d := levenshtein(input, name)
+		if theme := state.Sessions[name].ThemeName; theme != "" {
+			d = min(d, levenshtein(input, theme))
+		}
+		if d <= maxDist {
+			sessionDist[name] = d
+		}
+	}
+	sessions := sortByDistance(sessionDist)
+	commands := fuzzyMatches(input, commandNames, maxDist)
+
+	// Auto-attach only to a single clear winner that no command is as close to
+	if len(sessions) > 0 {
+		best := sessionDist[sessions[0]]
+		clear := len(sessions) == 1 || sessionDist[sessions[1]] > best
+		if clear && (len(commands) == 0 || levenshtein(input, commands[0]) > best) {
+			return switchResult{Session: sessions[0], Note: fmt.Sprintf("No session '%s' - switching to '%s'.", input, sessions[0])}
+		}
+	}
+
+	return switchResult{Sessions: sessions, Commands: commands, unknownName: input}
+}
+
+// err builds the error shown when no session could be chosen
+func (r switchResult) err() error {
+	if r.Ambiguous {
+		return fmt.Errorf("'%s' matches several beads:\n  %s", r.unknownName, strings.Join(r.Sessions, "\n  "))
+	}
+	if len(r.Sessions) == 0 && len(r.Commands) == 0 {
+		return fmt.Errorf("no session found for '%s'", r.unknownName)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("unknown command or session '%s'\n\nDid you mean:", r.unknownName))
+	for _, c := range r.Commands {
+		sb.WriteString(fmt.Sprintf("\n  wt %s", c))
+	}
+	for _, s := range r.Sessions {
+		sb.WriteString(fmt.Sprintf("\n  wt %s  (session)", s))
+	}
+	return fmt.Errorf("%s", sb.String())
+}
+
+// didYouMean returns a "Did you mean" hint for a mistyped subcommand, or ""
+func didYouMean(input string, candidates []string) string {
+	matches := fuzzyMatches(input, candidates, maxTypoDistance(input))
+	if len(matches) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("\nDid you mean: %s?", strings.Join(matches, ", "))
+}
+
+// maxTypoDistance returns how many edits are tolerated for an input of this length
+func maxTypoDistance(input string) int {
+	switch n := len(input); {
+	case n <= 3:
+		return 1
+	case n <= 7:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// fuzzyMatches returns candidates within maxDist edits of input, closest first
+func fuzzyMatches(input string, candidates []string, maxDist int) []string {
+	dist := make(map[string]int)
+	for _, c := range candidates {
+		if d := levenshtein(input, c); d <= maxDist {
+			dist[c] = d
+		}
+	}
+	return sortByDistance(dist)
+}
+
+// sortByDistance returns the keys ordered by distance, then alphabetically
+func sortByDistance(dist map[string]int) []string {
+	keys := make([]string, 0, len(dist))
+	for k := range dist {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if dist[keys[i]] != dist[keys[j]] {
+			return dist[keys[i]] < dist[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// levenshtein returns the edit distance between two strings
+func levenshtein(a, b string) int {
+	ra, rb := []rune(strings.ToLower(a)), []rune(strings.ToLower(b))
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/badri/wt/internal/session"
+)
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"alpha", "alpha", 0},
+		{"alpa", "alpha", 1},
+		{"stauts", "status", 2},
+		{"", "abc", 3},
+		{"Toast", "toast", 0},
+	}
+
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestFuzzyMatches(t *testing.T) {
+	got := fuzzyMatches("stats", commandNames, 2)
+	if len(got) == 0 || got[0] != "status" {
+		t.Errorf("fuzzyMatches(stats) = %v, want status first", got)
+	}
+
+	if got := fuzzyMatches("zzzzzz", commandNames, 2); len(got) != 0 {
+		t.Errorf("fuzzyMatches(zzzzzz) = %v, want none", got)
+	}
+}
+
+func TestResolveSwitchTarget(t *testing.T) {
+	state := &session.State{Sessions: map[string]*session.Session{
+		"alpha":      {Bead: "wt-a1b2"},
+		"bravo":      {Bead: "wt-c3d4"},
+		"wt-charlie": {Bead: "wt-c3x9", ThemeName: "charlie"},
+	}}
+
+	tests := []struct {
+		name          string
+		input         string
+		wantSession   string
+		wantAmbiguous bool
+		wantCommand   string
+	}{
+		{"exact name", "alpha", "alpha", false, ""},
+		{"exact bead", "wt-c3d4", "bravo", false, ""},
+		{"bead prefix", "wt-a1", "alpha", false, ""},
+		{"ambiguous bead prefix", "wt-c3", "", true, ""},
+		{"typo", "alpa", "alpha", false, ""},
+		{"typo of theme name", "charlei", "wt-charlie", false, ""},
+		{"command typo", "stauts", "", false, "status"},
+		{"nothing close", "qqqqqqqq", "", false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := resolveSwitchTarget(state, tt.input)
+			if result.Session != tt.wantSession {
+				t.Errorf("Session = %q, want %q", result.Session, tt.wantSession)
+			}
+			if result.Ambiguous != tt.wantAmbiguous {
+				t.Errorf("Ambiguous = %v, want %v", result.Ambiguous, tt.wantAmbiguous)
+			}
+			if tt.wantCommand != "" && (len(result.Commands) == 0 || result.Commands[0] != tt.wantCommand) {
+				t.Errorf("Commands = %v, want %q first", result.Commands, tt.wantCommand)
+			}
+		})
+	}
+}
+
+func TestSwitchResultErr(t *testing.T) {
+	err := switchResult{Commands: []string{"status"}, unknownName: "stauts"}.err()
+	if !strings.Contains(err.Error(), "Did you mean") || !strings.Contains(err.Error(), "wt status") {
+		t.Errorf("err() = %q, want did-you-mean with wt status", err)
+	}
+
+	err = switchResult{unknownName: "nope"}.err()
+	if err.Error() != "no session found for 'nope'" {
+		t.Errorf("err() = %q", err)
+	}
+}
+
+func TestDidYouMean(t *testing.T) {
+	if got := didYouMean("ad", []string{"add", "config", "remove"}); got != "\nDid you mean: add?" {
+		t.Errorf("didYouMean(ad) = %q", got)
+	}
+	if got := didYouMean("xyz", []string{"add", "config", "remove"}); got != "" {
+		t.Errorf("didYouMean(xyz) = %q, want empty", got)
+	}
+}
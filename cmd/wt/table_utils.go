@@ -24,6 +24,9 @@ func renderTable(title string, columns []table.Column, rows []table.Row) string
 	if len(rows) == 0 {
 		return ""
 	}
+	if outputPlain {
+		return renderPlainTable(title, columns, rows)
+	}
 
 	t := table.New(
 		table.WithColumns(columns),
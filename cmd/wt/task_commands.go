@@ -9,6 +9,8 @@ import (
 
 	"github.com/badri/wt/internal/config"
 	"github.com/badri/wt/internal/events"
+	"github.com/badri/wt/internal/githooks"
+	"github.com/badri/wt/internal/logging"
 	"github.com/badri/wt/internal/namepool"
 	"github.com/badri/wt/internal/project"
 	"github.com/badri/wt/internal/session"
@@ -199,13 +201,17 @@ func cmdTask(cfg *config.Config, args []string) error {
 			sessionName = "task-" + themeName
 		}
 	}
+	sessionName, err = claimSessionName(sessionName, flags.name != "")
+	if err != nil {
+		return err
+	}
 
 	// Create branch name from sanitized description
 	branchName := sanitizeBranchName("task/" + description)
 
 	// Create worktree
-	worktreePath := cfg.WorktreePath(sessionName)
-	fmt.Printf("Creating git worktree at %s...\n", worktreePath)
+	worktreePath := cfg.ProjectWorktreePath(projectName, sessionName)
+	logging.Infof("Creating git worktree at %s...", worktreePath)
 
 	// Get default branch to branch from
 	defaultBranch := "main"
@@ -217,6 +223,13 @@ func cmdTask(cfg *config.Config, args []string) error {
 		return fmt.Errorf("creating worktree: %w", err)
 	}
 
+	// Install project git hooks (tasks have no bead, so the bead ID check is skipped)
+	if proj != nil {
+		installGitHooks(proj, worktreePath, githooks.Vars{Session: sessionName, Project: proj.Name, Branch: branchName})
+	}
+	configureSigning(proj, worktreePath)
+	provisionWorktree(cfg, proj, worktreePath)
+
 	// Determine BEADS_DIR (main repo's .beads, even for tasks)
 	beadsDir := repoPath + "/.beads"
 
@@ -233,37 +246,50 @@ func cmdTask(cfg *config.Config, args []string) error {
 		fmt.Printf("Allocated %s=%d\n", portEnv, portOffset)
 	}
 
+	ag, err := projectAgent(proj)
+	if err != nil {
+		worktree.Remove(worktreePath)
+		return err
+	}
+
+	vars := project.CommandVars{Worktree: worktreePath, Session: sessionName, Branch: branchName, PortOffset: portOffset}
+	if proj != nil {
+		vars.Project = proj.Name
+	}
+
 	// Create tmux session
-	fmt.Printf("Creating tmux session '%s'...\n", sessionName)
+	logging.Infof("Creating tmux session '%s'...", sessionName)
 	tmuxOpts := &tmux.SessionOptions{
 		PortOffset: portOffset,
 		PortEnv:    portEnv,
+		Windows:    sessionWindows(proj, vars),
 	}
-	if err := tmux.NewSession(sessionName, worktreePath, beadsDir, cfg.EditorCmd, tmuxOpts); err != nil {
+	if err := tmux.NewSession(sessionName, worktreePath, beadsDir, agentCommand(cfg, proj, ag), tmuxOpts); err != nil {
 		worktree.Remove(worktreePath)
 		return fmt.Errorf("creating tmux session: %w", err)
 	}
+	tagTmuxSession(cfg, sessionName, "")
 
 	// Run test env setup if configured and not skipped
 	if proj != nil && proj.TestEnv != nil && proj.TestEnv.Setup != "" && !flags.noTestEnv {
-		fmt.Println("Running test environment setup...")
-		if err := testenv.RunSetup(proj, worktreePath, portOffset); err != nil {
-			fmt.Printf("Warning: test env setup failed: %v\n", err)
+		logging.Infof("Running test environment setup...")
+		if err := testenv.RunSetup(proj, vars); err != nil {
+			logging.Warnf("test env setup failed: %v", err)
 		}
 
 		if proj.TestEnv.HealthCheck != "" {
-			fmt.Println("Waiting for test environment to be ready...")
-			if err := testenv.WaitForHealthy(proj, worktreePath, portOffset, 30*time.Second); err != nil {
-				fmt.Printf("Warning: health check failed: %v\n", err)
+			logging.Infof("Waiting for test environment to be ready...")
+			if err := testenv.WaitForHealthy(proj, vars, 30*time.Second); err != nil {
+				logging.Warnf("health check failed: %v", err)
 			}
 		}
 	}
 
 	// Run on_create hooks if configured
 	if proj != nil && proj.Hooks != nil && len(proj.Hooks.OnCreate) > 0 {
-		fmt.Println("Running on_create hooks...")
-		if err := testenv.RunOnCreateHooks(proj, worktreePath, portOffset, portEnv); err != nil {
-			fmt.Printf("Warning: on_create hook failed: %v\n", err)
+		logging.Infof("Running on_create hooks...")
+		if err := testenv.RunOnCreateHooks(proj, vars, portEnv); err != nil {
+			logging.Warnf("on_create hook failed: %v", err)
 		}
 	}
 
@@ -281,6 +307,7 @@ func cmdTask(cfg *config.Config, args []string) error {
 		TaskDescription:     description,
 		CompletionCondition: condition,
 		ThemeName:           themeName, // Track allocated name for namepool deduplication
+		Agent:               ag.Name,
 	}
 	sess.UpdateActivity()
 
@@ -299,25 +326,9 @@ func cmdTask(cfg *config.Config, args []string) error {
 	fmt.Printf("  Branch:     %s\n", branchName)
 	fmt.Printf("  Condition:  %s\n", condition)
 
-	// Wait for Claude to start
-	fmt.Println("Waiting for Claude to start...")
-	if err := tmux.WaitForClaude(sessionName, 60*time.Second); err != nil {
-		fmt.Printf("Warning: %v (sending prompt anyway)\n", err)
-	}
-
-	// Accept bypass permissions warning if present
-	if err := tmux.AcceptBypassPermissionsWarning(sessionName); err != nil {
-		fmt.Printf("Warning: could not accept bypass warning: %v\n", err)
-	}
-
-	time.Sleep(2 * time.Second)
-
-	// Send initial task prompt
-	fmt.Println("Sending initial prompt to worker...")
-	prompt := buildTaskPrompt(description, condition, sessionName, proj)
-	if err := tmux.NudgeSession(sessionName, prompt); err != nil {
-		fmt.Printf("Warning: could not send initial prompt: %v\n", err)
-	}
+	// Wait for the agent to start, then send the initial task prompt
+	waitForAgent(sessionName, ag)
+	sendInitialPrompt(sessionName, ag, buildTaskPrompt(description, condition, sessionName, proj))
 
 	// Determine if we should switch
 	shouldSwitch := !flags.noSwitch
@@ -327,8 +338,8 @@ func cmdTask(cfg *config.Config, args []string) error {
 	}
 
 	if shouldSwitch {
-		fmt.Println("\nSwitching...")
-		return tmux.Attach(sessionName)
+		logging.Infof("\nSwitching...")
+		return switchToSession(cfg, sessionName)
 	}
 
 	return nil
@@ -363,7 +374,7 @@ func sanitizeBranchName(s string) string {
 
 // cmdDoneTask completes a task session based on its completion condition
 func cmdDoneTask(cfg *config.Config, state *session.State, sessionName string, sess *session.Session, cwd string) error {
-	fmt.Printf("Completing task session '%s'...\n", sessionName)
+	logging.Infof("Completing task session '%s'...", sessionName)
 	fmt.Printf("  Task:      %s\n", sess.TaskDescription)
 	fmt.Printf("  Branch:    %s\n", sess.Branch)
 	fmt.Printf("  Condition: %s\n", sess.CompletionCondition)
@@ -405,44 +416,44 @@ func cmdDoneTask(cfg *config.Config, state *session.State, sessionName string, s
 		fmt.Println("\n User confirmation assumed.")
 	}
 
-	// Run teardown hooks if configured
+	// Run teardown hooks if configured (wt teardown-all may have run them)
 	mgr := project.NewManager(cfg)
-	if proj, _ := mgr.Get(sess.Project); proj != nil {
+	if proj, _ := mgr.Get(sess.Project); proj != nil && sess.EnvDownAt == "" {
 		if proj.TestEnv != nil && proj.TestEnv.Teardown != "" {
-			fmt.Println("Running test environment teardown...")
-			if err := testenv.RunTeardown(proj, sess.Worktree, sess.PortOffset); err != nil {
-				fmt.Printf("Warning: teardown failed: %v\n", err)
+			logging.Infof("Running test environment teardown...")
+			if err := testenv.RunTeardown(proj, sessionVars(sessionName, sess)); err != nil {
+				logging.Warnf("teardown failed: %v", err)
 			}
 		}
 
 		if proj.Hooks != nil && len(proj.Hooks.OnClose) > 0 {
-			fmt.Println("Running on_close hooks...")
+			logging.Infof("Running on_close hooks...")
 			portEnv := ""
 			if proj.TestEnv != nil {
 				portEnv = proj.TestEnv.PortEnv
 			}
-			if err := testenv.RunOnCloseHooks(proj, sess.Worktree, sess.PortOffset, portEnv); err != nil {
-				fmt.Printf("Warning: on_close hook failed: %v\n", err)
+			if err := testenv.RunOnCloseHooks(proj, sessionVars(sessionName, sess), portEnv); err != nil {
+				logging.Warnf("on_close hook failed: %v", err)
 			}
 		}
 	}
 
 	// Kill tmux session
-	fmt.Println("Terminating tmux session...")
+	logging.Infof("Terminating tmux session...")
 	if err := tmux.Kill(sessionName); err != nil {
-		fmt.Printf("Warning: %v\n", err)
+		logging.Warnf("%v", err)
 	}
 
 	// Remove worktree
 	fmt.Printf("Removing worktree: %s\n", sess.Worktree)
 	if err := worktree.Remove(sess.Worktree); err != nil {
-		fmt.Printf("Warning: %v\n", err)
+		logging.Warnf("%v", err)
 	}
 
 	// Log session end event
 	eventLogger := events.NewLogger(cfg)
 	claudeSession := getClaudeSessionID(sess.Worktree)
-	eventLogger.LogSessionEnd(sessionName, "task:"+sess.TaskDescription, sess.Project, claudeSession, "task-completed", "")
+	eventLogger.LogSessionEndWithSummary(sessionName, "task:"+sess.TaskDescription, sess.Project, claudeSession, "task-completed", "", withLastMessage(nil, sess.Worktree, claudeSession))
 
 	// Remove from state
 	delete(state.Sessions, sessionName)
@@ -456,7 +467,7 @@ func cmdDoneTask(cfg *config.Config, state *session.State, sessionName string, s
 
 // checkChangesPushed verifies that changes have been pushed to remote
 func checkChangesPushed(cwd string) error {
-	cmd := exec.Command("git", "-C", cwd, "status", "-sb")
+	cmd := logging.Command("git", "-C", cwd, "status", "-sb")
 	output, err := cmd.Output()
 	if err != nil {
 		return fmt.Errorf("checking git status: %w", err)
@@ -498,8 +509,7 @@ func extractPRURL(message string) string {
 // checkPRMerged verifies that a PR has been merged
 func checkPRMerged(cwd, prURL string) error {
 	// Use gh CLI to check PR status
-	cmd := exec.Command("gh", "pr", "view", prURL, "--json", "state", "-q", ".state")
-	cmd.Dir = cwd
+	cmd := logging.CommandIn(cwd, "gh", "pr", "view", prURL, "--json", "state", "-q", ".state")
 	output, err := cmd.Output()
 	if err != nil {
 		return fmt.Errorf("checking PR status: %w", err)
@@ -545,8 +555,7 @@ func runTestsAndCheck(cwd string) error {
 		}
 
 		fmt.Printf("Running: %s\n", strings.Join(testCmd, " "))
-		cmd := exec.Command(testCmd[0], testCmd[1:]...)
-		cmd.Dir = cwd
+		cmd := logging.CommandIn(cwd, testCmd[0], testCmd[1:]...)
 		cmd.Stdout = os.Stdout
 		cmd.Stderr = os.Stderr
 		if err := cmd.Run(); err != nil {
@@ -559,11 +568,12 @@ func runTestsAndCheck(cwd string) error {
 }
 
 // buildTaskPrompt creates the prompt to send to Claude for a task session
-func buildTaskPrompt(description string, condition session.CompletionCondition, sessionName string, _ *project.Project) string {
+func buildTaskPrompt(description string, condition session.CompletionCondition, sessionName string, proj *project.Project) string {
 	var sb strings.Builder
 
 	sb.WriteString(fmt.Sprintf("Task: %s\n\n", description))
 	sb.WriteString("This is a lightweight task session (not a bead).\n\n")
+	sb.WriteString(proj.GuidelinesPrompt())
 
 	sb.WriteString("Workflow:\n")
 	sb.WriteString("1. Complete the task described above\n")
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/monitor"
+	"github.com/badri/wt/internal/session"
+	"github.com/badri/wt/internal/tmux"
+)
+
+// tmuxStatusRight is the status-right of sessions tagged with tmux_status.
+// tmux expands #{session_name} before running the command.
+const tmuxStatusRight = "#(wt status --short #{session_name} 2>/dev/null) %H:%M "
+
+// tagTmuxSession shows the session's bead, status and idle time in its tmux
+// window name and status line when tmux_status is enabled
+func tagTmuxSession(cfg *config.Config, name, label string) {
+	if !cfg.TmuxStatus {
+		return
+	}
+	if label == "" {
+		label = name
+	}
+	if err := tmux.TagSession(name, label, tmuxStatusRight); err != nil {
+		logging.Warnf("could not set tmux status line: %v", err)
+	}
+}
+
+// cmdStatusShort prints a one-line status for tmux status lines. Without a
+// name it uses the session of the current directory or tmux session.
+func cmdStatusShort(cfg *config.Config, nameOrBead string) error {
+	state, err := session.LoadState(cfg)
+	if err != nil {
+		return err
+	}
+
+	var name string
+	var sess *session.Session
+	if nameOrBead != "" {
+		name, sess = findSessionByNameOrBead(state, nameOrBead)
+	} else {
+		name, sess = currentNoteSession(state)
+	}
+	if sess == nil {
+		return fmt.Errorf("not a wt session")
+	}
+
+	status := sess.Status
+	if status == "" {
+		status = monitor.DetectStatus(name, 5)
+	}
+	idle := monitor.GetIdleMinutes(name)
+	if cfg.UseTranscriptActivity() {
+		if activity, minutes := monitor.DetectActivity(name, sess.Worktree, 5*time.Minute); activity != "" {
+			status, idle = activity, minutes
+		}
+	}
+
+	label := sess.Bead
+	if label == "" {
+		label = name
+	}
+	fmt.Println(shortStatusLine(label, status, idle))
+	return nil
+}
+
+// shortStatusLine formats a session for a status line, e.g.
+// "wt-abc · blocked · idle 12m"
+func shortStatusLine(label, status string, idleMinutes int) string {
+	line := label + " · " + status
+	switch {
+	case idleMinutes >= 60:
+		line += fmt.Sprintf(" · idle %dh%02dm", idleMinutes/60, idleMinutes%60)
+	case idleMinutes > 0:
+		line += fmt.Sprintf(" · idle %dm", idleMinutes)
+	}
+	return line
+}
@@ -0,0 +1,20 @@
+package main
+
+import "testing"
+
+func TestShortStatusLine(t *testing.T) {
+	tests := []struct {
+		label, status string
+		idle          int
+		want          string
+	}{
+		{"wt-abc", "working", 0, "wt-abc · working"},
+		{"wt-abc", "blocked", 12, "wt-abc · blocked · idle 12m"},
+		{"toast", "idle", 135, "toast · idle · idle 2h15m"},
+	}
+	for _, tt := range tests {
+		if got := shortStatusLine(tt.label, tt.status, tt.idle); got != tt.want {
+			t.Errorf("shortStatusLine(%q, %q, %d) = %q, want %q", tt.label, tt.status, tt.idle, got, tt.want)
+		}
+	}
+}
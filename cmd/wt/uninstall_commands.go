@@ -0,0 +1,240 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/badri/wt/internal/auto"
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/hub"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/session"
+	"github.com/badri/wt/internal/tmux"
+	"github.com/badri/wt/internal/worktree"
+)
+
+type uninstallFlags struct {
+	purge bool
+	force bool // discard unsaved work in worktrees
+	yes   bool // skip the confirmation
+}
+
+func parseUninstallFlags(args []string) (uninstallFlags, error) {
+	var flags uninstallFlags
+	for _, arg := range args {
+		switch arg {
+		case "--purge":
+			flags.purge = true
+		case "--force":
+			flags.force = true
+		case "--yes", "-y":
+			flags.yes = true
+		default:
+			return flags, fmt.Errorf("unknown flag: %s", arg)
+		}
+	}
+	return flags, nil
+}
+
+// cmdUninstall tears down every session and deletes wt's config and state.
+// Without --purge it only shows what would go.
+func cmdUninstall(cfg *config.Config, args []string) error {
+	flags, err := parseUninstallFlags(args)
+	if err != nil {
+		return err
+	}
+	if err := checkPurgeTarget(cfg.ConfigDir()); err != nil {
+		return err
+	}
+
+	runs, err := auto.RunningRuns(cfg)
+	if err != nil {
+		return fmt.Errorf("checking wt auto runs: %w", err)
+	}
+	if len(runs) > 0 {
+		var projects []string
+		for _, run := range runs {
+			projects = append(projects, run.Project)
+		}
+		return fmt.Errorf("wt auto is running for %s; stop it with 'wt auto --stop' first", strings.Join(projects, ", "))
+	}
+
+	state, err := session.LoadState(cfg)
+	if err != nil {
+		return fmt.Errorf("loading sessions: %w", err)
+	}
+	names := sessionNames(state)
+	sort.Strings(names)
+
+	printUninstallPlan(cfg, state, names)
+	if !flags.purge {
+		fmt.Println("\nRun 'wt uninstall --purge' to do it.")
+		return nil
+	}
+
+	if unsaved := sessionsWithUnsavedWork(state, names); len(unsaved) > 0 && !flags.force {
+		return fmt.Errorf("session(s) with work not saved anywhere else: %s; save it or use --force to discard it", strings.Join(unsaved, ", "))
+	}
+	if !flags.yes && !confirm("\nThis cannot be undone. Continue?", false) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	for _, name := range names {
+		fmt.Println()
+		if err := cmdKill(cfg, name, killFlags{force: true}); err != nil {
+			logging.Warnf("%s: %v", name, err)
+		}
+	}
+	for _, root := range cfg.WorktreeRoots() {
+		removeEmptyDirs(root)
+	}
+	if err := purgeConfigDir(cfg); err != nil {
+		return fmt.Errorf("removing %s: %w", cfg.ConfigDir(), err)
+	}
+
+	fmt.Printf("\n✓ Removed wt's sessions, worktrees and state (%s)\n", cfg.ConfigDir())
+	if tmux.SessionExists(hub.HubSessionName) {
+		fmt.Printf("  The hub session is still running: tmux kill-session -t %s\n", hub.HubSessionName)
+	}
+	if exe, err := os.Executable(); err == nil {
+		fmt.Printf("  To remove wt itself: rm %s\n", exe)
+	}
+	return nil
+}
+
+// printUninstallPlan lists what 'wt uninstall --purge' removes
+func printUninstallPlan(cfg *config.Config, state *session.State, names []string) {
+	fmt.Println("wt uninstall --purge removes:")
+	if len(names) == 0 {
+		fmt.Println("  Sessions:  none")
+	} else {
+		fmt.Printf("  Sessions:  %d, with their tmux sessions, test envs and worktrees\n", len(names))
+		for _, name := range names {
+			fmt.Printf("    %-16s %s\n", name, state.Sessions[name].Worktree)
+		}
+	}
+	fmt.Printf("  State:     %s (config, projects, events, auto state)\n", cfg.ConfigDir())
+	if others := otherProfiles(cfg); len(others) > 0 {
+		fmt.Printf("  Kept:      profiles %s (uninstall each with --profile)\n", strings.Join(others, ", "))
+	}
+	fmt.Println("  Beads stay in their projects; claimed ones are released.")
+}
+
+// sessionsWithUnsavedWork returns the sessions whose worktree has changes
+// or commits that exist nowhere else
+func sessionsWithUnsavedWork(state *session.State, names []string) []string {
+	var unsaved []string
+	for _, name := range names {
+		wt := state.Sessions[name].Worktree
+		if !worktree.Exists(wt) {
+			continue
+		}
+		if found, err := worktree.FindUnsaved(wt); err == nil && !found.Empty() {
+			unsaved = append(unsaved, name)
+		}
+	}
+	return unsaved
+}
+
+// checkPurgeTarget refuses config dirs whose removal would take more than
+// wt's own files with it
+func checkPurgeTarget(dir string) error {
+	home, _ := os.UserHomeDir()
+	clean := filepath.Clean(dir)
+	if dir == "" || clean == "/" || clean == filepath.Clean(home) {
+		return fmt.Errorf("refusing to remove config dir %q", dir)
+	}
+	return nil
+}
+
+// purgeConfigDir removes the config dir. The default profile's dir holds
+// the other profiles, which are kept along with the current profile choice.
+func purgeConfigDir(cfg *config.Config) error {
+	dir := cfg.ConfigDir()
+	if len(otherProfiles(cfg)) == 0 {
+		return os.RemoveAll(dir)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if e.Name() == "profiles" || e.Name() == "current_profile" {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(dir, e.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// otherProfiles returns the named profiles stored inside the config dir,
+// which only the default profile has
+func otherProfiles(cfg *config.Config) []string {
+	if cfg.Profile() != config.DefaultProfile {
+		return nil
+	}
+	profiles, err := config.ListProfiles(cfg.ConfigDir())
+	if err != nil {
+		return nil
+	}
+	var others []string
+	for _, p := range profiles {
+		if p != config.DefaultProfile {
+			others = append(others, p)
+		}
+	}
+	return others
+}
+
+// removeEmptyDirs removes the empty project directories of a worktree root,
+// then the root itself if nothing is left in it
+func removeEmptyDirs(root string) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		if e.IsDir() {
+			os.Remove(filepath.Join(root, e.Name())) // fails unless empty
+		}
+	}
+	os.Remove(root)
+}
+
+func cmdUninstallHelp() error {
+	help := `wt uninstall - Tear down all sessions and delete wt's state
+
+USAGE:
+    wt uninstall [--purge] [options]
+
+DESCRIPTION:
+    Without --purge, lists what would be removed. With --purge, after a
+    confirmation, every session is killed as with 'wt kill' (test env
+    teardown, tmux session, worktree; its bead is released), emptied
+    worktree directories are removed, and the config dir is deleted:
+    ~/.config/wt, or the active profile's. Named profiles kept under
+    ~/.config/wt/profiles survive purging the default profile.
+
+    Refuses while a wt auto run is active, and while a worktree has work
+    not saved anywhere else unless --force is given. Beads, branches and
+    the wt binary are left alone.
+
+OPTIONS:
+    --purge             Actually remove everything
+    --force             Discard unsaved work in worktrees
+    -y, --yes           Don't ask for confirmation
+    -h, --help          Show this help
+
+EXAMPLES:
+    wt uninstall                  Show what would be removed
+    wt uninstall --purge          Remove it all, after confirming
+`
+	fmt.Print(help)
+	return nil
+}
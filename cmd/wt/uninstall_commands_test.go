@@ -0,0 +1,62 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/badri/wt/internal/config"
+)
+
+func TestPurgeConfigDirKeepsOtherProfiles(t *testing.T) {
+	dir := t.TempDir()
+	cfg, err := config.LoadFromDir(dir)
+	if err != nil {
+		t.Fatalf("LoadFromDir() error: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "profiles", "work"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "projects"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := purgeConfigDir(cfg); err != nil {
+		t.Fatalf("purgeConfigDir() error: %v", err)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 || entries[0].Name() != "profiles" {
+		t.Errorf("config dir after purge = %v, want only profiles", entries)
+	}
+
+	// Without other profiles the whole dir goes
+	os.RemoveAll(filepath.Join(dir, "profiles"))
+	if err := purgeConfigDir(cfg); err != nil {
+		t.Fatalf("purgeConfigDir() error: %v", err)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("config dir still exists: %v", err)
+	}
+}
+
+func TestCheckPurgeTarget(t *testing.T) {
+	home, _ := os.UserHomeDir()
+	for _, dir := range []string{"", "/", home} {
+		if err := checkPurgeTarget(dir); err == nil {
+			t.Errorf("checkPurgeTarget(%q) = nil, want an error", dir)
+		}
+	}
+	if err := checkPurgeTarget(filepath.Join(home, ".config", "wt")); err != nil {
+		t.Errorf("checkPurgeTarget(~/.config/wt) = %v", err)
+	}
+}
+
+func TestParseUninstallFlags(t *testing.T) {
+	flags, err := parseUninstallFlags([]string{"--purge", "--force", "-y"})
+	if err != nil || !flags.purge || !flags.force || !flags.yes {
+		t.Errorf("parseUninstallFlags() = %+v, %v", flags, err)
+	}
+	if _, err := parseUninstallFlags([]string{"--all"}); err == nil {
+		t.Error("parseUninstallFlags(--all) = nil error")
+	}
+}
@@ -0,0 +1,404 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/events"
+	"github.com/badri/wt/internal/hub"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/merge"
+	"github.com/badri/wt/internal/monitor"
+	"github.com/badri/wt/internal/project"
+	"github.com/badri/wt/internal/session"
+	"github.com/badri/wt/internal/tmux"
+	"github.com/badri/wt/internal/verify"
+)
+
+// verifyJob identifies the merge a verification run checks
+type verifyJob struct {
+	project string
+	session string
+	bead    string
+	commit  string // merge commit on the default branch, if known
+	prURL   string // PR that merged, for auto-merge PRs
+}
+
+// verifyArgs holds the parsed arguments of 'wt verify'
+type verifyArgs struct {
+	target     string
+	job        verifyJob
+	background bool
+}
+
+func parseVerifyArgs(args []string) (*verifyArgs, error) {
+	va := &verifyArgs{}
+	for i := 0; i < len(args); i++ {
+		var dest *string
+		switch args[i] {
+		case "-p", "--project":
+			dest = &va.job.project
+		case "--commit":
+			dest = &va.job.commit
+		case "--bead":
+			dest = &va.job.bead
+		case "--session":
+			dest = &va.job.session
+		case "--pr":
+			dest = &va.job.prURL
+		case "--background":
+			va.background = true
+			continue
+		default:
+			if strings.HasPrefix(args[i], "-") || va.target != "" {
+				return nil, fmt.Errorf("unknown argument: %s", args[i])
+			}
+			va.target = args[i]
+			continue
+		}
+		if i+1 >= len(args) {
+			return nil, fmt.Errorf("%s requires a value", args[i])
+		}
+		*dest = args[i+1]
+		i++
+	}
+	return va, nil
+}
+
+func cmdVerify(cfg *config.Config, args []string) error {
+	if len(args) > 0 && args[0] == "revert" {
+		if len(args) != 2 {
+			return fmt.Errorf("usage: wt verify revert <session|bead>")
+		}
+		return cmdVerifyRevert(cfg, args[1])
+	}
+
+	va, err := parseVerifyArgs(args)
+	if err != nil {
+		return err
+	}
+	job := va.job
+	if va.target != "" {
+		mergeEvent, _, err := events.NewLogger(cfg).FindMerge(va.target)
+		if err != nil {
+			return fmt.Errorf("reading events: %w", err)
+		}
+		if mergeEvent == nil {
+			return fmt.Errorf("no direct merge recorded for '%s'. Use -p <project> to verify a project's default branch", va.target)
+		}
+		job = verifyJob{project: mergeEvent.Project, session: mergeEvent.Session, bead: mergeEvent.Bead, commit: mergeEvent.MergeCommit}
+	}
+	if job.project == "" {
+		return fmt.Errorf("usage: wt verify <session|bead> or wt verify -p <project>")
+	}
+
+	proj, err := project.NewManager(cfg).Get(job.project)
+	if err != nil {
+		return err
+	}
+	if proj.Verify == nil || proj.Verify.Command == "" {
+		return fmt.Errorf("project '%s' has no verify command. Set \"verify\": {\"command\": \"...\"} with 'wt project config %s'", proj.Name, proj.Name)
+	}
+	if va.background {
+		return startVerification(cfg, proj, job)
+	}
+	return runVerification(cfg, proj, job)
+}
+
+// startVerification runs 'wt verify' for a merge in the background, so
+// wt done doesn't wait for it and it survives the session being killed.
+// Output goes to verify/<bead>.log in the config directory.
+func startVerification(cfg *config.Config, proj *project.Project, job verifyJob) error {
+	exe, err := os.Executable()
+	if err != nil {
+		exe = "wt"
+	}
+	logDir := filepath.Join(cfg.ConfigDir(), "verify")
+	if err := os.MkdirAll(logDir, 0755); err != nil {
+		return err
+	}
+	name := job.bead
+	if name == "" {
+		name = proj.Name
+	}
+	logPath := filepath.Join(logDir, name+".log")
+	logFile, err := os.Create(logPath)
+	if err != nil {
+		return err
+	}
+	defer logFile.Close()
+
+	args := []string{"verify", "--project", proj.Name}
+	for _, f := range [][2]string{{"--commit", job.commit}, {"--bead", job.bead}, {"--session", job.session}, {"--pr", job.prURL}} {
+		if f[1] != "" {
+			args = append(args, f[0], f[1])
+		}
+	}
+	cmd := logging.Command(exe, args...)
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	cmd.Env = append(os.Environ(), config.ProfileEnv+"="+cfg.Profile())
+	// A new session keeps it running when wt done kills the worker's tmux
+	cmd.SysProcAttr = detachedProcAttr()
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("starting verification: %w", err)
+	}
+	fmt.Printf("Verifying %s in the background (log: %s)\n", proj.DefaultBranchName(), logPath)
+	return cmd.Process.Release()
+}
+
+// runVerification checks the default branch after a merge and, if it is
+// broken, tells the hub how to roll back or opens a revert PR
+func runVerification(cfg *config.Config, proj *project.Project, job verifyJob) error {
+	timeout, _ := time.ParseDuration(proj.Verify.Timeout)
+	branch := proj.DefaultBranchName()
+	fmt.Printf("Verifying %s of %s: %s\n", branch, proj.Name, proj.Verify.Command)
+
+	res, err := verify.Run(proj.RepoPath(), branch, proj.Verify.Command, timeout)
+	if err != nil {
+		return fmt.Errorf("verification could not run: %w", err)
+	}
+
+	logger := events.NewLogger(cfg)
+	if res.Passed {
+		fmt.Printf("✓ %s at %s passed in %s\n", branch, shortSHA(res.Commit), res.Duration)
+		logger.LogVerify(job.session, job.bead, proj.Name, job.commit, job.prURL, true, "")
+		return nil
+	}
+
+	fmt.Println(res.Output)
+	reason := "failed"
+	if res.TimedOut {
+		reason = "timed out"
+	}
+	fmt.Printf("✗ %s at %s %s after %s\n", branch, shortSHA(res.Commit), reason, res.Duration)
+	logger.LogVerify(job.session, job.bead, proj.Name, job.commit, job.prURL, false, res.Output)
+
+	fix := verifyFixHint(job)
+	if proj.Verify.RevertsOnFailure() && job.commit != "" {
+		if url, err := openRevertPR(proj, job); err != nil {
+			logging.Warnf("could not open revert PR: %v", err)
+		} else {
+			fix = "Revert PR: " + url
+		}
+	}
+	fmt.Println(fix)
+	notifyVerifyFailed(proj, job, branch, reason, fix)
+	return fmt.Errorf("%s is broken after merging %s", branch, verifySubject(job))
+}
+
+// verifySubject names what was merged, for messages
+func verifySubject(job verifyJob) string {
+	switch {
+	case job.bead != "":
+		return job.bead
+	case job.commit != "":
+		return shortSHA(job.commit)
+	default:
+		return "the latest changes"
+	}
+}
+
+// verifyFixHint is the one command that undoes the merge: wt rollback for
+// direct merges, a revert PR otherwise
+func verifyFixHint(job verifyJob) string {
+	switch {
+	case job.commit != "" && job.prURL == "" && job.session != "":
+		return fmt.Sprintf("Roll back with: wt rollback %s", job.session)
+	case job.commit != "" && job.bead != "":
+		return fmt.Sprintf("Open a revert PR with: wt verify revert %s", job.bead)
+	default:
+		return "Find the breaking merge with: git log --merges"
+	}
+}
+
+// notifyVerifyFailed raises a desktop notification and tells the hub
+func notifyVerifyFailed(proj *project.Project, job verifyJob, branch, reason, fix string) {
+	title := fmt.Sprintf("wt: %s %s broken", proj.Name, branch)
+	text := fmt.Sprintf("Verification %s after merging %s. %s", reason, verifySubject(job), fix)
+	if err := monitor.Notify(title, text); err != nil {
+		logging.Warnf("could not send notification: %v", err)
+	}
+	if tmux.SessionExists(hub.HubSessionName) {
+		if err := tmux.NudgeSession(hub.HubSessionName, "[wt] "+title+": "+text); err != nil {
+			logging.Warnf("could not notify the hub session: %v", err)
+		}
+	}
+}
+
+// openRevertPR opens a PR reverting the merge of a failed verification
+func openRevertPR(proj *project.Project, job verifyJob) (string, error) {
+	subject := verifySubject(job)
+	branch := "revert-" + strings.ReplaceAll(subject, "/", "-")
+	title := fmt.Sprintf("Revert %s: %s fails verification", subject, proj.DefaultBranchName())
+	body := fmt.Sprintf("Post-merge verification (`%s`) failed after merging %s (%s).", proj.Verify.Command, subject, job.commit)
+	if job.prURL != "" {
+		body += "\n\nReverts " + job.prURL
+	}
+	return merge.OpenRevertPR(proj.RepoPath(), job.commit, proj.DefaultBranchName(), branch, title, body)
+}
+
+// cmdVerifyRevert opens a revert PR for the merge behind a failed
+// verification
+func cmdVerifyRevert(cfg *config.Config, target string) error {
+	failure, err := events.NewLogger(cfg).FindVerifyFailure(target)
+	if err != nil {
+		return fmt.Errorf("reading events: %w", err)
+	}
+	if failure == nil || failure.MergeCommit == "" {
+		return fmt.Errorf("no failed verification with a merge commit recorded for '%s'", target)
+	}
+	proj, err := project.NewManager(cfg).Get(failure.Project)
+	if err != nil {
+		return err
+	}
+	if proj.Verify == nil {
+		proj.Verify = &project.Verify{}
+	}
+	job := verifyJob{project: failure.Project, session: failure.Session, bead: failure.Bead, commit: failure.MergeCommit, prURL: failure.PRURL}
+	url, err := openRevertPR(proj, job)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Revert PR: %s\n", url)
+	return nil
+}
+
+// verifyAfterMerge starts post-merge verification for a 'wt done' direct
+// merge, if the project has a verify command
+func verifyAfterMerge(cfg *config.Config, proj *project.Project, sessionName string, sess *session.Session, mergeCommit string) {
+	if proj.Verify == nil || proj.Verify.Command == "" {
+		return
+	}
+	job := verifyJob{project: proj.Name, session: sessionName, bead: sess.Bead, commit: mergeCommit}
+	if err := startVerification(cfg, proj, job); err != nil {
+		logging.Warnf("%v", err)
+	}
+}
+
+// recordVerifyPR remembers an auto-merge PR so the default branch is
+// verified once it merges (see syncVerifyPRs)
+func recordVerifyPR(cfg *config.Config, proj *project.Project, sessionName string, sess *session.Session, branch, prURL string) {
+	if proj.Verify == nil || proj.Verify.Command == "" {
+		return
+	}
+	err := verify.Update(cfg, func(store *verify.Store) error {
+		store.Put(&verify.Entry{
+			Bead:     sess.Bead,
+			Session:  sessionName,
+			Branch:   branch,
+			Project:  proj.Name,
+			RepoPath: proj.RepoPath(),
+			PRURL:    prURL,
+		})
+		return nil
+	})
+	if err != nil {
+		logging.Warnf("could not save pending verifications: %v", err)
+	}
+}
+
+// syncVerifyPRs starts verification for recorded auto-merge PRs that have
+// merged, and forgets PRs that were closed unmerged
+func syncVerifyPRs(cfg *config.Config) {
+	store, err := verify.Load(cfg)
+	if err != nil || len(store.Entries) == 0 {
+		return
+	}
+	var settled, merged []string
+	for beadID, e := range store.Entries {
+		switch status, _ := monitor.GetPRStatus(e.RepoPath, e.Branch); status {
+		case "merged":
+			merged = append(merged, beadID)
+			fallthrough
+		case "closed":
+			settled = append(settled, beadID)
+		}
+	}
+	if len(settled) == 0 {
+		return
+	}
+
+	// Take the entries out under the lock, so only one wt process verifies
+	// each merge and entries recorded meanwhile are kept
+	taken := make(map[string]*verify.Entry)
+	err = verify.Update(cfg, func(store *verify.Store) error {
+		for _, id := range settled {
+			if e := store.Get(id); e != nil {
+				taken[id] = e
+				store.Remove(id)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logging.Warnf("could not save pending verifications: %v", err)
+		return
+	}
+
+	mgr := project.NewManager(cfg)
+	for _, beadID := range merged {
+		e := taken[beadID]
+		if e == nil {
+			continue
+		}
+		proj, err := mgr.Get(e.Project)
+		if err != nil || proj.Verify == nil || proj.Verify.Command == "" {
+			continue
+		}
+		commit, _ := merge.PRMergeCommit(e.RepoPath, e.PRURL)
+		logging.Infof("PR for %s merged - verifying %s...", beadID, proj.DefaultBranchName())
+		job := verifyJob{project: e.Project, session: e.Session, bead: beadID, commit: commit, prURL: e.PRURL}
+		if err := startVerification(cfg, proj, job); err != nil {
+			logging.Warnf("%v", err)
+		}
+	}
+}
+
+// cmdVerifyHelp shows help for the verify command
+func cmdVerifyHelp() error {
+	help := `wt verify - Check the default branch after a merge
+
+USAGE:
+    wt verify <session|bead>
+    wt verify -p <project>
+    wt verify revert <session|bead>
+
+DESCRIPTION:
+    Runs the project's verify command (e.g. its build and tests) against
+    the tip of the default branch, in a temporary worktree. If it fails,
+    a desktop notification and a message to the hub give the one command
+    that undoes the merge: 'wt rollback' for direct merges, 'wt verify
+    revert' for PRs. With "on_failure": "revert" a revert PR is opened
+    right away.
+
+    With a verify command configured, this runs by itself in the
+    background after a direct-mode 'wt done', and after a pr-auto PR
+    merges (checked by 'wt pr sync', 'wt done' and 'wt close'). Logs are
+    in ~/.config/wt/verify/<bead>.log.
+
+    Configure it in the project config:
+        "verify": {"command": "go build ./... && go test ./...",
+                   "on_failure": "notify", "timeout": "20m"}
+
+COMMANDS:
+    revert <target>     Open a revert PR for the merge behind the last
+                        failed verification of a session or bead
+
+OPTIONS:
+    -p, --project <n>   Verify a project's default branch as it is now
+    --background        Run detached, logging to the verify directory
+    -h, --help          Show this help
+
+EXAMPLES:
+    wt verify toast                 Re-check main after toast's merge
+    wt verify -p myapp              Check myapp's main now
+    wt verify revert myapp-abc      Open a PR reverting myapp-abc's merge
+`
+	fmt.Print(help)
+	return nil
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseVerifyArgs(t *testing.T) {
+	va, err := parseVerifyArgs([]string{"--project", "web", "--commit", "abc123", "--bead", "web-a", "--background"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if va.job.project != "web" || va.job.commit != "abc123" || va.job.bead != "web-a" || !va.background || va.target != "" {
+		t.Errorf("parseVerifyArgs() = %+v", va)
+	}
+
+	for _, args := range [][]string{{"a", "b"}, {"--commit"}, {"--bogus"}} {
+		if _, err := parseVerifyArgs(args); err == nil {
+			t.Errorf("parseVerifyArgs(%v) should fail", args)
+		}
+	}
+}
+
+func TestVerifyFixHint(t *testing.T) {
+	tests := []struct {
+		job  verifyJob
+		want string
+	}{
+		{verifyJob{session: "toast", bead: "web-a", commit: "abc"}, "wt rollback toast"},
+		{verifyJob{session: "toast", bead: "web-a", commit: "abc", prURL: "https://example.com/pr/1"}, "wt verify revert web-a"},
+		{verifyJob{project: "web"}, "git log --merges"},
+	}
+	for _, tt := range tests {
+		if got := verifyFixHint(tt.job); !strings.Contains(got, tt.want) {
+			t.Errorf("verifyFixHint(%+v) = %q, want %q", tt.job, got, tt.want)
+		}
+	}
+}
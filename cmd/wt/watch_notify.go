@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/events"
+	"github.com/badri/wt/internal/monitor"
+)
+
+// A session turning error gets this many lines of its pane logged with the
+// session_error event, and the last few of them in the notification
+const (
+	errorPaneLines   = 20
+	errorNotifyLines = 5
+)
+
+// watchNotifier turns the session changes wt watch sees into desktop
+// notifications: a session turning idle, ready, blocked or error, its PR's
+// CI checks starting to fail, and a session ending. Delivery, including digest batching, is up to the
+// notifications section of the config.
+type watchNotifier struct {
+	notifier *monitor.Notifier
+	logger   *events.Logger
+
+	mu     sync.Mutex
+	prev   map[string]watchedStatus // session -> status at the last refresh
+	primed bool                     // the first refresh only records statuses
+}
+
+// watchedStatus is what a notification is built from
+type watchedStatus struct {
+	status  string
+	message string
+	checks  string // CI checks of the session's PR
+	bead    string
+	project string
+}
+
+func newWatchNotifier(cfg *config.Config) *watchNotifier {
+	return &watchNotifier{notifier: monitor.NewNotifier(cfg), logger: events.NewLogger(cfg), prev: make(map[string]watchedStatus)}
+}
+
+// observe compares all current sessions with the previous refresh, notifies
+// about the changes, and sends the digest when it is due
+func (w *watchNotifier) observe(current map[string]watchedStatus) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.primed {
+		for name, now := range current {
+			before, existed := w.prev[name]
+			if existed && before.status != now.status {
+				w.statusChanged(name, now)
+			}
+			if existed && now.checks == monitor.ChecksFailing && before.checks != monitor.ChecksFailing {
+				w.notifier.Send("checks", name, "wt: Checks Failing", fmt.Sprintf("Session '%s': CI checks are failing", name))
+			}
+		}
+		for name := range w.prev {
+			if _, ok := current[name]; !ok {
+				w.notifier.Send("ended", name, "wt: Session Ended", fmt.Sprintf("Session '%s' has ended", name))
+			}
+		}
+	}
+	w.prev = current
+	w.primed = true
+	w.notifier.Tick()
+}
+
+func (w *watchNotifier) statusChanged(name string, now watchedStatus) {
+	detail := func(fallback string) string {
+		if now.message != "" {
+			return fmt.Sprintf("Session '%s': %s", name, now.message)
+		}
+		return fmt.Sprintf(fallback, name)
+	}
+	switch now.status {
+	case "ready":
+		w.notifier.Send("ready", name, "wt: Ready for Review", detail("Session '%s' is ready for review"))
+	case "idle":
+		w.notifier.Send("idle", name, "wt: Session Idle", fmt.Sprintf("Session '%s' is now idle", name))
+	case "error":
+		w.notifier.Send("error", name, "wt: Session Error", w.sessionError(name, now, detail("Session '%s' has an error")))
+	case "blocked":
+		w.notifier.Send("blocked", name, "wt: Session Blocked", detail("Session '%s' is blocked"))
+	}
+}
+
+// sessionError logs a session_error event with the tail of the session's
+// pane, so the error can be read without attaching, and returns the
+// notification text with the last few lines appended
+func (w *watchNotifier) sessionError(name string, now watchedStatus, text string) string {
+	output, err := monitor.CaptureTail(name, errorPaneLines)
+	if err != nil {
+		output = ""
+	}
+	w.logger.LogSessionError(name, now.bead, now.project, now.message, output)
+	if tail := monitor.PaneTail(output, errorNotifyLines); tail != "" {
+		text += "\n" + tail
+	}
+	return text
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/monitor"
+)
+
+// Watch layouts
+const (
+	watchLayoutNormal  = ""
+	watchLayoutCompact = "compact"
+	watchLayoutWide    = "wide"
+)
+
+// watchOptions holds the flags for wt watch
+type watchOptions struct {
+	autoNudge bool
+	projects  []string // only show these projects (empty = all)
+	statuses  []string // only show these statuses (empty = all)
+	layout    string   // watchLayoutNormal, watchLayoutCompact or watchLayoutWide
+	append    bool     // print changes as log lines instead of running the TUI
+	order     sessionOrder
+}
+
+func parseWatchFlags(args []string) (watchOptions, error) {
+	var opts watchOptions
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--auto-nudge":
+			opts.autoNudge = true
+		case "-p", "--project":
+			if i+1 >= len(args) {
+				return opts, fmt.Errorf("%s requires a project name", args[i])
+			}
+			opts.projects = append(opts.projects, splitList(args[i+1])...)
+			i++
+		case "-s", "--status":
+			if i+1 >= len(args) {
+				return opts, fmt.Errorf("%s requires a status", args[i])
+			}
+			opts.statuses = append(opts.statuses, splitList(args[i+1])...)
+			i++
+		case "--compact":
+			opts.layout = watchLayoutCompact
+		case "--wide":
+			opts.layout = watchLayoutWide
+		case "--append":
+			opts.append = true
+		case "--sort":
+			if i+1 >= len(args) {
+				return opts, fmt.Errorf("--sort requires a key")
+			}
+			key, err := parseSortKey(args[i+1])
+			if err != nil {
+				return opts, err
+			}
+			opts.order.key = key
+			i++
+		case "--reverse":
+			opts.order.reverse = true
+		default:
+			return opts, fmt.Errorf("unknown flag: %s", args[i])
+		}
+	}
+	return opts, nil
+}
+
+// splitList splits a comma-separated flag value, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// matches reports whether a session passes the --project and --status filters
+func (o watchOptions) matches(item sessionItem) bool {
+	if len(o.projects) > 0 && !slices.Contains(o.projects, item.project) {
+		return false
+	}
+	if len(o.statuses) > 0 && !slices.Contains(o.statuses, item.status) {
+		return false
+	}
+	return true
+}
+
+// filterDescription summarises active filters for the watch header
+func (o watchOptions) filterDescription() string {
+	var parts []string
+	if len(o.projects) > 0 {
+		parts = append(parts, "project="+strings.Join(o.projects, ","))
+	}
+	if len(o.statuses) > 0 {
+		parts = append(parts, "status="+strings.Join(o.statuses, ","))
+	}
+	return strings.Join(parts, " ")
+}
+
+// runWatchAppend prints a log line whenever a session appears, changes or
+// ends, without clearing the screen, so the output can be redirected to a file.
+func runWatchAppend(cfg *config.Config, opts watchOptions) error {
+	var nudger *monitor.Nudger
+	if opts.autoNudge {
+		nudger = monitor.NewNudger(cfg.ConfigDir())
+	}
+	notes := newWatchNotifier(cfg)
+	perms := newPermissionWatcher(cfg, notes.notifier)
+	handoffs := newContextWatcher(cfg, notes.notifier)
+	comments := newCommentRelay(cfg)
+
+	prev := make(map[string]string)
+	for {
+		now := time.Now().Format("2006-01-02 15:04:05")
+		current := make(map[string]string)
+		for _, item := range collectWatchItems(cfg, opts, nudger, perms, notes, handoffs, comments) {
+			line := formatWatchLogLine(item)
+			current[item.name] = line
+			if prev[item.name] != line {
+				fmt.Printf("%s %s\n", now, line)
+			}
+		}
+		for name := range prev {
+			if _, ok := current[name]; !ok {
+				fmt.Printf("%s %s ended\n", now, name)
+			}
+		}
+		prev = current
+		time.Sleep(5 * time.Second)
+	}
+}
+
+// formatWatchLogLine renders a session as a single log line for --append.
+// Idle time is left out so that a line only changes when the session does.
+func formatWatchLogLine(item sessionItem) string {
+	fields := []string{item.name, "project=" + item.project, "bead=" + item.bead, "status=" + item.status}
+	if item.activity != "" {
+		fields = append(fields, "claude="+item.activity)
+	}
+	if item.stuckType != "" {
+		fields = append(fields, "stuck="+item.stuckType)
+	}
+	if item.message != "" {
+		fields = append(fields, fmt.Sprintf("message=%q", item.message))
+	}
+	return strings.Join(fields, " ")
+}
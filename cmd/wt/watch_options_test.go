@@ -0,0 +1,98 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseWatchFlags(t *testing.T) {
+	opts, err := parseWatchFlags([]string{"--project", "api,web", "-p", "cli", "--status", "ready", "--wide", "--append", "--auto-nudge"})
+	if err != nil {
+		t.Fatalf("parseWatchFlags() error: %v", err)
+	}
+	if want := []string{"api", "web", "cli"}; !slices.Equal(opts.projects, want) {
+		t.Errorf("projects = %v, want %v", opts.projects, want)
+	}
+	if want := []string{"ready"}; !slices.Equal(opts.statuses, want) {
+		t.Errorf("statuses = %v, want %v", opts.statuses, want)
+	}
+	if opts.layout != watchLayoutWide || !opts.append || !opts.autoNudge {
+		t.Errorf("parseWatchFlags() = %+v", opts)
+	}
+
+	opts, _ = parseWatchFlags([]string{"--wide", "--compact"})
+	if opts.layout != watchLayoutCompact {
+		t.Errorf("last layout flag should win, got %q", opts.layout)
+	}
+
+	for _, args := range [][]string{{"--project"}, {"--status"}, {"--tall"}} {
+		if _, err := parseWatchFlags(args); err == nil {
+			t.Errorf("parseWatchFlags(%v) should fail", args)
+		}
+	}
+}
+
+func TestWatchOptionsMatches(t *testing.T) {
+	item := sessionItem{project: "api", status: "ready"}
+
+	tests := []struct {
+		name string
+		opts watchOptions
+		want bool
+	}{
+		{"no filters", watchOptions{}, true},
+		{"matching project", watchOptions{projects: []string{"web", "api"}}, true},
+		{"other project", watchOptions{projects: []string{"web"}}, false},
+		{"matching status", watchOptions{statuses: []string{"ready", "blocked"}}, true},
+		{"other status", watchOptions{statuses: []string{"working"}}, false},
+		{"both match", watchOptions{projects: []string{"api"}, statuses: []string{"ready"}}, true},
+		{"status mismatch", watchOptions{projects: []string{"api"}, statuses: []string{"idle"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.opts.matches(item); got != tt.want {
+				t.Errorf("matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatWatchLogLine(t *testing.T) {
+	item := sessionItem{name: "toast", project: "api", bead: "api-abc", status: "blocked", message: "need review", stuckType: "idle", idle: 12}
+	got := formatWatchLogLine(item)
+	want := `toast project=api bead=api-abc status=blocked stuck=idle message="need review"`
+	if got != want {
+		t.Errorf("formatWatchLogLine() = %q, want %q", got, want)
+	}
+
+	// Idle time is not part of the line, so ticking minutes don't spam the log
+	item.idle = 13
+	if formatWatchLogLine(item) != got {
+		t.Error("formatWatchLogLine() changed with idle time")
+	}
+}
+
+func TestWatchViewWideGroupsByProject(t *testing.T) {
+	m := watchModel{
+		opts: watchOptions{layout: watchLayoutWide},
+		sessions: []sessionItem{
+			{name: "toast", project: "api", bead: "api-abc", status: "working"},
+			{name: "rye", project: "api", bead: "api-def", status: "ready"},
+			{name: "bagel", project: "web", bead: "web-xyz", status: "idle"},
+		},
+	}
+	view := m.View()
+
+	api := strings.Index(view, "api\n")
+	web := strings.Index(view, "web\n")
+	if api < 0 || web < 0 || api > web {
+		t.Fatalf("wide view should have api then web project headers:\n%s", view)
+	}
+	if strings.Count(view, "api\n") != 1 {
+		t.Errorf("wide view repeats the api header:\n%s", view)
+	}
+	if !strings.Contains(view, "SESSION") {
+		t.Errorf("wide view missing column header:\n%s", view)
+	}
+}
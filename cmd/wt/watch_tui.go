@@ -2,13 +2,14 @@ package main
 
 import (
 	"fmt"
-	"sort"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/badri/wt/internal/agent"
 	"github.com/badri/wt/internal/bead"
 	"github.com/badri/wt/internal/config"
 	"github.com/badri/wt/internal/monitor"
@@ -114,8 +115,34 @@ type sessionItem struct {
 	status    string
 	message   string
 	idle      int
-	stuckType string // "interrupted", "idle", or ""
-	nudgedAgo int    // minutes since last nudge, -1 if never
+	activity  string                // Transcript activity (thinking, waiting-input, ...) when enabled
+	stuckType string                // "interrupted", "idle", "permission", or ""
+	nudgedAgo int                   // minutes since last nudge, -1 if never
+	dependsOn string                // Sessions that must merge first (wt depend)
+	commits   *monitor.BranchCounts // Ahead/behind the base branch, nil if unknown
+	base      string                // Branch the session merges into
+	checks    string                // CI checks of the session's PR, "" without PRs
+	context   int                   // percent of the context window in use, -1 if unknown
+	epic      *epicSummary          // Epic the session works on, nil if none
+	created   time.Time             // When the session started, zero if unknown
+}
+
+// epicID returns the session's epic, "" outside epics
+func (sess sessionItem) epicID() string {
+	if sess.epic == nil {
+		return ""
+	}
+	return sess.epic.ID
+}
+
+// epicHeader returns the line to show above sess when it starts an epic's
+// group, i.e. when the session before it belongs elsewhere
+func epicHeader(sessions []sessionItem, i int) string {
+	sess := sessions[i]
+	if sess.epic == nil || (i > 0 && sessions[i-1].epicID() == sess.epicID() && sessions[i-1].project == sess.project) {
+		return ""
+	}
+	return helpStyle.Render("▾ epic "+sess.epic.label()) + "\n"
 }
 
 // Model
@@ -127,8 +154,12 @@ type watchModel struct {
 	height      int
 	lastRefresh time.Time
 	quitting    bool
-	autoNudge   bool
+	opts        watchOptions
 	nudger      *monitor.Nudger
+	perms       *permissionWatcher
+	notes       *watchNotifier
+	handoffs    *contextWatcher
+	comments    *commentRelay
 }
 
 // Messages
@@ -143,96 +174,166 @@ func tickCmd() tea.Cmd {
 	})
 }
 
-func loadSessionsCmd(cfg *config.Config, autoNudge bool, nudger *monitor.Nudger) tea.Cmd {
+func loadSessionsCmd(cfg *config.Config, opts watchOptions, nudger *monitor.Nudger, perms *permissionWatcher, notes *watchNotifier, handoffs *contextWatcher, comments *commentRelay) tea.Cmd {
 	return func() tea.Msg {
-		state, err := session.LoadState(cfg)
-		if err != nil {
-			return sessionsMsg{}
-		}
+		return sessionsMsg(collectWatchItems(cfg, opts, nudger, perms, notes, handoffs, comments))
+	}
+}
 
-		// Prune sessions whose tmux session no longer exists
-		state.PruneStaleSessions()
+// collectWatchItems gathers the sessions shown by wt watch, applying the
+// --project and --status filters, handling Claude permission prompts,
+// auto-nudging stuck sessions if enabled, asking agents near the end of
+// their context window to hand off and notifying status changes.
+func collectWatchItems(cfg *config.Config, opts watchOptions, nudger *monitor.Nudger, perms *permissionWatcher, notes *watchNotifier, handoffs *contextWatcher, comments *commentRelay) []sessionItem {
+	state, err := session.LoadState(cfg)
+	if err != nil {
+		return nil
+	}
 
-		var items []sessionItem
-		for name, sess := range state.Sessions {
-			status := sess.Status
-			if status == "" {
-				status = monitor.DetectStatus(name, 5)
+	// Prune sessions whose tmux session no longer exists
+	state.PruneStaleSessions()
+
+	counter := newBranchCounter(cfg)
+	epics := newEpicTracker()
+	var items []sessionItem
+	statuses := make(map[string]watchedStatus, len(state.Sessions))
+	for name, sess := range state.Sessions {
+		status := sess.Status
+		if status == "" {
+			status = monitor.DetectStatus(name, 5)
+		}
+		checks := counter.checks(sess)
+		statuses[name] = watchedStatus{status: status, message: sess.StatusMessage, checks: checks, bead: sess.Bead, project: sess.Project}
+		if comments != nil {
+			comments.check(name, sess)
+		}
+		if !opts.matches(sessionItem{project: sess.Project, status: status}) {
+			continue
+		}
+		idle := monitor.GetIdleMinutes(name)
+		activity := ""
+		if cfg.UseTranscriptActivity() {
+			if a, minutes := monitor.DetectActivity(name, sess.Worktree, 5*time.Minute); a != "" {
+				activity, idle = a, minutes
 			}
-			idle := monitor.GetIdleMinutes(name)
+		}
 
-			// Get bead title (use BeadsDir to find correct project)
-			title := ""
-			if beadInfo, err := bead.ShowInDir(sess.Bead, sess.BeadsDir); err == nil && beadInfo != nil {
-				title = beadInfo.Title
-			}
+		// Get bead title (use BeadsDir to find correct project)
+		title := ""
+		if beadInfo, err := bead.ShowInDir(sess.Bead, sess.BeadsDir); err == nil && beadInfo != nil {
+			title = beadInfo.Title
+		}
 
-			item := sessionItem{
-				name:      name,
-				bead:      sess.Bead,
-				title:     title,
-				project:   sess.Project,
-				status:    status,
-				message:   sess.StatusMessage,
-				idle:      idle,
-				nudgedAgo: -1,
-			}
+		item := sessionItem{
+			name:      name,
+			bead:      sess.Bead,
+			title:     title,
+			project:   sess.Project,
+			status:    status,
+			message:   sess.StatusMessage,
+			idle:      idle,
+			activity:  activity,
+			nudgedAgo: -1,
+			dependsOn: dependencyLabels(state, sess.DependsOn),
+			commits:   counter.counts(sess),
+			base:      counter.baseBranch(sess),
+			checks:    checks,
+			context:   -1,
+			epic:      epics.summary(sess.Epic, sess.BeadsDir),
+		}
+		if t, err := time.Parse(time.RFC3339, sess.CreatedAt); err == nil {
+			item.created = t
+		}
+		if handoffs != nil && sessionAgent(sess).Name == agent.Claude {
+			item.context = handoffs.check(name, sess.Worktree)
+		}
 
-			// Detect stuck state and optionally nudge
-			stuck := monitor.DetectStuckState(name, 5)
-			if stuck.Type != "none" {
-				item.stuckType = stuck.Type
-				if autoNudge && nudger != nil {
-					nudger.TryNudge(name, stuck)
+		// Detect stuck state and optionally nudge. The transcript knows better
+		// than tmux: a thinking session isn't stuck, and a permission prompt
+		// needs a human rather than a nudge.
+		stuck := monitor.DetectStuckState(name, 5)
+		switch activity {
+		case monitor.ActivityThinking:
+			stuck.Type = "none"
+		case monitor.ActivityWaitingPermission:
+			item.stuckType = "permission"
+			stuck.Type = "none"
+		}
+		if perms != nil {
+			if prompt, approved := perms.check(name, sess); prompt != nil {
+				if !approved {
+					item.stuckType = "permission"
+					item.message = "needs permission: " + prompt.String()
 				}
+				stuck.Type = "none"
 			}
-
-			// Track last nudge time
-			if nudger != nil {
-				if last := nudger.LastNudgeTime(name); !last.IsZero() {
-					item.nudgedAgo = int(time.Since(last).Minutes())
-				}
+		}
+		if stuck.Type != "none" {
+			item.stuckType = stuck.Type
+			// A plain shell has no agent to nudge
+			if opts.autoNudge && nudger != nil && sessionAgent(sess).AcceptsPrompts {
+				nudger.TryNudge(name, stuck)
 			}
-
-			items = append(items, item)
 		}
 
-		// Sort by name
-		sort.Slice(items, func(i, j int) bool {
-			return items[i].name < items[j].name
-		})
+		// Track last nudge time
+		if nudger != nil {
+			if last := nudger.LastNudgeTime(name); !last.IsZero() {
+				item.nudgedAgo = int(time.Since(last).Minutes())
+			}
+		}
 
-		return sessionsMsg(items)
+		items = append(items, item)
+	}
+	if handoffs != nil {
+		handoffs.checkHub()
 	}
+	if comments != nil {
+		comments.prune(state)
+	}
+	if notes != nil {
+		notes.observe(statuses)
+		sendDailySummaryIfDue(cfg)
+	}
+
+	sortWatchItems(items, opts)
+
+	return items
 }
 
-func switchSessionCmd(sessionName string) tea.Cmd {
+func switchSessionCmd(cfg *config.Config, sessionName string) tea.Cmd {
 	return func() tea.Msg {
 		// Use tmux switch-client to switch to the selected session
 		// This keeps the watch TUI running in its pane
+		rememberCurrentSession(cfg, sessionName)
 		err := tmux.SwitchClient(sessionName)
 		return switchedMsg{err: err}
 	}
 }
 
 // Initialize model
-func newWatchModel(cfg *config.Config, autoNudge bool) watchModel {
+func newWatchModel(cfg *config.Config, opts watchOptions) watchModel {
 	var nudger *monitor.Nudger
-	if autoNudge {
+	if opts.autoNudge {
 		nudger = monitor.NewNudger(cfg.ConfigDir())
 	}
+	notes := newWatchNotifier(cfg)
 	return watchModel{
 		cfg:         cfg,
 		sessions:    []sessionItem{},
 		cursor:      0,
 		lastRefresh: time.Now(),
-		autoNudge:   autoNudge,
+		opts:        opts,
 		nudger:      nudger,
+		perms:       newPermissionWatcher(cfg, notes.notifier),
+		notes:       notes,
+		handoffs:    newContextWatcher(cfg, notes.notifier),
+		comments:    newCommentRelay(cfg),
 	}
 }
 
 func (m watchModel) Init() tea.Cmd {
-	return tea.Batch(loadSessionsCmd(m.cfg, m.autoNudge, m.nudger), tickCmd())
+	return tea.Batch(loadSessionsCmd(m.cfg, m.opts, m.nudger, m.perms, m.notes, m.handoffs, m.comments), tickCmd())
 }
 
 func (m watchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -258,15 +359,15 @@ func (m watchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Switch to the selected session without quitting
 				// The watch continues running in its pane
 				sessionName := m.sessions[m.cursor].name
-				return m, switchSessionCmd(sessionName)
+				return m, switchSessionCmd(m.cfg, sessionName)
 			}
 
 		case key.Matches(msg, keys.Refresh):
-			return m, loadSessionsCmd(m.cfg, m.autoNudge, m.nudger)
+			return m, loadSessionsCmd(m.cfg, m.opts, m.nudger, m.perms, m.notes, m.handoffs, m.comments)
 
 		case key.Matches(msg, keyToggleNudge):
-			m.autoNudge = !m.autoNudge
-			if m.autoNudge && m.nudger == nil {
+			m.opts.autoNudge = !m.opts.autoNudge
+			if m.opts.autoNudge && m.nudger == nil {
 				m.nudger = monitor.NewNudger(m.cfg.ConfigDir())
 			}
 		}
@@ -277,9 +378,10 @@ func (m watchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tickMsg:
 		m.lastRefresh = time.Time(msg)
-		return m, tea.Batch(loadSessionsCmd(m.cfg, m.autoNudge, m.nudger), tickCmd())
+		return m, tea.Batch(loadSessionsCmd(m.cfg, m.opts, m.nudger, m.perms, m.notes, m.handoffs, m.comments), tickCmd())
 
 	case sessionsMsg:
+		m.cursor = followCursor(m.sessions, msg, m.cursor)
 		m.sessions = msg
 		// Adjust cursor if needed
 		if m.cursor >= len(m.sessions) && len(m.sessions) > 0 {
@@ -303,100 +405,249 @@ func (m watchModel) View() string {
 
 	// Title
 	s += titleStyle.Render("wt watch") + " "
-	s += helpStyle.Render(m.lastRefresh.Format("15:04:05")) + "\n\n"
+	s += helpStyle.Render(m.lastRefresh.Format("15:04:05"))
+	if filters := m.opts.filterDescription(); filters != "" {
+		s += " " + helpStyle.Render(filters)
+	}
+	s += "\n\n"
 
 	if len(m.sessions) == 0 {
-		s += normalStyle.Render("No active sessions.\n")
-		s += helpStyle.Render("\nStart one with: wt new <bead>")
+		if m.opts.filterDescription() != "" {
+			s += normalStyle.Render("No sessions match the filters.\n")
+		} else {
+			s += normalStyle.Render("No active sessions.\n")
+			s += helpStyle.Render("\nStart one with: wt new <bead>")
+		}
 	} else {
-		// Sessions list
-		for i, sess := range m.sessions {
-			// Status style
-			var statusStr string
-			switch sess.status {
-			case "working":
-				statusStr = statusWorkingStyle.Render("●")
-			case "idle":
-				statusStr = statusIdleStyle.Render("●")
-			case "ready":
-				statusStr = statusReadyStyle.Render("●")
-			case "blocked":
-				statusStr = statusBlockedStyle.Render("●")
-			case "error":
-				statusStr = statusErrorStyle.Render("●")
-			default:
-				statusStr = normalStyle.Render("●")
-			}
-
-			// Format line - show name and title (or bead if no title)
-			displayTitle := sess.title
-			if displayTitle == "" {
-				displayTitle = sess.bead
-			}
-			line := fmt.Sprintf("%s %-14s %s",
-				statusStr,
-				truncateStr(sess.name, 14),
-				truncateStr(displayTitle, 20))
-
-			// Apply selection style
-			if i == m.cursor {
-				s += selectedStyle.Render("> "+truncateStr(sess.name, 14)+" "+truncateStr(displayTitle, 20)) + "\n"
-			} else {
-				s += "  " + line + "\n"
-			}
+		switch m.opts.layout {
+		case watchLayoutCompact:
+			s += m.viewCompact()
+		case watchLayoutWide:
+			s += m.viewWide()
+		default:
+			s += m.viewList()
 		}
 
-		// Detail card for selected session
-		if m.cursor < len(m.sessions) {
-			sess := m.sessions[m.cursor]
-			s += "\n"
-
-			// Build card content
-			var cardContent string
-			cardContent += cardTitleStyle.Render(sess.name) + "\n"
-			cardContent += cardLabelStyle.Render("Bead:    ") + cardValueStyle.Render(sess.bead) + "\n"
-			if sess.title != "" {
-				cardContent += cardLabelStyle.Render("Title:   ") + cardValueStyle.Render(sess.title) + "\n"
-			}
-			cardContent += cardLabelStyle.Render("Project: ") + cardValueStyle.Render(sess.project) + "\n"
-			cardContent += cardLabelStyle.Render("Status:  ") + m.renderStatus(sess.status) + "\n"
-			if sess.message != "" {
-				cardContent += cardLabelStyle.Render("Message: ") + cardValueStyle.Render(sess.message) + "\n"
-			}
-			if sess.idle > 0 {
-				idleStr := fmt.Sprintf("%dm", sess.idle)
-				if sess.idle >= 60 {
-					idleStr = fmt.Sprintf("%dh %dm", sess.idle/60, sess.idle%60)
-				}
-				cardContent += cardLabelStyle.Render("Idle:    ") + cardValueStyle.Render(idleStr) + "\n"
-			}
-			if sess.stuckType != "" {
-				stuckStr := sess.stuckType
-				if sess.nudgedAgo >= 0 {
-					stuckStr += fmt.Sprintf(" (nudged %dm ago)", sess.nudgedAgo)
-				}
-				cardContent += cardLabelStyle.Render("Stuck:   ") + statusErrorStyle.Render(stuckStr) + "\n"
-			}
-
-			s += cardStyle.Render(cardContent)
+		// Detail card for selected session (no room for it in compact layout)
+		if m.opts.layout != watchLayoutCompact && m.cursor < len(m.sessions) {
+			s += "\n" + m.renderCard(m.sessions[m.cursor])
 		}
 	}
 
-	// Help - vertical
+	nudgeLabel := "n  auto-nudge: off"
+	if m.opts.autoNudge {
+		nudgeLabel = "n  auto-nudge: on"
+	}
+
+	// Help - a single line in compact layout, vertical otherwise
+	if m.opts.layout == watchLayoutCompact {
+		s += "\n" + helpStyle.Render("↑/↓ enter r "+strings.TrimPrefix(nudgeLabel, "n  ")+" q")
+		return plainText(s)
+	}
 	s += "\n\n"
 	s += helpStyle.Render("↑/↓  navigate") + "\n"
 	s += helpStyle.Render("enter  switch to session") + "\n"
 	s += helpStyle.Render("r  refresh") + "\n"
-	nudgeLabel := "n  auto-nudge: off"
-	if m.autoNudge {
-		nudgeLabel = "n  auto-nudge: on"
-	}
 	s += helpStyle.Render(nudgeLabel) + "\n"
 	s += helpStyle.Render("q  quit")
 
+	return plainText(s)
+}
+
+// displayTitle returns the bead title, or the bead ID if there is none
+func (sess sessionItem) displayTitle() string {
+	if sess.title != "" {
+		return sess.title
+	}
+	return sess.bead
+}
+
+// viewList renders the default layout: status, name and title per session
+func (m watchModel) viewList() string {
+	var s string
+	for i, sess := range m.sessions {
+		s += epicHeader(m.sessions, i)
+		line := fmt.Sprintf("%s %-14s %-20s %s",
+			statusDot(sess.status),
+			truncateStr(sess.name, 14),
+			truncateStr(sess.displayTitle(), 20),
+			helpStyle.Render(formatBranchCounts(sess.commits)))
+		if sess.checks != "" && sess.checks != monitor.ChecksNone {
+			line += " " + checksStyle(sess.checks).Render(monitor.ChecksIcon(sess.checks))
+		}
+		if sess.activity != "" {
+			line += " " + monitor.ActivityIcon(sess.activity)
+		}
+
+		// Apply selection style
+		if i == m.cursor {
+			s += selectedStyle.Render(fmt.Sprintf("> %-14s %-20s %s",
+				truncateStr(sess.name, 14), truncateStr(sess.displayTitle(), 20), formatBranchCounts(sess.commits))) + "\n"
+		} else {
+			s += "  " + line + "\n"
+		}
+	}
+	return s
+}
+
+// viewCompact renders one short line per session, for small panes
+func (m watchModel) viewCompact() string {
+	var s string
+	for i, sess := range m.sessions {
+		name := truncateStr(sess.name, 12)
+		if i == m.cursor {
+			s += selectedStyle.Render(">"+name) + "\n"
+			continue
+		}
+		line := statusDot(sess.status) + name
+		if sess.activity != "" {
+			line += " " + monitor.ActivityIcon(sess.activity)
+		}
+		if sess.stuckType != "" {
+			line += " " + statusErrorStyle.Render("!")
+		}
+		s += line + "\n"
+	}
 	return s
 }
 
+// viewWide renders a table grouped by project with one column per field
+func (m watchModel) viewWide() string {
+	var s string
+	row := "  %-2s %-14s %-16s %-8s %-6s %-9s %-10s %-32s %s"
+	s += headerStyle.Render(fmt.Sprintf(row, "", "SESSION", "BEAD", "STATUS", "IDLE", "COMMITS", "CHECKS", "TITLE", "MESSAGE")) + "\n"
+
+	project := ""
+	for i, sess := range m.sessions {
+		if i == 0 || sess.project != project {
+			project = sess.project
+			name := project
+			if name == "" {
+				name = "(no project)"
+			}
+			s += "\n" + cardTitleStyle.Render(name) + "\n"
+		}
+		s += epicHeader(m.sessions, i)
+
+		idle := "-"
+		if sess.idle > 0 {
+			idle = formatIdle(sess.idle)
+		}
+		message := sess.message
+		if sess.stuckType != "" && message == "" {
+			message = "stuck: " + sess.stuckType
+		}
+		marker := " "
+		if sess.activity != "" {
+			marker = monitor.ActivityIcon(sess.activity)
+		}
+		line := fmt.Sprintf(row, marker,
+			truncateStr(sess.name, 14),
+			truncateStr(sess.bead, 16),
+			sess.status,
+			idle,
+			formatBranchCounts(sess.commits),
+			formatChecks(sess.checks),
+			truncateStr(sess.displayTitle(), 32),
+			truncateStr(message, 40))
+
+		if i == m.cursor {
+			s += selectedStyle.Render(line) + "\n"
+		} else {
+			s += statusDot(sess.status) + normalStyle.Render(line[1:]) + "\n"
+		}
+	}
+	return s
+}
+
+// renderCard renders the detail card for a session
+func (m watchModel) renderCard(sess sessionItem) string {
+	var cardContent string
+	cardContent += cardTitleStyle.Render(sess.name) + "\n"
+	cardContent += cardLabelStyle.Render("Bead:    ") + cardValueStyle.Render(sess.bead) + "\n"
+	if sess.title != "" {
+		cardContent += cardLabelStyle.Render("Title:   ") + cardValueStyle.Render(sess.title) + "\n"
+	}
+	cardContent += cardLabelStyle.Render("Project: ") + cardValueStyle.Render(sess.project) + "\n"
+	if sess.epic != nil {
+		cardContent += cardLabelStyle.Render("Epic:    ") + cardValueStyle.Render(sess.epic.label()) + "\n"
+	}
+	cardContent += cardLabelStyle.Render("Status:  ") + m.renderStatus(sess.status) + "\n"
+	if sess.activity != "" {
+		cardContent += cardLabelStyle.Render("Claude:  ") + cardValueStyle.Render(monitor.ActivityIcon(sess.activity)+" "+sess.activity) + "\n"
+	}
+	if sess.message != "" {
+		cardContent += cardLabelStyle.Render("Message: ") + cardValueStyle.Render(sess.message) + "\n"
+	}
+	if sess.commits != nil {
+		commits := fmt.Sprintf("%d ahead, %d behind %s", sess.commits.Ahead, sess.commits.Behind, sess.base)
+		cardContent += cardLabelStyle.Render("Commits: ") + cardValueStyle.Render(commits) + "\n"
+	}
+	if sess.checks != "" && sess.checks != monitor.ChecksNone {
+		cardContent += cardLabelStyle.Render("Checks:  ") + checksStyle(sess.checks).Render(formatChecks(sess.checks)) + "\n"
+	}
+	if sess.context >= 0 {
+		cardContent += cardLabelStyle.Render("Context: ") + cardValueStyle.Render(fmt.Sprintf("%d%%", sess.context)) + "\n"
+	}
+	if sess.dependsOn != "" {
+		cardContent += cardLabelStyle.Render("After:   ") + cardValueStyle.Render(sess.dependsOn) + "\n"
+	}
+	if sess.idle > 0 {
+		cardContent += cardLabelStyle.Render("Idle:    ") + cardValueStyle.Render(formatIdle(sess.idle)) + "\n"
+	}
+	if sess.stuckType != "" {
+		stuckStr := sess.stuckType
+		if sess.nudgedAgo >= 0 {
+			stuckStr += fmt.Sprintf(" (nudged %dm ago)", sess.nudgedAgo)
+		}
+		cardContent += cardLabelStyle.Render("Stuck:   ") + statusErrorStyle.Render(stuckStr) + "\n"
+	}
+	return cardStyle.Render(cardContent)
+}
+
+// statusDot returns a colored status indicator
+func statusDot(status string) string {
+	if outputPlain {
+		return plainStatusIcon(status)
+	}
+	switch status {
+	case "working":
+		return statusWorkingStyle.Render("●")
+	case "idle":
+		return statusIdleStyle.Render("●")
+	case "ready":
+		return statusReadyStyle.Render("●")
+	case "blocked":
+		return statusBlockedStyle.Render("●")
+	case "error":
+		return statusErrorStyle.Render("●")
+	default:
+		return normalStyle.Render("●")
+	}
+}
+
+// formatIdle formats idle minutes as "5m" or "1h 20m"
+func formatIdle(minutes int) string {
+	if minutes >= 60 {
+		return fmt.Sprintf("%dh %dm", minutes/60, minutes%60)
+	}
+	return fmt.Sprintf("%dm", minutes)
+}
+
+// checksStyle colours a CI check state
+func checksStyle(checks string) lipgloss.Style {
+	switch checks {
+	case monitor.ChecksPassing:
+		return statusWorkingStyle
+	case monitor.ChecksFailing:
+		return statusErrorStyle
+	case monitor.ChecksPending:
+		return statusIdleStyle
+	default:
+		return normalStyle
+	}
+}
+
 // renderStatus returns a styled status string
 func (m watchModel) renderStatus(status string) string {
 	switch status {
@@ -427,15 +678,18 @@ func truncateStr(s string, max int) string {
 }
 
 // Run the watch TUI
-func runWatchTUI(cfg *config.Config, autoNudge bool) error {
-	m := newWatchModel(cfg, autoNudge)
+func runWatchTUI(cfg *config.Config, opts watchOptions) error {
+	m := newWatchModel(cfg, opts)
 	p := tea.NewProgram(m, tea.WithAltScreen())
 
 	_, err := p.Run()
 	return err
 }
 
-// cmdWatchTUI runs the new watch TUI
-func cmdWatchTUI(cfg *config.Config, autoNudge bool) error {
-	return runWatchTUI(cfg, autoNudge)
+// cmdWatchTUI runs the new watch TUI, or the log output with --append
+func cmdWatchTUI(cfg *config.Config, opts watchOptions) error {
+	if opts.append {
+		return runWatchAppend(cfg, opts)
+	}
+	return runWatchTUI(cfg, opts)
 }
@@ -0,0 +1,172 @@
+// Package agent describes the coding agents wt can run in a worker session:
+// how to start one, tell when it is ready for input, send it a prompt and
+// resume an earlier conversation.
+package agent
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/badri/wt/internal/tmux"
+)
+
+// Built-in agent names, as used in a project's "agent" setting
+const (
+	Claude = "claude"
+	Aider  = "aider"
+	Shell  = "shell"
+)
+
+// Profile encapsulates the CLI semantics of one agent.
+type Profile struct {
+	Name           string
+	DefaultCommand string         // Command started in the session's pane
+	ProcessNames   []string       // pane_current_command values while the agent runs
+	ReadyPattern   *regexp.Regexp // Pane content showing the agent is ready for input
+	ResumeFlag     string         // Flag to resume a conversation; empty if unsupported
+	ResumeNeedsID  bool           // ResumeFlag takes a conversation ID (claude --resume <id>)
+	BypassDialog   bool           // Starts with a bypass-permissions dialog to accept
+	MultilineWrap  [2]string      // Wraps multi-line prompts so they are sent as one message
+	AcceptsPrompts bool           // false for a plain shell: prompts are not sent
+}
+
+var profiles = map[string]*Profile{
+	Claude: {
+		Name:           Claude,
+		DefaultCommand: "claude --dangerously-skip-permissions",
+		ProcessNames:   []string{"claude", "node"},
+		ReadyPattern:   regexp.MustCompile("❯"),
+		ResumeFlag:     "--resume",
+		ResumeNeedsID:  true,
+		BypassDialog:   true,
+		AcceptsPrompts: true,
+	},
+	Aider: {
+		Name:           Aider,
+		DefaultCommand: "aider --yes-always",
+		ProcessNames:   []string{"aider", "python", "python3"},
+		ReadyPattern:   regexp.MustCompile(`(?m)^[a-z-]*> ?$`),
+		ResumeFlag:     "--restore-chat-history",
+		MultilineWrap:  [2]string{"{", "}"},
+		AcceptsPrompts: true,
+	},
+	Shell: {
+		Name: Shell,
+	},
+}
+
+// Get returns the profile for an agent name. An empty name means Claude.
+func Get(name string) (*Profile, error) {
+	if name == "" {
+		name = Claude
+	}
+	p, ok := profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown agent %q (valid: %s)", name, strings.Join(Names(), ", "))
+	}
+	return p, nil
+}
+
+// Names returns the built-in agent names, sorted.
+func Names() []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Command returns the command that starts the agent. override is a project's
+// agent_cmd; Claude otherwise uses the global editor_cmd. An empty result
+// means the pane runs a plain shell.
+func (p *Profile) Command(editorCmd, override string) string {
+	if override != "" {
+		return override
+	}
+	if p.Name == Claude && editorCmd != "" {
+		return editorCmd
+	}
+	return p.DefaultCommand
+}
+
+// CanResume reports whether the agent can resume a conversation. Agents whose
+// resume flag needs an ID can only resume when one was recorded.
+func (p *Profile) CanResume(conversationID string) bool {
+	if p.ResumeFlag == "" {
+		return false
+	}
+	return !p.ResumeNeedsID || conversationID != ""
+}
+
+// ResumeCommand returns command with the flags to resume a conversation,
+// or command unchanged if the agent can't resume it.
+func (p *Profile) ResumeCommand(command, conversationID string) string {
+	if command == "" || !p.CanResume(conversationID) {
+		return command
+	}
+	if p.ResumeNeedsID {
+		return fmt.Sprintf("%s %s %s", command, p.ResumeFlag, conversationID)
+	}
+	return command + " " + p.ResumeFlag
+}
+
+// IsReady reports whether captured pane content shows the agent's input prompt.
+func (p *Profile) IsReady(content string) bool {
+	return p.ReadyPattern != nil && p.ReadyPattern.MatchString(content)
+}
+
+// FormatPrompt prepares a prompt for pasting into the agent.
+func (p *Profile) FormatPrompt(prompt string) string {
+	if p.MultilineWrap[0] != "" && strings.Contains(prompt, "\n") {
+		return p.MultilineWrap[0] + "\n" + prompt + "\n" + p.MultilineWrap[1]
+	}
+	return prompt
+}
+
+// WaitReady waits for the agent process to start and show its input prompt.
+// A plain shell is ready immediately.
+func (p *Profile) WaitReady(session string, timeout time.Duration) error {
+	if p.ReadyPattern == nil {
+		return nil
+	}
+	deadline := time.Now().Add(timeout)
+
+	// First wait for the process to start
+	for time.Now().Before(deadline) {
+		if slices.Contains(p.ProcessNames, tmux.PaneCommand(session)) {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	// Then wait for the input prompt to appear
+	for time.Now().Before(deadline) {
+		content, err := tmux.CapturePane(session, 20)
+		if err == nil && p.IsReady(content) {
+			return nil
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+	return fmt.Errorf("timeout waiting for %s prompt", p.Name)
+}
+
+// Prepare dismisses startup dialogs. Call after WaitReady and before sending prompts.
+func (p *Profile) Prepare(session string) error {
+	if p.BypassDialog {
+		return tmux.AcceptBypassPermissionsWarning(session)
+	}
+	return nil
+}
+
+// SendPrompt sends a prompt to the agent. It does nothing for agents that
+// don't accept prompts.
+func (p *Profile) SendPrompt(session, prompt string) error {
+	if !p.AcceptsPrompts {
+		return nil
+	}
+	return tmux.NudgeSession(session, p.FormatPrompt(prompt))
+}
@@ -0,0 +1,119 @@
+package agent
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGet(t *testing.T) {
+	p, err := Get("")
+	if err != nil || p.Name != Claude {
+		t.Errorf("Get(\"\") = %v, %v; want claude", p, err)
+	}
+	for _, name := range []string{Claude, Aider, Shell} {
+		if p, err := Get(name); err != nil || p.Name != name {
+			t.Errorf("Get(%q) = %v, %v", name, p, err)
+		}
+	}
+	if _, err := Get("cursor"); err == nil {
+		t.Error("Get() with unknown agent should fail")
+	}
+	if want := []string{"aider", "claude", "shell"}; !slices.Equal(Names(), want) {
+		t.Errorf("Names() = %v, want %v", Names(), want)
+	}
+}
+
+func TestCommand(t *testing.T) {
+	claude, _ := Get(Claude)
+	aider, _ := Get(Aider)
+	shell, _ := Get(Shell)
+
+	tests := []struct {
+		name      string
+		profile   *Profile
+		editorCmd string
+		override  string
+		want      string
+	}{
+		{"claude uses editor_cmd", claude, "claude --model opus", "", "claude --model opus"},
+		{"claude default", claude, "", "", "claude --dangerously-skip-permissions"},
+		{"project override wins", claude, "claude", "claude-wrapper", "claude-wrapper"},
+		{"aider ignores editor_cmd", aider, "claude", "", "aider --yes-always"},
+		{"aider override", aider, "claude", "aider --model sonnet", "aider --model sonnet"},
+		{"shell runs nothing", shell, "claude", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.profile.Command(tt.editorCmd, tt.override); got != tt.want {
+				t.Errorf("Command() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResumeCommand(t *testing.T) {
+	claude, _ := Get(Claude)
+	aider, _ := Get(Aider)
+	shell, _ := Get(Shell)
+
+	tests := []struct {
+		name    string
+		profile *Profile
+		command string
+		id      string
+		want    string
+	}{
+		{"claude with id", claude, "claude", "abc-123", "claude --resume abc-123"},
+		{"claude without id", claude, "claude", "", "claude"},
+		{"aider restores history", aider, "aider", "", "aider --restore-chat-history"},
+		{"shell cannot resume", shell, "bash", "abc-123", "bash"},
+		{"no command", claude, "", "abc-123", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.profile.ResumeCommand(tt.command, tt.id); got != tt.want {
+				t.Errorf("ResumeCommand() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsReady(t *testing.T) {
+	claude, _ := Get(Claude)
+	aider, _ := Get(Aider)
+	shell, _ := Get(Shell)
+
+	if !claude.IsReady("╭───╮\n│ ❯ │\n╰───╯") {
+		t.Error("claude prompt not detected")
+	}
+	if claude.IsReady("Loading...") {
+		t.Error("claude ready while loading")
+	}
+	if !aider.IsReady("Aider v0.60\nRepo-map: using 1024 tokens\n> ") {
+		t.Error("aider prompt not detected")
+	}
+	if !aider.IsReady("Added main.go to the chat\narchitect>") {
+		t.Error("aider architect prompt not detected")
+	}
+	if aider.IsReady("Aider v0.60\nInitializing...") {
+		t.Error("aider ready while starting")
+	}
+	if shell.IsReady("$ ") || shell.AcceptsPrompts {
+		t.Error("shell has no agent prompt")
+	}
+}
+
+func TestFormatPrompt(t *testing.T) {
+	claude, _ := Get(Claude)
+	aider, _ := Get(Aider)
+
+	if got := claude.FormatPrompt("line 1\nline 2"); got != "line 1\nline 2" {
+		t.Errorf("claude FormatPrompt() = %q", got)
+	}
+	if got := aider.FormatPrompt("one line"); got != "one line" {
+		t.Errorf("aider single-line FormatPrompt() = %q", got)
+	}
+	if got := aider.FormatPrompt("line 1\nline 2"); got != "{\nline 1\nline 2\n}" {
+		t.Errorf("aider multi-line FormatPrompt() = %q", got)
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"os/signal"
 	"path/filepath"
 	"slices"
@@ -14,8 +13,10 @@ import (
 
 	"github.com/badri/wt/internal/bead"
 	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/logging"
 	"github.com/badri/wt/internal/msg"
 	"github.com/badri/wt/internal/project"
+	"github.com/badri/wt/internal/usage"
 )
 
 // Config holds auto-mode configuration from project config
@@ -42,13 +43,27 @@ type Options struct {
 	Check          bool
 	Stop           bool
 	Force          bool
-	Timeout        int    // minutes, 0 means use project default
-	Limit          int    // max beads to process, 0 means no limit
-	Epic           string // required: epic ID to process
-	PauseOnFailure bool   // stop and preserve worktree if bead fails
-	SkipAudit      bool   // bypass implicit audit
-	Resume         bool   // resume after failure
-	Abort          bool   // abort and clean up after failure
+	Timeout        int           // minutes, 0 means use project default
+	Limit          int           // max beads to process, 0 means no limit
+	Epic           string        // required: epic ID to process
+	Epics          []string      // all --epic arguments; those after Epic are queued behind it
+	PauseOnFailure bool          // stop and preserve worktree if bead fails
+	SkipAudit      bool          // bypass implicit audit
+	Resume         bool          // resume after failure
+	Abort          bool          // abort and clean up after failure
+	Rollback       bool          // with Abort: also undo the run's branch and bead changes
+	Isolated       bool          // give each epic bead a fresh worktree off the epic branch
+	Cooldown       time.Duration // pause between beads, overrides project auto.cooldown
+	NoPR           bool          // don't open a finalization PR when an epic completes
+	MaxDrift       int           // sync the epic branch when this many commits behind, overrides project auto.max_drift
+	DriftStrategy  string        // "rebase" or "merge", overrides project auto.drift_strategy
+	ResumeContext  bool          // epic mode: resume the previous bead's Claude session for the next bead
+	MaxCost        float64       // pause once the estimated Claude cost reaches this many USD, overrides project auto.budget
+	MaxTotalPoints int           // project mode: only start beads while their estimates add up to at most this
+	SmallestFirst  bool          // project mode: among beads free to start, run the smallest estimate first
+	Workers        int           // project mode: beads to run at once, each in its own session (0 or 1 = serially)
+	Queue          bool          // consume the external work queue configured in queue.url
+	Gate           string        // epic mode: review gate to wait at for 'wt auto approve', GateAfterEachBead or GateBeforeMerge
 }
 
 // Runner manages the auto execution loop
@@ -61,6 +76,17 @@ type Runner struct {
 	lockFile   string
 	stopFile   string
 	stopSignal chan struct{}
+
+	lastBeadEnd time.Time   // when the previous bead's Claude run finished, for cooldown
+	spent       float64     // estimated Claude cost of a project-mode run so far, USD
+	costed      int         // project-mode beads whose cost is in spent
+	lastCost    float64     // estimated Claude cost of the bead that ran last, USD
+	lastSignal  *BeadSignal // bead-done signal of the epic bead that ran last
+	report      *RunReport  // collected while the run goes, saved when it ends
+	points      int         // estimate points of the beads a project-mode run started
+	passedOver  map[string]bool
+
+	mergeApproved bool // resumed at the before-merge gate, which counts as approval
 }
 
 // NewRunner creates a new auto runner
@@ -96,9 +122,7 @@ func (r *Runner) resolveProjectForEpic(epicID string) (string, error) {
 	}
 
 	for _, proj := range projects {
-		cmd := exec.Command("bd", "show", epicID, "--json")
-		cmd.Dir = proj.RepoPath()
-		output, err := cmd.Output()
+		output, err := bead.Output(proj.RepoPath(), "show", epicID, "--json")
 		if err != nil {
 			continue
 		}
@@ -158,6 +182,10 @@ func (r *Runner) Run() error {
 		return r.checkStatus()
 	}
 
+	if r.opts.Queue {
+		return r.runQueueMode()
+	}
+
 	// Require --epic or --project for all other operations
 	if r.opts.Epic == "" && r.opts.Project == "" {
 		return fmt.Errorf("--epic <id> or --project <name> is required\n\nUsage:\n  wt auto --epic <epic-id>       Process all beads in an epic (single worktree)\n  wt auto --project <name>       Process ready beads serially (separate worktrees)\n  wt auto --check                Check status of a running auto session\n\nExample:\n  wt auto --epic wt-doc-epic\n  wt auto --project myapp")
@@ -206,12 +234,7 @@ func (r *Runner) Run() error {
 		defer store.Close()
 	}
 
-	// Handle --resume flag (needs project resolved for state file)
-	if r.opts.Resume {
-		return r.resumeRun()
-	}
-
-	// Acquire lock
+	// Acquire lock (also while resuming, so 'wt auto state' sees the run as active)
 	if err := r.acquireLock(); err != nil {
 		return err
 	}
@@ -223,9 +246,17 @@ func (r *Runner) Run() error {
 	// Setup signal handling
 	r.setupSignalHandler()
 
-	// Process the epic
-	if err := r.processEpic(); err != nil {
-		r.logger.Log("Error processing epic %s: %v", r.opts.Epic, err)
+	// Handle --resume flag (needs project resolved for state file)
+	run := r.processEpic
+	if r.opts.Resume {
+		run = r.resumeRun
+	}
+
+	// Process the epic, then any queued behind it
+	r.startReport("epic")
+	err = r.runQueue(run)
+	r.finishReport(err)
+	if err != nil {
 		return err
 	}
 
@@ -282,7 +313,10 @@ func (r *Runner) runProjectMode() error {
 	r.logger.Log("Starting project mode for %s", r.opts.Project)
 	fmt.Printf("Processing ready beads for project: %s\n", r.opts.Project)
 
-	if err := r.processProject(proj); err != nil {
+	r.startReport("project")
+	err = r.processProject(proj)
+	r.finishReport(err)
+	if err != nil {
 		r.logger.Log("Error processing project %s: %v", r.opts.Project, err)
 		return err
 	}
@@ -291,7 +325,9 @@ func (r *Runner) runProjectMode() error {
 	return nil
 }
 
-// processProject processes all ready beads in a project
+// processProject processes a project's ready beads in dependency order.
+// After each bead readiness is queried again, so beads it unblocked join
+// the queue in the same run.
 func (r *Runner) processProject(proj *project.Project) error {
 	beadsDir := proj.BeadsDir()
 
@@ -300,42 +336,89 @@ func (r *Runner) processProject(proj *project.Project) error {
 		return fmt.Errorf("no .beads directory in project %s", proj.Name)
 	}
 
-	// Get ready beads
-	readyBeads, err := bead.ReadyInDir(beadsDir)
+	attempted := make(map[string]bool)
+	deps := make(map[string]beadDeps)
+	queue, err := r.readyQueue(proj, attempted, deps)
 	if err != nil {
-		return fmt.Errorf("getting ready beads: %w", err)
+		return err
 	}
 
-	if len(readyBeads) == 0 {
+	if len(queue) == 0 {
 		fmt.Printf("No ready beads in project %s.\n", proj.Name)
 		return nil
 	}
 
-	// Apply limit if specified
-	if r.opts.Limit > 0 && len(readyBeads) > r.opts.Limit {
-		fmt.Printf("Found %d ready bead(s) in project %s, limiting to %d.\n", len(readyBeads), proj.Name, r.opts.Limit)
-		readyBeads = readyBeads[:r.opts.Limit]
+	if r.opts.Limit > 0 && len(queue) > r.opts.Limit {
+		fmt.Printf("Found %d ready bead(s) in project %s, limiting to %d.\n", len(queue), proj.Name, r.opts.Limit)
 	} else {
-		fmt.Printf("Found %d ready bead(s) in project %s.\n", len(readyBeads), proj.Name)
+		fmt.Printf("Found %d ready bead(s) in project %s.\n", len(queue), proj.Name)
+	}
+	r.logger.Log("Queue in dependency order: %s", describeQueue(queue, deps))
+	if r.opts.MaxTotalPoints > 0 {
+		fmt.Printf("Points budget: %d (beads start while their estimates fit; beads without an estimate are skipped).\n", r.opts.MaxTotalPoints)
 	}
 
 	// Handle --check flag
 	if r.opts.Check {
-		return r.checkBeads(readyBeads)
+		if r.opts.Limit > 0 && len(queue) > r.opts.Limit {
+			queue = queue[:r.opts.Limit]
+		}
+		return r.checkBeads(queue)
 	}
 
-	// Process each bead
-	for _, b := range readyBeads {
+	if r.opts.Workers > 1 && !r.opts.DryRun {
+		return r.processProjectPool(proj, queue, attempted, deps)
+	}
+
+	// Process beads until the queue is empty
+	for len(queue) > 0 {
+		if r.opts.Limit > 0 && len(attempted) >= r.opts.Limit {
+			break
+		}
 		if r.shouldStop() {
 			r.logger.Log("Stop signal received, stopping bead processing")
 			break
 		}
 
+		if err := r.checkBudget(r.spent, r.costLimit(proj), "project "+proj.Name); err != nil {
+			fmt.Printf("Stopping: %v\n", err)
+			break
+		}
+		if err := r.pace(proj); err != nil {
+			r.logger.Log("Pacing: %v, stopping bead processing", err)
+			fmt.Printf("Stopping: %v\n", err)
+			break
+		}
+
+		b, err := r.nextBead(queue)
+		if err != nil {
+			r.logger.Log("Points: %v, stopping bead processing", err)
+			fmt.Printf("Stopping: %v\n", err)
+			break
+		}
+		attempted[b.ID] = true
 		if err := r.processBead(proj, &b); err != nil {
 			r.logger.Log("Error processing bead %s: %v", b.ID, err)
 			fmt.Printf("Error processing bead %s: %v\n", b.ID, err)
 			// Continue with next bead
 		}
+
+		// Nothing closes beads in a dry run, so the rest of the queue stands
+		if r.opts.DryRun {
+			queue = withoutBead(queue, b.ID)
+			continue
+		}
+		next, err := r.readyQueue(proj, attempted, deps)
+		if err != nil {
+			r.logger.Log("Warning: %v, keeping the current queue", err)
+			queue = withoutBead(queue, b.ID)
+			continue
+		}
+		if unblocked := newlyReady(next, queue); len(unblocked) > 0 {
+			fmt.Printf("Newly ready: %s\n", strings.Join(unblocked, ", "))
+			r.logger.Log("Newly ready after %s: %s", b.ID, strings.Join(unblocked, ", "))
+		}
+		queue = next
 	}
 
 	return nil
@@ -361,15 +444,16 @@ func (r *Runner) processBead(proj *project.Project, b *bead.ReadyBead) error {
 		fmt.Printf("[DRY RUN] Would run: wt new %s --no-switch\n", b.ID)
 		fmt.Printf("[DRY RUN] Command: %s\n", autoCfg.Command)
 		fmt.Printf("[DRY RUN] Timeout: %v\n", timeout)
-		r.logger.LogBeadEnd(b.ID, "dry-run", time.Since(startTime))
+		r.recordBead(BeadRun{ID: b.ID, Title: b.Title, Estimate: b.EstimatedMinutes, Outcome: "dry-run"}, startTime, "", nil)
 		return nil
 	}
 
 	// Create session with wt new
 	sessionName, err := r.createSession(b.ID)
 	if err != nil {
-		r.logger.LogBeadEnd(b.ID, "failed-create", time.Since(startTime))
-		return fmt.Errorf("creating session: %w", err)
+		err = fmt.Errorf("creating session: %w", err)
+		r.recordBead(BeadRun{ID: b.ID, Title: b.Title, Estimate: b.EstimatedMinutes, Outcome: "failed-create"}, startTime, "", err)
+		return err
 	}
 
 	fmt.Printf("Created session: %s\n", sessionName)
@@ -379,12 +463,15 @@ func (r *Runner) processBead(proj *project.Project, b *bead.ReadyBead) error {
 
 	// Run claude in the session
 	outcome, err := r.runClaudeInSession(sessionName, autoCfg.Command, prompt, timeout)
+	r.trackSessionCost(b.ID, sessionName, startTime)
+	run := BeadRun{ID: b.ID, Title: b.Title, Estimate: b.EstimatedMinutes, Session: sessionName, Outcome: outcome}
 	if err != nil {
-		r.logger.LogBeadEnd(b.ID, outcome, time.Since(startTime))
-		return fmt.Errorf("running claude: %w", err)
+		err = fmt.Errorf("running claude: %w", err)
+		r.recordBead(run, startTime, "", err)
+		return err
 	}
 
-	r.logger.LogBeadEnd(b.ID, outcome, time.Since(startTime))
+	r.recordBead(run, startTime, r.sessionWorktree(sessionName), nil)
 
 	// Handle merge if configured
 	mergeMode := r.opts.MergeMode
@@ -404,7 +491,7 @@ func (r *Runner) processBead(proj *project.Project, b *bead.ReadyBead) error {
 
 // createSession creates a new wt session for the bead
 func (r *Runner) createSession(beadID string) (string, error) {
-	cmd := exec.Command("wt", "new", beadID, "--no-switch")
+	cmd := logging.Command("wt", "new", beadID, "--no-switch")
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return "", fmt.Errorf("%s: %w", string(output), err)
@@ -429,138 +516,6 @@ func (r *Runner) createSession(beadID string) (string, error) {
 	return "", fmt.Errorf("could not parse session name from: %s", string(output))
 }
 
-// runClaudeInSession runs claude in a tmux session and waits for completion
-func (r *Runner) runClaudeInSession(sessionName, command, prompt string, timeout time.Duration) (string, error) {
-	// Write prompt to a temp file to avoid send-keys issues with long prompts.
-	// Using send-keys with long/complex prompts can cause the text to appear
-	// twice in the input buffer (once executed, once echoed without Enter).
-	promptFile, err := os.CreateTemp("", "wt-auto-prompt-*.txt")
-	if err != nil {
-		return "failed-create-prompt", fmt.Errorf("creating temp prompt file: %w", err)
-	}
-	promptPath := promptFile.Name()
-
-	if _, err := promptFile.WriteString(prompt); err != nil {
-		promptFile.Close()
-		os.Remove(promptPath)
-		return "failed-write-prompt", fmt.Errorf("writing prompt to temp file: %w", err)
-	}
-	promptFile.Close()
-
-	// Build the shell command to start Claude with the prompt file.
-	// Prefix with space to avoid shell history.
-	fullCmd := fmt.Sprintf(" %s -p \"$(cat %q)\" && rm -f %q", command, promptPath, promptPath)
-
-	// Use paste-buffer for reliable command delivery (same pattern as NudgeSession).
-	// This is more reliable than send-keys for long command strings.
-	cmdFile, err := os.CreateTemp("", "wt-auto-cmd-*.txt")
-	if err != nil {
-		os.Remove(promptPath)
-		return "failed-create-cmd", fmt.Errorf("creating temp command file: %w", err)
-	}
-	cmdPath := cmdFile.Name()
-	defer os.Remove(cmdPath)
-
-	if _, err := cmdFile.WriteString(fullCmd); err != nil {
-		cmdFile.Close()
-		os.Remove(promptPath)
-		return "failed-write-cmd", fmt.Errorf("writing command to temp file: %w", err)
-	}
-	cmdFile.Close()
-
-	// Load command into tmux buffer
-	loadCmd := exec.Command("tmux", "load-buffer", cmdPath)
-	if err := loadCmd.Run(); err != nil {
-		os.Remove(promptPath)
-		return "failed-load-buffer", fmt.Errorf("loading buffer: %w", err)
-	}
-
-	// Paste buffer to the target pane
-	pasteCmd := exec.Command("tmux", "paste-buffer", "-t", sessionName)
-	if err := pasteCmd.Run(); err != nil {
-		os.Remove(promptPath)
-		return "failed-paste", fmt.Errorf("pasting buffer to %s: %w", sessionName, err)
-	}
-
-	// Wait for paste to complete, then send Enter
-	time.Sleep(500 * time.Millisecond)
-	enterCmd := exec.Command("tmux", "send-keys", "-t", sessionName, "Enter")
-	if err := enterCmd.Run(); err != nil {
-		os.Remove(promptPath)
-		return "failed-enter", fmt.Errorf("sending Enter to %s: %w", sessionName, err)
-	}
-
-	fmt.Printf("Started claude in session %s (timeout: %v)\n", sessionName, timeout)
-
-	// Wait for session to complete or timeout
-	ticker := time.NewTicker(10 * time.Second)
-	defer ticker.Stop()
-
-	timeoutCh := time.After(timeout)
-
-	for {
-		select {
-		case <-ticker.C:
-			// Check if session is still alive and active
-			if !r.isSessionActive(sessionName) {
-				fmt.Printf("Session %s completed\n", sessionName)
-				// Brief delay to let shell stabilize before next prompt
-				time.Sleep(2 * time.Second)
-				return "success", nil
-			}
-		case <-timeoutCh:
-			fmt.Printf("Session %s timed out after %v\n", sessionName, timeout)
-			return "timeout", nil
-		case <-r.stopSignal:
-			fmt.Printf("Stop signal received, leaving session %s running\n", sessionName)
-			return "stopped", nil
-		}
-	}
-}
-
-// isSessionActive checks if a tmux session has active processes
-func (r *Runner) isSessionActive(sessionName string) bool {
-	// Check if tmux session exists
-	cmd := exec.Command("tmux", "has-session", "-t", sessionName)
-	if err := cmd.Run(); err != nil {
-		return false
-	}
-
-	// Check if there's an active process (claude)
-	// Get the pane PID and check if it has children
-	cmd = exec.Command("tmux", "display-message", "-t", sessionName, "-p", "#{pane_pid}")
-	output, err := cmd.Output()
-	if err != nil {
-		return true // Assume active if we can't check
-	}
-
-	pid := strings.TrimSpace(string(output))
-	if pid == "" {
-		return false
-	}
-
-	// Check if the shell has child processes (claude running)
-	cmd = exec.Command("pgrep", "-P", pid)
-	if err := cmd.Run(); err != nil {
-		// No child processes, claude has finished
-		return false
-	}
-
-	return true
-}
-
-// waitForShellPrompt waits until the tmux session has no child processes (shell is idle at prompt).
-func (r *Runner) waitForShellPrompt(sessionName string, maxWait time.Duration) {
-	deadline := time.Now().Add(maxWait)
-	for time.Now().Before(deadline) {
-		if !r.isSessionActive(sessionName) {
-			return
-		}
-		time.Sleep(1 * time.Second)
-	}
-	r.logger.Log("Warning: timed out waiting for shell prompt in %s", sessionName)
-}
-
 // buildPrompt builds the prompt from template
 func (r *Runner) buildPrompt(template string, b *bead.ReadyBead, sessionName string, proj *project.Project) string {
 	prompt := template
@@ -570,10 +525,20 @@ func (r *Runner) buildPrompt(template string, b *bead.ReadyBead, sessionName str
 	prompt = strings.ReplaceAll(prompt, "{SESSION}", sessionName)
 	prompt = strings.ReplaceAll(prompt, "{PROJECT}", proj.Name)
 
-	// Get worktree path
-	worktreePath := r.cfg.WorktreePath(sessionName)
+	// Get worktree path ('wt new' names it after the bead)
+	worktreePath := r.cfg.ProjectWorktreePath(proj.Name, b.ID)
 	prompt = strings.ReplaceAll(prompt, "{WORKTREE}", worktreePath)
 
+	// Project conventions from context_files
+	if ctx := proj.ContextPrompt(""); ctx != "" {
+		prompt += "\n\n" + ctx
+	}
+
+	// Orchestration rules from the project's WORKERS.md
+	if guidelines := proj.GuidelinesPrompt(); guidelines != "" {
+		prompt += "\n\n" + guidelines
+	}
+
 	return prompt
 }
 
@@ -637,7 +602,7 @@ func (r *Runner) acquireLock() error {
 			// Check if process is still running
 			if r.isProcessRunning(lock.PID) {
 				if r.opts.Force {
-					fmt.Printf("Warning: Forcing lock override (previous PID: %d)\n", lock.PID)
+					logging.Warnf("Forcing lock override (previous PID: %d)", lock.PID)
 				} else {
 					return fmt.Errorf("another wt auto is running (PID: %d, started: %s). Use --force to override", lock.PID, lock.StartTime)
 				}
@@ -645,7 +610,11 @@ func (r *Runner) acquireLock() error {
 		}
 	}
 
-	// Write lock file
+	return r.writeLock()
+}
+
+// writeLock writes the lock file for the current epic
+func (r *Runner) writeLock() error {
 	lock := LockInfo{
 		PID:       os.Getpid(),
 		StartTime: time.Now().Format(time.RFC3339),
@@ -668,13 +637,7 @@ func (r *Runner) releaseLock() {
 
 // isProcessRunning checks if a process is running
 func (r *Runner) isProcessRunning(pid int) bool {
-	process, err := os.FindProcess(pid)
-	if err != nil {
-		return false
-	}
-	// On Unix, FindProcess always succeeds, so we need to send signal 0
-	err = process.Signal(syscall.Signal(0))
-	return err == nil
+	return processRunning(pid)
 }
 
 // signalStop signals a running wt auto to stop
@@ -709,7 +672,7 @@ func (r *Runner) signalStop() error {
 			stopPath = filepath.Join(r.cfg.ConfigDir(), "stop-auto")
 		}
 		if err := os.WriteFile(stopPath, []byte(time.Now().Format(time.RFC3339)), 0644); err != nil {
-			fmt.Printf("Warning: failed to send stop signal for %s: %v\n", lockPath, err)
+			logging.Warnf("failed to send stop signal for %s: %v", lockPath, err)
 			continue
 		}
 		if projName != "" {
@@ -746,7 +709,7 @@ func (r *Runner) setupSignalHandler() {
 
 	go func() {
 		<-sigCh
-		fmt.Println("\nReceived interrupt signal, will stop after current bead...")
+		logging.Infof("\nReceived interrupt signal, will stop after current bead...")
 		r.stopSignal <- struct{}{}
 	}()
 }
@@ -785,6 +748,28 @@ type EpicState struct {
 	StartTime      string            `json:"start_time"`
 	ProjectDir     string            `json:"project_dir"`
 	MergeMode      string            `json:"merge_mode"`
+	Isolated       bool              `json:"isolated,omitempty"`        // each bead runs in its own worktree
+	EpicBranch     string            `json:"epic_branch,omitempty"`     // branch bead worktrees are cut from
+	BeadWorktree   string            `json:"bead_worktree,omitempty"`   // current isolated bead worktree
+	BeadBranch     string            `json:"bead_branch,omitempty"`     // current isolated bead branch
+	NoPR           bool              `json:"no_pr,omitempty"`           // skip the finalization PR
+	PRURL          string            `json:"pr_url,omitempty"`          // finalization PR, once created
+	SkippedBeads   map[string]string `json:"skipped_beads,omitempty"`   // bead ID -> reason, set with 'wt auto state skip-bead'
+	ResumeContext  bool              `json:"resume_context,omitempty"`  // next bead resumes the previous bead's Claude session
+	ClaudeSessions map[string]string `json:"claude_sessions,omitempty"` // bead ID -> Claude session ID that worked on it
+	Cost           float64           `json:"cost,omitempty"`            // estimated Claude cost so far, USD
+	MaxCost        float64           `json:"max_cost,omitempty"`        // cost budget, USD (0 = none)
+	Snapshot       *RunSnapshot      `json:"snapshot,omitempty"`        // pre-run state for --abort --rollback
+	Gate           string            `json:"gate,omitempty"`            // review gate of --gate
+	PendingGate    *PendingGate      `json:"pending_gate,omitempty"`    // gate the run waits at, or was stopped at
+}
+
+// currentWorktree returns the worktree the current bead runs in
+func (s *EpicState) currentWorktree() string {
+	if s.BeadWorktree != "" {
+		return s.BeadWorktree
+	}
+	return s.Worktree
 }
 
 // EpicAuditResult holds the result of auditing an epic
@@ -799,8 +784,8 @@ type EpicAuditResult struct {
 	ProjectDir       string            `json:"project_dir"`
 }
 
-// processEpic sets up an epic for batch processing and starts the first bead.
-// Subsequent beads are handled by `wt signal bead-done` which triggers transitions.
+// processEpic sets up an epic and runs its beads one after another. Each
+// bead ends when its worker runs `wt signal bead-done` (see runSignaledBead).
 func (r *Runner) processEpic() error {
 	epicID := r.opts.Epic
 	r.logger.Log("Processing epic: %s", epicID)
@@ -808,6 +793,7 @@ func (r *Runner) processEpic() error {
 
 	// Run implicit audit unless skipped
 	if !r.opts.SkipAudit {
+		logging.Infof("Auditing epic %s...", epicID)
 		auditResult, err := r.auditEpic(epicID)
 		if err != nil {
 			return fmt.Errorf("audit failed: %w", err)
@@ -823,6 +809,9 @@ func (r *Runner) processEpic() error {
 		}
 
 		fmt.Printf("\n✓ Audit passed: %d bead(s) ready\n", len(auditResult.Beads))
+		for _, conflict := range auditResult.FileConflicts {
+			fmt.Printf("  ⚠ Possible conflict: %s\n", conflict)
+		}
 	}
 
 	// Get beads that block this epic (its dependencies)
@@ -843,7 +832,11 @@ func (r *Runner) processEpic() error {
 		for i, b := range beads {
 			fmt.Printf("  %d. %s: %s\n", i+1, b.ID, b.Title)
 		}
-		fmt.Println("\nWould create single worktree for sequential processing.")
+		if r.opts.Isolated {
+			fmt.Println("\nWould create an epic worktree plus a fresh worktree per bead (isolated mode).")
+		} else {
+			fmt.Println("\nWould create single worktree for sequential processing.")
+		}
 		fmt.Println("Worker signals completion via: wt signal bead-done \"<summary>\"")
 		return nil
 	}
@@ -854,11 +847,15 @@ func (r *Runner) processEpic() error {
 		return fmt.Errorf("finding project: %w", err)
 	}
 
+	// Snapshot bead statuses and branches before the run changes them
+	snapshot, branches := r.snapshotRun(projectDir, epicID, beads)
+
 	// Create single worktree for the epic (without --shell, so Claude starts)
 	sessionName, worktreePath, err := r.createEpicWorktree(epicID, proj)
 	if err != nil {
 		return fmt.Errorf("creating worktree: %w", err)
 	}
+	snapshot.recordBranch(worktreePath, branches)
 
 	fmt.Printf("\nCreated worktree: %s\n", worktreePath)
 	fmt.Printf("Session: %s\n", sessionName)
@@ -881,6 +878,12 @@ func (r *Runner) processEpic() error {
 		StartTime:      time.Now().Format(time.RFC3339),
 		ProjectDir:     projectDir,
 		MergeMode:      r.opts.MergeMode,
+		Isolated:       r.opts.Isolated,
+		NoPR:           r.opts.NoPR,
+		ResumeContext:  r.opts.ResumeContext,
+		MaxCost:        r.costLimit(proj),
+		Snapshot:       snapshot,
+		Gate:           r.opts.Gate,
 	}
 	for i, b := range beads {
 		state.Beads[i] = b.ID
@@ -900,10 +903,25 @@ func (r *Runner) processEpic() error {
 		timeout = time.Duration(r.opts.Timeout) * time.Minute
 	}
 
-	// Process all beads sequentially, staying alive for the entire epic
-	for i, b := range beads {
-		beadNum := i + 1
-		totalBeads := len(beads)
+	beadInfo := make(map[string]bead.ReadyBead, len(beads))
+	for _, b := range beads {
+		beadInfo[b.ID] = b
+	}
+
+	// Process beads in queue order, staying alive for the entire epic.
+	// The queue is re-read each time: 'wt auto state' may skip or reorder beads.
+	for {
+		r.applyQueuedEdits(state)
+		beadID := state.NextBead()
+		if beadID == "" {
+			break
+		}
+		b, ok := beadInfo[beadID]
+		if !ok {
+			b = bead.ReadyBead{ID: beadID, Title: state.BeadTitles[beadID]}
+		}
+		beadNum := slices.Index(state.Beads, beadID) + 1
+		totalBeads := len(state.Beads)
 
 		if r.shouldStop() {
 			state.Status = "paused"
@@ -930,6 +948,25 @@ func (r *Runner) processEpic() error {
 			continue
 		}
 
+		if err := r.paceEpic(state, proj); err != nil {
+			state.Status = "paused"
+			state.CurrentBead = b.ID
+			r.saveEpicState(state)
+			r.logger.Log("Pacing: %v, pausing epic", err)
+			fmt.Printf("\nPaused at bead %d/%d (%v). Use 'wt auto --resume' to continue.\n", beadNum, totalBeads, err)
+			return nil
+		}
+
+		if err := r.syncEpicBranch(state, proj); err != nil {
+			state.Status = "paused"
+			state.CurrentBead = b.ID
+			r.saveEpicState(state)
+			r.logger.Log("Main drift: %v, pausing epic", err)
+			fmt.Printf("\nPaused at bead %d/%d: %v\n", beadNum, totalBeads, err)
+			fmt.Printf("Bring %s up to date by hand, then 'wt auto --resume' to continue.\n", state.Worktree)
+			return nil
+		}
+
 		state.CurrentBead = b.ID
 		r.saveEpicState(state)
 
@@ -947,7 +984,13 @@ func (r *Runner) processEpic() error {
 		// Build batch-aware prompt
 		prompt := r.buildEpicBeadPrompt(&b, state.SessionName, proj, beadNum, totalBeads, state)
 
-		outcome, err := r.runClaudeInSession(state.SessionName, autoCfg.Command, prompt, timeout)
+		command, prompt := r.epicBeadCommand(state, autoCfg.Command, prompt)
+		beadStart := time.Now()
+		r.logger.LogBeadStart(b.ID, b.Title)
+		outcome, err := r.runEpicBead(state, b.ID, command, prompt, timeout)
+		r.recordClaudeSession(state, b.ID, beadStart)
+		r.trackEpicCost(state, b.ID, beadStart)
+		r.recordBead(BeadRun{ID: b.ID, Title: b.Title, Estimate: b.EstimatedMinutes, Epic: state.EpicID, Session: state.SessionName, Outcome: outcome}, beadStart, state.Worktree, err)
 		if err != nil || (outcome != "success" && outcome != "dry-run") {
 			// Dual-write: send STUCK message
 			if r.store != nil {
@@ -963,7 +1006,10 @@ func (r *Runner) processEpic() error {
 			}
 			state.FailedBeads[b.ID] = outcome
 			r.saveEpicState(state)
-			fmt.Printf("Warning: bead %s failed (%s), continuing...\n", b.ID, outcome)
+			logging.Warnf("bead %s failed (%s), continuing...", b.ID, outcome)
+			if !r.passGate(state, GateAfterEachBead, b.ID) {
+				return nil
+			}
 			continue
 		}
 
@@ -982,6 +1028,7 @@ func (r *Runner) processEpic() error {
 
 		state.CompletedBeads = append(state.CompletedBeads, b.ID)
 		r.saveEpicState(state)
+		r.closeSignaledBead(state, b.ID)
 		fmt.Printf("✓ Bead %s completed (commit: %s)\n", b.ID, commitHash)
 
 		// Dual-write: send DONE message
@@ -989,18 +1036,13 @@ func (r *Runner) processEpic() error {
 			doneBody, _ := json.Marshal(msg.DoneBody{BeadID: b.ID, CommitHash: commitHash, Summary: commitMsg})
 			r.store.Send(&msg.Message{Subject: msg.SubjectDone, From: state.SessionName, To: "orchestrator", Body: string(doneBody), ThreadID: epicID})
 		}
-
-		// Ensure Claude has exited before starting next bead (prevents pasting into REPL)
-		if i < len(beads)-1 {
-			fmt.Printf("  Ensuring Claude session is terminated for next bead...\n")
-			r.killClaudeSession(state.SessionName)
-			// Wait for shell prompt to be ready
-			r.waitForShellPrompt(state.SessionName, 10*time.Second)
+		if !r.passGate(state, GateAfterEachBead, b.ID) {
+			return nil
 		}
 	}
 
-	// Determine final status
-	allSucceeded := len(state.FailedBeads) == 0
+	// Determine final status (skipped beads stay open, so the epic can't close)
+	allSucceeded := len(state.FailedBeads) == 0 && len(state.SkippedBeads) == 0
 	if allSucceeded {
 		state.Status = "completed"
 	} else {
@@ -1016,20 +1058,37 @@ func (r *Runner) processEpic() error {
 			fmt.Printf("    - %s: %s\n", beadID, reason)
 		}
 	}
+	if len(state.SkippedBeads) > 0 {
+		fmt.Printf("  Skipped: %d\n", len(state.SkippedBeads))
+		for beadID, reason := range state.SkippedBeads {
+			fmt.Printf("    - %s: %s\n", beadID, reason)
+		}
+	}
 
 	// Only close epic if all beads succeeded
+	if allSucceeded && !r.passGate(state, GateBeforeMerge, "") {
+		return nil
+	}
 	if allSucceeded {
 		if err := r.closeEpic(state.EpicID, state.ProjectDir); err != nil {
-			fmt.Printf("Warning: could not auto-close epic: %v\n", err)
+			logging.Warnf("could not auto-close epic: %v", err)
 		} else {
 			fmt.Printf("✓ Epic %s closed\n", state.EpicID)
 		}
 
+		if prURL, err := createEpicPR(r.cfg, state); err != nil {
+			logging.Warnf("could not create epic PR: %v", err)
+		} else if prURL != "" {
+			r.logger.Log("Epic %s PR: %s", state.EpicID, prURL)
+			state.PRURL = prURL
+			r.saveEpicRecord(state)
+		}
+
 		batchMarkerPath := filepath.Join(state.Worktree, ".wt-batch-mode")
 		os.Remove(batchMarkerPath)
 		r.removeEpicState()
 	} else {
-		fmt.Printf("\n✗ Epic %s NOT closed due to failed beads\n", state.EpicID)
+		fmt.Printf("\n✗ Epic %s NOT closed due to failed or skipped beads\n", state.EpicID)
 		fmt.Printf("  Fix failures and run 'wt auto --resume --epic %s' to retry\n", state.EpicID)
 		fmt.Printf("  Or run 'wt auto --abort --epic %s' to clean up\n", state.EpicID)
 	}
@@ -1037,71 +1096,6 @@ func (r *Runner) processEpic() error {
 	return nil
 }
 
-// auditEpic checks if an epic is ready for batch processing
-func (r *Runner) auditEpic(epicID string) (*EpicAuditResult, error) {
-	fmt.Printf("Auditing epic %s...\n", epicID)
-
-	result := &EpicAuditResult{
-		EpicID:     epicID,
-		Ready:      true,
-		BeadTitles: make(map[string]string),
-	}
-
-	// Get beads blocking this epic
-	beads, projectDir, err := r.getEpicBeads(epicID)
-	if err != nil {
-		return nil, err
-	}
-	result.ProjectDir = projectDir
-
-	if len(beads) == 0 {
-		result.Ready = false
-		result.Issues = append(result.Issues, "No beads found blocking this epic")
-		return result, nil
-	}
-
-	for _, b := range beads {
-		result.Beads = append(result.Beads, b.ID)
-		result.BeadTitles[b.ID] = b.Title
-	}
-
-	// Check: beads all from same project (already ensured by getEpicBeads)
-
-	// Check: no external blockers (beads should be ready)
-	for _, b := range beads {
-		blockers, err := r.getBeadBlockers(b.ID, projectDir)
-		if err != nil {
-			continue
-		}
-		for _, blocker := range blockers {
-			// Check if blocker is in our bead list (internal) or external
-			// Also skip if blocker is the epic itself (parent-child relationship)
-			isInternal := blocker == epicID
-			for _, ob := range beads {
-				if ob.ID == blocker {
-					isInternal = true
-					break
-				}
-			}
-			if !isInternal {
-				result.Ready = false
-				result.ExternalBlockers = append(result.ExternalBlockers, fmt.Sprintf("%s blocked by %s", b.ID, blocker))
-				result.Issues = append(result.Issues, fmt.Sprintf("Bead %s has external blocker: %s", b.ID, blocker))
-			}
-		}
-	}
-
-	// Check: beads have descriptions (basic readiness)
-	for _, b := range beads {
-		if b.Description == "" {
-			result.Ready = false
-			result.Issues = append(result.Issues, fmt.Sprintf("Bead %s has no description", b.ID))
-		}
-	}
-
-	return result, nil
-}
-
 // getEpicBeads returns all beads that block the given epic (dependencies of the epic)
 func (r *Runner) getEpicBeads(epicID string) ([]bead.ReadyBead, string, error) {
 	// First, find which project contains this epic
@@ -1115,9 +1109,7 @@ func (r *Runner) getEpicBeads(epicID string) ([]bead.ReadyBead, string, error) {
 		projectDir := proj.RepoPath()
 
 		// Check if this epic exists in this project
-		cmd := exec.Command("bd", "show", epicID, "--json")
-		cmd.Dir = projectDir
-		output, err := cmd.Output()
+		output, err := bead.Output(projectDir, "show", epicID, "--json")
 		if err != nil {
 			continue // Epic not in this project
 		}
@@ -1135,9 +1127,7 @@ func (r *Runner) getEpicBeads(epicID string) ([]bead.ReadyBead, string, error) {
 		}
 
 		// Get epic's children via dependents from bd show --json
-		cmd = exec.Command("bd", "show", epicID, "--json")
-		cmd.Dir = projectDir
-		showOutput, showErr := cmd.Output()
+		showOutput, showErr := bead.Output(projectDir, "show", epicID, "--json")
 
 		var childIDs []string
 
@@ -1156,9 +1146,7 @@ func (r *Runner) getEpicBeads(epicID string) ([]bead.ReadyBead, string, error) {
 
 		// Fallback: try bd dep list --direction blocked-by
 		if len(childIDs) == 0 {
-			cmd = exec.Command("bd", "dep", "list", epicID, "--json", "--direction", "blocked-by")
-			cmd.Dir = projectDir
-			if depOutput, depErr := cmd.Output(); depErr == nil {
+			if depOutput, depErr := bead.Output(projectDir, "dep", "list", epicID, "--json", "--direction", "blocked-by"); depErr == nil {
 				var deps []struct {
 					ID string `json:"id"`
 				}
@@ -1201,9 +1189,7 @@ func (r *Runner) getEpicBeads(epicID string) ([]bead.ReadyBead, string, error) {
 
 // getBeadBlockers returns IDs of beads that block the given bead
 func (r *Runner) getBeadBlockers(beadID, projectDir string) ([]string, error) {
-	cmd := exec.Command("bd", "dep", "list", beadID, "--json", "--direction", "blocked-by")
-	cmd.Dir = projectDir
-	output, err := cmd.Output()
+	output, err := bead.Output(projectDir, "dep", "list", beadID, "--json", "--direction", "blocked-by")
 	if err != nil {
 		return nil, err
 	}
@@ -1223,7 +1209,7 @@ func (r *Runner) getBeadBlockers(beadID, projectDir string) ([]string, error) {
 }
 
 // createEpicWorktree creates a single worktree for processing an epic
-func (r *Runner) createEpicWorktree(epicID string, _ *project.Project) (string, string, error) {
+func (r *Runner) createEpicWorktree(epicID string, proj *project.Project) (string, string, error) {
 	// Generate a session name from epic ID
 	sessionName := strings.ReplaceAll(epicID, "-", "")
 	if len(sessionName) > 8 {
@@ -1237,7 +1223,7 @@ func (r *Runner) createEpicWorktree(epicID string, _ *project.Project) (string,
 	if r.opts.Project != "" {
 		args = append(args, "--project", r.opts.Project)
 	}
-	cmd := exec.Command("wt", args...)
+	cmd := logging.Command("wt", args...)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return "", "", fmt.Errorf("creating worktree: %s: %w", string(output), err)
@@ -1266,7 +1252,11 @@ func (r *Runner) createEpicWorktree(epicID string, _ *project.Project) (string,
 	}
 
 	if worktreePath == "" {
-		worktreePath = r.cfg.WorktreePath(sessionName)
+		projectName := ""
+		if proj != nil {
+			projectName = proj.Name
+		}
+		worktreePath = r.cfg.ProjectWorktreePath(projectName, epicID)
 	}
 
 	// Create batch mode marker file so wt done knows not to clean up session
@@ -1280,7 +1270,7 @@ func (r *Runner) createEpicWorktree(epicID string, _ *project.Project) (string,
 
 // buildEpicBeadPrompt builds the prompt for processing a bead within an epic
 // This is the batch-aware version that includes epic context and previous bead summaries
-func (r *Runner) buildEpicBeadPrompt(b *bead.ReadyBead, sessionName string, _ *project.Project, current, total int, state *EpicState) string {
+func (r *Runner) buildEpicBeadPrompt(b *bead.ReadyBead, sessionName string, proj *project.Project, current, total int, state *EpicState) string {
 	var sb strings.Builder
 
 	// Header with epic context
@@ -1319,6 +1309,12 @@ func (r *Runner) buildEpicBeadPrompt(b *bead.ReadyBead, sessionName string, _ *p
 		sb.WriteString("\n\n")
 	}
 
+	// Project conventions from context_files
+	sb.WriteString(proj.ContextPrompt(state.currentWorktree()))
+
+	// Orchestration rules from the project's WORKERS.md
+	sb.WriteString(proj.GuidelinesPrompt())
+
 	// Workflow section with bead-done signal
 	sb.WriteString("## Workflow\n")
 	sb.WriteString("1. Review previous commits if relevant: `git log --oneline -5`\n")
@@ -1362,9 +1358,7 @@ func (r *Runner) getProjectForPath(path string) (*project.Project, error) {
 
 // closeEpic closes the epic bead
 func (r *Runner) closeEpic(epicID, projectDir string) error {
-	cmd := exec.Command("bd", "close", epicID)
-	cmd.Dir = projectDir
-	output, err := cmd.CombinedOutput()
+	output, err := bead.CombinedOutput(projectDir, "close", epicID)
 	if err != nil {
 		return fmt.Errorf("closing epic: %s: %w", string(output), err)
 	}
@@ -1373,9 +1367,7 @@ func (r *Runner) closeEpic(epicID, projectDir string) error {
 
 // getEpicTitle fetches the title of an epic for batch-aware prompts
 func (r *Runner) getEpicTitle(epicID, projectDir string) string {
-	cmd := exec.Command("bd", "show", epicID, "--json")
-	cmd.Dir = projectDir
-	output, err := cmd.Output()
+	output, err := bead.Output(projectDir, "show", epicID, "--json")
 	if err != nil {
 		return ""
 	}
@@ -1402,6 +1394,9 @@ func (r *Runner) saveEpicState(state *EpicState) error {
 	if err != nil {
 		return err
 	}
+	if err := r.saveEpicRecord(state); err != nil {
+		return err
+	}
 	return os.WriteFile(r.epicStateFile(), data, 0644)
 }
 
@@ -1419,6 +1414,7 @@ func (r *Runner) loadEpicState() (*EpicState, error) {
 
 func (r *Runner) removeEpicState() {
 	os.Remove(r.epicStateFile())
+	os.Remove(r.beadSignalFile())
 }
 
 // checkStatus shows the status of a running or paused auto session
@@ -1454,6 +1450,8 @@ func (r *Runner) checkStatus() error {
 // checkStatusForProject shows the status of a running auto for a specific project.
 // Pass empty string for legacy global lock.
 func (r *Runner) checkStatusForProject(projectName string) error {
+	defer r.printEpicQueue(projectName)
+
 	// Temporarily set paths for this project
 	var lockFile, stateFile string
 	if projectName != "" {
@@ -1480,10 +1478,24 @@ func (r *Runner) checkStatusForProject(projectName string) error {
 			fmt.Printf("  Session:    %s\n", state.SessionName)
 			fmt.Printf("  Started:    %s\n", state.StartTime)
 			fmt.Printf("  Progress:   %d/%d beads completed\n", len(state.CompletedBeads), len(state.Beads))
+			if state.MaxCost > 0 {
+				fmt.Printf("  Cost:       ~%s of %s budget\n", usage.FormatCost(state.Cost), usage.FormatCost(state.MaxCost))
+			} else if state.Cost > 0 {
+				fmt.Printf("  Cost:       ~%s\n", usage.FormatCost(state.Cost))
+			}
+			if state.Isolated {
+				fmt.Printf("  Mode:       isolated (worktree per bead)\n")
+				if state.BeadWorktree != "" {
+					fmt.Printf("  Bead tree:  %s\n", state.BeadWorktree)
+				}
+			}
 
 			if state.CurrentBead != "" {
 				fmt.Printf("  Current:    %s\n", state.CurrentBead)
 			}
+			if state.PendingGate != nil {
+				fmt.Printf("  Gate:       %s\n", state.PendingGate.Describe(&state))
+			}
 			if len(state.FailedBeads) > 0 {
 				fmt.Printf("  Failed:     %d bead(s)\n", len(state.FailedBeads))
 			} else if state.FailedBead != "" {
@@ -1573,41 +1585,33 @@ func (r *Runner) resumeRun() error {
 		return fmt.Errorf("epic mismatch: state has %s, you specified %s", state.EpicID, r.opts.Epic)
 	}
 
-	fmt.Printf("Resuming epic %s...\n", state.EpicID)
+	logging.Infof("Resuming epic %s...", state.EpicID)
 	fmt.Printf("  Status: %s\n", state.Status)
 	fmt.Printf("  Progress: %d/%d completed\n", len(state.CompletedBeads), len(state.Beads))
 
-	// Find where to resume
-	resumeIndex := 0
-	completedSet := make(map[string]bool)
-	for _, id := range state.CompletedBeads {
-		completedSet[id] = true
+	// Find where to resume (failed beads are retried, skipped ones are not)
+	r.applyQueuedEdits(state)
+	retry := *state
+	retry.FailedBeads = nil
+	retry.FailedBead = ""
+	if next := retry.NextBead(); next != "" {
+		fmt.Printf("  Resuming from bead %d: %s\n", slices.Index(state.Beads, next)+1, next)
 	}
 
-	for i, id := range state.Beads {
-		if !completedSet[id] {
-			resumeIndex = i
-			break
+	// Fetch info for beads not yet completed
+	beadInfo := make(map[string]bead.ReadyBead)
+	for _, beadID := range state.Beads {
+		if slices.Contains(state.CompletedBeads, beadID) {
+			continue
 		}
-	}
-
-	fmt.Printf("  Resuming from bead %d: %s\n", resumeIndex+1, state.Beads[resumeIndex])
-
-	// Get remaining beads
-	var remainingBeads []bead.ReadyBead
-	for i := resumeIndex; i < len(state.Beads); i++ {
-		beadID := state.Beads[i]
-		// Fetch bead info
-		cmd := exec.Command("bd", "show", beadID, "--json")
-		cmd.Dir = state.ProjectDir
-		output, _ := cmd.Output()
+		output, _ := bead.Output(state.ProjectDir, "show", beadID, "--json")
 
 		var infos []bead.ReadyBead
 		json.Unmarshal(output, &infos)
 		if len(infos) > 0 {
-			remainingBeads = append(remainingBeads, infos[0])
+			beadInfo[beadID] = infos[0]
 		} else {
-			remainingBeads = append(remainingBeads, bead.ReadyBead{ID: beadID})
+			beadInfo[beadID] = bead.ReadyBead{ID: beadID}
 		}
 	}
 
@@ -1617,6 +1621,35 @@ func (r *Runner) resumeRun() error {
 		return fmt.Errorf("finding project: %w", err)
 	}
 
+	// Isolated mode: drop the bead worktree preserved by --pause-on-failure
+	if state.Isolated {
+		r.opts.Isolated = true
+		r.discardBeadWorktree(state)
+	}
+
+	if r.opts.ResumeContext {
+		if state.Isolated {
+			logging.Warnf("--resume-context is ignored for isolated runs")
+		}
+		state.ResumeContext = true
+	}
+	if r.opts.Gate != "" {
+		state.Gate = r.opts.Gate
+	}
+	if state.PendingGate != nil {
+		// Resuming a run stopped at a gate approves it
+		r.mergeApproved = state.PendingGate.Gate == GateBeforeMerge
+		state.PendingGate = nil
+	}
+	if r.opts.MaxCost > 0 {
+		state.MaxCost = r.opts.MaxCost
+	} else if state.MaxCost == 0 {
+		state.MaxCost = r.costLimit(proj)
+	}
+	if state.MaxCost > 0 {
+		fmt.Printf("  Cost: ~%s of %s budget\n", usage.FormatCost(state.Cost), usage.FormatCost(state.MaxCost))
+	}
+
 	// Update state - clear failures on resume
 	state.Status = "running"
 	state.FailedBead = ""
@@ -1641,9 +1674,18 @@ func (r *Runner) resumeRun() error {
 		state.BeadCommits = []BeadCommitInfo{}
 	}
 
-	// Process remaining beads with fresh sessions
-	for i, b := range remainingBeads {
-		beadNum := resumeIndex + i + 1
+	// Process remaining beads with fresh sessions, re-reading the queue each time
+	for {
+		r.applyQueuedEdits(state)
+		beadID := state.NextBead()
+		if beadID == "" {
+			break
+		}
+		b, ok := beadInfo[beadID]
+		if !ok {
+			b = bead.ReadyBead{ID: beadID, Title: state.BeadTitles[beadID]}
+		}
+		beadNum := slices.Index(state.Beads, beadID) + 1
 		totalBeads := len(state.Beads)
 
 		if r.shouldStop() {
@@ -1671,6 +1713,25 @@ func (r *Runner) resumeRun() error {
 			continue
 		}
 
+		if err := r.paceEpic(state, proj); err != nil {
+			state.Status = "paused"
+			state.CurrentBead = b.ID
+			r.saveEpicState(state)
+			r.logger.Log("Pacing: %v, pausing epic", err)
+			fmt.Printf("\nPaused at bead %d/%d (%v). Use 'wt auto --resume' to continue.\n", beadNum, totalBeads, err)
+			return nil
+		}
+
+		if err := r.syncEpicBranch(state, proj); err != nil {
+			state.Status = "paused"
+			state.CurrentBead = b.ID
+			r.saveEpicState(state)
+			r.logger.Log("Main drift: %v, pausing epic", err)
+			fmt.Printf("\nPaused at bead %d/%d: %v\n", beadNum, totalBeads, err)
+			fmt.Printf("Bring %s up to date by hand, then 'wt auto --resume' to continue.\n", state.Worktree)
+			return nil
+		}
+
 		state.CurrentBead = b.ID
 		r.saveEpicState(state)
 
@@ -1682,7 +1743,13 @@ func (r *Runner) resumeRun() error {
 		// Build batch-aware prompt (includes previous bead summaries)
 		prompt := r.buildEpicBeadPrompt(&b, state.SessionName, proj, beadNum, totalBeads, state)
 
-		outcome, err := r.runClaudeInSession(state.SessionName, autoCfg.Command, prompt, timeout)
+		command, prompt := r.epicBeadCommand(state, autoCfg.Command, prompt)
+		beadStart := time.Now()
+		r.logger.LogBeadStart(b.ID, b.Title)
+		outcome, err := r.runEpicBead(state, b.ID, command, prompt, timeout)
+		r.recordClaudeSession(state, b.ID, beadStart)
+		r.trackEpicCost(state, b.ID, beadStart)
+		r.recordBead(BeadRun{ID: b.ID, Title: b.Title, Estimate: b.EstimatedMinutes, Epic: state.EpicID, Session: state.SessionName, Outcome: outcome}, beadStart, state.Worktree, err)
 		if err != nil || (outcome != "success" && outcome != "dry-run") {
 			if r.opts.PauseOnFailure {
 				state.Status = "failed"
@@ -1694,7 +1761,10 @@ func (r *Runner) resumeRun() error {
 			// Track failed bead
 			state.FailedBeads[b.ID] = outcome
 			r.saveEpicState(state)
-			fmt.Printf("Warning: bead %s failed (%s), continuing...\n", b.ID, outcome)
+			logging.Warnf("bead %s failed (%s), continuing...", b.ID, outcome)
+			if !r.passGate(state, GateAfterEachBead, b.ID) {
+				return nil
+			}
 			continue
 		}
 
@@ -1713,6 +1783,7 @@ func (r *Runner) resumeRun() error {
 
 		state.CompletedBeads = append(state.CompletedBeads, b.ID)
 		r.saveEpicState(state)
+		r.closeSignaledBead(state, b.ID)
 		fmt.Printf("✓ Bead %s completed (commit: %s)\n", b.ID, commitHash)
 
 		// Dual-write: send DONE message
@@ -1720,19 +1791,13 @@ func (r *Runner) resumeRun() error {
 			doneBody, _ := json.Marshal(msg.DoneBody{BeadID: b.ID, CommitHash: commitHash, Summary: commitMsg})
 			r.store.Send(&msg.Message{Subject: msg.SubjectDone, From: state.SessionName, To: "orchestrator", Body: string(doneBody), ThreadID: state.EpicID})
 		}
-
-		// Ensure Claude has exited before starting next bead (prevents pasting into REPL)
-		// Only if there are more beads to process
-		if i < len(remainingBeads)-1 {
-			fmt.Printf("  Ensuring Claude session is terminated for next bead...\n")
-			r.killClaudeSession(state.SessionName)
-			// Wait for shell prompt to be ready
-			r.waitForShellPrompt(state.SessionName, 10*time.Second)
+		if !r.passGate(state, GateAfterEachBead, b.ID) {
+			return nil
 		}
 	}
 
-	// Determine final status based on failures
-	allSucceeded := len(state.FailedBeads) == 0
+	// Determine final status based on failures and skips
+	allSucceeded := len(state.FailedBeads) == 0 && len(state.SkippedBeads) == 0
 	if allSucceeded {
 		state.Status = "completed"
 	} else {
@@ -1748,11 +1813,20 @@ func (r *Runner) resumeRun() error {
 			fmt.Printf("    - %s: %s\n", beadID, reason)
 		}
 	}
+	if len(state.SkippedBeads) > 0 {
+		fmt.Printf("  Skipped: %d\n", len(state.SkippedBeads))
+		for beadID, reason := range state.SkippedBeads {
+			fmt.Printf("    - %s: %s\n", beadID, reason)
+		}
+	}
 
 	// Only close epic if all beads succeeded
+	if allSucceeded && !r.passGate(state, GateBeforeMerge, "") {
+		return nil
+	}
 	if allSucceeded {
 		if err := r.closeEpic(state.EpicID, state.ProjectDir); err != nil {
-			fmt.Printf("Warning: could not auto-close epic: %v\n", err)
+			logging.Warnf("could not auto-close epic: %v", err)
 		} else {
 			fmt.Printf("✓ Epic %s closed\n", state.EpicID)
 		}
@@ -1763,7 +1837,7 @@ func (r *Runner) resumeRun() error {
 
 		r.removeEpicState()
 	} else {
-		fmt.Printf("\n✗ Epic %s NOT closed due to failed beads\n", state.EpicID)
+		fmt.Printf("\n✗ Epic %s NOT closed due to failed or skipped beads\n", state.EpicID)
 		fmt.Printf("  Fix failures and run 'wt auto --resume --epic %s' to retry\n", state.EpicID)
 		fmt.Printf("  Or run 'wt auto --abort --epic %s' to clean up\n", state.EpicID)
 	}
@@ -1782,29 +1856,53 @@ func (r *Runner) abortRun() error {
 	if r.opts.Epic != "" && r.opts.Epic != state.EpicID {
 		return fmt.Errorf("epic mismatch: state has %s, you specified %s", state.EpicID, r.opts.Epic)
 	}
+	if r.opts.Rollback && state.Snapshot == nil {
+		return fmt.Errorf("epic %s has no pre-run snapshot to roll back to; run 'wt auto --abort' without --rollback", state.EpicID)
+	}
 
-	fmt.Printf("Aborting epic %s...\n", state.EpicID)
+	logging.Infof("Aborting epic %s...", state.EpicID)
 	fmt.Printf("  Status: %s\n", state.Status)
 	fmt.Printf("  Completed: %d/%d beads\n", len(state.CompletedBeads), len(state.Beads))
 
 	// Kill tmux session if exists
 	if state.SessionName != "" {
 		fmt.Printf("  Killing session: %s\n", state.SessionName)
-		cmd := exec.Command("tmux", "kill-session", "-t", state.SessionName)
+		cmd := logging.Command("tmux", "kill-session", "-t", state.SessionName)
 		cmd.Run() // Ignore errors
 	}
 
+	// Remove isolated bead worktree
+	if state.BeadWorktree != "" {
+		fmt.Printf("  Removing bead worktree: %s\n", state.BeadWorktree)
+		r.discardBeadWorktree(state)
+	}
+
 	// Remove worktree
 	if state.Worktree != "" {
 		fmt.Printf("  Removing worktree: %s\n", state.Worktree)
-		cmd := exec.Command("git", "worktree", "remove", state.Worktree, "--force")
+		cmd := logging.CommandIn(state.ProjectDir, "git", "worktree", "remove", state.Worktree, "--force")
 		cmd.Run() // Ignore errors
 	}
 
-	// Clean up state
+	var rollbackErr error
+	if r.opts.Rollback {
+		logging.Infof("  Rolling back to the pre-run state...")
+		rollbackErr = r.rollbackRun(state)
+	}
+
+	// Clean up state, keeping the epic's record for the queue view
+	state.Status = "aborted"
+	r.saveEpicRecord(state)
 	r.removeEpicState()
 	r.releaseLock()
 
+	if rollbackErr != nil {
+		return rollbackErr
+	}
+	if r.opts.Rollback {
+		fmt.Println("\n✓ Epic run aborted and rolled back.")
+		return nil
+	}
 	fmt.Println("\n✓ Epic run aborted and cleaned up.")
 	fmt.Printf("Note: %d bead(s) were completed before abort.\n", len(state.CompletedBeads))
 
@@ -1814,8 +1912,7 @@ func (r *Runner) abortRun() error {
 // getLatestCommitInfo retrieves the latest commit hash and message from a worktree
 func (r *Runner) getLatestCommitInfo(worktreePath string) (hash, message string, err error) {
 	// Get the latest commit hash
-	cmd := exec.Command("git", "rev-parse", "--short", "HEAD")
-	cmd.Dir = worktreePath
+	cmd := logging.CommandIn(worktreePath, "git", "rev-parse", "--short", "HEAD")
 	hashOutput, err := cmd.Output()
 	if err != nil {
 		return "", "", fmt.Errorf("getting commit hash: %w", err)
@@ -1823,7 +1920,7 @@ func (r *Runner) getLatestCommitInfo(worktreePath string) (hash, message string,
 	hash = strings.TrimSpace(string(hashOutput))
 
 	// Get the commit message (first line)
-	cmd = exec.Command("git", "log", "-1", "--format=%s")
+	cmd = logging.Command("git", "log", "-1", "--format=%s")
 	cmd.Dir = worktreePath
 	msgOutput, err := cmd.Output()
 	if err != nil {
@@ -1834,360 +1931,23 @@ func (r *Runner) getLatestCommitInfo(worktreePath string) (hash, message string,
 	return hash, message, nil
 }
 
-// killClaudeSession terminates the Claude process in a tmux session without killing the session
-func (r *Runner) killClaudeSession(sessionName string) error {
-	return KillClaudeInSession(sessionName)
-}
-
-// KillClaudeInSession terminates the Claude process in a tmux session without killing the session.
-// Exported for use by wt signal bead-done.
-func KillClaudeInSession(sessionName string) error {
-	// Send Ctrl+C to gracefully stop claude, then wait a moment
-	cmd := exec.Command("tmux", "send-keys", "-t", sessionName, "C-c")
-	if err := cmd.Run(); err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: could not send Ctrl+C to session: %v\n", err)
-	}
-
-	// Wait for Claude to exit
-	time.Sleep(2 * time.Second)
-
-	// If still running, send another Ctrl+C
-	cmd = exec.Command("tmux", "display-message", "-t", sessionName, "-p", "#{pane_pid}")
-	output, err := cmd.Output()
-	if err == nil {
-		pid := strings.TrimSpace(string(output))
-		if pid != "" {
-			// Check if the shell has child processes (claude running)
-			cmd = exec.Command("pgrep", "-P", pid)
-			if cmd.Run() == nil {
-				// Still has children, send another Ctrl+C
-				cmd = exec.Command("tmux", "send-keys", "-t", sessionName, "C-c")
-				cmd.Run()
-				time.Sleep(1 * time.Second)
-			}
-		}
-	}
-
-	return nil
-}
-
-// --- Exported functions for wt signal bead-done ---
+// --- Exported epic state helpers ---
 
 // EpicStateFile returns the path to the epic state file
 func EpicStateFile(cfg *config.Config) string {
 	return filepath.Join(cfg.ConfigDir(), "auto-epic-state.json")
 }
 
-// LoadEpicState loads the current epic state from disk
-func LoadEpicState(cfg *config.Config) (*EpicState, error) {
-	data, err := os.ReadFile(EpicStateFile(cfg))
-	if err != nil {
-		return nil, err
-	}
-	var state EpicState
-	if err := json.Unmarshal(data, &state); err != nil {
-		return nil, err
-	}
-	return &state, nil
-}
-
-// SaveEpicState saves the epic state to disk
-func SaveEpicState(cfg *config.Config, state *EpicState) error {
-	data, err := json.MarshalIndent(state, "", "  ")
-	if err != nil {
-		return err
-	}
-	return os.WriteFile(EpicStateFile(cfg), data, 0644)
-}
-
-// RemoveEpicState removes the epic state file
-func RemoveEpicState(cfg *config.Config) {
-	os.Remove(EpicStateFile(cfg))
-}
-
-// IsInAutoMode checks if the given worktree is part of an active auto mode epic
-func IsInAutoMode(cfg *config.Config, worktreePath string) (*EpicState, bool) {
-	state, err := LoadEpicState(cfg)
-	if err != nil {
-		return nil, false
-	}
-	// Check if this worktree matches the epic's worktree
-	if state.Worktree == worktreePath && state.Status == "running" {
-		return state, true
-	}
-	return nil, false
-}
-
-// HandleBeadDone handles the bead-done signal in auto mode.
-// This is the main orchestration function called by wt signal bead-done.
-func HandleBeadDone(cfg *config.Config, state *EpicState, summary string) error {
-	fmt.Println("\n=== Auto Mode: Bead Complete ===")
-
-	// 1. Post-bead housekeeping
-	if err := postBeadHousekeeping(cfg, state, summary); err != nil {
-		return fmt.Errorf("post-bead housekeeping: %w", err)
-	}
-
-	// 2. Check if there are more beads
-	nextBeadIndex := len(state.CompletedBeads)
-	if nextBeadIndex >= len(state.Beads) {
-		// All beads done - finalize epic
-		return finalizeEpic(cfg, state)
-	}
-
-	// 3. Pre-bead housekeeping for next bead
-	nextBeadID := state.Beads[nextBeadIndex]
-	if err := preBeadHousekeeping(cfg, state, nextBeadID); err != nil {
-		return fmt.Errorf("pre-bead housekeeping: %w", err)
-	}
-
-	// 4. Kill Claude and start next bead
-	if err := transitionToNextBead(cfg, state, nextBeadID, nextBeadIndex); err != nil {
-		return fmt.Errorf("transitioning to next bead: %w", err)
-	}
-
-	return nil
-}
-
-// postBeadHousekeeping handles tasks after a bead completes
-func postBeadHousekeeping(cfg *config.Config, state *EpicState, summary string) error {
-	currentBead := state.CurrentBead
-	fmt.Printf("Post-bead housekeeping for %s...\n", currentBead)
-
-	// Capture commit info
-	commitHash, commitMsg, err := getLatestCommit(state.Worktree)
-	if err != nil {
-		fmt.Printf("Warning: could not get commit info: %v\n", err)
-	} else {
-		state.BeadCommits = append(state.BeadCommits, BeadCommitInfo{
-			BeadID:     currentBead,
-			CommitHash: commitHash,
-			Summary:    commitMsg,
-			Title:      state.BeadTitles[currentBead],
-		})
-		fmt.Printf("  Commit: %s\n", commitHash)
-	}
-
-	// Dual-write: send DONE message to store
-	storePath := filepath.Join(cfg.ConfigDir(), "messages.db")
-	if store, err := msg.Open(storePath); err == nil {
-		doneBody, _ := json.Marshal(msg.DoneBody{BeadID: currentBead, CommitHash: commitHash, Summary: commitMsg})
-		store.Send(&msg.Message{Subject: msg.SubjectDone, From: state.SessionName, To: "orchestrator", Body: string(doneBody), ThreadID: state.EpicID})
-		store.Close()
-	}
-
-	// Mark bead as complete in state
-	state.CompletedBeads = append(state.CompletedBeads, currentBead)
-	fmt.Printf("  Progress: %d/%d beads completed\n", len(state.CompletedBeads), len(state.Beads))
-
-	// Close the bead
-	fmt.Printf("  Closing bead %s...\n", currentBead)
-	cmd := exec.Command("bd", "close", currentBead, "--reason", summary)
-	cmd.Dir = state.ProjectDir
-	if output, err := cmd.CombinedOutput(); err != nil {
-		fmt.Printf("  Warning: could not close bead: %s\n", string(output))
-	}
-
-	// Sync beads
-	fmt.Println("  Syncing beads...")
-	cmd = exec.Command("bd", "sync")
-	cmd.Dir = state.ProjectDir
-	cmd.Run() // Ignore errors
-
-	// Save state
-	if err := SaveEpicState(cfg, state); err != nil {
-		return fmt.Errorf("saving state: %w", err)
-	}
-
-	return nil
-}
-
-// preBeadHousekeeping handles tasks before starting a new bead
-func preBeadHousekeeping(cfg *config.Config, state *EpicState, beadID string) error {
-	fmt.Printf("Pre-bead housekeeping for %s...\n", beadID)
-
-	// Mark bead as in_progress
-	cmd := exec.Command("bd", "update", beadID, "--status", "in_progress")
-	cmd.Dir = state.ProjectDir
-	if err := cmd.Run(); err != nil {
-		fmt.Printf("  Warning: could not mark bead as in_progress: %v\n", err)
-	}
-
-	// Update current bead in state
-	state.CurrentBead = beadID
-	if err := SaveEpicState(cfg, state); err != nil {
-		return fmt.Errorf("saving state: %w", err)
-	}
-
-	return nil
-}
-
-// transitionToNextBead kills the current Claude session and starts the next bead
-func transitionToNextBead(cfg *config.Config, state *EpicState, beadID string, beadIndex int) error {
-	fmt.Printf("\n=== Starting bead %d/%d: %s ===\n", beadIndex+1, len(state.Beads), beadID)
-	fmt.Printf("Title: %s\n", state.BeadTitles[beadID])
-
-	// Kill current Claude session
-	fmt.Println("Ending current Claude session...")
-	if err := KillClaudeInSession(state.SessionName); err != nil {
-		fmt.Printf("Warning: %v\n", err)
-	}
-
-	// Wait for shell to stabilize
-	time.Sleep(2 * time.Second)
-
-	// Build prompt for next bead
-	prompt := BuildEpicBeadPrompt(beadID, state, beadIndex+1)
-
-	// Send prompt via NudgeSession
-	fmt.Println("Sending prompt to Claude...")
-	if err := nudgeSession(state.SessionName, prompt); err != nil {
-		return fmt.Errorf("sending prompt: %w", err)
-	}
-
-	fmt.Printf("\n✓ Bead %s started. Worker should now process it.\n", beadID)
-	return nil
-}
-
-// finalizeEpic completes the epic after all beads are done
-func finalizeEpic(cfg *config.Config, state *EpicState) error {
-	fmt.Println("\n=== All Beads Complete! ===")
-	fmt.Printf("Completed %d beads in epic %s\n", len(state.CompletedBeads), state.EpicID)
-
-	// Close the epic
-	fmt.Printf("Closing epic %s...\n", state.EpicID)
-	cmd := exec.Command("bd", "close", state.EpicID)
-	cmd.Dir = state.ProjectDir
-	if output, err := cmd.CombinedOutput(); err != nil {
-		fmt.Printf("Warning: could not close epic: %s\n", string(output))
-	} else {
-		fmt.Printf("✓ Epic %s closed\n", state.EpicID)
-	}
-
-	// Sync beads
-	cmd = exec.Command("bd", "sync")
-	cmd.Dir = state.ProjectDir
-	cmd.Run()
-
-	// Update state
-	state.Status = "completed"
-	state.CurrentBead = ""
-	if err := SaveEpicState(cfg, state); err != nil {
-		fmt.Printf("Warning: could not save final state: %v\n", err)
-	}
-
-	// Remove batch mode marker
-	batchMarkerPath := filepath.Join(state.Worktree, ".wt-batch-mode")
-	os.Remove(batchMarkerPath)
-
-	fmt.Println("\n=== Epic Processing Complete ===")
-	fmt.Printf("Session '%s' remains active for final review.\n", state.SessionName)
-	fmt.Println("Run 'wt done' when ready to clean up, or create a PR manually.")
-
-	return nil
-}
-
-// BuildEpicBeadPrompt builds the prompt for a bead in an epic.
-// Exported for use by wt signal.
-func BuildEpicBeadPrompt(beadID string, state *EpicState, beadNum int) string {
-	var sb strings.Builder
-
-	total := len(state.Beads)
-	title := state.BeadTitles[beadID]
-
-	// Header with epic context
-	sb.WriteString(fmt.Sprintf("You are working on bead %d/%d in epic %s: \"%s\"\n\n", beadNum, total, state.EpicID, title))
-
-	// Epic context section
-	sb.WriteString("## Epic Context\n")
-	if state.EpicTitle != "" {
-		sb.WriteString(fmt.Sprintf("Epic: %s\n", state.EpicTitle))
-	} else {
-		sb.WriteString(fmt.Sprintf("Epic ID: %s\n", state.EpicID))
-	}
-	sb.WriteString(fmt.Sprintf("Total beads: %d\n", total))
-	sb.WriteString(fmt.Sprintf("Current: %d/%d\n\n", beadNum, total))
-
-	// Previous work section (if any beads completed)
-	if len(state.BeadCommits) > 0 {
-		sb.WriteString("## Previous Work (already committed in this worktree)\n")
-		for _, commit := range state.BeadCommits {
-			t := commit.Title
-			if t == "" {
-				t = commit.Summary
-			}
-			sb.WriteString(fmt.Sprintf("- %s: %s (commit %s)\n", commit.BeadID, t, commit.CommitHash))
-		}
-		sb.WriteString("\n")
-	}
-
-	// Get bead description
-	description := getBeadDescription(beadID, state.ProjectDir)
-
-	// Your task section
-	sb.WriteString("## Your Task\n")
-	sb.WriteString(fmt.Sprintf("Bead: %s\n", beadID))
-	sb.WriteString(fmt.Sprintf("Title: %s\n\n", title))
-
-	if description != "" {
-		sb.WriteString(description)
-		sb.WriteString("\n\n")
-	}
-
-	// Workflow section with bead-done signal
-	sb.WriteString("## Workflow\n")
-	sb.WriteString("1. Review previous commits if relevant: `git log --oneline -5`\n")
-	sb.WriteString("2. Implement this bead (build on existing work)\n")
-	sb.WriteString("3. Commit your changes with descriptive message\n")
-	sb.WriteString("4. Signal completion: `wt signal bead-done \"<brief summary of what was done>\"`\n\n")
-
-	sb.WriteString("## Important\n")
-	sb.WriteString("Do NOT:\n")
-	sb.WriteString("- Create a PR (wt auto handles this after all beads)\n")
-	sb.WriteString("- Run `wt done` (use `wt signal bead-done` instead)\n")
-	sb.WriteString("- Modify files unrelated to this bead\n\n")
-
-	// Commit message format
-	sb.WriteString("## Commit Message Format\n")
-	sb.WriteString("Include this footer in your commit messages:\n\n")
-	sb.WriteString("```\n")
-	sb.WriteString("<commit message>\n\n")
-	sb.WriteString("Co-Authored-By: Claude Opus 4.5 <noreply@anthropic.com>\n")
-	sb.WriteString(fmt.Sprintf("Session: %s\n", state.SessionName))
-	sb.WriteString("```\n")
-
-	return sb.String()
-}
-
-// getBeadDescription fetches the description for a bead
-func getBeadDescription(beadID, projectDir string) string {
-	cmd := exec.Command("bd", "show", beadID, "--json")
-	cmd.Dir = projectDir
-	output, err := cmd.Output()
-	if err != nil {
-		return ""
-	}
-
-	var infos []struct {
-		Description string `json:"description"`
-	}
-	if err := json.Unmarshal(output, &infos); err != nil || len(infos) == 0 {
-		return ""
-	}
-	return infos[0].Description
-}
-
 // getLatestCommit retrieves the latest commit hash and message from a worktree
 func getLatestCommit(worktreePath string) (hash, message string, err error) {
-	cmd := exec.Command("git", "rev-parse", "--short", "HEAD")
-	cmd.Dir = worktreePath
+	cmd := logging.CommandIn(worktreePath, "git", "rev-parse", "--short", "HEAD")
 	hashOutput, err := cmd.Output()
 	if err != nil {
 		return "", "", fmt.Errorf("getting commit hash: %w", err)
 	}
 	hash = strings.TrimSpace(string(hashOutput))
 
-	cmd = exec.Command("git", "log", "-1", "--format=%s")
+	cmd = logging.Command("git", "log", "-1", "--format=%s")
 	cmd.Dir = worktreePath
 	msgOutput, err := cmd.Output()
 	if err != nil {
@@ -2197,41 +1957,3 @@ func getLatestCommit(worktreePath string) (hash, message string, err error) {
 
 	return hash, message, nil
 }
-
-// nudgeSession sends a message to a tmux session using the reliable paste-buffer pattern
-func nudgeSession(sessionName, message string) error {
-	// Write message to temp file
-	tmpFile, err := os.CreateTemp("", "wt-auto-nudge-*.txt")
-	if err != nil {
-		return fmt.Errorf("creating temp file: %w", err)
-	}
-	tmpPath := tmpFile.Name()
-	defer os.Remove(tmpPath)
-
-	if _, err := tmpFile.WriteString(message); err != nil {
-		tmpFile.Close()
-		return fmt.Errorf("writing temp file: %w", err)
-	}
-	tmpFile.Close()
-
-	// Load into tmux buffer
-	loadCmd := exec.Command("tmux", "load-buffer", tmpPath)
-	if err := loadCmd.Run(); err != nil {
-		return fmt.Errorf("loading buffer: %w", err)
-	}
-
-	// Paste buffer to the target pane
-	pasteCmd := exec.Command("tmux", "paste-buffer", "-t", sessionName)
-	if err := pasteCmd.Run(); err != nil {
-		return fmt.Errorf("pasting buffer to %s: %w", sessionName, err)
-	}
-
-	// Wait then send Enter
-	time.Sleep(500 * time.Millisecond)
-	enterCmd := exec.Command("tmux", "send-keys", "-t", sessionName, "Enter")
-	if err := enterCmd.Run(); err != nil {
-		return fmt.Errorf("sending Enter to %s: %w", sessionName, err)
-	}
-
-	return nil
-}
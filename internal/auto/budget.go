@@ -0,0 +1,95 @@
+package auto
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/badri/wt/internal/monitor"
+	"github.com/badri/wt/internal/project"
+	"github.com/badri/wt/internal/session"
+	"github.com/badri/wt/internal/usage"
+)
+
+// costLimit returns the run's cost budget in USD: --max-cost, else the
+// project's auto.budget, 0 for none
+func (r *Runner) costLimit(proj *project.Project) float64 {
+	if r.opts.MaxCost > 0 {
+		return r.opts.MaxCost
+	}
+	if proj != nil && proj.Auto != nil {
+		return proj.Auto.Budget
+	}
+	return 0
+}
+
+// beadCost estimates what the Claude work in worktreePath since start cost
+func (r *Runner) beadCost(beadID, worktreePath string, start time.Time) float64 {
+	u, err := usage.Since(worktreePath, start)
+	if err != nil {
+		r.logger.Log("Warning: could not read usage for bead %s: %v", beadID, err)
+		return 0
+	}
+	r.logger.Log("Bead %s usage: %d input, %d output, %d cache write, %d cache read tokens, ~%s",
+		beadID, u.InputTokens, u.OutputTokens, u.CacheWriteTokens, u.CacheReadTokens, usage.FormatCost(u.Cost))
+	r.lastCost = u.Cost
+	return u.Cost
+}
+
+// trackEpicCost adds a bead's estimated cost to the epic run's total
+func (r *Runner) trackEpicCost(state *EpicState, beadID string, start time.Time) {
+	if r.opts.DryRun {
+		return
+	}
+	worktree := state.Worktree
+	if state.Isolated {
+		worktree = isolatedBeadWorktreePath(state.Worktree, beadID)
+	}
+	c := r.beadCost(beadID, worktree, start)
+	state.Cost += c
+	fmt.Printf("  Estimated cost: %s (run total %s)\n", usage.FormatCost(c), usage.FormatCost(state.Cost))
+}
+
+// trackSessionCost adds the estimated cost of a project-mode bead, which
+// ran in its own session's worktree, to the run's total
+func (r *Runner) trackSessionCost(beadID, sessionName string, start time.Time) {
+	worktree := r.sessionWorktree(sessionName)
+	if worktree == "" {
+		return
+	}
+	c := r.beadCost(beadID, worktree, start)
+	r.spent += c
+	r.costed++
+	fmt.Printf("Estimated cost: %s (run total %s)\n", usage.FormatCost(c), usage.FormatCost(r.spent))
+}
+
+// sessionWorktree returns the worktree of a wt session, "" if unknown
+func (r *Runner) sessionWorktree(sessionName string) string {
+	state, err := session.LoadState(r.cfg)
+	if err != nil {
+		return ""
+	}
+	if sess, ok := state.Sessions[sessionName]; ok {
+		return sess.Worktree
+	}
+	return ""
+}
+
+// checkBudget returns an error once spent reached limit, and sends a
+// desktop notification so an unattended run doesn't stop silently
+func (r *Runner) checkBudget(spent, limit float64, what string) error {
+	if limit <= 0 || spent < limit {
+		return nil
+	}
+	err := fmt.Errorf("cost budget of %s reached (~%s spent)", usage.FormatCost(limit), usage.FormatCost(spent))
+	r.logger.Log("Budget: %v, pausing %s", err, what)
+	monitor.Notify("wt auto: Budget Reached", fmt.Sprintf("Paused %s at ~%s of %s", what, usage.FormatCost(spent), usage.FormatCost(limit)))
+	return err
+}
+
+// paceEpic is pace plus the epic run's cost budget
+func (r *Runner) paceEpic(state *EpicState, proj *project.Project) error {
+	if err := r.checkBudget(state.Cost, state.MaxCost, "epic "+state.EpicID); err != nil {
+		return fmt.Errorf("%w; raise it with 'wt auto --resume --max-cost <usd>'", err)
+	}
+	return r.pace(proj)
+}
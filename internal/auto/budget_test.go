@@ -0,0 +1,33 @@
+package auto
+
+import (
+	"testing"
+
+	"github.com/badri/wt/internal/project"
+)
+
+func TestCostLimit(t *testing.T) {
+	proj := &project.Project{Name: "myapp", Auto: &project.Auto{Budget: 25}}
+
+	r := &Runner{opts: &Options{}}
+	if got := r.costLimit(proj); got != 25 {
+		t.Errorf("costLimit() = %v, want project budget 25", got)
+	}
+	if got := r.costLimit(&project.Project{Name: "plain"}); got != 0 {
+		t.Errorf("costLimit() without budget = %v, want 0", got)
+	}
+
+	// --max-cost overrides the project budget
+	r.opts.MaxCost = 10
+	if got := r.costLimit(proj); got != 10 {
+		t.Errorf("costLimit() = %v, want --max-cost 10", got)
+	}
+
+	// Under the limit, or without one, the run goes on
+	if err := r.checkBudget(9.99, 10, "epic wt-x"); err != nil {
+		t.Errorf("checkBudget() under limit: %v", err)
+	}
+	if err := r.checkBudget(100, 0, "epic wt-x"); err != nil {
+		t.Errorf("checkBudget() without limit: %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+package auto
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/badri/wt/internal/monitor"
+)
+
+// claudeSessionSince returns the ID of the Claude session that wrote to the
+// worktree's transcripts last, provided it did so after start; "" otherwise.
+// Claude Code names each transcript <session-id>.jsonl.
+func claudeSessionSince(worktreePath string, start time.Time) string {
+	path, modTime := monitor.LatestTranscript(worktreePath)
+	if path == "" || modTime.Before(start) {
+		return ""
+	}
+	return strings.TrimSuffix(filepath.Base(path), ".jsonl")
+}
+
+// recordClaudeSession remembers which Claude session worked on a bead, so
+// a later bead can resume it with --resume-context. Isolated bead worktrees
+// are gone by now, and Claude only resumes sessions of the same directory.
+func (r *Runner) recordClaudeSession(state *EpicState, beadID string, start time.Time) {
+	if state.Isolated || r.opts.DryRun {
+		return
+	}
+	id := claudeSessionSince(state.currentWorktree(), start)
+	if id == "" {
+		r.logger.Log("Warning: no Claude transcript found for bead %s", beadID)
+		return
+	}
+	if state.ClaudeSessions == nil {
+		state.ClaudeSessions = make(map[string]string)
+	}
+	state.ClaudeSessions[beadID] = id
+	r.logger.Log("Bead %s ran in Claude session %s", beadID, id)
+}
+
+// contextSession returns the Claude session of the most recently completed
+// bead, which the next bead resumes when the run keeps Claude context
+func (s *EpicState) contextSession() string {
+	if !s.ResumeContext || s.Isolated {
+		return ""
+	}
+	for _, beadID := range slices.Backward(s.CompletedBeads) {
+		if id := s.ClaudeSessions[beadID]; id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// epicBeadCommand returns the command and prompt for the next bead. With
+// --resume-context the previous bead's Claude session is resumed, keeping
+// what it learned about the codebase, and the bead prompt is appended to it.
+func (r *Runner) epicBeadCommand(state *EpicState, command, prompt string) (string, string) {
+	id := state.contextSession()
+	if id == "" {
+		return command, prompt
+	}
+	fmt.Printf("  Resuming Claude session %s from the previous bead\n", id)
+	r.logger.Log("Resuming Claude session %s", id)
+
+	var sb strings.Builder
+	sb.WriteString("The previous bead is finished and committed. Keep the context of this ")
+	sb.WriteString("conversation: the architecture, conventions and decisions you worked out ")
+	sb.WriteString("still apply. Your next task follows.\n\n")
+	sb.WriteString(prompt)
+	return fmt.Sprintf("%s --resume %s", command, id), sb.String()
+}
@@ -0,0 +1,82 @@
+package auto
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/badri/wt/internal/monitor"
+)
+
+func TestClaudeSessionSince(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	worktree := "/tmp/worktrees/api/wt-abc"
+
+	if id := claudeSessionSince(worktree, time.Now()); id != "" {
+		t.Errorf("claudeSessionSince() without transcripts = %q", id)
+	}
+
+	dir := monitor.ClaudeProjectDir(worktree)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	old := filepath.Join(dir, "old-session.jsonl")
+	latest := filepath.Join(dir, "new-session.jsonl")
+	for _, path := range []string{old, latest} {
+		if err := os.WriteFile(path, []byte("{}\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	start := time.Now()
+	os.Chtimes(old, start.Add(-time.Hour), start.Add(-time.Hour))
+	os.Chtimes(latest, start.Add(time.Second), start.Add(time.Second))
+
+	if id := claudeSessionSince(worktree, start); id != "new-session" {
+		t.Errorf("claudeSessionSince() = %q, want new-session", id)
+	}
+	if id := claudeSessionSince(worktree, start.Add(time.Minute)); id != "" {
+		t.Errorf("claudeSessionSince() for a transcript older than the bead = %q", id)
+	}
+}
+
+func TestEpicBeadCommand(t *testing.T) {
+	logger, err := NewLogger(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+	r := &Runner{opts: &Options{}, logger: logger}
+
+	state := &EpicState{
+		CompletedBeads: []string{"wt-1", "wt-2"},
+		ClaudeSessions: map[string]string{"wt-1": "sess-1", "wt-3": "sess-3"},
+	}
+
+	// Without --resume-context every bead starts a fresh Claude
+	if command, prompt := r.epicBeadCommand(state, "claude", "Do wt-3"); command != "claude" || prompt != "Do wt-3" {
+		t.Errorf("epicBeadCommand() = %q, %q; want the command unchanged", command, prompt)
+	}
+
+	// The most recent completed bead with a recorded session is resumed;
+	// wt-3 failed, so its session is not used
+	state.ResumeContext = true
+	command, prompt := r.epicBeadCommand(state, "claude", "Do wt-3")
+	if command != "claude --resume sess-1" {
+		t.Errorf("command = %q, want claude --resume sess-1", command)
+	}
+	if !strings.HasSuffix(prompt, "\n\nDo wt-3") || !strings.Contains(prompt, "previous bead is finished") {
+		t.Errorf("prompt = %q", prompt)
+	}
+
+	state.ClaudeSessions["wt-2"] = "sess-2"
+	if command, _ := r.epicBeadCommand(state, "claude", "Do wt-3"); command != "claude --resume sess-2" {
+		t.Errorf("command = %q, want claude --resume sess-2", command)
+	}
+
+	state.Isolated = true
+	if command, _ := r.epicBeadCommand(state, "claude", "Do wt-3"); command != "claude" {
+		t.Errorf("isolated command = %q, want a fresh Claude", command)
+	}
+}
@@ -0,0 +1,119 @@
+package auto
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/merge"
+	"github.com/badri/wt/internal/project"
+)
+
+// driftPolicy holds the effective settings for keeping an epic branch
+// close to the default branch
+type driftPolicy struct {
+	maxDrift    int    // commits behind the default branch before syncing, 0 = never
+	strategy    string // "rebase" or "merge"
+	testCommand string // run after syncing, empty = none
+}
+
+// driftFor resolves the drift policy from project config, with --max-drift
+// and --drift-strategy taking precedence.
+func (r *Runner) driftFor(proj *project.Project) (*driftPolicy, error) {
+	p := &driftPolicy{strategy: "rebase"}
+	if proj != nil && proj.Auto != nil {
+		p.maxDrift = proj.Auto.MaxDrift
+		if proj.Auto.DriftStrategy != "" {
+			p.strategy = proj.Auto.DriftStrategy
+		}
+		p.testCommand = proj.Auto.TestCommand
+	}
+	if r.opts.MaxDrift > 0 {
+		p.maxDrift = r.opts.MaxDrift
+	}
+	if r.opts.DriftStrategy != "" {
+		p.strategy = r.opts.DriftStrategy
+	}
+	if p.strategy != "rebase" && p.strategy != "merge" {
+		return nil, fmt.Errorf("invalid drift strategy %q: use rebase or merge", p.strategy)
+	}
+	return p, nil
+}
+
+// syncEpicBranch rebases the epic branch onto the default branch (or merges
+// the default branch into it) when it has fallen more than maxDrift commits
+// behind, then runs the project's test command. It is called between beads;
+// an error means the run should pause. Conflicts are aborted, leaving the
+// branch as it was; failing tests leave the synced branch for inspection.
+func (r *Runner) syncEpicBranch(state *EpicState, proj *project.Project) error {
+	policy, err := r.driftFor(proj)
+	if err != nil {
+		return err
+	}
+	if policy.maxDrift <= 0 {
+		return nil
+	}
+
+	defaultBranch := "main"
+	if proj != nil && proj.DefaultBranch != "" {
+		defaultBranch = proj.DefaultBranch
+	}
+
+	if err := merge.FetchMain(state.Worktree, defaultBranch); err != nil {
+		r.logger.Log("Warning: could not check drift from %s: %v", defaultBranch, err)
+		return nil
+	}
+	behind, err := merge.CommitsBehind(state.Worktree, defaultBranch)
+	if err != nil {
+		r.logger.Log("Warning: could not check drift from %s: %v", defaultBranch, err)
+		return nil
+	}
+	if behind <= policy.maxDrift {
+		return nil
+	}
+
+	if dirty, _ := merge.HasUncommittedChanges(state.Worktree); dirty {
+		return fmt.Errorf("epic branch is %d commits behind %s but has uncommitted changes", behind, defaultBranch)
+	}
+
+	before, _, _ := getLatestCommit(state.Worktree)
+	action := "rebasing onto"
+	if policy.strategy == "merge" {
+		action = "merging"
+	}
+	logging.Infof("Epic branch is %d commits behind %s, %s it...", behind, defaultBranch, action)
+	r.logger.Log("Drift: %d commits behind %s, %s (head %s)", behind, defaultBranch, policy.strategy, before)
+
+	var result *merge.RebaseResult
+	if policy.strategy == "merge" {
+		result, err = merge.MergeMain(state.Worktree, defaultBranch)
+	} else {
+		result, err = merge.RebaseOnMain(state.Worktree, defaultBranch)
+	}
+	if err != nil {
+		return err
+	}
+	if result.HasConflicts {
+		if policy.strategy == "merge" {
+			merge.AbortMerge(state.Worktree)
+		} else {
+			merge.AbortRebase(state.Worktree)
+		}
+		return fmt.Errorf("%s onto %s conflicts in %s", policy.strategy, defaultBranch, strings.Join(result.ConflictedFiles, ", "))
+	}
+	fmt.Printf("✓ Epic branch synced with %s\n", defaultBranch)
+
+	if policy.testCommand == "" {
+		return nil
+	}
+	fmt.Printf("Running tests: %s\n", policy.testCommand)
+	cmd := logging.CommandIn(state.Worktree, "sh", "-c", policy.testCommand)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		r.logger.Log("Tests failed after syncing with %s: %v\n%s", defaultBranch, err, output)
+		return fmt.Errorf("tests failed after syncing with %s (previous head %s): %w", defaultBranch, before, err)
+	}
+	r.logger.Log("Tests passed after syncing with %s", defaultBranch)
+	fmt.Println("✓ Tests passed")
+	return nil
+}
@@ -0,0 +1,141 @@
+package auto
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/badri/wt/internal/merge"
+	"github.com/badri/wt/internal/project"
+)
+
+func TestDriftFor(t *testing.T) {
+	proj := &project.Project{Auto: &project.Auto{MaxDrift: 20, DriftStrategy: "merge", TestCommand: "make test"}}
+
+	r := &Runner{opts: &Options{}}
+	p, err := r.driftFor(proj)
+	if err != nil {
+		t.Fatalf("driftFor() unexpected error: %v", err)
+	}
+	if p.maxDrift != 20 || p.strategy != "merge" || p.testCommand != "make test" {
+		t.Errorf("driftFor() = %+v, want project settings", p)
+	}
+
+	r.opts.MaxDrift, r.opts.DriftStrategy = 5, "rebase"
+	if p, _ := r.driftFor(proj); p.maxDrift != 5 || p.strategy != "rebase" {
+		t.Errorf("driftFor() = %+v, want flags to override", p)
+	}
+
+	r.opts = &Options{}
+	if p, _ := r.driftFor(&project.Project{}); p.maxDrift != 0 || p.strategy != "rebase" {
+		t.Errorf("driftFor() without config = %+v, want no syncing", p)
+	}
+	if _, err := r.driftFor(&project.Project{Auto: &project.Auto{DriftStrategy: "squash"}}); err == nil {
+		t.Error("driftFor() expected error for invalid strategy")
+	}
+}
+
+// git runs a git command in dir, failing the test on error
+func git(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func commitFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	git(t, dir, "add", name)
+	git(t, dir, "commit", "-q", "-m", "change "+name)
+}
+
+// driftRepos returns an epic worktree and a second clone that pushes to main
+func driftRepos(t *testing.T) (epic, upstream string) {
+	root := t.TempDir()
+	origin := filepath.Join(root, "origin.git")
+	git(t, root, "init", "-q", "--bare", "-b", "main", origin)
+
+	upstream = filepath.Join(root, "upstream")
+	git(t, root, "clone", "-q", origin, upstream)
+	git(t, upstream, "config", "user.email", "test@test.com")
+	git(t, upstream, "config", "user.name", "Test")
+	git(t, upstream, "checkout", "-q", "-b", "main")
+	commitFile(t, upstream, "README", "hello\n")
+	git(t, upstream, "push", "-q", "origin", "main")
+
+	epic = filepath.Join(root, "epic")
+	git(t, root, "clone", "-q", origin, epic)
+	git(t, epic, "config", "user.email", "test@test.com")
+	git(t, epic, "config", "user.name", "Test")
+	git(t, epic, "checkout", "-q", "-b", "epic")
+	commitFile(t, epic, "epic.txt", "bead 1\n")
+	return epic, upstream
+}
+
+func TestSyncEpicBranch(t *testing.T) {
+	epic, upstream := driftRepos(t)
+	for _, name := range []string{"a", "b", "c"} {
+		commitFile(t, upstream, name, name+"\n")
+	}
+	git(t, upstream, "push", "-q", "origin", "main")
+
+	logger, err := NewLogger(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+	r := &Runner{opts: &Options{}, logger: logger}
+	state := &EpicState{Worktree: epic}
+	proj := &project.Project{Auto: &project.Auto{MaxDrift: 3, TestCommand: "test -f a"}}
+
+	// Within the limit nothing happens
+	if err := r.syncEpicBranch(state, proj); err != nil {
+		t.Fatalf("syncEpicBranch() unexpected error: %v", err)
+	}
+	if behind, _ := merge.CommitsBehind(epic, "main"); behind != 3 {
+		t.Errorf("CommitsBehind() = %d, want the branch untouched", behind)
+	}
+
+	proj.Auto.MaxDrift = 2
+	if err := r.syncEpicBranch(state, proj); err != nil {
+		t.Fatalf("syncEpicBranch() unexpected error: %v", err)
+	}
+	if behind, _ := merge.CommitsBehind(epic, "main"); behind != 0 {
+		t.Errorf("CommitsBehind() after sync = %d, want 0", behind)
+	}
+	if got := git(t, epic, "log", "-1", "--format=%s"); got != "change epic.txt" {
+		t.Errorf("epic commit should be replayed on top of main, head is %q", got)
+	}
+}
+
+func TestSyncEpicBranchConflict(t *testing.T) {
+	epic, upstream := driftRepos(t)
+	commitFile(t, upstream, "other.txt", "unrelated\n")
+	commitFile(t, upstream, "epic.txt", "main's version\n")
+	git(t, upstream, "push", "-q", "origin", "main")
+	before := git(t, epic, "rev-parse", "HEAD")
+
+	logger, err := NewLogger(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+	r := &Runner{opts: &Options{MaxDrift: 1, DriftStrategy: "merge"}, logger: logger}
+	err = r.syncEpicBranch(&EpicState{Worktree: epic}, &project.Project{})
+	if err == nil || !strings.Contains(err.Error(), "epic.txt") {
+		t.Fatalf("syncEpicBranch() = %v, want a conflict in epic.txt", err)
+	}
+	if head := git(t, epic, "rev-parse", "HEAD"); head != before {
+		t.Error("a conflicting sync should leave the branch as it was")
+	}
+	if dirty, _ := merge.HasUncommittedChanges(epic); dirty {
+		t.Error("a conflicting sync should be aborted")
+	}
+}
@@ -0,0 +1,113 @@
+package auto
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/badri/wt/internal/bead"
+	"github.com/badri/wt/internal/config"
+)
+
+// fileRefPattern matches file paths mentioned in bead descriptions
+var fileRefPattern = regexp.MustCompile("(?:^|[\\s(\\[`'\"])((?:\\./)?[a-zA-Z0-9_][a-zA-Z0-9_/.-]*\\.(?:go|py|ts|tsx|js|jsx|rb|java|rs|c|cpp|h|hpp|swift|kt|sql|proto))\\b")
+
+// AuditEpic checks whether an epic is ready for 'wt auto --epic' without
+// starting a run.
+func AuditEpic(cfg *config.Config, epicID string) (*EpicAuditResult, error) {
+	return NewRunner(cfg, &Options{Epic: epicID}).auditEpic(epicID)
+}
+
+// auditEpic checks if an epic is ready for batch processing
+func (r *Runner) auditEpic(epicID string) (*EpicAuditResult, error) {
+	result := &EpicAuditResult{
+		EpicID:     epicID,
+		Ready:      true,
+		BeadTitles: make(map[string]string),
+	}
+
+	// Get beads blocking this epic
+	beads, projectDir, err := r.getEpicBeads(epicID)
+	if err != nil {
+		return nil, err
+	}
+	result.ProjectDir = projectDir
+
+	if len(beads) == 0 {
+		result.Ready = false
+		result.Issues = append(result.Issues, "No beads found blocking this epic")
+		return result, nil
+	}
+
+	for _, b := range beads {
+		result.Beads = append(result.Beads, b.ID)
+		result.BeadTitles[b.ID] = b.Title
+	}
+
+	// Check: beads all from same project (already ensured by getEpicBeads)
+
+	// Check: no external blockers (beads should be ready)
+	for _, b := range beads {
+		blockers, err := r.getBeadBlockers(b.ID, projectDir)
+		if err != nil {
+			continue
+		}
+		for _, blocker := range blockers {
+			// Check if blocker is in our bead list (internal) or external
+			// Also skip if blocker is the epic itself (parent-child relationship)
+			isInternal := blocker == epicID
+			for _, ob := range beads {
+				if ob.ID == blocker {
+					isInternal = true
+					break
+				}
+			}
+			if !isInternal {
+				result.Ready = false
+				result.ExternalBlockers = append(result.ExternalBlockers, fmt.Sprintf("%s blocked by %s", b.ID, blocker))
+				result.Issues = append(result.Issues, fmt.Sprintf("Bead %s has external blocker: %s", b.ID, blocker))
+			}
+		}
+	}
+
+	// Check: beads have descriptions (basic readiness)
+	for _, b := range beads {
+		if b.Description == "" {
+			result.Ready = false
+			result.Issues = append(result.Issues, fmt.Sprintf("Bead %s has no description", b.ID))
+		}
+	}
+
+	// Beads touching the same files may conflict, especially in isolated
+	// mode. This is a warning, not a readiness failure.
+	result.FileConflicts = predictFileConflicts(beads)
+
+	return result, nil
+}
+
+// predictFileConflicts reports files mentioned in the descriptions of more
+// than one bead, as "path (bead, bead)", sorted by path.
+func predictFileConflicts(beads []bead.ReadyBead) []string {
+	touchedBy := make(map[string][]string)
+	for _, b := range beads {
+		seen := make(map[string]bool)
+		for _, match := range fileRefPattern.FindAllStringSubmatch(b.Description, -1) {
+			path := strings.TrimPrefix(match[1], "./")
+			if seen[path] {
+				continue
+			}
+			seen[path] = true
+			touchedBy[path] = append(touchedBy[path], b.ID)
+		}
+	}
+
+	var conflicts []string
+	for path, ids := range touchedBy {
+		if len(ids) > 1 {
+			conflicts = append(conflicts, fmt.Sprintf("%s (%s)", path, strings.Join(ids, ", ")))
+		}
+	}
+	sort.Strings(conflicts)
+	return conflicts
+}
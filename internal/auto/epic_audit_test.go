@@ -0,0 +1,29 @@
+package auto
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/badri/wt/internal/bead"
+)
+
+func TestPredictFileConflicts(t *testing.T) {
+	beads := []bead.ReadyBead{
+		{ID: "wt-a", Description: "Update internal/auth/login.go and `cmd/wt/main.go` for SSO."},
+		{ID: "wt-b", Description: "Refactor ./internal/auth/login.go; see internal/auth/login.go again."},
+		{ID: "wt-c", Description: "Touch cmd/wt/main.go (help text) and docs only."},
+		{ID: "wt-d", Description: "No files mentioned, see https://example.com/page.html"},
+	}
+
+	want := []string{
+		"cmd/wt/main.go (wt-a, wt-c)",
+		"internal/auth/login.go (wt-a, wt-b)",
+	}
+	if got := predictFileConflicts(beads); !reflect.DeepEqual(got, want) {
+		t.Errorf("predictFileConflicts() = %v, want %v", got, want)
+	}
+
+	if got := predictFileConflicts(beads[:1]); len(got) != 0 {
+		t.Errorf("predictFileConflicts(single bead) = %v, want none", got)
+	}
+}
@@ -0,0 +1,315 @@
+package auto
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/logging"
+)
+
+// Epic state edits made with 'wt auto state'. A running auto keeps the epic
+// state in memory and rewrites the state file after every bead, so edits to
+// a running epic are queued in a side file and applied by the runner before
+// it picks the next bead. Edits to a paused or failed epic are applied to
+// the state file directly.
+
+// Epic edit operations
+const (
+	EditSkip    = "skip"
+	EditRequeue = "requeue"
+)
+
+// EpicEdit is a change to an epic's bead queue
+type EpicEdit struct {
+	Op     string `json:"op"`
+	Bead   string `json:"bead"`
+	Front  bool   `json:"front,omitempty"`  // requeue: run next instead of last
+	Reason string `json:"reason,omitempty"` // skip: why the bead was skipped
+}
+
+// Bead states reported by EpicState.BeadStatus
+const (
+	BeadDone    = "done"
+	BeadCurrent = "current"
+	BeadFailed  = "failed"
+	BeadSkipped = "skipped"
+	BeadPending = "pending"
+)
+
+// NextBead returns the first bead in queue order that is not completed,
+// failed or skipped, or "" when none is left.
+func (s *EpicState) NextBead() string {
+	for _, id := range s.Beads {
+		if status, _ := s.BeadStatus(id); status == BeadPending || status == BeadCurrent {
+			return id
+		}
+	}
+	return ""
+}
+
+// BeadStatus returns the state of a bead in the epic and, for failed and
+// skipped beads, the reason.
+func (s *EpicState) BeadStatus(id string) (status, reason string) {
+	switch {
+	case slices.Contains(s.CompletedBeads, id):
+		return BeadDone, ""
+	case s.SkippedBeads[id] != "":
+		return BeadSkipped, s.SkippedBeads[id]
+	case s.FailedBeads[id] != "":
+		return BeadFailed, s.FailedBeads[id]
+	case s.FailedBead == id:
+		return BeadFailed, s.FailureReason
+	case s.CurrentBead == id:
+		return BeadCurrent, ""
+	}
+	return BeadPending, ""
+}
+
+// Apply applies an edit to the bead queue. A bead that is being worked on
+// in a running epic can't be skipped or requeued.
+func (s *EpicState) Apply(e EpicEdit) error {
+	if !slices.Contains(s.Beads, e.Bead) {
+		return fmt.Errorf("bead %s is not part of epic %s", e.Bead, s.EpicID)
+	}
+	status, _ := s.BeadStatus(e.Bead)
+	if status == BeadDone {
+		return fmt.Errorf("bead %s is already completed", e.Bead)
+	}
+	if status == BeadCurrent && s.Status == "running" {
+		return fmt.Errorf("bead %s is in progress; stop the run with 'wt auto --stop' first", e.Bead)
+	}
+
+	switch e.Op {
+	case EditSkip:
+		if status == BeadSkipped {
+			return fmt.Errorf("bead %s is already skipped", e.Bead)
+		}
+		reason := e.Reason
+		if reason == "" {
+			reason = "skipped by user"
+		}
+		if s.SkippedBeads == nil {
+			s.SkippedBeads = make(map[string]string)
+		}
+		s.SkippedBeads[e.Bead] = reason
+		s.clearFailure(e.Bead)
+	case EditRequeue:
+		delete(s.SkippedBeads, e.Bead)
+		s.clearFailure(e.Bead)
+		s.Beads = slices.DeleteFunc(s.Beads, func(id string) bool { return id == e.Bead })
+		pos := len(s.Beads)
+		if e.Front {
+			if next := s.NextBead(); next != "" {
+				pos = slices.Index(s.Beads, next)
+			}
+		}
+		s.Beads = slices.Insert(s.Beads, pos, e.Bead)
+	default:
+		return fmt.Errorf("unknown epic edit: %s", e.Op)
+	}
+	return nil
+}
+
+func (s *EpicState) clearFailure(id string) {
+	delete(s.FailedBeads, id)
+	if s.FailedBead == id {
+		s.FailedBead = ""
+		s.FailureReason = ""
+	}
+}
+
+// ProjectEpicStateFile returns the epic state file of a project's auto run
+// (the legacy global file when project is empty)
+func ProjectEpicStateFile(cfg *config.Config, project string) string {
+	if project == "" {
+		return EpicStateFile(cfg)
+	}
+	return filepath.Join(cfg.ConfigDir(), fmt.Sprintf("auto-epic-state-%s.json", project))
+}
+
+func epicEditsFile(cfg *config.Config, project string) string {
+	if project == "" {
+		return filepath.Join(cfg.ConfigDir(), "auto-epic-edits.json")
+	}
+	return filepath.Join(cfg.ConfigDir(), fmt.Sprintf("auto-epic-edits-%s.json", project))
+}
+
+// EpicStateProjects returns the projects that have an epic state file.
+// The legacy global state file is reported as "".
+func EpicStateProjects(cfg *config.Config) ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(cfg.ConfigDir(), "auto-epic-state-*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var projects []string
+	if _, err := os.Stat(EpicStateFile(cfg)); err == nil {
+		projects = append(projects, "")
+	}
+	for _, m := range matches {
+		name := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(m), "auto-epic-state-"), ".json")
+		projects = append(projects, name)
+	}
+	return projects, nil
+}
+
+// LoadProjectEpicState loads a project's epic state
+func LoadProjectEpicState(cfg *config.Config, project string) (*EpicState, error) {
+	data, err := os.ReadFile(ProjectEpicStateFile(cfg, project))
+	if err != nil {
+		return nil, err
+	}
+	var state EpicState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// SaveProjectEpicState saves a project's epic state
+func SaveProjectEpicState(cfg *config.Config, project string, state *EpicState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(ProjectEpicStateFile(cfg, project), data, 0644)
+}
+
+// RunnerActive reports whether a wt auto process holds the project's lock
+func RunnerActive(cfg *config.Config, project string) bool {
+	lockFile := filepath.Join(cfg.ConfigDir(), "auto.lock")
+	if project != "" {
+		lockFile = filepath.Join(cfg.ConfigDir(), fmt.Sprintf("auto-%s.lock", project))
+	}
+	data, err := os.ReadFile(lockFile)
+	if err != nil {
+		return false
+	}
+	var lock LockInfo
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return false
+	}
+	return processRunning(lock.PID)
+}
+
+// processRunning checks if a process is running
+func processRunning(pid int) bool {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	// On Unix, FindProcess always succeeds, so we need to send signal 0
+	return process.Signal(syscall.Signal(0)) == nil
+}
+
+// QueuedEpicEdits returns edits waiting for the running auto to apply them
+func QueuedEpicEdits(cfg *config.Config, project string) ([]EpicEdit, error) {
+	return readEpicEdits(epicEditsFile(cfg, project))
+}
+
+// EditEpicState applies an edit to a project's epic. If an auto run is
+// active the edit is checked against the state and queued for the runner;
+// queued reports whether that happened.
+func EditEpicState(cfg *config.Config, project string, edit EpicEdit) (queued bool, err error) {
+	state, err := LoadProjectEpicState(cfg, project)
+	if err != nil {
+		return false, fmt.Errorf("loading epic state: %w", err)
+	}
+
+	if !RunnerActive(cfg, project) {
+		if err := state.Apply(edit); err != nil {
+			return false, err
+		}
+		return false, SaveProjectEpicState(cfg, project, state)
+	}
+
+	// Validate against the state as it will be once earlier edits are applied
+	pending, err := QueuedEpicEdits(cfg, project)
+	if err != nil {
+		return false, err
+	}
+	for _, e := range pending {
+		_ = state.Apply(e)
+	}
+	if err := state.Apply(edit); err != nil {
+		return false, err
+	}
+
+	f, err := os.OpenFile(epicEditsFile(cfg, project), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return false, fmt.Errorf("opening edits file: %w", err)
+	}
+	defer f.Close()
+	data, err := json.Marshal(edit)
+	if err != nil {
+		return false, err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return false, fmt.Errorf("queuing edit: %w", err)
+	}
+	return true, nil
+}
+
+// takeEpicEdits removes and returns the queued edits. The file is renamed
+// before reading so edits queued meanwhile land in a new file.
+func takeEpicEdits(path string) ([]EpicEdit, error) {
+	taken := path + ".applying"
+	if err := os.Rename(path, taken); err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer os.Remove(taken)
+	return readEpicEdits(taken)
+}
+
+func readEpicEdits(path string) ([]EpicEdit, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var edits []EpicEdit
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e EpicEdit
+		if err := json.Unmarshal(scanner.Bytes(), &e); err == nil && e.Bead != "" {
+			edits = append(edits, e)
+		}
+	}
+	return edits, scanner.Err()
+}
+
+// applyQueuedEdits applies edits queued with 'wt auto state' to the running
+// epic. Called before each bead is picked.
+func (r *Runner) applyQueuedEdits(state *EpicState) {
+	edits, err := takeEpicEdits(epicEditsFile(r.cfg, r.opts.Project))
+	if err != nil {
+		r.logger.Log("Warning: could not read queued epic edits: %v", err)
+		return
+	}
+	if len(edits) == 0 {
+		return
+	}
+	for _, e := range edits {
+		if err := state.Apply(e); err != nil {
+			logging.Warnf("ignoring %s of %s: %v", e.Op, e.Bead, err)
+			r.logger.Log("Ignoring queued %s of %s: %v", e.Op, e.Bead, err)
+			continue
+		}
+		fmt.Printf("Applied queued edit: %s %s\n", e.Op, e.Bead)
+		r.logger.Log("Applied queued edit: %s %s", e.Op, e.Bead)
+	}
+	r.saveEpicState(state)
+}
@@ -0,0 +1,170 @@
+package auto
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/badri/wt/internal/config"
+)
+
+func newEditTestState() *EpicState {
+	return &EpicState{
+		EpicID:         "wt-epic",
+		Beads:          []string{"wt-a", "wt-b", "wt-c", "wt-d"},
+		CompletedBeads: []string{"wt-a"},
+		FailedBeads:    map[string]string{"wt-b": "timeout"},
+		CurrentBead:    "wt-c",
+		Status:         "running",
+	}
+}
+
+func TestEpicStateNextBead(t *testing.T) {
+	state := newEditTestState()
+	if got := state.NextBead(); got != "wt-c" {
+		t.Errorf("NextBead() = %q, want wt-c", got)
+	}
+
+	state.CompletedBeads = append(state.CompletedBeads, "wt-c")
+	state.SkippedBeads = map[string]string{"wt-d": "hopeless"}
+	if got := state.NextBead(); got != "" {
+		t.Errorf("NextBead() = %q, want none left", got)
+	}
+
+	if status, reason := state.BeadStatus("wt-d"); status != BeadSkipped || reason != "hopeless" {
+		t.Errorf("BeadStatus(wt-d) = %q, %q", status, reason)
+	}
+	if status, reason := state.BeadStatus("wt-b"); status != BeadFailed || reason != "timeout" {
+		t.Errorf("BeadStatus(wt-b) = %q, %q", status, reason)
+	}
+}
+
+func TestEpicStateApply(t *testing.T) {
+	state := newEditTestState()
+
+	if err := state.Apply(EpicEdit{Op: EditSkip, Bead: "wt-d", Reason: "needs design"}); err != nil {
+		t.Fatalf("skip wt-d: %v", err)
+	}
+	if state.SkippedBeads["wt-d"] != "needs design" {
+		t.Errorf("SkippedBeads = %v", state.SkippedBeads)
+	}
+
+	// Requeueing a failed bead clears the failure and moves it last
+	if err := state.Apply(EpicEdit{Op: EditRequeue, Bead: "wt-b"}); err != nil {
+		t.Fatalf("requeue wt-b: %v", err)
+	}
+	if _, failed := state.FailedBeads["wt-b"]; failed {
+		t.Error("requeue should clear the failure")
+	}
+	if want := []string{"wt-a", "wt-c", "wt-d", "wt-b"}; !slices.Equal(state.Beads, want) {
+		t.Errorf("Beads = %v, want %v", state.Beads, want)
+	}
+
+	// --next puts a skipped bead before the next pending one
+	state.CompletedBeads = append(state.CompletedBeads, "wt-c")
+	if err := state.Apply(EpicEdit{Op: EditRequeue, Bead: "wt-d", Front: true}); err != nil {
+		t.Fatalf("requeue wt-d --next: %v", err)
+	}
+	if state.NextBead() != "wt-d" || state.SkippedBeads["wt-d"] != "" {
+		t.Errorf("NextBead() = %q, Beads = %v, Skipped = %v", state.NextBead(), state.Beads, state.SkippedBeads)
+	}
+}
+
+func TestEpicStateApplyErrors(t *testing.T) {
+	state := newEditTestState()
+	tests := []struct {
+		name string
+		edit EpicEdit
+	}{
+		{"unknown bead", EpicEdit{Op: EditSkip, Bead: "wt-z"}},
+		{"completed bead", EpicEdit{Op: EditRequeue, Bead: "wt-a"}},
+		{"bead in progress", EpicEdit{Op: EditSkip, Bead: "wt-c"}},
+		{"unknown op", EpicEdit{Op: "delete", Bead: "wt-d"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := state.Apply(tt.edit); err == nil {
+				t.Errorf("Apply(%+v) should fail", tt.edit)
+			}
+		})
+	}
+
+	// The current bead of a paused epic can be skipped
+	state.Status = "paused"
+	if err := state.Apply(EpicEdit{Op: EditSkip, Bead: "wt-c"}); err != nil {
+		t.Errorf("skip current bead of paused epic: %v", err)
+	}
+	if err := state.Apply(EpicEdit{Op: EditSkip, Bead: "wt-c"}); err == nil {
+		t.Error("skipping twice should fail")
+	}
+}
+
+func newEditTestConfig(t *testing.T) *config.Config {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "config.json"), []byte(`{"worktree_root": "`+dir+`"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := config.LoadFromDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cfg
+}
+
+func TestEditEpicState(t *testing.T) {
+	cfg := newEditTestConfig(t)
+	state := newEditTestState()
+	state.Status = "paused"
+	if err := SaveProjectEpicState(cfg, "proj", state); err != nil {
+		t.Fatal(err)
+	}
+
+	// No runner: applied to the state file
+	queued, err := EditEpicState(cfg, "proj", EpicEdit{Op: EditSkip, Bead: "wt-d"})
+	if err != nil || queued {
+		t.Fatalf("EditEpicState() = %v, %v; want applied", queued, err)
+	}
+	saved, _ := LoadProjectEpicState(cfg, "proj")
+	if saved.SkippedBeads["wt-d"] == "" {
+		t.Error("skip not saved to state file")
+	}
+
+	// Runner holding the lock: queued for it
+	lock, _ := json.Marshal(LockInfo{PID: os.Getpid()})
+	if err := os.WriteFile(filepath.Join(cfg.ConfigDir(), "auto-proj.lock"), lock, 0644); err != nil {
+		t.Fatal(err)
+	}
+	queued, err = EditEpicState(cfg, "proj", EpicEdit{Op: EditRequeue, Bead: "wt-d", Front: true})
+	if err != nil || !queued {
+		t.Fatalf("EditEpicState() = %v, %v; want queued", queued, err)
+	}
+	// Edits are validated before they are queued
+	if _, err := EditEpicState(cfg, "proj", EpicEdit{Op: EditSkip, Bead: "wt-z"}); err == nil {
+		t.Error("invalid edit should not be queued")
+	}
+
+	edits, err := takeEpicEdits(epicEditsFile(cfg, "proj"))
+	if err != nil || len(edits) != 1 || edits[0].Bead != "wt-d" || !edits[0].Front {
+		t.Fatalf("takeEpicEdits() = %+v, %v", edits, err)
+	}
+	if edits, _ := QueuedEpicEdits(cfg, "proj"); len(edits) != 0 {
+		t.Errorf("edits should be consumed, got %+v", edits)
+	}
+}
+
+func TestEpicStateProjects(t *testing.T) {
+	cfg := newEditTestConfig(t)
+	_ = SaveProjectEpicState(cfg, "api", &EpicState{EpicID: "api-epic"})
+	_ = SaveProjectEpicState(cfg, "", &EpicState{EpicID: "legacy"})
+
+	projects, err := EpicStateProjects(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"", "api"}; !slices.Equal(projects, want) {
+		t.Errorf("EpicStateProjects() = %v, want %v", projects, want)
+	}
+}
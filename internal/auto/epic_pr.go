@@ -0,0 +1,120 @@
+package auto
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/merge"
+	"github.com/badri/wt/internal/project"
+)
+
+// epicPRTitle returns the title for an epic's finalization PR
+func epicPRTitle(state *EpicState) string {
+	if state.EpicTitle != "" {
+		return fmt.Sprintf("%s (%s)", state.EpicTitle, state.EpicID)
+	}
+	return fmt.Sprintf("Epic %s", state.EpicID)
+}
+
+// epicPRBody builds the finalization PR description: the epic bead and a
+// changelog of every completed bead with its commit.
+func epicPRBody(state *EpicState) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Closes epic: %s\n", state.EpicID))
+	if state.EpicTitle != "" {
+		sb.WriteString(fmt.Sprintf("\n%s\n", state.EpicTitle))
+	}
+
+	sb.WriteString("\n## Changes\n\n")
+	if len(state.BeadCommits) == 0 {
+		sb.WriteString("No bead commits were recorded.\n")
+	}
+	for _, commit := range state.BeadCommits {
+		title := commit.Title
+		if title == "" {
+			title = state.BeadTitles[commit.BeadID]
+		}
+		line := fmt.Sprintf("- **%s**", commit.BeadID)
+		if title != "" {
+			line += ": " + title
+		}
+		if commit.CommitHash != "" {
+			line += fmt.Sprintf(" (%s)", commit.CommitHash)
+		}
+		sb.WriteString(line + "\n")
+		if commit.Summary != "" && commit.Summary != title {
+			sb.WriteString(fmt.Sprintf("  %s\n", commit.Summary))
+		}
+	}
+
+	sb.WriteString(fmt.Sprintf("\nGenerated by wt auto from %d bead(s).\n", len(state.BeadCommits)))
+	return sb.String()
+}
+
+// resolveEpicMergeMode returns the merge mode for an epic: the --merge-mode
+// it was started with, then the project's, then the global default.
+func resolveEpicMergeMode(cfg *config.Config, state *EpicState, proj *project.Project) string {
+	if state.MergeMode != "" {
+		return state.MergeMode
+	}
+	if proj != nil && proj.MergeMode != "" {
+		return proj.MergeMode
+	}
+	return cfg.DefaultMergeMode
+}
+
+// epicProject finds the registered project containing the epic's repo
+func epicProject(cfg *config.Config, state *EpicState) *project.Project {
+	projects, err := project.NewManager(cfg).List()
+	if err != nil {
+		return nil
+	}
+	for _, proj := range projects {
+		if proj.RepoPath() == state.ProjectDir || strings.HasPrefix(state.ProjectDir, proj.RepoPath()) {
+			return proj
+		}
+	}
+	return nil
+}
+
+// createEpicPR pushes the epic branch and opens a single PR covering every
+// completed bead, enabling auto-merge in pr-auto mode. Direct merge mode and
+// --no-pr skip the PR; the session is left for 'wt done' as before.
+func createEpicPR(cfg *config.Config, state *EpicState) (string, error) {
+	if state.NoPR {
+		return "", nil
+	}
+	proj := epicProject(cfg, state)
+	mergeMode := resolveEpicMergeMode(cfg, state, proj)
+	if mergeMode == "direct" || mergeMode == "none" {
+		return "", nil
+	}
+
+	branch, err := merge.GetCurrentBranch(state.Worktree)
+	if err != nil {
+		return "", err
+	}
+	baseBranch := "main"
+	if proj != nil && proj.DefaultBranch != "" {
+		baseBranch = proj.DefaultBranch
+	}
+
+	logging.Infof("Creating PR for epic %s (%s -> %s)...", state.EpicID, branch, baseBranch)
+	prURL, err := merge.CreatePRWithBody(state.Worktree, branch, baseBranch, epicPRTitle(state), epicPRBody(state))
+	if err != nil {
+		return "", err
+	}
+	fmt.Printf("✓ PR: %s\n", prURL)
+
+	if mergeMode == "pr-auto" {
+		if err := merge.EnableAutoMerge(state.Worktree, prURL); err != nil {
+			logging.Warnf("could not enable auto-merge: %v", err)
+		} else {
+			fmt.Println("✓ Auto-merge enabled")
+		}
+	}
+	return prURL, nil
+}
@@ -0,0 +1,61 @@
+package auto
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/project"
+)
+
+func TestEpicPRTitle(t *testing.T) {
+	if got := epicPRTitle(&EpicState{EpicID: "wt-epic", EpicTitle: "Docs batch"}); got != "Docs batch (wt-epic)" {
+		t.Errorf("epicPRTitle() = %q", got)
+	}
+	if got := epicPRTitle(&EpicState{EpicID: "wt-epic"}); got != "Epic wt-epic" {
+		t.Errorf("epicPRTitle() without title = %q", got)
+	}
+}
+
+func TestEpicPRBody(t *testing.T) {
+	state := &EpicState{
+		EpicID:     "wt-epic",
+		EpicTitle:  "Docs batch",
+		BeadTitles: map[string]string{"wt-def": "Document config"},
+		BeadCommits: []BeadCommitInfo{
+			{BeadID: "wt-abc", CommitHash: "abc1234", Summary: "Add install guide", Title: "Install docs"},
+			{BeadID: "wt-def", CommitHash: "def5678", Summary: "Document config"},
+		},
+	}
+	got := epicPRBody(state)
+
+	for _, want := range []string{
+		"Closes epic: wt-epic\n",
+		"- **wt-abc**: Install docs (abc1234)\n  Add install guide\n",
+		"- **wt-def**: Document config (def5678)\n",
+		"from 2 bead(s)",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("epicPRBody() missing %q in:\n%s", want, got)
+		}
+	}
+	// A summary identical to the title is not repeated
+	if strings.Count(got, "Document config") != 1 {
+		t.Errorf("epicPRBody() repeats summary:\n%s", got)
+	}
+}
+
+func TestResolveEpicMergeMode(t *testing.T) {
+	cfg := &config.Config{DefaultMergeMode: "pr-review"}
+	proj := &project.Project{MergeMode: "pr-auto"}
+
+	if got := resolveEpicMergeMode(cfg, &EpicState{MergeMode: "direct"}, proj); got != "direct" {
+		t.Errorf("flag merge mode = %q, want direct", got)
+	}
+	if got := resolveEpicMergeMode(cfg, &EpicState{}, proj); got != "pr-auto" {
+		t.Errorf("project merge mode = %q, want pr-auto", got)
+	}
+	if got := resolveEpicMergeMode(cfg, &EpicState{}, nil); got != "pr-review" {
+		t.Errorf("default merge mode = %q, want pr-review", got)
+	}
+}
@@ -0,0 +1,114 @@
+package auto
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/badri/wt/internal/bead"
+)
+
+// A bead's points are its bd estimate (estimated_minutes, 'bd update
+// --estimate'). --max-total-points caps the points a project-mode run
+// starts; --smallest-first orders the queue by them.
+
+// compareEstimates orders two beads smallest estimate first. Beads
+// without an estimate sort after those with one.
+func compareEstimates(a, b bead.ReadyBead) int {
+	ea, eb := a.EstimatedMinutes, b.EstimatedMinutes
+	switch {
+	case ea == eb:
+		return 0
+	case eb <= 0:
+		return -1
+	case ea <= 0:
+		return 1
+	case ea < eb:
+		return -1
+	}
+	return 1
+}
+
+// nextBead picks the bead to start from the queue: the first one, or with
+// --max-total-points the first one whose estimate still fits the budget.
+// Beads that don't fit, or have no estimate, are passed over and may still
+// run later if a smaller bead leaves room; once nothing fits the run is
+// done.
+func (r *Runner) nextBead(queue []bead.ReadyBead) (bead.ReadyBead, error) {
+	limit := r.opts.MaxTotalPoints
+	if limit <= 0 {
+		return queue[0], nil
+	}
+	for _, b := range queue {
+		points := b.EstimatedMinutes
+		switch {
+		case points <= 0:
+			r.passOver(b.ID, "it has no estimate")
+		case r.points+points > limit:
+			r.passOver(b.ID, fmt.Sprintf("its %d points would go past the budget (%d of %d used)", points, r.points, limit))
+		default:
+			r.points += points
+			r.logger.Log("Bead %s: %d points, %d of %d used", b.ID, points, r.points, limit)
+			return b, nil
+		}
+	}
+	return bead.ReadyBead{}, fmt.Errorf("points budget of %d reached (%d used, no remaining bead fits)", limit, r.points)
+}
+
+// passOver tells once per run why a bead was not started
+func (r *Runner) passOver(beadID, why string) {
+	if r.passedOver == nil {
+		r.passedOver = make(map[string]bool)
+	}
+	if r.passedOver[beadID] {
+		return
+	}
+	r.passedOver[beadID] = true
+	r.logger.Log("Passing over %s: %s", beadID, why)
+	fmt.Printf("Passing over %s: %s\n", beadID, why)
+}
+
+// withoutBead returns queue minus the bead with the given ID
+func withoutBead(queue []bead.ReadyBead, id string) []bead.ReadyBead {
+	out := make([]bead.ReadyBead, 0, len(queue))
+	for _, b := range queue {
+		if b.ID != id {
+			out = append(out, b)
+		}
+	}
+	return out
+}
+
+// EstimateAccuracy compares the estimates of the run's completed beads
+// with how long they took: how many beads had an estimate, their total
+// estimate and their total actual time
+func (rep *RunReport) EstimateAccuracy() (n int, estimated, actual time.Duration) {
+	for _, b := range rep.Beads {
+		if b.Estimate <= 0 || b.Failed() {
+			continue
+		}
+		n++
+		estimated += time.Duration(b.Estimate) * time.Minute
+		actual += time.Duration(b.Seconds) * time.Second
+	}
+	return n, estimated, actual
+}
+
+// estimateMarkdown is the report's section on estimates, "" when no
+// completed bead had one
+func (rep *RunReport) estimateMarkdown() string {
+	n, estimated, actual := rep.EstimateAccuracy()
+	if n == 0 {
+		return ""
+	}
+	s := fmt.Sprintf("\n## Estimates\n\n%d completed bead(s) with an estimate: estimated %s, took %s", n, estimated, actual)
+	if estimated > 0 {
+		s += fmt.Sprintf(" (%.1fx the estimate)", actual.Seconds()/estimated.Seconds())
+	}
+	s += "\n\n| Bead | Estimate | Actual |\n|------|----------|--------|\n"
+	for _, b := range rep.Beads {
+		if b.Estimate > 0 && !b.Failed() {
+			s += fmt.Sprintf("| %s | %s | %s |\n", b.ID, time.Duration(b.Estimate)*time.Minute, b.Duration)
+		}
+	}
+	return s
+}
@@ -0,0 +1,82 @@
+package auto
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/badri/wt/internal/bead"
+)
+
+func estimatedBeads(estimates map[string]int, ids ...string) []bead.ReadyBead {
+	beads := beadsWithIDs(ids...)
+	for i := range beads {
+		beads[i].EstimatedMinutes = estimates[beads[i].ID]
+	}
+	return beads
+}
+
+func TestOrderSmallestFirst(t *testing.T) {
+	estimates := map[string]int{"a": 120, "b": 15, "c": 0, "d": 30}
+	deps := map[string]beadDeps{
+		// b is small but waits for a
+		"b": {blockers: []string{"a"}},
+		"a": {dependents: []string{"b"}},
+	}
+	got := beadIDs(orderByDependencies(estimatedBeads(estimates, "a", "b", "c", "d"), deps, true))
+	if want := []string{"d", "a", "b", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("smallest first = %v, want %v", got, want)
+	}
+}
+
+func TestNextBeadPointsBudget(t *testing.T) {
+	logger, err := NewLogger(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+	r := &Runner{opts: &Options{MaxTotalPoints: 60}, logger: logger}
+	queue := estimatedBeads(map[string]int{"big": 90, "x": 40, "y": 30, "z": 20}, "none", "big", "x", "y", "z")
+
+	var started []string
+	for {
+		b, err := r.nextBead(queue)
+		if err != nil {
+			break
+		}
+		started = append(started, b.ID)
+		queue = withoutBead(queue, b.ID)
+	}
+	if want := []string{"x", "z"}; !reflect.DeepEqual(started, want) {
+		t.Errorf("started %v, want %v", started, want)
+	}
+	if r.points != 60 {
+		t.Errorf("points = %d, want 60", r.points)
+	}
+
+	// Without a budget the queue order stands
+	r = &Runner{opts: &Options{}}
+	if b, _ := r.nextBead(estimatedBeads(nil, "none", "big")); b.ID != "none" {
+		t.Errorf("nextBead without budget = %s, want none", b.ID)
+	}
+}
+
+func TestRunReportEstimates(t *testing.T) {
+	rep := sampleReport()
+	if md := rep.Markdown(); strings.Contains(md, "## Estimates") {
+		t.Errorf("report without estimates has an Estimates section:\n%s", md)
+	}
+
+	rep.Beads[0].Estimate, rep.Beads[0].Seconds = 10, 1200
+	rep.Beads[1].Estimate = 15 // failed, so not compared
+	n, estimated, actual := rep.EstimateAccuracy()
+	if n != 1 || estimated.Minutes() != 10 || actual.Minutes() != 20 {
+		t.Errorf("EstimateAccuracy() = %d, %s, %s", n, estimated, actual)
+	}
+	md := rep.Markdown()
+	for _, want := range []string{"estimated 10m0s, took 20m0s (2.0x the estimate)", "| app-a | 10m0s | 20m0s |"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+}
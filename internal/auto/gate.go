@@ -0,0 +1,165 @@
+package auto
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/hub"
+	"github.com/badri/wt/internal/monitor"
+	"github.com/badri/wt/internal/msg"
+	"github.com/badri/wt/internal/tmux"
+)
+
+// Review gates of wt auto --gate
+const (
+	GateAfterEachBead = "after-each-bead" // wait after every bead of the epic
+	GateBeforeMerge   = "before-merge"    // wait before the epic is closed and its PR opened
+)
+
+// gatePollInterval is how often a run waiting at a gate looks for approval
+const gatePollInterval = 5 * time.Second
+
+// ValidateGate checks a --gate value
+func ValidateGate(gate string) error {
+	switch gate {
+	case "", GateAfterEachBead, GateBeforeMerge:
+		return nil
+	}
+	return fmt.Errorf("invalid --gate %q: use %s or %s", gate, GateAfterEachBead, GateBeforeMerge)
+}
+
+// PendingGate is the checkpoint a gated run is waiting at
+type PendingGate struct {
+	Gate  string `json:"gate"`
+	Bead  string `json:"bead,omitempty"` // bead that just finished, for after-each-bead
+	Since string `json:"since"`
+}
+
+// Describe says what is waiting for approval
+func (g *PendingGate) Describe(state *EpicState) string {
+	if g.Gate == GateBeforeMerge {
+		return fmt.Sprintf("epic %s finished all %d bead(s) and waits for approval before it is closed and merged", state.EpicID, len(state.Beads))
+	}
+	done := len(state.CompletedBeads) + len(state.FailedBeads)
+	outcome := "completed"
+	if reason, failed := state.FailedBeads[g.Bead]; failed {
+		outcome = "failed (" + reason + ")"
+	}
+	return fmt.Sprintf("epic %s: bead %s %s (%d/%d), waiting for approval to continue", state.EpicID, g.Bead, outcome, done, len(state.Beads))
+}
+
+// ApproveFile is the file 'wt auto approve' writes for a project's run
+func ApproveFile(cfg *config.Config, project string) string {
+	if project == "" {
+		return filepath.Join(cfg.ConfigDir(), "approve-auto")
+	}
+	return filepath.Join(cfg.ConfigDir(), fmt.Sprintf("approve-auto-%s", project))
+}
+
+// Approve lets a project's run past the gate it is waiting at. A run
+// stopped at a gate is approved by resuming it instead.
+func Approve(cfg *config.Config, project string) (*EpicState, error) {
+	state, err := LoadProjectEpicState(cfg, project)
+	if err != nil {
+		return nil, fmt.Errorf("no epic state for project '%s': %w", project, err)
+	}
+	if state.PendingGate == nil {
+		return nil, fmt.Errorf("epic %s is not waiting for approval (status: %s)", state.EpicID, state.Status)
+	}
+	if !RunnerActive(cfg, project) {
+		return nil, fmt.Errorf("epic %s was stopped at its %s gate; continue it with: wt auto --resume --epic %s", state.EpicID, state.PendingGate.Gate, state.EpicID)
+	}
+	if err := os.WriteFile(ApproveFile(cfg, project), []byte(time.Now().Format(time.RFC3339)), 0644); err != nil {
+		return nil, fmt.Errorf("writing approval: %w", err)
+	}
+	return state, nil
+}
+
+// passGate stops at a review gate when the run has it: it notifies the user
+// and the hub, then waits for 'wt auto approve'. It returns false when the
+// run was stopped while waiting; the state is then saved as paused with the
+// gate still pending.
+func (r *Runner) passGate(state *EpicState, gate, beadID string) bool {
+	if state.Gate != gate || r.opts.DryRun {
+		return true
+	}
+	if gate == GateBeforeMerge && r.mergeApproved {
+		// Resuming a run stopped at this gate approved it
+		r.mergeApproved = false
+		return true
+	}
+
+	approveFile := r.approveFile()
+	os.Remove(approveFile) // an earlier approval doesn't count for this gate
+
+	status := state.Status
+	state.Status = "awaiting-approval"
+	state.PendingGate = &PendingGate{Gate: gate, Bead: beadID, Since: time.Now().Format(time.RFC3339)}
+	r.saveEpicState(state)
+
+	text := state.PendingGate.Describe(state)
+	r.logger.Log("Gate %s: %s", gate, text)
+	r.notifyGate(state, text)
+	fmt.Printf("\n⏸ %s\n", text)
+	fmt.Printf("  Review %s, then: %s\n", state.currentWorktree(), r.projectCommand("wt auto approve"))
+	fmt.Printf("  Or stop here with: %s\n", r.projectCommand("wt auto --stop"))
+
+	ticker := time.NewTicker(gatePollInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := os.Stat(approveFile); err == nil {
+			os.Remove(approveFile)
+			state.Status = status
+			state.PendingGate = nil
+			r.saveEpicState(state)
+			r.logger.Log("Gate %s approved", gate)
+			fmt.Println("✓ Approved, continuing")
+			return true
+		}
+		if r.shouldStop() {
+			state.Status = "paused"
+			r.saveEpicState(state)
+			r.logger.Log("Stopped at gate %s", gate)
+			fmt.Printf("\nStopped at the %s gate. Use 'wt auto --resume --epic %s' to approve and continue.\n", gate, state.EpicID)
+			return false
+		}
+		select {
+		case <-ticker.C:
+		case <-r.stopSignal:
+			r.stopSignal <- struct{}{} // let shouldStop see it
+		}
+	}
+}
+
+// approveFile is the approval file of the runner's project
+func (r *Runner) approveFile() string {
+	return ApproveFile(r.cfg, r.opts.Project)
+}
+
+// projectCommand adds the runner's project to a wt auto command line
+func (r *Runner) projectCommand(command string) string {
+	if r.opts.Project == "" {
+		return command
+	}
+	return command + " --project " + r.opts.Project
+}
+
+// notifyGate tells the user a run waits for approval: a desktop
+// notification, a message to the hub and, when the hub runs, a nudge
+func (r *Runner) notifyGate(state *EpicState, text string) {
+	monitor.Notify("wt auto: Approval Needed", text)
+	text = fmt.Sprintf("%s. Approve with: %s", text, r.projectCommand("wt auto approve"))
+	if r.store != nil {
+		if _, err := r.store.Send(&msg.Message{Subject: msg.SubjectProgress, From: "orchestrator", To: hub.HubSessionName, Body: text, ThreadID: state.EpicID}); err != nil {
+			r.logger.Log("Warning: could not message the hub: %v", err)
+		}
+	}
+	if tmux.SessionExists(hub.HubSessionName) {
+		if err := tmux.NudgeSession(hub.HubSessionName, "[wt] "+text); err != nil {
+			r.logger.Log("Warning: could not notify the hub session: %v", err)
+		}
+	}
+}
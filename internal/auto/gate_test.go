@@ -0,0 +1,117 @@
+package auto
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/badri/wt/internal/config"
+)
+
+func TestValidateGate(t *testing.T) {
+	for _, gate := range []string{"", GateAfterEachBead, GateBeforeMerge} {
+		if err := ValidateGate(gate); err != nil {
+			t.Errorf("ValidateGate(%q) error: %v", gate, err)
+		}
+	}
+	if err := ValidateGate("after-merge"); err == nil {
+		t.Error("ValidateGate(after-merge): want an error")
+	}
+}
+
+func TestPendingGateDescribe(t *testing.T) {
+	state := &EpicState{
+		EpicID:         "wt-epic",
+		Beads:          []string{"wt-1", "wt-2", "wt-3"},
+		CompletedBeads: []string{"wt-1"},
+		FailedBeads:    map[string]string{"wt-2": "timeout"},
+	}
+	got := (&PendingGate{Gate: GateAfterEachBead, Bead: "wt-2"}).Describe(state)
+	if !strings.Contains(got, "wt-2 failed (timeout) (2/3)") {
+		t.Errorf("Describe() = %q, want the failed bead and progress", got)
+	}
+	got = (&PendingGate{Gate: GateBeforeMerge}).Describe(state)
+	if !strings.Contains(got, "before it is closed and merged") {
+		t.Errorf("Describe() = %q, want the merge gate", got)
+	}
+}
+
+func TestApprove(t *testing.T) {
+	cfg, err := config.LoadFromDir(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadFromDir() error: %v", err)
+	}
+	r := &Runner{cfg: cfg, opts: &Options{Project: "app"}}
+	state := &EpicState{EpicID: "wt-epic", Status: "running"}
+	if err := r.saveEpicState(state); err != nil {
+		t.Fatalf("saveEpicState() error: %v", err)
+	}
+
+	if _, err := Approve(cfg, "app"); err == nil || !strings.Contains(err.Error(), "not waiting") {
+		t.Errorf("Approve() without a gate = %v, want not waiting", err)
+	}
+
+	state.Status = "paused"
+	state.PendingGate = &PendingGate{Gate: GateBeforeMerge}
+	r.saveEpicState(state)
+	if _, err := Approve(cfg, "app"); err == nil || !strings.Contains(err.Error(), "--resume") {
+		t.Errorf("Approve() with no runner = %v, want a pointer to --resume", err)
+	}
+
+	lock, _ := json.Marshal(LockInfo{PID: os.Getpid()})
+	if err := os.WriteFile(filepath.Join(cfg.ConfigDir(), "auto-app.lock"), lock, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Approve(cfg, "app"); err != nil {
+		t.Fatalf("Approve() error: %v", err)
+	}
+	if _, err := os.Stat(ApproveFile(cfg, "app")); err != nil {
+		t.Errorf("approve file not written: %v", err)
+	}
+}
+
+func TestPassGate(t *testing.T) {
+	cfg, err := config.LoadFromDir(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadFromDir() error: %v", err)
+	}
+	logger, err := NewLogger(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLogger() error: %v", err)
+	}
+	defer logger.Close()
+	r := &Runner{
+		cfg:        cfg,
+		opts:       &Options{Project: "app"},
+		logger:     logger,
+		stopFile:   filepath.Join(cfg.ConfigDir(), "stop-auto-app"),
+		stopSignal: make(chan struct{}, 1),
+	}
+	state := &EpicState{EpicID: "wt-epic", Status: "running", Gate: GateBeforeMerge, Beads: []string{"wt-1"}}
+
+	// Other gates, and a merge gate approved by resuming, pass straight away
+	if !r.passGate(state, GateAfterEachBead, "wt-1") {
+		t.Error("passGate() at a gate the run doesn't have: want true")
+	}
+	r.mergeApproved = true
+	if !r.passGate(state, GateBeforeMerge, "") || r.mergeApproved {
+		t.Error("passGate() after resuming at the merge gate: want true, once")
+	}
+
+	// Stopping while waiting pauses the run with the gate pending
+	if err := os.WriteFile(r.stopFile, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if r.passGate(state, GateBeforeMerge, "") {
+		t.Fatal("passGate() with a stop request: want false")
+	}
+	saved, err := LoadProjectEpicState(cfg, "app")
+	if err != nil {
+		t.Fatalf("LoadProjectEpicState() error: %v", err)
+	}
+	if saved.Status != "paused" || saved.PendingGate == nil || saved.PendingGate.Gate != GateBeforeMerge {
+		t.Errorf("saved state = %s %+v, want paused at %s", saved.Status, saved.PendingGate, GateBeforeMerge)
+	}
+}
@@ -0,0 +1,316 @@
+package auto
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/badri/wt/internal/bead"
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/msg"
+	"github.com/badri/wt/internal/project"
+	"github.com/badri/wt/internal/workqueue"
+)
+
+const (
+	// queuePollWait is how long one pop waits for an item, which is also
+	// how quickly a waiting queue run notices a stop
+	queuePollWait = 5 * time.Second
+	// queueMaxBackoff caps the wait between reconnects to the queue
+	queueMaxBackoff = 2 * time.Minute
+)
+
+// queueLockName names the lock of a queue run whose items may go to any
+// project
+const queueLockName = "queue"
+
+// runQueueMode consumes the configured external work queue until stopped:
+// each item becomes a bead (or names one) that is worked in its own
+// session like in project mode, and is linked back to its source
+func (r *Runner) runQueueMode() error {
+	settings := r.cfg.QueueSettings()
+	if settings == nil {
+		return fmt.Errorf("no work queue configured; set queue.url in the wt config ('wt config edit')")
+	}
+	if r.opts.Abort || r.opts.Resume {
+		return fmt.Errorf("--abort and --resume are only supported with --epic mode")
+	}
+	if r.opts.DryRun {
+		return fmt.Errorf("--dry-run can't be used with --queue: reading an item takes it off the queue")
+	}
+	if r.opts.Project == "" {
+		r.opts.Project = settings.Project
+	}
+	if r.opts.Project != "" {
+		if _, err := r.projMgr.Get(r.opts.Project); err != nil {
+			return fmt.Errorf("project '%s' not found", r.opts.Project)
+		}
+		r.setProjectPaths(r.opts.Project)
+	} else {
+		r.setProjectPaths(queueLockName)
+	}
+
+	// Initialize logger
+	logsDir := filepath.Join(r.cfg.ConfigDir(), "logs")
+	if err := os.MkdirAll(logsDir, 0755); err != nil {
+		return fmt.Errorf("creating logs directory: %w", err)
+	}
+	logger, err := NewLogger(logsDir)
+	if err != nil {
+		return fmt.Errorf("creating logger: %w", err)
+	}
+	defer logger.Close()
+	r.logger = logger
+
+	// Open message store for dual-write
+	storePath := filepath.Join(r.cfg.ConfigDir(), "messages.db")
+	store, err := msg.Open(storePath)
+	if err != nil {
+		r.logger.Log("Warning: could not open message store: %v", err)
+	} else {
+		r.store = store
+		defer store.Close()
+	}
+
+	if err := r.acquireLock(); err != nil {
+		return err
+	}
+	defer r.releaseLock()
+
+	os.Remove(r.stopFile)
+	r.setupSignalHandler()
+
+	r.logger.Log("Starting queue mode on %s (%s)", settings.QueueName(), settings.DisplayURL())
+	fmt.Printf("Waiting for work on %s (%s). Stop with 'wt auto --stop' or Ctrl-C.\n", settings.QueueName(), settings.DisplayURL())
+
+	r.startReport("queue")
+	err = r.consumeQueue(settings)
+	r.finishReport(err)
+	if err != nil {
+		r.logger.Log("Queue mode stopped: %v", err)
+		return err
+	}
+
+	r.logger.Log("Queue mode complete")
+	return nil
+}
+
+// consumeQueue pops and processes items until a stop, the --limit or a
+// budget is reached. The connection is closed while an item is worked.
+// Lost connections are retried with backoff.
+func (r *Runner) consumeQueue(settings *config.Queue) error {
+	var q workqueue.Queue
+	defer func() {
+		if q != nil {
+			q.Close()
+		}
+	}()
+
+	processed := 0
+	backoff := time.Second
+	for {
+		if r.opts.Limit > 0 && processed >= r.opts.Limit {
+			fmt.Printf("Processed %d item(s), the --limit.\n", processed)
+			return nil
+		}
+		if r.shouldStop() {
+			r.logger.Log("Stop signal received, stopping queue consumption")
+			return nil
+		}
+		if err := r.checkBudget(r.spent, r.costLimit(r.defaultQueueProject()), "queue run"); err != nil {
+			fmt.Printf("Stopping: %v\n", err)
+			return nil
+		}
+
+		if q == nil {
+			conn, err := workqueue.Open(settings)
+			if err != nil {
+				r.logger.Log("Warning: %v, retrying in %v", err, backoff)
+				logging.Warnf("%v, retrying in %v", err, backoff)
+				if !r.wait(backoff) {
+					return nil
+				}
+				backoff = min(backoff*2, queueMaxBackoff)
+				continue
+			}
+			q, backoff = conn, time.Second
+		}
+
+		data, err := q.Pop(queuePollWait)
+		if err != nil {
+			r.logger.Log("Warning: reading the queue: %v, reconnecting", err)
+			q.Close()
+			q = nil
+			continue
+		}
+		if data == nil {
+			continue
+		}
+
+		item, proj, err := r.receiveQueueItem(data)
+		if err != nil {
+			r.logger.Log("Dropping queue item: %v", err)
+			fmt.Printf("Dropping queue item: %v\n", err)
+			continue
+		}
+		if item == nil {
+			continue
+		}
+
+		// An item that can't start now goes back on the queue for the next run
+		if err := r.pace(proj); err != nil {
+			if perr := q.Push(data); perr != nil {
+				r.logger.Log("Warning: could not requeue %s: %v", item.Ref(), perr)
+				logging.Warnf("could not requeue %s: %v", item.Ref(), perr)
+			} else {
+				r.logger.Log("Requeued %s", item.Ref())
+			}
+			if err != errStopped {
+				r.logger.Log("Pacing: %v, stopping queue consumption", err)
+				fmt.Printf("Stopping: %v\n", err)
+			}
+			return nil
+		}
+
+		// Working an item can take hours, during which the connection
+		// would sit unanswered until the server drops it as stale; reconnect
+		// for the next item instead
+		q.Close()
+		q = nil
+
+		if err := r.workQueueItem(proj, item); err != nil {
+			r.logger.Log("Error processing %s: %v", item.Ref(), err)
+			fmt.Printf("Error processing %s: %v\n", item.Ref(), err)
+		}
+		processed++
+	}
+}
+
+// receiveQueueItem parses an item and resolves its project. It returns a
+// nil item for one that was already received, since producers may
+// deliver an item more than once.
+func (r *Runner) receiveQueueItem(data []byte) (*workqueue.Item, *project.Project, error) {
+	item, err := workqueue.Parse(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	r.logger.Log("Received queue item %s", item.Ref())
+	fmt.Printf("\nReceived %s\n", item.Ref())
+
+	links, err := workqueue.Load(r.cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("loading queue links: %w", err)
+	}
+	if link := links.FindItem(item.Source, item.ID); link != nil {
+		fmt.Printf("Already received as %s, skipping\n", link.Bead)
+		return nil, nil, nil
+	}
+
+	projName := item.Project
+	if projName == "" {
+		projName = r.opts.Project
+	}
+	if projName == "" {
+		return nil, nil, fmt.Errorf("item %s names no project and no default is set (--project or queue.project)", item.Ref())
+	}
+	proj, err := r.projMgr.Get(projName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("item %s: project '%s' not found", item.Ref(), projName)
+	}
+	return item, proj, nil
+}
+
+// workQueueItem works an item's bead in its own session and records the
+// link from the item to the bead, session and outcome
+func (r *Runner) workQueueItem(proj *project.Project, item *workqueue.Item) error {
+	b, created, err := r.queueItemBead(proj, item)
+	if err != nil {
+		return err
+	}
+
+	links, err := workqueue.Load(r.cfg)
+	if err != nil {
+		return fmt.Errorf("loading queue links: %w", err)
+	}
+	link := &workqueue.Link{
+		Bead:       b.ID,
+		Project:    proj.Name,
+		ItemID:     item.ID,
+		Source:     item.Source,
+		URL:        item.URL,
+		Created:    created,
+		ReceivedAt: time.Now().Format(time.RFC3339),
+	}
+	links.Put(link)
+	if err := links.Save(); err != nil {
+		return fmt.Errorf("saving queue link for %s: %w", b.ID, err)
+	}
+
+	err = r.processBead(proj, b)
+	if n := len(r.report.Beads); n > 0 && r.report.Beads[n-1].ID == b.ID {
+		run := r.report.Beads[n-1]
+		run.Source = item.Ref()
+		link.Session, link.Outcome = run.Session, run.Outcome
+	}
+	link.FinishedAt = time.Now().Format(time.RFC3339)
+	if serr := links.Save(); serr != nil {
+		r.logger.Log("Warning: saving queue link for %s: %v", b.ID, serr)
+	}
+	return err
+}
+
+// queueItemBead returns the bead an item names, or creates one from it
+func (r *Runner) queueItemBead(proj *project.Project, item *workqueue.Item) (*bead.ReadyBead, bool, error) {
+	if item.Bead != "" {
+		info, err := bead.ShowFullInDir(item.Bead, proj.BeadsDir())
+		if err != nil {
+			return nil, false, fmt.Errorf("item %s: %w", item.Ref(), err)
+		}
+		return &bead.ReadyBead{
+			ID:          info.ID,
+			Title:       info.Title,
+			Description: info.Description,
+			Status:      info.Status,
+			Priority:    info.Priority,
+			IssueType:   info.IssueType,
+		}, false, nil
+	}
+
+	opts := &bead.CreateOptions{
+		Description: item.BeadDescription(),
+		Priority:    2,
+		Type:        item.Type,
+		Labels:      append([]string{"queue"}, item.Labels...),
+	}
+	if item.Priority != nil {
+		opts.Priority = *item.Priority
+	}
+	id, err := bead.CreateInDir(proj.BeadsDir(), item.Title, opts)
+	if err != nil {
+		return nil, false, fmt.Errorf("item %s: %w", item.Ref(), err)
+	}
+	r.logger.Log("Created bead %s from %s", id, item.Ref())
+	fmt.Printf("Created bead %s from %s\n", id, item.Ref())
+	return &bead.ReadyBead{
+		ID:          id,
+		Title:       item.Title,
+		Description: opts.Description,
+		Priority:    opts.Priority,
+		IssueType:   item.Type,
+		Labels:      opts.Labels,
+	}, true, nil
+}
+
+// defaultQueueProject returns the run's default project, for its budget
+func (r *Runner) defaultQueueProject() *project.Project {
+	if r.opts.Project == "" {
+		return nil
+	}
+	proj, err := r.projMgr.Get(r.opts.Project)
+	if err != nil {
+		return nil
+	}
+	return proj
+}
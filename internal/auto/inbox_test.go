@@ -0,0 +1,50 @@
+package auto
+
+import (
+	"testing"
+	"time"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/project"
+	"github.com/badri/wt/internal/workqueue"
+)
+
+func TestReceiveQueueItem(t *testing.T) {
+	cfg, err := config.LoadFromDir(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadFromDir() error: %v", err)
+	}
+	mgr := project.NewManager(cfg)
+	if err := mgr.Save(&project.Project{Name: "app", Repo: t.TempDir()}); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	logger, err := NewLogger(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLogger() error: %v", err)
+	}
+	defer logger.Close()
+	r := &Runner{cfg: cfg, projMgr: mgr, opts: &Options{}, logger: logger}
+
+	if _, _, err := r.receiveQueueItem([]byte(`{"title":"Fix CI"}`)); err == nil {
+		t.Error("item without a project and no default: want an error")
+	}
+
+	r.opts.Project = "app"
+	item, proj, err := r.receiveQueueItem([]byte(`{"id":"7","source":"ci","title":"Fix CI"}`))
+	if err != nil || item == nil || proj.Name != "app" {
+		t.Fatalf("receiveQueueItem() = %+v, %+v, %v; want the item in app", item, proj, err)
+	}
+	if _, _, err := r.receiveQueueItem([]byte(`{"title":"Fix CI","project":"missing"}`)); err == nil {
+		t.Error("item naming an unknown project: want an error")
+	}
+
+	// An item received before is skipped
+	links, _ := workqueue.Load(cfg)
+	links.Put(&workqueue.Link{Bead: "app-1", ItemID: "7", Source: "ci", ReceivedAt: time.Now().Format(time.RFC3339)})
+	if err := links.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	if item, _, err := r.receiveQueueItem([]byte(`{"id":"7","source":"ci","title":"Fix CI"}`)); err != nil || item != nil {
+		t.Errorf("redelivered item = %+v, %v; want it skipped", item, err)
+	}
+}
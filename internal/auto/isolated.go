@@ -0,0 +1,146 @@
+package auto
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/badri/wt/internal/logging"
+)
+
+// isolatedBeadBranch returns the branch name used for a bead in isolated mode.
+// The bead branch hangs off the epic branch so it can be fast-forwarded back.
+func isolatedBeadBranch(epicBranch, beadID string) string {
+	return epicBranch + "-" + beadID
+}
+
+// isolatedBeadWorktreePath returns the worktree path used for a bead in isolated mode.
+// Bead worktrees are created as siblings of the epic worktree.
+func isolatedBeadWorktreePath(epicWorktree, beadID string) string {
+	return epicWorktree + "-" + strings.ReplaceAll(beadID, "/", "-")
+}
+
+// runEpicBead runs Claude for one epic bead, either directly in the shared epic
+// worktree or, in isolated mode, in a throwaway worktree of its own.
+func (r *Runner) runEpicBead(state *EpicState, beadID, command, prompt string, timeout time.Duration) (string, error) {
+	if state.Isolated {
+		return r.runIsolatedBead(state, beadID, command, prompt, timeout)
+	}
+	return r.runSignaledBead(state, beadID, command, prompt, timeout)
+}
+
+// runIsolatedBead runs a single epic bead in a fresh worktree branched off the
+// accumulated epic branch. On success the bead branch is fast-forwarded into the
+// epic worktree; on failure it is discarded so prior beads are never touched.
+// With --pause-on-failure the failed worktree is kept for inspection and cleaned
+// up by --resume or --abort.
+func (r *Runner) runIsolatedBead(state *EpicState, beadID, command, prompt string, timeout time.Duration) (string, error) {
+	if err := r.createBeadWorktree(state, beadID); err != nil {
+		return "failed-worktree", err
+	}
+	fmt.Printf("  Isolated worktree: %s\n", state.BeadWorktree)
+
+	if err := cdSession(state.SessionName, state.BeadWorktree); err != nil {
+		r.discardBeadWorktree(state)
+		return "failed-worktree", err
+	}
+
+	outcome, err := r.runSignaledBead(state, beadID, command, prompt, timeout)
+	if err != nil || outcome != "success" {
+		if outcome == "stopped" || r.opts.PauseOnFailure {
+			// Leave the bead worktree in place for inspection
+			return outcome, err
+		}
+		cdSession(state.SessionName, state.Worktree)
+		r.discardBeadWorktree(state)
+		fmt.Printf("  Discarded isolated worktree for %s\n", beadID)
+		return outcome, err
+	}
+
+	cdSession(state.SessionName, state.Worktree)
+	if err := r.mergeBeadWorktree(state); err != nil {
+		r.logger.Log("Merging isolated bead %s failed: %v", beadID, err)
+		r.discardBeadWorktree(state)
+		return "failed-merge", nil
+	}
+	r.discardBeadWorktree(state)
+	return outcome, nil
+}
+
+// createBeadWorktree creates a fresh worktree for beadID off the epic worktree's HEAD
+// and records it in the epic state.
+func (r *Runner) createBeadWorktree(state *EpicState, beadID string) error {
+	if state.EpicBranch == "" {
+		cmd := logging.CommandIn(state.Worktree, "git", "rev-parse", "--abbrev-ref", "HEAD")
+		output, err := cmd.Output()
+		if err != nil {
+			return fmt.Errorf("getting epic branch: %w", err)
+		}
+		state.EpicBranch = strings.TrimSpace(string(output))
+	}
+
+	path := isolatedBeadWorktreePath(state.Worktree, beadID)
+	branch := isolatedBeadBranch(state.EpicBranch, beadID)
+
+	// Clear out leftovers from an earlier attempt at the same bead
+	state.BeadWorktree = path
+	state.BeadBranch = branch
+	r.discardBeadWorktree(state)
+
+	cmd := logging.CommandIn(state.Worktree, "git", "worktree", "add", "-b", branch, path, "HEAD")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("creating bead worktree: %s: %w", strings.TrimSpace(string(output)), err)
+	}
+
+	// Carry the batch mode marker over so wt done behaves the same in the bead worktree
+	markerPath := filepath.Join(path, ".wt-batch-mode")
+	if err := os.WriteFile(markerPath, []byte(state.EpicID), 0644); err != nil {
+		r.logger.Log("Warning: could not create batch mode marker: %v", err)
+	}
+
+	state.BeadWorktree = path
+	state.BeadBranch = branch
+	r.saveEpicState(state)
+	return nil
+}
+
+// mergeBeadWorktree fast-forwards the epic branch to the bead branch.
+func (r *Runner) mergeBeadWorktree(state *EpicState) error {
+	cmd := logging.CommandIn(state.Worktree, "git", "merge", "--ff-only", state.BeadBranch)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s: %w", strings.TrimSpace(string(output)), err)
+	}
+	return nil
+}
+
+// discardBeadWorktree removes the current bead worktree and branch, if any.
+func (r *Runner) discardBeadWorktree(state *EpicState) {
+	if state.BeadWorktree == "" {
+		return
+	}
+
+	cmd := logging.CommandIn(state.Worktree, "git", "worktree", "remove", "--force", state.BeadWorktree)
+	cmd.Run() // Ignore errors - worktree may not exist
+
+	if state.BeadBranch != "" {
+		cmd = logging.Command("git", "branch", "-D", state.BeadBranch)
+		cmd.Dir = state.Worktree
+		cmd.Run() // Ignore errors - branch may not exist
+	}
+
+	state.BeadWorktree = ""
+	state.BeadBranch = ""
+	r.saveEpicState(state)
+}
+
+// cdSession changes the working directory of the shell in a tmux session.
+func cdSession(sessionName, dir string) error {
+	cmd := logging.Command("tmux", "send-keys", "-t", sessionName, fmt.Sprintf(" cd %q", dir), "Enter")
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("changing directory in %s: %w", sessionName, err)
+	}
+	time.Sleep(500 * time.Millisecond)
+	return nil
+}
@@ -0,0 +1,75 @@
+package auto
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestIsolatedBeadBranch(t *testing.T) {
+	tests := []struct {
+		epicBranch string
+		beadID     string
+		want       string
+	}{
+		{"wt-epic", "wt-abc", "wt-epic-wt-abc"},
+		{"feature/batch", "wt-1a3", "feature/batch-wt-1a3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := isolatedBeadBranch(tt.epicBranch, tt.beadID); got != tt.want {
+				t.Errorf("isolatedBeadBranch(%q, %q) = %q, want %q", tt.epicBranch, tt.beadID, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsolatedBeadWorktreePath(t *testing.T) {
+	tests := []struct {
+		worktree string
+		beadID   string
+		want     string
+	}{
+		{"/home/u/worktrees/auto-wtepic", "wt-abc", "/home/u/worktrees/auto-wtepic-wt-abc"},
+		{"/tmp/wt", "ns/wt-abc", "/tmp/wt-ns-wt-abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := isolatedBeadWorktreePath(tt.worktree, tt.beadID); got != tt.want {
+				t.Errorf("isolatedBeadWorktreePath(%q, %q) = %q, want %q", tt.worktree, tt.beadID, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEpicStateIsolatedFields(t *testing.T) {
+	state := &EpicState{
+		EpicID:       "wt-epic",
+		Worktree:     "/tmp/worktree",
+		Isolated:     true,
+		EpicBranch:   "wt-epic",
+		BeadWorktree: "/tmp/worktree-wt-a1",
+		BeadBranch:   "wt-epic-wt-a1",
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		t.Fatalf("marshaling state: %v", err)
+	}
+
+	var readState EpicState
+	if err := json.Unmarshal(data, &readState); err != nil {
+		t.Fatalf("unmarshaling state: %v", err)
+	}
+
+	if !readState.Isolated {
+		t.Error("expected Isolated to be true")
+	}
+	if readState.BeadWorktree != state.BeadWorktree {
+		t.Errorf("expected bead worktree %s, got %s", state.BeadWorktree, readState.BeadWorktree)
+	}
+	if readState.BeadBranch != state.BeadBranch {
+		t.Errorf("expected bead branch %s, got %s", state.BeadBranch, readState.BeadBranch)
+	}
+}
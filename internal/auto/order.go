@@ -0,0 +1,145 @@
+package auto
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/badri/wt/internal/bead"
+	"github.com/badri/wt/internal/project"
+)
+
+// beadDeps holds the blocking dependencies of a bead
+type beadDeps struct {
+	blockers   []string // Beads that must be done first
+	dependents []string // Beads waiting on this one
+}
+
+// orderByDependencies sorts ready beads topologically: a bead blocked by
+// another bead in the queue comes after it. Among beads free to start,
+// those that unblock the most other beads go first, then bd's own order.
+// With smallestFirst the smallest estimate goes first, and unblocking
+// decides only between equal estimates. Beads caught in a dependency cycle
+// keep bd's order at the end.
+func orderByDependencies(beads []bead.ReadyBead, deps map[string]beadDeps, smallestFirst bool) []bead.ReadyBead {
+	inQueue := make(map[string]bool, len(beads))
+	for _, b := range beads {
+		inQueue[b.ID] = true
+	}
+
+	// Count blockers still in the queue
+	pending := make(map[string]int, len(beads))
+	for _, b := range beads {
+		for _, id := range deps[b.ID].blockers {
+			if inQueue[id] && id != b.ID {
+				pending[b.ID]++
+			}
+		}
+	}
+
+	ordered := make([]bead.ReadyBead, 0, len(beads))
+	placed := make(map[string]bool, len(beads))
+	for len(ordered) < len(beads) {
+		next := -1
+		for i, b := range beads {
+			if placed[b.ID] || pending[b.ID] > 0 {
+				continue
+			}
+			if next < 0 {
+				next = i
+				continue
+			}
+			if smallestFirst {
+				if cmp := compareEstimates(b, beads[next]); cmp != 0 {
+					if cmp < 0 {
+						next = i
+					}
+					continue
+				}
+			}
+			if len(deps[b.ID].dependents) > len(deps[beads[next].ID].dependents) {
+				next = i
+			}
+		}
+		if next < 0 {
+			// A cycle: nothing is free, so fall back to bd's order
+			for _, b := range beads {
+				if !placed[b.ID] {
+					ordered = append(ordered, b)
+					placed[b.ID] = true
+				}
+			}
+			break
+		}
+
+		b := beads[next]
+		ordered = append(ordered, b)
+		placed[b.ID] = true
+		for _, id := range deps[b.ID].dependents {
+			if inQueue[id] && pending[id] > 0 {
+				pending[id]--
+			}
+		}
+	}
+	return ordered
+}
+
+// readyQueue returns a project's ready beads in dependency order, leaving
+// out beads already attempted in this run. deps caches bd lookups across
+// calls; a bead whose dependencies can't be read sorts as if it had none.
+func (r *Runner) readyQueue(proj *project.Project, attempted map[string]bool, deps map[string]beadDeps) ([]bead.ReadyBead, error) {
+	readyBeads, err := bead.ReadyInDir(proj.BeadsDir())
+	if err != nil {
+		return nil, fmt.Errorf("getting ready beads: %w", err)
+	}
+	readyBeads, excluded, err := bead.FilterReady(readyBeads, proj.ReadyFilter, proj.RepoPath())
+	if err != nil {
+		return nil, err
+	}
+	if len(excluded) > 0 && r.logger != nil {
+		r.logger.Log("ready_filter excluded: %s", strings.Join(excluded, ", "))
+	}
+
+	var queue []bead.ReadyBead
+	for _, b := range readyBeads {
+		if attempted[b.ID] {
+			continue
+		}
+		if _, ok := deps[b.ID]; !ok {
+			blockers, dependents, err := bead.Deps(b.ID, proj.RepoPath())
+			if err != nil && r.logger != nil {
+				r.logger.Log("Could not read dependencies of %s: %v", b.ID, err)
+			}
+			deps[b.ID] = beadDeps{blockers: blockers, dependents: dependents}
+		}
+		queue = append(queue, b)
+	}
+	return orderByDependencies(queue, deps, r.opts.SmallestFirst), nil
+}
+
+// newlyReady returns the IDs in queue that were not in the previous one
+func newlyReady(queue, previous []bead.ReadyBead) []string {
+	seen := make(map[string]bool, len(previous))
+	for _, b := range previous {
+		seen[b.ID] = true
+	}
+	var ids []string
+	for _, b := range queue {
+		if !seen[b.ID] {
+			ids = append(ids, b.ID)
+		}
+	}
+	return ids
+}
+
+// describeQueue renders a queue for logs, e.g. "wt-a, wt-b (unblocks 2)"
+func describeQueue(queue []bead.ReadyBead, deps map[string]beadDeps) string {
+	parts := make([]string, 0, len(queue))
+	for _, b := range queue {
+		if n := len(deps[b.ID].dependents); n > 0 {
+			parts = append(parts, fmt.Sprintf("%s (unblocks %d)", b.ID, n))
+		} else {
+			parts = append(parts, b.ID)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
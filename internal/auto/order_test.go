@@ -0,0 +1,90 @@
+package auto
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/badri/wt/internal/bead"
+)
+
+func beadsWithIDs(ids ...string) []bead.ReadyBead {
+	beads := make([]bead.ReadyBead, len(ids))
+	for i, id := range ids {
+		beads[i] = bead.ReadyBead{ID: id}
+	}
+	return beads
+}
+
+func beadIDs(beads []bead.ReadyBead) []string {
+	ids := make([]string, len(beads))
+	for i, b := range beads {
+		ids[i] = b.ID
+	}
+	return ids
+}
+
+func TestOrderByDependencies(t *testing.T) {
+	tests := []struct {
+		name  string
+		queue []string
+		deps  map[string]beadDeps
+		want  []string
+	}{
+		{
+			name:  "no dependencies keeps bd order",
+			queue: []string{"a", "b", "c"},
+			want:  []string{"a", "b", "c"},
+		},
+		{
+			name:  "blockers first",
+			queue: []string{"a", "b", "c"},
+			deps: map[string]beadDeps{
+				"a": {blockers: []string{"c"}},
+				"c": {dependents: []string{"a"}},
+			},
+			want: []string{"c", "a", "b"},
+		},
+		{
+			name:  "beads unblocking more work lead",
+			queue: []string{"a", "b", "c"},
+			deps: map[string]beadDeps{
+				"b": {dependents: []string{"x"}},
+				"c": {dependents: []string{"x", "y"}},
+			},
+			want: []string{"c", "b", "a"},
+		},
+		{
+			name:  "dependents outside the queue don't block",
+			queue: []string{"a", "b"},
+			deps: map[string]beadDeps{
+				"a": {blockers: []string{"closed-1"}},
+			},
+			want: []string{"a", "b"},
+		},
+		{
+			name:  "cycle falls back to bd order",
+			queue: []string{"a", "b", "c"},
+			deps: map[string]beadDeps{
+				"a": {blockers: []string{"b"}, dependents: []string{"b"}},
+				"b": {blockers: []string{"a"}, dependents: []string{"a"}},
+			},
+			want: []string{"c", "a", "b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := beadIDs(orderByDependencies(beadsWithIDs(tt.queue...), tt.deps, false))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("orderByDependencies() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewlyReady(t *testing.T) {
+	got := newlyReady(beadsWithIDs("b", "d", "c"), beadsWithIDs("a", "b", "c"))
+	if want := []string{"d"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("newlyReady() = %v, want %v", got, want)
+	}
+}
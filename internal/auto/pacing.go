@@ -0,0 +1,209 @@
+package auto
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/project"
+)
+
+// errStopped is returned by pacing waits when a stop was requested.
+var errStopped = fmt.Errorf("stop requested")
+
+// BudgetInfo tracks how many beads an auto run has started today for a project
+type BudgetInfo struct {
+	Date  string `json:"date"` // YYYY-MM-DD, local time
+	Count int    `json:"count"`
+}
+
+// pacing holds the effective pacing settings for a run
+type pacing struct {
+	cooldown    time.Duration
+	dailyBudget int
+	quietStart  int // minutes after midnight, -1 if unset
+	quietEnd    int
+}
+
+// pacingFor resolves pacing from project config, with --cooldown taking precedence.
+func (r *Runner) pacingFor(proj *project.Project) (*pacing, error) {
+	p := &pacing{quietStart: -1, quietEnd: -1}
+
+	if proj != nil && proj.Auto != nil {
+		if proj.Auto.Cooldown != "" {
+			d, err := time.ParseDuration(proj.Auto.Cooldown)
+			if err != nil {
+				return nil, fmt.Errorf("invalid auto.cooldown %q: %w", proj.Auto.Cooldown, err)
+			}
+			p.cooldown = d
+		}
+		p.dailyBudget = proj.Auto.DailyBudget
+		if proj.Auto.QuietHours != "" {
+			start, end, err := parseQuietHours(proj.Auto.QuietHours)
+			if err != nil {
+				return nil, err
+			}
+			p.quietStart, p.quietEnd = start, end
+		}
+	}
+
+	if r.opts.Cooldown > 0 {
+		p.cooldown = r.opts.Cooldown
+	}
+
+	return p, nil
+}
+
+// pace blocks until the next bead is allowed to start. It waits out the
+// cooldown since the previous bead and any quiet hours, and returns an error
+// if the daily budget is spent or a stop was requested while waiting.
+func (r *Runner) pace(proj *project.Project) error {
+	if r.opts.DryRun {
+		return nil
+	}
+
+	p, err := r.pacingFor(proj)
+	if err != nil {
+		return err
+	}
+
+	if p.dailyBudget > 0 {
+		budget := r.loadBudget(time.Now())
+		if budget.Count >= p.dailyBudget {
+			return fmt.Errorf("daily budget of %d bead(s) reached", p.dailyBudget)
+		}
+	}
+
+	if p.cooldown > 0 && !r.lastBeadEnd.IsZero() {
+		if remaining := p.cooldown - time.Since(r.lastBeadEnd); remaining > 0 {
+			logging.Infof("Cooling down for %v before next bead...", remaining.Round(time.Second))
+			r.logger.Log("Cooldown: waiting %v", remaining)
+			if !r.wait(remaining) {
+				return errStopped
+			}
+		}
+	}
+
+	if p.quietStart >= 0 {
+		now := time.Now()
+		if inQuietHours(now, p.quietStart, p.quietEnd) {
+			remaining := untilQuietEnd(now, p.quietEnd)
+			logging.Infof("Quiet hours in effect, waiting %v before next bead...", remaining.Round(time.Minute))
+			r.logger.Log("Quiet hours: waiting %v", remaining)
+			if !r.wait(remaining) {
+				return errStopped
+			}
+		}
+	}
+
+	if p.dailyBudget > 0 {
+		r.recordBeadStart(time.Now())
+	}
+	return nil
+}
+
+// wait sleeps for d, returning false early if a stop is requested.
+func (r *Runner) wait(d time.Duration) bool {
+	deadline := time.After(d)
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-deadline:
+			return true
+		case <-r.stopSignal:
+			return false
+		case <-ticker.C:
+			if _, err := os.Stat(r.stopFile); err == nil {
+				os.Remove(r.stopFile)
+				return false
+			}
+		}
+	}
+}
+
+// budgetFile returns the path of the per-project daily budget counter
+func (r *Runner) budgetFile() string {
+	return filepath.Join(r.cfg.ConfigDir(), fmt.Sprintf("auto-budget-%s.json", r.opts.Project))
+}
+
+// loadBudget returns today's bead count, resetting it on a new day
+func (r *Runner) loadBudget(now time.Time) *BudgetInfo {
+	today := now.Format("2006-01-02")
+	budget := &BudgetInfo{Date: today}
+
+	data, err := os.ReadFile(r.budgetFile())
+	if err != nil {
+		return budget
+	}
+	var stored BudgetInfo
+	if err := json.Unmarshal(data, &stored); err != nil || stored.Date != today {
+		return budget
+	}
+	return &stored
+}
+
+// recordBeadStart increments today's bead count
+func (r *Runner) recordBeadStart(now time.Time) {
+	budget := r.loadBudget(now)
+	budget.Count++
+
+	data, err := json.MarshalIndent(budget, "", "  ")
+	if err != nil {
+		return
+	}
+	if err := os.WriteFile(r.budgetFile(), data, 0644); err != nil && r.logger != nil {
+		r.logger.Log("Warning: could not save daily budget: %v", err)
+	}
+}
+
+// parseQuietHours parses a "HH:MM-HH:MM" window into minutes after midnight.
+// The window may wrap past midnight (e.g. "22:00-07:00").
+func parseQuietHours(s string) (start, end int, err error) {
+	from, to, ok := strings.Cut(s, "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid quiet_hours %q: expected HH:MM-HH:MM", s)
+	}
+	if start, err = parseClock(from); err != nil {
+		return 0, 0, fmt.Errorf("invalid quiet_hours %q: %w", s, err)
+	}
+	if end, err = parseClock(to); err != nil {
+		return 0, 0, fmt.Errorf("invalid quiet_hours %q: %w", s, err)
+	}
+	if start == end {
+		return 0, 0, fmt.Errorf("invalid quiet_hours %q: start and end are the same", s)
+	}
+	return start, end, nil
+}
+
+// parseClock parses "HH:MM" into minutes after midnight
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("bad time %q", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// inQuietHours reports whether now falls inside the [start, end) window
+func inQuietHours(now time.Time, start, end int) bool {
+	m := now.Hour()*60 + now.Minute()
+	if start < end {
+		return m >= start && m < end
+	}
+	return m >= start || m < end
+}
+
+// untilQuietEnd returns how long until the quiet window ends
+func untilQuietEnd(now time.Time, end int) time.Duration {
+	endTime := time.Date(now.Year(), now.Month(), now.Day(), end/60, end%60, 0, 0, now.Location())
+	if !endTime.After(now) {
+		endTime = endTime.Add(24 * time.Hour)
+	}
+	return endTime.Sub(now)
+}
@@ -0,0 +1,138 @@
+package auto
+
+import (
+	"testing"
+	"time"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/project"
+)
+
+func TestParseQuietHours(t *testing.T) {
+	tests := []struct {
+		in        string
+		start     int
+		end       int
+		expectErr bool
+	}{
+		{"22:00-07:00", 22 * 60, 7 * 60, false},
+		{"12:30-13:45", 12*60 + 30, 13*60 + 45, false},
+		{" 9:00 - 17:00 ", 9 * 60, 17 * 60, false},
+		{"22:00", 0, 0, true},
+		{"25:00-07:00", 0, 0, true},
+		{"08:00-08:00", 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			start, end, err := parseQuietHours(tt.in)
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("parseQuietHours(%q) expected error", tt.in)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseQuietHours(%q) unexpected error: %v", tt.in, err)
+			}
+			if start != tt.start || end != tt.end {
+				t.Errorf("parseQuietHours(%q) = %d, %d, want %d, %d", tt.in, start, end, tt.start, tt.end)
+			}
+		})
+	}
+}
+
+func TestInQuietHours(t *testing.T) {
+	at := func(h, m int) time.Time {
+		return time.Date(2026, 1, 20, h, m, 0, 0, time.Local)
+	}
+
+	tests := []struct {
+		name  string
+		now   time.Time
+		start int
+		end   int
+		want  bool
+	}{
+		{"same day inside", at(12, 45), 12 * 60, 13 * 60, true},
+		{"same day at end", at(13, 0), 12 * 60, 13 * 60, false},
+		{"same day before", at(11, 59), 12 * 60, 13 * 60, false},
+		{"overnight late", at(23, 0), 22 * 60, 7 * 60, true},
+		{"overnight early", at(6, 59), 22 * 60, 7 * 60, true},
+		{"overnight daytime", at(12, 0), 22 * 60, 7 * 60, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := inQuietHours(tt.now, tt.start, tt.end); got != tt.want {
+				t.Errorf("inQuietHours(%v) = %v, want %v", tt.now.Format("15:04"), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUntilQuietEnd(t *testing.T) {
+	late := time.Date(2026, 1, 20, 23, 0, 0, 0, time.Local)
+	if got := untilQuietEnd(late, 7*60); got != 8*time.Hour {
+		t.Errorf("untilQuietEnd(23:00, 07:00) = %v, want 8h", got)
+	}
+
+	early := time.Date(2026, 1, 20, 6, 30, 0, 0, time.Local)
+	if got := untilQuietEnd(early, 7*60); got != 30*time.Minute {
+		t.Errorf("untilQuietEnd(06:30, 07:00) = %v, want 30m", got)
+	}
+}
+
+func TestPacingFor(t *testing.T) {
+	proj := &project.Project{
+		Name: "myapp",
+		Auto: &project.Auto{Cooldown: "5m", DailyBudget: 10, QuietHours: "22:00-07:00"},
+	}
+
+	r := &Runner{opts: &Options{}}
+	p, err := r.pacingFor(proj)
+	if err != nil {
+		t.Fatalf("pacingFor() unexpected error: %v", err)
+	}
+	if p.cooldown != 5*time.Minute || p.dailyBudget != 10 || p.quietStart != 22*60 || p.quietEnd != 7*60 {
+		t.Errorf("pacingFor() = %+v, want project settings", p)
+	}
+
+	// --cooldown overrides the project setting
+	r.opts.Cooldown = 30 * time.Second
+	if p, _ := r.pacingFor(proj); p.cooldown != 30*time.Second {
+		t.Errorf("pacingFor() cooldown = %v, want 30s", p.cooldown)
+	}
+
+	// No auto section means no pacing
+	r.opts.Cooldown = 0
+	p, _ = r.pacingFor(&project.Project{Name: "plain"})
+	if p.cooldown != 0 || p.dailyBudget != 0 || p.quietStart != -1 {
+		t.Errorf("pacingFor() without config = %+v, want no pacing", p)
+	}
+
+	if _, err := r.pacingFor(&project.Project{Auto: &project.Auto{Cooldown: "soon"}}); err == nil {
+		t.Error("pacingFor() expected error for invalid cooldown")
+	}
+}
+
+func TestDailyBudget(t *testing.T) {
+	cfg, err := config.LoadFromDir(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadFromDir() unexpected error: %v", err)
+	}
+	r := &Runner{cfg: cfg, opts: &Options{Project: "myapp"}}
+
+	day1 := time.Date(2026, 1, 20, 9, 0, 0, 0, time.Local)
+	r.recordBeadStart(day1)
+	r.recordBeadStart(day1)
+	if got := r.loadBudget(day1).Count; got != 2 {
+		t.Errorf("loadBudget() count = %d, want 2", got)
+	}
+
+	// Count resets on a new day
+	day2 := day1.Add(24 * time.Hour)
+	if got := r.loadBudget(day2).Count; got != 0 {
+		t.Errorf("loadBudget() next day count = %d, want 0", got)
+	}
+}
@@ -0,0 +1,242 @@
+package auto
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/badri/wt/internal/bead"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/project"
+	"github.com/badri/wt/internal/tmux"
+	"github.com/badri/wt/internal/usage"
+)
+
+// With --workers N a project-mode run keeps up to N beads going at once,
+// each in its own wt session. The runner creates the sessions one at a
+// time, so 'wt new' hands out names and port offsets without racing, and
+// collects completions from the exit marker each Claude run writes.
+// Readiness is checked again whenever a bead finishes, so a bead never
+// starts before the beads blocking it are done. The cost budget counts the
+// running beads too, each at its cost so far or at the average cost of
+// the beads finished, whichever is more.
+
+// poolSlot is a bead running in the worker pool
+type poolSlot struct {
+	bead     bead.ReadyBead
+	session  string
+	exitPath string
+	worktree string // the session's worktree, for the bead's cost so far
+	start    time.Time
+	deadline time.Time
+}
+
+// status returns what ended the slot's Claude run, "" while it runs
+func (s *poolSlot) status(now time.Time, sessionExists func(string) bool) string {
+	if _, ok := readExitCode(s.exitPath); ok {
+		return eventExited
+	}
+	if now.After(s.deadline) {
+		return outcomeTimeout
+	}
+	if !sessionExists(s.session) {
+		return outcomeSessionLost
+	}
+	return ""
+}
+
+// processProjectPool works a project's queue with up to --workers beads in
+// flight. Once the limit, a budget or pacing stops new beads, the running
+// ones are still waited for; a stop request leaves them running.
+func (r *Runner) processProjectPool(proj *project.Project, queue []bead.ReadyBead, attempted map[string]bool, deps map[string]beadDeps) error {
+	workers := r.opts.Workers
+	fmt.Printf("Running up to %d beads at once.\n", workers)
+	r.logger.Log("Worker pool: %d workers", workers)
+
+	var running []*poolSlot
+	starting := true
+	for {
+		for starting && len(running) < workers && len(queue) > 0 {
+			if r.shouldStop() {
+				return r.stopPool(running)
+			}
+			if r.opts.Limit > 0 && len(attempted) >= r.opts.Limit {
+				starting = false
+				break
+			}
+			if err := r.canStartPoolBead(proj, running); err != nil {
+				fmt.Printf("Stopping: %v\n", err)
+				starting = false
+				break
+			}
+			b, err := r.nextBead(queue)
+			if err != nil {
+				r.logger.Log("Points: %v, stopping bead processing", err)
+				fmt.Printf("Stopping: %v\n", err)
+				starting = false
+				break
+			}
+			attempted[b.ID] = true
+			queue = withoutBead(queue, b.ID)
+			slot, err := r.startPoolBead(proj, &b, len(running)+1)
+			if err != nil {
+				r.logger.Log("Error processing bead %s: %v", b.ID, err)
+				fmt.Printf("Error processing bead %s: %v\n", b.ID, err)
+				continue
+			}
+			running = append(running, slot)
+		}
+		if len(running) == 0 {
+			return nil
+		}
+
+		time.Sleep(watchInterval)
+		if r.shouldStop() {
+			return r.stopPool(running)
+		}
+
+		finished := false
+		still := running[:0]
+		for _, slot := range running {
+			if event := slot.status(time.Now(), sessionExists); event != "" {
+				r.finishPoolBead(slot, event)
+				finished = true
+				continue
+			}
+			still = append(still, slot)
+		}
+		running = still
+		if !finished || !starting {
+			continue
+		}
+
+		next, err := r.readyQueue(proj, attempted, deps)
+		if err != nil {
+			r.logger.Log("Warning: %v, keeping the current queue", err)
+			continue
+		}
+		if unblocked := newlyReady(next, queue); len(unblocked) > 0 {
+			fmt.Printf("Newly ready: %s\n", strings.Join(unblocked, ", "))
+			r.logger.Log("Newly ready: %s", strings.Join(unblocked, ", "))
+		}
+		queue = next
+	}
+}
+
+// stopPool ends a stopped run, leaving the running sessions to finish on
+// their own
+func (r *Runner) stopPool(running []*poolSlot) error {
+	r.logger.Log("Stop signal received, stopping bead processing")
+	fmt.Printf("Stop signal received, leaving %d running session(s)\n", len(running))
+	for _, slot := range running {
+		r.finishPoolBead(slot, outcomeStopped)
+	}
+	return nil
+}
+
+// canStartPoolBead applies the cost budget and pacing before another bead
+// starts
+func (r *Runner) canStartPoolBead(proj *project.Project, running []*poolSlot) error {
+	if err := r.checkBudget(r.committedCost(running), r.costLimit(proj), "project "+proj.Name); err != nil {
+		return err
+	}
+	if err := r.pace(proj); err != nil {
+		r.logger.Log("Pacing: %v, starting no more beads", err)
+		return err
+	}
+	return nil
+}
+
+// committedCost is the run's spend plus what the running beads are held
+// to: each its cost so far or the average finished bead, whichever is more
+func (r *Runner) committedCost(running []*poolSlot) float64 {
+	reserve := 0.0
+	if r.costed > 0 {
+		reserve = r.spent / float64(r.costed)
+	}
+	committed := r.spent
+	for _, slot := range running {
+		c := reserve
+		if slot.worktree != "" {
+			if u, err := usage.Since(slot.worktree, slot.start); err == nil && u.Cost > c {
+				c = u.Cost
+			}
+		}
+		committed += c
+	}
+	return committed
+}
+
+// startPoolBead creates the bead's session and starts Claude in it without
+// waiting for it
+func (r *Runner) startPoolBead(proj *project.Project, b *bead.ReadyBead, running int) (*poolSlot, error) {
+	r.logger.LogBeadStart(b.ID, b.Title)
+	start := time.Now()
+
+	fmt.Printf("\n=== Starting bead: %s (%d/%d running) ===\n", b.ID, running, r.opts.Workers)
+	fmt.Printf("Title: %s\n", b.Title)
+
+	autoCfg := r.getAutoConfig(proj)
+	timeout := time.Duration(autoCfg.TimeoutMinutes) * time.Minute
+	if r.opts.Timeout > 0 {
+		timeout = time.Duration(r.opts.Timeout) * time.Minute
+	}
+
+	sessionName, err := r.createSession(b.ID)
+	if err != nil {
+		err = fmt.Errorf("creating session: %w", err)
+		r.recordBead(BeadRun{ID: b.ID, Title: b.Title, Estimate: b.EstimatedMinutes, Outcome: "failed-create"}, start, "", err)
+		return nil, err
+	}
+	fmt.Printf("Created session: %s\n", sessionName)
+
+	prompt := r.buildPrompt(autoCfg.PromptTemplate, b, sessionName, proj)
+	exitPath, outcome, err := r.startClaude(sessionName, autoCfg.Command, prompt)
+	if err != nil {
+		err = fmt.Errorf("running claude: %w", err)
+		r.recordBead(BeadRun{ID: b.ID, Title: b.Title, Estimate: b.EstimatedMinutes, Session: sessionName, Outcome: outcome}, start, "", err)
+		return nil, err
+	}
+	fmt.Printf("Started claude in session %s (timeout: %v)\n", sessionName, timeout)
+
+	return &poolSlot{bead: *b, session: sessionName, exitPath: exitPath, worktree: r.sessionWorktree(sessionName), start: start, deadline: start.Add(timeout)}, nil
+}
+
+// finishPoolBead records a bead whose run ended with event. A timed out
+// bead's session is killed, so its Claude run stops spending.
+func (r *Runner) finishPoolBead(slot *poolSlot, event string) {
+	r.lastBeadEnd = time.Now()
+	b := slot.bead
+	outcome := event
+	switch event {
+	case eventExited:
+		os.Remove(slot.exitPath)
+		outcome = "success"
+		fmt.Printf("Session %s completed (%s)\n", slot.session, b.ID)
+	case outcomeTimeout:
+		fmt.Printf("Session %s timed out after %v (%s)\n", slot.session, slot.deadline.Sub(slot.start), b.ID)
+	case outcomeSessionLost:
+		fmt.Printf("Session %s is gone (%s)\n", slot.session, b.ID)
+	}
+
+	r.trackSessionCost(b.ID, slot.session, slot.start)
+	worktree := ""
+	if outcome == "success" {
+		worktree = r.sessionWorktree(slot.session)
+	}
+	r.recordBead(BeadRun{ID: b.ID, Title: b.Title, Estimate: b.EstimatedMinutes, Session: slot.session, Outcome: outcome}, slot.start, worktree, nil)
+
+	// After recordBead, which captures the pane of a failed bead
+	if event == outcomeTimeout {
+		if err := tmux.Kill(slot.session); err != nil {
+			r.logger.Log("Warning: could not kill timed out session %s: %v", slot.session, err)
+		} else {
+			r.logger.Log("Killed timed out session %s", slot.session)
+		}
+	}
+}
+
+func sessionExists(name string) bool {
+	return logging.Command("tmux", "has-session", "-t", name).Run() == nil
+}
@@ -0,0 +1,125 @@
+package auto
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/badri/wt/internal/bead"
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/monitor"
+	"github.com/badri/wt/internal/project"
+)
+
+func TestPoolSlotStatus(t *testing.T) {
+	start := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	slot := &poolSlot{
+		session:  "app-toast",
+		exitPath: filepath.Join(t.TempDir(), "run.exit"),
+		start:    start,
+		deadline: start.Add(30 * time.Minute),
+	}
+	alive := func(string) bool { return true }
+	gone := func(string) bool { return false }
+
+	if got := slot.status(start.Add(time.Minute), alive); got != "" {
+		t.Errorf("status() while running = %q, want none", got)
+	}
+	if got := slot.status(start.Add(time.Minute), gone); got != outcomeSessionLost {
+		t.Errorf("status() without a session = %q, want %q", got, outcomeSessionLost)
+	}
+	if got := slot.status(start.Add(31*time.Minute), alive); got != outcomeTimeout {
+		t.Errorf("status() past the deadline = %q, want %q", got, outcomeTimeout)
+	}
+
+	// An exit counts even after the deadline or with the session gone
+	if err := os.WriteFile(slot.exitPath, []byte("0\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := slot.status(start.Add(31*time.Minute), gone); got != eventExited {
+		t.Errorf("status() after exit = %q, want %q", got, eventExited)
+	}
+}
+
+func TestCanStartPoolBeadBudget(t *testing.T) {
+	logger, err := NewLogger(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+	t.Setenv("HOME", t.TempDir())
+	proj := &project.Project{Name: "myapp", Auto: &project.Auto{Budget: 10}}
+
+	// Two finished beads averaged $3: each running bead holds $3
+	r := &Runner{opts: &Options{}, logger: logger, spent: 6, costed: 2}
+	one := []*poolSlot{{session: "app-a"}}
+	if err := r.canStartPoolBead(proj, one); err != nil {
+		t.Errorf("canStartPoolBead() at $9 of $10: %v", err)
+	}
+	two := []*poolSlot{{session: "app-a"}, {session: "app-b"}}
+	if err := r.canStartPoolBead(proj, two); err == nil {
+		t.Error("canStartPoolBead() at $12 of $10 should stop")
+	}
+
+	// A running bead that spent more than the average holds what it spent
+	worktree := filepath.Join(t.TempDir(), "app-a")
+	start := time.Now().Add(-time.Minute)
+	dir := monitor.ClaudeProjectDir(worktree)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	line := `{"type":"assistant","timestamp":"` + time.Now().UTC().Format(time.RFC3339) + `","message":{"id":"msg_1","model":"claude-sonnet-4-5","usage":{"input_tokens":1500000,"output_tokens":0}}}`
+	if err := os.WriteFile(filepath.Join(dir, "s.jsonl"), []byte(line+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	expensive := []*poolSlot{{session: "app-a", worktree: worktree, start: start}}
+	if got := r.committedCost(expensive); got < 10.49 || got > 10.51 {
+		t.Errorf("committedCost() = %v, want 6 spent + 4.5 in flight", got)
+	}
+	if err := r.canStartPoolBead(proj, expensive); err == nil {
+		t.Error("canStartPoolBead() should count what a running bead spent")
+	}
+}
+
+func TestFinishPoolBeadTimeout(t *testing.T) {
+	// A fake tmux that logs its arguments
+	bin := t.TempDir()
+	calls := filepath.Join(bin, "calls")
+	script := "#!/bin/sh\necho \"$*\" >> " + calls + "\n"
+	if err := os.WriteFile(filepath.Join(bin, "tmux"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	cfg, err := config.LoadFromDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger, err := NewLogger(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+	r := &Runner{cfg: cfg, opts: &Options{}, logger: logger}
+
+	start := time.Now().Add(-31 * time.Minute)
+	slot := &poolSlot{
+		bead:     bead.ReadyBead{ID: "app-1"},
+		session:  "app-toast",
+		exitPath: filepath.Join(t.TempDir(), "run.exit"),
+		start:    start,
+		deadline: start.Add(30 * time.Minute),
+	}
+	r.finishPoolBead(slot, outcomeSessionLost)
+	if data, _ := os.ReadFile(calls); strings.Contains(string(data), "kill-session") {
+		t.Errorf("a lost session was killed: %s", data)
+	}
+
+	r.finishPoolBead(slot, outcomeTimeout)
+	data, _ := os.ReadFile(calls)
+	if !strings.Contains(string(data), "kill-session -t app-toast") {
+		t.Errorf("timed out session not killed, tmux calls:\n%s", data)
+	}
+}
@@ -0,0 +1,265 @@
+package auto
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/logging"
+)
+
+// EpicQueue holds the epics a project's auto run processes after the
+// current one, back to back
+type EpicQueue struct {
+	Epics []string `json:"epics"`          // waiting, in run order
+	Done  []string `json:"done,omitempty"` // finished by this run, in order
+}
+
+// Add appends an epic unless it is already queued
+func (q *EpicQueue) Add(epicID string) bool {
+	if slices.Contains(q.Epics, epicID) {
+		return false
+	}
+	q.Epics = append(q.Epics, epicID)
+	return true
+}
+
+// Remove drops an epic from the queue
+func (q *EpicQueue) Remove(epicID string) bool {
+	i := slices.Index(q.Epics, epicID)
+	if i < 0 {
+		return false
+	}
+	q.Epics = slices.Delete(q.Epics, i, i+1)
+	return true
+}
+
+// pop takes the next epic off the queue, "" when it is empty
+func (q *EpicQueue) pop() string {
+	if len(q.Epics) == 0 {
+		return ""
+	}
+	next := q.Epics[0]
+	q.Epics = q.Epics[1:]
+	return next
+}
+
+func epicQueueFile(cfg *config.Config, project string) string {
+	if project == "" {
+		return filepath.Join(cfg.ConfigDir(), "auto-queue.json")
+	}
+	return filepath.Join(cfg.ConfigDir(), fmt.Sprintf("auto-queue-%s.json", project))
+}
+
+// LoadEpicQueue loads a project's epic queue; a missing queue is empty
+func LoadEpicQueue(cfg *config.Config, project string) (*EpicQueue, error) {
+	q := &EpicQueue{}
+	data, err := os.ReadFile(epicQueueFile(cfg, project))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return q, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, q); err != nil {
+		return nil, fmt.Errorf("parsing epic queue: %w", err)
+	}
+	return q, nil
+}
+
+// SaveEpicQueue saves a project's epic queue, removing the file once there
+// is nothing left to show
+func SaveEpicQueue(cfg *config.Config, project string, q *EpicQueue) error {
+	path := epicQueueFile(cfg, project)
+	if len(q.Epics) == 0 && len(q.Done) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	data, err := json.MarshalIndent(q, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// EpicQueueProjects returns the projects that have an epic queue. The
+// legacy global queue is reported as "".
+func EpicQueueProjects(cfg *config.Config) ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(cfg.ConfigDir(), "auto-queue-*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var projects []string
+	if _, err := os.Stat(epicQueueFile(cfg, "")); err == nil {
+		projects = append(projects, "")
+	}
+	for _, m := range matches {
+		projects = append(projects, strings.TrimSuffix(strings.TrimPrefix(filepath.Base(m), "auto-queue-"), ".json"))
+	}
+	return projects, nil
+}
+
+// ResolveEpicProject returns the registered project that owns an epic
+func ResolveEpicProject(cfg *config.Config, epicID string) (string, error) {
+	return NewRunner(cfg, &Options{}).resolveProjectForEpic(epicID)
+}
+
+// epicRecordFile is where an epic's own state is kept. The project's state
+// file only holds the epic that is running; these outlive it.
+func epicRecordFile(cfg *config.Config, epicID string) string {
+	return filepath.Join(cfg.ConfigDir(), "auto-epics", epicID+".json")
+}
+
+// saveEpicRecord writes the per-epic copy of an epic's state
+func (r *Runner) saveEpicRecord(state *EpicState) error {
+	path := epicRecordFile(r.cfg, state.EpicID)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// LoadEpicRecord loads the last saved state of an epic
+func LoadEpicRecord(cfg *config.Config, epicID string) (*EpicState, error) {
+	data, err := os.ReadFile(epicRecordFile(cfg, epicID))
+	if err != nil {
+		return nil, err
+	}
+	var state EpicState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// queueEpics adds the extra --epic arguments to the project's queue. All
+// queued epics must belong to the project of the first one.
+func (r *Runner) queueEpics() error {
+	q, err := LoadEpicQueue(r.cfg, r.opts.Project)
+	if err != nil {
+		return err
+	}
+	if !r.opts.Resume {
+		q.Done = nil // a new run starts a new history
+	}
+	for _, epicID := range r.opts.Epics {
+		if epicID == r.opts.Epic {
+			continue
+		}
+		projName, err := r.resolveProjectForEpic(epicID)
+		if err != nil {
+			return err
+		}
+		if projName != r.opts.Project {
+			return fmt.Errorf("epic %s belongs to project %s, not %s: queue epics of one project per run", epicID, projName, r.opts.Project)
+		}
+		q.Add(epicID)
+	}
+	return SaveEpicQueue(r.cfg, r.opts.Project, q)
+}
+
+// runQueue runs the current epic with run, then the queued epics one after
+// another. The queue stops at an epic that doesn't finish (paused, failed,
+// or with failed or skipped beads); 'wt auto --resume' picks it up again.
+func (r *Runner) runQueue(run func() error) error {
+	if r.opts.DryRun {
+		return r.dryRunQueue()
+	}
+	if err := r.queueEpics(); err != nil {
+		return err
+	}
+
+	for {
+		if err := run(); err != nil {
+			r.logger.Log("Error processing epic %s: %v", r.opts.Epic, err)
+			return err
+		}
+
+		q, err := LoadEpicQueue(r.cfg, r.opts.Project)
+		if err != nil {
+			return fmt.Errorf("loading epic queue: %w", err)
+		}
+		if _, err := r.loadEpicState(); err == nil {
+			// The epic is still open; the rest of the queue waits for it
+			if len(q.Epics) > 0 {
+				fmt.Printf("\n%d epic(s) still queued (%s); they run once %s finishes.\n",
+					len(q.Epics), strings.Join(q.Epics, ", "), r.opts.Epic)
+			}
+			return nil
+		}
+
+		q.Done = append(q.Done, r.opts.Epic)
+		next := ""
+		if !r.shouldStop() {
+			next = q.pop()
+		}
+		if err := SaveEpicQueue(r.cfg, r.opts.Project, q); err != nil {
+			logging.Warnf("could not save epic queue: %v", err)
+		}
+		if next == "" {
+			if len(q.Epics) > 0 {
+				fmt.Printf("\nStopped with %d epic(s) still queued.\n", len(q.Epics))
+			}
+			return nil
+		}
+
+		r.logger.Log("Starting queued epic %s", next)
+		fmt.Printf("\n=== Next queued epic: %s (%d more queued) ===\n", next, len(q.Epics))
+		r.opts.Epic = next
+		if err := r.writeLock(); err != nil {
+			logging.Warnf("could not update lock: %v", err)
+		}
+		run = r.processEpic
+	}
+}
+
+// dryRunQueue previews every epic given on the command line
+func (r *Runner) dryRunQueue() error {
+	epics := r.opts.Epics
+	if len(epics) == 0 {
+		epics = []string{r.opts.Epic}
+	}
+	for i, epicID := range epics {
+		if i > 0 {
+			fmt.Println()
+		}
+		r.opts.Epic = epicID
+		if err := r.processEpic(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// printEpicQueue shows the epics a project's run finished and those still
+// waiting, below the current epic's status
+func (r *Runner) printEpicQueue(projectName string) {
+	q, err := LoadEpicQueue(r.cfg, projectName)
+	if err != nil || (len(q.Epics) == 0 && len(q.Done) == 0) {
+		return
+	}
+	fmt.Println("\nEpic queue:")
+	for _, epicID := range q.Done {
+		line := fmt.Sprintf("  ✓ %s", epicID)
+		if state, err := LoadEpicRecord(r.cfg, epicID); err == nil {
+			line += fmt.Sprintf(" [%s, %d/%d beads]", state.Status, len(state.CompletedBeads), len(state.Beads))
+			if state.PRURL != "" {
+				line += " " + state.PRURL
+			}
+		}
+		fmt.Println(line)
+	}
+	for i, epicID := range q.Epics {
+		fmt.Printf("  %d. %s [queued]\n", i+1, epicID)
+	}
+}
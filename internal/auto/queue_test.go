@@ -0,0 +1,84 @@
+package auto
+
+import (
+	"os"
+	"slices"
+	"testing"
+)
+
+func TestEpicQueueAddRemovePop(t *testing.T) {
+	q := &EpicQueue{}
+	if !q.Add("wt-a") || !q.Add("wt-b") {
+		t.Fatal("Add() of new epics should succeed")
+	}
+	if q.Add("wt-a") {
+		t.Error("Add() of a queued epic should be refused")
+	}
+	q.Add("wt-c")
+	if !q.Remove("wt-b") || q.Remove("wt-b") {
+		t.Error("Remove() should drop a queued epic exactly once")
+	}
+	if got := q.pop(); got != "wt-a" {
+		t.Errorf("pop() = %q, want wt-a", got)
+	}
+	if got := q.pop(); got != "wt-c" {
+		t.Errorf("pop() = %q, want wt-c", got)
+	}
+	if got := q.pop(); got != "" {
+		t.Errorf("pop() on an empty queue = %q", got)
+	}
+}
+
+func TestEpicQueuePersistence(t *testing.T) {
+	cfg := newEditTestConfig(t)
+
+	q := &EpicQueue{Epics: []string{"wt-b", "wt-c"}, Done: []string{"wt-a"}}
+	if err := SaveEpicQueue(cfg, "proj", q); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadEpicQueue(cfg, "proj")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(loaded.Epics, q.Epics) || !slices.Equal(loaded.Done, q.Done) {
+		t.Errorf("LoadEpicQueue() = %+v, want %+v", loaded, q)
+	}
+	if projects, _ := EpicQueueProjects(cfg); !slices.Equal(projects, []string{"proj"}) {
+		t.Errorf("EpicQueueProjects() = %v, want [proj]", projects)
+	}
+
+	// An empty queue removes the file
+	if err := SaveEpicQueue(cfg, "proj", &EpicQueue{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(epicQueueFile(cfg, "proj")); !os.IsNotExist(err) {
+		t.Error("empty queue should remove the queue file")
+	}
+	if loaded, err := LoadEpicQueue(cfg, "proj"); err != nil || len(loaded.Epics) != 0 {
+		t.Errorf("LoadEpicQueue() of a missing queue = %+v, %v", loaded, err)
+	}
+}
+
+func TestSaveEpicStateKeepsRecord(t *testing.T) {
+	cfg := newEditTestConfig(t)
+	r := NewRunner(cfg, &Options{Project: "proj"})
+
+	state := newEditTestState()
+	if err := r.saveEpicState(state); err != nil {
+		t.Fatal(err)
+	}
+	r.removeEpicState()
+
+	record, err := LoadEpicRecord(cfg, "wt-epic")
+	if err != nil {
+		t.Fatalf("LoadEpicRecord() error: %v", err)
+	}
+	if record.Status != "running" || !slices.Equal(record.Beads, state.Beads) {
+		t.Errorf("LoadEpicRecord() = %+v", record)
+	}
+
+	// Records must not look like project state files
+	if projects, _ := EpicStateProjects(cfg); len(projects) != 0 {
+		t.Errorf("EpicStateProjects() = %v, want none", projects)
+	}
+}
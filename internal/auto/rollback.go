@@ -0,0 +1,184 @@
+package auto
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/badri/wt/internal/bead"
+	"github.com/badri/wt/internal/logging"
+)
+
+// RunSnapshot records what an epic run starts from, so 'wt auto --abort
+// --rollback' can put the project back the way it was
+type RunSnapshot struct {
+	TakenAt       string            `json:"taken_at"`
+	Branch        string            `json:"branch,omitempty"`         // epic branch the run works on
+	BranchPoint   string            `json:"branch_point,omitempty"`   // commit the epic branch started at
+	BranchCreated bool              `json:"branch_created,omitempty"` // the run created the branch
+	BeadStatuses  map[string]string `json:"bead_statuses,omitempty"`  // bead ID -> status before the run, epic included
+}
+
+// snapshotRun records the statuses of the epic and its beads and which
+// branches exist, before the epic worktree is created
+func (r *Runner) snapshotRun(projectDir, epicID string, beads []bead.ReadyBead) (*RunSnapshot, map[string]bool) {
+	snap := &RunSnapshot{
+		TakenAt:      time.Now().Format(time.RFC3339),
+		BeadStatuses: make(map[string]string, len(beads)+1),
+	}
+	if status, err := beadStatus(projectDir, epicID); err == nil {
+		snap.BeadStatuses[epicID] = status
+	} else {
+		r.logger.Log("Warning: could not snapshot epic status: %v", err)
+	}
+	for _, b := range beads {
+		snap.BeadStatuses[b.ID] = b.Status
+	}
+	return snap, localBranches(projectDir)
+}
+
+// recordBranch completes the snapshot with the epic worktree's branch and
+// where it starts. existing are the branches from before the run.
+func (s *RunSnapshot) recordBranch(worktreePath string, existing map[string]bool) {
+	branch, err := gitOutput(worktreePath, "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return
+	}
+	point, err := gitOutput(worktreePath, "rev-parse", "HEAD")
+	if err != nil {
+		return
+	}
+	s.Branch = branch
+	s.BranchPoint = point
+	s.BranchCreated = !existing[branch]
+}
+
+// rollbackRun returns the project to its state before the run: the epic
+// branch is deleted, or reset if it existed before, and every bead whose
+// status the run changed gets its old status back, which reopens beads
+// closed along the way. Call it once the worktrees are gone.
+func (r *Runner) rollbackRun(state *EpicState) error {
+	snap := state.Snapshot
+	var failed []string
+
+	if snap.Branch != "" {
+		if err := rollbackBranch(state.ProjectDir, snap); err != nil {
+			failed = append(failed, err.Error())
+		}
+	}
+
+	current := make(map[string]string, len(snap.BeadStatuses))
+	for id := range snap.BeadStatuses {
+		status, err := beadStatus(state.ProjectDir, id)
+		if err != nil {
+			failed = append(failed, err.Error())
+			continue
+		}
+		current[id] = status
+	}
+	for _, s := range statusRestores(snap.BeadStatuses, current) {
+		if err := bead.UpdateStatusInDir(s.bead, s.to, state.ProjectDir); err != nil {
+			failed = append(failed, err.Error())
+			continue
+		}
+		verb := "Restored"
+		if s.from == "closed" {
+			verb = "Reopened"
+		}
+		fmt.Printf("  %s %s: %s -> %s\n", verb, s.bead, s.from, s.to)
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("rollback incomplete:\n  %s", strings.Join(failed, "\n  "))
+	}
+	return nil
+}
+
+// rollbackBranch deletes the epic branch the run created, on origin too if
+// it was pushed, or moves a branch that was already there back to where the
+// run started it
+func rollbackBranch(projectDir string, snap *RunSnapshot) error {
+	if !snap.BranchCreated {
+		if _, err := gitOutput(projectDir, "branch", "-f", snap.Branch, snap.BranchPoint); err != nil {
+			return fmt.Errorf("resetting branch %s: %w", snap.Branch, err)
+		}
+		fmt.Printf("  Reset branch %s to %s\n", snap.Branch, shortSHA(snap.BranchPoint))
+		return nil
+	}
+	if _, err := gitOutput(projectDir, "branch", "-D", snap.Branch); err != nil {
+		return fmt.Errorf("deleting branch %s: %w", snap.Branch, err)
+	}
+	fmt.Printf("  Deleted branch %s\n", snap.Branch)
+	if _, err := gitOutput(projectDir, "ls-remote", "--exit-code", "--heads", "origin", snap.Branch); err != nil {
+		return nil // never pushed, or no origin
+	}
+	if _, err := gitOutput(projectDir, "push", "origin", "--delete", snap.Branch); err != nil {
+		return fmt.Errorf("deleting origin/%s: %w", snap.Branch, err)
+	}
+	fmt.Printf("  Deleted origin/%s\n", snap.Branch)
+	return nil
+}
+
+// statusRestore is a bead status the run changed
+type statusRestore struct {
+	bead, from, to string
+}
+
+// statusRestores lists the beads whose status differs from before, by ID
+func statusRestores(before, now map[string]string) []statusRestore {
+	var restores []statusRestore
+	for id, was := range before {
+		if is, ok := now[id]; ok && is != was && was != "" {
+			restores = append(restores, statusRestore{bead: id, from: is, to: was})
+		}
+	}
+	sort.Slice(restores, func(i, j int) bool { return restores[i].bead < restores[j].bead })
+	return restores
+}
+
+// beadStatus returns a bead's current status
+func beadStatus(projectDir, beadID string) (string, error) {
+	output, err := bead.Output(projectDir, "show", beadID, "--json")
+	if err != nil {
+		return "", fmt.Errorf("reading %s: %w", beadID, err)
+	}
+	var infos []struct {
+		Status string `json:"status"`
+	}
+	if err := json.Unmarshal(output, &infos); err != nil || len(infos) == 0 {
+		return "", fmt.Errorf("reading %s: unexpected bd output", beadID)
+	}
+	return infos[0].Status, nil
+}
+
+// localBranches returns the names of a repo's local branches
+func localBranches(repoDir string) map[string]bool {
+	branches := make(map[string]bool)
+	output, err := gitOutput(repoDir, "for-each-ref", "--format=%(refname:short)", "refs/heads")
+	if err != nil {
+		return branches
+	}
+	for _, b := range strings.Split(output, "\n") {
+		if b != "" {
+			branches[b] = true
+		}
+	}
+	return branches
+}
+
+func gitOutput(dir string, args ...string) (string, error) {
+	output, err := logging.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", strings.TrimSpace(string(output)), err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
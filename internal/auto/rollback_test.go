@@ -0,0 +1,68 @@
+package auto
+
+import (
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestStatusRestores(t *testing.T) {
+	before := map[string]string{"wt-epic": "open", "wt-a": "open", "wt-b": "in_progress", "wt-c": "open", "wt-d": ""}
+	now := map[string]string{"wt-epic": "in_progress", "wt-a": "closed", "wt-b": "in_progress", "wt-d": "closed"}
+	want := []statusRestore{
+		{bead: "wt-a", from: "closed", to: "open"},
+		{bead: "wt-epic", from: "in_progress", to: "open"},
+	}
+	if got := statusRestores(before, now); !reflect.DeepEqual(got, want) {
+		t.Errorf("statusRestores() = %+v, want %+v", got, want)
+	}
+}
+
+func TestRollbackBranch(t *testing.T) {
+	repo := t.TempDir()
+	git := func(args ...string) string {
+		t.Helper()
+		out, err := gitOutput(repo, args...)
+		if err != nil {
+			t.Fatalf("git %v: %v", args, err)
+		}
+		return out
+	}
+	git("init", "-q", "-b", "main")
+	git("config", "user.email", "test@test.com")
+	git("config", "user.name", "Test")
+	git("commit", "-q", "--allow-empty", "-m", "base")
+	git("branch", "existing")
+	existing := localBranches(repo)
+
+	// The run creates one branch and moves the other
+	for _, branch := range []string{"wt-epic", "existing"} {
+		wt := filepath.Join(t.TempDir(), branch)
+		if branch == "existing" {
+			git("worktree", "add", "-q", wt, branch)
+		} else {
+			git("worktree", "add", "-q", "-b", branch, wt)
+		}
+		snap := &RunSnapshot{}
+		snap.recordBranch(wt, existing)
+		if snap.Branch != branch || snap.BranchCreated != (branch == "wt-epic") {
+			t.Fatalf("recordBranch() = %+v", snap)
+		}
+		if err := exec.Command("git", "-C", wt, "commit", "-q", "--allow-empty", "-m", "bead work").Run(); err != nil {
+			t.Fatal(err)
+		}
+		git("worktree", "remove", "--force", wt)
+
+		if err := rollbackBranch(repo, snap); err != nil {
+			t.Fatalf("rollbackBranch(%s): %v", branch, err)
+		}
+		if branch == "wt-epic" {
+			if localBranches(repo)["wt-epic"] {
+				t.Error("rollback kept the branch the run created")
+			}
+		} else if got := git("rev-parse", branch); got != snap.BranchPoint {
+			t.Errorf("rollback left %s at %s, want %s", branch, got, snap.BranchPoint)
+		}
+	}
+}
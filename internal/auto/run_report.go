@@ -0,0 +1,350 @@
+package auto
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/badri/wt/internal/hub"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/monitor"
+	"github.com/badri/wt/internal/msg"
+	"github.com/badri/wt/internal/project"
+	"github.com/badri/wt/internal/tmux"
+	"github.com/badri/wt/internal/usage"
+)
+
+// errorTailLines is how much of a failed bead's pane the report keeps
+const errorTailLines = 20
+
+// RunReport summarizes one wt auto run: what was attempted, how each bead
+// ended, and what the run produced. It is saved next to the run's log.
+type RunReport struct {
+	Mode      string     `json:"mode"` // "epic", "project" or "queue"
+	Project   string     `json:"project"`
+	Status    string     `json:"status"` // completed, partial, paused, stopped, failed
+	Error     string     `json:"error,omitempty"`
+	StartTime time.Time  `json:"start_time"`
+	EndTime   time.Time  `json:"end_time"`
+	Runtime   string     `json:"runtime"`
+	Cost      float64    `json:"cost,omitempty"` // estimated Claude cost of this run, USD
+	Epics     []EpicRun  `json:"epics,omitempty"`
+	Beads     []*BeadRun `json:"beads"`
+}
+
+// EpicRun is where an epic stood when the run ended
+type EpicRun struct {
+	ID     string `json:"id"`
+	Title  string `json:"title,omitempty"`
+	Status string `json:"status"`
+	PRURL  string `json:"pr_url,omitempty"`
+}
+
+// BeadRun is one bead the run attempted
+type BeadRun struct {
+	ID            string  `json:"id"`
+	Title         string  `json:"title,omitempty"`
+	Epic          string  `json:"epic,omitempty"`
+	Source        string  `json:"source,omitempty"` // queue mode: the item the bead came from
+	Session       string  `json:"session,omitempty"`
+	Outcome       string  `json:"outcome"`
+	Estimate      int     `json:"estimate_minutes,omitempty"` // the bead's bd estimate
+	Duration      string  `json:"duration"`
+	Seconds       int     `json:"duration_seconds"`
+	Cost          float64 `json:"cost,omitempty"`
+	Commit        string  `json:"commit,omitempty"`
+	CommitSummary string  `json:"commit_summary,omitempty"`
+	Error         string  `json:"error,omitempty"`
+	ErrorTail     string  `json:"error_tail,omitempty"` // last lines of the session's pane
+}
+
+// Failed reports whether the bead didn't complete
+func (b *BeadRun) Failed() bool {
+	return b.Outcome != "success"
+}
+
+// startReport begins collecting the run report
+func (r *Runner) startReport(mode string) {
+	r.report = &RunReport{Mode: mode, Project: r.opts.Project, StartTime: time.Now()}
+}
+
+// recordBead adds a finished bead to the run report and the log. The
+// commit is read from worktree on success; on failure the tail of the
+// session's pane is kept, since that is usually where the error is.
+func (r *Runner) recordBead(run BeadRun, start time.Time, worktree string, err error) {
+	d := time.Since(start)
+	r.logger.LogBeadEnd(run.ID, run.Outcome, d)
+	if r.report == nil {
+		return
+	}
+	run.Duration = d.Round(time.Second).String()
+	run.Seconds = int(d.Seconds())
+	run.Cost = r.lastCost
+	r.lastCost = 0
+	if err != nil {
+		run.Error = err.Error()
+	}
+	if !run.Failed() && worktree != "" {
+		run.Commit, run.CommitSummary, _ = getLatestCommit(worktree)
+	}
+	if run.Failed() && run.Session != "" {
+		if tail, perr := monitor.CaptureTail(run.Session, errorTailLines); perr == nil {
+			run.ErrorTail = tail
+		}
+	}
+	r.report.Beads = append(r.report.Beads, &run)
+}
+
+// finishReport completes the run report, saves it under logs/ as markdown
+// and JSON, and posts it where the project's auto config asks. Runs that
+// attempted nothing, and dry runs, leave no report.
+func (r *Runner) finishReport(runErr error) {
+	rep := r.report
+	if rep == nil || r.opts.DryRun || (len(rep.Beads) == 0 && runErr == nil) {
+		return
+	}
+	rep.EndTime = time.Now()
+	rep.Runtime = rep.EndTime.Sub(rep.StartTime).Round(time.Second).String()
+	for _, b := range rep.Beads {
+		rep.Cost += b.Cost
+	}
+	if runErr != nil {
+		rep.Error = runErr.Error()
+	}
+	rep.Epics = r.reportEpics()
+	rep.Status = rep.status()
+
+	base := strings.TrimSuffix(r.logger.file.Name(), ".log") + "-report"
+	if err := rep.Save(base); err != nil {
+		r.logger.Log("Warning: could not save run report: %v", err)
+		logging.Warnf("could not save run report: %v", err)
+		return
+	}
+	r.logger.Log("Run report: %s.md", base)
+	fmt.Printf("\nRun report: %s.md\n", base)
+
+	var auto *project.Auto
+	if proj, err := r.projMgr.Get(rep.Project); err == nil && proj.Auto != nil {
+		auto = proj.Auto
+	}
+	if auto == nil {
+		return
+	}
+	if auto.ReportWebhook != "" {
+		if err := rep.post(auto.ReportWebhook); err != nil {
+			r.logger.Log("Warning: could not post run report: %v", err)
+			logging.Warnf("could not post run report: %v", err)
+		}
+	}
+	if auto.ReportToHub {
+		r.sendReportToHub(base + ".md")
+	}
+}
+
+// reportEpics looks up the epics the run's beads belonged to
+func (r *Runner) reportEpics() []EpicRun {
+	var ids []string
+	seen := make(map[string]bool)
+	add := func(id string) {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	for _, b := range r.report.Beads {
+		add(b.Epic)
+	}
+	if r.report.Mode == "epic" {
+		add(r.opts.Epic)
+	}
+	var epics []EpicRun
+	for _, id := range ids {
+		e := EpicRun{ID: id, Status: "unknown"}
+		if state, err := LoadEpicRecord(r.cfg, id); err == nil {
+			e.Title, e.Status, e.PRURL = state.EpicTitle, state.Status, state.PRURL
+		}
+		epics = append(epics, e)
+	}
+	return epics
+}
+
+// status sums the run up: an error fails it, an unfinished epic or bead
+// makes it paused, stopped or partial
+func (rep *RunReport) status() string {
+	if rep.Error != "" {
+		return "failed"
+	}
+	for _, e := range rep.Epics {
+		if e.Status == "paused" || e.Status == "running" {
+			return "paused"
+		}
+	}
+	status := "completed"
+	for _, b := range rep.Beads {
+		switch {
+		case b.Outcome == "stopped":
+			return "stopped"
+		case b.Failed():
+			status = "partial"
+		}
+	}
+	return status
+}
+
+// Counts returns how many beads completed and how many did not
+func (rep *RunReport) Counts() (completed, failed int) {
+	for _, b := range rep.Beads {
+		if b.Failed() {
+			failed++
+		} else {
+			completed++
+		}
+	}
+	return completed, failed
+}
+
+// Summary is the report in one line
+func (rep *RunReport) Summary() string {
+	completed, failed := rep.Counts()
+	what := rep.Project
+	if len(rep.Epics) > 0 {
+		ids := make([]string, len(rep.Epics))
+		for i, e := range rep.Epics {
+			ids[i] = e.ID
+		}
+		what = strings.Join(ids, ", ")
+	}
+	s := fmt.Sprintf("wt auto run for %s %s: %d bead(s) completed, %d failed in %s", what, rep.Status, completed, failed, rep.Runtime)
+	if rep.Cost > 0 {
+		s += fmt.Sprintf(", ~%s", usage.FormatCost(rep.Cost))
+	}
+	return s
+}
+
+// Save writes the report to base.md and base.json
+func (rep *RunReport) Save(base string) error {
+	data, err := json.MarshalIndent(rep, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(base), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(base+".json", data, 0644); err != nil {
+		return err
+	}
+	return os.WriteFile(base+".md", []byte(rep.Markdown()), 0644)
+}
+
+// Markdown renders the report for people
+func (rep *RunReport) Markdown() string {
+	var sb strings.Builder
+	completed, failed := rep.Counts()
+	fmt.Fprintf(&sb, "# wt auto run report\n\n")
+	fmt.Fprintf(&sb, "- **Project:** %s (%s mode)\n", rep.Project, rep.Mode)
+	fmt.Fprintf(&sb, "- **Status:** %s\n", rep.Status)
+	fmt.Fprintf(&sb, "- **Started:** %s\n", rep.StartTime.Format(time.RFC3339))
+	fmt.Fprintf(&sb, "- **Runtime:** %s\n", rep.Runtime)
+	fmt.Fprintf(&sb, "- **Beads:** %d attempted, %d completed, %d failed\n", len(rep.Beads), completed, failed)
+	if rep.Cost > 0 {
+		fmt.Fprintf(&sb, "- **Estimated cost:** %s\n", usage.FormatCost(rep.Cost))
+	}
+	if rep.Error != "" {
+		fmt.Fprintf(&sb, "- **Error:** %s\n", rep.Error)
+	}
+
+	if len(rep.Epics) > 0 {
+		fmt.Fprintf(&sb, "\n## Epics\n\n")
+		for _, e := range rep.Epics {
+			line := fmt.Sprintf("- %s", e.ID)
+			if e.Title != "" {
+				line += ": " + e.Title
+			}
+			line += fmt.Sprintf(" (%s)", e.Status)
+			if e.PRURL != "" {
+				line += " " + e.PRURL
+			}
+			sb.WriteString(line + "\n")
+		}
+	}
+
+	if len(rep.Beads) > 0 {
+		fmt.Fprintf(&sb, "\n## Beads\n\n")
+		fmt.Fprintf(&sb, "| Bead | Title | Outcome | Duration | Commit |\n")
+		fmt.Fprintf(&sb, "|------|-------|---------|----------|--------|\n")
+		for _, b := range rep.Beads {
+			commit := ""
+			if b.Commit != "" {
+				commit = fmt.Sprintf("`%s` %s", b.Commit, b.CommitSummary)
+			}
+			title := b.Title
+			if b.Source != "" {
+				title += " (from " + b.Source + ")"
+			}
+			fmt.Fprintf(&sb, "| %s | %s | %s | %s | %s |\n", b.ID, tableCell(title), b.Outcome, b.Duration, tableCell(commit))
+		}
+	}
+
+	sb.WriteString(rep.estimateMarkdown())
+
+	if failed > 0 {
+		fmt.Fprintf(&sb, "\n## Failures\n")
+		for _, b := range rep.Beads {
+			if !b.Failed() {
+				continue
+			}
+			fmt.Fprintf(&sb, "\n### %s: %s\n\n", b.ID, b.Outcome)
+			if b.Error != "" {
+				fmt.Fprintf(&sb, "%s\n\n", b.Error)
+			}
+			if b.ErrorTail != "" {
+				fmt.Fprintf(&sb, "```\n%s\n```\n", b.ErrorTail)
+			}
+		}
+	}
+	return sb.String()
+}
+
+// tableCell keeps text from breaking a markdown table row
+func tableCell(s string) string {
+	return strings.ReplaceAll(s, "|", "\\|")
+}
+
+// post sends the JSON report to a webhook
+func (rep *RunReport) post(url string) error {
+	data, err := json.Marshal(rep)
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// sendReportToHub leaves the summary in the hub's messages and, when the
+// hub is running, tells its Claude where the report is
+func (r *Runner) sendReportToHub(path string) {
+	text := fmt.Sprintf("%s. Report: %s", r.report.Summary(), path)
+	if r.store != nil {
+		if _, err := r.store.Send(&msg.Message{Subject: msg.SubjectProgress, From: "orchestrator", To: hub.HubSessionName, Body: text}); err != nil {
+			r.logger.Log("Warning: could not message the hub: %v", err)
+		}
+	}
+	if tmux.SessionExists(hub.HubSessionName) {
+		if err := tmux.NudgeSession(hub.HubSessionName, "[wt] "+text); err != nil {
+			r.logger.Log("Warning: could not notify the hub session: %v", err)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package auto
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func sampleReport() *RunReport {
+	start := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	return &RunReport{
+		Mode:      "epic",
+		Project:   "myapp",
+		StartTime: start,
+		EndTime:   start.Add(42 * time.Minute),
+		Runtime:   "42m0s",
+		Epics:     []EpicRun{{ID: "app-epic", Title: "Auth", Status: "partial"}},
+		Beads: []*BeadRun{
+			{ID: "app-a", Title: "Add login | logout", Epic: "app-epic", Outcome: "success", Duration: "20m0s", Commit: "abc1234", CommitSummary: "Add login"},
+			{ID: "app-b", Title: "Add refresh", Epic: "app-epic", Outcome: "timeout", Duration: "22m0s", ErrorTail: "FAIL TestRefresh"},
+		},
+	}
+}
+
+func TestRunReportStatus(t *testing.T) {
+	rep := sampleReport()
+	if got := rep.status(); got != "partial" {
+		t.Errorf("status = %q, want partial", got)
+	}
+
+	rep.Epics[0].Status = "paused"
+	if got := rep.status(); got != "paused" {
+		t.Errorf("status = %q, want paused", got)
+	}
+
+	rep = sampleReport()
+	rep.Beads = rep.Beads[:1]
+	if got := rep.status(); got != "completed" {
+		t.Errorf("status = %q, want completed", got)
+	}
+
+	rep.Error = "audit failed"
+	if got := rep.status(); got != "failed" {
+		t.Errorf("status = %q, want failed", got)
+	}
+}
+
+func TestRunReportMarkdown(t *testing.T) {
+	rep := sampleReport()
+	rep.Status = rep.status()
+	md := rep.Markdown()
+	for _, want := range []string{
+		"**Status:** partial",
+		"2 attempted, 1 completed, 1 failed",
+		"- app-epic: Auth (partial)",
+		"| app-a | Add login \\| logout | success | 20m0s | `abc1234` Add login |",
+		"### app-b: timeout",
+		"FAIL TestRefresh",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+}
+
+func TestRunReportSave(t *testing.T) {
+	base := filepath.Join(t.TempDir(), "logs", "auto-2026-03-01-090000-report")
+	rep := sampleReport()
+	if err := rep.Save(base); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(base + ".json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got RunReport
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Beads) != 2 || got.Beads[1].ErrorTail != "FAIL TestRefresh" {
+		t.Errorf("round trip lost beads: %+v", got.Beads)
+	}
+	if _, err := os.Stat(base + ".md"); err != nil {
+		t.Errorf("markdown report not written: %v", err)
+	}
+}
+
+func TestRunReportPost(t *testing.T) {
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ = io.ReadAll(req.Body)
+	}))
+	defer srv.Close()
+
+	if err := sampleReport().post(srv.URL); err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	if !strings.Contains(string(body), `"project":"myapp"`) {
+		t.Errorf("unexpected webhook body: %s", body)
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+	if err := sampleReport().post(failing.URL); err == nil {
+		t.Error("expected an error for a failing webhook")
+	}
+}
@@ -0,0 +1,58 @@
+package auto
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+
+	"github.com/badri/wt/internal/config"
+)
+
+// RunningRun is a wt auto process that holds a lock, with the progress of
+// its epic when it runs one
+type RunningRun struct {
+	Project     string `json:"project,omitempty"`
+	Epic        string `json:"epic,omitempty"`
+	PID         int    `json:"pid"`
+	StartTime   string `json:"start_time"`
+	Status      string `json:"status,omitempty"` // epic status: running, paused, ...
+	CurrentBead string `json:"current_bead,omitempty"`
+	Completed   int    `json:"completed,omitempty"`
+	Total       int    `json:"total,omitempty"`
+}
+
+// RunningRuns returns the wt auto runs whose process is alive, by project.
+// Locks left behind by runs that died are skipped.
+func RunningRuns(cfg *config.Config) ([]RunningRun, error) {
+	r := &Runner{cfg: cfg}
+	locks, err := r.findAllAutoLocks()
+	if err != nil {
+		return nil, err
+	}
+	var runs []RunningRun
+	for _, path := range locks {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var lock LockInfo
+		if err := json.Unmarshal(data, &lock); err != nil || !processRunning(lock.PID) {
+			continue
+		}
+		run := RunningRun{Project: lock.Project, Epic: lock.Epic, PID: lock.PID, StartTime: lock.StartTime}
+		if run.Project == "" {
+			run.Project = projectNameFromLockFile(path)
+		}
+		state, err := LoadProjectEpicState(cfg, projectNameFromLockFile(path))
+		if err == nil && (state.EpicID == run.Epic || (run.Epic == "" && state.Status == "running")) {
+			run.Epic = state.EpicID
+			run.Status = state.Status
+			run.CurrentBead = state.CurrentBead
+			run.Completed = len(state.CompletedBeads)
+			run.Total = len(state.Beads)
+		}
+		runs = append(runs, run)
+	}
+	sort.Slice(runs, func(i, j int) bool { return runs[i].Project < runs[j].Project })
+	return runs, nil
+}
@@ -0,0 +1,39 @@
+package auto
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRunningRuns(t *testing.T) {
+	cfg := newEditTestConfig(t)
+	writeLock := func(name string, lock LockInfo) {
+		data, _ := json.Marshal(lock)
+		if err := os.WriteFile(filepath.Join(cfg.ConfigDir(), name), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeLock("auto-api.lock", LockInfo{PID: os.Getpid(), Project: "api", Epic: "api-epic", StartTime: "2026-03-01T09:00:00Z"})
+	writeLock("auto-web.lock", LockInfo{PID: os.Getpid(), Project: "web"})
+	writeLock("auto-dead.lock", LockInfo{PID: 1 << 30, Project: "dead"})
+	_ = SaveProjectEpicState(cfg, "api", &EpicState{EpicID: "api-epic", Status: "running", CurrentBead: "api-2", Beads: []string{"api-1", "api-2", "api-3"}, CompletedBeads: []string{"api-1"}})
+	_ = SaveProjectEpicState(cfg, "web", &EpicState{EpicID: "web-old", Status: "completed"})
+
+	runs, err := RunningRuns(cfg)
+	if err != nil {
+		t.Fatalf("RunningRuns() error: %v", err)
+	}
+	if len(runs) != 2 {
+		t.Fatalf("RunningRuns() = %+v, want api and web", runs)
+	}
+	api, web := runs[0], runs[1]
+	if api.Project != "api" || api.Epic != "api-epic" || api.CurrentBead != "api-2" || api.Completed != 1 || api.Total != 3 {
+		t.Errorf("api run = %+v", api)
+	}
+	if web.Project != "web" || web.Epic != "" || web.Status != "" {
+		t.Errorf("web run should not pick up a finished epic: %+v", web)
+	}
+}
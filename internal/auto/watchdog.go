@@ -0,0 +1,403 @@
+package auto
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/badri/wt/internal/bead"
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/logging"
+)
+
+// An epic bead ends only when its worker runs 'wt signal bead-done'. The
+// signal is left in a file for the runner, which is the one place that
+// closes the bead and starts the next. Each Claude run writes its exit
+// status to a marker file, so the watchdog knows exactly when a worker
+// exited, and one that exits without signaling is re-prompted once and then
+// failed.
+
+// maxBeadRestarts is how often the watchdog re-prompts a worker that exited
+// without signaling before failing the bead
+const maxBeadRestarts = 1
+
+// watchInterval is how often a running bead is checked
+const watchInterval = 5 * time.Second
+
+// How long a worker that signaled gets to exit on its own, and how long an
+// interrupted one gets to exit before the next Ctrl+C
+const (
+	signalGracePeriod = 30 * time.Second
+	interruptGrace    = 10 * time.Second
+)
+
+// What ended a Claude run; the outcomes besides "success" are recorded as
+// the bead's failure reason
+const (
+	eventSignaled      = "signaled"
+	eventExited        = "exited"
+	outcomeTimeout     = "timeout"
+	outcomeStopped     = "stopped"
+	outcomeNoSignal    = "exited-without-signal"
+	outcomeSessionLost = "session-lost"
+)
+
+// BeadSignal is what 'wt signal bead-done' leaves for the auto runner
+type BeadSignal struct {
+	BeadID  string `json:"bead_id"`
+	Summary string `json:"summary,omitempty"`
+	Time    string `json:"time"`
+}
+
+// BeadSignalFile returns the file 'wt signal bead-done' writes for a
+// project's auto run (the legacy global file when project is empty)
+func BeadSignalFile(cfg *config.Config, project string) string {
+	if project == "" {
+		return filepath.Join(cfg.ConfigDir(), "auto-bead-done.json")
+	}
+	return filepath.Join(cfg.ConfigDir(), fmt.Sprintf("auto-bead-done-%s.json", project))
+}
+
+// FindAutoEpic returns the running epic whose worktree, or current isolated
+// bead worktree, is worktreePath, with the project its state belongs to
+func FindAutoEpic(cfg *config.Config, worktreePath string) (string, *EpicState, bool) {
+	projects, err := EpicStateProjects(cfg)
+	if err != nil {
+		return "", nil, false
+	}
+	for _, p := range projects {
+		state, err := LoadProjectEpicState(cfg, p)
+		if err != nil || state.Status != "running" {
+			continue
+		}
+		if state.Worktree == worktreePath || (state.BeadWorktree != "" && state.BeadWorktree == worktreePath) {
+			return p, state, true
+		}
+	}
+	return "", nil, false
+}
+
+// SignalBeadDone records that the current bead of a running epic is done.
+// The runner picks the signal up, closes the bead and starts the next one.
+func SignalBeadDone(cfg *config.Config, project string, state *EpicState, summary string) error {
+	if state.CurrentBead == "" {
+		return fmt.Errorf("epic %s has no bead in progress", state.EpicID)
+	}
+	data, err := json.MarshalIndent(BeadSignal{
+		BeadID:  state.CurrentBead,
+		Summary: summary,
+		Time:    time.Now().Format(time.RFC3339),
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	// Write and rename, so the runner never reads half a signal
+	path := BeadSignalFile(cfg, project)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// readBeadSignal returns the bead-done signal in path if it is for beadID
+func readBeadSignal(path, beadID string) (*BeadSignal, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var sig BeadSignal
+	if err := json.Unmarshal(data, &sig); err != nil || sig.BeadID != beadID {
+		return nil, false
+	}
+	return &sig, true
+}
+
+// readExitCode returns the exit status a Claude run wrote to its marker
+// file, and whether it has exited at all
+func readExitCode(path string) (int, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, false
+	}
+	code, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		// Exited, but the status got lost
+		return -1, true
+	}
+	return code, true
+}
+
+// claudeShellCommand is the command line pasted into the session: it runs
+// Claude on the prompt file and records its exit status in exitPath.
+// Prefixed with a space to keep it out of shell history.
+func claudeShellCommand(command, promptPath, exitPath string) string {
+	return fmt.Sprintf(" %s -p \"$(cat %q)\"; echo $? > %q; rm -f %q", command, promptPath, exitPath, promptPath)
+}
+
+// watchdogPrompt asks a worker that exited without signaling to finish its
+// bead
+func watchdogPrompt(beadID string, exitCode int) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Your previous run on bead %s exited (status %d) without signaling completion.\n\n", beadID, exitCode)
+	sb.WriteString("Check `git status` and `git log --oneline -5` to see how far it got, then finish the bead ")
+	sb.WriteString("and commit. When it is done, run `wt signal bead-done \"<brief summary of what was done>\"`. ")
+	sb.WriteString("The bead is failed if you exit again without signaling.\n")
+	return sb.String()
+}
+
+// continueCommand continues the conversation of a run that exited early,
+// unless the command already resumes a specific one
+func continueCommand(command string) string {
+	if strings.Contains(command, "--resume") || strings.Contains(command, "--continue") {
+		return command
+	}
+	return command + " --continue"
+}
+
+func (r *Runner) beadSignalFile() string {
+	return BeadSignalFile(r.cfg, r.opts.Project)
+}
+
+// runClaudeInSession runs claude in a tmux session and waits for it to exit.
+// Project-mode beads end when Claude does.
+func (r *Runner) runClaudeInSession(sessionName, command, prompt string, timeout time.Duration) (string, error) {
+	defer func() { r.lastBeadEnd = time.Now() }()
+
+	exitPath, outcome, err := r.startClaude(sessionName, command, prompt)
+	if err != nil {
+		return outcome, err
+	}
+	fmt.Printf("Started claude in session %s (timeout: %v)\n", sessionName, timeout)
+
+	event, _ := r.watchClaude(sessionName, exitPath, time.After(timeout), nil)
+	os.Remove(exitPath)
+	switch event {
+	case eventExited:
+		fmt.Printf("Session %s completed\n", sessionName)
+		return "success", nil
+	case outcomeTimeout:
+		fmt.Printf("Session %s timed out after %v\n", sessionName, timeout)
+	case outcomeStopped:
+		fmt.Printf("Stop signal received, leaving session %s running\n", sessionName)
+	}
+	return event, nil
+}
+
+// runSignaledBead runs an epic bead until its worker signals bead-done. A
+// worker that exits without signaling is re-prompted to finish, up to
+// maxBeadRestarts times, and the bead fails after that.
+func (r *Runner) runSignaledBead(state *EpicState, beadID, command, prompt string, timeout time.Duration) (string, error) {
+	defer func() { r.lastBeadEnd = time.Now() }()
+
+	signalPath := r.beadSignalFile()
+	r.lastSignal = nil
+	signaled := func() bool {
+		sig, ok := readBeadSignal(signalPath, beadID)
+		if ok {
+			r.lastSignal = sig
+		}
+		return ok
+	}
+	// The worker may have signaled while no runner was watching (resume)
+	if signaled() {
+		fmt.Printf("  Bead %s was already signaled done\n", beadID)
+		os.Remove(signalPath)
+		return "success", nil
+	}
+	os.Remove(signalPath)
+
+	deadline := time.After(timeout)
+	for restarts := 0; ; restarts++ {
+		exitPath, outcome, err := r.startClaude(state.SessionName, command, prompt)
+		if err != nil {
+			return outcome, err
+		}
+		fmt.Printf("Started claude in session %s (timeout: %v)\n", state.SessionName, timeout)
+
+		event, code := r.watchClaude(state.SessionName, exitPath, deadline, signaled)
+		// A worker may signal and exit within one check
+		if event == eventExited && signaled() {
+			event = eventSignaled
+		}
+		switch event {
+		case eventSignaled:
+			fmt.Printf("  Bead %s signaled done\n", beadID)
+			r.finishClaude(state.SessionName, exitPath)
+			os.Remove(signalPath)
+			return "success", nil
+		case eventExited:
+			os.Remove(exitPath)
+			r.logger.Log("Watchdog: claude exited (status %d) without signaling bead-done for %s", code, beadID)
+			if restarts >= maxBeadRestarts {
+				fmt.Printf("  Watchdog: Claude exited (status %d) without 'wt signal bead-done', failing %s\n", code, beadID)
+				return outcomeNoSignal, nil
+			}
+			fmt.Printf("  Watchdog: Claude exited (status %d) without 'wt signal bead-done', re-prompting (%d/%d)\n", code, restarts+1, maxBeadRestarts)
+			command, prompt = continueCommand(command), watchdogPrompt(beadID, code)
+		case outcomeTimeout:
+			fmt.Printf("Session %s timed out after %v\n", state.SessionName, timeout)
+			r.interruptClaude(state.SessionName, exitPath)
+			return outcomeTimeout, nil
+		case outcomeStopped:
+			fmt.Printf("Stop signal received, leaving session %s running\n", state.SessionName)
+			return outcomeStopped, nil
+		default:
+			fmt.Printf("  Watchdog: session %s is gone\n", state.SessionName)
+			return event, nil
+		}
+	}
+}
+
+// startClaude pastes the command running Claude on prompt into the session
+// and returns the path its exit status will be written to
+func (r *Runner) startClaude(sessionName, command, prompt string) (string, string, error) {
+	// Write prompt to a temp file to avoid send-keys issues with long prompts.
+	// Using send-keys with long/complex prompts can cause the text to appear
+	// twice in the input buffer (once executed, once echoed without Enter).
+	promptFile, err := os.CreateTemp("", "wt-auto-prompt-*.txt")
+	if err != nil {
+		return "", "failed-create-prompt", fmt.Errorf("creating temp prompt file: %w", err)
+	}
+	promptPath := promptFile.Name()
+
+	if _, err := promptFile.WriteString(prompt); err != nil {
+		promptFile.Close()
+		os.Remove(promptPath)
+		return "", "failed-write-prompt", fmt.Errorf("writing prompt to temp file: %w", err)
+	}
+	promptFile.Close()
+
+	// The marker must not exist until Claude exits
+	exitPath := strings.TrimSuffix(promptPath, ".txt") + ".exit"
+	os.Remove(exitPath)
+
+	// Use paste-buffer for reliable command delivery (same pattern as NudgeSession).
+	// This is more reliable than send-keys for long command strings.
+	cmdFile, err := os.CreateTemp("", "wt-auto-cmd-*.txt")
+	if err != nil {
+		os.Remove(promptPath)
+		return "", "failed-create-cmd", fmt.Errorf("creating temp command file: %w", err)
+	}
+	cmdPath := cmdFile.Name()
+	defer os.Remove(cmdPath)
+
+	if _, err := cmdFile.WriteString(claudeShellCommand(command, promptPath, exitPath)); err != nil {
+		cmdFile.Close()
+		os.Remove(promptPath)
+		return "", "failed-write-cmd", fmt.Errorf("writing command to temp file: %w", err)
+	}
+	cmdFile.Close()
+
+	if err := logging.Command("tmux", "load-buffer", cmdPath).Run(); err != nil {
+		os.Remove(promptPath)
+		return "", "failed-load-buffer", fmt.Errorf("loading buffer: %w", err)
+	}
+	if err := logging.Command("tmux", "paste-buffer", "-t", sessionName).Run(); err != nil {
+		os.Remove(promptPath)
+		return "", "failed-paste", fmt.Errorf("pasting buffer to %s: %w", sessionName, err)
+	}
+
+	// Wait for paste to complete, then send Enter
+	time.Sleep(500 * time.Millisecond)
+	if err := logging.Command("tmux", "send-keys", "-t", sessionName, "Enter").Run(); err != nil {
+		os.Remove(promptPath)
+		return "", "failed-enter", fmt.Errorf("sending Enter to %s: %w", sessionName, err)
+	}
+	return exitPath, "", nil
+}
+
+// watchClaude waits until the Claude run writing exitPath exits, signaled
+// reports true, the deadline passes, the run is stopped or the session
+// disappears. It returns what happened and, for an exit, Claude's status.
+func (r *Runner) watchClaude(sessionName, exitPath string, deadline <-chan time.Time, signaled func() bool) (string, int) {
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if signaled != nil && signaled() {
+				return eventSignaled, 0
+			}
+			if code, ok := readExitCode(exitPath); ok {
+				return eventExited, code
+			}
+			if logging.Command("tmux", "has-session", "-t", sessionName).Run() != nil {
+				return outcomeSessionLost, 0
+			}
+		case <-deadline:
+			return outcomeTimeout, 0
+		case <-r.stopSignal:
+			return outcomeStopped, 0
+		}
+	}
+}
+
+// finishClaude gives a worker that signaled a moment to exit on its own,
+// then interrupts it, so the next bead starts at a shell prompt
+func (r *Runner) finishClaude(sessionName, exitPath string) {
+	if waitForExit(exitPath, signalGracePeriod) {
+		os.Remove(exitPath)
+		return
+	}
+	r.interruptClaude(sessionName, exitPath)
+}
+
+// interruptClaude sends Ctrl+C until the Claude run exits. A run killed by
+// the interrupt may take the exit marker with it, so a pane back at its
+// shell counts as exited too.
+func (r *Runner) interruptClaude(sessionName, exitPath string) {
+	defer os.Remove(exitPath)
+	for i := 0; i < 2; i++ {
+		logging.Command("tmux", "send-keys", "-t", sessionName, "C-c").Run()
+		if waitForExit(exitPath, interruptGrace) || paneAtShell(sessionName) {
+			return
+		}
+	}
+	r.logger.Log("Warning: claude in %s did not exit after Ctrl+C", sessionName)
+}
+
+// waitForExit waits up to d for a Claude run to write its exit marker
+func waitForExit(exitPath string, d time.Duration) bool {
+	for end := time.Now().Add(d); time.Now().Before(end); time.Sleep(time.Second) {
+		if _, ok := readExitCode(exitPath); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// paneAtShell reports whether the session's foreground process is a shell
+func paneAtShell(sessionName string) bool {
+	out, err := logging.Command("tmux", "display-message", "-t", sessionName, "-p", "#{pane_current_command}").Output()
+	if err != nil {
+		return false
+	}
+	switch strings.TrimSpace(string(out)) {
+	case "bash", "zsh", "sh", "fish", "dash":
+		return true
+	}
+	return false
+}
+
+// closeSignaledBead closes a bead that signaled bead-done, with the
+// worker's summary as the reason
+func (r *Runner) closeSignaledBead(state *EpicState, beadID string) {
+	sig := r.lastSignal
+	if sig == nil || sig.BeadID != beadID {
+		return
+	}
+	args := []string{"close", beadID}
+	if sig.Summary != "" {
+		args = append(args, "--reason", sig.Summary)
+	}
+	if output, err := bead.CombinedOutput(state.ProjectDir, args...); err != nil {
+		r.logger.Log("Warning: could not close bead %s: %s", beadID, strings.TrimSpace(string(output)))
+	}
+	bead.CombinedOutput(state.ProjectDir, "sync") // Ignore errors
+}
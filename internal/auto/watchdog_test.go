@@ -0,0 +1,112 @@
+package auto
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSignalBeadDone(t *testing.T) {
+	cfg := newEditTestConfig(t)
+	state := &EpicState{EpicID: "wt-epic", Worktree: "/wt/epic", Status: "running", CurrentBead: "wt-b"}
+	if err := SaveProjectEpicState(cfg, "proj", state); err != nil {
+		t.Fatal(err)
+	}
+
+	project, found, ok := FindAutoEpic(cfg, "/wt/epic")
+	if !ok || project != "proj" || found.CurrentBead != "wt-b" {
+		t.Fatalf("FindAutoEpic() = %q, %+v, %v", project, found, ok)
+	}
+	if _, _, ok := FindAutoEpic(cfg, "/wt/other"); ok {
+		t.Error("FindAutoEpic() matched an unrelated worktree")
+	}
+
+	if err := SignalBeadDone(cfg, project, found, "added the thing"); err != nil {
+		t.Fatal(err)
+	}
+	path := BeadSignalFile(cfg, "proj")
+	sig, ok := readBeadSignal(path, "wt-b")
+	if !ok || sig.Summary != "added the thing" {
+		t.Errorf("readBeadSignal() = %+v, %v", sig, ok)
+	}
+	if _, ok := readBeadSignal(path, "wt-c"); ok {
+		t.Error("a signal for wt-b must not finish wt-c")
+	}
+
+	found.CurrentBead = ""
+	if err := SignalBeadDone(cfg, project, found, ""); err == nil {
+		t.Error("expected an error without a bead in progress")
+	}
+}
+
+func TestFindAutoEpicIsolatedWorktree(t *testing.T) {
+	cfg := newEditTestConfig(t)
+	state := &EpicState{EpicID: "wt-epic", Worktree: "/wt/epic", BeadWorktree: "/wt/epic-b", Status: "running"}
+	if err := SaveProjectEpicState(cfg, "", state); err != nil {
+		t.Fatal(err)
+	}
+	if project, _, ok := FindAutoEpic(cfg, "/wt/epic-b"); !ok || project != "" {
+		t.Errorf("FindAutoEpic() on the bead worktree = %q, %v", project, ok)
+	}
+
+	state.Status = "paused"
+	if err := SaveProjectEpicState(cfg, "", state); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, ok := FindAutoEpic(cfg, "/wt/epic"); ok {
+		t.Error("FindAutoEpic() should only match running epics")
+	}
+}
+
+func TestClaudeShellCommandWritesExitStatus(t *testing.T) {
+	dir := t.TempDir()
+	for _, tt := range []struct {
+		command string
+		want    int
+	}{
+		{"true", 0},
+		{"false", 1},
+	} {
+		promptPath := filepath.Join(dir, "prompt.txt")
+		exitPath := filepath.Join(dir, "prompt.exit")
+		os.Remove(exitPath)
+		if err := os.WriteFile(promptPath, []byte("do the bead"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := readExitCode(exitPath); ok {
+			t.Fatal("readExitCode() before the run should report still running")
+		}
+
+		line := claudeShellCommand(tt.command, promptPath, exitPath)
+		if !strings.HasPrefix(line, " ") {
+			t.Error("command should start with a space to stay out of shell history")
+		}
+		if out, err := exec.Command("sh", "-c", line).CombinedOutput(); err != nil {
+			t.Fatalf("running %q: %v: %s", line, err, out)
+		}
+		if code, ok := readExitCode(exitPath); !ok || code != tt.want {
+			t.Errorf("%s: readExitCode() = %d, %v; want %d", tt.command, code, ok, tt.want)
+		}
+		if _, err := os.Stat(promptPath); !os.IsNotExist(err) {
+			t.Errorf("%s: prompt file should be removed after the run", tt.command)
+		}
+	}
+}
+
+func TestContinueCommand(t *testing.T) {
+	tests := map[string]string{
+		"claude":                      "claude --continue",
+		"claude --resume abc":         "claude --resume abc",
+		"claude --continue --verbose": "claude --continue --verbose",
+	}
+	for in, want := range tests {
+		if got := continueCommand(in); got != want {
+			t.Errorf("continueCommand(%q) = %q, want %q", in, got, want)
+		}
+	}
+	if p := watchdogPrompt("wt-b", 1); !strings.Contains(p, "wt-b") || !strings.Contains(p, "wt signal bead-done") {
+		t.Errorf("watchdogPrompt() = %q", p)
+	}
+}
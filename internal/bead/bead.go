@@ -3,7 +3,6 @@ package bead
 import (
 	"encoding/json"
 	"fmt"
-	"os/exec"
 	"strings"
 )
 
@@ -22,6 +21,11 @@ type ReadyBead struct {
 	Status      string `json:"status"`
 	Priority    int    `json:"priority"`
 	IssueType   string `json:"issue_type"`
+
+	Labels           []string `json:"labels,omitempty"`
+	Assignee         string   `json:"assignee,omitempty"`
+	EstimatedMinutes int      `json:"estimated_minutes,omitempty"`
+	UpdatedAt        string   `json:"updated_at,omitempty"`
 }
 
 func Show(beadID string) (*BeadInfo, error) {
@@ -38,11 +42,7 @@ func ShowInDir(beadID, beadsDir string) (*BeadInfo, error) {
 	}
 
 	// Run bd show to get bead info
-	cmd := exec.Command("bd", "show", beadID, "--json")
-	if projectDir != "" {
-		cmd.Dir = projectDir
-	}
-	output, err := cmd.Output()
+	output, err := Output(projectDir, "show", beadID, "--json")
 	if err != nil {
 		// bd show might not support --json, try parsing text output
 		return showFromTextInDir(beadID, projectDir)
@@ -61,11 +61,7 @@ func showFromText(beadID string) (*BeadInfo, error) {
 }
 
 func showFromTextInDir(beadID, projectDir string) (*BeadInfo, error) {
-	cmd := exec.Command("bd", "show", beadID)
-	if projectDir != "" {
-		cmd.Dir = projectDir
-	}
-	output, err := cmd.Output()
+	output, err := Output(projectDir, "show", beadID)
 	if err != nil {
 		return nil, fmt.Errorf("bead not found: %s", beadID)
 	}
@@ -99,8 +95,12 @@ func showFromTextInDir(beadID, projectDir string) (*BeadInfo, error) {
 }
 
 func Close(beadID string) error {
-	cmd := exec.Command("bd", "close", beadID)
-	output, err := cmd.CombinedOutput()
+	return CloseInDir(beadID, "")
+}
+
+// CloseInDir closes a bead of the project in projectDir
+func CloseInDir(beadID, projectDir string) error {
+	output, err := CombinedOutput(projectDir, "close", beadID)
 	if err != nil {
 		return fmt.Errorf("closing bead: %s: %w", string(output), err)
 	}
@@ -113,11 +113,7 @@ func UpdateStatus(beadID, status string) error {
 
 // UpdateStatusInDir updates bead status in a specific project directory
 func UpdateStatusInDir(beadID, status, projectDir string) error {
-	cmd := exec.Command("bd", "update", beadID, "--status", status)
-	if projectDir != "" {
-		cmd.Dir = projectDir
-	}
-	output, err := cmd.CombinedOutput()
+	output, err := CombinedOutput(projectDir, "update", beadID, "--status", status)
 	if err != nil {
 		return fmt.Errorf("updating bead status: %s: %w", string(output), err)
 	}
@@ -142,8 +138,7 @@ func ExtractProject(beadID string) string {
 
 // Ready returns all beads that are ready to work on (no blockers)
 func Ready() ([]ReadyBead, error) {
-	cmd := exec.Command("bd", "ready", "--json")
-	output, err := cmd.Output()
+	output, err := Output("", "ready", "--json")
 	if err != nil {
 		return nil, fmt.Errorf("getting ready beads: %w", err)
 	}
@@ -164,9 +159,7 @@ func ReadyInDir(beadsDir string) ([]ReadyBead, error) {
 	projectDir := strings.TrimSuffix(beadsDir, "/.beads")
 	projectDir = strings.TrimSuffix(projectDir, ".beads")
 
-	cmd := exec.Command("bd", "ready", "--json")
-	cmd.Dir = projectDir
-	output, err := cmd.Output()
+	output, err := Output(projectDir, "ready", "--json")
 	if err != nil {
 		return nil, fmt.Errorf("getting ready beads from %s: %w", beadsDir, err)
 	}
@@ -197,11 +190,12 @@ func CreateInDir(beadsDir, title string, opts *CreateOptions) (string, error) {
 		if opts.Type != "" {
 			args = append(args, "-t", opts.Type)
 		}
+		if len(opts.Labels) > 0 {
+			args = append(args, "-l", strings.Join(opts.Labels, ","))
+		}
 	}
 
-	cmd := exec.Command("bd", args...)
-	cmd.Dir = projectDir
-	output, err := cmd.CombinedOutput()
+	output, err := CombinedOutput(projectDir, args...)
 	if err != nil {
 		return "", fmt.Errorf("creating bead: %s: %w", string(output), err)
 	}
@@ -226,6 +220,7 @@ type CreateOptions struct {
 	Description string
 	Priority    int
 	Type        string
+	Labels      []string
 }
 
 // ListInDir returns all beads from a specific beads directory
@@ -239,9 +234,7 @@ func ListInDir(beadsDir string, status string) ([]ReadyBead, error) {
 		args = append(args, "--status", status)
 	}
 
-	cmd := exec.Command("bd", args...)
-	cmd.Dir = projectDir
-	output, err := cmd.Output()
+	output, err := Output(projectDir, args...)
 	if err != nil {
 		return nil, fmt.Errorf("listing beads from %s: %w", beadsDir, err)
 	}
@@ -261,8 +254,7 @@ func List(status string) ([]ReadyBead, error) {
 		args = append(args, "--status", status)
 	}
 
-	cmd := exec.Command("bd", args...)
-	output, err := cmd.Output()
+	output, err := Output("", args...)
 	if err != nil {
 		return nil, fmt.Errorf("listing beads: %w", err)
 	}
@@ -277,8 +269,7 @@ func List(status string) ([]ReadyBead, error) {
 
 // Search searches for beads by title
 func Search(query string) ([]ReadyBead, error) {
-	cmd := exec.Command("bd", "search", query, "--json")
-	output, err := cmd.Output()
+	output, err := Output("", "search", query, "--json")
 	if err != nil {
 		// Search might not support --json, fall back to list and filter
 		return searchFallback(query)
@@ -324,10 +315,12 @@ func Create(title string, opts *CreateOptions) (string, error) {
 		if opts.Type != "" {
 			args = append(args, "--type", opts.Type)
 		}
+		if len(opts.Labels) > 0 {
+			args = append(args, "--labels", strings.Join(opts.Labels, ","))
+		}
 	}
 
-	cmd := exec.Command("bd", args...)
-	output, err := cmd.CombinedOutput()
+	output, err := CombinedOutput("", args...)
 	if err != nil {
 		return "", fmt.Errorf("creating bead: %s: %w", string(output), err)
 	}
@@ -347,10 +340,77 @@ func Create(title string, opts *CreateOptions) (string, error) {
 	return "", fmt.Errorf("could not parse created bead ID from: %s", string(output))
 }
 
+// AddCommentInDir adds a comment to a bead in a specific beads directory
+func AddCommentInDir(beadID, text, beadsDir string) error {
+	projectDir := ""
+	if beadsDir != "" {
+		projectDir = strings.TrimSuffix(beadsDir, "/.beads")
+		projectDir = strings.TrimSuffix(projectDir, ".beads")
+	}
+	output, err := CombinedOutput(projectDir, "comments", "add", beadID, text)
+	if err != nil {
+		return fmt.Errorf("adding bead comment: %s: %w", string(output), err)
+	}
+	return nil
+}
+
+// Comment is a comment on a bead, as bd comments --json reports it
+type Comment struct {
+	ID        json.RawMessage `json:"id"`
+	Author    string          `json:"author"`
+	Text      string          `json:"text"`
+	CreatedAt string          `json:"created_at"`
+}
+
+// Key identifies a comment across reads: its ID, or its time and author if
+// bd reported none
+func (c Comment) Key() string {
+	if len(c.ID) > 0 && string(c.ID) != "null" {
+		return strings.Trim(string(c.ID), `"`)
+	}
+	return c.CreatedAt + "/" + c.Author
+}
+
+// CommentsInDir lists a bead's comments, oldest first, in a specific beads
+// directory
+func CommentsInDir(beadID, beadsDir string) ([]Comment, error) {
+	projectDir := strings.TrimSuffix(beadsDir, "/.beads")
+	output, err := Output(projectDir, "comments", beadID, "--json")
+	if err != nil {
+		return nil, fmt.Errorf("listing bead comments: %w", err)
+	}
+	var comments []Comment
+	if err := json.Unmarshal(output, &comments); err != nil {
+		return nil, fmt.Errorf("parsing bead comments: %w", err)
+	}
+	return comments, nil
+}
+
+// AddDepInDir records that beadID depends on (is blocked by) dependsOn,
+// in a specific beads directory
+func AddDepInDir(beadID, dependsOn, beadsDir string) error {
+	projectDir := strings.TrimSuffix(beadsDir, "/.beads")
+	output, err := CombinedOutput(projectDir, "dep", "add", beadID, dependsOn)
+	if err != nil {
+		return fmt.Errorf("adding bead dependency: %s: %w", string(output), err)
+	}
+	return nil
+}
+
+// AddDiscoveredFromInDir links beadID to the bead whose work turned it
+// up, without blocking it, in a specific beads directory
+func AddDiscoveredFromInDir(beadID, from, beadsDir string) error {
+	projectDir := strings.TrimSuffix(beadsDir, "/.beads")
+	output, err := CombinedOutput(projectDir, "dep", "add", beadID, from, "--type", "discovered-from")
+	if err != nil {
+		return fmt.Errorf("linking bead to %s: %s: %w", from, string(output), err)
+	}
+	return nil
+}
+
 // UpdateDescription updates a bead's description
 func UpdateDescription(beadID, description string) error {
-	cmd := exec.Command("bd", "update", beadID, "--description", description)
-	output, err := cmd.CombinedOutput()
+	output, err := CombinedOutput("", "update", beadID, "--description", description)
 	if err != nil {
 		return fmt.Errorf("updating bead description: %s: %w", string(output), err)
 	}
@@ -383,11 +443,7 @@ func ShowFullInDir(beadID, beadsDir string) (*BeadInfoFull, error) {
 	}
 
 	// Try JSON first
-	cmd := exec.Command("bd", "show", beadID, "--json")
-	if projectDir != "" {
-		cmd.Dir = projectDir
-	}
-	output, err := cmd.Output()
+	output, err := Output(projectDir, "show", beadID, "--json")
 	if err == nil {
 		var info BeadInfoFull
 		if err := json.Unmarshal(output, &info); err == nil {
@@ -396,11 +452,7 @@ func ShowFullInDir(beadID, beadsDir string) (*BeadInfoFull, error) {
 	}
 
 	// Fallback to text parsing (bd show might not support --json)
-	cmd = exec.Command("bd", "show", beadID)
-	if projectDir != "" {
-		cmd.Dir = projectDir
-	}
-	output, err = cmd.Output()
+	output, err = Output(projectDir, "show", beadID)
 	if err != nil {
 		return nil, fmt.Errorf("bead not found: %s", beadID)
 	}
@@ -434,3 +486,65 @@ func ShowFullInDir(beadID, beadsDir string) (*BeadInfoFull, error) {
 
 	return info, nil
 }
+
+// Labels returns a bead's labels. projectDir may be "" for the current
+// directory.
+func Labels(beadID, projectDir string) ([]string, error) {
+	output, err := Output(projectDir, "show", beadID, "--json")
+	if err != nil {
+		return nil, fmt.Errorf("bead not found: %s", beadID)
+	}
+
+	// bd show --json returns an array with one element
+	var infos []struct {
+		Labels []string `json:"labels"`
+	}
+	if err := json.Unmarshal(output, &infos); err != nil {
+		return nil, fmt.Errorf("parsing bead %s: %w", beadID, err)
+	}
+	if len(infos) == 0 {
+		return nil, fmt.Errorf("bead not found: %s", beadID)
+	}
+	return infos[0].Labels, nil
+}
+
+// Deps returns the beads that block a bead and the beads it blocks, from
+// the "blocks" dependencies in bd show --json. projectDir may be "" for
+// the current directory.
+func Deps(beadID, projectDir string) (blockers, dependents []string, err error) {
+	output, err := Output(projectDir, "show", beadID, "--json")
+	if err != nil {
+		return nil, nil, fmt.Errorf("bead not found: %s", beadID)
+	}
+
+	type dep struct {
+		ID             string `json:"id"`
+		DependencyType string `json:"dependency_type"`
+	}
+	var infos []struct {
+		Dependencies []dep `json:"dependencies"`
+		Dependents   []dep `json:"dependents"`
+	}
+	if err := json.Unmarshal(output, &infos); err != nil {
+		return nil, nil, fmt.Errorf("parsing bead %s: %w", beadID, err)
+	}
+	if len(infos) == 0 {
+		return nil, nil, fmt.Errorf("bead not found: %s", beadID)
+	}
+
+	// Older bd versions don't report the type; treat those as blocking
+	blocking := func(d dep) bool {
+		return d.DependencyType == "" || d.DependencyType == "blocks"
+	}
+	for _, d := range infos[0].Dependencies {
+		if blocking(d) {
+			blockers = append(blockers, d.ID)
+		}
+	}
+	for _, d := range infos[0].Dependents {
+		if blocking(d) {
+			dependents = append(dependents, d.ID)
+		}
+	}
+	return blockers, dependents, nil
+}
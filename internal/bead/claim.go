@@ -0,0 +1,146 @@
+package bead
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Claim is who holds a bead: an in_progress bead with an assignee. Claims
+// live in bd itself, so every hub syncing the same beads sees them.
+type Claim struct {
+	Bead     string
+	Title    string
+	Status   string
+	Claimant string
+	Since    time.Time // last update of the bead; zero if unknown
+}
+
+// Held reports whether anyone holds the bead
+func (c *Claim) Held() bool {
+	return c.Status == "in_progress" && c.Claimant != ""
+}
+
+// Stale reports whether the claim is older than ttl and may be taken over
+func (c *Claim) Stale(ttl time.Duration, now time.Time) bool {
+	return c.Held() && !c.Since.IsZero() && now.Sub(c.Since) > ttl
+}
+
+// ClaimedError is returned when a bead is claimed by someone else
+type ClaimedError struct {
+	Claim *Claim
+}
+
+func (e *ClaimedError) Error() string {
+	msg := fmt.Sprintf("bead %s is already claimed by %s", e.Claim.Bead, e.Claim.Claimant)
+	if !e.Claim.Since.IsZero() {
+		msg += fmt.Sprintf(" (since %s)", e.Claim.Since.Local().Format("2006-01-02 15:04"))
+	}
+	return msg
+}
+
+// claimFromBead builds a Claim from a listed or shown bead
+func claimFromBead(b *ReadyBead) *Claim {
+	c := &Claim{Bead: b.ID, Title: b.Title, Status: b.Status, Claimant: b.Assignee}
+	if t, err := time.Parse(time.RFC3339, b.UpdatedAt); err == nil {
+		c.Since = t
+	}
+	return c
+}
+
+// GetClaim returns the current claim on a bead. projectDir may be "" for
+// the current directory.
+func GetClaim(beadID, projectDir string) (*Claim, error) {
+	return getClaim(beadID, projectDir, Output)
+}
+
+// getClaim reads a bead's claim with bd run through output
+func getClaim(beadID, projectDir string, output func(dir string, args ...string) ([]byte, error)) (*Claim, error) {
+	data, err := output(projectDir, "show", beadID, "--json")
+	if err != nil {
+		return nil, fmt.Errorf("bead not found: %s", beadID)
+	}
+
+	// bd show --json returns an array with one element
+	var infos []ReadyBead
+	if err := json.Unmarshal(data, &infos); err != nil {
+		return nil, fmt.Errorf("parsing bead %s: %w", beadID, err)
+	}
+	if len(infos) == 0 {
+		return nil, fmt.Errorf("bead not found: %s", beadID)
+	}
+	return claimFromBead(&infos[0]), nil
+}
+
+// ListClaims returns the in_progress beads of a project with their claimants
+func ListClaims(beadsDir string) ([]*Claim, error) {
+	beads, err := ListInDir(beadsDir, "in_progress")
+	if err != nil {
+		return nil, err
+	}
+	claims := make([]*Claim, 0, len(beads))
+	for i := range beads {
+		claims = append(claims, claimFromBead(&beads[i]))
+	}
+	return claims, nil
+}
+
+// ClaimBead marks a bead in_progress and assigned to claimant. It refuses
+// with a *ClaimedError when someone else holds a claim younger than ttl.
+// A negative ttl takes over any claim. The returned claim is the one
+// taken over, or nil.
+//
+// The check and the update happen under an exclusive beads lock, so of
+// the wt processes sharing a beads directory only one can claim a bead.
+// Hubs on other machines only see each other's claims through bd sync:
+// the claim syncs before the check and after the update, then reads the
+// bead back and fails if another hub's claim won the merge. Two hubs
+// claiming within one sync of each other can still both start.
+func ClaimBead(beadID, claimant, projectDir string, ttl time.Duration) (*Claim, error) {
+	unlock, err := lockBeadsDir(locateBeadsDir(projectDir), false)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	output := func(dir string, args ...string) ([]byte, error) {
+		return runLocked(dir, args, false)
+	}
+
+	runLocked(projectDir, []string{"sync"}, true) // Ignore errors: bd may have no remote
+	cur, err := getClaim(beadID, projectDir, output)
+	if err != nil {
+		return nil, err
+	}
+	var takenOver *Claim
+	if cur.Held() && cur.Claimant != claimant {
+		if ttl >= 0 && !cur.Stale(ttl, time.Now()) {
+			return nil, &ClaimedError{Claim: cur}
+		}
+		takenOver = cur
+	}
+
+	out, err := runLocked(projectDir, []string{"update", beadID, "--status", "in_progress", "--assignee", claimant}, true)
+	if err != nil {
+		return nil, fmt.Errorf("claiming bead: %s: %w", string(out), err)
+	}
+
+	runLocked(projectDir, []string{"sync"}, true)
+	after, err := getClaim(beadID, projectDir, output)
+	if err != nil {
+		return nil, err
+	}
+	if after.Claimant != claimant {
+		return nil, &ClaimedError{Claim: after}
+	}
+	return takenOver, nil
+}
+
+// ReleaseClaim puts a claimed bead back to open and unassigned, so it shows
+// up as ready again
+func ReleaseClaim(beadID, projectDir string) error {
+	output, err := CombinedOutput(projectDir, "update", beadID, "--status", "open", "--assignee", "")
+	if err != nil {
+		return fmt.Errorf("releasing bead: %s: %w", string(output), err)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package bead
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClaimStale(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	c := claimFromBead(&ReadyBead{ID: "wt-a", Status: "in_progress", Assignee: "me@box", UpdatedAt: "2026-03-01T10:00:00Z"})
+	if !c.Held() {
+		t.Fatal("an assigned in_progress bead should be held")
+	}
+	if !c.Stale(24*time.Hour, now) {
+		t.Error("a 26h old claim should be stale with a 24h ttl")
+	}
+	if c.Stale(48*time.Hour, now) {
+		t.Error("a 26h old claim should hold with a 48h ttl")
+	}
+
+	for _, b := range []ReadyBead{
+		{ID: "wt-b", Status: "open", Assignee: "me@box"},
+		{ID: "wt-c", Status: "in_progress"},
+	} {
+		if claimFromBead(&b).Held() {
+			t.Errorf("%+v should not be held", b)
+		}
+	}
+
+	// Without a timestamp a claim never expires on its own
+	c = claimFromBead(&ReadyBead{ID: "wt-d", Status: "in_progress", Assignee: "me@box"})
+	if c.Stale(time.Minute, now) {
+		t.Error("a claim with no update time should not be stale")
+	}
+}
+
+func TestClaimedError(t *testing.T) {
+	err := &ClaimedError{Claim: &Claim{Bead: "wt-a", Claimant: "ci@runner"}}
+	if msg := err.Error(); !strings.Contains(msg, "wt-a") || !strings.Contains(msg, "ci@runner") {
+		t.Errorf("Error() = %q", msg)
+	}
+}
+
+func TestClaimBeadRace(t *testing.T) {
+	dir := t.TempDir()
+	assignee := filepath.Join(dir, "assignee")
+	log := filepath.Join(dir, "log")
+	fakeBd(t, `echo "$1" >> `+log+`
+case "$1" in
+show)
+	if [ -s `+assignee+` ]; then
+		echo "[{\"id\":\"wt-a\",\"status\":\"in_progress\",\"assignee\":\"$(cat `+assignee+`)\"}]"
+	else
+		echo '[{"id":"wt-a","status":"open"}]'
+	fi ;;
+update)
+	sleep 0.1
+	printf %s "$6" > `+assignee+` ;;
+esac`)
+
+	project := t.TempDir()
+	if err := os.Mkdir(filepath.Join(project, ".beads"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i, hub := range []string{"a@box", "b@box"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = ClaimBead("wt-a", hub, project, time.Hour)
+		}()
+	}
+	wg.Wait()
+
+	won := 0
+	for _, err := range errs {
+		var claimed *ClaimedError
+		switch {
+		case err == nil:
+			won++
+		case !errors.As(err, &claimed):
+			t.Errorf("ClaimBead() error = %v, want a *ClaimedError", err)
+		}
+	}
+	if won != 1 {
+		t.Errorf("%d hubs won the claim, want 1 (errors: %v)", won, errs)
+	}
+
+	data, _ := os.ReadFile(log)
+	if calls := strings.Fields(string(data)); len(calls) < 5 || calls[0] != "sync" || calls[2] != "update" || calls[3] != "sync" {
+		t.Errorf("bd calls = %v, want sync, show, update, sync, show first", calls)
+	}
+}
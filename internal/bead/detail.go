@@ -0,0 +1,57 @@
+package bead
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Detail is everything bd show --json reports about a bead that wt shows
+type Detail struct {
+	ID                 string    `json:"id"`
+	Title              string    `json:"title"`
+	Description        string    `json:"description,omitempty"`
+	Design             string    `json:"design,omitempty"`
+	AcceptanceCriteria string    `json:"acceptance_criteria,omitempty"`
+	Notes              string    `json:"notes,omitempty"`
+	Status             string    `json:"status"`
+	Priority           int       `json:"priority"`
+	IssueType          string    `json:"issue_type"`
+	Assignee           string    `json:"assignee,omitempty"`
+	Labels             []string  `json:"labels,omitempty"`
+	CreatedAt          string    `json:"created_at,omitempty"`
+	UpdatedAt          string    `json:"updated_at,omitempty"`
+	ClosedAt           string    `json:"closed_at,omitempty"`
+	Dependencies       []Related `json:"dependencies,omitempty"`
+	Dependents         []Related `json:"dependents,omitempty"`
+}
+
+// Related is a bead on the other end of a dependency
+type Related struct {
+	ID             string `json:"id"`
+	Title          string `json:"title"`
+	Status         string `json:"status"`
+	IssueType      string `json:"issue_type,omitempty"`
+	DependencyType string `json:"dependency_type,omitempty"`
+}
+
+// ShowDetail returns a bead's full bd show --json record. projectDir may be
+// "" for the current directory.
+func ShowDetail(beadID, projectDir string) (*Detail, error) {
+	output, err := Output(projectDir, "show", beadID, "--json")
+	if err != nil {
+		return nil, fmt.Errorf("bead not found: %s", beadID)
+	}
+	return parseDetail(beadID, output)
+}
+
+// parseDetail reads bd show --json, which returns an array with one element
+func parseDetail(beadID string, output []byte) (*Detail, error) {
+	var details []Detail
+	if err := json.Unmarshal(output, &details); err != nil {
+		return nil, fmt.Errorf("parsing bead %s: %w", beadID, err)
+	}
+	if len(details) == 0 {
+		return nil, fmt.Errorf("bead not found: %s", beadID)
+	}
+	return &details[0], nil
+}
@@ -0,0 +1,39 @@
+package bead
+
+import "testing"
+
+func TestParseDetail(t *testing.T) {
+	output := []byte(`[{
+		"id": "app-abc",
+		"title": "Add login",
+		"description": "Users sign in with email",
+		"status": "in_progress",
+		"priority": 1,
+		"issue_type": "feature",
+		"labels": ["auth"],
+		"dependencies": [{"id": "app-epic", "title": "Auth", "status": "open", "dependency_type": "parent-child"},
+		                 {"id": "app-db", "title": "Schema", "status": "closed", "dependency_type": "blocks"}],
+		"dependents": [{"id": "app-ui", "title": "Login page", "status": "open"}]
+	}]`)
+
+	d, err := parseDetail("app-abc", output)
+	if err != nil {
+		t.Fatalf("parseDetail: %v", err)
+	}
+	if d.Title != "Add login" || d.Status != "in_progress" || d.Priority != 1 || d.IssueType != "feature" {
+		t.Errorf("unexpected detail: %+v", d)
+	}
+	if len(d.Dependencies) != 2 || d.Dependencies[1].Status != "closed" {
+		t.Errorf("dependencies = %+v", d.Dependencies)
+	}
+	if len(d.Dependents) != 1 || d.Dependents[0].Title != "Login page" {
+		t.Errorf("dependents = %+v", d.Dependents)
+	}
+
+	if _, err := parseDetail("app-abc", []byte(`[]`)); err == nil {
+		t.Error("expected an error for an empty result")
+	}
+	if _, err := parseDetail("app-abc", []byte(`not json`)); err == nil {
+		t.Error("expected an error for invalid output")
+	}
+}
@@ -0,0 +1,39 @@
+package bead
+
+// Epic returns the epic the bead belongs to: the bead itself if it is an
+// epic, else the first epic among the beads it depends on. Returns "" if
+// it belongs to none.
+func (d *Detail) Epic() string {
+	if d.IssueType == "epic" {
+		return d.ID
+	}
+	for _, dep := range d.Dependencies {
+		if dep.IssueType == "epic" {
+			return dep.ID
+		}
+	}
+	return ""
+}
+
+// Progress counts an epic's children, the beads that depend on it, and
+// how many of them are closed
+func (d *Detail) Progress() (done, total int) {
+	for _, child := range d.Dependents {
+		total++
+		if child.Status == "closed" {
+			done++
+		}
+	}
+	return done, total
+}
+
+// EpicOf returns the epic a bead belongs to (see Detail.Epic), or "" if it
+// belongs to none or bd can't show it. projectDir may be "" for the
+// current directory.
+func EpicOf(beadID, projectDir string) string {
+	d, err := ShowDetail(beadID, projectDir)
+	if err != nil {
+		return ""
+	}
+	return d.Epic()
+}
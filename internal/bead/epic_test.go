@@ -0,0 +1,36 @@
+package bead
+
+import "testing"
+
+func TestDetailEpic(t *testing.T) {
+	output := []byte(`[{"id":"app-c1","title":"Child","issue_type":"task","dependencies":[
+		{"id":"app-b0","title":"Blocker","status":"open","issue_type":"task","dependency_type":"blocks"},
+		{"id":"app-e1","title":"Epic","status":"open","issue_type":"epic","dependency_type":"parent-child"}]}]`)
+	d, err := parseDetail("app-c1", output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := d.Epic(); got != "app-e1" {
+		t.Errorf("Epic() = %q, want app-e1", got)
+	}
+
+	epic := &Detail{ID: "app-e1", IssueType: "epic"}
+	if got := epic.Epic(); got != "app-e1" {
+		t.Errorf("an epic's Epic() = %q, want itself", got)
+	}
+	if got := (&Detail{ID: "app-x", IssueType: "bug"}).Epic(); got != "" {
+		t.Errorf("Epic() of a bead outside any epic = %q", got)
+	}
+}
+
+func TestDetailProgress(t *testing.T) {
+	d := &Detail{ID: "app-e1", IssueType: "epic", Dependents: []Related{
+		{ID: "app-c1", Status: "closed"},
+		{ID: "app-c2", Status: "in_progress"},
+		{ID: "app-c3", Status: "closed"},
+		{ID: "app-c4", Status: "open"},
+	}}
+	if done, total := d.Progress(); done != 2 || total != 4 {
+		t.Errorf("Progress() = %d/%d, want 2/4", done, total)
+	}
+}
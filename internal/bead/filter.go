@@ -0,0 +1,364 @@
+package bead
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Filter is a parsed ready filter: a boolean expression over bead fields
+// that ready beads must match, e.g.
+//
+//	estimate > 0 and not labels has needs-design
+//
+// Comparisons are field op value with ops == != < <= > >= and contains
+// (substring; for labels "has" and "contains" test membership). A bare
+// field is true when it is set. Terms combine with and, or, not and
+// parentheses; values with spaces are quoted.
+type Filter struct {
+	expr filterNode
+	src  string
+}
+
+// filterFields maps field names to whether they are numeric
+var filterFields = map[string]bool{
+	"id":          false,
+	"title":       false,
+	"description": false,
+	"status":      false,
+	"type":        false,
+	"assignee":    false,
+	"labels":      false,
+	"priority":    true,
+	"estimate":    true,
+}
+
+type filterNode interface {
+	eval(b *ReadyBead) bool
+}
+
+type andNode struct{ left, right filterNode }
+type orNode struct{ left, right filterNode }
+type notNode struct{ inner filterNode }
+
+type compareNode struct {
+	field string
+	op    string // "" for a bare field
+	value string
+}
+
+func (n andNode) eval(b *ReadyBead) bool { return n.left.eval(b) && n.right.eval(b) }
+func (n orNode) eval(b *ReadyBead) bool  { return n.left.eval(b) || n.right.eval(b) }
+func (n notNode) eval(b *ReadyBead) bool { return !n.inner.eval(b) }
+
+func (n compareNode) eval(b *ReadyBead) bool {
+	if n.field == "labels" {
+		if n.op == "" {
+			return len(b.Labels) > 0
+		}
+		return slices.Contains(b.Labels, n.value)
+	}
+	if filterFields[n.field] {
+		got := b.Priority
+		if n.field == "estimate" {
+			got = b.EstimatedMinutes
+		}
+		if n.op == "" {
+			return got != 0
+		}
+		want, _ := strconv.Atoi(n.value) // checked when parsed
+		switch n.op {
+		case "==":
+			return got == want
+		case "!=":
+			return got != want
+		case "<":
+			return got < want
+		case "<=":
+			return got <= want
+		case ">":
+			return got > want
+		case ">=":
+			return got >= want
+		}
+		return false
+	}
+
+	var got string
+	switch n.field {
+	case "id":
+		got = b.ID
+	case "title":
+		got = b.Title
+	case "description":
+		got = b.Description
+	case "status":
+		got = b.Status
+	case "type":
+		got = b.IssueType
+	case "assignee":
+		got = b.Assignee
+	}
+	switch n.op {
+	case "":
+		return got != ""
+	case "==":
+		return strings.EqualFold(got, n.value)
+	case "!=":
+		return !strings.EqualFold(got, n.value)
+	case "contains":
+		return strings.Contains(strings.ToLower(got), strings.ToLower(n.value))
+	}
+	return false
+}
+
+// ParseFilter parses a ready filter expression
+func ParseFilter(expr string) (*Filter, error) {
+	tokens, err := tokenizeFilter(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty ready filter")
+	}
+	p := &filterParser{tokens: tokens}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("ready filter %q: %w", expr, err)
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("ready filter %q: unexpected %q", expr, p.tokens[p.pos].text)
+	}
+	return &Filter{expr: node, src: expr}, nil
+}
+
+// Match reports whether a bead passes the filter
+func (f *Filter) Match(b *ReadyBead) bool {
+	return f.expr.eval(b)
+}
+
+// UsesLabels reports whether the filter looks at labels, which bd ready
+// may not include
+func (f *Filter) UsesLabels() bool {
+	return usesField(f.expr, "labels")
+}
+
+func usesField(n filterNode, field string) bool {
+	switch n := n.(type) {
+	case andNode:
+		return usesField(n.left, field) || usesField(n.right, field)
+	case orNode:
+		return usesField(n.left, field) || usesField(n.right, field)
+	case notNode:
+		return usesField(n.inner, field)
+	case compareNode:
+		return n.field == field
+	}
+	return false
+}
+
+func (f *Filter) String() string {
+	return f.src
+}
+
+// FilterReady applies a project's ready filter to ready beads and returns
+// the beads that pass and the IDs of those that don't. Labels missing from
+// the bd ready output are looked up when the filter needs them.
+func FilterReady(beads []ReadyBead, expr, projectDir string) (kept []ReadyBead, excluded []string, err error) {
+	if strings.TrimSpace(expr) == "" {
+		return beads, nil, nil
+	}
+	f, err := ParseFilter(expr)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, b := range beads {
+		if f.UsesLabels() && b.Labels == nil {
+			if labels, err := Labels(b.ID, projectDir); err == nil {
+				b.Labels = labels
+			}
+		}
+		if f.Match(&b) {
+			kept = append(kept, b)
+		} else {
+			excluded = append(excluded, b.ID)
+		}
+	}
+	return kept, excluded, nil
+}
+
+type filterToken struct {
+	text   string
+	quoted bool
+}
+
+func tokenizeFilter(expr string) ([]filterToken, error) {
+	var tokens []filterToken
+	runes := []rune(expr)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(' || r == ')':
+			tokens = append(tokens, filterToken{text: string(r)})
+			i++
+		case r == '"' || r == '\'':
+			end := i + 1
+			for end < len(runes) && runes[end] != r {
+				end++
+			}
+			if end >= len(runes) {
+				return nil, fmt.Errorf("unterminated quote in ready filter %q", expr)
+			}
+			tokens = append(tokens, filterToken{text: string(runes[i+1 : end]), quoted: true})
+			i = end + 1
+		case strings.ContainsRune("=!<>&|", r):
+			end := i + 1
+			for end < len(runes) && strings.ContainsRune("=!<>&|", runes[end]) {
+				end++
+			}
+			tokens = append(tokens, filterToken{text: string(runes[i:end])})
+			i = end
+		default:
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && !strings.ContainsRune("()\"'=!<>&|", runes[end]) {
+				end++
+			}
+			tokens = append(tokens, filterToken{text: string(runes[i:end])})
+			i = end
+		}
+	}
+	return tokens, nil
+}
+
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+}
+
+func (p *filterParser) peek() (filterToken, bool) {
+	if p.pos >= len(p.tokens) {
+		return filterToken{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+// keyword reports whether the next token is one of words, consuming it
+func (p *filterParser) keyword(words ...string) bool {
+	t, ok := p.peek()
+	if !ok || t.quoted || !slices.Contains(words, strings.ToLower(t.text)) {
+		return false
+	}
+	p.pos++
+	return true
+}
+
+func (p *filterParser) parseOr() (filterNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("or", "||") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (filterNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("and", "&&") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseNot() (filterNode, error) {
+	if p.keyword("not", "!") {
+		inner, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{inner}, nil
+	}
+	if p.keyword("(") {
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.keyword(")") {
+			return nil, fmt.Errorf("missing )")
+		}
+		return inner, nil
+	}
+	return p.parseCompare()
+}
+
+func (p *filterParser) parseCompare() (filterNode, error) {
+	t, ok := p.peek()
+	if !ok {
+		return nil, fmt.Errorf("expected a field")
+	}
+	field := strings.ToLower(t.text)
+	numeric, known := filterFields[field]
+	if t.quoted || !known {
+		return nil, fmt.Errorf("unknown field %q (fields: id, title, description, status, type, assignee, labels, priority, estimate)", t.text)
+	}
+	p.pos++
+
+	op, ok := p.peek()
+	if !ok || op.quoted {
+		return compareNode{field: field}, nil
+	}
+	opText := strings.ToLower(op.text)
+	switch opText {
+	case "==", "!=", "<", "<=", ">", ">=", "contains", "has":
+	case "=":
+		opText = "=="
+	default:
+		return compareNode{field: field}, nil // a bare field
+	}
+	p.pos++
+
+	v, ok := p.peek()
+	if !ok || (!v.quoted && (v.text == "(" || v.text == ")")) {
+		return nil, fmt.Errorf("%s %s needs a value", field, op.text)
+	}
+	p.pos++
+
+	switch {
+	case field == "labels":
+		if opText != "has" && opText != "contains" {
+			return nil, fmt.Errorf("labels only supports has or contains")
+		}
+	case numeric:
+		if opText == "has" || opText == "contains" {
+			return nil, fmt.Errorf("%s is a number; use == != < <= > >=", field)
+		}
+		if _, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(v.text), "P")); err != nil {
+			return nil, fmt.Errorf("%s needs a number, got %q", field, v.text)
+		}
+		v.text = strings.TrimPrefix(strings.ToUpper(v.text), "P") // priority P2 == 2
+	default:
+		switch opText {
+		case "has":
+			opText = "contains"
+		case "<", "<=", ">", ">=":
+			return nil, fmt.Errorf("%s is text; use == != or contains", field)
+		}
+	}
+	return compareNode{field: field, op: opText, value: v.text}, nil
+}
@@ -0,0 +1,94 @@
+package bead
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFilterMatch(t *testing.T) {
+	b := &ReadyBead{
+		ID:               "wt-abc",
+		Title:            "Add retry to uploads",
+		Status:           "open",
+		Priority:         1,
+		IssueType:        "feature",
+		Labels:           []string{"backend", "needs-design"},
+		EstimatedMinutes: 90,
+	}
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{"estimate > 0", true},
+		{"estimate", true},
+		{"not labels has needs-design", false},
+		{"!labels has frontend", true},
+		{"estimate > 0 and not labels has needs-design", false},
+		{"labels has needs-design or priority <= 1", true},
+		{"priority == P1", true},
+		{"priority > 2", false},
+		{"type == Feature", true},
+		{"type != bug && title contains retry", true},
+		{`title contains "retry to"`, true},
+		{"assignee", false},
+		{"not (type == bug or priority >= 3)", true},
+	}
+	for _, tt := range tests {
+		f, err := ParseFilter(tt.expr)
+		if err != nil {
+			t.Errorf("ParseFilter(%q) error: %v", tt.expr, err)
+			continue
+		}
+		if got := f.Match(b); got != tt.want {
+			t.Errorf("%q matched = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestParseFilterErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"size > 3",
+		"priority > high",
+		"title > x",
+		"labels == backend",
+		"estimate >",
+		"(priority > 1",
+		"priority > 1 priority",
+		`title contains "open`,
+	} {
+		if _, err := ParseFilter(expr); err == nil {
+			t.Errorf("ParseFilter(%q) should fail", expr)
+		}
+	}
+}
+
+func TestFilterUsesLabels(t *testing.T) {
+	f, _ := ParseFilter("estimate > 0 and not (labels has wip)")
+	if !f.UsesLabels() {
+		t.Error("UsesLabels() = false for a labels term")
+	}
+	f, _ = ParseFilter(`title contains labels`)
+	if f.UsesLabels() {
+		t.Error("UsesLabels() = true for a value that reads 'labels'")
+	}
+}
+
+func TestFilterReady(t *testing.T) {
+	beads := []ReadyBead{
+		{ID: "wt-a", EstimatedMinutes: 30, Labels: []string{}},
+		{ID: "wt-b", Labels: []string{}},
+		{ID: "wt-c", EstimatedMinutes: 60, Labels: []string{"needs-design"}},
+	}
+	kept, excluded, err := FilterReady(beads, "estimate > 0 and not labels has needs-design", "")
+	if err != nil {
+		t.Fatalf("FilterReady() error: %v", err)
+	}
+	if len(kept) != 1 || kept[0].ID != "wt-a" || !slices.Equal(excluded, []string{"wt-b", "wt-c"}) {
+		t.Errorf("FilterReady() = %v, %v", kept, excluded)
+	}
+
+	if kept, excluded, _ := FilterReady(beads, "  ", ""); len(kept) != 3 || excluded != nil {
+		t.Error("an empty filter should keep every bead")
+	}
+}
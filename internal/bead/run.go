@@ -0,0 +1,153 @@
+package bead
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/badri/wt/internal/filelock"
+	"github.com/badri/wt/internal/logging"
+)
+
+// Several wt processes (sessions closing beads, auto runs, the hub) shell
+// out to bd at the same time. bd keeps its state in a SQLite database and
+// a JSONL export under .beads/, and concurrent writers have left that
+// state corrupted. Every bd invocation therefore goes through Output or
+// CombinedOutput, which hold an advisory lock per beads directory and
+// retry when bd reports the database busy.
+
+var (
+	// lockTimeout bounds how long an invocation waits for the beads lock
+	lockTimeout = 2 * time.Minute
+	// busyRetries is how many times a busy bd invocation is retried
+	busyRetries = 5
+	// busyBackoff is the delay before the first retry; it doubles each time
+	busyBackoff = 200 * time.Millisecond
+)
+
+// Output runs bd with args in dir (the project directory containing
+// .beads/, or "" for the current directory) and returns its standard
+// output. On failure the *exec.ExitError carries bd's stderr.
+func Output(dir string, args ...string) ([]byte, error) {
+	return run(dir, args, false)
+}
+
+// CombinedOutput runs bd like Output and returns stdout and stderr together
+func CombinedOutput(dir string, args ...string) ([]byte, error) {
+	return run(dir, args, true)
+}
+
+func run(dir string, args []string, combined bool) ([]byte, error) {
+	beadsDir := locateBeadsDir(dir)
+	unlock, err := lockBeadsDir(beadsDir, readOnly(args))
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	return runLocked(dir, args, combined)
+}
+
+// runLocked runs bd for a caller already holding the beads lock, retrying
+// while the database is busy
+func runLocked(dir string, args []string, combined bool) ([]byte, error) {
+	delay := busyBackoff
+	for attempt := 0; ; attempt++ {
+		var stdout, stderr bytes.Buffer
+		cmd := logging.CommandIn(dir, "bd", args...)
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		if combined {
+			cmd.Stderr = &stdout
+		}
+		err := cmd.Run()
+		if err == nil {
+			return stdout.Bytes(), nil
+		}
+
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && !combined {
+			exitErr.Stderr = stderr.Bytes()
+		}
+		if attempt >= busyRetries || !isBusy(stdout.String()+stderr.String()) {
+			return stdout.Bytes(), err
+		}
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// locateBeadsDir returns the beads directory bd will use when run in dir:
+// $BEADS_DIR if set, else the nearest .beads/ at or above dir. Without one
+// the directory itself is used, which still serializes callers sharing it.
+func locateBeadsDir(dir string) string {
+	if env := os.Getenv("BEADS_DIR"); env != "" {
+		if abs, err := filepath.Abs(env); err == nil {
+			return abs
+		}
+		return env
+	}
+
+	start, err := filepath.Abs(dir)
+	if err != nil {
+		return dir
+	}
+	for d := start; ; {
+		candidate := filepath.Join(d, ".beads")
+		if info, err := os.Stat(candidate); err == nil && info.IsDir() {
+			return candidate
+		}
+		parent := filepath.Dir(d)
+		if parent == d {
+			return start
+		}
+		d = parent
+	}
+}
+
+// lockPath returns the lock file for a beads directory. Lock files live in
+// the temp dir rather than .beads/ so they never show up in git.
+func lockPath(beadsDir string) string {
+	sum := sha1.Sum([]byte(beadsDir))
+	return filepath.Join(os.TempDir(), "wt-bd-locks", hex.EncodeToString(sum[:8])+".lock")
+}
+
+// lockBeadsDir takes the advisory lock for beadsDir, shared for read-only
+// commands and exclusive otherwise, and returns a function releasing it
+func lockBeadsDir(beadsDir string, shared bool) (func(), error) {
+	unlock, err := filelock.Lock(lockPath(beadsDir), shared, lockTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("locking beads in %s: %w", beadsDir, err)
+	}
+	return unlock, nil
+}
+
+// readOnly reports whether a bd command only reads beads state
+func readOnly(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	switch args[0] {
+	case "show", "list", "ready", "search", "blocked", "stats", "prime", "version":
+		return true
+	case "dep", "comments":
+		// "bd dep list" and "bd comments <id>" read; their other forms write
+		return len(args) > 1 && (args[1] == "list" || args[0] == "comments" && args[1] != "add")
+	}
+	return false
+}
+
+// isBusy reports whether bd output says the database was locked by
+// another process, which is worth retrying
+func isBusy(output string) bool {
+	lower := strings.ToLower(output)
+	return strings.Contains(lower, "database is locked") ||
+		strings.Contains(lower, "sqlite_busy") ||
+		strings.Contains(lower, "database is busy")
+}
@@ -0,0 +1,168 @@
+package bead
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeBd puts a bd script running body first on PATH
+func fakeBd(t *testing.T, body string) {
+	t.Helper()
+	bin := t.TempDir()
+	script := "#!/bin/sh\n" + body + "\n"
+	if err := os.WriteFile(filepath.Join(bin, "bd"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("BEADS_DIR", "")
+}
+
+func TestLocateBeadsDir(t *testing.T) {
+	t.Setenv("BEADS_DIR", "")
+	project := t.TempDir()
+	beadsDir := filepath.Join(project, ".beads")
+	nested := filepath.Join(project, "internal", "pkg")
+	for _, dir := range []string{beadsDir, nested} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := locateBeadsDir(project); got != beadsDir {
+		t.Errorf("locateBeadsDir(project) = %s, want %s", got, beadsDir)
+	}
+	if got := locateBeadsDir(nested); got != beadsDir {
+		t.Errorf("locateBeadsDir(nested) = %s, want %s", got, beadsDir)
+	}
+
+	other := t.TempDir()
+	if got := locateBeadsDir(other); got != other {
+		t.Errorf("locateBeadsDir(no beads) = %s, want %s", got, other)
+	}
+
+	t.Setenv("BEADS_DIR", "/shared/.beads")
+	if got := locateBeadsDir(project); got != "/shared/.beads" {
+		t.Errorf("locateBeadsDir with BEADS_DIR = %s, want /shared/.beads", got)
+	}
+}
+
+func TestLockPath(t *testing.T) {
+	a := lockPath("/a/.beads")
+	if a != lockPath("/a/.beads") {
+		t.Error("lockPath should be stable for the same beads dir")
+	}
+	if a == lockPath("/b/.beads") {
+		t.Error("lockPath should differ between beads dirs")
+	}
+	if strings.Contains(a, "/a/.beads") {
+		t.Errorf("lockPath(%q) = %s, should live outside the beads dir", "/a/.beads", a)
+	}
+}
+
+func TestReadOnly(t *testing.T) {
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		{[]string{"show", "wt-abc", "--json"}, true},
+		{[]string{"list", "--json"}, true},
+		{[]string{"ready", "--json"}, true},
+		{[]string{"dep", "list", "wt-abc"}, true},
+		{[]string{"comments", "wt-abc"}, true},
+		{[]string{"comments", "add", "wt-abc", "note"}, false},
+		{[]string{"dep", "add", "wt-abc", "wt-def"}, false},
+		{[]string{"close", "wt-abc"}, false},
+		{[]string{"update", "wt-abc", "--status", "in_progress"}, false},
+		{[]string{"sync"}, false},
+		{nil, false},
+	}
+	for _, tc := range tests {
+		if got := readOnly(tc.args); got != tc.want {
+			t.Errorf("readOnly(%v) = %v, want %v", tc.args, got, tc.want)
+		}
+	}
+}
+
+func TestIsBusy(t *testing.T) {
+	if !isBusy("Error: database is locked") {
+		t.Error("expected 'database is locked' to be busy")
+	}
+	if !isBusy("sqlite3: SQLITE_BUSY") {
+		t.Error("expected SQLITE_BUSY to be busy")
+	}
+	if isBusy("Error: issue wt-xyz not found") {
+		t.Error("not found should not be busy")
+	}
+}
+
+func TestRunRetriesWhenBusy(t *testing.T) {
+	counter := filepath.Join(t.TempDir(), "attempts")
+	fakeBd(t, `echo x >> `+counter+`
+if [ $(wc -l < `+counter+`) -lt 3 ]; then echo "Error: database is locked" >&2; exit 1; fi
+echo closed`)
+
+	orig := busyBackoff
+	busyBackoff = time.Millisecond
+	defer func() { busyBackoff = orig }()
+
+	output, err := CombinedOutput(t.TempDir(), "close", "wt-abc")
+	if err != nil {
+		t.Fatalf("CombinedOutput() error = %v", err)
+	}
+	if strings.TrimSpace(string(output)) != "closed" {
+		t.Errorf("output = %q, want %q", output, "closed")
+	}
+	data, _ := os.ReadFile(counter)
+	if n := strings.Count(string(data), "x"); n != 3 {
+		t.Errorf("bd ran %d times, want 3", n)
+	}
+}
+
+func TestRunDoesNotRetryOtherErrors(t *testing.T) {
+	counter := filepath.Join(t.TempDir(), "attempts")
+	fakeBd(t, `echo x >> `+counter+`
+echo "Error: issue not found" >&2; exit 1`)
+
+	_, err := Output(t.TempDir(), "show", "wt-abc")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	data, _ := os.ReadFile(counter)
+	if n := strings.Count(string(data), "x"); n != 1 {
+		t.Errorf("bd ran %d times, want 1", n)
+	}
+}
+
+func TestRunSerializesWriters(t *testing.T) {
+	log := filepath.Join(t.TempDir(), "log")
+	fakeBd(t, `echo start >> `+log+`
+sleep 0.1
+echo end >> `+log)
+
+	project := t.TempDir()
+	if err := os.Mkdir(filepath.Join(project, ".beads"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := CombinedOutput(project, "sync"); err != nil {
+				t.Errorf("CombinedOutput() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	data, _ := os.ReadFile(log)
+	want := strings.Repeat("start\nend\n", 3)
+	if string(data) != want {
+		t.Errorf("bd invocations overlapped:\n%s", data)
+	}
+}
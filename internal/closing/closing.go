@@ -0,0 +1,53 @@
+// Package closing records beads whose closing 'wt done' put off because of
+// the project's bead_close policy. The records outlive the sessions so the
+// bead can be closed once its PR merges, or with an explicit 'wt close'.
+package closing
+
+import (
+	"path/filepath"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/jsonstore"
+)
+
+// Entry is a bead left open by 'wt done'
+type Entry struct {
+	Bead     string `json:"bead"`
+	Session  string `json:"session"`
+	Branch   string `json:"branch"`
+	Project  string `json:"project"`
+	RepoPath string `json:"repo_path"`
+	PRURL    string `json:"pr_url,omitempty"`
+	Mode     string `json:"mode"` // bead_close policy that kept it open: "merged" or "manual"
+}
+
+// Store holds the open entries, keyed by bead ID
+type Store struct {
+	*jsonstore.Store[Entry]
+}
+
+func storePath(cfg *config.Config) string {
+	return filepath.Join(cfg.ConfigDir(), "close-pending.json")
+}
+
+// Load reads the pending closes from the config directory
+func Load(cfg *config.Config) (*Store, error) {
+	s, err := jsonstore.Load[Entry](storePath(cfg))
+	if err != nil {
+		return nil, err
+	}
+	return &Store{s}, nil
+}
+
+// Update changes the pending closes under a lock, so two 'wt done' runs
+// finishing at once both keep their entry
+func Update(cfg *config.Config, fn func(s *Store) error) error {
+	return jsonstore.Update(storePath(cfg), func(s *jsonstore.Store[Entry]) error {
+		return fn(&Store{s})
+	})
+}
+
+// Put adds or replaces the entry for e.Bead
+func (s *Store) Put(e *Entry) {
+	s.Set(e.Bead, e)
+}
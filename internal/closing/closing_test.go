@@ -0,0 +1,48 @@
+package closing
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/badri/wt/internal/config"
+)
+
+func TestStoreRoundTrip(t *testing.T) {
+	cfg, err := config.LoadFromDir(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadFromDir() error: %v", err)
+	}
+
+	store, err := Load(cfg)
+	if err != nil {
+		t.Fatalf("Load() on missing file error: %v", err)
+	}
+	if len(store.Entries) != 0 {
+		t.Fatalf("new store has %d entries, want 0", len(store.Entries))
+	}
+
+	store.Put(&Entry{Bead: "wt-a", Branch: "wt-a", RepoPath: "/repo", PRURL: "https://github.com/o/r/pull/1", Mode: "merged"})
+	store.Put(&Entry{Bead: "wt-b", Branch: "wt-b", RepoPath: "/repo", Mode: "manual"})
+	if err := store.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	loaded, err := Load(cfg)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if e := loaded.Get("wt-a"); e == nil || e.Mode != "merged" {
+		t.Fatalf("Get(wt-a) = %+v, want the merged entry", e)
+	}
+
+	// The file goes away with the last entry
+	loaded.Remove("wt-a")
+	loaded.Remove("wt-b")
+	if err := loaded.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(cfg.ConfigDir(), "close-pending.json")); !os.IsNotExist(err) {
+		t.Errorf("store file still exists after removing every entry: %v", err)
+	}
+}
@@ -5,23 +5,38 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 type Config struct {
 	WorktreeRoot     string `json:"worktree_root"`
 	EditorCmd        string `json:"editor_cmd"`
 	DefaultMergeMode string `json:"default_merge_mode"`
+	IdleDetection    string `json:"idle_detection,omitempty"`  // "tmux" (default) or "transcript"
+	TmuxStatus       bool   `json:"tmux_status,omitempty"`     // Show bead, status and idle time in session status lines
+	OpenApp          string `json:"open_app,omitempty"`        // Editor 'wt open' opens worktrees in (default "code")
+	Claimant         string `json:"claimant,omitempty"`        // Identity recorded on claimed beads (default user@host[/profile])
+	ClaimTTL         string `json:"claim_ttl,omitempty"`       // Age after which another hub may take over a claim (default 24h)
+	ContextHandoff   int    `json:"context_handoff,omitempty"` // Context window percent at which wt watch asks an agent to hand off (0 = off)
+	ContextWindow    int    `json:"context_window,omitempty"`  // Context window size in tokens (default 200000)
+
+	Notifications *Notifications   `json:"notifications,omitempty"` // Desktop notification delivery (digest mode)
+	Events        *EventsRetention `json:"events,omitempty"`        // Rotation and retention of the events log
+	Queue         *Queue           `json:"queue,omitempty"`         // External work queue consumed by 'wt auto --queue'
 
 	// Internal paths
 	configDir string
+	profile   string
+
+	// Where values came from (see Source)
+	fileKeys   map[string]bool
+	envValues  map[string]string // key -> value taken from a WT_* variable
+	fileValues map[string]string // key -> value the override replaced
 }
 
+// Load loads the config of the active profile (see ResolveProfile)
 func Load() (*Config, error) {
-	configDir, err := getConfigDir()
-	if err != nil {
-		return nil, err
-	}
-	return LoadFromDir(configDir)
+	return LoadProfile("")
 }
 
 func LoadFromDir(configDir string) (*Config, error) {
@@ -44,10 +59,15 @@ func LoadFromDir(configDir string) (*Config, error) {
 		if err := json.Unmarshal(data, cfg); err != nil {
 			return nil, err
 		}
+		cfg.recordFileKeys(data)
 	} else if !os.IsNotExist(err) {
 		return nil, err
 	}
 
+	if err := cfg.applyEnv(); err != nil {
+		return nil, err
+	}
+
 	// Ensure namepool exists
 	if err := cfg.ensureNamepool(); err != nil {
 		return nil, err
@@ -61,6 +81,53 @@ func LoadFromDir(configDir string) (*Config, error) {
 	return cfg, nil
 }
 
+// UseTranscriptActivity reports whether session activity is read from Claude
+// transcripts instead of tmux pane activity.
+func (c *Config) UseTranscriptActivity() bool {
+	return c.IdleDetection == "transcript"
+}
+
+// OpenAppCmd returns the editor command for 'wt open'
+func (c *Config) OpenAppCmd() string {
+	if c.OpenApp == "" {
+		return "code"
+	}
+	return c.OpenApp
+}
+
+// DefaultClaimTTL is how long a bead claim holds when claim_ttl is unset
+const DefaultClaimTTL = 24 * time.Hour
+
+// ClaimantID returns the identity 'wt new' records on the beads it claims.
+// It defaults to user@host, with the profile appended for non-default
+// profiles so two hubs on one machine don't share claims.
+func (c *Config) ClaimantID() string {
+	if c.Claimant != "" {
+		return c.Claimant
+	}
+	user := os.Getenv("USER")
+	if user == "" {
+		user = "wt"
+	}
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "localhost"
+	}
+	id := user + "@" + strings.Split(host, ".")[0]
+	if p := c.Profile(); p != DefaultProfile {
+		id += "/" + p
+	}
+	return id
+}
+
+// ClaimExpiry returns how old a claim must be before it counts as stale
+func (c *Config) ClaimExpiry() time.Duration {
+	if d, err := time.ParseDuration(c.ClaimTTL); err == nil && d > 0 {
+		return d
+	}
+	return DefaultClaimTTL
+}
+
 func (c *Config) ConfigDir() string {
 	return c.configDir
 }
@@ -73,18 +140,36 @@ func (c *Config) SessionsPath() string {
 	return filepath.Join(c.configDir, "sessions.json")
 }
 
+// WorktreePath returns the flat-layout path worktree_root/<name>, used for
+// sessions without a project and by worktrees created before project
+// namespacing
 func (c *Config) WorktreePath(sessionName string) string {
-	root := expandPath(c.WorktreeRoot)
-	return filepath.Join(root, sessionName)
+	return filepath.Join(c.WorktreeRootPath(), sessionName)
+}
+
+// ProjectWorktreePath returns <root>/<project>/<name>, so equal bead IDs or
+// session names in different projects never share a directory. The root is
+// the project's worktree_root if it has one (see ProjectWorktreeRoot).
+// Without a project it falls back to WorktreePath.
+func (c *Config) ProjectWorktreePath(project, name string) string {
+	if project == "" {
+		return c.WorktreePath(name)
+	}
+	return filepath.Join(c.ProjectWorktreeRoot(project), project, name)
+}
+
+// WorktreeRootPath returns worktree_root with ~ expanded
+func (c *Config) WorktreeRootPath() string {
+	return expandPath(c.WorktreeRoot)
 }
 
 func (c *Config) ConfigPath() string {
 	return filepath.Join(c.configDir, "config.json")
 }
 
-// Save writes the config to disk
+// Save writes the config to disk, leaving out environment overrides
 func (c *Config) Save() error {
-	data, err := json.MarshalIndent(c, "", "  ")
+	data, err := json.MarshalIndent(c.withoutEnv(), "", "  ")
 	if err != nil {
 		return err
 	}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestLoadFromDir_CreatesDefaultFiles(t *testing.T) {
@@ -123,6 +124,13 @@ func TestWorktreePath(t *testing.T) {
 	if cfg.WorktreePath("mysession") != expected {
 		t.Errorf("expected WorktreePath %q, got %q", expected, cfg.WorktreePath("mysession"))
 	}
+
+	if got := cfg.ProjectWorktreePath("api", "wt-abc"); got != "/tmp/test-worktrees/api/wt-abc" {
+		t.Errorf("ProjectWorktreePath(api) = %q", got)
+	}
+	if got := cfg.ProjectWorktreePath("", "mysession"); got != expected {
+		t.Errorf("ProjectWorktreePath without project = %q, want %q", got, expected)
+	}
 }
 
 func TestExpandPath(t *testing.T) {
@@ -176,3 +184,46 @@ func containsHelper(s, substr string) bool {
 	}
 	return false
 }
+
+func TestClaimSettings(t *testing.T) {
+	cfg, _ := LoadFromDir(t.TempDir())
+	if cfg.ClaimExpiry() != DefaultClaimTTL {
+		t.Errorf("ClaimExpiry() = %s, want default %s", cfg.ClaimExpiry(), DefaultClaimTTL)
+	}
+	if id := cfg.ClaimantID(); !contains(id, "@") || contains(id, "/") {
+		t.Errorf("ClaimantID() = %q, want user@host", id)
+	}
+
+	cfg.ClaimTTL = "90m"
+	if cfg.ClaimExpiry() != 90*time.Minute {
+		t.Errorf("ClaimExpiry() = %s, want 1h30m", cfg.ClaimExpiry())
+	}
+	cfg.profile = "work"
+	if id := cfg.ClaimantID(); !contains(id, "/work") {
+		t.Errorf("ClaimantID() = %q, want the profile appended", id)
+	}
+	cfg.Claimant = "ci-runner"
+	if cfg.ClaimantID() != "ci-runner" {
+		t.Errorf("ClaimantID() = %q, want the configured claimant", cfg.ClaimantID())
+	}
+}
+
+func TestProjectWorktreeRoot(t *testing.T) {
+	dir := t.TempDir()
+	cfg, _ := LoadFromDir(dir)
+	cfg.WorktreeRoot = "/wt"
+	projects := filepath.Join(dir, "projects")
+	os.MkdirAll(projects, 0755)
+	os.WriteFile(filepath.Join(projects, "mono.json"), []byte(`{"name":"mono","worktree_root":"/big/wt"}`), 0644)
+	os.WriteFile(filepath.Join(projects, "web.json"), []byte(`{"name":"web"}`), 0644)
+
+	if got := cfg.ProjectWorktreePath("mono", "mono-abc"); got != "/big/wt/mono/mono-abc" {
+		t.Errorf("ProjectWorktreePath(mono) = %q", got)
+	}
+	if got := cfg.ProjectWorktreePath("web", "web-abc"); got != "/wt/web/web-abc" {
+		t.Errorf("ProjectWorktreePath(web) = %q", got)
+	}
+	if got := cfg.WorktreeRoots(); len(got) != 2 || got[0] != "/wt" || got[1] != "/big/wt" {
+		t.Errorf("WorktreeRoots() = %v, want [/wt /big/wt]", got)
+	}
+}
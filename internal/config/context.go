@@ -0,0 +1,44 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DefaultContextWindow is the context window of current Claude models, in tokens
+const DefaultContextWindow = 200000
+
+// ContextWindowTokens returns the context window size the handoff threshold
+// is measured against
+func (c *Config) ContextWindowTokens() int64 {
+	if c.ContextWindow > 0 {
+		return int64(c.ContextWindow)
+	}
+	return DefaultContextWindow
+}
+
+// SetContextHandoff sets the context handoff threshold from a percentage
+// such as "80" or "80%"; "off" or "0" disables it
+func (c *Config) SetContextHandoff(value string) error {
+	if value == "off" || value == "0" {
+		c.ContextHandoff = 0
+		return nil
+	}
+	pct, err := strconv.Atoi(strings.TrimSuffix(value, "%"))
+	if err != nil || pct < 1 || pct > 100 {
+		return fmt.Errorf("invalid context handoff threshold: %s (use a percentage like 80, or off)", value)
+	}
+	c.ContextHandoff = pct
+	return nil
+}
+
+// SetContextWindow sets the context window size in tokens
+func (c *Config) SetContextWindow(value string) error {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1000 {
+		return fmt.Errorf("invalid context window: %s (use a token count like 200000)", value)
+	}
+	c.ContextWindow = n
+	return nil
+}
@@ -0,0 +1,28 @@
+package config
+
+import "testing"
+
+func TestContextHandoffSettings(t *testing.T) {
+	cfg := &Config{}
+	if cfg.ContextWindowTokens() != DefaultContextWindow {
+		t.Errorf("ContextWindowTokens() = %d, want default", cfg.ContextWindowTokens())
+	}
+
+	for value, want := range map[string]int{"80": 80, "75%": 75, "off": 0, "0": 0} {
+		if err := cfg.SetContextHandoff(value); err != nil || cfg.ContextHandoff != want {
+			t.Errorf("SetContextHandoff(%q) = %d, %v, want %d", value, cfg.ContextHandoff, err, want)
+		}
+	}
+	for _, bad := range []string{"soon", "120", "-5"} {
+		if err := cfg.SetContextHandoff(bad); err == nil {
+			t.Errorf("SetContextHandoff(%q) should fail", bad)
+		}
+	}
+
+	if err := cfg.SetContextWindow("1000000"); err != nil || cfg.ContextWindowTokens() != 1000000 {
+		t.Errorf("SetContextWindow() = %d, %v", cfg.ContextWindowTokens(), err)
+	}
+	if err := cfg.SetContextWindow("big"); err == nil {
+		t.Error("SetContextWindow(big) should fail")
+	}
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// DefaultSMTPPort is the submission port, which uses STARTTLS
+const DefaultSMTPPort = 587
+
+// Email configures the email sink: SMTP settings, whether notifications
+// are emailed, and when the daily summary goes out
+type Email struct {
+	SMTPHost     string   `json:"smtp_host"`
+	SMTPPort     int      `json:"smtp_port,omitempty"` // default 587 (STARTTLS); 465 uses implicit TLS
+	Username     string   `json:"username,omitempty"`
+	Password     string   `json:"password,omitempty"`
+	PasswordEnv  string   `json:"password_env,omitempty"` // environment variable holding the password
+	From         string   `json:"from"`
+	To           []string `json:"to"`
+	Notify       bool     `json:"notify,omitempty"`        // also email the notifications wt watch sends
+	DailySummary string   `json:"daily_summary,omitempty"` // local time the daily summary is sent, e.g. "18:00"
+}
+
+// EmailSettings returns the email sink's settings, nil unless an SMTP
+// host, a sender and at least one recipient are configured
+func (c *Config) EmailSettings() *Email {
+	if c.Notifications == nil || c.Notifications.Email == nil {
+		return nil
+	}
+	e := c.Notifications.Email
+	if e.SMTPHost == "" || e.From == "" || len(e.To) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Port returns the SMTP port, 587 by default
+func (e *Email) Port() int {
+	if e.SMTPPort > 0 {
+		return e.SMTPPort
+	}
+	return DefaultSMTPPort
+}
+
+// SMTPPassword returns the password, read from PasswordEnv when set so it
+// can stay out of the config file
+func (e *Email) SMTPPassword() string {
+	if e.PasswordEnv != "" {
+		return os.Getenv(e.PasswordEnv)
+	}
+	return e.Password
+}
+
+// SummaryTime returns today's send time of the daily summary in now's
+// location; ok is false when no daily summary is configured
+func (e *Email) SummaryTime(now time.Time) (at time.Time, ok bool, err error) {
+	if e.DailySummary == "" {
+		return time.Time{}, false, nil
+	}
+	t, err := time.Parse("15:04", e.DailySummary)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid daily_summary %q: use a time like 18:00", e.DailySummary)
+	}
+	return time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location()), true, nil
+}
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEmailSettings(t *testing.T) {
+	cfg := &Config{Notifications: &Notifications{Email: &Email{SMTPHost: "smtp.example.com", From: "wt@example.com"}}}
+	if cfg.EmailSettings() != nil {
+		t.Error("EmailSettings() without recipients should be nil")
+	}
+	cfg.Notifications.Email.To = []string{"team@example.com"}
+	e := cfg.EmailSettings()
+	if e == nil || e.Port() != DefaultSMTPPort {
+		t.Fatalf("EmailSettings() = %+v", e)
+	}
+
+	t.Setenv("WT_TEST_SMTP_PASSWORD", "s3cret")
+	e.Password, e.PasswordEnv = "in-file", "WT_TEST_SMTP_PASSWORD"
+	if got := e.SMTPPassword(); got != "s3cret" {
+		t.Errorf("SMTPPassword() = %q, want the environment's", got)
+	}
+
+	now := time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC)
+	if _, ok, err := e.SummaryTime(now); ok || err != nil {
+		t.Errorf("SummaryTime() without daily_summary = %v, %v", ok, err)
+	}
+	e.DailySummary = "18:00"
+	if at, ok, err := e.SummaryTime(now); !ok || err != nil || !at.Equal(time.Date(2026, 3, 2, 18, 0, 0, 0, time.UTC)) {
+		t.Errorf("SummaryTime() = %v, %v, %v", at, ok, err)
+	}
+	e.DailySummary = "6pm"
+	if _, _, err := e.SummaryTime(now); err == nil {
+		t.Error("SummaryTime() should reject 6pm")
+	}
+}
@@ -0,0 +1,112 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Environment variables that override config.json, so CI jobs can drive wt
+// without writing a config file
+const (
+	WorktreeRootEnv = "WT_WORKTREE_ROOT"
+	EditorCmdEnv    = "WT_EDITOR_CMD"
+	MergeModeEnv    = "WT_MERGE_MODE"
+)
+
+// Where a config value comes from
+const (
+	SourceFile    = "file"
+	SourceEnv     = "env"
+	SourceDefault = "default"
+)
+
+// envOverride ties a config key to the environment variable overriding it
+type envOverride struct {
+	key   string
+	env   string
+	field func(*Config) *string
+}
+
+var envOverrides = []envOverride{
+	{"worktree_root", WorktreeRootEnv, func(c *Config) *string { return &c.WorktreeRoot }},
+	{"editor_cmd", EditorCmdEnv, func(c *Config) *string { return &c.EditorCmd }},
+	{"default_merge_mode", MergeModeEnv, func(c *Config) *string { return &c.DefaultMergeMode }},
+}
+
+// ValidMergeMode reports whether mode is one of direct, pr-auto, pr-review
+func ValidMergeMode(mode string) bool {
+	return mode == "direct" || mode == "pr-auto" || mode == "pr-review"
+}
+
+// recordFileKeys remembers which top-level keys config.json sets, for Source
+func (c *Config) recordFileKeys(data []byte) {
+	var raw map[string]json.RawMessage
+	if json.Unmarshal(data, &raw) != nil {
+		return
+	}
+	c.fileKeys = make(map[string]bool, len(raw))
+	for k := range raw {
+		c.fileKeys[k] = true
+	}
+}
+
+// applyEnv overrides config values with the WT_* environment variables.
+// The replaced values are kept so Save never writes an override to disk.
+func (c *Config) applyEnv() error {
+	for _, o := range envOverrides {
+		value := os.Getenv(o.env)
+		if value == "" {
+			continue
+		}
+		if o.key == "default_merge_mode" && !ValidMergeMode(value) {
+			return fmt.Errorf("%s: invalid merge mode %q (valid: direct, pr-auto, pr-review)", o.env, value)
+		}
+		if c.envValues == nil {
+			c.envValues = make(map[string]string)
+			c.fileValues = make(map[string]string)
+		}
+		field := o.field(c)
+		c.fileValues[o.key] = *field
+		c.envValues[o.key] = value
+		*field = value
+	}
+	return nil
+}
+
+// withoutEnv returns a copy of the config holding the file values of keys
+// that are still overridden from the environment. A key set since loading
+// keeps its new value.
+func (c *Config) withoutEnv() *Config {
+	out := *c
+	for _, o := range envOverrides {
+		env, ok := c.envValues[o.key]
+		if field := o.field(&out); ok && *field == env {
+			*field = c.fileValues[o.key]
+		}
+	}
+	return &out
+}
+
+// EnvVar returns the environment variable overriding a config key, or ""
+// when the key can't be overridden
+func EnvVar(key string) string {
+	for _, o := range envOverrides {
+		if o.key == key {
+			return o.env
+		}
+	}
+	return ""
+}
+
+// Source reports where the value of a top-level config key comes from:
+// SourceEnv, SourceFile or SourceDefault
+func (c *Config) Source(key string) string {
+	if _, ok := c.envValues[key]; ok {
+		return SourceEnv
+	}
+	if c.fileKeys[key] {
+		return SourceFile
+	}
+	return SourceDefault
+}
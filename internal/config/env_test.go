@@ -0,0 +1,78 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEnvOverrides(t *testing.T) {
+	dir := t.TempDir()
+	file := `{"worktree_root": "/file/worktrees", "editor_cmd": "vim"}`
+	if err := os.WriteFile(filepath.Join(dir, "config.json"), []byte(file), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(WorktreeRootEnv, "/ci/worktrees")
+	t.Setenv(MergeModeEnv, "direct")
+
+	cfg, err := LoadFromDir(dir)
+	if err != nil {
+		t.Fatalf("LoadFromDir: %v", err)
+	}
+	if cfg.WorktreeRoot != "/ci/worktrees" || cfg.DefaultMergeMode != "direct" {
+		t.Errorf("env not applied: root %q, merge %q", cfg.WorktreeRoot, cfg.DefaultMergeMode)
+	}
+	for key, want := range map[string]string{
+		"worktree_root":      SourceEnv,
+		"editor_cmd":         SourceFile,
+		"default_merge_mode": SourceEnv,
+		"open_app":           SourceDefault,
+	} {
+		if got := cfg.Source(key); got != want {
+			t.Errorf("Source(%s) = %q, want %q", key, got, want)
+		}
+	}
+
+	// Saving writes the file values back, plus anything set since loading
+	cfg.OpenApp = "cursor"
+	cfg.DefaultMergeMode = "pr-auto"
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(cfg.ConfigPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var saved Config
+	if err := json.Unmarshal(data, &saved); err != nil {
+		t.Fatal(err)
+	}
+	if saved.WorktreeRoot != "/file/worktrees" {
+		t.Errorf("saved worktree_root = %q, want the file value", saved.WorktreeRoot)
+	}
+	if saved.DefaultMergeMode != "pr-auto" || saved.OpenApp != "cursor" {
+		t.Errorf("saved merge %q, open app %q; want the values set after loading", saved.DefaultMergeMode, saved.OpenApp)
+	}
+	if cfg.WorktreeRoot != "/ci/worktrees" {
+		t.Errorf("Save changed the in-memory override to %q", cfg.WorktreeRoot)
+	}
+}
+
+func TestEnvOverrideInvalidMergeMode(t *testing.T) {
+	t.Setenv(MergeModeEnv, "yolo")
+	if _, err := LoadFromDir(t.TempDir()); err == nil {
+		t.Error("expected an error for an invalid WT_MERGE_MODE")
+	}
+}
+
+func TestEnvOverrideNamedProfile(t *testing.T) {
+	t.Setenv(WorktreeRootEnv, "/ci/worktrees")
+	cfg, err := loadProfileFromBase(t.TempDir(), "work")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.WorktreeRoot != "/ci/worktrees" {
+		t.Errorf("WorktreeRoot = %q, want the env override over the profile default", cfg.WorktreeRoot)
+	}
+}
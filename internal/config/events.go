@@ -0,0 +1,120 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultEventsMaxSize is the size past which events.jsonl is rotated when
+// events.max_size is unset
+const DefaultEventsMaxSize = 10 << 20
+
+// EventsRetention configures rotation and retention of the events log
+type EventsRetention struct {
+	MaxAge  string `json:"max_age,omitempty"`  // e.g. "90d"; unset keeps events forever
+	MaxSize string `json:"max_size,omitempty"` // rotate events.jsonl past this size, e.g. "10MB"; "off" never rotates on size
+}
+
+// EventsMaxAge returns how long events are kept, 0 for forever
+func (c *Config) EventsMaxAge() time.Duration {
+	if c.Events == nil || c.Events.MaxAge == "" {
+		return 0
+	}
+	d, err := ParseAge(c.Events.MaxAge)
+	if err != nil {
+		return 0
+	}
+	return d
+}
+
+// EventsMaxSize returns the size in bytes past which events.jsonl is
+// rotated, 0 when rotation by size is off
+func (c *Config) EventsMaxSize() int64 {
+	if c.Events == nil || c.Events.MaxSize == "" {
+		return DefaultEventsMaxSize
+	}
+	if c.Events.MaxSize == "off" {
+		return 0
+	}
+	n, err := ParseSize(c.Events.MaxSize)
+	if err != nil {
+		return DefaultEventsMaxSize
+	}
+	return n
+}
+
+// SetEventsMaxAge sets how long events are kept; "off" keeps them forever
+func (c *Config) SetEventsMaxAge(value string) error {
+	if value == "off" || value == "0" {
+		value = ""
+	} else if _, err := ParseAge(value); err != nil {
+		return fmt.Errorf("invalid events max age: %s (use a duration like 90d or 720h, or off)", value)
+	}
+	if c.Events == nil {
+		c.Events = &EventsRetention{}
+	}
+	c.Events.MaxAge = value
+	return nil
+}
+
+// SetEventsMaxSize sets the rotation size; "off" only rotates on age
+func (c *Config) SetEventsMaxSize(value string) error {
+	if value == "off" || value == "0" {
+		value = "off"
+	} else if _, err := ParseSize(value); err != nil {
+		return fmt.Errorf("invalid events max size: %s (use a size like 10MB or 500KB, or off)", value)
+	}
+	if c.Events == nil {
+		c.Events = &EventsRetention{}
+	}
+	c.Events.MaxSize = value
+	return nil
+}
+
+// ParseAge parses a positive duration, allowing days and weeks: "90d",
+// "2w", "36h"
+func ParseAge(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	unit := time.Duration(0)
+	switch {
+	case strings.HasSuffix(s, "d"):
+		unit = 24 * time.Hour
+	case strings.HasSuffix(s, "w"):
+		unit = 7 * 24 * time.Hour
+	}
+	if unit > 0 {
+		n, err := strconv.Atoi(s[:len(s)-1])
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid duration: %s", s)
+		}
+		return time.Duration(n) * unit, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid duration: %s", s)
+	}
+	return d, nil
+}
+
+// ParseSize parses a positive size in bytes, with an optional KB, MB or GB
+// suffix (powers of 1024): "10MB", "512KB", "2048"
+func ParseSize(s string) (int64, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	mult := int64(1)
+	for _, u := range []struct {
+		suffix string
+		mult   int64
+	}{{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"G", 1 << 30}, {"M", 1 << 20}, {"K", 1 << 10}, {"B", 1}} {
+		if strings.HasSuffix(s, u.suffix) {
+			s, mult = strings.TrimSpace(strings.TrimSuffix(s, u.suffix)), u.mult
+			break
+		}
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid size: %s", s)
+	}
+	return n * mult, nil
+}
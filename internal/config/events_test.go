@@ -0,0 +1,48 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseAgeAndSize(t *testing.T) {
+	for in, want := range map[string]time.Duration{"90d": 90 * 24 * time.Hour, "2w": 14 * 24 * time.Hour, "36h": 36 * time.Hour} {
+		if got, err := ParseAge(in); err != nil || got != want {
+			t.Errorf("ParseAge(%q) = %v, %v, want %v", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{"", "d", "-3d", "soon", "0h"} {
+		if _, err := ParseAge(bad); err == nil {
+			t.Errorf("ParseAge(%q) should fail", bad)
+		}
+	}
+	for in, want := range map[string]int64{"10MB": 10 << 20, "512kb": 512 << 10, "1G": 1 << 30, "2048": 2048, "100 B": 100} {
+		if got, err := ParseSize(in); err != nil || got != want {
+			t.Errorf("ParseSize(%q) = %d, %v, want %d", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{"", "MB", "-1MB", "ten"} {
+		if _, err := ParseSize(bad); err == nil {
+			t.Errorf("ParseSize(%q) should fail", bad)
+		}
+	}
+}
+
+func TestEventsRetention(t *testing.T) {
+	cfg := &Config{}
+	if cfg.EventsMaxSize() != DefaultEventsMaxSize || cfg.EventsMaxAge() != 0 {
+		t.Fatalf("defaults: size %d, age %v", cfg.EventsMaxSize(), cfg.EventsMaxAge())
+	}
+	if err := cfg.SetEventsMaxAge("30d"); err != nil || cfg.EventsMaxAge() != 30*24*time.Hour {
+		t.Errorf("SetEventsMaxAge(30d): %v, age %v", err, cfg.EventsMaxAge())
+	}
+	if err := cfg.SetEventsMaxSize("off"); err != nil || cfg.EventsMaxSize() != 0 {
+		t.Errorf("SetEventsMaxSize(off): %v, size %d", err, cfg.EventsMaxSize())
+	}
+	if err := cfg.SetEventsMaxAge("off"); err != nil || cfg.EventsMaxAge() != 0 {
+		t.Errorf("SetEventsMaxAge(off): %v, age %v", err, cfg.EventsMaxAge())
+	}
+	if err := cfg.SetEventsMaxSize("huge"); err == nil {
+		t.Error("SetEventsMaxSize(huge) should fail")
+	}
+}
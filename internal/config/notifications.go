@@ -0,0 +1,92 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Notification delivery modes
+const (
+	NotifyImmediate = "immediate" // send a desktop notification right away
+	NotifyDigest    = "digest"    // batch into the next digest notification
+	NotifyOff       = "off"       // don't notify
+)
+
+// NotifyEvents are the event types that trigger desktop notifications
+var NotifyEvents = []string{"idle", "ready", "blocked", "error", "ended", "permission", "checks", "context"}
+
+// Notifications configures how desktop notifications are delivered
+type Notifications struct {
+	Digest string            `json:"digest,omitempty"` // batch window, e.g. "15m"; empty sends every notification at once
+	Events map[string]string `json:"events,omitempty"` // event type -> immediate, digest or off
+	Email  *Email            `json:"email,omitempty"`  // SMTP delivery of notifications and the daily summary
+}
+
+// DigestWindow returns how long notifications are batched, 0 when digest
+// mode is off
+func (c *Config) DigestWindow() time.Duration {
+	if c.Notifications == nil || c.Notifications.Digest == "" {
+		return 0
+	}
+	d, err := time.ParseDuration(c.Notifications.Digest)
+	if err != nil || d < 0 {
+		return 0
+	}
+	return d
+}
+
+// NotifyMode returns how notifications of an event type are delivered. In
+// digest mode errors still go out immediately unless configured otherwise;
+// without a digest window, "digest" falls back to immediate.
+func (c *Config) NotifyMode(eventType string) string {
+	mode := ""
+	if c.Notifications != nil {
+		mode = c.Notifications.Events[eventType]
+	}
+	digest := c.DigestWindow() > 0
+	switch {
+	case mode == NotifyOff:
+		return NotifyOff
+	case !digest:
+		return NotifyImmediate
+	case mode != "":
+		return mode
+	case eventType == "error":
+		return NotifyImmediate
+	default:
+		return NotifyDigest
+	}
+}
+
+// SetNotifyDigest sets the digest window; "off" or "0" disables digest mode
+func (c *Config) SetNotifyDigest(value string) error {
+	if value == "off" || value == "0" {
+		value = ""
+	} else if d, err := time.ParseDuration(value); err != nil || d <= 0 {
+		return fmt.Errorf("invalid digest window: %s (use a duration like 15m, or off)", value)
+	}
+	if c.Notifications == nil {
+		c.Notifications = &Notifications{}
+	}
+	c.Notifications.Digest = value
+	return nil
+}
+
+// SetNotifyMode sets the delivery mode of one event type
+func (c *Config) SetNotifyMode(eventType, mode string) error {
+	if !slices.Contains(NotifyEvents, eventType) {
+		return fmt.Errorf("unknown notification event: %s (valid: %v)", eventType, NotifyEvents)
+	}
+	if mode != NotifyImmediate && mode != NotifyDigest && mode != NotifyOff {
+		return fmt.Errorf("invalid notification mode: %s (valid: immediate, digest, off)", mode)
+	}
+	if c.Notifications == nil {
+		c.Notifications = &Notifications{}
+	}
+	if c.Notifications.Events == nil {
+		c.Notifications.Events = make(map[string]string)
+	}
+	c.Notifications.Events[eventType] = mode
+	return nil
+}
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNotifyMode(t *testing.T) {
+	cfg := &Config{}
+	if cfg.DigestWindow() != 0 || cfg.NotifyMode("idle") != NotifyImmediate {
+		t.Errorf("without a digest window everything should be immediate")
+	}
+
+	if err := cfg.SetNotifyDigest("15m"); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.DigestWindow() != 15*time.Minute {
+		t.Errorf("DigestWindow() = %v, want 15m", cfg.DigestWindow())
+	}
+	tests := map[string]string{
+		"idle":       NotifyDigest,
+		"ended":      NotifyDigest,
+		"permission": NotifyDigest,
+		"error":      NotifyImmediate,
+	}
+	for eventType, want := range tests {
+		if got := cfg.NotifyMode(eventType); got != want {
+			t.Errorf("NotifyMode(%s) = %s, want %s", eventType, got, want)
+		}
+	}
+
+	if err := cfg.SetNotifyMode("permission", NotifyImmediate); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.SetNotifyMode("idle", NotifyOff); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.SetNotifyMode("error", NotifyDigest); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.NotifyMode("permission") != NotifyImmediate || cfg.NotifyMode("idle") != NotifyOff || cfg.NotifyMode("error") != NotifyDigest {
+		t.Errorf("per-event modes not applied: %+v", cfg.Notifications.Events)
+	}
+
+	// Turning the digest off sends batched events immediately; "off" stays off
+	if err := cfg.SetNotifyDigest("off"); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.NotifyMode("error") != NotifyImmediate || cfg.NotifyMode("idle") != NotifyOff {
+		t.Errorf("after digest off: error=%s idle=%s", cfg.NotifyMode("error"), cfg.NotifyMode("idle"))
+	}
+
+	for _, bad := range []string{"soon", "-5m", "0s"} {
+		if err := cfg.SetNotifyDigest(bad); err == nil {
+			t.Errorf("SetNotifyDigest(%q) should fail", bad)
+		}
+	}
+	if err := cfg.SetNotifyMode("merged-ish", NotifyOff); err == nil {
+		t.Error("SetNotifyMode with an unknown event should fail")
+	}
+	if err := cfg.SetNotifyMode("idle", "sometimes"); err == nil {
+		t.Error("SetNotifyMode with an unknown mode should fail")
+	}
+}
@@ -0,0 +1,162 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	// ProfileEnv selects a config profile, like the global --profile flag
+	ProfileEnv = "WT_PROFILE"
+
+	// DefaultProfile is the profile stored directly in ~/.config/wt
+	DefaultProfile = "default"
+
+	currentProfileFile = "current_profile"
+)
+
+// BaseDir returns the wt config directory that holds the default profile
+// and the profiles/ directory
+func BaseDir() (string, error) {
+	return getConfigDir()
+}
+
+// ProfileDir returns the config directory of a profile
+func ProfileDir(baseDir, name string) string {
+	if name == "" || name == DefaultProfile {
+		return baseDir
+	}
+	return filepath.Join(baseDir, "profiles", name)
+}
+
+// ValidateProfileName rejects names that can't be used as a directory
+func ValidateProfileName(name string) error {
+	if name == "" {
+		return fmt.Errorf("profile name is empty")
+	}
+	if strings.HasPrefix(name, ".") || strings.ContainsAny(name, `/\ `) {
+		return fmt.Errorf("invalid profile name %q: use letters, digits, '-' and '_'", name)
+	}
+	return nil
+}
+
+// ResolveProfile picks the profile to use: the --profile flag, then
+// WT_PROFILE, then the one chosen with 'wt config profile switch'.
+func ResolveProfile(baseDir, flag string) string {
+	if flag != "" {
+		return flag
+	}
+	if env := os.Getenv(ProfileEnv); env != "" {
+		return env
+	}
+	return CurrentProfile(baseDir)
+}
+
+// CurrentProfile returns the profile selected with SwitchProfile
+func CurrentProfile(baseDir string) string {
+	data, err := os.ReadFile(filepath.Join(baseDir, currentProfileFile))
+	if err != nil {
+		return DefaultProfile
+	}
+	if name := strings.TrimSpace(string(data)); name != "" {
+		return name
+	}
+	return DefaultProfile
+}
+
+// SwitchProfile makes name the profile used when neither --profile nor
+// WT_PROFILE is given
+func SwitchProfile(baseDir, name string) error {
+	if err := ValidateProfileName(name); err != nil {
+		return err
+	}
+	path := filepath.Join(baseDir, currentProfileFile)
+	if name == DefaultProfile {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	return os.WriteFile(path, []byte(name+"\n"), 0644)
+}
+
+// ProfileExists reports whether a profile has been created
+func ProfileExists(baseDir, name string) bool {
+	if name == DefaultProfile {
+		return true
+	}
+	info, err := os.Stat(ProfileDir(baseDir, name))
+	return err == nil && info.IsDir()
+}
+
+// ListProfiles returns the default profile followed by the named profiles
+func ListProfiles(baseDir string) ([]string, error) {
+	profiles := []string{DefaultProfile}
+	entries, err := os.ReadDir(filepath.Join(baseDir, "profiles"))
+	if os.IsNotExist(err) {
+		return profiles, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var named []string
+	for _, e := range entries {
+		if e.IsDir() && ValidateProfileName(e.Name()) == nil && e.Name() != DefaultProfile {
+			named = append(named, e.Name())
+		}
+	}
+	sort.Strings(named)
+	return append(profiles, named...), nil
+}
+
+// LoadProfile loads the config of a profile, resolving an empty name with
+// ResolveProfile. Named profiles without a config file keep their
+// worktrees under ~/worktrees/<profile> so they never collide with the
+// default profile's.
+func LoadProfile(flag string) (*Config, error) {
+	baseDir, err := BaseDir()
+	if err != nil {
+		return nil, err
+	}
+	return loadProfileFromBase(baseDir, ResolveProfile(baseDir, flag))
+}
+
+func loadProfileFromBase(baseDir, name string) (*Config, error) {
+	if err := ValidateProfileName(name); err != nil {
+		return nil, err
+	}
+	cfg, err := LoadFromDir(ProfileDir(baseDir, name))
+	if err != nil {
+		return nil, err
+	}
+	cfg.profile = name
+	if name != DefaultProfile && !cfg.ConfigExists() && cfg.Source("worktree_root") != SourceEnv {
+		cfg.WorktreeRoot = "~/worktrees/" + name
+	}
+	return cfg, nil
+}
+
+// CreateProfile sets up a named profile with its own config file
+func CreateProfile(baseDir, name string) (*Config, error) {
+	cfg, err := loadProfileFromBase(baseDir, name)
+	if err != nil {
+		return nil, err
+	}
+	if !cfg.ConfigExists() {
+		if err := cfg.Save(); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+// Profile returns the name of the profile this config was loaded from
+func (c *Config) Profile() string {
+	if c.profile == "" {
+		return DefaultProfile
+	}
+	return c.profile
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestResolveProfile(t *testing.T) {
+	base := t.TempDir()
+	t.Setenv(ProfileEnv, "")
+
+	if got := ResolveProfile(base, ""); got != DefaultProfile {
+		t.Errorf("ResolveProfile() = %q, want %q", got, DefaultProfile)
+	}
+
+	if err := SwitchProfile(base, "work"); err != nil {
+		t.Fatalf("SwitchProfile failed: %v", err)
+	}
+	if got := ResolveProfile(base, ""); got != "work" {
+		t.Errorf("ResolveProfile() after switch = %q, want work", got)
+	}
+
+	t.Setenv(ProfileEnv, "personal")
+	if got := ResolveProfile(base, ""); got != "personal" {
+		t.Errorf("ResolveProfile() with %s = %q, want personal", ProfileEnv, got)
+	}
+	if got := ResolveProfile(base, "client"); got != "client" {
+		t.Errorf("ResolveProfile() with flag = %q, want client", got)
+	}
+
+	if err := SwitchProfile(base, DefaultProfile); err != nil {
+		t.Fatalf("SwitchProfile(default) failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(base, currentProfileFile)); !os.IsNotExist(err) {
+		t.Error("switching back to the default profile should remove current_profile")
+	}
+}
+
+func TestProfiles(t *testing.T) {
+	base := t.TempDir()
+
+	cfg, err := CreateProfile(base, "work")
+	if err != nil {
+		t.Fatalf("CreateProfile failed: %v", err)
+	}
+	if cfg.ConfigDir() != filepath.Join(base, "profiles", "work") {
+		t.Errorf("ConfigDir() = %q", cfg.ConfigDir())
+	}
+	if cfg.Profile() != "work" || cfg.WorktreeRoot != "~/worktrees/work" {
+		t.Errorf("profile %q has worktree root %q", cfg.Profile(), cfg.WorktreeRoot)
+	}
+	if !cfg.ConfigExists() {
+		t.Error("CreateProfile should write config.json")
+	}
+
+	def, err := loadProfileFromBase(base, DefaultProfile)
+	if err != nil {
+		t.Fatalf("loading default profile failed: %v", err)
+	}
+	if def.ConfigDir() != base || def.WorktreeRoot != "~/worktrees" {
+		t.Errorf("default profile: dir %q, worktree root %q", def.ConfigDir(), def.WorktreeRoot)
+	}
+
+	if _, err := CreateProfile(base, "client-b"); err != nil {
+		t.Fatalf("CreateProfile failed: %v", err)
+	}
+	got, err := ListProfiles(base)
+	if err != nil {
+		t.Fatalf("ListProfiles failed: %v", err)
+	}
+	if want := []string{DefaultProfile, "client-b", "work"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ListProfiles() = %v, want %v", got, want)
+	}
+
+	for _, name := range []string{"", "../etc", ".hidden", "a/b"} {
+		if ValidateProfileName(name) == nil {
+			t.Errorf("ValidateProfileName(%q) should fail", name)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// DefaultQueueName is the Redis list or NATS subject items are read from
+const DefaultQueueName = "wt.queue"
+
+// Queue configures the external work queue 'wt auto --queue' consumes:
+// beads pushed by CI, alert triage or other systems
+type Queue struct {
+	URL     string `json:"url"`               // redis://[:password@]host:6379[/db], rediss:// for TLS, or nats://[user:pass@]host:4222
+	Name    string `json:"name,omitempty"`    // Redis list key or NATS subject (default "wt.queue")
+	Group   string `json:"group,omitempty"`   // NATS queue group, so several workers share the items (default "wt")
+	Project string `json:"project,omitempty"` // Project of items that don't name one
+}
+
+// QueueSettings returns the work queue settings, nil unless a URL is set
+func (c *Config) QueueSettings() *Queue {
+	if c.Queue == nil || c.Queue.URL == "" {
+		return nil
+	}
+	return c.Queue
+}
+
+// QueueName returns the list key or subject, "wt.queue" by default
+func (q *Queue) QueueName() string {
+	if q.Name != "" {
+		return q.Name
+	}
+	return DefaultQueueName
+}
+
+// QueueGroup returns the NATS queue group, "wt" by default
+func (q *Queue) QueueGroup() string {
+	if q.Group != "" {
+		return q.Group
+	}
+	return "wt"
+}
+
+// Backend returns "redis" or "nats" from the URL's scheme
+func (q *Queue) Backend() (string, error) {
+	u, err := url.Parse(q.URL)
+	if err != nil {
+		return "", fmt.Errorf("invalid queue url: %w", err)
+	}
+	switch u.Scheme {
+	case "redis", "rediss":
+		return "redis", nil
+	case "nats", "tls":
+		return "nats", nil
+	}
+	return "", fmt.Errorf("unsupported queue url %q: use redis://, rediss:// or nats://", q.URL)
+}
+
+// DisplayURL returns the URL with any password masked, for output
+func (q *Queue) DisplayURL() string {
+	u, err := url.Parse(q.URL)
+	if err != nil {
+		return q.URL
+	}
+	return u.Redacted()
+}
@@ -0,0 +1,33 @@
+package config
+
+import "testing"
+
+func TestQueueSettings(t *testing.T) {
+	cfg := &Config{Queue: &Queue{Name: "jobs"}}
+	if cfg.QueueSettings() != nil {
+		t.Error("QueueSettings() without a url should be nil")
+	}
+
+	cfg.Queue.URL = "redis://:hunter2@localhost:6379/1"
+	q := cfg.QueueSettings()
+	if q == nil || q.QueueName() != "jobs" || q.QueueGroup() != "wt" {
+		t.Fatalf("QueueSettings() = %+v", q)
+	}
+	if got := q.DisplayURL(); got != "redis://:xxxxx@localhost:6379/1" {
+		t.Errorf("DisplayURL() = %q, want the password masked", got)
+	}
+
+	for url, want := range map[string]string{
+		"redis://localhost":  "redis",
+		"rediss://localhost": "redis",
+		"nats://localhost":   "nats",
+		"tls://localhost":    "nats",
+	} {
+		if got, err := (&Queue{URL: url}).Backend(); err != nil || got != want {
+			t.Errorf("Backend(%s) = %q, %v; want %s", url, got, err, want)
+		}
+	}
+	if _, err := (&Queue{URL: "kafka://localhost"}).Backend(); err == nil {
+		t.Error("Backend(kafka://) succeeded, want an error")
+	}
+}
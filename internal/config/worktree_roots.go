@@ -0,0 +1,61 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ProjectWorktreeRoot returns the directory a project's worktrees live
+// under: the project's own worktree_root if it sets one (e.g. to put a large
+// monorepo on another disk), else the global worktree_root
+func (c *Config) ProjectWorktreeRoot(project string) string {
+	if project != "" {
+		if root := c.projectWorktreeRoots()[project]; root != "" {
+			return root
+		}
+	}
+	return c.WorktreeRootPath()
+}
+
+// WorktreeRoots returns every directory worktrees may live under: the global
+// worktree_root first, then project overrides, without duplicates
+func (c *Config) WorktreeRoots() []string {
+	roots := []string{c.WorktreeRootPath()}
+	var overrides []string
+	for _, root := range c.projectWorktreeRoots() {
+		overrides = append(overrides, root)
+	}
+	sort.Strings(overrides)
+	for _, root := range overrides {
+		if !slices.Contains(roots, root) {
+			roots = append(roots, root)
+		}
+	}
+	return roots
+}
+
+// projectWorktreeRoots reads the worktree_root overrides of the registered
+// projects, keyed by project name. Only that key is read, so the config
+// package doesn't depend on the project package.
+func (c *Config) projectWorktreeRoots() map[string]string {
+	files, _ := filepath.Glob(filepath.Join(c.configDir, "projects", "*.json"))
+	roots := make(map[string]string)
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		var p struct {
+			WorktreeRoot string `json:"worktree_root"`
+		}
+		if json.Unmarshal(data, &p) != nil || p.WorktreeRoot == "" {
+			continue
+		}
+		roots[strings.TrimSuffix(filepath.Base(file), ".json")] = expandPath(p.WorktreeRoot)
+	}
+	return roots
+}
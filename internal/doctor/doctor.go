@@ -7,7 +7,9 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/badri/wt/internal/bead"
 	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/logging"
 	"github.com/badri/wt/internal/session"
 	"github.com/badri/wt/internal/tmux"
 	"github.com/badri/wt/internal/worktree"
@@ -35,17 +37,42 @@ func Run(cfg *config.Config) error {
 	// 3. Check beads (bd command)
 	results = append(results, checkBeads())
 
-	// 4. Check worktree root directory
-	results = append(results, checkWorktreeRoot(cfg))
+	// 4. Check worktree root directories (global and per-project)
+	for _, root := range cfg.WorktreeRoots() {
+		results = append(results, checkWorktreeRoot(root))
+	}
 
 	// 5. Check config
 	results = append(results, checkConfig(cfg))
 
-	// 6. Check for orphaned sessions/worktrees
+	// 6. Check state files are in the current format
+	results = append(results, checkStateFormat(cfg))
+
+	// 7. Check worktrees are namespaced by project
+	results = append(results, checkWorktreeLayout(cfg))
+
+	// 8. Check for orphaned sessions/worktrees
 	orphanResults := checkOrphans(cfg)
 	results = append(results, orphanResults...)
 
-	// 7. Check CLAUDE.md configuration
+	// 9. Check for tmux sessions started outside wt under wt names
+	state, stateErr := session.LoadState(cfg)
+	if stateErr == nil {
+		results = append(results, checkForeignSessions(state))
+	}
+
+	// 10. Check session test env ports are free or held by their env
+	if stateErr == nil {
+		results = append(results, checkPorts(cfg, state))
+	}
+
+	// 11. Check projects that open PRs can reach their repo with gh
+	results = append(results, checkProjectRemotes(cfg))
+
+	// 12. Check worker guidelines are not stale
+	results = append(results, checkGuidelines(cfg))
+
+	// 13. Check CLAUDE.md configuration
 	claudeResults := checkClaudeMD()
 	results = append(results, claudeResults...)
 
@@ -96,7 +123,7 @@ func checkTmux() CheckResult {
 	}
 
 	// Check tmux version
-	cmd := exec.Command("tmux", "-V")
+	cmd := logging.Command("tmux", "-V")
 	output, err := cmd.Output()
 	if err != nil {
 		return CheckResult{
@@ -137,7 +164,7 @@ func checkGit() CheckResult {
 	}
 
 	// Check git version
-	cmd := exec.Command("git", "--version")
+	cmd := logging.Command("git", "--version")
 	output, err := cmd.Output()
 	if err != nil {
 		return CheckResult{
@@ -170,8 +197,7 @@ func checkBeads() CheckResult {
 	}
 
 	// Check bd version
-	cmd := exec.Command("bd", "version")
-	output, err := cmd.Output()
+	output, err := bead.Output("", "version")
 	if err != nil {
 		return CheckResult{
 			Name:    "beads (bd)",
@@ -189,8 +215,7 @@ func checkBeads() CheckResult {
 	}
 }
 
-func checkWorktreeRoot(cfg *config.Config) CheckResult {
-	root := expandPath(cfg.WorktreeRoot)
+func checkWorktreeRoot(root string) CheckResult {
 
 	// Check if directory exists
 	info, err := os.Stat(root)
@@ -327,20 +352,21 @@ func checkOrphans(cfg *config.Config) []CheckResult {
 		})
 	}
 
-	// Check for orphaned worktrees (directory exists but no session)
-	root := expandPath(cfg.WorktreeRoot)
-	entries, err := os.ReadDir(root)
-	if err == nil {
+	// Check for orphaned worktrees (directory exists but no session), in
+	// every worktree root
+	inUse := make(map[string]bool)
+	for _, sess := range state.Sessions {
+		inUse[sess.Worktree] = true
+	}
+	for _, root := range cfg.WorktreeRoots() {
+		if _, err := os.Stat(root); err != nil {
+			continue
+		}
 		var orphanedWorktrees []string
-		for _, entry := range entries {
-			if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
-				if _, ok := state.Sessions[entry.Name()]; !ok {
-					// Check if it's actually a git worktree
-					gitDir := filepath.Join(root, entry.Name(), ".git")
-					if _, err := os.Stat(gitDir); err == nil {
-						orphanedWorktrees = append(orphanedWorktrees, entry.Name())
-					}
-				}
+		for _, dir := range worktreeDirs(root) {
+			if !inUse[dir] {
+				rel, _ := filepath.Rel(root, dir)
+				orphanedWorktrees = append(orphanedWorktrees, rel)
 			}
 		}
 
@@ -543,7 +569,7 @@ func checkClaudeMD() []CheckResult {
 
 // getGitRoot returns the root of the current git repository, or empty string if not in a repo.
 func getGitRoot() string {
-	cmd := exec.Command("git", "rev-parse", "--show-toplevel")
+	cmd := logging.Command("git", "rev-parse", "--show-toplevel")
 	output, err := cmd.Output()
 	if err != nil {
 		return ""
@@ -551,14 +577,6 @@ func getGitRoot() string {
 	return strings.TrimSpace(string(output))
 }
 
-func expandPath(path string) string {
-	if strings.HasPrefix(path, "~/") {
-		home, _ := os.UserHomeDir()
-		return filepath.Join(home, path[2:])
-	}
-	return path
-}
-
 func truncate(s string, maxLen int) string {
 	if len(s) <= maxLen {
 		return s
@@ -0,0 +1,54 @@
+package doctor
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/project"
+)
+
+// checkGuidelines finds projects whose WORKERS.md hasn't been edited in
+// guidelines_max_age days. Every worker prompt carries it, so rules that
+// no longer hold mislead each new session.
+func checkGuidelines(cfg *config.Config) CheckResult {
+	projects, err := project.NewManager(cfg).List()
+	if err != nil || len(projects) == 0 {
+		return CheckResult{Name: "worker guidelines", Status: "ok", Message: "no projects registered"}
+	}
+
+	withGuidelines, stale := staleGuidelines(projects, time.Now())
+	if withGuidelines == 0 {
+		return CheckResult{Name: "worker guidelines", Status: "ok", Message: "no project has a WORKERS.md"}
+	}
+	if len(stale) == 0 {
+		return CheckResult{Name: "worker guidelines", Status: "ok", Message: fmt.Sprintf("%d project(s) with current WORKERS.md", withGuidelines)}
+	}
+	return CheckResult{
+		Name:    "worker guidelines",
+		Status:  "warn",
+		Message: fmt.Sprintf("%d project(s) with stale WORKERS.md", len(stale)),
+		Details: append(stale, "Review with: wt project guidelines edit <project>"),
+	}
+}
+
+// staleGuidelines counts the projects with a WORKERS.md and describes those
+// older than their guidelines_max_age, sorted by project
+func staleGuidelines(projects []*project.Project, now time.Time) (int, []string) {
+	count := 0
+	var stale []string
+	for _, proj := range projects {
+		age, ok := proj.GuidelinesAge(now)
+		if !ok {
+			continue
+		}
+		count++
+		days := int(age.Hours() / 24)
+		if maxAge := proj.GuidelinesMaxAgeDays(); days > maxAge {
+			stale = append(stale, fmt.Sprintf("%s: last edited %d days ago (max %d)", proj.Name, days, maxAge))
+		}
+	}
+	sort.Strings(stale)
+	return count, stale
+}
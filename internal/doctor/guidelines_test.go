@@ -0,0 +1,60 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/project"
+)
+
+func TestStaleGuidelines(t *testing.T) {
+	cfg, err := config.LoadFromDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	mgr := project.NewManager(cfg)
+	os.MkdirAll(filepath.Join(cfg.ConfigDir(), "projects"), 0755)
+
+	now := time.Now()
+	setup := []struct {
+		name, config string
+		age          int // days; -1 for no WORKERS.md
+	}{
+		{"api", `{"name": "api"}`, 100},
+		{"web", `{"name": "web", "guidelines_max_age": 120}`, 100},
+		{"cli", `{"name": "cli", "guidelines_max_age": 7}`, 8},
+		{"docs", `{"name": "docs"}`, -1},
+	}
+	var projects []*project.Project
+	for _, s := range setup {
+		os.WriteFile(filepath.Join(cfg.ConfigDir(), "projects", s.name+".json"), []byte(s.config), 0644)
+		if s.age >= 0 {
+			path := mgr.GuidelinesPath(s.name)
+			os.MkdirAll(filepath.Dir(path), 0755)
+			os.WriteFile(path, []byte("- rule\n"), 0644)
+			mtime := now.Add(-time.Duration(s.age) * 24 * time.Hour)
+			os.Chtimes(path, mtime, mtime)
+		}
+		proj, err := mgr.Get(s.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		projects = append(projects, proj)
+	}
+
+	count, stale := staleGuidelines(projects, now)
+	if count != 3 {
+		t.Errorf("staleGuidelines() counted %d projects with guidelines, want 3", count)
+	}
+	want := []string{
+		"api: last edited 100 days ago (max 90)",
+		"cli: last edited 8 days ago (max 7)",
+	}
+	if !reflect.DeepEqual(stale, want) {
+		t.Errorf("staleGuidelines() = %v, want %v", stale, want)
+	}
+}
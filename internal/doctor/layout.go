@@ -0,0 +1,154 @@
+package doctor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/session"
+	"github.com/badri/wt/internal/tmux"
+)
+
+// isGitWorktree reports whether dir is a git checkout (a worktree has a
+// .git file, the main checkout a .git directory)
+func isGitWorktree(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, ".git"))
+	return err == nil
+}
+
+// worktreeDirs lists the git worktrees under root: at the top level (the
+// flat layout) and inside project directories (worktree_root/<project>/<name>)
+func worktreeDirs(root string) []string {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil
+	}
+	var dirs []string
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		path := filepath.Join(root, entry.Name())
+		if isGitWorktree(path) {
+			dirs = append(dirs, path)
+			continue
+		}
+		children, err := os.ReadDir(path)
+		if err != nil {
+			continue
+		}
+		for _, child := range children {
+			childPath := filepath.Join(path, child.Name())
+			if child.IsDir() && !strings.HasPrefix(child.Name(), ".") && isGitWorktree(childPath) {
+				dirs = append(dirs, childPath)
+			}
+		}
+	}
+	return dirs
+}
+
+// legacyWorktrees returns the sessions of a project whose worktree still
+// sits directly in worktree_root, mapped to where it belongs now
+func legacyWorktrees(cfg *config.Config, state *session.State) map[string]string {
+	root := cfg.WorktreeRootPath()
+	moves := make(map[string]string)
+	for name, sess := range state.Sessions {
+		if sess.Project == "" || sess.Worktree == "" || filepath.Dir(sess.Worktree) != root {
+			continue
+		}
+		moves[name] = cfg.ProjectWorktreePath(sess.Project, filepath.Base(sess.Worktree))
+	}
+	return moves
+}
+
+// checkWorktreeLayout warns about project sessions using the flat layout,
+// where equal bead IDs or names from different projects collide
+func checkWorktreeLayout(cfg *config.Config) CheckResult {
+	state, err := session.LoadState(cfg)
+	if err != nil {
+		return CheckResult{Name: "worktree layout", Status: "warn", Message: "cannot load session state"}
+	}
+
+	moves := legacyWorktrees(cfg, state)
+	if len(moves) == 0 {
+		return CheckResult{Name: "worktree layout", Status: "ok", Message: "worktrees namespaced by project"}
+	}
+	return CheckResult{
+		Name:    "worktree layout",
+		Status:  "warn",
+		Message: fmt.Sprintf("%d session(s) in the flat worktree layout", len(moves)),
+		Details: []string{fmt.Sprintf("Sessions: %s", strings.Join(sortedKeys(moves), ", ")),
+			"Migrate with: wt doctor --migrate-worktrees"},
+	}
+}
+
+// MigrateWorktrees moves flat-layout worktrees of project sessions to
+// worktree_root/<project>/<name> with 'git worktree move'. Sessions whose
+// tmux session is running are skipped, since their processes still use the
+// old path.
+func MigrateWorktrees(cfg *config.Config) error {
+	state, err := session.LoadState(cfg)
+	if err != nil {
+		return err
+	}
+
+	moves := legacyWorktrees(cfg, state)
+	if len(moves) == 0 {
+		fmt.Println("All session worktrees already use the project layout.")
+		return nil
+	}
+
+	moved, skipped := 0, 0
+	for _, name := range sortedKeys(moves) {
+		sess := state.Sessions[name]
+		target := moves[name]
+
+		if tmux.SessionExists(name) {
+			fmt.Printf("  - %s: skipped, session is running (migrate after 'wt done' or 'wt kill')\n", name)
+			skipped++
+			continue
+		}
+		if _, err := os.Stat(target); err == nil {
+			fmt.Printf("  ✗ %s: %s already exists\n", name, target)
+			skipped++
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return fmt.Errorf("creating %s: %w", filepath.Dir(target), err)
+		}
+
+		cmd := logging.Command("git", "-C", sess.Worktree, "worktree", "move", sess.Worktree, target)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			fmt.Printf("  ✗ %s: %s\n", name, strings.TrimSpace(string(output)))
+			skipped++
+			continue
+		}
+
+		sess.Worktree = target
+		if err := state.Save(); err != nil {
+			return fmt.Errorf("saving session state: %w", err)
+		}
+		fmt.Printf("  ✓ %s → %s\n", name, target)
+		moved++
+	}
+
+	fmt.Printf("\nMoved %d worktree(s)", moved)
+	if skipped > 0 {
+		fmt.Printf(", %d left in place", skipped)
+	}
+	fmt.Println()
+	return nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,79 @@
+package doctor
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/session"
+)
+
+func git(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v failed: %v\nOutput: %s", args, err, out)
+	}
+}
+
+func TestWorktreeLayout(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	cfg, err := config.LoadFromDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	root := t.TempDir()
+	cfg.WorktreeRoot = root
+
+	repo := t.TempDir()
+	initGitRepo(t, repo)
+	git(t, repo, "-c", "user.name=Test", "-c", "user.email=test@test.com", "commit", "-q", "--allow-empty", "-m", "init")
+
+	legacy := filepath.Join(root, "wt-abc")
+	namespaced := cfg.ProjectWorktreePath("web", "wt-def")
+	git(t, repo, "worktree", "add", "-q", "-b", "wt-abc", legacy)
+	git(t, repo, "worktree", "add", "-q", "-b", "wt-def", namespaced)
+
+	state, err := session.LoadState(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state.Sessions["wt-doctor-toast"] = &session.Session{Bead: "wt-abc", Project: "api", Worktree: legacy}
+	state.Sessions["wt-doctor-shadow"] = &session.Session{Bead: "wt-def", Project: "web", Worktree: namespaced}
+	if err := state.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := worktreeDirs(root); !reflect.DeepEqual(got, []string{namespaced, legacy}) && !reflect.DeepEqual(got, []string{legacy, namespaced}) {
+		t.Errorf("worktreeDirs() = %v, want both layouts", got)
+	}
+
+	want := map[string]string{"wt-doctor-toast": filepath.Join(root, "api", "wt-abc")}
+	if got := legacyWorktrees(cfg, state); !reflect.DeepEqual(got, want) {
+		t.Errorf("legacyWorktrees() = %v, want %v", got, want)
+	}
+	if r := checkWorktreeLayout(cfg); r.Status != "warn" {
+		t.Errorf("checkWorktreeLayout() = %+v, want a warning", r)
+	}
+
+	if err := MigrateWorktrees(cfg); err != nil {
+		t.Fatalf("MigrateWorktrees() unexpected error: %v", err)
+	}
+	state, _ = session.LoadState(cfg)
+	moved := state.Sessions["wt-doctor-toast"].Worktree
+	if moved != want["wt-doctor-toast"] {
+		t.Errorf("migrated worktree = %q, want %q", moved, want["wt-doctor-toast"])
+	}
+	if _, err := os.Stat(filepath.Join(moved, ".git")); err != nil {
+		t.Errorf("migrated worktree is not a checkout: %v", err)
+	}
+	if r := checkWorktreeLayout(cfg); r.Status != "ok" {
+		t.Errorf("checkWorktreeLayout() after migration = %+v", r)
+	}
+}
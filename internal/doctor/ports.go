@@ -0,0 +1,348 @@
+package doctor
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"net"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/project"
+	"github.com/badri/wt/internal/session"
+	"github.com/badri/wt/internal/testenv"
+)
+
+// Port statuses of a session's test env port
+const (
+	PortFree     = "free"     // nothing listens
+	PortOwned    = "owned"    // the session's env listens
+	PortConflict = "conflict" // another process listens
+	PortShared   = "shared"   // another session maps the same port
+)
+
+// SessionPort is one test_env port of a session, base port plus the
+// session's PortOffset, and who listens on it
+type SessionPort struct {
+	Session string
+	Project string
+	Name    string
+	Base    int
+	Port    int
+	Offset  int
+	EnvUp   bool
+	Status  string
+	Owner   string // listening process, e.g. "pid 812 (postgres)"
+	Note    string
+}
+
+// listener is a process listening on a TCP port, as lsof reports it
+type listener struct {
+	PID     int
+	Command string
+	Cwd     string
+}
+
+// dockerCommands are processes that publish container ports. wt can't
+// tell which env a container belongs to, so they count as the session's
+// own while its env is up.
+var dockerCommands = []string{"docker-proxy", "com.docker", "vpnkit", "rootlessport", "gvproxy", "podman"}
+
+// checkPorts verifies that each session's test env ports are free or held
+// by that session's env, so "address already in use" has a known culprit
+func checkPorts(cfg *config.Config, state *session.State) CheckResult {
+	ports := SessionPorts(cfg, state)
+	if len(ports) == 0 {
+		return CheckResult{Name: "test env ports", Status: "ok", Message: "no sessions with test_env ports"}
+	}
+
+	var details []string
+	status := "ok"
+	for _, p := range ports {
+		switch p.Status {
+		case PortShared:
+			status = "error"
+		case PortConflict:
+			if status == "ok" {
+				status = "warn"
+			}
+		default:
+			continue
+		}
+		details = append(details, fmt.Sprintf("%s %s :%d: %s", p.Session, p.Name, p.Port, p.describe()))
+	}
+
+	sessions := make(map[string]bool)
+	for _, p := range ports {
+		sessions[p.Session] = true
+	}
+	if status == "ok" {
+		return CheckResult{Name: "test env ports", Status: "ok",
+			Message: fmt.Sprintf("%d port(s) of %d session(s) free or held by their env", len(ports), len(sessions))}
+	}
+	details = append(details, "Full port map: wt doctor --ports")
+	return CheckResult{
+		Name:    "test env ports",
+		Status:  status,
+		Message: fmt.Sprintf("%d of %d session port(s) in conflict", len(details)-1, len(ports)),
+		Details: details,
+	}
+}
+
+// SessionPorts maps and probes the test_env ports of every session, sorted
+// by port
+func SessionPorts(cfg *config.Config, state *session.State) []SessionPort {
+	mgr := project.NewManager(cfg)
+	projects := make(map[string]*project.Project)
+	worktrees := make(map[string]string, len(state.Sessions))
+	var ports []SessionPort
+	for name, sess := range state.Sessions {
+		worktrees[name] = sess.Worktree
+		proj, ok := projects[sess.Project]
+		if !ok {
+			proj, _ = mgr.Get(sess.Project)
+			projects[sess.Project] = proj
+		}
+		for _, mp := range testenv.MapPorts(proj, sess.PortOffset) {
+			ports = append(ports, SessionPort{
+				Session: name,
+				Project: sess.Project,
+				Name:    mp.Name,
+				Base:    mp.Base,
+				Port:    mp.Port,
+				Offset:  sess.PortOffset,
+				EnvUp:   sess.EnvDownAt == "" && !sess.IsPaused(),
+			})
+		}
+	}
+	sortPorts(ports)
+
+	_, lsofErr := exec.LookPath("lsof")
+	for i := range ports {
+		p := &ports[i]
+		var listeners []listener
+		if lsofErr == nil {
+			listeners = portListeners(p.Port)
+		}
+		classifyPort(p, listeners, portInUse(p.Port), worktrees)
+	}
+	markShared(ports)
+	return ports
+}
+
+// classifyPort sets a port's status from the processes listening on it.
+// A listener belongs to a session when its working directory is inside the
+// session's worktree; container proxies belong to a session whose env is
+// up. inUse covers listeners lsof can't see, like another user's.
+func classifyPort(p *SessionPort, listeners []listener, inUse bool, worktrees map[string]string) {
+	p.Status, p.Owner, p.Note = PortFree, "", ""
+	if len(listeners) == 0 {
+		if !inUse {
+			return
+		}
+		if p.EnvUp {
+			p.Status = PortOwned
+			p.Note = "listener not visible, presumably the env"
+			return
+		}
+		p.Status = PortConflict
+		p.Note = "in use by an unknown process while the env is down"
+		return
+	}
+
+	for _, l := range listeners {
+		if within(l.Cwd, worktrees[p.Session]) {
+			p.Status, p.Owner = PortOwned, l.describe()
+			return
+		}
+	}
+	for _, l := range listeners {
+		if isDockerCommand(l.Command) && p.EnvUp {
+			p.Status, p.Owner = PortOwned, l.describe()
+			p.Note = "container port"
+			return
+		}
+	}
+
+	l := listeners[0]
+	p.Status, p.Owner = PortConflict, l.describe()
+	for _, name := range sortedKeys(worktrees) {
+		if name != p.Session && within(l.Cwd, worktrees[name]) {
+			p.Note = "held by session " + name
+			return
+		}
+	}
+	switch {
+	case isDockerCommand(l.Command):
+		p.Note = "a container holds it while the env is down"
+	default:
+		p.Note = "not a wt process"
+	}
+}
+
+// markShared flags ports that more than one session maps to. Projects with
+// different base ports can overlap even though offsets are unique.
+func markShared(ports []SessionPort) {
+	byPort := make(map[int][]string)
+	for _, p := range ports {
+		byPort[p.Port] = append(byPort[p.Port], p.Session)
+	}
+	for i := range ports {
+		p := &ports[i]
+		var others []string
+		for _, s := range byPort[p.Port] {
+			if s != p.Session {
+				others = append(others, s)
+			}
+		}
+		if len(others) > 0 {
+			sort.Strings(others)
+			p.Status = PortShared
+			p.Note = "also mapped by " + strings.Join(others, ", ")
+		}
+	}
+}
+
+func sortPorts(ports []SessionPort) {
+	sort.Slice(ports, func(i, j int) bool {
+		if ports[i].Port != ports[j].Port {
+			return ports[i].Port < ports[j].Port
+		}
+		return ports[i].Session < ports[j].Session
+	})
+}
+
+// describe says who holds a port and why that is a problem
+func (p SessionPort) describe() string {
+	switch {
+	case p.Owner != "" && p.Note != "":
+		return p.Owner + ", " + p.Note
+	case p.Owner != "":
+		return p.Owner
+	}
+	return p.Note
+}
+
+func (l listener) describe() string {
+	return fmt.Sprintf("pid %d (%s)", l.PID, l.Command)
+}
+
+func isDockerCommand(command string) bool {
+	for _, d := range dockerCommands {
+		if strings.HasPrefix(command, d) {
+			return true
+		}
+	}
+	return false
+}
+
+// within reports whether path is dir or inside it
+func within(path, dir string) bool {
+	if path == "" || dir == "" {
+		return false
+	}
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// portInUse reports whether a TCP port can't be bound
+func portInUse(port int) bool {
+	ln, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return true
+	}
+	ln.Close()
+	return false
+}
+
+// portListeners asks lsof which processes listen on a TCP port, with their
+// working directories
+func portListeners(port int) []listener {
+	out, err := logging.Command("lsof", "-nP", fmt.Sprintf("-iTCP:%d", port), "-sTCP:LISTEN", "-Fpc").Output()
+	if err != nil {
+		return nil // lsof exits 1 when nothing listens
+	}
+	listeners := parseLsof(out)
+	for i := range listeners {
+		cwd, err := logging.Command("lsof", "-a", "-p", strconv.Itoa(listeners[i].PID), "-d", "cwd", "-Fn").Output()
+		if err == nil {
+			for _, l := range parseLsofNames(cwd) {
+				listeners[i].Cwd = l
+			}
+		}
+	}
+	return listeners
+}
+
+// parseLsof reads the process records of lsof -F output: a "p<pid>" line
+// starts a process, "c<command>" names it
+func parseLsof(out []byte) []listener {
+	var listeners []listener
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		switch line[0] {
+		case 'p':
+			pid, err := strconv.Atoi(line[1:])
+			if err != nil {
+				continue
+			}
+			listeners = append(listeners, listener{PID: pid})
+		case 'c':
+			if n := len(listeners); n > 0 {
+				listeners[n-1].Command = line[1:]
+			}
+		}
+	}
+	return listeners
+}
+
+// parseLsofNames returns the "n<name>" fields of lsof -F output
+func parseLsofNames(out []byte) []string {
+	var names []string
+	for _, line := range strings.Split(string(out), "\n") {
+		if strings.HasPrefix(line, "n") {
+			names = append(names, line[1:])
+		}
+	}
+	return names
+}
+
+// PrintPorts prints the test_env port map of all sessions with the process
+// holding each port, for wt doctor --ports
+func PrintPorts(cfg *config.Config) error {
+	state, err := session.LoadState(cfg)
+	if err != nil {
+		return err
+	}
+	ports := SessionPorts(cfg, state)
+	if len(ports) == 0 {
+		fmt.Println("No sessions with test_env ports.")
+		return nil
+	}
+
+	fmt.Printf("%-6s %-20s %-12s %-6s %-7s %-5s %-9s %s\n", "PORT", "SESSION", "SERVICE", "BASE", "OFFSET", "ENV", "STATUS", "HELD BY")
+	conflicts := 0
+	for _, p := range ports {
+		env := "up"
+		if !p.EnvUp {
+			env = "down"
+		}
+		if p.Status == PortConflict || p.Status == PortShared {
+			conflicts++
+		}
+		fmt.Printf("%-6d %-20s %-12s %-6d %-7d %-5s %-9s %s\n", p.Port, p.Session, p.Name, p.Base, p.Offset, env, p.Status, p.describe())
+	}
+	if conflicts > 0 {
+		return fmt.Errorf("%d port(s) in conflict", conflicts)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package doctor
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClassifyPort(t *testing.T) {
+	worktrees := map[string]string{
+		"app-toast": "/wt/app/toast",
+		"app-ash":   "/wt/app/ash",
+	}
+	tests := []struct {
+		name      string
+		envUp     bool
+		listeners []listener
+		inUse     bool
+		status    string
+		note      string
+	}{
+		{"free", true, nil, false, PortFree, ""},
+		{"own process", true, []listener{{PID: 10, Command: "node", Cwd: "/wt/app/toast/web"}}, true, PortOwned, ""},
+		{"container of up env", true, []listener{{PID: 11, Command: "docker-proxy"}}, true, PortOwned, "container port"},
+		{"container while env down", false, []listener{{PID: 11, Command: "com.docker.backend"}}, true, PortConflict, "a container holds it while the env is down"},
+		{"other session", true, []listener{{PID: 12, Command: "node", Cwd: "/wt/app/ash"}}, true, PortConflict, "held by session app-ash"},
+		{"foreign process", true, []listener{{PID: 13, Command: "postgres", Cwd: "/var/lib/postgresql"}}, true, PortConflict, "not a wt process"},
+		{"invisible while env up", true, nil, true, PortOwned, "listener not visible, presumably the env"},
+		{"invisible while env down", false, nil, true, PortConflict, "in use by an unknown process while the env is down"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &SessionPort{Session: "app-toast", Port: 5433, EnvUp: tt.envUp}
+			classifyPort(p, tt.listeners, tt.inUse, worktrees)
+			if p.Status != tt.status || p.Note != tt.note {
+				t.Errorf("classifyPort() = %q %q, want %q %q", p.Status, p.Note, tt.status, tt.note)
+			}
+		})
+	}
+}
+
+func TestMarkShared(t *testing.T) {
+	ports := []SessionPort{
+		{Session: "web-toast", Name: "db", Port: 5433, Status: PortFree},
+		{Session: "api-ash", Name: "db", Port: 5433, Status: PortOwned},
+		{Session: "api-ash", Name: "redis", Port: 6380, Status: PortOwned},
+	}
+	markShared(ports)
+	var got []string
+	for _, p := range ports {
+		got = append(got, p.Status+" "+p.Note)
+	}
+	want := []string{"shared also mapped by api-ash", "shared also mapped by web-toast", "owned "}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("markShared() = %q, want %q", got, want)
+	}
+}
+
+func TestParseLsof(t *testing.T) {
+	out := []byte("p812\ncpostgres\nf5\np901\ncdocker-proxy\n")
+	got := parseLsof(out)
+	want := []listener{{PID: 812, Command: "postgres"}, {PID: 901, Command: "docker-proxy"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseLsof() = %+v, want %+v", got, want)
+	}
+	if names := parseLsofNames([]byte("p812\nfcwd\nn/wt/app/toast\n")); !reflect.DeepEqual(names, []string{"/wt/app/toast"}) {
+		t.Errorf("parseLsofNames() = %v", names)
+	}
+}
+
+func TestWithin(t *testing.T) {
+	if !within("/wt/app/toast/web", "/wt/app/toast") || !within("/wt/app/toast", "/wt/app/toast") {
+		t.Error("within() should accept the worktree and paths below it")
+	}
+	if within("/wt/app/toaster", "/wt/app/toast") || within("/wt/app", "/wt/app/toast") || within("", "/wt") {
+		t.Error("within() accepted a path outside the worktree")
+	}
+}
@@ -0,0 +1,61 @@
+package doctor
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/project"
+)
+
+// checkProjectRemotes finds projects whose merge mode opens PRs but that
+// have no remote, or whose remote gh can't reach. Otherwise wt done only
+// fails once the branch is already pushed.
+func checkProjectRemotes(cfg *config.Config) CheckResult {
+	projects, err := project.NewManager(cfg).List()
+	if err != nil || len(projects) == 0 {
+		return CheckResult{Name: "project remotes", Status: "ok", Message: "no projects registered"}
+	}
+
+	problems := remoteProblems(projects, cfg.DefaultMergeMode, project.CheckGHAccess)
+	if len(problems) == 0 {
+		return CheckResult{Name: "project remotes", Status: "ok", Message: "PR merge modes can reach their repos"}
+	}
+	return CheckResult{
+		Name:    "project remotes",
+		Status:  "warn",
+		Message: fmt.Sprintf("%d project(s) cannot open PRs", len(problems)),
+		Details: problems,
+	}
+}
+
+// remoteProblems describes each project using a PR merge mode that has no
+// remote or fails ghAccess, sorted by project. Each remote is checked once.
+func remoteProblems(projects []*project.Project, defaultMode string, ghAccess func(string) error) []string {
+	checked := make(map[string]error)
+	var problems []string
+	for _, proj := range projects {
+		mode := proj.MergeMode
+		if mode == "" {
+			mode = defaultMode
+		}
+		if !project.MergeNeedsGH(mode) {
+			continue
+		}
+		if proj.RepoURL == "" {
+			problems = append(problems, fmt.Sprintf("%s (%s): no origin remote", proj.Name, mode))
+			continue
+		}
+		url := project.CanonicalRepoURL(proj.RepoURL)
+		err, ok := checked[url]
+		if !ok {
+			err = ghAccess(url)
+			checked[url] = err
+		}
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s (%s): %v", proj.Name, mode, err))
+		}
+	}
+	sort.Strings(problems)
+	return problems
+}
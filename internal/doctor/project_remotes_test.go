@@ -0,0 +1,43 @@
+package doctor
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/badri/wt/internal/project"
+)
+
+func TestRemoteProblems(t *testing.T) {
+	projects := []*project.Project{
+		{Name: "api", MergeMode: "pr-review", RepoURL: "https://github.com/acme/api"},
+		{Name: "api-v2", MergeMode: "pr-auto", RepoURL: "git@github.com:acme/api.git"},
+		{Name: "web", MergeMode: "pr-review", RepoURL: "https://github.com/acme/web"},
+		{Name: "local", MergeMode: "direct"},
+		{Name: "scratch", RepoURL: ""}, // falls back to the default merge mode
+	}
+	calls := 0
+	access := func(url string) error {
+		calls++
+		if url == "https://github.com/acme/web" {
+			return errors.New("gh cannot access it")
+		}
+		return nil
+	}
+
+	got := remoteProblems(projects, "pr-review", access)
+	want := []string{
+		"scratch (pr-review): no origin remote",
+		"web (pr-review): gh cannot access it",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("remoteProblems() = %v, want %v", got, want)
+	}
+	if calls != 2 {
+		t.Errorf("expected each remote to be checked once, got %d checks", calls)
+	}
+
+	if got := remoteProblems(projects[3:], "direct", access); len(got) != 0 {
+		t.Errorf("expected no problems for direct merges, got %v", got)
+	}
+}
@@ -0,0 +1,30 @@
+package doctor
+
+import (
+	"fmt"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/migrate"
+)
+
+// checkStateFormat finds state files in the format of an older wt, which
+// this version misreads until 'wt migrate' upgrades them
+func checkStateFormat(cfg *config.Config) CheckResult {
+	changes, err := migrate.Plan(cfg)
+	if err != nil {
+		return CheckResult{Name: "state format", Status: "error", Message: err.Error()}
+	}
+	if len(changes) == 0 {
+		return CheckResult{Name: "state format", Status: "ok", Message: fmt.Sprintf("current (format %d)", migrate.Version)}
+	}
+	details := make([]string, 0, len(changes)+1)
+	for _, c := range changes {
+		details = append(details, c.Desc)
+	}
+	return CheckResult{
+		Name:    "state format",
+		Status:  "warn",
+		Message: fmt.Sprintf("%d file change(s) from an older wt pending", len(changes)),
+		Details: append(details, "Upgrade with: wt migrate"),
+	}
+}
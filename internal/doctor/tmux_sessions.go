@@ -0,0 +1,54 @@
+package doctor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/badri/wt/internal/hub"
+	"github.com/badri/wt/internal/session"
+	"github.com/badri/wt/internal/tmux"
+)
+
+// checkForeignSessions finds tmux sessions started outside wt that carry
+// the name of a wt session or the hub. wt would nudge, switch to or kill
+// them as if they were its own.
+func checkForeignSessions(state *session.State) CheckResult {
+	tmuxSessions, err := tmux.ListSessions()
+	if err != nil {
+		return CheckResult{Name: "tmux names", Status: "ok", Message: "no tmux sessions"}
+	}
+	names := []string{hub.HubSessionName}
+	for name := range state.Sessions {
+		names = append(names, name)
+	}
+
+	foreign := foreignCollisions(names, tmuxSessions, tmux.IsWTSession)
+	if len(foreign) == 0 {
+		return CheckResult{Name: "tmux names", Status: "ok", Message: "no foreign sessions use wt names"}
+	}
+	return CheckResult{
+		Name:    "tmux names",
+		Status:  "warn",
+		Message: fmt.Sprintf("%d tmux session(s) not started by wt use wt names", len(foreign)),
+		Details: []string{fmt.Sprintf("Sessions: %s", strings.Join(foreign, ", ")),
+			"Fix with: tmux rename-session -t <name> <new-name>"},
+	}
+}
+
+// foreignCollisions returns the tmux sessions named like a wt session that
+// isWT says wt didn't start, sorted
+func foreignCollisions(wtNames, tmuxSessions []string, isWT func(string) bool) []string {
+	wanted := make(map[string]bool, len(wtNames))
+	for _, n := range wtNames {
+		wanted[n] = true
+	}
+	var foreign []string
+	for _, s := range tmuxSessions {
+		if wanted[s] && !isWT(s) {
+			foreign = append(foreign, s)
+		}
+	}
+	sort.Strings(foreign)
+	return foreign
+}
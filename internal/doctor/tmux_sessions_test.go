@@ -0,0 +1,16 @@
+package doctor
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestForeignCollisions(t *testing.T) {
+	wtNames := []string{"hub", "app-toast", "app-ash"}
+	tmuxSessions := []string{"app-toast", "hub", "app-ash", "scratch"}
+	ours := map[string]bool{"app-ash": true}
+	got := foreignCollisions(wtNames, tmuxSessions, func(name string) bool { return ours[name] })
+	if want := []string{"app-toast", "hub"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("foreignCollisions() = %v, want %v", got, want)
+	}
+}
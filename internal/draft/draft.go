@@ -0,0 +1,39 @@
+// Package draft records draft PRs opened by wt. The records outlive the
+// sessions so a draft can be marked ready for review once its worker
+// signals ready or, after 'wt done', once its checks pass.
+package draft
+
+import (
+	"path/filepath"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/jsonstore"
+)
+
+// Entry describes one draft PR.
+type Entry struct {
+	Bead     string `json:"bead"`
+	Branch   string `json:"branch"`
+	Project  string `json:"project,omitempty"`
+	RepoPath string `json:"repo_path"`
+	PRURL    string `json:"pr_url"`
+}
+
+// Store holds all draft entries, keyed by bead ID.
+type Store struct {
+	*jsonstore.Store[Entry]
+}
+
+// Load reads the draft store from the config directory.
+func Load(cfg *config.Config) (*Store, error) {
+	s, err := jsonstore.Load[Entry](filepath.Join(cfg.ConfigDir(), "drafts.json"))
+	if err != nil {
+		return nil, err
+	}
+	return &Store{s}, nil
+}
+
+// Put adds or replaces the entry for e.Bead.
+func (s *Store) Put(e *Entry) {
+	s.Set(e.Bead, e)
+}
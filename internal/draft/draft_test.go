@@ -0,0 +1,44 @@
+package draft
+
+import (
+	"testing"
+
+	"github.com/badri/wt/internal/config"
+)
+
+func TestStoreRoundTrip(t *testing.T) {
+	cfg, err := config.LoadFromDir(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadFromDir() error: %v", err)
+	}
+
+	store, err := Load(cfg)
+	if err != nil {
+		t.Fatalf("Load() on missing file error: %v", err)
+	}
+	if len(store.Entries) != 0 {
+		t.Fatalf("new store has %d entries, want 0", len(store.Entries))
+	}
+
+	store.Put(&Entry{Bead: "wt-a", Branch: "wt-a", RepoPath: "/repo", PRURL: "https://github.com/o/r/pull/1"})
+	store.Put(&Entry{Bead: "wt-b", Branch: "wt-b", RepoPath: "/repo", PRURL: "https://github.com/o/r/pull/2"})
+	if err := store.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	loaded, err := Load(cfg)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if e := loaded.Get("wt-a"); e == nil || e.PRURL != "https://github.com/o/r/pull/1" {
+		t.Fatalf("Get(wt-a) = %+v, want PR 1", e)
+	}
+
+	loaded.Remove("wt-a")
+	if loaded.Get("wt-a") != nil {
+		t.Error("Get(wt-a) after Remove should be nil")
+	}
+	if loaded.Get("wt-b") == nil {
+		t.Error("Remove(wt-a) should not remove wt-b")
+	}
+}
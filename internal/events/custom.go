@@ -0,0 +1,49 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// CustomPrefix namespaces event types emitted by projects and hooks, so
+// they never clash with wt's own
+const CustomPrefix = "custom."
+
+// customName is what 'wt events emit' accepts: lowercase words joined by
+// dots, dashes or underscores, e.g. "deploy-started" or "deploy.started"
+var customName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*(\.[a-z0-9][a-z0-9_-]*)*$`)
+
+// CustomType returns the namespaced type of a custom event. The prefix may
+// already be present.
+func CustomType(name string) (EventType, error) {
+	name = strings.TrimPrefix(name, CustomPrefix)
+	if !customName.MatchString(name) {
+		return "", fmt.Errorf("invalid event name %q (use lowercase letters, digits, '-', '_' and '.', e.g. deploy-started)", name)
+	}
+	return EventType(CustomPrefix + name), nil
+}
+
+// IsCustom reports whether an event was emitted by a project or hook
+func (t EventType) IsCustom() bool {
+	return strings.HasPrefix(string(t), CustomPrefix)
+}
+
+// LogCustom logs a custom event with optional JSON data
+func (l *Logger) LogCustom(eventType EventType, session, bead, project, note string, data json.RawMessage) error {
+	if !eventType.IsCustom() {
+		return fmt.Errorf("event type %q is not namespaced with %q", eventType, CustomPrefix)
+	}
+	if len(data) > 0 && !json.Valid(data) {
+		return fmt.Errorf("event data is not valid JSON")
+	}
+	return l.Log(&Event{
+		Type:    eventType,
+		Session: session,
+		Bead:    bead,
+		Project: project,
+		Note:    note,
+		Data:    data,
+	})
+}
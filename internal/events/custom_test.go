@@ -0,0 +1,61 @@
+package events
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCustomType(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    EventType
+		wantErr bool
+	}{
+		{"deploy-started", "custom.deploy-started", false},
+		{"deploy.staging_done", "custom.deploy.staging_done", false},
+		{"custom.deploy-started", "custom.deploy-started", false},
+		{"session_start", "custom.session_start", false},
+		{"Deploy", "", true},
+		{"deploy started", "", true},
+		{".deploy", "", true},
+		{"", "", true},
+	}
+	for _, tt := range tests {
+		got, err := CustomType(tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("CustomType(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("CustomType(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+	if EventSessionStart.IsCustom() {
+		t.Error("built-in types should not be custom")
+	}
+}
+
+func TestLogger_LogCustom(t *testing.T) {
+	cfg := setupTestConfig(t)
+	logger := NewLogger(cfg)
+
+	if err := logger.LogCustom(EventNote, "toast", "bead-1", "proj", "", nil); err == nil {
+		t.Error("LogCustom should refuse built-in types")
+	}
+	typ, _ := CustomType("deploy-started")
+	if err := logger.LogCustom(typ, "toast", "bead-1", "proj", "", json.RawMessage(`{"env":`)); err == nil {
+		t.Error("LogCustom should refuse invalid JSON data")
+	}
+	if err := logger.LogCustom(typ, "toast", "bead-1", "proj", "staging", json.RawMessage(`{"env":"staging"}`)); err != nil {
+		t.Fatalf("LogCustom failed: %v", err)
+	}
+
+	recent, err := logger.Recent(1)
+	if err != nil || len(recent) != 1 {
+		t.Fatalf("Recent() = %v, %v", recent, err)
+	}
+	e := recent[0]
+	if e.Type != "custom.deploy-started" || e.Note != "staging" || string(e.Data) != `{"env":"staging"}` {
+		t.Errorf("unexpected custom event: %+v", e)
+	}
+}
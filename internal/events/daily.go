@@ -0,0 +1,166 @@
+package events
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ReviewWindow is how far back the daily summary looks for PRs still
+// waiting for review
+const ReviewWindow = 7 * 24 * time.Hour
+
+// DailySummary is what the events log says about a period, for the daily
+// summary email: finished sessions, merges, failures, and PRs that wt saw
+// opened but not merged
+type DailySummary struct {
+	From      time.Time `json:"from"`
+	To        time.Time `json:"to"`
+	Completed []Event   `json:"completed"` // session_end events, other than kills
+	Merged    []Event   `json:"merged"`    // session_end events with a merge commit
+	Failures  []Event   `json:"failures"`  // session_error and verify_failed events
+	// PRs opened within ReviewWindow of To that no later event merged
+	PendingReviews []Event `json:"pending_reviews"`
+	// Sessions waiting for review right now; filled in by the caller,
+	// since the events log doesn't know current statuses
+	ReadySessions []string `json:"ready_sessions,omitempty"`
+}
+
+// BuildDailySummary summarizes the events between from and to. evts may
+// reach back further, to ReviewWindow before to, for pending reviews.
+func BuildDailySummary(evts []Event, from, to time.Time) *DailySummary {
+	s := &DailySummary{From: from, To: to}
+	merged := make(map[string]bool)
+	var opened []Event
+	for _, e := range evts {
+		t, err := time.Parse(time.RFC3339, e.Time)
+		if err != nil || t.After(to) {
+			continue
+		}
+		if e.Type == EventSessionEnd && e.MergeCommit != "" && e.PRURL != "" {
+			merged[e.PRURL] = true
+		}
+		if e.Type == EventPRMerged && e.PRURL != "" {
+			merged[e.PRURL] = true
+		}
+		if e.Type == EventSessionEnd && e.PRURL != "" && e.MergeCommit == "" && t.After(to.Add(-ReviewWindow)) {
+			opened = append(opened, e)
+		}
+		if t.Before(from) {
+			continue
+		}
+		switch e.Type {
+		case EventSessionEnd:
+			if e.MergeMode == "killed" {
+				continue
+			}
+			s.Completed = append(s.Completed, e)
+			if e.MergeCommit != "" {
+				s.Merged = append(s.Merged, e)
+			}
+		case EventSessionError, EventVerifyFailed:
+			s.Failures = append(s.Failures, e)
+		}
+	}
+	seen := make(map[string]bool)
+	for _, e := range opened {
+		if !merged[e.PRURL] && !seen[e.PRURL] {
+			seen[e.PRURL] = true
+			s.PendingReviews = append(s.PendingReviews, e)
+		}
+	}
+	return s
+}
+
+// Subject is the email subject, with the counts
+func (s *DailySummary) Subject() string {
+	return fmt.Sprintf("wt daily summary: %d completed, %d merged, %d failed, %d awaiting review",
+		len(s.Completed), len(s.Merged), len(s.Failures), len(s.PendingReviews)+len(s.ReadySessions))
+}
+
+// Text renders the summary as a plain text email body
+func (s *DailySummary) Text() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "wt activity from %s to %s\n", s.From.Format("2006-01-02 15:04"), s.To.Format("2006-01-02 15:04"))
+
+	section := func(title string, evts []Event, line func(Event) string) {
+		fmt.Fprintf(&sb, "\n%s (%d)\n", title, len(evts))
+		if len(evts) == 0 {
+			sb.WriteString("  none\n")
+		}
+		for _, e := range evts {
+			fmt.Fprintf(&sb, "  - %s\n", line(e))
+		}
+	}
+	section("Sessions completed", s.Completed, func(e Event) string {
+		line := describeSession(e)
+		if e.MergeMode != "" {
+			line += " [" + e.MergeMode + "]"
+		}
+		if e.Summary != nil && e.Summary.Text != "" {
+			line += ": " + oneLine(e.Summary.Text)
+		}
+		return line
+	})
+	section("Merged", s.Merged, func(e Event) string {
+		if e.PRURL != "" {
+			return describeSession(e) + " " + e.PRURL
+		}
+		return describeSession(e) + " merged directly (" + shortCommit(e.MergeCommit) + ")"
+	})
+	section("Failures", s.Failures, func(e Event) string {
+		what := "session error"
+		if e.Type == EventVerifyFailed {
+			what = "post-merge verification failed"
+		}
+		line := describeSession(e) + ": " + what
+		if e.Note != "" {
+			line += ": " + oneLine(e.Note)
+		}
+		return line
+	})
+	section("PRs awaiting review", s.PendingReviews, func(e Event) string {
+		return describeSession(e) + " " + e.PRURL
+	})
+	if len(s.ReadySessions) > 0 {
+		fmt.Fprintf(&sb, "\nSessions ready for review (%d)\n", len(s.ReadySessions))
+		for _, name := range s.ReadySessions {
+			fmt.Fprintf(&sb, "  - %s\n", name)
+		}
+	}
+	return sb.String()
+}
+
+// describeSession names an event's session, bead and project
+func describeSession(e Event) string {
+	parts := []string{}
+	for _, p := range []string{e.Session, e.Bead} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	s := strings.Join(parts, " ")
+	if e.Project != "" {
+		s += " (" + e.Project + ")"
+	}
+	return s
+}
+
+// oneLine shortens text to its first line, at most 120 characters
+func oneLine(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		s = s[:i]
+	}
+	if len(s) > 120 {
+		s = s[:117] + "..."
+	}
+	return s
+}
+
+func shortCommit(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
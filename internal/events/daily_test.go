@@ -0,0 +1,54 @@
+package events
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildDailySummary(t *testing.T) {
+	to := time.Date(2026, 3, 2, 18, 0, 0, 0, time.UTC)
+	from := to.Add(-24 * time.Hour)
+	at := func(d time.Duration) string { return to.Add(-d).Format(time.RFC3339) }
+	evts := []Event{
+		// Opened two days ago, merged today
+		{Time: at(48 * time.Hour), Type: EventSessionEnd, Session: "old", PRURL: "https://github.com/o/r/pull/1", MergeMode: "pr-review"},
+		{Time: at(3 * time.Hour), Type: EventPRMerged, PRURL: "https://github.com/o/r/pull/1"},
+		// Opened three days ago, still open
+		{Time: at(72 * time.Hour), Type: EventSessionEnd, Session: "waiting", Bead: "app-w", PRURL: "https://github.com/o/r/pull/2", MergeMode: "pr-review"},
+		{Time: at(5 * time.Hour), Type: EventSessionEnd, Session: "toast", Bead: "app-a", Project: "app", MergeMode: "direct", MergeCommit: "abcdef123456"},
+		{Time: at(4 * time.Hour), Type: EventSessionEnd, Session: "rye", MergeMode: "pr-auto", PRURL: "https://github.com/o/r/pull/3", MergeCommit: "123"},
+		{Time: at(2 * time.Hour), Type: EventSessionEnd, Session: "gone", MergeMode: "killed"},
+		{Time: at(time.Hour), Type: EventSessionError, Session: "shadow", Note: "tests keep failing"},
+		{Time: at(30 * time.Minute), Type: EventVerifyFailed, Session: "toast"},
+		// Outside the window
+		{Time: at(30 * time.Hour), Type: EventSessionError, Session: "yesterday"},
+		{Time: to.Add(time.Hour).Format(time.RFC3339), Type: EventSessionEnd, Session: "future"},
+	}
+
+	s := BuildDailySummary(evts, from, to)
+	if len(s.Completed) != 2 || len(s.Merged) != 2 || len(s.Failures) != 2 {
+		t.Fatalf("completed %d, merged %d, failures %d; want 2, 2, 2", len(s.Completed), len(s.Merged), len(s.Failures))
+	}
+	if len(s.PendingReviews) != 1 || s.PendingReviews[0].Session != "waiting" {
+		t.Errorf("PendingReviews = %+v, want only waiting", s.PendingReviews)
+	}
+
+	s.ReadySessions = []string{"bagel"}
+	if got, want := s.Subject(), "wt daily summary: 2 completed, 2 merged, 2 failed, 2 awaiting review"; got != want {
+		t.Errorf("Subject() = %q, want %q", got, want)
+	}
+	text := s.Text()
+	for _, want := range []string{
+		"toast app-a (app) merged directly (abcdef1)",
+		"rye https://github.com/o/r/pull/3",
+		"shadow: session error: tests keep failing",
+		"toast: post-merge verification failed",
+		"waiting app-w https://github.com/o/r/pull/2",
+		"Sessions ready for review (1)\n  - bagel",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Text() missing %q:\n%s", want, text)
+		}
+	}
+}
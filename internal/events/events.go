@@ -21,32 +21,66 @@ const (
 	EventSessionKill  EventType = "session_kill"
 	EventHubHandoff   EventType = "hub_handoff"
 	EventPRCreated    EventType = "pr_created"
+	EventPRUpdated    EventType = "pr_updated" // 'wt done' pushed follow-up commits to an open PR
 	EventPRMerged     EventType = "pr_merged"
 	EventCompaction   EventType = "compaction"
+	EventNote         EventType = "note" // Human annotation added with wt note
+	EventRollback     EventType = "rollback"
+	EventBlocked      EventType = "blocked"       // Worker stopped with wt block
+	EventUnblocked    EventType = "unblocked"     // Worker resumed with wt unblock
+	EventVerified     EventType = "verified"      // Default branch passed post-merge verification
+	EventVerifyFailed EventType = "verify_failed" // Default branch failed post-merge verification
+	EventSessionError EventType = "session_error" // Session entered the error state
+	EventBeadComment  EventType = "bead_comment"  // A new bead comment was passed to the session's worker
+	EventCommand      EventType = "command"       // A wt command was run on the session or its bead
+	// A worker stopped at a Claude tool permission dialog
+	EventPermissionRequested EventType = "permission_requested"
 )
 
 // Event represents a logged event
 type Event struct {
-	Time          string    `json:"time"`
-	Type          EventType `json:"type"`
-	Session       string    `json:"session"`
-	Bead          string    `json:"bead"`
-	Project       string    `json:"project"`
-	ClaudeSession string    `json:"claude_session,omitempty"`
-	PRURL         string    `json:"pr_url,omitempty"`
-	MergeMode     string    `json:"merge_mode,omitempty"`
-	WorktreePath  string    `json:"worktree,omitempty"`
+	Time          string          `json:"time"`
+	Type          EventType       `json:"type"`
+	Session       string          `json:"session"`
+	Bead          string          `json:"bead"`
+	Project       string          `json:"project"`
+	ClaudeSession string          `json:"claude_session,omitempty"`
+	PRURL         string          `json:"pr_url,omitempty"`
+	MergeMode     string          `json:"merge_mode,omitempty"`
+	WorktreePath  string          `json:"worktree,omitempty"`
+	Summary       *Summary        `json:"summary,omitempty"`
+	Note          string          `json:"note,omitempty"`          // Annotation text for note events
+	MergeCommit   string          `json:"merge_commit,omitempty"`  // Merge commit of a direct merge
+	RevertCommit  string          `json:"revert_commit,omitempty"` // Commit that rolled back MergeCommit
+	Permission    string          `json:"permission,omitempty"`    // Tool use asked for, e.g. "Bash(go test ./...)"
+	AutoApproved  bool            `json:"auto_approved,omitempty"` // Permission granted from the project's auto_approve list
+	Blocker       string          `json:"blocker,omitempty"`       // Bead a blocked worker is waiting on
+	Output        string          `json:"output,omitempty"`        // Last lines of the session's pane
+	Error         string          `json:"error,omitempty"`         // Why the command of a command event failed
+	Data          json.RawMessage `json:"data,omitempty"`          // Payload of a custom event
+}
+
+// Summary captures what a session accomplished, recorded when it ends
+type Summary struct {
+	Commits     []string `json:"commits,omitempty"`      // one-line commit log (hash + subject)
+	DiffStat    string   `json:"diff_stat,omitempty"`    // git diff --stat summary line
+	Text        string   `json:"text,omitempty"`         // one-paragraph narrative summary
+	LastMessage string   `json:"last_message,omitempty"` // the worker's last message from its Claude transcript, on one line
 }
 
 // Logger handles event logging
 type Logger struct {
 	eventsFile string
+	maxSize    int64         // rotate the log past this size, 0 = never
+	maxAge     time.Duration // drop archives older than this, 0 = keep forever
 }
 
 // NewLogger creates a new event logger
 func NewLogger(cfg *config.Config) *Logger {
 	return &Logger{
 		eventsFile: filepath.Join(cfg.ConfigDir(), "events.jsonl"),
+		maxSize:    cfg.EventsMaxSize(),
+		maxAge:     cfg.EventsMaxAge(),
 	}
 }
 
@@ -60,17 +94,20 @@ func (l *Logger) Log(event *Event) error {
 	if err != nil {
 		return fmt.Errorf("opening events file: %w", err)
 	}
-	defer f.Close()
 
 	data, err := json.Marshal(event)
 	if err != nil {
+		f.Close()
 		return fmt.Errorf("marshaling event: %w", err)
 	}
 
 	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
 		return fmt.Errorf("writing event: %w", err)
 	}
+	f.Close()
 
+	l.maybeCompact()
 	return nil
 }
 
@@ -98,6 +135,27 @@ func (l *Logger) LogSessionEnd(session, bead, project, claudeSession, mergeMode,
 	})
 }
 
+// LogSessionEndWithSummary logs a session end event including a session summary
+func (l *Logger) LogSessionEndWithSummary(session, bead, project, claudeSession, mergeMode, prURL string, summary *Summary) error {
+	return l.LogSessionEndMerged(session, bead, project, claudeSession, mergeMode, prURL, "", summary)
+}
+
+// LogSessionEndMerged logs a session end event that records the merge commit
+// of a direct merge, so the merge can be rolled back later
+func (l *Logger) LogSessionEndMerged(session, bead, project, claudeSession, mergeMode, prURL, mergeCommit string, summary *Summary) error {
+	return l.Log(&Event{
+		Type:          EventSessionEnd,
+		Session:       session,
+		Bead:          bead,
+		Project:       project,
+		ClaudeSession: claudeSession,
+		MergeMode:     mergeMode,
+		PRURL:         prURL,
+		Summary:       summary,
+		MergeCommit:   mergeCommit,
+	})
+}
+
 // LogSessionKill logs a session kill event
 func (l *Logger) LogSessionKill(session, bead, project string) error {
 	return l.Log(&Event{
@@ -132,26 +190,222 @@ func (l *Logger) LogCompaction(sessionName, bead, project, workdir string) error
 	})
 }
 
-// Recent returns the most recent N events
-func (l *Logger) Recent(n int) ([]Event, error) {
-	data, err := os.ReadFile(l.eventsFile)
+// LogNote logs a human annotation about a session or bead
+func (l *Logger) LogNote(session, bead, project, note string) error {
+	return l.Log(&Event{
+		Type:    EventNote,
+		Session: session,
+		Bead:    bead,
+		Project: project,
+		Note:    note,
+	})
+}
+
+// LogRollback logs the revert of a session's direct merge
+func (l *Logger) LogRollback(session, bead, project, mergeCommit, revertCommit string) error {
+	return l.Log(&Event{
+		Type:         EventRollback,
+		Session:      session,
+		Bead:         bead,
+		Project:      project,
+		MergeCommit:  mergeCommit,
+		RevertCommit: revertCommit,
+	})
+}
+
+// LogBlocked logs a worker stopping with a reason, and the bead it waits
+// on if any
+func (l *Logger) LogBlocked(session, bead, project, reason, blocker string) error {
+	return l.Log(&Event{
+		Type:    EventBlocked,
+		Session: session,
+		Bead:    bead,
+		Project: project,
+		Note:    reason,
+		Blocker: blocker,
+	})
+}
+
+// LogSessionError logs a session entering the error state, with its status
+// message and the last lines of its pane
+func (l *Logger) LogSessionError(session, bead, project, message, output string) error {
+	return l.Log(&Event{
+		Type:    EventSessionError,
+		Session: session,
+		Bead:    bead,
+		Project: project,
+		Note:    message,
+		Output:  output,
+	})
+}
+
+// LogBeadComment logs a bead comment delivered to the session's worker
+func (l *Logger) LogBeadComment(session, bead, project, comment string) error {
+	return l.Log(&Event{
+		Type:    EventBeadComment,
+		Session: session,
+		Bead:    bead,
+		Project: project,
+		Note:    comment,
+	})
+}
+
+// LogCommand logs a wt command run on a session or its bead, timed by when
+// it started. failure is its error, "" if it succeeded.
+func (l *Logger) LogCommand(session, bead, project, command string, started time.Time, failure string) error {
+	return l.Log(&Event{
+		Time:    started.Format(time.RFC3339),
+		Type:    EventCommand,
+		Session: session,
+		Bead:    bead,
+		Project: project,
+		Note:    command,
+		Error:   failure,
+	})
+}
+
+// LogUnblocked logs a blocked worker resuming
+func (l *Logger) LogUnblocked(session, bead, project, note string) error {
+	return l.Log(&Event{
+		Type:    EventUnblocked,
+		Session: session,
+		Bead:    bead,
+		Project: project,
+		Note:    note,
+	})
+}
+
+// LogVerify logs the outcome of a post-merge verification of the default
+// branch. mergeCommit is the merge that triggered it; note holds the tail
+// of a failing run's output.
+func (l *Logger) LogVerify(session, bead, project, mergeCommit, prURL string, passed bool, note string) error {
+	eventType := EventVerified
+	if !passed {
+		eventType = EventVerifyFailed
+	}
+	return l.Log(&Event{
+		Type:        eventType,
+		Session:     session,
+		Bead:        bead,
+		Project:     project,
+		MergeCommit: mergeCommit,
+		PRURL:       prURL,
+		Note:        note,
+	})
+}
+
+// FindVerifyFailure returns the most recent failed verification whose
+// session name or bead matches query, or nil
+func (l *Logger) FindVerifyFailure(query string) (*Event, error) {
+	all, err := l.All()
 	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, nil
+		return nil, err
+	}
+	for i := len(all) - 1; i >= 0; i-- {
+		e := all[i]
+		if e.Type == EventVerifyFailed && (e.Session == query || e.Bead == query) {
+			return &e, nil
+		}
+	}
+	return nil, nil
+}
+
+// FindMerge returns the most recent session end with a recorded merge commit
+// whose session name or bead matches query, and the rollback event for that
+// merge if it has already been reverted. Returns a nil merge if none matches.
+func (l *Logger) FindMerge(query string) (merge *Event, rollback *Event, err error) {
+	all, err := l.All()
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := len(all) - 1; i >= 0; i-- {
+		e := all[i]
+		if e.Type == EventSessionEnd && e.MergeCommit != "" && (e.Session == query || e.Bead == query) {
+			merge = &e
+			break
+		}
+	}
+	if merge == nil {
+		return nil, nil, nil
+	}
+	for _, e := range all {
+		if e.Type == EventRollback && e.MergeCommit == merge.MergeCommit {
+			return merge, &e, nil
 		}
+	}
+	return merge, nil, nil
+}
+
+// Notes returns all note events, oldest first
+func (l *Logger) Notes() ([]Event, error) {
+	all, err := l.All()
+	if err != nil {
 		return nil, err
 	}
+	var notes []Event
+	for _, e := range all {
+		if e.Type == EventNote {
+			notes = append(notes, e)
+		}
+	}
+	return notes, nil
+}
 
-	var allEvents []Event
-	for _, line := range splitLines(data) {
-		if len(line) == 0 {
+// NotesFor filters note events to those about a session (by name) or bead,
+// made between from and to (RFC3339; empty means unbounded). Session names
+// are reused, so the time window keeps notes on an older namesake out.
+func NotesFor(notes []Event, session, bead, from, to string) []Event {
+	var matched []Event
+	for _, e := range notes {
+		if e.Type != EventNote {
+			continue
+		}
+		if !(session != "" && e.Session == session) && !(bead != "" && e.Bead == bead) {
 			continue
 		}
-		var event Event
-		if err := json.Unmarshal(line, &event); err != nil {
-			continue // Skip invalid lines
+		if !withinWindow(e.Time, from, to) {
+			continue
+		}
+		matched = append(matched, e)
+	}
+	return matched
+}
+
+// withinWindow reports whether an RFC3339 time lies between from and to.
+// Unparseable bounds are treated as unbounded.
+func withinWindow(t, from, to string) bool {
+	at, err := time.Parse(time.RFC3339, t)
+	if err != nil {
+		return true
+	}
+	if start, err := time.Parse(time.RFC3339, from); err == nil && at.Before(start) {
+		return false
+	}
+	if end, err := time.Parse(time.RFC3339, to); err == nil && at.After(end) {
+		return false
+	}
+	return true
+}
+
+// Recent returns the most recent N events, reading rotated archives when
+// the active log has fewer
+func (l *Logger) Recent(n int) ([]Event, error) {
+	allEvents, err := readEventsFile(l.eventsFile)
+	if err != nil {
+		return nil, err
+	}
+	if len(allEvents) < n {
+		archives, err := l.Archives()
+		if err != nil {
+			return nil, err
+		}
+		for i := len(archives) - 1; i >= 0 && len(allEvents) < n; i-- {
+			older, err := readEventsFile(archives[i].Path)
+			if err != nil {
+				return nil, err
+			}
+			allEvents = append(older, allEvents...)
 		}
-		allEvents = append(allEvents, event)
 	}
 
 	// Return last N events
@@ -227,12 +481,25 @@ func (l *Logger) Since(d time.Duration) ([]Event, error) {
 	return l.SinceTime(cutoff)
 }
 
-// SinceTime returns events since the given time
+// SinceTime returns events since the given time, skipping archives whose
+// events all come before it
 func (l *Logger) SinceTime(cutoff time.Time) ([]Event, error) {
-	allEvents, err := l.All()
+	archives, err := l.Archives()
+	if err != nil {
+		return nil, err
+	}
+	for len(archives) > 0 && archives[0].Until.Before(cutoff) {
+		archives = archives[1:]
+	}
+	allEvents, err := readArchives(archives)
 	if err != nil {
 		return nil, err
 	}
+	current, err := readEventsFile(l.eventsFile)
+	if err != nil {
+		return nil, err
+	}
+	allEvents = append(allEvents, current...)
 
 	var filtered []Event
 	for _, e := range allEvents {
@@ -248,29 +515,21 @@ func (l *Logger) SinceTime(cutoff time.Time) ([]Event, error) {
 	return filtered, nil
 }
 
-// All returns all events
+// All returns all events, rotated archives included, oldest first
 func (l *Logger) All() ([]Event, error) {
-	data, err := os.ReadFile(l.eventsFile)
+	archives, err := l.Archives()
 	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, nil
-		}
 		return nil, err
 	}
-
-	var allEvents []Event
-	for _, line := range splitLines(data) {
-		if len(line) == 0 {
-			continue
-		}
-		var event Event
-		if err := json.Unmarshal(line, &event); err != nil {
-			continue
-		}
-		allEvents = append(allEvents, event)
+	allEvents, err := readArchives(archives)
+	if err != nil {
+		return nil, err
 	}
-
-	return allEvents, nil
+	current, err := readEventsFile(l.eventsFile)
+	if err != nil {
+		return nil, err
+	}
+	return append(allEvents, current...), nil
 }
 
 // NewSinceLastRead returns events since the last read marker and optionally clears
@@ -334,6 +593,10 @@ func (l *Logger) Tail(ctx context.Context, events chan<- Event) error {
 			if err != nil {
 				continue
 			}
+			if info.Size() < lastSize {
+				// Rotated: read the fresh log from the start
+				lastSize = 0
+			}
 
 			if info.Size() > lastSize {
 				// Read new content
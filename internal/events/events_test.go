@@ -99,6 +99,40 @@ func TestLogger_LogSessionEnd(t *testing.T) {
 	}
 }
 
+func TestLogger_LogSessionEndWithSummary(t *testing.T) {
+	cfg := setupTestConfig(t)
+	logger := NewLogger(cfg)
+
+	summary := &Summary{
+		Commits:  []string{"abc1234 Add feature", "def5678 Fix tests"},
+		DiffStat: "3 files changed, 42 insertions(+), 7 deletions(-)",
+		Text:     "Added the feature and fixed the tests.",
+	}
+	err := logger.LogSessionEndWithSummary("test-session", "test-bead", "test-project", "claude-123", "direct", "", summary)
+	if err != nil {
+		t.Fatalf("LogSessionEndWithSummary failed: %v", err)
+	}
+
+	events, _ := logger.Recent(10)
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+
+	e := events[0]
+	if e.Type != EventSessionEnd {
+		t.Errorf("expected type %s, got %s", EventSessionEnd, e.Type)
+	}
+	if e.Summary == nil {
+		t.Fatal("expected summary to be recorded")
+	}
+	if len(e.Summary.Commits) != 2 {
+		t.Errorf("expected 2 commits, got %d", len(e.Summary.Commits))
+	}
+	if e.Summary.Text != summary.Text {
+		t.Errorf("expected summary text %q, got %q", summary.Text, e.Summary.Text)
+	}
+}
+
 func TestLogger_LogSessionKill(t *testing.T) {
 	cfg := setupTestConfig(t)
 	logger := NewLogger(cfg)
@@ -359,3 +393,127 @@ func TestLogger_EventsFile(t *testing.T) {
 		t.Error("expected non-empty events file path")
 	}
 }
+
+func TestLogger_LogNote(t *testing.T) {
+	cfg := setupTestConfig(t)
+	logger := NewLogger(cfg)
+
+	_ = logger.LogSessionStart("toast", "bead-1", "proj", "/path")
+	if err := logger.LogNote("toast", "bead-1", "proj", "killed: wrong approach"); err != nil {
+		t.Fatalf("LogNote failed: %v", err)
+	}
+
+	notes, err := logger.Notes()
+	if err != nil {
+		t.Fatalf("Notes failed: %v", err)
+	}
+	if len(notes) != 1 {
+		t.Fatalf("expected 1 note, got %d", len(notes))
+	}
+	if notes[0].Type != EventNote || notes[0].Note != "killed: wrong approach" || notes[0].Session != "toast" {
+		t.Errorf("unexpected note event: %+v", notes[0])
+	}
+}
+
+func TestNotesFor(t *testing.T) {
+	notes := []Event{
+		{Type: EventNote, Time: "2026-01-01T10:00:00Z", Session: "toast", Bead: "bead-1", Note: "old namesake"},
+		{Type: EventNote, Time: "2026-01-02T10:00:00Z", Session: "toast", Bead: "bead-2", Note: "by session"},
+		{Type: EventNote, Time: "2026-01-02T11:00:00Z", Bead: "bead-2", Note: "by bead"},
+		{Type: EventNote, Time: "2026-01-02T12:00:00Z", Session: "rye", Bead: "bead-3", Note: "other"},
+		{Type: EventSessionStart, Time: "2026-01-02T10:30:00Z", Session: "toast", Bead: "bead-2"},
+	}
+
+	got := NotesFor(notes, "toast", "bead-2", "2026-01-02T09:00:00Z", "")
+	if len(got) != 2 || got[0].Note != "by session" || got[1].Note != "by bead" {
+		t.Errorf("NotesFor() with window = %+v", got)
+	}
+
+	got = NotesFor(notes, "toast", "", "", "2026-01-01T23:00:00Z")
+	if len(got) != 1 || got[0].Note != "old namesake" {
+		t.Errorf("NotesFor() up to end time = %+v", got)
+	}
+
+	// Bounds in another time zone compare by instant, not by string
+	got = NotesFor(notes, "rye", "", "2026-01-02T13:00:00+02:00", "")
+	if len(got) != 1 {
+		t.Errorf("NotesFor() with offset bound = %+v", got)
+	}
+}
+
+func TestLogger_FindMerge(t *testing.T) {
+	cfg := setupTestConfig(t)
+	logger := NewLogger(cfg)
+
+	_ = logger.LogSessionEndMerged("toast", "bead-1", "proj", "", "direct", "", "aaa111", nil)
+	_ = logger.LogSessionEnd("rye", "bead-2", "proj", "", "pr-review", "https://example.com/pr/1")
+	_ = logger.LogSessionEndMerged("bagel", "bead-1", "proj", "", "direct", "", "bbb222", nil)
+
+	// Most recent merge for the bead wins
+	merge, rollback, err := logger.FindMerge("bead-1")
+	if err != nil {
+		t.Fatalf("FindMerge failed: %v", err)
+	}
+	if merge == nil || merge.MergeCommit != "bbb222" || rollback != nil {
+		t.Errorf("FindMerge(bead-1) = %+v, %+v", merge, rollback)
+	}
+
+	merge, _, _ = logger.FindMerge("toast")
+	if merge == nil || merge.MergeCommit != "aaa111" {
+		t.Errorf("FindMerge(toast) = %+v", merge)
+	}
+
+	// PR merges record no merge commit
+	if merge, _, _ := logger.FindMerge("rye"); merge != nil {
+		t.Errorf("FindMerge(rye) = %+v, want nil", merge)
+	}
+
+	_ = logger.LogRollback("bagel", "bead-1", "proj", "bbb222", "ccc333")
+	_, rollback, _ = logger.FindMerge("bagel")
+	if rollback == nil || rollback.RevertCommit != "ccc333" {
+		t.Errorf("FindMerge(bagel) rollback = %+v", rollback)
+	}
+}
+
+func TestLogger_LogSessionError(t *testing.T) {
+	cfg := setupTestConfig(t)
+	logger := NewLogger(cfg)
+
+	if err := logger.LogSessionError("toast", "bead-1", "proj", "tests failing", "--- FAIL: TestLogin\nFAIL"); err != nil {
+		t.Fatalf("LogSessionError failed: %v", err)
+	}
+
+	recent, err := logger.Recent(1)
+	if err != nil {
+		t.Fatalf("Recent failed: %v", err)
+	}
+	if len(recent) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(recent))
+	}
+	e := recent[0]
+	if e.Type != EventSessionError || e.Note != "tests failing" || e.Output != "--- FAIL: TestLogin\nFAIL" {
+		t.Errorf("unexpected error event: %+v", e)
+	}
+}
+
+func TestLogger_LogCommand(t *testing.T) {
+	cfg := setupTestConfig(t)
+	logger := NewLogger(cfg)
+
+	started := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	if err := logger.LogCommand("toast", "bead-1", "proj", "wt nudge toast", started, "session not running"); err != nil {
+		t.Fatalf("LogCommand failed: %v", err)
+	}
+
+	recent, err := logger.Recent(1)
+	if err != nil {
+		t.Fatalf("Recent failed: %v", err)
+	}
+	if len(recent) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(recent))
+	}
+	e := recent[0]
+	if e.Type != EventCommand || e.Time != "2026-03-01T10:00:00Z" || e.Note != "wt nudge toast" || e.Error != "session not running" {
+		t.Errorf("unexpected command event: %+v", e)
+	}
+}
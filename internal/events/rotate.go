@@ -0,0 +1,299 @@
+package events
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// archiveTimeFormat names archives by the time of their newest event, so
+// they sort oldest first
+const archiveTimeFormat = "20060102-150405.000000000"
+
+// Archive is a rotated, gzip-compressed part of the events log. Until is
+// the time of its newest event.
+type Archive struct {
+	Path  string    `json:"path"`
+	Until time.Time `json:"until"`
+	Size  int64     `json:"size"`
+}
+
+// CompactResult reports what applying the retention policy did
+type CompactResult struct {
+	Rotated  *Archive  `json:"rotated,omitempty"` // archive the active log was rotated into
+	Removed  []Archive `json:"removed,omitempty"` // archives dropped for being older than max_age
+	Archives []Archive `json:"archives"`          // archives left, oldest first
+}
+
+// Archives returns the rotated parts of the events log, oldest first
+func (l *Logger) Archives() ([]Archive, error) {
+	dir := filepath.Dir(l.eventsFile)
+	prefix, suffix := l.archivePrefix(), ".jsonl.gz"
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var archives []Archive
+	for _, e := range entries {
+		name := e.Name()
+		if !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, suffix) {
+			continue
+		}
+		at, err := time.ParseInLocation(archiveTimeFormat, strings.TrimSuffix(strings.TrimPrefix(name, prefix), suffix), time.UTC)
+		if err != nil {
+			continue
+		}
+		a := Archive{Path: filepath.Join(dir, name), Until: at}
+		if info, err := e.Info(); err == nil {
+			a.Size = info.Size()
+		}
+		archives = append(archives, a)
+	}
+	sort.Slice(archives, func(i, j int) bool { return archives[i].Until.Before(archives[j].Until) })
+	return archives, nil
+}
+
+// archivePrefix is "events-" for events.jsonl
+func (l *Logger) archivePrefix() string {
+	return strings.TrimSuffix(filepath.Base(l.eventsFile), ".jsonl") + "-"
+}
+
+// Compact applies the retention policy: the active log is rotated if it is
+// over max_size or holds events older than max_age, and archives whose
+// events are all older than max_age are deleted
+func (l *Logger) Compact() (*CompactResult, error) {
+	result := &CompactResult{}
+	if l.needsRotation(time.Now()) {
+		a, err := l.rotate(time.Now())
+		if err != nil {
+			return nil, err
+		}
+		result.Rotated = a
+	}
+	removed, err := l.prune(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	result.Removed = removed
+	if result.Archives, err = l.Archives(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// maybeCompact is Compact without the report, run after each write
+func (l *Logger) maybeCompact() {
+	if l.maxSize <= 0 && l.maxAge <= 0 {
+		return
+	}
+	now := time.Now()
+	if l.needsRotation(now) {
+		if _, err := l.rotate(now); err != nil {
+			return
+		}
+		l.prune(now)
+	}
+}
+
+// needsRotation reports whether the active log is over max_size or its
+// oldest event is older than max_age
+func (l *Logger) needsRotation(now time.Time) bool {
+	info, err := os.Stat(l.eventsFile)
+	if err != nil || info.Size() == 0 {
+		return false
+	}
+	if l.maxSize > 0 && info.Size() > l.maxSize {
+		return true
+	}
+	if l.maxAge > 0 {
+		if oldest, ok := l.oldestEventTime(); ok && now.Sub(oldest) > l.maxAge {
+			return true
+		}
+	}
+	return false
+}
+
+// oldestEventTime reads the time of the first event in the active log
+func (l *Logger) oldestEventTime() (time.Time, bool) {
+	f, err := os.Open(l.eventsFile)
+	if err != nil {
+		return time.Time{}, false
+	}
+	defer f.Close()
+	line, err := bufio.NewReader(f).ReadBytes('\n')
+	if err != nil && err != io.EOF {
+		return time.Time{}, false
+	}
+	var e Event
+	if json.Unmarshal(line, &e) != nil {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, e.Time)
+	return t, err == nil
+}
+
+// rotate moves the active log aside and compresses it into an archive
+// named after its newest event (now if it has none). Renaming first means a
+// concurrent rotation finds nothing to move, and new events go to a fresh
+// events.jsonl right away.
+func (l *Logger) rotate(now time.Time) (*Archive, error) {
+	moved := l.eventsFile + ".rotating-" + now.UTC().Format(archiveTimeFormat)
+	if err := os.Rename(l.eventsFile, moved); err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("rotating events log: %w", err)
+	}
+	until := now.UTC()
+	if evts, err := readEventsFile(moved); err == nil {
+		if newest, ok := newestEventTime(evts); ok {
+			until = newest.UTC()
+		}
+	}
+	archive := l.archivePath(until)
+	for _, err := os.Stat(archive); err == nil; _, err = os.Stat(archive) {
+		until = until.Add(time.Nanosecond)
+		archive = l.archivePath(until)
+	}
+	if err := gzipFile(moved, archive); err != nil {
+		// Put the log back unless new events have started a fresh one
+		if _, serr := os.Stat(l.eventsFile); os.IsNotExist(serr) {
+			os.Rename(moved, l.eventsFile)
+		}
+		return nil, fmt.Errorf("compressing events log: %w", err)
+	}
+	os.Remove(moved)
+	a := &Archive{Path: archive, Until: until}
+	if info, err := os.Stat(archive); err == nil {
+		a.Size = info.Size()
+	}
+	return a, nil
+}
+
+func (l *Logger) archivePath(until time.Time) string {
+	return filepath.Join(filepath.Dir(l.eventsFile), l.archivePrefix()+until.Format(archiveTimeFormat)+".jsonl.gz")
+}
+
+// newestEventTime returns the latest time among events
+func newestEventTime(evts []Event) (time.Time, bool) {
+	var newest time.Time
+	for _, e := range evts {
+		if t, err := time.Parse(time.RFC3339, e.Time); err == nil && t.After(newest) {
+			newest = t
+		}
+	}
+	return newest, !newest.IsZero()
+}
+
+// prune deletes archives whose newest event is older than max_age
+func (l *Logger) prune(now time.Time) ([]Archive, error) {
+	if l.maxAge <= 0 {
+		return nil, nil
+	}
+	archives, err := l.Archives()
+	if err != nil {
+		return nil, err
+	}
+	var removed []Archive
+	for _, a := range archives {
+		if now.Sub(a.Until) <= l.maxAge {
+			continue
+		}
+		if err := os.Remove(a.Path); err != nil {
+			return removed, fmt.Errorf("removing %s: %w", a.Path, err)
+		}
+		removed = append(removed, a)
+	}
+	return removed, nil
+}
+
+func gzipFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	tmp := dst + ".tmp"
+	out, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(out)
+	_, err = io.Copy(zw, in)
+	if cerr := zw.Close(); err == nil {
+		err = cerr
+	}
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, dst)
+}
+
+// readEventsFile parses a JSONL events file, gzip-compressed or not. A
+// missing file has no events.
+func readEventsFile(path string) ([]Event, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		zr, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", path, err)
+		}
+		defer zr.Close()
+		r = zr
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return parseEvents(data), nil
+}
+
+func parseEvents(data []byte) []Event {
+	var events []Event
+	for _, line := range splitLines(data) {
+		if len(line) == 0 {
+			continue
+		}
+		var event Event
+		if err := json.Unmarshal(line, &event); err != nil {
+			continue // Skip invalid lines
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+// readArchives returns the events of the given archives, oldest first
+func readArchives(archives []Archive) ([]Event, error) {
+	var all []Event
+	for _, a := range archives {
+		events, err := readEventsFile(a.Path)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, events...)
+	}
+	return all, nil
+}
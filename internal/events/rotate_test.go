@@ -0,0 +1,104 @@
+package events
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func logAt(t *testing.T, l *Logger, session string, at time.Time) {
+	t.Helper()
+	if err := l.Log(&Event{Type: EventSessionStart, Session: session, Time: at.Format(time.RFC3339)}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func sessions(evts []Event) []string {
+	var names []string
+	for _, e := range evts {
+		names = append(names, e.Session)
+	}
+	return names
+}
+
+func TestRotationBySize(t *testing.T) {
+	l := &Logger{eventsFile: filepath.Join(t.TempDir(), "events.jsonl"), maxSize: 200}
+	now := time.Now()
+	for i, name := range []string{"a", "b", "c", "d", "e"} {
+		logAt(t, l, name, now.Add(time.Duration(i-5)*time.Minute))
+	}
+
+	archives, err := l.Archives()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(archives) == 0 {
+		t.Fatal("log past max size was not rotated")
+	}
+	if info, err := os.Stat(l.eventsFile); err == nil && info.Size() > l.maxSize {
+		t.Errorf("active log is %d bytes after rotation", info.Size())
+	}
+
+	all, err := l.All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := sessions(all); len(got) != 5 || got[0] != "a" || got[4] != "e" {
+		t.Errorf("All() = %v, want a..e in order", got)
+	}
+	recent, err := l.Recent(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := sessions(recent); len(got) != 4 || got[0] != "b" || got[3] != "e" {
+		t.Errorf("Recent(4) = %v, want b..e", got)
+	}
+	since, err := l.SinceTime(now.Add(-150 * time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := sessions(since); len(got) != 2 || got[0] != "d" {
+		t.Errorf("SinceTime() = %v, want d, e", got)
+	}
+}
+
+func TestCompactByAge(t *testing.T) {
+	l := &Logger{eventsFile: filepath.Join(t.TempDir(), "events.jsonl")}
+	now := time.Now()
+
+	// An expired archive, and a log whose oldest event has expired
+	logAt(t, l, "ancient", now.Add(-100*24*time.Hour))
+	if _, err := l.rotate(now); err != nil {
+		t.Fatal(err)
+	}
+	logAt(t, l, "old", now.Add(-40*24*time.Hour))
+	logAt(t, l, "new", now.Add(-time.Hour))
+	l.maxAge = 30 * 24 * time.Hour
+
+	result, err := l.Compact()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Rotated == nil {
+		t.Error("log holding expired events was not rotated")
+	}
+	if len(result.Removed) != 1 || len(result.Archives) != 1 {
+		t.Errorf("Compact() removed %d and kept %d archives, want 1 and 1", len(result.Removed), len(result.Archives))
+	}
+	all, err := l.All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := sessions(all); len(got) != 2 || got[0] != "old" || got[1] != "new" {
+		t.Errorf("All() after compact = %v, want old, new", got)
+	}
+
+	again, err := l.Compact()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.Rotated != nil || len(again.Removed) != 0 {
+		t.Errorf("second Compact() changed something: %+v", again)
+	}
+}
@@ -0,0 +1,47 @@
+// Package filelock provides advisory locks on files, for wt processes
+// that share state on disk: flock on Unix and LockFileEx on Windows.
+package filelock
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// pollInterval is how often a waiting Lock retries
+var pollInterval = 50 * time.Millisecond
+
+// Lock takes an advisory lock on the file at path, creating it and its
+// directory if needed. The lock is shared or exclusive; Lock waits up to
+// timeout for a conflicting lock to go and returns a function releasing it.
+func Lock(path string, shared bool, timeout time.Duration) (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("creating lock dir: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("opening lock: %w", err)
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		locked, err := tryLockFile(f, shared)
+		if locked {
+			break
+		}
+		if err != nil || time.Now().After(deadline) {
+			f.Close()
+			if err == nil {
+				err = fmt.Errorf("timed out after %v", timeout)
+			}
+			return nil, err
+		}
+		time.Sleep(pollInterval)
+	}
+
+	return func() {
+		unlockFile(f)
+		f.Close()
+	}, nil
+}
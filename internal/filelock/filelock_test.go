@@ -0,0 +1,31 @@
+package filelock
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "locks", "a.lock")
+
+	unlock, err := Lock(path, false, time.Second)
+	if err != nil {
+		t.Fatalf("Lock() error: %v", err)
+	}
+	if _, err := Lock(path, true, 100*time.Millisecond); err == nil {
+		t.Fatal("Lock(shared) succeeded while an exclusive lock is held")
+	}
+	unlock()
+
+	unlockA, err := Lock(path, true, time.Second)
+	if err != nil {
+		t.Fatalf("Lock(shared) error: %v", err)
+	}
+	defer unlockA()
+	unlockB, err := Lock(path, true, time.Second)
+	if err != nil {
+		t.Fatalf("second Lock(shared) error: %v", err)
+	}
+	unlockB()
+}
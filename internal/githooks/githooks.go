@@ -0,0 +1,206 @@
+// Package githooks renders per-project git hooks and installs them into
+// session worktrees. Hooks are written to the worktree's private git dir and
+// enabled with a worktree-scoped core.hooksPath, so the main checkout and
+// other worktrees are unaffected. Any hooks the repo already had are chained.
+package githooks
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/project"
+)
+
+// Hook names managed by wt
+const (
+	PreCommit = "pre-commit"
+	CommitMsg = "commit-msg"
+)
+
+// hooksDirName is the directory inside the worktree's git dir holding wt hooks
+const hooksDirName = "wt-hooks"
+
+// Vars are substituted into hook commands and checks.
+type Vars struct {
+	BeadID  string
+	Session string
+	Project string
+	Branch  string
+}
+
+// Enabled returns true if the project has any git hooks configured.
+func Enabled(proj *project.Project) bool {
+	if proj == nil || proj.GitHooks == nil {
+		return false
+	}
+	h := proj.GitHooks
+	return h.RequireBeadID || h.RequireTrailer != "" || len(h.PreCommit) > 0 || len(h.CommitMsg) > 0
+}
+
+// Render returns the hook scripts for a project keyed by hook name.
+// chainDir is the repo's original hooks directory; its hooks run after ours.
+// Hooks with nothing configured are omitted.
+func Render(proj *project.Project, vars Vars, chainDir string) map[string]string {
+	scripts := make(map[string]string)
+	if !Enabled(proj) {
+		return scripts
+	}
+	h := proj.GitHooks
+
+	if len(h.PreCommit) > 0 {
+		var sb strings.Builder
+		writeHeader(&sb, PreCommit)
+		for _, c := range h.PreCommit {
+			sb.WriteString(expand(c, vars) + "\n")
+		}
+		writeChain(&sb, PreCommit, chainDir)
+		scripts[PreCommit] = sb.String()
+	}
+
+	if h.RequireBeadID || h.RequireTrailer != "" || len(h.CommitMsg) > 0 {
+		var sb strings.Builder
+		writeHeader(&sb, CommitMsg)
+		sb.WriteString("msg_file=\"$1\"\n\n")
+		sb.WriteString("# Merge commits are created by wt itself\n")
+		sb.WriteString("[ -f \"$(git rev-parse --git-dir)/MERGE_HEAD\" ] && exit 0\n\n")
+		if h.RequireBeadID && vars.BeadID != "" {
+			sb.WriteString(fmt.Sprintf("if ! grep -qF %s \"$msg_file\"; then\n", shellQuote(vars.BeadID)))
+			sb.WriteString(fmt.Sprintf("  echo \"commit-msg: commit message must reference bead %s\" >&2\n", vars.BeadID))
+			sb.WriteString("  exit 1\nfi\n\n")
+		}
+		if h.RequireTrailer != "" {
+			trailer := expand(h.RequireTrailer, vars)
+			sb.WriteString(fmt.Sprintf("if ! git interpret-trailers --parse \"$msg_file\" | grep -qi %s; then\n", shellQuote("^"+trailer+":")))
+			sb.WriteString(fmt.Sprintf("  echo \"commit-msg: commit message must include a '%s:' trailer\" >&2\n", trailer))
+			sb.WriteString("  exit 1\nfi\n\n")
+		}
+		for _, c := range h.CommitMsg {
+			sb.WriteString(expand(c, vars) + "\n")
+		}
+		writeChain(&sb, CommitMsg, chainDir)
+		scripts[CommitMsg] = sb.String()
+	}
+
+	return scripts
+}
+
+// Install writes the project's hooks into a worktree and points the worktree's
+// core.hooksPath at them. Returns the names of the installed hooks, and
+// whether it had to turn on extensions.worktreeConfig in the repo's shared
+// config, which changes how every worktree of the repo reads its config.
+func Install(proj *project.Project, worktreePath string, vars Vars) (installed []string, enabledWorktreeConfig bool, err error) {
+	if !Enabled(proj) {
+		return nil, false, nil
+	}
+
+	gitDir, err := gitOutput(worktreePath, "rev-parse", "--absolute-git-dir")
+	if err != nil {
+		return nil, false, fmt.Errorf("finding git dir: %w", err)
+	}
+	hooksDir := filepath.Join(gitDir, hooksDirName)
+
+	chainDir, err := originalHooksDir(worktreePath, hooksDir)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if err := os.MkdirAll(hooksDir, 0755); err != nil {
+		return nil, false, fmt.Errorf("creating hooks dir: %w", err)
+	}
+
+	for name, script := range Render(proj, vars, chainDir) {
+		if err := os.WriteFile(filepath.Join(hooksDir, name), []byte(script), 0755); err != nil {
+			return installed, false, fmt.Errorf("writing %s hook: %w", name, err)
+		}
+		installed = append(installed, name)
+	}
+
+	// Worktree-scoped config needs the worktreeConfig extension on the repo
+	if !WorktreeConfigEnabled(worktreePath) {
+		if _, err := gitOutput(worktreePath, "config", "extensions.worktreeConfig", "true"); err != nil {
+			return installed, false, fmt.Errorf("enabling worktree config: %w", err)
+		}
+		enabledWorktreeConfig = true
+	}
+	if _, err := gitOutput(worktreePath, "config", "--worktree", "core.hooksPath", hooksDir); err != nil {
+		return installed, enabledWorktreeConfig, fmt.Errorf("setting core.hooksPath: %w", err)
+	}
+
+	return installed, enabledWorktreeConfig, nil
+}
+
+// WorktreeConfigEnabled reports whether the repo of a worktree has
+// extensions.worktreeConfig on
+func WorktreeConfigEnabled(worktreePath string) bool {
+	out, err := gitOutput(worktreePath, "config", "--bool", "extensions.worktreeConfig")
+	return err == nil && out == "true"
+}
+
+// originalHooksDir returns the hooks directory git would use without wt,
+// ignoring a previous wt install in this worktree.
+func originalHooksDir(worktreePath, wtHooksDir string) (string, error) {
+	dir, err := gitOutput(worktreePath, "rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return "", fmt.Errorf("finding hooks dir: %w", err)
+	}
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(worktreePath, dir)
+	}
+	if filepath.Clean(dir) != filepath.Clean(wtHooksDir) {
+		return dir, nil
+	}
+
+	// Already installed: fall back to the shared config or the common dir
+	if shared, err := gitOutput(worktreePath, "config", "--local", "core.hooksPath"); err == nil && shared != "" {
+		return shared, nil
+	}
+	common, err := gitOutput(worktreePath, "rev-parse", "--path-format=absolute", "--git-common-dir")
+	if err != nil {
+		return "", fmt.Errorf("finding git common dir: %w", err)
+	}
+	return filepath.Join(common, "hooks"), nil
+}
+
+func writeHeader(sb *strings.Builder, name string) {
+	sb.WriteString("#!/bin/sh\n")
+	sb.WriteString(fmt.Sprintf("# %s hook installed by wt. Edit the project's git_hooks config and\n", name))
+	sb.WriteString("# run 'wt project hooks install' instead of changing this file.\n")
+	sb.WriteString("set -e\n\n")
+}
+
+func writeChain(sb *strings.Builder, name, chainDir string) {
+	if chainDir == "" {
+		return
+	}
+	orig := shellQuote(filepath.Join(chainDir, name))
+	sb.WriteString("\n# Run the repository's own hook, if any\n")
+	sb.WriteString(fmt.Sprintf("if [ -x %s ]; then\n", orig))
+	sb.WriteString(fmt.Sprintf("  exec %s \"$@\"\n", orig))
+	sb.WriteString("fi\n")
+}
+
+// expand replaces {BEAD_ID}, {SESSION}, {PROJECT} and {BRANCH} placeholders.
+func expand(s string, vars Vars) string {
+	s = strings.ReplaceAll(s, "{BEAD_ID}", vars.BeadID)
+	s = strings.ReplaceAll(s, "{SESSION}", vars.Session)
+	s = strings.ReplaceAll(s, "{PROJECT}", vars.Project)
+	s = strings.ReplaceAll(s, "{BRANCH}", vars.Branch)
+	return s
+}
+
+// shellQuote quotes s for use as a single sh word.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func gitOutput(dir string, args ...string) (string, error) {
+	cmd := logging.CommandIn(dir, "git", args...)
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
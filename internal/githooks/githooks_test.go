@@ -0,0 +1,152 @@
+package githooks
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/badri/wt/internal/project"
+)
+
+func TestEnabled(t *testing.T) {
+	tests := []struct {
+		name string
+		proj *project.Project
+		want bool
+	}{
+		{"nil project", nil, false},
+		{"no git hooks", &project.Project{}, false},
+		{"empty git hooks", &project.Project{GitHooks: &project.GitHooks{}}, false},
+		{"require bead id", &project.Project{GitHooks: &project.GitHooks{RequireBeadID: true}}, true},
+		{"pre-commit only", &project.Project{GitHooks: &project.GitHooks{PreCommit: []string{"make lint"}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Enabled(tt.proj); got != tt.want {
+				t.Errorf("Enabled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	proj := &project.Project{GitHooks: &project.GitHooks{
+		RequireBeadID:  true,
+		RequireTrailer: "Co-Authored-By",
+		PreCommit:      []string{"echo checking {BEAD_ID} on {BRANCH}"},
+	}}
+	vars := Vars{BeadID: "wt-abc", Session: "toast", Project: "wt", Branch: "wt-abc"}
+
+	scripts := Render(proj, vars, "/repo/.git/hooks")
+	if len(scripts) != 2 {
+		t.Fatalf("Render() returned %d scripts, want 2", len(scripts))
+	}
+
+	pre := scripts[PreCommit]
+	for _, want := range []string{"#!/bin/sh", "echo checking wt-abc on wt-abc", "exec '/repo/.git/hooks/pre-commit' \"$@\""} {
+		if !strings.Contains(pre, want) {
+			t.Errorf("pre-commit missing %q:\n%s", want, pre)
+		}
+	}
+
+	msg := scripts[CommitMsg]
+	for _, want := range []string{"grep -qF 'wt-abc'", "'^Co-Authored-By:'", "MERGE_HEAD"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("commit-msg missing %q:\n%s", want, msg)
+		}
+	}
+}
+
+func TestRenderSkipsBeadCheckWithoutBead(t *testing.T) {
+	proj := &project.Project{GitHooks: &project.GitHooks{RequireBeadID: true}}
+
+	scripts := Render(proj, Vars{Session: "task-toast"}, "")
+	if strings.Contains(scripts[CommitMsg], "grep -qF") {
+		t.Errorf("commit-msg should not check bead ID for task sessions:\n%s", scripts[CommitMsg])
+	}
+	if strings.Contains(scripts[CommitMsg], "exec ") {
+		t.Errorf("commit-msg should not chain without a hooks dir:\n%s", scripts[CommitMsg])
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	if got := shellQuote("it's"); got != `'it'\''s'` {
+		t.Errorf("shellQuote() = %s", got)
+	}
+}
+
+func TestInstall(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	repo := t.TempDir()
+	git(t, repo, "init", "-q", "-b", "main")
+	git(t, repo, "config", "user.email", "test@example.com")
+	git(t, repo, "config", "user.name", "Test")
+	git(t, repo, "commit", "-q", "--allow-empty", "-m", "initial")
+
+	wt := filepath.Join(t.TempDir(), "toast")
+	git(t, repo, "worktree", "add", "-q", "-b", "wt-abc", wt)
+
+	proj := &project.Project{GitHooks: &project.GitHooks{RequireBeadID: true, RequireTrailer: "Co-Authored-By"}}
+	installed, enabled, err := Install(proj, wt, Vars{BeadID: "wt-abc", Session: "toast"})
+	if err != nil {
+		t.Fatalf("Install() error: %v", err)
+	}
+	if len(installed) != 1 || installed[0] != CommitMsg {
+		t.Errorf("Install() = %v, want [%s]", installed, CommitMsg)
+	}
+	if !enabled || !WorktreeConfigEnabled(repo) {
+		t.Errorf("Install() enabled worktree config = %v, want it turned on", enabled)
+	}
+
+	// Commits without the bead ID or trailer are rejected
+	if err := gitErr(wt, "commit", "--allow-empty", "-m", "no bead"); err == nil {
+		t.Error("commit without bead ID should be rejected")
+	}
+	if err := gitErr(wt, "commit", "--allow-empty", "-m", "wt-abc: no trailer"); err == nil {
+		t.Error("commit without trailer should be rejected")
+	}
+	if err := gitErr(wt, "commit", "--allow-empty", "-m", "wt-abc: fix\n\nCo-Authored-By: Someone <s@example.com>"); err != nil {
+		t.Errorf("valid commit rejected: %v", err)
+	}
+
+	// The main checkout is unaffected
+	if err := gitErr(repo, "commit", "--allow-empty", "-m", "anything"); err != nil {
+		t.Errorf("main checkout commit rejected: %v", err)
+	}
+
+	// Reinstalling keeps chaining to the original hooks dir, not to itself
+	if _, enabled, err := Install(proj, wt, Vars{BeadID: "wt-abc"}); err != nil || enabled {
+		t.Fatalf("second Install() = %v, %v; want the worktree config left as it was", enabled, err)
+	}
+	gitDir := strings.TrimSpace(git(t, wt, "rev-parse", "--absolute-git-dir"))
+	data, err := os.ReadFile(filepath.Join(gitDir, hooksDirName, CommitMsg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), hooksDirName+"/"+CommitMsg) {
+		t.Errorf("reinstalled hook chains to itself:\n%s", data)
+	}
+}
+
+func git(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, output)
+	}
+	return string(output)
+}
+
+func gitErr(dir string, args ...string) error {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	return cmd.Run()
+}
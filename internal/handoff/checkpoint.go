@@ -6,7 +6,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 	"time"
@@ -14,6 +13,7 @@ import (
 	"github.com/badri/wt/internal/bead"
 	"github.com/badri/wt/internal/config"
 	"github.com/badri/wt/internal/events"
+	"github.com/badri/wt/internal/logging"
 	"github.com/badri/wt/internal/session"
 )
 
@@ -126,7 +126,7 @@ func SaveCheckpoint(cfg *config.Config, opts *CheckpointOptions) (*Checkpoint, e
 	if err := logger.LogCompaction(sessionName, cp.Bead, cp.Project, cwd); err != nil {
 		// Non-fatal
 		if !opts.Quiet {
-			fmt.Fprintf(os.Stderr, "Warning: could not log compaction event: %v\n", err)
+			logging.Warnf("could not log compaction event: %v", err)
 		}
 	}
 
@@ -275,7 +275,7 @@ func saveCheckpointFile(path string, cp *Checkpoint) error {
 // Git helpers
 
 func getGitBranch() string {
-	cmd := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD")
+	cmd := logging.Command("git", "rev-parse", "--abbrev-ref", "HEAD")
 	out, err := cmd.Output()
 	if err != nil {
 		return "unknown"
@@ -284,7 +284,7 @@ func getGitBranch() string {
 }
 
 func getGitDiffStat() string {
-	cmd := exec.Command("git", "diff", "--stat", "HEAD")
+	cmd := logging.Command("git", "diff", "--stat", "HEAD")
 	var out bytes.Buffer
 	cmd.Stdout = &out
 	cmd.Run()
@@ -292,7 +292,7 @@ func getGitDiffStat() string {
 }
 
 func getGitStatusBrief() string {
-	cmd := exec.Command("git", "status", "-s")
+	cmd := logging.Command("git", "status", "-s")
 	var out bytes.Buffer
 	cmd.Stdout = &out
 	cmd.Run()
@@ -306,7 +306,7 @@ func getGitStatusBrief() string {
 }
 
 func getRecentCommits(n int) string {
-	cmd := exec.Command("git", "log", "--oneline", fmt.Sprintf("-%d", n))
+	cmd := logging.Command("git", "log", "--oneline", fmt.Sprintf("-%d", n))
 	var out bytes.Buffer
 	cmd.Stdout = &out
 	cmd.Run()
@@ -0,0 +1,168 @@
+package handoff
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/badri/wt/internal/config"
+)
+
+const (
+	// DecisionsFile is the hub's decision journal, one JSON decision per line
+	DecisionsFile = "hub_decisions.jsonl"
+
+	// contextDecisions is how many recent decisions handoff context and
+	// prime output include; wt hub log shows the rest
+	contextDecisions = 20
+
+	// decisionsHeading starts the journal section of handoff context, so
+	// prime can tell the handoff already carries it
+	decisionsHeading = "### Decision Journal"
+)
+
+// Decision is one entry of the hub's decision journal: what the
+// orchestrator decided and why, kept across handoffs and compaction
+type Decision struct {
+	Time    time.Time `json:"time"`
+	Text    string    `json:"text"`
+	Bead    string    `json:"bead,omitempty"`
+	Session string    `json:"session,omitempty"` // wt session the decision is about
+	Hub     string    `json:"hub,omitempty"`     // Claude session ID of the hub that made it
+}
+
+// DecisionFilter selects journal entries for wt hub log
+type DecisionFilter struct {
+	Since   time.Time
+	Bead    string
+	Session string
+	Grep    string // case-insensitive substring of the text
+	Limit   int    // newest N matches, 0 for all
+}
+
+func decisionsPath(cfg *config.Config) string {
+	return filepath.Join(cfg.ConfigDir(), DecisionsFile)
+}
+
+// AppendDecision adds d to the journal, stamped with the current time and
+// the hub's Claude session when those are unset
+func AppendDecision(cfg *config.Config, d *Decision) error {
+	d.Text = strings.TrimSpace(d.Text)
+	if d.Text == "" {
+		return fmt.Errorf("empty decision")
+	}
+	if d.Time.IsZero() {
+		d.Time = time.Now().UTC()
+	}
+	if d.Hub == "" {
+		d.Hub = getClaudeSession()
+	}
+	data, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+
+	path := decisionsPath(cfg)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+// LoadDecisions reads the journal, oldest first. Lines that don't parse
+// are skipped; a missing journal is empty.
+func LoadDecisions(cfg *config.Config) ([]Decision, error) {
+	f, err := os.Open(decisionsPath(cfg))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var decisions []Decision
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var d Decision
+		if json.Unmarshal(scanner.Bytes(), &d) == nil && d.Text != "" {
+			decisions = append(decisions, d)
+		}
+	}
+	return decisions, scanner.Err()
+}
+
+// FilterDecisions returns the decisions matching f, oldest first
+func FilterDecisions(decisions []Decision, f DecisionFilter) []Decision {
+	grep := strings.ToLower(f.Grep)
+	var out []Decision
+	for _, d := range decisions {
+		switch {
+		case !f.Since.IsZero() && d.Time.Before(f.Since):
+		case f.Bead != "" && d.Bead != f.Bead:
+		case f.Session != "" && d.Session != f.Session:
+		case grep != "" && !strings.Contains(strings.ToLower(d.Text), grep):
+		default:
+			out = append(out, d)
+		}
+	}
+	if f.Limit > 0 && len(out) > f.Limit {
+		out = out[len(out)-f.Limit:]
+	}
+	return out
+}
+
+// RecentDecisions returns the last n decisions of the journal
+func RecentDecisions(cfg *config.Config, n int) ([]Decision, int, error) {
+	decisions, err := LoadDecisions(cfg)
+	if err != nil {
+		return nil, 0, err
+	}
+	return FilterDecisions(decisions, DecisionFilter{Limit: n}), len(decisions), nil
+}
+
+// FormatDecision is one journal line: time, what it is about and the text
+func FormatDecision(d Decision) string {
+	var about []string
+	if d.Bead != "" {
+		about = append(about, d.Bead)
+	}
+	if d.Session != "" {
+		about = append(about, d.Session)
+	}
+	line := d.Time.Local().Format("2006-01-02 15:04")
+	if len(about) > 0 {
+		line += " [" + strings.Join(about, ", ") + "]"
+	}
+	return line + " " + d.Text
+}
+
+// FormatDecisions renders recent decisions for handoff context and prime,
+// pointing at wt hub log when older ones were left out
+func FormatDecisions(decisions []Decision, total int) string {
+	if len(decisions) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString(decisionsHeading + "\n")
+	sb.WriteString("Decisions earlier hubs recorded with wt hub note, oldest first:\n")
+	for _, d := range decisions {
+		sb.WriteString("- " + FormatDecision(d) + "\n")
+	}
+	if older := total - len(decisions); older > 0 {
+		sb.WriteString(fmt.Sprintf("(%d older decisions: wt hub log)\n", older))
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
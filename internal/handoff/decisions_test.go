@@ -0,0 +1,140 @@
+package handoff
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/badri/wt/internal/config"
+)
+
+func TestAppendAndLoadDecisions(t *testing.T) {
+	cfg, err := config.LoadFromDir(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	t.Setenv("CLAUDE_SESSION_ID", "hub-1")
+
+	decisions, err := LoadDecisions(cfg)
+	if err != nil || len(decisions) != 0 {
+		t.Fatalf("LoadDecisions() on a new config = %v, %v", decisions, err)
+	}
+
+	if err := AppendDecision(cfg, &Decision{Text: "  Hold proj-12  ", Bead: "proj-12"}); err != nil {
+		t.Fatalf("AppendDecision() error: %v", err)
+	}
+	if err := AppendDecision(cfg, &Decision{Text: "Kill toast", Session: "toast"}); err != nil {
+		t.Fatalf("AppendDecision() error: %v", err)
+	}
+	if err := AppendDecision(cfg, &Decision{Text: " "}); err == nil {
+		t.Error("AppendDecision() should reject an empty decision")
+	}
+
+	// A damaged line doesn't hide the rest of the journal
+	f, err := os.OpenFile(filepath.Join(cfg.ConfigDir(), DecisionsFile), os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString("{not json\n")
+	f.Close()
+
+	decisions, err = LoadDecisions(cfg)
+	if err != nil {
+		t.Fatalf("LoadDecisions() error: %v", err)
+	}
+	if len(decisions) != 2 {
+		t.Fatalf("got %d decisions, want 2", len(decisions))
+	}
+	d := decisions[0]
+	if d.Text != "Hold proj-12" || d.Bead != "proj-12" || d.Hub != "hub-1" || d.Time.IsZero() {
+		t.Errorf("first decision = %+v", d)
+	}
+	if decisions[1].Session != "toast" {
+		t.Errorf("second decision = %+v", decisions[1])
+	}
+}
+
+func TestFilterDecisions(t *testing.T) {
+	now := time.Now()
+	decisions := []Decision{
+		{Time: now.Add(-72 * time.Hour), Text: "Merge the API first", Bead: "proj-1"},
+		{Time: now.Add(-2 * time.Hour), Text: "Hold proj-2 for review", Bead: "proj-2"},
+		{Time: now.Add(-time.Hour), Text: "Kill toast", Session: "toast"},
+		{Time: now, Text: "Retry proj-2 with the v2 API", Bead: "proj-2"},
+	}
+
+	texts := func(ds []Decision) string {
+		var out []string
+		for _, d := range ds {
+			out = append(out, d.Text)
+		}
+		return strings.Join(out, "|")
+	}
+
+	tests := []struct {
+		name   string
+		filter DecisionFilter
+		want   string
+	}{
+		{"all", DecisionFilter{}, "Merge the API first|Hold proj-2 for review|Kill toast|Retry proj-2 with the v2 API"},
+		{"limit keeps newest", DecisionFilter{Limit: 2}, "Kill toast|Retry proj-2 with the v2 API"},
+		{"since", DecisionFilter{Since: now.Add(-24 * time.Hour)}, "Hold proj-2 for review|Kill toast|Retry proj-2 with the v2 API"},
+		{"bead", DecisionFilter{Bead: "proj-2"}, "Hold proj-2 for review|Retry proj-2 with the v2 API"},
+		{"session", DecisionFilter{Session: "toast"}, "Kill toast"},
+		{"grep ignores case", DecisionFilter{Grep: "api"}, "Merge the API first|Retry proj-2 with the v2 API"},
+		{"combined", DecisionFilter{Bead: "proj-2", Grep: "api", Limit: 5}, "Retry proj-2 with the v2 API"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := texts(FilterDecisions(decisions, tt.filter)); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatDecisions(t *testing.T) {
+	if got := FormatDecisions(nil, 0); got != "" {
+		t.Errorf("FormatDecisions(nil) = %q, want empty", got)
+	}
+
+	at := time.Date(2026, 3, 4, 15, 30, 0, 0, time.Local)
+	decisions := []Decision{
+		{Time: at, Text: "Hold proj-12", Bead: "proj-12", Session: "toast"},
+		{Time: at, Text: "Merge order: api, then web"},
+	}
+	got := FormatDecisions(decisions, 25)
+	for _, want := range []string{
+		decisionsHeading + "\n",
+		"- 2026-03-04 15:30 [proj-12, toast] Hold proj-12\n",
+		"- 2026-03-04 15:30 Merge order: api, then web\n",
+		"(23 older decisions: wt hub log)",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("FormatDecisions() missing %q in:\n%s", want, got)
+		}
+	}
+	if strings.Contains(FormatDecisions(decisions, 2), "older decisions") {
+		t.Error("FormatDecisions() should not mention older decisions when all are shown")
+	}
+}
+
+func TestCollectContextIncludesDecisions(t *testing.T) {
+	cfg, err := config.LoadFromDir(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if err := AppendDecision(cfg, &Decision{Text: "Hold proj-12 until the API PR merges"}); err != nil {
+		t.Fatal(err)
+	}
+
+	context, err := collectContext(cfg, &Options{Message: "Carry on"})
+	if err != nil {
+		t.Fatalf("collectContext() error: %v", err)
+	}
+	if !strings.Contains(context, decisionsHeading) || !strings.Contains(context, "Hold proj-12 until the API PR merges") {
+		t.Errorf("handoff context lacks the decision journal:\n%s", context)
+	}
+}
@@ -3,7 +3,7 @@
 package handoff
 
 import (
-	"bytes"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -15,6 +15,7 @@ import (
 	"github.com/badri/wt/internal/config"
 	"github.com/badri/wt/internal/events"
 	"github.com/badri/wt/internal/hub"
+	"github.com/badri/wt/internal/logging"
 	"github.com/badri/wt/internal/session"
 )
 
@@ -41,9 +42,10 @@ type Options struct {
 
 // Result contains the outcome of a handoff operation
 type Result struct {
-	MarkerWritten bool
-	BeadUpdated   bool
-	Message       string
+	MarkerWritten   bool
+	BeadUpdated     bool
+	CheckpointSaved bool // Worker handoff: the worktree was checkpointed instead
+	Message         string
 }
 
 // Run executes the handoff process
@@ -59,6 +61,9 @@ func Run(cfg *config.Config, opts *Options) (*Result, error) {
 
 	// Check if we're in hub mode - use hub beads
 	inHub := os.Getenv("WT_HUB") == "1"
+	// A worker hands off through a checkpoint of its own worktree, leaving
+	// the hub's handoff file and marker alone
+	worker := !inHub && inWorkerSession(cfg)
 
 	// Dry run - just show what would happen
 	if opts.DryRun {
@@ -68,6 +73,13 @@ func Run(cfg *config.Config, opts *Options) (*Result, error) {
 		fmt.Println()
 		fmt.Println(context)
 		fmt.Println("Would:")
+		if worker {
+			cwd, _ := os.Getwd()
+			fmt.Println("  1. Save a checkpoint to", getCheckpointPath(cwd))
+			fmt.Println("  2. Clear tmux history")
+			fmt.Println("  3. Respawn Claude via tmux respawn-pane and have it run wt prime")
+			return result, nil
+		}
 		if inHub {
 			fmt.Println("  1. Update hub handoff bead with context")
 		} else {
@@ -80,6 +92,18 @@ func Run(cfg *config.Config, opts *Options) (*Result, error) {
 		return result, nil
 	}
 
+	if worker {
+		if _, err := SaveCheckpoint(cfg, &CheckpointOptions{Notes: opts.Message, Trigger: "handoff", Quiet: true}); err != nil {
+			return nil, fmt.Errorf("saving checkpoint: %w", err)
+		}
+		result.CheckpointSaved = true
+		clearTmuxHistory()
+		if err := respawnClaude(cfg, true); err != nil {
+			return nil, fmt.Errorf("respawning Claude: %w", err)
+		}
+		return result, nil
+	}
+
 	// 2. Write handoff context to file (always) and hub bead (if in hub)
 	// Always write to file so the new Claude can read it via the startup prompt
 	handoffPath := getHandoffFilePath(cfg)
@@ -91,7 +115,7 @@ func Run(cfg *config.Config, opts *Options) (*Result, error) {
 	if inHub {
 		if err := hub.UpdateHandoffBead(cfg, context); err != nil {
 			// Non-fatal - file is the primary mechanism now
-			fmt.Fprintf(os.Stderr, "Warning: could not update hub handoff bead: %v\n", err)
+			logging.Warnf("could not update hub handoff bead: %v", err)
 		}
 	}
 	result.BeadUpdated = true
@@ -108,7 +132,7 @@ func Run(cfg *config.Config, opts *Options) (*Result, error) {
 		logger := events.NewLogger(cfg)
 		cwd, _ := os.Getwd()
 		if err := logger.LogHubHandoff(claudeSession, opts.Message, cwd); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: could not log hub handoff: %v\n", err)
+			logging.Warnf("could not log hub handoff: %v", err)
 		}
 	}
 
@@ -116,7 +140,7 @@ func Run(cfg *config.Config, opts *Options) (*Result, error) {
 	clearTmuxHistory()
 
 	// 6. Respawn Claude via tmux
-	if err := respawnClaude(cfg); err != nil {
+	if err := respawnClaude(cfg, false); err != nil {
 		return nil, fmt.Errorf("respawning Claude: %w", err)
 	}
 
@@ -138,16 +162,18 @@ func collectContext(cfg *config.Config, opts *Options) (string, error) {
 		sb.WriteString("\n\n")
 	}
 
+	// The decision journal always travels with the handoff, so the next
+	// hub knows why things are the way they are
+	if decisions, total, err := RecentDecisions(cfg, contextDecisions); err == nil {
+		sb.WriteString(FormatDecisions(decisions, total))
+	}
+
 	// Auto-collect state if requested
 	if opts.AutoCollect {
-		// Get active sessions
-		state, err := session.LoadState(cfg)
-		if err == nil && len(state.Sessions) > 0 {
-			sb.WriteString("### Active Sessions\n")
-			for name, sess := range state.Sessions {
-				sb.WriteString(fmt.Sprintf("- %s: %s (%s)\n", name, sess.Bead, sess.Project))
-			}
-			sb.WriteString("\n")
+		// Snapshot every worker: branch, commits, signal and PR state
+		workers, err := CollectWorkers(cfg)
+		if err == nil && len(workers) > 0 {
+			sb.WriteString(FormatWorkers(workers))
 		}
 
 		// Get ready beads with full details
@@ -184,7 +210,7 @@ func collectContext(cfg *config.Config, opts *Options) (string, error) {
 
 // getTmuxSessionName returns the current tmux session name
 func getTmuxSessionName() string {
-	cmd := exec.Command("tmux", "display-message", "-p", "#S")
+	cmd := logging.Command("tmux", "display-message", "-p", "#S")
 	output, err := cmd.Output()
 	if err != nil {
 		return ""
@@ -292,7 +318,7 @@ func getSessionName() string {
 	}
 
 	// Try to get from tmux window name
-	cmd := exec.Command("tmux", "display-message", "-p", "#W")
+	cmd := logging.Command("tmux", "display-message", "-p", "#W")
 	output, err := cmd.Output()
 	if err == nil {
 		return strings.TrimSpace(string(output))
@@ -311,7 +337,7 @@ func getClaudeSession() string {
 
 // clearTmuxHistory clears the tmux pane history
 func clearTmuxHistory() {
-	exec.Command("tmux", "clear-history").Run()
+	logging.Command("tmux", "clear-history").Run()
 }
 
 // HubPrompt is the context prompt injected when starting Claude in hub mode
@@ -322,8 +348,9 @@ const HubPrompt = "You are in the hub session. For queries about ready/available
 // Note: Avoid backticks as they cause shell interpretation issues in tmux respawn-pane
 const HandoffPrompt = "A handoff just occurred. IMPORTANT: First read the handoff context file at ~/.config/wt/handoff.md to understand what was happening in the previous session, then acknowledge the handoff to the user."
 
-// respawnClaude respawns Claude in the hub's main pane (pane 0)
-func respawnClaude(cfg *config.Config) error {
+// respawnClaude respawns Claude in the hub's main pane (pane 0), or in the
+// current pane of a worker
+func respawnClaude(cfg *config.Config, worker bool) error {
 	// Check if we're in tmux
 	if os.Getenv("TMUX") == "" {
 		return fmt.Errorf("not in a tmux session - cannot respawn")
@@ -384,17 +411,25 @@ func respawnClaude(cfg *config.Config) error {
 				`echo "A handoff file exists. Please read ~/.config/wt/handoff.md and acknowledge the context." | `+
 				`tmux load-buffer -; tmux paste-buffer -t %s; sleep 1; tmux send-keys -t %s Enter`,
 			targetPane, targetPane)
-		bgCmd := exec.Command("sh", "-c", nudgeScript+" &")
+		bgCmd := logging.Command("sh", "-c", nudgeScript+" &")
 		_ = bgCmd.Start() // Don't wait - let it run in background
+	} else if worker && targetPane != "" {
+		nudgeScript := fmt.Sprintf(
+			`sleep 7; `+
+				`echo "A handoff just occurred. Run wt prime to recover the checkpoint of this worktree, then carry on with the work it describes." | `+
+				`tmux load-buffer -; tmux paste-buffer -t %s; sleep 1; tmux send-keys -t %s Enter`,
+			targetPane, targetPane)
+		bgCmd := logging.Command("sh", "-c", nudgeScript+" &")
+		_ = bgCmd.Start()
 	}
 
 	var cmd *exec.Cmd
 	if currentPane != "" {
 		// Use the pane ID (e.g., %0, %1) which is stable unlike indices
-		cmd = exec.Command("tmux", "respawn-pane", "-k", "-t", currentPane, respawnCmd)
+		cmd = logging.Command("tmux", "respawn-pane", "-k", "-t", currentPane, respawnCmd)
 	} else {
 		// Fallback: respawn current pane (no target = current)
-		cmd = exec.Command("tmux", "respawn-pane", "-k", respawnCmd)
+		cmd = logging.Command("tmux", "respawn-pane", "-k", respawnCmd)
 	}
 	return cmd.Run()
 }
@@ -450,6 +485,25 @@ func ClearHandoffContent(cfg *config.Config) error {
 	return ArchiveHandoffFile(cfg)
 }
 
+// inWorkerSession reports whether the current directory is the worktree of
+// a wt session
+func inWorkerSession(cfg *config.Config) bool {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return false
+	}
+	state, err := session.LoadState(cfg)
+	if err != nil {
+		return false
+	}
+	for _, s := range state.Sessions {
+		if s.Worktree == cwd {
+			return true
+		}
+	}
+	return false
+}
+
 // IsInTmux checks if we're running inside tmux
 func IsInTmux() bool {
 	return os.Getenv("TMUX") != ""
@@ -457,15 +511,15 @@ func IsInTmux() bool {
 
 // RunBdCommand runs a bd command and returns its output
 func RunBdCommand(args ...string) (string, error) {
-	cmd := exec.Command("bd", args...)
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-
-	err := cmd.Run()
+	output, err := bead.Output("", args...)
 	if err != nil {
-		return "", fmt.Errorf("%s: %s", err, stderr.String())
+		var stderr []byte
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			stderr = exitErr.Stderr
+		}
+		return "", fmt.Errorf("%s: %s", err, stderr)
 	}
 
-	return stdout.String(), nil
+	return string(output), nil
 }
@@ -1,12 +1,10 @@
 package handoff
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 	"time"
@@ -14,6 +12,7 @@ import (
 	"github.com/badri/wt/internal/bead"
 	"github.com/badri/wt/internal/config"
 	"github.com/badri/wt/internal/hub"
+	"github.com/badri/wt/internal/logging"
 	"github.com/badri/wt/internal/session"
 )
 
@@ -27,6 +26,7 @@ type PrimeOptions struct {
 	Quiet     bool // Suppress non-essential output
 	NoBdPrime bool // Skip running bd prime
 	HookMode  bool // Read session_id from Claude's SessionStart hook JSON on stdin
+	NoStatus  bool // Skip the hub status snapshot
 }
 
 // PrimeResult contains the outcome of a prime operation
@@ -36,13 +36,22 @@ type PrimeResult struct {
 	HandoffTime       time.Time
 	HandoffContent    string
 	BdPrimeOutput     string
-	IsPostCompaction  bool        // Session resumed after compaction
-	CheckpointContent *Checkpoint // Recovered checkpoint data
+	IsPostCompaction  bool         // Session resumed after compaction
+	CheckpointContent *Checkpoint  // Recovered checkpoint data
+	Snapshot          *HubSnapshot // Hub status, for hub sessions
+	Decisions         string       // Recent decision journal, for hub sessions
 }
 
 // Prime injects context on session startup
 func Prime(cfg *config.Config, opts *PrimeOptions) (*PrimeResult, error) {
 	result := &PrimeResult{}
+	inHub := os.Getenv("WT_HUB") == "1"
+
+	// The hub starts with a snapshot of what it oversees, taken before the
+	// handoff marker below is cleared so a pending handoff still shows
+	if inHub && !opts.NoStatus {
+		result.Snapshot = CollectSnapshot(cfg)
+	}
 
 	// 1. Check for handoff marker (explicit handoff via wt handoff)
 	exists, prevSession, handoffTime, err := CheckMarker(cfg)
@@ -57,7 +66,7 @@ func Prime(cfg *config.Config, opts *PrimeOptions) (*PrimeResult, error) {
 
 		// Clear the marker
 		if err := ClearMarker(cfg); err != nil {
-			fmt.Printf("Warning: could not clear handoff marker: %v\n", err)
+			logging.Warnf("could not clear handoff marker: %v", err)
 		}
 	}
 
@@ -66,7 +75,7 @@ func Prime(cfg *config.Config, opts *PrimeOptions) (*PrimeResult, error) {
 	if err != nil {
 		// Non-fatal
 		if !opts.Quiet {
-			fmt.Printf("Warning: could not load checkpoint: %v\n", err)
+			logging.Warnf("could not load checkpoint: %v", err)
 		}
 	}
 	if checkpoint != nil {
@@ -75,7 +84,6 @@ func Prime(cfg *config.Config, opts *PrimeOptions) (*PrimeResult, error) {
 	}
 
 	// 3. Get handoff content - from hub bead or legacy file
-	inHub := os.Getenv("WT_HUB") == "1"
 	var content string
 	if inHub {
 		// Try hub handoff bead first
@@ -91,10 +99,19 @@ func Prime(cfg *config.Config, opts *PrimeOptions) (*PrimeResult, error) {
 		content, err = GetHandoffContent(cfg)
 	}
 	if err != nil && !opts.Quiet {
-		fmt.Printf("Warning: could not get handoff content: %v\n", err)
+		logging.Warnf("could not get handoff content: %v", err)
 	}
 	result.HandoffContent = content
 
+	// The hub's decision journal, unless the handoff already carries it
+	if inHub && !strings.Contains(content, decisionsHeading) {
+		decisions, total, err := RecentDecisions(cfg, contextDecisions)
+		if err != nil && !opts.Quiet {
+			logging.Warnf("could not read decision journal: %v", err)
+		}
+		result.Decisions = FormatDecisions(decisions, total)
+	}
+
 	// 4. Run bd prime if not disabled
 	if !opts.NoBdPrime {
 		bdOutput, err := runBdPrime()
@@ -121,6 +138,12 @@ func OutputPrimeResult(result *PrimeResult) {
 		fmt.Println("╚══════════════════════════════════════════════════════════════╝")
 		fmt.Println()
 		fmt.Println(FormatCheckpointForRecovery(result.CheckpointContent))
+		if result.Snapshot != nil {
+			fmt.Println(FormatSnapshot(result.Snapshot))
+		}
+		if result.Decisions != "" {
+			fmt.Print(result.Decisions)
+		}
 		return // Don't show other content when recovering from compaction
 	}
 
@@ -151,6 +174,16 @@ func OutputPrimeResult(result *PrimeResult) {
 		// Note: Content is cleared by the caller after display if needed
 	}
 
+	// Hub status snapshot
+	if result.Snapshot != nil {
+		fmt.Println(FormatSnapshot(result.Snapshot))
+	}
+
+	// Decisions of earlier hubs
+	if result.Decisions != "" {
+		fmt.Print(result.Decisions)
+	}
+
 	// bd prime output
 	if result.BdPrimeOutput != "" {
 		fmt.Println(result.BdPrimeOutput)
@@ -170,17 +203,7 @@ func OutputPrimeResult(result *PrimeResult) {
 
 // runBdPrime runs bd prime and returns its output
 func runBdPrime() (string, error) {
-	cmd := exec.Command("bd", "prime")
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-
-	err := cmd.Run()
-	if err != nil {
-		return "", fmt.Errorf("%s: %s", err, stderr.String())
-	}
-
-	return stdout.String(), nil
+	return RunBdCommand("prime")
 }
 
 // GenerateSummary creates a summary of current state for handoff or context
@@ -0,0 +1,267 @@
+package handoff
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/badri/wt/internal/auto"
+	"github.com/badri/wt/internal/bead"
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/hub"
+	"github.com/badri/wt/internal/monitor"
+	"github.com/badri/wt/internal/project"
+	"github.com/badri/wt/internal/session"
+)
+
+// snapshotReadyLimit is how many ready beads per project the prime context
+// lists; the JSON snapshot has them all
+const snapshotReadyLimit = 5
+
+// HubSnapshot is a structured picture of everything the hub oversees, for
+// 'wt hub --status --json' and the hub's startup context
+type HubSnapshot struct {
+	Time     string            `json:"time"`
+	Hub      HubInfo           `json:"hub"`
+	Sessions []SessionSnapshot `json:"sessions"`
+	Ready    []ProjectReady    `json:"ready"`
+	AutoRuns []auto.RunningRun `json:"auto_runs"`
+	Handoffs []PendingHandoff  `json:"handoffs"`
+	Errors   []string          `json:"errors,omitempty"` // parts that could not be collected
+}
+
+// HubInfo is the hub's own tmux session
+type HubInfo struct {
+	Running    bool   `json:"running"`
+	Attached   bool   `json:"attached"`
+	WorkingDir string `json:"working_dir,omitempty"`
+}
+
+// SessionSnapshot is one active worker session and its last signal
+type SessionSnapshot struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"` // "bead" or "task"
+	Bead        string `json:"bead,omitempty"`
+	Task        string `json:"task,omitempty"`
+	Project     string `json:"project"`
+	Status      string `json:"status"`
+	Message     string `json:"message,omitempty"` // from the last 'wt signal'
+	IdleMinutes int    `json:"idle_minutes"`      // -1 when the tmux session is gone
+	AwaitingAck bool   `json:"awaiting_ack,omitempty"`
+	BlockedOn   string `json:"blocked_on,omitempty"`
+	Paused      bool   `json:"paused,omitempty"`
+	StackedOn   string `json:"stacked_on,omitempty"`
+	Worktree    string `json:"worktree"`
+}
+
+// ProjectReady is a project's ready beads after its ready_filter
+type ProjectReady struct {
+	Project string           `json:"project"`
+	Beads   []bead.ReadyBead `json:"beads"`
+	Hidden  int              `json:"hidden,omitempty"` // excluded by ready_filter
+}
+
+// PendingHandoff is a handoff not yet picked up: the hub's own, or a
+// worker's checkpoint waiting for its fresh instance
+type PendingHandoff struct {
+	Session string `json:"session"`
+	Kind    string `json:"kind"` // "hub" or "checkpoint"
+	Time    string `json:"time,omitempty"`
+	From    string `json:"from,omitempty"`    // hub: the session that handed off
+	Trigger string `json:"trigger,omitempty"` // checkpoint: what wrote it
+}
+
+// CollectSnapshot gathers the hub snapshot. Parts that fail are left empty
+// and named in Errors, so one broken project doesn't hide the rest.
+func CollectSnapshot(cfg *config.Config) *HubSnapshot {
+	snap := &HubSnapshot{
+		Time:     time.Now().Format(time.RFC3339),
+		Sessions: []SessionSnapshot{},
+		Ready:    []ProjectReady{},
+		AutoRuns: []auto.RunningRun{},
+		Handoffs: []PendingHandoff{},
+	}
+
+	status := hub.GetStatus()
+	snap.Hub = HubInfo{Running: status.Exists, Attached: status.Attached, WorkingDir: status.WorkingDir}
+
+	if exists, prev, at, err := CheckMarker(cfg); err != nil {
+		snap.Errors = append(snap.Errors, fmt.Sprintf("handoff marker: %v", err))
+	} else if exists {
+		h := PendingHandoff{Session: hub.HubSessionName, Kind: "hub", From: prev}
+		if !at.IsZero() {
+			h.Time = at.Format(time.RFC3339)
+		}
+		snap.Handoffs = append(snap.Handoffs, h)
+	}
+
+	if state, err := session.LoadState(cfg); err != nil {
+		snap.Errors = append(snap.Errors, fmt.Sprintf("sessions: %v", err))
+	} else {
+		snap.Sessions, snap.Handoffs = collectSessions(state, snap.Handoffs)
+	}
+
+	projects, err := project.NewManager(cfg).List()
+	if err != nil {
+		snap.Errors = append(snap.Errors, fmt.Sprintf("projects: %v", err))
+	}
+	for _, proj := range projects {
+		beads, err := bead.ReadyInDir(filepath.Join(proj.RepoPath(), ".beads"))
+		if err != nil {
+			continue // no beads in this project
+		}
+		beads, excluded, err := bead.FilterReady(beads, proj.ReadyFilter, proj.RepoPath())
+		if err != nil {
+			snap.Errors = append(snap.Errors, fmt.Sprintf("ready beads of %s: %v", proj.Name, err))
+			continue
+		}
+		if beads == nil {
+			beads = []bead.ReadyBead{}
+		}
+		snap.Ready = append(snap.Ready, ProjectReady{Project: proj.Name, Beads: beads, Hidden: len(excluded)})
+	}
+
+	if runs, err := auto.RunningRuns(cfg); err != nil {
+		snap.Errors = append(snap.Errors, fmt.Sprintf("auto runs: %v", err))
+	} else if runs != nil {
+		snap.AutoRuns = runs
+	}
+	return snap
+}
+
+// collectSessions snapshots the active sessions, sorted by name, adding
+// worker checkpoints to the pending handoffs
+func collectSessions(state *session.State, handoffs []PendingHandoff) ([]SessionSnapshot, []PendingHandoff) {
+	sessions := []SessionSnapshot{}
+	for name, sess := range state.Sessions {
+		s := SessionSnapshot{
+			Name:        name,
+			Type:        "bead",
+			Bead:        sess.Bead,
+			Project:     sess.Project,
+			Status:      sess.Status,
+			Message:     sess.StatusMessage,
+			IdleMinutes: monitor.GetIdleMinutes(name),
+			AwaitingAck: sess.AwaitingAck,
+			BlockedOn:   sess.BlockedOn,
+			Paused:      sess.PausedAt != "",
+			StackedOn:   sess.StackedOn,
+			Worktree:    sess.Worktree,
+		}
+		if sess.IsTask() {
+			s.Type, s.Task = "task", sess.TaskDescription
+		}
+		if s.Status == "" {
+			s.Status = "working"
+		}
+		sessions = append(sessions, s)
+
+		if sess.Worktree != "" && CheckpointExistsIn(sess.Worktree) {
+			h := PendingHandoff{Session: name, Kind: "checkpoint"}
+			if cp, err := LoadCheckpointFrom(sess.Worktree); err == nil && cp != nil {
+				h.Time, h.Trigger = cp.CreatedAt, cp.Trigger
+			}
+			handoffs = append(handoffs, h)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].Name < sessions[j].Name })
+	sort.SliceStable(handoffs, func(i, j int) bool { return handoffs[i].Kind == "hub" && handoffs[j].Kind != "hub" })
+	return sessions, handoffs
+}
+
+// FormatSnapshot renders the snapshot as markdown for the hub's startup
+// context
+func FormatSnapshot(snap *HubSnapshot) string {
+	var sb strings.Builder
+	sb.WriteString("## 📋 Hub Status\n\n")
+
+	sb.WriteString("### Active Sessions\n")
+	if len(snap.Sessions) == 0 {
+		sb.WriteString("No active sessions.\n")
+	}
+	for _, s := range snap.Sessions {
+		what := s.Bead
+		if s.Type == "task" {
+			what = "task: " + s.Task
+		}
+		line := fmt.Sprintf("- **%s** (%s, %s): %s", s.Name, what, s.Project, s.Status)
+		if s.Message != "" {
+			line += " — " + s.Message
+		}
+		var flags []string
+		if s.AwaitingAck {
+			flags = append(flags, "awaiting ack")
+		}
+		if s.BlockedOn != "" {
+			flags = append(flags, "blocked on "+s.BlockedOn)
+		}
+		if s.Paused {
+			flags = append(flags, "paused")
+		}
+		if s.IdleMinutes > 0 {
+			flags = append(flags, fmt.Sprintf("idle %dm", s.IdleMinutes))
+		}
+		if len(flags) > 0 {
+			line += " [" + strings.Join(flags, ", ") + "]"
+		}
+		sb.WriteString(line + "\n")
+	}
+
+	if len(snap.AutoRuns) > 0 {
+		sb.WriteString("\n### Running wt auto\n")
+		for _, r := range snap.AutoRuns {
+			line := fmt.Sprintf("- %s", r.Project)
+			if r.Epic != "" {
+				line += fmt.Sprintf(": epic %s, %d/%d beads", r.Epic, r.Completed, r.Total)
+			}
+			if r.CurrentBead != "" {
+				line += ", on " + r.CurrentBead
+			}
+			sb.WriteString(line + fmt.Sprintf(" (pid %d)\n", r.PID))
+		}
+	}
+
+	if len(snap.Handoffs) > 0 {
+		sb.WriteString("\n### Pending Handoffs\n")
+		for _, h := range snap.Handoffs {
+			line := fmt.Sprintf("- %s: %s", h.Session, h.Kind)
+			if h.From != "" {
+				line += " from " + h.From
+			}
+			if h.Trigger != "" {
+				line += " (" + h.Trigger + ")"
+			}
+			sb.WriteString(line + "\n")
+		}
+	}
+
+	var ready []string
+	for _, p := range snap.Ready {
+		if len(p.Beads) == 0 {
+			continue
+		}
+		ready = append(ready, fmt.Sprintf("**%s** (%d ready)", p.Project, len(p.Beads)))
+		for i, b := range p.Beads {
+			if i == snapshotReadyLimit {
+				ready = append(ready, fmt.Sprintf("  ... and %d more", len(p.Beads)-snapshotReadyLimit))
+				break
+			}
+			ready = append(ready, fmt.Sprintf("  - %s: %s (P%d)", b.ID, b.Title, b.Priority))
+		}
+	}
+	if len(ready) > 0 {
+		sb.WriteString("\n### Ready Beads\n")
+		sb.WriteString(strings.Join(ready, "\n") + "\n")
+	}
+
+	if len(snap.Errors) > 0 {
+		sb.WriteString("\n")
+		for _, e := range snap.Errors {
+			sb.WriteString(fmt.Sprintf("_Could not collect %s_\n", e))
+		}
+	}
+	sb.WriteString("\nRefresh with: wt hub --status --json\n")
+	return sb.String()
+}
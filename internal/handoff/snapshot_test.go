@@ -0,0 +1,88 @@
+package handoff
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/badri/wt/internal/auto"
+	"github.com/badri/wt/internal/bead"
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/session"
+)
+
+func TestCollectSessions(t *testing.T) {
+	worker := t.TempDir()
+	cp, _ := json.Marshal(Checkpoint{CreatedAt: "2026-03-01T09:00:00Z", Trigger: "manual"})
+	if err := os.MkdirAll(filepath.Join(worker, WorktreeCheckpointDir), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(worker, WorktreeCheckpointDir, CheckpointFile), cp, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	state := &session.State{Sessions: map[string]*session.Session{
+		"toast": {Bead: "api-1", Project: "api", Worktree: worker, Status: "ready", StatusMessage: "PR open"},
+		"bagel": {Project: "api", Worktree: t.TempDir(), Type: session.SessionTypeTask, TaskDescription: "Bump deps", BlockedOn: "api-9"},
+	}}
+	hubHandoff := []PendingHandoff{{Session: "hub", Kind: "hub"}}
+	sessions, handoffs := collectSessions(state, hubHandoff)
+
+	if len(sessions) != 2 || sessions[0].Name != "bagel" || sessions[1].Name != "toast" {
+		t.Fatalf("sessions = %+v, want bagel then toast", sessions)
+	}
+	if b := sessions[0]; b.Type != "task" || b.Task != "Bump deps" || b.Status != "working" || b.BlockedOn != "api-9" {
+		t.Errorf("bagel = %+v", b)
+	}
+	if s := sessions[1]; s.Status != "ready" || s.Message != "PR open" {
+		t.Errorf("toast = %+v", s)
+	}
+	if len(handoffs) != 2 || handoffs[0].Kind != "hub" || handoffs[1].Session != "toast" || handoffs[1].Trigger != "manual" {
+		t.Errorf("handoffs = %+v", handoffs)
+	}
+}
+
+func TestFormatSnapshot(t *testing.T) {
+	snap := &HubSnapshot{
+		Sessions: []SessionSnapshot{{Name: "toast", Type: "bead", Bead: "api-1", Project: "api", Status: "ready", Message: "PR open", AwaitingAck: true}},
+		AutoRuns: []auto.RunningRun{{Project: "web", Epic: "web-epic", PID: 42, Completed: 1, Total: 3, CurrentBead: "web-2"}},
+		Handoffs: []PendingHandoff{{Session: "toast", Kind: "checkpoint", Trigger: "auto"}},
+		Ready: []ProjectReady{{Project: "api", Beads: []bead.ReadyBead{
+			{ID: "api-2", Title: "A", Priority: 1}, {ID: "api-3"}, {ID: "api-4"}, {ID: "api-5"}, {ID: "api-6"}, {ID: "api-7"}, {ID: "api-8"},
+		}}},
+		Errors: []string{"auto runs: boom"},
+	}
+	out := FormatSnapshot(snap)
+	for _, want := range []string{
+		"- **toast** (api-1, api): ready — PR open [awaiting ack]",
+		"- web: epic web-epic, 1/3 beads, on web-2 (pid 42)",
+		"- toast: checkpoint (auto)",
+		"**api** (7 ready)",
+		"  - api-2: A (P1)",
+		"... and 2 more",
+		"Could not collect auto runs: boom",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("FormatSnapshot() missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestCollectSnapshot_Empty(t *testing.T) {
+	cfg, err := config.LoadFromDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(CollectSnapshot(cfg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Empty lists stay lists, so consumers needn't check for null
+	for _, want := range []string{`"sessions":[]`, `"ready":[]`, `"auto_runs":[]`, `"handoffs":[]`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("snapshot JSON missing %s: %s", want, data)
+		}
+	}
+}
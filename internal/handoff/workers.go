@@ -0,0 +1,170 @@
+package handoff
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/monitor"
+	"github.com/badri/wt/internal/project"
+	"github.com/badri/wt/internal/session"
+)
+
+// WorkerState is a snapshot of one worker session, collected so a fresh hub
+// does not have to re-interrogate every worker after a handoff.
+type WorkerState struct {
+	Name          string
+	Bead          string
+	Project       string
+	Title         string // Task description for task sessions
+	Branch        string
+	Ahead         int
+	Behind        int
+	BaseBranch    string // Branch ahead/behind is measured against
+	LastCommit    string // "<hash> <subject> (<relative date>)"
+	Uncommitted   int    // Number of changed files in the worktree
+	Status        string
+	StatusMessage string // Message from the last 'wt signal'
+	PRStatus      string // open, merged, closed, none ("" when not checked)
+	PRURL         string
+	Error         string // Set when the worktree could not be inspected
+}
+
+// CollectWorkers gathers the state of every active session, sorted by name
+func CollectWorkers(cfg *config.Config) ([]WorkerState, error) {
+	state, err := session.LoadState(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(state.Sessions))
+	for name := range state.Sessions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	mgr := project.NewManager(cfg)
+	workers := make([]WorkerState, 0, len(names))
+	for _, name := range names {
+		proj, _ := mgr.Get(state.Sessions[name].Project)
+		workers = append(workers, collectWorker(name, state.Sessions[name], proj))
+	}
+	return workers, nil
+}
+
+// collectWorker inspects a single session's worktree
+func collectWorker(name string, sess *session.Session, proj *project.Project) WorkerState {
+	w := WorkerState{
+		Name:          name,
+		Bead:          sess.Bead,
+		Project:       sess.Project,
+		Branch:        sess.Branch,
+		Status:        sess.Status,
+		StatusMessage: sess.StatusMessage,
+	}
+	if sess.IsTask() {
+		w.Title = sess.TaskDescription
+	}
+	if w.Status == "" {
+		w.Status = "working"
+	}
+
+	if sess.Worktree == "" {
+		w.Error = "no worktree"
+		return w
+	}
+	if branch, err := gitOutput(sess.Worktree, "rev-parse", "--abbrev-ref", "HEAD"); err != nil {
+		w.Error = "worktree not accessible"
+		return w
+	} else if branch != "" {
+		w.Branch = branch
+	}
+
+	defaultBranch := "main"
+	mergeMode := "pr-review"
+	if proj != nil {
+		if proj.DefaultBranch != "" {
+			defaultBranch = proj.DefaultBranch
+		}
+		if proj.MergeMode != "" {
+			mergeMode = proj.MergeMode
+		}
+	}
+
+	// Stacked sessions are measured against their parent branch
+	w.BaseBranch = defaultBranch
+	if sess.StackBranch != "" {
+		w.BaseBranch = sess.StackBranch
+	}
+	counts, _ := monitor.GetBranchCounts(sess.Worktree, w.BaseBranch)
+	w.Ahead, w.Behind = counts.Ahead, counts.Behind
+
+	w.LastCommit, _ = gitOutput(sess.Worktree, "log", "-1", "--format=%h %s (%cr)")
+	if out, err := gitOutput(sess.Worktree, "status", "--porcelain"); err == nil && out != "" {
+		w.Uncommitted = len(strings.Split(out, "\n"))
+	}
+
+	// Direct mode never opens PRs, so skip the gh lookup
+	if mergeMode != "direct" && w.Branch != "" {
+		w.PRStatus, w.PRURL = monitor.GetPRStatus(sess.Worktree, w.Branch)
+	}
+	return w
+}
+
+// gitOutput runs a git command in dir and returns its trimmed output
+func gitOutput(dir string, args ...string) (string, error) {
+	cmd := logging.Command("git", append([]string{"-C", dir}, args...)...)
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// FormatWorkers renders worker snapshots as the "Workers" section of a handoff
+func FormatWorkers(workers []WorkerState) string {
+	if len(workers) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("### Workers\n")
+	for _, w := range workers {
+		heading := w.Name
+		if w.Bead != "" {
+			heading += " — " + w.Bead
+		}
+		if w.Title != "" {
+			heading += fmt.Sprintf(" (%s)", w.Title)
+		}
+		sb.WriteString(fmt.Sprintf("#### %s\n", heading))
+		sb.WriteString(fmt.Sprintf("- Project: %s\n", w.Project))
+		sb.WriteString(fmt.Sprintf("- Status: %s\n", w.Status))
+		if w.StatusMessage != "" {
+			sb.WriteString(fmt.Sprintf("- Last signal: %s\n", w.StatusMessage))
+		}
+		if w.Error != "" {
+			sb.WriteString(fmt.Sprintf("- Branch: %s (%s)\n\n", w.Branch, w.Error))
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("- Branch: %s (%d ahead, %d behind %s)\n", w.Branch, w.Ahead, w.Behind, w.BaseBranch))
+		if w.LastCommit != "" {
+			sb.WriteString(fmt.Sprintf("- Last commit: %s\n", w.LastCommit))
+		}
+		if w.Uncommitted > 0 {
+			sb.WriteString(fmt.Sprintf("- Uncommitted: %d file(s)\n", w.Uncommitted))
+		} else {
+			sb.WriteString("- Uncommitted: clean\n")
+		}
+		switch {
+		case w.PRURL != "":
+			sb.WriteString(fmt.Sprintf("- PR: %s %s\n", w.PRStatus, w.PRURL))
+		case w.PRStatus != "":
+			sb.WriteString(fmt.Sprintf("- PR: %s\n", w.PRStatus))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
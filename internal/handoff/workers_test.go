@@ -0,0 +1,131 @@
+package handoff
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/badri/wt/internal/project"
+	"github.com/badri/wt/internal/session"
+)
+
+func TestCollectWorker(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	repo := t.TempDir()
+	runGit(t, repo, "init", "-q", "-b", "main")
+	runGit(t, repo, "config", "user.email", "test@example.com")
+	runGit(t, repo, "config", "user.name", "Test")
+	runGit(t, repo, "commit", "-q", "--allow-empty", "-m", "initial")
+
+	wt := filepath.Join(t.TempDir(), "toast")
+	runGit(t, repo, "worktree", "add", "-q", "-b", "wt-abc", wt)
+	runGit(t, wt, "commit", "-q", "--allow-empty", "-m", "first change")
+	runGit(t, wt, "commit", "-q", "--allow-empty", "-m", "second change")
+	runGit(t, repo, "commit", "-q", "--allow-empty", "-m", "main moved")
+	if err := os.WriteFile(filepath.Join(wt, "a.txt"), []byte("a"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(wt, "b.txt"), []byte("b"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	sess := &session.Session{
+		Bead:          "wt-abc",
+		Project:       "wt",
+		Worktree:      wt,
+		Branch:        "wt-abc",
+		Status:        "blocked",
+		StatusMessage: "Need API key",
+	}
+	// Direct mode skips the gh lookup
+	proj := &project.Project{Name: "wt", MergeMode: "direct"}
+
+	w := collectWorker("toast", sess, proj)
+	if w.Error != "" {
+		t.Fatalf("collectWorker() error: %s", w.Error)
+	}
+	if w.Branch != "wt-abc" {
+		t.Errorf("Branch = %q, want wt-abc", w.Branch)
+	}
+	if w.Ahead != 2 || w.Behind != 1 {
+		t.Errorf("Ahead/Behind = %d/%d, want 2/1", w.Ahead, w.Behind)
+	}
+	if w.BaseBranch != "main" {
+		t.Errorf("BaseBranch = %q, want main", w.BaseBranch)
+	}
+	if !strings.Contains(w.LastCommit, "second change") {
+		t.Errorf("LastCommit = %q, want it to contain 'second change'", w.LastCommit)
+	}
+	if w.Uncommitted != 2 {
+		t.Errorf("Uncommitted = %d, want 2", w.Uncommitted)
+	}
+	if w.StatusMessage != "Need API key" {
+		t.Errorf("StatusMessage = %q", w.StatusMessage)
+	}
+	if w.PRStatus != "" {
+		t.Errorf("PRStatus = %q, want empty in direct mode", w.PRStatus)
+	}
+}
+
+func TestCollectWorkerMissingWorktree(t *testing.T) {
+	sess := &session.Session{Bead: "wt-abc", Project: "wt", Worktree: filepath.Join(t.TempDir(), "gone"), Branch: "wt-abc"}
+	w := collectWorker("toast", sess, nil)
+	if w.Error == "" {
+		t.Error("expected an error for a missing worktree")
+	}
+	if w.Status != "working" {
+		t.Errorf("Status = %q, want default 'working'", w.Status)
+	}
+}
+
+func TestFormatWorkers(t *testing.T) {
+	if got := FormatWorkers(nil); got != "" {
+		t.Errorf("FormatWorkers(nil) = %q, want empty", got)
+	}
+
+	workers := []WorkerState{
+		{
+			Name: "toast", Bead: "wt-abc", Project: "wt", Branch: "wt-abc",
+			Ahead: 3, Behind: 1, BaseBranch: "main",
+			LastCommit: "abc1234 Add login (2 hours ago)", Uncommitted: 2,
+			Status: "ready", StatusMessage: "PR ready for review",
+			PRStatus: "open", PRURL: "https://github.com/o/r/pull/7",
+		},
+		{Name: "shadow", Bead: "wt-def", Project: "wt", Branch: "wt-def", Status: "working", Error: "worktree not accessible"},
+	}
+	got := FormatWorkers(workers)
+
+	for _, want := range []string{
+		"### Workers\n",
+		"#### toast — wt-abc\n",
+		"- Status: ready\n",
+		"- Last signal: PR ready for review\n",
+		"- Branch: wt-abc (3 ahead, 1 behind main)\n",
+		"- Last commit: abc1234 Add login (2 hours ago)\n",
+		"- Uncommitted: 2 file(s)\n",
+		"- PR: open https://github.com/o/r/pull/7\n",
+		"#### shadow — wt-def\n",
+		"- Branch: wt-def (worktree not accessible)\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("FormatWorkers() missing %q in:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "Last signal: \n") {
+		t.Error("empty signal should be omitted")
+	}
+}
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, output)
+	}
+}
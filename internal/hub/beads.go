@@ -5,12 +5,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 	"time"
 
+	bd "github.com/badri/wt/internal/bead"
 	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/logging"
 )
 
 const (
@@ -54,9 +55,7 @@ func InitHubBeads(cfg *config.Config) error {
 	}
 
 	// Initialize beads with hub- prefix
-	cmd := exec.Command("bd", "init", "--prefix", HubBeadPrefix)
-	cmd.Dir = cfg.ConfigDir()
-	output, err := cmd.CombinedOutput()
+	output, err := bd.CombinedOutput(cfg.ConfigDir(), "init", "--prefix", HubBeadPrefix)
 	if err != nil {
 		return fmt.Errorf("initializing hub beads: %s: %w", string(output), err)
 	}
@@ -112,9 +111,7 @@ func UpdateHandoffBead(cfg *config.Config, content string) error {
 	}
 
 	// Update the description
-	cmd := exec.Command("bd", "update", bead.ID, "--description", content)
-	cmd.Dir = cfg.ConfigDir()
-	output, err := cmd.CombinedOutput()
+	output, err := bd.CombinedOutput(cfg.ConfigDir(), "update", bead.ID, "--description", content)
 	if err != nil {
 		return fmt.Errorf("updating handoff bead: %s: %w", string(output), err)
 	}
@@ -140,9 +137,7 @@ func ClearHandoffBead(cfg *config.Config) error {
 // findBeadByTitle searches for a bead by title in the hub beads
 func findBeadByTitle(projectDir, title string) (*HubBead, error) {
 	// List all beads and find by title
-	cmd := exec.Command("bd", "list", "--json")
-	cmd.Dir = projectDir
-	output, err := cmd.Output()
+	output, err := bd.Output(projectDir, "list", "--json")
 	if err != nil {
 		// No beads yet is not an error
 		return nil, nil
@@ -165,9 +160,7 @@ func findBeadByTitle(projectDir, title string) (*HubBead, error) {
 
 // showBead returns full bead details
 func showBead(projectDir, beadID string) (*HubBead, error) {
-	cmd := exec.Command("bd", "show", beadID, "--json")
-	cmd.Dir = projectDir
-	output, err := cmd.Output()
+	output, err := bd.Output(projectDir, "show", beadID, "--json")
 	if err != nil {
 		return nil, fmt.Errorf("showing bead %s: %w", beadID, err)
 	}
@@ -196,9 +189,7 @@ func createHandoffBead(projectDir, _ string) (*HubBead, error) {
 		"--description", "",
 	}
 
-	cmd := exec.Command("bd", args...)
-	cmd.Dir = projectDir
-	output, err := cmd.CombinedOutput()
+	output, err := bd.CombinedOutput(projectDir, args...)
 	if err != nil {
 		return nil, fmt.Errorf("creating handoff bead: %s: %w", string(output), err)
 	}
@@ -210,9 +201,7 @@ func createHandoffBead(projectDir, _ string) (*HubBead, error) {
 	}
 
 	// Update to pinned status
-	updateCmd := exec.Command("bd", "update", beadID, "--status", StatusPinned)
-	updateCmd.Dir = projectDir
-	if output, err := updateCmd.CombinedOutput(); err != nil {
+	if output, err := bd.CombinedOutput(projectDir, "update", beadID, "--status", StatusPinned); err != nil {
 		return nil, fmt.Errorf("pinning handoff bead: %s: %w", string(output), err)
 	}
 
@@ -266,7 +255,7 @@ func CollectHubState(cfg *config.Config) string {
 	var parts []string
 
 	// Get active worker sessions
-	sessionsOutput, err := exec.Command("wt", "list", "--json").Output()
+	sessionsOutput, err := logging.Command("wt", "list", "--json").Output()
 	if err == nil {
 		var sessions map[string]interface{}
 		if json.Unmarshal(sessionsOutput, &sessions) == nil && len(sessions) > 0 {
@@ -285,7 +274,7 @@ func CollectHubState(cfg *config.Config) string {
 	}
 
 	// Get ready beads across projects
-	readyOutput, err := exec.Command("wt", "ready", "--json").Output()
+	readyOutput, err := logging.Command("wt", "ready", "--json").Output()
 	if err == nil {
 		var ready []map[string]interface{}
 		if json.Unmarshal(readyOutput, &ready) == nil && len(ready) > 0 {
@@ -306,7 +295,7 @@ func CollectHubState(cfg *config.Config) string {
 	}
 
 	// Get in-progress beads
-	inProgressOutput, err := exec.Command("bd", "list", "--status=in_progress", "--json").Output()
+	inProgressOutput, err := bd.Output("", "list", "--status=in_progress", "--json")
 	if err == nil {
 		var inProgress []map[string]interface{}
 		if json.Unmarshal(inProgressOutput, &inProgress) == nil && len(inProgress) > 0 {
@@ -5,12 +5,12 @@ package hub
 import (
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/logging"
 	"github.com/badri/wt/internal/tmux"
 )
 
@@ -95,7 +95,7 @@ func GetStatus() Status {
 	status := Status{}
 
 	// Check if hub session exists
-	cmd := exec.Command("tmux", "has-session", "-t", HubSessionName)
+	cmd := logging.Command("tmux", "has-session", "-t", tmux.ExactTarget(HubSessionName))
 	if err := cmd.Run(); err != nil {
 		return status
 	}
@@ -108,20 +108,20 @@ func GetStatus() Status {
 	}
 
 	// Get working directory
-	cmd = exec.Command("tmux", "display-message", "-t", HubSessionName, "-p", "#{pane_current_path}")
+	cmd = logging.Command("tmux", "display-message", "-t", HubSessionName, "-p", "#{pane_current_path}")
 	if output, err := cmd.Output(); err == nil {
 		status.WorkingDir = strings.TrimSpace(string(output))
 	}
 
 	// Get window count
-	cmd = exec.Command("tmux", "list-windows", "-t", HubSessionName, "-F", "#{window_id}")
+	cmd = logging.Command("tmux", "list-windows", "-t", HubSessionName, "-F", "#{window_id}")
 	if output, err := cmd.Output(); err == nil {
 		lines := strings.Split(strings.TrimSpace(string(output)), "\n")
 		status.WindowCount = len(lines)
 	}
 
 	// Get current pane info
-	cmd = exec.Command("tmux", "display-message", "-t", HubSessionName, "-p", "#{pane_current_command}")
+	cmd = logging.Command("tmux", "display-message", "-t", HubSessionName, "-p", "#{pane_current_command}")
 	if output, err := cmd.Output(); err == nil {
 		status.CurrentPane = strings.TrimSpace(string(output))
 	}
@@ -132,10 +132,13 @@ func GetStatus() Status {
 // createOrAttach creates a new hub session or attaches to existing one.
 func createOrAttach(cfg *config.Config, opts *Options) error {
 	if Exists() {
+		if !tmux.IsWTSession(HubSessionName) {
+			return fmt.Errorf("tmux session '%s' was not started by wt; rename it with 'tmux rename-session -t %s <name>' and run 'wt hub' again", HubSessionName, HubSessionName)
+		}
 		// Hub exists - optionally add watch pane before attaching
 		if opts.Watch {
 			if err := addWatchPane(); err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: could not add watch pane: %v\n", err)
+				logging.Warnf("could not add watch pane: %v", err)
 			}
 		}
 		return attach()
@@ -150,7 +153,7 @@ func create(cfg *config.Config, opts *Options) error {
 	// Initialize hub-level beads store
 	if err := InitHubBeads(cfg); err != nil {
 		// Non-fatal - hub can work without beads
-		fmt.Fprintf(os.Stderr, "Warning: could not initialize hub beads: %v\n", err)
+		logging.Warnf("could not initialize hub beads: %v", err)
 	}
 
 	// Use home directory as working directory
@@ -161,11 +164,12 @@ func create(cfg *config.Config, opts *Options) error {
 
 	// Create detached tmux session with WT_HUB=1 set from the start
 	// Using -e flag ensures the shell inherits the env var immediately
-	cmd := exec.Command("tmux", "new-session",
+	cmd := logging.Command("tmux", "new-session",
 		"-d",                 // detached
 		"-s", HubSessionName, // session name
 		"-c", homeDir, // working directory
 		"-e", "WT_HUB=1", // mark as hub session for child processes
+		"-e", "WT_PROFILE=", // follow 'wt config profile switch' instead of the launching profile
 	)
 
 	if err := cmd.Run(); err != nil {
@@ -189,7 +193,7 @@ func create(cfg *config.Config, opts *Options) error {
 
 		// Send the editor command to start
 		// Prefix with space to avoid shell history
-		sendCmd := exec.Command("tmux", "send-keys", "-t", HubSessionName, " "+fullCmd, "Enter")
+		sendCmd := logging.Command("tmux", "send-keys", "-t", HubSessionName, " "+fullCmd, "Enter")
 		if err := sendCmd.Run(); err != nil {
 			return fmt.Errorf("starting editor in hub: %w", err)
 		}
@@ -198,19 +202,19 @@ func create(cfg *config.Config, opts *Options) error {
 
 	// Create a right-side pane for wt watch (unless --no-watch)
 	if !opts.NoWatch {
-		splitCmd := exec.Command("tmux", "split-window", "-h", "-t", HubSessionName, "-l", "25%", "-c", homeDir)
+		splitCmd := logging.Command("tmux", "split-window", "-h", "-t", HubSessionName, "-l", "25%", "-c", homeDir)
 		if err := splitCmd.Run(); err != nil {
 			// Non-fatal - watch pane is optional
-			fmt.Fprintf(os.Stderr, "Warning: could not create watch pane: %v\n", err)
+			logging.Warnf("could not create watch pane: %v", err)
 		} else {
 			// Start wt watch in a loop so it restarts if user quits
 			// This ensures the watch pane stays active
 			// Prefix with space to avoid shell history
-			watchCmd := exec.Command("tmux", "send-keys", "-t", HubSessionName+".1", " while true; do wt watch; sleep 1; done", "Enter")
+			watchCmd := logging.Command("tmux", "send-keys", "-t", HubSessionName+".1", " while true; do wt watch; sleep 1; done", "Enter")
 			_ = watchCmd.Run() // Non-fatal if this fails
 
 			// Focus back on the main pane (pane 0)
-			focusCmd := exec.Command("tmux", "select-pane", "-t", HubSessionName+".0")
+			focusCmd := logging.Command("tmux", "select-pane", "-t", HubSessionName+".0")
 			_ = focusCmd.Run()
 		}
 	}
@@ -244,7 +248,7 @@ func create(cfg *config.Config, opts *Options) error {
 // addWatchPane adds a watch pane to an existing hub session.
 func addWatchPane() error {
 	// Check how many panes exist
-	cmd := exec.Command("tmux", "list-panes", "-t", HubSessionName, "-F", "#{pane_id}")
+	cmd := logging.Command("tmux", "list-panes", "-t", HubSessionName, "-F", "#{pane_id}")
 	output, err := cmd.Output()
 	if err != nil {
 		return fmt.Errorf("listing panes: %w", err)
@@ -263,18 +267,18 @@ func addWatchPane() error {
 	}
 
 	// Create watch pane
-	splitCmd := exec.Command("tmux", "split-window", "-h", "-t", HubSessionName, "-l", "25%", "-c", homeDir)
+	splitCmd := logging.Command("tmux", "split-window", "-h", "-t", HubSessionName, "-l", "25%", "-c", homeDir)
 	if err := splitCmd.Run(); err != nil {
 		return fmt.Errorf("creating watch pane: %w", err)
 	}
 
 	// Start wt watch in a loop
 	// Prefix with space to avoid shell history
-	watchCmd := exec.Command("tmux", "send-keys", "-t", HubSessionName+".1", " while true; do wt watch; sleep 1; done", "Enter")
+	watchCmd := logging.Command("tmux", "send-keys", "-t", HubSessionName+".1", " while true; do wt watch; sleep 1; done", "Enter")
 	_ = watchCmd.Run()
 
 	// Focus back on main pane
-	focusCmd := exec.Command("tmux", "select-pane", "-t", HubSessionName+".0")
+	focusCmd := logging.Command("tmux", "select-pane", "-t", HubSessionName+".0")
 	_ = focusCmd.Run()
 
 	fmt.Println("Added watch pane to hub")
@@ -283,22 +287,7 @@ func addWatchPane() error {
 
 // attach attaches to the hub session.
 func attach() error {
-	// Check if we're inside tmux
-	if os.Getenv("TMUX") != "" {
-		// Switch to the hub session
-		cmd := exec.Command("tmux", "switch-client", "-t", HubSessionName)
-		cmd.Stdin = os.Stdin
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
-		return cmd.Run()
-	}
-
-	// Attach to the session
-	cmd := exec.Command("tmux", "attach-session", "-t", HubSessionName)
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	return cmd.Run()
+	return tmux.Attach(HubSessionName)
 }
 
 // detach detaches from hub and returns to previous session.
@@ -318,14 +307,14 @@ func detach() error {
 	lastSession := getLastSession()
 	if lastSession == "" || lastSession == HubSessionName {
 		// No previous session, just detach
-		fmt.Println("No previous session to return to. Detaching...")
-		cmd := exec.Command("tmux", "detach-client")
+		logging.Infof("No previous session to return to. Detaching...")
+		cmd := logging.Command("tmux", "detach-client")
 		return cmd.Run()
 	}
 
 	// Switch to last session
 	fmt.Printf("Returning to session: %s\n", lastSession)
-	cmd := exec.Command("tmux", "switch-client", "-t", lastSession)
+	cmd := logging.Command("tmux", "switch-client", "-t", lastSession)
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
@@ -334,7 +323,7 @@ func detach() error {
 
 // Exists returns true if the hub session exists.
 func Exists() bool {
-	cmd := exec.Command("tmux", "has-session", "-t", HubSessionName)
+	cmd := logging.Command("tmux", "has-session", "-t", tmux.ExactTarget(HubSessionName))
 	return cmd.Run() == nil
 }
 
@@ -352,7 +341,7 @@ func Kill() error {
 		return fmt.Errorf("hub session does not exist")
 	}
 
-	cmd := exec.Command("tmux", "kill-session", "-t", HubSessionName)
+	cmd := logging.Command("tmux", "kill-session", "-t", HubSessionName)
 	return cmd.Run()
 }
 
@@ -405,7 +394,7 @@ func killWithConfirmation(force bool) error {
 
 // getCurrentSession returns the name of the current tmux session.
 func getCurrentSession() string {
-	cmd := exec.Command("tmux", "display-message", "-p", "#{session_name}")
+	cmd := logging.Command("tmux", "display-message", "-p", "#{session_name}")
 	output, err := cmd.Output()
 	if err != nil {
 		return ""
@@ -417,7 +406,7 @@ func getCurrentSession() string {
 func getLastSession() string {
 	// tmux stores last session in the session stack
 	// We can get it via the client's last session
-	cmd := exec.Command("tmux", "display-message", "-p", "#{client_last_session}")
+	cmd := logging.Command("tmux", "display-message", "-p", "#{client_last_session}")
 	output, err := cmd.Output()
 	if err != nil {
 		return ""
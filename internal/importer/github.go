@@ -0,0 +1,75 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strconv"
+
+	"github.com/badri/wt/internal/logging"
+)
+
+// GitHubIssues lists open issues of repo carrying label, using the gh CLI.
+// An empty label lists all open issues.
+func GitHubIssues(repo, label string, limit int) ([]Issue, error) {
+	args := []string{"issue", "list", "--repo", repo, "--state", "open",
+		"--limit", strconv.Itoa(limit), "--json", "number,title,body,url,labels"}
+	if label != "" {
+		args = append(args, "--label", label)
+	}
+	cmd := logging.Command("gh", args...)
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("gh issue list: %s: %w", string(exitErr.Stderr), err)
+		}
+		return nil, fmt.Errorf("gh issue list: %w", err)
+	}
+	return parseGitHubIssues(repo, output)
+}
+
+// parseGitHubIssues parses the JSON output of 'gh issue list'
+func parseGitHubIssues(repo string, data []byte) ([]Issue, error) {
+	var raw []struct {
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+		Body   string `json:"body"`
+		URL    string `json:"url"`
+		Labels []struct {
+			Name string `json:"name"`
+		} `json:"labels"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parsing gh output: %w", err)
+	}
+
+	issues := make([]Issue, 0, len(raw))
+	for _, r := range raw {
+		issue := Issue{
+			Source: SourceGitHub,
+			Repo:   repo,
+			Key:    strconv.Itoa(r.Number),
+			Title:  r.Title,
+			Body:   r.Body,
+			URL:    r.URL,
+		}
+		for _, l := range r.Labels {
+			issue.Labels = append(issue.Labels, l.Name)
+		}
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
+
+// CloseGitHubIssue closes a GitHub issue with a comment, using the gh CLI
+func CloseGitHubIssue(repo, number, comment string) error {
+	args := []string{"issue", "close", number, "--repo", repo}
+	if comment != "" {
+		args = append(args, "--comment", comment)
+	}
+	cmd := logging.Command("gh", args...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("gh issue close: %s: %w", string(output), err)
+	}
+	return nil
+}
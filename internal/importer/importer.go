@@ -0,0 +1,117 @@
+// Package importer pulls issues from upstream trackers (GitHub, Jira) into
+// beads. Each imported bead is linked to its issue so the issue isn't
+// imported twice and, if requested, is closed when the bead is.
+package importer
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/jsonstore"
+)
+
+// Issue sources
+const (
+	SourceGitHub = "github"
+	SourceJira   = "jira"
+)
+
+// Issue is an upstream issue to import
+type Issue struct {
+	Source string
+	Repo   string // GitHub owner/repo; empty for Jira
+	Key    string // GitHub issue number or Jira issue key
+	Title  string
+	Body   string
+	URL    string
+	Labels []string
+}
+
+// Ref returns a short reference to the issue, e.g. "owner/repo#12" or "PROJ-12"
+func (i *Issue) Ref() string {
+	return ref(i.Source, i.Repo, i.Key)
+}
+
+// Link records the upstream issue a bead was imported from.
+type Link struct {
+	Bead          string `json:"bead"`
+	Project       string `json:"project,omitempty"`
+	Source        string `json:"source"`
+	Repo          string `json:"repo,omitempty"`
+	Key           string `json:"key"`
+	URL           string `json:"url,omitempty"`
+	CloseUpstream bool   `json:"close_upstream,omitempty"` // close the issue when the bead closes
+	DoneState     string `json:"done_state,omitempty"`     // Jira transition used to close
+	ImportedAt    string `json:"imported_at"`
+	ClosedAt      string `json:"closed_at,omitempty"` // when wt closed the upstream issue
+}
+
+// Ref returns a short reference to the linked issue
+func (l *Link) Ref() string {
+	return ref(l.Source, l.Repo, l.Key)
+}
+
+func ref(source, repo, key string) string {
+	if source == SourceGitHub {
+		return fmt.Sprintf("%s#%s", repo, key)
+	}
+	return key
+}
+
+// Store holds all links, keyed by bead ID.
+type Store struct {
+	*jsonstore.Store[Link]
+}
+
+// Load reads the link store from the config directory.
+func Load(cfg *config.Config) (*Store, error) {
+	s, err := jsonstore.Load[Link](filepath.Join(cfg.ConfigDir(), "imports.json"))
+	if err != nil {
+		return nil, err
+	}
+	return &Store{s}, nil
+}
+
+// Put adds or replaces the link for l.Bead.
+func (s *Store) Put(l *Link) {
+	s.Set(l.Bead, l)
+}
+
+// Find returns the link for an upstream issue, or nil if it wasn't imported.
+func (s *Store) Find(issue *Issue) *Link {
+	for _, l := range s.Entries {
+		if l.Source == issue.Source && l.Repo == issue.Repo && l.Key == issue.Key {
+			return l
+		}
+	}
+	return nil
+}
+
+// BeadDescription builds the description of a bead imported from issue,
+// with a back-reference to the issue first.
+func BeadDescription(issue *Issue) string {
+	desc := fmt.Sprintf("Imported from %s", issue.Ref())
+	if issue.URL != "" {
+		desc += ": " + issue.URL
+	}
+	if issue.Body != "" {
+		desc += "\n\n" + issue.Body
+	}
+	return desc
+}
+
+// CloseUpstream closes the issue a link points to, leaving comment on it.
+func CloseUpstream(l *Link, comment string) error {
+	switch l.Source {
+	case SourceGitHub:
+		return CloseGitHubIssue(l.Repo, l.Key, comment)
+	case SourceJira:
+		client, err := NewJiraClientFromEnv()
+		if err != nil {
+			return err
+		}
+		return client.Close(l.Key, l.DoneState, comment)
+	}
+	return fmt.Errorf("unknown issue source: %s", l.Source)
+}
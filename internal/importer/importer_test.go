@@ -0,0 +1,157 @@
+package importer
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/badri/wt/internal/config"
+)
+
+func TestStoreRoundTrip(t *testing.T) {
+	cfg, err := config.LoadFromDir(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadFromDir() error: %v", err)
+	}
+
+	store, err := Load(cfg)
+	if err != nil {
+		t.Fatalf("Load() on missing file error: %v", err)
+	}
+	if len(store.Entries) != 0 {
+		t.Fatalf("new store has %d links, want 0", len(store.Entries))
+	}
+
+	store.Put(&Link{Bead: "wt-a", Source: SourceGitHub, Repo: "acme/app", Key: "12", CloseUpstream: true})
+	store.Put(&Link{Bead: "wt-b", Source: SourceJira, Key: "APP-7"})
+	if err := store.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	loaded, err := Load(cfg)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if l := loaded.Get("wt-a"); l == nil || !l.CloseUpstream || l.Ref() != "acme/app#12" {
+		t.Fatalf("Get(wt-a) = %+v, want acme/app#12 with close_upstream", l)
+	}
+
+	if l := loaded.Find(&Issue{Source: SourceJira, Key: "APP-7"}); l == nil || l.Bead != "wt-b" {
+		t.Errorf("Find(APP-7) = %+v, want wt-b", l)
+	}
+	if l := loaded.Find(&Issue{Source: SourceGitHub, Repo: "other/app", Key: "12"}); l != nil {
+		t.Errorf("Find(other/app#12) = %+v, want nil", l)
+	}
+}
+
+func TestParseGitHubIssues(t *testing.T) {
+	data := []byte(`[{"number":42,"title":"Fix login","body":"Steps...","url":"https://github.com/acme/app/issues/42","labels":[{"name":"agent-ready"},{"name":"bug"}]}]`)
+
+	issues, err := parseGitHubIssues("acme/app", data)
+	if err != nil {
+		t.Fatalf("parseGitHubIssues() error: %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("got %d issues, want 1", len(issues))
+	}
+	got := issues[0]
+	if got.Key != "42" || got.Ref() != "acme/app#42" || got.Title != "Fix login" {
+		t.Errorf("issue = %+v", got)
+	}
+	if strings.Join(got.Labels, ",") != "agent-ready,bug" {
+		t.Errorf("labels = %v", got.Labels)
+	}
+
+	if _, err := parseGitHubIssues("acme/app", []byte("not json")); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+}
+
+func TestBeadDescription(t *testing.T) {
+	issue := &Issue{Source: SourceGitHub, Repo: "acme/app", Key: "42", URL: "https://github.com/acme/app/issues/42", Body: "Steps to reproduce"}
+	want := "Imported from acme/app#42: https://github.com/acme/app/issues/42\n\nSteps to reproduce"
+	if got := BeadDescription(issue); got != want {
+		t.Errorf("BeadDescription() = %q, want %q", got, want)
+	}
+
+	bare := &Issue{Source: SourceJira, Key: "APP-1"}
+	if got := BeadDescription(bare); got != "Imported from APP-1" {
+		t.Errorf("BeadDescription(bare) = %q", got)
+	}
+}
+
+func TestJiraJQL(t *testing.T) {
+	tests := []struct {
+		label, extra, want string
+	}{
+		{"agent-ready", "", `labels = "agent-ready" AND statusCategory != Done ORDER BY created ASC`},
+		{"", "project = APP", `statusCategory != Done AND (project = APP) ORDER BY created ASC`},
+	}
+	for _, tt := range tests {
+		if got := JiraJQL(tt.label, tt.extra); got != tt.want {
+			t.Errorf("JiraJQL(%q, %q) = %q, want %q", tt.label, tt.extra, got, tt.want)
+		}
+	}
+}
+
+func TestJiraClient(t *testing.T) {
+	var transitioned, commented string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "me@example.com" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.URL.Path == "/rest/api/2/search":
+			if !strings.Contains(r.URL.Query().Get("jql"), "agent-ready") {
+				t.Errorf("jql = %q", r.URL.Query().Get("jql"))
+			}
+			w.Write([]byte(`{"issues":[{"key":"APP-7","fields":{"summary":"Add export","description":"CSV please","labels":["agent-ready"]}}]}`))
+		case r.URL.Path == "/rest/api/2/issue/APP-7/transitions" && r.Method == "GET":
+			w.Write([]byte(`{"transitions":[{"id":"11","name":"Start","to":{"name":"In Progress"}},{"id":"31","name":"Resolve","to":{"name":"Done"}}]}`))
+		case r.URL.Path == "/rest/api/2/issue/APP-7/transitions" && r.Method == "POST":
+			var body struct {
+				Transition struct {
+					ID string `json:"id"`
+				} `json:"transition"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			transitioned = body.Transition.ID
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/rest/api/2/issue/APP-7/comment":
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+			commented = body["body"]
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := &JiraClient{BaseURL: server.URL, User: "me@example.com", Token: "secret"}
+
+	issues, err := client.Search(JiraJQL("agent-ready", ""), 50)
+	if err != nil {
+		t.Fatalf("Search() error: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Key != "APP-7" || issues[0].URL != server.URL+"/browse/APP-7" {
+		t.Fatalf("Search() = %+v", issues)
+	}
+
+	if err := client.Close("APP-7", "", "Done in wt-abc"); err != nil {
+		t.Fatalf("Close() error: %v", err)
+	}
+	if transitioned != "31" {
+		t.Errorf("transition = %q, want 31 (matched by target status)", transitioned)
+	}
+	if commented != "Done in wt-abc" {
+		t.Errorf("comment = %q", commented)
+	}
+
+	if err := client.Close("APP-7", "Shipped", ""); err == nil || !strings.Contains(err.Error(), "Start, Resolve") {
+		t.Errorf("Close() with unknown state error = %v, want list of transitions", err)
+	}
+}
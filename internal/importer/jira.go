@@ -0,0 +1,183 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JiraClient talks to the Jira REST API (v2).
+type JiraClient struct {
+	BaseURL string // e.g. https://example.atlassian.net
+	User    string // account email; empty to send Token as a bearer token
+	Token   string
+	HTTP    *http.Client
+}
+
+// NewJiraClientFromEnv configures a client from JIRA_URL, JIRA_USER and
+// JIRA_API_TOKEN. Without JIRA_USER the token is sent as a bearer token
+// (Jira Server/Data Center personal access tokens).
+func NewJiraClientFromEnv() (*JiraClient, error) {
+	baseURL := os.Getenv("JIRA_URL")
+	token := os.Getenv("JIRA_API_TOKEN")
+	if baseURL == "" || token == "" {
+		return nil, fmt.Errorf("JIRA_URL and JIRA_API_TOKEN must be set (and JIRA_USER for Jira Cloud)")
+	}
+	return &JiraClient{
+		BaseURL: strings.TrimSuffix(baseURL, "/"),
+		User:    os.Getenv("JIRA_USER"),
+		Token:   token,
+		HTTP:    &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// JiraJQL builds the query for open issues carrying label, narrowed by an
+// optional extra JQL clause
+func JiraJQL(label, extra string) string {
+	var clauses []string
+	if label != "" {
+		clauses = append(clauses, fmt.Sprintf("labels = %q", label))
+	}
+	clauses = append(clauses, "statusCategory != Done")
+	if extra != "" {
+		clauses = append(clauses, "("+extra+")")
+	}
+	return strings.Join(clauses, " AND ") + " ORDER BY created ASC"
+}
+
+// Search returns the issues matching jql
+func (c *JiraClient) Search(jql string, limit int) ([]Issue, error) {
+	query := url.Values{}
+	query.Set("jql", jql)
+	query.Set("maxResults", strconv.Itoa(limit))
+	query.Set("fields", "summary,description,labels")
+
+	var result struct {
+		Issues []struct {
+			Key    string `json:"key"`
+			Fields struct {
+				Summary     string   `json:"summary"`
+				Description string   `json:"description"`
+				Labels      []string `json:"labels"`
+			} `json:"fields"`
+		} `json:"issues"`
+	}
+	if err := c.do("GET", "/rest/api/2/search?"+query.Encode(), nil, &result); err != nil {
+		return nil, fmt.Errorf("searching Jira: %w", err)
+	}
+
+	issues := make([]Issue, 0, len(result.Issues))
+	for _, r := range result.Issues {
+		issues = append(issues, Issue{
+			Source: SourceJira,
+			Key:    r.Key,
+			Title:  r.Fields.Summary,
+			Body:   r.Fields.Description,
+			URL:    c.BaseURL + "/browse/" + r.Key,
+			Labels: r.Fields.Labels,
+		})
+	}
+	return issues, nil
+}
+
+// Close comments on an issue and moves it through the transition named
+// doneState (default "Done")
+func (c *JiraClient) Close(key, doneState, comment string) error {
+	if doneState == "" {
+		doneState = "Done"
+	}
+
+	var transitions struct {
+		Transitions []struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+			To   struct {
+				Name string `json:"name"`
+			} `json:"to"`
+		} `json:"transitions"`
+	}
+	if err := c.do("GET", "/rest/api/2/issue/"+key+"/transitions", nil, &transitions); err != nil {
+		return fmt.Errorf("listing transitions of %s: %w", key, err)
+	}
+
+	var transitionID string
+	var available []string
+	for _, t := range transitions.Transitions {
+		if strings.EqualFold(t.Name, doneState) || strings.EqualFold(t.To.Name, doneState) {
+			transitionID = t.ID
+			break
+		}
+		available = append(available, t.Name)
+	}
+	if transitionID == "" {
+		return fmt.Errorf("%s has no transition to %q (available: %s)", key, doneState, strings.Join(available, ", "))
+	}
+
+	if comment != "" {
+		body := map[string]string{"body": comment}
+		if err := c.do("POST", "/rest/api/2/issue/"+key+"/comment", body, nil); err != nil {
+			return fmt.Errorf("commenting on %s: %w", key, err)
+		}
+	}
+
+	body := map[string]any{"transition": map[string]string{"id": transitionID}}
+	if err := c.do("POST", "/rest/api/2/issue/"+key+"/transitions", body, nil); err != nil {
+		return fmt.Errorf("transitioning %s: %w", key, err)
+	}
+	return nil
+}
+
+// do sends a request to the API and decodes the JSON response into out
+func (c *JiraClient) do(method, path string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, c.BaseURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.User != "" {
+		req.SetBasicAuth(c.User, c.Token)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	client := c.HTTP
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%s %s: %s: %s", method, path, resp.Status, strings.TrimSpace(string(data)))
+	}
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, out)
+}
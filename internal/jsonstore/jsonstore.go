@@ -0,0 +1,117 @@
+// Package jsonstore keeps records in a JSON file of entries keyed by ID,
+// like the beads 'wt done' left open or the PRs awaiting verification.
+// Saves write a temp file and rename it into place, so a reader never sees
+// half a file, and Update holds a lock across its read-modify-write, so
+// concurrent wt processes don't drop each other's entries.
+package jsonstore
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/badri/wt/internal/filelock"
+)
+
+// lockTimeout bounds how long Update waits for another process's update
+var lockTimeout = 30 * time.Second
+
+// Store holds the entries of a store file
+type Store[E any] struct {
+	Entries map[string]*E
+	path    string
+}
+
+// Load reads the store at path. A missing file is an empty store.
+func Load[E any](path string) (*Store[E], error) {
+	s := &Store[E]{Entries: make(map[string]*E), path: path}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &s.Entries); err != nil {
+		return nil, err
+	}
+	if s.Entries == nil {
+		s.Entries = make(map[string]*E)
+	}
+	return s, nil
+}
+
+// Update loads the store at path under an exclusive lock, lets fn change
+// it and saves the result. Nothing is saved when fn returns an error.
+func Update[E any](path string, fn func(s *Store[E]) error) error {
+	unlock, err := filelock.Lock(path+".lock", false, lockTimeout)
+	if err != nil {
+		return fmt.Errorf("locking %s: %w", filepath.Base(path), err)
+	}
+	defer unlock()
+
+	s, err := Load[E](path)
+	if err != nil {
+		return err
+	}
+	if err := fn(s); err != nil {
+		return err
+	}
+	return s.Save()
+}
+
+// Save writes the store, removing the file once it has no entries
+func (s *Store[E]) Save() error {
+	if len(s.Entries) == 0 {
+		if err := os.Remove(s.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	data, err := json.MarshalIndent(s.Entries, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
+
+// Get returns the entry for id, or nil
+func (s *Store[E]) Get(id string) *E {
+	return s.Entries[id]
+}
+
+// Set adds or replaces the entry for id
+func (s *Store[E]) Set(id string, e *E) {
+	s.Entries[id] = e
+}
+
+// Remove deletes the entry for id
+func (s *Store[E]) Remove(id string) {
+	delete(s.Entries, id)
+}
@@ -0,0 +1,88 @@
+package jsonstore
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+type entry struct {
+	Name string `json:"name"`
+}
+
+func TestStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "store.json")
+
+	s, err := Load[entry](path)
+	if err != nil || len(s.Entries) != 0 {
+		t.Fatalf("Load() on missing file = %+v, %v; want an empty store", s, err)
+	}
+	s.Set("a", &entry{Name: "first"})
+	if err := s.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	loaded, err := Load[entry](path)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if e := loaded.Get("a"); e == nil || e.Name != "first" {
+		t.Fatalf("Get(a) = %+v, want first", e)
+	}
+	if matches, _ := filepath.Glob(path + ".*.tmp"); len(matches) != 0 {
+		t.Errorf("Save() left temp files: %v", matches)
+	}
+
+	// The file goes away with the last entry
+	loaded.Remove("a")
+	if err := loaded.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("store file still exists after removing every entry: %v", err)
+	}
+}
+
+func TestUpdateConcurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "store.json")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			id := fmt.Sprintf("e%d", i)
+			if err := Update(path, func(s *Store[entry]) error {
+				s.Set(id, &entry{Name: id})
+				return nil
+			}); err != nil {
+				t.Errorf("Update(%s) error: %v", id, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	s, err := Load[entry](path)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if len(s.Entries) != 20 {
+		t.Errorf("store has %d entries after 20 concurrent updates, want 20", len(s.Entries))
+	}
+}
+
+func TestUpdateErrorSavesNothing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "store.json")
+	err := Update(path, func(s *Store[entry]) error {
+		s.Set("a", &entry{})
+		return fmt.Errorf("changed my mind")
+	})
+	if err == nil {
+		t.Fatal("Update() should return fn's error")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Update() saved the store despite the error: %v", err)
+	}
+}
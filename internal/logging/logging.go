@@ -0,0 +1,208 @@
+// Package logging is wt's leveled logger: a slog handler printing short,
+// optionally colored lines to stderr. Progress notes and warnings show by
+// default, --verbose adds debug lines, among them every git, tmux, bd and
+// gh command wt runs, and --quiet leaves only errors.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// Environment variables passing --verbose and --quiet on to the wt
+// processes wt starts, e.g. 'wt new' run by wt auto
+const (
+	VerboseEnv = "WT_VERBOSE"
+	QuietEnv   = "WT_QUIET"
+)
+
+// ANSI colors of the level prefixes
+const (
+	colorDim    = "\x1b[2m"
+	colorYellow = "\x1b[33m"
+	colorRed    = "\x1b[31m"
+	colorReset  = "\x1b[0m"
+)
+
+// level is shared by every handler Setup creates, so Enabled is cheap
+var level = new(slog.LevelVar)
+
+func init() {
+	level.Set(slog.LevelInfo)
+	slog.SetDefault(slog.New(NewHandler(os.Stderr, false)))
+}
+
+// Setup makes the default logger print to w from minLevel up, colored
+// when color is set
+func Setup(w io.Writer, minLevel slog.Level, color bool) {
+	level.Set(minLevel)
+	slog.SetDefault(slog.New(NewHandler(w, color)))
+}
+
+// Verbose reports whether debug lines are shown
+func Verbose() bool {
+	return level.Level() <= slog.LevelDebug
+}
+
+// Handler prints records as "Warning: message key=value". Info records
+// have no prefix; debug records are dimmed when colored.
+type Handler struct {
+	mu    *sync.Mutex
+	w     io.Writer
+	color bool
+	attrs []slog.Attr
+	group string
+}
+
+// NewHandler returns a handler writing to w
+func NewHandler(w io.Writer, color bool) *Handler {
+	return &Handler{mu: &sync.Mutex{}, w: w, color: color}
+}
+
+func (h *Handler) Enabled(_ context.Context, l slog.Level) bool {
+	return l >= level.Level()
+}
+
+func (h *Handler) Handle(_ context.Context, r slog.Record) error {
+	var sb strings.Builder
+	prefix, color := levelPrefix(r.Level)
+	if h.color && color != "" {
+		sb.WriteString(color)
+	}
+	sb.WriteString(prefix)
+	if h.color && color != "" && r.Level > slog.LevelDebug {
+		sb.WriteString(colorReset)
+	}
+	sb.WriteString(r.Message)
+	for _, a := range h.attrs {
+		writeAttr(&sb, "", a)
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		writeAttr(&sb, h.group, a)
+		return true
+	})
+	if h.color && r.Level <= slog.LevelDebug {
+		sb.WriteString(colorReset)
+	}
+	sb.WriteString("\n")
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := io.WriteString(h.w, sb.String())
+	return err
+}
+
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	// Qualify the keys now, as later groups don't apply to these attrs
+	h2 := *h
+	h2.attrs = append([]slog.Attr{}, h.attrs...)
+	for _, a := range attrs {
+		if h.group != "" {
+			a.Key = h.group + "." + a.Key
+		}
+		h2.attrs = append(h2.attrs, a)
+	}
+	return &h2
+}
+
+func (h *Handler) WithGroup(name string) slog.Handler {
+	h2 := *h
+	if h.group != "" {
+		name = h.group + "." + name
+	}
+	h2.group = name
+	return &h2
+}
+
+func levelPrefix(l slog.Level) (prefix, color string) {
+	switch {
+	case l >= slog.LevelError:
+		return "error: ", colorRed
+	case l >= slog.LevelWarn:
+		return "Warning: ", colorYellow
+	case l >= slog.LevelInfo:
+		return "", ""
+	}
+	return "debug: ", colorDim
+}
+
+func writeAttr(sb *strings.Builder, group string, a slog.Attr) {
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+	key := a.Key
+	if group != "" {
+		key = group + "." + key
+	}
+	value := a.Value.Resolve().String()
+	if value == "" || strings.ContainsAny(value, " \t\"") {
+		value = fmt.Sprintf("%q", value)
+	}
+	fmt.Fprintf(sb, " %s=%s", key, value)
+}
+
+// Infof logs a progress note, hidden by --quiet
+func Infof(format string, args ...any) {
+	slog.Info(fmt.Sprintf(format, args...))
+}
+
+// Warnf logs a warning: something went wrong, but the command carries on
+func Warnf(format string, args ...any) {
+	slog.Warn(fmt.Sprintf(format, args...))
+}
+
+// Debugf logs a line shown only with --verbose
+func Debugf(format string, args ...any) {
+	if Verbose() {
+		slog.Debug(fmt.Sprintf(format, args...))
+	}
+}
+
+// Command is exec.Command that logs the command line with --verbose
+func Command(name string, args ...string) *exec.Cmd {
+	Debugf("exec: %s", commandLine(name, args))
+	return exec.Command(name, args...)
+}
+
+// CommandContext is exec.CommandContext that logs the command line with
+// --verbose
+func CommandContext(ctx context.Context, name string, args ...string) *exec.Cmd {
+	Debugf("exec: %s", commandLine(name, args))
+	return exec.CommandContext(ctx, name, args...)
+}
+
+// CommandIn is Command run in dir ("" for the current directory)
+func CommandIn(dir, name string, args ...string) *exec.Cmd {
+	if dir == "" {
+		return Command(name, args...)
+	}
+	Debugf("exec: %s (in %s)", commandLine(name, args), dir)
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	return cmd
+}
+
+// maxArgLen shortens long arguments, like prompts, in debug lines
+const maxArgLen = 200
+
+// commandLine quotes the arguments that need it, so the line can be
+// pasted into a shell unless an argument had to be shortened
+func commandLine(name string, args []string) string {
+	parts := []string{name}
+	for _, a := range args {
+		if len(a) > maxArgLen {
+			a = a[:maxArgLen] + "..."
+		}
+		if a == "" || strings.ContainsAny(a, " \t\n'\"$`\\|&;<>()*?[]{}~#") {
+			a = "'" + strings.ReplaceAll(a, "'", `'\''`) + "'"
+		}
+		parts = append(parts, a)
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,131 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func withLogger(t *testing.T, minLevel slog.Level, color bool) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	prevLevel, prevLogger := level.Level(), slog.Default()
+	Setup(&buf, minLevel, color)
+	t.Cleanup(func() {
+		level.Set(prevLevel)
+		slog.SetDefault(prevLogger)
+	})
+	return &buf
+}
+
+func TestLevels(t *testing.T) {
+	tests := []struct {
+		name     string
+		minLevel slog.Level
+		want     string
+	}{
+		{"default", slog.LevelInfo, "Creating worktree...\nWarning: no remote\nerror: failed\n"},
+		{"verbose", slog.LevelDebug, "debug: exec: git status\nCreating worktree...\nWarning: no remote\nerror: failed\n"},
+		{"quiet", slog.LevelError, "error: failed\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := withLogger(t, tt.minLevel, false)
+			Debugf("exec: %s", "git status")
+			Infof("Creating worktree...")
+			Warnf("no %s", "remote")
+			slog.Error("failed")
+			if got := buf.String(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerbose(t *testing.T) {
+	withLogger(t, slog.LevelDebug, false)
+	if !Verbose() {
+		t.Error("Verbose() = false at debug level")
+	}
+	withLogger(t, slog.LevelInfo, false)
+	if Verbose() {
+		t.Error("Verbose() = true at info level")
+	}
+}
+
+func TestColor(t *testing.T) {
+	buf := withLogger(t, slog.LevelDebug, true)
+	Warnf("careful")
+	Debugf("details")
+	want := colorYellow + "Warning: " + colorReset + "careful\n" +
+		colorDim + "debug: details" + colorReset + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestAttrs(t *testing.T) {
+	buf := withLogger(t, slog.LevelInfo, false)
+	slog.With("session", "toast").WithGroup("bead").Warn("stuck", "id", "wt-1", "title", "Fix it")
+	want := `Warning: stuck session=toast bead.id=wt-1 bead.title="Fix it"` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestCommandLine(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"git", []string{"status", "--short"}, "git status --short"},
+		{"git", []string{"commit", "-m", "it's done"}, `git commit -m 'it'\''s done'`},
+		{"tmux", []string{"send-keys", "-t", "toast", ""}, "tmux send-keys -t toast ''"},
+	}
+	for _, tt := range tests {
+		if got := commandLine(tt.name, tt.args); got != tt.want {
+			t.Errorf("commandLine(%q, %q) = %q, want %q", tt.name, tt.args, got, tt.want)
+		}
+	}
+
+	long := strings.Repeat("x", maxArgLen+50)
+	got := commandLine("claude", []string{long})
+	if want := "claude " + strings.Repeat("x", maxArgLen) + "..."; got != want {
+		t.Errorf("long argument not shortened: %q", got)
+	}
+}
+
+func TestCommandIn(t *testing.T) {
+	buf := withLogger(t, slog.LevelDebug, false)
+	cmd := CommandIn("/tmp/repo", "git", "status")
+	if cmd.Dir != "/tmp/repo" {
+		t.Errorf("Dir = %q, want /tmp/repo", cmd.Dir)
+	}
+	if got, want := buf.String(), "debug: exec: git status (in /tmp/repo)\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	if cmd := CommandIn("", "git", "status"); cmd.Dir != "" {
+		t.Errorf("Dir = %q, want empty", cmd.Dir)
+	}
+	if got, want := buf.String(), "debug: exec: git status\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestCommandContext(t *testing.T) {
+	buf := withLogger(t, slog.LevelDebug, false)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	cmd := CommandContext(ctx, "claude", "--print", "sum up")
+	if got, want := buf.String(), "debug: exec: claude --print 'sum up'\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if err := cmd.Run(); err == nil {
+		t.Error("Run() with a canceled context succeeded")
+	}
+}
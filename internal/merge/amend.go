@@ -0,0 +1,146 @@
+package merge
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"github.com/badri/wt/internal/logging"
+)
+
+// updatesMarker starts the section of a PR body that lists follow-up
+// commits pushed by 'wt done --amend-pr'
+const updatesMarker = "<!-- wt:updates -->"
+
+// ExistingPR is an open PR for a session's branch
+type ExistingPR struct {
+	URL       string
+	Number    int
+	Body      string
+	IsDraft   bool
+	Author    string
+	Reviewers []string // users who reviewed it, other than the author
+}
+
+// FindOpenPR returns the open PR for a branch, or nil if it has none
+func FindOpenPR(dir, branch string) (*ExistingPR, error) {
+	cmd := logging.CommandIn(dir, "gh", "pr", "view", branch, "--json", "url,number,state,body,isDraft,author,latestReviews")
+	output, err := cmd.Output()
+	if err != nil {
+		// gh fails the same way for "no PR" and for other problems; only
+		// a missing PR is expected here
+		if ee, ok := err.(*exec.ExitError); ok && strings.Contains(string(ee.Stderr), "no pull requests found") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("looking up PR for %s: %w", branch, err)
+	}
+	return parseExistingPR(output)
+}
+
+func parseExistingPR(data []byte) (*ExistingPR, error) {
+	var view struct {
+		URL     string `json:"url"`
+		Number  int    `json:"number"`
+		State   string `json:"state"`
+		Body    string `json:"body"`
+		IsDraft bool   `json:"isDraft"`
+		Author  struct {
+			Login string `json:"login"`
+		} `json:"author"`
+		LatestReviews []struct {
+			Author struct {
+				Login string `json:"login"`
+			} `json:"author"`
+		} `json:"latestReviews"`
+	}
+	if err := json.Unmarshal(data, &view); err != nil {
+		return nil, fmt.Errorf("parsing PR: %w", err)
+	}
+	if view.State != "OPEN" {
+		return nil, nil
+	}
+	pr := &ExistingPR{URL: view.URL, Number: view.Number, Body: view.Body, IsDraft: view.IsDraft, Author: view.Author.Login}
+	for _, r := range view.LatestReviews {
+		login := r.Author.Login
+		if login == "" || strings.EqualFold(login, pr.Author) || slices.Contains(pr.Reviewers, login) {
+			continue
+		}
+		pr.Reviewers = append(pr.Reviewers, login)
+	}
+	return pr, nil
+}
+
+// NewCommits returns the commits on HEAD that the remote branch doesn't
+// have, as "<short sha> <subject>". Commits that a rebase only rewrote are
+// not new: they are matched by patch, and those the rebase brought in from
+// baseRef (e.g. "origin/main") are left out, so after 'wt done' rebases the
+// branch just the follow-up commits are listed. Fetch the branch first.
+func NewCommits(worktreePath, branch, baseRef string) ([]string, error) {
+	return cherryLog(worktreePath, branch, "--right-only", baseRef)
+}
+
+// RemoteOnlyCommits returns the commits on the remote branch that HEAD
+// doesn't have, by patch, e.g. review suggestions applied on GitHub. A
+// forced push would drop them.
+func RemoteOnlyCommits(worktreePath, branch string) ([]string, error) {
+	return cherryLog(worktreePath, branch, "--left-only")
+}
+
+// cherryLog lists one side of origin/<branch>...HEAD, skipping commits with
+// the same patch on both sides and those reachable from exclude
+func cherryLog(worktreePath, branch, side string, exclude ...string) ([]string, error) {
+	args := []string{"-C", worktreePath, "log", side, "--cherry-pick", "--no-merges", "--reverse", "--format=%h %s",
+		"origin/" + branch + "...HEAD"}
+	if len(exclude) > 0 {
+		args = append(append(args, "--not"), exclude...)
+	}
+	cmd := logging.Command("git", args...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("comparing with origin/%s: %s: %w", branch, strings.TrimSpace(string(output)), err)
+	}
+	var commits []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			commits = append(commits, line)
+		}
+	}
+	return commits, nil
+}
+
+// PushUpdate pushes a branch that already has a PR. After a rebase the push
+// is not a fast-forward, so it is forced, but only if the remote branch is
+// still where the last fetch saw it.
+func PushUpdate(worktreePath, branch string) error {
+	cmd := logging.Command("git", "-C", worktreePath, "push", "--force-with-lease", "-u", "origin", branch)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("pushing branch: %s: %w", strings.TrimSpace(string(output)), err)
+	}
+	return nil
+}
+
+// UpdatePRBody replaces a PR's description
+func UpdatePRBody(dir, pr, body string) error {
+	cmd := logging.CommandIn(dir, "gh", "pr", "edit", pr, "--body", body)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("updating PR description: %s: %w", strings.TrimSpace(string(output)), err)
+	}
+	return nil
+}
+
+// AppendUpdate adds a dated list of follow-up commits to a PR body, under
+// an "Updates" section that later updates extend
+func AppendUpdate(body, date string, commits []string) string {
+	var sb strings.Builder
+	sb.WriteString(strings.TrimRight(body, "\n"))
+	if !strings.Contains(body, updatesMarker) {
+		sb.WriteString("\n\n" + updatesMarker + "\n## Updates")
+	}
+	sb.WriteString(fmt.Sprintf("\n\n**%s**\n", date))
+	for _, c := range commits {
+		sb.WriteString("- " + c + "\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,106 @@
+package merge
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseExistingPR(t *testing.T) {
+	pr, err := parseExistingPR([]byte(`{"url":"https://github.com/o/r/pull/7","number":7,"state":"OPEN",
+		"body":"Closes bead: wt-1","isDraft":false,"author":{"login":"bot"},
+		"latestReviews":[{"author":{"login":"alice"}},{"author":{"login":"bot"}},{"author":{"login":"bob"}},{"author":{"login":"alice"}}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pr == nil || pr.Number != 7 || pr.Body != "Closes bead: wt-1" {
+		t.Fatalf("parseExistingPR() = %+v", pr)
+	}
+	if want := []string{"alice", "bob"}; !reflect.DeepEqual(pr.Reviewers, want) {
+		t.Errorf("Reviewers = %v, want %v", pr.Reviewers, want)
+	}
+
+	for _, state := range []string{"MERGED", "CLOSED"} {
+		if pr, err := parseExistingPR([]byte(`{"state":"` + state + `"}`)); err != nil || pr != nil {
+			t.Errorf("%s PR: got %+v, %v; want nil", state, pr, err)
+		}
+	}
+	if _, err := parseExistingPR([]byte("not json")); err == nil {
+		t.Error("parseExistingPR() should fail on invalid JSON")
+	}
+}
+
+func TestAppendUpdate(t *testing.T) {
+	body := AppendUpdate("Fixes the thing.\n", "2026-01-02", []string{"abc123 Address review"})
+	if !strings.HasPrefix(body, "Fixes the thing.\n\n"+updatesMarker+"\n## Updates\n\n**2026-01-02**") || !strings.HasSuffix(body, "- abc123 Address review\n") {
+		t.Errorf("first update:\n%s", body)
+	}
+
+	body = AppendUpdate(body, "2026-01-03", []string{"def456 Fix test", "fed654 Rename"})
+	if strings.Count(body, "## Updates") != 1 {
+		t.Errorf("second update repeated the section:\n%s", body)
+	}
+	if !strings.HasSuffix(body, "- abc123 Address review\n\n**2026-01-03**\n- def456 Fix test\n- fed654 Rename\n") {
+		t.Errorf("second update:\n%s", body)
+	}
+}
+
+func TestNewAndRemoteOnlyCommits(t *testing.T) {
+	remote := filepath.Join(t.TempDir(), "remote.git")
+	gitRun(t, t.TempDir(), "init", "-q", "--bare", remote)
+	repo := initTestRepo(t)
+	base, _ := GetCurrentBranch(repo)
+	gitRun(t, repo, "remote", "add", "origin", remote)
+	gitRun(t, repo, "push", "-q", "origin", base)
+
+	commit := func(dir, name string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(name+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		gitRun(t, dir, "add", name)
+		gitRun(t, dir, "commit", "-q", "-m", "Add "+name)
+	}
+
+	// The PR branch is pushed, then the base moves on and the branch is
+	// rebased and gets a follow-up commit
+	gitRun(t, repo, "checkout", "-q", "-b", "feature")
+	commit(repo, "first.txt")
+	gitRun(t, repo, "push", "-q", "origin", "feature")
+	gitRun(t, repo, "checkout", "-q", base)
+	commit(repo, "base.txt")
+	gitRun(t, repo, "push", "-q", "origin", base)
+	gitRun(t, repo, "checkout", "-q", "feature")
+	gitRun(t, repo, "rebase", "-q", base)
+	commit(repo, "followup.txt")
+
+	got, err := NewCommits(repo, "feature", "origin/"+base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || !strings.HasSuffix(got[0], " Add followup.txt") {
+		t.Errorf("NewCommits() = %v, want just the follow-up", got)
+	}
+	if got, err := RemoteOnlyCommits(repo, "feature"); err != nil || len(got) != 0 {
+		t.Errorf("RemoteOnlyCommits() = %v, %v; want none", got, err)
+	}
+
+	// Someone else pushes to the PR branch
+	other := filepath.Join(t.TempDir(), "other")
+	gitRun(t, t.TempDir(), "clone", "-q", "-b", "feature", remote, other)
+	gitRun(t, other, "config", "user.email", "other@test.com")
+	gitRun(t, other, "config", "user.name", "Other")
+	commit(other, "suggestion.txt")
+	gitRun(t, other, "push", "-q", "origin", "feature")
+	gitRun(t, repo, "fetch", "-q", "origin", "feature")
+
+	got, err = RemoteOnlyCommits(repo, "feature")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || !strings.HasSuffix(got[0], " Add suggestion.txt") {
+		t.Errorf("RemoteOnlyCommits() = %v, want the suggestion", got)
+	}
+}
@@ -0,0 +1,118 @@
+package merge
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/badri/wt/internal/logging"
+)
+
+// Combined state of a PR's status checks
+const (
+	ChecksPassed  = "passed"  // every check succeeded, or there are none
+	ChecksPending = "pending" // some check is still running
+	ChecksFailed  = "failed"  // some check failed
+)
+
+// PRChecks returns the combined state of a PR's status checks
+func PRChecks(dir, pr string) (string, error) {
+	cmd := logging.CommandIn(dir, "gh", "pr", "view", pr, "--json", "statusCheckRollup")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("getting PR checks: %w", err)
+	}
+	return checksState(output)
+}
+
+// PRStatus is where a pr-auto PR stands on its way to merging
+type PRStatus struct {
+	State       string   // OPEN, MERGED or CLOSED
+	MergeCommit string   // set once merged
+	Checks      string   // ChecksPassed, ChecksPending or ChecksFailed
+	Failed      []string // names of the failed checks
+}
+
+// GetPRStatus returns a PR's state, merge commit and checks
+func GetPRStatus(dir, pr string) (*PRStatus, error) {
+	cmd := logging.CommandIn(dir, "gh", "pr", "view", pr, "--json", "state,mergeCommit,statusCheckRollup")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("viewing PR %s: %w", pr, err)
+	}
+	return parsePRStatus(output)
+}
+
+// rollupCheck is one entry of gh's statusCheckRollup: a check run (name,
+// status and conclusion) or a commit status (context and state)
+type rollupCheck struct {
+	Name       string `json:"name"`
+	Context    string `json:"context"`
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion"`
+	State      string `json:"state"`
+}
+
+func parsePRStatus(data []byte) (*PRStatus, error) {
+	var view struct {
+		State       string `json:"state"`
+		MergeCommit *struct {
+			OID string `json:"oid"`
+		} `json:"mergeCommit"`
+		Checks []rollupCheck `json:"statusCheckRollup"`
+	}
+	if err := json.Unmarshal(data, &view); err != nil {
+		return nil, fmt.Errorf("parsing PR status: %w", err)
+	}
+	status := &PRStatus{State: view.State}
+	if view.MergeCommit != nil {
+		status.MergeCommit = view.MergeCommit.OID
+	}
+	status.Checks, status.Failed = combineChecks(view.Checks)
+	return status, nil
+}
+
+// checksState combines the statusCheckRollup of gh pr view --json
+func checksState(data []byte) (string, error) {
+	var view struct {
+		Checks []rollupCheck `json:"statusCheckRollup"`
+	}
+	if err := json.Unmarshal(data, &view); err != nil {
+		return "", fmt.Errorf("parsing PR checks: %w", err)
+	}
+	state, _ := combineChecks(view.Checks)
+	return state, nil
+}
+
+// combineChecks returns the combined state of checks and the names of the
+// ones that failed. Any failure fails the whole.
+func combineChecks(checks []rollupCheck) (string, []string) {
+	pending := false
+	var failed []string
+	for _, c := range checks {
+		result := c.Conclusion
+		if c.State != "" {
+			result = c.State
+		} else if c.Status != "COMPLETED" {
+			pending = true
+			continue
+		}
+		switch result {
+		case "SUCCESS", "NEUTRAL", "SKIPPED":
+		case "PENDING", "EXPECTED":
+			pending = true
+		default:
+			name := c.Name
+			if name == "" {
+				name = c.Context
+			}
+			failed = append(failed, name)
+		}
+	}
+	switch {
+	case len(failed) > 0:
+		return ChecksFailed, failed
+	case pending:
+		return ChecksPending, nil
+	}
+	return ChecksPassed, nil
+}
@@ -0,0 +1,62 @@
+package merge
+
+import "testing"
+
+func TestChecksState(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want string
+	}{
+		{"no checks", `{"statusCheckRollup":[]}`, ChecksPassed},
+		{"all green", `{"statusCheckRollup":[
+			{"__typename":"CheckRun","status":"COMPLETED","conclusion":"SUCCESS"},
+			{"__typename":"CheckRun","status":"COMPLETED","conclusion":"SKIPPED"},
+			{"__typename":"StatusContext","state":"SUCCESS"}]}`, ChecksPassed},
+		{"run in progress", `{"statusCheckRollup":[
+			{"__typename":"CheckRun","status":"COMPLETED","conclusion":"SUCCESS"},
+			{"__typename":"CheckRun","status":"IN_PROGRESS","conclusion":""}]}`, ChecksPending},
+		{"status pending", `{"statusCheckRollup":[{"__typename":"StatusContext","state":"PENDING"}]}`, ChecksPending},
+		{"failure beats pending", `{"statusCheckRollup":[
+			{"__typename":"CheckRun","status":"QUEUED","conclusion":""},
+			{"__typename":"CheckRun","status":"COMPLETED","conclusion":"FAILURE"}]}`, ChecksFailed},
+		{"status error", `{"statusCheckRollup":[{"__typename":"StatusContext","state":"ERROR"}]}`, ChecksFailed},
+	}
+	for _, tt := range tests {
+		got, err := checksState([]byte(tt.json))
+		if err != nil {
+			t.Fatalf("%s: checksState() error: %v", tt.name, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: checksState() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	if _, err := checksState([]byte("not json")); err == nil {
+		t.Error("checksState() should fail on invalid JSON")
+	}
+}
+
+func TestParsePRStatus(t *testing.T) {
+	status, err := parsePRStatus([]byte(`{"state":"OPEN","mergeCommit":null,"statusCheckRollup":[
+		{"__typename":"CheckRun","name":"build","status":"COMPLETED","conclusion":"SUCCESS"},
+		{"__typename":"CheckRun","name":"test","status":"COMPLETED","conclusion":"FAILURE"},
+		{"__typename":"StatusContext","context":"ci/lint","state":"ERROR"}]}`))
+	if err != nil {
+		t.Fatalf("parsePRStatus() error: %v", err)
+	}
+	if status.State != "OPEN" || status.MergeCommit != "" || status.Checks != ChecksFailed {
+		t.Errorf("parsePRStatus() = %+v", status)
+	}
+	if len(status.Failed) != 2 || status.Failed[0] != "test" || status.Failed[1] != "ci/lint" {
+		t.Errorf("Failed = %v, want [test ci/lint]", status.Failed)
+	}
+
+	status, err = parsePRStatus([]byte(`{"state":"MERGED","mergeCommit":{"oid":"abc123"},"statusCheckRollup":[]}`))
+	if err != nil {
+		t.Fatalf("parsePRStatus() error: %v", err)
+	}
+	if status.State != "MERGED" || status.MergeCommit != "abc123" || status.Checks != ChecksPassed {
+		t.Errorf("parsePRStatus() = %+v", status)
+	}
+}
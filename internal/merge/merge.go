@@ -2,8 +2,9 @@ package merge
 
 import (
 	"fmt"
-	"os/exec"
 	"strings"
+
+	"github.com/badri/wt/internal/logging"
 )
 
 // Mode represents the merge mode for a project
@@ -15,68 +16,98 @@ const (
 	ModePRReview Mode = "pr-review"
 )
 
-// DirectMerge merges the branch directly to the default branch and pushes
-func DirectMerge(worktreePath, branch, defaultBranch string) error {
+// DirectMerge merges the branch directly to the default branch and pushes.
+// Returns the SHA of the merge commit.
+func DirectMerge(worktreePath, branch, defaultBranch string) (string, error) {
 	// Get the main repo path from the worktree
 	repoPath, err := getMainRepoPath(worktreePath)
 	if err != nil {
-		return fmt.Errorf("getting main repo: %w", err)
+		return "", fmt.Errorf("getting main repo: %w", err)
 	}
 
 	// First push the branch to remote
 	if err := pushBranch(worktreePath, branch); err != nil {
-		return fmt.Errorf("pushing branch: %w", err)
+		return "", fmt.Errorf("pushing branch: %w", err)
 	}
 
 	// Checkout default branch in main repo
-	cmd := exec.Command("git", "-C", repoPath, "checkout", defaultBranch)
+	cmd := logging.Command("git", "-C", repoPath, "checkout", defaultBranch)
 	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("checking out %s: %s: %w", defaultBranch, string(output), err)
+		return "", fmt.Errorf("checking out %s: %s: %w", defaultBranch, string(output), err)
 	}
 
 	// Pull latest
-	cmd = exec.Command("git", "-C", repoPath, "pull", "--ff-only")
+	cmd = logging.Command("git", "-C", repoPath, "pull", "--ff-only")
 	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("pulling %s: %s: %w", defaultBranch, string(output), err)
+		return "", fmt.Errorf("pulling %s: %s: %w", defaultBranch, string(output), err)
 	}
 
-	// Merge the branch
-	cmd = exec.Command("git", "-C", repoPath, "merge", "--no-ff", branch, "-m", fmt.Sprintf("Merge branch '%s'", branch))
+	// Merge the branch, signing the merge commit like the worktree's commits
+	args := append(signingArgs(worktreePath), "-C", repoPath, "merge", "--no-ff", branch, "-m", fmt.Sprintf("Merge branch '%s'", branch))
+	cmd = logging.Command("git", args...)
 	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("merging %s: %s: %w", branch, string(output), err)
+		return "", fmt.Errorf("merging %s: %s: %w", branch, string(output), err)
+	}
+
+	mergeCommit, err := revParse(repoPath, "HEAD")
+	if err != nil {
+		return "", err
 	}
 
 	// Push
-	cmd = exec.Command("git", "-C", repoPath, "push")
+	cmd = logging.Command("git", "-C", repoPath, "push")
 	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("pushing: %s: %w", string(output), err)
+		return "", fmt.Errorf("pushing: %s: %w", string(output), err)
 	}
 
 	// Delete the remote branch
-	cmd = exec.Command("git", "-C", repoPath, "push", "origin", "--delete", branch)
+	cmd = logging.Command("git", "-C", repoPath, "push", "origin", "--delete", branch)
 	_ = cmd.Run() // Ignore errors, branch might not exist on remote
 
 	// Delete the local branch
-	cmd = exec.Command("git", "-C", repoPath, "branch", "-d", branch)
+	cmd = logging.Command("git", "-C", repoPath, "branch", "-d", branch)
 	_ = cmd.Run() // Ignore errors
 
-	return nil
+	return mergeCommit, nil
 }
 
 // CreatePR creates a pull request using gh CLI
 func CreatePR(worktreePath, branch, defaultBranch, title string) (string, error) {
+	return CreatePRWithBody(worktreePath, branch, defaultBranch, title, fmt.Sprintf("Closes bead: %s", branch))
+}
+
+// CreatePRWithBody creates a pull request with a custom description
+func CreatePRWithBody(worktreePath, branch, defaultBranch, title, body string) (string, error) {
+	return createPR(worktreePath, branch, defaultBranch, title, body, false)
+}
+
+// CreateDraftPR creates a draft pull request, which doesn't request reviews
+// until it is marked ready. An existing PR for the branch is returned as is.
+func CreateDraftPR(worktreePath, branch, defaultBranch, title string) (string, error) {
+	return CreateDraftPRWithBody(worktreePath, branch, defaultBranch, title, fmt.Sprintf("Closes bead: %s", branch))
+}
+
+// CreateDraftPRWithBody creates a draft pull request with a custom description
+func CreateDraftPRWithBody(worktreePath, branch, defaultBranch, title, body string) (string, error) {
+	return createPR(worktreePath, branch, defaultBranch, title, body, true)
+}
+
+func createPR(worktreePath, branch, defaultBranch, title, body string, draft bool) (string, error) {
 	// Push the branch first
 	if err := pushBranch(worktreePath, branch); err != nil {
 		return "", fmt.Errorf("pushing branch: %w", err)
 	}
 
 	// Create PR using gh
-	cmd := exec.Command("gh", "pr", "create",
+	args := []string{"pr", "create",
 		"--base", defaultBranch,
 		"--head", branch,
 		"--title", title,
-		"--body", fmt.Sprintf("Closes bead: %s", branch))
-	cmd.Dir = worktreePath
+		"--body", body}
+	if draft {
+		args = append(args, "--draft")
+	}
+	cmd := logging.CommandIn(worktreePath, "gh", args...)
 
 	output, err := cmd.CombinedOutput()
 	if err != nil {
@@ -94,8 +125,7 @@ func CreatePR(worktreePath, branch, defaultBranch, title string) (string, error)
 
 // EnableAutoMerge enables auto-merge on a PR
 func EnableAutoMerge(worktreePath, prURL string) error {
-	cmd := exec.Command("gh", "pr", "merge", prURL, "--auto", "--merge")
-	cmd.Dir = worktreePath
+	cmd := logging.CommandIn(worktreePath, "gh", "pr", "merge", prURL, "--auto", "--merge")
 
 	output, err := cmd.CombinedOutput()
 	if err != nil {
@@ -105,9 +135,33 @@ func EnableAutoMerge(worktreePath, prURL string) error {
 	return nil
 }
 
+// MarkPRReady takes a draft PR out of draft, requesting reviews
+func MarkPRReady(dir, pr string) error {
+	cmd := logging.CommandIn(dir, "gh", "pr", "ready", pr)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("marking PR ready: %s: %w", strings.TrimSpace(string(output)), err)
+	}
+
+	return nil
+}
+
+// RetargetPR changes the base branch of an existing PR
+func RetargetPR(worktreePath, pr, baseBranch string) error {
+	cmd := logging.CommandIn(worktreePath, "gh", "pr", "edit", pr, "--base", baseBranch)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("retargeting PR: %s: %w", strings.TrimSpace(string(output)), err)
+	}
+
+	return nil
+}
+
 // HasUncommittedChanges checks if the worktree has uncommitted changes
 func HasUncommittedChanges(worktreePath string) (bool, error) {
-	cmd := exec.Command("git", "-C", worktreePath, "status", "--porcelain")
+	cmd := logging.Command("git", "-C", worktreePath, "status", "--porcelain")
 	output, err := cmd.Output()
 	if err != nil {
 		return false, fmt.Errorf("checking git status: %w", err)
@@ -117,7 +171,7 @@ func HasUncommittedChanges(worktreePath string) (bool, error) {
 
 // GetCurrentBranch returns the current branch name
 func GetCurrentBranch(worktreePath string) (string, error) {
-	cmd := exec.Command("git", "-C", worktreePath, "rev-parse", "--abbrev-ref", "HEAD")
+	cmd := logging.Command("git", "-C", worktreePath, "rev-parse", "--abbrev-ref", "HEAD")
 	output, err := cmd.Output()
 	if err != nil {
 		return "", fmt.Errorf("getting current branch: %w", err)
@@ -126,7 +180,7 @@ func GetCurrentBranch(worktreePath string) (string, error) {
 }
 
 func pushBranch(worktreePath, branch string) error {
-	cmd := exec.Command("git", "-C", worktreePath, "push", "-u", "origin", branch)
+	cmd := logging.Command("git", "-C", worktreePath, "push", "-u", "origin", branch)
 	if output, err := cmd.CombinedOutput(); err != nil {
 		return fmt.Errorf("%s: %w", string(output), err)
 	}
@@ -134,7 +188,7 @@ func pushBranch(worktreePath, branch string) error {
 }
 
 func getMainRepoPath(worktreePath string) (string, error) {
-	cmd := exec.Command("git", "-C", worktreePath, "rev-parse", "--path-format=absolute", "--git-common-dir")
+	cmd := logging.Command("git", "-C", worktreePath, "rev-parse", "--path-format=absolute", "--git-common-dir")
 	output, err := cmd.Output()
 	if err != nil {
 		return "", err
@@ -149,8 +203,7 @@ func getMainRepoPath(worktreePath string) (string, error) {
 }
 
 func getExistingPRURL(worktreePath, branch string) (string, error) {
-	cmd := exec.Command("gh", "pr", "view", branch, "--json", "url", "-q", ".url")
-	cmd.Dir = worktreePath
+	cmd := logging.CommandIn(worktreePath, "gh", "pr", "view", branch, "--json", "url", "-q", ".url")
 	output, err := cmd.Output()
 	if err != nil {
 		return "", fmt.Errorf("getting existing PR: %w", err)
@@ -174,7 +227,7 @@ type ConflictInfo struct {
 
 // FetchMain fetches the latest changes from origin for the default branch
 func FetchMain(worktreePath, defaultBranch string) error {
-	cmd := exec.Command("git", "-C", worktreePath, "fetch", "origin", defaultBranch)
+	cmd := logging.Command("git", "-C", worktreePath, "fetch", "origin", defaultBranch)
 	if output, err := cmd.CombinedOutput(); err != nil {
 		return fmt.Errorf("fetching %s: %s: %w", defaultBranch, string(output), err)
 	}
@@ -184,7 +237,7 @@ func FetchMain(worktreePath, defaultBranch string) error {
 // CommitsBehind returns the number of commits the current branch is behind the default branch
 func CommitsBehind(worktreePath, defaultBranch string) (int, error) {
 	// Count commits that are in origin/defaultBranch but not in HEAD
-	cmd := exec.Command("git", "-C", worktreePath, "rev-list", "--count", "HEAD..origin/"+defaultBranch)
+	cmd := logging.Command("git", "-C", worktreePath, "rev-list", "--count", "HEAD..origin/"+defaultBranch)
 	output, err := cmd.Output()
 	if err != nil {
 		return 0, fmt.Errorf("counting commits behind: %w", err)
@@ -202,7 +255,7 @@ func CommitsBehind(worktreePath, defaultBranch string) (int, error) {
 // Returns a RebaseResult indicating success or conflict status
 func RebaseOnMain(worktreePath, defaultBranch string) (*RebaseResult, error) {
 	// Attempt rebase
-	cmd := exec.Command("git", "-C", worktreePath, "rebase", "origin/"+defaultBranch)
+	cmd := logging.Command("git", "-C", worktreePath, "rebase", "origin/"+defaultBranch)
 	output, err := cmd.CombinedOutput()
 
 	if err != nil {
@@ -224,9 +277,61 @@ func RebaseOnMain(worktreePath, defaultBranch string) (*RebaseResult, error) {
 	}, nil
 }
 
+// RebaseOnto moves the commits the current branch has on top of oldBase
+// onto newBase, for a branch that was started from the wrong base branch.
+// Both bases are compared on origin.
+func RebaseOnto(worktreePath, newBase, oldBase string) (*RebaseResult, error) {
+	cmd := logging.Command("git", "-C", worktreePath, "rebase", "--onto", "origin/"+newBase, "origin/"+oldBase)
+	output, err := cmd.CombinedOutput()
+
+	if err != nil {
+		if strings.Contains(string(output), "CONFLICT") || strings.Contains(string(output), "could not apply") {
+			conflictedFiles, _ := GetConflictedFiles(worktreePath)
+			return &RebaseResult{
+				Success:         false,
+				HasConflicts:    true,
+				ConflictedFiles: conflictedFiles,
+			}, nil
+		}
+		return nil, fmt.Errorf("rebasing onto %s: %s: %w", newBase, string(output), err)
+	}
+
+	return &RebaseResult{Success: true}, nil
+}
+
+// MergeMain merges the default branch into the current branch, the
+// alternative to RebaseOnMain for branches whose history must not change
+func MergeMain(worktreePath, defaultBranch string) (*RebaseResult, error) {
+	cmd := logging.Command("git", "-C", worktreePath, "merge", "--no-edit", "origin/"+defaultBranch)
+	output, err := cmd.CombinedOutput()
+
+	if err != nil {
+		if strings.Contains(string(output), "CONFLICT") {
+			conflictedFiles, _ := GetConflictedFiles(worktreePath)
+			return &RebaseResult{
+				Success:         false,
+				HasConflicts:    true,
+				ConflictedFiles: conflictedFiles,
+			}, nil
+		}
+		return nil, fmt.Errorf("merging %s: %s: %w", defaultBranch, string(output), err)
+	}
+
+	return &RebaseResult{Success: true}, nil
+}
+
+// AbortMerge aborts an in-progress merge
+func AbortMerge(worktreePath string) error {
+	cmd := logging.Command("git", "-C", worktreePath, "merge", "--abort")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("aborting merge: %s: %w", string(output), err)
+	}
+	return nil
+}
+
 // GetConflictedFiles returns the list of files with merge conflicts
 func GetConflictedFiles(worktreePath string) ([]string, error) {
-	cmd := exec.Command("git", "-C", worktreePath, "diff", "--name-only", "--diff-filter=U")
+	cmd := logging.Command("git", "-C", worktreePath, "diff", "--name-only", "--diff-filter=U")
 	output, err := cmd.Output()
 	if err != nil {
 		return nil, fmt.Errorf("getting conflicted files: %w", err)
@@ -241,7 +346,7 @@ func GetConflictedFiles(worktreePath string) ([]string, error) {
 
 // IsRebaseInProgress checks if a rebase is currently in progress
 func IsRebaseInProgress(worktreePath string) bool {
-	cmd := exec.Command("git", "-C", worktreePath, "rev-parse", "--git-path", "rebase-merge")
+	cmd := logging.Command("git", "-C", worktreePath, "rev-parse", "--git-path", "rebase-merge")
 	output, err := cmd.Output()
 	if err != nil {
 		return false
@@ -249,25 +354,25 @@ func IsRebaseInProgress(worktreePath string) bool {
 
 	// Check if the rebase-merge directory exists
 	rebasePath := strings.TrimSpace(string(output))
-	cmd = exec.Command("test", "-d", rebasePath)
+	cmd = logging.Command("test", "-d", rebasePath)
 	if err := cmd.Run(); err == nil {
 		return true
 	}
 
 	// Also check rebase-apply for older git versions
-	cmd = exec.Command("git", "-C", worktreePath, "rev-parse", "--git-path", "rebase-apply")
+	cmd = logging.Command("git", "-C", worktreePath, "rev-parse", "--git-path", "rebase-apply")
 	output, err = cmd.Output()
 	if err != nil {
 		return false
 	}
 	rebasePath = strings.TrimSpace(string(output))
-	cmd = exec.Command("test", "-d", rebasePath)
+	cmd = logging.Command("test", "-d", rebasePath)
 	return cmd.Run() == nil
 }
 
 // ContinueRebase continues a rebase after conflicts have been resolved
 func ContinueRebase(worktreePath string) error {
-	cmd := exec.Command("git", "-C", worktreePath, "rebase", "--continue")
+	cmd := logging.Command("git", "-C", worktreePath, "rebase", "--continue")
 	cmd.Env = append(cmd.Env, "GIT_EDITOR=true") // Skip commit message editor
 	if output, err := cmd.CombinedOutput(); err != nil {
 		return fmt.Errorf("continuing rebase: %s: %w", string(output), err)
@@ -277,7 +382,7 @@ func ContinueRebase(worktreePath string) error {
 
 // AbortRebase aborts an in-progress rebase
 func AbortRebase(worktreePath string) error {
-	cmd := exec.Command("git", "-C", worktreePath, "rebase", "--abort")
+	cmd := logging.Command("git", "-C", worktreePath, "rebase", "--abort")
 	if output, err := cmd.CombinedOutput(); err != nil {
 		return fmt.Errorf("aborting rebase: %s: %w", string(output), err)
 	}
@@ -286,7 +391,7 @@ func AbortRebase(worktreePath string) error {
 
 // GetConflictMarkers reads a conflicted file and extracts the conflict markers
 func GetConflictMarkers(worktreePath, filePath string) ([]ConflictInfo, error) {
-	cmd := exec.Command("git", "-C", worktreePath, "diff", "--", filePath)
+	cmd := logging.Command("git", "-C", worktreePath, "diff", "--", filePath)
 	output, err := cmd.Output()
 	if err != nil {
 		return nil, fmt.Errorf("getting diff: %w", err)
@@ -294,7 +399,7 @@ func GetConflictMarkers(worktreePath, filePath string) ([]ConflictInfo, error) {
 
 	// For a more detailed view, read the file directly to see conflict markers
 	fullPath := worktreePath + "/" + filePath
-	cmd = exec.Command("cat", fullPath)
+	cmd = logging.Command("cat", fullPath)
 	content, err := cmd.Output()
 	if err != nil {
 		return nil, fmt.Errorf("reading file: %w", err)
@@ -346,7 +451,7 @@ func GetConflictMarkers(worktreePath, filePath string) ([]ConflictInfo, error) {
 
 // StageResolvedFile stages a file after conflict resolution
 func StageResolvedFile(worktreePath, filePath string) error {
-	cmd := exec.Command("git", "-C", worktreePath, "add", filePath)
+	cmd := logging.Command("git", "-C", worktreePath, "add", filePath)
 	if output, err := cmd.CombinedOutput(); err != nil {
 		return fmt.Errorf("staging file: %s: %w", string(output), err)
 	}
@@ -361,7 +466,7 @@ func GetBranchStatus(worktreePath, defaultBranch string) (string, error) {
 	}
 
 	// Get ahead/behind counts
-	cmd := exec.Command("git", "-C", worktreePath, "rev-list", "--left-right", "--count", "HEAD...origin/"+defaultBranch)
+	cmd := logging.Command("git", "-C", worktreePath, "rev-list", "--left-right", "--count", "HEAD...origin/"+defaultBranch)
 	output, err := cmd.Output()
 	if err != nil {
 		return "", fmt.Errorf("getting branch status: %w", err)
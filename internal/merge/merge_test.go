@@ -4,6 +4,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -225,3 +226,46 @@ func TestModeConstants(t *testing.T) {
 		t.Errorf("expected ModePRReview to be 'pr-review', got %q", ModePRReview)
 	}
 }
+
+func TestRebaseOnto(t *testing.T) {
+	repoDir := initTestRepo(t)
+	git := func(args ...string) string {
+		t.Helper()
+		out, err := exec.Command("git", append([]string{"-C", repoDir}, args...)...).CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %s: %v", args, out, err)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	commit := func(file string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repoDir, file), []byte(file+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		git("add", file)
+		git("commit", "-m", "add "+file)
+	}
+
+	// Two release branches off the initial commit, each with its own
+	// commit, and a session branch started from the wrong one
+	git("branch", "-M", "main")
+	git("checkout", "-b", "release-1")
+	commit("release1.txt")
+	git("update-ref", "refs/remotes/origin/release-1", "HEAD")
+	git("checkout", "-b", "release-2", "main")
+	commit("release2.txt")
+	git("update-ref", "refs/remotes/origin/release-2", "HEAD")
+	git("checkout", "-b", "fix", "release-1")
+	commit("fix.txt")
+
+	result, err := RebaseOnto(repoDir, "release-2", "release-1")
+	if err != nil || !result.Success {
+		t.Fatalf("RebaseOnto() = %+v, %v; want success", result, err)
+	}
+	if got := git("log", "--format=%s", "origin/release-2..HEAD"); got != "add fix.txt" {
+		t.Errorf("commits on top of release-2 = %q, want only the session's commit", got)
+	}
+	if _, err := os.Stat(filepath.Join(repoDir, "release1.txt")); !os.IsNotExist(err) {
+		t.Error("release-1's commit was carried over")
+	}
+}
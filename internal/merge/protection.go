@@ -0,0 +1,175 @@
+package merge
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"github.com/badri/wt/internal/logging"
+)
+
+// DirectPushBlockers returns why GitHub would reject a direct push to
+// branch: rulesets and classic branch protection requiring pull requests
+// or status checks, or restricting updates. None means a direct merge's
+// push should go through. dir is any checkout of the repository, which gh
+// resolves to owner/repo.
+func DirectPushBlockers(dir, branch string) ([]string, error) {
+	path := "repos/{owner}/{repo}/"
+	escaped := url.PathEscape(branch)
+
+	rules, err := ghAPI(dir, path+"rules/branches/"+escaped)
+	if err != nil {
+		return nil, fmt.Errorf("reading rulesets of %s: %w", branch, err)
+	}
+	blockers, err := rulesetBlockers(rules)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := ghAPI(dir, path+"branches/"+escaped)
+	if err != nil {
+		return nil, fmt.Errorf("reading branch %s: %w", branch, err)
+	}
+	var b struct {
+		Protected bool `json:"protected"`
+	}
+	if err := json.Unmarshal(info, &b); err != nil {
+		return nil, fmt.Errorf("parsing branch %s: %w", branch, err)
+	}
+	if !b.Protected {
+		return blockers, nil
+	}
+
+	// The full protection settings are only readable by admins; others
+	// see the required status checks on the branch itself
+	var classic []string
+	if protection, err := ghAPI(dir, path+"branches/"+escaped+"/protection"); err == nil {
+		classic, err = protectionBlockers(protection)
+		if err != nil {
+			return nil, err
+		}
+	} else if classic, err = branchCheckBlockers(info); err != nil {
+		return nil, err
+	}
+	for _, c := range classic {
+		if !slices.Contains(blockers, c) {
+			blockers = append(blockers, c)
+		}
+	}
+	return blockers, nil
+}
+
+// Reasons a direct push is rejected
+const (
+	blockPullRequest  = "changes must go through a pull request"
+	blockStatusChecks = "required status checks must pass first"
+	blockUpdate       = "updates are restricted"
+	blockMergeQueue   = "changes must go through the merge queue"
+	blockDeployments  = "required deployments must succeed first"
+)
+
+// rulesetBlockers reads the rules of GET repos/{owner}/{repo}/rules/branches/{branch}
+func rulesetBlockers(data []byte) ([]string, error) {
+	var rules []struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("parsing rulesets: %w", err)
+	}
+	var blockers []string
+	for _, r := range rules {
+		reason := ""
+		switch r.Type {
+		case "pull_request":
+			reason = blockPullRequest
+		case "required_status_checks":
+			reason = blockStatusChecks
+		case "update":
+			reason = blockUpdate
+		case "merge_queue":
+			reason = blockMergeQueue
+		case "required_deployments":
+			reason = blockDeployments
+		}
+		if reason != "" && !slices.Contains(blockers, reason) {
+			blockers = append(blockers, reason)
+		}
+	}
+	return blockers, nil
+}
+
+// protectionBlockers reads GET repos/{owner}/{repo}/branches/{branch}/protection.
+// Admins may push past protection that isn't enforced for them, and only
+// admins can read it, so it blocks only with enforce_admins on.
+func protectionBlockers(data []byte) ([]string, error) {
+	var p struct {
+		RequiredPullRequestReviews *struct{} `json:"required_pull_request_reviews"`
+		RequiredStatusChecks       *struct {
+			Contexts []string `json:"contexts"`
+			Checks   []struct {
+				Context string `json:"context"`
+			} `json:"checks"`
+		} `json:"required_status_checks"`
+		EnforceAdmins *struct {
+			Enabled bool `json:"enabled"`
+		} `json:"enforce_admins"`
+		LockBranch *struct {
+			Enabled bool `json:"enabled"`
+		} `json:"lock_branch"`
+	}
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("parsing branch protection: %w", err)
+	}
+	if p.EnforceAdmins == nil || !p.EnforceAdmins.Enabled {
+		return nil, nil
+	}
+	var blockers []string
+	if p.RequiredPullRequestReviews != nil {
+		blockers = append(blockers, blockPullRequest)
+	}
+	if c := p.RequiredStatusChecks; c != nil && (len(c.Contexts) > 0 || len(c.Checks) > 0) {
+		blockers = append(blockers, blockStatusChecks)
+	}
+	if p.LockBranch != nil && p.LockBranch.Enabled {
+		blockers = append(blockers, blockUpdate)
+	}
+	return blockers, nil
+}
+
+// branchCheckBlockers reads the status checks GET
+// repos/{owner}/{repo}/branches/{branch} shows of a protected branch
+func branchCheckBlockers(data []byte) ([]string, error) {
+	var b struct {
+		Protection struct {
+			RequiredStatusChecks struct {
+				EnforcementLevel string   `json:"enforcement_level"`
+				Contexts         []string `json:"contexts"`
+			} `json:"required_status_checks"`
+		} `json:"protection"`
+	}
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("parsing branch: %w", err)
+	}
+	checks := b.Protection.RequiredStatusChecks
+	// Whoever can't read the protection isn't an admin, so "non_admins"
+	// applies to them too
+	if checks.EnforcementLevel != "" && checks.EnforcementLevel != "off" && len(checks.Contexts) > 0 {
+		return []string{blockStatusChecks}, nil
+	}
+	return nil, nil
+}
+
+func ghAPI(dir, endpoint string) ([]byte, error) {
+	cmd := logging.CommandIn(dir, "gh", "api", endpoint)
+	output, err := cmd.Output()
+	if err != nil {
+		if ee, ok := err.(*exec.ExitError); ok && len(ee.Stderr) > 0 {
+			return nil, fmt.Errorf("%s", strings.TrimSpace(string(ee.Stderr)))
+		}
+		return nil, err
+	}
+	return output, nil
+}
@@ -0,0 +1,66 @@
+package merge
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRulesetBlockers(t *testing.T) {
+	data := []byte(`[
+		{"type":"deletion","ruleset_id":1},
+		{"type":"pull_request","parameters":{"required_approving_review_count":1},"ruleset_id":1},
+		{"type":"required_status_checks","ruleset_id":2},
+		{"type":"pull_request","ruleset_id":2}]`)
+	got, err := rulesetBlockers(data)
+	if err != nil {
+		t.Fatalf("rulesetBlockers() error: %v", err)
+	}
+	if want := []string{blockPullRequest, blockStatusChecks}; !reflect.DeepEqual(got, want) {
+		t.Errorf("rulesetBlockers() = %v, want %v", got, want)
+	}
+
+	if got, err := rulesetBlockers([]byte(`[{"type":"non_fast_forward"}]`)); err != nil || len(got) != 0 {
+		t.Errorf("rulesetBlockers(non_fast_forward) = %v, %v; want none", got, err)
+	}
+	if _, err := rulesetBlockers([]byte(`{"message":"Not Found"}`)); err == nil {
+		t.Error("rulesetBlockers() should fail on a non-list reply")
+	}
+}
+
+func TestProtectionBlockers(t *testing.T) {
+	enforced := []byte(`{
+		"required_status_checks":{"strict":true,"contexts":["ci/test"],"checks":[{"context":"ci/test"}]},
+		"required_pull_request_reviews":{"required_approving_review_count":1},
+		"enforce_admins":{"enabled":true},
+		"lock_branch":{"enabled":false}}`)
+	got, err := protectionBlockers(enforced)
+	if err != nil {
+		t.Fatalf("protectionBlockers() error: %v", err)
+	}
+	if want := []string{blockPullRequest, blockStatusChecks}; !reflect.DeepEqual(got, want) {
+		t.Errorf("protectionBlockers() = %v, want %v", got, want)
+	}
+
+	// Admins can push past protection that isn't enforced for them
+	bypass := []byte(`{"required_pull_request_reviews":{},"enforce_admins":{"enabled":false}}`)
+	if got, err := protectionBlockers(bypass); err != nil || len(got) != 0 {
+		t.Errorf("protectionBlockers(admin bypass) = %v, %v; want none", got, err)
+	}
+}
+
+func TestBranchCheckBlockers(t *testing.T) {
+	tests := []struct {
+		json string
+		want int
+	}{
+		{`{"protected":true,"protection":{"enabled":true,"required_status_checks":{"enforcement_level":"non_admins","contexts":["build"]}}}`, 1},
+		{`{"protected":true,"protection":{"enabled":true,"required_status_checks":{"enforcement_level":"everyone","contexts":[]}}}`, 0},
+		{`{"protected":true,"protection":{"enabled":true,"required_status_checks":{"enforcement_level":"off","contexts":["build"]}}}`, 0},
+	}
+	for _, tt := range tests {
+		got, err := branchCheckBlockers([]byte(tt.json))
+		if err != nil || len(got) != tt.want {
+			t.Errorf("branchCheckBlockers(%s) = %v, %v; want %d blocker(s)", tt.json, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package merge
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/badri/wt/internal/logging"
+)
+
+// RequestReviewers asks users or teams ("org/team") to review a PR. The PR
+// author is left out, since GitHub rejects review requests to the author.
+// Returns the reviewers actually requested.
+func RequestReviewers(worktreePath, prURL string, reviewers []string) ([]string, error) {
+	author := prAuthor(worktreePath, prURL)
+	var requested []string
+	for _, r := range reviewers {
+		if !strings.EqualFold(r, author) {
+			requested = append(requested, r)
+		}
+	}
+	if len(requested) == 0 {
+		return nil, nil
+	}
+
+	cmd := logging.CommandIn(worktreePath, "gh", "pr", "edit", prURL, "--add-reviewer", strings.Join(requested, ","))
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("requesting reviewers: %s: %w", strings.TrimSpace(string(output)), err)
+	}
+	return requested, nil
+}
+
+// prAuthor returns the login of a PR's author, or "" if unknown
+func prAuthor(worktreePath, prURL string) string {
+	cmd := logging.CommandIn(worktreePath, "gh", "pr", "view", prURL, "--json", "author", "-q", ".author.login")
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
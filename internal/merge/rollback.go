@@ -0,0 +1,127 @@
+package merge
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/badri/wt/internal/logging"
+)
+
+// RevertMerge reverts a merge commit on the default branch of the main repo
+// and pushes. Returns the SHA of the revert commit.
+func RevertMerge(repoPath, mergeCommit, defaultBranch string) (string, error) {
+	cmd := logging.Command("git", "-C", repoPath, "checkout", defaultBranch)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("checking out %s: %s: %w", defaultBranch, string(output), err)
+	}
+
+	cmd = logging.Command("git", "-C", repoPath, "pull", "--ff-only")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("pulling %s: %s: %w", defaultBranch, string(output), err)
+	}
+
+	revertCommit, err := revertMergeCommit(repoPath, mergeCommit)
+	if err != nil {
+		return "", err
+	}
+
+	cmd = logging.Command("git", "-C", repoPath, "push")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("pushing: %s: %w", string(output), err)
+	}
+	return revertCommit, nil
+}
+
+// revertMergeCommit reverts a merge commit against its first parent (the
+// branch it was merged into) on the checked-out branch. A conflicting revert
+// is aborted, leaving the repo as it was.
+func revertMergeCommit(repoPath, mergeCommit string) (string, error) {
+	cmd := logging.Command("git", "-C", repoPath, "revert", "--no-edit", "-m", "1", mergeCommit)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		_ = logging.Command("git", "-C", repoPath, "revert", "--abort").Run()
+		return "", fmt.Errorf("reverting %s: %s: %w", mergeCommit, string(output), err)
+	}
+	return revParse(repoPath, "HEAD")
+}
+
+// ReapplyOnBranch creates branch at base with the revert commit undone, so
+// the rolled-back work can be fixed and merged again. (The original branch
+// can't be re-merged: its commits are already in the history the revert
+// undid.) The branch is built in a temporary worktree that is removed after.
+func ReapplyOnBranch(repoPath, branch, base, revertCommit string) error {
+	tmpDir, err := os.MkdirTemp("", "wt-reapply-")
+	if err != nil {
+		return fmt.Errorf("creating temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	cmd := logging.Command("git", "-C", repoPath, "worktree", "add", "-b", branch, tmpDir, base)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git worktree add: %s: %w", string(output), err)
+	}
+	defer logging.Command("git", "-C", repoPath, "worktree", "remove", "--force", tmpDir).Run()
+
+	cmd = logging.Command("git", "-C", tmpDir, "revert", "--no-edit", revertCommit)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		_ = logging.Command("git", "-C", tmpDir, "revert", "--abort").Run()
+		return fmt.Errorf("reapplying %s: %s: %w", revertCommit, string(output), err)
+	}
+	return nil
+}
+
+// revParse resolves a revision to its full SHA
+func revParse(repoPath, rev string) (string, error) {
+	cmd := logging.Command("git", "-C", repoPath, "rev-parse", rev)
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("resolving %s: %w", rev, err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// OpenRevertPR opens a PR that reverts commit on the default branch, for
+// when a merge broke it and reverting directly isn't wanted. The revert is
+// built on branch in a temporary worktree of repoPath. Returns the PR URL.
+func OpenRevertPR(repoPath, commit, defaultBranch, branch, title, body string) (string, error) {
+	tmpDir, err := os.MkdirTemp("", "wt-revert-")
+	if err != nil {
+		return "", fmt.Errorf("creating temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	base := defaultBranch
+	if err := logging.Command("git", "-C", repoPath, "fetch", "origin", defaultBranch).Run(); err == nil {
+		base = "origin/" + defaultBranch
+	}
+	cmd := logging.Command("git", "-C", repoPath, "worktree", "add", "-b", branch, tmpDir, base)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("git worktree add: %s: %w", string(output), err)
+	}
+	defer logging.Command("git", "-C", repoPath, "worktree", "remove", "--force", tmpDir).Run()
+
+	// Merge commits are reverted against their first parent; squashed and
+	// rebased merges are plain commits
+	args := []string{"-C", tmpDir, "revert", "--no-edit"}
+	if parents, err := revParse(tmpDir, commit+"^2"); err == nil && parents != "" {
+		args = append(args, "-m", "1")
+	}
+	cmd = logging.Command("git", append(args, commit)...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		_ = logging.Command("git", "-C", tmpDir, "revert", "--abort").Run()
+		return "", fmt.Errorf("reverting %s: %s: %w", commit, string(output), err)
+	}
+
+	return CreatePRWithBody(tmpDir, branch, defaultBranch, title, body)
+}
+
+// PRMergeCommit returns the merge commit of a merged PR, or "" if it has
+// not merged
+func PRMergeCommit(dir, pr string) (string, error) {
+	cmd := logging.CommandIn(dir, "gh", "pr", "view", pr, "--json", "mergeCommit", "-q", ".mergeCommit.oid // \"\"")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("viewing PR %s: %w", pr, err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
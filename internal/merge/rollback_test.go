@@ -0,0 +1,107 @@
+package merge
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func gitRun(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v failed: %s: %v", args, output, err)
+	}
+}
+
+// mergeFeature merges a branch that adds feature.txt with --no-ff, as
+// DirectMerge does, and returns the merge commit
+func mergeFeature(t *testing.T, repoDir string) (string, string) {
+	t.Helper()
+	base, err := GetCurrentBranch(repoDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gitRun(t, repoDir, "checkout", "-b", "feature")
+	if err := os.WriteFile(filepath.Join(repoDir, "feature.txt"), []byte("feature\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitRun(t, repoDir, "add", ".")
+	gitRun(t, repoDir, "commit", "-m", "Add feature")
+	gitRun(t, repoDir, "checkout", base)
+	gitRun(t, repoDir, "merge", "--no-ff", "feature", "-m", "Merge branch 'feature'")
+	gitRun(t, repoDir, "branch", "-d", "feature")
+
+	mergeCommit, err := revParse(repoDir, "HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base, mergeCommit
+}
+
+func TestRevertMergeCommit(t *testing.T) {
+	repoDir := initTestRepo(t)
+	_, mergeCommit := mergeFeature(t, repoDir)
+
+	revertCommit, err := revertMergeCommit(repoDir, mergeCommit)
+	if err != nil {
+		t.Fatalf("revertMergeCommit failed: %v", err)
+	}
+	if revertCommit == "" || revertCommit == mergeCommit {
+		t.Errorf("revertMergeCommit() = %q, want a new commit", revertCommit)
+	}
+	if _, err := os.Stat(filepath.Join(repoDir, "feature.txt")); !os.IsNotExist(err) {
+		t.Error("feature.txt should be gone after the revert")
+	}
+}
+
+func TestRevertMergeCommit_Conflict(t *testing.T) {
+	repoDir := initTestRepo(t)
+	_, mergeCommit := mergeFeature(t, repoDir)
+
+	// Later work on the merged file makes the revert conflict
+	if err := os.WriteFile(filepath.Join(repoDir, "feature.txt"), []byte("changed\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitRun(t, repoDir, "commit", "-am", "Change feature")
+
+	if _, err := revertMergeCommit(repoDir, mergeCommit); err == nil {
+		t.Fatal("conflicting revert should fail")
+	}
+	if hasChanges, _ := HasUncommittedChanges(repoDir); hasChanges {
+		t.Error("failed revert should be aborted, leaving the repo clean")
+	}
+}
+
+func TestReapplyOnBranch(t *testing.T) {
+	repoDir := initTestRepo(t)
+	base, mergeCommit := mergeFeature(t, repoDir)
+	revertCommit, err := revertMergeCommit(repoDir, mergeCommit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ReapplyOnBranch(repoDir, "feature-fix", revertCommit, revertCommit); err != nil {
+		t.Fatalf("ReapplyOnBranch failed: %v", err)
+	}
+
+	// The new branch has the work back; the default branch does not
+	cmd := exec.Command("git", "-C", repoDir, "cat-file", "-e", "feature-fix:feature.txt")
+	if err := cmd.Run(); err != nil {
+		t.Error("feature.txt should be restored on feature-fix")
+	}
+	if current, _ := GetCurrentBranch(repoDir); current != base {
+		t.Errorf("current branch = %q, want %q", current, base)
+	}
+	if _, err := os.Stat(filepath.Join(repoDir, "feature.txt")); !os.IsNotExist(err) {
+		t.Error("default branch checkout should not have feature.txt")
+	}
+
+	// The temporary worktree is cleaned up
+	output, _ := exec.Command("git", "-C", repoDir, "worktree", "list").Output()
+	if lines := strings.Count(strings.TrimSpace(string(output)), "\n") + 1; lines != 1 {
+		t.Errorf("expected only the main worktree, got:\n%s", output)
+	}
+}
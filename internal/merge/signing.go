@@ -0,0 +1,97 @@
+package merge
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/badri/wt/internal/logging"
+)
+
+// Signature states of a commit
+const (
+	SignatureGood       = "good"       // signed, and git verified the signature
+	SignatureUnverified = "unverified" // signed, but the key isn't known here
+	SignatureBad        = "bad"        // bad or revoked signature
+	SignatureMissing    = "missing"    // not signed
+)
+
+// CommitSignature is the signature state of one commit
+type CommitSignature struct {
+	SHA     string
+	Subject string
+	State   string
+}
+
+// CommitSignatures returns the signature state of the commits on HEAD that
+// aren't on base, oldest first
+func CommitSignatures(worktreePath, base string) ([]CommitSignature, error) {
+	cmd := logging.Command("git", "-C", worktreePath, "log", "--reverse", "--format=%H%x09%G?%x09%s", base+"..HEAD")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("listing commits since %s: %w", base, err)
+	}
+
+	var sigs []CommitSignature
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		parts := strings.SplitN(line, "\t", 3)
+		if len(parts) < 3 {
+			continue
+		}
+		sig := CommitSignature{SHA: parts[0], Subject: parts[2]}
+		sig.State = signatureState(parts[1], func() bool { return hasSignature(worktreePath, sig.SHA) })
+		sigs = append(sigs, sig)
+	}
+	return sigs, nil
+}
+
+// signatureState maps git's %G? code to a signature state. Git reports N
+// for SSH signatures when no allowed signers file is configured, so N only
+// means unsigned when the commit has no signature header.
+func signatureState(code string, signed func() bool) string {
+	switch code {
+	case "G", "U", "X", "Y":
+		return SignatureGood
+	case "B", "R":
+		return SignatureBad
+	case "E":
+		return SignatureUnverified
+	}
+	if signed() {
+		return SignatureUnverified
+	}
+	return SignatureMissing
+}
+
+// hasSignature reports whether a commit object carries a signature header
+func hasSignature(worktreePath, sha string) bool {
+	output, err := logging.Command("git", "-C", worktreePath, "cat-file", "commit", sha).Output()
+	if err != nil {
+		return false
+	}
+	header, _, _ := bytes.Cut(output, []byte("\n\n"))
+	return bytes.Contains(header, []byte("\ngpgsig"))
+}
+
+// signingArgs returns -c options that make a git command sign commits the
+// way the worktree does, or nil when the worktree doesn't sign
+func signingArgs(worktreePath string) []string {
+	if gitConfigValue(worktreePath, "commit.gpgsign") != "true" {
+		return nil
+	}
+	args := []string{"-c", "commit.gpgsign=true"}
+	for _, key := range []string{"gpg.format", "user.signingkey"} {
+		if v := gitConfigValue(worktreePath, key); v != "" {
+			args = append(args, "-c", key+"="+v)
+		}
+	}
+	return args
+}
+
+func gitConfigValue(dir, key string) string {
+	output, err := logging.Command("git", "-C", dir, "config", "--get", key).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
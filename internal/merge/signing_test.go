@@ -0,0 +1,90 @@
+package merge
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestSignatureState(t *testing.T) {
+	signed := func() bool { return true }
+	unsigned := func() bool { return false }
+	tests := []struct {
+		code   string
+		signed func() bool
+		want   string
+	}{
+		{"G", unsigned, SignatureGood},
+		{"U", unsigned, SignatureGood},
+		{"B", unsigned, SignatureBad},
+		{"R", unsigned, SignatureBad},
+		{"E", unsigned, SignatureUnverified},
+		{"N", signed, SignatureUnverified}, // SSH signature, no allowed signers file
+		{"N", unsigned, SignatureMissing},
+	}
+	for _, tt := range tests {
+		if got := signatureState(tt.code, tt.signed); got != tt.want {
+			t.Errorf("signatureState(%q) = %q, want %q", tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestCommitSignatures(t *testing.T) {
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen not installed")
+	}
+	keyDir := t.TempDir()
+	key := filepath.Join(keyDir, "id_ed25519")
+	if output, err := exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-f", key).CombinedOutput(); err != nil {
+		t.Fatalf("ssh-keygen: %s: %v", output, err)
+	}
+
+	repo := initTestRepo(t)
+	base, _ := GetCurrentBranch(repo)
+	gitRun(t, repo, "checkout", "-q", "-b", "feature")
+	gitRun(t, repo, "commit", "-q", "--allow-empty", "-m", "unsigned")
+	if got := signingArgs(repo); got != nil {
+		t.Errorf("signingArgs() without signing = %v, want nil", got)
+	}
+
+	gitRun(t, repo, "config", "gpg.format", "ssh")
+	gitRun(t, repo, "config", "user.signingkey", key+".pub")
+	gitRun(t, repo, "config", "commit.gpgsign", "true")
+	gitRun(t, repo, "commit", "-q", "--allow-empty", "-m", "signed")
+
+	states := func() []string {
+		t.Helper()
+		sigs, err := CommitSignatures(repo, base)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, s := range sigs {
+			got = append(got, s.Subject+":"+s.State)
+		}
+		return got
+	}
+	if got, want := states(), []string{"unsigned:missing", "signed:unverified"}; !slices.Equal(got, want) {
+		t.Errorf("CommitSignatures() = %v, want %v", got, want)
+	}
+
+	pub, err := os.ReadFile(key + ".pub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	allowed := filepath.Join(keyDir, "allowed_signers")
+	if err := os.WriteFile(allowed, append([]byte("test@test.com "), pub...), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitRun(t, repo, "config", "gpg.ssh.allowedSignersFile", allowed)
+	if got, want := states(), []string{"unsigned:missing", "signed:good"}; !slices.Equal(got, want) {
+		t.Errorf("CommitSignatures() with allowed signers = %v, want %v", got, want)
+	}
+
+	want := []string{"-c", "commit.gpgsign=true", "-c", "gpg.format=ssh", "-c", "user.signingkey=" + key + ".pub"}
+	if got := signingArgs(repo); !slices.Equal(got, want) {
+		t.Errorf("signingArgs() = %v, want %v", got, want)
+	}
+}
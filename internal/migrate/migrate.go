@@ -0,0 +1,138 @@
+// Package migrate upgrades the state wt keeps in its config directory when
+// its on-disk format changes between versions, so an upgrade never needs
+// hand-edited JSON. Every file a migration rewrites is backed up first.
+package migrate
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/badri/wt/internal/config"
+)
+
+// Version is the state format this wt writes. Add a migration and bump it
+// whenever a state file changes incompatibly.
+const Version = 3
+
+// versionFile records the state format of the config dir
+const versionFile = "state-version"
+
+// Change is one step of a migration: what it does and the files it rewrites
+type Change struct {
+	Version int      // migration the change belongs to
+	Desc    string   // e.g. "sessions.json: flatten the nested sessions map"
+	Files   []string // files backed up before apply
+	apply   func() error
+}
+
+type migration struct {
+	version int
+	plan    func(cfg *config.Config) ([]Change, error)
+}
+
+var migrations = []migration{
+	{1, planSessions},
+	{2, planAutoFiles},
+	{3, planEvents},
+}
+
+// StateVersion returns the state format recorded in the config dir, 0 for
+// state written before formats were recorded
+func StateVersion(cfg *config.Config) (int, error) {
+	data, err := os.ReadFile(filepath.Join(cfg.ConfigDir(), versionFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	v, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0, fmt.Errorf("parsing %s: %w", versionFile, err)
+	}
+	return v, nil
+}
+
+// Plan returns the changes that bring the config dir to Version, skipping
+// migrations the recorded state format already includes
+func Plan(cfg *config.Config) ([]Change, error) {
+	stored, err := StateVersion(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if stored > Version {
+		return nil, fmt.Errorf("state format %d is newer than this wt understands (%d); upgrade wt", stored, Version)
+	}
+	var changes []Change
+	for _, m := range migrations {
+		if m.version <= stored {
+			continue
+		}
+		planned, err := m.plan(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("migration %d: %w", m.version, err)
+		}
+		for i := range planned {
+			planned[i].Version = m.version
+		}
+		changes = append(changes, planned...)
+	}
+	return changes, nil
+}
+
+// Apply backs up the files the changes rewrite, applies them and records
+// Version. It returns the backup directory, "" when nothing was backed up.
+func Apply(cfg *config.Config, changes []Change, now time.Time) (string, error) {
+	backupDir := ""
+	for _, c := range changes {
+		for _, path := range c.Files {
+			if backupDir == "" {
+				backupDir = filepath.Join(cfg.ConfigDir(), "backups", "migrate-"+now.Format("20060102-150405"))
+			}
+			if err := backup(cfg.ConfigDir(), path, backupDir); err != nil {
+				return "", fmt.Errorf("backing up %s: %w", path, err)
+			}
+		}
+	}
+	for _, c := range changes {
+		if err := c.apply(); err != nil {
+			return backupDir, fmt.Errorf("%s: %w", c.Desc, err)
+		}
+	}
+	path := filepath.Join(cfg.ConfigDir(), versionFile)
+	if err := os.WriteFile(path, []byte(strconv.Itoa(Version)+"\n"), 0644); err != nil {
+		return backupDir, fmt.Errorf("recording state format: %w", err)
+	}
+	return backupDir, nil
+}
+
+// backup copies a file of the config dir to the same relative path under dir
+func backup(configDir, path, dir string) error {
+	rel, err := filepath.Rel(configDir, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		rel = filepath.Base(path)
+	}
+	dest := filepath.Join(dir, rel)
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return err
+	}
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	out, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, src); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,156 @@
+package migrate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/badri/wt/internal/config"
+)
+
+func testConfig(t *testing.T) *config.Config {
+	t.Helper()
+	cfg, err := config.LoadFromDir(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadFromDir() error: %v", err)
+	}
+	return cfg
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestFlattenSessions(t *testing.T) {
+	flat, nested, err := flattenSessions([]byte(`{"sessions": {"toast": {"bead": "wt-a", "worktree": "/w/toast"}}}`))
+	if err != nil || !nested {
+		t.Fatalf("flattenSessions(nested) = %v, %v", nested, err)
+	}
+	if !strings.Contains(string(flat), `"toast": {`) || strings.Contains(string(flat), `"sessions"`) {
+		t.Errorf("flattened = %s", flat)
+	}
+
+	for _, data := range []string{
+		`{}`,
+		`{"toast": {"bead": "wt-a"}}`,
+		`{"sessions": {"bead": "wt-a", "worktree": "/w/sessions"}}`, // a session named "sessions"
+	} {
+		if _, nested, err := flattenSessions([]byte(data)); err != nil || nested {
+			t.Errorf("flattenSessions(%s) = %v, %v, want flat", data, nested, err)
+		}
+	}
+}
+
+func TestUpgradeEvents(t *testing.T) {
+	data := strings.Join([]string{
+		`{"ts":"2026-01-19T08:30:00Z","type":"session.created","session":"toast"}`,
+		`{"time":"2026-01-19T09:00:00Z","type":"note","session":"toast","note":"kept as is"}`,
+		`not json`,
+		`{"ts":"2026-01-19T10:50:00Z","type":"session.closed","session":"toast"}`,
+		``,
+	}, "\n")
+
+	upgraded, n := upgradeEvents([]byte(data))
+	if n != 2 {
+		t.Fatalf("upgradeEvents() rewrote %d lines, want 2", n)
+	}
+	lines := strings.Split(string(upgraded), "\n")
+	if lines[0] != `{"session":"toast","time":"2026-01-19T08:30:00Z","type":"session_start"}` {
+		t.Errorf("line 0 = %s", lines[0])
+	}
+	if lines[1] != `{"time":"2026-01-19T09:00:00Z","type":"note","session":"toast","note":"kept as is"}` || lines[2] != "not json" {
+		t.Errorf("untouched lines changed: %q", lines[1:3])
+	}
+	if !strings.Contains(lines[3], `"type":"session_end"`) {
+		t.Errorf("line 3 = %s", lines[3])
+	}
+
+	if _, n := upgradeEvents(upgraded); n != 0 {
+		t.Errorf("second upgradeEvents() rewrote %d lines, want 0", n)
+	}
+}
+
+func TestPlanAndApply(t *testing.T) {
+	cfg := testConfig(t)
+	dir := cfg.ConfigDir()
+	writeFile(t, cfg.SessionsPath(), `{"sessions": {"toast": {"bead": "wt-a"}}}`)
+	writeFile(t, filepath.Join(dir, "events.jsonl"), `{"ts":"2026-01-19T08:30:00Z","type":"session.killed"}`+"\n")
+	writeFile(t, filepath.Join(dir, "auto.lock"), `{"pid": 99999999, "project": "api"}`)
+	writeFile(t, filepath.Join(dir, "auto-queue.json"), `{"epics": ["wt-e1"]}`)
+
+	changes, err := Plan(cfg)
+	if err != nil {
+		t.Fatalf("Plan() error: %v", err)
+	}
+	var descs []string
+	for _, c := range changes {
+		descs = append(descs, fmt.Sprintf("[%d] %s", c.Version, c.Desc))
+	}
+	want := []string{
+		"[1] sessions.json: flatten the nested sessions map",
+		"[2] auto.lock: remove the stale global lock",
+		"[2] auto-queue.json: rename to auto-queue-api.json",
+		"[3] events.jsonl: upgrade 1 event(s) to the current format",
+	}
+	if strings.Join(descs, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("Plan() =\n%s\nwant\n%s", strings.Join(descs, "\n"), strings.Join(want, "\n"))
+	}
+
+	backupDir, err := Apply(cfg, changes, time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Apply() error: %v", err)
+	}
+	if backupDir != filepath.Join(dir, "backups", "migrate-20260301-100000") {
+		t.Errorf("backup dir = %s", backupDir)
+	}
+	if got := readFile(t, filepath.Join(backupDir, "sessions.json")); !strings.Contains(got, `"sessions"`) {
+		t.Errorf("sessions.json backup = %s", got)
+	}
+	if got := readFile(t, filepath.Join(dir, "auto-queue-api.json")); got != `{"epics": ["wt-e1"]}` {
+		t.Errorf("auto-queue-api.json = %s", got)
+	}
+	if fileExists(filepath.Join(dir, "auto.lock")) || fileExists(filepath.Join(dir, "auto-queue.json")) {
+		t.Error("legacy auto files still exist")
+	}
+	if got := readFile(t, filepath.Join(dir, "events.jsonl")); !strings.Contains(got, `"type":"session_kill"`) {
+		t.Errorf("events.jsonl = %s", got)
+	}
+
+	if v, err := StateVersion(cfg); err != nil || v != Version {
+		t.Errorf("StateVersion() = %d, %v, want %d", v, err, Version)
+	}
+	if changes, err := Plan(cfg); err != nil || len(changes) != 0 {
+		t.Errorf("Plan() after Apply() = %v, %v, want nothing", changes, err)
+	}
+}
+
+func TestPlanRefusesRunningAutoAndNewerState(t *testing.T) {
+	cfg := testConfig(t)
+	dir := cfg.ConfigDir()
+
+	writeFile(t, filepath.Join(dir, "auto.lock"), fmt.Sprintf(`{"pid": %d}`, os.Getpid()))
+	if _, err := Plan(cfg); err == nil || !strings.Contains(err.Error(), "wt auto --stop") {
+		t.Errorf("Plan() with a running global auto = %v, want an error", err)
+	}
+	os.Remove(filepath.Join(dir, "auto.lock"))
+
+	writeFile(t, filepath.Join(dir, versionFile), fmt.Sprintf("%d\n", Version+1))
+	if _, err := Plan(cfg); err == nil || !strings.Contains(err.Error(), "newer") {
+		t.Errorf("Plan() with newer state = %v, want an error", err)
+	}
+}
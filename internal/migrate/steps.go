@@ -0,0 +1,261 @@
+package migrate
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/badri/wt/internal/auto"
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/project"
+)
+
+// planSessions flattens a sessions.json written as {"sessions": {...}},
+// which the current format would read as one session named "sessions"
+func planSessions(cfg *config.Config) ([]Change, error) {
+	path := cfg.SessionsPath()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	flat, nested, err := flattenSessions(data)
+	if err != nil || !nested {
+		return nil, err
+	}
+	return []Change{{
+		Desc:  "sessions.json: flatten the nested sessions map",
+		Files: []string{path},
+		apply: func() error { return os.WriteFile(path, flat, 0644) },
+	}}, nil
+}
+
+// flattenSessions returns the sessions of a nested sessions.json as a flat
+// map, and false if the file is flat already
+func flattenSessions(data []byte) ([]byte, bool, error) {
+	var top map[string]json.RawMessage
+	if err := json.Unmarshal(data, &top); err != nil {
+		return nil, false, fmt.Errorf("parsing sessions.json: %w", err)
+	}
+	inner, ok := top["sessions"]
+	if !ok || len(top) != 1 {
+		return nil, false, nil
+	}
+	// A session named "sessions" has string fields, not sessions
+	var sessions map[string]map[string]json.RawMessage
+	if err := json.Unmarshal(inner, &sessions); err != nil {
+		return nil, false, nil
+	}
+	if sessions == nil {
+		sessions = make(map[string]map[string]json.RawMessage)
+	}
+	flat, err := json.MarshalIndent(sessions, "", "  ")
+	if err != nil {
+		return nil, false, err
+	}
+	return flat, true, nil
+}
+
+// legacyAutoFiles are the global wt auto files from before runs were kept
+// per project, with the name each has for a project's run
+var legacyAutoFiles = []struct{ legacy, perProject string }{
+	{"auto-epic-state.json", "auto-epic-state-%s.json"},
+	{"auto-epic-edits.json", "auto-epic-edits-%s.json"},
+	{"auto-bead-done.json", "auto-bead-done-%s.json"},
+	{"auto-queue.json", "auto-queue-%s.json"},
+	{"stop-auto", "stop-auto-%s"},
+	{"approve-auto", "approve-auto-%s"},
+}
+
+// planAutoFiles removes a stale global auto.lock and moves the global wt
+// auto files to the per-project names of the project they belong to. When
+// that project can't be told, they stay: wt auto still reads them.
+func planAutoFiles(cfg *config.Config) ([]Change, error) {
+	dir := cfg.ConfigDir()
+	var changes []Change
+
+	lock := filepath.Join(dir, "auto.lock")
+	if fileExists(lock) {
+		if auto.RunnerActive(cfg, "") {
+			return nil, fmt.Errorf("a wt auto run holds the global auto.lock; stop it with 'wt auto --stop' first")
+		}
+		changes = append(changes, Change{
+			Desc:  "auto.lock: remove the stale global lock",
+			Files: []string{lock},
+			apply: func() error { return os.Remove(lock) },
+		})
+	}
+
+	proj := legacyAutoProject(cfg)
+	if proj == "" {
+		return changes, nil
+	}
+	for _, f := range legacyAutoFiles {
+		from := filepath.Join(dir, f.legacy)
+		to := filepath.Join(dir, fmt.Sprintf(f.perProject, proj))
+		if !fileExists(from) || fileExists(to) {
+			continue
+		}
+		changes = append(changes, Change{
+			Desc:  fmt.Sprintf("%s: rename to %s", f.legacy, filepath.Base(to)),
+			Files: []string{from},
+			apply: func() error { return os.Rename(from, to) },
+		})
+	}
+	return changes, nil
+}
+
+// legacyAutoProject returns the project of the global wt auto files: the
+// one recorded in auto.lock, or the one whose repo the epic state names
+func legacyAutoProject(cfg *config.Config) string {
+	if data, err := os.ReadFile(filepath.Join(cfg.ConfigDir(), "auto.lock")); err == nil {
+		var lock auto.LockInfo
+		if json.Unmarshal(data, &lock) == nil && lock.Project != "" {
+			return lock.Project
+		}
+	}
+	data, err := os.ReadFile(auto.EpicStateFile(cfg))
+	if err != nil {
+		return ""
+	}
+	var state auto.EpicState
+	if err := json.Unmarshal(data, &state); err != nil || state.ProjectDir == "" {
+		return ""
+	}
+	projects, err := project.NewManager(cfg).List()
+	if err != nil {
+		return ""
+	}
+	for _, p := range projects {
+		if filepath.Clean(p.RepoPath()) == filepath.Clean(state.ProjectDir) {
+			return p.Name
+		}
+	}
+	return ""
+}
+
+// legacyEventTypes maps the dotted event types of early versions to the
+// current ones
+var legacyEventTypes = map[string]string{
+	"session.created": "session_start",
+	"session.closed":  "session_end",
+	"session.killed":  "session_kill",
+}
+
+// planEvents upgrades events logged with a "ts" time or a dotted type, in
+// events.jsonl and its rotated archives
+func planEvents(cfg *config.Config) ([]Change, error) {
+	dir := cfg.ConfigDir()
+	archives, err := filepath.Glob(filepath.Join(dir, "events-*.jsonl.gz"))
+	if err != nil {
+		return nil, err
+	}
+	var changes []Change
+	for _, path := range append([]string{filepath.Join(dir, "events.jsonl")}, archives...) {
+		data, err := readEventsFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		upgraded, n := upgradeEvents(data)
+		if n == 0 {
+			continue
+		}
+		changes = append(changes, Change{
+			Desc:  fmt.Sprintf("%s: upgrade %d event(s) to the current format", filepath.Base(path), n),
+			Files: []string{path},
+			apply: func() error { return writeEventsFile(path, upgraded) },
+		})
+	}
+	return changes, nil
+}
+
+// upgradeEvents rewrites the event lines in an old format, leaving the
+// others as they are, and returns how many it rewrote
+func upgradeEvents(data []byte) ([]byte, int) {
+	lines := bytes.Split(data, []byte("\n"))
+	n := 0
+	for i, line := range lines {
+		if upgraded, ok := upgradeEvent(line); ok {
+			lines[i] = upgraded
+			n++
+		}
+	}
+	return bytes.Join(lines, []byte("\n")), n
+}
+
+func upgradeEvent(line []byte) ([]byte, bool) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(line, &fields); err != nil {
+		return nil, false
+	}
+	changed := false
+	if ts, ok := fields["ts"]; ok {
+		if _, ok := fields["time"]; !ok {
+			fields["time"] = ts
+			delete(fields, "ts")
+			changed = true
+		}
+	}
+	var typ string
+	if json.Unmarshal(fields["type"], &typ) == nil {
+		if current, ok := legacyEventTypes[typ]; ok {
+			fields["type"], _ = json.Marshal(current)
+			changed = true
+		}
+	}
+	if !changed {
+		return nil, false
+	}
+	out, err := json.Marshal(fields)
+	if err != nil {
+		return nil, false
+	}
+	return out, true
+}
+
+func readEventsFile(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	if !strings.HasSuffix(path, ".gz") {
+		return io.ReadAll(f)
+	}
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	defer zr.Close()
+	return io.ReadAll(zr)
+}
+
+func writeEventsFile(path string, data []byte) error {
+	if !strings.HasSuffix(path, ".gz") {
+		return os.WriteFile(path, data, 0644)
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
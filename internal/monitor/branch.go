@@ -0,0 +1,73 @@
+package monitor
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/badri/wt/internal/logging"
+)
+
+// BranchCountsTTL is how long ahead/behind counts are reused before git is
+// asked again, so refreshing dashboards don't run rev-list every tick
+const BranchCountsTTL = 30 * time.Second
+
+// BranchCounts compares a worktree's HEAD with the branch it merges into
+type BranchCounts struct {
+	Ahead  int `json:"ahead"`  // commits on HEAD not on the base branch
+	Behind int `json:"behind"` // commits on the base branch not on HEAD
+}
+
+// String renders the counts as "↑3 ↓12"
+func (c BranchCounts) String() string {
+	return fmt.Sprintf("↑%d ↓%d", c.Ahead, c.Behind)
+}
+
+type branchCountsEntry struct {
+	counts BranchCounts
+	ok     bool
+	at     time.Time
+}
+
+var branchCountsCache = struct {
+	sync.Mutex
+	entries map[string]branchCountsEntry
+}{entries: make(map[string]branchCountsEntry)}
+
+// GetBranchCounts counts commits ahead of and behind base for a worktree.
+// The remote-tracking branch is preferred, the local branch is the
+// fallback; nothing is fetched. ok is false when neither can be compared.
+// Results are cached for BranchCountsTTL.
+func GetBranchCounts(worktreePath, base string) (counts BranchCounts, ok bool) {
+	key := worktreePath + "\x00" + base
+
+	branchCountsCache.Lock()
+	entry, cached := branchCountsCache.entries[key]
+	branchCountsCache.Unlock()
+	if cached && time.Since(entry.at) < BranchCountsTTL {
+		return entry.counts, entry.ok
+	}
+
+	counts, ok = countAheadBehind(worktreePath, base)
+
+	branchCountsCache.Lock()
+	branchCountsCache.entries[key] = branchCountsEntry{counts: counts, ok: ok, at: time.Now()}
+	branchCountsCache.Unlock()
+	return counts, ok
+}
+
+func countAheadBehind(worktreePath, base string) (BranchCounts, bool) {
+	for _, ref := range []string{"origin/" + base, base} {
+		cmd := logging.Command("git", "-C", worktreePath, "rev-list", "--left-right", "--count", ref+"...HEAD")
+		output, err := cmd.Output()
+		if err != nil {
+			continue
+		}
+		var c BranchCounts
+		if _, err := fmt.Sscanf(strings.TrimSpace(string(output)), "%d\t%d", &c.Behind, &c.Ahead); err == nil {
+			return c, true
+		}
+	}
+	return BranchCounts{}, false
+}
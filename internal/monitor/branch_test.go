@@ -0,0 +1,60 @@
+package monitor
+
+import (
+	"os/exec"
+	"testing"
+	"time"
+)
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=Test", "-c", "user.email=test@test.com"}, args...)...)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, out)
+	}
+}
+
+func TestGetBranchCounts(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	dir := t.TempDir()
+	runGit(t, dir, "init", "-q", "-b", "main")
+	runGit(t, dir, "commit", "-q", "--allow-empty", "-m", "init")
+	runGit(t, dir, "checkout", "-q", "-b", "feature")
+	runGit(t, dir, "commit", "-q", "--allow-empty", "-m", "feature 1")
+	runGit(t, dir, "commit", "-q", "--allow-empty", "-m", "feature 2")
+	runGit(t, dir, "checkout", "-q", "main")
+	runGit(t, dir, "commit", "-q", "--allow-empty", "-m", "main 1")
+	runGit(t, dir, "checkout", "-q", "feature")
+
+	counts, ok := GetBranchCounts(dir, "main")
+	if !ok || counts != (BranchCounts{Ahead: 2, Behind: 1}) {
+		t.Fatalf("GetBranchCounts() = %+v, %v; want ahead 2, behind 1", counts, ok)
+	}
+	if counts.String() != "↑2 ↓1" {
+		t.Errorf("String() = %q", counts.String())
+	}
+
+	// Within the TTL the cached counts are returned
+	runGit(t, dir, "commit", "-q", "--allow-empty", "-m", "feature 3")
+	if counts, _ := GetBranchCounts(dir, "main"); counts.Ahead != 2 {
+		t.Errorf("cached Ahead = %d, want 2", counts.Ahead)
+	}
+
+	// Once expired, git is asked again
+	key := dir + "\x00main"
+	branchCountsCache.Lock()
+	entry := branchCountsCache.entries[key]
+	entry.at = time.Now().Add(-BranchCountsTTL)
+	branchCountsCache.entries[key] = entry
+	branchCountsCache.Unlock()
+	if counts, _ := GetBranchCounts(dir, "main"); counts.Ahead != 3 {
+		t.Errorf("refreshed Ahead = %d, want 3", counts.Ahead)
+	}
+
+	if _, ok := GetBranchCounts(dir, "no-such-branch"); ok {
+		t.Error("GetBranchCounts() with a missing base should not be ok")
+	}
+}
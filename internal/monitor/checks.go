@@ -0,0 +1,134 @@
+package monitor
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/badri/wt/internal/logging"
+)
+
+// PRInfoTTL is how long PR and CI check results are reused before gh is
+// asked again; checks change slowly and every lookup is an API call
+const PRInfoTTL = time.Minute
+
+// CI check states of a PR, summarised over all its checks
+const (
+	ChecksNone    = "none"    // no PR, or a PR without checks
+	ChecksPending = "pending" // some checks queued or running, none failed
+	ChecksFailing = "failing" // at least one check failed
+	ChecksPassing = "passing" // all checks succeeded or were skipped
+)
+
+// PRInfo is a branch's PR status plus its CI checks
+type PRInfo struct {
+	Status string `json:"status"` // open, merged, closed, none
+	URL    string `json:"url,omitempty"`
+	Checks string `json:"checks"` // none, pending, failing, passing
+}
+
+// checkRun is one entry of gh's statusCheckRollup: a check run (status and
+// conclusion) or a commit status context (state)
+type checkRun struct {
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion"`
+	State      string `json:"state"`
+}
+
+type prInfoEntry struct {
+	info PRInfo
+	at   time.Time
+}
+
+var prInfoCache = struct {
+	sync.Mutex
+	entries map[string]prInfoEntry
+}{entries: make(map[string]prInfoEntry)}
+
+// GetPRInfo is GetPRStatus plus the CI check state of the PR. Results are
+// cached for PRInfoTTL.
+func GetPRInfo(worktreePath, branch string) PRInfo {
+	key := worktreePath + "\x00" + branch
+
+	prInfoCache.Lock()
+	entry, cached := prInfoCache.entries[key]
+	prInfoCache.Unlock()
+	if cached && time.Since(entry.at) < PRInfoTTL {
+		return entry.info
+	}
+
+	info := fetchPRInfo(worktreePath, branch)
+
+	prInfoCache.Lock()
+	prInfoCache.entries[key] = prInfoEntry{info: info, at: time.Now()}
+	prInfoCache.Unlock()
+	return info
+}
+
+func fetchPRInfo(worktreePath, branch string) PRInfo {
+	cmd := logging.CommandIn(worktreePath, "gh", "pr", "view", branch, "--json", "state,url,statusCheckRollup")
+	output, err := cmd.Output()
+	if err != nil {
+		return PRInfo{Status: "none", Checks: ChecksNone}
+	}
+	return parsePRInfo(output)
+}
+
+func parsePRInfo(data []byte) PRInfo {
+	var pr struct {
+		State  string     `json:"state"`
+		URL    string     `json:"url"`
+		Checks []checkRun `json:"statusCheckRollup"`
+	}
+	if err := json.Unmarshal(data, &pr); err != nil {
+		return PRInfo{Status: "none", Checks: ChecksNone}
+	}
+	return PRInfo{Status: strings.ToLower(pr.State), URL: pr.URL, Checks: summarizeChecks(pr.Checks)}
+}
+
+// summarizeChecks folds the checks of a PR into one state: any failure
+// wins, then anything unfinished
+func summarizeChecks(checks []checkRun) string {
+	if len(checks) == 0 {
+		return ChecksNone
+	}
+	pending := false
+	for _, c := range checks {
+		switch strings.ToUpper(c.State) {
+		case "FAILURE", "ERROR":
+			return ChecksFailing
+		case "PENDING", "EXPECTED":
+			pending = true
+			continue
+		case "SUCCESS":
+			continue
+		}
+		if strings.ToUpper(c.Status) != "COMPLETED" {
+			pending = true
+			continue
+		}
+		switch strings.ToUpper(c.Conclusion) {
+		case "FAILURE", "TIMED_OUT", "CANCELLED", "ACTION_REQUIRED", "STARTUP_FAILURE":
+			return ChecksFailing
+		}
+	}
+	if pending {
+		return ChecksPending
+	}
+	return ChecksPassing
+}
+
+// ChecksIcon returns an icon for a CI check state
+func ChecksIcon(checks string) string {
+	switch checks {
+	case ChecksPassing:
+		return "✓"
+	case ChecksFailing:
+		return "✗"
+	case ChecksPending:
+		return "…"
+	default:
+		return "-"
+	}
+}
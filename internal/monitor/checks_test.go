@@ -0,0 +1,47 @@
+package monitor
+
+import "testing"
+
+func TestSummarizeChecks(t *testing.T) {
+	tests := []struct {
+		name   string
+		checks []checkRun
+		want   string
+	}{
+		{"no checks", nil, ChecksNone},
+		{"all passed", []checkRun{
+			{Status: "COMPLETED", Conclusion: "SUCCESS"},
+			{Status: "COMPLETED", Conclusion: "SKIPPED"},
+			{State: "SUCCESS"},
+		}, ChecksPassing},
+		{"one running", []checkRun{
+			{Status: "COMPLETED", Conclusion: "SUCCESS"},
+			{Status: "IN_PROGRESS"},
+		}, ChecksPending},
+		{"status context pending", []checkRun{{State: "PENDING"}}, ChecksPending},
+		{"failure beats pending", []checkRun{
+			{Status: "QUEUED"},
+			{Status: "COMPLETED", Conclusion: "FAILURE"},
+		}, ChecksFailing},
+		{"timed out", []checkRun{{Status: "COMPLETED", Conclusion: "TIMED_OUT"}}, ChecksFailing},
+		{"status context error", []checkRun{{State: "ERROR"}}, ChecksFailing},
+	}
+	for _, tt := range tests {
+		if got := summarizeChecks(tt.checks); got != tt.want {
+			t.Errorf("%s: summarizeChecks() = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParsePRInfo(t *testing.T) {
+	data := `{"state":"OPEN","url":"https://github.com/o/r/pull/7","statusCheckRollup":[
+		{"__typename":"CheckRun","name":"test","status":"COMPLETED","conclusion":"FAILURE"}]}`
+	got := parsePRInfo([]byte(data))
+	want := PRInfo{Status: "open", URL: "https://github.com/o/r/pull/7", Checks: ChecksFailing}
+	if got != want {
+		t.Errorf("parsePRInfo() = %+v, want %+v", got, want)
+	}
+	if got := parsePRInfo([]byte("not json")); got.Status != "none" || got.Checks != ChecksNone {
+		t.Errorf("parsePRInfo(garbage) = %+v", got)
+	}
+}
@@ -0,0 +1,66 @@
+package monitor
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// ContextUsage is how full a Claude conversation's context window is
+type ContextUsage struct {
+	Transcript string // transcript of the conversation
+	Tokens     int64  // tokens the conversation occupies
+}
+
+// Percent returns the share of a context window of the given size in use
+func (u ContextUsage) Percent(window int64) int {
+	if window <= 0 {
+		return 0
+	}
+	return int(u.Tokens * 100 / window)
+}
+
+// GetContextUsage returns how much context the latest Claude conversation in
+// a worktree occupies. Each assistant message records the prompt it was
+// given, so the last one's input and cache tokens plus its output is what
+// the next turn starts from. ok is false when there is no transcript or no
+// usage in it yet.
+func GetContextUsage(worktreePath string) (usage ContextUsage, ok bool) {
+	path, _ := LatestTranscript(worktreePath)
+	if path == "" {
+		return usage, false
+	}
+	data, err := transcriptTail(path)
+	if err != nil {
+		return usage, false
+	}
+	tokens, ok := lastContextTokens(data)
+	return ContextUsage{Transcript: path, Tokens: tokens}, ok
+}
+
+// lastContextTokens scans JSONL data backwards for the last assistant
+// message that carries usage
+func lastContextTokens(data []byte) (int64, bool) {
+	lines := bytes.Split(data, []byte("\n"))
+	for i := len(lines) - 1; i >= 0; i-- {
+		if !bytes.Contains(lines[i], []byte(`"usage"`)) {
+			continue
+		}
+		var entry struct {
+			Type    string `json:"type"`
+			Message struct {
+				Usage *struct {
+					InputTokens      int64 `json:"input_tokens"`
+					OutputTokens     int64 `json:"output_tokens"`
+					CacheWriteTokens int64 `json:"cache_creation_input_tokens"`
+					CacheReadTokens  int64 `json:"cache_read_input_tokens"`
+				} `json:"usage"`
+			} `json:"message"`
+		}
+		if err := json.Unmarshal(lines[i], &entry); err != nil || entry.Type != "assistant" || entry.Message.Usage == nil {
+			continue
+		}
+		u := entry.Message.Usage
+		return u.InputTokens + u.CacheWriteTokens + u.CacheReadTokens + u.OutputTokens, true
+	}
+	return 0, false
+}
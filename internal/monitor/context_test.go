@@ -0,0 +1,34 @@
+package monitor
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLastContextTokens(t *testing.T) {
+	data := strings.Join([]string{
+		`{"type":"assistant","message":{"usage":{"input_tokens":5,"cache_read_input_tokens":1000,"output_tokens":50}}}`,
+		`{"type":"user","message":{"content":"next"}}`,
+		`{"type":"assistant","message":{"usage":{"input_tokens":10,"cache_creation_input_tokens":500,"cache_read_input_tokens":120000,"output_tokens":300}}}`,
+		`{"type":"user","message":{"content":[{"type":"tool_result","content":"mentions \"usage\" in a result"}]}}`,
+		``,
+	}, "\n")
+	tokens, ok := lastContextTokens([]byte(data))
+	if !ok || tokens != 120810 {
+		t.Errorf("lastContextTokens() = %d, %v, want 120810, true", tokens, ok)
+	}
+
+	if _, ok := lastContextTokens([]byte(`{"type":"user","message":{"content":"hi"}}`)); ok {
+		t.Error("lastContextTokens() without usage should report !ok")
+	}
+}
+
+func TestContextUsagePercent(t *testing.T) {
+	u := ContextUsage{Tokens: 170000}
+	if got := u.Percent(200000); got != 85 {
+		t.Errorf("Percent() = %d, want 85", got)
+	}
+	if got := u.Percent(0); got != 0 {
+		t.Errorf("Percent(0) = %d, want 0", got)
+	}
+}
@@ -0,0 +1,104 @@
+package monitor
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/badri/wt/internal/config"
+)
+
+// Notifier sends desktop notifications for session events. Events in digest
+// mode are collected and sent as one summary once per window; the rest go
+// out as they happen.
+type Notifier struct {
+	mu      sync.Mutex
+	window  time.Duration
+	mode    func(eventType string) string
+	send    func(title, message string) error
+	pending map[string][]string // event type -> sessions, in arrival order
+	since   time.Time           // when the first pending event arrived
+}
+
+// NewNotifier creates a Notifier that follows the notifications section of
+// the config, emailing notifications too when email.notify is set
+func NewNotifier(cfg *config.Config) *Notifier {
+	n := newNotifier(cfg.DigestWindow(), cfg.NotifyMode)
+	if e := cfg.EmailSettings(); e != nil && e.Notify {
+		n.send = withEmail(n.send, e)
+	}
+	return n
+}
+
+// newNotifier creates a Notifier. mode returns the delivery mode of an event
+// type; with a zero window nothing is batched.
+func newNotifier(window time.Duration, mode func(eventType string) string) *Notifier {
+	return &Notifier{
+		window:  window,
+		mode:    mode,
+		send:    Notify,
+		pending: make(map[string][]string),
+	}
+}
+
+// Send notifies about an event of a session, or queues it for the digest
+func (n *Notifier) Send(eventType, session, title, message string) {
+	mode := config.NotifyImmediate
+	if n.mode != nil {
+		mode = n.mode(eventType)
+	}
+	switch {
+	case mode == config.NotifyOff:
+		return
+	case mode == config.NotifyDigest && n.window > 0:
+		n.mu.Lock()
+		if len(n.pending) == 0 {
+			n.since = time.Now()
+		}
+		if !slices.Contains(n.pending[eventType], session) {
+			n.pending[eventType] = append(n.pending[eventType], session)
+		}
+		n.mu.Unlock()
+	default:
+		n.send(title, message)
+	}
+}
+
+// Tick sends the digest once the window since its first event has passed.
+// Call it on every refresh.
+func (n *Notifier) Tick() {
+	n.mu.Lock()
+	if len(n.pending) == 0 || time.Since(n.since) < n.window {
+		n.mu.Unlock()
+		return
+	}
+	title, message := digestText(n.pending)
+	n.pending = make(map[string][]string)
+	n.mu.Unlock()
+
+	n.send(title, message)
+}
+
+// digestText summarises pending events, one line per event type:
+// "idle: toast, shadow"
+func digestText(pending map[string][]string) (title, message string) {
+	types := make([]string, 0, len(pending))
+	count := 0
+	for eventType, sessions := range pending {
+		types = append(types, eventType)
+		count += len(sessions)
+	}
+	slices.Sort(types)
+
+	lines := make([]string, 0, len(types))
+	for _, eventType := range types {
+		lines = append(lines, fmt.Sprintf("%s: %s", eventType, strings.Join(pending[eventType], ", ")))
+	}
+	title = "wt: 1 session update"
+	if count != 1 {
+		title = fmt.Sprintf("wt: %d session updates", count)
+	}
+	return title, strings.Join(lines, "\n")
+}
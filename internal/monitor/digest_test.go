@@ -0,0 +1,69 @@
+package monitor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/badri/wt/internal/config"
+)
+
+func TestNotifierDigest(t *testing.T) {
+	type note struct{ title, message string }
+	var sent []note
+	modes := map[string]string{"error": config.NotifyImmediate, "blocked": config.NotifyOff}
+	n := newNotifier(time.Hour, func(eventType string) string {
+		if mode, ok := modes[eventType]; ok {
+			return mode
+		}
+		return config.NotifyDigest
+	})
+	n.send = func(title, message string) error {
+		sent = append(sent, note{title, message})
+		return nil
+	}
+
+	n.Send("idle", "toast", "wt: Session Idle", "toast is idle")
+	n.Send("idle", "shadow", "wt: Session Idle", "shadow is idle")
+	n.Send("idle", "toast", "wt: Session Idle", "toast is idle")
+	n.Send("ended", "obsidian", "wt: Session Ended", "obsidian ended")
+	n.Send("blocked", "toast", "wt: Session Blocked", "toast is blocked")
+	n.Send("error", "shadow", "wt: Session Error", "shadow has an error")
+
+	// Errors pass through, the rest waits for the window
+	if len(sent) != 1 || sent[0].title != "wt: Session Error" {
+		t.Fatalf("sent before the window = %+v, want only the error", sent)
+	}
+	n.Tick()
+	if len(sent) != 1 {
+		t.Fatalf("digest sent before the window passed: %+v", sent)
+	}
+
+	n.since = time.Now().Add(-time.Hour)
+	n.Tick()
+	if len(sent) != 2 {
+		t.Fatalf("digest not sent after the window: %+v", sent)
+	}
+	digest := sent[1]
+	if digest.title != "wt: 3 session updates" || digest.message != "ended: obsidian\nidle: toast, shadow" {
+		t.Errorf("digest = %+v", digest)
+	}
+
+	// Nothing pending, nothing sent
+	n.Tick()
+	if len(sent) != 2 {
+		t.Errorf("empty digest sent: %+v", sent[2:])
+	}
+}
+
+func TestNotifierWithoutWindow(t *testing.T) {
+	sent := 0
+	n := newNotifier(0, func(string) string { return config.NotifyDigest })
+	n.send = func(title, message string) error {
+		sent++
+		return nil
+	}
+	n.Send("idle", "toast", "wt: Session Idle", "toast is idle")
+	if sent != 1 {
+		t.Errorf("without a window notifications should be immediate, sent %d", sent)
+	}
+}
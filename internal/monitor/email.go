@@ -0,0 +1,108 @@
+package monitor
+
+import (
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/badri/wt/internal/config"
+)
+
+// emailTimeout bounds connecting to the SMTP server and the whole exchange
+// after it, so a server that stops answering can't hang the sender
+var emailTimeout = 30 * time.Second
+
+// SendEmail sends a plain text email with the configured SMTP settings.
+// Port 465 uses implicit TLS; other ports upgrade with STARTTLS when the
+// server offers it.
+func SendEmail(e *config.Email, subject, body string) error {
+	addr := net.JoinHostPort(e.SMTPHost, strconv.Itoa(e.Port()))
+	msg := emailMessage(e.From, e.To, subject, body, time.Now())
+
+	dialer := &net.Dialer{Timeout: emailTimeout}
+	var conn net.Conn
+	var err error
+	if e.Port() == 465 {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, &tls.Config{ServerName: e.SMTPHost})
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("connecting to %s: %w", addr, err)
+	}
+	if err := conn.SetDeadline(time.Now().Add(emailTimeout)); err != nil {
+		conn.Close()
+		return fmt.Errorf("connecting to %s: %w", addr, err)
+	}
+	c, err := smtp.NewClient(conn, e.SMTPHost)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("connecting to %s: %w", addr, err)
+	}
+	defer c.Close()
+	if e.Port() != 465 {
+		if ok, _ := c.Extension("STARTTLS"); ok {
+			if err := c.StartTLS(&tls.Config{ServerName: e.SMTPHost}); err != nil {
+				return fmt.Errorf("starting TLS with %s: %w", addr, err)
+			}
+		}
+	}
+	if e.Username != "" {
+		auth := smtp.PlainAuth("", e.Username, e.SMTPPassword(), e.SMTPHost)
+		if err := c.Auth(auth); err != nil {
+			return fmt.Errorf("authenticating with %s: %w", addr, err)
+		}
+	}
+	if err := c.Mail(e.From); err != nil {
+		return fmt.Errorf("sending email via %s: %w", addr, err)
+	}
+	for _, to := range e.To {
+		if err := c.Rcpt(to); err != nil {
+			return fmt.Errorf("sending email to %s: %w", to, err)
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return fmt.Errorf("sending email via %s: %w", addr, err)
+	}
+	if _, err := w.Write(msg); err != nil {
+		return fmt.Errorf("sending email via %s: %w", addr, err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("sending email via %s: %w", addr, err)
+	}
+	return c.Quit()
+}
+
+// emailMessage builds the RFC 5322 message, with CRLF line endings
+func emailMessage(from string, to []string, subject, body string, date time.Time) []byte {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "From: %s\r\n", from)
+	fmt.Fprintf(&sb, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&sb, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&sb, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	sb.WriteString("MIME-Version: 1.0\r\n")
+	sb.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	sb.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	sb.WriteString("\r\n")
+	body = strings.ReplaceAll(body, "\r\n", "\n")
+	sb.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	if !strings.HasSuffix(body, "\n") {
+		sb.WriteString("\r\n")
+	}
+	return []byte(sb.String())
+}
+
+// withEmail sends each notification through send and, in the background
+// so a slow SMTP server can't hold up wt watch, as an email
+func withEmail(send func(title, message string) error, e *config.Email) func(title, message string) error {
+	return func(title, message string) error {
+		go SendEmail(e, title, message)
+		return send(title, message)
+	}
+}
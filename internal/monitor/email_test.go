@@ -0,0 +1,127 @@
+package monitor
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/badri/wt/internal/config"
+)
+
+func TestEmailMessage(t *testing.T) {
+	date := time.Date(2026, 3, 2, 18, 0, 0, 0, time.UTC)
+	msg := string(emailMessage("wt@example.com", []string{"a@example.com", "b@example.com"}, "wt: Session Idle", "line one\nline two", date))
+	for _, want := range []string{
+		"From: wt@example.com\r\n",
+		"To: a@example.com, b@example.com\r\n",
+		"Subject: wt: Session Idle\r\n",
+		"Date: Mon, 02 Mar 2026 18:00:00 +0000\r\n",
+		"\r\n\r\nline one\r\nline two\r\n",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("message missing %q:\n%s", want, msg)
+		}
+	}
+}
+
+// fakeSMTP accepts one message and returns what was sent after DATA
+func fakeSMTP(t *testing.T) (port int, received chan string) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	received = make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		reply := func(s string) { conn.Write([]byte(s + "\r\n")) }
+		reply("220 fake")
+		var data strings.Builder
+		inData := false
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			if inData {
+				if line == ".\r\n" {
+					inData = false
+					received <- data.String()
+					reply("250 ok")
+					continue
+				}
+				data.WriteString(line)
+				continue
+			}
+			switch cmd := strings.ToUpper(strings.Fields(line)[0]); cmd {
+			case "EHLO", "HELO":
+				reply("250 fake")
+			case "DATA":
+				inData = true
+				reply("354 go ahead")
+			case "QUIT":
+				reply("221 bye")
+				return
+			default:
+				reply("250 ok")
+			}
+		}
+	}()
+	return ln.Addr().(*net.TCPAddr).Port, received
+}
+
+func TestSendEmail(t *testing.T) {
+	port, received := fakeSMTP(t)
+	e := &config.Email{SMTPHost: "127.0.0.1", SMTPPort: port, From: "wt@example.com", To: []string{"team@example.com"}}
+	if err := SendEmail(e, "wt daily summary", "2 completed"); err != nil {
+		t.Fatalf("SendEmail() = %v", err)
+	}
+	select {
+	case msg := <-received:
+		if !strings.Contains(msg, "Subject: wt daily summary") || !strings.Contains(msg, "2 completed") {
+			t.Errorf("server received:\n%s", msg)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no message received")
+	}
+}
+
+func TestSendEmailTimeout(t *testing.T) {
+	// A server that accepts but never greets
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			t.Cleanup(func() { conn.Close() })
+		}
+	}()
+
+	defer func(d time.Duration) { emailTimeout = d }(emailTimeout)
+	emailTimeout = 200 * time.Millisecond
+
+	e := &config.Email{SMTPHost: "127.0.0.1", SMTPPort: ln.Addr().(*net.TCPAddr).Port, From: "wt@example.com", To: []string{"team@example.com"}}
+	done := make(chan error, 1)
+	go func() { done <- SendEmail(e, "wt daily summary", "2 completed") }()
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Error("SendEmail() to a silent server succeeded")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("SendEmail() didn't time out")
+	}
+}
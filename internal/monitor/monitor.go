@@ -2,10 +2,10 @@ package monitor
 
 import (
 	"fmt"
-	"os/exec"
 	"strings"
 	"time"
 
+	"github.com/badri/wt/internal/logging"
 	"github.com/badri/wt/internal/tmux"
 )
 
@@ -24,7 +24,7 @@ type SessionStatus struct {
 // GetTmuxLastActivity gets the last activity time for a tmux session
 func GetTmuxLastActivity(sessionName string) (time.Time, error) {
 	// Get the activity time of the session
-	cmd := exec.Command("tmux", "display-message", "-t", sessionName, "-p", "#{session_activity}")
+	cmd := logging.Command("tmux", "display-message", "-t", sessionName, "-p", "#{session_activity}")
 	output, err := cmd.Output()
 	if err != nil {
 		return time.Time{}, err
@@ -69,8 +69,7 @@ func DetectStatus(sessionName string, idleThresholdMinutes int) string {
 
 // GetPRStatus checks the PR status for a branch using gh CLI
 func GetPRStatus(worktreePath, branch string) (status, url string) {
-	cmd := exec.Command("gh", "pr", "view", branch, "--json", "state,url", "-q", ".state + \" \" + .url")
-	cmd.Dir = worktreePath
+	cmd := logging.CommandIn(worktreePath, "gh", "pr", "view", branch, "--json", "state,url", "-q", ".state + \" \" + .url")
 	output, err := cmd.Output()
 	if err != nil {
 		return "none", ""
@@ -109,6 +108,30 @@ func DetectStuckState(sessionName string, idleThresholdMinutes int) StuckState {
 	return StuckState{Type: "none"}
 }
 
+// CaptureTail returns the last n non-blank lines of a session's pane,
+// scrollback included
+func CaptureTail(sessionName string, n int) (string, error) {
+	content, err := tmux.CapturePane(sessionName, 200)
+	if err != nil {
+		return "", err
+	}
+	return PaneTail(content, n), nil
+}
+
+// PaneTail returns the last n non-blank lines of captured pane content
+func PaneTail(content string, n int) string {
+	var lines []string
+	for _, line := range strings.Split(content, "\n") {
+		if line = strings.TrimRight(line, " \t"); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
+
 // StatusIcon returns an icon for the status
 func StatusIcon(status string) string {
 	switch status {
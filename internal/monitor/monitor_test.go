@@ -0,0 +1,16 @@
+package monitor
+
+import "testing"
+
+func TestPaneTail(t *testing.T) {
+	pane := "one\n\ntwo  \nthree\nfour\n\n\n"
+	if got := PaneTail(pane, 2); got != "three\nfour" {
+		t.Errorf("PaneTail = %q", got)
+	}
+	if got := PaneTail(pane, 10); got != "one\ntwo\nthree\nfour" {
+		t.Errorf("PaneTail = %q", got)
+	}
+	if got := PaneTail("\n\n", 5); got != "" {
+		t.Errorf("PaneTail of a blank pane = %q", got)
+	}
+}
@@ -2,9 +2,10 @@ package monitor
 
 import (
 	"fmt"
-	"os/exec"
 	"runtime"
 	"strings"
+
+	"github.com/badri/wt/internal/logging"
 )
 
 // Notify sends a desktop notification with sound
@@ -29,13 +30,13 @@ func notifyMacOS(title, message string) error {
 	message = escapeAppleScript(message)
 
 	script := `display notification "` + message + `" with title "` + title + `" sound name "default"`
-	cmd := exec.Command("osascript", "-e", script)
+	cmd := logging.Command("osascript", "-e", script)
 	return cmd.Run()
 }
 
 func notifyLinux(title, message string) error {
 	// Use --urgency=critical to increase chance of sound on some desktop environments
-	cmd := exec.Command("notify-send", "--urgency=critical", title, message)
+	cmd := logging.Command("notify-send", "--urgency=critical", title, message)
 	return cmd.Run()
 }
 
@@ -4,11 +4,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"sync"
 	"time"
 
+	"github.com/badri/wt/internal/logging"
 	"github.com/badri/wt/internal/tmux"
 )
 
@@ -78,7 +78,7 @@ func (n *Nudger) LastNudgeTime(sessionName string) time.Time {
 
 func nudgeInterrupted(sessionName string) error {
 	// Send Enter to resume after an interruption
-	cmd := exec.Command("tmux", "send-keys", "-t", sessionName, "Enter")
+	cmd := logging.Command("tmux", "send-keys", "-t", sessionName, "Enter")
 	return cmd.Run()
 }
 
@@ -0,0 +1,189 @@
+package monitor
+
+import (
+	"strings"
+
+	"github.com/badri/wt/internal/logging"
+)
+
+// PermissionPrompt is a Claude tool permission dialog found in a pane
+type PermissionPrompt struct {
+	Tool   string // Claude tool name: Bash, Edit, Write, WebFetch, or an MCP tool
+	Detail string // The command, file or URL the tool wants to use
+	// More is set when the dialog shows more than Detail and a one-line
+	// description, e.g. a multi-line Bash command
+	More bool
+}
+
+// String renders the prompt the way Claude permission rules are written,
+// e.g. "Bash(go test ./...)"
+func (p PermissionPrompt) String() string {
+	if p.Detail == "" {
+		return p.Tool
+	}
+	return p.Tool + "(" + p.Detail + ")"
+}
+
+// permissionTitles maps the dialog titles of Claude's built-in tools to
+// their tool names
+var permissionTitles = map[string]string{
+	"Bash command":  "Bash",
+	"Edit file":     "Edit",
+	"Create file":   "Write",
+	"Write file":    "Write",
+	"Read file":     "Read",
+	"Read files":    "Read",
+	"Fetch":         "WebFetch",
+	"Web Search":    "WebSearch",
+	"Notebook edit": "NotebookEdit",
+}
+
+// DetectPermissionPrompt looks for a tool permission dialog ("Do you want
+// to proceed?" with numbered Yes/No options) at the bottom of captured pane
+// content. Returns nil if there is none.
+func DetectPermissionPrompt(content string) *PermissionPrompt {
+	lines := strings.Split(strings.TrimRight(content, "\n"), "\n")
+	for i := range lines {
+		lines[i] = trimBox(lines[i])
+	}
+
+	// The question and its options must be the last thing on screen,
+	// otherwise the dialog was already answered
+	question := -1
+	for i := len(lines) - 1; i >= 0 && i >= len(lines)-15; i-- {
+		if strings.HasPrefix(lines[i], "Do you want to") {
+			question = i
+			break
+		}
+	}
+	if question < 0 || !hasYesOption(lines[question+1:]) {
+		return nil
+	}
+
+	// File edits name the file in the question, below a diff of the change
+	q := strings.TrimSuffix(lines[question], "?")
+	if file, ok := strings.CutPrefix(q, "Do you want to make this edit to "); ok {
+		return &PermissionPrompt{Tool: "Edit", Detail: filePath(lines[:question], file)}
+	}
+	if file, ok := strings.CutPrefix(q, "Do you want to create "); ok {
+		return &PermissionPrompt{Tool: "Write", Detail: filePath(lines[:question], file)}
+	}
+
+	// Walk up to the dialog's title: the first line after the box top or,
+	// without a box, the first line after a blank run
+	var body []string
+	for i := question - 1; i >= 0 && i >= question-20; i-- {
+		if strings.Contains(lines[i], "╭") || strings.Contains(lines[i], "───") {
+			break
+		}
+		body = append([]string{lines[i]}, body...)
+	}
+	for len(body) > 0 && body[0] == "" {
+		body = body[1:]
+	}
+	if len(body) == 0 {
+		return &PermissionPrompt{}
+	}
+
+	title := body[0]
+	var rest []string
+	for _, line := range body[1:] {
+		if line != "" {
+			rest = append(rest, line)
+		}
+	}
+	var detail string
+	if len(rest) > 0 {
+		detail = rest[0]
+	}
+
+	if tool, ok := permissionTitles[title]; ok {
+		return &PermissionPrompt{Tool: tool, Detail: detail, More: len(rest) > 2}
+	}
+	// MCP and other tools show "Tool use" with "name(args)" below
+	if name, args, ok := strings.Cut(detail, "("); ok {
+		return &PermissionPrompt{Tool: strings.TrimSpace(name), Detail: strings.TrimSuffix(args, ")")}
+	}
+	return &PermissionPrompt{Tool: title, Detail: detail}
+}
+
+// trimBox strips the dialog border and padding from a pane line
+func trimBox(line string) string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "│")
+	line = strings.TrimSuffix(line, "│")
+	return strings.TrimSpace(line)
+}
+
+// filePath returns the relative path of a file named by its base name in
+// an edit question, from the header of the diff shown above it
+func filePath(lines []string, name string) string {
+	for i := len(lines) - 1; i >= 0 && i >= len(lines)-40; i-- {
+		line := trimBox(lines[i])
+		if line == name || strings.HasSuffix(line, "/"+name) {
+			return line
+		}
+	}
+	return name
+}
+
+func hasYesOption(lines []string) bool {
+	for _, line := range lines {
+		line = strings.TrimSpace(strings.TrimPrefix(line, "❯"))
+		if strings.HasPrefix(line, "1. Yes") {
+			return true
+		}
+	}
+	return false
+}
+
+// shellOperators chain or redirect commands; a Bash prefix rule never
+// matches a command containing them, so "Bash(go test:*)" can't approve
+// "go test ./... && rm -rf ~"
+var shellOperators = []string{"&&", "||", ";", "|", ">", "<", "`", "$("}
+
+// MatchPermission reports whether a prompt is covered by an allowlist of
+// rules in Claude's permission syntax: "Tool" allows any use of a tool,
+// "Tool(detail)" only that exact use, and a trailing "*" or ":*" in the
+// detail matches by prefix, as in "Bash(go test:*)". Prefix rules never
+// match a prompt whose detail isn't fully shown.
+func MatchPermission(rules []string, p PermissionPrompt) bool {
+	for _, rule := range rules {
+		rule = strings.TrimSpace(rule)
+		tool, pattern, hasPattern := strings.Cut(rule, "(")
+		if tool != p.Tool || p.Tool == "" {
+			continue
+		}
+		if !hasPattern {
+			return true
+		}
+		pattern = strings.TrimSuffix(pattern, ")")
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			prefix = strings.TrimSuffix(prefix, ":")
+			if p.More || p.Tool == "Bash" && containsAny(p.Detail, shellOperators) {
+				continue
+			}
+			if strings.HasPrefix(p.Detail, prefix) {
+				return true
+			}
+		} else if p.Detail == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// ApprovePermission answers a permission dialog with its first option,
+// "Yes", allowing this one use only
+func ApprovePermission(sessionName string) error {
+	return logging.Command("tmux", "send-keys", "-t", sessionName, "1").Run()
+}
+
+func containsAny(s string, subs []string) bool {
+	for _, sub := range subs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
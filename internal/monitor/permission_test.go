@@ -0,0 +1,90 @@
+package monitor
+
+import "testing"
+
+const bashDialog = `⏺ Running the tests before committing.
+
+╭──────────────────────────────────────────────────────────────╮
+│ Bash command                                                 │
+│                                                              │
+│   go test ./internal/...                                     │
+│   Run the internal package tests                             │
+│                                                              │
+│ Do you want to proceed?                                      │
+│ ❯ 1. Yes                                                     │
+│   2. Yes, and don't ask again for go test commands in /repo  │
+│   3. No, and tell Claude what to do differently (esc)        │
+╰──────────────────────────────────────────────────────────────╯
+`
+
+const editDialog = `╭──────────────────────────────────────────────────────────────╮
+│ Edit file                                                    │
+│ ╭──────────────────────────────────────────────────────────╮ │
+│ │ internal/foo.go                                          │ │
+│ │  12 -   return nil                                       │ │
+│ │  12 +   return err                                       │ │
+│ ╰──────────────────────────────────────────────────────────╯ │
+│ Do you want to make this edit to foo.go?                     │
+│ ❯ 1. Yes                                                     │
+│   2. Yes, allow all edits during this session (shift+tab)    │
+│   3. No, and tell Claude what to do differently (esc)        │
+╰──────────────────────────────────────────────────────────────╯
+`
+
+const mcpDialog = `╭──────────────────────────────────────────────────────────────╮
+│ Tool use                                                     │
+│                                                              │
+│   linear__create_issue(title: "Flaky test")                  │
+│   Create a new issue                                         │
+│                                                              │
+│ Do you want to proceed?                                      │
+│ ❯ 1. Yes                                                     │
+│   2. No, and tell Claude what to do differently (esc)        │
+╰──────────────────────────────────────────────────────────────╯
+`
+
+func TestDetectPermissionPrompt(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    *PermissionPrompt
+	}{
+		{"bash", bashDialog, &PermissionPrompt{Tool: "Bash", Detail: "go test ./internal/..."}},
+		{"edit", editDialog, &PermissionPrompt{Tool: "Edit", Detail: "internal/foo.go"}},
+		{"mcp", mcpDialog, &PermissionPrompt{Tool: "linear__create_issue", Detail: `title: "Flaky test"`}},
+		{"answered", bashDialog + "\n⏺ Bash(go test ./internal/...)\n  ⎿  ok\n" + "\n\n\n\n\n\n\n\n\n\n\n\n\n\n> ", nil},
+		{"prompt", "╭────╮\n│ > │\n╰────╯\n", nil},
+	}
+	for _, tt := range tests {
+		got := DetectPermissionPrompt(tt.content)
+		if (got == nil) != (tt.want == nil) || got != nil && *got != *tt.want {
+			t.Errorf("%s: DetectPermissionPrompt() = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMatchPermission(t *testing.T) {
+	rules := []string{"Read", "Bash(go test:*)", "Bash(make lint)", "Edit(docs/*)"}
+	tests := []struct {
+		prompt PermissionPrompt
+		want   bool
+	}{
+		{PermissionPrompt{Tool: "Read", Detail: "main.go"}, true},
+		{PermissionPrompt{Tool: "Bash", Detail: "go test ./..."}, true},
+		{PermissionPrompt{Tool: "Bash", Detail: "make lint"}, true},
+		{PermissionPrompt{Tool: "Bash", Detail: "make lint-fix"}, false},
+		{PermissionPrompt{Tool: "Bash", Detail: "go test ./... && rm -rf /"}, false},
+		{PermissionPrompt{Tool: "Bash", Detail: "go test ./... | tee out"}, false},
+		{PermissionPrompt{Tool: "Bash", Detail: "go test ./...", More: true}, false},
+		{PermissionPrompt{Tool: "Bash", Detail: "go build ./..."}, false},
+		{PermissionPrompt{Tool: "Edit", Detail: "docs/index.md"}, true},
+		{PermissionPrompt{Tool: "Edit", Detail: "main.go"}, false},
+		{PermissionPrompt{Tool: "Write", Detail: "docs/new.md"}, false},
+		{PermissionPrompt{}, false},
+	}
+	for _, tt := range tests {
+		if got := MatchPermission(rules, tt.prompt); got != tt.want {
+			t.Errorf("MatchPermission(%s) = %v, want %v", tt.prompt, got, tt.want)
+		}
+	}
+}
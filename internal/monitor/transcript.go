@@ -0,0 +1,222 @@
+package monitor
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/badri/wt/internal/tmux"
+)
+
+// Activity states derived from a Claude transcript
+const (
+	ActivityThinking          = "thinking"           // Generating or running a tool
+	ActivityWaitingInput      = "waiting-input"      // Finished its turn, waiting for a prompt
+	ActivityWaitingPermission = "waiting-permission" // Blocked on a tool permission prompt
+	ActivityIdle              = "idle"               // No transcript activity for a while
+)
+
+// transcriptTailBytes is how much of the end of a transcript is read to find the last event
+const transcriptTailBytes = 256 * 1024
+
+// permissionPromptMarker is shown by Claude when a tool call needs approval
+const permissionPromptMarker = "Do you want to"
+
+// Transcript event kinds, as classified by lastTranscriptEvent
+const (
+	eventToolUse     = "tool_use"  // Assistant requested a tool call
+	eventAssistant   = "assistant" // Assistant text, end of turn
+	eventUser        = "user"      // User prompt or tool result
+	eventInterrupted = "interrupted"
+)
+
+// transcriptEntry is the subset of a Claude Code JSONL line we care about
+type transcriptEntry struct {
+	Type    string `json:"type"`
+	Message struct {
+		Content json.RawMessage `json:"content"`
+	} `json:"message"`
+}
+
+// contentBlock is one element of a message's content array
+type contentBlock struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// ClaudeProjectDir returns the directory where Claude Code keeps transcripts
+// for a working directory (non-alphanumerics in the path become dashes).
+func ClaudeProjectDir(worktreePath string) string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	encoded := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '-'
+	}, worktreePath)
+	return filepath.Join(home, ".claude", "projects", encoded)
+}
+
+// LatestTranscript returns the most recently modified transcript for a worktree
+func LatestTranscript(worktreePath string) (string, time.Time) {
+	dir := ClaudeProjectDir(worktreePath)
+	if dir == "" {
+		return "", time.Time{}
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", time.Time{}
+	}
+
+	var latest string
+	var latestTime time.Time
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".jsonl") {
+			continue
+		}
+		info, err := entry.Info()
+		if err == nil && info.ModTime().After(latestTime) {
+			latestTime = info.ModTime()
+			latest = filepath.Join(dir, entry.Name())
+		}
+	}
+	return latest, latestTime
+}
+
+// DetectActivity classifies a session from its Claude transcript. Unlike tmux
+// activity, Claude redrawing a permission prompt does not count as work.
+// Returns "" and -1 when the session has no transcript.
+func DetectActivity(sessionName, worktreePath string, idleThreshold time.Duration) (activity string, idleMinutes int) {
+	path, modTime := LatestTranscript(worktreePath)
+	if path == "" {
+		return "", -1
+	}
+	event, err := lastTranscriptEvent(path)
+	if err != nil || event == "" {
+		return "", -1
+	}
+
+	age := time.Since(modTime)
+	permissionPrompt := false
+	if event == eventToolUse {
+		content, err := tmux.CapturePane(sessionName, 30)
+		permissionPrompt = err == nil && strings.Contains(content, permissionPromptMarker)
+	}
+	return classifyActivity(event, age, permissionPrompt, idleThreshold), int(age.Minutes())
+}
+
+// classifyActivity maps the last transcript event and its age to an activity state
+func classifyActivity(event string, age time.Duration, permissionPrompt bool, idleThreshold time.Duration) string {
+	switch event {
+	case eventToolUse:
+		// A pending tool call is either running or waiting for approval
+		if permissionPrompt {
+			return ActivityWaitingPermission
+		}
+		return ActivityThinking
+	case eventUser:
+		if age < idleThreshold {
+			return ActivityThinking
+		}
+		return ActivityIdle
+	case eventAssistant, eventInterrupted:
+		if age < idleThreshold {
+			return ActivityWaitingInput
+		}
+		return ActivityIdle
+	default:
+		return ""
+	}
+}
+
+// lastTranscriptEvent returns the kind of the last user/assistant entry in a transcript
+func lastTranscriptEvent(path string) (string, error) {
+	data, err := transcriptTail(path)
+	if err != nil {
+		return "", err
+	}
+	return lastEventKind(data), nil
+}
+
+// transcriptTail reads the last transcriptTailBytes of a transcript
+func transcriptTail(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	offset := max(info.Size()-transcriptTailBytes, 0)
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return io.ReadAll(f)
+}
+
+// lastEventKind scans JSONL data backwards for the last conversational entry
+func lastEventKind(data []byte) string {
+	lines := bytes.Split(data, []byte("\n"))
+	for i := len(lines) - 1; i >= 0; i-- {
+		var entry transcriptEntry
+		if err := json.Unmarshal(lines[i], &entry); err != nil {
+			continue // blank, partial (first line of the tail) or malformed
+		}
+		switch entry.Type {
+		case "assistant":
+			for _, block := range contentBlocks(entry.Message.Content) {
+				if block.Type == "tool_use" {
+					return eventToolUse
+				}
+			}
+			return eventAssistant
+		case "user":
+			for _, block := range contentBlocks(entry.Message.Content) {
+				if strings.HasPrefix(block.Text, "[Request interrupted") {
+					return eventInterrupted
+				}
+			}
+			return eventUser
+		}
+	}
+	return ""
+}
+
+// contentBlocks decodes message content, which is either a string or an array of blocks
+func contentBlocks(raw json.RawMessage) []contentBlock {
+	var blocks []contentBlock
+	if err := json.Unmarshal(raw, &blocks); err == nil {
+		return blocks
+	}
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return []contentBlock{{Type: "text", Text: text}}
+	}
+	return nil
+}
+
+// ActivityIcon returns an icon for a transcript activity state
+func ActivityIcon(activity string) string {
+	switch activity {
+	case ActivityThinking:
+		return "🧠"
+	case ActivityWaitingInput:
+		return "💬"
+	case ActivityWaitingPermission:
+		return "🔐"
+	case ActivityIdle:
+		return "💤"
+	default:
+		return " "
+	}
+}
@@ -0,0 +1,144 @@
+package monitor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLastEventKind(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		want  string
+	}{
+		{
+			name: "pending tool call",
+			lines: []string{
+				`{"type":"user","message":{"role":"user","content":"fix the bug"}}`,
+				`{"type":"assistant","message":{"content":[{"type":"text","text":"Running tests"},{"type":"tool_use","name":"Bash"}]}}`,
+			},
+			want: eventToolUse,
+		},
+		{
+			name: "end of turn",
+			lines: []string{
+				`{"type":"assistant","message":{"content":[{"type":"text","text":"Done."}]}}`,
+				`{"type":"summary","summary":"Bug fix"}`,
+				``,
+			},
+			want: eventAssistant,
+		},
+		{
+			name: "tool result",
+			lines: []string{
+				`{"type":"assistant","message":{"content":[{"type":"tool_use","name":"Read"}]}}`,
+				`{"type":"user","message":{"content":[{"type":"tool_result","content":"file contents"}]}}`,
+			},
+			want: eventUser,
+		},
+		{
+			name: "interrupted",
+			lines: []string{
+				`{"type":"user","message":{"content":[{"type":"text","text":"[Request interrupted by user]"}]}}`,
+			},
+			want: eventInterrupted,
+		},
+		{
+			name:  "partial first line is skipped",
+			lines: []string{`"content":"trunc`, `{"type":"user","message":{"content":"hi"}}`},
+			want:  eventUser,
+		},
+		{
+			name:  "no conversational entries",
+			lines: []string{`{"type":"summary"}`},
+			want:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := lastEventKind([]byte(strings.Join(tt.lines, "\n")))
+			if got != tt.want {
+				t.Errorf("lastEventKind() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassifyActivity(t *testing.T) {
+	threshold := 5 * time.Minute
+	tests := []struct {
+		event      string
+		age        time.Duration
+		permission bool
+		want       string
+	}{
+		{eventToolUse, time.Minute, true, ActivityWaitingPermission},
+		{eventToolUse, 20 * time.Minute, true, ActivityWaitingPermission},
+		{eventToolUse, 20 * time.Minute, false, ActivityThinking}, // long-running tool
+		{eventUser, time.Minute, false, ActivityThinking},
+		{eventUser, 10 * time.Minute, false, ActivityIdle},
+		{eventAssistant, time.Minute, false, ActivityWaitingInput},
+		{eventAssistant, 10 * time.Minute, false, ActivityIdle},
+		{eventInterrupted, time.Minute, false, ActivityWaitingInput},
+		{"", time.Minute, false, ""},
+	}
+
+	for _, tt := range tests {
+		got := classifyActivity(tt.event, tt.age, tt.permission, threshold)
+		if got != tt.want {
+			t.Errorf("classifyActivity(%q, %v, %v) = %q, want %q", tt.event, tt.age, tt.permission, got, tt.want)
+		}
+	}
+}
+
+func TestClaudeProjectDir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	got := ClaudeProjectDir("/Users/me/worktrees/toast.v2")
+	want := filepath.Join(home, ".claude", "projects", "-Users-me-worktrees-toast-v2")
+	if got != want {
+		t.Errorf("ClaudeProjectDir() = %q, want %q", got, want)
+	}
+}
+
+func TestLatestTranscript(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	worktree := "/tmp/worktrees/toast"
+	if path, _ := LatestTranscript(worktree); path != "" {
+		t.Errorf("LatestTranscript() with no transcripts = %q, want empty", path)
+	}
+
+	dir := ClaudeProjectDir(worktree)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	older := filepath.Join(dir, "old.jsonl")
+	newer := filepath.Join(dir, "new.jsonl")
+	for _, p := range []string{older, newer} {
+		if err := os.WriteFile(p, []byte(`{"type":"user","message":{"content":"hi"}}`+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	past := time.Now().Add(-time.Hour)
+	os.Chtimes(older, past, past)
+
+	path, modTime := LatestTranscript(worktree)
+	if path != newer {
+		t.Errorf("LatestTranscript() = %q, want %q", path, newer)
+	}
+	if time.Since(modTime) > time.Minute {
+		t.Errorf("LatestTranscript() modTime = %v, want recent", modTime)
+	}
+
+	kind, err := lastTranscriptEvent(path)
+	if err != nil || kind != eventUser {
+		t.Errorf("lastTranscriptEvent() = %q, %v; want %q", kind, err, eventUser)
+	}
+}
@@ -0,0 +1,77 @@
+package monorepo
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// codeOwnersPaths are where GitHub looks for CODEOWNERS, in order
+var codeOwnersPaths = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// OwnerRule is one CODEOWNERS line
+type OwnerRule struct {
+	Pattern string
+	Owners  []string
+}
+
+// LoadCodeOwners reads the repository's CODEOWNERS file. Returns nil rules
+// if there is none.
+func LoadCodeOwners(repoPath string) ([]OwnerRule, error) {
+	for _, rel := range codeOwnersPaths {
+		data, err := os.ReadFile(filepath.Join(repoPath, rel))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return ParseCodeOwners(string(data)), nil
+	}
+	return nil, nil
+}
+
+// ParseCodeOwners parses CODEOWNERS content, skipping comments and blank lines
+func ParseCodeOwners(content string) []OwnerRule {
+	var rules []OwnerRule
+	for _, line := range strings.Split(content, "\n") {
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		rules = append(rules, OwnerRule{Pattern: fields[0], Owners: fields[1:]})
+	}
+	return rules
+}
+
+// Reviewers returns the owners of the given files as gh reviewer names
+// ("user" or "org/team"), sorted. As on GitHub, the last matching rule
+// wins for each file. Email owners can't be requested and are skipped.
+func Reviewers(rules []OwnerRule, files []string) []string {
+	seen := make(map[string]bool)
+	for _, f := range files {
+		var owners []string
+		for _, r := range rules {
+			if Match(r.Pattern, f) {
+				owners = r.Owners
+			}
+		}
+		for _, o := range owners {
+			if !strings.HasPrefix(o, "@") {
+				continue
+			}
+			seen[strings.TrimPrefix(o, "@")] = true
+		}
+	}
+
+	reviewers := make([]string, 0, len(seen))
+	for r := range seen {
+		reviewers = append(reviewers, r)
+	}
+	sort.Strings(reviewers)
+	return reviewers
+}
@@ -0,0 +1,138 @@
+// Package monorepo checks that a bead's changes stay within the path scopes
+// its "scope:<name>" labels select, and maps changed files to their
+// CODEOWNERS. Scope patterns and CODEOWNERS patterns share one syntax: a
+// gitignore-style subset with "*", "?", "**", leading "/" anchoring and
+// trailing "/" for directories.
+package monorepo
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/project"
+)
+
+// ScopeLabelPrefix marks bead labels that select a scope, e.g. "scope:api"
+const ScopeLabelPrefix = "scope:"
+
+// Check is the result of comparing changed files with a bead's scopes
+type Check struct {
+	Scopes     []string // scopes selected by the bead's labels
+	Unknown    []string // selected scopes the project doesn't declare
+	OutOfScope []string // changed files matching none of the scopes
+}
+
+// ScopesFromLabels returns the scope names selected by bead labels
+func ScopesFromLabels(labels []string) []string {
+	var scopes []string
+	for _, l := range labels {
+		if name, ok := strings.CutPrefix(l, ScopeLabelPrefix); ok && name != "" {
+			scopes = append(scopes, name)
+		}
+	}
+	return scopes
+}
+
+// CheckScope compares files with the patterns of the given scopes. Files
+// are out of scope when no pattern of any selected, declared scope matches.
+func CheckScope(cfg *project.Monorepo, scopes, files []string) *Check {
+	check := &Check{Scopes: scopes}
+	var patterns []string
+	for _, name := range scopes {
+		p, ok := cfg.Scopes[name]
+		if !ok {
+			check.Unknown = append(check.Unknown, name)
+			continue
+		}
+		patterns = append(patterns, p...)
+	}
+	if len(patterns) == 0 {
+		return check
+	}
+
+	for _, f := range files {
+		inScope := false
+		for _, p := range patterns {
+			if Match(p, f) {
+				inScope = true
+				break
+			}
+		}
+		if !inScope {
+			check.OutOfScope = append(check.OutOfScope, f)
+		}
+	}
+	return check
+}
+
+// ChangedFiles returns the files changed on the worktree's branch since it
+// diverged from base.
+func ChangedFiles(worktreePath, base string) ([]string, error) {
+	cmd := logging.Command("git", "-C", worktreePath, "diff", "--name-only", base+"...HEAD")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("listing changed files: %w", err)
+	}
+	var files []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, line)
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// Match reports whether a repo-relative file path matches a pattern.
+// Patterns without a slash (other than a trailing one) match at any depth;
+// a pattern matching a directory matches every file under it.
+func Match(pattern, path string) bool {
+	re, err := patternRegexp(pattern)
+	if err != nil {
+		return false
+	}
+	return re.MatchString(path)
+}
+
+func patternRegexp(pattern string) (*regexp.Regexp, error) {
+	p := strings.TrimSpace(pattern)
+	dirOnly := strings.HasSuffix(p, "/")
+	p = strings.TrimSuffix(p, "/")
+	anchored := strings.HasPrefix(p, "/") || strings.Contains(p, "/")
+	p = strings.TrimPrefix(p, "/")
+	if p == "" {
+		return nil, fmt.Errorf("empty pattern")
+	}
+
+	var b strings.Builder
+	if anchored {
+		b.WriteString("^")
+	} else {
+		b.WriteString("^(?:.*/)?")
+	}
+	for i := 0; i < len(p); i++ {
+		switch {
+		case strings.HasPrefix(p[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(p[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case p[i] == '*':
+			b.WriteString("[^/]*")
+		case p[i] == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(p[i : i+1]))
+		}
+	}
+	if dirOnly {
+		b.WriteString("/.*$")
+	} else {
+		b.WriteString("(?:/.*)?$")
+	}
+	return regexp.Compile(b.String())
+}
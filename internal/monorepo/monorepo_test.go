@@ -0,0 +1,128 @@
+package monorepo
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/badri/wt/internal/project"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"services/api/", "services/api/main.go", true},
+		{"services/api/", "services/api", false},
+		{"services/api", "services/api/handlers/user.go", true},
+		{"services/api/**", "services/api/handlers/user.go", true},
+		{"/services/api/", "other/services/api/main.go", false},
+		{"services/api/", "services/apiv2/main.go", false},
+		{"*.md", "README.md", true},
+		{"*.md", "docs/guide/intro.md", true},
+		{"*.md", "main.go", false},
+		{"docs/", "packages/web/docs/index.html", true},
+		{"/docs/", "packages/web/docs/index.html", false},
+		{"apps/*/package.json", "apps/web/package.json", true},
+		{"apps/*/package.json", "apps/web/sub/package.json", false},
+		{"**/testdata/", "internal/auth/testdata/token.json", true},
+		{"go.mod", "go.mod", true},
+		{"go.mod", "tools/go.mod", true},
+		{"/go.mod", "tools/go.mod", false},
+		{"*", "anything/at/all.txt", true},
+	}
+	for _, tc := range tests {
+		if got := Match(tc.pattern, tc.path); got != tc.want {
+			t.Errorf("Match(%q, %q) = %v, want %v", tc.pattern, tc.path, got, tc.want)
+		}
+	}
+}
+
+func TestScopesFromLabels(t *testing.T) {
+	got := ScopesFromLabels([]string{"bug", "scope:api", "scope:", "scope:web"})
+	want := []string{"api", "web"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ScopesFromLabels() = %v, want %v", got, want)
+	}
+}
+
+func TestCheckScope(t *testing.T) {
+	cfg := &project.Monorepo{Scopes: map[string][]string{
+		"api": {"services/api/", "libs/shared/"},
+		"web": {"apps/web/"},
+	}}
+	files := []string{"apps/web/index.ts", "go.mod", "libs/shared/log.go", "services/api/main.go"}
+
+	check := CheckScope(cfg, []string{"api"}, files)
+	if want := []string{"apps/web/index.ts", "go.mod"}; !reflect.DeepEqual(check.OutOfScope, want) {
+		t.Errorf("OutOfScope = %v, want %v", check.OutOfScope, want)
+	}
+
+	check = CheckScope(cfg, []string{"api", "web", "mobile"}, files)
+	if want := []string{"go.mod"}; !reflect.DeepEqual(check.OutOfScope, want) {
+		t.Errorf("OutOfScope = %v, want %v", check.OutOfScope, want)
+	}
+	if want := []string{"mobile"}; !reflect.DeepEqual(check.Unknown, want) {
+		t.Errorf("Unknown = %v, want %v", check.Unknown, want)
+	}
+
+	// Only unknown scopes: nothing to check against
+	check = CheckScope(cfg, []string{"mobile"}, files)
+	if len(check.OutOfScope) != 0 {
+		t.Errorf("OutOfScope = %v, want none for unknown scopes", check.OutOfScope)
+	}
+}
+
+func TestReviewers(t *testing.T) {
+	rules := ParseCodeOwners(`# Default owners
+*                 @acme/core
+
+services/api/     @alice @acme/backend   # API team
+apps/web/         @bob ops@example.com
+*.md              @docs-writer
+`)
+	if len(rules) != 4 {
+		t.Fatalf("ParseCodeOwners() returned %d rules, want 4", len(rules))
+	}
+
+	got := Reviewers(rules, []string{"services/api/main.go", "apps/web/index.ts"})
+	want := []string{"acme/backend", "alice", "bob"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Reviewers() = %v, want %v", got, want)
+	}
+
+	// The last matching rule wins, so the API owners don't own its README
+	got = Reviewers(rules, []string{"services/api/README.md"})
+	if want := []string{"docs-writer"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Reviewers(README) = %v, want %v", got, want)
+	}
+
+	if got := Reviewers(rules, nil); len(got) != 0 {
+		t.Errorf("Reviewers(no files) = %v, want none", got)
+	}
+}
+
+func TestLoadCodeOwners(t *testing.T) {
+	repo := t.TempDir()
+	rules, err := LoadCodeOwners(repo)
+	if err != nil || rules != nil {
+		t.Fatalf("LoadCodeOwners(no file) = %v, %v; want nil, nil", rules, err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(repo, ".github"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, ".github", "CODEOWNERS"), []byte("* @acme/core\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	rules, err = LoadCodeOwners(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rules) != 1 || rules[0].Pattern != "*" {
+		t.Errorf("LoadCodeOwners() = %v, want the .github/CODEOWNERS rule", rules)
+	}
+}
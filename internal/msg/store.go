@@ -16,6 +16,7 @@ const (
 	SubjectDone     Subject = "DONE"
 	SubjectStuck    Subject = "STUCK"
 	SubjectProgress Subject = "PROGRESS"
+	SubjectAck      Subject = "ACK" // Acknowledges a worker's signal (wt ack)
 )
 
 // Message represents a single message in the store.
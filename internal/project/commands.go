@@ -0,0 +1,151 @@
+package project
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+// CommandVars are the session values hook and test env commands can use as
+// Go template variables, e.g. "docker compose -p {{.Session}} up -d" or
+// "{{if .Bead}}bd show {{.Bead}}{{end}}"
+type CommandVars struct {
+	Worktree   string
+	Bead       string
+	Session    string
+	Project    string
+	Branch     string
+	PortOffset int
+}
+
+// commandVarNames are the fields of CommandVars, for validation
+var commandVarNames = map[string]bool{
+	"Worktree": true, "Bead": true, "Session": true, "Project": true, "Branch": true, "PortOffset": true,
+}
+
+// ExpandCommand evaluates the template variables and conditionals in a
+// command. A command without "{{" is returned unchanged.
+func ExpandCommand(command string, vars CommandVars) (string, error) {
+	if !strings.Contains(command, "{{") {
+		return command, nil
+	}
+	tmpl, err := template.New("command").Option("missingkey=error").Parse(command)
+	if err != nil {
+		return "", fmt.Errorf("invalid command template %q: %w", command, err)
+	}
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, vars); err != nil {
+		return "", fmt.Errorf("expanding command %q: %w", command, err)
+	}
+	return sb.String(), nil
+}
+
+// ValidateCommand checks that a command template parses, uses only known
+// variables, in every branch of its conditionals, and expands
+func ValidateCommand(command string) error {
+	if !strings.Contains(command, "{{") {
+		return nil
+	}
+	tmpl, err := template.New("command").Parse(command)
+	if err != nil {
+		return fmt.Errorf("invalid template: %w", err)
+	}
+	if name := unknownField(tmpl.Tree.Root); name != "" {
+		return fmt.Errorf("unknown variable {{.%s}} (known: .Worktree, .Bead, .Session, .Project, .Branch, .PortOffset)", name)
+	}
+	sample := CommandVars{Worktree: "/tmp/worktree", Bead: "proj-abc", Session: "toast", Project: "proj", Branch: "proj-abc", PortOffset: 1000}
+	if _, err := ExpandCommand(command, sample); err != nil {
+		return err
+	}
+	return nil
+}
+
+// unknownField returns the first field referenced in a template that
+// CommandVars lacks
+func unknownField(node parse.Node) string {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return ""
+		}
+		for _, c := range n.Nodes {
+			if name := unknownField(c); name != "" {
+				return name
+			}
+		}
+	case *parse.ActionNode:
+		return unknownField(n.Pipe)
+	case *parse.PipeNode:
+		if n == nil {
+			return ""
+		}
+		for _, c := range n.Cmds {
+			if name := unknownField(c); name != "" {
+				return name
+			}
+		}
+	case *parse.CommandNode:
+		for _, a := range n.Args {
+			if name := unknownField(a); name != "" {
+				return name
+			}
+		}
+	case *parse.FieldNode:
+		if !commandVarNames[n.Ident[0]] {
+			return n.Ident[0]
+		}
+	case *parse.IfNode:
+		return unknownBranch(&n.BranchNode)
+	case *parse.WithNode:
+		return unknownBranch(&n.BranchNode)
+	case *parse.RangeNode:
+		return unknownBranch(&n.BranchNode)
+	}
+	return ""
+}
+
+func unknownBranch(n *parse.BranchNode) string {
+	for _, c := range []parse.Node{n.Pipe, n.List, n.ElseList} {
+		if name := unknownField(c); name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// ValidateCommands checks the templates of the project's hook, test env and
+// window commands
+func (p *Project) ValidateCommands() error {
+	if err := p.validateWindows(); err != nil {
+		return err
+	}
+	var commands [][2]string
+	if te := p.TestEnv; te != nil {
+		commands = append(commands,
+			[2]string{"test_env.setup", te.Setup},
+			[2]string{"test_env.teardown", te.Teardown},
+			[2]string{"test_env.pause", te.Pause},
+			[2]string{"test_env.resume", te.Resume},
+			[2]string{"test_env.health_check", te.HealthCheck},
+			[2]string{"test_env.status", te.Status},
+			[2]string{"test_env.test", te.Test})
+	}
+	if h := p.Hooks; h != nil {
+		for i, c := range h.OnCreate {
+			commands = append(commands, [2]string{fmt.Sprintf("hooks.on_create[%d]", i), c})
+		}
+		for i, c := range h.OnClose {
+			commands = append(commands, [2]string{fmt.Sprintf("hooks.on_close[%d]", i), c})
+		}
+	}
+	for i, w := range p.Windows {
+		commands = append(commands, [2]string{fmt.Sprintf("windows[%d].command", i), w.Command})
+	}
+	for _, c := range commands {
+		if err := ValidateCommand(c[1]); err != nil {
+			return fmt.Errorf("%s: %w", c[0], err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package project
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExpandCommand(t *testing.T) {
+	vars := CommandVars{Worktree: "/wt/api/toast", Bead: "api-7", Session: "toast", Project: "api", Branch: "api-7", PortOffset: 1100}
+	tests := []struct {
+		command string
+		want    string
+	}{
+		{"npm test", "npm test"},
+		{"docker compose -p {{.Session}} up -d", "docker compose -p toast up -d"},
+		{"cd {{.Worktree}} && echo {{.PortOffset}}", "cd /wt/api/toast && echo 1100"},
+		{`{{if eq .Project "api"}}make api{{else}}make{{end}}`, "make api"},
+		{"{{if .Bead}}bd show {{.Bead}}{{end}}", "bd show api-7"},
+		{"awk '{print $1}' ${{.Branch}}", "awk '{print $1}' $api-7"},
+	}
+	for _, tt := range tests {
+		got, err := ExpandCommand(tt.command, vars)
+		if err != nil || got != tt.want {
+			t.Errorf("ExpandCommand(%q) = %q, %v, want %q", tt.command, got, err, tt.want)
+		}
+	}
+
+	if _, err := ExpandCommand("echo {{.Nope}}", vars); err == nil {
+		t.Error("ExpandCommand() with an unknown variable should fail")
+	}
+}
+
+func TestValidateCommands(t *testing.T) {
+	p := &Project{
+		Name:    "api",
+		TestEnv: &TestEnv{Setup: "docker compose -p {{.Session}} up -d", Teardown: "docker compose down"},
+		Hooks:   &Hooks{OnCreate: []string{"npm ci", "{{if .Bead}}echo {{.Bead}}{{end}}"}},
+		Windows: []Window{{Name: "tests", Command: "npm test -- --watch"}, {Name: "logs", Command: "docker compose -p {{.Session}} logs -f"}, {Name: "shell"}},
+	}
+	if err := p.ValidateCommands(); err != nil {
+		t.Fatalf("ValidateCommands() error: %v", err)
+	}
+
+	bad := []struct {
+		mutate func(*Project)
+		field  string
+	}{
+		{func(p *Project) { p.TestEnv.Setup = "up {{.Sesion}}" }, "test_env.setup"},
+		{func(p *Project) { p.Hooks.OnClose = []string{"ok", "{{if .Bead}}"} }, "hooks.on_close[1]"},
+		// Unknown fields are caught in branches the sample values don't take
+		{func(p *Project) { p.TestEnv.HealthCheck = "{{if not .Bead}}{{.Typo}}{{end}}" }, "test_env.health_check"},
+		{func(p *Project) { p.Windows = []Window{{Name: "logs", Command: "logs {{.Sesion}}"}} }, "windows[0].command"},
+		{func(p *Project) { p.Windows = []Window{{Name: "tests"}, {Name: " "}} }, "windows[1]: name is required"},
+		{func(p *Project) { p.Windows = []Window{{Name: "api:logs"}} }, "can't contain"},
+		{func(p *Project) { p.Windows = []Window{{Name: "tests"}, {Name: "tests"}} }, "duplicate name"},
+	}
+	for _, b := range bad {
+		q := *p
+		te, h := *p.TestEnv, *p.Hooks
+		q.TestEnv, q.Hooks = &te, &h
+		b.mutate(&q)
+		err := q.ValidateCommands()
+		if err == nil || !strings.Contains(err.Error(), b.field) {
+			t.Errorf("ValidateCommands() = %v, want an error about %s", err, b.field)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package project
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultContextMaxBytes is how much of each context file is inlined into
+// worker prompts when context_max_bytes is not set.
+const DefaultContextMaxBytes = 8 * 1024
+
+// ContextPrompt returns a "## Project Context" prompt section with the
+// project's context_files, read relative to dir (a worktree, or the repo
+// if dir is empty). Longer files are cut off with a pointer to the rest;
+// missing files are skipped. Returns "" if there is nothing to include.
+func (p *Project) ContextPrompt(dir string) string {
+	if p == nil || len(p.ContextFiles) == 0 {
+		return ""
+	}
+	if dir == "" {
+		dir = p.RepoPath()
+	}
+	maxBytes := p.ContextMaxBytes
+	if maxBytes <= 0 {
+		maxBytes = DefaultContextMaxBytes
+	}
+
+	var sb strings.Builder
+	for _, rel := range p.ContextFiles {
+		if !filepath.IsLocal(rel) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, rel))
+		if err != nil {
+			continue
+		}
+		content := strings.TrimSpace(string(data))
+		if content == "" {
+			continue
+		}
+
+		sb.WriteString(fmt.Sprintf("### %s\n", rel))
+		if len(content) > maxBytes {
+			cut := maxBytes
+			for cut > 0 && !utf8.RuneStart(content[cut]) {
+				cut--
+			}
+			sb.WriteString(content[:cut])
+			sb.WriteString(fmt.Sprintf("\n\n(Truncated; read %s for the rest.)", rel))
+		} else {
+			sb.WriteString(content)
+		}
+		sb.WriteString("\n\n")
+	}
+	if sb.Len() == 0 {
+		return ""
+	}
+
+	return "## Project Context\nFollow the conventions in these project documents.\n\n" + sb.String()
+}
@@ -0,0 +1,44 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestContextPrompt(t *testing.T) {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "docs"), 0755)
+	os.WriteFile(filepath.Join(dir, "docs", "ARCHITECTURE.md"), []byte("Handlers live in internal/api.\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "CONTRIBUTING.md"), []byte(strings.Repeat("x", 100)), 0644)
+
+	var nilProj *Project
+	if got := nilProj.ContextPrompt(dir); got != "" {
+		t.Errorf("nil project ContextPrompt() = %q, want empty", got)
+	}
+
+	proj := &Project{
+		ContextFiles:    []string{"docs/ARCHITECTURE.md", "missing.md", "../outside.md", "CONTRIBUTING.md"},
+		ContextMaxBytes: 40,
+	}
+	got := proj.ContextPrompt(dir)
+
+	for _, want := range []string{
+		"## Project Context",
+		"### docs/ARCHITECTURE.md\nHandlers live in internal/api.",
+		"### CONTRIBUTING.md\n" + strings.Repeat("x", 40) + "\n\n(Truncated; read CONTRIBUTING.md for the rest.)",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("ContextPrompt() missing %q in:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "missing.md") || strings.Contains(got, "outside.md") {
+		t.Errorf("ContextPrompt() should skip missing and non-local files:\n%s", got)
+	}
+
+	proj.ContextFiles = []string{"missing.md"}
+	if got := proj.ContextPrompt(dir); got != "" {
+		t.Errorf("ContextPrompt() with no readable files = %q, want empty", got)
+	}
+}
@@ -0,0 +1,88 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// GuidelinesFile is the name of a project's worker guidelines file, kept in
+// the wt config dir next to the project config
+const GuidelinesFile = "WORKERS.md"
+
+// DefaultGuidelinesMaxAge is how many days WORKERS.md may go unedited
+// before wt doctor calls it stale, when guidelines_max_age is not set
+const DefaultGuidelinesMaxAge = 90
+
+// GuidelinesTemplate is what 'wt project guidelines edit' starts a new
+// WORKERS.md with. Saved unchanged, it is discarded.
+const GuidelinesTemplate = `# Worker guidelines
+
+Rules for every worker of this project, added to their initial prompts.
+Replace this text with orchestration rules that don't belong in the repo's
+CLAUDE.md, for example:
+
+- Run the integration tests with the test env up before signaling ready
+- Don't touch the generated files under api/gen
+`
+
+// GuidelinesPath returns where a project's WORKERS.md lives
+func (m *Manager) GuidelinesPath(name string) string {
+	return filepath.Join(m.projectsDir, name, GuidelinesFile)
+}
+
+// GuidelinesPath returns the project's WORKERS.md, or "" for a project
+// not loaded through a Manager
+func (p *Project) GuidelinesPath() string {
+	if p == nil {
+		return ""
+	}
+	return p.guidelinesFile
+}
+
+// Guidelines returns the project's worker guidelines, "" if it has none
+func (p *Project) Guidelines() string {
+	path := p.GuidelinesPath()
+	if path == "" {
+		return ""
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// GuidelinesPrompt returns a "## Worker Guidelines" prompt section with the
+// project's WORKERS.md, or "" if there is none
+func (p *Project) GuidelinesPrompt() string {
+	guidelines := p.Guidelines()
+	if guidelines == "" {
+		return ""
+	}
+	return "## Worker Guidelines\nThe orchestrator's rules for this project's workers. Follow them.\n\n" + guidelines + "\n\n"
+}
+
+// GuidelinesMaxAgeDays returns after how many days without an edit
+// WORKERS.md counts as stale
+func (p *Project) GuidelinesMaxAgeDays() int {
+	if p.GuidelinesMaxAge > 0 {
+		return p.GuidelinesMaxAge
+	}
+	return DefaultGuidelinesMaxAge
+}
+
+// GuidelinesAge returns how long ago WORKERS.md was last edited, and false
+// if the project has none
+func (p *Project) GuidelinesAge(now time.Time) (time.Duration, bool) {
+	path := p.GuidelinesPath()
+	if path == "" {
+		return 0, false
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, false
+	}
+	return now.Sub(info.ModTime()), true
+}
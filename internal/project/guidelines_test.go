@@ -0,0 +1,69 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGuidelinesPrompt(t *testing.T) {
+	cfg, tmpDir := setupTestConfig(t)
+	repoDir := setupTestRepo(t)
+	mgr := NewManager(cfg)
+	if _, err := mgr.Add("myproject", repoDir, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	proj, err := mgr.Get("myproject")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(tmpDir, "projects", "myproject", GuidelinesFile)
+	if proj.GuidelinesPath() != path {
+		t.Errorf("GuidelinesPath() = %q, want %q", proj.GuidelinesPath(), path)
+	}
+	if got := proj.GuidelinesPrompt(); got != "" {
+		t.Errorf("GuidelinesPrompt() without WORKERS.md = %q, want empty", got)
+	}
+	if _, ok := proj.GuidelinesAge(time.Now()); ok {
+		t.Error("GuidelinesAge() reported a missing WORKERS.md")
+	}
+
+	os.MkdirAll(filepath.Dir(path), 0755)
+	os.WriteFile(path, []byte("\n- Never edit api/gen\n"), 0644)
+	got := proj.GuidelinesPrompt()
+	if !strings.HasPrefix(got, "## Worker Guidelines\n") || !strings.HasSuffix(got, "\n\n- Never edit api/gen\n\n") {
+		t.Errorf("GuidelinesPrompt() = %q", got)
+	}
+
+	// Projects are listed from their .json files only
+	projects, err := mgr.List()
+	if err != nil || len(projects) != 1 {
+		t.Fatalf("List() = %d projects, %v; want 1", len(projects), err)
+	}
+
+	if err := mgr.Delete("myproject"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Dir(path)); !os.IsNotExist(err) {
+		t.Errorf("Delete() left the guidelines directory: %v", err)
+	}
+}
+
+func TestGuidelinesWithoutManager(t *testing.T) {
+	var nilProj *Project
+	if got := nilProj.GuidelinesPrompt(); got != "" {
+		t.Errorf("nil project GuidelinesPrompt() = %q, want empty", got)
+	}
+	if got := (&Project{Name: "x"}).GuidelinesPrompt(); got != "" {
+		t.Errorf("GuidelinesPrompt() of an unloaded project = %q, want empty", got)
+	}
+	if got := (&Project{}).GuidelinesMaxAgeDays(); got != DefaultGuidelinesMaxAge {
+		t.Errorf("GuidelinesMaxAgeDays() = %d, want %d", got, DefaultGuidelinesMaxAge)
+	}
+	if got := (&Project{GuidelinesMaxAge: 14}).GuidelinesMaxAgeDays(); got != 14 {
+		t.Errorf("GuidelinesMaxAgeDays() = %d, want 14", got)
+	}
+}
@@ -4,26 +4,50 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 
 	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/logging"
 )
 
 // Project represents a registered project configuration.
 type Project struct {
-	Name          string   `json:"name"`
-	Repo          string   `json:"repo"`                     // Local path to the repository (may include ~)
-	RepoURL       string   `json:"repo_url,omitempty"`       // Canonical git remote URL for repo identity
-	DefaultBranch string   `json:"default_branch,omitempty"` // Branch to create worktrees from and merge back to
-	BeadsPrefix   string   `json:"beads_prefix,omitempty"`
-	MergeMode     string   `json:"merge_mode,omitempty"`
-	RequireCI     bool     `json:"require_ci,omitempty"`
-	AutoMerge     bool     `json:"auto_merge_on_green,omitempty"`
-	AutoRebase    string   `json:"auto_rebase,omitempty"` // "true" (default), "false", or "prompt"
-	TestEnv       *TestEnv `json:"test_env,omitempty"`
-	Hooks         *Hooks   `json:"hooks,omitempty"`
+	Name             string                   `json:"name"`
+	Repo             string                   `json:"repo"`                     // Local path to the repository (may include ~)
+	RepoURL          string                   `json:"repo_url,omitempty"`       // Canonical git remote URL for repo identity
+	DefaultBranch    string                   `json:"default_branch,omitempty"` // Branch to create worktrees from and merge back to
+	WorktreeRoot     string                   `json:"worktree_root,omitempty"`  // Overrides the global worktree_root for this project's worktrees
+	BeadsPrefix      string                   `json:"beads_prefix,omitempty"`
+	MergeMode        string                   `json:"merge_mode,omitempty"`
+	RequireCI        bool                     `json:"require_ci,omitempty"`
+	AutoMerge        bool                     `json:"auto_merge_on_green,omitempty"`
+	AutoRebase       string                   `json:"auto_rebase,omitempty"` // "true" (default), "false", or "prompt"
+	BeadClose        string                   `json:"bead_close,omitempty"`  // When 'wt done' closes the bead: "done" (default), "merged" or "manual"
+	TestEnv          *TestEnv                 `json:"test_env,omitempty"`
+	Hooks            *Hooks                   `json:"hooks,omitempty"`
+	GitHooks         *GitHooks                `json:"git_hooks,omitempty"`
+	Signing          *Signing                 `json:"signing,omitempty"`
+	Provision        *Provision               `json:"provision,omitempty"`
+	Monorepo         *Monorepo                `json:"monorepo,omitempty"`
+	Sparse           *Sparse                  `json:"sparse,omitempty"`
+	BeadTemplates    map[string]*BeadTemplate `json:"bead_templates,omitempty"`     // Templates for wt create --template
+	SummaryComment   bool                     `json:"summary_comment,omitempty"`    // Post session end summaries as bead comments
+	DraftPRs         bool                     `json:"draft_prs,omitempty"`          // pr-review PRs open as drafts, marked ready once checks pass
+	MergeWait        string                   `json:"merge_wait,omitempty"`         // pr-auto 'wt done' waits this long for the PR to merge, e.g. "30m"
+	ActivityComments bool                     `json:"activity_comments,omitempty"`  // Post session lifecycle events as bead comments
+	ContextFiles     []string                 `json:"context_files,omitempty"`      // Repo files inlined into worker prompts, e.g. docs/ARCHITECTURE.md
+	ContextMaxBytes  int                      `json:"context_max_bytes,omitempty"`  // Per-file limit (default DefaultContextMaxBytes)
+	GuidelinesMaxAge int                      `json:"guidelines_max_age,omitempty"` // Days before wt doctor calls WORKERS.md stale (default DefaultGuidelinesMaxAge)
+	Agent            string                   `json:"agent,omitempty"`              // Worker agent: claude (default), aider or shell
+	AgentCmd         string                   `json:"agent_cmd,omitempty"`          // Overrides the agent's start command
+	AutoApprove      []string                 `json:"auto_approve,omitempty"`       // Tool uses wt watch approves in Claude permission prompts, e.g. "Bash(go test:*)"
+	ReadyFilter      string                   `json:"ready_filter,omitempty"`       // Extra gate on bd ready beads, e.g. "estimate > 0 and not labels has needs-design"
+	Verify           *Verify                  `json:"verify,omitempty"`
+	Auto             *Auto                    `json:"auto,omitempty"`
+	Windows          []Window                 `json:"windows,omitempty"` // Extra tmux windows of each session, e.g. tests or logs
+
+	guidelinesFile string // WORKERS.md in the wt config dir, set by Manager.Get
 }
 
 // AutoRebaseMode returns the effective auto-rebase mode for the project.
@@ -35,12 +59,75 @@ func (p *Project) AutoRebaseMode() string {
 	return p.AutoRebase
 }
 
+// Bead close policies of 'wt done' (bead_close)
+const (
+	BeadCloseDone   = "done"   // close as soon as 'wt done' merges or opens the PR
+	BeadCloseMerged = "merged" // close once the work is merged; PRs are tracked until then
+	BeadCloseManual = "manual" // leave the bead open until an explicit 'wt close'
+)
+
+// BeadCloseMode returns the project's bead close policy, "done" if unset
+func (p *Project) BeadCloseMode() string {
+	if p.BeadClose == "" {
+		return BeadCloseDone
+	}
+	return p.BeadClose
+}
+
 // TestEnv contains test environment configuration.
 type TestEnv struct {
-	Setup       string `json:"setup,omitempty"`
-	Teardown    string `json:"teardown,omitempty"`
-	PortEnv     string `json:"port_env,omitempty"`
-	HealthCheck string `json:"health_check,omitempty"`
+	Setup       string         `json:"setup,omitempty"`
+	Teardown    string         `json:"teardown,omitempty"`
+	Pause       string         `json:"pause,omitempty"`  // Stops services for 'wt pause', keeping their data (default: teardown)
+	Resume      string         `json:"resume,omitempty"` // Starts them again for 'wt resume' (default: setup)
+	PortEnv     string         `json:"port_env,omitempty"`
+	HealthCheck string         `json:"health_check,omitempty"`
+	Status      string         `json:"status,omitempty"` // Shows service/container state, e.g. "docker compose ps"
+	Ports       map[string]int `json:"ports,omitempty"`  // Service ports before the session's offset is added
+	Test        string         `json:"test,omitempty"`   // Runs the tests against the session's environment, e.g. "npm test"
+}
+
+// TestCommand returns the command workers run the tests with: test_env.test,
+// else auto.test_command. Returns "" if neither is set.
+func (p *Project) TestCommand() string {
+	if p == nil {
+		return ""
+	}
+	if p.TestEnv != nil && p.TestEnv.Test != "" {
+		return p.TestEnv.Test
+	}
+	if p.Auto != nil {
+		return p.Auto.TestCommand
+	}
+	return ""
+}
+
+// Auto contains pacing settings for wt auto runs.
+type Auto struct {
+	Cooldown    string  `json:"cooldown,omitempty"`     // Pause between beads, e.g. "5m"
+	DailyBudget int     `json:"daily_budget,omitempty"` // Max beads started per day (0 = unlimited)
+	QuietHours  string  `json:"quiet_hours,omitempty"`  // Local time window with no new beads, e.g. "22:00-07:00"
+	Budget      float64 `json:"budget,omitempty"`       // Pause a run once its estimated Claude cost reaches this many USD (0 = unlimited)
+
+	MaxDrift      int    `json:"max_drift,omitempty"`      // Epic runs sync with the default branch when this many commits behind
+	DriftStrategy string `json:"drift_strategy,omitempty"` // "rebase" (default) or "merge"
+	TestCommand   string `json:"test_command,omitempty"`   // Run after syncing, e.g. "go test ./..."
+
+	ReportWebhook string `json:"report_webhook,omitempty"` // POST each run's JSON report here
+	ReportToHub   bool   `json:"report_to_hub,omitempty"`  // Message the hub each run's summary
+}
+
+// Verify configures the check run against the default branch after wt
+// merges to it, directly or through an auto-merge PR.
+type Verify struct {
+	Command   string `json:"command"`              // Run in a temporary worktree of the default branch, e.g. "go build ./... && go test ./..."
+	OnFailure string `json:"on_failure,omitempty"` // "notify" (default) or "revert" to also open a revert PR
+	Timeout   string `json:"timeout,omitempty"`    // e.g. "20m" (default 30m)
+}
+
+// RevertsOnFailure reports whether a failed verification opens a revert PR
+func (v *Verify) RevertsOnFailure() bool {
+	return v != nil && v.OnFailure == "revert"
 }
 
 // Hooks contains lifecycle hook commands.
@@ -49,6 +136,71 @@ type Hooks struct {
 	OnClose  []string `json:"on_close,omitempty"`
 }
 
+// GitHooks contains git hooks installed into each session worktree.
+// Commands may use {BEAD_ID}, {SESSION}, {PROJECT} and {BRANCH} placeholders.
+type GitHooks struct {
+	RequireBeadID  bool     `json:"require_bead_id,omitempty"` // commit-msg rejects messages without the session's bead ID
+	RequireTrailer string   `json:"require_trailer,omitempty"` // commit-msg requires this trailer, e.g. "Co-Authored-By"
+	PreCommit      []string `json:"pre_commit,omitempty"`      // Commands run before each commit
+	CommitMsg      []string `json:"commit_msg,omitempty"`      // Extra commit-msg commands; the message file is $1
+}
+
+// Signing configures commit signing in session worktrees, for repos whose
+// branch protection rejects unsigned commits at push time.
+type Signing struct {
+	Require        bool   `json:"require,omitempty"`         // wt done refuses to merge unsigned commits
+	Format         string `json:"format,omitempty"`          // gpg.format: "openpgp" (default), "ssh" or "x509"
+	Key            string `json:"key,omitempty"`             // user.signingkey, e.g. a GPG key ID or ~/.ssh/id_ed25519.pub (default: your git config)
+	AllowedSigners string `json:"allowed_signers,omitempty"` // gpg.ssh.allowedSignersFile, so SSH signatures can be verified locally
+}
+
+// Required reports whether worktrees sign their commits and wt done checks
+// the signatures
+func (s *Signing) Required() bool {
+	return s != nil && s.Require
+}
+
+// Provision shares dependency and build directories with new worktrees so
+// workers don't start with a full install.
+type Provision struct {
+	Dirs        []string `json:"dirs,omitempty"`         // Directories relative to the repo root, e.g. node_modules, .venv
+	Mode        string   `json:"mode,omitempty"`         // "symlink" (default) or "copy"
+	CacheDir    string   `json:"cache_dir,omitempty"`    // Where the directories come from (default: the main repo)
+	WarmCommand string   `json:"warm_command,omitempty"` // Run once to populate the cache, e.g. "npm ci"
+}
+
+// Monorepo declares path scopes that wt done checks a bead's changes
+// against. Beads select scopes with "scope:<name>" labels.
+type Monorepo struct {
+	Scopes     map[string][]string `json:"scopes,omitempty"`       // Scope name -> path patterns in CODEOWNERS syntax
+	OutOfScope string              `json:"out_of_scope,omitempty"` // "warn" (default) or "block"
+	CodeOwners bool                `json:"codeowners,omitempty"`   // Request CODEOWNERS of changed files as PR reviewers
+}
+
+// Sparse makes new worktrees check out only part of the repo, for
+// monorepos where a full checkout per bead is slow and large.
+type Sparse struct {
+	Paths  []string            `json:"paths,omitempty"`   // Always checked out; directories in cone mode
+	Scopes map[string][]string `json:"scopes,omitempty"`  // Extra paths for beads labelled "scope:<name>"
+	NoCone bool                `json:"no_cone,omitempty"` // Treat paths as gitignore-style patterns instead of directories
+}
+
+// Enabled reports whether new worktrees are sparse
+func (s *Sparse) Enabled() bool {
+	return s != nil && (len(s.Paths) > 0 || len(s.Scopes) > 0)
+}
+
+// Cone reports whether paths are cone-mode directories
+func (s *Sparse) Cone() bool {
+	return s == nil || !s.NoCone
+}
+
+// BlocksOutOfScope reports whether wt done refuses changes outside the
+// bead's scopes rather than warning about them.
+func (m *Monorepo) BlocksOutOfScope() bool {
+	return m != nil && m.OutOfScope == "block"
+}
+
 // Manager handles project registration and lookup.
 type Manager struct {
 	projectsDir string
@@ -109,6 +261,7 @@ func (m *Manager) Get(name string) (*Project, error) {
 	if err := json.Unmarshal(data, &proj); err != nil {
 		return nil, fmt.Errorf("invalid project config: %w", err)
 	}
+	proj.guidelinesFile = m.GuidelinesPath(name)
 
 	return &proj, nil
 }
@@ -149,7 +302,7 @@ func (m *Manager) Add(name, repoPath string, opts *AddOptions) (*Project, error)
 	}
 
 	// Get git remote URL for canonical repo identity
-	repoURL := getGitRemoteURL(expandedPath)
+	repoURL := DetectRepoURL(expandedPath)
 
 	// Auto-discover beads prefix from .beads/config.json if available
 	beadsPrefix := discoverBeadsPrefix(expandedPath)
@@ -194,8 +347,12 @@ func (m *Manager) Add(name, repoPath string, opts *AddOptions) (*Project, error)
 	return proj, nil
 }
 
-// Save writes a project config to disk.
+// Save writes a project config to disk. Command templates are validated
+// first, so a typo fails here rather than when a session starts.
 func (m *Manager) Save(proj *Project) error {
+	if err := proj.ValidateCommands(); err != nil {
+		return fmt.Errorf("project '%s': %w", proj.Name, err)
+	}
 	if err := m.EnsureProjectsDir(); err != nil {
 		return err
 	}
@@ -215,6 +372,9 @@ func (m *Manager) Delete(name string) error {
 	if _, err := os.Stat(path); os.IsNotExist(err) {
 		return fmt.Errorf("project '%s' not found", name)
 	}
+	if err := os.RemoveAll(filepath.Dir(m.GuidelinesPath(name))); err != nil {
+		return fmt.Errorf("removing worker guidelines: %w", err)
+	}
 	return os.Remove(path)
 }
 
@@ -247,6 +407,14 @@ func (p *Project) RepoPath() string {
 	return ExpandPath(p.Repo)
 }
 
+// DefaultBranchName returns the project's default branch, "main" if unset.
+func (p *Project) DefaultBranchName() string {
+	if p.DefaultBranch == "" {
+		return "main"
+	}
+	return p.DefaultBranch
+}
+
 // BeadsDir returns the beads directory for a project.
 func (p *Project) BeadsDir() string {
 	return filepath.Join(p.RepoPath(), ".beads")
@@ -276,7 +444,7 @@ func extractPrefix(beadID string) string {
 
 // getGitRemoteURL gets the origin remote URL from a git repository.
 func getGitRemoteURL(repoPath string) string {
-	cmd := exec.Command("git", "-C", repoPath, "remote", "get-url", "origin")
+	cmd := logging.Command("git", "-C", repoPath, "remote", "get-url", "origin")
 	output, err := cmd.Output()
 	if err != nil {
 		return ""
@@ -313,7 +481,7 @@ func (m *Manager) validateRepoRegistration(repoURL, branch, beadsPrefix string)
 	}
 
 	for _, existing := range projects {
-		if existing.RepoURL != repoURL {
+		if !sameRepo(existing.RepoURL, repoURL) {
 			continue
 		}
 
@@ -331,7 +499,8 @@ func (m *Manager) validateRepoRegistration(repoURL, branch, beadsPrefix string)
 	return nil
 }
 
-// FindByRepoURL finds all projects registered for a given repo URL.
+// FindByRepoURL finds all projects registered for a given repo URL. SSH and
+// HTTPS remotes of the same repository match.
 func (m *Manager) FindByRepoURL(repoURL string) ([]*Project, error) {
 	if repoURL == "" {
 		return nil, nil
@@ -344,7 +513,7 @@ func (m *Manager) FindByRepoURL(repoURL string) ([]*Project, error) {
 
 	var matches []*Project
 	for _, proj := range projects {
-		if proj.RepoURL == repoURL {
+		if sameRepo(proj.RepoURL, repoURL) {
 			matches = append(matches, proj)
 		}
 	}
@@ -335,8 +335,8 @@ func TestManager_Add_MultipleBranches(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Add main branch failed: %v", err)
 	}
-	if proj1.RepoURL != "git@github.com:test/repo.git" {
-		t.Errorf("expected RepoURL to be set, got %q", proj1.RepoURL)
+	if proj1.RepoURL != "https://github.com/test/repo" {
+		t.Errorf("expected canonical RepoURL, got %q", proj1.RepoURL)
 	}
 
 	// Second registration with feature branch (same repo, different branch)
@@ -0,0 +1,96 @@
+package project
+
+import (
+	"fmt"
+	"net/url"
+	"os/exec"
+	"strings"
+
+	"github.com/badri/wt/internal/logging"
+)
+
+// CanonicalRepoURL normalizes a git remote URL to https://host/owner/repo,
+// so the SSH and HTTPS remotes of one repository compare equal. URLs it
+// doesn't recognise (local paths, file://) are returned trimmed.
+func CanonicalRepoURL(raw string) string {
+	raw = strings.TrimSpace(raw)
+	host, path := splitRemote(raw)
+	if host == "" || path == "" {
+		return raw
+	}
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	return "https://" + strings.ToLower(host) + "/" + path
+}
+
+// DetectRepoURL returns the canonical URL of a repository's origin remote,
+// or "" when it has none
+func DetectRepoURL(repoPath string) string {
+	return CanonicalRepoURL(getGitRemoteURL(repoPath))
+}
+
+// RepoHost returns the host of a remote URL ("github.com"), or "" for local
+// remotes
+func RepoHost(repoURL string) string {
+	host, _ := splitRemote(repoURL)
+	return strings.ToLower(host)
+}
+
+// splitRemote splits scp-like (git@host:owner/repo) and URL-style
+// (ssh://, https://, git://) remotes into host and path
+func splitRemote(raw string) (host, path string) {
+	if strings.Contains(raw, "://") {
+		u, err := url.Parse(raw)
+		if err != nil || u.Scheme == "file" {
+			return "", ""
+		}
+		return u.Hostname(), u.Path
+	}
+	// scp-like syntax: [user@]host:path, where host has no slash
+	colon := strings.Index(raw, ":")
+	if colon <= 0 || strings.Contains(raw[:colon], "/") {
+		return "", ""
+	}
+	host = raw[:colon]
+	if at := strings.LastIndex(host, "@"); at >= 0 {
+		host = host[at+1:]
+	}
+	return host, raw[colon+1:]
+}
+
+// sameRepo reports whether two remote URLs point at the same repository
+func sameRepo(a, b string) bool {
+	return a != "" && CanonicalRepoURL(a) == CanonicalRepoURL(b)
+}
+
+// MergeNeedsGH reports whether a merge mode opens pull requests with gh
+func MergeNeedsGH(mergeMode string) bool {
+	return mergeMode == "pr-review" || mergeMode == "pr-auto"
+}
+
+// CheckGHAccess verifies that gh is installed, logged in to the repo's host
+// and can see the repository, so PR merge modes don't fail halfway through
+// wt done
+func CheckGHAccess(repoURL string) error {
+	host := RepoHost(repoURL)
+	if host == "" {
+		return fmt.Errorf("remote %s is not hosted; PR merge modes need a GitHub remote", repoURL)
+	}
+	if _, err := exec.LookPath("gh"); err != nil {
+		return fmt.Errorf("gh is not installed (https://cli.github.com)")
+	}
+	if out, err := logging.Command("gh", "auth", "status", "--hostname", host).CombinedOutput(); err != nil {
+		return fmt.Errorf("gh is not logged in to %s (run: gh auth login --hostname %s): %s", host, host, firstLine(string(out)))
+	}
+	if out, err := logging.Command("gh", "repo", "view", CanonicalRepoURL(repoURL), "--json", "name").CombinedOutput(); err != nil {
+		return fmt.Errorf("gh cannot access %s: %s", CanonicalRepoURL(repoURL), firstLine(string(out)))
+	}
+	return nil
+}
+
+func firstLine(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		s = s[:i]
+	}
+	return s
+}
@@ -0,0 +1,62 @@
+package project
+
+import "testing"
+
+func TestCanonicalRepoURL(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{"git@github.com:acme/widgets.git", "https://github.com/acme/widgets"},
+		{"ssh://git@github.com/acme/widgets.git", "https://github.com/acme/widgets"},
+		{"https://github.com/acme/widgets.git", "https://github.com/acme/widgets"},
+		{"https://github.com/acme/widgets/", "https://github.com/acme/widgets"},
+		{"https://user@GitHub.com/acme/widgets", "https://github.com/acme/widgets"},
+		{"ssh://git@ghe.corp.example:2222/team/svc.git", "https://ghe.corp.example/team/svc"},
+		{"/srv/git/widgets.git", "/srv/git/widgets.git"},
+		{"file:///srv/git/widgets.git", "file:///srv/git/widgets.git"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := CanonicalRepoURL(tt.raw); got != tt.want {
+			t.Errorf("CanonicalRepoURL(%q) = %q, want %q", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestRepoHost(t *testing.T) {
+	if got := RepoHost("git@github.com:acme/widgets.git"); got != "github.com" {
+		t.Errorf("RepoHost(scp) = %q", got)
+	}
+	if got := RepoHost("/srv/git/widgets.git"); got != "" {
+		t.Errorf("RepoHost(local path) = %q, want empty", got)
+	}
+}
+
+func TestSameRepo(t *testing.T) {
+	if !sameRepo("git@github.com:acme/widgets.git", "https://github.com/acme/widgets") {
+		t.Error("expected SSH and HTTPS remotes of one repo to match")
+	}
+	if sameRepo("git@github.com:acme/widgets.git", "git@github.com:acme/gadgets.git") {
+		t.Error("expected different repos not to match")
+	}
+	if sameRepo("", "") {
+		t.Error("expected empty URLs not to match")
+	}
+}
+
+func TestManager_FindByRepoURL_MatchesOtherForms(t *testing.T) {
+	cfg, _ := setupTestConfig(t)
+	mgr := NewManager(cfg)
+	// A project registered before URLs were canonicalized
+	if err := mgr.Save(&Project{Name: "legacy", Repo: t.TempDir(), RepoURL: "git@github.com:acme/widgets.git"}); err != nil {
+		t.Fatal(err)
+	}
+	matches, err := mgr.FindByRepoURL("https://github.com/acme/widgets")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 1 || matches[0].Name != "legacy" {
+		t.Errorf("expected legacy project to match, got %v", matches)
+	}
+}
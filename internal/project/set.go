@@ -0,0 +1,212 @@
+package project
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// allowedValues lists the keys whose values are one of a few words
+var allowedValues = map[string][]string{
+	"merge_mode":            {"direct", "pr-auto", "pr-review"},
+	"auto_rebase":           {"true", "false", "prompt"},
+	"bead_close":            {"done", "merged", "manual"},
+	"agent":                 {"claude", "aider", "shell"},
+	"auto.drift_strategy":   {"rebase", "merge"},
+	"verify.on_failure":     {"notify", "revert"},
+	"provision.mode":        {"symlink", "copy"},
+	"monorepo.out_of_scope": {"warn", "block"},
+	"signing.format":        {"openpgp", "ssh", "x509"},
+}
+
+// readOnlyKeys can't be changed with Set: the name is the config file's
+// name, and repo_url is detected from the repository
+var readOnlyKeys = map[string]string{
+	"name":     "rename the project by removing and adding it again",
+	"repo_url": "it is detected from the repository's origin remote",
+}
+
+// pathStep is one step of a key: an object field or a list index
+type pathStep struct {
+	field string
+	index int // -1 for a field
+}
+
+// Set changes one config value by key, e.g. "merge_mode",
+// "test_env.setup" or "hooks.on_create[0]". Index len(list) appends to a
+// list. The value is read as JSON when it parses as the field's type
+// (numbers, booleans, lists), otherwise as a string. Unknown keys, values
+// of the wrong type and values outside a key's allowed words are rejected
+// and leave the project unchanged.
+func (p *Project) Set(key, value string) error {
+	steps, err := parseKeyPath(key)
+	if err != nil {
+		return err
+	}
+	if why, ok := readOnlyKeys[key]; ok {
+		return fmt.Errorf("%s can't be set: %s", key, why)
+	}
+	if allowed, ok := allowedValues[key]; ok && !slices.Contains(allowed, value) {
+		return fmt.Errorf("invalid %s: %s (valid: %s)", key, value, strings.Join(allowed, ", "))
+	}
+
+	data, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	var tree any
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return err
+	}
+
+	// Try the value as JSON first, so "20" sets a number and "true" a bool,
+	// then fall back to a plain string
+	var candidates []any
+	var parsed any
+	if json.Unmarshal([]byte(value), &parsed) == nil {
+		candidates = append(candidates, parsed)
+	}
+	candidates = append(candidates, value)
+
+	for i, v := range candidates {
+		updated, err := setPath(deepCopy(tree), steps, v)
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		next, err := decodeStrict(updated)
+		if err == nil {
+			*p = *next
+			return nil
+		}
+		if i == len(candidates)-1 {
+			return fmt.Errorf("%s: %s", key, decodeError(err))
+		}
+	}
+	return nil
+}
+
+// parseKeyPath splits "hooks.on_create[0]" into its steps
+func parseKeyPath(key string) ([]pathStep, error) {
+	if key == "" {
+		return nil, fmt.Errorf("empty key")
+	}
+	var steps []pathStep
+	for _, part := range strings.Split(key, ".") {
+		field, rest, _ := strings.Cut(part, "[")
+		if field == "" {
+			return nil, fmt.Errorf("invalid key %q", key)
+		}
+		steps = append(steps, pathStep{field: field, index: -1})
+		for rest != "" {
+			idx, after, ok := strings.Cut(rest, "]")
+			n, err := strconv.Atoi(idx)
+			if !ok || err != nil || n < 0 {
+				return nil, fmt.Errorf("invalid index in key %q", key)
+			}
+			steps = append(steps, pathStep{index: n})
+			if after == "" {
+				break
+			}
+			if !strings.HasPrefix(after, "[") {
+				return nil, fmt.Errorf("invalid key %q", key)
+			}
+			rest = after[1:]
+		}
+	}
+	return steps, nil
+}
+
+// setPath sets value at steps inside a decoded JSON tree, creating objects
+// and lists on the way
+func setPath(node any, steps []pathStep, value any) (any, error) {
+	if len(steps) == 0 {
+		return value, nil
+	}
+	step := steps[0]
+	if step.index < 0 {
+		obj, ok := node.(map[string]any)
+		if node == nil {
+			obj, ok = map[string]any{}, true
+		}
+		if !ok {
+			return nil, fmt.Errorf("%s is not inside an object", step.field)
+		}
+		child, err := setPath(obj[step.field], steps[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		obj[step.field] = child
+		return obj, nil
+	}
+
+	list, ok := node.([]any)
+	if node == nil {
+		list, ok = []any{}, true
+	}
+	if !ok {
+		return nil, fmt.Errorf("[%d] indexes something that is not a list", step.index)
+	}
+	switch {
+	case step.index < len(list):
+	case step.index == len(list):
+		list = append(list, nil)
+	default:
+		return nil, fmt.Errorf("index %d is past the end of the list (length %d)", step.index, len(list))
+	}
+	child, err := setPath(list[step.index], steps[1:], value)
+	if err != nil {
+		return nil, err
+	}
+	list[step.index] = child
+	return list, nil
+}
+
+// decodeStrict turns a JSON tree back into a Project, failing on fields
+// the Project doesn't have
+func decodeStrict(tree any) (*Project, error) {
+	data, err := json.Marshal(tree)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var p Project
+	if err := dec.Decode(&p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// decodeError rewords json errors in terms of config keys
+func decodeError(err error) string {
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &typeErr):
+		return fmt.Sprintf("expected %s, got %s", typeErr.Type, typeErr.Value)
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		return "unknown key " + strings.TrimPrefix(err.Error(), "json: unknown field ")
+	}
+	return err.Error()
+}
+
+func deepCopy(node any) any {
+	switch n := node.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(n))
+		for k, v := range n {
+			out[k] = deepCopy(v)
+		}
+		return out
+	case []any:
+		out := make([]any, len(n))
+		for i, v := range n {
+			out[i] = deepCopy(v)
+		}
+		return out
+	}
+	return node
+}
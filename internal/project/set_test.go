@@ -0,0 +1,74 @@
+package project
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestProjectSet(t *testing.T) {
+	p := &Project{Name: "proj", Repo: "~/code/proj", MergeMode: "pr-review"}
+
+	sets := []struct{ key, value string }{
+		{"merge_mode", "direct"},
+		{"default_branch", "develop"},
+		{"test_env.setup", "docker compose up -d"},
+		{"test_env.ports", `{"web": 3000}`},
+		{"hooks.on_create[0]", "npm ci"},
+		{"hooks.on_create[1]", "make seed"},
+		{"hooks.on_create[0]", "pnpm i"},
+		{"auto.daily_budget", "20"},
+		{"require_ci", "true"},
+		{"context_files", `["docs/ARCHITECTURE.md"]`},
+		{"test_env.port_env", "3000"}, // a string key keeps numeric-looking text
+	}
+	for _, s := range sets {
+		if err := p.Set(s.key, s.value); err != nil {
+			t.Fatalf("Set(%s, %s): %v", s.key, s.value, err)
+		}
+	}
+
+	if p.MergeMode != "direct" || p.DefaultBranch != "develop" {
+		t.Errorf("merge_mode %q, default_branch %q", p.MergeMode, p.DefaultBranch)
+	}
+	if p.TestEnv.Setup != "docker compose up -d" || p.TestEnv.Ports["web"] != 3000 || p.TestEnv.PortEnv != "3000" {
+		t.Errorf("test_env = %+v", p.TestEnv)
+	}
+	if want := []string{"pnpm i", "make seed"}; !reflect.DeepEqual(p.Hooks.OnCreate, want) {
+		t.Errorf("hooks.on_create = %v, want %v", p.Hooks.OnCreate, want)
+	}
+	if p.Auto.DailyBudget != 20 || !p.RequireCI || len(p.ContextFiles) != 1 {
+		t.Errorf("daily_budget %d, require_ci %v, context_files %v", p.Auto.DailyBudget, p.RequireCI, p.ContextFiles)
+	}
+	if p.Name != "proj" || p.Repo != "~/code/proj" {
+		t.Errorf("Set changed unrelated fields: %+v", p)
+	}
+}
+
+func TestProjectSetRejects(t *testing.T) {
+	tests := []struct {
+		key, value, wantErr string
+	}{
+		{"merge_mode", "yolo", "valid: direct, pr-auto, pr-review"},
+		{"auto_rebase", "sometimes", "invalid auto_rebase"},
+		{"test_env.setpu", "make", "unknown key"},
+		{"nope", "1", "unknown key"},
+		{"auto.daily_budget", "lots", "expected int"},
+		{"hooks.on_create[3]", "npm ci", "past the end"},
+		{"hooks.on_create[x]", "npm ci", "invalid index"},
+		{"merge_mode[0]", "direct", "not a list"},
+		{"name", "other", "can't be set"},
+		{"", "x", "empty key"},
+	}
+	for _, tt := range tests {
+		p := &Project{Name: "proj", MergeMode: "pr-review"}
+		err := p.Set(tt.key, tt.value)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("Set(%q, %q) error = %v, want it to mention %q", tt.key, tt.value, err, tt.wantErr)
+			continue
+		}
+		if p.MergeMode != "pr-review" || p.Hooks != nil {
+			t.Errorf("Set(%q, %q) changed the project on error: %+v", tt.key, tt.value, p)
+		}
+	}
+}
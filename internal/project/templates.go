@@ -0,0 +1,94 @@
+package project
+
+import (
+	"sort"
+	"strings"
+)
+
+// BeadTemplate pre-fills a new bead created with wt create --template.
+// Description may contain {TITLE} and {DESCRIPTION} placeholders.
+type BeadTemplate struct {
+	Type        string   `json:"type,omitempty"`     // Bead type, defaults to the template name
+	Priority    *int     `json:"priority,omitempty"` // Default priority (0-4)
+	Labels      []string `json:"labels,omitempty"`   // Labels added to the bead
+	Description string   `json:"description,omitempty"`
+}
+
+// DefaultBeadTemplates are available in every project unless overridden
+// by a template of the same name in the project's bead_templates.
+var DefaultBeadTemplates = map[string]*BeadTemplate{
+	"bug": {
+		Type: "bug",
+		Description: `## Context
+{DESCRIPTION}
+
+## Steps to Reproduce
+1.
+
+## Expected vs Actual
+
+## Acceptance Criteria
+- [ ] Bug no longer reproduces
+- [ ] Regression test added
+
+## Test Plan
+`,
+	},
+	"feature": {
+		Type: "feature",
+		Description: `## Context
+{DESCRIPTION}
+
+## Acceptance Criteria
+- [ ]
+
+## Test Plan
+`,
+	},
+	"chore": {
+		Type: "chore",
+		Description: `## Context
+{DESCRIPTION}
+
+## Done When
+- [ ]
+`,
+	},
+}
+
+// BeadTemplate returns the named template, preferring the project's own
+// definition over the built-in default.
+func (p *Project) BeadTemplate(name string) (*BeadTemplate, bool) {
+	if t, ok := p.BeadTemplates[name]; ok && t != nil {
+		return t, true
+	}
+	t, ok := DefaultBeadTemplates[name]
+	return t, ok
+}
+
+// BeadTemplateNames returns the names of all templates available to the project.
+func (p *Project) BeadTemplateNames() []string {
+	seen := make(map[string]bool)
+	for name := range DefaultBeadTemplates {
+		seen[name] = true
+	}
+	for name := range p.BeadTemplates {
+		seen[name] = true
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Render fills in the template description for a bead.
+func (t *BeadTemplate) Render(title, description string) string {
+	if t.Description == "" {
+		return description
+	}
+	s := strings.ReplaceAll(t.Description, "{TITLE}", title)
+	s = strings.ReplaceAll(s, "{DESCRIPTION}", description)
+	return strings.TrimRight(s, "\n") + "\n"
+}
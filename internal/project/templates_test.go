@@ -0,0 +1,48 @@
+package project
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestProject_BeadTemplate(t *testing.T) {
+	p := &Project{BeadTemplates: map[string]*BeadTemplate{
+		"bug":   {Type: "bug", Description: "custom bug"},
+		"spike": {Type: "task", Description: "## Question\n{DESCRIPTION}"},
+	}}
+
+	if tmpl, ok := p.BeadTemplate("bug"); !ok || tmpl.Description != "custom bug" {
+		t.Errorf("BeadTemplate(bug) should prefer the project template, got %+v", tmpl)
+	}
+	if tmpl, ok := p.BeadTemplate("feature"); !ok || tmpl != DefaultBeadTemplates["feature"] {
+		t.Errorf("BeadTemplate(feature) should fall back to the built-in, got %+v", tmpl)
+	}
+	if _, ok := p.BeadTemplate("nope"); ok {
+		t.Error("BeadTemplate(nope) should not exist")
+	}
+
+	want := []string{"bug", "chore", "feature", "spike"}
+	if got := p.BeadTemplateNames(); !slices.Equal(got, want) {
+		t.Errorf("BeadTemplateNames() = %v, want %v", got, want)
+	}
+}
+
+func TestBeadTemplate_Render(t *testing.T) {
+	tmpl := &BeadTemplate{Description: "# {TITLE}\n\n## Context\n{DESCRIPTION}\n\n"}
+	got := tmpl.Render("Fix crash", "Crashes on save")
+	want := "# Fix crash\n\n## Context\nCrashes on save\n"
+	if got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+
+	// Templates without a description leave the description untouched
+	if got := (&BeadTemplate{}).Render("t", "plain"); got != "plain" {
+		t.Errorf("Render() = %q, want %q", got, "plain")
+	}
+
+	// Built-ins include acceptance criteria scaffolding
+	if !strings.Contains(DefaultBeadTemplates["feature"].Render("t", ""), "## Acceptance Criteria") {
+		t.Error("feature template should include acceptance criteria")
+	}
+}
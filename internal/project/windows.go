@@ -0,0 +1,31 @@
+package project
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Window is an extra tmux window of each session of the project, e.g.
+// {"name": "tests", "command": "npm test -- --watch"}. The command may use
+// the template variables of hook commands.
+type Window struct {
+	Name    string `json:"name"`
+	Command string `json:"command,omitempty"` // Typed into the window's shell; empty leaves just the shell
+}
+
+// validateWindows checks that windows have distinct names tmux can target
+func (p *Project) validateWindows() error {
+	seen := map[string]bool{}
+	for i, w := range p.Windows {
+		switch {
+		case strings.TrimSpace(w.Name) == "":
+			return fmt.Errorf("windows[%d]: name is required", i)
+		case strings.ContainsAny(w.Name, ":."):
+			return fmt.Errorf("windows[%d]: name %q can't contain ':' or '.'", i, w.Name)
+		case seen[w.Name]:
+			return fmt.Errorf("windows[%d]: duplicate name %q", i, w.Name)
+		}
+		seen[w.Name] = true
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+// Package provision gives new session worktrees a project's dependency and
+// build directories (node_modules, vendor, target, .venv) by symlinking or
+// copying them from the main repo or a warm cache, so workers don't start
+// with a full install. A project's warm_command populates the cache once;
+// wt keeps that cache as a detached worktree of the default branch.
+package provision
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/project"
+)
+
+// Provisioning modes
+const (
+	ModeSymlink = "symlink"
+	ModeCopy    = "copy"
+)
+
+// Result reports what Apply did with each configured directory.
+type Result struct {
+	Provided []string // linked or copied into the worktree
+	Missing  []string // not present in the source
+	Existing []string // already present in the worktree, left alone
+}
+
+// Enabled returns true if the project has directories to provision.
+func Enabled(proj *project.Project) bool {
+	return proj != nil && proj.Provision != nil && len(proj.Provision.Dirs) > 0
+}
+
+// Mode returns the effective provisioning mode of the project.
+func Mode(proj *project.Project) string {
+	if proj.Provision != nil && proj.Provision.Mode == ModeCopy {
+		return ModeCopy
+	}
+	return ModeSymlink
+}
+
+// CacheDir returns the wt-managed cache checkout of a project.
+func CacheDir(cfg *config.Config, name string) string {
+	return filepath.Join(cfg.ConfigDir(), "cache", name)
+}
+
+// Source returns the directory provisioned directories come from: the
+// configured cache_dir, the managed cache when a warm_command is set, or
+// the main repo.
+func Source(cfg *config.Config, proj *project.Project) string {
+	p := proj.Provision
+	switch {
+	case p != nil && p.CacheDir != "":
+		return project.ExpandPath(p.CacheDir)
+	case p != nil && p.WarmCommand != "":
+		return CacheDir(cfg, proj.Name)
+	}
+	return proj.RepoPath()
+}
+
+// warmMarker records the warm_command that last populated a project's cache
+func warmMarker(cfg *config.Config, name string) string {
+	return filepath.Join(cfg.ConfigDir(), "cache", name+".warm")
+}
+
+// Warm runs the project's warm_command in its source directory unless it
+// already ran (force runs it again, first updating the managed cache to the
+// default branch). Returns whether the command ran.
+func Warm(cfg *config.Config, proj *project.Project, force bool) (bool, error) {
+	if proj.Provision == nil || proj.Provision.WarmCommand == "" {
+		return false, nil
+	}
+	command := proj.Provision.WarmCommand
+	marker := warmMarker(cfg, proj.Name)
+	if data, err := os.ReadFile(marker); err == nil && string(data) == command && !force {
+		return false, nil
+	}
+
+	source := Source(cfg, proj)
+	if proj.Provision.CacheDir == "" {
+		if err := syncCache(proj, source); err != nil {
+			return false, err
+		}
+	} else if err := os.MkdirAll(source, 0755); err != nil {
+		return false, fmt.Errorf("creating cache dir: %w", err)
+	}
+
+	cmd := logging.CommandIn(source, "sh", "-c", command)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return true, fmt.Errorf("warm_command %q: %w", command, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(marker), 0755); err != nil {
+		return true, err
+	}
+	return true, os.WriteFile(marker, []byte(command), 0644)
+}
+
+// syncCache creates the managed cache checkout, or moves an existing one to
+// the tip of the default branch.
+func syncCache(proj *project.Project, cacheDir string) error {
+	branch := proj.DefaultBranch
+	if branch == "" {
+		branch = "main"
+	}
+	var cmd *exec.Cmd
+	if _, err := os.Stat(cacheDir); os.IsNotExist(err) {
+		if err := os.MkdirAll(filepath.Dir(cacheDir), 0755); err != nil {
+			return fmt.Errorf("creating cache dir: %w", err)
+		}
+		cmd = logging.Command("git", "-C", proj.RepoPath(), "worktree", "add", "--detach", cacheDir, branch)
+	} else {
+		cmd = logging.Command("git", "-C", cacheDir, "checkout", "-q", "--detach", branch)
+	}
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("preparing cache checkout: %s: %w", strings.TrimSpace(string(output)), err)
+	}
+	return nil
+}
+
+// Apply provisions a new worktree, warming the cache first if needed.
+func Apply(cfg *config.Config, proj *project.Project, worktreePath string) (*Result, error) {
+	result := &Result{}
+	if !Enabled(proj) {
+		return result, nil
+	}
+	if _, err := Warm(cfg, proj, false); err != nil {
+		return result, err
+	}
+
+	source := Source(cfg, proj)
+	mode := Mode(proj)
+	for _, dir := range proj.Provision.Dirs {
+		dir = filepath.Clean(dir)
+		if !filepath.IsLocal(dir) {
+			return result, fmt.Errorf("provision dir %q must be relative to the repo root", dir)
+		}
+		src := filepath.Join(source, dir)
+		dst := filepath.Join(worktreePath, dir)
+
+		if _, err := os.Stat(src); err != nil {
+			result.Missing = append(result.Missing, dir)
+			continue
+		}
+		if _, err := os.Lstat(dst); err == nil {
+			result.Existing = append(result.Existing, dir)
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return result, err
+		}
+
+		if mode == ModeCopy {
+			if output, err := logging.Command("cp", "-a", src, dst).CombinedOutput(); err != nil {
+				return result, fmt.Errorf("copying %s: %s: %w", dir, strings.TrimSpace(string(output)), err)
+			}
+		} else if err := os.Symlink(src, dst); err != nil {
+			return result, fmt.Errorf("linking %s: %w", dir, err)
+		}
+		result.Provided = append(result.Provided, dir)
+	}
+
+	// A "node_modules/" ignore rule doesn't match a symlink, so exclude the
+	// links explicitly to keep them out of the worker's commits
+	if mode == ModeSymlink && len(result.Provided) > 0 {
+		if err := excludeDirs(worktreePath, result.Provided); err != nil {
+			return result, fmt.Errorf("excluding provisioned dirs from git: %w", err)
+		}
+	}
+	return result, nil
+}
+
+// excludeDirs adds root-anchored patterns for dirs to the repository's
+// info/exclude, which all of its worktrees share.
+func excludeDirs(worktreePath string, dirs []string) error {
+	cmd := logging.CommandIn(worktreePath, "git", "rev-parse", "--path-format=absolute", "--git-common-dir")
+	output, err := cmd.Output()
+	if err != nil {
+		return err
+	}
+	excludeFile := filepath.Join(strings.TrimSpace(string(output)), "info", "exclude")
+
+	data, err := os.ReadFile(excludeFile)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	lines := strings.Split(string(data), "\n")
+
+	var add []string
+	for _, dir := range dirs {
+		pattern := "/" + filepath.ToSlash(dir)
+		if !slices.Contains(lines, pattern) {
+			add = append(add, pattern)
+		}
+	}
+	if len(add) == 0 {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(excludeFile), 0755); err != nil {
+		return err
+	}
+	content := string(data)
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	content += "# Provisioned by wt\n" + strings.Join(add, "\n") + "\n"
+	return os.WriteFile(excludeFile, []byte(content), 0644)
+}
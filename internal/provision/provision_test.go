@@ -0,0 +1,164 @@
+package provision
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/project"
+)
+
+func TestEnabledAndMode(t *testing.T) {
+	if Enabled(nil) || Enabled(&project.Project{}) || Enabled(&project.Project{Provision: &project.Provision{}}) {
+		t.Error("Enabled() should be false without dirs")
+	}
+	proj := &project.Project{Provision: &project.Provision{Dirs: []string{"node_modules"}}}
+	if !Enabled(proj) {
+		t.Error("Enabled() should be true with dirs")
+	}
+	if Mode(proj) != ModeSymlink {
+		t.Errorf("Mode() = %s, want symlink by default", Mode(proj))
+	}
+	proj.Provision.Mode = ModeCopy
+	if Mode(proj) != ModeCopy {
+		t.Errorf("Mode() = %s, want copy", Mode(proj))
+	}
+}
+
+func TestSource(t *testing.T) {
+	cfg, err := config.LoadFromDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	proj := &project.Project{Name: "app", Repo: "/code/app", Provision: &project.Provision{Dirs: []string{"vendor"}}}
+	if got := Source(cfg, proj); got != "/code/app" {
+		t.Errorf("Source() = %s, want the main repo", got)
+	}
+	proj.Provision.WarmCommand = "npm ci"
+	if got := Source(cfg, proj); got != CacheDir(cfg, "app") {
+		t.Errorf("Source() = %s, want the managed cache", got)
+	}
+	proj.Provision.CacheDir = "/var/cache/app"
+	if got := Source(cfg, proj); got != "/var/cache/app" {
+		t.Errorf("Source() = %s, want cache_dir", got)
+	}
+}
+
+func TestApply(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	cfg, err := config.LoadFromDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo := t.TempDir()
+	git(t, repo, "init", "-q", "-b", "main")
+	git(t, repo, "config", "user.email", "test@example.com")
+	git(t, repo, "config", "user.name", "Test")
+	writeFile(t, filepath.Join(repo, ".gitignore"), "node_modules/\n")
+	git(t, repo, "add", ".")
+	git(t, repo, "commit", "-q", "-m", "initial")
+	writeFile(t, filepath.Join(repo, "node_modules", "left-pad", "index.js"), "module.exports = 1\n")
+
+	proj := &project.Project{Name: "app", Repo: repo, Provision: &project.Provision{Dirs: []string{"node_modules", ".venv"}}}
+
+	wt := filepath.Join(t.TempDir(), "toast")
+	git(t, repo, "worktree", "add", "-q", "-b", "wt-abc", wt)
+	result, err := Apply(cfg, proj, wt)
+	if err != nil {
+		t.Fatalf("Apply() error: %v", err)
+	}
+	if strings.Join(result.Provided, ",") != "node_modules" || strings.Join(result.Missing, ",") != ".venv" {
+		t.Errorf("Apply() = %+v, want node_modules provided and .venv missing", result)
+	}
+	if target, err := os.Readlink(filepath.Join(wt, "node_modules")); err != nil || target != filepath.Join(repo, "node_modules") {
+		t.Errorf("node_modules link = %q, %v", target, err)
+	}
+	if status := git(t, wt, "status", "--porcelain"); status != "" {
+		t.Errorf("worktree not clean after provisioning:\n%s", status)
+	}
+
+	// Copy mode gives the worktree its own directory
+	proj.Provision.Mode = ModeCopy
+	wt2 := filepath.Join(t.TempDir(), "shadow")
+	git(t, repo, "worktree", "add", "-q", "-b", "wt-def", wt2)
+	if _, err := Apply(cfg, proj, wt2); err != nil {
+		t.Fatalf("Apply(copy) error: %v", err)
+	}
+	info, err := os.Lstat(filepath.Join(wt2, "node_modules"))
+	if err != nil || !info.IsDir() {
+		t.Fatalf("copied node_modules: %v, %v", info, err)
+	}
+	if _, err := os.Stat(filepath.Join(wt2, "node_modules", "left-pad", "index.js")); err != nil {
+		t.Errorf("copied file missing: %v", err)
+	}
+
+	// Existing directories are left alone
+	result, err = Apply(cfg, proj, wt2)
+	if err != nil || strings.Join(result.Existing, ",") != "node_modules" {
+		t.Errorf("second Apply() = %+v, %v, want node_modules existing", result, err)
+	}
+
+	proj.Provision.Dirs = []string{"../outside"}
+	if _, err := Apply(cfg, proj, wt2); err == nil {
+		t.Error("Apply() should reject dirs outside the repo")
+	}
+}
+
+func TestWarmRunsOnce(t *testing.T) {
+	cfg, err := config.LoadFromDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache := t.TempDir()
+	proj := &project.Project{Name: "app", Provision: &project.Provision{
+		Dirs:        []string{"deps"},
+		CacheDir:    cache,
+		WarmCommand: "mkdir -p deps && echo x >> deps/runs",
+	}}
+
+	for i, force := range []bool{false, false, true} {
+		if _, err := Warm(cfg, proj, force); err != nil {
+			t.Fatalf("Warm() #%d error: %v", i, err)
+		}
+	}
+	data, err := os.ReadFile(filepath.Join(cache, "deps", "runs"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runs := strings.Count(string(data), "x"); runs != 2 {
+		t.Errorf("warm_command ran %d times, want 2 (first and forced)", runs)
+	}
+
+	// A changed command runs again
+	proj.Provision.WarmCommand += " && true"
+	if ran, err := Warm(cfg, proj, false); err != nil || !ran {
+		t.Errorf("Warm() after command change = %v, %v, want ran", ran, err)
+	}
+}
+
+func git(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, output)
+	}
+	return string(output)
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
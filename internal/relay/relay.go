@@ -0,0 +1,112 @@
+// Package relay records which bead comments wt watch has passed on to each
+// session's worker, so a comment added with bd while a session runs reaches
+// the worker once, across restarts of wt watch.
+package relay
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/badri/wt/internal/bead"
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/jsonstore"
+)
+
+// Entry is what a session's worker has been given
+type Entry struct {
+	Bead      string   `json:"bead"`
+	Delivered []string `json:"delivered"` // comment keys, see bead.Comment.Key
+}
+
+// Store holds the entries, keyed by session name
+type Store struct {
+	*jsonstore.Store[Entry]
+}
+
+// Load reads the delivered comments from the config directory
+func Load(cfg *config.Config) (*Store, error) {
+	s, err := jsonstore.Load[Entry](filepath.Join(cfg.ConfigDir(), "bead-comments.json"))
+	if err != nil {
+		return nil, err
+	}
+	return &Store{s}, nil
+}
+
+// Delivered returns the keys already given to a session working on bead.
+// A session that moved to another bead starts over.
+func (s *Store) Delivered(session, beadID string) []string {
+	e := s.Entries[session]
+	if e == nil || e.Bead != beadID {
+		return nil
+	}
+	return e.Delivered
+}
+
+// MarkDelivered records that a comment was given to a session
+func (s *Store) MarkDelivered(session, beadID, key string) {
+	e := s.Entries[session]
+	if e == nil || e.Bead != beadID {
+		e = &Entry{Bead: beadID}
+		s.Entries[session] = e
+	}
+	e.Delivered = append(e.Delivered, key)
+}
+
+// Prune forgets sessions that no longer exist, and reports whether any
+// were dropped
+func (s *Store) Prune(exists func(session string) bool) bool {
+	pruned := false
+	for name := range s.Entries {
+		if !exists(name) {
+			delete(s.Entries, name)
+			pruned = true
+		}
+	}
+	return pruned
+}
+
+// Pending returns the comments a session's worker hasn't seen: added after
+// the session started, not delivered yet, and not posted by wt itself
+func Pending(comments []bead.Comment, started time.Time, delivered []string) []bead.Comment {
+	seen := make(map[string]bool, len(delivered))
+	for _, key := range delivered {
+		seen[key] = true
+	}
+	var pending []bead.Comment
+	for _, c := range comments {
+		if seen[c.Key()] || FromWT(c.Text) || strings.TrimSpace(c.Text) == "" {
+			continue
+		}
+		if created, err := time.Parse(time.RFC3339, c.CreatedAt); err != nil || !created.After(started) {
+			continue
+		}
+		pending = append(pending, c)
+	}
+	return pending
+}
+
+// wtCommentPrefixes start the comments wt posts itself: activity comments,
+// TODO follow-up lists and transcript work logs
+var wtCommentPrefixes = []string{"wt: ", "TODO/FIXME markers added by this work:", "Work log of session "}
+
+// FromWT reports whether a comment was posted by wt rather than a person
+func FromWT(text string) bool {
+	for _, p := range wtCommentPrefixes {
+		if strings.HasPrefix(text, p) {
+			return true
+		}
+	}
+	// Session summaries: "Session <name> summary (<diffstat>):"
+	first, _, _ := strings.Cut(text, "\n")
+	return strings.HasPrefix(first, "Session ") && strings.Contains(first, " summary")
+}
+
+// Prompt is the nudge that hands a comment to the worker
+func Prompt(c bead.Comment) string {
+	text := strings.TrimSpace(c.Text)
+	if c.Author != "" {
+		return "New bead comment: " + text + " (from " + c.Author + ")"
+	}
+	return "New bead comment: " + text
+}
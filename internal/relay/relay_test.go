@@ -0,0 +1,103 @@
+package relay
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/badri/wt/internal/bead"
+	"github.com/badri/wt/internal/config"
+)
+
+func TestStoreRoundTrip(t *testing.T) {
+	cfg, err := config.LoadFromDir(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadFromDir() error: %v", err)
+	}
+
+	store, err := Load(cfg)
+	if err != nil {
+		t.Fatalf("Load() on missing file error: %v", err)
+	}
+	store.MarkDelivered("toast", "wt-a", "1")
+	store.MarkDelivered("toast", "wt-a", "2")
+	store.MarkDelivered("shadow", "wt-b", "7")
+	if err := store.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	loaded, err := Load(cfg)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if got := loaded.Delivered("toast", "wt-a"); len(got) != 2 {
+		t.Errorf("Delivered(toast, wt-a) = %v, want 2 keys", got)
+	}
+	// A session moved to another bead starts over
+	if got := loaded.Delivered("toast", "wt-c"); got != nil {
+		t.Errorf("Delivered(toast, wt-c) = %v, want none", got)
+	}
+
+	if !loaded.Prune(func(name string) bool { return name == "shadow" }) {
+		t.Error("Prune() = false, want toast dropped")
+	}
+	if loaded.Prune(func(name string) bool { return name == "shadow" }) {
+		t.Error("second Prune() = true, want nothing dropped")
+	}
+
+	// The file goes away with the last entry
+	loaded.Prune(func(string) bool { return false })
+	if err := loaded.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(cfg.ConfigDir(), "bead-comments.json")); !os.IsNotExist(err) {
+		t.Errorf("store file still exists after pruning every entry: %v", err)
+	}
+}
+
+func TestPending(t *testing.T) {
+	started := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	comments := []bead.Comment{
+		{ID: json.RawMessage("1"), Author: "alice", Text: "Before the session started", CreatedAt: "2026-03-01T09:00:00Z"},
+		{ID: json.RawMessage("2"), Author: "alice", Text: "Use the v2 endpoint instead", CreatedAt: "2026-03-01T10:05:00Z"},
+		{ID: json.RawMessage("3"), Author: "alice", Text: "Also keep the old flag", CreatedAt: "2026-03-01T10:06:00Z"},
+		{ID: json.RawMessage("4"), Author: "wt", Text: "wt: session toast started", CreatedAt: "2026-03-01T10:07:00Z"},
+		{ID: json.RawMessage("5"), Author: "bob", Text: "  ", CreatedAt: "2026-03-01T10:08:00Z"},
+		{ID: json.RawMessage("6"), Author: "bob", Text: "No timestamp", CreatedAt: ""},
+	}
+
+	pending := Pending(comments, started, []string{"3"})
+	if len(pending) != 1 || pending[0].Key() != "2" {
+		t.Fatalf("Pending() = %+v, want only comment 2", pending)
+	}
+}
+
+func TestFromWT(t *testing.T) {
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"wt: session toast signaled ready", true},
+		{"TODO/FIXME markers added by this work:\n- main.go:12 TODO: retry", true},
+		{"Work log of session toast (12 turns):", true},
+		{"Session toast summary (3 files changed):\n- commit", true},
+		{"Session handling looks wrong in main.go", false},
+		{"Please also update the docs", false},
+	}
+	for _, tt := range tests {
+		if got := FromWT(tt.text); got != tt.want {
+			t.Errorf("FromWT(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestPrompt(t *testing.T) {
+	if got := Prompt(bead.Comment{Author: "alice", Text: " Use v2\n"}); got != "New bead comment: Use v2 (from alice)" {
+		t.Errorf("Prompt() = %q", got)
+	}
+	if got := Prompt(bead.Comment{Text: "Use v2"}); got != "New bead comment: Use v2" {
+		t.Errorf("Prompt() without author = %q", got)
+	}
+}
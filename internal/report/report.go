@@ -0,0 +1,191 @@
+// Package report holds the structured result a worker attaches when it
+// finishes ('wt signal ready --report <file>'). wt done turns it into the PR
+// description and creates the follow-up beads it suggests.
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/badri/wt/internal/config"
+)
+
+// Tests records the test run a worker did before finishing
+type Tests struct {
+	Command string `json:"command,omitempty"`
+	Run     int    `json:"run"`
+	Passed  int    `json:"passed"`
+	Failed  int    `json:"failed,omitempty"`
+}
+
+// FollowUp is work the worker found but left for a new bead
+type FollowUp struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Type        string `json:"type,omitempty"`     // bd issue type (default task)
+	Priority    *int   `json:"priority,omitempty"` // 0-4 (default 2)
+	Bead        string `json:"bead,omitempty"`     // Set once wt has created the bead
+}
+
+// Report is a worker's structured result
+type Report struct {
+	Summary       string     `json:"summary,omitempty"`
+	FilesChanged  []string   `json:"files_changed,omitempty"`
+	Tests         *Tests     `json:"tests,omitempty"`
+	FollowUps     []FollowUp `json:"follow_ups,omitempty"`
+	OpenQuestions []string   `json:"open_questions,omitempty"`
+}
+
+// Parse reads a report, rejecting unknown keys so a typo doesn't silently
+// drop part of it
+func Parse(data []byte) (*Report, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var r Report
+	if err := dec.Decode(&r); err != nil {
+		return nil, fmt.Errorf("parsing report: %w", err)
+	}
+	for i, f := range r.FollowUps {
+		if strings.TrimSpace(f.Title) == "" {
+			return nil, fmt.Errorf("follow_ups[%d] has no title", i)
+		}
+		if f.Priority != nil && (*f.Priority < 0 || *f.Priority > 4) {
+			return nil, fmt.Errorf("follow_ups[%d] priority must be 0-4", i)
+		}
+	}
+	if t := r.Tests; t != nil && (t.Passed < 0 || t.Failed < 0 || t.Passed+t.Failed > t.Run) {
+		return nil, fmt.Errorf("tests: passed and failed must add up to at most run")
+	}
+	return &r, nil
+}
+
+// Read parses a report file, or stdin for "-"
+func Read(path string) (*Report, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading report: %w", err)
+	}
+	return Parse(data)
+}
+
+func reportPath(cfg *config.Config, beadID string) string {
+	return filepath.Join(cfg.ConfigDir(), "reports", beadID+".json")
+}
+
+// Save stores a bead's report until wt done consumes it
+func Save(cfg *config.Config, beadID string, r *Report) error {
+	path := reportPath(cfg, beadID)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// Load returns a bead's stored report, or nil if it has none
+func Load(cfg *config.Config, beadID string) (*Report, error) {
+	data, err := os.ReadFile(reportPath(cfg, beadID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var r Report
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("parsing stored report: %w", err)
+	}
+	return &r, nil
+}
+
+// Remove deletes a bead's stored report
+func Remove(cfg *config.Config, beadID string) error {
+	if err := os.Remove(reportPath(cfg, beadID)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Brief is a one-line digest, e.g. "3 files, tests 41/42 passed, 2 follow-ups"
+func (r *Report) Brief() string {
+	var parts []string
+	if n := len(r.FilesChanged); n > 0 {
+		parts = append(parts, plural(n, "file"))
+	}
+	if t := r.Tests; t != nil {
+		parts = append(parts, fmt.Sprintf("tests %d/%d passed", t.Passed, t.Run))
+	}
+	if n := len(r.FollowUps); n > 0 {
+		parts = append(parts, plural(n, "follow-up"))
+	}
+	if n := len(r.OpenQuestions); n > 0 {
+		parts = append(parts, plural(n, "open question"))
+	}
+	if len(parts) == 0 {
+		return "no details"
+	}
+	return strings.Join(parts, ", ")
+}
+
+func plural(n int, word string) string {
+	if n == 1 {
+		return "1 " + word
+	}
+	return fmt.Sprintf("%d %ss", n, word)
+}
+
+// PRBody renders the report as a PR description
+func (r *Report) PRBody(beadID string) string {
+	var sb strings.Builder
+	if r.Summary != "" {
+		fmt.Fprintf(&sb, "## Summary\n\n%s\n\n", strings.TrimSpace(r.Summary))
+	}
+	if len(r.FilesChanged) > 0 {
+		sb.WriteString("## Files changed\n\n")
+		for _, f := range r.FilesChanged {
+			fmt.Fprintf(&sb, "- `%s`\n", f)
+		}
+		sb.WriteString("\n")
+	}
+	if t := r.Tests; t != nil {
+		sb.WriteString("## Tests\n\n")
+		if t.Command != "" {
+			fmt.Fprintf(&sb, "`%s`: ", t.Command)
+		}
+		fmt.Fprintf(&sb, "%d run, %d passed, %d failed\n\n", t.Run, t.Passed, t.Failed)
+	}
+	if len(r.FollowUps) > 0 {
+		sb.WriteString("## Follow-ups\n\n")
+		for _, f := range r.FollowUps {
+			if f.Bead != "" {
+				fmt.Fprintf(&sb, "- %s (%s)\n", f.Title, f.Bead)
+			} else {
+				fmt.Fprintf(&sb, "- %s\n", f.Title)
+			}
+		}
+		sb.WriteString("\n")
+	}
+	if len(r.OpenQuestions) > 0 {
+		sb.WriteString("## Open questions\n\n")
+		for _, q := range r.OpenQuestions {
+			fmt.Fprintf(&sb, "- %s\n", q)
+		}
+		sb.WriteString("\n")
+	}
+	fmt.Fprintf(&sb, "Closes bead: %s", beadID)
+	return sb.String()
+}
@@ -0,0 +1,85 @@
+package report
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/badri/wt/internal/config"
+)
+
+const sample = `{
+  "summary": "Added retry with backoff to uploads",
+  "files_changed": ["upload/retry.go", "upload/retry_test.go"],
+  "tests": {"command": "go test ./upload/...", "run": 42, "passed": 42},
+  "follow_ups": [{"title": "Make backoff configurable", "priority": 3}],
+  "open_questions": ["Should retries cover 4xx responses?"]
+}`
+
+func TestParse(t *testing.T) {
+	r, err := Parse([]byte(sample))
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	if got := r.Brief(); got != "2 files, tests 42/42 passed, 1 follow-up, 1 open question" {
+		t.Errorf("Brief() = %q", got)
+	}
+	if *r.FollowUps[0].Priority != 3 {
+		t.Errorf("follow-up priority = %d, want 3", *r.FollowUps[0].Priority)
+	}
+
+	for _, bad := range []string{
+		`{"sumary": "typo"}`,
+		`{"follow_ups": [{"description": "no title"}]}`,
+		`{"follow_ups": [{"title": "x", "priority": 7}]}`,
+		`{"tests": {"run": 3, "passed": 3, "failed": 1}}`,
+		`not json`,
+	} {
+		if _, err := Parse([]byte(bad)); err == nil {
+			t.Errorf("Parse(%s) should fail", bad)
+		}
+	}
+}
+
+func TestPRBody(t *testing.T) {
+	r, _ := Parse([]byte(sample))
+	r.FollowUps[0].Bead = "wt-xyz"
+	body := r.PRBody("wt-abc")
+	for _, want := range []string{
+		"## Summary\n\nAdded retry with backoff to uploads",
+		"- `upload/retry.go`",
+		"`go test ./upload/...`: 42 run, 42 passed, 0 failed",
+		"- Make backoff configurable (wt-xyz)",
+		"- Should retries cover 4xx responses?",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("PRBody() missing %q:\n%s", want, body)
+		}
+	}
+	if !strings.HasSuffix(body, "Closes bead: wt-abc") {
+		t.Errorf("PRBody() should end with the bead reference:\n%s", body)
+	}
+	if got := (&Report{}).PRBody("wt-abc"); got != "Closes bead: wt-abc" {
+		t.Errorf("empty PRBody() = %q", got)
+	}
+}
+
+func TestStore(t *testing.T) {
+	cfg, _ := config.LoadFromDir(t.TempDir())
+	if r, err := Load(cfg, "wt-abc"); r != nil || err != nil {
+		t.Fatalf("Load() with no report = %v, %v", r, err)
+	}
+	r, _ := Parse([]byte(sample))
+	if err := Save(cfg, "wt-abc", r); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Load(cfg, "wt-abc")
+	if err != nil || got == nil || got.Summary != r.Summary {
+		t.Fatalf("Load() = %+v, %v", got, err)
+	}
+	if err := Remove(cfg, "wt-abc"); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := Load(cfg, "wt-abc"); got != nil {
+		t.Error("report still there after Remove()")
+	}
+}
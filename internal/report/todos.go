@@ -0,0 +1,87 @@
+package report
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/badri/wt/internal/logging"
+)
+
+// TODO is a TODO or FIXME marker that a branch adds
+type TODO struct {
+	File string `json:"file"`
+	Line int    `json:"line"`
+	Kind string `json:"kind"` // TODO or FIXME
+	Text string `json:"text"`
+}
+
+// todoPattern matches a marker as a word, e.g. "// TODO: x", "# FIXME(bob) x"
+var todoPattern = regexp.MustCompile(`\b(TODO|FIXME)\b(?:\([^)]*\))?:?\s*(.*)`)
+
+// hunkPattern reads the new-file start line of a hunk header
+var hunkPattern = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,\d+)? @@`)
+
+// ScanTODOs returns the TODO and FIXME markers the worktree's branch adds
+// compared with baseRef, e.g. "main". Markers that were moved or were
+// already there are not in the added lines, so they are left out.
+func ScanTODOs(worktreePath, baseRef string) ([]TODO, error) {
+	cmd := logging.Command("git", "-C", worktreePath, "diff", "--no-color", "--no-ext-diff", "-U0", baseRef+"...HEAD")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("diffing against %s: %w", baseRef, err)
+	}
+	return parseTODOs(string(output)), nil
+}
+
+func parseTODOs(diff string) []TODO {
+	var todos []TODO
+	file := ""
+	line := 0
+	for _, l := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(l, "+++ "):
+			file = strings.TrimPrefix(strings.TrimPrefix(l, "+++ "), "b/")
+			if file == "/dev/null" {
+				file = ""
+			}
+		case strings.HasPrefix(l, "@@"):
+			if m := hunkPattern.FindStringSubmatch(l); m != nil {
+				line, _ = strconv.Atoi(m[1])
+			}
+		case strings.HasPrefix(l, "+") && file != "":
+			if m := todoPattern.FindStringSubmatch(l[1:]); m != nil {
+				todos = append(todos, TODO{File: file, Line: line, Kind: m[1], Text: cleanTODOText(m[2])})
+			}
+			line++
+		}
+	}
+	return todos
+}
+
+// cleanTODOText drops what closes a comment, e.g. "*/" or "-->"
+func cleanTODOText(s string) string {
+	s = strings.TrimSpace(s)
+	for _, end := range []string{"*/", "-->", "#}", "%>"} {
+		s = strings.TrimSpace(strings.TrimSuffix(s, end))
+	}
+	return s
+}
+
+// Title is the title of the follow-up bead for the marker
+func (t TODO) Title() string {
+	text := t.Text
+	if text == "" {
+		text = fmt.Sprintf("%s in %s", t.Kind, t.File)
+	}
+	if r := []rune(text); len(r) > 80 {
+		text = strings.TrimSpace(string(r[:77])) + "..."
+	}
+	return text
+}
+
+// Location is the marker's place as "file:line"
+func (t TODO) Location() string {
+	return fmt.Sprintf("%s:%d", t.File, t.Line)
+}
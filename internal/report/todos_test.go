@@ -0,0 +1,55 @@
+package report
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const todoDiff = `diff --git a/upload/retry.go b/upload/retry.go
+index 1111111..2222222 100644
+--- a/upload/retry.go
++++ b/upload/retry.go
+@@ -10,0 +11,2 @@ func retry() {
++	// TODO: make the backoff configurable
++	delay := time.Second
+@@ -20 +22 @@ func retry() {
+-	// TODO: old marker, only reworded
++	// TODO(bob) handle 429 separately
+diff --git a/README.md b/README.md
+--- a/README.md
++++ b/README.md
+@@ -3,0 +4 @@
++<!-- FIXME: document retries -->
+diff --git a/gone.go b/gone.go
+--- a/gone.go
++++ /dev/null
+@@ -1 +0,0 @@
+-// FIXME: removed with the file
+diff --git a/notes.txt b/notes.txt
+--- a/notes.txt
++++ b/notes.txt
+@@ -1,0 +2 @@
++TODOS are not markers; neither is MYTODO
+`
+
+func TestParseTODOs(t *testing.T) {
+	want := []TODO{
+		{File: "upload/retry.go", Line: 11, Kind: "TODO", Text: "make the backoff configurable"},
+		{File: "upload/retry.go", Line: 22, Kind: "TODO", Text: "handle 429 separately"},
+		{File: "README.md", Line: 4, Kind: "FIXME", Text: "document retries"},
+	}
+	if got := parseTODOs(todoDiff); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseTODOs() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestTODOTitle(t *testing.T) {
+	if got := (TODO{File: "a.go", Kind: "FIXME"}).Title(); got != "FIXME in a.go" {
+		t.Errorf("Title() of an empty marker = %q", got)
+	}
+	long := TODO{Kind: "TODO", Text: strings.Repeat("word ", 30)}
+	if got := long.Title(); len([]rune(got)) > 80 || !strings.HasSuffix(got, "...") {
+		t.Errorf("Title() of a long marker = %q", got)
+	}
+}
@@ -0,0 +1,136 @@
+package session
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/badri/wt/internal/config"
+)
+
+// Steps of 'wt new' recorded in a Pending session, in order
+const (
+	StepWorktree  = "worktree"
+	StepGitHooks  = "git_hooks"
+	StepProvision = "provision"
+	StepTmux      = "tmux"
+	StepTestEnv   = "test_env"
+	StepOnCreate  = "on_create"
+	StepState     = "state"
+	StepPrompt    = "prompt"
+)
+
+// Pending records how far 'wt new' or 'wt clone' got creating a session.
+// It is removed once the session is complete; if the command dies midway,
+// it remains so that rerunning it with --resume finishes the remaining steps.
+type Pending struct {
+	Bead       string   `json:"bead"`
+	Name       string   `json:"name"`
+	ThemeName  string   `json:"theme_name,omitempty"`
+	Project    string   `json:"project,omitempty"`
+	RepoPath   string   `json:"repo_path"`
+	Worktree   string   `json:"worktree"`
+	Branch     string   `json:"branch,omitempty"`
+	BaseBranch string   `json:"base_branch"`
+	StackedOn  string   `json:"stacked_on,omitempty"`
+	PortOffset int      `json:"port_offset,omitempty"`
+	Steps      []string `json:"steps"`
+	StartedAt  string   `json:"started_at"`
+
+	path string
+}
+
+func pendingDir(cfg *config.Config) string {
+	return filepath.Join(cfg.ConfigDir(), "pending")
+}
+
+// NewPending starts a pending record for a bead. It is not written until
+// the first step is marked.
+func NewPending(cfg *config.Config, beadID string) *Pending {
+	return &Pending{
+		Bead:      beadID,
+		StartedAt: Now(),
+		path:      filepath.Join(pendingDir(cfg), beadID+".json"),
+	}
+}
+
+// LoadPending returns the pending record of an interrupted 'wt new' for a
+// bead, or nil if there is none.
+func LoadPending(cfg *config.Config, beadID string) (*Pending, error) {
+	p := NewPending(cfg, beadID)
+	data, err := os.ReadFile(p.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// ListPending returns the pending records of all interrupted 'wt new' runs
+func ListPending(cfg *config.Config) ([]*Pending, error) {
+	entries, err := os.ReadDir(pendingDir(cfg))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var pending []*Pending
+	for _, e := range entries {
+		name := e.Name()
+		if filepath.Ext(name) != ".json" {
+			continue
+		}
+		p, err := LoadPending(cfg, name[:len(name)-len(".json")])
+		if err != nil || p == nil {
+			continue
+		}
+		pending = append(pending, p)
+	}
+	return pending, nil
+}
+
+// Done reports whether a step has completed
+func (p *Pending) Done(step string) bool {
+	return slices.Contains(p.Steps, step)
+}
+
+// Mark records a completed step and saves the record
+func (p *Pending) Mark(step string) error {
+	if !p.Done(step) {
+		p.Steps = append(p.Steps, step)
+	}
+	return p.Save()
+}
+
+// Unmark forgets a step that was rolled back
+func (p *Pending) Unmark(step string) {
+	p.Steps = slices.DeleteFunc(p.Steps, func(s string) bool { return s == step })
+}
+
+// Save writes the record to disk
+func (p *Pending) Save() error {
+	if err := os.MkdirAll(filepath.Dir(p.path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(p.path, data, 0644)
+}
+
+// Remove deletes the record once the session is complete
+func (p *Pending) Remove() error {
+	if err := os.Remove(p.path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package session
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/badri/wt/internal/config"
+)
+
+func TestPendingRoundTrip(t *testing.T) {
+	cfg, err := config.LoadFromDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if p, err := LoadPending(cfg, "wt-abc"); err != nil || p != nil {
+		t.Fatalf("LoadPending(none) = %v, %v; want nil, nil", p, err)
+	}
+
+	p := NewPending(cfg, "wt-abc")
+	p.Name = "wt-toast"
+	if err := p.Mark(StepWorktree); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Mark(StepTmux); err != nil {
+		t.Fatal(err)
+	}
+	p.Mark(StepTmux)
+
+	loaded, err := LoadPending(cfg, "wt-abc")
+	if err != nil || loaded == nil {
+		t.Fatalf("LoadPending() = %v, %v", loaded, err)
+	}
+	if want := []string{StepWorktree, StepTmux}; !reflect.DeepEqual(loaded.Steps, want) {
+		t.Errorf("Steps = %v, want %v", loaded.Steps, want)
+	}
+	if loaded.Name != "wt-toast" || !loaded.Done(StepTmux) || loaded.Done(StepState) {
+		t.Errorf("loaded pending = %+v", loaded)
+	}
+
+	loaded.Unmark(StepTmux)
+	if loaded.Done(StepTmux) {
+		t.Error("Unmark() should forget the step")
+	}
+
+	all, err := ListPending(cfg)
+	if err != nil || len(all) != 1 || all[0].Bead != "wt-abc" {
+		t.Errorf("ListPending() = %v, %v; want the wt-abc record", all, err)
+	}
+
+	if err := loaded.Remove(); err != nil {
+		t.Fatal(err)
+	}
+	if p, _ := LoadPending(cfg, "wt-abc"); p != nil {
+		t.Error("record still present after Remove()")
+	}
+	if err := loaded.Remove(); err != nil {
+		t.Errorf("Remove() twice = %v, want nil", err)
+	}
+}
@@ -22,17 +22,28 @@ const (
 )
 
 type Session struct {
-	Bead          string `json:"bead"`
-	Project       string `json:"project"`
-	Worktree      string `json:"worktree"`
-	Branch        string `json:"branch"`
-	PortOffset    int    `json:"port_offset,omitempty"`
-	BeadsDir      string `json:"beads_dir"`
-	CreatedAt     string `json:"created_at"`
-	LastActivity  string `json:"last_activity"`
-	Status        string `json:"status"`                   // working, idle, ready, blocked, error
-	StatusMessage string `json:"status_message,omitempty"` // Optional message (e.g., PR URL, error details)
-	ThemeName     string `json:"theme_name,omitempty"`     // Allocated name from namepool (without project prefix)
+	Bead          string       `json:"bead"`
+	Project       string       `json:"project"`
+	Worktree      string       `json:"worktree"`
+	Branch        string       `json:"branch"`
+	PortOffset    int          `json:"port_offset,omitempty"`
+	BeadsDir      string       `json:"beads_dir"`
+	CreatedAt     string       `json:"created_at"`
+	LastActivity  string       `json:"last_activity"`
+	Status        string       `json:"status"`                   // working, idle, ready, blocked, error
+	StatusMessage string       `json:"status_message,omitempty"` // Optional message (e.g., PR URL, error details)
+	ThemeName     string       `json:"theme_name,omitempty"`     // Allocated name from namepool (without project prefix)
+	StackedOn     string       `json:"stacked_on,omitempty"`     // Parent bead this session's branch is stacked on
+	StackBranch   string       `json:"stack_branch,omitempty"`   // Parent branch this session's branch was created from
+	AwaitingAck   bool         `json:"awaiting_ack,omitempty"`   // Blocked in 'wt signal --wait' until 'wt ack'
+	BlockedOn     string       `json:"blocked_on,omitempty"`     // Bead a 'wt block --on' session waits for
+	Agent         string       `json:"agent,omitempty"`          // Agent running in the session (empty = claude)
+	DependsOn     []Dependency `json:"depends_on,omitempty"`     // Sessions whose work must merge before this one's
+	PausedAt      string       `json:"paused_at,omitempty"`      // Set by 'wt pause'; tmux and the test env are stopped
+	EnvDownAt     string       `json:"env_down_at,omitempty"`    // Set by 'wt teardown-all'; only the test env is stopped
+	ResumeID      string       `json:"resume_id,omitempty"`      // Agent conversation 'wt resume' continues
+	SparsePaths   []string     `json:"sparse_paths,omitempty"`   // Sparse-checkout paths; empty for a full checkout
+	Epic          string       `json:"epic,omitempty"`           // Epic the bead belongs to, or the bead itself for an epic (wt auto)
 
 	// Task session fields
 	Type                SessionType         `json:"type,omitempty"`                 // "bead" or "task"
@@ -40,6 +51,28 @@ type Session struct {
 	CompletionCondition CompletionCondition `json:"completion_condition,omitempty"` // How task is considered complete
 }
 
+// Dependency is another session's work that must merge first, recorded by
+// bead and branch so it outlives that session
+type Dependency struct {
+	Bead   string `json:"bead"`
+	Branch string `json:"branch"`
+}
+
+// DependsOnBead reports whether the session declared a dependency on a bead
+func (s *Session) DependsOnBead(beadID string) bool {
+	for _, d := range s.DependsOn {
+		if d.Bead == beadID {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPaused returns true if the session was stopped with 'wt pause'
+func (s *Session) IsPaused() bool {
+	return s.PausedAt != ""
+}
+
 // IsBead returns true if this is a bead-based session
 func (s *Session) IsBead() bool {
 	return s.Type == "" || s.Type == SessionTypeBead
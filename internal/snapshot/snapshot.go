@@ -0,0 +1,135 @@
+// Package snapshot records the session fleet at 'wt shutdown' so that
+// 'wt resume-all' can recreate it after a reboot. Uncommitted work is kept
+// as patch files next to the snapshot, in case the worktree does not survive.
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/session"
+)
+
+// File is the snapshot file name in the config directory
+const File = "shutdown.json"
+
+// PatchDir is the directory (in the config directory) holding uncommitted-change patches
+const PatchDir = "shutdown"
+
+// Saved is one session as it was at shutdown.
+type Saved struct {
+	Session       *session.Session `json:"session"`
+	Head          string           `json:"head,omitempty"`           // Commit checked out at shutdown
+	Patch         string           `json:"patch,omitempty"`          // Uncommitted changes, if any
+	ClaudeSession string           `json:"claude_session,omitempty"` // For claude --resume
+}
+
+// Snapshot holds every session saved by the last shutdown.
+type Snapshot struct {
+	CreatedAt string            `json:"created_at"`
+	Sessions  map[string]*Saved `json:"sessions"`
+	path      string
+}
+
+// Load reads the snapshot, returning an empty one if there is none.
+func Load(cfg *config.Config) (*Snapshot, error) {
+	s := &Snapshot{
+		Sessions: make(map[string]*Saved),
+		path:     filepath.Join(cfg.ConfigDir(), File),
+	}
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+	if s.Sessions == nil {
+		s.Sessions = make(map[string]*Saved)
+	}
+	return s, nil
+}
+
+// Save writes the snapshot, or removes the file once no sessions are left.
+func (s *Snapshot) Save() error {
+	if len(s.Sessions) == 0 {
+		err := os.Remove(s.path)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0644)
+}
+
+// PatchPath returns where a session's uncommitted changes are saved.
+func PatchPath(cfg *config.Config, sessionName string) string {
+	return filepath.Join(cfg.ConfigDir(), PatchDir, sessionName+".patch")
+}
+
+// WritePatch saves all uncommitted changes in a worktree, including untracked
+// files, as a binary patch against HEAD. A temporary index is used so the
+// worktree's own index is left untouched. Returns false if there was nothing to save.
+func WritePatch(worktreePath, patchPath string) (bool, error) {
+	tmpIndex, err := os.CreateTemp("", "wt-shutdown-index-*")
+	if err != nil {
+		return false, err
+	}
+	tmpIndex.Close()
+	defer os.Remove(tmpIndex.Name())
+
+	env := append(os.Environ(), "GIT_INDEX_FILE="+tmpIndex.Name())
+	for _, args := range [][]string{{"read-tree", "HEAD"}, {"add", "-A"}} {
+		cmd := logging.Command("git", append([]string{"-C", worktreePath}, args...)...)
+		cmd.Env = env
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return false, fmt.Errorf("git %s: %s: %w", args[0], strings.TrimSpace(string(output)), err)
+		}
+	}
+
+	cmd := logging.Command("git", "-C", worktreePath, "diff", "--cached", "--binary", "HEAD")
+	cmd.Env = env
+	patch, err := cmd.Output()
+	if err != nil {
+		return false, fmt.Errorf("git diff: %w", err)
+	}
+	if len(patch) == 0 {
+		return false, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(patchPath), 0755); err != nil {
+		return false, err
+	}
+	return true, os.WriteFile(patchPath, patch, 0644)
+}
+
+// ApplyPatch restores changes saved by WritePatch into a worktree.
+func ApplyPatch(worktreePath, patchPath string) error {
+	cmd := logging.Command("git", "-C", worktreePath, "apply", "--binary", patchPath)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git apply: %s: %w", strings.TrimSpace(string(output)), err)
+	}
+	return nil
+}
+
+// Head returns the commit checked out in a worktree.
+func Head(worktreePath string) string {
+	output, err := logging.Command("git", "-C", worktreePath, "rev-parse", "HEAD").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
@@ -0,0 +1,127 @@
+package snapshot
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/session"
+)
+
+func TestLoadSave(t *testing.T) {
+	cfg, err := config.LoadFromDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := Load(cfg)
+	if err != nil {
+		t.Fatalf("Load() with no file error: %v", err)
+	}
+	if len(s.Sessions) != 0 {
+		t.Errorf("Load() with no file = %d sessions, want 0", len(s.Sessions))
+	}
+
+	s.CreatedAt = "2026-01-01T00:00:00Z"
+	s.Sessions["toast"] = &Saved{
+		Session:       &session.Session{Bead: "proj-abc", Branch: "proj-abc", PortOffset: 2},
+		Head:          "abc123",
+		ClaudeSession: "uuid-1",
+	}
+	if err := s.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	loaded, err := Load(cfg)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	got := loaded.Sessions["toast"]
+	if got == nil || got.Session.Bead != "proj-abc" || got.Session.PortOffset != 2 || got.ClaudeSession != "uuid-1" {
+		t.Errorf("Load() = %+v", got)
+	}
+
+	// Saving an empty snapshot removes the file
+	delete(loaded.Sessions, "toast")
+	if err := loaded.Save(); err != nil {
+		t.Fatalf("Save() empty error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(cfg.ConfigDir(), File)); !os.IsNotExist(err) {
+		t.Errorf("snapshot file should be removed, stat err = %v", err)
+	}
+}
+
+func TestWriteAndApplyPatch(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	repo := t.TempDir()
+	git(t, repo, "init", "-q", "-b", "main")
+	git(t, repo, "config", "user.email", "test@example.com")
+	git(t, repo, "config", "user.name", "Test")
+	writeFile(t, repo, "tracked.txt", "original\n")
+	git(t, repo, "add", "tracked.txt")
+	git(t, repo, "commit", "-q", "-m", "initial")
+
+	patchPath := filepath.Join(t.TempDir(), "toast.patch")
+
+	// Clean worktree: nothing to save
+	saved, err := WritePatch(repo, patchPath)
+	if err != nil || saved {
+		t.Fatalf("WritePatch() on clean tree = %v, %v; want false, nil", saved, err)
+	}
+
+	// Modified tracked file, staged new file and untracked file
+	writeFile(t, repo, "tracked.txt", "changed\n")
+	writeFile(t, repo, "staged.txt", "staged\n")
+	git(t, repo, "add", "staged.txt")
+	writeFile(t, repo, "untracked.txt", "untracked\n")
+
+	saved, err = WritePatch(repo, patchPath)
+	if err != nil || !saved {
+		t.Fatalf("WritePatch() = %v, %v; want true, nil", saved, err)
+	}
+
+	// The worktree's own index is unchanged
+	if out := git(t, repo, "diff", "--cached", "--name-only"); out != "staged.txt\n" {
+		t.Errorf("index changed by WritePatch, staged = %q", out)
+	}
+
+	// Restore into a fresh clone of the same commit
+	clone := filepath.Join(t.TempDir(), "clone")
+	git(t, repo, "clone", "-q", repo, clone)
+	if err := ApplyPatch(clone, patchPath); err != nil {
+		t.Fatalf("ApplyPatch() error: %v", err)
+	}
+	for name, want := range map[string]string{"tracked.txt": "changed\n", "staged.txt": "staged\n", "untracked.txt": "untracked\n"} {
+		data, err := os.ReadFile(filepath.Join(clone, name))
+		if err != nil || string(data) != want {
+			t.Errorf("%s = %q, %v; want %q", name, data, err, want)
+		}
+	}
+
+	if Head(repo) == "" {
+		t.Error("Head() returned empty for a repo with a commit")
+	}
+}
+
+func git(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, output)
+	}
+	return string(output)
+}
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,41 @@
+// Package stack records stacked branches: a bead whose branch was created off
+// another bead's unmerged branch. The records outlive the sessions so the
+// child's PR can be retargeted to the default branch once the parent merges.
+package stack
+
+import (
+	"path/filepath"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/jsonstore"
+)
+
+// Entry describes one stacked bead.
+type Entry struct {
+	Bead         string `json:"bead"`
+	Branch       string `json:"branch"`
+	ParentBead   string `json:"parent_bead"`
+	ParentBranch string `json:"parent_branch"`
+	Project      string `json:"project,omitempty"`
+	RepoPath     string `json:"repo_path"`
+	PRURL        string `json:"pr_url,omitempty"`
+}
+
+// Store holds all stack entries, keyed by child bead ID.
+type Store struct {
+	*jsonstore.Store[Entry]
+}
+
+// Load reads the stack store from the config directory.
+func Load(cfg *config.Config) (*Store, error) {
+	s, err := jsonstore.Load[Entry](filepath.Join(cfg.ConfigDir(), "stacks.json"))
+	if err != nil {
+		return nil, err
+	}
+	return &Store{s}, nil
+}
+
+// Put adds or replaces the entry for e.Bead.
+func (s *Store) Put(e *Entry) {
+	s.Set(e.Bead, e)
+}
@@ -0,0 +1,45 @@
+package stack
+
+import (
+	"testing"
+
+	"github.com/badri/wt/internal/config"
+)
+
+func TestStoreRoundTrip(t *testing.T) {
+	cfg, err := config.LoadFromDir(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadFromDir() error: %v", err)
+	}
+
+	store, err := Load(cfg)
+	if err != nil {
+		t.Fatalf("Load() on missing file error: %v", err)
+	}
+	if len(store.Entries) != 0 {
+		t.Fatalf("new store has %d entries, want 0", len(store.Entries))
+	}
+
+	store.Put(&Entry{Bead: "wt-b", Branch: "wt-b", ParentBead: "wt-a", ParentBranch: "wt-a", RepoPath: "/repo"})
+	store.Put(&Entry{Bead: "wt-c", Branch: "wt-c", ParentBead: "wt-b", ParentBranch: "wt-b", RepoPath: "/repo"})
+	if err := store.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	loaded, err := Load(cfg)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	e := loaded.Get("wt-b")
+	if e == nil || e.ParentBranch != "wt-a" {
+		t.Fatalf("Get(wt-b) = %+v, want parent branch wt-a", e)
+	}
+
+	loaded.Remove("wt-b")
+	if loaded.Get("wt-b") != nil {
+		t.Error("Get(wt-b) after Remove should be nil")
+	}
+	if loaded.Get("wt-c") == nil {
+		t.Error("Remove(wt-b) should not remove wt-c")
+	}
+}
@@ -0,0 +1,184 @@
+// Package summary builds end-of-session summaries from a worktree's git history.
+// Summaries are stored on session_end events and shown by wt seance.
+package summary
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/badri/wt/internal/events"
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/transcript"
+)
+
+// generateTimeout bounds how long we wait for Claude to write the narrative summary.
+const generateTimeout = 90 * time.Second
+
+// lastMessageMax caps the length of a stored last message, in runes
+const lastMessageMax = 300
+
+// Capture collects the commit list and diff stat for a worktree relative to
+// baseBranch and, if generate is set, asks Claude for a one-paragraph summary.
+// Returns nil if there is nothing to summarize.
+func Capture(worktreePath, baseBranch, title string, generate bool) *events.Summary {
+	s := Collect(worktreePath, baseBranch)
+	if s == nil {
+		return nil
+	}
+
+	if generate {
+		text, err := Generate(worktreePath, title, s)
+		if err != nil {
+			logging.Warnf("could not generate session summary: %v", err)
+		} else {
+			s.Text = text
+		}
+	}
+
+	return s
+}
+
+// Collect returns the commits and diff stat on the worktree's branch that are
+// not on baseBranch. Returns nil if the branch has no commits of its own.
+func Collect(worktreePath, baseBranch string) *events.Summary {
+	cmd := logging.CommandIn(worktreePath, "git", "log", "--oneline", "--no-decorate", baseBranch+"..HEAD")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil
+	}
+	commits := parseLines(string(output))
+	if len(commits) == 0 {
+		return nil
+	}
+
+	s := &events.Summary{Commits: commits}
+
+	cmd = logging.Command("git", "diff", "--shortstat", baseBranch+"...HEAD")
+	cmd.Dir = worktreePath
+	if output, err := cmd.Output(); err == nil {
+		s.DiffStat = strings.TrimSpace(string(output))
+	}
+
+	return s
+}
+
+// Generate asks Claude for a one-paragraph summary of the session's work.
+func Generate(worktreePath, title string, s *events.Summary) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), generateTimeout)
+	defer cancel()
+
+	cmd := logging.CommandContext(ctx, "claude", "--print", BuildPrompt(title, s))
+	cmd.Dir = worktreePath
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("running claude: %w", err)
+	}
+
+	text := strings.Join(strings.Fields(string(output)), " ")
+	if text == "" {
+		return "", fmt.Errorf("claude returned an empty summary")
+	}
+	return text, nil
+}
+
+// LastMessage returns the last thing a worker said in its Claude session,
+// collapsed to one line and shortened, or "" if the transcript can't be
+// found. It usually tells where the work stopped.
+func LastMessage(worktreePath, claudeSession string) string {
+	if claudeSession == "" {
+		return ""
+	}
+	path, err := transcript.Find(worktreePath, claudeSession)
+	if err != nil {
+		return ""
+	}
+	messages, err := transcript.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return oneLine(transcript.LastAssistantText(messages), lastMessageMax)
+}
+
+// oneLine collapses whitespace and cuts s to max runes
+func oneLine(s string, max int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if r := []rune(s); len(r) > max {
+		s = strings.TrimSpace(string(r[:max-1])) + "…"
+	}
+	return s
+}
+
+// BuildPrompt builds the prompt used to generate a narrative summary.
+func BuildPrompt(title string, s *events.Summary) string {
+	var sb strings.Builder
+	sb.WriteString("Write a single plain-text paragraph (3-5 sentences, no markdown, no preamble) ")
+	sb.WriteString("summarizing what this work session accomplished, for someone skimming past sessions.\n\n")
+	if title != "" {
+		sb.WriteString(fmt.Sprintf("Task: %s\n\n", title))
+	}
+	sb.WriteString("Commits:\n")
+	for _, c := range s.Commits {
+		sb.WriteString(fmt.Sprintf("- %s\n", c))
+	}
+	if s.DiffStat != "" {
+		sb.WriteString(fmt.Sprintf("\nDiff: %s\n", s.DiffStat))
+	}
+	return sb.String()
+}
+
+// FormatComment renders a summary as a bead comment.
+func FormatComment(sessionName string, s *events.Summary) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Session %s summary", sessionName))
+	if s.DiffStat != "" {
+		sb.WriteString(fmt.Sprintf(" (%s)", s.DiffStat))
+	}
+	sb.WriteString(":\n")
+	if s.Text != "" {
+		sb.WriteString("\n" + s.Text + "\n")
+	}
+	sb.WriteString("\nCommits:\n")
+	for _, c := range s.Commits {
+		sb.WriteString(fmt.Sprintf("- %s\n", c))
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// Headline returns a short one-line description of a summary for list views:
+// the narrative, else the worker's last message, else the commits.
+func Headline(s *events.Summary) string {
+	if s == nil {
+		return ""
+	}
+	switch {
+	case s.Text != "":
+		return s.Text
+	case s.LastMessage != "":
+		return s.LastMessage
+	case len(s.Commits) == 0:
+		return ""
+	case len(s.Commits) == 1:
+		return stripHash(s.Commits[0])
+	}
+	return fmt.Sprintf("%d commits: %s", len(s.Commits), stripHash(s.Commits[0]))
+}
+
+// stripHash removes the leading abbreviated hash from a one-line commit.
+func stripHash(commit string) string {
+	if _, subject, ok := strings.Cut(commit, " "); ok {
+		return subject
+	}
+	return commit
+}
+
+func parseLines(output string) []string {
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
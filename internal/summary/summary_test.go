@@ -0,0 +1,130 @@
+package summary
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/badri/wt/internal/events"
+)
+
+func TestBuildPrompt(t *testing.T) {
+	s := &events.Summary{
+		Commits:  []string{"abc1234 Add login form", "def5678 Wire up auth API"},
+		DiffStat: "4 files changed, 120 insertions(+)",
+	}
+
+	prompt := BuildPrompt("Add user login", s)
+
+	for _, want := range []string{
+		"single plain-text paragraph",
+		"Task: Add user login",
+		"- abc1234 Add login form",
+		"- def5678 Wire up auth API",
+		"Diff: 4 files changed, 120 insertions(+)",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt should contain %q, got %q", want, prompt)
+		}
+	}
+}
+
+func TestBuildPromptNoTitle(t *testing.T) {
+	prompt := BuildPrompt("", &events.Summary{Commits: []string{"abc1234 Fix"}})
+	if strings.Contains(prompt, "Task:") {
+		t.Errorf("prompt should not contain task line, got %q", prompt)
+	}
+	if strings.Contains(prompt, "Diff:") {
+		t.Errorf("prompt should not contain diff line, got %q", prompt)
+	}
+}
+
+func TestFormatComment(t *testing.T) {
+	s := &events.Summary{
+		Commits:  []string{"abc1234 Add login form"},
+		DiffStat: "1 file changed, 10 insertions(+)",
+		Text:     "Added a login form.",
+	}
+
+	comment := FormatComment("toast", s)
+
+	for _, want := range []string{
+		"Session toast summary (1 file changed, 10 insertions(+)):",
+		"Added a login form.",
+		"Commits:\n- abc1234 Add login form",
+	} {
+		if !strings.Contains(comment, want) {
+			t.Errorf("comment should contain %q, got %q", want, comment)
+		}
+	}
+	if strings.HasSuffix(comment, "\n") {
+		t.Errorf("comment should not end with newline, got %q", comment)
+	}
+}
+
+func TestHeadline(t *testing.T) {
+	tests := []struct {
+		name    string
+		summary *events.Summary
+		want    string
+	}{
+		{"nil", nil, ""},
+		{"text wins", &events.Summary{Commits: []string{"abc Fix"}, Text: "Fixed it.", LastMessage: "Done."}, "Fixed it."},
+		{"last message before commits", &events.Summary{Commits: []string{"abc Fix"}, LastMessage: "Done, PR is up."}, "Done, PR is up."},
+		{"last message only", &events.Summary{LastMessage: "Stuck on the flaky test."}, "Stuck on the flaky test."},
+		{"empty", &events.Summary{}, ""},
+		{"single commit", &events.Summary{Commits: []string{"abc1234 Fix the bug"}}, "Fix the bug"},
+		{"multiple commits", &events.Summary{Commits: []string{"abc1234 Latest", "def5678 Earlier"}}, "2 commits: Latest"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Headline(tt.summary); got != tt.want {
+				t.Errorf("Headline() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseLines(t *testing.T) {
+	got := parseLines("abc Fix\n\n  def Add  \n")
+	if len(got) != 2 || got[0] != "abc Fix" || got[1] != "def Add" {
+		t.Errorf("parseLines() = %q", got)
+	}
+}
+
+func TestOneLine(t *testing.T) {
+	if got := oneLine("  Done.\n\nPR is\tup. ", 50); got != "Done. PR is up." {
+		t.Errorf("oneLine() = %q", got)
+	}
+	if got := oneLine("abcdef ghij", 8); got != "abcdef…" {
+		t.Errorf("oneLine() cut = %q, want %q", got, "abcdef…")
+	}
+}
+
+func TestLastMessage(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	dir := filepath.Join(home, ".claude", "projects", "-gone-worktree")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	lines := `{"type":"assistant","message":{"content":[{"type":"text","text":"Tests pass.\nOpening the PR."}]}}
+{"type":"assistant","message":{"content":[{"type":"tool_use","id":"t1","name":"Bash","input":{"command":"wt done"}}]}}
+`
+	if err := os.WriteFile(filepath.Join(dir, "abc-123.jsonl"), []byte(lines), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The worktree is gone, so the transcript is found by session ID
+	if got := LastMessage("/gone/worktree", "abc-123"); got != "Tests pass. Opening the PR." {
+		t.Errorf("LastMessage() = %q", got)
+	}
+	if got := LastMessage("/gone/worktree", "missing"); got != "" {
+		t.Errorf("LastMessage() without a transcript = %q, want empty", got)
+	}
+	if got := LastMessage("/gone/worktree", ""); got != "" {
+		t.Errorf("LastMessage() without a session = %q, want empty", got)
+	}
+}
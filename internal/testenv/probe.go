@@ -0,0 +1,112 @@
+package testenv
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/badri/wt/internal/logging"
+	"github.com/badri/wt/internal/project"
+)
+
+// DefaultProbeTimeout bounds a single health check or status command run
+// for 'wt status'
+const DefaultProbeTimeout = 10 * time.Second
+
+// MappedPort is a project port shifted by a session's port offset
+type MappedPort struct {
+	Name string `json:"name"`
+	Base int    `json:"base"`
+	Port int    `json:"port"`
+}
+
+// Health is the result of running a project's health check once
+type Health struct {
+	Healthy  bool          `json:"healthy"`
+	Output   string        `json:"output,omitempty"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"-"`
+}
+
+// MapPorts returns the project's test_env ports as seen by a session,
+// sorted by base port
+func MapPorts(proj *project.Project, portOffset int) []MappedPort {
+	if proj == nil || proj.TestEnv == nil {
+		return nil
+	}
+	ports := make([]MappedPort, 0, len(proj.TestEnv.Ports))
+	for name, base := range proj.TestEnv.Ports {
+		ports = append(ports, MappedPort{Name: name, Base: base, Port: base + portOffset})
+	}
+	sort.Slice(ports, func(i, j int) bool {
+		if ports[i].Base != ports[j].Base {
+			return ports[i].Base < ports[j].Base
+		}
+		return ports[i].Name < ports[j].Name
+	})
+	return ports
+}
+
+// CheckHealth runs the health check once, unlike WaitForHealthy which
+// retries until the services come up. Returns nil if no health check is
+// configured.
+func CheckHealth(proj *project.Project, vars project.CommandVars, timeout time.Duration) *Health {
+	if proj == nil || proj.TestEnv == nil || proj.TestEnv.HealthCheck == "" {
+		return nil
+	}
+
+	start := time.Now()
+	out, err := runProbe(proj.TestEnv.HealthCheck, vars, proj.TestEnv.PortEnv, timeout)
+	h := &Health{Healthy: err == nil, Output: out, Duration: time.Since(start)}
+	if err != nil {
+		h.Error = err.Error()
+	}
+	return h
+}
+
+// ServiceStatus runs the project's test_env status command (e.g.
+// "docker compose ps") and returns its output
+func ServiceStatus(proj *project.Project, vars project.CommandVars, timeout time.Duration) (string, error) {
+	if proj == nil || proj.TestEnv == nil || proj.TestEnv.Status == "" {
+		return "", nil
+	}
+	return runProbe(proj.TestEnv.Status, vars, proj.TestEnv.PortEnv, timeout)
+}
+
+// runProbe executes a shell command like runHook, but captures its output
+// and kills it after timeout
+func runProbe(command string, vars project.CommandVars, portEnv string, timeout time.Duration) (string, error) {
+	if portEnv == "" {
+		portEnv = "PORT_OFFSET"
+	}
+	command, err := project.ExpandCommand(command, vars)
+	if err != nil {
+		return "", err
+	}
+	if timeout == 0 {
+		timeout = DefaultProbeTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := logging.CommandContext(ctx, "sh", "-c", command)
+	cmd.Dir = vars.Worktree
+	cmd.Env = append(os.Environ(), fmt.Sprintf("%s=%d", portEnv, vars.PortOffset))
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	// Don't wait on children of the shell that still hold the output pipe
+	cmd.WaitDelay = time.Second
+
+	err = cmd.Run()
+	output := strings.TrimSpace(out.String())
+	if ctx.Err() == context.DeadlineExceeded {
+		return output, fmt.Errorf("timed out after %v", timeout)
+	}
+	return output, err
+}
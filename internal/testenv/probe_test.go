@@ -0,0 +1,54 @@
+package testenv
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/badri/wt/internal/project"
+)
+
+func TestMapPorts(t *testing.T) {
+	proj := &project.Project{TestEnv: &project.TestEnv{Ports: map[string]int{"web": 3000, "db": 5432}}}
+	got := MapPorts(proj, 1000)
+	want := []MappedPort{{Name: "web", Base: 3000, Port: 4000}, {Name: "db", Base: 5432, Port: 6432}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MapPorts() = %v, want %v", got, want)
+	}
+	if MapPorts(&project.Project{}, 1000) != nil {
+		t.Error("MapPorts() without test env should be nil")
+	}
+}
+
+func TestCheckHealth(t *testing.T) {
+	dir := t.TempDir()
+	if CheckHealth(&project.Project{TestEnv: &project.TestEnv{}}, at(dir, 0), 0) != nil {
+		t.Error("CheckHealth() without health_check should be nil")
+	}
+
+	ok := &project.Project{TestEnv: &project.TestEnv{HealthCheck: `test "$APP_PORTS" = 1100`, PortEnv: "APP_PORTS"}}
+	if h := CheckHealth(ok, at(dir, 1100), 0); h == nil || !h.Healthy {
+		t.Errorf("CheckHealth() = %+v, want healthy", h)
+	}
+
+	down := &project.Project{TestEnv: &project.TestEnv{HealthCheck: "echo connection refused; exit 7"}}
+	h := CheckHealth(down, at(dir, 1000), 0)
+	if h == nil || h.Healthy || h.Output != "connection refused" || h.Error == "" {
+		t.Errorf("CheckHealth() = %+v, want unhealthy with output", h)
+	}
+
+	slow := &project.Project{TestEnv: &project.TestEnv{HealthCheck: "sleep 5"}}
+	h = CheckHealth(slow, at(dir, 1000), 100*time.Millisecond)
+	if h == nil || h.Healthy || !strings.Contains(h.Error, "timed out") {
+		t.Errorf("CheckHealth() = %+v, want a timeout", h)
+	}
+}
+
+func TestServiceStatus(t *testing.T) {
+	proj := &project.Project{TestEnv: &project.TestEnv{Status: "echo db up; echo web up"}}
+	out, err := ServiceStatus(proj, at(t.TempDir(), 1000), 0)
+	if err != nil || out != "db up\nweb up" {
+		t.Errorf("ServiceStatus() = %q, %v", out, err)
+	}
+}
@@ -0,0 +1,66 @@
+package testenv
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/badri/wt/internal/project"
+)
+
+// Prompt returns a "## Test Environment" section for a worker's prompt:
+// the port variable its shell has, the session's ports, and the health
+// check and test commands as they apply to this session. Returns "" when
+// the project has no test env.
+func Prompt(proj *project.Project, vars project.CommandVars) string {
+	if proj == nil || proj.TestEnv == nil {
+		return ""
+	}
+	te := proj.TestEnv
+	portEnv := te.PortEnv
+	if portEnv == "" {
+		portEnv = "PORT_OFFSET"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## Test Environment\n")
+	sb.WriteString("This session has its own test environment, separate from other sessions'.\n")
+	if vars.PortOffset > 0 {
+		sb.WriteString(fmt.Sprintf("- `%s=%d` is set in your shell. Services listen on their usual port plus this offset; never hard-code the base ports.\n", portEnv, vars.PortOffset))
+	} else {
+		sb.WriteString(fmt.Sprintf("- `%s` is set in your shell. Services listen on their usual port plus this offset; never hard-code the base ports.\n", portEnv))
+	}
+	if ports := MapPorts(proj, vars.PortOffset); len(ports) > 0 {
+		var mapped []string
+		for _, p := range ports {
+			mapped = append(mapped, fmt.Sprintf("%s %d", p.Name, p.Port))
+		}
+		sb.WriteString(fmt.Sprintf("- Ports for this session: %s\n", strings.Join(mapped, ", ")))
+	}
+	if te.HealthCheck != "" {
+		sb.WriteString(fmt.Sprintf("- Check the services are up with `%s` (exit 0 means healthy). If it keeps failing, signal blocked rather than starting services on other ports.\n", expandForPrompt(te.HealthCheck, vars)))
+	}
+	if test := proj.TestCommand(); test != "" {
+		sb.WriteString(fmt.Sprintf("- Run the tests with `%s`, from the worktree root. It targets this session's services.\n", expandForPrompt(test, vars)))
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// TestStep is the workflow step for running the tests, naming the command
+// when the project configures one
+func TestStep(proj *project.Project, vars project.CommandVars) string {
+	if test := proj.TestCommand(); test != "" {
+		return fmt.Sprintf("Run tests and fix any failures: `%s`", expandForPrompt(test, vars))
+	}
+	return "Run tests and fix any failures"
+}
+
+// expandForPrompt fills in a command's template variables, leaving it as
+// written if it doesn't expand
+func expandForPrompt(command string, vars project.CommandVars) string {
+	expanded, err := project.ExpandCommand(command, vars)
+	if err != nil {
+		return command
+	}
+	return expanded
+}
@@ -0,0 +1,55 @@
+package testenv
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/badri/wt/internal/project"
+)
+
+func TestPrompt(t *testing.T) {
+	if got := Prompt(&project.Project{}, project.CommandVars{}); got != "" {
+		t.Errorf("Prompt() without test env = %q, want empty", got)
+	}
+
+	proj := &project.Project{TestEnv: &project.TestEnv{
+		PortEnv:     "APP_PORT_OFFSET",
+		HealthCheck: "curl -sf localhost:$((3000 + APP_PORT_OFFSET))/health",
+		Ports:       map[string]int{"web": 3000, "db": 5432},
+		Test:        "docker compose -p {{.Session}} run --rm app npm test",
+	}}
+	got := Prompt(proj, project.CommandVars{Session: "toast", PortOffset: 1100})
+	for _, want := range []string{
+		"## Test Environment",
+		"`APP_PORT_OFFSET=1100` is set in your shell",
+		"Ports for this session: web 4100, db 6532",
+		"`curl -sf localhost:$((3000 + APP_PORT_OFFSET))/health`",
+		"`docker compose -p toast run --rm app npm test`",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Prompt() missing %q in:\n%s", want, got)
+		}
+	}
+}
+
+func TestPrompt_Minimal(t *testing.T) {
+	got := Prompt(&project.Project{TestEnv: &project.TestEnv{Setup: "make up"}}, project.CommandVars{})
+	if !strings.Contains(got, "`PORT_OFFSET` is set in your shell") {
+		t.Errorf("expected the default port variable, got:\n%s", got)
+	}
+	for _, unwanted := range []string{"Ports for this session", "health", "Run the tests"} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("Prompt() should not mention %q without config:\n%s", unwanted, got)
+		}
+	}
+}
+
+func TestTestStep(t *testing.T) {
+	if got := TestStep(&project.Project{}, project.CommandVars{}); got != "Run tests and fix any failures" {
+		t.Errorf("TestStep() = %q", got)
+	}
+	proj := &project.Project{TestEnv: &project.TestEnv{}, Auto: &project.Auto{TestCommand: "go test ./..."}}
+	if got := TestStep(proj, project.CommandVars{}); got != "Run tests and fix any failures: `go test ./...`" {
+		t.Errorf("TestStep() with auto.test_command = %q", got)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"os/exec"
 	"time"
 
+	"github.com/badri/wt/internal/logging"
 	"github.com/badri/wt/internal/project"
 )
 
@@ -34,26 +35,52 @@ func AllocatePortOffset(proj *project.Project, usedOffsets []int) int {
 	return offset
 }
 
-// RunSetup executes the setup command if configured.
-func RunSetup(proj *project.Project, workdir string, portOffset int) error {
+// RunSetup executes the setup command if configured. Commands run in
+// vars.Worktree with the port offset in PORT_OFFSET (or port_env), after
+// their template variables are expanded from vars.
+func RunSetup(proj *project.Project, vars project.CommandVars) error {
 	if proj == nil || proj.TestEnv == nil || proj.TestEnv.Setup == "" {
 		return nil
 	}
 
-	return runHook(proj.TestEnv.Setup, workdir, portOffset, proj.TestEnv.PortEnv)
+	return runHook(proj.TestEnv.Setup, vars, proj.TestEnv.PortEnv)
 }
 
 // RunTeardown executes the teardown command if configured.
-func RunTeardown(proj *project.Project, workdir string, portOffset int) error {
+func RunTeardown(proj *project.Project, vars project.CommandVars) error {
 	if proj == nil || proj.TestEnv == nil || proj.TestEnv.Teardown == "" {
 		return nil
 	}
 
-	return runHook(proj.TestEnv.Teardown, workdir, portOffset, proj.TestEnv.PortEnv)
+	return runHook(proj.TestEnv.Teardown, vars, proj.TestEnv.PortEnv)
+}
+
+// RunPause stops the environment of a paused session: the pause command if
+// configured, else teardown.
+func RunPause(proj *project.Project, vars project.CommandVars) error {
+	if proj == nil || proj.TestEnv == nil {
+		return nil
+	}
+	if proj.TestEnv.Pause == "" {
+		return RunTeardown(proj, vars)
+	}
+	return runHook(proj.TestEnv.Pause, vars, proj.TestEnv.PortEnv)
+}
+
+// RunResume starts the environment of a resumed session: the resume command
+// if configured, else setup.
+func RunResume(proj *project.Project, vars project.CommandVars) error {
+	if proj == nil || proj.TestEnv == nil {
+		return nil
+	}
+	if proj.TestEnv.Resume == "" {
+		return RunSetup(proj, vars)
+	}
+	return runHook(proj.TestEnv.Resume, vars, proj.TestEnv.PortEnv)
 }
 
 // WaitForHealthy runs the health check command until it succeeds or times out.
-func WaitForHealthy(proj *project.Project, workdir string, portOffset int, timeout time.Duration) error {
+func WaitForHealthy(proj *project.Project, vars project.CommandVars, timeout time.Duration) error {
 	if proj == nil || proj.TestEnv == nil || proj.TestEnv.HealthCheck == "" {
 		return nil
 	}
@@ -66,7 +93,7 @@ func WaitForHealthy(proj *project.Project, workdir string, portOffset int, timeo
 	interval := 500 * time.Millisecond
 
 	for time.Now().Before(deadline) {
-		err := runHook(proj.TestEnv.HealthCheck, workdir, portOffset, proj.TestEnv.PortEnv)
+		err := runHook(proj.TestEnv.HealthCheck, vars, proj.TestEnv.PortEnv)
 		if err == nil {
 			return nil
 		}
@@ -77,14 +104,11 @@ func WaitForHealthy(proj *project.Project, workdir string, portOffset int, timeo
 }
 
 // runHook executes a shell command with PORT_OFFSET (or custom env var) set.
-func runHook(command, workdir string, portOffset int, portEnv string) error {
-	if portEnv == "" {
-		portEnv = "PORT_OFFSET"
+func runHook(command string, vars project.CommandVars, portEnv string) error {
+	cmd, err := hookCommand(command, vars, portEnv)
+	if err != nil {
+		return err
 	}
-
-	cmd := exec.Command("sh", "-c", command)
-	cmd.Dir = workdir
-	cmd.Env = append(os.Environ(), fmt.Sprintf("%s=%d", portEnv, portOffset))
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 
@@ -92,23 +116,13 @@ func runHook(command, workdir string, portOffset int, portEnv string) error {
 }
 
 // RunOnCreateHooks executes project hooks configured for session creation.
-func RunOnCreateHooks(proj *project.Project, workdir string, portOffset int, portEnv string) error {
+func RunOnCreateHooks(proj *project.Project, vars project.CommandVars, portEnv string) error {
 	if proj == nil || proj.Hooks == nil {
 		return nil
 	}
 
-	if portEnv == "" {
-		portEnv = "PORT_OFFSET"
-	}
-
 	for _, hook := range proj.Hooks.OnCreate {
-		cmd := exec.Command("sh", "-c", hook)
-		cmd.Dir = workdir
-		cmd.Env = append(os.Environ(), fmt.Sprintf("%s=%d", portEnv, portOffset))
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
-
-		if err := cmd.Run(); err != nil {
+		if err := runHook(hook, vars, portEnv); err != nil {
 			return fmt.Errorf("on_create hook failed: %w", err)
 		}
 	}
@@ -117,23 +131,13 @@ func RunOnCreateHooks(proj *project.Project, workdir string, portOffset int, por
 }
 
 // RunOnCloseHooks executes project hooks configured for session close.
-func RunOnCloseHooks(proj *project.Project, workdir string, portOffset int, portEnv string) error {
+func RunOnCloseHooks(proj *project.Project, vars project.CommandVars, portEnv string) error {
 	if proj == nil || proj.Hooks == nil {
 		return nil
 	}
 
-	if portEnv == "" {
-		portEnv = "PORT_OFFSET"
-	}
-
 	for _, hook := range proj.Hooks.OnClose {
-		cmd := exec.Command("sh", "-c", hook)
-		cmd.Dir = workdir
-		cmd.Env = append(os.Environ(), fmt.Sprintf("%s=%d", portEnv, portOffset))
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
-
-		if err := cmd.Run(); err != nil {
+		if err := runHook(hook, vars, portEnv); err != nil {
 			return fmt.Errorf("on_close hook failed: %w", err)
 		}
 	}
@@ -141,6 +145,28 @@ func RunOnCloseHooks(proj *project.Project, workdir string, portOffset int, port
 	return nil
 }
 
+// hookCommand builds the shell command for a hook or test env command:
+// template variables expanded, run in the worktree with the port offset set.
+// WT_SESSION, WT_BEAD and WT_PROJECT let the command attribute what it
+// reports, e.g. with 'wt events emit'.
+func hookCommand(command string, vars project.CommandVars, portEnv string) (*exec.Cmd, error) {
+	if portEnv == "" {
+		portEnv = "PORT_OFFSET"
+	}
+	expanded, err := project.ExpandCommand(command, vars)
+	if err != nil {
+		return nil, err
+	}
+	cmd := logging.CommandIn(vars.Worktree, "sh", "-c", expanded)
+	cmd.Env = append(os.Environ(), fmt.Sprintf("%s=%d", portEnv, vars.PortOffset))
+	for name, value := range map[string]string{"WT_SESSION": vars.Session, "WT_BEAD": vars.Bead, "WT_PROJECT": vars.Project} {
+		if value != "" {
+			cmd.Env = append(cmd.Env, name+"="+value)
+		}
+	}
+	return cmd, nil
+}
+
 func contains(slice []int, val int) bool {
 	for _, v := range slice {
 		if v == val {
@@ -1,6 +1,8 @@
 package testenv
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/badri/wt/internal/project"
@@ -61,7 +63,7 @@ func TestAllocatePortOffset_SkipsGaps(t *testing.T) {
 }
 
 func TestRunSetup_NilProject(t *testing.T) {
-	err := RunSetup(nil, "/tmp", 1000)
+	err := RunSetup(nil, at("/tmp", 1000))
 	if err != nil {
 		t.Errorf("expected nil error for nil project, got %v", err)
 	}
@@ -69,7 +71,7 @@ func TestRunSetup_NilProject(t *testing.T) {
 
 func TestRunSetup_NoTestEnv(t *testing.T) {
 	proj := &project.Project{Name: "test"}
-	err := RunSetup(proj, "/tmp", 1000)
+	err := RunSetup(proj, at("/tmp", 1000))
 	if err != nil {
 		t.Errorf("expected nil error for project without test env, got %v", err)
 	}
@@ -80,7 +82,7 @@ func TestRunSetup_NoSetupCmd(t *testing.T) {
 		Name:    "test",
 		TestEnv: &project.TestEnv{},
 	}
-	err := RunSetup(proj, "/tmp", 1000)
+	err := RunSetup(proj, at("/tmp", 1000))
 	if err != nil {
 		t.Errorf("expected nil error for empty setup cmd, got %v", err)
 	}
@@ -93,7 +95,7 @@ func TestRunSetup_Success(t *testing.T) {
 			Setup: "true", // Always succeeds
 		},
 	}
-	err := RunSetup(proj, "/tmp", 1000)
+	err := RunSetup(proj, at("/tmp", 1000))
 	if err != nil {
 		t.Errorf("expected nil error for 'true' command, got %v", err)
 	}
@@ -106,14 +108,14 @@ func TestRunSetup_Failure(t *testing.T) {
 			Setup: "false", // Always fails
 		},
 	}
-	err := RunSetup(proj, "/tmp", 1000)
+	err := RunSetup(proj, at("/tmp", 1000))
 	if err == nil {
 		t.Error("expected error for 'false' command")
 	}
 }
 
 func TestRunTeardown_NilProject(t *testing.T) {
-	err := RunTeardown(nil, "/tmp", 1000)
+	err := RunTeardown(nil, at("/tmp", 1000))
 	if err != nil {
 		t.Errorf("expected nil error for nil project, got %v", err)
 	}
@@ -126,14 +128,63 @@ func TestRunTeardown_Success(t *testing.T) {
 			Teardown: "true",
 		},
 	}
-	err := RunTeardown(proj, "/tmp", 1000)
+	err := RunTeardown(proj, at("/tmp", 1000))
 	if err != nil {
 		t.Errorf("expected nil error for 'true' command, got %v", err)
 	}
 }
 
+func TestRunPauseResume_Fallback(t *testing.T) {
+	dir := t.TempDir()
+	proj := &project.Project{
+		Name: "test",
+		TestEnv: &project.TestEnv{
+			Setup:    "touch setup-ran",
+			Teardown: "touch teardown-ran",
+		},
+	}
+	if err := RunPause(proj, at(dir, 1000)); err != nil {
+		t.Fatalf("RunPause() error: %v", err)
+	}
+	if err := RunResume(proj, at(dir, 1000)); err != nil {
+		t.Fatalf("RunResume() error: %v", err)
+	}
+	for _, f := range []string{"teardown-ran", "setup-ran"} {
+		if _, err := os.Stat(filepath.Join(dir, f)); err != nil {
+			t.Errorf("expected %s: pause and resume fall back to teardown and setup", f)
+		}
+	}
+}
+
+func TestRunPauseResume_Dedicated(t *testing.T) {
+	dir := t.TempDir()
+	proj := &project.Project{
+		Name: "test",
+		TestEnv: &project.TestEnv{
+			Teardown: "false",
+			Setup:    "false",
+			Pause:    "touch paused",
+			Resume:   "touch resumed",
+		},
+	}
+	if err := RunPause(proj, at(dir, 1000)); err != nil {
+		t.Fatalf("RunPause() error: %v", err)
+	}
+	if err := RunResume(proj, at(dir, 1000)); err != nil {
+		t.Fatalf("RunResume() error: %v", err)
+	}
+	for _, f := range []string{"paused", "resumed"} {
+		if _, err := os.Stat(filepath.Join(dir, f)); err != nil {
+			t.Errorf("expected the dedicated command to create %s", f)
+		}
+	}
+	if err := RunPause(nil, at(dir, 1000)); err != nil {
+		t.Errorf("RunPause(nil) = %v", err)
+	}
+}
+
 func TestRunOnCreateHooks_NilProject(t *testing.T) {
-	err := RunOnCreateHooks(nil, "/tmp", 1000, "")
+	err := RunOnCreateHooks(nil, at("/tmp", 1000), "")
 	if err != nil {
 		t.Errorf("expected nil error for nil project, got %v", err)
 	}
@@ -141,7 +192,7 @@ func TestRunOnCreateHooks_NilProject(t *testing.T) {
 
 func TestRunOnCreateHooks_NoHooks(t *testing.T) {
 	proj := &project.Project{Name: "test"}
-	err := RunOnCreateHooks(proj, "/tmp", 1000, "")
+	err := RunOnCreateHooks(proj, at("/tmp", 1000), "")
 	if err != nil {
 		t.Errorf("expected nil error for project without hooks, got %v", err)
 	}
@@ -154,7 +205,7 @@ func TestRunOnCreateHooks_Success(t *testing.T) {
 			OnCreate: []string{"true", "true"},
 		},
 	}
-	err := RunOnCreateHooks(proj, "/tmp", 1000, "")
+	err := RunOnCreateHooks(proj, at("/tmp", 1000), "")
 	if err != nil {
 		t.Errorf("expected nil error for 'true' hooks, got %v", err)
 	}
@@ -167,7 +218,7 @@ func TestRunOnCreateHooks_Failure(t *testing.T) {
 			OnCreate: []string{"true", "false"},
 		},
 	}
-	err := RunOnCreateHooks(proj, "/tmp", 1000, "")
+	err := RunOnCreateHooks(proj, at("/tmp", 1000), "")
 	if err == nil {
 		t.Error("expected error when hook fails")
 	}
@@ -180,12 +231,65 @@ func TestRunOnCloseHooks_Success(t *testing.T) {
 			OnClose: []string{"true"},
 		},
 	}
-	err := RunOnCloseHooks(proj, "/tmp", 1000, "")
+	err := RunOnCloseHooks(proj, at("/tmp", 1000), "")
 	if err != nil {
 		t.Errorf("expected nil error for 'true' hook, got %v", err)
 	}
 }
 
+func TestRunHooks_TemplateVars(t *testing.T) {
+	dir := t.TempDir()
+	proj := &project.Project{
+		Name: "test",
+		Hooks: &project.Hooks{
+			OnCreate: []string{`echo "{{.Session}} {{.Bead}} {{.Branch}} $(({{.PortOffset}} + 80))" > vars.txt`},
+		},
+		TestEnv: &project.TestEnv{
+			Setup: `{{if eq .Project "api"}}touch api{{else}}touch other{{end}}`,
+		},
+	}
+	vars := project.CommandVars{Worktree: dir, Bead: "api-7", Session: "toast", Project: "api", Branch: "api-7", PortOffset: 1000}
+	if err := RunOnCreateHooks(proj, vars, ""); err != nil {
+		t.Fatalf("RunOnCreateHooks() error: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "vars.txt"))
+	if err != nil || string(data) != "toast api-7 api-7 1080\n" {
+		t.Errorf("hook wrote %q, %v", data, err)
+	}
+	if err := RunSetup(proj, vars); err != nil {
+		t.Fatalf("RunSetup() error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "api")); err != nil {
+		t.Errorf("conditional setup didn't take the api branch: %v", err)
+	}
+
+	proj.TestEnv.Setup = "echo {{.Nope}}"
+	if err := RunSetup(proj, vars); err == nil {
+		t.Error("RunSetup() with an unknown variable should fail")
+	}
+}
+
+func TestRunHooks_SessionEnv(t *testing.T) {
+	dir := t.TempDir()
+	proj := &project.Project{
+		Name:  "test",
+		Hooks: &project.Hooks{OnClose: []string{`echo "$WT_SESSION $WT_BEAD $WT_PROJECT" > env.txt`}},
+	}
+	vars := project.CommandVars{Worktree: dir, Bead: "api-7", Session: "toast", Project: "api"}
+	if err := RunOnCloseHooks(proj, vars, ""); err != nil {
+		t.Fatalf("RunOnCloseHooks() error: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "env.txt"))
+	if err != nil || string(data) != "toast api-7 api\n" {
+		t.Errorf("hook saw %q, %v", data, err)
+	}
+}
+
+// at returns the vars of a session with just a worktree and port offset
+func at(dir string, portOffset int) project.CommandVars {
+	return project.CommandVars{Worktree: dir, PortOffset: portOffset}
+}
+
 func TestContains(t *testing.T) {
 	tests := []struct {
 		slice    []int
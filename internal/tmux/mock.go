@@ -35,6 +35,7 @@ type MockSession struct {
 	EditorCmd  string
 	PortOffset int
 	PortEnv    string
+	Windows    []Window
 }
 
 // NewMockRunner creates a new MockRunner with an empty session map.
@@ -62,6 +63,7 @@ func (m *MockRunner) NewSession(name, workdir, beadsDir, editorCmd string, opts
 	if opts != nil {
 		sess.PortOffset = opts.PortOffset
 		sess.PortEnv = opts.PortEnv
+		sess.Windows = opts.Windows
 	}
 	m.Sessions[name] = sess
 	return nil
@@ -7,6 +7,8 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/badri/wt/internal/logging"
 )
 
 // nudgeMutex serializes NudgeSession calls to prevent interleaved keystrokes
@@ -16,12 +18,23 @@ var nudgeMutex sync.Mutex
 // SessionOptions contains optional configuration for creating a tmux session.
 type SessionOptions struct {
 	PortOffset int
-	PortEnv    string // defaults to PORT_OFFSET if empty
+	PortEnv    string   // defaults to PORT_OFFSET if empty
+	Windows    []Window // Extra windows next to the agent's, e.g. tests in watch mode
+}
+
+// Window is an extra window of a session, running Command in a shell that
+// stays open when the command exits
+type Window struct {
+	Name    string
+	Command string
 }
 
 func NewSession(name, workdir, beadsDir, editorCmd string, opts *SessionOptions) error {
 	// Check if session already exists
 	if SessionExists(name) {
+		if !IsWTSession(name) {
+			return fmt.Errorf("tmux session '%s' already exists and was not started by wt", name)
+		}
 		return fmt.Errorf("tmux session '%s' already exists", name)
 	}
 
@@ -32,18 +45,9 @@ func NewSession(name, workdir, beadsDir, editorCmd string, opts *SessionOptions)
 		"-d",       // detached
 		"-s", name, // session name
 		"-c", workdir, // working directory
-		"-e", fmt.Sprintf("BEADS_DIR=%s", beadsDir), // environment vars via -e flag
-		"-e", fmt.Sprintf("WT_SESSION=%s", name),
-	}
-
-	// Add PORT_OFFSET if configured
-	if opts != nil && opts.PortOffset > 0 {
-		portEnv := opts.PortEnv
-		if portEnv == "" {
-			portEnv = "PORT_OFFSET"
-		}
-		args = append(args, "-e", fmt.Sprintf("%s=%d", portEnv, opts.PortOffset))
 	}
+	env := sessionEnv(name, beadsDir, opts)
+	args = append(args, envArgs(env)...)
 
 	// If editorCmd is provided, run it directly as the pane process
 	// This eliminates the race condition where send-keys might arrive before shell is ready
@@ -51,16 +55,79 @@ func NewSession(name, workdir, beadsDir, editorCmd string, opts *SessionOptions)
 		args = append(args, editorCmd)
 	}
 
-	cmd := exec.Command("tmux", args...)
+	cmd := logging.Command("tmux", args...)
 	cmd.Env = os.Environ()
 
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("creating tmux session: %w", err)
 	}
 
+	// Extra windows are a convenience: one failing leaves the session up
+	if opts != nil {
+		for _, w := range opts.Windows {
+			if err := newWindow(name, workdir, env, w); err != nil {
+				logging.Warnf("could not create window '%s': %v", w.Name, err)
+			}
+		}
+	}
+
 	return nil
 }
 
+// sessionEnv is the environment wt sets, with -e flags, in every window of
+// a session
+func sessionEnv(name, beadsDir string, opts *SessionOptions) []string {
+	env := []string{
+		fmt.Sprintf("BEADS_DIR=%s", beadsDir),
+		fmt.Sprintf("WT_SESSION=%s", name),
+	}
+
+	// Keep wt inside the session on the profile that created it
+	if profile := os.Getenv("WT_PROFILE"); profile != "" {
+		env = append(env, fmt.Sprintf("WT_PROFILE=%s", profile))
+	}
+
+	// Add PORT_OFFSET if configured
+	if opts != nil && opts.PortOffset > 0 {
+		portEnv := opts.PortEnv
+		if portEnv == "" {
+			portEnv = "PORT_OFFSET"
+		}
+		env = append(env, fmt.Sprintf("%s=%d", portEnv, opts.PortOffset))
+	}
+	return env
+}
+
+func envArgs(env []string) []string {
+	var args []string
+	for _, e := range env {
+		args = append(args, "-e", e)
+	}
+	return args
+}
+
+// newWindow adds a window behind the agent's, which stays the active one so
+// nudges and pane captures keep reaching the agent. The command is typed
+// into the window's shell, leaving the shell open with the command in its
+// history when it exits.
+func newWindow(session, workdir string, env []string, w Window) error {
+	args := []string{"new-window", "-d", "-P", "-F", "#{window_id}",
+		"-t", ExactTarget(session) + ":", "-n", w.Name, "-c", workdir}
+	args = append(args, envArgs(env)...)
+	output, err := logging.Command("tmux", args...).Output()
+	if err != nil {
+		return err
+	}
+	if w.Command == "" {
+		return nil
+	}
+	windowID := strings.TrimSpace(string(output))
+	if err := logging.Command("tmux", "send-keys", "-t", windowID, "-l", w.Command).Run(); err != nil {
+		return err
+	}
+	return logging.Command("tmux", "send-keys", "-t", windowID, "Enter").Run()
+}
+
 // NewSeanceSession creates a tmux session for resuming a past Claude conversation.
 // It runs editorCmd --resume in a new session, optionally switching to it.
 // editorCmd should be the base command (e.g., "claude --dangerously-skip-permissions")
@@ -75,10 +142,11 @@ func NewSeanceSession(name, workdir, editorCmd, claudeSessionID string, switchTo
 
 	// Create tmux session with command running directly as the pane process
 	// This eliminates the send-keys race condition
-	cmd := exec.Command("tmux", "new-session",
+	cmd := logging.Command("tmux", "new-session",
 		"-d",       // detached
 		"-s", name, // session name
 		"-c", workdir, // working directory
+		"-e", "WT_SEANCE=1", // mark as a wt session
 		resumeCmd, // run command directly
 	)
 
@@ -94,11 +162,11 @@ func NewSeanceSession(name, workdir, editorCmd, claudeSessionID string, switchTo
 	return nil
 }
 
+// Attach moves the terminal to a session. Inside tmux it switches the
+// client, since attaching there would nest tmux.
 func Attach(name string) error {
-	// Check if we're inside tmux
-	if os.Getenv("TMUX") != "" {
-		// Switch to the session
-		cmd := exec.Command("tmux", "switch-client", "-t", name)
+	if InsideTmux() {
+		cmd := logging.Command("tmux", "switch-client", "-t", ExactTarget(name))
 		cmd.Stdin = os.Stdin
 		cmd.Stdout = os.Stdout
 		cmd.Stderr = os.Stderr
@@ -106,18 +174,23 @@ func Attach(name string) error {
 	}
 
 	// Attach to the session
-	cmd := exec.Command("tmux", "attach-session", "-t", name)
+	cmd := logging.Command("tmux", "attach-session", "-t", ExactTarget(name))
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	return cmd.Run()
 }
 
+// InsideTmux reports whether wt runs inside a tmux client
+func InsideTmux() bool {
+	return os.Getenv("TMUX") != ""
+}
+
 // SwitchClient switches the tmux client to a different session.
 // Unlike Attach, this doesn't need to capture stdin/stdout since
 // it's meant to be called from background processes like the watch TUI.
 func SwitchClient(name string) error {
-	cmd := exec.Command("tmux", "switch-client", "-t", name)
+	cmd := logging.Command("tmux", "switch-client", "-t", ExactTarget(name))
 	return cmd.Run()
 }
 
@@ -144,20 +217,20 @@ func NudgeSession(session, message string) error {
 	tmpFile.Close()
 
 	// 2. Load into tmux buffer
-	loadCmd := exec.Command("tmux", "load-buffer", tmpPath)
+	loadCmd := logging.Command("tmux", "load-buffer", tmpPath)
 	if err := loadCmd.Run(); err != nil {
 		return fmt.Errorf("loading buffer: %w", err)
 	}
 
 	// 3. Paste buffer to the target pane
-	pasteCmd := exec.Command("tmux", "paste-buffer", "-t", session)
+	pasteCmd := logging.Command("tmux", "paste-buffer", "-t", session)
 	if err := pasteCmd.Run(); err != nil {
 		return fmt.Errorf("pasting buffer to %s: %w", session, err)
 	}
 
 	// 4. Wait then send Enter
 	time.Sleep(500 * time.Millisecond)
-	enterCmd := exec.Command("tmux", "send-keys", "-t", session, "Enter")
+	enterCmd := logging.Command("tmux", "send-keys", "-t", session, "Enter")
 	if err := enterCmd.Run(); err != nil {
 		return fmt.Errorf("sending Enter to %s: %w", session, err)
 	}
@@ -172,13 +245,9 @@ func WaitForClaude(session string, timeout time.Duration) error {
 
 	// First wait for the process to start
 	for time.Now().Before(deadline) {
-		cmd := exec.Command("tmux", "display-message", "-t", session, "-p", "#{pane_current_command}")
-		output, err := cmd.Output()
-		if err == nil {
-			command := strings.TrimSpace(string(output))
-			if command == "claude" || command == "node" {
-				break
-			}
+		command := PaneCommand(session)
+		if command == "claude" || command == "node" {
+			break
 		}
 		time.Sleep(100 * time.Millisecond)
 	}
@@ -194,10 +263,20 @@ func WaitForClaude(session string, timeout time.Duration) error {
 	return fmt.Errorf("timeout waiting for Claude prompt")
 }
 
+// PaneCommand returns the command running in a session's active pane, or "" on error.
+func PaneCommand(session string) string {
+	cmd := logging.Command("tmux", "display-message", "-t", session, "-p", "#{pane_current_command}")
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
 // CapturePane captures the visible content of a tmux session's pane.
 // Returns up to 'lines' lines of content from the pane.
 func CapturePane(session string, lines int) (string, error) {
-	cmd := exec.Command("tmux", "capture-pane", "-t", session, "-p", "-S", fmt.Sprintf("-%d", lines))
+	cmd := logging.Command("tmux", "capture-pane", "-t", session, "-p", "-S", fmt.Sprintf("-%d", lines))
 	output, err := cmd.Output()
 	if err != nil {
 		return "", fmt.Errorf("capturing pane: %w", err)
@@ -228,7 +307,7 @@ func AcceptBypassPermissionsWarning(session string) error {
 	}
 
 	// Press Down to select "Yes, I accept" (option 2)
-	downCmd := exec.Command("tmux", "send-keys", "-t", session, "Down")
+	downCmd := logging.Command("tmux", "send-keys", "-t", session, "Down")
 	if err := downCmd.Run(); err != nil {
 		return fmt.Errorf("sending Down key: %w", err)
 	}
@@ -237,7 +316,7 @@ func AcceptBypassPermissionsWarning(session string) error {
 	time.Sleep(200 * time.Millisecond)
 
 	// Press Enter to confirm
-	enterCmd := exec.Command("tmux", "send-keys", "-t", session, "Enter")
+	enterCmd := logging.Command("tmux", "send-keys", "-t", session, "Enter")
 	if err := enterCmd.Run(); err != nil {
 		return fmt.Errorf("sending Enter key: %w", err)
 	}
@@ -246,13 +325,13 @@ func AcceptBypassPermissionsWarning(session string) error {
 }
 
 func Kill(name string) error {
-	cmd := exec.Command("tmux", "kill-session", "-t", name)
+	cmd := logging.Command("tmux", "kill-session", "-t", name)
 	return cmd.Run()
 }
 
 // GetSessionEnv gets an environment variable from a tmux session
 func GetSessionEnv(session, varName string) string {
-	cmd := exec.Command("tmux", "show-environment", "-t", session, varName)
+	cmd := logging.Command("tmux", "show-environment", "-t", session, varName)
 	output, err := cmd.Output()
 	if err != nil {
 		return ""
@@ -265,14 +344,55 @@ func GetSessionEnv(session, varName string) string {
 	return ""
 }
 
+// SessionExists reports whether a session has exactly this name. A plain
+// target would also match a longer name starting with it.
 func SessionExists(name string) bool {
-	cmd := exec.Command("tmux", "has-session", "-t", name)
+	cmd := logging.Command("tmux", "has-session", "-t", ExactTarget(name))
 	return cmd.Run() == nil
 }
 
+// ExactTarget is a -t target that only matches the session with this name
+func ExactTarget(name string) string {
+	return "=" + name
+}
+
+// IsWTSession reports whether wt started the session: worker sessions carry
+// WT_SESSION in their tmux environment, the hub WT_HUB and seance sessions
+// WT_SEANCE. A session without any was made outside wt and only shares the
+// name.
+func IsWTSession(name string) bool {
+	cmd := logging.Command("tmux", "show-environment", "-t", ExactTarget(name))
+	output, err := cmd.Output()
+	if err != nil {
+		return false
+	}
+	return hasWTEnv(string(output))
+}
+
+// hasWTEnv reports whether show-environment output sets a wt variable
+func hasWTEnv(env string) bool {
+	for _, line := range strings.Split(env, "\n") {
+		if strings.HasPrefix(line, "WT_SESSION=") || strings.HasPrefix(line, "WT_HUB=") || strings.HasPrefix(line, "WT_SEANCE=") {
+			return true
+		}
+	}
+	return false
+}
+
 // CurrentSession returns the name of the current tmux session, or empty string if not in tmux
 func CurrentSession() string {
-	cmd := exec.Command("tmux", "display-message", "-p", "#{session_name}")
+	cmd := logging.Command("tmux", "display-message", "-p", "#{session_name}")
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// LastSession returns the session the current client was in before this
+// one, or empty string if there is none
+func LastSession() string {
+	cmd := logging.Command("tmux", "display-message", "-p", "#{client_last_session}")
 	output, err := cmd.Output()
 	if err != nil {
 		return ""
@@ -281,7 +401,7 @@ func CurrentSession() string {
 }
 
 func ListSessions() ([]string, error) {
-	cmd := exec.Command("tmux", "list-sessions", "-F", "#{session_name}")
+	cmd := logging.Command("tmux", "list-sessions", "-F", "#{session_name}")
 	output, err := cmd.Output()
 	if err != nil {
 		// No sessions is not an error
@@ -300,3 +420,27 @@ func ListSessions() ([]string, error) {
 	}
 	return sessions, nil
 }
+
+// StatusInterval is how often, in seconds, tmux refreshes the status line
+// of a tagged session
+const StatusInterval = 15
+
+// TagSession names a session's window after label and sets the session's
+// status-right, which tmux re-evaluates every StatusInterval seconds. Only
+// this session's options change; the user's global status line is kept
+// for every other session.
+func TagSession(session, label, statusRight string) error {
+	commands := [][]string{
+		{"rename-window", "-t", session + ":", label},
+		{"set-option", "-w", "-t", session + ":", "automatic-rename", "off"},
+		{"set-option", "-t", session, "status-right", statusRight},
+		{"set-option", "-t", session, "status-right-length", "80"},
+		{"set-option", "-t", session, "status-interval", fmt.Sprintf("%d", StatusInterval)},
+	}
+	for _, args := range commands {
+		if output, err := logging.Command("tmux", args...).CombinedOutput(); err != nil {
+			return fmt.Errorf("tmux %s: %s: %w", args[0], strings.TrimSpace(string(output)), err)
+		}
+	}
+	return nil
+}
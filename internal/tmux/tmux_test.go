@@ -0,0 +1,55 @@
+package tmux
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestHasWTEnv(t *testing.T) {
+	tests := []struct {
+		env  string
+		want bool
+	}{
+		{"BEADS_DIR=/p/.beads\nWT_SESSION=toast\n", true},
+		{"WT_HUB=1\nWT_PROFILE=\n", true},
+		{"WT_SEANCE=1\n", true},
+		{"-WT_SESSION\nWT_PROFILE=work\nSHELL=/bin/zsh\n", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := hasWTEnv(tt.env); got != tt.want {
+			t.Errorf("hasWTEnv(%q) = %v, want %v", tt.env, got, tt.want)
+		}
+	}
+}
+
+func TestExactTarget(t *testing.T) {
+	if got := ExactTarget("toast"); got != "=toast" {
+		t.Errorf("ExactTarget() = %q", got)
+	}
+}
+
+func TestSessionEnv(t *testing.T) {
+	t.Setenv("WT_PROFILE", "")
+	got := sessionEnv("toast", "/p/.beads", nil)
+	want := []string{"BEADS_DIR=/p/.beads", "WT_SESSION=toast"}
+	if !slices.Equal(got, want) {
+		t.Errorf("sessionEnv() = %v, want %v", got, want)
+	}
+
+	t.Setenv("WT_PROFILE", "work")
+	got = sessionEnv("toast", "/p/.beads", &SessionOptions{PortOffset: 1000})
+	want = []string{"BEADS_DIR=/p/.beads", "WT_SESSION=toast", "WT_PROFILE=work", "PORT_OFFSET=1000"}
+	if !slices.Equal(got, want) {
+		t.Errorf("sessionEnv() = %v, want %v", got, want)
+	}
+
+	got = sessionEnv("toast", "/p/.beads", &SessionOptions{PortOffset: 2000, PortEnv: "WT_PORT"})
+	if got[len(got)-1] != "WT_PORT=2000" {
+		t.Errorf("sessionEnv() = %v, want WT_PORT=2000 last", got)
+	}
+
+	if got := envArgs([]string{"A=1", "B=2"}); !slices.Equal(got, []string{"-e", "A=1", "-e", "B=2"}) {
+		t.Errorf("envArgs() = %v", got)
+	}
+}
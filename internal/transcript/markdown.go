@@ -0,0 +1,119 @@
+package transcript
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MaxResultBytes caps each tool result in markdown exports; JSON exports
+// keep results whole
+const MaxResultBytes = 4000
+
+// Markdown renders a transcript for reading. Tool results are folded under
+// their tool call and cut off at MaxResultBytes.
+func Markdown(h Header, messages []Message) string {
+	var sb strings.Builder
+
+	title := h.Session
+	if h.Bead != "" && h.Bead != h.Session {
+		title = fmt.Sprintf("%s (%s)", h.Session, h.Bead)
+	}
+	fmt.Fprintf(&sb, "# Transcript: %s\n\n", title)
+	if h.Project != "" {
+		fmt.Fprintf(&sb, "- Project: %s\n", h.Project)
+	}
+	fmt.Fprintf(&sb, "- Claude session: %s\n", h.ClaudeSession)
+	if len(messages) > 0 {
+		fmt.Fprintf(&sb, "- Started: %s\n", formatTime(messages[0].Timestamp))
+	}
+	fmt.Fprintf(&sb, "- Exported: %s\n", formatTime(h.ExportedAt))
+
+	// Claude Code writes each content block as its own entry, so a heading
+	// starts only when the speaker changes
+	speaker := ""
+	for _, msg := range messages {
+		if !onlyToolResults(msg) && msg.Role != speaker {
+			speaker = msg.Role
+			role := "User"
+			if msg.Role == "assistant" {
+				role = "Assistant"
+			}
+			fmt.Fprintf(&sb, "\n## %s · %s\n", role, formatTime(msg.Timestamp))
+		}
+		for _, b := range msg.Blocks {
+			sb.WriteString("\n")
+			writeBlock(&sb, b)
+		}
+	}
+	return sb.String()
+}
+
+func writeBlock(sb *strings.Builder, b Block) {
+	switch b.Type {
+	case "text":
+		sb.WriteString(strings.TrimSpace(b.Text) + "\n")
+	case "thinking":
+		fmt.Fprintf(sb, "<details><summary>Thinking</summary>\n\n%s\n\n</details>\n", strings.TrimSpace(b.Text))
+	case "tool_use":
+		fmt.Fprintf(sb, "**Tool: %s**\n\n", b.Tool)
+		writeFenced(sb, "json", prettyJSON(b.Input))
+	case "tool_result":
+		label := "Result"
+		if b.IsError {
+			label = "Error"
+		}
+		text := b.Text
+		if len(text) > MaxResultBytes {
+			text = fmt.Sprintf("%s\n… (%d more bytes)", text[:MaxResultBytes], len(text)-MaxResultBytes)
+		}
+		fmt.Fprintf(sb, "<details><summary>%s</summary>\n\n", label)
+		writeFenced(sb, "", text)
+		sb.WriteString("\n</details>\n")
+	}
+}
+
+// writeFenced writes a code block whose fence is longer than any run of
+// backticks in the content
+func writeFenced(sb *strings.Builder, lang, content string) {
+	fence := "```"
+	for strings.Contains(content, fence) {
+		fence += "`"
+	}
+	fmt.Fprintf(sb, "%s%s\n%s\n%s\n", fence, lang, strings.TrimRight(content, "\n"), fence)
+}
+
+func prettyJSON(raw json.RawMessage) string {
+	var v interface{}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return string(raw)
+	}
+	var out strings.Builder
+	enc := json.NewEncoder(&out)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return string(raw)
+	}
+	return out.String()
+}
+
+// onlyToolResults reports whether a user message just carries tool output,
+// which reads as part of the assistant's turn
+func onlyToolResults(msg Message) bool {
+	for _, b := range msg.Blocks {
+		if b.Type != "tool_result" {
+			return false
+		}
+	}
+	return true
+}
+
+func formatTime(ts string) string {
+	t, err := time.Parse(time.RFC3339, ts)
+	if err != nil {
+		return ts
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
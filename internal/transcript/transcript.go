@@ -0,0 +1,207 @@
+// Package transcript reads Claude Code session transcripts and exports them
+// as markdown or JSON.
+package transcript
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/badri/wt/internal/monitor"
+)
+
+// Message is one user or assistant turn of a conversation
+type Message struct {
+	Role      string  `json:"role"` // "user" or "assistant"
+	Timestamp string  `json:"timestamp,omitempty"`
+	Blocks    []Block `json:"blocks"`
+}
+
+// Block is one piece of message content
+type Block struct {
+	Type    string          `json:"type"` // text, thinking, tool_use or tool_result
+	Text    string          `json:"text,omitempty"`
+	Tool    string          `json:"tool,omitempty"`    // tool_use: tool name
+	ToolID  string          `json:"tool_id,omitempty"` // links a tool_result to its tool_use
+	Input   json.RawMessage `json:"input,omitempty"`   // tool_use: arguments
+	IsError bool            `json:"is_error,omitempty"`
+}
+
+// Header describes the wt session a transcript belongs to
+type Header struct {
+	Session       string `json:"session"`
+	Bead          string `json:"bead,omitempty"`
+	Project       string `json:"project,omitempty"`
+	ClaudeSession string `json:"claude_session"`
+	ExportedAt    string `json:"exported_at"`
+}
+
+// Export is the JSON export format
+type Export struct {
+	Header
+	Messages []Message `json:"messages"`
+}
+
+// entry is the subset of a transcript JSONL line needed for export
+type entry struct {
+	Type        string `json:"type"`
+	Timestamp   string `json:"timestamp"`
+	IsSidechain bool   `json:"isSidechain"`
+	Message     struct {
+		Content json.RawMessage `json:"content"`
+	} `json:"message"`
+}
+
+// rawBlock is a content block as Claude Code writes it
+type rawBlock struct {
+	Type      string          `json:"type"`
+	Text      string          `json:"text"`
+	Thinking  string          `json:"thinking"`
+	ID        string          `json:"id"`
+	Name      string          `json:"name"`
+	Input     json.RawMessage `json:"input"`
+	ToolUseID string          `json:"tool_use_id"`
+	Content   json.RawMessage `json:"content"`
+	IsError   bool            `json:"is_error"`
+}
+
+// Find returns the transcript file of a Claude session. It looks in the
+// directory Claude Code uses for the worktree first, then in every project
+// directory, since the worktree of an ended session may be gone.
+func Find(worktreePath, sessionID string) (string, error) {
+	name := sessionID + ".jsonl"
+	if worktreePath != "" {
+		path := filepath.Join(monitor.ClaudeProjectDir(worktreePath), name)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	matches, _ := filepath.Glob(filepath.Join(home, ".claude", "projects", "*", name))
+	if len(matches) == 0 {
+		return "", fmt.Errorf("no transcript found for Claude session %s", sessionID)
+	}
+	return matches[0], nil
+}
+
+// ReadFile parses a transcript file
+func ReadFile(path string) ([]Message, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Read(f)
+}
+
+// Read parses transcript JSONL into messages, skipping metadata entries,
+// sub-agent sidechains and malformed lines
+func Read(r io.Reader) ([]Message, error) {
+	var messages []Message
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadBytes('\n')
+		if len(line) > 0 {
+			if msg, ok := parseLine(line); ok {
+				messages = append(messages, msg)
+			}
+		}
+		if err == io.EOF {
+			return messages, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func parseLine(line []byte) (Message, bool) {
+	var e entry
+	if err := json.Unmarshal(line, &e); err != nil {
+		return Message{}, false
+	}
+	if (e.Type != "user" && e.Type != "assistant") || e.IsSidechain {
+		return Message{}, false
+	}
+
+	msg := Message{Role: e.Type, Timestamp: e.Timestamp}
+	var text string
+	if err := json.Unmarshal(e.Message.Content, &text); err == nil {
+		if strings.TrimSpace(text) != "" {
+			msg.Blocks = []Block{{Type: "text", Text: text}}
+		}
+		return msg, len(msg.Blocks) > 0
+	}
+
+	var raw []rawBlock
+	if err := json.Unmarshal(e.Message.Content, &raw); err != nil {
+		return Message{}, false
+	}
+	for _, b := range raw {
+		switch b.Type {
+		case "text":
+			if strings.TrimSpace(b.Text) != "" {
+				msg.Blocks = append(msg.Blocks, Block{Type: "text", Text: b.Text})
+			}
+		case "thinking":
+			if strings.TrimSpace(b.Thinking) != "" {
+				msg.Blocks = append(msg.Blocks, Block{Type: "thinking", Text: b.Thinking})
+			}
+		case "tool_use":
+			msg.Blocks = append(msg.Blocks, Block{Type: "tool_use", Tool: b.Name, ToolID: b.ID, Input: b.Input})
+		case "tool_result":
+			msg.Blocks = append(msg.Blocks, Block{Type: "tool_result", ToolID: b.ToolUseID, Text: resultText(b.Content), IsError: b.IsError})
+		}
+	}
+	return msg, len(msg.Blocks) > 0
+}
+
+// resultText flattens tool result content, a string or an array of blocks
+func resultText(raw json.RawMessage) string {
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return text
+	}
+	var blocks []rawBlock
+	if err := json.Unmarshal(raw, &blocks); err != nil {
+		return ""
+	}
+	var parts []string
+	for _, b := range blocks {
+		switch b.Type {
+		case "text":
+			parts = append(parts, b.Text)
+		case "image":
+			parts = append(parts, "[image]")
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// LastAssistantText returns the text of the last assistant message that has
+// any, ignoring thinking and tool use. Returns "" if there is none.
+func LastAssistantText(messages []Message) string {
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role != "assistant" {
+			continue
+		}
+		var parts []string
+		for _, b := range messages[i].Blocks {
+			if b.Type == "text" && strings.TrimSpace(b.Text) != "" {
+				parts = append(parts, strings.TrimSpace(b.Text))
+			}
+		}
+		if len(parts) > 0 {
+			return strings.Join(parts, "\n\n")
+		}
+	}
+	return ""
+}
@@ -0,0 +1,84 @@
+package transcript
+
+import (
+	"strings"
+	"testing"
+)
+
+const sample = `{"type":"queue-operation","operation":"enqueue"}
+{"type":"user","timestamp":"2026-01-20T09:00:00Z","message":{"role":"user","content":"Fix the flaky test"}}
+{"type":"assistant","timestamp":"2026-01-20T09:00:05Z","message":{"content":[{"type":"thinking","thinking":"Look at the test first."}]}}
+{"type":"assistant","timestamp":"2026-01-20T09:00:06Z","message":{"content":[{"type":"text","text":"Running it."},{"type":"tool_use","id":"t1","name":"Bash","input":{"command":"go test ./... && echo ok"}}]}}
+{"type":"user","timestamp":"2026-01-20T09:00:09Z","message":{"content":[{"type":"tool_result","tool_use_id":"t1","content":[{"type":"text","text":"FAIL TestFlaky"}],"is_error":true}]}}
+{"type":"assistant","isSidechain":true,"message":{"content":[{"type":"text","text":"sub-agent chatter"}]}}
+{"type":"assistant","timestamp":"2026-01-20T09:01:00Z","message":{"content":[{"type":"text","text":"Fixed with ` + "```" + `t.Parallel()` + "```" + ` removed."}]}}
+not json
+`
+
+func TestRead(t *testing.T) {
+	messages, err := Read(strings.NewReader(sample))
+	if err != nil {
+		t.Fatalf("Read() unexpected error: %v", err)
+	}
+	if len(messages) != 5 {
+		t.Fatalf("Read() = %d messages, want 5 (metadata, sidechains and bad lines skipped)", len(messages))
+	}
+	if b := messages[0].Blocks[0]; messages[0].Role != "user" || b.Text != "Fix the flaky test" {
+		t.Errorf("string content = %+v", messages[0])
+	}
+	if b := messages[2].Blocks[1]; b.Type != "tool_use" || b.Tool != "Bash" || b.ToolID != "t1" {
+		t.Errorf("tool_use block = %+v", b)
+	}
+	if b := messages[3].Blocks[0]; b.Type != "tool_result" || b.Text != "FAIL TestFlaky" || !b.IsError {
+		t.Errorf("tool_result block = %+v", b)
+	}
+}
+
+func TestLastAssistantText(t *testing.T) {
+	messages, err := Read(strings.NewReader(sample))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "Fixed with ```t.Parallel()``` removed."
+	if got := LastAssistantText(messages); got != want {
+		t.Errorf("LastAssistantText() = %q, want %q", got, want)
+	}
+
+	// Tool use and thinking aren't messages to the user
+	if got := LastAssistantText(messages[:2]); got != "" {
+		t.Errorf("LastAssistantText() with only thinking = %q, want empty", got)
+	}
+	if got := LastAssistantText(messages[:4]); got != "Running it." {
+		t.Errorf("LastAssistantText() = %q, want %q", got, "Running it.")
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	messages, _ := Read(strings.NewReader(sample))
+	md := Markdown(Header{Session: "toast", Bead: "wt-abc", ClaudeSession: "c1", ExportedAt: "2026-01-20T10:00:00Z"}, messages)
+
+	for _, want := range []string{
+		"# Transcript: toast (wt-abc)",
+		"- Claude session: c1",
+		"**Tool: Bash**",
+		`"command": "go test ./... && echo ok"`,
+		"<details><summary>Error</summary>",
+		"FAIL TestFlaky",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("Markdown() missing %q:\n%s", want, md)
+		}
+	}
+	// Consecutive assistant entries and tool results share one heading
+	if n := strings.Count(md, "## Assistant"); n != 1 {
+		t.Errorf("Markdown() has %d assistant headings, want 1", n)
+	}
+}
+
+func TestWriteFenced(t *testing.T) {
+	var sb strings.Builder
+	writeFenced(&sb, "", "a ``` b")
+	if !strings.HasPrefix(sb.String(), "````\n") {
+		t.Errorf("writeFenced() = %q, want a longer fence", sb.String())
+	}
+}
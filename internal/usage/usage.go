@@ -0,0 +1,185 @@
+// Package usage estimates what Claude work cost from the token counts Claude
+// Code records in its transcripts. Prices are list API prices, so the
+// estimate is an upper bound for subscription plans.
+package usage
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/badri/wt/internal/monitor"
+)
+
+// Usage is the token usage of some Claude work and its estimated cost
+type Usage struct {
+	InputTokens      int64   `json:"input_tokens"`
+	OutputTokens     int64   `json:"output_tokens"`
+	CacheWriteTokens int64   `json:"cache_write_tokens"`
+	CacheReadTokens  int64   `json:"cache_read_tokens"`
+	Cost             float64 `json:"cost"` // USD
+}
+
+// Add accumulates u2 into u
+func (u *Usage) Add(u2 Usage) {
+	u.InputTokens += u2.InputTokens
+	u.OutputTokens += u2.OutputTokens
+	u.CacheWriteTokens += u2.CacheWriteTokens
+	u.CacheReadTokens += u2.CacheReadTokens
+	u.Cost += u2.Cost
+}
+
+// price is USD per million input and output tokens. Cache writes cost 1.25x
+// input and cache reads 0.1x.
+type price struct {
+	model  string // model ID prefix
+	input  float64
+	output float64
+}
+
+// prices are matched in order, so more specific prefixes come first.
+// Unknown models are priced like Sonnet.
+var prices = []price{
+	{"claude-opus-4-5", 5, 25},
+	{"claude-opus", 15, 75},
+	{"claude-3-opus", 15, 75},
+	{"claude-haiku-4", 1, 5},
+	{"claude-3-5-haiku", 0.8, 4},
+	{"claude-3-haiku", 0.25, 1.25},
+	{"claude-sonnet", 3, 15},
+	{"claude-3", 3, 15},
+}
+
+var defaultPrice = price{"", 3, 15}
+
+func priceFor(model string) price {
+	for _, p := range prices {
+		if strings.HasPrefix(model, p.model) {
+			return p
+		}
+	}
+	return defaultPrice
+}
+
+// cost estimates the cost of one message's tokens on a model
+func cost(model string, u Usage) float64 {
+	p := priceFor(model)
+	return (float64(u.InputTokens)*p.input +
+		float64(u.CacheWriteTokens)*p.input*1.25 +
+		float64(u.CacheReadTokens)*p.input*0.1 +
+		float64(u.OutputTokens)*p.output) / 1e6
+}
+
+// entry is the subset of a transcript line that carries usage
+type entry struct {
+	Type      string `json:"type"`
+	Timestamp string `json:"timestamp"`
+	Message   struct {
+		ID    string `json:"id"`
+		Model string `json:"model"`
+		Usage *struct {
+			InputTokens      int64 `json:"input_tokens"`
+			OutputTokens     int64 `json:"output_tokens"`
+			CacheWriteTokens int64 `json:"cache_creation_input_tokens"`
+			CacheReadTokens  int64 `json:"cache_read_input_tokens"`
+		} `json:"usage"`
+	} `json:"message"`
+}
+
+// Since sums the usage Claude recorded for a working directory since the
+// given time, across all its transcripts
+func Since(worktreePath string, since time.Time) (Usage, error) {
+	var total Usage
+	dir := monitor.ClaudeProjectDir(worktreePath)
+	if dir == "" {
+		return total, nil
+	}
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return total, nil
+		}
+		return total, err
+	}
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), ".jsonl") {
+			continue
+		}
+		if info, err := f.Info(); err != nil || info.ModTime().Before(since) {
+			continue
+		}
+		u, err := FileSince(filepath.Join(dir, f.Name()), since)
+		if err != nil {
+			return total, err
+		}
+		total.Add(u)
+	}
+	return total, nil
+}
+
+// FileSince sums the usage in one transcript since the given time. Claude
+// Code writes one line per content block, each repeating the message's
+// usage, so messages are counted once by ID.
+func FileSince(path string, since time.Time) (Usage, error) {
+	var total Usage
+	f, err := os.Open(path)
+	if err != nil {
+		return total, err
+	}
+	defer f.Close()
+
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if !strings.Contains(string(line), `"usage"`) {
+			continue
+		}
+		var e entry
+		if json.Unmarshal(line, &e) != nil || e.Type != "assistant" || e.Message.Usage == nil {
+			continue
+		}
+		if t, err := time.Parse(time.RFC3339, e.Timestamp); err == nil && t.Before(since) {
+			continue
+		}
+		if e.Message.ID != "" {
+			if seen[e.Message.ID] {
+				continue
+			}
+			seen[e.Message.ID] = true
+		}
+		mu := e.Message.Usage
+		u := Usage{
+			InputTokens:      mu.InputTokens,
+			OutputTokens:     mu.OutputTokens,
+			CacheWriteTokens: mu.CacheWriteTokens,
+			CacheReadTokens:  mu.CacheReadTokens,
+		}
+		u.Cost = cost(e.Message.Model, u)
+		total.Add(u)
+	}
+	if err := scanner.Err(); err != nil {
+		return total, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return total, nil
+}
+
+// FormatCost renders a USD amount, e.g. "$3.27"
+func FormatCost(c float64) string {
+	return fmt.Sprintf("$%.2f", c)
+}
+
+// ParseCost parses a USD amount given as "20", "20.50" or "$20"
+func ParseCost(s string) (float64, error) {
+	c, err := strconv.ParseFloat(strings.TrimPrefix(strings.TrimSpace(s), "$"), 64)
+	if err != nil || c <= 0 {
+		return 0, fmt.Errorf("invalid cost %q (expected a positive amount like 20 or $12.50)", s)
+	}
+	return c, nil
+}
@@ -0,0 +1,72 @@
+package usage
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFileSince(t *testing.T) {
+	lines := []string{
+		// Before the cutoff
+		`{"type":"assistant","timestamp":"2026-03-01T09:00:00.000Z","message":{"id":"msg_0","model":"claude-sonnet-4-5","usage":{"input_tokens":1000000,"output_tokens":0}}}`,
+		`{"type":"user","timestamp":"2026-03-01T10:00:01.000Z","message":{"role":"user","content":"go"}}`,
+		// Two content blocks of one message repeat its usage
+		`{"type":"assistant","timestamp":"2026-03-01T10:00:02.000Z","message":{"id":"msg_1","model":"claude-sonnet-4-5","usage":{"input_tokens":100000,"output_tokens":20000,"cache_creation_input_tokens":40000,"cache_read_input_tokens":500000}}}`,
+		`{"type":"assistant","timestamp":"2026-03-01T10:00:03.000Z","message":{"id":"msg_1","model":"claude-sonnet-4-5","usage":{"input_tokens":100000,"output_tokens":20000,"cache_creation_input_tokens":40000,"cache_read_input_tokens":500000}}}`,
+		`{"type":"assistant","timestamp":"2026-03-01T10:05:00.000Z","message":{"id":"msg_2","model":"claude-opus-4-1","usage":{"input_tokens":10000,"output_tokens":1000}}}`,
+		`not json with "usage"`,
+	}
+	path := filepath.Join(t.TempDir(), "s.jsonl")
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	u, err := FileSince(path, time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.InputTokens != 110000 || u.OutputTokens != 21000 || u.CacheWriteTokens != 40000 || u.CacheReadTokens != 500000 {
+		t.Errorf("FileSince() tokens = %+v", u)
+	}
+	// Sonnet: 0.1M*3 + 0.04M*3.75 + 0.5M*0.3 + 0.02M*15 = 0.9
+	// Opus 4.1: 0.01M*15 + 0.001M*75 = 0.225
+	if want := 1.125; math.Abs(u.Cost-want) > 1e-9 {
+		t.Errorf("FileSince() cost = %v, want %v", u.Cost, want)
+	}
+}
+
+func TestPriceFor(t *testing.T) {
+	tests := map[string]float64{
+		"claude-opus-4-5-20251101":  5,
+		"claude-opus-4-1-20250805":  15,
+		"claude-sonnet-4-5":         3,
+		"claude-haiku-4-5-20251001": 1,
+		"claude-3-5-haiku-20241022": 0.8,
+		"some-other-model":          3,
+	}
+	for model, want := range tests {
+		if got := priceFor(model).input; got != want {
+			t.Errorf("priceFor(%s).input = %v, want %v", model, got, want)
+		}
+	}
+}
+
+func TestParseCost(t *testing.T) {
+	for in, want := range map[string]float64{"20": 20, "$12.50": 12.5, " 3 ": 3} {
+		if got, err := ParseCost(in); err != nil || got != want {
+			t.Errorf("ParseCost(%q) = %v, %v, want %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "0", "-5", "20usd", "$"} {
+		if _, err := ParseCost(in); err == nil {
+			t.Errorf("ParseCost(%q) expected error", in)
+		}
+	}
+	if got := FormatCost(3.267); got != "$3.27" {
+		t.Errorf("FormatCost() = %s", got)
+	}
+}
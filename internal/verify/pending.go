@@ -0,0 +1,50 @@
+package verify
+
+import (
+	"path/filepath"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/jsonstore"
+)
+
+// Entry is an auto-merge PR waiting to merge, so the default branch can be
+// verified once it has
+type Entry struct {
+	Bead     string `json:"bead"`
+	Session  string `json:"session"`
+	Branch   string `json:"branch"`
+	Project  string `json:"project"`
+	RepoPath string `json:"repo_path"`
+	PRURL    string `json:"pr_url"`
+}
+
+// Store holds the pending entries, keyed by bead ID
+type Store struct {
+	*jsonstore.Store[Entry]
+}
+
+func storePath(cfg *config.Config) string {
+	return filepath.Join(cfg.ConfigDir(), "verify-pending.json")
+}
+
+// Load reads the pending verifications from the config directory
+func Load(cfg *config.Config) (*Store, error) {
+	s, err := jsonstore.Load[Entry](storePath(cfg))
+	if err != nil {
+		return nil, err
+	}
+	return &Store{s}, nil
+}
+
+// Update loads, changes and saves the pending verifications while holding
+// their lock
+func Update(cfg *config.Config, fn func(s *Store) error) error {
+	return jsonstore.Update(storePath(cfg), func(s *jsonstore.Store[Entry]) error {
+		return fn(&Store{s})
+	})
+}
+
+// Put adds or replaces the entry for e.Bead
+func (s *Store) Put(e *Entry) {
+	s.Set(e.Bead, e)
+}
@@ -0,0 +1,97 @@
+// Package verify runs a project's post-merge verification command against
+// the updated default branch, in a temporary worktree so the main checkout
+// and session worktrees are left alone.
+package verify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/badri/wt/internal/logging"
+)
+
+// DefaultTimeout bounds a verification run when the project sets none
+const DefaultTimeout = 30 * time.Minute
+
+// outputLines is how much of the command's output a Result keeps
+const outputLines = 40
+
+// Result is the outcome of one verification run
+type Result struct {
+	Commit   string // default branch commit that was tested
+	Passed   bool
+	TimedOut bool
+	Output   string // last lines of combined output
+	Duration time.Duration
+}
+
+// Run fetches defaultBranch, checks out its tip in a temporary worktree of
+// repoPath and runs command there with sh -c. An error means verification
+// could not run; a failing command is reported in the Result.
+func Run(repoPath, defaultBranch, command string, timeout time.Duration) (*Result, error) {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	ref := defaultBranch
+	fetch := logging.Command("git", "-C", repoPath, "fetch", "origin", defaultBranch)
+	if err := fetch.Run(); err == nil {
+		ref = "origin/" + defaultBranch
+	}
+	commit, err := gitOutput(repoPath, "rev-parse", ref)
+	if err != nil {
+		return nil, fmt.Errorf("resolving %s: %w", ref, err)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "wt-verify-")
+	if err != nil {
+		return nil, fmt.Errorf("creating temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	add := logging.Command("git", "-C", repoPath, "worktree", "add", "--detach", tmpDir, commit)
+	if output, err := add.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("git worktree add: %s: %w", string(output), err)
+	}
+	defer logging.Command("git", "-C", repoPath, "worktree", "remove", "--force", tmpDir).Run()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	cmd := logging.CommandContext(ctx, "sh", "-c", command)
+	cmd.Dir = tmpDir
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+
+	start := time.Now()
+	runErr := cmd.Run()
+	res := &Result{
+		Commit:   commit,
+		Passed:   runErr == nil,
+		TimedOut: ctx.Err() == context.DeadlineExceeded,
+		Output:   tail(out.String(), outputLines),
+		Duration: time.Since(start).Round(time.Second),
+	}
+	return res, nil
+}
+
+// tail returns the last n lines of s
+func tail(s string, n int) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+func gitOutput(dir string, args ...string) (string, error) {
+	output, err := logging.Command("git", append([]string{"-C", dir}, args...)...).Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
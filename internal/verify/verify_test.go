@@ -0,0 +1,81 @@
+package verify
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/badri/wt/internal/config"
+)
+
+func git(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v failed: %v\nOutput: %s", args, err, out)
+	}
+}
+
+func TestRun(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	repo := t.TempDir()
+	git(t, repo, "init", "-q", "-b", "main")
+	os.WriteFile(filepath.Join(repo, "ok.txt"), []byte("ok\n"), 0644)
+	git(t, repo, "add", ".")
+	git(t, repo, "-c", "user.name=Test", "-c", "user.email=test@test.com", "commit", "-q", "-m", "init")
+
+	res, err := Run(repo, "main", "test -f ok.txt", time.Minute)
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if !res.Passed || len(res.Commit) != 40 {
+		t.Errorf("Run() = %+v, want a pass at the main commit", res)
+	}
+
+	res, err = Run(repo, "main", "echo broken build; exit 1", time.Minute)
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if res.Passed || !strings.Contains(res.Output, "broken build") {
+		t.Errorf("Run() = %+v, want a failure with its output", res)
+	}
+
+	// The temporary worktree is gone afterwards
+	out, _ := exec.Command("git", "-C", repo, "worktree", "list").Output()
+	if strings.Count(string(out), "\n") != 1 {
+		t.Errorf("worktrees left behind:\n%s", out)
+	}
+}
+
+func TestTail(t *testing.T) {
+	if got := tail("a\nb\nc\n", 2); got != "b\nc" {
+		t.Errorf("tail() = %q, want last two lines", got)
+	}
+}
+
+func TestStore(t *testing.T) {
+	cfg, _ := config.LoadFromDir(t.TempDir())
+	store, err := Load(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.Put(&Entry{Bead: "wt-a", Branch: "wt-a", PRURL: "https://example.com/pr/1"})
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+	store, _ = Load(cfg)
+	if store.Entries["wt-a"] == nil || store.Entries["wt-a"].PRURL != "https://example.com/pr/1" {
+		t.Errorf("Load() = %v, want the saved entry", store.Entries)
+	}
+
+	store.Remove("wt-a")
+	store.Save()
+	if _, err := os.Stat(filepath.Join(cfg.ConfigDir(), "verify-pending.json")); !os.IsNotExist(err) {
+		t.Error("an empty store should remove its file")
+	}
+}
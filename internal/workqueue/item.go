@@ -0,0 +1,69 @@
+package workqueue
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Item is one unit of work pushed to the queue. It either names an
+// existing bead, or carries what's needed to create one.
+type Item struct {
+	ID      string `json:"id,omitempty"`      // Set by the producer so a redelivered item isn't processed twice
+	Project string `json:"project,omitempty"` // Project to work in (default: queue.project, then --project)
+	Bead    string `json:"bead,omitempty"`    // Existing bead to work on
+
+	// For new beads
+	Title       string   `json:"title,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Priority    *int     `json:"priority,omitempty"`
+	Type        string   `json:"type,omitempty"`
+	Labels      []string `json:"labels,omitempty"`
+
+	// Where the item came from, recorded on the bead and in wt auto inbox
+	Source string `json:"source,omitempty"` // e.g. "ci", "alertmanager"
+	URL    string `json:"url,omitempty"`    // e.g. the failing CI run
+}
+
+// Parse decodes a queue item. An item needs a bead or a title.
+func Parse(data []byte) (*Item, error) {
+	var item Item
+	if err := json.Unmarshal(data, &item); err != nil {
+		return nil, fmt.Errorf("invalid queue item: %w", err)
+	}
+	item.Bead = strings.TrimSpace(item.Bead)
+	item.Title = strings.TrimSpace(item.Title)
+	if item.Bead == "" && item.Title == "" {
+		return nil, fmt.Errorf("invalid queue item: needs a bead or a title")
+	}
+	if item.Priority != nil && (*item.Priority < 0 || *item.Priority > 4) {
+		return nil, fmt.Errorf("invalid queue item: priority must be 0-4")
+	}
+	return &item, nil
+}
+
+// Ref returns a short reference to where the item came from, e.g.
+// "ci:build-1234", "ci" or "queue"
+func (i *Item) Ref() string {
+	source := i.Source
+	if source == "" {
+		source = "queue"
+	}
+	if i.ID != "" {
+		return source + ":" + i.ID
+	}
+	return source
+}
+
+// BeadDescription builds the description of a bead created for the item,
+// with a back-reference to its source first
+func (i *Item) BeadDescription() string {
+	desc := fmt.Sprintf("Queued from %s", i.Ref())
+	if i.URL != "" {
+		desc += ": " + i.URL
+	}
+	if i.Description != "" {
+		desc += "\n\n" + i.Description
+	}
+	return desc
+}
@@ -0,0 +1,77 @@
+package workqueue
+
+import (
+	"path/filepath"
+	"sort"
+
+	"github.com/badri/wt/internal/config"
+	"github.com/badri/wt/internal/jsonstore"
+)
+
+// Link records what became of a queue item: the bead it was worked as,
+// the session that worked it and how that ended
+type Link struct {
+	Bead       string `json:"bead"`
+	Project    string `json:"project,omitempty"`
+	ItemID     string `json:"item_id,omitempty"`
+	Source     string `json:"source,omitempty"`
+	URL        string `json:"url,omitempty"`
+	Created    bool   `json:"created,omitempty"` // the bead was created from the item
+	Session    string `json:"session,omitempty"`
+	Outcome    string `json:"outcome,omitempty"` // auto's outcome: "merged", "failed", ...
+	ReceivedAt string `json:"received_at"`
+	FinishedAt string `json:"finished_at,omitempty"`
+}
+
+// Ref returns a short reference to the item's source
+func (l *Link) Ref() string {
+	return (&Item{ID: l.ItemID, Source: l.Source}).Ref()
+}
+
+// Store holds all links, keyed by bead ID
+type Store struct {
+	*jsonstore.Store[Link]
+}
+
+// Load reads the link store from the config directory
+func Load(cfg *config.Config) (*Store, error) {
+	s, err := jsonstore.Load[Link](filepath.Join(cfg.ConfigDir(), "queue_items.json"))
+	if err != nil {
+		return nil, err
+	}
+	return &Store{s}, nil
+}
+
+// Put adds or replaces the link for l.Bead
+func (s *Store) Put(l *Link) {
+	s.Set(l.Bead, l)
+}
+
+// FindItem returns the link of an item ID from a source, or nil if the
+// item wasn't received before
+func (s *Store) FindItem(source, id string) *Link {
+	if id == "" {
+		return nil
+	}
+	for _, l := range s.Entries {
+		if l.Source == source && l.ItemID == id {
+			return l
+		}
+	}
+	return nil
+}
+
+// List returns the links, most recently received first
+func (s *Store) List() []*Link {
+	links := make([]*Link, 0, len(s.Entries))
+	for _, l := range s.Entries {
+		links = append(links, l)
+	}
+	sort.Slice(links, func(i, j int) bool {
+		if links[i].ReceivedAt != links[j].ReceivedAt {
+			return links[i].ReceivedAt > links[j].ReceivedAt
+		}
+		return links[i].Bead < links[j].Bead
+	})
+	return links
+}
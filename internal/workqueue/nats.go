@@ -0,0 +1,178 @@
+package workqueue
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// natsQueue is a NATS subject wt subscribes to in a queue group, so each
+// item goes to one of the workers listening. Core NATS doesn't store
+// messages: items published while no worker is subscribed are dropped.
+// Each subscription takes a single message, so the server doesn't route
+// more items to a worker busy with the one it got.
+type natsQueue struct {
+	conn       net.Conn
+	r          *bufio.Reader
+	subject    string
+	group      string
+	sid        int
+	subscribed bool
+	partial    string // line cut off by a read deadline
+}
+
+func dialNATS(u *url.URL, subject, group string) (*natsQueue, error) {
+	conn, err := dial(u.Host, "4222", u.Scheme == "tls")
+	if err != nil {
+		return nil, fmt.Errorf("connecting to nats: %w", err)
+	}
+	q := &natsQueue{conn: conn, r: bufio.NewReader(conn), subject: subject, group: group}
+
+	conn.SetDeadline(time.Now().Add(dialTimeout))
+	line, err := q.readLine()
+	if err != nil || !strings.HasPrefix(line, "INFO ") {
+		conn.Close()
+		return nil, fmt.Errorf("nats: unexpected greeting %q: %v", strings.TrimSpace(line), err)
+	}
+
+	opts := map[string]any{"verbose": false, "pedantic": false, "name": "wt", "lang": "go", "version": "1"}
+	if u.User != nil {
+		if password, ok := u.User.Password(); ok {
+			opts["user"], opts["pass"] = u.User.Username(), password
+		} else {
+			opts["auth_token"] = u.User.Username()
+		}
+	}
+	connect, _ := json.Marshal(opts)
+	if err := q.send("CONNECT " + string(connect) + "\r\n"); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if err := q.flush(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return q, nil
+}
+
+func (q *natsQueue) Pop(wait time.Duration) ([]byte, error) {
+	if !q.subscribed {
+		// The server unsubscribes after one message
+		q.sid++
+		if err := q.send(fmt.Sprintf("SUB %s %s %d\r\nUNSUB %d 1\r\n", q.subject, q.group, q.sid, q.sid)); err != nil {
+			return nil, err
+		}
+		q.subscribed = true
+	}
+
+	q.conn.SetDeadline(time.Now().Add(wait))
+	for {
+		line, err := q.readLine()
+		if err != nil {
+			if isTimeout(err) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("nats: %w", err)
+		}
+		payload, ok, err := q.handle(line)
+		if ok {
+			q.subscribed = false
+		}
+		if err != nil || ok {
+			return payload, err
+		}
+	}
+}
+
+func (q *natsQueue) Push(data []byte) error {
+	if err := q.send(fmt.Sprintf("PUB %s %d\r\n%s\r\n", q.subject, len(data), data)); err != nil {
+		return err
+	}
+	// The server answers PING once it has processed the PUB before it
+	return q.flush()
+}
+
+func (q *natsQueue) Close() error {
+	return q.conn.Close()
+}
+
+// handle processes one protocol line; ok is true when it was a message
+func (q *natsQueue) handle(line string) (payload []byte, ok bool, err error) {
+	line = strings.TrimRight(line, "\r\n")
+	op, args, _ := strings.Cut(line, " ")
+	switch strings.ToUpper(op) {
+	case "MSG":
+		// MSG <subject> <sid> [reply-to] <#bytes>
+		fields := strings.Fields(args)
+		if len(fields) < 3 {
+			return nil, false, fmt.Errorf("nats: bad message header %q", line)
+		}
+		n, err := strconv.Atoi(fields[len(fields)-1])
+		if err != nil {
+			return nil, false, fmt.Errorf("nats: bad message header %q", line)
+		}
+		// The payload follows its header; don't let a short Pop wait cut it off
+		q.conn.SetReadDeadline(time.Now().Add(dialTimeout))
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(q.r, buf); err != nil {
+			return nil, false, fmt.Errorf("nats: %w", err)
+		}
+		return buf[:n], true, nil
+	case "PING":
+		return nil, false, q.send("PONG\r\n")
+	case "-ERR":
+		return nil, false, fmt.Errorf("nats: %s", strings.Trim(args, "' "))
+	}
+	// +OK, PONG and INFO updates need no answer
+	return nil, false, nil
+}
+
+// flush sends PING and waits for the PONG, surfacing errors for what was
+// sent before it
+func (q *natsQueue) flush() error {
+	if err := q.send("PING\r\n"); err != nil {
+		return err
+	}
+	q.conn.SetDeadline(time.Now().Add(dialTimeout))
+	for {
+		line, err := q.readLine()
+		if err != nil {
+			return fmt.Errorf("nats: %w", err)
+		}
+		if strings.TrimSpace(line) == "PONG" {
+			return nil
+		}
+		if _, ok, err := q.handle(line); err != nil {
+			return err
+		} else if ok {
+			return fmt.Errorf("nats: message received while publishing")
+		}
+	}
+}
+
+// readLine reads a protocol line. A line cut off by the read deadline is
+// kept and completed by the next read instead of being lost.
+func (q *natsQueue) readLine() (string, error) {
+	s, err := q.r.ReadString('\n')
+	q.partial += s
+	if err != nil {
+		return "", err
+	}
+	line := q.partial
+	q.partial = ""
+	return line, nil
+}
+
+func (q *natsQueue) send(s string) error {
+	q.conn.SetWriteDeadline(time.Now().Add(dialTimeout))
+	if _, err := io.WriteString(q.conn, s); err != nil {
+		return fmt.Errorf("nats: %w", err)
+	}
+	return nil
+}
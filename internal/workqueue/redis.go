@@ -0,0 +1,131 @@
+package workqueue
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// redisQueue is a Redis list: producers RPUSH items, wt BLPOPs them
+type redisQueue struct {
+	conn net.Conn
+	r    *bufio.Reader
+	key  string
+}
+
+func dialRedis(u *url.URL, key string) (*redisQueue, error) {
+	conn, err := dial(u.Host, "6379", u.Scheme == "rediss")
+	if err != nil {
+		return nil, fmt.Errorf("connecting to redis: %w", err)
+	}
+	q := &redisQueue{conn: conn, r: bufio.NewReader(conn), key: key}
+
+	if password, ok := u.User.Password(); ok {
+		args := []string{"AUTH", password}
+		if user := u.User.Username(); user != "" {
+			args = []string{"AUTH", user, password}
+		}
+		if _, err := q.do(dialTimeout, args...); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("redis auth: %w", err)
+		}
+	}
+	if db := strings.Trim(u.Path, "/"); db != "" {
+		if _, err := q.do(dialTimeout, "SELECT", db); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("redis select %s: %w", db, err)
+		}
+	}
+	return q, nil
+}
+
+func (q *redisQueue) Pop(wait time.Duration) ([]byte, error) {
+	secs := int(wait.Seconds())
+	if secs < 1 {
+		secs = 1
+	}
+	reply, err := q.do(wait+dialTimeout, "BLPOP", q.key, strconv.Itoa(secs))
+	if err != nil {
+		return nil, err
+	}
+	// nil on timeout, else [key, value]
+	pair, ok := reply.([]any)
+	if !ok || len(pair) != 2 {
+		return nil, nil
+	}
+	value, _ := pair[1].([]byte)
+	return value, nil
+}
+
+func (q *redisQueue) Push(data []byte) error {
+	_, err := q.do(dialTimeout, "RPUSH", q.key, string(data))
+	return err
+}
+
+func (q *redisQueue) Close() error {
+	return q.conn.Close()
+}
+
+// do sends a command and reads its reply
+func (q *redisQueue) do(timeout time.Duration, args ...string) (any, error) {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "*%d\r\n", len(args))
+	for _, a := range args {
+		fmt.Fprintf(&sb, "$%d\r\n%s\r\n", len(a), a)
+	}
+	q.conn.SetDeadline(time.Now().Add(timeout))
+	if _, err := io.WriteString(q.conn, sb.String()); err != nil {
+		return nil, fmt.Errorf("redis: %w", err)
+	}
+	return readRESP(q.r)
+}
+
+// readRESP reads one RESP reply: a string, an int64, a []byte bulk
+// string, a []any array, or nil
+func readRESP(r *bufio.Reader) (any, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, fmt.Errorf("redis: %w", err)
+	}
+	line = strings.TrimRight(line, "\r\n")
+	if line == "" {
+		return nil, fmt.Errorf("redis: empty reply")
+	}
+	body := line[1:]
+	switch line[0] {
+	case '+':
+		return body, nil
+	case '-':
+		return nil, fmt.Errorf("redis: %s", body)
+	case ':':
+		return strconv.ParseInt(body, 10, 64)
+	case '$':
+		n, err := strconv.Atoi(body)
+		if err != nil || n < 0 {
+			return nil, err
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, fmt.Errorf("redis: %w", err)
+		}
+		return buf[:n], nil
+	case '*':
+		n, err := strconv.Atoi(body)
+		if err != nil || n < 0 {
+			return nil, err
+		}
+		items := make([]any, n)
+		for i := range items {
+			if items[i], err = readRESP(r); err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	}
+	return nil, fmt.Errorf("redis: unexpected reply %q", line)
+}
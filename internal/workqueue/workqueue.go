@@ -0,0 +1,66 @@
+// Package workqueue connects wt auto to an external work queue, so CI
+// failures, alert triage and other systems can push beads to process.
+// Redis lists and NATS subjects are supported, each through a small
+// client speaking the server's wire protocol.
+package workqueue
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/badri/wt/internal/config"
+)
+
+// dialTimeout bounds connecting to the queue server
+const dialTimeout = 10 * time.Second
+
+// Queue is a connection to the work queue
+type Queue interface {
+	// Pop waits up to wait for the next item and removes it from the
+	// queue. Returns nil when none arrived in time.
+	Pop(wait time.Duration) ([]byte, error)
+	// Push adds an item to the queue
+	Push(data []byte) error
+	Close() error
+}
+
+// Open connects to the queue configured in settings
+func Open(settings *config.Queue) (Queue, error) {
+	if settings == nil {
+		return nil, fmt.Errorf("no work queue configured (set queue.url in the config)")
+	}
+	backend, err := settings.Backend()
+	if err != nil {
+		return nil, err
+	}
+	u, err := url.Parse(settings.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid queue url: %w", err)
+	}
+	if backend == "redis" {
+		return dialRedis(u, settings.QueueName())
+	}
+	return dialNATS(u, settings.QueueName(), settings.QueueGroup())
+}
+
+// dial connects to host, adding defaultPort when it has none, over TLS
+// when useTLS is set
+func dial(host, defaultPort string, useTLS bool) (net.Conn, error) {
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		host = net.JoinHostPort(host, defaultPort)
+	}
+	if useTLS {
+		serverName, _, _ := net.SplitHostPort(host)
+		return tls.DialWithDialer(&net.Dialer{Timeout: dialTimeout}, "tcp", host, &tls.Config{ServerName: serverName})
+	}
+	return net.DialTimeout("tcp", host, dialTimeout)
+}
+
+// isTimeout reports whether err is a read deadline passing
+func isTimeout(err error) bool {
+	ne, ok := err.(net.Error)
+	return ok && ne.Timeout()
+}
@@ -0,0 +1,323 @@
+package workqueue
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/badri/wt/internal/config"
+)
+
+// fakeRedis serves RPUSH and BLPOP on in-memory lists. BLPOP on an empty
+// list answers nil at once instead of blocking.
+type fakeRedis struct {
+	mu    sync.Mutex
+	lists map[string][]string
+	cmds  []string
+}
+
+func startFakeRedis(t *testing.T) (*fakeRedis, string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	f := &fakeRedis{lists: make(map[string][]string)}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(conn)
+		}
+	}()
+	return f, ln.Addr().String()
+}
+
+func (f *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	for {
+		reply, err := readRESP(r)
+		if err != nil {
+			return
+		}
+		var args []string
+		for _, a := range reply.([]any) {
+			args = append(args, string(a.([]byte)))
+		}
+		f.mu.Lock()
+		f.cmds = append(f.cmds, args[0])
+		switch args[0] {
+		case "AUTH", "SELECT":
+			io.WriteString(conn, "+OK\r\n")
+		case "RPUSH":
+			f.lists[args[1]] = append(f.lists[args[1]], args[2])
+			fmt.Fprintf(conn, ":%d\r\n", len(f.lists[args[1]]))
+		case "BLPOP":
+			if list := f.lists[args[1]]; len(list) > 0 {
+				f.lists[args[1]] = list[1:]
+				fmt.Fprintf(conn, "*2\r\n$%d\r\n%s\r\n$%d\r\n%s\r\n", len(args[1]), args[1], len(list[0]), list[0])
+			} else {
+				io.WriteString(conn, "*-1\r\n")
+			}
+		default:
+			fmt.Fprintf(conn, "-ERR unknown command '%s'\r\n", args[0])
+		}
+		f.mu.Unlock()
+	}
+}
+
+func TestRedisQueue(t *testing.T) {
+	fake, addr := startFakeRedis(t)
+
+	q, err := Open(&config.Queue{URL: "redis://:secret@" + addr + "/2", Name: "jobs"})
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	defer q.Close()
+
+	if err := q.Push([]byte(`{"bead":"wt-a"}`)); err != nil {
+		t.Fatalf("Push() error: %v", err)
+	}
+	got, err := q.Pop(time.Second)
+	if err != nil || string(got) != `{"bead":"wt-a"}` {
+		t.Fatalf("Pop() = %q, %v; want the pushed item", got, err)
+	}
+	got, err = q.Pop(time.Second)
+	if err != nil || got != nil {
+		t.Fatalf("Pop() on empty queue = %q, %v; want nil", got, err)
+	}
+
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	if want := "AUTH SELECT RPUSH BLPOP BLPOP"; strings.Join(fake.cmds, " ") != want {
+		t.Errorf("commands = %v, want %s", fake.cmds, want)
+	}
+}
+
+// fakeNATS speaks enough of the NATS protocol for publishers and a queue
+// group of subscribers on the same server
+type fakeNATS struct {
+	mu   sync.Mutex
+	subs map[string][]*subscriber
+}
+
+type subscriber struct {
+	conn net.Conn
+	sid  string
+	max  int // messages left before auto-unsubscribe, 0 for no limit
+}
+
+func startFakeNATS(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	f := &fakeNATS{subs: make(map[string][]*subscriber)}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(conn)
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func (f *fakeNATS) serve(conn net.Conn) {
+	defer conn.Close()
+	io.WriteString(conn, `INFO {"server_id":"fake","max_payload":1048576}`+"\r\n")
+	r := bufio.NewReader(conn)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "CONNECT":
+		case "PING":
+			io.WriteString(conn, "PONG\r\n")
+		case "SUB":
+			// SUB <subject> <group> <sid>
+			f.mu.Lock()
+			f.subs[fields[1]] = append(f.subs[fields[1]], &subscriber{conn: conn, sid: fields[3]})
+			f.mu.Unlock()
+		case "UNSUB":
+			// UNSUB <sid> [max]
+			f.mu.Lock()
+			for _, subs := range f.subs {
+				for _, s := range subs {
+					if s.conn == conn && s.sid == fields[1] && len(fields) > 2 {
+						s.max, _ = strconv.Atoi(fields[2])
+					}
+				}
+			}
+			f.mu.Unlock()
+		case "PUB":
+			n, _ := strconv.Atoi(fields[2])
+			payload := make([]byte, n+2)
+			if _, err := io.ReadFull(r, payload); err != nil {
+				return
+			}
+			// One member of the queue group gets the message
+			f.mu.Lock()
+			if subs := f.subs[fields[1]]; len(subs) > 0 {
+				s := subs[0]
+				fmt.Fprintf(s.conn, "PING\r\nMSG %s %s %d\r\n%s\r\n", fields[1], s.sid, n, payload[:n])
+				if s.max--; s.max == 0 {
+					f.subs[fields[1]] = subs[1:]
+				}
+			}
+			f.mu.Unlock()
+		default:
+			io.WriteString(conn, "-ERR 'Unknown Protocol Operation'\r\n")
+		}
+	}
+}
+
+func TestNATSQueue(t *testing.T) {
+	addr := startFakeNATS(t)
+	settings := &config.Queue{URL: "nats://" + addr}
+
+	consumer, err := Open(settings)
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	defer consumer.Close()
+
+	// The first pop subscribes; nothing has been published yet
+	got, err := consumer.Pop(200 * time.Millisecond)
+	if err != nil || got != nil {
+		t.Fatalf("Pop() before publishing = %q, %v; want nil", got, err)
+	}
+
+	producer, err := Open(settings)
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	defer producer.Close()
+	if err := producer.Push([]byte(`{"title":"Fix CI"}`)); err != nil {
+		t.Fatalf("Push() error: %v", err)
+	}
+
+	got, err = consumer.Pop(2 * time.Second)
+	if err != nil || string(got) != `{"title":"Fix CI"}` {
+		t.Fatalf("Pop() = %q, %v; want the published item", got, err)
+	}
+
+	// While the consumer works its item, the next one goes to another worker
+	other, err := Open(settings)
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	defer other.Close()
+	if got, err := other.Pop(200 * time.Millisecond); err != nil || got != nil {
+		t.Fatalf("Pop() before publishing = %q, %v; want nil", got, err)
+	}
+	if err := producer.Push([]byte(`{"title":"Fix lint"}`)); err != nil {
+		t.Fatalf("Push() error: %v", err)
+	}
+	if got, err := other.Pop(2 * time.Second); err != nil || string(got) != `{"title":"Fix lint"}` {
+		t.Fatalf("other Pop() = %q, %v; want the second item", got, err)
+	}
+}
+
+func TestNATSPopKeepsPartialFrame(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+	go io.Copy(io.Discard, server)
+
+	q := &natsQueue{conn: client, r: bufio.NewReader(client), subject: "wt.queue", group: "wt", subscribed: true}
+	go func() {
+		io.WriteString(server, "MSG wt.queue 1 ")
+		time.Sleep(300 * time.Millisecond)
+		io.WriteString(server, "5\r\nhello\r\n")
+	}()
+
+	if got, err := q.Pop(100 * time.Millisecond); err != nil || got != nil {
+		t.Fatalf("Pop() mid-frame = %q, %v; want nil", got, err)
+	}
+	if got, err := q.Pop(2 * time.Second); err != nil || string(got) != "hello" {
+		t.Fatalf("Pop() = %q, %v; want the message whose header was cut off", got, err)
+	}
+}
+
+func TestOpenRejectsUnknownScheme(t *testing.T) {
+	if _, err := Open(&config.Queue{URL: "amqp://localhost"}); err == nil {
+		t.Error("Open(amqp://) succeeded, want an unsupported url error")
+	}
+	if _, err := Open(nil); err == nil {
+		t.Error("Open(nil) succeeded, want a not configured error")
+	}
+}
+
+func TestParseItem(t *testing.T) {
+	item, err := Parse([]byte(`{"id":"1234","source":"ci","title":" Fix login ","description":"Trace...","url":"https://ci/1234","priority":1}`))
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	if item.Title != "Fix login" || item.Ref() != "ci:1234" || *item.Priority != 1 {
+		t.Errorf("Parse() = %+v", item)
+	}
+	if want := "Queued from ci:1234: https://ci/1234\n\nTrace..."; item.BeadDescription() != want {
+		t.Errorf("BeadDescription() = %q, want %q", item.BeadDescription(), want)
+	}
+
+	for _, data := range []string{`not json`, `{"source":"ci"}`, `{"title":"x","priority":7}`} {
+		if _, err := Parse([]byte(data)); err == nil {
+			t.Errorf("Parse(%s) succeeded, want an error", data)
+		}
+	}
+	if item, err := Parse([]byte(`{"bead":"wt-a"}`)); err != nil || item.Ref() != "queue" {
+		t.Errorf("Parse(bead only) = %+v, %v; want ref queue", item, err)
+	}
+}
+
+func TestStoreRoundTrip(t *testing.T) {
+	cfg, err := config.LoadFromDir(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadFromDir() error: %v", err)
+	}
+
+	store, err := Load(cfg)
+	if err != nil {
+		t.Fatalf("Load() on missing file error: %v", err)
+	}
+	store.Put(&Link{Bead: "wt-a", ItemID: "1", Source: "ci", ReceivedAt: "2026-01-01T10:00:00Z"})
+	store.Put(&Link{Bead: "wt-b", ItemID: "1", Source: "alerts", ReceivedAt: "2026-01-02T10:00:00Z", Outcome: "success"})
+	if err := store.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	loaded, err := Load(cfg)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if l := loaded.FindItem("ci", "1"); l == nil || l.Bead != "wt-a" {
+		t.Errorf("FindItem(ci, 1) = %+v, want wt-a", l)
+	}
+	if l := loaded.FindItem("ci", ""); l != nil {
+		t.Errorf("FindItem without id = %+v, want nil", l)
+	}
+	if list := loaded.List(); len(list) != 2 || list[0].Bead != "wt-b" {
+		t.Errorf("List() = %+v, want wt-b first", list)
+	}
+}
@@ -0,0 +1,55 @@
+package worktree
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/badri/wt/internal/logging"
+)
+
+// SigningConfig is the git signing setup of a worktree. Empty fields keep
+// the value from the user's git config.
+type SigningConfig struct {
+	Format         string // gpg.format
+	Key            string // user.signingkey
+	AllowedSigners string // gpg.ssh.allowedSignersFile
+}
+
+// ConfigureSigning makes every commit and tag in a worktree signed. The
+// settings are worktree-scoped, so the main checkout and other worktrees
+// are unaffected.
+func ConfigureSigning(worktreePath string, sc SigningConfig) error {
+	// Worktree-scoped config needs the worktreeConfig extension on the repo
+	if err := gitConfig(worktreePath, false, "extensions.worktreeConfig", "true"); err != nil {
+		return err
+	}
+	settings := [][2]string{
+		{"commit.gpgsign", "true"},
+		{"tag.gpgsign", "true"},
+		{"gpg.format", sc.Format},
+		{"user.signingkey", sc.Key},
+		{"gpg.ssh.allowedSignersFile", sc.AllowedSigners},
+	}
+	for _, kv := range settings {
+		if kv[1] == "" {
+			continue
+		}
+		if err := gitConfig(worktreePath, true, kv[0], kv[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// gitConfig sets a config value in the repo, or in the worktree only
+func gitConfig(worktreePath string, worktreeScope bool, key, value string) error {
+	args := []string{"-C", worktreePath, "config"}
+	if worktreeScope {
+		args = append(args, "--worktree")
+	}
+	args = append(args, key, value)
+	if output, err := logging.Command("git", args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("setting %s: %s: %w", key, strings.TrimSpace(string(output)), err)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package worktree
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfigureSigning(t *testing.T) {
+	repo := t.TempDir()
+	runGit(t, repo, "init", "-q", "-b", "main")
+	commitFile(t, repo, "README", "hello\n")
+	wt := filepath.Join(t.TempDir(), "wt")
+	runGit(t, repo, "worktree", "add", "-q", "-b", "feature", wt)
+
+	if err := ConfigureSigning(wt, SigningConfig{Format: "ssh", Key: "/keys/id.pub"}); err != nil {
+		t.Fatal(err)
+	}
+
+	get := func(dir, key string) string {
+		out, _ := exec.Command("git", "-C", dir, "config", "--get", key).Output()
+		return strings.TrimSpace(string(out))
+	}
+	for key, want := range map[string]string{
+		"commit.gpgsign":             "true",
+		"tag.gpgsign":                "true",
+		"gpg.format":                 "ssh",
+		"user.signingkey":            "/keys/id.pub",
+		"gpg.ssh.allowedSignersFile": "", // unset fields keep the user's config
+	} {
+		if got := get(wt, key); got != want {
+			t.Errorf("worktree %s = %q, want %q", key, got, want)
+		}
+	}
+	// The main checkout is unaffected
+	if got := get(repo, "commit.gpgsign"); got != "" {
+		t.Errorf("main repo commit.gpgsign = %q, want unset", got)
+	}
+}
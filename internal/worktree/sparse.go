@@ -0,0 +1,103 @@
+package worktree
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/badri/wt/internal/logging"
+)
+
+// CreateSparse creates a worktree that checks out only the given paths. In
+// cone mode paths are directories (files at the repo root are always
+// included); otherwise they are gitignore-style patterns. The branch is
+// created from baseBranch unless it already exists. Nothing outside the
+// paths is ever written, which is what makes this fast in large repos.
+func CreateSparse(repoPath, worktreePath, branch, baseBranch string, paths []string, cone bool) error {
+	if err := os.MkdirAll(filepath.Dir(worktreePath), 0755); err != nil {
+		return fmt.Errorf("creating worktree directory: %w", err)
+	}
+
+	args := []string{"-C", repoPath, "worktree", "add", "--no-checkout"}
+	if BranchExists(repoPath, branch) {
+		args = append(args, worktreePath, branch)
+	} else {
+		args = append(args, "-b", branch, worktreePath)
+		if baseBranch != "" {
+			args = append(args, baseBranch)
+		}
+	}
+	if output, err := logging.Command("git", args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("git worktree add: %s: %w", string(output), err)
+	}
+
+	if err := SetSparse(worktreePath, paths, cone); err != nil {
+		Remove(worktreePath)
+		return err
+	}
+	// --no-checkout leaves the index empty; fill it and the working tree
+	// within the sparse patterns
+	if output, err := logging.Command("git", "-C", worktreePath, "read-tree", "-mu", "HEAD").CombinedOutput(); err != nil {
+		Remove(worktreePath)
+		return fmt.Errorf("checking out sparse worktree: %s: %w", string(output), err)
+	}
+	return nil
+}
+
+// SetSparse replaces a worktree's sparse-checkout patterns, adding or
+// removing files to match. The setting is per worktree; the main repo and
+// other worktrees keep their full checkout.
+func SetSparse(worktreePath string, paths []string, cone bool) error {
+	mode := "--cone"
+	if !cone {
+		mode = "--no-cone"
+	}
+	args := append([]string{"-C", worktreePath, "sparse-checkout", "set", mode}, paths...)
+	if output, err := logging.Command("git", args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("git sparse-checkout set: %s: %w", strings.TrimSpace(string(output)), err)
+	}
+	return nil
+}
+
+// AddSparse widens a sparse worktree with more paths
+func AddSparse(worktreePath string, paths []string) error {
+	args := append([]string{"-C", worktreePath, "sparse-checkout", "add"}, paths...)
+	if output, err := logging.Command("git", args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("git sparse-checkout add: %s: %w", strings.TrimSpace(string(output)), err)
+	}
+	return nil
+}
+
+// DisableSparse checks out the whole tree in a sparse worktree
+func DisableSparse(worktreePath string) error {
+	if output, err := logging.Command("git", "-C", worktreePath, "sparse-checkout", "disable").CombinedOutput(); err != nil {
+		return fmt.Errorf("git sparse-checkout disable: %s: %w", strings.TrimSpace(string(output)), err)
+	}
+	return nil
+}
+
+// IsSparse reports whether a worktree has a sparse checkout
+func IsSparse(worktreePath string) bool {
+	output, err := logging.Command("git", "-C", worktreePath, "config", "--bool", "core.sparseCheckout").Output()
+	return err == nil && strings.TrimSpace(string(output)) == "true"
+}
+
+// SparsePaths returns a sparse worktree's patterns: directories in cone
+// mode, patterns otherwise. It returns nil for a full checkout.
+func SparsePaths(worktreePath string) ([]string, error) {
+	if !IsSparse(worktreePath) {
+		return nil, nil
+	}
+	output, err := logging.Command("git", "-C", worktreePath, "sparse-checkout", "list").CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("git sparse-checkout list: %s: %w", strings.TrimSpace(string(output)), err)
+	}
+	var paths []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			paths = append(paths, line)
+		}
+	}
+	return paths, nil
+}
@@ -0,0 +1,62 @@
+package worktree
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCreateSparse(t *testing.T) {
+	repo := t.TempDir()
+	runGit(t, repo, "init", "-q", "-b", "main")
+	for _, dir := range []string{"api", "web", "docs"} {
+		if err := os.MkdirAll(filepath.Join(repo, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+		commitFile(t, repo, filepath.Join(dir, "main.go"), dir+"\n")
+	}
+	commitFile(t, repo, "README", "hello\n")
+
+	wt := filepath.Join(t.TempDir(), "wt")
+	if err := CreateSparse(repo, wt, "feature", "main", []string{"api"}, true); err != nil {
+		t.Fatalf("CreateSparse: %v", err)
+	}
+
+	exists := func(name string) bool {
+		_, err := os.Stat(filepath.Join(wt, name))
+		return err == nil
+	}
+	for name, want := range map[string]bool{"api/main.go": true, "README": true, "web/main.go": false, "docs/main.go": false} {
+		if got := exists(name); got != want {
+			t.Errorf("%s checked out = %v, want %v", name, got, want)
+		}
+	}
+	status, err := exec.Command("git", "-C", wt, "status", "--porcelain").Output()
+	if err != nil || strings.TrimSpace(string(status)) != "" {
+		t.Errorf("sparse worktree not clean: %q (%v)", status, err)
+	}
+
+	if paths, err := SparsePaths(wt); err != nil || !reflect.DeepEqual(paths, []string{"api"}) {
+		t.Errorf("SparsePaths() = %v, %v; want [api]", paths, err)
+	}
+	if paths, _ := SparsePaths(repo); paths != nil {
+		t.Errorf("main repo has sparse paths %v, want a full checkout", paths)
+	}
+
+	if err := AddSparse(wt, []string{"web"}); err != nil {
+		t.Fatalf("AddSparse: %v", err)
+	}
+	if !exists("web/main.go") || exists("docs/main.go") {
+		t.Error("AddSparse did not check out just web/")
+	}
+
+	if err := DisableSparse(wt); err != nil {
+		t.Fatalf("DisableSparse: %v", err)
+	}
+	if !exists("docs/main.go") || IsSparse(wt) {
+		t.Error("DisableSparse did not check out the whole tree")
+	}
+}
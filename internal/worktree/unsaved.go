@@ -0,0 +1,130 @@
+package worktree
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/badri/wt/internal/logging"
+)
+
+// Unsaved is the work in a worktree that exists nowhere else: changes not
+// yet committed, and commits not yet pushed to a remote
+type Unsaved struct {
+	Files   []string // git status --porcelain lines
+	Commits []string // one-line summaries of unpushed commits
+
+	pushed []string // rev-list arguments excluding pushed commits
+}
+
+// Empty reports whether removing the worktree would lose nothing
+func (u *Unsaved) Empty() bool {
+	return len(u.Files) == 0 && len(u.Commits) == 0
+}
+
+// FindUnsaved lists the uncommitted changes and unpushed commits of a
+// worktree. Commits count as pushed once the upstream branch, or any remote
+// branch when there is no upstream, contains them. A repo without remotes
+// has nothing to push to, so only its uncommitted changes are reported.
+func FindUnsaved(worktreePath string) (*Unsaved, error) {
+	u := &Unsaved{}
+	status, err := gitLines(worktreePath, "status", "--porcelain")
+	if err != nil {
+		return nil, fmt.Errorf("git status: %w", err)
+	}
+	u.Files = status
+
+	if _, err := gitLines(worktreePath, "rev-parse", "--abbrev-ref", "@{u}"); err == nil {
+		u.pushed = []string{"^@{u}"}
+	} else if remotes, _ := gitLines(worktreePath, "remote"); len(remotes) > 0 {
+		u.pushed = []string{"--not", "--remotes"}
+	} else {
+		return u, nil
+	}
+	args := append([]string{"log", "--format=%h %s", "HEAD"}, u.pushed...)
+	if u.Commits, err = gitLines(worktreePath, args...); err != nil {
+		return nil, fmt.Errorf("git log: %w", err)
+	}
+	return u, nil
+}
+
+// Summary describes the unsaved work, listing at most max entries of each kind
+func (u *Unsaved) Summary(max int) string {
+	var sb strings.Builder
+	write := func(what string, items []string) {
+		if len(items) == 0 {
+			return
+		}
+		fmt.Fprintf(&sb, "  %d %s:\n", len(items), what)
+		for i, item := range items {
+			if i == max {
+				fmt.Fprintf(&sb, "    ... and %d more\n", len(items)-max)
+				break
+			}
+			fmt.Fprintf(&sb, "    %s\n", item)
+		}
+	}
+	write("uncommitted file(s)", u.Files)
+	write("unpushed commit(s)", u.Commits)
+	return sb.String()
+}
+
+// Archive saves the unsaved work of a worktree to dest, so it can be
+// recovered after the worktree is removed: uncommitted changes, including
+// untracked files, as changes.patch (apply with 'git apply'), and unpushed
+// commits as format-patch files (apply with 'git am').
+func Archive(worktreePath, dest string, u *Unsaved) error {
+	if err := os.MkdirAll(dest, 0755); err != nil {
+		return fmt.Errorf("creating archive directory: %w", err)
+	}
+
+	if len(u.Files) > 0 {
+		// Stage into a throwaway index so untracked files show up in the
+		// diff without touching the worktree's own index
+		index := filepath.Join(dest, ".index")
+		defer os.Remove(index)
+		env := append(os.Environ(), "GIT_INDEX_FILE="+index)
+		steps := [][]string{{"read-tree", "HEAD"}, {"add", "-A"}}
+		for _, args := range steps {
+			cmd := logging.Command("git", append([]string{"-C", worktreePath}, args...)...)
+			cmd.Env = env
+			if out, err := cmd.CombinedOutput(); err != nil {
+				return fmt.Errorf("git %s: %s: %w", args[0], strings.TrimSpace(string(out)), err)
+			}
+		}
+		cmd := logging.Command("git", "-C", worktreePath, "diff", "--cached", "--binary", "HEAD")
+		cmd.Env = env
+		patch, err := cmd.Output()
+		if err != nil {
+			return fmt.Errorf("git diff: %w", err)
+		}
+		if err := os.WriteFile(filepath.Join(dest, "changes.patch"), patch, 0644); err != nil {
+			return fmt.Errorf("writing changes.patch: %w", err)
+		}
+	}
+
+	if len(u.Commits) > 0 {
+		args := []string{"-C", worktreePath, "format-patch", "--quiet", "-o", filepath.Join(dest, "commits"), "HEAD"}
+		args = append(args, u.pushed...)
+		if out, err := logging.Command("git", args...).CombinedOutput(); err != nil {
+			return fmt.Errorf("git format-patch: %s: %w", strings.TrimSpace(string(out)), err)
+		}
+	}
+	return nil
+}
+
+// gitLines runs a git command in dir and returns its non-empty output lines
+func gitLines(dir string, args ...string) ([]string, error) {
+	out, err := logging.Command("git", append([]string{"-C", dir}, args...)...).Output()
+	if err != nil {
+		return nil, err
+	}
+	var lines []string
+	for _, line := range strings.Split(strings.TrimRight(string(out), "\n"), "\n") {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, nil
+}
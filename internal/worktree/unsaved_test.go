@@ -0,0 +1,139 @@
+package worktree
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@t", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@t")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, output)
+	}
+}
+
+func commitFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, dir, "add", name)
+	runGit(t, dir, "commit", "-q", "-m", "add "+name)
+}
+
+// cloneWithRemote returns a clone of a bare repo holding one pushed commit
+func cloneWithRemote(t *testing.T) string {
+	t.Helper()
+	remote := filepath.Join(t.TempDir(), "remote.git")
+	runGit(t, t.TempDir(), "init", "-q", "--bare", remote)
+	clone := filepath.Join(t.TempDir(), "clone")
+	runGit(t, t.TempDir(), "clone", "-q", remote, clone)
+	commitFile(t, clone, "README", "hello\n")
+	runGit(t, clone, "push", "-q", "origin", "HEAD")
+	return clone
+}
+
+func TestFindUnsaved(t *testing.T) {
+	t.Run("clean and pushed", func(t *testing.T) {
+		dir := cloneWithRemote(t)
+		u, err := FindUnsaved(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !u.Empty() {
+			t.Errorf("expected nothing unsaved, got %+v", u)
+		}
+	})
+
+	t.Run("uncommitted and unpushed", func(t *testing.T) {
+		dir := cloneWithRemote(t)
+		runGit(t, dir, "checkout", "-q", "-b", "feature")
+		commitFile(t, dir, "a.txt", "a\n")
+		if err := os.WriteFile(filepath.Join(dir, "README"), []byte("changed\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "new.txt"), []byte("new\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		u, err := FindUnsaved(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(u.Files) != 2 {
+			t.Errorf("Files = %v, want 2 entries", u.Files)
+		}
+		if len(u.Commits) != 1 || !strings.Contains(u.Commits[0], "add a.txt") {
+			t.Errorf("Commits = %v, want the a.txt commit", u.Commits)
+		}
+		summary := u.Summary(10)
+		if !strings.Contains(summary, "2 uncommitted file(s)") || !strings.Contains(summary, "1 unpushed commit(s)") {
+			t.Errorf("unexpected summary:\n%s", summary)
+		}
+	})
+
+	t.Run("no remote reports only uncommitted changes", func(t *testing.T) {
+		dir := t.TempDir()
+		runGit(t, dir, "init", "-q")
+		commitFile(t, dir, "README", "hello\n")
+		u, err := FindUnsaved(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !u.Empty() {
+			t.Errorf("expected nothing unsaved without a remote, got %+v", u)
+		}
+	})
+}
+
+func TestUnsavedSummaryTruncates(t *testing.T) {
+	u := &Unsaved{Files: []string{"?? a", "?? b", "?? c"}}
+	summary := u.Summary(2)
+	if strings.Contains(summary, "?? c") || !strings.Contains(summary, "... and 1 more") {
+		t.Errorf("unexpected summary:\n%s", summary)
+	}
+}
+
+func TestArchive(t *testing.T) {
+	dir := cloneWithRemote(t)
+	commitFile(t, dir, "a.txt", "a\n")
+	if err := os.WriteFile(filepath.Join(dir, "new.txt"), []byte("new\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	u, err := FindUnsaved(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dest := filepath.Join(t.TempDir(), "archive")
+	if err := Archive(dir, dest, u); err != nil {
+		t.Fatalf("Archive: %v", err)
+	}
+
+	patch, err := os.ReadFile(filepath.Join(dest, "changes.patch"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(patch), "new.txt") {
+		t.Errorf("changes.patch misses the untracked file:\n%s", patch)
+	}
+	commits, _ := filepath.Glob(filepath.Join(dest, "commits", "*.patch"))
+	if len(commits) != 1 {
+		t.Errorf("expected 1 commit patch, got %v", commits)
+	}
+	if _, err := os.Stat(filepath.Join(dest, ".index")); !os.IsNotExist(err) {
+		t.Error("temporary index was left behind")
+	}
+
+	// The worktree's own index is untouched
+	out, _ := exec.Command("git", "-C", dir, "status", "--porcelain").Output()
+	if !strings.Contains(string(out), "?? new.txt") {
+		t.Errorf("worktree status changed: %q", out)
+	}
+}
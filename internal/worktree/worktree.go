@@ -6,6 +6,8 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+
+	"github.com/badri/wt/internal/logging"
 )
 
 func Create(repoPath, worktreePath, branch string) error {
@@ -16,15 +18,15 @@ func Create(repoPath, worktreePath, branch string) error {
 	}
 
 	// Check if branch exists
-	branchExists := checkBranchExists(repoPath, branch)
+	branchExists := BranchExists(repoPath, branch)
 
 	var cmd *exec.Cmd
 	if branchExists {
 		// Use existing branch
-		cmd = exec.Command("git", "-C", repoPath, "worktree", "add", worktreePath, branch)
+		cmd = logging.Command("git", "-C", repoPath, "worktree", "add", worktreePath, branch)
 	} else {
 		// Create new branch
-		cmd = exec.Command("git", "-C", repoPath, "worktree", "add", "-b", branch, worktreePath)
+		cmd = logging.Command("git", "-C", repoPath, "worktree", "add", "-b", branch, worktreePath)
 	}
 
 	output, err := cmd.CombinedOutput()
@@ -38,7 +40,7 @@ func Create(repoPath, worktreePath, branch string) error {
 func Remove(worktreePath string) error {
 	// First, find the main repo that owns this worktree
 	// Try to remove via git worktree remove
-	cmd := exec.Command("git", "-C", worktreePath, "worktree", "remove", "--force", worktreePath)
+	cmd := logging.Command("git", "-C", worktreePath, "worktree", "remove", "--force", worktreePath)
 	if err := cmd.Run(); err != nil {
 		// Fallback: just remove the directory
 		if err := os.RemoveAll(worktreePath); err != nil {
@@ -58,7 +60,7 @@ func Exists(worktreePath string) bool {
 }
 
 func FindGitRoot() (string, error) {
-	cmd := exec.Command("git", "rev-parse", "--show-toplevel")
+	cmd := logging.Command("git", "rev-parse", "--show-toplevel")
 	output, err := cmd.Output()
 	if err != nil {
 		return "", fmt.Errorf("not in a git repository")
@@ -66,15 +68,16 @@ func FindGitRoot() (string, error) {
 	return strings.TrimSpace(string(output)), nil
 }
 
-func checkBranchExists(repoPath, branch string) bool {
+// BranchExists checks whether a branch exists locally or on origin
+func BranchExists(repoPath, branch string) bool {
 	// Check local branches
-	cmd := exec.Command("git", "-C", repoPath, "show-ref", "--verify", "--quiet", "refs/heads/"+branch)
+	cmd := logging.Command("git", "-C", repoPath, "show-ref", "--verify", "--quiet", "refs/heads/"+branch)
 	if cmd.Run() == nil {
 		return true
 	}
 
 	// Check remote branches
-	cmd = exec.Command("git", "-C", repoPath, "show-ref", "--verify", "--quiet", "refs/remotes/origin/"+branch)
+	cmd = logging.Command("git", "-C", repoPath, "show-ref", "--verify", "--quiet", "refs/remotes/origin/"+branch)
 	return cmd.Run() == nil
 }
 
@@ -108,7 +111,7 @@ func SymlinkClaudeDir(repoPath, worktreePath string) error {
 func IsBranchMerged(repoPath, branch, targetBranch string) bool {
 	// Use merge-base --is-ancestor to check if branch is an ancestor of target
 	// This returns exit code 0 if branch is merged into target
-	cmd := exec.Command("git", "-C", repoPath, "merge-base", "--is-ancestor", branch, targetBranch)
+	cmd := logging.Command("git", "-C", repoPath, "merge-base", "--is-ancestor", branch, targetBranch)
 	return cmd.Run() == nil
 }
 
@@ -121,9 +124,9 @@ func CreateFromBranch(repoPath, worktreePath, newBranch, baseBranch string) erro
 	}
 
 	// Check if branch already exists
-	if checkBranchExists(repoPath, newBranch) {
+	if BranchExists(repoPath, newBranch) {
 		// Use existing branch
-		cmd := exec.Command("git", "-C", repoPath, "worktree", "add", worktreePath, newBranch)
+		cmd := logging.Command("git", "-C", repoPath, "worktree", "add", worktreePath, newBranch)
 		output, err := cmd.CombinedOutput()
 		if err != nil {
 			return fmt.Errorf("git worktree add: %s: %w", string(output), err)
@@ -132,7 +135,7 @@ func CreateFromBranch(repoPath, worktreePath, newBranch, baseBranch string) erro
 	}
 
 	// Create new branch from base
-	cmd := exec.Command("git", "-C", repoPath, "worktree", "add", "-b", newBranch, worktreePath, baseBranch)
+	cmd := logging.Command("git", "-C", repoPath, "worktree", "add", "-b", newBranch, worktreePath, baseBranch)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return fmt.Errorf("git worktree add: %s: %w", string(output), err)